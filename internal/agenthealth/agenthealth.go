@@ -1,9 +1,10 @@
-// Package feed provides a TUI for the Gas Town activity feed.
-// This file implements stuck detection for agents using structured beads data.
+// Package agenthealth classifies agent health (working, stalled, GUPP violation,
+// zombie) from structured beads data. It is shared by the activity feed TUI and
+// the daemon's utilization sampler.
 // Previous approach used tmux pane scraping with regex patterns, which produced
 // false positives (HTML `>`, compiler output matching `error:`). This version
 // uses reliable structured signals from beads (hook state, timestamps).
-package feed
+package agenthealth
 
 import (
 	"strconv"
@@ -282,33 +283,19 @@ func isRalphMode(issue *beads.Issue) bool {
 
 // deriveSessionName maps bead ID components to a tmux session name.
 // Uses the naming conventions from internal/session/.
-// Note: session.*SessionName functions take a rigPrefix (e.g. "gt"),
-// not a rig name (e.g. "gastown"). Use session.PrefixFor(rig) to convert.
 func deriveSessionName(rig, role, name string) string {
-	switch role {
-	case constants.RoleMayor:
-		return session.MayorSessionName()
-	case constants.RoleDeacon:
-		return session.DeaconSessionName()
-	case constants.RoleWitness:
-		return session.WitnessSessionName(session.PrefixFor(rig))
-	case constants.RoleRefinery:
-		return session.RefinerySessionName(session.PrefixFor(rig))
-	case constants.RoleCrew:
-		return session.CrewSessionName(session.PrefixFor(rig), name)
-	case constants.RolePolecat:
-		return session.PolecatSessionName(session.PrefixFor(rig), name)
-	default:
-		// Fallback: construct from components
-		rigPrefix := session.PrefixFor(rig)
-		if rig == "" {
-			return session.HQPrefix + role
-		}
-		if name == "" {
-			return rigPrefix + "-" + role
-		}
-		return rigPrefix + "-" + role + "-" + name
+	if sess, err := session.SessionName(role, rig, name); err == nil {
+		return sess
+	}
+	// Fallback for roles session doesn't know: construct from components
+	if rig == "" {
+		return session.HQPrefix + role
+	}
+	rigPrefix := session.PrefixFor(rig)
+	if name == "" {
+		return rigPrefix + "-" + role
 	}
+	return rigPrefix + "-" + role + "-" + name
 }
 
 // sortProblemAgents sorts agents by state priority (problems first)
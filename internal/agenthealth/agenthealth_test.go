@@ -1,4 +1,4 @@
-package feed
+package agenthealth
 
 import (
 	"fmt"
@@ -734,57 +734,3 @@ func TestIsRalphMode(t *testing.T) {
 		})
 	}
 }
-
-// TestNudgeTarget tests the nudge target format for all agent types
-func TestNudgeTarget(t *testing.T) {
-	tests := []struct {
-		name     string
-		agent    *ProblemAgent
-		expected string
-	}{
-		{
-			name:     "mayor",
-			agent:    &ProblemAgent{Role: "mayor", Name: "mayor", Rig: ""},
-			expected: "mayor",
-		},
-		{
-			name:     "deacon",
-			agent:    &ProblemAgent{Role: "deacon", Name: "deacon", Rig: ""},
-			expected: "deacon",
-		},
-		{
-			name:     "witness",
-			agent:    &ProblemAgent{Role: "witness", Name: "witness", Rig: "gastown"},
-			expected: "gastown/witness",
-		},
-		{
-			name:     "refinery",
-			agent:    &ProblemAgent{Role: "refinery", Name: "refinery", Rig: "gastown"},
-			expected: "gastown/refinery",
-		},
-		{
-			name:     "crew",
-			agent:    &ProblemAgent{Role: "crew", Name: "joe", Rig: "gastown"},
-			expected: "gastown/crew/joe",
-		},
-		{
-			name:     "polecat",
-			agent:    &ProblemAgent{Role: "polecat", Name: "Toast", Rig: "gastown"},
-			expected: "gastown/Toast",
-		},
-		{
-			name:     "unknown role falls back to session ID",
-			agent:    &ProblemAgent{Role: "custom", Name: "x", Rig: "r", SessionID: "gt-r-custom-x"},
-			expected: "gt-r-custom-x",
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			got := nudgeTarget(tt.agent)
-			if got != tt.expected {
-				t.Errorf("nudgeTarget() = %q, want %q", got, tt.expected)
-			}
-		})
-	}
-}
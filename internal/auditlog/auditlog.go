@@ -0,0 +1,198 @@
+// Package auditlog records state-changing gt commands in an append-only log.
+//
+// Every mutation (nudges, mail sends and archives, agent restarts, branch
+// deletes, and so on) is appended to <townRoot>/logs/audit.jsonl with who ran
+// it, when, with what arguments, and whether it succeeded. Records are never
+// rewritten; past maxLogSize the log rolls over to audit.jsonl.1 (and so on,
+// keeping maxBackups files). gt audit reads them all back with time-range and
+// actor filters.
+package auditlog
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/steveyegge/gastown/internal/lock"
+)
+
+// Result values for Record.Result.
+const (
+	ResultOK    = "ok"
+	ResultError = "error"
+)
+
+// maxArgLen caps each recorded argument, in bytes, so message bodies don't
+// bloat the log.
+const maxArgLen = 200
+
+// maxLogSize is the size at which Append rolls the log over, and maxBackups
+// how many rolled-over files are kept. A var so tests can shrink it.
+var maxLogSize int64 = 10 * 1024 * 1024
+
+const maxBackups = 3
+
+// Record is one state-changing command.
+type Record struct {
+	Timestamp time.Time `json:"ts"`
+	Actor     string    `json:"actor"`
+	Command   string    `json:"command"`           // Command path without the binary name, e.g. "mail send"
+	Args      []string  `json:"args,omitempty"`    // Arguments and flags as given
+	Result    string    `json:"result"`            // ResultOK or ResultError
+	Error     string    `json:"error,omitempty"`   // Error message when Result is ResultError
+	Duration  int64     `json:"duration_ms"`       // Wall time of the command
+	Session   string    `json:"session,omitempty"` // GT_SESSION of the caller, if any
+}
+
+// Query selects records. Zero fields match everything.
+type Query struct {
+	Since   time.Time
+	Until   time.Time
+	Actor   string // Case-insensitive substring of the actor
+	Command string // Command path prefix, e.g. "mail"
+}
+
+// Match reports whether r satisfies the query.
+func (q Query) Match(r Record) bool {
+	if !q.Since.IsZero() && r.Timestamp.Before(q.Since) {
+		return false
+	}
+	if !q.Until.IsZero() && r.Timestamp.After(q.Until) {
+		return false
+	}
+	if q.Actor != "" && !strings.Contains(strings.ToLower(r.Actor), strings.ToLower(q.Actor)) {
+		return false
+	}
+	if q.Command != "" && r.Command != q.Command && !strings.HasPrefix(r.Command, q.Command+" ") {
+		return false
+	}
+	return true
+}
+
+// Path returns <townRoot>/logs/audit.jsonl.
+func Path(townRoot string) string {
+	return filepath.Join(townRoot, "logs", "audit.jsonl")
+}
+
+// Append adds a record to the town's audit log. A zero timestamp is set to
+// now; timestamps are stored in UTC.
+func Append(townRoot string, r Record) error {
+	if r.Timestamp.IsZero() {
+		r.Timestamp = time.Now()
+	}
+	r.Timestamp = r.Timestamp.UTC()
+	r.Args = append([]string(nil), r.Args...)
+	for i, a := range r.Args {
+		r.Args[i] = truncateArg(a)
+	}
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+
+	path := Path(townRoot)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	// Concurrent gt processes append to the same file.
+	unlock, err := lock.FlockAcquire(path + ".flock")
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if info, err := os.Stat(path); err == nil && info.Size() >= maxLogSize {
+		if err := rollOver(path); err != nil {
+			return err
+		}
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.Write(append(data, '\n'))
+	return err
+}
+
+// truncateArg cuts a to maxArgLen bytes, backing up to a character boundary
+// so multi-byte text isn't split.
+func truncateArg(a string) string {
+	if len(a) <= maxArgLen {
+		return a
+	}
+	cut := maxArgLen
+	for cut > 0 && !utf8.RuneStart(a[cut]) {
+		cut--
+	}
+	return a[:cut] + "…"
+}
+
+// backupPath returns the n-th rolled-over log: path.1 is the newest.
+func backupPath(path string, n int) string {
+	return fmt.Sprintf("%s.%d", path, n)
+}
+
+// rollOver shifts path to path.1, path.1 to path.2, and so on, dropping the
+// oldest. The caller holds the log's lock.
+func rollOver(path string) error {
+	_ = os.Remove(backupPath(path, maxBackups))
+	for n := maxBackups - 1; n >= 1; n-- {
+		if err := os.Rename(backupPath(path, n), backupPath(path, n+1)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return os.Rename(path, backupPath(path, 1))
+}
+
+// Read returns the records matching q, oldest first, across the log and its
+// rolled-over files. A missing log yields no records; malformed lines are
+// skipped.
+func Read(townRoot string, q Query) ([]Record, error) {
+	path := Path(townRoot)
+	var records []Record
+	for n := maxBackups; n >= 0; n-- {
+		file := path
+		if n > 0 {
+			file = backupPath(path, n)
+		}
+		var err error
+		if records, err = readFile(file, q, records); err != nil {
+			return records, err
+		}
+	}
+	return records, nil
+}
+
+// readFile appends the records in file matching q to records.
+func readFile(file string, q Query, records []Record) ([]Record, error) {
+	f, err := os.Open(file) //nolint:gosec // G304: path is under townRoot
+	if err != nil {
+		if os.IsNotExist(err) {
+			return records, nil
+		}
+		return records, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var r Record
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			continue
+		}
+		if q.Match(r) {
+			records = append(records, r)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return records, fmt.Errorf("reading %s: %w", file, err)
+	}
+	return records, nil
+}
@@ -0,0 +1,126 @@
+package auditlog
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestAppendAndRead(t *testing.T) {
+	townRoot := t.TempDir()
+	base := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	recs := []Record{
+		{Timestamp: base, Actor: "mayor", Command: "nudge", Args: []string{"nudge", "gastown/nux", "hi"}, Result: ResultOK},
+		{Timestamp: base.Add(time.Hour), Actor: "gastown/crew/max", Command: "mail send", Result: ResultOK},
+		{Timestamp: base.Add(2 * time.Hour), Actor: "gastown/crew/max", Command: "prune-branches", Result: ResultError, Error: "boom"},
+	}
+	for _, r := range recs {
+		if err := Append(townRoot, r); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+	}
+
+	all, err := Read(townRoot, Query{})
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if len(all) != 3 || all[0].Command != "nudge" || all[2].Error != "boom" {
+		t.Fatalf("Read returned %+v", all)
+	}
+
+	tests := []struct {
+		name string
+		q    Query
+		want int
+	}{
+		{"actor", Query{Actor: "MAX"}, 2},
+		{"since", Query{Since: base.Add(30 * time.Minute)}, 2},
+		{"until", Query{Until: base.Add(time.Hour)}, 2},
+		{"range", Query{Since: base.Add(30 * time.Minute), Until: base.Add(90 * time.Minute)}, 1},
+		{"command prefix", Query{Command: "mail"}, 1},
+		{"command not a word prefix", Query{Command: "mai"}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Read(townRoot, tt.q)
+			if err != nil {
+				t.Fatalf("Read: %v", err)
+			}
+			if len(got) != tt.want {
+				t.Errorf("got %d records, want %d", len(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestAppendTruncatesLongArgs(t *testing.T) {
+	townRoot := t.TempDir()
+	args := []string{"mail", "send", strings.Repeat("x", 5000)}
+	if err := Append(townRoot, Record{Command: "mail send", Args: args, Result: ResultOK}); err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+	if len(args[2]) != 5000 {
+		t.Error("Append modified the caller's args")
+	}
+	got, err := Read(townRoot, Query{})
+	if err != nil || len(got) != 1 {
+		t.Fatalf("Read = %v, %v", got, err)
+	}
+	if n := len(got[0].Args[2]); n > maxArgLen+len("…") {
+		t.Errorf("recorded arg length %d, want <= %d", n, maxArgLen)
+	}
+	if got[0].Timestamp.IsZero() || got[0].Timestamp.Location() != time.UTC {
+		t.Errorf("timestamp = %v, want set and in UTC", got[0].Timestamp)
+	}
+}
+
+func TestTruncateArgKeepsUTF8(t *testing.T) {
+	// "é" is two bytes, so byte maxArgLen falls inside a character.
+	got := truncateArg("x" + strings.Repeat("é", maxArgLen))
+	if !utf8.ValidString(got) || !strings.HasSuffix(got, "é…") {
+		t.Errorf("truncateArg split a character: %q", got[len(got)-8:])
+	}
+	if len(got) > maxArgLen+len("…") {
+		t.Errorf("truncated to %d bytes, want <= %d", len(got), maxArgLen)
+	}
+}
+
+func TestAppendRollsOver(t *testing.T) {
+	townRoot := t.TempDir()
+	defer func(n int64) { maxLogSize = n }(maxLogSize)
+	maxLogSize = 1 // Every append after the first rolls over
+
+	base := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < maxBackups+3; i++ {
+		if err := Append(townRoot, Record{Timestamp: base.Add(time.Duration(i) * time.Minute), Command: "nudge", Result: ResultOK}); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+	}
+	if _, err := os.Stat(backupPath(Path(townRoot), maxBackups+1)); !os.IsNotExist(err) {
+		t.Errorf("kept more than %d backups", maxBackups)
+	}
+	got, err := Read(townRoot, Query{})
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if len(got) != maxBackups+1 {
+		t.Fatalf("Read returned %d records, want the newest %d", len(got), maxBackups+1)
+	}
+	for i := 1; i < len(got); i++ {
+		if !got[i].Timestamp.After(got[i-1].Timestamp) {
+			t.Errorf("records out of order: %v", got)
+		}
+	}
+	if want := base.Add(time.Duration(maxBackups+2) * time.Minute); !got[len(got)-1].Timestamp.Equal(want) {
+		t.Errorf("newest record at %v, want %v", got[len(got)-1].Timestamp, want)
+	}
+}
+
+func TestReadMissingLog(t *testing.T) {
+	got, err := Read(t.TempDir(), Query{})
+	if err != nil || got != nil {
+		t.Errorf("Read on empty town = %v, %v; want nil, nil", got, err)
+	}
+}
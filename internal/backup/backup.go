@@ -0,0 +1,505 @@
+// Package backup writes and restores workspace backups: a gzipped tarball of
+// the town state that cannot be rebuilt from git remotes — town and rig
+// configuration, beads databases, and mailboxes.
+//
+// Git checkouts (the shared bare repo, the mayor and refinery clones, crew
+// and polecat worktrees) and runtime state (.runtime/, logs, pid and lock
+// files) are left out. gt restore re-clones the checkouts from the URLs
+// recorded in each rig's config.json.
+package backup
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/rig"
+)
+
+// ManifestName is the archive entry describing the backup. It is always the
+// first entry so restore can read it without unpacking the whole archive.
+const ManifestName = "gt-backup.json"
+
+// FormatVersion is the manifest version written by this build.
+const FormatVersion = 1
+
+// Manifest describes a backup.
+type Manifest struct {
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	Town      string    `json:"town,omitempty"`
+	TownRoot  string    `json:"town_root"` // Where the town lived; restore's default destination
+	Host      string    `json:"host,omitempty"`
+	DoltLive  bool      `json:"dolt_live,omitempty"` // Dolt server was running while databases were copied
+	Rigs      []RigInfo `json:"rigs"`
+	Files     int       `json:"files"`
+}
+
+// RigInfo records what restore needs to rebuild a rig's checkouts.
+type RigInfo struct {
+	Name          string   `json:"name"`
+	GitURL        string   `json:"git_url"`
+	DefaultBranch string   `json:"default_branch,omitempty"`
+	Prefix        string   `json:"prefix,omitempty"`
+	Crew          []string `json:"crew,omitempty"` // Crew workspaces to re-create
+}
+
+// townPaths are the town-level entries a backup includes.
+var townPaths = []string{
+	"mayor",      // town.json, rigs.json, daemon.json, accounts
+	"settings",   // town settings, escalation config, rig templates
+	".beads",     // town beads config and routes
+	".dolt-data", // all beads databases
+	"deacon",
+	"plugins",
+	"CLAUDE.md",
+	"AGENTS.md",
+	".gitignore",
+	"logs/audit.jsonl",
+}
+
+// rigPaths are the per-rig entries a backup includes.
+var rigPaths = []string{
+	"config.json",
+	"settings",
+	".beads",
+	"plugins",
+	"mayor",    // minus the mayor/rig clone
+	"refinery", // minus the refinery/rig worktree
+	"witness",
+	".runtime/overlay",     // files copied into new worktrees (.env etc.)
+	".runtime/setup-hooks", // scripts run in new worktrees
+}
+
+// skippedDirs are directories left out wherever they appear under an
+// included entry, relative to the town root for town entries and to the rig
+// for rig entries.
+var skippedDirs = map[string]bool{
+	"mayor/rig":    true,
+	"refinery/rig": true,
+	"deacon/dogs":  true, // dog worktrees
+}
+
+// skipDirName reports whether a directory is runtime state or a checkout.
+func skipDirName(name string) bool {
+	return name == ".git" || name == constants.DirRuntime || name == "logs"
+}
+
+// skipFileName reports whether a file is live process state that must not
+// be restored: pid files, sockets, and Dolt/beads locks.
+func skipFileName(name string) bool {
+	switch name {
+	case "LOCK", "sql-server.info", "heartbeat.json":
+		return true
+	}
+	for _, ext := range []string{".pid", ".sock", ".lock"} {
+		if strings.HasSuffix(name, ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// Collect returns the files a backup of townRoot includes, as slash-separated
+// paths relative to townRoot, sorted.
+func Collect(townRoot string) ([]string, error) {
+	var files []string
+	for _, p := range townPaths {
+		found, err := collectEntry(townRoot, "", p)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, found...)
+	}
+
+	rigsConfig, err := config.LoadRigsConfig(constants.MayorRigsPath(townRoot))
+	if err != nil && !errors.Is(err, config.ErrNotFound) {
+		return nil, fmt.Errorf("loading rigs registry: %w", err)
+	}
+	if rigsConfig != nil {
+		for name := range rigsConfig.Rigs {
+			for _, p := range rigPaths {
+				found, err := collectEntry(townRoot, name, p)
+				if err != nil {
+					return nil, err
+				}
+				files = append(files, found...)
+			}
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// collectEntry walks base/entry under townRoot, applying the skip rules.
+// base is "" for town entries and the rig name for rig entries.
+func collectEntry(townRoot, base, entry string) ([]string, error) {
+	root := filepath.Join(townRoot, base, filepath.FromSlash(entry))
+	if _, err := os.Lstat(root); os.IsNotExist(err) {
+		return nil, nil
+	}
+	var files []string
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(filepath.Join(townRoot, base), p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if d.IsDir() {
+			if p != root && (skipDirName(d.Name()) || skippedDirs[rel]) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if skipFileName(d.Name()) || !(d.Type().IsRegular() || d.Type()&fs.ModeSymlink != 0) {
+			return nil
+		}
+		files = append(files, path.Join(base, rel))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("collecting %s: %w", path.Join(base, entry), err)
+	}
+	return files, nil
+}
+
+// NewManifest describes the town at townRoot as of now.
+func NewManifest(townRoot string) (*Manifest, error) {
+	m := &Manifest{
+		Version:   FormatVersion,
+		CreatedAt: time.Now().UTC(),
+		TownRoot:  townRoot,
+		Rigs:      []RigInfo{},
+	}
+	m.Host, _ = os.Hostname()
+	if town, err := config.LoadTownConfig(constants.MayorTownPath(townRoot)); err == nil {
+		m.Town = town.Name
+	}
+	rigsConfig, err := config.LoadRigsConfig(constants.MayorRigsPath(townRoot))
+	if err != nil && !errors.Is(err, config.ErrNotFound) {
+		return nil, fmt.Errorf("loading rigs registry: %w", err)
+	}
+	if rigsConfig == nil {
+		return m, nil
+	}
+	for name, entry := range rigsConfig.Rigs {
+		info := RigInfo{Name: name, GitURL: entry.GitURL}
+		if entry.BeadsConfig != nil {
+			info.Prefix = entry.BeadsConfig.Prefix
+		}
+		rigPath := filepath.Join(townRoot, name)
+		if cfg, err := rig.LoadRigConfig(rigPath); err == nil {
+			info.DefaultBranch = cfg.DefaultBranch
+			if cfg.GitURL != "" {
+				info.GitURL = cfg.GitURL
+			}
+		}
+		if entries, err := os.ReadDir(filepath.Join(rigPath, "crew")); err == nil {
+			for _, e := range entries {
+				if e.IsDir() && !strings.HasPrefix(e.Name(), ".") {
+					info.Crew = append(info.Crew, e.Name())
+				}
+			}
+		}
+		m.Rigs = append(m.Rigs, info)
+	}
+	sort.Slice(m.Rigs, func(i, j int) bool { return m.Rigs[i].Name < m.Rigs[j].Name })
+	return m, nil
+}
+
+// Write writes a gzipped tarball of files (relative to townRoot) to w, with
+// the manifest as the first entry. The manifest's file count is filled in.
+//
+// Symlinks into the town are archived relative to the link, so they resolve
+// wherever the backup is restored. Symlinks pointing outside the town can't
+// be restored and are left out; they are returned as "name -> target".
+func Write(w io.Writer, townRoot string, m *Manifest, files []string) (skipped []string, err error) {
+	var kept []string
+	links := make(map[string]string)
+	for _, rel := range files {
+		p := filepath.Join(townRoot, filepath.FromSlash(rel))
+		info, err := os.Lstat(p)
+		if err != nil {
+			return nil, fmt.Errorf("archiving %s: %w", rel, err)
+		}
+		if info.Mode()&fs.ModeSymlink != 0 {
+			target, err := os.Readlink(p)
+			if err != nil {
+				return nil, fmt.Errorf("archiving %s: %w", rel, err)
+			}
+			link, ok := archiveLink(townRoot, rel, target)
+			if !ok {
+				skipped = append(skipped, rel+" -> "+target)
+				continue
+			}
+			links[rel] = link
+		}
+		kept = append(kept, rel)
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	m.Files = len(kept)
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := tw.WriteHeader(&tar.Header{
+		Name:     ManifestName,
+		Mode:     0644,
+		Size:     int64(len(data)),
+		ModTime:  m.CreatedAt,
+		Typeflag: tar.TypeReg,
+	}); err != nil {
+		return nil, err
+	}
+	if _, err := tw.Write(data); err != nil {
+		return nil, err
+	}
+
+	for _, rel := range kept {
+		if err := writeFile(tw, townRoot, rel, links[rel]); err != nil {
+			return nil, fmt.Errorf("archiving %s: %w", rel, err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	return skipped, gz.Close()
+}
+
+// archiveLink returns the link target to archive for the symlink rel, made
+// relative when it is absolute. ok is false when the target lies outside
+// the town, where Extract would refuse it.
+func archiveLink(townRoot, rel, target string) (link string, ok bool) {
+	dir := filepath.Dir(filepath.Join(townRoot, filepath.FromSlash(rel)))
+	if filepath.IsAbs(target) {
+		roots := []string{townRoot}
+		if resolved, err := filepath.EvalSymlinks(townRoot); err == nil && resolved != townRoot {
+			roots = append(roots, resolved)
+		}
+		for _, root := range roots {
+			inTown, err := filepath.Rel(root, target)
+			if err != nil || inTown == ".." || strings.HasPrefix(inTown, ".."+string(filepath.Separator)) {
+				continue
+			}
+			if link, err = filepath.Rel(dir, filepath.Join(townRoot, inTown)); err == nil {
+				return link, true
+			}
+		}
+		return "", false
+	}
+	if _, err := safeJoin("", path.Join(path.Dir(rel), filepath.ToSlash(target))); err != nil {
+		return "", false
+	}
+	return target, true
+}
+
+func writeFile(tw *tar.Writer, townRoot, rel, link string) error {
+	p := filepath.Join(townRoot, filepath.FromSlash(rel))
+	info, err := os.Lstat(p)
+	if err != nil {
+		return err
+	}
+	hdr, err := tar.FileInfoHeader(info, link)
+	if err != nil {
+		return err
+	}
+	hdr.Name = rel
+	hdr.Uname, hdr.Gname = "", ""
+	if err := tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	if !info.Mode().IsRegular() {
+		return nil
+	}
+	f, err := os.Open(p) //nolint:gosec // G304: path is within the town root
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = io.CopyN(tw, f, hdr.Size)
+	return err
+}
+
+// ReadManifest reads the manifest from the backup at archivePath.
+func ReadManifest(archivePath string) (*Manifest, error) {
+	f, err := os.Open(archivePath) //nolint:gosec // G304: user-supplied backup path
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s is not a gt backup: %w", archivePath, err)
+	}
+	defer gz.Close()
+	hdr, err := tar.NewReader(gz).Next()
+	if err != nil {
+		return nil, fmt.Errorf("%s is not a gt backup: %w", archivePath, err)
+	}
+	if hdr.Name != ManifestName {
+		return nil, fmt.Errorf("%s is not a gt backup: first entry is %q, want %s", archivePath, hdr.Name, ManifestName)
+	}
+	return decodeManifest(io.LimitReader(gz, hdr.Size))
+}
+
+func decodeManifest(r io.Reader) (*Manifest, error) {
+	var m Manifest
+	if err := json.NewDecoder(r).Decode(&m); err != nil {
+		return nil, fmt.Errorf("parsing backup manifest: %w", err)
+	}
+	if m.Version > FormatVersion {
+		return nil, fmt.Errorf("backup format version %d is newer than this gt supports (%d); upgrade gt", m.Version, FormatVersion)
+	}
+	return &m, nil
+}
+
+// Extract unpacks the backup read from r into dest and returns its manifest.
+// Entries that would land outside dest are rejected, as are writes through a
+// symlink. Symlinks are created last, so one from the archive can't redirect
+// a later entry.
+func Extract(r io.Reader, dest string) (*Manifest, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("not a gt backup: %w", err)
+	}
+	defer gz.Close()
+	tr := tar.NewReader(gz)
+
+	var m *Manifest
+	var links []*tar.Header
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading backup: %w", err)
+		}
+		if m == nil {
+			if hdr.Name != ManifestName {
+				return nil, fmt.Errorf("not a gt backup: first entry is %q, want %s", hdr.Name, ManifestName)
+			}
+			if m, err = decodeManifest(tr); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if hdr.Typeflag == tar.TypeSymlink {
+			links = append(links, hdr)
+			continue
+		}
+		if err := extractEntry(tr, hdr, dest); err != nil {
+			return nil, fmt.Errorf("extracting %s: %w", hdr.Name, err)
+		}
+	}
+	if m == nil {
+		return nil, fmt.Errorf("not a gt backup: archive is empty")
+	}
+	for _, hdr := range links {
+		if err := extractEntry(tr, hdr, dest); err != nil {
+			return nil, fmt.Errorf("extracting %s: %w", hdr.Name, err)
+		}
+	}
+	return m, nil
+}
+
+func extractEntry(tr *tar.Reader, hdr *tar.Header, dest string) error {
+	target, err := safeJoin(dest, hdr.Name)
+	if err != nil {
+		return err
+	}
+	if err := checkNoSymlinks(dest, filepath.Dir(target)); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+	if hdr.Typeflag != tar.TypeDir {
+		// Replace rather than follow a symlink already at target
+		if fi, err := os.Lstat(target); err == nil && fi.Mode()&fs.ModeSymlink != 0 {
+			if err := os.Remove(target); err != nil {
+				return err
+			}
+		}
+	}
+	switch hdr.Typeflag {
+	case tar.TypeDir:
+		return os.MkdirAll(target, 0755)
+	case tar.TypeSymlink:
+		if filepath.IsAbs(hdr.Linkname) {
+			return fmt.Errorf("absolute symlink target %q", hdr.Linkname)
+		}
+		if _, err := safeJoin(dest, path.Join(path.Dir(hdr.Name), filepath.ToSlash(hdr.Linkname))); err != nil {
+			return err
+		}
+		return os.Symlink(hdr.Linkname, target)
+	case tar.TypeReg:
+		f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, fs.FileMode(hdr.Mode)&0777) //nolint:gosec // G304: target checked by safeJoin
+		if err != nil {
+			return err
+		}
+		if _, err := io.CopyN(f, tr, hdr.Size); err != nil {
+			_ = f.Close()
+			return err
+		}
+		if err := f.Close(); err != nil {
+			return err
+		}
+		return os.Chtimes(target, hdr.ModTime, hdr.ModTime)
+	default:
+		return nil // Devices, fifos: never written by Write
+	}
+}
+
+// checkNoSymlinks rejects dir if any path component from dest down to it is
+// a symlink, whether restored from the archive or already on disk, since a
+// write through it could land outside dest.
+func checkNoSymlinks(dest, dir string) error {
+	rel, err := filepath.Rel(dest, dir)
+	if err != nil || rel == "." {
+		return err
+	}
+	p := dest
+	for _, part := range strings.Split(rel, string(filepath.Separator)) {
+		p = filepath.Join(p, part)
+		fi, err := os.Lstat(p)
+		if os.IsNotExist(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if fi.Mode()&fs.ModeSymlink != 0 {
+			return fmt.Errorf("backup entry would be written through symlink %s", p)
+		}
+	}
+	return nil
+}
+
+// safeJoin joins an archive entry name onto dest, rejecting names that
+// would escape it.
+func safeJoin(dest, name string) (string, error) {
+	clean := path.Clean(name)
+	if path.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, "../") {
+		return "", fmt.Errorf("backup entry %q escapes the destination directory", name)
+	}
+	return filepath.Join(dest, filepath.FromSlash(clean)), nil
+}
@@ -0,0 +1,281 @@
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// makeTown lays out a small town with one rig, including the clones and
+// runtime state a backup must leave out.
+func makeTown(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	files := map[string]string{
+		"mayor/town.json":                      `{"type":"town","version":2,"name":"hq-test"}`,
+		"mayor/rigs.json":                      `{"version":1,"rigs":{"api":{"git_url":"https://example.com/api.git","beads":{"prefix":"api"}}}}`,
+		"settings/config.json":                 `{}`,
+		".beads/routes.jsonl":                  `{"prefix":"api-","path":"api"}`,
+		".dolt-data/api/.dolt/noms/manifest":   "m",
+		".dolt-data/api/.dolt/noms/LOCK":       "",
+		".dolt-data/api/.dolt/sql-server.info": "1234",
+		"deacon/heartbeat.json":                `{}`,
+		"deacon/dogs/alpha/README":             "worktree",
+		"daemon/dolt.pid":                      "1234",
+		"logs/audit.jsonl":                     "{}\n",
+		"logs/town.log":                        "noise",
+		".runtime/pids/x":                      "1",
+		"CLAUDE.md":                            "# town",
+		"api/config.json":                      `{"type":"rig","name":"api","git_url":"https://example.com/api.git","default_branch":"main"}`,
+		"api/settings/config.json":             `{}`,
+		"api/.beads/redirect":                  "mayor/rig/.beads",
+		"api/.repo.git/HEAD":                   "ref: refs/heads/main",
+		"api/mayor/rig/README.md":              "clone",
+		"api/refinery/rig/README.md":           "worktree",
+		"api/refinery/mail/inbox.jsonl":        "{}\n",
+		"api/witness/mail/inbox.jsonl":         "{}\n",
+		"api/crew/max/README.md":               "crew clone",
+		"api/polecats/nux/README.md":           "polecat worktree",
+		"api/.runtime/overlay/.env":            "KEY=1",
+		"api/.runtime/locks/x.lock":            "",
+	}
+	for name, data := range files {
+		p := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+func TestCollect(t *testing.T) {
+	root := makeTown(t)
+	files, err := Collect(root)
+	if err != nil {
+		t.Fatalf("Collect: %v", err)
+	}
+	want := []string{
+		".beads/routes.jsonl",
+		".dolt-data/api/.dolt/noms/manifest",
+		"CLAUDE.md",
+		"api/.beads/redirect",
+		"api/.runtime/overlay/.env",
+		"api/config.json",
+		"api/refinery/mail/inbox.jsonl",
+		"api/settings/config.json",
+		"api/witness/mail/inbox.jsonl",
+		"logs/audit.jsonl",
+		"mayor/rigs.json",
+		"mayor/town.json",
+		"settings/config.json",
+	}
+	if !reflect.DeepEqual(files, want) {
+		t.Errorf("Collect =\n  %v\nwant\n  %v", files, want)
+	}
+}
+
+func TestNewManifest(t *testing.T) {
+	root := makeTown(t)
+	m, err := NewManifest(root)
+	if err != nil {
+		t.Fatalf("NewManifest: %v", err)
+	}
+	if m.Town != "hq-test" || m.TownRoot != root || m.Version != FormatVersion {
+		t.Errorf("unexpected header: %+v", m)
+	}
+	want := []RigInfo{{
+		Name:          "api",
+		GitURL:        "https://example.com/api.git",
+		DefaultBranch: "main",
+		Prefix:        "api",
+		Crew:          []string{"max"},
+	}}
+	if !reflect.DeepEqual(m.Rigs, want) {
+		t.Errorf("Rigs = %+v, want %+v", m.Rigs, want)
+	}
+}
+
+func TestWriteExtractRoundTrip(t *testing.T) {
+	root := makeTown(t)
+	files, err := Collect(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := NewManifest(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if _, err := Write(&buf, root, m, files); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	archive := filepath.Join(t.TempDir(), "town.tar.gz")
+	if err := os.WriteFile(archive, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ReadManifest(archive)
+	if err != nil {
+		t.Fatalf("ReadManifest: %v", err)
+	}
+	if got.Files != len(files) || got.Town != "hq-test" {
+		t.Errorf("manifest = %+v", got)
+	}
+
+	dest := t.TempDir()
+	if _, err := Extract(bytes.NewReader(buf.Bytes()), dest); err != nil {
+		t.Fatalf("Extract: %v", err)
+	}
+	for _, rel := range files {
+		want, _ := os.ReadFile(filepath.Join(root, rel))
+		have, err := os.ReadFile(filepath.Join(dest, rel))
+		if err != nil || !bytes.Equal(want, have) {
+			t.Errorf("%s not restored intact: %v", rel, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dest, "api", "mayor", "rig")); !os.IsNotExist(err) {
+		t.Error("clone directory should not be in the backup")
+	}
+}
+
+func TestWriteSymlinks(t *testing.T) {
+	root := makeTown(t)
+	beadsDir := filepath.Join(root, ".beads")
+	outside := t.TempDir()
+	if err := os.Symlink(filepath.Join(beadsDir, "routes.jsonl"), filepath.Join(beadsDir, "abs.yaml")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(outside, "x"), filepath.Join(beadsDir, "out.yaml")); err != nil {
+		t.Fatal(err)
+	}
+	files, err := Collect(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := NewManifest(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	skipped, err := Write(&buf, root, m, files)
+	if err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if len(skipped) != 1 || !strings.HasPrefix(skipped[0], ".beads/out.yaml -> ") {
+		t.Errorf("skipped = %v", skipped)
+	}
+
+	dest := t.TempDir()
+	if _, err := Extract(bytes.NewReader(buf.Bytes()), dest); err != nil {
+		t.Fatalf("Extract: %v", err)
+	}
+	if link, err := os.Readlink(filepath.Join(dest, ".beads", "abs.yaml")); err != nil || link != "routes.jsonl" {
+		t.Errorf("absolute symlink restored as %q, %v; want relative", link, err)
+	}
+	if _, err := os.Lstat(filepath.Join(dest, ".beads", "out.yaml")); !os.IsNotExist(err) {
+		t.Error("symlink outside the town should not be restored")
+	}
+}
+
+func TestExtractRejectsEscapes(t *testing.T) {
+	for _, name := range []string{"../evil", "/etc/evil", "a/../../evil"} {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			gz := gzip.NewWriter(&buf)
+			tw := tar.NewWriter(gz)
+			manifest := []byte(`{"version":1}`)
+			_ = tw.WriteHeader(&tar.Header{Name: ManifestName, Mode: 0644, Size: int64(len(manifest)), Typeflag: tar.TypeReg})
+			_, _ = tw.Write(manifest)
+			_ = tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: 1, Typeflag: tar.TypeReg})
+			_, _ = tw.Write([]byte("x"))
+			_ = tw.Close()
+			_ = gz.Close()
+
+			_, err := Extract(&buf, t.TempDir())
+			if err == nil || !strings.Contains(err.Error(), "escapes") {
+				t.Errorf("err = %v, want escape rejection", err)
+			}
+		})
+	}
+}
+
+// TestExtractRejectsWritesThroughSymlinks chains links that each look
+// in-bounds ("d" -> ".", "d/e" -> "..") and then writes a file under the
+// chain, and writes over a tree whose existing link points outside dest.
+func TestExtractRejectsWritesThroughSymlinks(t *testing.T) {
+	archive := func(hdrs ...*tar.Header) *bytes.Buffer {
+		var buf bytes.Buffer
+		gz := gzip.NewWriter(&buf)
+		tw := tar.NewWriter(gz)
+		manifest := []byte(`{"version":1}`)
+		_ = tw.WriteHeader(&tar.Header{Name: ManifestName, Mode: 0644, Size: int64(len(manifest)), Typeflag: tar.TypeReg})
+		_, _ = tw.Write(manifest)
+		for _, hdr := range hdrs {
+			_ = tw.WriteHeader(hdr)
+			if hdr.Typeflag == tar.TypeReg {
+				_, _ = tw.Write(make([]byte, hdr.Size))
+			}
+		}
+		_ = tw.Close()
+		_ = gz.Close()
+		return &buf
+	}
+
+	t.Run("link chain", func(t *testing.T) {
+		parent := t.TempDir()
+		dest := filepath.Join(parent, "town")
+		buf := archive(
+			&tar.Header{Name: "d", Linkname: ".", Typeflag: tar.TypeSymlink},
+			&tar.Header{Name: "d/e", Linkname: "..", Typeflag: tar.TypeSymlink},
+			&tar.Header{Name: "d/e/evil", Mode: 0644, Size: 1, Typeflag: tar.TypeReg},
+		)
+		if _, err := Extract(buf, dest); err == nil {
+			t.Error("Extract succeeded, want rejection")
+		}
+		if _, err := os.Lstat(filepath.Join(parent, "evil")); !os.IsNotExist(err) {
+			t.Error("file was written outside the destination")
+		}
+	})
+
+	t.Run("existing link", func(t *testing.T) {
+		dest, outside := t.TempDir(), t.TempDir()
+		if err := os.Symlink(outside, filepath.Join(dest, "settings")); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Symlink(filepath.Join(outside, "f"), filepath.Join(dest, "f")); err != nil {
+			t.Fatal(err)
+		}
+		buf := archive(&tar.Header{Name: "settings/config.json", Mode: 0644, Size: 1, Typeflag: tar.TypeReg})
+		if _, err := Extract(buf, dest); err == nil || !strings.Contains(err.Error(), "symlink") {
+			t.Errorf("err = %v, want symlink rejection", err)
+		}
+		buf = archive(&tar.Header{Name: "f", Mode: 0644, Size: 1, Typeflag: tar.TypeReg})
+		if _, err := Extract(buf, dest); err != nil {
+			t.Fatalf("Extract: %v", err)
+		}
+		if entries, _ := os.ReadDir(outside); len(entries) != 0 {
+			t.Errorf("wrote outside the destination: %v", entries)
+		}
+		if fi, err := os.Lstat(filepath.Join(dest, "f")); err != nil || !fi.Mode().IsRegular() {
+			t.Errorf("f should be replaced by a regular file: %v", err)
+		}
+	})
+}
+
+func TestReadManifestRejectsNonBackup(t *testing.T) {
+	p := filepath.Join(t.TempDir(), "not.tar.gz")
+	if err := os.WriteFile(p, []byte("plain text"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadManifest(p); err == nil || !strings.Contains(err.Error(), "not a gt backup") {
+		t.Errorf("err = %v, want not a gt backup", err)
+	}
+}
@@ -65,7 +65,7 @@ func (b *Beads) DetachMoleculeWithAudit(pinnedBeadID string, opts DetachOptions)
 	}
 	if err := b.LogDetachAudit(entry); err != nil {
 		// Log error but don't fail the detach operation
-		fmt.Fprintf(os.Stderr, "Warning: failed to write audit log: %v\n", err)
+		logger.Warn("failed to write audit log", "err", err)
 	}
 
 	// Clear attachment fields by passing nil
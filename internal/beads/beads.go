@@ -14,15 +14,20 @@ import (
 	"sync"
 	"time"
 
+	"github.com/steveyegge/gastown/internal/errkind"
+	"github.com/steveyegge/gastown/internal/logging"
 	"github.com/steveyegge/gastown/internal/runtime"
 	"github.com/steveyegge/gastown/internal/telemetry"
 )
 
+// logger is the beads subsystem logger.
+var logger = logging.For("beads")
+
 // Common errors
 // ZFC: Only define errors that don't require stderr parsing for decisions.
 // ErrNotARepo and ErrSyncConflict were removed - agents should handle these directly.
 var (
-	ErrNotInstalled = errors.New("bd not installed: run 'pip install beads-cli' or see https://github.com/anthropics/beads")
+	ErrNotInstalled = errkind.New(errkind.ExternalTool, "bd not installed: run 'pip install beads-cli' or see https://github.com/anthropics/beads", "")
 	ErrNotFound     = errors.New("issue not found")
 	ErrFlagTitle    = errors.New("title looks like a CLI flag (starts with '-'); use --title=\"...\" to set flag-like titles intentionally")
 )
@@ -302,12 +307,21 @@ func (b *Beads) Init(prefix string) error {
 }
 
 // run executes a bd command and returns stdout.
-func (b *Beads) run(args ...string) (_ []byte, retErr error) {
+func (b *Beads) run(args ...string) ([]byte, error) {
+	return b.runInput("", args...)
+}
+
+// runInput is run with input on bd's stdin, for bodies passed with
+// "--body-file -". Large bodies can't go in argv: each argument is limited
+// to 128KiB by the kernel.
+func (b *Beads) runInput(input string, args ...string) (_ []byte, retErr error) {
 	start := time.Now()
 	// Declare buffers before defer so the closure captures them after cmd.Run.
 	var stdout, stderr bytes.Buffer
 	defer func() {
 		telemetry.RecordBDCall(context.Background(), args, float64(time.Since(start).Milliseconds()), retErr, stdout.Bytes(), stderr.String())
+		logger.Debug("ran bd", "args", strings.Join(args, " "), "dir", b.workDir, "duration", time.Since(start), "err", retErr)
+		showCache.invalidateFor(args)
 	}()
 	// Conditionally use --allow-stale to prevent failures when db is temporarily stale
 	// (e.g., after daemon is killed during shutdown). Only if bd supports it.
@@ -327,6 +341,9 @@ func (b *Beads) run(args ...string) (_ []byte, retErr error) {
 	cmd.Env = append(b.buildRunEnv(), "BEADS_DIR="+beadsDir)
 	cmd.Env = append(cmd.Env, telemetry.OTELEnvForSubprocess()...)
 
+	if input != "" {
+		cmd.Stdin = strings.NewReader(input)
+	}
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
 
@@ -355,6 +372,7 @@ func (b *Beads) runWithRouting(args ...string) (_ []byte, retErr error) { //noli
 	var stdout, stderr bytes.Buffer
 	defer func() {
 		telemetry.RecordBDCall(context.Background(), args, float64(time.Since(start).Milliseconds()), retErr, stdout.Bytes(), stderr.String())
+		showCache.invalidateFor(args)
 	}()
 	fullArgs := MaybePrependAllowStale(args)
 
@@ -386,6 +404,11 @@ func (b *Beads) Run(args ...string) ([]byte, error) {
 	return b.run(args...)
 }
 
+// RunWithInput is Run with input on bd's stdin (e.g., for "--body-file -").
+func (b *Beads) RunWithInput(input string, args ...string) ([]byte, error) {
+	return b.runInput(input, args...)
+}
+
 // wrapError wraps bd errors with context.
 // ZFC: Avoid parsing stderr to make decisions. Transport errors to agents instead.
 // Exception: ErrNotInstalled (exec.ErrNotFound) and ErrNotFound (issue lookup) are
@@ -686,6 +709,10 @@ func (b *Beads) ReadyWithType(issueType string) ([]*Issue, error) {
 
 // Show returns detailed information about an issue.
 func (b *Beads) Show(id string) (*Issue, error) {
+	if issue, ok := showCache.get(id); ok {
+		return issue, nil
+	}
+
 	// Route cross-rig queries via routes.jsonl so that rig-level bead IDs
 	// (e.g., "gt-abc123") resolve to the correct rig database.
 	targetDir := ResolveRoutingTarget(b.getTownRoot(), id, b.getResolvedBeadsDir())
@@ -709,30 +736,40 @@ func (b *Beads) Show(id string) (*Issue, error) {
 		return nil, ErrNotFound
 	}
 
+	showCache.put(issues[0])
 	return issues[0], nil
 }
 
 // ShowMultiple fetches multiple issues by ID in a single bd call.
 // Returns a map of ID to Issue. Missing IDs are not included in the map.
 func (b *Beads) ShowMultiple(ids []string) (map[string]*Issue, error) {
-	if len(ids) == 0 {
-		return make(map[string]*Issue), nil
+	result := make(map[string]*Issue, len(ids))
+	var missing []string
+	for _, id := range ids {
+		if issue, ok := showCache.get(id); ok {
+			result[id] = issue
+		} else {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) == 0 {
+		return result, nil
 	}
 
 	// bd show supports multiple IDs
-	args := append([]string{"show", "--json"}, ids...)
+	args := append([]string{"show", "--json"}, missing...)
 	out, err := b.run(args...)
 	if err != nil {
 		return nil, fmt.Errorf("bd show: %w", err)
 	}
 
-	var issues []*Issue
-	if err := json.Unmarshal(out, &issues); err != nil {
+	var fetched []*Issue
+	if err := json.Unmarshal(out, &fetched); err != nil {
 		return nil, fmt.Errorf("parsing bd show output: %w", err)
 	}
 
-	result := make(map[string]*Issue, len(issues))
-	for _, issue := range issues {
+	for _, issue := range fetched {
+		showCache.put(issue)
 		result[issue.ID] = issue
 	}
 
@@ -975,7 +1012,14 @@ func normalizeBugTitle(title string) string {
 
 // Update updates an existing issue.
 func (b *Beads) Update(id string, opts UpdateOptions) error {
-	args := []string{"update", id}
+	args := append([]string{"update", id}, updateFlags(opts)...)
+	_, err := b.run(args...)
+	return err
+}
+
+// updateFlags builds the bd update flags for opts.
+func updateFlags(opts UpdateOptions) []string {
+	var args []string
 
 	if opts.Title != nil {
 		args = append(args, "--title="+*opts.Title)
@@ -1006,8 +1050,7 @@ func (b *Beads) Update(id string, opts UpdateOptions) error {
 		}
 	}
 
-	_, err := b.run(args...)
-	return err
+	return args
 }
 
 // Close closes one or more issues.
@@ -430,6 +430,27 @@ func (b *Beads) UpdateAgentState(id string, state string) (retErr error) {
 	return nil
 }
 
+// LabelCrashLoop marks an agent bead whose session kept dying, so the
+// daemon stopped restarting it. Cleared by 'gt daemon clear-backoff'.
+const LabelCrashLoop = "gt:crash-loop"
+
+// MarkAgentCrashLoop sets or clears the crash-loop label on an agent bead.
+// Setting it also moves agent_state to stuck, so the agent shows up as
+// needing help.
+func (b *Beads) MarkAgentCrashLoop(id string, crashLooping bool) error {
+	flag := "--remove-label="
+	if crashLooping {
+		flag = "--add-label="
+	}
+	if _, err := b.runWithRouting("update", id, flag+LabelCrashLoop); err != nil {
+		return fmt.Errorf("updating crash-loop label: %w", err)
+	}
+	if crashLooping {
+		return b.UpdateAgentState(id, string(AgentStateStuck))
+	}
+	return nil
+}
+
 // SetHookBead and ClearHookBead removed (hq-l6mm5).
 // Hook slot on agent beads is no longer maintained. Work bead status=hooked
 // and assignee=<agent> is the authoritative source for hook tracking.
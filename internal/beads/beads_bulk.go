@@ -0,0 +1,119 @@
+package beads
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+)
+
+// ShowMany fetches many issues, which may live in different rig databases,
+// with one bd show per database rather than one per issue. IDs are routed by
+// prefix via routes.jsonl, like Show. Missing IDs are left out of the map;
+// an error is returned alongside whatever was found.
+func (b *Beads) ShowMany(ids []string) (map[string]*Issue, error) {
+	result := make(map[string]*Issue, len(ids))
+	groups := make(map[string][]string) // Target beads dir -> IDs
+	seen := make(map[string]bool, len(ids))
+	ownDir := b.getResolvedBeadsDir()
+	for _, id := range ids {
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		if issue, ok := showCache.get(id); ok {
+			result[id] = issue
+			continue
+		}
+		dir := ResolveRoutingTarget(b.getTownRoot(), id, ownDir)
+		groups[dir] = append(groups[dir], id)
+	}
+
+	var (
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+		errs []error
+	)
+	for dir, group := range groups {
+		target := b
+		if dir != ownDir {
+			target = NewWithBeadsDir(filepath.Dir(dir), dir)
+		}
+		wg.Add(1)
+		go func(target *Beads, group []string) {
+			defer wg.Done()
+			found, err := target.showGroup(group)
+			mu.Lock()
+			defer mu.Unlock()
+			for id, issue := range found {
+				result[id] = issue
+			}
+			if err != nil {
+				errs = append(errs, err)
+			}
+		}(target, group)
+	}
+	wg.Wait()
+	return result, errors.Join(errs...)
+}
+
+// showGroup fetches IDs from one database. bd show fails the whole call if
+// any ID is missing, so on failure each ID is retried alone: one stale hook
+// shouldn't hide every other title.
+func (b *Beads) showGroup(ids []string) (map[string]*Issue, error) {
+	found, err := b.ShowMultiple(ids)
+	if err == nil || len(ids) == 1 {
+		return found, err
+	}
+	found = make(map[string]*Issue, len(ids))
+	var errs []error
+	for _, id := range ids {
+		issue, err := b.Show(id)
+		switch {
+		case err == nil:
+			found[id] = issue
+		case !errors.Is(err, ErrNotFound):
+			errs = append(errs, fmt.Errorf("%s: %w", id, err))
+		}
+	}
+	return found, errors.Join(errs...)
+}
+
+// GetAgentBeads is the batch form of GetAgentBead: it fetches many agent
+// beads with ShowMany. Missing beads and beads that aren't agent beads are
+// left out of the map.
+func (b *Beads) GetAgentBeads(ids []string) (map[string]*Issue, error) {
+	found, err := b.ShowMany(ids)
+	for id, issue := range found {
+		if !IsAgentBead(issue) {
+			delete(found, id)
+		}
+	}
+	return found, err
+}
+
+// UpdateMany applies the same update to many issues in one bd invocation.
+// Like Update, it operates on this wrapper's database; IDs are not routed.
+func (b *Beads) UpdateMany(ids []string, opts UpdateOptions) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	args := append([]string{"update"}, ids...)
+	_, err := b.run(append(args, updateFlags(opts)...)...)
+	return err
+}
+
+// HookBeadID returns the bead hooked to an agent bead: the hook_bead column,
+// or for legacy beads the hook_bead field in the description.
+func HookBeadID(agent *Issue) string {
+	if agent == nil {
+		return ""
+	}
+	if agent.HookBead != "" {
+		return agent.HookBead
+	}
+	if fields := ParseAgentFields(agent.Description); fields != nil {
+		return fields.HookBead
+	}
+	return ""
+}
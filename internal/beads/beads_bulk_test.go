@@ -0,0 +1,141 @@
+package beads
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// installBulkFakeBd puts a bd on PATH that logs each invocation and answers
+// show with one issue per ID, failing the whole call if any ID is
+// "gt-missing" (as bd show does).
+func installBulkFakeBd(t *testing.T) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake bd is a shell script")
+	}
+	binDir := t.TempDir()
+	logPath := filepath.Join(binDir, "bd.log")
+	script := `#!/bin/sh
+[ "$1" = "--allow-stale" ] && shift
+echo "$@" >> "` + logPath + `"
+cmd=$1; shift
+case "$cmd" in
+show)
+  out=""
+  for a in "$@"; do
+    case "$a" in
+    --*) ;;
+    gt-missing) echo "Error: issue gt-missing not found" >&2; exit 1 ;;
+    *) out="$out${out:+,}{\"id\":\"$a\",\"title\":\"title $a\",\"issue_type\":\"task\"}" ;;
+    esac
+  done
+  echo "[$out]"
+  ;;
+update) echo "ok" ;;
+esac
+`
+	if err := os.WriteFile(filepath.Join(binDir, "bd"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	return logPath
+}
+
+func bdCalls(t *testing.T, logPath string) []string {
+	t.Helper()
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		return nil
+	}
+	var calls []string
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		if line != "version" { // The --allow-stale probe
+			calls = append(calls, line)
+		}
+	}
+	return calls
+}
+
+func TestShowMany_OneCallPerDatabase(t *testing.T) {
+	logPath := installBulkFakeBd(t)
+	b := NewWithBeadsDir(t.TempDir(), t.TempDir())
+
+	got, err := b.ShowMany([]string{"gt-a", "gt-b", "gt-a", ""})
+	if err != nil {
+		t.Fatalf("ShowMany: %v", err)
+	}
+	if len(got) != 2 || got["gt-a"].Title != "title gt-a" || got["gt-b"].Title != "title gt-b" {
+		t.Errorf("ShowMany = %v", got)
+	}
+	if calls := bdCalls(t, logPath); len(calls) != 1 {
+		t.Errorf("bd calls = %q, want one", calls)
+	}
+}
+
+func TestShowMany_MissingIDDoesNotHideOthers(t *testing.T) {
+	logPath := installBulkFakeBd(t)
+	b := NewWithBeadsDir(t.TempDir(), t.TempDir())
+
+	got, err := b.ShowMany([]string{"gt-a", "gt-missing", "gt-b"})
+	if err != nil {
+		t.Errorf("ShowMany: %v (a missing ID is not an error)", err)
+	}
+	if len(got) != 2 || got["gt-a"] == nil || got["gt-b"] == nil {
+		t.Errorf("ShowMany = %v, want gt-a and gt-b", got)
+	}
+	// The batch, then each ID alone.
+	if calls := bdCalls(t, logPath); len(calls) != 4 {
+		t.Errorf("bd calls = %q, want 4", calls)
+	}
+}
+
+func TestGetAgentBeads_FiltersNonAgents(t *testing.T) {
+	installBulkFakeBd(t)
+	b := NewWithBeadsDir(t.TempDir(), t.TempDir())
+
+	got, err := b.GetAgentBeads([]string{"gt-a"})
+	if err != nil {
+		t.Fatalf("GetAgentBeads: %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("GetAgentBeads = %v, want task beads filtered out", got)
+	}
+}
+
+func TestUpdateMany_SingleInvocation(t *testing.T) {
+	logPath := installBulkFakeBd(t)
+	b := NewWithBeadsDir(t.TempDir(), t.TempDir())
+
+	status := "open"
+	if err := b.UpdateMany([]string{"gt-a", "gt-b"}, UpdateOptions{Status: &status, AddLabels: []string{"x"}}); err != nil {
+		t.Fatalf("UpdateMany: %v", err)
+	}
+	calls := bdCalls(t, logPath)
+	want := "update gt-a gt-b --status=open --add-label=x"
+	if len(calls) != 1 || calls[0] != want {
+		t.Errorf("bd calls = %q, want [%q]", calls, want)
+	}
+
+	if err := b.UpdateMany(nil, UpdateOptions{Status: &status}); err != nil {
+		t.Fatalf("UpdateMany(nil): %v", err)
+	}
+	if calls := bdCalls(t, logPath); len(calls) != 1 {
+		t.Errorf("UpdateMany(nil) ran bd: %q", calls)
+	}
+}
+
+func TestHookBeadID(t *testing.T) {
+	if got := HookBeadID(&Issue{HookBead: "gt-col"}); got != "gt-col" {
+		t.Errorf("column: got %q", got)
+	}
+	legacy := &Issue{Description: "role_type: polecat\nhook_bead: gt-desc\n"}
+	if got := HookBeadID(legacy); got != "gt-desc" {
+		t.Errorf("description fallback: got %q", got)
+	}
+	if got := HookBeadID(nil); got != "" {
+		t.Errorf("nil: got %q", got)
+	}
+}
@@ -146,7 +146,7 @@ func (b *Beads) CreateChannelBead(name string, subscribers []string, createdBy s
 		Subscribers: subscribers,
 		Status:      ChannelStatusActive,
 		CreatedBy:   createdBy,
-		CreatedAt:   time.Now().Format(time.RFC3339),
+		CreatedAt:   time.Now().UTC().Format(time.RFC3339),
 	}
 
 	description := FormatChannelDescription(title, fields)
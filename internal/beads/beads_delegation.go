@@ -5,8 +5,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
-
-	"github.com/steveyegge/gastown/internal/style"
 )
 
 // Delegation represents a work delegation relationship between work units.
@@ -79,7 +77,7 @@ func (b *Beads) AddDelegation(d *Delegation) error {
 	// Also add a dependency so child blocks parent (work must complete before parent can close)
 	if err := b.AddDependency(d.Parent, d.Child); err != nil {
 		// Log but don't fail - the delegation is still recorded
-		style.PrintWarning("could not add blocking dependency for delegation: %v", err)
+		logger.Warn("could not add blocking dependency for delegation", "parent", d.Parent, "child", d.Child, "err", err)
 	}
 
 	return nil
@@ -96,7 +94,7 @@ func (b *Beads) RemoveDelegation(parent, child string) error {
 	// Also remove the blocking dependency
 	if err := b.RemoveDependency(parent, child); err != nil {
 		// Log but don't fail
-		style.PrintWarning("could not remove blocking dependency: %v", err)
+		logger.Warn("could not remove blocking dependency", "parent", parent, "child", child, "err", err)
 	}
 
 	return nil
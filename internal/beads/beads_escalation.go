@@ -27,6 +27,9 @@ type EscalationFields struct {
 	ReescalationCount  int    // Number of times this has been re-escalated
 	LastReescalatedAt  string // When last re-escalated (empty if never)
 	LastReescalatedBy  string // Who last re-escalated (empty if never)
+	Chain              string // Escalation chain spec (see config.ParseEscalationChain); empty if none
+	ChainLevel         int    // Index of the chain level notified last
+	LevelNotifiedAt    string // When the current chain level was notified
 }
 
 
@@ -97,6 +100,13 @@ func FormatEscalationDescription(title string, fields *EscalationFields) string
 		lines = append(lines, "last_reescalated_by: null")
 	}
 
+	// Chain fields, only for escalations that follow a chain
+	if fields.Chain != "" {
+		lines = append(lines, fmt.Sprintf("chain: %s", fields.Chain))
+		lines = append(lines, fmt.Sprintf("chain_level: %d", fields.ChainLevel))
+		lines = append(lines, fmt.Sprintf("level_notified_at: %s", fields.LevelNotifiedAt))
+	}
+
 	return strings.Join(lines, "\n")
 }
 
@@ -152,6 +162,14 @@ func ParseEscalationFields(description string) *EscalationFields {
 			fields.LastReescalatedAt = value
 		case "last_reescalated_by":
 			fields.LastReescalatedBy = value
+		case "chain":
+			fields.Chain = value
+		case "chain_level":
+			if n, err := strconv.Atoi(value); err == nil {
+				fields.ChainLevel = n
+			}
+		case "level_notified_at":
+			fields.LevelNotifiedAt = value
 		}
 	}
 
@@ -218,7 +236,7 @@ func (b *Beads) AckEscalation(id, ackedBy string) error {
 	// Parse existing fields
 	fields := ParseEscalationFields(issue.Description)
 	fields.AckedBy = ackedBy
-	fields.AckedAt = time.Now().Format(time.RFC3339)
+	fields.AckedAt = time.Now().UTC().Format(time.RFC3339)
 
 	// Format new description
 	description := FormatEscalationDescription(issue.Title, fields)
@@ -350,6 +368,30 @@ func (b *Beads) ListStaleEscalations(threshold time.Duration) ([]*Issue, error)
 	return stale, nil
 }
 
+// AdvanceEscalationChain records that an escalation moved to the given
+// chain level and that the level was notified at notifiedAt.
+func (b *Beads) AdvanceEscalationChain(id string, level int, notifiedAt time.Time) error {
+	issue, fields, err := b.GetEscalationBead(id)
+	if err != nil {
+		return err
+	}
+	if issue == nil {
+		return fmt.Errorf("escalation not found: %s", id)
+	}
+	if fields.Chain == "" {
+		return fmt.Errorf("escalation %s has no chain", id)
+	}
+
+	fields.ChainLevel = level
+	fields.LevelNotifiedAt = notifiedAt.UTC().Format(time.RFC3339)
+	description := FormatEscalationDescription(issue.Title, fields)
+
+	return b.Update(id, UpdateOptions{
+		Description: &description,
+		AddLabels:   []string{"chain-advanced"},
+	})
+}
+
 // ReescalationResult holds the result of a reescalation operation.
 type ReescalationResult struct {
 	ID              string
@@ -405,7 +447,7 @@ func (b *Beads) ReescalateEscalation(id, reescalatedBy string, maxReescalations
 	newSeverity := bumpSeverity(fields.Severity)
 	fields.Severity = newSeverity
 	fields.ReescalationCount++
-	fields.LastReescalatedAt = time.Now().Format(time.RFC3339)
+	fields.LastReescalatedAt = time.Now().UTC().Format(time.RFC3339)
 	fields.LastReescalatedBy = reescalatedBy
 
 	result.NewSeverity = newSeverity
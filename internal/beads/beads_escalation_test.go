@@ -322,3 +322,30 @@ func TestBumpSeverity(t *testing.T) {
 		})
 	}
 }
+
+func TestEscalationChainFieldsRoundTrip(t *testing.T) {
+	original := &EscalationFields{
+		Severity:        "high",
+		EscalatedBy:     "gastown/polecats/nux",
+		EscalatedAt:     "2024-06-15T12:00:00Z",
+		Chain:           "gastown/crew/max=30m,gastown/witness=1h,mayor/",
+		ChainLevel:      1,
+		LevelNotifiedAt: "2024-06-15T12:30:00Z",
+	}
+
+	parsed := ParseEscalationFields(FormatEscalationDescription("Tests flaky", original))
+	if parsed.Chain != original.Chain {
+		t.Errorf("Chain: got %q, want %q", parsed.Chain, original.Chain)
+	}
+	if parsed.ChainLevel != original.ChainLevel {
+		t.Errorf("ChainLevel: got %d, want %d", parsed.ChainLevel, original.ChainLevel)
+	}
+	if parsed.LevelNotifiedAt != original.LevelNotifiedAt {
+		t.Errorf("LevelNotifiedAt: got %q, want %q", parsed.LevelNotifiedAt, original.LevelNotifiedAt)
+	}
+
+	// Escalations without a chain don't carry the chain fields at all.
+	if desc := FormatEscalationDescription("Disk full", &EscalationFields{Severity: "low"}); strings.Contains(desc, "chain:") {
+		t.Errorf("description without a chain mentions one:\n%s", desc)
+	}
+}
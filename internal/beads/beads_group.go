@@ -151,7 +151,7 @@ func (b *Beads) CreateGroupBead(name string, fields *GroupFields) (*Issue, error
 	}
 	fields.Name = name
 	if fields.CreatedAt == "" {
-		fields.CreatedAt = time.Now().Format(time.RFC3339)
+		fields.CreatedAt = time.Now().UTC().Format(time.RFC3339)
 	}
 
 	description := FormatGroupDescription(title, fields)
@@ -54,10 +54,10 @@ func ResolveBeadsDir(workDir string) string {
 	// Detect circular redirects: if resolved path equals original beads dir,
 	// this is an errant redirect file (e.g., redirect in mayor/rig/.beads pointing to itself)
 	if resolved == beadsDir {
-		fmt.Fprintf(os.Stderr, "Warning: circular redirect detected in %s (points to itself), ignoring redirect\n", redirectPath)
+		logger.Warn("circular redirect points to itself, ignoring", "path", redirectPath)
 		// Remove the errant redirect file to prevent future warnings
 		if err := os.Remove(redirectPath); err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: could not remove errant redirect file: %v\n", err)
+			logger.Warn("could not remove errant redirect file", "err", err)
 		}
 		return beadsDir
 	}
@@ -71,7 +71,7 @@ func ResolveBeadsDir(workDir string) string {
 // resolveBeadsDirWithDepth follows redirect chains with a depth limit.
 func resolveBeadsDirWithDepth(beadsDir string, maxDepth int) string {
 	if maxDepth <= 0 {
-		fmt.Fprintf(os.Stderr, "Warning: redirect chain too deep at %s, stopping\n", beadsDir)
+		logger.Warn("redirect chain too deep, stopping", "beads_dir", beadsDir)
 		return beadsDir
 	}
 
@@ -99,7 +99,7 @@ func resolveBeadsDirWithDepth(beadsDir string, maxDepth int) string {
 
 	// Detect circular redirect
 	if resolved == beadsDir {
-		fmt.Fprintf(os.Stderr, "Warning: circular redirect detected in %s, stopping\n", redirectPath)
+		logger.Warn("circular redirect detected, stopping", "path", redirectPath)
 		return beadsDir
 	}
 
@@ -283,8 +283,8 @@ func SetupRedirect(townRoot, worktreePath string) error {
 			// No redirect file — this is an unexpected fallback
 			rigBeadsPath := filepath.Join(rigRoot, ".beads")
 			mayorBeadsPath := filepath.Join(rigRoot, "mayor", "rig", ".beads")
-			fmt.Fprintf(os.Stderr, "Warning: rig .beads not found at %s, using %s\n", rigBeadsPath, mayorBeadsPath)
-			fmt.Fprintf(os.Stderr, "  Run 'bd doctor' to fix rig beads configuration\n")
+			logger.Warn("rig .beads not found, using mayor's; run 'bd doctor' to fix rig beads configuration",
+				"path", rigBeadsPath, "using", mayorBeadsPath)
 		}
 	}
 
@@ -66,7 +66,7 @@ func (b *Beads) CreateSlingContext(workBeadTitle, workBeadID string, fields *cap
 	if depErr != nil {
 		// Non-fatal: the context bead was created, just missing the dep link.
 		// This can happen if the work bead is in a different DB and external refs aren't set up.
-		fmt.Printf("Warning: could not add tracks dep %s → %s: %v\n", issue.ID, workBeadID, depErr)
+		logger.Warn("could not add tracks dep", "from", issue.ID, "to", workBeadID, "err", depErr)
 	}
 
 	return &issue, nil
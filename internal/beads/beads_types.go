@@ -68,14 +68,14 @@ func ResolveRoutingTarget(townRoot, beadID, fallbackDir string) string {
 	// Look up rig path for this prefix
 	rigPath := GetRigPathForPrefix(townRoot, prefix)
 	if rigPath == "" {
-		fmt.Fprintf(os.Stderr, "Warning: no route found for prefix %q (bead %s), falling back to %s\n", prefix, beadID, fallbackDir)
+		logger.Warn("no route found for prefix, falling back", "prefix", prefix, "bead", beadID, "fallback", fallbackDir)
 		return fallbackDir
 	}
 
 	// Resolve redirects and get final beads directory
 	beadsDir := ResolveBeadsDir(rigPath)
 	if beadsDir == "" {
-		fmt.Fprintf(os.Stderr, "Warning: could not resolve beads dir for rig %s (bead %s), falling back to %s\n", rigPath, beadID, fallbackDir)
+		logger.Warn("could not resolve beads dir for rig, falling back", "rig", rigPath, "bead", beadID, "fallback", fallbackDir)
 		return fallbackDir
 	}
 
@@ -0,0 +1,177 @@
+package beads
+
+import (
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// issueCache holds bd show results by bead ID, so commands that look up the
+// same beads repeatedly (gt status resolving agent and hook beads across a
+// large town) pay for each bd call once. It is off unless a command calls
+// EnableIssueCache.
+//
+// Mutations made through Beads invalidate what they touch. Writes made by
+// other processes are not seen until the entry expires, so the TTL bounds
+// how stale a cached read can be.
+type issueCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration // Zero means disabled
+	entries map[string]issueCacheEntry
+	now     func() time.Time
+}
+
+type issueCacheEntry struct {
+	issue *Issue
+	at    time.Time
+}
+
+var showCache = &issueCache{now: time.Now}
+
+// readOnlyCommands are bd subcommands that never change beads.
+var readOnlyCommands = map[string]bool{
+	"show":    true,
+	"list":    true,
+	"ready":   true,
+	"blocked": true,
+	"search":  true,
+	"stats":   true,
+	"count":   true,
+	"info":    true,
+	"version": true,
+	"where":   true,
+	"config":  true, // gt only reads config through Beads
+}
+
+// idScopedCommands are bd subcommands that change only the beads named in
+// their arguments. Anything else that isn't read-only (create, close, which
+// can close a parent, delete, mol, sync, ...) may change beads gt didn't
+// name, and flushes the whole cache.
+var idScopedCommands = map[string]bool{
+	"update":    true,
+	"label":     true,
+	"comment":   true,
+	"comments":  true,
+	"dep":       true,
+	"set-state": true,
+	"slot":      true,
+	"agent":     true,
+}
+
+// EnableIssueCache turns on caching of Show and ShowMultiple results for
+// the rest of the process, with entries expiring after ttl. Calling it
+// again changes the TTL and keeps existing entries.
+func EnableIssueCache(ttl time.Duration) {
+	showCache.mu.Lock()
+	defer showCache.mu.Unlock()
+	showCache.ttl = ttl
+	if showCache.entries == nil {
+		showCache.entries = make(map[string]issueCacheEntry)
+	}
+}
+
+// DisableIssueCache turns the cache off and drops its entries.
+func DisableIssueCache() {
+	showCache.mu.Lock()
+	defer showCache.mu.Unlock()
+	showCache.ttl = 0
+	showCache.entries = nil
+}
+
+// InvalidateIssues drops the given beads from the cache. Callers that change
+// beads without going through Beads (e.g. running bd directly) use it to
+// keep later reads in this process fresh.
+func InvalidateIssues(ids ...string) {
+	showCache.mu.Lock()
+	defer showCache.mu.Unlock()
+	for _, id := range ids {
+		delete(showCache.entries, id)
+	}
+}
+
+// InvalidateAllIssues empties the cache, leaving it enabled.
+func InvalidateAllIssues() {
+	showCache.mu.Lock()
+	defer showCache.mu.Unlock()
+	if showCache.entries != nil {
+		showCache.entries = make(map[string]issueCacheEntry)
+	}
+}
+
+// get returns a copy of the cached issue, if it is present and fresh.
+func (c *issueCache) get(id string) (*Issue, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.ttl <= 0 {
+		return nil, false
+	}
+	e, ok := c.entries[id]
+	if !ok {
+		return nil, false
+	}
+	if c.now().Sub(e.at) >= c.ttl {
+		delete(c.entries, id)
+		return nil, false
+	}
+	return copyIssue(e.issue), true
+}
+
+// put caches a copy of issue. Callers keep theirs to modify freely.
+func (c *issueCache) put(issue *Issue) {
+	if issue == nil || issue.ID == "" {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.ttl <= 0 {
+		return
+	}
+	c.entries[issue.ID] = issueCacheEntry{issue: copyIssue(issue), at: c.now()}
+}
+
+// copyIssue returns a deep copy of issue, so neither the cache nor its
+// callers see each other's changes to the slice fields.
+func copyIssue(issue *Issue) *Issue {
+	cp := *issue
+	cp.Children = slices.Clone(issue.Children)
+	cp.DependsOn = slices.Clone(issue.DependsOn)
+	cp.Blocks = slices.Clone(issue.Blocks)
+	cp.BlockedBy = slices.Clone(issue.BlockedBy)
+	cp.Labels = slices.Clone(issue.Labels)
+	cp.Dependencies = slices.Clone(issue.Dependencies)
+	cp.Dependents = slices.Clone(issue.Dependents)
+	return &cp
+}
+
+// invalidateFor drops whatever a bd command may have changed: nothing for a
+// read, the named beads for an ID-scoped mutation, everything otherwise.
+func (c *issueCache) invalidateFor(args []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.entries) == 0 {
+		return
+	}
+	var sub string
+	for _, a := range args {
+		if !strings.HasPrefix(a, "-") {
+			sub = a
+			break
+		}
+	}
+	switch {
+	case readOnlyCommands[sub]:
+	case idScopedCommands[sub]:
+		for _, a := range args {
+			if !strings.HasPrefix(a, "-") {
+				delete(c.entries, a)
+			}
+			// --flag=<id> forms, e.g. dep add --blocked-by=<id>
+			if _, v, ok := strings.Cut(a, "="); ok {
+				delete(c.entries, v)
+			}
+		}
+	default:
+		c.entries = make(map[string]issueCacheEntry)
+	}
+}
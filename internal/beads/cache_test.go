@@ -0,0 +1,141 @@
+package beads
+
+import (
+	"testing"
+	"time"
+)
+
+func newTestIssueCache(ttl time.Duration) (*issueCache, *time.Time) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	c := &issueCache{ttl: ttl, entries: make(map[string]issueCacheEntry)}
+	c.now = func() time.Time { return now }
+	return c, &now
+}
+
+func TestIssueCache_TTL(t *testing.T) {
+	c, now := newTestIssueCache(2 * time.Second)
+	c.put(&Issue{ID: "gt-abc", Title: "first"})
+
+	got, ok := c.get("gt-abc")
+	if !ok || got.Title != "first" {
+		t.Fatalf("get() = %v, %v; want cached issue", got, ok)
+	}
+
+	*now = now.Add(2 * time.Second)
+	if _, ok := c.get("gt-abc"); ok {
+		t.Error("get() returned an entry at its TTL")
+	}
+}
+
+func TestIssueCache_Disabled(t *testing.T) {
+	c, _ := newTestIssueCache(0)
+	c.put(&Issue{ID: "gt-abc"})
+	if _, ok := c.get("gt-abc"); ok {
+		t.Error("disabled cache returned an entry")
+	}
+}
+
+func TestIssueCache_ReturnsCopies(t *testing.T) {
+	c, _ := newTestIssueCache(time.Minute)
+	orig := &Issue{ID: "gt-abc", Status: "open"}
+	c.put(orig)
+	orig.Status = "closed"
+
+	got, _ := c.get("gt-abc")
+	if got.Status != "open" {
+		t.Errorf("caller's change leaked into cache: status = %q", got.Status)
+	}
+	got.Status = "hooked"
+	again, _ := c.get("gt-abc")
+	if again.Status != "open" {
+		t.Errorf("returned copy's change leaked into cache: status = %q", again.Status)
+	}
+}
+
+func TestIssueCache_CopiesSlices(t *testing.T) {
+	c, _ := newTestIssueCache(time.Minute)
+	orig := &Issue{
+		ID:           "gt-abc",
+		Labels:       []string{"gt:task"},
+		DependsOn:    []string{"gt-dep"},
+		Dependencies: []IssueDep{{ID: "gt-dep", Status: "open"}},
+	}
+	c.put(orig)
+	orig.Labels[0] = "changed"
+	orig.DependsOn[0] = "changed"
+	orig.Dependencies[0].Status = "closed"
+
+	got, _ := c.get("gt-abc")
+	if got.Labels[0] != "gt:task" || got.DependsOn[0] != "gt-dep" || got.Dependencies[0].Status != "open" {
+		t.Errorf("caller's slice change leaked into cache: %+v", got)
+	}
+	got.Labels[0] = "changed"
+	got.Dependencies[0].Status = "closed"
+	again, _ := c.get("gt-abc")
+	if again.Labels[0] != "gt:task" || again.Dependencies[0].Status != "open" {
+		t.Errorf("returned copy's slice change leaked into cache: %+v", again)
+	}
+}
+
+func TestIssueCache_InvalidateFor(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		kept []string // IDs still cached afterwards
+	}{
+		{"read", []string{"show", "gt-a", "--json"}, []string{"gt-a", "gt-b", "gt-c"}},
+		{"list", []string{"list", "--status=open", "--json"}, []string{"gt-a", "gt-b", "gt-c"}},
+		{"update", []string{"update", "gt-a", "--status=hooked"}, []string{"gt-b", "gt-c"}},
+		{"dep", []string{"dep", "add", "gt-a", "gt-b"}, []string{"gt-c"}},
+		{"flag value", []string{"dep", "add", "gt-a", "--blocked-by=gt-c"}, []string{"gt-b"}},
+		{"leading flag", []string{"--no-daemon", "update", "gt-b"}, []string{"gt-a", "gt-c"}},
+		{"close", []string{"close", "gt-a"}, nil},
+		{"create", []string{"create", "--json", "--title=x"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := newTestIssueCache(time.Minute)
+			for _, id := range []string{"gt-a", "gt-b", "gt-c"} {
+				c.put(&Issue{ID: id})
+			}
+			c.invalidateFor(tt.args)
+			if len(c.entries) != len(tt.kept) {
+				t.Errorf("%d entries left, want %v", len(c.entries), tt.kept)
+			}
+			for _, id := range tt.kept {
+				if _, ok := c.get(id); !ok {
+					t.Errorf("%s was invalidated", id)
+				}
+			}
+		})
+	}
+}
+
+func TestShowMultiple_ServedFromCache(t *testing.T) {
+	EnableIssueCache(time.Minute)
+	defer DisableIssueCache()
+	showCache.put(&Issue{ID: "gt-a", Title: "a"})
+	showCache.put(&Issue{ID: "gt-b", Title: "b"})
+
+	// All hits: no bd call is made, so this works without bd or a database.
+	b := New(t.TempDir())
+	got, err := b.ShowMultiple([]string{"gt-a", "gt-b"})
+	if err != nil {
+		t.Fatalf("ShowMultiple: %v", err)
+	}
+	if len(got) != 2 || got["gt-a"].Title != "a" || got["gt-b"].Title != "b" {
+		t.Errorf("ShowMultiple = %v", got)
+	}
+	if issue, err := b.Show("gt-a"); err != nil || issue.Title != "a" {
+		t.Errorf("Show = %v, %v", issue, err)
+	}
+
+	InvalidateIssues("gt-a")
+	if _, ok := showCache.get("gt-a"); ok {
+		t.Error("InvalidateIssues left gt-a cached")
+	}
+	InvalidateAllIssues()
+	if _, ok := showCache.get("gt-b"); ok {
+		t.Error("InvalidateAllIssues left gt-b cached")
+	}
+}
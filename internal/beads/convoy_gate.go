@@ -0,0 +1,147 @@
+package beads
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+)
+
+// ConvoyMemberMissing is the Status of a convoy member whose bead can't be
+// found.
+const ConvoyMemberMissing = "missing"
+
+// ConvoyMember is one bead tracked by a convoy, with the merge request
+// queued for it, if any.
+type ConvoyMember struct {
+	ID     string `json:"id"`
+	Title  string `json:"title"`
+	Status string `json:"status"`
+	MR     string `json:"mr,omitempty"` // Open merge request for this member
+}
+
+// Ready reports whether the member is ready to land: its work is closed, or
+// waiting in a merge queue.
+func (m ConvoyMember) Ready() bool {
+	return m.Status == "closed" || m.MR != ""
+}
+
+// ConvoyGate is a convoy's members and whether their merges are held until
+// all of them are ready (see ConvoyGateMerge).
+type ConvoyGate struct {
+	ConvoyID string         `json:"convoy"`
+	Gated    bool           `json:"gated"`
+	Members  []ConvoyMember `json:"members"`
+}
+
+// Waiting returns the members that are not ready yet.
+func (g *ConvoyGate) Waiting() []string {
+	var ids []string
+	for _, m := range g.Members {
+		if !m.Ready() {
+			ids = append(ids, m.ID)
+		}
+	}
+	return ids
+}
+
+// Missing returns the members that can't be found in any beads database.
+// They can never become ready, so a gated convoy with missing members holds
+// until someone untracks them or ungates the convoy.
+func (g *ConvoyGate) Missing() []string {
+	var ids []string
+	for _, m := range g.Members {
+		if m.Status == ConvoyMemberMissing {
+			ids = append(ids, m.ID)
+		}
+	}
+	return ids
+}
+
+// Open reports whether members' merges may proceed: the convoy isn't gated,
+// or every member is ready.
+func (g *ConvoyGate) Open() bool {
+	return !g.Gated || len(g.Waiting()) == 0
+}
+
+// ConvoyGate loads a convoy from these (town) beads, then its members from
+// whichever rig databases they live in, and the merge requests queued for
+// them in those rigs.
+func (b *Beads) ConvoyGate(convoyID string) (*ConvoyGate, error) {
+	convoy, err := b.Show(convoyID)
+	if err != nil {
+		return nil, err
+	}
+	gate := &ConvoyGate{ConvoyID: convoyID}
+	if fields := ParseConvoyFields(convoy); fields != nil {
+		gate.Gated = fields.Gate == ConvoyGateMerge
+	}
+
+	out, err := b.run("dep", "list", convoyID, "--direction=down", "--type=tracks", "--json")
+	if err != nil {
+		return nil, fmt.Errorf("listing members of %s: %w", convoyID, err)
+	}
+	var tracked []struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(out, &tracked); err != nil {
+		return nil, fmt.Errorf("parsing members of %s: %w", convoyID, err)
+	}
+	ids := make([]string, 0, len(tracked))
+	for _, t := range tracked {
+		ids = append(ids, ExtractIssueID(t.ID))
+	}
+	sort.Strings(ids)
+
+	// Member status lives in the rig databases; the tracks edge in town
+	// beads only has a stale copy.
+	issues, err := b.ShowMany(ids)
+	if err != nil {
+		return nil, fmt.Errorf("getting members of %s: %w", convoyID, err)
+	}
+	mrs, err := b.convoyMemberMRs(ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		m := ConvoyMember{ID: id, Status: ConvoyMemberMissing, MR: mrs[id]}
+		if issue := issues[id]; issue != nil {
+			m.Title = issue.Title
+			m.Status = issue.Status
+		}
+		gate.Members = append(gate.Members, m)
+	}
+	return gate, nil
+}
+
+// convoyMemberMRs finds the open merge request for each member, looking in
+// the merge queue of each rig that holds a member.
+func (b *Beads) convoyMemberMRs(ids []string) (map[string]string, error) {
+	wanted := make(map[string]bool, len(ids))
+	dirs := make(map[string]bool)
+	ownDir := b.getResolvedBeadsDir()
+	for _, id := range ids {
+		wanted[id] = true
+		dirs[ResolveRoutingTarget(b.getTownRoot(), id, ownDir)] = true
+	}
+
+	mrs := make(map[string]string)
+	for dir := range dirs {
+		queue, err := NewWithBeadsDir(filepath.Dir(dir), dir).List(ListOptions{
+			Status:   "open",
+			Label:    "gt:merge-request",
+			Priority: -1,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("listing merge requests in %s: %w", dir, err)
+		}
+		for _, mr := range queue {
+			fields := ParseMRFields(mr)
+			if fields == nil || !wanted[fields.SourceIssue] || mr.Status != "open" {
+				continue
+			}
+			mrs[fields.SourceIssue] = mr.ID
+		}
+	}
+	return mrs, nil
+}
@@ -0,0 +1,43 @@
+package beads
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestConvoyGateOpen(t *testing.T) {
+	gate := &ConvoyGate{ConvoyID: "hq-cv-abc", Members: []ConvoyMember{
+		{ID: "gt-api", Status: "in_progress", MR: "gt-mr1"},
+		{ID: "bd-client", Status: "in_progress"},
+		{ID: "bd-docs", Status: "closed"},
+	}}
+	if !gate.Open() {
+		t.Error("ungated convoy is held")
+	}
+
+	gate.Gated = true
+	if gate.Open() {
+		t.Error("gated convoy with an unready member is open")
+	}
+	if got := gate.Waiting(); !slices.Equal(got, []string{"bd-client"}) {
+		t.Errorf("Waiting() = %v, want [bd-client]", got)
+	}
+
+	gate.Members[1].MR = "bd-mr7"
+	if !gate.Open() || len(gate.Waiting()) != 0 {
+		t.Errorf("gate with every member queued or closed: Open() = %v, Waiting() = %v", gate.Open(), gate.Waiting())
+	}
+}
+
+func TestConvoyGateMissing(t *testing.T) {
+	gate := &ConvoyGate{ConvoyID: "hq-cv-abc", Gated: true, Members: []ConvoyMember{
+		{ID: "gt-api", Status: "closed"},
+		{ID: "bd-client", Status: ConvoyMemberMissing},
+	}}
+	if got := gate.Missing(); !slices.Equal(got, []string{"bd-client"}) {
+		t.Errorf("Missing() = %v, want [bd-client]", got)
+	}
+	if gate.Open() {
+		t.Error("gate with a missing member is open")
+	}
+}
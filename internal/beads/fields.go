@@ -4,6 +4,7 @@ package beads
 import (
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 )
 
@@ -224,12 +225,20 @@ func SetAttachmentFields(issue *Issue, fields *AttachmentFields) string {
 // ConvoyFields holds the structured fields for a convoy bead.
 // These fields are stored as key: value lines in the issue description.
 type ConvoyFields struct {
-	Owner      string // Convoy owner address (e.g., "mayor/")
-	Notify     string // Additional notification address
-	Molecule   string // Associated molecule/swarm ID
-	Merge      string // Merge strategy
+	Owner     string // Convoy owner address (e.g., "mayor/")
+	Notify    string // Additional notification address
+	Molecule  string // Associated molecule/swarm ID
+	Merge     string // Merge strategy
+	MaxActive int    // Max tasks in flight at once; 0 = unthrottled
+	Gate      string // ConvoyGateMerge holds members' merges until all are ready
 }
 
+// ConvoyGateMerge is the convoy Gate value that holds every member's merge
+// request in its rig's queue until all members are ready to land, so
+// coordinated changes across rigs (an API change and its clients) merge
+// together.
+const ConvoyGateMerge = "merge"
+
 // ParseConvoyFields extracts convoy fields from an issue's description.
 // Returns nil if no convoy fields found.
 func ParseConvoyFields(issue *Issue) *ConvoyFields {
@@ -270,6 +279,14 @@ func ParseConvoyFields(issue *Issue) *ConvoyFields {
 		case "merge":
 			fields.Merge = value
 			hasFields = true
+		case "max-active":
+			if n, err := strconv.Atoi(value); err == nil && n > 0 {
+				fields.MaxActive = n
+				hasFields = true
+			}
+		case "gate":
+			fields.Gate = value
+			hasFields = true
 		}
 	}
 
@@ -315,6 +332,12 @@ func FormatConvoyFields(fields *ConvoyFields) string {
 	if fields.Molecule != "" {
 		lines = append(lines, "Molecule: "+fields.Molecule)
 	}
+	if fields.MaxActive > 0 {
+		lines = append(lines, "Max-Active: "+strconv.Itoa(fields.MaxActive))
+	}
+	if fields.Gate != "" {
+		lines = append(lines, "Gate: "+fields.Gate)
+	}
 
 	return strings.Join(lines, "\n")
 }
@@ -329,10 +352,12 @@ func SetConvoyFields(issue *Issue, fields *ConvoyFields) string {
 
 	// Known convoy field keys (lowercase)
 	convoyKeys := map[string]bool{
-		"owner":    true,
-		"notify":   true,
-		"merge":    true,
-		"molecule": true,
+		"owner":      true,
+		"notify":     true,
+		"merge":      true,
+		"molecule":   true,
+		"max-active": true,
+		"gate":       true,
 	}
 
 	// Collect non-convoy lines from existing description
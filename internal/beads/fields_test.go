@@ -291,6 +291,11 @@ func TestFormatConvoyFields(t *testing.T) {
 			fields: &ConvoyFields{Merge: "mr"},
 			want:   "Merge: mr",
 		},
+		{
+			name:   "throttled",
+			fields: &ConvoyFields{Owner: "mayor/", MaxActive: 4},
+			want:   "Owner: mayor/\nMax-Active: 4",
+		},
 	}
 
 	for _, tt := range tests {
@@ -403,6 +408,41 @@ func TestSetConvoyFieldsWithMixedContent(t *testing.T) {
 	}
 }
 
+func TestConvoyMaxActiveRoundTrip(t *testing.T) {
+	issue := &Issue{Description: "Convoy tracking 12 issues\nOwner: mayor/\nMax-Active: 3"}
+	fields := ParseConvoyFields(issue)
+	if fields == nil || fields.MaxActive != 3 {
+		t.Fatalf("ParseConvoyFields MaxActive = %+v, want 3", fields)
+	}
+
+	// Lifting the throttle drops the line.
+	fields.MaxActive = 0
+	got := SetConvoyFields(issue, fields)
+	if strings.Contains(got, "Max-Active") {
+		t.Errorf("Max-Active not removed, got:\n%s", got)
+	}
+
+	// Non-positive or malformed values are ignored.
+	if f := ParseConvoyFields(&Issue{Description: "Max-Active: lots"}); f != nil {
+		t.Errorf("malformed Max-Active parsed as %+v", f)
+	}
+}
+
+func TestConvoyGateRoundTrip(t *testing.T) {
+	issue := &Issue{Description: "Convoy tracking 2 issues\nOwner: mayor/\nGate: merge"}
+	fields := ParseConvoyFields(issue)
+	if fields == nil || fields.Gate != ConvoyGateMerge {
+		t.Fatalf("ParseConvoyFields Gate = %+v, want %q", fields, ConvoyGateMerge)
+	}
+
+	// Ungating drops the line and keeps the rest.
+	fields.Gate = ""
+	got := SetConvoyFields(issue, fields)
+	if strings.Contains(got, "Gate") || !strings.Contains(got, "Owner: mayor/") {
+		t.Errorf("SetConvoyFields after ungating = %q", got)
+	}
+}
+
 // --- ParseAgentFields (not covered in beads_test.go) ---
 
 func TestParseAgentFields_AllFields(t *testing.T) {
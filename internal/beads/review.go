@@ -0,0 +1,141 @@
+package beads
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// reviewLabelPrefix starts the label marking work that awaits review before
+// it may merge; the rest of the label is the reviewing role.
+const reviewLabelPrefix = "review:"
+
+// ReviewLabel is the label marking an issue as awaiting review by role
+// (e.g., "review:witness").
+func ReviewLabel(role string) string {
+	return reviewLabelPrefix + role
+}
+
+// PendingReviews returns the roles an issue still awaits review from.
+func PendingReviews(issue *Issue) []string {
+	var roles []string
+	for _, l := range issue.Labels {
+		if role, ok := strings.CutPrefix(l, reviewLabelPrefix); ok && role != "" {
+			roles = append(roles, role)
+		}
+	}
+	return roles
+}
+
+// LabelReview marks a review bead: a request for a witness to review an
+// integration branch before the refinery lands it.
+const LabelReview = "gt:review"
+
+// Review verdicts, stored on a review bead as a "verdict:<verdict>" label
+// when the reviewer responds. A review bead without one is pending.
+const (
+	ReviewPending          = "pending"
+	ReviewApproved         = "approved"
+	ReviewChangesRequested = "changes-requested"
+)
+
+const verdictLabelPrefix = "verdict:"
+
+// VerdictLabel is the label recording a review verdict.
+func VerdictLabel(verdict string) string {
+	return verdictLabelPrefix + verdict
+}
+
+// ReviewFields are the structured fields of a review bead. They lead the
+// description; the diff under review follows them.
+type ReviewFields struct {
+	Epic   string // Epic whose integration branch is under review
+	Branch string // Integration branch
+	Target string // Branch it lands on
+	Head   string // Branch commit reviewed; a newer head needs a new review
+}
+
+// ParseReviewFields extracts the fields of a review bead, stopping at the
+// diff so diff lines are never mistaken for fields. Returns nil if there
+// are none.
+func ParseReviewFields(issue *Issue) *ReviewFields {
+	if issue == nil || issue.Description == "" {
+		return nil
+	}
+	fields := &ReviewFields{}
+	hasFields := false
+	for _, line := range strings.Split(issue.Description, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "```") {
+			break
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		switch strings.ToLower(strings.TrimSpace(key)) {
+		case "epic":
+			fields.Epic = value
+		case "branch":
+			fields.Branch = value
+		case "target":
+			fields.Target = value
+		case "head":
+			fields.Head = value
+		default:
+			continue
+		}
+		hasFields = true
+	}
+	if !hasFields {
+		return nil
+	}
+	return fields
+}
+
+// FormatReviewFields renders a review bead's description: its fields, then
+// the diff stat and diff in fenced blocks.
+func FormatReviewFields(fields *ReviewFields, stat, diff string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "epic: %s\n", fields.Epic)
+	fmt.Fprintf(&b, "branch: %s\n", fields.Branch)
+	fmt.Fprintf(&b, "target: %s\n", fields.Target)
+	fmt.Fprintf(&b, "head: %s\n", fields.Head)
+	if stat != "" {
+		fmt.Fprintf(&b, "\n```\n%s\n```\n", stat)
+	}
+	if diff != "" {
+		fmt.Fprintf(&b, "\n```diff\n%s\n```\n", diff)
+	}
+	return b.String()
+}
+
+// ReviewVerdict returns a review bead's verdict, or ReviewPending.
+func ReviewVerdict(issue *Issue) string {
+	for _, l := range issue.Labels {
+		if v, ok := strings.CutPrefix(l, verdictLabelPrefix); ok && v != "" {
+			return v
+		}
+	}
+	return ReviewPending
+}
+
+// LatestReview returns the most recent review bead for an epic's
+// integration branch and its fields, or nil if none was ever requested.
+func (b *Beads) LatestReview(epicID string) (*Issue, *ReviewFields, error) {
+	reviews, err := b.List(ListOptions{Status: "all", Label: LabelReview, Priority: -1})
+	if err != nil {
+		return nil, nil, fmt.Errorf("listing reviews: %w", err)
+	}
+	sort.SliceStable(reviews, func(i, j int) bool { return reviews[i].CreatedAt > reviews[j].CreatedAt })
+	for _, r := range reviews {
+		if fields := ParseReviewFields(r); fields != nil && fields.Epic == epicID {
+			return r, fields, nil
+		}
+	}
+	return nil, nil, nil
+}
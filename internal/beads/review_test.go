@@ -0,0 +1,34 @@
+package beads
+
+import "testing"
+
+func TestReviewFieldsRoundTrip(t *testing.T) {
+	in := &ReviewFields{Epic: "gt-epic", Branch: "integration/gt-epic", Target: "main", Head: "abc123"}
+	// The diff has lines that look like fields; they must not override.
+	diff := "--- a/config.yaml\n+++ b/config.yaml\n+branch: not-a-field\n+epic: nope"
+	issue := &Issue{Description: FormatReviewFields(in, " config.yaml | 2 ++", diff)}
+
+	got := ParseReviewFields(issue)
+	if got == nil || *got != *in {
+		t.Fatalf("ParseReviewFields = %+v, want %+v", got, in)
+	}
+	if ParseReviewFields(&Issue{Description: "just prose"}) != nil {
+		t.Error("prose description should have no review fields")
+	}
+}
+
+func TestReviewVerdictAndPendingReviews(t *testing.T) {
+	review := &Issue{Labels: []string{LabelReview}}
+	if v := ReviewVerdict(review); v != ReviewPending {
+		t.Errorf("verdict = %q, want %q", v, ReviewPending)
+	}
+	review.Labels = append(review.Labels, VerdictLabel(ReviewChangesRequested))
+	if v := ReviewVerdict(review); v != ReviewChangesRequested {
+		t.Errorf("verdict = %q, want %q", v, ReviewChangesRequested)
+	}
+
+	work := &Issue{Labels: []string{"security", ReviewLabel("witness"), "review:"}}
+	if roles := PendingReviews(work); len(roles) != 1 || roles[0] != "witness" {
+		t.Errorf("PendingReviews = %v, want [witness]", roles)
+	}
+}
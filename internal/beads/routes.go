@@ -47,7 +47,7 @@ func LoadRoutes(beadsDir string) ([]Route, error) {
 
 		var route Route
 		if err := json.Unmarshal([]byte(line), &route); err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: skipping malformed route at %s:%d: %v\n", routesPath, lineNum, err)
+			logger.Warn("skipping malformed route", "path", routesPath, "line", lineNum, "err", err)
 			continue
 		}
 		if route.Prefix != "" && route.Path != "" {
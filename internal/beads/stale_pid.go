@@ -1,7 +1,6 @@
 package beads
 
 import (
-	"fmt"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -41,6 +40,6 @@ func CleanStaleDoltServerPID(beadsDir string) {
 	if err := proc.Signal(syscall.Signal(0)); err != nil {
 		// Process is dead — remove stale PID file
 		_ = os.Remove(pidPath)
-		fmt.Fprintf(os.Stderr, "Cleaned stale dolt-server.pid (PID %d) from %s\n", pid, beadsDir)
+		logger.Info("cleaned stale dolt-server.pid", "pid", pid, "beads_dir", beadsDir)
 	}
 }
@@ -0,0 +1,418 @@
+// Package beadwatch turns bead mutations into events. A Watcher watches the
+// town's beads databases for writes (fsnotify on each .beads directory and
+// the Dolt data directories, with a slow poll as a backstop), diffs each
+// database's beads against its last snapshot, and reports what was created,
+// changed, closed, reopened, or deleted, so dashboards and the dispatcher
+// can react without polling bd themselves.
+package beadwatch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/events"
+)
+
+// Change kinds.
+const (
+	Created  = "created"
+	Updated  = "updated" // Title, assignee, or anything else; status unchanged
+	Status   = "status"  // Status changed, other than closing or reopening
+	Closed   = "closed"
+	Reopened = "reopened"
+	Deleted  = "deleted"
+)
+
+// DefaultDebounce is how long a Watcher waits after a write for further
+// writes before rescanning. One gt command often makes several bd calls.
+const DefaultDebounce = 500 * time.Millisecond
+
+// DefaultInterval is how often every source is rescanned regardless of
+// writes, to catch changes the file watch missed.
+const DefaultInterval = 5 * time.Minute
+
+// TownSource is the name of the town-level (hq) beads source.
+const TownSource = "town"
+
+// Change is one bead mutation.
+type Change struct {
+	Source     string `json:"source"` // TownSource or a rig name
+	Bead       string `json:"bead"`
+	Kind       string `json:"change"`
+	Status     string `json:"status"`
+	PrevStatus string `json:"prev_status,omitempty"` // Set for Status, Closed, Reopened
+	Title      string `json:"title"`
+	Assignee   string `json:"assignee,omitempty"`
+}
+
+// MayFreeWork reports whether the change could make work ready to dispatch:
+// a new or reopened bead, a bead back to open, or a close that may unblock
+// dependents.
+func (c Change) MayFreeWork() bool {
+	switch c.Kind {
+	case Created, Reopened, Closed:
+		return true
+	case Status:
+		return c.Status == "open"
+	}
+	return false
+}
+
+// Source is one beads database to watch.
+type Source struct {
+	Name    string   // TownSource or a rig name
+	WorkDir string   // Directory the database is opened from
+	Dirs    []string // Directories whose writes signal a change
+}
+
+// Sources returns the town's beads databases: the town's and each rig's.
+// A source whose metadata.json names its Dolt database watches only that
+// database's directory, so a write rescans just the one source; Dolt
+// directories no source claims are shared by the sources that name none.
+func Sources(townRoot string) ([]Source, error) {
+	rigs, err := config.LoadRigsConfig(filepath.Join(townRoot, "mayor", "rigs.json"))
+	if err != nil {
+		return nil, err
+	}
+	doltDirs, _ := filepath.Glob(filepath.Join(townRoot, ".dolt-data", "*", ".dolt", "noms"))
+	nomsDir := func(db string) string {
+		return filepath.Join(townRoot, ".dolt-data", db, ".dolt", "noms")
+	}
+
+	sources := []Source{{Name: TownSource, WorkDir: townRoot}}
+	names := make([]string, 0, len(rigs.Rigs))
+	for name := range rigs.Rigs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		sources = append(sources, Source{Name: name, WorkDir: filepath.Join(townRoot, name, "mayor", "rig")})
+	}
+	claimed := make(map[string]bool)
+	var unmapped []int
+	for i := range sources {
+		beadsDir := beads.ResolveBeadsDir(sources[i].WorkDir)
+		sources[i].Dirs = []string{beadsDir}
+		if db := doltDatabase(beadsDir); db != "" {
+			sources[i].Dirs = append(sources[i].Dirs, nomsDir(db))
+			claimed[nomsDir(db)] = true
+		} else {
+			unmapped = append(unmapped, i)
+		}
+	}
+	for _, i := range unmapped {
+		for _, dir := range doltDirs {
+			if !claimed[dir] {
+				sources[i].Dirs = append(sources[i].Dirs, dir)
+			}
+		}
+	}
+	return sources, nil
+}
+
+// doltDatabase returns the dolt_database named in a beads directory's
+// metadata.json, or "" if there is none.
+func doltDatabase(beadsDir string) string {
+	data, err := os.ReadFile(filepath.Join(beadsDir, "metadata.json"))
+	if err != nil {
+		return ""
+	}
+	var meta struct {
+		DoltDatabase string `json:"dolt_database"`
+	}
+	if json.Unmarshal(data, &meta) != nil {
+		return ""
+	}
+	return meta.DoltDatabase
+}
+
+// Lister lists every bead in a database, open or closed.
+type Lister func(workDir string) ([]*beads.Issue, error)
+
+// ListAll is the default Lister.
+func ListAll(workDir string) ([]*beads.Issue, error) {
+	return beads.New(workDir).List(beads.ListOptions{Status: "all", Priority: -1})
+}
+
+// beadState is what a snapshot keeps of a bead to tell what changed.
+type beadState struct {
+	Status, Title, Assignee, UpdatedAt string
+}
+
+// Watcher reports bead mutations across a set of sources.
+type Watcher struct {
+	// Debounce is how long to wait after a write for more before rescanning.
+	Debounce time.Duration
+	// Interval is how often every source is rescanned anyway; 0 disables.
+	Interval time.Duration
+	// OnError, if set, is called when a scan or the file watch fails. The
+	// source's previous snapshot is kept, so a failed scan reports nothing.
+	OnError func(error)
+	// Actor is the actor of the bead_changed events logged when LogEvents
+	// is set.
+	Actor string
+	// LogEvents logs a bead_changed event for each change. Only one
+	// watcher per town should, or the feed sees each change twice.
+	LogEvents bool
+	// List lists a source's beads. Defaults to ListAll.
+	List Lister
+
+	sources []Source
+
+	mu     sync.Mutex
+	snaps  map[string]map[string]beadState // Source name -> bead ID -> state
+	subs   map[int]func([]Change)
+	nextID int
+}
+
+// New returns a watcher for the given sources. Call Prime, then Run.
+func New(sources []Source) *Watcher {
+	return &Watcher{
+		Debounce: DefaultDebounce,
+		Interval: DefaultInterval,
+		Actor:    "gt",
+		List:     ListAll,
+		sources:  sources,
+		snaps:    make(map[string]map[string]beadState),
+		subs:     make(map[int]func([]Change)),
+	}
+}
+
+// Subscribe calls fn with each batch of changes a scan finds. fn runs on the
+// watcher's goroutine, so long work should be handed off. The returned
+// function unsubscribes.
+func (w *Watcher) Subscribe(fn func([]Change)) (unsubscribe func()) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	id := w.nextID
+	w.nextID++
+	w.subs[id] = fn
+	return func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		delete(w.subs, id)
+	}
+}
+
+// Prime snapshots every source without reporting anything, so the first
+// scan reports only what changed since. Sources that fail to list are
+// retried (silently) by the next scan.
+func (w *Watcher) Prime() error {
+	var errs []error
+	for _, src := range w.sources {
+		issues, err := w.List(src.WorkDir)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", src.Name, err))
+			continue
+		}
+		w.mu.Lock()
+		w.snaps[src.Name] = snapshot(issues)
+		w.mu.Unlock()
+	}
+	return errors.Join(errs...)
+}
+
+// Scan rescans the named sources (all of them if none are named), reports
+// their changes to subscribers, logs them if LogEvents is set, and returns
+// them.
+func (w *Watcher) Scan(names ...string) ([]Change, error) {
+	var changes []Change
+	var errs []error
+	for _, src := range w.sources {
+		if len(names) > 0 && !contains(names, src.Name) {
+			continue
+		}
+		issues, err := w.List(src.WorkDir)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", src.Name, err))
+			continue
+		}
+		next := snapshot(issues)
+		w.mu.Lock()
+		prev, primed := w.snaps[src.Name]
+		w.snaps[src.Name] = next
+		w.mu.Unlock()
+		if primed {
+			changes = append(changes, diff(src.Name, prev, next)...)
+		}
+	}
+	if len(changes) > 0 {
+		w.publish(changes)
+	}
+	return changes, errors.Join(errs...)
+}
+
+func (w *Watcher) publish(changes []Change) {
+	if w.LogEvents {
+		for _, c := range changes {
+			_ = events.LogFeed(events.TypeBeadChanged, w.Actor,
+				events.BeadChangedPayload(c.Source, c.Bead, c.Kind, c.Status, c.PrevStatus, c.Title, c.Assignee))
+		}
+	}
+	w.mu.Lock()
+	subs := make([]func([]Change), 0, len(w.subs))
+	for _, fn := range w.subs {
+		subs = append(subs, fn)
+	}
+	w.mu.Unlock()
+	for _, fn := range subs {
+		fn(changes)
+	}
+}
+
+// Run watches the sources' directories and rescans a source after writes
+// to it, and every source each Interval, until ctx is done.
+func (w *Watcher) Run(ctx context.Context) error {
+	fw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("watching beads: %w", err)
+	}
+	defer fw.Close()
+	owners := w.watchDirs(fw)
+
+	debounce := time.NewTimer(time.Hour)
+	debounce.Stop()
+	defer debounce.Stop()
+	var poll <-chan time.Time
+	if w.Interval > 0 {
+		ticker := time.NewTicker(w.Interval)
+		defer ticker.Stop()
+		poll = ticker.C
+	}
+	pending := make(map[string]bool)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case ev, ok := <-fw.Events:
+			if !ok {
+				return nil
+			}
+			for _, name := range owners[filepath.Dir(ev.Name)] {
+				pending[name] = true
+			}
+			if len(pending) > 0 {
+				debounce.Reset(w.Debounce)
+			}
+
+		case err, ok := <-fw.Errors:
+			if !ok {
+				return nil
+			}
+			w.reportError(err)
+
+		case <-debounce.C:
+			names := make([]string, 0, len(pending))
+			for name := range pending {
+				names = append(names, name)
+			}
+			pending = make(map[string]bool)
+			if _, err := w.Scan(names...); err != nil {
+				w.reportError(err)
+			}
+
+		case <-poll:
+			if _, err := w.Scan(); err != nil {
+				w.reportError(err)
+			}
+			// A beads directory created since Run started is picked up here.
+			owners = w.watchDirs(fw)
+		}
+	}
+}
+
+// watchDirs adds a watch on each source directory that exists and returns
+// which sources each directory belongs to.
+func (w *Watcher) watchDirs(fw *fsnotify.Watcher) map[string][]string {
+	owners := make(map[string][]string)
+	for _, src := range w.sources {
+		for _, dir := range src.Dirs {
+			dir = filepath.Clean(dir)
+			if _, err := os.Stat(dir); err != nil {
+				continue
+			}
+			if _, seen := owners[dir]; !seen {
+				if err := fw.Add(dir); err != nil {
+					w.reportError(err)
+					continue
+				}
+			}
+			owners[dir] = append(owners[dir], src.Name)
+		}
+	}
+	return owners
+}
+
+func (w *Watcher) reportError(err error) {
+	if w.OnError != nil {
+		w.OnError(err)
+	}
+}
+
+func snapshot(issues []*beads.Issue) map[string]beadState {
+	snap := make(map[string]beadState, len(issues))
+	for _, issue := range issues {
+		snap[issue.ID] = beadState{
+			Status:    issue.Status,
+			Title:     issue.Title,
+			Assignee:  issue.Assignee,
+			UpdatedAt: issue.UpdatedAt,
+		}
+	}
+	return snap
+}
+
+// diff returns the changes from prev to next, in bead ID order.
+func diff(source string, prev, next map[string]beadState) []Change {
+	var changes []Change
+	for id, now := range next {
+		c := Change{Source: source, Bead: id, Status: now.Status, Title: now.Title, Assignee: now.Assignee}
+		before, existed := prev[id]
+		switch {
+		case !existed:
+			c.Kind = Created
+		case before.Status != now.Status:
+			c.PrevStatus = before.Status
+			switch {
+			case now.Status == "closed":
+				c.Kind = Closed
+			case before.Status == "closed":
+				c.Kind = Reopened
+			default:
+				c.Kind = Status
+			}
+		case before != now:
+			c.Kind = Updated
+		default:
+			continue
+		}
+		changes = append(changes, c)
+	}
+	for id, before := range prev {
+		if _, ok := next[id]; !ok {
+			changes = append(changes, Change{Source: source, Bead: id, Kind: Deleted, Status: before.Status, Title: before.Title, Assignee: before.Assignee})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Bead < changes[j].Bead })
+	return changes
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,237 @@
+package beadwatch
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+)
+
+// fakeDB is an in-memory Lister keyed by work dir.
+type fakeDB struct {
+	mu     sync.Mutex
+	issues map[string]map[string]beads.Issue
+	fail   bool
+}
+
+func (f *fakeDB) set(workDir string, issue beads.Issue) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.issues == nil {
+		f.issues = make(map[string]map[string]beads.Issue)
+	}
+	if f.issues[workDir] == nil {
+		f.issues[workDir] = make(map[string]beads.Issue)
+	}
+	f.issues[workDir][issue.ID] = issue
+}
+
+func (f *fakeDB) remove(workDir, id string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.issues[workDir], id)
+}
+
+func (f *fakeDB) list(workDir string) ([]*beads.Issue, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.fail {
+		return nil, errors.New("bd unavailable")
+	}
+	var out []*beads.Issue
+	for _, issue := range f.issues[workDir] {
+		issue := issue
+		out = append(out, &issue)
+	}
+	return out, nil
+}
+
+func newWatcher(t *testing.T, db *fakeDB, sources ...Source) *Watcher {
+	t.Helper()
+	w := New(sources)
+	w.List = db.list
+	if err := w.Prime(); err != nil {
+		t.Fatalf("Prime: %v", err)
+	}
+	return w
+}
+
+func TestScan(t *testing.T) {
+	db := &fakeDB{}
+	db.set("/town", beads.Issue{ID: "hq-1", Title: "one", Status: "open"})
+	db.set("/town", beads.Issue{ID: "hq-2", Title: "two", Status: "closed"})
+	db.set("/town", beads.Issue{ID: "hq-3", Title: "three", Status: "open"})
+	db.set("/town", beads.Issue{ID: "hq-4", Title: "four", Status: "open"})
+	db.set("/town", beads.Issue{ID: "hq-5", Title: "five", Status: "open"})
+	w := newWatcher(t, db, Source{Name: TownSource, WorkDir: "/town"})
+
+	if changes, err := w.Scan(); err != nil || len(changes) != 0 {
+		t.Fatalf("Scan() with no changes = %v, %v; want none", changes, err)
+	}
+
+	db.set("/town", beads.Issue{ID: "hq-1", Title: "one", Status: "closed"})
+	db.set("/town", beads.Issue{ID: "hq-2", Title: "two", Status: "open"})
+	db.set("/town", beads.Issue{ID: "hq-3", Title: "three", Status: "in_progress"})
+	db.set("/town", beads.Issue{ID: "hq-4", Title: "four, renamed", Status: "open", Assignee: "gastown/polecats/toast"})
+	db.remove("/town", "hq-5")
+	db.set("/town", beads.Issue{ID: "hq-6", Title: "six", Status: "open"})
+
+	var got []Change
+	w.Subscribe(func(c []Change) { got = append(got, c...) })
+	changes, err := w.Scan()
+	if err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+	want := []Change{
+		{Source: TownSource, Bead: "hq-1", Kind: Closed, Status: "closed", PrevStatus: "open", Title: "one"},
+		{Source: TownSource, Bead: "hq-2", Kind: Reopened, Status: "open", PrevStatus: "closed", Title: "two"},
+		{Source: TownSource, Bead: "hq-3", Kind: Status, Status: "in_progress", PrevStatus: "open", Title: "three"},
+		{Source: TownSource, Bead: "hq-4", Kind: Updated, Status: "open", Title: "four, renamed", Assignee: "gastown/polecats/toast"},
+		{Source: TownSource, Bead: "hq-5", Kind: Deleted, Status: "open", Title: "five"},
+		{Source: TownSource, Bead: "hq-6", Kind: Created, Status: "open", Title: "six"},
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("Scan() = %+v, want %+v", changes, want)
+	}
+	for i := range want {
+		if changes[i] != want[i] {
+			t.Errorf("change %d = %+v, want %+v", i, changes[i], want[i])
+		}
+	}
+	if len(got) != len(want) {
+		t.Errorf("subscriber got %d changes, want %d", len(got), len(want))
+	}
+
+	if changes, _ := w.Scan(); len(changes) != 0 {
+		t.Errorf("second Scan() = %+v, want none", changes)
+	}
+}
+
+func TestScan_KeepsSnapshotOnError(t *testing.T) {
+	db := &fakeDB{}
+	db.set("/town", beads.Issue{ID: "hq-1", Status: "open"})
+	w := newWatcher(t, db, Source{Name: TownSource, WorkDir: "/town"})
+
+	db.fail = true
+	if _, err := w.Scan(); err == nil {
+		t.Fatal("Scan() with a failing lister succeeded")
+	}
+	db.fail = false
+	db.set("/town", beads.Issue{ID: "hq-1", Status: "closed"})
+	changes, err := w.Scan()
+	if err != nil || len(changes) != 1 || changes[0].Kind != Closed {
+		t.Errorf("Scan() after recovery = %+v, %v; want hq-1 closed", changes, err)
+	}
+}
+
+func TestScan_NamedSources(t *testing.T) {
+	db := &fakeDB{}
+	w := newWatcher(t, db,
+		Source{Name: TownSource, WorkDir: "/town"},
+		Source{Name: "gastown", WorkDir: "/town/gastown/mayor/rig"})
+
+	db.set("/town", beads.Issue{ID: "hq-1", Status: "open"})
+	db.set("/town/gastown/mayor/rig", beads.Issue{ID: "gt-1", Status: "open"})
+	changes, _ := w.Scan("gastown")
+	if len(changes) != 1 || changes[0].Source != "gastown" || changes[0].Bead != "gt-1" {
+		t.Errorf("Scan(gastown) = %+v, want only gt-1", changes)
+	}
+}
+
+func TestMayFreeWork(t *testing.T) {
+	tests := []struct {
+		change Change
+		want   bool
+	}{
+		{Change{Kind: Created, Status: "open"}, true},
+		{Change{Kind: Closed, Status: "closed"}, true},
+		{Change{Kind: Reopened, Status: "open"}, true},
+		{Change{Kind: Status, Status: "open", PrevStatus: "in_progress"}, true},
+		{Change{Kind: Status, Status: "in_progress", PrevStatus: "open"}, false},
+		{Change{Kind: Updated, Status: "open"}, false},
+		{Change{Kind: Deleted, Status: "open"}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.change.MayFreeWork(); got != tt.want {
+			t.Errorf("%+v.MayFreeWork() = %v, want %v", tt.change, got, tt.want)
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	db := &fakeDB{}
+	w := newWatcher(t, db, Source{Name: TownSource, WorkDir: "/town", Dirs: []string{dir}})
+	w.Debounce = 10 * time.Millisecond
+	scanned := make(chan []Change, 1)
+	w.Subscribe(func(c []Change) { scanned <- c })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- w.Run(ctx) }()
+	defer func() {
+		cancel()
+		if err := <-done; err != nil {
+			t.Errorf("Run: %v", err)
+		}
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	db.set("/town", beads.Issue{ID: "hq-1", Title: "new", Status: "open"})
+	if err := os.WriteFile(filepath.Join(dir, "issues.jsonl"), []byte("{}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case changes := <-scanned:
+		if len(changes) != 1 || changes[0].Kind != Created {
+			t.Errorf("changes = %+v, want hq-1 created", changes)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no scan after writing to the beads dir")
+	}
+}
+
+func TestSources_MapsDoltDirs(t *testing.T) {
+	town := t.TempDir()
+	mkdir := func(rel string) string {
+		dir := filepath.Join(town, filepath.FromSlash(rel))
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		return dir
+	}
+	write := func(rel, content string) {
+		if err := os.WriteFile(filepath.Join(town, filepath.FromSlash(rel)), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	mkdir("mayor")
+	write("mayor/rigs.json", `{"version":1,"rigs":{"gastown":{"git_url":"x"},"other":{"git_url":"y"}}}`)
+	mkdir(".beads")
+	write(".beads/metadata.json", `{"dolt_database":"hq"}`)
+	mkdir("gastown/mayor/rig/.beads")
+	write("gastown/mayor/rig/.beads/metadata.json", `{"dolt_database":"gastown"}`)
+	mkdir("other/mayor/rig/.beads")
+	hq := mkdir(".dolt-data/hq/.dolt/noms")
+	gastown := mkdir(".dolt-data/gastown/.dolt/noms")
+	stray := mkdir(".dolt-data/stray/.dolt/noms")
+
+	sources, err := Sources(town)
+	if err != nil {
+		t.Fatalf("Sources: %v", err)
+	}
+	want := map[string]string{TownSource: hq, "gastown": gastown, "other": stray}
+	if len(sources) != len(want) {
+		t.Fatalf("sources = %+v", sources)
+	}
+	for _, src := range sources {
+		if len(src.Dirs) != 2 || src.Dirs[1] != want[src.Name] {
+			t.Errorf("%s dirs = %v, want beads dir and %s", src.Name, src.Dirs, want[src.Name])
+		}
+	}
+}
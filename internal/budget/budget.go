@@ -0,0 +1,249 @@
+// Package budget evaluates spend against per-rig and per-agent budget caps.
+// Spend comes from cost tracking (gt costs); enforcement (pausing dispatch,
+// mailing the mayor) lives in cmd.
+package budget
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"time"
+)
+
+// Budget periods.
+const (
+	PeriodDay   = "day"
+	PeriodWeek  = "week"
+	PeriodMonth = "month"
+)
+
+// DefaultWarnAt is the fraction of a cap at which a budget is reported as
+// nearly spent when Config.WarnAt is unset.
+const DefaultWarnAt = 0.8
+
+// Config caps spend per rig and per agent. Town-wide, stored as "budget" in
+// settings/config.json. Caps are in USD per period.
+type Config struct {
+	// Period is the window caps apply to: "day" (default), "week", or "month".
+	// Weeks start on Monday; all periods use local time.
+	Period string `json:"period,omitempty"`
+
+	// Rigs caps spend per rig. "*" sets the cap for rigs not listed.
+	Rigs map[string]float64 `json:"rigs,omitempty"`
+
+	// Agents caps spend per agent. Keys are address patterns in path.Match
+	// syntax (e.g., "gastown/polecats/*", "mayor"); every matching pattern's
+	// cap applies to each matching agent individually.
+	Agents map[string]float64 `json:"agents,omitempty"`
+
+	// WarnAt is the fraction of a cap at which gt status flags the budget
+	// as nearly spent (default 0.8).
+	WarnAt float64 `json:"warn_at,omitempty"`
+}
+
+// Validate checks the config for errors.
+func (c *Config) Validate() error {
+	if c == nil {
+		return nil
+	}
+	switch c.Period {
+	case "", PeriodDay, PeriodWeek, PeriodMonth:
+	default:
+		return fmt.Errorf("budget.period: %q is not day, week, or month", c.Period)
+	}
+	for rig, v := range c.Rigs {
+		if v < 0 {
+			return fmt.Errorf("budget.rigs[%s]: cap must not be negative", rig)
+		}
+	}
+	for pattern, v := range c.Agents {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("budget.agents: bad pattern %q: %w", pattern, err)
+		}
+		if v < 0 {
+			return fmt.Errorf("budget.agents[%s]: cap must not be negative", pattern)
+		}
+	}
+	if c.WarnAt < 0 || c.WarnAt > 1 {
+		return fmt.Errorf("budget.warn_at: %v is not between 0 and 1", c.WarnAt)
+	}
+	return nil
+}
+
+// Enabled reports whether any cap is set.
+func (c *Config) Enabled() bool {
+	return c != nil && (len(c.Rigs) > 0 || len(c.Agents) > 0)
+}
+
+// GetPeriod returns Period or PeriodDay if unset.
+func (c *Config) GetPeriod() string {
+	if c == nil || c.Period == "" {
+		return PeriodDay
+	}
+	return c.Period
+}
+
+// GetWarnAt returns WarnAt or DefaultWarnAt if unset.
+func (c *Config) GetWarnAt() float64 {
+	if c == nil || c.WarnAt == 0 {
+		return DefaultWarnAt
+	}
+	return c.WarnAt
+}
+
+// PeriodStart returns the start of the period containing now.
+func (c *Config) PeriodStart(now time.Time) time.Time {
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	switch c.GetPeriod() {
+	case PeriodWeek:
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	case PeriodMonth:
+		return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	default:
+		return day
+	}
+}
+
+// RigCap returns the rig's cap, or 0 if uncapped.
+func (c *Config) RigCap(rig string) float64 {
+	if c == nil || c.Rigs == nil || rig == "" {
+		return 0
+	}
+	if v, ok := c.Rigs[rig]; ok {
+		return v
+	}
+	return c.Rigs["*"]
+}
+
+// AgentCap returns the tightest cap among patterns matching the agent, and
+// the pattern it came from, or 0 if uncapped.
+func (c *Config) AgentCap(address string) (float64, string) {
+	if c == nil {
+		return 0, ""
+	}
+	var best float64
+	var from string
+	for _, pattern := range sortedKeys(c.Agents) {
+		v := c.Agents[pattern]
+		if v <= 0 {
+			continue
+		}
+		if ok, _ := path.Match(pattern, address); ok && (best == 0 || v < best) {
+			best, from = v, pattern
+		}
+	}
+	return best, from
+}
+
+// Spend is spend so far in the current period.
+type Spend struct {
+	ByRig   map[string]float64 // rig → USD
+	ByAgent map[string]float64 // agent address → USD
+}
+
+// Scopes a State applies to.
+const (
+	ScopeRig   = "rig"
+	ScopeAgent = "agent"
+)
+
+// State is one capped rig or agent and how much of its budget is spent.
+type State struct {
+	Scope    string  `json:"scope"` // "rig" or "agent"
+	Name     string  `json:"name"`  // Rig name or agent address
+	Cap      float64 `json:"cap_usd"`
+	Spent    float64 `json:"spent_usd"`
+	Exceeded bool    `json:"exceeded"`
+	Warning  bool    `json:"warning,omitempty"` // Past WarnAt but not exceeded
+}
+
+// Fraction returns spent/cap.
+func (s State) Fraction() float64 {
+	if s.Cap <= 0 {
+		return 0
+	}
+	return s.Spent / s.Cap
+}
+
+// Report is the budget state for a period.
+type Report struct {
+	Period string    `json:"period"`
+	Since  time.Time `json:"since"`
+	States []State   `json:"states"`
+}
+
+// Evaluate compares spend against the caps. Every capped rig is reported
+// (listed rigs, and "*"-capped rigs that have spend); agents are reported
+// when they have spend under a matching cap.
+func Evaluate(c *Config, spend Spend, now time.Time) *Report {
+	r := &Report{Period: c.GetPeriod(), Since: c.PeriodStart(now)}
+	if !c.Enabled() {
+		return r
+	}
+	warnAt := c.GetWarnAt()
+	add := func(scope, name string, limit, spent float64) {
+		s := State{Scope: scope, Name: name, Cap: limit, Spent: spent}
+		s.Exceeded = spent >= limit
+		s.Warning = !s.Exceeded && spent >= limit*warnAt
+		r.States = append(r.States, s)
+	}
+
+	rigs := make(map[string]bool)
+	for rig := range c.Rigs {
+		if rig != "*" {
+			rigs[rig] = true
+		}
+	}
+	for rig := range spend.ByRig {
+		rigs[rig] = true
+	}
+	for _, rig := range sortedKeys(rigs) {
+		if limit := c.RigCap(rig); limit > 0 {
+			add(ScopeRig, rig, limit, spend.ByRig[rig])
+		}
+	}
+	for _, addr := range sortedKeys(spend.ByAgent) {
+		if limit, _ := c.AgentCap(addr); limit > 0 {
+			add(ScopeAgent, addr, limit, spend.ByAgent[addr])
+		}
+	}
+	return r
+}
+
+// Exceeded returns the states over budget.
+func (r *Report) Exceeded() []State {
+	var out []State
+	for _, s := range r.States {
+		if s.Exceeded {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// Blocks reports whether new work for an agent in a rig is paused, and the
+// exceeded budget responsible. Either argument may be empty.
+func (r *Report) Blocks(rig, address string) (State, bool) {
+	if r == nil {
+		return State{}, false
+	}
+	for _, s := range r.States {
+		if !s.Exceeded {
+			continue
+		}
+		if (s.Scope == ScopeRig && rig != "" && s.Name == rig) ||
+			(s.Scope == ScopeAgent && address != "" && s.Name == address) {
+			return s, true
+		}
+	}
+	return State{}, false
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,115 @@
+package budget
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPeriodStart(t *testing.T) {
+	// Thursday afternoon.
+	now := time.Date(2026, 10, 15, 15, 30, 0, 0, time.UTC)
+	tests := []struct {
+		period string
+		want   time.Time
+	}{
+		{"", time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)},
+		{PeriodDay, time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)},
+		{PeriodWeek, time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC)}, // Monday
+		{PeriodMonth, time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		c := &Config{Period: tt.period}
+		if got := c.PeriodStart(now); !got.Equal(tt.want) {
+			t.Errorf("period %q: start = %v, want %v", tt.period, got, tt.want)
+		}
+	}
+
+	// A Sunday belongs to the week that started six days earlier.
+	sunday := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	if got := (&Config{Period: PeriodWeek}).PeriodStart(sunday); got.Day() != 12 {
+		t.Errorf("Sunday week start = %v, want Oct 12", got)
+	}
+}
+
+func TestAgentCap(t *testing.T) {
+	c := &Config{Agents: map[string]float64{
+		"*/polecats/*":       10,
+		"gastown/polecats/*": 5,
+		"mayor":              20,
+	}}
+	tests := []struct {
+		addr    string
+		want    float64
+		pattern string
+	}{
+		{"gastown/polecats/nux", 5, "gastown/polecats/*"}, // Tightest wins
+		{"beads/polecats/ace", 10, "*/polecats/*"},
+		{"mayor", 20, "mayor"},
+		{"gastown/crew/max", 0, ""},
+	}
+	for _, tt := range tests {
+		got, pattern := c.AgentCap(tt.addr)
+		if got != tt.want || pattern != tt.pattern {
+			t.Errorf("AgentCap(%s) = %v from %q, want %v from %q", tt.addr, got, pattern, tt.want, tt.pattern)
+		}
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	c := &Config{
+		Rigs:   map[string]float64{"gastown": 50, "*": 10, "beads": 0},
+		Agents: map[string]float64{"*/polecats/*": 5},
+	}
+	spend := Spend{
+		ByRig:   map[string]float64{"gastown": 42, "wyvern": 12, "beads": 100},
+		ByAgent: map[string]float64{"gastown/polecats/nux": 5.5, "gastown/polecats/ace": 1, "gastown/crew/max": 30},
+	}
+	r := Evaluate(c, spend, time.Now())
+
+	got := make(map[string]State)
+	for _, s := range r.States {
+		got[s.Scope+":"+s.Name] = s
+	}
+	if len(got) != 4 {
+		t.Fatalf("states = %+v, want gastown, wyvern, nux, ace", r.States)
+	}
+	if s := got["rig:gastown"]; s.Exceeded || !s.Warning {
+		t.Errorf("gastown at 84%% = %+v, want warning", s)
+	}
+	if s := got["rig:wyvern"]; !s.Exceeded || s.Cap != 10 {
+		t.Errorf("wyvern under \"*\" = %+v, want exceeded at cap 10", s)
+	}
+	if _, ok := got["rig:beads"]; ok {
+		t.Error("beads has cap 0 (uncapped) but was reported")
+	}
+	if s := got["agent:gastown/polecats/nux"]; !s.Exceeded {
+		t.Errorf("nux = %+v, want exceeded", s)
+	}
+
+	if _, blocked := r.Blocks("gastown", "gastown/polecats/ace"); blocked {
+		t.Error("ace is within its budget in a rig within budget, but was blocked")
+	}
+	if s, blocked := r.Blocks("gastown", "gastown/polecats/nux"); !blocked || s.Name != "gastown/polecats/nux" {
+		t.Errorf("nux: blocked=%v by %+v, want blocked by own budget", blocked, s)
+	}
+	if s, blocked := r.Blocks("wyvern", ""); !blocked || s.Scope != ScopeRig {
+		t.Errorf("wyvern rig: blocked=%v by %+v, want blocked by rig budget", blocked, s)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	bad := []*Config{
+		{Period: "fortnight"},
+		{Rigs: map[string]float64{"gastown": -1}},
+		{Agents: map[string]float64{"[": 1}},
+		{WarnAt: 1.5},
+	}
+	for _, c := range bad {
+		if err := c.Validate(); err == nil {
+			t.Errorf("Validate(%+v) = nil, want error", c)
+		}
+	}
+	if err := (&Config{Period: PeriodWeek, Rigs: map[string]float64{"*": 10}}).Validate(); err != nil {
+		t.Errorf("valid config: %v", err)
+	}
+}
@@ -0,0 +1,201 @@
+package checkpoint
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// Label marks checkpoint beads written by gt checkpoint <agent>.
+const Label = "gt:checkpoint"
+
+// Section headings in a snapshot's bead description.
+const (
+	sectionSummary    = "## Summary"
+	sectionDiff       = "## Worktree diff"
+	sectionScrollback = "## Scrollback"
+)
+
+// Snapshot is a point-in-time copy of an agent's conversation context and
+// work: what it was showing on screen, what it had hooked, and what it had
+// changed. Snapshots are stored as closed checkpoint beads so a fresh
+// session can be re-seeded from one after a crash or model switch.
+type Snapshot struct {
+	Agent         string    // Agent address, e.g. "gastown/crew/max"
+	Session       string    // Tmux session the snapshot was taken from
+	HookedBead    string    // Bead on the agent's hook
+	Branch        string    // Git branch of the agent's worktree
+	LastCommit    string    // HEAD of the agent's worktree
+	WorkDir       string    // The agent's worktree
+	ModifiedFiles []string  // Files with uncommitted changes
+	CapturedAt    time.Time // When the snapshot was taken
+	CapturedBy    string    // Who took it
+
+	Summary    string // Generated summary of where the work stands
+	Diff       string // git diff HEAD of the worktree, possibly clipped
+	Scrollback string // Tail of the agent's pane, possibly clipped
+}
+
+// CaptureDiff returns the uncommitted changes in dir (git diff HEAD),
+// clipped to maxBytes. Returns "" outside a git repo or when clean.
+func CaptureDiff(dir string, maxBytes int) string {
+	cmd := exec.Command("git", "diff", "HEAD")
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return Clip(strings.TrimRight(string(out), "\n"), maxBytes)
+}
+
+// Clip shortens s to at most maxBytes, cutting at a line boundary and
+// noting how much was dropped. maxBytes <= 0 means no limit.
+func Clip(s string, maxBytes int) string {
+	if maxBytes <= 0 || len(s) <= maxBytes {
+		return s
+	}
+	cut := s[:maxBytes]
+	if i := strings.LastIndexByte(cut, '\n'); i > 0 {
+		cut = cut[:i]
+	}
+	return fmt.Sprintf("%s\n[... %d more bytes clipped]", cut, len(s)-len(cut))
+}
+
+// Description renders the snapshot as a checkpoint bead description:
+// "key: value" header lines, then the summary, diff, and scrollback
+// sections.
+func (s *Snapshot) Description() string {
+	var b strings.Builder
+	field := func(key, value string) {
+		if value == "" {
+			value = "null"
+		}
+		fmt.Fprintf(&b, "%s: %s\n", key, value)
+	}
+	field("agent", s.Agent)
+	field("session", s.Session)
+	field("hooked_bead", s.HookedBead)
+	field("branch", s.Branch)
+	field("last_commit", s.LastCommit)
+	field("work_dir", s.WorkDir)
+	field("modified_files", strings.Join(s.ModifiedFiles, ", "))
+	field("captured_at", s.CapturedAt.UTC().Format(time.RFC3339))
+	field("captured_by", s.CapturedBy)
+
+	fmt.Fprintf(&b, "\n%s\n%s\n", sectionSummary, s.Summary)
+	if s.Diff != "" {
+		fmt.Fprintf(&b, "\n%s\n```diff\n%s\n```\n", sectionDiff, s.Diff)
+	}
+	if s.Scrollback != "" {
+		fmt.Fprintf(&b, "\n%s\n```\n%s\n```\n", sectionScrollback, s.Scrollback)
+	}
+	return b.String()
+}
+
+// ParseSnapshot reads a snapshot back from a checkpoint bead description.
+func ParseSnapshot(description string) *Snapshot {
+	s := &Snapshot{}
+	sections := map[string]*string{
+		sectionSummary:    &s.Summary,
+		sectionDiff:       &s.Diff,
+		sectionScrollback: &s.Scrollback,
+	}
+
+	var current *string
+	var body []string
+	flush := func() {
+		if current != nil {
+			text := strings.TrimSpace(strings.Join(body, "\n"))
+			text = strings.TrimPrefix(text, "```diff")
+			text = strings.TrimPrefix(text, "```")
+			text = strings.TrimSuffix(text, "```")
+			*current = strings.Trim(text, "\n")
+		}
+		body = nil
+	}
+
+	for _, line := range strings.Split(description, "\n") {
+		// The scrollback is last and may contain anything, so no heading
+		// ends it.
+		if target, ok := sections[line]; ok && current != &s.Scrollback {
+			flush()
+			current = target
+			continue
+		}
+		if current != nil {
+			body = append(body, line)
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		if value == "null" {
+			value = ""
+		}
+		switch strings.TrimSpace(key) {
+		case "agent":
+			s.Agent = value
+		case "session":
+			s.Session = value
+		case "hooked_bead":
+			s.HookedBead = value
+		case "branch":
+			s.Branch = value
+		case "last_commit":
+			s.LastCommit = value
+		case "work_dir":
+			s.WorkDir = value
+		case "modified_files":
+			if value != "" {
+				s.ModifiedFiles = strings.Split(value, ", ")
+			}
+		case "captured_at":
+			if t, err := time.Parse(time.RFC3339, value); err == nil {
+				s.CapturedAt = t
+			}
+		case "captured_by":
+			s.CapturedBy = value
+		}
+	}
+	flush()
+	return s
+}
+
+// FallbackSummary describes the snapshot without a model, for when no
+// summarizer is available: the hooked work, the git state, and the last
+// lines of the pane.
+func (s *Snapshot) FallbackSummary(hookTitle string, tailLines int) string {
+	var lines []string
+	if s.HookedBead != "" {
+		hook := "Hooked: " + s.HookedBead
+		if hookTitle != "" {
+			hook += " (" + hookTitle + ")"
+		}
+		lines = append(lines, hook)
+	} else {
+		lines = append(lines, "Hooked: nothing")
+	}
+	if s.Branch != "" {
+		lines = append(lines, "Branch: "+s.Branch)
+	}
+	if len(s.ModifiedFiles) > 0 {
+		lines = append(lines, fmt.Sprintf("Uncommitted changes in %d file(s): %s", len(s.ModifiedFiles), strings.Join(s.ModifiedFiles, ", ")))
+	}
+	if tail := lastLines(s.Scrollback, tailLines); tail != "" {
+		lines = append(lines, "", "Last output:", tail)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// lastLines returns the last n lines of s, ignoring trailing blank lines.
+func lastLines(s string, n int) string {
+	all := strings.Split(strings.TrimRight(s, "\n "), "\n")
+	if len(all) > n {
+		all = all[len(all)-n:]
+	}
+	return strings.Join(all, "\n")
+}
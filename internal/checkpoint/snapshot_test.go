@@ -0,0 +1,106 @@
+package checkpoint
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	captured := time.Date(2026, 3, 1, 12, 30, 0, 0, time.UTC)
+	s := &Snapshot{
+		Agent:         "gastown/crew/max",
+		Session:       "gt-gastown-crew-max",
+		HookedBead:    "gt-abc12",
+		Branch:        "feature/x",
+		LastCommit:    "deadbeef",
+		WorkDir:       "/town/gastown/crew/max",
+		ModifiedFiles: []string{"a.go", "b.go"},
+		CapturedAt:    captured,
+		CapturedBy:    "mayor/",
+		Summary:       "- Goal: fix x\n- Next: run tests",
+		Diff:          "diff --git a/a.go b/a.go\n+new line",
+		// Scrollback may contain anything, including section headings.
+		Scrollback: "$ go test\n## Summary\nok",
+	}
+
+	got := ParseSnapshot(s.Description())
+	if got.Agent != s.Agent || got.Session != s.Session || got.HookedBead != s.HookedBead ||
+		got.Branch != s.Branch || got.LastCommit != s.LastCommit || got.WorkDir != s.WorkDir ||
+		got.CapturedBy != s.CapturedBy {
+		t.Errorf("header fields = %+v, want %+v", got, s)
+	}
+	if !got.CapturedAt.Equal(captured) {
+		t.Errorf("CapturedAt = %v, want %v", got.CapturedAt, captured)
+	}
+	if strings.Join(got.ModifiedFiles, ",") != "a.go,b.go" {
+		t.Errorf("ModifiedFiles = %v", got.ModifiedFiles)
+	}
+	if got.Summary != s.Summary {
+		t.Errorf("Summary = %q, want %q", got.Summary, s.Summary)
+	}
+	if got.Diff != s.Diff {
+		t.Errorf("Diff = %q, want %q", got.Diff, s.Diff)
+	}
+	if got.Scrollback != s.Scrollback {
+		t.Errorf("Scrollback = %q, want %q", got.Scrollback, s.Scrollback)
+	}
+}
+
+func TestSnapshotRoundTripEmpty(t *testing.T) {
+	s := &Snapshot{Agent: "mayor/", CapturedAt: time.Now(), Summary: "Hooked: nothing"}
+	got := ParseSnapshot(s.Description())
+	if got.HookedBead != "" || got.Branch != "" || got.ModifiedFiles != nil {
+		t.Errorf("empty fields not preserved: %+v", got)
+	}
+	if got.Diff != "" || got.Scrollback != "" {
+		t.Errorf("unexpected sections: diff=%q scrollback=%q", got.Diff, got.Scrollback)
+	}
+	if got.Summary != "Hooked: nothing" {
+		t.Errorf("Summary = %q", got.Summary)
+	}
+}
+
+func TestClip(t *testing.T) {
+	if got := Clip("short", 100); got != "short" {
+		t.Errorf("Clip under limit = %q", got)
+	}
+	if got := Clip("anything", 0); got != "anything" {
+		t.Errorf("Clip with no limit = %q", got)
+	}
+
+	got := Clip("line one\nline two\nline three", 15)
+	if !strings.HasPrefix(got, "line one\n[... ") {
+		t.Errorf("Clip should cut at a line boundary, got %q", got)
+	}
+	if !strings.Contains(got, "20 more bytes clipped") {
+		t.Errorf("Clip should note the clipped bytes, got %q", got)
+	}
+}
+
+func TestFallbackSummary(t *testing.T) {
+	s := &Snapshot{
+		HookedBead:    "gt-abc12",
+		Branch:        "feature/x",
+		ModifiedFiles: []string{"a.go"},
+		Scrollback:    "one\ntwo\nthree\n\n",
+	}
+	got := s.FallbackSummary("Fix the thing", 2)
+	for _, want := range []string{
+		"Hooked: gt-abc12 (Fix the thing)",
+		"Branch: feature/x",
+		"1 file(s): a.go",
+		"Last output:\ntwo\nthree",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("FallbackSummary missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "one") {
+		t.Errorf("FallbackSummary should keep only the last 2 lines:\n%s", got)
+	}
+
+	if got := (&Snapshot{}).FallbackSummary("", 5); got != "Hooked: nothing" {
+		t.Errorf("empty FallbackSummary = %q", got)
+	}
+}
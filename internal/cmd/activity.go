@@ -79,7 +79,7 @@ func init() {
 	activityEmitCmd.Flags().StringVar(&activityReason, "reason", "", "Reason for the action")
 	activityEmitCmd.Flags().StringVar(&activityMessage, "message", "", "Human-readable message")
 	activityEmitCmd.Flags().StringVar(&activityStatus, "status", "", "Status (for polecat_checked: working, idle, stuck)")
-	activityEmitCmd.Flags().StringVar(&activityIssue, "issue", "", "Issue ID (for polecat_checked)")
+	activityEmitCmd.Flags().StringVar(&activityIssue, "issue", "", "Issue ID (for polecat_checked and merge events)")
 	activityEmitCmd.Flags().StringVar(&activityTo, "to", "", "Escalation target (for escalation_sent: mayor, deacon)")
 	activityEmitCmd.Flags().IntVar(&activityCount, "count", 0, "Polecat count (for patrol events)")
 
@@ -145,6 +145,9 @@ func runActivityEmit(cmd *cobra.Command, args []string) error {
 		if activityTarget != "" {
 			payload["branch"] = activityTarget
 		}
+		if activityIssue != "" {
+			payload["issue"] = activityIssue
+		}
 		if activityReason != "" {
 			payload["reason"] = activityReason
 		}
@@ -49,7 +49,7 @@ func runAgentLog(cmd *cobra.Command, args []string) error {
 
 	provider, err := telemetry.Init(ctx, "gastown", "")
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "warning: telemetry init failed: %v\n", err)
+		logger.Warn("telemetry init failed", "err", err)
 	}
 	if provider != nil {
 		defer func() {
@@ -74,7 +74,7 @@ var AgentTypeIcons = map[AgentType]string{
 
 var agentsCmd = &cobra.Command{
 	Use:     "agents",
-	Aliases: []string{"ag"},
+	Aliases: []string{"ag", "agent"},
 	GroupID: GroupAgents,
 	Short:   "List Gas Town agent sessions",
 	Long: `List Gas Town agent sessions to stdout.
@@ -0,0 +1,214 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/incident"
+	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var (
+	agentsRestartReason string
+	agentsRestartDryRun bool
+	agentsRestartNoMail bool
+)
+
+var agentsRestartCmd = &cobra.Command{
+	Use:   "restart <address>",
+	Short: "Restart an agent session, preserving its launch state",
+	Long: `Kill and recreate an agent's tmux session with the same launch command,
+working directory, and session environment it was running with.
+
+Before the restart, the pane scrollback is saved to
+<town>/.runtime/diagnostics/restart/<session>-<unix>.log so nothing on screen
+is lost. After the restart, the agent gets a mail explaining why it was
+restarted and where the old scrollback lives.
+
+The launch command is read from the pane itself; if the pane was started
+with a plain shell, the role's standard startup command is used instead.
+
+Examples:
+  gt agent restart gastown/nux
+  gt agent restart gastown/witness --reason "wedged on permission prompt"
+  gt agent restart mayor --dry-run`,
+	Args: cobra.ExactArgs(1),
+	RunE: runAgentsRestart,
+}
+
+func init() {
+	agentsRestartCmd.Flags().StringVarP(&agentsRestartReason, "reason", "r", "manual restart", "Why the agent is being restarted (included in the mail)")
+	agentsRestartCmd.Flags().BoolVarP(&agentsRestartDryRun, "dry-run", "n", false, "Show what would be restarted without doing it")
+	agentsRestartCmd.Flags().BoolVar(&agentsRestartNoMail, "no-mail", false, "Don't mail the agent about the restart")
+	agentsCmd.AddCommand(agentsRestartCmd)
+}
+
+// agentLaunchState is what gets carried across a restart.
+type agentLaunchState struct {
+	command string
+	workDir string
+	env     map[string]string
+}
+
+func runAgentsRestart(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	sessionName, err := resolveRoleToSession(args[0])
+	if err != nil {
+		return fmt.Errorf("resolving %q: %w", args[0], err)
+	}
+
+	t := tmux.NewTmux()
+	exists, err := t.HasSession(sessionName)
+	if err != nil {
+		return fmt.Errorf("checking session: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("session %q not found - is the agent running?", sessionName)
+	}
+
+	state, err := captureAgentLaunchState(t, sessionName, townRoot)
+	if err != nil {
+		return err
+	}
+
+	if agentsRestartDryRun {
+		fmt.Printf("Would restart %s\n", sessionName)
+		fmt.Printf("  workdir: %s\n", state.workDir)
+		fmt.Printf("  command: %s\n", state.command)
+		fmt.Printf("  env:     %d variable(s)\n", len(state.env))
+		return nil
+	}
+
+	// Save scrollback before the kill so the agent's last screen survives.
+	scrollbackPath, err := saveRestartScrollback(t, townRoot, sessionName)
+	if err != nil {
+		style.PrintWarning("could not save scrollback: %v", err)
+	}
+
+	_ = events.LogFeed(events.TypeSessionDeath, sessionName,
+		events.SessionDeathPayload(sessionName, sessionNameToAddress(sessionName), agentsRestartReason, "gt agent restart"))
+
+	if err := t.KillSessionWithProcesses(sessionName); err != nil {
+		return fmt.Errorf("killing session: %w", err)
+	}
+	if err := t.NewSessionWithCommandAndEnv(sessionName, state.workDir, state.command, state.env); err != nil {
+		return fmt.Errorf("recreating session: %w", err)
+	}
+	// GT_PANE_ID is pane-specific; record the new pane instead of the old one.
+	if paneID, err := t.GetPaneID(sessionName); err == nil {
+		_ = t.SetEnvironment(sessionName, "GT_PANE_ID", paneID)
+	}
+
+	fmt.Printf("%s Restarted %s\n", style.SuccessPrefix, sessionName)
+	if scrollbackPath != "" {
+		fmt.Printf("  Scrollback: %s\n", scrollbackPath)
+	}
+
+	if agentsRestartNoMail {
+		return nil
+	}
+	address := sessionNameToAddress(sessionName)
+	if address == "" {
+		return nil
+	}
+	if err := sendRestartMail(townRoot, address, scrollbackPath); err != nil {
+		style.PrintWarning("could not send restart mail: %v", err)
+	}
+	return nil
+}
+
+// captureAgentLaunchState reads the launch command, working directory, and
+// session environment from a running session, falling back to the role's
+// canonical command and home directory where the pane doesn't say.
+func captureAgentLaunchState(t *tmux.Tmux, sessionName, townRoot string) (*agentLaunchState, error) {
+	state := &agentLaunchState{}
+
+	state.command, _ = t.GetPaneStartCommand(sessionName)
+	if state.command == "" {
+		restartCmd, err := buildRestartCommand(sessionName)
+		if err != nil {
+			return nil, fmt.Errorf("determining launch command: %w", err)
+		}
+		state.command = restartCmd
+	}
+
+	if workDir, err := t.GetPaneWorkDir(sessionName); err == nil {
+		if _, statErr := os.Stat(workDir); statErr == nil {
+			state.workDir = workDir
+		}
+	}
+	if state.workDir == "" {
+		workDir, err := sessionWorkDir(sessionName, townRoot)
+		if err != nil {
+			return nil, fmt.Errorf("determining working directory: %w", err)
+		}
+		state.workDir = workDir
+	}
+
+	env, err := t.GetAllEnvironment(sessionName)
+	if err != nil {
+		return nil, fmt.Errorf("reading session environment: %w", err)
+	}
+	delete(env, "GT_PANE_ID")
+	state.env = env
+
+	return state, nil
+}
+
+// saveRestartScrollback saves the session's full scrollback as an incident
+// bundle under <townRoot>/.runtime/diagnostics/restart/.
+func saveRestartScrollback(t *tmux.Tmux, townRoot, sessionName string) (string, error) {
+	content, err := t.CapturePaneAll(sessionName)
+	if err != nil {
+		return "", err
+	}
+	var bead string
+	if id, err := session.ParseSessionName(sessionName); err == nil {
+		bead = findHookedBeadForAgent(beads.New(townRoot), id.Address())
+	}
+	return incident.Save(townRoot, incident.Incident{
+		Kind:    incident.KindRestart,
+		Session: sessionName,
+		Bead:    bead,
+		Reason:  agentsRestartReason,
+	}, content)
+}
+
+// sendRestartMail tells the restarted agent what happened.
+func sendRestartMail(townRoot, address, scrollbackPath string) error {
+	sender, err := resolveMailSender()
+	if err != nil {
+		return err
+	}
+	var body strings.Builder
+	fmt.Fprintf(&body, "Your session was restarted by %s.\n\n", sender)
+	fmt.Fprintf(&body, "Reason: %s\n", agentsRestartReason)
+	fmt.Fprintf(&body, "Time: %s\n", time.Now().Format(time.RFC3339))
+	if scrollbackPath != "" {
+		fmt.Fprintf(&body, "Previous scrollback: %s\n", scrollbackPath)
+	}
+	body.WriteString("\nRun `gt hook` to pick up where you left off.")
+
+	router := mail.NewRouterWithTownRoot(townRoot, townRoot)
+	defer router.WaitPendingNotifications()
+	return router.Send(&mail.Message{
+		From:      sender,
+		To:        address,
+		Subject:   "🔄 RESTARTED: " + agentsRestartReason,
+		Body:      body.String(),
+		Timestamp: time.Now(),
+	})
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+var (
+	agentsSpeakTyped    bool
+	agentsSpeakDelayMs  int
+	agentsSpeakJitterMs int
+)
+
+var agentsSpeakCmd = &cobra.Command{
+	Use:   "speak <target> <message>",
+	Short: "Type text into an agent's pane",
+	Long: `Send text to an agent's pane and submit it, like gt nudge but without
+the sender prefix or delivery modes.
+
+By default text is delivered the same way as nudges: bulk send-keys, or
+char-by-char if the agent's runtime sets typing_delay_ms in its tmux config.
+A failed bulk injection automatically falls back to typing.
+
+Use --typed to force human-like typing for TUIs that misbehave on
+instantaneous input. --delay-ms and --jitter-ms tune the keystroke pacing.
+
+Targets:
+  mayor, deacon, witness, refinery, crew  Role shortcuts (rig from GT_RIG)
+  <rig>/<polecat>, <rig>/crew/<name>      Agent paths
+  <session-name>                          Raw tmux session name
+
+Examples:
+  gt agent speak gastown/nux "continue"
+  gt agent speak mayor "status?" --typed
+  gt agent speak gastown/crew/max "hello" --typed --delay-ms 60 --jitter-ms 30`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: runAgentsSpeak,
+}
+
+func init() {
+	agentsSpeakCmd.Flags().BoolVar(&agentsSpeakTyped, "typed", false, "Type char-by-char instead of bulk send-keys")
+	agentsSpeakCmd.Flags().IntVar(&agentsSpeakDelayMs, "delay-ms", int(tmux.DefaultTypingProfile.Delay.Milliseconds()), "Mean delay between keystrokes with --typed")
+	agentsSpeakCmd.Flags().IntVar(&agentsSpeakJitterMs, "jitter-ms", int(tmux.DefaultTypingProfile.Jitter.Milliseconds()), "Maximum random keystroke jitter with --typed")
+	agentsCmd.AddCommand(agentsSpeakCmd)
+}
+
+func runAgentsSpeak(cmd *cobra.Command, args []string) error {
+	target := args[0]
+	message := strings.Join(args[1:], " ")
+
+	if agentsSpeakDelayMs < 0 || agentsSpeakJitterMs < 0 {
+		return fmt.Errorf("--delay-ms and --jitter-ms must be non-negative")
+	}
+
+	sessionName, err := resolveRoleToSession(target)
+	if err != nil {
+		return fmt.Errorf("resolving target %q: %w", target, err)
+	}
+
+	t := tmux.NewTmux()
+	exists, err := t.HasSession(sessionName)
+	if err != nil {
+		return fmt.Errorf("checking session: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("session %q not found", sessionName)
+	}
+
+	if agentsSpeakTyped {
+		profile := tmux.TypingProfile{
+			Delay:  time.Duration(agentsSpeakDelayMs) * time.Millisecond,
+			Jitter: time.Duration(agentsSpeakJitterMs) * time.Millisecond,
+		}
+		err = t.NudgeSessionTyped(sessionName, message, profile)
+	} else {
+		err = t.NudgeSession(sessionName, message)
+	}
+	if err != nil {
+		return fmt.Errorf("speaking to %s: %w", sessionName, err)
+	}
+
+	fmt.Printf("%s Sent to %s\n", style.SuccessPrefix, sessionName)
+	return nil
+}
@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/telemetry"
+	"github.com/steveyegge/gastown/internal/townlog"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var annotateNoBead bool
+
+var annotateCmd = &cobra.Command{
+	Use:     "annotate <agent> <note>",
+	GroupID: GroupAgents,
+	Short:   "Record an operator note on an agent's timeline",
+	Long: `Record an operator note against an agent at the current time, without
+touching its pane. Use it to mark "I intervened here" so reviews and replays
+of the session show where and why a human stepped in.
+
+The note is written to:
+  - the town log (gt log --agent <agent> --type annotate)
+  - the activity feed (gt feed)
+  - the agent's transcript stream, when agent output logging is on
+    (GT_LOG_AGENT_OUTPUT=true)
+  - a comment on the agent's hooked bead, if it has one (skip with --no-bead)
+
+Targets:
+  mayor, deacon, witness, refinery, crew  Role shortcuts (rig from GT_RIG)
+  <rig>/<polecat>, <rig>/crew/<name>      Agent paths
+  <session-name>                          Raw tmux session name
+
+Examples:
+  gt annotate gastown/nux "killed runaway test loop, restarted build"
+  gt annotate mayor "approved the release plan by hand"
+  gt annotate gastown/crew/max "paired on the migration" --no-bead`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: runAnnotate,
+}
+
+func init() {
+	annotateCmd.Flags().BoolVar(&annotateNoBead, "no-bead", false, "Don't comment on the agent's hooked bead")
+	rootCmd.AddCommand(annotateCmd)
+}
+
+func runAnnotate(cmd *cobra.Command, args []string) error {
+	target := args[0]
+	note := strings.TrimSpace(strings.Join(args[1:], " "))
+	if note == "" {
+		return fmt.Errorf("note must not be empty")
+	}
+
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	sessionName, err := resolveRoleToSession(target)
+	if err != nil {
+		return fmt.Errorf("resolving target %q: %w", target, err)
+	}
+	agent := target
+	if addr, ok := sessionNameToCanonicalAddress(sessionName, target); ok {
+		agent = addr
+	}
+	actor := detectActor()
+	now := time.Now().UTC()
+
+	if err := townlog.NewLogger(townRoot).LogEvent(townlog.Event{
+		Timestamp: now,
+		Type:      townlog.EventAnnotate,
+		Agent:     agent,
+		Context:   note,
+	}); err != nil {
+		return fmt.Errorf("writing town log: %w", err)
+	}
+
+	var beadID string
+	if !annotateNoBead {
+		beadID = annotateHookedBead(townRoot, agent, actor, note)
+	}
+
+	_ = events.LogFeed(events.TypeAnnotate, actor, events.AnnotatePayload(agent, sessionName, note, beadID))
+	telemetry.RecordAgentEvent(context.Background(), sessionName, "", "annotation", "operator", note, "", now)
+
+	fmt.Printf("%s Annotated %s at %s\n", style.SuccessPrefix, agent, now.Local().Format("15:04:05"))
+	if beadID != "" {
+		fmt.Printf("  Noted on hooked bead %s\n", beadID)
+	}
+	return nil
+}
+
+// annotateHookedBead comments the note on the agent's hooked bead, if any,
+// and returns the bead ID. Best-effort: a missing bead or bd failure just
+// means the note lives in the logs only.
+func annotateHookedBead(townRoot, agent, actor, note string) string {
+	var hooked []*beads.Issue
+	townBeadsDir := filepath.Join(townRoot, ".beads")
+	if _, err := os.Stat(townBeadsDir); err == nil {
+		hooked, _ = beads.New(townBeadsDir).List(beads.ListOptions{
+			Status:   beads.StatusHooked,
+			Assignee: agent,
+			Priority: -1,
+		})
+	}
+	if len(hooked) == 0 {
+		hooked = scanAllRigsForHookedBeads(townRoot, agent)
+	}
+	if len(hooked) == 0 {
+		return ""
+	}
+
+	beadID := hooked[0].ID
+	beadsDir := beadsDirForID(beadID)
+	if beadsDir == "" {
+		beadsDir = townBeadsDir
+	}
+	comment := fmt.Sprintf("Operator note (%s): %s", actor, note)
+	if _, err := beads.New(beadsDir).Run("comment", beadID, comment); err != nil {
+		style.PrintWarning("could not comment on %s: %v", beadID, err)
+		return ""
+	}
+	return beadID
+}
@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/suggest"
+	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/tui/picker"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var attachCmd = &cobra.Command{
+	Use:     "attach [query]",
+	GroupID: GroupAgents,
+	Short:   "Attach to any agent session (fuzzy picker)",
+	Long: `Attach to a running agent session, picked by address or fuzzy match.
+
+Lists every running agent session in the town — mayor, deacon, witnesses,
+refineries, crew, and polecats, including those on remote rig hosts — and
+attaches to the one you choose. With no argument, opens a fuzzy finder:
+type to filter, arrows to move, Enter to attach, Esc to cancel.
+
+With a query, attaches directly when it names one session (an address like
+gastown/crew/max, a session name, or a fuzzy query with a single match);
+otherwise opens the finder pre-filtered.
+
+Inside tmux on the town's server, switches the current client; outside
+tmux, attaches the terminal. Sessions on remote rig hosts are attached
+over ssh.
+
+Examples:
+  gt attach                     # Pick from all agents
+  gt attach mayor               # Attach to the mayor
+  gt attach gastown/crew/max    # Exact address
+  gt attach gtoast              # Fuzzy: gastown/polecats/toast`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runAttach,
+}
+
+func init() {
+	rootCmd.AddCommand(attachCmd)
+}
+
+// attachTarget is a running agent session offered by gt attach.
+type attachTarget struct {
+	Address string
+	Session string
+	Host    string // Remote rig host, "" when local
+}
+
+func runAttach(cmd *cobra.Command, args []string) error {
+	if _, err := workspace.FindFromCwdOrError(); err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	targets, err := listAttachTargets()
+	if err != nil {
+		return err
+	}
+	if len(targets) == 0 {
+		return fmt.Errorf("no agent sessions running (start some with 'gt up')")
+	}
+
+	query := ""
+	if len(args) == 1 {
+		query = args[0]
+		if t, ok := resolveAttachQuery(query, targets); ok {
+			return attachToTmuxSession(t.Session)
+		}
+		if len(suggest.FuzzyRank(query, attachLabels(targets))) == 0 {
+			return fmt.Errorf("%s", suggest.FormatSuggestion("Agent session", query,
+				suggest.FindSimilar(query, attachLabels(targets), 3), "List sessions with: gt agents --all"))
+		}
+	}
+
+	if !term.IsTerminal(int(os.Stdin.Fd())) || !term.IsTerminal(int(os.Stdout.Fd())) {
+		var matches []string
+		for _, i := range suggest.FuzzyRank(query, attachLabels(targets)) {
+			matches = append(matches, "  "+targets[i].Address)
+		}
+		return fmt.Errorf("no terminal for the picker; pass one of:\n%s", strings.Join(matches, "\n"))
+	}
+
+	items := make([]picker.Item, len(targets))
+	for i, t := range targets {
+		detail := t.Session
+		if t.Host != "" {
+			detail += " @" + t.Host
+		}
+		items[i] = picker.Item{Label: t.Address, Detail: detail}
+	}
+	chosen, err := picker.Run("attach", items, query)
+	if err != nil {
+		return fmt.Errorf("running picker: %w", err)
+	}
+	if chosen < 0 {
+		return NewSilentExit(1)
+	}
+	return attachToTmuxSession(targets[chosen].Session)
+}
+
+// listAttachTargets returns every running agent session, using the same
+// discovery and ordering as 'gt agents' (polecats included).
+func listAttachTargets() ([]attachTarget, error) {
+	sessions, err := getAgentSessions(true)
+	if err != nil {
+		return nil, fmt.Errorf("listing sessions: %w", err)
+	}
+	var targets []attachTarget
+	for _, s := range sessions {
+		identity, err := session.ParseSessionName(s.Name)
+		if err != nil {
+			continue
+		}
+		targets = append(targets, attachTarget{
+			Address: identity.Address(),
+			Session: s.Name,
+			Host:    tmux.HostForSession(s.Name),
+		})
+	}
+	return targets, nil
+}
+
+// resolveAttachQuery returns the target a query names unambiguously: an
+// exact address or session name (case-insensitive), else the only fuzzy match.
+func resolveAttachQuery(query string, targets []attachTarget) (attachTarget, bool) {
+	for _, t := range targets {
+		if strings.EqualFold(query, t.Address) || strings.EqualFold(query, t.Session) {
+			return t, true
+		}
+	}
+	if matches := suggest.FuzzyRank(query, attachLabels(targets)); len(matches) == 1 {
+		return targets[matches[0]], true
+	}
+	return attachTarget{}, false
+}
+
+// attachLabels returns the strings queries are matched against.
+func attachLabels(targets []attachTarget) []string {
+	labels := make([]string, len(targets))
+	for i, t := range targets {
+		labels[i] = t.Address + " " + t.Session
+	}
+	return labels
+}
@@ -0,0 +1,32 @@
+package cmd
+
+import "testing"
+
+func TestResolveAttachQuery(t *testing.T) {
+	targets := []attachTarget{
+		{Address: "mayor", Session: "hq-mayor"},
+		{Address: "gastown/witness", Session: "gt-witness"},
+		{Address: "gastown/crew/max", Session: "gt-crew-max"},
+		{Address: "gastown/polecats/toast", Session: "gt-toast"},
+		{Address: "beads/polecats/toast", Session: "bd-toast"},
+	}
+
+	tests := []struct {
+		query   string
+		want    string
+		resolve bool
+	}{
+		{"mayor", "hq-mayor", true},
+		{"GASTOWN/CREW/MAX", "gt-crew-max", true}, // exact, case-insensitive
+		{"bd-toast", "bd-toast", true},            // session name
+		{"gmax", "gt-crew-max", true},             // single fuzzy match
+		{"toast", "", false},                      // ambiguous: two polecats
+		{"zzz", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := resolveAttachQuery(tt.query, targets)
+		if ok != tt.resolve || got.Session != tt.want {
+			t.Errorf("resolveAttachQuery(%q) = %q, %v; want %q, %v", tt.query, got.Session, ok, tt.want, tt.resolve)
+		}
+	}
+}
@@ -12,19 +12,23 @@ import (
 	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/auditlog"
 	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/events"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/townlog"
+	"github.com/steveyegge/gastown/internal/util"
 	"github.com/steveyegge/gastown/internal/workspace"
 )
 
 // Audit command flags
 var (
-	auditActor string
-	auditSince string
-	auditLimit int
-	auditJSON  bool
+	auditActor     string
+	auditSince     string
+	auditUntil     string
+	auditMutations bool
+	auditLimit     int
+	auditJSON      bool
 )
 
 var auditCmd = &cobra.Command{
@@ -34,6 +38,8 @@ var auditCmd = &cobra.Command{
 	Long: `Query provenance data across git commits, beads, and events.
 
 Shows a unified timeline of work performed by an actor including:
+  - State-changing gt commands (nudges, mail sends, restarts, branch
+    deletes, ...) from the audit log, with arguments and result
   - Git commits authored by the actor
   - Beads (issues) created by the actor
   - Beads closed by the actor (via assignee)
@@ -46,6 +52,8 @@ Examples:
   gt audit --actor=mayor                  # Show mayor's activity
   gt audit --since=24h                    # Show all activity in last 24h
   gt audit --actor=joe --since=1h         # Combined filters
+  gt audit --since=48h --until=24h        # Activity between 2 and 1 days ago
+  gt audit --mutations --actor=mayor      # Only gt commands that changed state
   gt audit --json                         # Output as JSON`,
 	RunE: runAudit,
 }
@@ -53,6 +61,8 @@ Examples:
 func init() {
 	auditCmd.Flags().StringVar(&auditActor, "actor", "", "Filter by actor (agent address or partial match)")
 	auditCmd.Flags().StringVar(&auditSince, "since", "", "Show events since duration (e.g., 1h, 24h, 7d)")
+	auditCmd.Flags().StringVar(&auditUntil, "until", "", "Show events older than duration (e.g., 1h, 24h, 7d)")
+	auditCmd.Flags().BoolVar(&auditMutations, "mutations", false, "Only show state-changing gt commands from the audit log")
 	auditCmd.Flags().IntVarP(&auditLimit, "limit", "n", 50, "Maximum number of entries to show")
 	auditCmd.Flags().BoolVar(&auditJSON, "json", false, "Output as JSON")
 
@@ -62,7 +72,7 @@ func init() {
 // AuditEntry represents a single entry in the audit log.
 type AuditEntry struct {
 	Timestamp time.Time `json:"timestamp"`
-	Source    string    `json:"source"` // "git", "beads", "townlog", "events"
+	Source    string    `json:"source"` // "audit", "git", "beads", "townlog", "events"
 	Type      string    `json:"type"`   // "commit", "bead_created", "bead_closed", "spawn", etc.
 	Actor     string    `json:"actor"`
 	Summary   string    `json:"summary"`
@@ -86,37 +96,39 @@ func runAudit(cmd *cobra.Command, args []string) error {
 		sinceTime = time.Now().Add(-duration)
 	}
 
+	var untilTime time.Time
+	if auditUntil != "" {
+		duration, err := parseDuration(auditUntil)
+		if err != nil {
+			return fmt.Errorf("invalid --until duration: %w", err)
+		}
+		untilTime = time.Now().Add(-duration)
+	}
+
 	// Collect entries from all sources
 	var allEntries []AuditEntry
 
-	// 1. Git commits
-	gitEntries, err := collectGitCommits(townRoot, auditActor, sinceTime)
+	// 1. Audit log of gt mutations
+	mutationEntries, err := collectMutations(townRoot, auditActor, sinceTime, untilTime)
 	if err != nil {
-		// Non-fatal: log and continue
-		fmt.Fprintf(os.Stderr, "Warning: could not query git commits: %v\n", err)
+		logger.Warn("could not read audit log", "err", err)
 	}
-	allEntries = append(allEntries, gitEntries...)
+	allEntries = append(allEntries, mutationEntries...)
 
-	// 2. Beads (created_by, assignee)
-	beadsEntries, err := collectBeadsActivity(townRoot, auditActor, sinceTime)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Warning: could not query beads: %v\n", err)
-	}
-	allEntries = append(allEntries, beadsEntries...)
-
-	// 3. Town log events
-	townlogEntries, err := collectTownlogEvents(townRoot, auditActor, sinceTime)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Warning: could not query town log: %v\n", err)
+	if !auditMutations {
+		allEntries = append(allEntries, collectProvenance(townRoot, sinceTime)...)
 	}
-	allEntries = append(allEntries, townlogEntries...)
 
-	// 4. Activity feed events
-	feedEntries, err := collectFeedEvents(townRoot, auditActor, sinceTime)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Warning: could not query events feed: %v\n", err)
+	// Sources without an upper time bound are trimmed here.
+	if !untilTime.IsZero() {
+		kept := allEntries[:0]
+		for _, e := range allEntries {
+			if !e.Timestamp.After(untilTime) {
+				kept = append(kept, e)
+			}
+		}
+		allEntries = kept
 	}
-	allEntries = append(allEntries, feedEntries...)
 
 	// Sort by timestamp (newest first)
 	sort.Slice(allEntries, func(i, j int) bool {
@@ -144,6 +156,68 @@ func runAudit(cmd *cobra.Command, args []string) error {
 	return outputAuditText(allEntries)
 }
 
+// collectProvenance gathers entries from git, beads, the town log, and the
+// activity feed. Each source is best-effort.
+func collectProvenance(townRoot string, sinceTime time.Time) []AuditEntry {
+	var entries []AuditEntry
+
+	// Git commits
+	gitEntries, err := collectGitCommits(townRoot, auditActor, sinceTime)
+	if err != nil {
+		// Non-fatal: log and continue
+		logger.Warn("could not query git commits", "err", err)
+	}
+	entries = append(entries, gitEntries...)
+
+	// Beads (created_by, assignee)
+	beadsEntries, err := collectBeadsActivity(townRoot, auditActor, sinceTime)
+	if err != nil {
+		logger.Warn("could not query beads", "err", err)
+	}
+	entries = append(entries, beadsEntries...)
+
+	// Town log events
+	townlogEntries, err := collectTownlogEvents(townRoot, auditActor, sinceTime)
+	if err != nil {
+		logger.Warn("could not query town log", "err", err)
+	}
+	entries = append(entries, townlogEntries...)
+
+	// Activity feed events
+	feedEntries, err := collectFeedEvents(townRoot, auditActor, sinceTime)
+	if err != nil {
+		logger.Warn("could not query events feed", "err", err)
+	}
+	return append(entries, feedEntries...)
+}
+
+// collectMutations reads state-changing gt commands from the audit log.
+func collectMutations(townRoot, actor string, since, until time.Time) ([]AuditEntry, error) {
+	records, err := auditlog.Read(townRoot, auditlog.Query{Since: since, Until: until})
+	if err != nil {
+		return nil, err
+	}
+	var entries []AuditEntry
+	for _, r := range records {
+		if actor != "" && !matchesActor(r.Actor, actor) {
+			continue
+		}
+		summary := "gt " + strings.Join(r.Args, " ")
+		if r.Error != "" {
+			summary += " → " + r.Error
+		}
+		entries = append(entries, AuditEntry{
+			Timestamp: r.Timestamp,
+			Source:    "audit",
+			Type:      r.Result,
+			Actor:     r.Actor,
+			Summary:   summary,
+			Details:   r.Command,
+		})
+	}
+	return entries, nil
+}
+
 // parseDuration parses a duration string with support for days (d).
 func parseDuration(s string) (time.Duration, error) {
 	// Check for days suffix
@@ -318,11 +392,12 @@ func collectBeadsActivity(townRoot, actor string, since time.Time) ([]AuditEntry
 
 // parseBeadsTimestamp parses a beads timestamp string.
 func parseBeadsTimestamp(s string) time.Time {
-	// Try various formats
+	if t, err := util.ParseTimestamp(s); err == nil {
+		return t
+	}
+	// Try shorter forms (zone-less, so UTC like the full ones)
 	formats := []string{
-		time.RFC3339,
 		"2006-01-02 15:04",
-		"2006-01-02T15:04:05",
 		"2006-01-02",
 	}
 	for _, format := range formats {
@@ -491,7 +566,7 @@ func outputAuditText(entries []AuditEntry) error {
 	var currentDate string
 
 	for _, e := range entries {
-		date := e.Timestamp.Format("2006-01-02")
+		date := util.DayKey(e.Timestamp, time.Local)
 		if date != currentDate {
 			if currentDate != "" {
 				fmt.Println()
@@ -500,7 +575,7 @@ func outputAuditText(entries []AuditEntry) error {
 			currentDate = date
 		}
 
-		timeStr := e.Timestamp.Format("15:04:05")
+		timeStr := e.Timestamp.Local().Format("15:04:05")
 		sourceStr := formatSource(e.Source)
 		typeStr := formatType(e.Type)
 
@@ -528,6 +603,8 @@ func outputAuditText(entries []AuditEntry) error {
 
 func formatSource(source string) string {
 	switch source {
+	case "audit":
+		return style.Bold.Render("[audit]")
 	case "git":
 		return style.Bold.Render("[git]")
 	case "beads":
@@ -543,6 +620,10 @@ func formatSource(source string) string {
 
 func formatType(t string) string {
 	switch t {
+	case auditlog.ResultOK:
+		return style.Success.Render("ok")
+	case auditlog.ResultError:
+		return style.Error.Render("error")
 	case "commit":
 		return style.Success.Render("commit")
 	case "bead_created":
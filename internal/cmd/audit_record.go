@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/auditlog"
+)
+
+// auditedCommands are the state-changing commands recorded in the audit log,
+// by command path without the binary name. Read-only commands are not
+// recorded.
+var auditedCommands = map[string]bool{
+	// Communication
+	"nudge":                true,
+	"nudge undo":           true,
+	"nudge circuit probe":  true,
+	"broadcast":            true,
+	"exec":                 true,
+	"mail send":            true,
+	"mail reply":           true,
+	"mail archive":         true,
+	"mail delete":          true,
+	"mail clear":           true,
+	"mail mark-read":       true,
+	"mail mark-unread":     true,
+	"mail import":          true,
+	"mail schedule cancel": true,
+	"mail schedule run":    true,
+	"mail channel delete":  true,
+	"mail group delete":    true,
+	"mail group remove":    true,
+	"mail queue delete":    true,
+	"escalate":             true,
+	"quiet":                true,
+	"dnd":                  true,
+	"annotate":             true,
+	"agents speak":         true,
+	"standup":              true,
+
+	// Work assignment
+	"sling":                  true,
+	"dispatch":               true,
+	"unsling":                true,
+	"release":                true,
+	"done":                   true,
+	"handoff":                true,
+	"checkpoint":             true,
+	"restore-checkpoint":     true,
+	"review request":         true,
+	"review approve":         true,
+	"review request-changes": true,
+	"bead deps":              true,
+	"bead move":              true,
+	"mol run":                true,
+	"convoy create":          true,
+	"convoy launch":          true,
+	"convoy throttle":        true,
+	"convoy close":           true,
+	"convoy land":            true,
+
+	// Agent lifecycle
+	"start":               true,
+	"up":                  true,
+	"down":                true,
+	"shutdown":            true,
+	"quiesce":             true,
+	"quiesce resume":      true,
+	"resume":              true,
+	"agents restart":      true,
+	"mayor restart":       true,
+	"deacon restart":      true,
+	"deacon force-kill":   true,
+	"witness restart":     true,
+	"refinery restart":    true,
+	"session start":       true,
+	"session stop":        true,
+	"session restart":     true,
+	"session migrate":     true,
+	"crew add":            true,
+	"crew start":          true,
+	"crew stop":           true,
+	"crew restart":        true,
+	"crew remove":         true,
+	"polecat nuke":        true,
+	"polecat remove":      true,
+	"polecat prune":       true,
+	"polecat scale":       true,
+	"dog remove":          true,
+	"orphans kill":        true,
+	"orphans procs kill":  true,
+	"dolt restart":        true,
+	"dolt kill-imposters": true,
+
+	// Branches, worktrees, and rigs
+	"prune-branches":   true,
+	"worktree remove":  true,
+	"worktree gc":      true,
+	"rig add":          true,
+	"rig remove":       true,
+	"rig start":        true,
+	"rig stop":         true,
+	"rig restart":      true,
+	"rig park":         true,
+	"rig unpark":       true,
+	"rig dock":         true,
+	"rig undock":       true,
+	"town add":         true,
+	"town use":         true,
+	"town remove":      true,
+	"town import":      true,
+	"restore":          true,
+	"incident archive": true,
+	"incident prune":   true,
+	"disk prune":       true,
+	"config set":       true,
+}
+
+// auditCommandPath returns cmd's path without the binary name, e.g. "mail send".
+func auditCommandPath(cmd *cobra.Command) string {
+	path := buildCommandPath(cmd)
+	if i := strings.IndexByte(path, ' '); i >= 0 {
+		return path[i+1:]
+	}
+	return ""
+}
+
+// recordAudit appends a finished command to the town's audit log if it is a
+// mutation. Best-effort: audit failures never change the command's outcome.
+func recordAudit(cmd *cobra.Command, started time.Time, runErr error) {
+	if cmd == nil {
+		return
+	}
+	path := auditCommandPath(cmd)
+	if !auditedCommands[path] {
+		return
+	}
+	if help, _ := cmd.Flags().GetBool("help"); help {
+		return
+	}
+	townRoot := detectTownRootFromCwd()
+	if townRoot == "" {
+		return
+	}
+
+	rec := auditlog.Record{
+		Timestamp: started,
+		Actor:     detectActor(),
+		Command:   path,
+		Args:      os.Args[1:],
+		Result:    auditlog.ResultOK,
+		Duration:  time.Since(started).Milliseconds(),
+		Session:   os.Getenv("GT_SESSION"),
+	}
+	if runErr != nil {
+		rec.Result = auditlog.ResultError
+		rec.Error = runErr.Error()
+	}
+	_ = auditlog.Append(townRoot, rec)
+}
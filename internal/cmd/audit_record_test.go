@@ -0,0 +1,19 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAuditedCommandsExist(t *testing.T) {
+	for path := range auditedCommands {
+		cmd, _, err := rootCmd.Find(strings.Fields(path))
+		if err != nil {
+			t.Errorf("audited command %q not found: %v", path, err)
+			continue
+		}
+		if got := auditCommandPath(cmd); got != path {
+			t.Errorf("audited command %q resolves to %q", path, got)
+		}
+	}
+}
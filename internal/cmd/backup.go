@@ -0,0 +1,346 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/backup"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/doltserver"
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var (
+	backupOutput  string
+	backupDryRun  bool
+	restoreForce  bool
+	restoreNoRigs bool
+)
+
+var backupCmd = &cobra.Command{
+	Use:     "backup",
+	GroupID: GroupWorkspace,
+	Short:   "Write a tarball of the town's state (config, beads, mail)",
+	Long: `Write a gzipped tarball of the town state that can't be rebuilt from git:
+
+  - town config (mayor/, settings/, .beads/, deacon/, plugins/, CLAUDE.md)
+  - rig config (config.json, settings/, .beads/, plugins/, overlay files)
+  - beads databases (.dolt-data/)
+  - mailboxes (witness and refinery mail) and the audit log
+
+Git clones (.repo.git, mayor/rig, refinery/rig, crew and polecat
+worktrees) and runtime state (.runtime/, logs, pid and lock files) are left
+out; 'gt restore' re-clones them from each rig's git URL.
+
+Databases are copied from disk. For a consistent snapshot, stop the Dolt
+server first with 'gt dolt stop'.
+
+Examples:
+  gt backup                          # ./gt-backup-<town>-<time>.tar.gz
+  gt backup -o ~/backups/town.tar.gz
+  gt backup --dry-run                # list what would be archived`,
+	Args: cobra.NoArgs,
+	RunE: runBackup,
+}
+
+var restoreCmd = &cobra.Command{
+	Use:     "restore <backup.tar.gz> [path]",
+	GroupID: GroupWorkspace,
+	Short:   "Recreate a town from a gt backup",
+	Long: `Recreate a town from a tarball written by 'gt backup'.
+
+The backup is unpacked into path (default: where the town lived when it was
+backed up), which must be empty or missing unless --force is given. Then
+each rig's bare repo, mayor clone, and refinery worktree are cloned from its
+git URL, crew workspaces are re-created, the town is added to the town
+registry, and the result is checked for state that won't work on this
+machine: missing clones or databases, stale local paths, and agent
+sessions that are already running under the same names.
+
+Examples:
+  gt restore gt-backup-hq-20261016-120000.tar.gz
+  gt restore town.tar.gz ~/gt
+  gt restore town.tar.gz ~/gt --no-rigs   # unpack only, skip cloning`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runRestore,
+}
+
+func init() {
+	backupCmd.Flags().StringVarP(&backupOutput, "output", "o", "", "Output file (default: ./gt-backup-<town>-<time>.tar.gz)")
+	backupCmd.Flags().BoolVarP(&backupDryRun, "dry-run", "n", false, "List the files that would be archived")
+	restoreCmd.Flags().BoolVar(&restoreForce, "force", false, "Restore into a non-empty directory (existing files are overwritten)")
+	restoreCmd.Flags().BoolVar(&restoreNoRigs, "no-rigs", false, "Unpack only; don't clone rig repositories or create crew")
+
+	rootCmd.AddCommand(backupCmd)
+	rootCmd.AddCommand(restoreCmd)
+}
+
+func runBackup(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	files, err := backup.Collect(townRoot)
+	if err != nil {
+		return err
+	}
+	if backupDryRun {
+		for _, f := range files {
+			fmt.Println(f)
+		}
+		fmt.Printf("\n%d files\n", len(files))
+		return nil
+	}
+
+	m, err := backup.NewManifest(townRoot)
+	if err != nil {
+		return err
+	}
+	if running, _, _ := doltserver.IsRunning(townRoot); running {
+		m.DoltLive = true
+		style.PrintWarning("Dolt server is running; databases are copied live. Stop it with 'gt dolt stop' for a consistent snapshot.")
+	}
+
+	out := backupOutput
+	if out == "" {
+		name := m.Town
+		if name == "" {
+			name = filepath.Base(townRoot)
+		}
+		out = fmt.Sprintf("gt-backup-%s-%s.tar.gz", name, m.CreatedAt.Format("20060102-150405"))
+	}
+	if abs, err := filepath.Abs(out); err == nil {
+		out = abs
+	}
+
+	// Write to a temp file and rename so a failed backup never leaves a
+	// truncated archive behind under the final name.
+	tmp, err := os.CreateTemp(filepath.Dir(out), ".gt-backup-*.tmp")
+	if err != nil {
+		return fmt.Errorf("creating backup file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	skipped, err := backup.Write(tmp, townRoot, m, files)
+	if err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("writing backup: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("writing backup: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), out); err != nil {
+		return fmt.Errorf("writing backup: %w", err)
+	}
+
+	var size int64
+	if info, err := os.Stat(out); err == nil {
+		size = info.Size()
+	}
+	for _, l := range skipped {
+		style.PrintWarning("skipped symlink outside the town: %s", l)
+	}
+	fmt.Printf("%s Wrote %s (%d files, %s)\n", style.SuccessPrefix, out, m.Files, formatBackupSize(size))
+	if len(m.Rigs) > 0 {
+		var names []string
+		for _, r := range m.Rigs {
+			names = append(names, r.Name)
+		}
+		fmt.Printf("  Rigs: %s\n", strings.Join(names, ", "))
+	}
+	fmt.Printf("\nRestore on another machine with: %s\n", style.Dim.Render("gt restore "+filepath.Base(out)+" [path]"))
+	return nil
+}
+
+func formatBackupSize(n int64) string {
+	switch {
+	case n >= 1<<30:
+		return fmt.Sprintf("%.1f GB", float64(n)/(1<<30))
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(n)/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", n)
+	}
+}
+
+func runRestore(cmd *cobra.Command, args []string) error {
+	archive := args[0]
+	m, err := backup.ReadManifest(archive)
+	if err != nil {
+		return err
+	}
+
+	dest := m.TownRoot
+	if len(args) == 2 {
+		dest = args[1]
+	}
+	if dest == "" {
+		return fmt.Errorf("backup does not record where the town lived; pass a path")
+	}
+	if dest, err = filepath.Abs(dest); err != nil {
+		return err
+	}
+	if err := checkRestoreDest(dest, restoreForce); err != nil {
+		return err
+	}
+
+	fmt.Printf("Restoring town %s to %s...\n", style.Bold.Render(m.Town), dest)
+	fmt.Printf("  Backup: %s from %s (%d files)\n", m.CreatedAt.Local().Format("2006-01-02 15:04"), m.Host, m.Files)
+	if m.DoltLive {
+		style.PrintWarning("databases were copied while the Dolt server was running; run 'gt doctor' after starting it")
+	}
+
+	if err := os.MkdirAll(dest, 0755); err != nil {
+		return fmt.Errorf("creating %s: %w", dest, err)
+	}
+	f, err := os.Open(archive) //nolint:gosec // G304: user-supplied backup path
+	if err != nil {
+		return err
+	}
+	_, err = backup.Extract(f, dest)
+	_ = f.Close()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("  %s Unpacked town state\n", style.SuccessPrefix)
+
+	// Everything below runs against the restored town.
+	if err := enterTown(dest); err != nil {
+		return err
+	}
+	if err := session.InitRegistry(dest); err != nil {
+		style.PrintWarning("loading rig prefixes: %v", err)
+	}
+
+	if !restoreNoRigs {
+		restoreRigs(cmd, dest, m)
+	}
+	registerTown(dest, m.Town)
+
+	fmt.Printf("\nValidating restored town...\n")
+	problems := validateRestoredTown(dest, m, tmux.NewTmux().HasSession)
+	if len(problems) == 0 {
+		fmt.Printf("  %s Layout, databases, and sessions look good\n", style.SuccessPrefix)
+	}
+	for _, p := range problems {
+		style.PrintWarning("%s", p)
+	}
+
+	fmt.Printf("\nNext steps:\n")
+	fmt.Printf("  cd %s\n", dest)
+	fmt.Printf("  gt doctor --fix   # repair anything machine-specific\n")
+	fmt.Printf("  gt up             # start the Dolt server, daemon, and agents\n")
+	return nil
+}
+
+// checkRestoreDest refuses to unpack over an existing, non-empty directory
+// unless forced.
+func checkRestoreDest(dest string, force bool) error {
+	entries, err := os.ReadDir(dest)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	if len(entries) > 0 && !force {
+		return fmt.Errorf("%s is not empty (use --force to restore over it)", dest)
+	}
+	return nil
+}
+
+// restoreRigs re-clones each registered rig's checkouts and re-creates the
+// crew workspaces recorded in the backup. Failures are warnings so one
+// unreachable remote doesn't block the rest of the town.
+func restoreRigs(cmd *cobra.Command, townRoot string, m *backup.Manifest) {
+	rigsConfig, err := config.LoadRigsConfig(constants.MayorRigsPath(townRoot))
+	if err != nil {
+		if !errors.Is(err, config.ErrNotFound) {
+			style.PrintWarning("loading rigs registry: %v", err)
+		}
+		return
+	}
+	mgr := rig.NewManager(townRoot, rigsConfig, git.NewGit(townRoot))
+	for _, r := range m.Rigs {
+		if !mgr.RigExists(r.Name) {
+			style.PrintWarning("rig %s is in the backup manifest but not in rigs.json; skipping", r.Name)
+			continue
+		}
+		fmt.Printf("\nCloning rig %s (%s)...\n", style.Bold.Render(r.Name), r.GitURL)
+		if err := mgr.RestoreClones(r.Name); err != nil {
+			style.PrintWarning("%s: %v", r.Name, err)
+			continue
+		}
+		if err := doltserver.EnsureMetadata(townRoot, r.Name); err != nil {
+			style.PrintWarning("%s: setting Dolt metadata: %v", r.Name, err)
+		}
+		fmt.Printf("  %s Restored bare repo, mayor clone, and refinery worktree\n", style.SuccessPrefix)
+
+		if len(r.Crew) == 0 {
+			continue
+		}
+		prevRig := crewRig
+		crewRig = r.Name
+		err := runCrewAdd(cmd, r.Crew)
+		crewRig = prevRig
+		if err != nil {
+			style.PrintWarning("%s: creating crew: %v", r.Name, err)
+		}
+	}
+}
+
+// validateRestoredTown reports what will keep the restored town from
+// starting cleanly on this machine. hasSession reports whether a tmux
+// session exists.
+func validateRestoredTown(townRoot string, m *backup.Manifest, hasSession func(string) (bool, error)) []string {
+	var problems []string
+	if _, err := os.Stat(constants.MayorTownPath(townRoot)); err != nil {
+		problems = append(problems, "mayor/town.json is missing; the backup may be incomplete")
+	}
+	if _, err := os.Stat(filepath.Join(townRoot, ".dolt-data")); err != nil {
+		problems = append(problems, "no beads databases (.dolt-data/) in the backup")
+	}
+
+	sessions := []string{session.MayorSessionName(), session.DeaconSessionName()}
+	for _, r := range m.Rigs {
+		rigPath := filepath.Join(townRoot, r.Name)
+		cfg, err := rig.LoadRigConfig(rigPath)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: config.json unreadable: %v", r.Name, err))
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(rigPath, "mayor", "rig")); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: mayor clone missing (retry with 'gt restore --force', or clone %s)", r.Name, r.GitURL))
+		}
+		if _, err := os.Stat(filepath.Join(townRoot, ".dolt-data", r.Name)); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: beads database .dolt-data/%s is missing", r.Name, r.Name))
+		}
+		if cfg.LocalRepo != "" {
+			if _, err := os.Stat(cfg.LocalRepo); err != nil {
+				problems = append(problems, fmt.Sprintf("%s: local_repo %s does not exist on this machine (clear it in %s/config.json)", r.Name, cfg.LocalRepo, r.Name))
+			}
+		}
+		if r.Prefix != "" {
+			sessions = append(sessions, session.WitnessSessionName(r.Prefix), session.RefinerySessionName(r.Prefix))
+		}
+	}
+
+	for _, s := range sessions {
+		if running, err := hasSession(s); err == nil && running {
+			problems = append(problems, fmt.Sprintf("session %s is already running (another town on this machine?); stop it before 'gt up'", s))
+		}
+	}
+	return problems
+}
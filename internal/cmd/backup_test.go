@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/backup"
+)
+
+func TestCheckRestoreDest(t *testing.T) {
+	dir := t.TempDir()
+	if err := checkRestoreDest(filepath.Join(dir, "new"), false); err != nil {
+		t.Errorf("missing dir: %v", err)
+	}
+	if err := checkRestoreDest(dir, false); err != nil {
+		t.Errorf("empty dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "x"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := checkRestoreDest(dir, false); err == nil {
+		t.Error("non-empty dir should be refused without --force")
+	}
+	if err := checkRestoreDest(dir, true); err != nil {
+		t.Errorf("non-empty dir with --force: %v", err)
+	}
+}
+
+func TestValidateRestoredTown(t *testing.T) {
+	town := t.TempDir()
+	write := func(rel, data string) {
+		p := filepath.Join(town, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("mayor/town.json", `{"name":"hq"}`)
+	write(".dolt-data/api/.dolt/noms/manifest", "m")
+	write("api/config.json", `{"name":"api","git_url":"https://example.com/api.git"}`)
+	write("api/mayor/rig/README.md", "")
+	write("web/config.json", `{"name":"web","local_repo":"/nonexistent/web"}`)
+
+	m := &backup.Manifest{Rigs: []backup.RigInfo{
+		{Name: "api", Prefix: "api"},
+		{Name: "web", Prefix: "wb"},
+	}}
+	running := map[string]bool{"hq-mayor": true, "wb-witness": true}
+	problems := validateRestoredTown(town, m, func(s string) (bool, error) { return running[s], nil })
+
+	joined := strings.Join(problems, "\n")
+	for _, want := range []string{
+		"web: mayor clone missing",
+		"web: beads database .dolt-data/web is missing",
+		"web: local_repo /nonexistent/web does not exist",
+		"session hq-mayor is already running",
+		"session wb-witness is already running",
+	} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("problems missing %q:\n%s", want, joined)
+		}
+	}
+	if strings.Contains(joined, "api:") {
+		t.Errorf("healthy rig reported problems:\n%s", joined)
+	}
+}
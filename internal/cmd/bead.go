@@ -26,6 +26,8 @@ prefix-based routing.
 Subcommands:
   move    Move a bead from one repository to another
   show    Show details of a bead (routes by prefix)
+  deps    Add and remove a bead's dependencies
+  watch   Stream bead changes as they happen
   read    Alias for show`,
 }
 
@@ -196,13 +198,12 @@ func runBeadMove(cmd *cobra.Command, args []string) error {
 	closeCmd.Stderr = os.Stderr
 	if err := closeCmd.Run(); err != nil {
 		// Clean up the new bead since we couldn't close the source
-		fmt.Fprintf(os.Stderr, "Warning: failed to close source bead: %v\n", err)
+		logger.Warn("failed to close source bead", "bead", sourceID, "err", err)
 		cleanupCmd := exec.Command("bd", "close", newID, "--reason", "Cleanup: source bead close failed during move")
 		if cleanupErr := cleanupCmd.Run(); cleanupErr != nil {
-			fmt.Fprintf(os.Stderr, "Warning: also failed to clean up new bead %s: %v\n", newID, cleanupErr)
-			fmt.Fprintf(os.Stderr, "Both %s and %s remain open - manual cleanup needed\n", sourceID, newID)
+			logger.Error("failed to clean up new bead; both remain open, manual cleanup needed", "bead", newID, "source", sourceID, "err", cleanupErr)
 		} else {
-			fmt.Fprintf(os.Stderr, "Cleaned up new bead %s\n", newID)
+			logger.Info("cleaned up new bead", "bead", newID)
 		}
 		return err
 	}
@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/tui/deps"
+	"golang.org/x/term"
+)
+
+var (
+	beadDepsAdd    []string
+	beadDepsRemove []string
+)
+
+var beadDepsCmd = &cobra.Command{
+	Use:   "deps <bead-id>",
+	Short: "Edit a bead's dependencies",
+	Long: `Add and remove dependency edges around a bead.
+
+Opens an editor listing what the bead depends on and what it blocks, with
+the upstream and downstream subgraph drawn below and redrawn after every
+change. Edges that would create a cycle are refused, showing the cycle.
+
+Keys:
+  a          Add a dependency (this bead waits on another)
+  b          Add a dependent (another bead waits on this one)
+  d          Remove the selected edge
+  enter      Open the selected bead
+  backspace  Go back
+  q          Quit
+
+With --add or --remove the edits are applied without the editor (same
+cycle check). Without a terminal, the subgraph is printed.
+
+Examples:
+  gt bead deps gt-abc123
+  gt bead deps gt-abc123 --add gt-def456     # gt-abc123 waits on gt-def456
+  gt bead deps gt-abc123 --remove gt-def456`,
+	Args: cobra.ExactArgs(1),
+	RunE: runBeadDeps,
+}
+
+func init() {
+	beadDepsCmd.Flags().StringSliceVar(&beadDepsAdd, "add", nil, "Add a dependency on this bead (repeatable)")
+	beadDepsCmd.Flags().StringSliceVar(&beadDepsRemove, "remove", nil, "Remove the dependency on this bead (repeatable)")
+	beadCmd.AddCommand(beadDepsCmd)
+}
+
+func runBeadDeps(cmd *cobra.Command, args []string) error {
+	id := args[0]
+	store := beadDepsStore{}
+
+	if len(beadDepsAdd) > 0 || len(beadDepsRemove) > 0 {
+		return applyBeadDeps(deps.NewGraph(store), id)
+	}
+
+	if !term.IsTerminal(int(os.Stdin.Fd())) || !term.IsTerminal(int(os.Stdout.Fd())) {
+		g := deps.NewGraph(store)
+		n, err := g.Node(id)
+		if err != nil {
+			return fmt.Errorf("getting bead %s: %w", id, err)
+		}
+		printBeadDepsGraph(g, n)
+		return nil
+	}
+
+	if _, err := store.Node(id); err != nil {
+		return fmt.Errorf("getting bead %s: %w", id, err)
+	}
+	edits, err := deps.Run(store, id)
+	for _, e := range edits {
+		fmt.Printf("%s %s\n", style.SuccessPrefix, e)
+	}
+	return err
+}
+
+// applyBeadDeps applies --remove then --add to id, stopping at the first
+// refused edge.
+func applyBeadDeps(g *deps.Graph, id string) error {
+	for _, dep := range beadDepsRemove {
+		if err := g.Remove(id, dep); err != nil {
+			return fmt.Errorf("removing %s → %s: %w", id, dep, err)
+		}
+		fmt.Printf("%s %s\n", style.SuccessPrefix, deps.Edit{Issue: id, DependsOn: dep})
+	}
+	for _, dep := range beadDepsAdd {
+		if err := g.Add(id, dep); err != nil {
+			var cycle *deps.CycleError
+			if errors.As(err, &cycle) {
+				return fmt.Errorf("refusing %s → %s: %w", id, dep, err)
+			}
+			return fmt.Errorf("adding %s → %s: %w", id, dep, err)
+		}
+		fmt.Printf("%s %s\n", style.SuccessPrefix, deps.Edit{Added: true, Issue: id, DependsOn: dep})
+	}
+	n, err := g.Node(id)
+	if err != nil {
+		return err
+	}
+	fmt.Println()
+	printBeadDepsGraph(g, n)
+	return nil
+}
+
+// printBeadDepsGraph prints the subgraph around n.
+func printBeadDepsGraph(g *deps.Graph, n *deps.Node) {
+	fmt.Printf("%s\n", style.Bold.Render(n.String()))
+	for _, dir := range []struct {
+		upstream bool
+		label    string
+	}{{true, "Depends on"}, {false, "Blocks"}} {
+		lines := g.Tree(n.ID, dir.upstream, 3)
+		fmt.Printf("\n%s\n", dir.label)
+		if len(lines) == 0 {
+			fmt.Printf("  %s\n", style.Dim.Render("(none)"))
+		}
+		for _, l := range lines {
+			fmt.Printf("  %s\n", l)
+		}
+	}
+}
+
+// beadDepsStore reads and edits blocking dependencies through bd, routing
+// each bead to its rig's database by prefix.
+type beadDepsStore struct{}
+
+func (beadDepsStore) Node(id string) (*deps.Node, error) {
+	issue, err := beads.New(resolveBeadDir(id)).Show(id)
+	if err != nil {
+		return nil, err
+	}
+	n := &deps.Node{ID: issue.ID, Title: issue.Title, Status: issue.Status}
+	for _, d := range issue.Dependencies {
+		if isBlockingDepType(d.DependencyType) {
+			n.DependsOn = append(n.DependsOn, d.ID)
+		}
+	}
+	for _, d := range issue.Dependents {
+		if isBlockingDepType(d.DependencyType) {
+			n.Blocks = append(n.Blocks, d.ID)
+		}
+	}
+	return n, nil
+}
+
+func (beadDepsStore) AddDependency(issue, dependsOn string) error {
+	return beads.New(resolveBeadDir(issue)).AddDependency(issue, dependsOn)
+}
+
+func (beadDepsStore) RemoveDependency(issue, dependsOn string) error {
+	return beads.New(resolveBeadDir(issue)).RemoveDependency(issue, dependsOn)
+}
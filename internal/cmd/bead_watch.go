@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beadwatch"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var (
+	beadWatchRigs     []string
+	beadWatchJSON     bool
+	beadWatchEmit     bool
+	beadWatchInterval time.Duration
+)
+
+var beadWatchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Stream bead changes as they happen",
+	Long: `Watch the town's beads databases and print each bead that is created,
+changed, closed, reopened, or deleted, until interrupted.
+
+Changes are noticed from writes to each .beads directory and the Dolt data
+directory, then found by diffing each database against its last listing;
+every database is also rescanned each --interval in case a write was
+missed. Only changes made after the watch starts are reported.
+
+With --emit, each change is also logged to the town's event log as a
+bead_changed event, so 'gt events -f --topic bead', webhooks, and
+notification rules see it. The daemon does this itself when its bead_watch
+patrol is enabled; don't also use --emit then, or each change is logged
+twice.
+
+Examples:
+  gt bead watch                    # Every database in the town
+  gt bead watch --rig gastown      # One rig's beads
+  gt bead watch --json | jq -r 'select(.change == "closed") | .bead'`,
+	Args: cobra.NoArgs,
+	RunE: runBeadWatch,
+}
+
+func init() {
+	beadWatchCmd.Flags().StringSliceVar(&beadWatchRigs, "rig", nil, "Only watch this rig's beads; \"town\" for town beads (repeatable)")
+	beadWatchCmd.Flags().BoolVar(&beadWatchJSON, "json", false, "Print changes as JSON lines")
+	beadWatchCmd.Flags().BoolVar(&beadWatchEmit, "emit", false, "Log each change as a bead_changed event")
+	beadWatchCmd.Flags().DurationVar(&beadWatchInterval, "interval", beadwatch.DefaultInterval, "Rescan everything this often (0 to only follow writes)")
+	beadCmd.AddCommand(beadWatchCmd)
+}
+
+func runBeadWatch(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	sources, err := beadwatch.Sources(townRoot)
+	if err != nil {
+		return fmt.Errorf("loading rigs: %w", err)
+	}
+	if len(beadWatchRigs) > 0 {
+		var selected []beadwatch.Source
+		for _, name := range beadWatchRigs {
+			found := false
+			for _, src := range sources {
+				if src.Name == name {
+					selected = append(selected, src)
+					found = true
+				}
+			}
+			if !found {
+				return fmt.Errorf("unknown rig %q", name)
+			}
+		}
+		sources = selected
+	}
+
+	w := beadwatch.New(sources)
+	w.Interval = beadWatchInterval
+	w.LogEvents = beadWatchEmit
+	w.Actor = detectSender()
+	w.OnError = func(err error) {
+		fmt.Fprintf(os.Stderr, "%s %v\n", style.WarningPrefix, err)
+	}
+	if err := w.Prime(); err != nil {
+		w.OnError(err)
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	w.Subscribe(func(changes []beadwatch.Change) {
+		for _, c := range changes {
+			if beadWatchJSON {
+				_ = enc.Encode(c)
+				continue
+			}
+			fmt.Println(formatBeadChange(c))
+		}
+	})
+	if !beadWatchJSON {
+		fmt.Printf("%s Watching %d beads database(s); Ctrl-C to stop\n", style.Dim.Render("○"), len(sources))
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	return w.Run(ctx)
+}
+
+// formatBeadChange renders a change as one line, e.g.
+// "gastown  gt-abc12  closed (was in_progress)  Fix login".
+func formatBeadChange(c beadwatch.Change) string {
+	kind := c.Kind
+	if c.PrevStatus != "" {
+		kind = fmt.Sprintf("%s (was %s)", c.Status, c.PrevStatus)
+		if c.Kind == beadwatch.Reopened {
+			kind = fmt.Sprintf("reopened (was %s)", c.PrevStatus)
+		}
+	}
+	line := fmt.Sprintf("%s  %s  %s  %s", style.Dim.Render(c.Source), style.Bold.Render(c.Bead), kind, c.Title)
+	if c.Assignee != "" {
+		line += style.Dim.Render("  → " + c.Assignee)
+	}
+	return line
+}
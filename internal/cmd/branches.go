@@ -0,0 +1,397 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/gofrs/flock"
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/util"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// branchAgingSender is the From address on branch aging mail.
+const branchAgingSender = "gt-branches"
+
+// Aging levels of an unmerged integration branch, in increasing order.
+const (
+	branchAgeOK     = "ok"
+	branchAgeWarn   = "stale"
+	branchAgeMail   = "mail-owner"
+	branchAgeDelete = "delete"
+)
+
+var (
+	branchesJSON   bool
+	branchesNotify bool
+	branchesFetch  bool
+	branchesAll    bool
+)
+
+var branchesCmd = &cobra.Command{
+	Use:     "branches [rig]",
+	GroupID: GroupWork,
+	Short:   "Report unmerged integration branches by age",
+	Long: `Report a rig's unmerged integration branches, oldest first, with the
+agent that owns each (the assignee or creator of its epic).
+
+Landed integration branches are cleaned up when they merge; the ones that
+never land linger. Each branch is aged from its first commit and checked
+against the rig's aging policies (merge_queue.integration_branch_aging in
+settings/config.json, in days):
+
+  warn_days    Reported as stale                         (default 3)
+  mail_days    Owner is mailed, once                     (default 7)
+  delete_days  Flagged for deletion                       (default 14)
+
+The daemon's branch_aging patrol runs 'gt branches --all --fetch --notify'
+every 6 hours, so owners are mailed without anyone watching the report.
+Flagged branches are not deleted automatically: land them with
+'gt mq integration land', or delete them.
+
+The report uses the remote-tracking refs as last fetched; pass --fetch to
+fetch origin first.
+
+Examples:
+  gt branches
+  gt branches gastown --json
+  gt branches --all --fetch --notify   # What the branch_aging patrol runs`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runBranches,
+}
+
+func init() {
+	branchesCmd.Flags().BoolVar(&branchesJSON, "json", false, "Output as JSON")
+	branchesCmd.Flags().BoolVar(&branchesNotify, "notify", false, "Mail owners of branches past the mail threshold")
+	branchesCmd.Flags().BoolVar(&branchesFetch, "fetch", false, "Fetch origin before reporting")
+	branchesCmd.Flags().BoolVar(&branchesAll, "all", false, "Report on every rig")
+
+	rootCmd.AddCommand(branchesCmd)
+}
+
+// integrationBranchAge is one unmerged integration branch in the aging report.
+type integrationBranchAge struct {
+	Branch    string    `json:"branch"`
+	Base      string    `json:"base"`
+	Epic      string    `json:"epic,omitempty"`
+	Owner     string    `json:"owner,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	AgeDays   int       `json:"age_days"`
+	Ahead     int       `json:"ahead"`
+	Level     string    `json:"level"`
+}
+
+func runBranches(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	var rigs []*rig.Rig
+	switch {
+	case branchesAll:
+		if len(args) > 0 {
+			return fmt.Errorf("--all and a rig name are mutually exclusive")
+		}
+		if rigs, _, err = getAllRigs(); err != nil {
+			return err
+		}
+	default:
+		rigName := ""
+		if len(args) > 0 {
+			rigName = args[0]
+		} else if rigName, err = inferRigFromCwd(townRoot); err != nil {
+			return fmt.Errorf("could not determine rig (specify one, or use --all): %w", err)
+		}
+		_, r, err := getRig(rigName)
+		if err != nil {
+			return err
+		}
+		rigs = []*rig.Rig{r}
+	}
+
+	reports := make(map[string][]integrationBranchAge)
+	for _, r := range rigs {
+		var mq *config.MergeQueueConfig
+		if settings, err := config.LoadRigSettings(filepath.Join(r.Path, "settings", "config.json")); err == nil {
+			mq = settings.MergeQueue
+		}
+		policy := mq.GetIntegrationBranchAging()
+
+		report, err := integrationBranchReport(r, policy, time.Now(), branchesFetch)
+		if err != nil {
+			if !branchesAll {
+				return err
+			}
+			style.PrintWarning("%s: %v", r.Name, err)
+			continue
+		}
+		if branchesNotify {
+			notifyAgingBranchOwners(townRoot, r.Name, report)
+		}
+		reports[r.Name] = report
+	}
+
+	if branchesJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if !branchesAll {
+			return enc.Encode(reports[rigs[0].Name])
+		}
+		return enc.Encode(reports)
+	}
+
+	for i, r := range rigs {
+		report, ok := reports[r.Name]
+		if !ok {
+			continue
+		}
+		if i > 0 {
+			fmt.Println()
+		}
+		printBranchReport(r.Name, report)
+	}
+	return nil
+}
+
+// printBranchReport prints one rig's aging report as a table.
+func printBranchReport(rigName string, report []integrationBranchAge) {
+	if len(report) == 0 {
+		fmt.Printf("%s No unmerged integration branches in %s\n", style.Bold.Render("✓"), rigName)
+		return
+	}
+	fmt.Printf("%s Unmerged integration branches in %s (%d)\n\n", style.Bold.Render("🌿"), rigName, len(report))
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "  BRANCH\tAGE\tAHEAD\tOWNER\tEPIC\tSTATUS")
+	for _, b := range report {
+		owner := b.Owner
+		if owner == "" {
+			owner = "-"
+		}
+		epic := b.Epic
+		if epic == "" {
+			epic = "-"
+		}
+		fmt.Fprintf(w, "  %s\t%dd\t%d\t%s\t%s\t%s\n", b.Branch, b.AgeDays, b.Ahead, owner, epic, renderBranchAgeLevel(b.Level))
+	}
+	_ = w.Flush()
+}
+
+// integrationBranchReport lists the rig's unmerged integration branches,
+// local or on origin, oldest first. With fetch it fetches origin first;
+// otherwise origin's branches are as of the last fetch.
+func integrationBranchReport(r *rig.Rig, policy *config.BranchAgingConfig, now time.Time, fetch bool) ([]integrationBranchAge, error) {
+	g, err := getRigGit(r.Path)
+	if err != nil {
+		return nil, fmt.Errorf("initializing git: %w", err)
+	}
+	if fetch {
+		_ = g.Fetch("origin") // Non-fatal: report on what we have
+	}
+
+	pattern := integrationBranchPattern(getIntegrationBranchTemplate(r.Path, ""))
+	seen := make(map[string]bool)
+	var names []string
+	local, _ := g.ListBranches(pattern)
+	remote, _ := g.ListRemoteBranches("origin", pattern)
+	for _, name := range append(local, remote...) {
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+
+	epics := integrationBranchEpics(beads.New(r.Path))
+	var report []integrationBranchAge
+	for _, name := range names {
+		ref := name
+		if exists, _ := g.BranchExists(name); !exists {
+			ref = "origin/" + name
+		}
+
+		entry := integrationBranchAge{Branch: name, Base: r.DefaultBranch()}
+		if epic := epics[name]; epic != nil {
+			entry.Epic = epic.ID
+			entry.Owner = epic.Assignee
+			if entry.Owner == "" {
+				entry.Owner = epic.CreatedBy
+			}
+			if base := beads.GetBaseBranchField(epic.Description); base != "" {
+				entry.Base = base
+			}
+		}
+
+		baseRef := "origin/" + entry.Base
+		if merged, err := g.IsAncestor(ref, baseRef); err == nil && merged {
+			continue
+		}
+		created, err := g.BranchCreatedTime(baseRef, ref)
+		if err != nil {
+			continue
+		}
+		entry.CreatedAt = created
+		entry.AgeDays = int(now.Sub(created).Hours() / 24)
+		entry.Ahead, _ = g.CommitsAhead(baseRef, ref)
+		entry.Level = branchAgeLevel(policy, entry.AgeDays)
+		report = append(report, entry)
+	}
+
+	sort.SliceStable(report, func(i, j int) bool {
+		return report[i].CreatedAt.Before(report[j].CreatedAt)
+	})
+	return report, nil
+}
+
+// integrationBranchPattern returns the branch glob matching integration
+// branches made from template: its literal prefix up to the first variable,
+// or the default integration/ prefix if it starts with one.
+func integrationBranchPattern(template string) string {
+	prefix, _, _ := strings.Cut(template, "{")
+	if prefix == "" {
+		prefix = constants.BranchIntegrationPrefix
+	}
+	return prefix + "*"
+}
+
+// integrationBranchEpics maps integration branch names to the epics that
+// recorded them. Best effort: an empty map if beads can't be listed.
+func integrationBranchEpics(bd *beads.Beads) map[string]*beads.Issue {
+	result := make(map[string]*beads.Issue)
+	epics, err := bd.List(beads.ListOptions{Type: "epic", Status: "all", Priority: -1})
+	if err != nil {
+		return result
+	}
+	for _, epic := range epics {
+		if branch := beads.GetIntegrationBranchField(epic.Description); branch != "" {
+			result[branch] = epic
+		}
+	}
+	return result
+}
+
+// branchAgeLevel returns the highest aging policy a branch of ageDays has
+// reached. Thresholds of zero are disabled.
+func branchAgeLevel(policy *config.BranchAgingConfig, ageDays int) string {
+	reached := func(days int) bool { return days > 0 && ageDays >= days }
+	switch {
+	case reached(policy.DeleteDays):
+		return branchAgeDelete
+	case reached(policy.MailDays):
+		return branchAgeMail
+	case reached(policy.WarnDays):
+		return branchAgeWarn
+	}
+	return branchAgeOK
+}
+
+func renderBranchAgeLevel(level string) string {
+	switch level {
+	case branchAgeDelete:
+		return style.Error.Render("flagged for deletion")
+	case branchAgeMail:
+		return style.Warning.Render("mail owner")
+	case branchAgeWarn:
+		return style.Warning.Render("stale")
+	}
+	return style.Dim.Render("ok")
+}
+
+// branchAgingNotifiedPath records which integration branches' owners have
+// been mailed about them.
+func branchAgingNotifiedPath(townRoot string) string {
+	return filepath.Join(townRoot, ".runtime", "branch-aging-notified.json")
+}
+
+// notifyAgingBranchOwners mails the owner of each branch past the mail
+// threshold, once per branch. Branches without a known owner are skipped.
+// Failures are warnings.
+func notifyAgingBranchOwners(townRoot, rigName string, report []integrationBranchAge) {
+	router := mail.NewRouter(townRoot)
+	defer router.WaitPendingNotifications()
+	err := notifyBranchesOnce(townRoot, rigName, report, func(b integrationBranchAge) error {
+		if err := router.Send(agingBranchMail(rigName, b)); err != nil {
+			style.PrintWarning("could not mail %s about %s: %v", b.Owner, b.Branch, err)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		style.PrintWarning("could not record branch aging notifications: %v", err)
+	}
+}
+
+// notifyBranchesOnce calls send for each owned branch past the mail
+// threshold that hasn't been notified yet, and records the ones sent. The
+// record is held under a lock for the whole pass so concurrent runs (a
+// patrol and a manual --notify) don't mail the same owner twice.
+func notifyBranchesOnce(townRoot, rigName string, report []integrationBranchAge, send func(integrationBranchAge) error) error {
+	path := branchAgingNotifiedPath(townRoot)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	lock := flock.New(path + ".lock")
+	if err := lock.Lock(); err != nil {
+		return fmt.Errorf("locking %s: %w", path, err)
+	}
+	defer func() { _ = lock.Unlock() }()
+
+	notified := make(map[string]string) // rig/branch → branch created_at
+	if data, err := os.ReadFile(path); err == nil {
+		_ = json.Unmarshal(data, &notified)
+	}
+
+	changed := false
+	for _, b := range report {
+		if (b.Level != branchAgeMail && b.Level != branchAgeDelete) || b.Owner == "" {
+			continue
+		}
+		key := rigName + "/" + b.Branch
+		created := b.CreatedAt.UTC().Format(time.RFC3339)
+		if notified[key] == created {
+			continue
+		}
+		if err := send(b); err != nil {
+			continue
+		}
+		notified[key] = created
+		changed = true
+	}
+
+	if !changed {
+		return nil
+	}
+	return util.AtomicWriteJSON(path, notified)
+}
+
+// agingBranchMail is the notification sent to a stale branch's owner.
+func agingBranchMail(rigName string, b integrationBranchAge) *mail.Message {
+	var body strings.Builder
+	fmt.Fprintf(&body, "Integration branch %s in %s has not merged into %s after %d days.\n\n", b.Branch, rigName, b.Base, b.AgeDays)
+	if b.Epic != "" {
+		fmt.Fprintf(&body, "Epic:  %s\n", b.Epic)
+	}
+	fmt.Fprintf(&body, "Ahead: %d commit(s)\n\n", b.Ahead)
+	body.WriteString("Land it with 'gt mq integration land', or delete it if the work was abandoned.\n")
+	body.WriteString("Run 'gt branches' for the rig's aging report.")
+
+	return &mail.Message{
+		From:     branchAgingSender,
+		To:       b.Owner,
+		Subject:  fmt.Sprintf("STALE BRANCH: %s (%d days unmerged)", b.Branch, b.AgeDays),
+		Body:     body.String(),
+		Type:     mail.TypeNotification,
+		Priority: mail.PriorityNormal,
+	}
+}
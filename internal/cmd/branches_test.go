@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+func TestBranchAgeLevel(t *testing.T) {
+	policy := config.DefaultBranchAgingConfig()
+	tests := []struct {
+		ageDays int
+		want    string
+	}{
+		{0, branchAgeOK},
+		{2, branchAgeOK},
+		{3, branchAgeWarn},
+		{7, branchAgeMail},
+		{13, branchAgeMail},
+		{14, branchAgeDelete},
+		{90, branchAgeDelete},
+	}
+	for _, tt := range tests {
+		if got := branchAgeLevel(policy, tt.ageDays); got != tt.want {
+			t.Errorf("branchAgeLevel(%d) = %q, want %q", tt.ageDays, got, tt.want)
+		}
+	}
+
+	// Zero thresholds are disabled.
+	noDelete := &config.BranchAgingConfig{WarnDays: 3, MailDays: 0, DeleteDays: 0}
+	if got := branchAgeLevel(noDelete, 30); got != branchAgeWarn {
+		t.Errorf("branchAgeLevel with mail/delete disabled = %q, want %q", got, branchAgeWarn)
+	}
+}
+
+func TestIntegrationBranchPattern(t *testing.T) {
+	tests := map[string]string{
+		"integration/{title}":    "integration/*",
+		"integration/{epic}":     "integration/*",
+		"int/{prefix}/{epic}":    "int/*",
+		"{user}/integration/{x}": "integration/*",
+	}
+	for template, want := range tests {
+		if got := integrationBranchPattern(template); got != want {
+			t.Errorf("integrationBranchPattern(%q) = %q, want %q", template, got, want)
+		}
+	}
+}
+
+func TestNotifyBranchesOnce(t *testing.T) {
+	townRoot := t.TempDir()
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	report := []integrationBranchAge{
+		{Branch: "integration/a", Owner: "gastown/crew/max", CreatedAt: created, Level: branchAgeMail},
+		{Branch: "integration/b", Owner: "gastown/crew/max", CreatedAt: created, Level: branchAgeWarn},
+		{Branch: "integration/c", CreatedAt: created, Level: branchAgeDelete}, // No owner
+		{Branch: "integration/d", Owner: "mayor/", CreatedAt: created, Level: branchAgeDelete},
+	}
+
+	var sent []string
+	send := func(b integrationBranchAge) error {
+		sent = append(sent, b.Branch)
+		if b.Branch == "integration/d" {
+			return errors.New("mail failed")
+		}
+		return nil
+	}
+	if err := notifyBranchesOnce(townRoot, "gastown", report, send); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"integration/a", "integration/d"}; !reflect.DeepEqual(sent, want) {
+		t.Errorf("first pass sent %v, want %v", sent, want)
+	}
+
+	// A is recorded and not mailed again; D failed, so it is retried. A
+	// branch recreated under the same name is a new branch.
+	sent = nil
+	report = append(report, integrationBranchAge{Branch: "integration/a", Owner: "gastown/crew/max", CreatedAt: created.Add(time.Hour), Level: branchAgeMail})
+	if err := notifyBranchesOnce(townRoot, "gastown", report, send); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"integration/d", "integration/a"}; !reflect.DeepEqual(sent, want) {
+		t.Errorf("second pass sent %v, want %v", sent, want)
+	}
+	if _, err := os.Stat(branchAgingNotifiedPath(townRoot)); err != nil {
+		t.Errorf("notified state not written: %v", err)
+	}
+}
@@ -0,0 +1,251 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gofrs/flock"
+	"github.com/steveyegge/gastown/internal/budget"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// budgetSender is the From address on budget mail.
+const budgetSender = "gt-budget"
+
+// budgetReportCacheTTL is how long a computed budget report is reused. The
+// scheduler enforces budgets on every daemon heartbeat, and each report
+// reads the cost log and digest beads for the whole period.
+const budgetReportCacheTTL = 5 * time.Minute
+
+// budgetReportCache is the last computed report, under .runtime/.
+type budgetReportCache struct {
+	Config   string         `json:"config"` // The caps it was computed for
+	Computed time.Time      `json:"computed"`
+	Report   *budget.Report `json:"report"`
+}
+
+func budgetReportCachePath(townRoot string) string {
+	return filepath.Join(constants.TownRuntimePath(townRoot), "budget-report.json")
+}
+
+// loadBudgetReport evaluates the town's budget caps against spend so far in
+// the current period, reusing a report computed in the last
+// budgetReportCacheTTL. It returns nil when no caps are configured.
+func loadBudgetReport(townRoot string, now time.Time) (*budget.Report, error) {
+	settings, err := config.LoadOrCreateTownSettings(config.TownSettingsPath(townRoot))
+	if err != nil {
+		return nil, fmt.Errorf("loading town settings: %w", err)
+	}
+	cfg := settings.Budget
+	if !cfg.Enabled() {
+		return nil, nil
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cachedBudgetReport(budgetReportCachePath(townRoot), cfg, now, func() (*budget.Report, error) {
+		entries, err := costEntriesSince(cfg.PeriodStart(now), now)
+		if err != nil {
+			return nil, err
+		}
+		return budget.Evaluate(cfg, budgetSpend(entries), now), nil
+	})
+}
+
+// cachedBudgetReport returns the report cached at path if it is fresh and
+// was computed for the same caps and period, otherwise computes and caches
+// a new one.
+func cachedBudgetReport(path string, cfg *budget.Config, now time.Time, compute func() (*budget.Report, error)) (*budget.Report, error) {
+	key, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	var cached budgetReportCache
+	if data, err := os.ReadFile(path); err == nil && json.Unmarshal(data, &cached) == nil && cached.Report != nil { //nolint:gosec // G304: path is under townRoot
+		age := now.Sub(cached.Computed)
+		if cached.Config == string(key) && age >= 0 && age < budgetReportCacheTTL && cached.Report.Since.Equal(cfg.PeriodStart(now)) {
+			return cached.Report, nil
+		}
+	}
+
+	r, err := compute()
+	if err != nil {
+		return nil, err
+	}
+	_ = util.EnsureDirAndWriteJSON(path, budgetReportCache{Config: string(key), Computed: now, Report: r}) // Best effort
+	return r, nil
+}
+
+// costEntriesSince returns recorded session costs from start through now:
+// undigested entries from the costs log, plus digest beads for earlier days.
+func costEntriesSince(start, now time.Time) ([]CostEntry, error) {
+	var entries []CostEntry
+	for day := start; !day.After(now); day = day.AddDate(0, 0, 1) {
+		dayEntries, err := querySessionCostEntries(day)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, dayEntries...)
+	}
+	if days := int(math.Ceil(now.Sub(start).Hours() / 24)); days > 1 {
+		digested, err := queryDigestBeads(days)
+		if err != nil {
+			return nil, err
+		}
+		startDay := util.DayKey(start, time.Local)
+		for _, e := range digested {
+			if util.DayKey(e.EndedAt, time.Local) >= startDay {
+				entries = append(entries, e)
+			}
+		}
+	}
+	return entries, nil
+}
+
+// budgetSpend totals cost entries by rig and agent.
+func budgetSpend(entries []CostEntry) budget.Spend {
+	spend := budget.Spend{ByRig: make(map[string]float64), ByAgent: make(map[string]float64)}
+	for _, e := range entries {
+		if e.Rig != "" {
+			spend.ByRig[e.Rig] += e.CostUSD
+		}
+		spend.ByAgent[costEntryAgent(e)] += e.CostUSD
+	}
+	return spend
+}
+
+// budgetNotifiedPath records which exceeded budgets the mayor has been told
+// about this period.
+func budgetNotifiedPath(townRoot string) string {
+	return filepath.Join(townRoot, ".runtime", "budget-notified.json")
+}
+
+// notifyBudgetExceeded mails the mayor once per period about each budget
+// that is over its cap. Failures are warnings: enforcement doesn't depend
+// on the mail.
+func notifyBudgetExceeded(townRoot string, r *budget.Report) {
+	if len(r.Exceeded()) == 0 {
+		return
+	}
+	err := notifyBudgetOnce(budgetNotifiedPath(townRoot), r, func(fresh []budget.State) error {
+		router := mail.NewRouter(townRoot)
+		defer router.WaitPendingNotifications()
+		return router.Send(budgetExceededMail(r, fresh))
+	})
+	if err != nil {
+		style.PrintWarning("could not mail mayor about exceeded budgets: %v", err)
+	}
+}
+
+// notifyBudgetOnce calls send with the exceeded budgets not yet notified
+// this period, and records them once sent. The record is held under a lock
+// for the whole pass so concurrent dispatch runs don't mail twice.
+func notifyBudgetOnce(path string, r *budget.Report, send func([]budget.State) error) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	lock := flock.New(path + ".lock")
+	if err := lock.Lock(); err != nil {
+		return fmt.Errorf("locking %s: %w", path, err)
+	}
+	defer func() { _ = lock.Unlock() }()
+
+	notified := make(map[string]string) // scope:name → period start
+	if data, err := os.ReadFile(path); err == nil {
+		_ = json.Unmarshal(data, &notified)
+	}
+	period := r.Since.Format(time.RFC3339)
+
+	var fresh []budget.State
+	for _, s := range r.Exceeded() {
+		key := s.Scope + ":" + s.Name
+		if notified[key] != period {
+			fresh = append(fresh, s)
+			notified[key] = period
+		}
+	}
+	if len(fresh) == 0 {
+		return nil
+	}
+	if err := send(fresh); err != nil {
+		return err
+	}
+	return util.AtomicWriteJSON(path, notified)
+}
+
+// budgetExceededMail is the mayor's notice of newly exceeded budgets.
+func budgetExceededMail(r *budget.Report, fresh []budget.State) *mail.Message {
+	var body strings.Builder
+	fmt.Fprintf(&body, "These budgets are spent for this %s (since %s):\n\n", r.Period, r.Since.Format("2006-01-02"))
+	var names []string
+	for _, s := range fresh {
+		fmt.Fprintf(&body, "- %s %s: $%.2f of $%.2f\n", s.Scope, s.Name, s.Spent, s.Cap)
+		names = append(names, s.Name)
+	}
+	body.WriteString("\nDispatch to them is paused until the period rolls over or the caps\n")
+	body.WriteString("in settings/config.json (\"budget\") are raised. Work already hooked continues.")
+
+	return &mail.Message{
+		From:     budgetSender,
+		To:       "mayor/",
+		Subject:  "BUDGET EXCEEDED: " + strings.Join(names, ", "),
+		Body:     body.String(),
+		Type:     mail.TypeNotification,
+		Priority: mail.PriorityHigh,
+	}
+}
+
+// enforceBudget loads the budget report for a dispatch run and notifies the
+// mayor of newly exceeded budgets (not on dry runs). A report that can't be
+// computed is a warning and pauses nothing.
+func enforceBudget(townRoot string, dryRun bool) *budget.Report {
+	r, err := loadBudgetReport(townRoot, time.Now())
+	if err != nil {
+		style.PrintWarning("budget not enforced: %v", err)
+		return nil
+	}
+	if r != nil && !dryRun {
+		notifyBudgetExceeded(townRoot, r)
+	}
+	return r
+}
+
+// outputBudgetText renders the budget section of gt status: exceeded and
+// nearly spent budgets, or a one-line all-clear.
+func outputBudgetText(w io.Writer, r *budget.Report) {
+	if r == nil || len(r.States) == 0 {
+		return
+	}
+	var flagged []budget.State
+	for _, s := range r.States {
+		if s.Exceeded || s.Warning {
+			flagged = append(flagged, s)
+		}
+	}
+	if len(flagged) == 0 {
+		fmt.Fprintf(w, "%s %s\n\n", style.Bold.Render("Budget:"),
+			style.Dim.Render(fmt.Sprintf("%d within limits this %s", len(r.States), r.Period)))
+		return
+	}
+	fmt.Fprintf(w, "%s %s\n", style.Bold.Render("Budget:"), style.Dim.Render("this "+r.Period))
+	for _, s := range flagged {
+		mark := style.Warning.Render("⚠")
+		note := fmt.Sprintf("%.0f%%", s.Fraction()*100)
+		if s.Exceeded {
+			mark = style.Error.Render("⏸")
+			note = "dispatch paused"
+		}
+		fmt.Fprintf(w, "  %s %-5s %-28s $%.2f / $%.2f  %s\n", mark, s.Scope, s.Name, s.Spent, s.Cap, style.Dim.Render(note))
+	}
+	fmt.Fprintln(w)
+}
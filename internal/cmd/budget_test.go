@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/budget"
+	"github.com/steveyegge/gastown/internal/scheduler/assign"
+	"github.com/steveyegge/gastown/internal/scheduler/capacity"
+)
+
+func TestBudgetEnforcement(t *testing.T) {
+	spend := budgetSpend([]CostEntry{
+		{Role: "polecat", Rig: "gastown", Worker: "nux", CostUSD: 6},
+		{Role: "crew", Rig: "gastown", Worker: "max", CostUSD: 2},
+		{Role: "polecat", Rig: "beads", Worker: "ace", CostUSD: 30},
+		{Role: "mayor", Worker: "mayor", CostUSD: 1},
+	})
+	if spend.ByRig["gastown"] != 8 || spend.ByAgent["gastown/polecats/nux"] != 6 || spend.ByAgent["mayor"] != 1 {
+		t.Fatalf("spend = %+v", spend)
+	}
+
+	cfg := &budget.Config{
+		Rigs:   map[string]float64{"beads": 25},
+		Agents: map[string]float64{"*/polecats/*": 5},
+	}
+	r := budget.Evaluate(cfg, spend, time.Now())
+
+	agents, paused := filterBudgetAgents(r, []assign.Agent{
+		{Address: "gastown/polecats/nux", Rig: "gastown", Role: "polecat"},
+		{Address: "gastown/crew/max", Rig: "gastown", Role: "crew"},
+		{Address: "beads/crew/zoe", Rig: "beads", Role: "crew"},
+	})
+	if len(agents) != 1 || agents[0].Address != "gastown/crew/max" {
+		t.Errorf("kept %+v, want only gastown/crew/max", agents)
+	}
+	if len(paused) != 2 || paused["beads/crew/zoe"] == "" {
+		t.Errorf("paused = %v, want nux (own budget) and zoe (rig budget)", paused)
+	}
+
+	pending := filterBudgetPending(r, []capacity.PendingBead{
+		{ID: "c1", TargetRig: "gastown"},
+		{ID: "c2", TargetRig: "beads"},
+	})
+	if len(pending) != 1 || pending[0].ID != "c1" {
+		t.Errorf("pending = %+v, want only the gastown bead", pending)
+	}
+
+	if kept, paused := filterBudgetAgents(nil, agents); len(kept) != 1 || paused != nil {
+		t.Errorf("no budget configured: kept %v paused %v", kept, paused)
+	}
+}
+
+func TestCachedBudgetReport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "budget-report.json")
+	cfg := &budget.Config{Rigs: map[string]float64{"gastown": 10}}
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.Local)
+	computed := 0
+	compute := func() (*budget.Report, error) {
+		computed++
+		return budget.Evaluate(cfg, budget.Spend{ByRig: map[string]float64{"gastown": float64(computed)}}, now), nil
+	}
+
+	for _, at := range []time.Time{now, now.Add(time.Minute)} {
+		if _, err := cachedBudgetReport(path, cfg, at, compute); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if computed != 1 {
+		t.Errorf("computed %d times within the TTL, want 1", computed)
+	}
+
+	r, _ := cachedBudgetReport(path, cfg, now.Add(budgetReportCacheTTL), compute)
+	if computed != 2 || r.States[0].Spent != 2 {
+		t.Errorf("after the TTL: computed %d times, spent %v; want a fresh report", computed, r.States[0].Spent)
+	}
+
+	cfg.Rigs["gastown"] = 20 // Changed caps invalidate the cache
+	if _, err := cachedBudgetReport(path, cfg, now.Add(budgetReportCacheTTL), compute); err != nil || computed != 3 {
+		t.Errorf("computed %d times after the caps changed, want 3", computed)
+	}
+}
+
+func TestNotifyBudgetOnce(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "budget-notified.json")
+	cfg := &budget.Config{Rigs: map[string]float64{"gastown": 5, "beads": 5}}
+	now := time.Now()
+	var sent []string
+	send := func(fresh []budget.State) error {
+		for _, s := range fresh {
+			sent = append(sent, s.Name)
+		}
+		return nil
+	}
+
+	r := budget.Evaluate(cfg, budget.Spend{ByRig: map[string]float64{"gastown": 6}}, now)
+	if err := notifyBudgetOnce(path, r, send); err != nil {
+		t.Fatal(err)
+	}
+	r = budget.Evaluate(cfg, budget.Spend{ByRig: map[string]float64{"gastown": 7, "beads": 9}}, now)
+	if err := notifyBudgetOnce(path, r, send); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(sent, ",") != "gastown,beads" {
+		t.Errorf("sent %v, want gastown once then beads", sent)
+	}
+
+	failing := func([]budget.State) error { return errors.New("mail down") }
+	r = budget.Evaluate(&budget.Config{Rigs: map[string]float64{"wyvern": 1}}, budget.Spend{ByRig: map[string]float64{"wyvern": 2}}, now)
+	if err := notifyBudgetOnce(path, r, failing); err == nil {
+		t.Fatal("expected the send error")
+	}
+	if err := notifyBudgetOnce(path, r, send); err != nil || sent[len(sent)-1] != "wyvern" {
+		t.Errorf("a failed send was recorded as notified: sent %v, %v", sent, err)
+	}
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/gofrs/flock"
 	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/budget"
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/events"
 	"github.com/steveyegge/gastown/internal/scheduler/capacity"
@@ -93,6 +94,9 @@ func dispatchScheduledWork(townRoot, actor string, batchOverride int, dryRun boo
 		cleanupStaleContexts(townRoot)
 	}
 
+	// Beads for rigs over budget stay queued until the period rolls over.
+	budgetReport := enforceBudget(townRoot, dryRun)
+
 	// Wire up the DispatchCycle
 	successfulRigs := make(map[string]bool)
 	// Track polecat names from dispatch results, keyed by context bead ID.
@@ -107,7 +111,11 @@ func dispatchScheduledWork(townRoot, actor string, batchOverride int, dryRun boo
 			return cap, nil
 		},
 		QueryPending: func() ([]capacity.PendingBead, error) {
-			return getReadySlingContexts(townRoot)
+			pending, err := getReadySlingContexts(townRoot)
+			if err != nil {
+				return nil, err
+			}
+			return filterBudgetPending(budgetReport, pending), nil
 		},
 		Execute: func(b capacity.PendingBead) error {
 			result, err := dispatchSingleBead(b, townRoot, actor)
@@ -134,13 +142,11 @@ func dispatchScheduledWork(townRoot, actor string, batchOverride int, dryRun boo
 			if errors.As(err, &onSuccessErr) {
 				// Polecat launched but context close failed — not a true dispatch failure.
 				// Log a distinct warning so operators can distinguish from "polecat never launched".
-				fmt.Fprintf(os.Stderr, "%s Dispatch of %s succeeded but context close failed: %v\n",
-					style.Warning.Render("⚠"), b.WorkBeadID, err)
+				logger.Warn("dispatch succeeded but context close failed", "bead", b.WorkBeadID, "context", b.ID, "err", err)
 				// Last-resort close attempt to prevent double-dispatch on next cycle.
 				// OnSuccess already retried 2x; this is a final attempt before circuit-breaking.
 				if closeErr := townBeads.CloseSlingContext(b.ID, "dispatch-close-failed"); closeErr != nil {
-					fmt.Fprintf(os.Stderr, "%s CRITICAL: last-resort close of %s failed — risk of double-dispatch for %s: %v\n",
-						style.Warning.Render("⚠"), b.ID, b.WorkBeadID, closeErr)
+					logger.Error("last-resort context close failed; risk of double-dispatch", "context", b.ID, "bead", b.WorkBeadID, "err", closeErr)
 				} else {
 					// Last-resort close succeeded — context is now closed.
 					// Log feed event so dashboards can detect bead DB degradation.
@@ -496,8 +502,7 @@ func listReadyWorkBeadIDsWithError(townRoot string) (map[string]bool, error) {
 		if err != nil {
 			failCount++
 			lastErr = err
-			fmt.Fprintf(os.Stderr, "%s Warning: bd ready failed for %s: %v\n",
-				style.Dim.Render("⚠"), dir, err)
+			logger.Warn("bd ready failed", "dir", dir, "err", err)
 			continue
 		}
 		var readyBeads []struct {
@@ -525,3 +530,17 @@ func listReadyWorkBeadIDs(townRoot string) map[string]bool {
 	return ids
 }
 
+// filterBudgetPending holds back queued beads whose target rig is over
+// budget.
+func filterBudgetPending(r *budget.Report, pending []capacity.PendingBead) []capacity.PendingBead {
+	if r == nil {
+		return pending
+	}
+	var kept []capacity.PendingBead
+	for _, b := range pending {
+		if _, blocked := r.Blocks(b.TargetRig, ""); !blocked {
+			kept = append(kept, b)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,409 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/checkpoint"
+	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/redact"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// Size caps for what a checkpoint bead stores.
+const (
+	checkpointMaxDiffBytes       = 100 * 1024
+	checkpointMaxScrollbackBytes = 64 * 1024
+	checkpointSummaryTailLines   = 15
+)
+
+var restoreCheckpointCmd = &cobra.Command{
+	Use:     "restore-checkpoint <checkpoint-id>",
+	GroupID: GroupDiag,
+	Short:   "Re-seed an agent's session from a checkpoint bead",
+	Long: `Re-seed a fresh session from a checkpoint taken with gt checkpoint <agent>.
+
+The checkpoint's summary is hooked to the agent as mail, so gt prime shows
+it at the start of the next session along with pointers to the full
+snapshot (diff and scrollback) in the checkpoint bead. If the checkpointed
+work bead has lost its owner, it is hooked back to the agent.
+
+If the agent's session is running, it is restarted so the fresh session
+picks up the checkpoint immediately. Use --no-restart to leave it alone;
+the checkpoint then waits on the hook for the next session.
+
+Use --agent to restore onto a different agent than the one checkpointed,
+e.g. after moving work to a crew member running another model.
+
+Examples:
+  gt restore-checkpoint hq-abc12
+  gt restore-checkpoint hq-abc12 --agent gastown/crew/max
+  gt restore-checkpoint hq-abc12 --no-restart`,
+	Args: cobra.ExactArgs(1),
+	RunE: runRestoreCheckpoint,
+}
+
+var (
+	checkpointLines      int
+	checkpointNoSummary  bool
+	checkpointSummarizer string
+
+	restoreCheckpointAgent     string
+	restoreCheckpointNoRestart bool
+	restoreCheckpointDryRun    bool
+)
+
+func init() {
+	checkpointCmd.Flags().IntVarP(&checkpointLines, "lines", "n", 300,
+		"Lines of pane scrollback to capture")
+	checkpointCmd.Flags().BoolVar(&checkpointNoSummary, "no-summary", false,
+		"Skip the generated summary (use a plain one)")
+	checkpointCmd.Flags().StringVar(&checkpointSummarizer, "summarizer", "",
+		"Agent that writes the summary (default: mail_summary_agent setting, else claude haiku)")
+
+	restoreCheckpointCmd.Flags().StringVar(&restoreCheckpointAgent, "agent", "",
+		"Restore onto this agent instead of the checkpointed one")
+	restoreCheckpointCmd.Flags().BoolVar(&restoreCheckpointNoRestart, "no-restart", false,
+		"Don't restart a running session; wait for the next one")
+	restoreCheckpointCmd.Flags().BoolVar(&restoreCheckpointDryRun, "dry-run", false,
+		"Show what would be done without doing it")
+
+	rootCmd.AddCommand(restoreCheckpointCmd)
+}
+
+func runCheckpointAgent(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	sessionName, err := resolveRoleToSession(args[0])
+	if err != nil {
+		return err
+	}
+
+	id, snap, err := checkpointSession(townRoot, sessionName, !checkpointNoSummary)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s Checkpoint %s saved for %s\n", style.Bold.Render("✓"), id, snap.Agent)
+	if snap.HookedBead != "" {
+		fmt.Printf("  Hooked: %s\n", snap.HookedBead)
+	}
+	if snap.Branch != "" {
+		fmt.Printf("  Branch: %s (%d modified file(s))\n", snap.Branch, len(snap.ModifiedFiles))
+	}
+	fmt.Printf("  Restore with: gt restore-checkpoint %s\n", id)
+	return nil
+}
+
+// checkpointSession snapshots an agent's session into a checkpoint bead
+// and returns the bead ID. The summary is generated only with summarize.
+func checkpointSession(townRoot, sessionName string, summarize bool) (string, *checkpoint.Snapshot, error) {
+	agentID := sessionToAgentID(sessionName)
+
+	snap := &checkpoint.Snapshot{
+		Agent:      agentID,
+		Session:    sessionName,
+		CapturedAt: time.Now(),
+		CapturedBy: detectSender(),
+	}
+
+	// The pane is best-effort: a crashed agent's session may be gone, and
+	// the rest of the snapshot is still worth keeping.
+	t := tmux.NewTmux()
+	if running, _ := t.HasSession(sessionName); running {
+		if lines, err := t.CapturePaneLines(sessionName, checkpointLines); err == nil {
+			snap.Scrollback = checkpoint.Clip(strings.TrimRight(strings.Join(lines, "\n"), "\n "), checkpointMaxScrollbackBytes)
+		} else {
+			style.PrintWarning("could not capture %s scrollback: %v", sessionName, err)
+		}
+		if dir, err := t.GetPaneWorkDir(sessionName); err == nil && dir != "" {
+			snap.WorkDir = dir
+		}
+	} else {
+		style.PrintWarning("session %s is not running; checkpointing without scrollback", sessionName)
+	}
+	if snap.WorkDir == "" {
+		if dir, err := sessionWorkDir(sessionName, townRoot); err == nil {
+			snap.WorkDir = dir
+		}
+	}
+
+	if snap.WorkDir != "" {
+		if cp, err := checkpoint.Capture(snap.WorkDir); err == nil {
+			snap.Branch = cp.Branch
+			snap.LastCommit = cp.LastCommit
+			snap.ModifiedFiles = cp.ModifiedFiles
+		}
+		snap.Diff = checkpoint.CaptureDiff(snap.WorkDir, checkpointMaxDiffBytes)
+	}
+
+	// Mask secrets before the capture reaches the summarizer or the bead.
+	redactor := redact.ForTown(townRoot)
+	snap.Scrollback = redactor.String(snap.Scrollback)
+	snap.Diff = redactor.String(snap.Diff)
+
+	var hookTitle string
+	if hooked := findAgentHookedWork(townRoot, agentID); hooked != nil {
+		snap.HookedBead = hooked.ID
+		hookTitle = hooked.Title
+	}
+
+	snap.Summary = summarizeCheckpoint(townRoot, snap, hookTitle, summarize)
+
+	id, err := createCheckpointBead(townRoot, snap)
+	if err != nil {
+		return "", nil, err
+	}
+	return id, snap, nil
+}
+
+// findAgentHookedWork returns the work bead on an agent's hook, looking in
+// town beads and then every rig. Hooked handoff mail is skipped.
+func findAgentHookedWork(townRoot, agentID string) *beads.Issue {
+	pick := func(issues []*beads.Issue) *beads.Issue {
+		for _, issue := range issues {
+			if !beads.HasLabel(issue, "gt:message") {
+				return issue
+			}
+		}
+		return nil
+	}
+
+	b := beads.New(filepath.Join(townRoot, ".beads"))
+	if issues, err := b.List(beads.ListOptions{
+		Status:   beads.StatusHooked,
+		Assignee: agentID,
+		Priority: -1,
+	}); err == nil {
+		if issue := pick(issues); issue != nil {
+			return issue
+		}
+	}
+	return pick(scanAllRigsForHookedBeads(townRoot, agentID))
+}
+
+// summarizeCheckpoint asks the summary agent where the work stands, falling
+// back to a plain summary when it can't (or summarize is false).
+func summarizeCheckpoint(townRoot string, snap *checkpoint.Snapshot, hookTitle string, summarize bool) string {
+	fallback := snap.FallbackSummary(hookTitle, checkpointSummaryTailLines)
+	if !summarize {
+		return fallback
+	}
+
+	rc, err := resolveSummaryAgent(townRoot, checkpointSummarizer)
+	if err != nil {
+		style.PrintWarning("%v; using a plain summary", err)
+		return fallback
+	}
+	fmt.Printf("Summarizing %s's session with %s...\n", snap.Agent, rc.Command)
+	summary, err := runSummaryAgent(context.Background(), rc, buildCheckpointPrompt(snap, hookTitle))
+	if err != nil {
+		style.PrintWarning("%v; using a plain summary", err)
+		return fallback
+	}
+	return summary
+}
+
+func buildCheckpointPrompt(snap *checkpoint.Snapshot, hookTitle string) string {
+	var b strings.Builder
+	b.WriteString("You are writing a checkpoint for a coding agent whose session is about to be replaced. ")
+	b.WriteString("From its terminal scrollback and uncommitted diff below, write a short briefing for the agent that continues the work: ")
+	b.WriteString("the goal, what is done, what is in progress, and the next concrete steps. ")
+	b.WriteString("Use terse bullet points. Output only the briefing.\n\n")
+	fmt.Fprintf(&b, "Agent: %s\n", snap.Agent)
+	if snap.HookedBead != "" {
+		fmt.Fprintf(&b, "Hooked work: %s %s\n", snap.HookedBead, hookTitle)
+	}
+	if snap.Branch != "" {
+		fmt.Fprintf(&b, "Branch: %s\n", snap.Branch)
+	}
+	if snap.Diff != "" {
+		fmt.Fprintf(&b, "\nUncommitted diff:\n%s\n", snap.Diff)
+	}
+	if snap.Scrollback != "" {
+		fmt.Fprintf(&b, "\nScrollback:\n%s\n", snap.Scrollback)
+	}
+	return b.String()
+}
+
+// createCheckpointBead stores a snapshot as a closed event bead in town
+// beads and returns its ID.
+func createCheckpointBead(townRoot string, snap *checkpoint.Snapshot) (string, error) {
+	title := fmt.Sprintf("Checkpoint: %s %s", snap.Agent, snap.CapturedAt.Format("2006-01-02 15:04"))
+	bd := beads.New(townRoot)
+	// Description on stdin: pane captures can exceed the argv limit.
+	output, err := bd.RunWithInput(snap.Description(), "create",
+		"--type=event",
+		"--title="+title,
+		"--event-category=agent.checkpoint",
+		"--labels="+checkpoint.Label,
+		"--body-file", "-",
+		"--silent",
+	)
+	if err != nil {
+		return "", fmt.Errorf("creating checkpoint bead: %w", err)
+	}
+	id := strings.TrimSpace(string(output))
+
+	// Closed: it's a record, not work.
+	_ = bd.CloseWithReason("checkpoint", id)
+	return id, nil
+}
+
+func runRestoreCheckpoint(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	checkpointID := args[0]
+
+	snap, err := loadCheckpointSnapshot(townRoot, checkpointID)
+	if err != nil {
+		return err
+	}
+
+	agentID := snap.Agent
+	sessionName := snap.Session
+	if restoreCheckpointAgent != "" {
+		if sessionName, err = resolveRoleToSession(restoreCheckpointAgent); err != nil {
+			return err
+		}
+		agentID = sessionToAgentID(sessionName)
+	}
+	if agentID == "" || sessionName == "" {
+		return fmt.Errorf("checkpoint %s names no agent; use --agent", checkpointID)
+	}
+
+	t := tmux.NewTmux()
+	running, _ := t.HasSession(sessionName)
+	restart := running && !restoreCheckpointNoRestart
+
+	rehook := snap.HookedBead != "" && workNeedsRehook(snap.HookedBead, agentID)
+
+	if restoreCheckpointDryRun {
+		if rehook {
+			fmt.Printf("Would hook %s to %s\n", snap.HookedBead, agentID)
+		}
+		fmt.Printf("Would hook mail to %s:\n  Subject: %s\n%s\n", agentID,
+			restoreMailSubject(checkpointID), formatRestoreMail(checkpointID, snap))
+		if restart {
+			fmt.Printf("Would restart session %s\n", sessionName)
+		}
+		return nil
+	}
+
+	if rehook {
+		if err := rehookWork(townRoot, snap.HookedBead, agentID, sessionName); err != nil {
+			style.PrintWarning("could not hook %s to %s: %v", snap.HookedBead, agentID, err)
+		} else {
+			fmt.Printf("%s Hooked %s to %s\n", style.Bold.Render("🪝"), snap.HookedBead, agentID)
+		}
+	}
+
+	mailID, err := hookCheckpointMail(townRoot, checkpointID, agentID, snap)
+	if err != nil {
+		return fmt.Errorf("hooking checkpoint mail: %w", err)
+	}
+	fmt.Printf("%s Checkpoint summary hooked to %s (%s)\n", style.Bold.Render("📝"), agentID, mailID)
+
+	if !restart {
+		if running {
+			fmt.Printf("  Session %s left running; the checkpoint is on its hook\n", sessionName)
+		} else {
+			fmt.Printf("  %s is not running; its next session starts from the checkpoint\n", agentID)
+		}
+		return nil
+	}
+
+	restartCmd, err := buildRestartCommand(sessionName)
+	if err != nil {
+		return err
+	}
+	updateSessionEnvForHandoff(t, sessionName, "")
+	pane, err := getSessionPane(sessionName)
+	if err != nil {
+		return fmt.Errorf("getting pane for %s: %w", sessionName, err)
+	}
+	if err := respawnSessionPane(t, sessionName, pane, restartCmd); err != nil {
+		return err
+	}
+	fmt.Printf("%s Restarted %s from checkpoint %s\n", style.Bold.Render("✓"), sessionName, checkpointID)
+	return nil
+}
+
+// loadCheckpointSnapshot reads the snapshot stored in a checkpoint bead.
+func loadCheckpointSnapshot(townRoot, checkpointID string) (*checkpoint.Snapshot, error) {
+	bd := beads.New(beads.ResolveBeadsDir(townRoot))
+	issue, err := bd.Show(checkpointID)
+	if err != nil {
+		return nil, fmt.Errorf("loading checkpoint %s: %w", checkpointID, err)
+	}
+	if !beads.HasLabel(issue, checkpoint.Label) {
+		return nil, fmt.Errorf("%s is not a checkpoint bead (no %s label)", checkpointID, checkpoint.Label)
+	}
+	return checkpoint.ParseSnapshot(issue.Description), nil
+}
+
+// workNeedsRehook reports whether a bead an agent was working on has come
+// off its hook with nobody else picking it up since, so it should be hooked
+// back.
+func workNeedsRehook(beadID, agentID string) bool {
+	info, err := getBeadInfo(beadID)
+	if err != nil || info.Status == "closed" {
+		return false
+	}
+	if info.Assignee != "" && mail.AddressToIdentity(info.Assignee) != mail.AddressToIdentity(agentID) {
+		return false
+	}
+	return info.Status != "hooked" || info.Assignee == ""
+}
+
+// rehookWork hooks a bead back to an agent.
+func rehookWork(townRoot, beadID, agentID, sessionName string) error {
+	workDir, _ := sessionWorkDir(sessionName, townRoot)
+	return hookBeadWithRetry(beadID, agentID, beads.ResolveHookDir(townRoot, beadID, workDir))
+}
+
+// hookCheckpointMail hooks a checkpoint's summary to its agent as mail, so
+// gt prime shows it at the start of the agent's next session.
+func hookCheckpointMail(townRoot, checkpointID, agentID string, snap *checkpoint.Snapshot) (string, error) {
+	return createHookedMail(townRoot, mail.AddressToIdentity(agentID),
+		restoreMailSubject(checkpointID), formatRestoreMail(checkpointID, snap))
+}
+
+func restoreMailSubject(checkpointID string) string {
+	return fmt.Sprintf("🔖 RESTORE: checkpoint %s", checkpointID)
+}
+
+// formatRestoreMail is the hooked mail a restored session reads first.
+func formatRestoreMail(checkpointID string, snap *checkpoint.Snapshot) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Your previous session was checkpointed at %s", snap.CapturedAt.Local().Format("2006-01-02 15:04"))
+	if snap.CapturedBy != "" {
+		fmt.Fprintf(&b, " by %s", snap.CapturedBy)
+	}
+	b.WriteString(". Continue from here.\n\n")
+	b.WriteString(snap.Summary)
+	b.WriteString("\n\n---\n")
+	if snap.HookedBead != "" {
+		fmt.Fprintf(&b, "Work: %s (bd show %s)\n", snap.HookedBead, snap.HookedBead)
+	}
+	if snap.Branch != "" {
+		fmt.Fprintf(&b, "Branch: %s\n", snap.Branch)
+	}
+	if len(snap.ModifiedFiles) > 0 {
+		fmt.Fprintf(&b, "Uncommitted at checkpoint: %s\n", strings.Join(snap.ModifiedFiles, ", "))
+	}
+	fmt.Fprintf(&b, "Full snapshot (diff, scrollback): bd show %s\n", checkpointID)
+	return b.String()
+}
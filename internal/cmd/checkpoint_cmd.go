@@ -13,7 +13,7 @@ import (
 )
 
 var checkpointCmd = &cobra.Command{
-	Use:     "checkpoint",
+	Use:     "checkpoint [<agent>]",
 	GroupID: GroupDiag,
 	Short:   "Manage session checkpoints for crash recovery",
 	Long: `Manage checkpoints for polecat session crash recovery.
@@ -28,7 +28,24 @@ Checkpoint data includes:
 - Git branch and last commit
 - Timestamp
 
-Checkpoints are stored in .polecat-checkpoint.json in the polecat directory.`,
+Checkpoints are stored in .polecat-checkpoint.json in the polecat directory.
+
+With an agent argument, snapshot that agent into a checkpoint bead: the
+pane scrollback, the hooked bead, the worktree diff, and a generated
+summary of where the work stands. Restore it into a fresh session with
+gt restore-checkpoint <id>, e.g. after a crash or a model switch.
+
+Examples:
+  gt checkpoint write                    # Checkpoint this session to a file
+  gt checkpoint gastown/crew/max         # Snapshot max into a checkpoint bead
+  gt checkpoint gastown/nux --no-summary # Skip the generated summary`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			return cmd.Help()
+		}
+		return runCheckpointAgent(cmd, args)
+	},
 }
 
 var checkpointWriteCmd = &cobra.Command{
@@ -10,7 +10,10 @@ import (
 	"strings"
 
 	beadsdk "github.com/steveyegge/beads"
+	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/convoy"
+	"github.com/steveyegge/gastown/internal/incident"
+	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/workspace"
 
 	"github.com/spf13/cobra"
@@ -92,6 +95,7 @@ func runClose(cmd *cobra.Command, args []string) error {
 	beadIDs := extractBeadIDs(filteredArgs)
 	if len(beadIDs) > 0 {
 		checkConvoyCompletion(beadIDs)
+		archiveClosedIncidents(beadIDs)
 	}
 
 	return nil
@@ -138,14 +142,14 @@ func closeChildren(parentID string, visited map[string]bool, depth int) error {
 	out, err := exec.Command("bd", "children", parentID, "--json").Output()
 	if err != nil {
 		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() != 0 {
-			fmt.Fprintf(os.Stderr, "Warning: bd children %s failed: %v\n", parentID, err)
+			logger.Warn("bd children failed", "parent", parentID, "err", err)
 		}
 		return nil
 	}
 
 	var children []childBead
 	if err := json.Unmarshal(out, &children); err != nil {
-		fmt.Fprintf(os.Stderr, "Warning: failed to parse children of %s: %v\n", parentID, err)
+		logger.Warn("failed to parse children", "parent", parentID, "err", err)
 		return nil
 	}
 
@@ -175,7 +179,7 @@ func closeChildren(parentID string, visited map[string]bool, depth int) error {
 	bdArgs = append(bdArgs, childIDs...)
 	bdArgs = append(bdArgs, "--reason", reason, "--force")
 
-	fmt.Fprintf(os.Stderr, "Cascade: closing %d children of %s\n", len(childIDs), parentID)
+	logger.Info("cascade: closing children", "parent", parentID, "count", len(childIDs))
 
 	bdCmd := exec.Command("bd", bdArgs...)
 	bdCmd.Stdout = os.Stdout
@@ -220,6 +224,26 @@ func extractBeadIDs(args []string) []string {
 	return ids
 }
 
+// archiveClosedIncidents archives incident bundles linked to the closed
+// beads, unless operational.incidents.archive_on_close is false. Best-effort.
+func archiveClosedIncidents(beadIDs []string) {
+	townRoot, err := workspace.FindFromCwd()
+	if err != nil || townRoot == "" {
+		return
+	}
+	if !config.LoadOperationalConfig(townRoot).GetIncidentsConfig().ArchiveOnCloseV() {
+		return
+	}
+	total := 0
+	for _, id := range beadIDs {
+		n, _ := incident.Archive(townRoot, id)
+		total += n
+	}
+	if total > 0 {
+		fmt.Printf("%s\n", style.Dim.Render(fmt.Sprintf("Archived %d incident bundle(s)", total)))
+	}
+}
+
 // checkConvoyCompletion checks if any closed issues are tracked by convoys
 // and triggers convoy completion checks. This implements the ZFC principle:
 // the closure event propagates at the source (bd close) rather than relying
@@ -10,6 +10,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/util"
 	"github.com/steveyegge/gastown/internal/wisp"
 )
 
@@ -418,12 +419,9 @@ func wispAge(w *compactIssue, now time.Time) (time.Duration, error) {
 	if ts == "" {
 		ts = w.CreatedAt
 	}
-	t, err := time.Parse(time.RFC3339, ts)
+	t, err := util.ParseTimestamp(ts)
 	if err != nil {
-		t, err = time.Parse("2006-01-02T15:04:05Z", ts)
-		if err != nil {
-			return 0, fmt.Errorf("parsing timestamp %q: %w", ts, err)
-		}
+		return 0, err
 	}
 	return now.Sub(t), nil
 }
@@ -114,7 +114,7 @@ func runDailyDigest() error {
 	if err != nil {
 		// Non-fatal: continue with creation attempt
 		if compactReportVerbose {
-			fmt.Fprintf(os.Stderr, "warning: idempotency check failed: %v\n", err)
+			logger.Warn("idempotency check failed", "err", err)
 		}
 	} else if existingID != "" {
 		fmt.Printf("%s Compaction digest already sent for %s (bead: %s)\n",
@@ -168,7 +168,7 @@ func runDailyDigest() error {
 	// Create permanent event bead for audit trail
 	beadID, err := createCompactReportBead(report, markdown)
 	if err != nil && compactReportVerbose {
-		fmt.Fprintf(os.Stderr, "warning: failed to create report bead: %v\n", err)
+		logger.Warn("failed to create report bead", "err", err)
 	}
 
 	// Send mail to deacon/, cc mayor/
@@ -366,7 +366,7 @@ func runWeeklyRollup() error {
 	existingID, err := findExistingWeeklyRollup(weekStart, weekEnd)
 	if err != nil {
 		if compactReportVerbose {
-			fmt.Fprintf(os.Stderr, "warning: weekly idempotency check failed: %v\n", err)
+			logger.Warn("weekly idempotency check failed", "err", err)
 		}
 	} else if existingID != "" {
 		fmt.Printf("%s Weekly rollup already sent for %s to %s (bead: %s)\n",
@@ -424,7 +424,7 @@ func runWeeklyRollup() error {
 	// Create audit event bead for the weekly rollup (for future idempotency checks)
 	beadID, beadErr := createWeeklyRollupBead(rollup, markdown)
 	if beadErr != nil && compactReportVerbose {
-		fmt.Fprintf(os.Stderr, "warning: failed to create weekly rollup bead: %v\n", beadErr)
+		logger.Warn("failed to create weekly rollup bead", "err", beadErr)
 	}
 
 	// Send to mayor/
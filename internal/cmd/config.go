@@ -33,7 +33,9 @@ Commands:
   gt config agent get <name>         Show agent configuration
   gt config agent set <name> <cmd>   Set custom agent command
   gt config agent remove <name>      Remove custom agent
-  gt config default-agent [name]     Get or set default agent`,
+  gt config default-agent [name]     Get or set default agent
+  gt config validate                 Check configuration files for mistakes
+  gt config schema <kind>            Print a JSON Schema for a config file`,
 }
 
 // Agent subcommands
@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/configcheck"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var configValidateJSON bool
+
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check the town's configuration files for mistakes",
+	Long: `Load the town's configuration and report problems gt would otherwise
+hit at runtime, or silently ignore:
+
+  - files that are missing or aren't valid JSON
+  - unknown keys (usually misspellings, which gt drops without a word)
+  - values the loaders reject (type, version, witness rules, ...)
+  - invalid rig names and unknown role names (role_agents)
+  - agents whose tmux session names collide, e.g. two rigs sharing a beads
+    prefix, or a polecat named "witness"
+  - rig directories and local repo paths that don't exist
+
+Checked: mayor/town.json, mayor/rigs.json, settings/config.json, and each
+rig's settings/config.json. Exits non-zero if any errors are found;
+warnings alone don't fail.
+
+Examples:
+  gt config validate
+  gt config validate --json`,
+	Args: cobra.NoArgs,
+	RunE: runConfigValidate,
+}
+
+// configSchemas maps each config file kind to a zero value of its type.
+var configSchemas = map[string]struct {
+	file string
+	v    interface{}
+}{
+	"town":          {"mayor/town.json", &config.TownConfig{}},
+	"rigs":          {"mayor/rigs.json", &config.RigsConfig{}},
+	"mayor":         {"mayor/config.json", &config.MayorConfig{}},
+	"daemon":        {"mayor/daemon.json", &config.DaemonPatrolConfig{}},
+	"town-settings": {"settings/config.json", &config.TownSettings{}},
+	"rig-settings":  {"<rig>/settings/config.json", &config.RigSettings{}},
+	"escalation":    {"settings/escalation.json", &config.EscalationConfig{}},
+}
+
+var configSchemaCmd = &cobra.Command{
+	Use:   "schema <kind>",
+	Short: "Print a JSON Schema for a configuration file",
+	Long: `Print a JSON Schema for one of gt's configuration files, for editor
+completion and validation. The schema rejects unknown keys.
+
+Kinds:
+  town            mayor/town.json
+  rigs            mayor/rigs.json
+  mayor           mayor/config.json
+  daemon          mayor/daemon.json
+  town-settings   settings/config.json
+  rig-settings    <rig>/settings/config.json
+  escalation      settings/escalation.json
+
+Examples:
+  gt config schema rigs > rigs.schema.json
+  gt config schema rig-settings > .vscode/gt-rig-settings.schema.json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runConfigSchema,
+}
+
+func init() {
+	configValidateCmd.Flags().BoolVar(&configValidateJSON, "json", false, "Output the report as JSON")
+	configCmd.AddCommand(configValidateCmd)
+	configCmd.AddCommand(configSchemaCmd)
+}
+
+func runConfigValidate(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	report := configcheck.Check(townRoot)
+	if configValidateJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			return err
+		}
+	} else {
+		printConfigReport(report)
+	}
+	if n := report.Errors(); n > 0 {
+		return NewSilentExit(1)
+	}
+	return nil
+}
+
+// printConfigReport prints the report grouped by file, checked files
+// first in check order.
+func printConfigReport(report *configcheck.Report) {
+	files := append([]string(nil), report.Files...)
+	seen := make(map[string]bool, len(files))
+	for _, f := range files {
+		seen[f] = true
+	}
+	var extra []string
+	for _, i := range report.Issues {
+		if !seen[i.File] {
+			seen[i.File] = true
+			extra = append(extra, i.File)
+		}
+	}
+	sort.Strings(extra)
+	files = append(files, extra...)
+
+	var warnings int
+	for _, f := range files {
+		issues := report.IssuesFor(f)
+		if len(issues) == 0 {
+			fmt.Printf("%s %s\n", style.SuccessPrefix, f)
+			continue
+		}
+		fmt.Printf("%s %s\n", style.ErrorPrefix, f)
+		for _, i := range issues {
+			label := style.Error.Render(i.Severity)
+			if i.Severity == configcheck.SeverityWarning {
+				label = style.Warning.Render(i.Severity)
+				warnings++
+			}
+			where := ""
+			if i.Key != "" {
+				where = style.Bold.Render(i.Key) + ": "
+			}
+			fmt.Printf("    %s %s%s\n", label, where, i.Message)
+		}
+	}
+
+	errs := report.Errors()
+	fmt.Println()
+	if errs == 0 && warnings == 0 {
+		fmt.Printf("%s Configuration OK (%d files)\n", style.SuccessPrefix, len(report.Files))
+		return
+	}
+	fmt.Printf("%d error(s), %d warning(s) in %d file(s)\n", errs, warnings, len(report.Files))
+}
+
+func runConfigSchema(cmd *cobra.Command, args []string) error {
+	s, ok := configSchemas[args[0]]
+	if !ok {
+		kinds := make([]string, 0, len(configSchemas))
+		for k := range configSchemas {
+			kinds = append(kinds, k)
+		}
+		sort.Strings(kinds)
+		return fmt.Errorf("unknown config kind %q (want one of %s)", args[0], strings.Join(kinds, ", "))
+	}
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(config.Schema(s.v, "Gas Town "+s.file))
+}
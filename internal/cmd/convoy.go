@@ -193,6 +193,7 @@ COMMANDS:
   close     Close a convoy (verifies all items done, or use --force)
   land      Land an owned convoy (cleanup worktrees, close convoy)
   status    Show convoy progress, tracked issues, and active workers
+  gate      Hold members' merges until every member is ready (cross-rig changes)
   list      List convoys (the dashboard view)`,
 }
 
@@ -452,6 +453,10 @@ func runConvoyCreate(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if convoyMaxActive < 0 {
+		return fmt.Errorf("invalid --max-active %d: must be 0 (unthrottled) or more", convoyMaxActive)
+	}
+
 	// If first arg looks like an issue ID (has beads prefix), treat all args as issues
 	// and auto-generate a name from the first issue's title
 	if looksLikeIssueID(name) {
@@ -494,10 +499,14 @@ func runConvoyCreate(cmd *cobra.Command, args []string) error {
 		owner = detectSender()
 	}
 	convoyFieldValues := &beads.ConvoyFields{
-		Owner:    owner,
-		Notify:   convoyNotify,
-		Merge:    convoyMerge,
-		Molecule: convoyMolecule,
+		Owner:     owner,
+		Notify:    convoyNotify,
+		Merge:     convoyMerge,
+		Molecule:  convoyMolecule,
+		MaxActive: convoyMaxActive,
+	}
+	if convoyGated {
+		convoyFieldValues.Gate = beads.ConvoyGateMerge
 	}
 	description = beads.SetConvoyFields(&beads.Issue{Description: description}, convoyFieldValues)
 
@@ -574,6 +583,12 @@ func runConvoyCreate(cmd *cobra.Command, args []string) error {
 	if convoyMolecule != "" {
 		fmt.Printf("  Molecule: %s\n", convoyMolecule)
 	}
+	if convoyMaxActive > 0 {
+		fmt.Printf("  Throttle: %d task(s) in flight\n", convoyMaxActive)
+	}
+	if convoyGated {
+		fmt.Printf("  Gate:     %s\n", "merges held until all issues are ready")
+	}
 	if convoyOwned {
 		fmt.Printf("  Owned:    %s\n", style.Warning.Render("caller-managed lifecycle"))
 	}
@@ -1296,9 +1311,10 @@ func findStrandedConvoys(townBeads string) ([]strandedConvoyInfo, error) {
 	}
 
 	var convoys []struct {
-		ID        string `json:"id"`
-		Title     string `json:"title"`
-		CreatedAt string `json:"created_at"`
+		ID          string `json:"id"`
+		Title       string `json:"title"`
+		Description string `json:"description"`
+		CreatedAt   string `json:"created_at"`
 	}
 	if err := json.Unmarshal(out, &convoys); err != nil {
 		return nil, fmt.Errorf("parsing convoy list: %w", err)
@@ -1310,7 +1326,7 @@ func findStrandedConvoys(townBeads string) ([]strandedConvoyInfo, error) {
 		if err != nil {
 			// Write to stderr explicitly — stdout may be consumed as JSON
 			// by the daemon's JSON parser (fixes #2142).
-			fmt.Fprintf(os.Stderr, "⚠ Warning: skipping convoy %s: %v\n", convoy.ID, err)
+			logger.Warn("skipping convoy", "convoy", convoy.ID, "err", err)
 			continue
 		}
 		// Empty convoys (0 tracked issues) are stranded — they need
@@ -1353,6 +1369,14 @@ func findStrandedConvoys(townBeads string) ([]strandedConvoyInfo, error) {
 			}
 		}
 
+		// A throttled convoy at its Max-Active limit is progressing, not
+		// stranded: the rest of its ready work waits for a slot to free up.
+		if maxActive := convoyMaxActiveFromFields(convoy.Description); maxActive > 0 && len(readyIssues) > 0 {
+			if convoyops.FeedBudget(maxActive, countInFlightTracked(tracked)) == 0 {
+				continue
+			}
+		}
+
 		if len(readyIssues) > 0 {
 			stranded = append(stranded, strandedConvoyInfo{
 				ID:           convoy.ID,
@@ -1643,6 +1667,16 @@ func runConvoyStatus(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Gated convoys also report which members are ready to land, from the
+	// rigs' merge queues.
+	var gate *beads.ConvoyGate
+	if convoyGateFromFields(convoy.Description) {
+		if gate, err = beads.New(filepath.Dir(townBeads)).ConvoyGate(convoyID); err != nil {
+			style.PrintWarning("could not check merge gate: %v", err)
+			gate = nil
+		}
+	}
+
 	if convoyStatusJSON {
 		lifecycle := "system-managed"
 		if isOwned {
@@ -1655,6 +1689,8 @@ func runConvoyStatus(cmd *cobra.Command, args []string) error {
 			Owned         bool               `json:"owned"`
 			Lifecycle     string             `json:"lifecycle"`
 			MergeStrategy string             `json:"merge_strategy,omitempty"`
+			MaxActive     int                `json:"max_active,omitempty"`
+			Gate          *beads.ConvoyGate  `json:"gate,omitempty"`
 			Tracked       []trackedIssueInfo `json:"tracked"`
 			Completed     int                `json:"completed"`
 			Total         int                `json:"total"`
@@ -1666,6 +1702,8 @@ func runConvoyStatus(cmd *cobra.Command, args []string) error {
 			Owned:         isOwned,
 			Lifecycle:     lifecycle,
 			MergeStrategy: convoyMergeFromFields(convoy.Description),
+			MaxActive:     convoyMaxActiveFromFields(convoy.Description),
+			Gate:          gate,
 			Tracked:       tracked,
 			Completed:     completed,
 			Total:         len(tracked),
@@ -1688,6 +1726,12 @@ func runConvoyStatus(cmd *cobra.Command, args []string) error {
 	if merge != "" {
 		fmt.Printf("  Merge:     %s\n", merge)
 	}
+	if maxActive := convoyMaxActiveFromFields(convoy.Description); maxActive > 0 {
+		fmt.Printf("  Throttle:  %d/%d in flight\n", countInFlightTracked(tracked), maxActive)
+	}
+	if gate != nil {
+		fmt.Printf("  Gate:      %s\n", formatConvoyGateSummary(gate))
+	}
 	fmt.Printf("  Progress:  %d/%d completed\n", completed, len(tracked))
 	fmt.Printf("  Created:   %s\n", convoy.CreatedAt)
 	if convoy.ClosedAt != "" {
@@ -0,0 +1,197 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+// Gate flags.
+var (
+	convoyGated    bool // gt convoy create --gated
+	convoyGateJSON bool // gt convoy gate --json
+)
+
+var convoyGateCmd = &cobra.Command{
+	Use:   "gate <convoy-id> [on|off]",
+	Short: "Hold a convoy's merges until every member is ready",
+	Long: `Show or set a convoy's merge gate.
+
+A gated convoy coordinates a change that spans rigs, such as an API change
+in one rig and the client update in another. Each member's merge request
+waits in its rig's merge queue until every member is ready to land: closed,
+or with a merge request of its own queued. Then the refineries merge them
+as usual.
+
+The gate applies whatever the convoy's merge strategy: members of a gated
+convoy with the direct or local strategy go through the merge queue anyway,
+since pushing straight to the target branch (or not at all) would skip the
+gate. A member whose bead can't be found fails the gate; the refinery holds
+the convoy's merges and mails the mayor.
+
+Without on/off, shows each member's readiness, grouped by rig. Held merge
+requests show as blocked by the convoy in the merge queue.
+
+Examples:
+  gt convoy gate hq-cv-abc          # Show what the gate is waiting on
+  gt convoy gate hq-cv-abc on       # Hold merges until all members are ready
+  gt convoy gate hq-cv-abc off      # Let members merge independently
+  gt convoy create "API v2" gt-api bd-client --gated`,
+	Args:         cobra.RangeArgs(1, 2),
+	SilenceUsage: true,
+	RunE:         runConvoyGate,
+}
+
+func init() {
+	convoyCreateCmd.Flags().BoolVar(&convoyGated, "gated", false, "Hold members' merges until every member is ready to land")
+	convoyGateCmd.Flags().BoolVar(&convoyGateJSON, "json", false, "Output as JSON")
+	convoyCmd.AddCommand(convoyGateCmd)
+}
+
+func runConvoyGate(cmd *cobra.Command, args []string) error {
+	townBeads, err := getTownBeadsDir()
+	if err != nil {
+		return err
+	}
+	convoyID, err := resolveConvoyArg(townBeads, args[0])
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 2 {
+		var gate string
+		switch args[1] {
+		case "on":
+			gate = beads.ConvoyGateMerge
+		case "off":
+		default:
+			return fmt.Errorf("invalid gate setting %q: must be on or off", args[1])
+		}
+		if err := setConvoyGate(townBeads, convoyID, gate); err != nil {
+			return err
+		}
+		if gate == "" {
+			fmt.Printf("%s Convoy %s ungated: members merge independently\n", style.Bold.Render("✓"), convoyID)
+			return nil
+		}
+		fmt.Printf("%s Convoy %s gated: members' merges wait until all are ready\n", style.Bold.Render("✓"), convoyID)
+	}
+
+	gate, err := beads.New(filepath.Dir(townBeads)).ConvoyGate(convoyID)
+	if err != nil {
+		return fmt.Errorf("checking gate for %s: %w", convoyID, err)
+	}
+	if convoyGateJSON {
+		type jsonGate struct {
+			*beads.ConvoyGate
+			Open    bool     `json:"open"`
+			Waiting []string `json:"waiting,omitempty"`
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(jsonGate{ConvoyGate: gate, Open: gate.Open(), Waiting: gate.Waiting()})
+	}
+	printConvoyGate(filepath.Dir(townBeads), gate)
+	return nil
+}
+
+// setConvoyGate rewrites a convoy's Gate field, keeping its other fields and
+// prose intact. An empty gate removes it.
+func setConvoyGate(townBeads, convoyID, gate string) error {
+	_, _, description, err := showConvoyForThrottle(townBeads, convoyID)
+	if err != nil {
+		return err
+	}
+	issue := &beads.Issue{Description: description}
+	fields := beads.ParseConvoyFields(issue)
+	if fields == nil {
+		fields = &beads.ConvoyFields{}
+	}
+	fields.Gate = gate
+	newDesc := beads.SetConvoyFields(issue, fields)
+	if out, err := BdCmd("update", convoyID, "--description="+newDesc).
+		Dir(townBeads).WithAutoCommit().
+		CombinedOutput(); err != nil {
+		return fmt.Errorf("bd update %s --description: %w\noutput: %s", convoyID, err, out)
+	}
+	return nil
+}
+
+// enforceConvoyMergeGate switches work in a merge-gated convoy from the
+// direct or local merge strategy to the merge queue, where the gate holds it
+// until every member is ready. Both strategies bypass the refinery, so
+// honoring them would let one member land alone.
+func enforceConvoyMergeGate(townRoot string, info *ConvoyInfo) {
+	if info == nil || (info.MergeStrategy != "direct" && info.MergeStrategy != "local") {
+		return
+	}
+	_, _, description, err := showConvoyForThrottle(filepath.Join(townRoot, ".beads"), info.ID)
+	if err != nil || !convoyGateFromFields(description) {
+		return
+	}
+	fmt.Printf("%s Convoy %s gates merges: using the merge queue instead of the %s strategy\n",
+		style.Bold.Render("→"), info.ID, info.MergeStrategy)
+	info.MergeStrategy = "mr"
+}
+
+// convoyGateFromFields reports whether a convoy description gates merges.
+func convoyGateFromFields(description string) bool {
+	fields := beads.ParseConvoyFields(&beads.Issue{Description: description})
+	return fields != nil && fields.Gate == beads.ConvoyGateMerge
+}
+
+// formatConvoyGateSummary is the one-line gate state for gt convoy status.
+func formatConvoyGateSummary(gate *beads.ConvoyGate) string {
+	ready := len(gate.Members) - len(gate.Waiting())
+	if gate.Open() {
+		return fmt.Sprintf("open (%d/%d members ready to land)", ready, len(gate.Members))
+	}
+	return fmt.Sprintf("holding merges (%d/%d members ready to land)", ready, len(gate.Members))
+}
+
+// printConvoyGate shows a gate's members grouped by rig.
+func printConvoyGate(townRoot string, gate *beads.ConvoyGate) {
+	state := style.Dim.Render("off (members merge independently)")
+	if gate.Gated {
+		state = formatConvoyGateSummary(gate)
+		if !gate.Open() {
+			state = style.Warning.Render(state)
+		}
+	}
+	fmt.Printf("🚚 %s merge gate %s\n", style.Bold.Render(gate.ConvoyID), state)
+
+	byRig := make(map[string][]beads.ConvoyMember)
+	for _, m := range gate.Members {
+		rig := resolveRigForBead(townRoot, m.ID)
+		if rig == "" {
+			rig = "town"
+		}
+		byRig[rig] = append(byRig[rig], m)
+	}
+	rigs := make([]string, 0, len(byRig))
+	for rig := range byRig {
+		rigs = append(rigs, rig)
+	}
+	sort.Strings(rigs)
+	for _, rig := range rigs {
+		fmt.Printf("\n  %s\n", style.Bold.Render(rig))
+		for _, m := range byRig[rig] {
+			mark, detail := "○", m.Status
+			switch {
+			case m.Status == beads.ConvoyMemberMissing:
+				mark, detail = style.Error.Render("✗"), "missing: gate can't open"
+			case m.Status == "closed":
+				mark, detail = "✓", "closed"
+			case m.MR != "":
+				mark, detail = "✓", "queued as "+m.MR
+			}
+			fmt.Printf("    %s %s: %s %s\n", mark, m.ID, m.Title, style.Dim.Render("["+detail+"]"))
+		}
+	}
+}
@@ -313,13 +313,29 @@ func runConvoyLaunch(cmd *cobra.Command, args []string) error {
 				return err
 			}
 
-			results, err := dispatchWave1(convoyID, dag, waves, townRoot)
+			// Throttle Wave 1 when it is wider than the convoy's in-flight
+			// limit or the host's free polecat slots. The daemon feeds the
+			// held-back tasks as slots free up.
+			wave1Size := 0
+			if len(waves) > 0 {
+				wave1Size = len(waves[0].Tasks)
+			}
+			throttle, err := resolveLaunchThrottle(convoyID, wave1Size, townRoot)
+			if err != nil {
+				return err
+			}
+			dispatchWaves, queued := splitWave1(waves, throttle.Limit)
+
+			results, err := dispatchWave1(convoyID, dag, dispatchWaves, townRoot)
 			if err != nil {
 				return fmt.Errorf("dispatch wave 1: %w", err)
 			}
 
 			// Report results.
 			fmt.Print(renderLaunchOutput(convoyID, waves, results, dag))
+			if len(queued) > 0 {
+				fmt.Print(renderThrottleNotice(convoyID, throttle, queued, dag))
+			}
 			return nil
 		}
 	}
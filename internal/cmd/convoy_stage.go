@@ -371,6 +371,16 @@ func runConvoyStage(cmd *cobra.Command, args []string) error {
 			return err
 		}
 		fmt.Printf("Convoy launched: %s (status: open)\n", convoyID)
+		if convoyLaunchMaxActive > 0 {
+			townBeads, err := getTownBeadsDir()
+			if err != nil {
+				return err
+			}
+			if err := setConvoyMaxActive(townBeads, convoyID, convoyLaunchMaxActive); err != nil {
+				return err
+			}
+			fmt.Printf("Throttled to %d task(s) in flight\n", convoyLaunchMaxActive)
+		}
 	}
 
 	return nil
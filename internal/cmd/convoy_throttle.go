@@ -0,0 +1,426 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+	convoyops "github.com/steveyegge/gastown/internal/convoy"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+// Throttle flags.
+var (
+	convoyMaxActive        int           // gt convoy create --max-active
+	convoyLaunchMaxActive  int           // gt convoy launch --max-active
+	convoyProgressWatch    bool          // gt convoy progress --watch
+	convoyProgressInterval time.Duration // gt convoy progress --interval
+)
+
+var convoyThrottleCmd = &cobra.Command{
+	Use:   "throttle <convoy-id> <max-active>",
+	Short: "Limit how many of a convoy's tasks are in flight at once",
+	Long: `Set the maximum number of a convoy's tracked tasks that may be in flight
+(hooked, in progress, or assigned) at the same time.
+
+A throttled convoy starts at most max-active tasks; the daemon feeds another
+ready task each time one completes or a slot frees up. Use this when a
+convoy fans out wider than the available agents or host capacity.
+
+Lowering the limit does not stop work already in flight: the convoy holds
+new dispatches until it drains below the new limit. A limit of 0 lifts the
+throttle.
+
+Examples:
+  gt convoy throttle hq-cv-abc 4    # At most 4 tasks in flight
+  gt convoy throttle hq-cv-abc 0    # Remove the limit`,
+	Args:         cobra.ExactArgs(2),
+	SilenceUsage: true,
+	RunE:         runConvoyThrottle,
+}
+
+var convoyProgressCmd = &cobra.Command{
+	Use:   "progress <convoy-id>",
+	Short: "Show a convoy's rollout progress (done, in flight, queued, blocked)",
+	Long: `Show how far a convoy's rollout has progressed.
+
+Tasks are counted as done (closed), in flight (hooked, in progress, or
+assigned), queued (ready and waiting for a slot), or blocked (waiting on
+other tasks). Throttled convoys also show their in-flight limit.
+
+With --watch, the view refreshes until the convoy closes or Ctrl-C.
+
+Examples:
+  gt convoy progress hq-cv-abc
+  gt convoy progress hq-cv-abc --watch
+  gt convoy progress hq-cv-abc --watch --interval 10s`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runConvoyProgress,
+}
+
+func init() {
+	convoyCreateCmd.Flags().IntVar(&convoyMaxActive, "max-active", 0, "Max tasks in flight at once; the rest are fed as agents free up (0 = unthrottled)")
+	convoyLaunchCmd.Flags().IntVar(&convoyLaunchMaxActive, "max-active", 0, "Max tasks in flight at once (default: host capacity when Wave 1 exceeds it)")
+
+	convoyProgressCmd.Flags().BoolVarP(&convoyProgressWatch, "watch", "w", false, "Refresh until the convoy closes")
+	convoyProgressCmd.Flags().DurationVar(&convoyProgressInterval, "interval", 5*time.Second, "Refresh interval for --watch")
+
+	convoyCmd.AddCommand(convoyThrottleCmd)
+	convoyCmd.AddCommand(convoyProgressCmd)
+}
+
+// convoyMaxActiveFromFields extracts the in-flight limit from a convoy
+// description. Returns 0 if the convoy is unthrottled.
+func convoyMaxActiveFromFields(description string) int {
+	fields := beads.ParseConvoyFields(&beads.Issue{Description: description})
+	if fields == nil {
+		return 0
+	}
+	return fields.MaxActive
+}
+
+// isInFlightTracked reports whether a tracked issue occupies a slot: it is
+// hooked or in progress, or open but already assigned to an agent.
+func isInFlightTracked(t trackedIssueInfo) bool {
+	return convoyops.InFlightStatuses[t.Status] || (t.Status == "open" && t.Assignee != "")
+}
+
+// countInFlightTracked counts tracked issues that occupy a slot.
+func countInFlightTracked(tracked []trackedIssueInfo) int {
+	n := 0
+	for _, t := range tracked {
+		if isInFlightTracked(t) {
+			n++
+		}
+	}
+	return n
+}
+
+// showConvoyForThrottle fetches the fields the throttle commands need from
+// a convoy bead in town beads.
+func showConvoyForThrottle(townBeads, convoyID string) (title, status, description string, err error) {
+	out, err := runBdJSON(townBeads, "show", convoyID, "--json")
+	if err != nil {
+		return "", "", "", fmt.Errorf("convoy '%s' not found", convoyID)
+	}
+	var convoys []struct {
+		Title       string `json:"title"`
+		Status      string `json:"status"`
+		Description string `json:"description"`
+		IssueType   string `json:"issue_type"`
+	}
+	if err := json.Unmarshal(out, &convoys); err != nil {
+		return "", "", "", fmt.Errorf("parsing convoy data: %w", err)
+	}
+	if len(convoys) == 0 {
+		return "", "", "", fmt.Errorf("convoy '%s' not found", convoyID)
+	}
+	c := convoys[0]
+	if c.IssueType != "" && c.IssueType != "convoy" {
+		return "", "", "", fmt.Errorf("'%s' is not a convoy (type: %s)", convoyID, c.IssueType)
+	}
+	return c.Title, c.Status, c.Description, nil
+}
+
+// setConvoyMaxActive rewrites a convoy's Max-Active field, keeping its other
+// fields and prose intact.
+func setConvoyMaxActive(townBeads, convoyID string, maxActive int) error {
+	_, _, description, err := showConvoyForThrottle(townBeads, convoyID)
+	if err != nil {
+		return err
+	}
+	issue := &beads.Issue{Description: description}
+	fields := beads.ParseConvoyFields(issue)
+	if fields == nil {
+		fields = &beads.ConvoyFields{}
+	}
+	fields.MaxActive = maxActive
+	newDesc := beads.SetConvoyFields(issue, fields)
+	if out, err := BdCmd("update", convoyID, "--description="+newDesc).
+		Dir(townBeads).WithAutoCommit().
+		CombinedOutput(); err != nil {
+		return fmt.Errorf("bd update %s --description: %w\noutput: %s", convoyID, err, out)
+	}
+	return nil
+}
+
+// resolveConvoyArg accepts a convoy ID or a numeric shortcut from gt convoy list.
+func resolveConvoyArg(townBeads, arg string) (string, error) {
+	if n, err := strconv.Atoi(arg); err == nil && n > 0 {
+		return resolveConvoyNumber(townBeads, n)
+	}
+	return arg, nil
+}
+
+func runConvoyThrottle(cmd *cobra.Command, args []string) error {
+	maxActive, err := strconv.Atoi(args[1])
+	if err != nil || maxActive < 0 {
+		return fmt.Errorf("invalid max-active %q: must be a non-negative integer", args[1])
+	}
+
+	townBeads, err := getTownBeadsDir()
+	if err != nil {
+		return err
+	}
+	convoyID, err := resolveConvoyArg(townBeads, args[0])
+	if err != nil {
+		return err
+	}
+	if err := setConvoyMaxActive(townBeads, convoyID, maxActive); err != nil {
+		return err
+	}
+
+	if maxActive == 0 {
+		fmt.Printf("%s Convoy %s unthrottled\n", style.Bold.Render("✓"), convoyID)
+		return nil
+	}
+	fmt.Printf("%s Convoy %s throttled to %d task(s) in flight\n", style.Bold.Render("✓"), convoyID, maxActive)
+	if tracked, err := getTrackedIssues(townBeads, convoyID); err == nil {
+		if inFlight := countInFlightTracked(tracked); inFlight > maxActive {
+			fmt.Printf("  %s\n", style.Dim.Render(fmt.Sprintf("%d already in flight; new dispatches hold until it drains below %d", inFlight, maxActive)))
+		}
+	}
+	return nil
+}
+
+// launchThrottle is the in-flight limit applied when launching a convoy.
+type launchThrottle struct {
+	Limit  int    // 0 = unthrottled
+	Reason string // Where the limit came from, for display
+}
+
+// chooseLaunchThrottle picks the in-flight limit for a launch. An explicit
+// --max-active wins, then a limit already set on the convoy. Otherwise a
+// Wave 1 wider than the host's free polecat slots is throttled to those
+// slots (at least one, so the rollout always starts).
+func chooseLaunchThrottle(requested, current, wave1Size, hostFree int, hostLimited bool) launchThrottle {
+	switch {
+	case requested > 0:
+		return launchThrottle{Limit: requested, Reason: "--max-active"}
+	case current > 0:
+		return launchThrottle{Limit: current, Reason: "convoy Max-Active"}
+	case hostLimited && wave1Size > hostFree:
+		return launchThrottle{Limit: max(hostFree, 1), Reason: "host capacity"}
+	}
+	return launchThrottle{}
+}
+
+// hostFreePolecatSlots estimates how many more polecats the host can run,
+// from the polecat namepool size minus running polecat sessions. The second
+// result is false when the scheduler is enabled: it already queues slings
+// beyond scheduler.max_polecats, so launches need no host throttle.
+func hostFreePolecatSlots(townRoot string) (int, bool) {
+	if deferred, err := shouldDeferDispatch(); err != nil || deferred {
+		return 0, false
+	}
+	size := config.LoadOperationalConfig(townRoot).GetPolecatConfig().NamepoolSizeV()
+	return max(size-countActivePolecats(), 0), true
+}
+
+// resolveLaunchThrottle decides the in-flight limit for launching a convoy
+// and records it on the convoy so the daemon feeds the rest at that pace.
+func resolveLaunchThrottle(convoyID string, wave1Size int, townRoot string) (launchThrottle, error) {
+	townBeads, err := getTownBeadsDir()
+	if err != nil {
+		return launchThrottle{}, err
+	}
+	_, _, description, err := showConvoyForThrottle(townBeads, convoyID)
+	if err != nil {
+		return launchThrottle{}, err
+	}
+	current := convoyMaxActiveFromFields(description)
+	hostFree, hostLimited := hostFreePolecatSlots(townRoot)
+
+	t := chooseLaunchThrottle(convoyLaunchMaxActive, current, wave1Size, hostFree, hostLimited)
+	if t.Limit > 0 && t.Limit != current {
+		if err := setConvoyMaxActive(townBeads, convoyID, t.Limit); err != nil {
+			return launchThrottle{}, fmt.Errorf("setting Max-Active on %s: %w", convoyID, err)
+		}
+	}
+	return t, nil
+}
+
+// splitWave1 returns waves with Wave 1 cut to limit tasks, and the Wave 1
+// tasks held back. A limit of 0 leaves the waves unchanged.
+func splitWave1(waves []Wave, limit int) ([]Wave, []string) {
+	if limit <= 0 || len(waves) == 0 || len(waves[0].Tasks) <= limit {
+		return waves, nil
+	}
+	split := make([]Wave, len(waves))
+	copy(split, waves)
+	split[0].Tasks = waves[0].Tasks[:limit:limit]
+	queued := append([]string(nil), waves[0].Tasks[limit:]...)
+	return split, queued
+}
+
+// renderThrottleNotice lists the Wave 1 tasks held back by a throttled launch.
+func renderThrottleNotice(convoyID string, t launchThrottle, queued []string, dag *ConvoyDAG) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "\nThrottled to %d task(s) in flight (%s).\n", t.Limit, t.Reason)
+	fmt.Fprintf(&b, "Queued (Wave 1, fed as agents free up):\n")
+
+	sorted := append([]string(nil), queued...)
+	sort.Strings(sorted)
+	for _, id := range sorted {
+		title, rigInfo := "", ""
+		if node := dag.Nodes[id]; node != nil {
+			title = node.Title
+			if node.Rig != "" {
+				rigInfo = fmt.Sprintf("  (rig: %s)", node.Rig)
+			}
+		}
+		fmt.Fprintf(&b, "  ○ %s  %s%s\n", id, title, rigInfo)
+	}
+	b.WriteString("\n")
+	fmt.Fprintf(&b, "  Watch:  gt convoy progress %s --watch\n", convoyID)
+	fmt.Fprintf(&b, "  Adjust: gt convoy throttle %s <max-active>\n", convoyID)
+	return b.String()
+}
+
+// convoyProgress counts a convoy's tracked tasks by rollout state.
+type convoyProgress struct {
+	Total     int
+	Done      int // Closed
+	InFlight  int // Hooked, in progress, or assigned
+	Queued    int // Ready, waiting for a slot
+	Blocked   int // Waiting on other tasks
+	MaxActive int // 0 = unthrottled
+}
+
+// summarizeConvoyProgress buckets tracked issues by rollout state.
+func summarizeConvoyProgress(tracked []trackedIssueInfo, maxActive int) convoyProgress {
+	p := convoyProgress{Total: len(tracked), MaxActive: maxActive}
+	for _, t := range tracked {
+		switch {
+		case t.Status == "closed" || t.Status == "tombstone":
+			p.Done++
+		case isInFlightTracked(t):
+			p.InFlight++
+		case t.Blocked:
+			p.Blocked++
+		default:
+			p.Queued++
+		}
+	}
+	return p
+}
+
+// progressBarWidth is the width of the gt convoy progress bar in cells.
+const progressBarWidth = 40
+
+// renderProgressBar draws done (█), in-flight (▓), and remaining (░) cells.
+func renderProgressBar(p convoyProgress, width int) string {
+	if p.Total == 0 {
+		return strings.Repeat("░", width)
+	}
+	done := p.Done * width / p.Total
+	inFlight := (p.Done + p.InFlight) * width / p.Total
+	return strings.Repeat("█", done) + strings.Repeat("▓", inFlight-done) + strings.Repeat("░", width-inFlight)
+}
+
+// renderConvoyProgress formats the progress view for one convoy.
+func renderConvoyProgress(convoyID, title, status string, p convoyProgress, tracked []trackedIssueInfo) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "🚚 %s %s\n\n", style.Bold.Render(convoyID+":"), title)
+
+	pct := 0
+	if p.Total > 0 {
+		pct = p.Done * 100 / p.Total
+	}
+	fmt.Fprintf(&b, "  %s %3d%%  (%d/%d done)\n\n", renderProgressBar(p, progressBarWidth), pct, p.Done, p.Total)
+
+	inFlight := fmt.Sprintf("%d", p.InFlight)
+	if p.MaxActive > 0 {
+		inFlight = fmt.Sprintf("%d/%d", p.InFlight, p.MaxActive)
+	}
+	fmt.Fprintf(&b, "  Status:    %s\n", status)
+	fmt.Fprintf(&b, "  In flight: %s\n", inFlight)
+	fmt.Fprintf(&b, "  Queued:    %d\n", p.Queued)
+	fmt.Fprintf(&b, "  Blocked:   %d\n", p.Blocked)
+
+	var active []string
+	for _, t := range tracked {
+		if !isInFlightTracked(t) {
+			continue
+		}
+		who := t.Assignee
+		if t.Worker != "" {
+			who = t.Worker
+			if t.WorkerAge != "" {
+				who += " (" + t.WorkerAge + ")"
+			}
+		}
+		line := fmt.Sprintf("    ▶ %s: %s", t.ID, t.Title)
+		if who != "" {
+			line += "  " + style.Dim.Render("@"+who)
+		}
+		active = append(active, line)
+	}
+	if len(active) > 0 {
+		fmt.Fprintf(&b, "\n  %s\n", style.Bold.Render("In flight:"))
+		b.WriteString(strings.Join(active, "\n"))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func runConvoyProgress(cmd *cobra.Command, args []string) error {
+	if convoyProgressWatch && convoyProgressInterval < time.Second {
+		return fmt.Errorf("--interval must be at least 1s")
+	}
+	townBeads, err := getTownBeadsDir()
+	if err != nil {
+		return err
+	}
+	convoyID, err := resolveConvoyArg(townBeads, args[0])
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	ticker := time.NewTicker(convoyProgressInterval)
+	defer ticker.Stop()
+	for {
+		title, status, description, err := showConvoyForThrottle(townBeads, convoyID)
+		if err != nil {
+			return err
+		}
+		tracked, err := getTrackedIssues(townBeads, convoyID)
+		if err != nil {
+			return fmt.Errorf("getting tracked issues for %s: %w", convoyID, err)
+		}
+		p := summarizeConvoyProgress(tracked, convoyMaxActiveFromFields(description))
+		out := renderConvoyProgress(convoyID, title, status, p, tracked)
+
+		if !convoyProgressWatch {
+			fmt.Print(out)
+			return nil
+		}
+		fmt.Print("\033[H\033[2J")
+		fmt.Print(out)
+		if normalizeConvoyStatus(status) == convoyStatusClosed {
+			return nil
+		}
+		fmt.Printf("\n  %s\n", style.Dim.Render(fmt.Sprintf("Updated %s · refreshing every %s · Ctrl-C to exit",
+			time.Now().Format("15:04:05"), convoyProgressInterval)))
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestChooseLaunchThrottle(t *testing.T) {
+	tests := []struct {
+		name                                string
+		requested, current, wave1, hostFree int
+		hostLimited                         bool
+		wantLimit                           int
+		wantReason                          string
+	}{
+		{"unthrottled fits host", 0, 0, 3, 10, true, 0, ""},
+		{"flag wins", 2, 5, 8, 1, true, 2, "--max-active"},
+		{"convoy field", 0, 4, 8, 1, true, 4, "convoy Max-Active"},
+		{"host capacity", 0, 0, 8, 3, true, 3, "host capacity"},
+		{"host full still starts one", 0, 0, 8, 0, true, 1, "host capacity"},
+		{"scheduler handles capacity", 0, 0, 8, 0, false, 0, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := chooseLaunchThrottle(tt.requested, tt.current, tt.wave1, tt.hostFree, tt.hostLimited)
+			if got.Limit != tt.wantLimit || got.Reason != tt.wantReason {
+				t.Errorf("got %+v, want {Limit:%d Reason:%s}", got, tt.wantLimit, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestSplitWave1(t *testing.T) {
+	waves := []Wave{
+		{Number: 1, Tasks: []string{"gt-a", "gt-b", "gt-c", "gt-d"}},
+		{Number: 2, Tasks: []string{"gt-e"}},
+	}
+
+	split, queued := splitWave1(waves, 2)
+	if !reflect.DeepEqual(split[0].Tasks, []string{"gt-a", "gt-b"}) {
+		t.Errorf("dispatched = %v, want [gt-a gt-b]", split[0].Tasks)
+	}
+	if !reflect.DeepEqual(queued, []string{"gt-c", "gt-d"}) {
+		t.Errorf("queued = %v, want [gt-c gt-d]", queued)
+	}
+	if len(waves[0].Tasks) != 4 || len(split[1].Tasks) != 1 {
+		t.Errorf("splitWave1 modified input or later waves: %v / %v", waves, split)
+	}
+
+	for _, limit := range []int{0, 4, 10} {
+		split, queued := splitWave1(waves, limit)
+		if len(queued) != 0 || len(split[0].Tasks) != 4 {
+			t.Errorf("limit %d: got %v queued %v, want wave unchanged", limit, split[0].Tasks, queued)
+		}
+	}
+}
+
+func TestSummarizeConvoyProgress(t *testing.T) {
+	tracked := []trackedIssueInfo{
+		{ID: "gt-1", Status: "closed"},
+		{ID: "gt-2", Status: "closed"},
+		{ID: "gt-3", Status: "hooked"},
+		{ID: "gt-4", Status: "open", Assignee: "gastown/polecats/nux"},
+		{ID: "gt-5", Status: "open"},
+		{ID: "gt-6", Status: "open", Blocked: true},
+	}
+	got := summarizeConvoyProgress(tracked, 2)
+	want := convoyProgress{Total: 6, Done: 2, InFlight: 2, Queued: 1, Blocked: 1, MaxActive: 2}
+	if got != want {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+	if n := countInFlightTracked(tracked); n != 2 {
+		t.Errorf("countInFlightTracked = %d, want 2", n)
+	}
+}
+
+func TestRenderProgressBar(t *testing.T) {
+	p := convoyProgress{Total: 4, Done: 1, InFlight: 2}
+	if got, want := renderProgressBar(p, 8), "██▓▓▓▓░░"; got != want {
+		t.Errorf("renderProgressBar = %q, want %q", got, want)
+	}
+	if got := renderProgressBar(convoyProgress{}, 4); got != "░░░░" {
+		t.Errorf("empty convoy bar = %q", got)
+	}
+}
+
+func TestRenderThrottleNotice(t *testing.T) {
+	dag := &ConvoyDAG{Nodes: map[string]*ConvoyDAGNode{
+		"gt-c": {ID: "gt-c", Title: "Task C", Rig: "gastown"},
+	}}
+	out := renderThrottleNotice("hq-cv-abc", launchThrottle{Limit: 2, Reason: "host capacity"}, []string{"gt-d", "gt-c"}, dag)
+
+	for _, want := range []string{
+		"Throttled to 2 task(s) in flight (host capacity)",
+		"○ gt-c  Task C  (rig: gastown)",
+		"gt convoy progress hq-cv-abc --watch",
+		"gt convoy throttle hq-cv-abc <max-active>",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Index(out, "gt-c") > strings.Index(out, "gt-d") {
+		t.Errorf("queued tasks not sorted:\n%s", out)
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/util"
 	"github.com/steveyegge/gastown/internal/workspace"
 )
 
@@ -29,6 +30,8 @@ var (
 	costsWeek    bool
 	costsByRole  bool
 	costsByRig   bool
+	costsByAgent bool
+	costsByDay   bool
 	costsVerbose bool
 
 	// Record subcommand flags
@@ -39,17 +42,22 @@ var (
 	digestYesterday bool
 	digestDate      string
 	digestDryRun    bool
-
 )
 
 var costsCmd = &cobra.Command{
 	Use:     "costs",
+	Aliases: []string{"cost"},
 	GroupID: GroupDiag,
 	Short:   "Show costs for running Claude sessions",
 	Long: `Display costs for Claude Code sessions in Gas Town.
 
 Costs are calculated from Claude Code transcript files at ~/.claude/projects/
 by summing token usage from assistant messages and applying model-specific pricing.
+Token counts are recorded alongside dollar estimates and carried into the daily
+digest beads, so both can be broken down by agent, rig, role, or day.
+Budget caps ("budget" in settings/config.json) are checked against these
+costs: over-budget rigs and agents get no new dispatch and the mayor is mailed.
+Spend against the caps is recomputed at most every 5 minutes.
 
 Examples:
   gt costs              # Live costs from running sessions
@@ -57,6 +65,8 @@ Examples:
   gt costs --week       # This week's costs from digest beads + today's log
   gt costs --by-role    # Breakdown by role (polecat, witness, etc.)
   gt costs --by-rig     # Breakdown by rig
+  gt costs --by-agent   # Breakdown by agent (gastown/polecats/nux, mayor, ...)
+  gt costs --week --by-day  # Daily totals for the week
   gt costs --json       # Output as JSON
   gt costs -v           # Show debug output for failures
 
@@ -112,6 +122,8 @@ func init() {
 	costsCmd.Flags().BoolVar(&costsWeek, "week", false, "Show this week's total from session events")
 	costsCmd.Flags().BoolVar(&costsByRole, "by-role", false, "Show breakdown by role")
 	costsCmd.Flags().BoolVar(&costsByRig, "by-rig", false, "Show breakdown by rig")
+	costsCmd.Flags().BoolVar(&costsByAgent, "by-agent", false, "Show breakdown by agent")
+	costsCmd.Flags().BoolVar(&costsByDay, "by-day", false, "Show breakdown by day")
 	costsCmd.Flags().BoolVarP(&costsVerbose, "verbose", "v", false, "Show debug output for failures")
 
 	// Add record subcommand
@@ -129,33 +141,39 @@ func init() {
 
 // SessionCost represents cost info for a single session.
 type SessionCost struct {
-	Session string  `json:"session"`
-	Role    string  `json:"role"`
-	Rig     string  `json:"rig,omitempty"`
-	Worker  string  `json:"worker,omitempty"`
-	Cost    float64 `json:"cost_usd"`
-	Running bool    `json:"running"`
+	Session string      `json:"session"`
+	Role    string      `json:"role"`
+	Rig     string      `json:"rig,omitempty"`
+	Worker  string      `json:"worker,omitempty"`
+	Cost    float64     `json:"cost_usd"`
+	Tokens  *CostTokens `json:"tokens,omitempty"`
+	Running bool        `json:"running"`
 }
 
 // CostEntry is a ledger entry for historical cost tracking.
 type CostEntry struct {
-	SessionID string    `json:"session_id"`
-	Role      string    `json:"role"`
-	Rig       string    `json:"rig,omitempty"`
-	Worker    string    `json:"worker,omitempty"`
-	CostUSD   float64   `json:"cost_usd"`
-	StartedAt time.Time `json:"started_at"`
-	EndedAt   time.Time `json:"ended_at"`
-	WorkItem  string    `json:"work_item,omitempty"`
+	SessionID string      `json:"session_id"`
+	Role      string      `json:"role"`
+	Rig       string      `json:"rig,omitempty"`
+	Worker    string      `json:"worker,omitempty"`
+	CostUSD   float64     `json:"cost_usd"`
+	StartedAt time.Time   `json:"started_at"`
+	EndedAt   time.Time   `json:"ended_at"`
+	WorkItem  string      `json:"work_item,omitempty"`
+	Tokens    *CostTokens `json:"tokens,omitempty"`
+	Sessions  int         `json:"sessions,omitempty"` // Sessions a digest-synthesized entry stands for
 }
 
 // CostsOutput is the JSON output structure.
 type CostsOutput struct {
-	Sessions []SessionCost      `json:"sessions,omitempty"`
-	Total    float64            `json:"total_usd"`
-	ByRole   map[string]float64 `json:"by_role,omitempty"`
-	ByRig    map[string]float64 `json:"by_rig,omitempty"`
-	Period   string             `json:"period,omitempty"`
+	Sessions []SessionCost         `json:"sessions,omitempty"`
+	Total    float64               `json:"total_usd"`
+	Tokens   int                   `json:"tokens,omitempty"`
+	ByRole   map[string]float64    `json:"by_role,omitempty"`
+	ByRig    map[string]float64    `json:"by_rig,omitempty"`
+	ByAgent  map[string]CostTotals `json:"by_agent,omitempty"`
+	ByDay    map[string]CostTotals `json:"by_day,omitempty"`
+	Period   string                `json:"period,omitempty"`
 }
 
 // costRegex matches cost patterns like "$1.23" or "$12.34"
@@ -171,8 +189,8 @@ type TranscriptMessage struct {
 
 // TranscriptMessageBody contains the message content and usage info.
 type TranscriptMessageBody struct {
-	Model string          `json:"model"`
-	Role  string          `json:"role"`
+	Model string           `json:"model"`
+	Role  string           `json:"role"`
 	Usage *TranscriptUsage `json:"usage,omitempty"`
 }
 
@@ -213,7 +231,7 @@ var modelPricing = map[string]struct {
 
 func runCosts(cmd *cobra.Command, args []string) error {
 	// If querying ledger, use ledger functions
-	if costsToday || costsWeek || costsByRole || costsByRig {
+	if costsToday || costsWeek || costsByRole || costsByRig || costsByAgent || costsByDay {
 		return runCostsFromLedger()
 	}
 
@@ -245,21 +263,17 @@ func runLiveCosts() error {
 		// Get working directory of the session
 		workDir, err := getTmuxSessionWorkDir(sess)
 		if err != nil {
-			if costsVerbose {
-				fmt.Fprintf(os.Stderr, "[costs] could not get workdir for %s: %v\n", sess, err)
-			}
+			logger.Debug("costs: could not get workdir", "session", sess, "err", err)
 			continue
 		}
 
-		// Extract cost from Claude transcript
-		cost, err := extractCostFromWorkDir(workDir)
+		// Extract usage from Claude transcript
+		usage, err := extractUsageFromWorkDir(workDir)
 		if err != nil {
-			if costsVerbose {
-				fmt.Fprintf(os.Stderr, "[costs] could not extract cost for %s: %v\n", sess, err)
-			}
+			logger.Debug("costs: could not extract cost", "session", sess, "err", err)
 			// Still include the session with zero cost
-			cost = 0.0
 		}
+		cost := calculateCost(usage)
 
 		// Check if an agent appears to be running
 		running := t.IsAgentRunning(sess)
@@ -270,6 +284,7 @@ func runLiveCosts() error {
 			Rig:     rig,
 			Worker:  worker,
 			Cost:    cost,
+			Tokens:  costTokensFromUsage(usage),
 			Running: running,
 		})
 		total += cost
@@ -313,8 +328,8 @@ func runCostsFromLedger() error {
 		// Also include today's wisps (not yet digested)
 		todayEntries, _ := querySessionCostEntries(now)
 		entries = append(entries, todayEntries...)
-	} else if costsByRole || costsByRig {
-		// When using --by-role or --by-rig without time filter, default to today
+	} else if costsByRole || costsByRig || costsByAgent || costsByDay {
+		// When using a breakdown flag without time filter, default to today
 		// (querying all historical events would be expensive and likely empty)
 		entries, err = querySessionCostEntries(now)
 		if err != nil {
@@ -333,20 +348,27 @@ func runCostsFromLedger() error {
 
 	// Calculate totals
 	var total float64
+	var tokens int
 	byRole := make(map[string]float64)
 	byRig := make(map[string]float64)
+	byAgent := make(map[string]CostTotals)
+	byDay := make(map[string]CostTotals)
 
 	for _, entry := range entries {
 		total += entry.CostUSD
+		tokens += entry.Tokens.Total()
 		byRole[entry.Role] += entry.CostUSD
 		if entry.Rig != "" {
 			byRig[entry.Rig] += entry.CostUSD
 		}
+		addCostTotals(byAgent, costEntryAgent(entry), entry)
+		addCostTotals(byDay, util.DayKey(entry.EndedAt, time.Local), entry)
 	}
 
 	// Build output
 	output := CostsOutput{
-		Total: total,
+		Total:  total,
+		Tokens: tokens,
 	}
 
 	if costsByRole {
@@ -355,6 +377,12 @@ func runCostsFromLedger() error {
 	if costsByRig {
 		output.ByRig = byRig
 	}
+	if costsByAgent {
+		output.ByAgent = byAgent
+	}
+	if costsByDay {
+		output.ByDay = byDay
+	}
 
 	// Set period label
 	if costsToday {
@@ -433,9 +461,7 @@ func querySessionEvents() []CostEntry {
 		entries, err := querySessionEventsFromLocation(location)
 		if err != nil {
 			// Log but continue with other locations
-			if costsVerbose {
-				fmt.Fprintf(os.Stderr, "[costs] query from %s failed: %v\n", location, err)
-			}
+			logger.Debug("costs: query failed", "location", location, "err", err)
 			continue
 		}
 
@@ -598,8 +624,8 @@ func queryDigestBeads(days int) ([]CostEntry, error) {
 			}
 		}
 
-		// Check date is within range
-		digestDate, err := time.Parse("2006-01-02", digest.Date)
+		// Check date is within range. Digest dates are local calendar days.
+		digestDate, err := time.ParseInLocation("2006-01-02", digest.Date, time.Local)
 		if err != nil {
 			continue
 		}
@@ -608,9 +634,12 @@ func queryDigestBeads(days int) ([]CostEntry, error) {
 		}
 
 		// If the digest has per-session data (old format), use it directly.
-		// Otherwise, synthesize entries from the aggregate ByRole data.
+		// Otherwise, synthesize entries from the aggregate ByAgent data, or
+		// ByRole for digests written before agents were tracked.
 		if len(digest.Sessions) > 0 {
 			entries = append(entries, digest.Sessions...)
+		} else if len(digest.ByAgent) > 0 {
+			entries = append(entries, costEntriesFromAgents(digest.Date, digestDate, digest.ByAgent)...)
 		} else {
 			for role, cost := range digest.ByRole {
 				entries = append(entries, CostEntry{
@@ -792,25 +821,24 @@ func calculateCost(usage *TokenUsage) float64 {
 	return inputCost + cacheReadCost + cacheCreateCost + outputCost
 }
 
-// extractCostFromWorkDir extracts cost from Claude Code transcript for a working directory.
-// This reads the most recent transcript file and sums all token usage.
-func extractCostFromWorkDir(workDir string) (float64, error) {
+// extractUsageFromWorkDir sums token usage from the most recent Claude Code
+// transcript for a working directory.
+func extractUsageFromWorkDir(workDir string) (*TokenUsage, error) {
 	projectDir, err := getClaudeProjectDir(workDir)
 	if err != nil {
-		return 0, fmt.Errorf("getting project dir: %w", err)
+		return nil, fmt.Errorf("getting project dir: %w", err)
 	}
 
 	transcriptPath, err := findLatestTranscript(projectDir)
 	if err != nil {
-		return 0, fmt.Errorf("finding transcript: %w", err)
+		return nil, fmt.Errorf("finding transcript: %w", err)
 	}
 
 	usage, err := parseTranscriptUsage(transcriptPath)
 	if err != nil {
-		return 0, fmt.Errorf("parsing transcript: %w", err)
+		return nil, fmt.Errorf("parsing transcript: %w", err)
 	}
-
-	return calculateCost(usage), nil
+	return usage, nil
 }
 
 // getTmuxSessionWorkDir gets the current working directory of a tmux session.
@@ -882,7 +910,11 @@ func outputLedgerHuman(output CostsOutput, entries []CostEntry) error {
 	fmt.Printf("\n%s Cost Summary%s\n\n", style.Bold.Render("📊"), periodStr)
 
 	// Total
-	fmt.Printf("%s $%.2f\n", style.Bold.Render("Total:"), output.Total)
+	fmt.Printf("%s $%.2f", style.Bold.Render("Total:"), output.Total)
+	if output.Tokens > 0 {
+		fmt.Printf(" %s", style.Dim.Render("("+formatTokens(output.Tokens)+" tokens)"))
+	}
+	fmt.Println()
 
 	// By role breakdown
 	if output.ByRole != nil && len(output.ByRole) > 0 {
@@ -901,6 +933,18 @@ func outputLedgerHuman(output CostsOutput, entries []CostEntry) error {
 		}
 	}
 
+	// By agent breakdown
+	if len(output.ByAgent) > 0 {
+		fmt.Printf("\n%s\n", style.Bold.Render("By Agent:"))
+		printCostTotals(output.ByAgent, 28)
+	}
+
+	// By day breakdown
+	if len(output.ByDay) > 0 {
+		fmt.Printf("\n%s\n", style.Bold.Render("By Day:"))
+		printCostTotals(output.ByDay, 12)
+	}
+
 	// Session count
 	fmt.Printf("\n%s %d sessions\n", style.Dim.Render("Entries:"), len(entries))
 
@@ -909,13 +953,14 @@ func outputLedgerHuman(output CostsOutput, entries []CostEntry) error {
 
 // CostLogEntry represents a single entry in the costs.jsonl log file.
 type CostLogEntry struct {
-	SessionID string    `json:"session_id"`
-	Role      string    `json:"role"`
-	Rig       string    `json:"rig,omitempty"`
-	Worker    string    `json:"worker,omitempty"`
-	CostUSD   float64   `json:"cost_usd"`
-	EndedAt   time.Time `json:"ended_at"`
-	WorkItem  string    `json:"work_item,omitempty"`
+	SessionID string      `json:"session_id"`
+	Role      string      `json:"role"`
+	Rig       string      `json:"rig,omitempty"`
+	Worker    string      `json:"worker,omitempty"`
+	CostUSD   float64     `json:"cost_usd"`
+	EndedAt   time.Time   `json:"ended_at"`
+	WorkItem  string      `json:"work_item,omitempty"`
+	Tokens    *CostTokens `json:"tokens,omitempty"`
 }
 
 // getCostsLogPath returns the path to the costs log file.
@@ -944,9 +989,7 @@ func runCostsRecord(cmd *cobra.Command, args []string) error {
 	if session == "" {
 		// Not a Gas Town session (e.g., Claude Code launched outside gt agent system).
 		// Exit silently — no costs to record.
-		if costsVerbose {
-			fmt.Fprintf(os.Stderr, "[costs] no session context found, skipping costs record\n")
-		}
+		logger.Debug("costs: no session context found, skipping costs record")
 		return nil
 	}
 
@@ -957,24 +1000,20 @@ func runCostsRecord(cmd *cobra.Command, args []string) error {
 		var err error
 		workDir, err = getTmuxSessionWorkDir(session)
 		if err != nil {
-			if costsVerbose {
-				fmt.Fprintf(os.Stderr, "[costs] could not get workdir for %s: %v\n", session, err)
-			}
+			logger.Debug("costs: could not get workdir", "session", session, "err", err)
 		}
 	}
 
-	// Extract cost from Claude transcript
-	var cost float64
+	// Extract usage from Claude transcript
+	var usage *TokenUsage
 	if workDir != "" {
 		var err error
-		cost, err = extractCostFromWorkDir(workDir)
+		usage, err = extractUsageFromWorkDir(workDir)
 		if err != nil {
-			if costsVerbose {
-				fmt.Fprintf(os.Stderr, "[costs] could not extract cost from transcript: %v\n", err)
-			}
-			cost = 0.0
+			logger.Debug("costs: could not extract cost from transcript", "err", err)
 		}
 	}
+	cost := calculateCost(usage)
 
 	// Parse session name
 	role, rig, worker := parseSessionName(session)
@@ -986,8 +1025,9 @@ func runCostsRecord(cmd *cobra.Command, args []string) error {
 		Rig:       rig,
 		Worker:    worker,
 		CostUSD:   cost,
-		EndedAt:   time.Now(),
+		EndedAt:   time.Now().UTC(),
 		WorkItem:  recordWorkItem,
+		Tokens:    costTokensFromUsage(usage),
 	}
 
 	// Marshal to JSON
@@ -1107,22 +1147,26 @@ func detectCurrentTmuxSession() string {
 
 // CostDigest represents the aggregated daily cost report.
 type CostDigest struct {
-	Date         string             `json:"date"`
-	TotalUSD     float64            `json:"total_usd"`
-	SessionCount int                `json:"session_count"`
-	Sessions     []CostEntry        `json:"sessions,omitempty"`
-	ByRole       map[string]float64 `json:"by_role"`
-	ByRig        map[string]float64 `json:"by_rig,omitempty"`
+	Date         string                `json:"date"`
+	TotalUSD     float64               `json:"total_usd"`
+	SessionCount int                   `json:"session_count"`
+	Sessions     []CostEntry           `json:"sessions,omitempty"`
+	TotalTokens  int                   `json:"total_tokens,omitempty"`
+	ByRole       map[string]float64    `json:"by_role"`
+	ByRig        map[string]float64    `json:"by_rig,omitempty"`
+	ByAgent      map[string]CostTotals `json:"by_agent,omitempty"`
 }
 
 // CostDigestPayload is the compact payload stored in the bead.
 // It excludes per-session details to avoid exceeding Dolt column size limits.
 type CostDigestPayload struct {
-	Date         string             `json:"date"`
-	TotalUSD     float64            `json:"total_usd"`
-	SessionCount int                `json:"session_count"`
-	ByRole       map[string]float64 `json:"by_role"`
-	ByRig        map[string]float64 `json:"by_rig,omitempty"`
+	Date         string                `json:"date"`
+	TotalUSD     float64               `json:"total_usd"`
+	SessionCount int                   `json:"session_count"`
+	TotalTokens  int                   `json:"total_tokens,omitempty"`
+	ByRole       map[string]float64    `json:"by_role"`
+	ByRig        map[string]float64    `json:"by_rig,omitempty"`
+	ByAgent      map[string]CostTotals `json:"by_agent,omitempty"`
 }
 
 // runCostsDigest aggregates session cost entries into a daily digest bead.
@@ -1161,11 +1205,14 @@ func runCostsDigest(cmd *cobra.Command, args []string) error {
 		Sessions: costEntries,
 		ByRole:   make(map[string]float64),
 		ByRig:    make(map[string]float64),
+		ByAgent:  make(map[string]CostTotals),
 	}
 
 	for _, e := range costEntries {
 		digest.TotalUSD += e.CostUSD
+		digest.TotalTokens += e.Tokens.Total()
 		digest.SessionCount++
+		addCostTotals(digest.ByAgent, costEntryAgent(e), e)
 		digest.ByRole[e.Role] += e.CostUSD
 		if e.Rig != "" {
 			digest.ByRig[e.Rig] += e.CostUSD
@@ -1198,7 +1245,7 @@ func runCostsDigest(cmd *cobra.Command, args []string) error {
 	// Delete source entries from log file
 	deletedCount, deleteErr := deleteSessionCostEntries(targetDate)
 	if deleteErr != nil {
-		fmt.Fprintf(os.Stderr, "warning: failed to delete some source entries: %v\n", deleteErr)
+		logger.Warn("failed to delete some source entries", "err", deleteErr)
 	}
 
 	fmt.Printf("%s Created Cost Report %s (bead: %s)\n", style.Success.Render("✓"), dateStr, digestID)
@@ -1236,14 +1283,12 @@ func querySessionCostEntries(targetDate time.Time) ([]CostEntry, error) {
 
 		var logEntry CostLogEntry
 		if err := json.Unmarshal([]byte(line), &logEntry); err != nil {
-			if costsVerbose {
-				fmt.Fprintf(os.Stderr, "[costs] failed to parse log entry: %v\n", err)
-			}
+			logger.Debug("costs: failed to parse log entry", "err", err)
 			continue
 		}
 
 		// Filter by target date
-		if logEntry.EndedAt.Format("2006-01-02") != targetDay {
+		if util.DayKey(logEntry.EndedAt, time.Local) != targetDay {
 			continue
 		}
 
@@ -1255,6 +1300,7 @@ func querySessionCostEntries(targetDate time.Time) ([]CostEntry, error) {
 			CostUSD:   logEntry.CostUSD,
 			EndedAt:   logEntry.EndedAt,
 			WorkItem:  logEntry.WorkItem,
+			Tokens:    logEntry.Tokens,
 		})
 	}
 
@@ -1301,8 +1347,10 @@ func createCostDigestBead(digest CostDigest) (string, error) {
 		Date:         digest.Date,
 		TotalUSD:     digest.TotalUSD,
 		SessionCount: digest.SessionCount,
+		TotalTokens:  digest.TotalTokens,
 		ByRole:       digest.ByRole,
 		ByRig:        digest.ByRig,
+		ByAgent:      digest.ByAgent,
 	}
 	payloadJSON, err := json.Marshal(compactPayload)
 	if err != nil {
@@ -1370,7 +1418,7 @@ func deleteSessionCostEntries(targetDate time.Time) (int, error) {
 		}
 
 		// Remove entries from target date
-		if logEntry.EndedAt.Format("2006-01-02") == targetDay {
+		if util.DayKey(logEntry.EndedAt, time.Local) == targetDay {
 			deletedCount++
 			continue
 		}
@@ -1394,4 +1442,3 @@ func deleteSessionCostEntries(targetDate time.Time) (int, error) {
 
 	return deletedCount, nil
 }
-
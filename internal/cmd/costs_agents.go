@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/session"
+)
+
+// CostTokens is the token usage behind a cost.
+type CostTokens struct {
+	Input       int `json:"input"`
+	Output      int `json:"output"`
+	CacheRead   int `json:"cache_read,omitempty"`
+	CacheCreate int `json:"cache_create,omitempty"`
+}
+
+// Total returns all tokens processed, cached or not.
+func (t *CostTokens) Total() int {
+	if t == nil {
+		return 0
+	}
+	return t.Input + t.Output + t.CacheRead + t.CacheCreate
+}
+
+// costTokensFromUsage converts transcript usage, or returns nil if empty.
+func costTokensFromUsage(u *TokenUsage) *CostTokens {
+	if u == nil {
+		return nil
+	}
+	t := &CostTokens{
+		Input:       u.InputTokens,
+		Output:      u.OutputTokens,
+		CacheRead:   u.CacheReadInputTokens,
+		CacheCreate: u.CacheCreationInputTokens,
+	}
+	if t.Total() == 0 {
+		return nil
+	}
+	return t
+}
+
+// CostTotals aggregates cost and tokens for one agent or day.
+type CostTotals struct {
+	CostUSD  float64 `json:"cost_usd"`
+	Tokens   int     `json:"tokens,omitempty"`
+	Sessions int     `json:"sessions"`
+}
+
+// costAgentAddress returns the agent address a cost entry belongs to, in
+// mail-address form (mayor, gastown/witness, gastown/polecats/nux).
+func costAgentAddress(role, rig, worker string) string {
+	switch {
+	case rig == "" && worker != "":
+		return worker
+	case rig == "":
+		return role
+	case role == constants.RoleCrew && worker != "":
+		return rig + "/crew/" + worker
+	case role == constants.RolePolecat && worker != "":
+		return rig + "/polecats/" + worker
+	default:
+		return rig + "/" + role
+	}
+}
+
+// costEntriesFromAgents synthesizes one ledger entry per agent from a
+// digest's by-agent totals, so agent, role, and rig breakdowns work over
+// digested days.
+func costEntriesFromAgents(date string, day time.Time, byAgent map[string]CostTotals) []CostEntry {
+	var entries []CostEntry
+	for addr, totals := range byAgent {
+		e := CostEntry{
+			SessionID: fmt.Sprintf("digest-%s-%s", date, addr),
+			Role:      "unknown",
+			Worker:    addr,
+			CostUSD:   totals.CostUSD,
+			EndedAt:   day,
+			Sessions:  totals.Sessions,
+		}
+		if totals.Tokens > 0 {
+			// Digests keep only the total; count it as input.
+			e.Tokens = &CostTokens{Input: totals.Tokens}
+		}
+		if id, err := session.ParseAddress(addr); err == nil {
+			e.Role, e.Rig, e.Worker = string(id.Role), id.Rig, id.Name
+		}
+		entries = append(entries, e)
+	}
+	return entries
+}
+
+// addCostTotals adds entry to the bucket for key.
+func addCostTotals(m map[string]CostTotals, key string, e CostEntry) {
+	t := m[key]
+	t.CostUSD += e.CostUSD
+	t.Tokens += e.Tokens.Total()
+	t.Sessions += max(e.Sessions, 1)
+	m[key] = t
+}
+
+// costEntryAgent returns the agent address for a ledger entry.
+func costEntryAgent(e CostEntry) string {
+	return costAgentAddress(e.Role, e.Rig, e.Worker)
+}
+
+// formatTokens renders a token count compactly (e.g. 12.3M).
+func formatTokens(n int) string {
+	switch {
+	case n >= 1_000_000:
+		return fmt.Sprintf("%.1fM", float64(n)/1_000_000)
+	case n >= 1_000:
+		return fmt.Sprintf("%.1fk", float64(n)/1_000)
+	default:
+		return fmt.Sprintf("%d", n)
+	}
+}
+
+// printCostTotals prints a breakdown sorted by key.
+func printCostTotals(m map[string]CostTotals, width int) {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		t := m[k]
+		line := fmt.Sprintf("  %-*s $%8.2f  %3d sessions", width, k, t.CostUSD, t.Sessions)
+		if t.Tokens > 0 {
+			line += "  " + formatTokens(t.Tokens) + " tokens"
+		}
+		fmt.Println(line)
+	}
+}
@@ -292,3 +292,80 @@ func TestCostDigestPayload_ExcludesSessions(t *testing.T) {
 		t.Errorf("by_role should have 3 entries, got %d", len(asDigest.ByRole))
 	}
 }
+
+func TestCostAgentAddress(t *testing.T) {
+	tests := []struct {
+		role, rig, worker string
+		want              string
+	}{
+		{"mayor", "", "mayor", "mayor"},
+		{"deacon", "", "deacon", "deacon"},
+		{"witness", "gastown", "", "gastown/witness"},
+		{"refinery", "gastown", "", "gastown/refinery"},
+		{"crew", "gastown", "max", "gastown/crew/max"},
+		{"polecat", "gastown", "toast", "gastown/polecats/toast"},
+		{"unknown", "", "weird-session", "weird-session"},
+	}
+	for _, tt := range tests {
+		if got := costAgentAddress(tt.role, tt.rig, tt.worker); got != tt.want {
+			t.Errorf("costAgentAddress(%q, %q, %q) = %q, want %q", tt.role, tt.rig, tt.worker, got, tt.want)
+		}
+	}
+}
+
+func TestCostDigestByAgent_RoundTrip(t *testing.T) {
+	day := time.Date(2026, 2, 14, 0, 0, 0, 0, time.UTC)
+	sessions := []CostEntry{
+		{Role: "polecat", Rig: "gastown", Worker: "toast", CostUSD: 1.50, EndedAt: day, Tokens: &CostTokens{Input: 1000, Output: 500}},
+		{Role: "polecat", Rig: "gastown", Worker: "toast", CostUSD: 0.50, EndedAt: day, Tokens: &CostTokens{Input: 200, CacheRead: 300}},
+		{Role: "witness", Rig: "gastown", CostUSD: 0.25, EndedAt: day},
+		{Role: "mayor", Worker: "mayor", CostUSD: 1.00, EndedAt: day},
+	}
+	byAgent := make(map[string]CostTotals)
+	for _, e := range sessions {
+		addCostTotals(byAgent, costEntryAgent(e), e)
+	}
+	if got := byAgent["gastown/polecats/toast"]; got.Sessions != 2 || got.Tokens != 2000 || got.CostUSD != 2.0 {
+		t.Errorf("toast totals = %+v, want 2 sessions, 2000 tokens, $2.00", got)
+	}
+
+	// Digested days are re-expanded into one entry per agent; aggregating
+	// those must give back the same per-agent, per-role, and per-rig totals.
+	payload, err := json.Marshal(CostDigestPayload{Date: "2026-02-14", ByAgent: byAgent})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var digest CostDigest
+	if err := json.Unmarshal(payload, &digest); err != nil {
+		t.Fatal(err)
+	}
+	entries := costEntriesFromAgents(digest.Date, day, digest.ByAgent)
+
+	again := make(map[string]CostTotals)
+	byRole := make(map[string]float64)
+	for _, e := range entries {
+		addCostTotals(again, costEntryAgent(e), e)
+		byRole[e.Role] += e.CostUSD
+	}
+	if len(again) != len(byAgent) {
+		t.Fatalf("agents after round trip = %v, want %v", again, byAgent)
+	}
+	for addr, want := range byAgent {
+		if again[addr] != want {
+			t.Errorf("%s after round trip = %+v, want %+v", addr, again[addr], want)
+		}
+	}
+	if byRole["polecat"] != 2.0 || byRole["witness"] != 0.25 || byRole["mayor"] != 1.0 {
+		t.Errorf("by role after round trip = %v", byRole)
+	}
+}
+
+func TestCostTokensFromUsage(t *testing.T) {
+	if got := costTokensFromUsage(&TokenUsage{}); got != nil {
+		t.Errorf("empty usage = %+v, want nil", got)
+	}
+	got := costTokensFromUsage(&TokenUsage{InputTokens: 10, OutputTokens: 20, CacheReadInputTokens: 30, CacheCreationInputTokens: 40})
+	if got == nil || got.Total() != 100 {
+		t.Errorf("tokens = %+v, want total 100", got)
+	}
+}
@@ -61,7 +61,7 @@ func runCrewList(cmd *cobra.Command, args []string) error {
 
 		workers, err := crewMgr.List()
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "warning: failed to list crew workers in %s: %v\n", r.Name, err)
+			logger.Warn("failed to list crew workers", "rig", r.Name, "err", err)
 			continue
 		}
 
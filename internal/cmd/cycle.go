@@ -214,11 +214,18 @@ func cycleRigOpsSession(direction int, currentSession, rig string) error {
 	return cycleInGroup(direction, currentSession, sessions)
 }
 
-// listTmuxSessions returns all tmux session names.
+// listTmuxSessions returns all tmux session names, leaving out the hidden
+// sessions of tmux control connections.
 func listTmuxSessions() ([]string, error) {
 	out, err := tmux.BuildCommand("list-sessions", "-F", "#{session_name}").Output()
 	if err != nil {
 		return nil, err
 	}
-	return splitLines(string(out)), nil
+	var sessions []string
+	for _, s := range splitLines(string(out)) {
+		if !tmux.IsControlSession(s) {
+			sessions = append(sessions, s)
+		}
+	}
+	return sessions, nil
 }
@@ -6,6 +6,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -27,6 +28,10 @@ The daemon is a simple Go process that:
 - Processes lifecycle requests (cycle, restart, shutdown)
 - Restarts sessions when agents request cycling
 
+Changes to mayor/daemon.json (patrols enabled, intervals) and to rig config
+take effect without a restart: the daemon watches the town's config files
+and logs a config_reloaded event when it picks up a change.
+
 The daemon is a "dumb scheduler" - all intelligence is in agents.`,
 }
 
@@ -133,10 +138,13 @@ When an agent crashes repeatedly, the daemon enters crash loop mode and
 stops restarting it. Use this command to reset the crash loop counter so
 the daemon will resume restarting the agent.
 
-The agent name is the session identity (e.g., "deacon", "mayor").
+The agent name is the session identity (e.g., "deacon", "mayor",
+"<rig>/witness", "<rig>/refinery"). Clearing also removes the crash-loop
+label from the agent's bead. 'gt daemon status' lists crash-looping agents.
 
 Examples:
-  gt daemon clear-backoff deacon   # Reset deacon crash loop`,
+  gt daemon clear-backoff deacon             # Reset deacon crash loop
+  gt daemon clear-backoff gastown/witness    # Reset a rig's witness`,
 	Args: cobra.ExactArgs(1),
 	RunE: runDaemonClearBackoff,
 }
@@ -289,6 +297,19 @@ func runDaemonStatus(cmd *cobra.Command, args []string) error {
 		fmt.Printf("\nStart with: %s\n", style.Dim.Render("gt daemon start"))
 	}
 
+	if looping, err := daemon.CrashLoopingAgents(townRoot); err == nil && len(looping) > 0 {
+		agents := make([]string, 0, len(looping))
+		for agentID := range looping {
+			agents = append(agents, agentID)
+		}
+		sort.Strings(agents)
+		fmt.Printf("\n%s Crash-looping (not restarted):\n", style.Bold.Render("⚠"))
+		for _, agentID := range agents {
+			fmt.Printf("  %s since %s - '%s'\n", agentID, looping[agentID].Format("2006-01-02 15:04:05"),
+				style.Dim.Render("gt daemon clear-backoff "+agentID))
+		}
+	}
+
 	return nil
 }
 
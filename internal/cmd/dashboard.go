@@ -1,7 +1,9 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"os"
 	"os/exec"
@@ -13,15 +15,18 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/configwatch"
 	"github.com/steveyegge/gastown/internal/doltserver"
+	"github.com/steveyegge/gastown/internal/logging"
 	"github.com/steveyegge/gastown/internal/web"
 	"github.com/steveyegge/gastown/internal/workspace"
 )
 
 var (
-	dashboardPort int
-	dashboardBind string
-	dashboardOpen bool
+	dashboardPort    int
+	dashboardBind    string
+	dashboardOpen    bool
+	dashboardLogJSON bool
 )
 
 var dashboardCmd = &cobra.Command{
@@ -36,11 +41,15 @@ The dashboard shows real-time convoy status with:
 - Last activity indicator (green/yellow/red)
 - Auto-refresh every 30 seconds via htmx
 
+Edits to web_timeouts and worker_status in settings/config.json take effect
+without restarting the dashboard.
+
 Example:
   gt dashboard                    # Start on default port 8080
   gt dashboard --port 3000        # Start on port 3000
   gt dashboard --bind 0.0.0.0     # Listen on all interfaces
-  gt dashboard --open             # Start and open browser`,
+  gt dashboard --open             # Start and open browser
+  gt dashboard --log-json         # Log JSON records to stderr`,
 	RunE: runDashboard,
 }
 
@@ -48,6 +57,7 @@ func init() {
 	dashboardCmd.Flags().IntVar(&dashboardPort, "port", 8080, "HTTP port to listen on")
 	dashboardCmd.Flags().StringVar(&dashboardBind, "bind", "127.0.0.1", "Address to bind to (use 0.0.0.0 for all interfaces)")
 	dashboardCmd.Flags().BoolVar(&dashboardOpen, "open", false, "Open browser automatically")
+	dashboardCmd.Flags().BoolVar(&dashboardLogJSON, "log-json", false, "Log JSON records to stderr (default: logging.format in town settings)")
 	rootCmd.AddCommand(dashboardCmd)
 }
 
@@ -55,6 +65,7 @@ func runDashboard(cmd *cobra.Command, args []string) error {
 	// Check if we're in a workspace - if not, run in setup mode
 	var handler http.Handler
 	var err error
+	var logCfg *logging.Config
 
 	townRoot, wsErr := workspace.FindFromCwdOrError()
 	if wsErr != nil {
@@ -71,25 +82,33 @@ func runDashboard(cmd *cobra.Command, args []string) error {
 		// Without this, inherited env vars could point bd at the wrong port.
 		ensureDoltPortEnv(townRoot)
 
-		fetcher, fetchErr := web.NewLiveConvoyFetcher()
-		if fetchErr != nil {
-			return fmt.Errorf("creating convoy fetcher: %w", fetchErr)
-		}
-
-		// Load web timeouts config (nil-safe: NewDashboardMux applies defaults)
+		// Load web timeouts config (nil-safe: the dashboard applies defaults),
+		// and keep watching it so settings edits apply without a restart.
 		var webCfg *config.WebTimeoutsConfig
-		if ts, loadErr := config.LoadOrCreateTownSettings(config.TownSettingsPath(townRoot)); loadErr == nil {
+		watcher, watchErr := configwatch.New(townRoot)
+		if watchErr == nil {
+			webCfg = watcher.Current().Settings.WebTimeouts
+			logCfg = watcher.Current().Settings.Logging
+		} else if ts, loadErr := config.LoadOrCreateTownSettings(config.TownSettingsPath(townRoot)); loadErr == nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "warning: config hot-reload disabled: %v\n", watchErr)
 			webCfg = ts.WebTimeouts
+			logCfg = ts.Logging
 		} else {
 			fmt.Fprintf(cmd.ErrOrStderr(), "warning: loading town settings: %v (using defaults)\n", loadErr)
 		}
 
-		handler, err = web.NewDashboardMux(fetcher, webCfg)
-		if err != nil {
-			return fmt.Errorf("creating dashboard handler: %w", err)
+		dashboard, dashErr := web.NewReloadableDashboard(webCfg)
+		if dashErr != nil {
+			return fmt.Errorf("creating dashboard handler: %w", dashErr)
+		}
+		handler = dashboard
+		if watcher != nil {
+			watchDashboardConfig(cmd, watcher, dashboard)
 		}
 	}
 
+	setupDashboardLogging(cmd, logCfg)
+
 	// Build the listen address and display URL
 	listenAddr := fmt.Sprintf("%s:%d", dashboardBind, dashboardPort)
 	displayHost := dashboardBind
@@ -149,6 +168,43 @@ func runDashboard(cmd *cobra.Command, args []string) error {
 	return server.ListenAndServe()
 }
 
+// watchDashboardConfig rebuilds the dashboard when settings/config.json
+// changes. A settings file that fails to load keeps the running dashboard.
+func watchDashboardConfig(cmd *cobra.Command, watcher *configwatch.Watcher, dashboard *web.ReloadableDashboard) {
+	errOut := cmd.ErrOrStderr()
+	watcher.Actor = "dashboard"
+	watcher.OnError = func(err error) {
+		fmt.Fprintf(errOut, "warning: config reload: %v (keeping previous config)\n", err)
+	}
+	watcher.Subscribe(func(snap *configwatch.Snapshot) {
+		if !snap.HasChanged(configwatch.SettingsFile) {
+			return
+		}
+		if err := dashboard.Reload(snap.Settings.WebTimeouts); err != nil {
+			fmt.Fprintf(errOut, "warning: reloading dashboard: %v (keeping previous config)\n", err)
+		}
+	})
+	go func() {
+		if err := watcher.Run(context.Background()); err != nil {
+			fmt.Fprintf(errOut, "warning: config hot-reload stopped: %v\n", err)
+		}
+	}()
+}
+
+// setupDashboardLogging switches the dashboard's logs, request logging from
+// the web handlers included, to JSON on stderr when --log-json or the town's
+// logging.format asks for it. Text logs are left as set up for every command.
+func setupDashboardLogging(cmd *cobra.Command, cfg *logging.Config) {
+	if !dashboardLogJSON && !cfg.JSON() {
+		return
+	}
+	opts := logging.OptionsFromConfig(cfg, slog.LevelInfo, boolFlag(cmd, "verbose"), boolFlag(cmd, "quiet"))
+	opts.Writer = cmd.ErrOrStderr()
+	opts.JSON = true
+	opts.CaptureStdLog = true
+	logging.Setup(opts)
+}
+
 // ensureDoltPortEnv sets GT_DOLT_PORT and BEADS_DOLT_PORT to the actual Dolt
 // SQL server port. This prevents bd subprocesses from inheriting a stale or
 // incorrect port (e.g., the dashboard's HTTP listen port) from the environment.
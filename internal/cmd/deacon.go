@@ -193,6 +193,10 @@ Beads can get stuck in 'hooked' status when agents die or abandon work.
 This command finds hooked beads older than the threshold (default: 1 hour),
 checks if the assignee agent is still alive, and unhooks them if not.
 
+Work hooked to a live agent that has stopped making progress is left alone
+here; a witness rule with "no_progress_for" and "do": "detach" handles that
+(see gt witness rules).
+
 Examples:
   gt deacon stale-hooks                 # Find and unhook stale beads
   gt deacon stale-hooks --dry-run       # Preview what would be unhooked
@@ -552,6 +556,11 @@ func startDeaconSession(t *tmux.Tmux, sessionName, agentOverride string) error {
 	if paneID, err := t.GetPaneID(sessionName); err == nil {
 		_ = t.SetEnvironment(sessionName, "GT_PANE_ID", paneID)
 	}
+	if err := session.ApplyRoleLayout(t, session.LayoutConfig{
+		SessionID: sessionName, WorkDir: deaconDir, Role: "deacon", TownRoot: townRoot,
+	}); err != nil {
+		style.PrintWarning("session layout for %s: %v", sessionName, err)
+	}
 
 	// Apply Deacon theme (non-fatal: theming failure doesn't affect operation)
 	// Note: ConfigureGasTownSession includes cycle bindings
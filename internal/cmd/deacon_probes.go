@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/deacon"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var (
+	deaconProbesRun  bool
+	deaconProbesJSON bool
+)
+
+var deaconProbesCmd = &cobra.Command{
+	Use:   "probes [probe...]",
+	Short: "Show or run the deacon's scheduled health probes",
+	Long: `Show the latest result of each deacon health probe, or run probes now.
+
+The daemon's deacon_probes patrol runs each probe on its own interval:
+
+  tmux              tmux server reachable, mayor/deacon sessions alive (1m)
+  bd_sync           beads databases pushed to their remotes in the last 24h (30m)
+  disk_space        free space on the town's filesystem (10m)
+  orphan_processes  agent processes detached from any session (10m)
+  mail_backlog      unread mail piling up for the mayor or deacon (5m)
+
+A probe going to warn or fail opens a bead labeled gt:probe; the bead is
+closed when the probe recovers. Results are kept in deacon/probe-state.json
+and summarized in gt status.
+
+Intervals can be overridden, or probes disabled, in settings/config.json:
+
+  "operational": {"deacon": {"probes": {"disk_space": {"interval": "1h"}, "mail_backlog": {"enabled": false}}}}
+
+Examples:
+  gt deacon probes                 # Latest results
+  gt deacon probes --run           # Run every probe now
+  gt deacon probes --run tmux      # Run one probe now`,
+	RunE: runDeaconProbes,
+}
+
+func init() {
+	deaconProbesCmd.Flags().BoolVar(&deaconProbesRun, "run", false, "Run the probes now instead of showing the last results")
+	deaconProbesCmd.Flags().BoolVar(&deaconProbesJSON, "json", false, "Output as JSON")
+	deaconCmd.AddCommand(deaconProbesCmd)
+}
+
+func runDeaconProbes(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	known := make(map[string]bool)
+	for _, p := range deacon.Probes() {
+		known[p.Name] = true
+	}
+	for _, name := range args {
+		if !known[name] {
+			return fmt.Errorf("unknown probe %q (see gt deacon probes --help)", name)
+		}
+	}
+
+	var records []*deacon.ProbeRecord
+	if deaconProbesRun {
+		records, err = deacon.RunProbes(townRoot, deacon.ProbeRunOptions{Force: true, Only: args})
+		if err != nil {
+			return fmt.Errorf("running probes: %w", err)
+		}
+	} else {
+		state, err := deacon.LoadProbeState(townRoot)
+		if err != nil {
+			return fmt.Errorf("loading probe state: %w", err)
+		}
+		for name, rec := range state.Probes {
+			if len(args) == 0 || slices.Contains(args, name) {
+				records = append(records, rec)
+			}
+		}
+		sortProbeRecords(records)
+	}
+
+	if deaconProbesJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(records)
+	}
+
+	if len(records) == 0 {
+		fmt.Printf("%s No probe results recorded yet (run gt deacon probes --run)\n", style.Dim.Render("○"))
+		return nil
+	}
+	for _, rec := range records {
+		fmt.Printf("%s %-16s %s %s\n", probeStatusIcon(rec.Status), rec.Name, rec.Message,
+			style.Dim.Render(fmt.Sprintf("(%s ago)", time.Since(rec.LastRun).Round(time.Second))))
+		for _, d := range rec.Details {
+			fmt.Printf("    %s\n", style.Dim.Render(d))
+		}
+		if rec.BeadID != "" {
+			fmt.Printf("    %s\n", style.Dim.Render("bead: "+rec.BeadID))
+		}
+	}
+	return nil
+}
+
+// loadProbeRecords returns the latest deacon probe results sorted by name,
+// or nil if none have run. Errors are ignored: probes are advisory in status.
+func loadProbeRecords(townRoot string) []*deacon.ProbeRecord {
+	state, err := deacon.LoadProbeState(townRoot)
+	if err != nil {
+		return nil
+	}
+	var records []*deacon.ProbeRecord
+	for _, rec := range state.Probes {
+		records = append(records, rec)
+	}
+	sortProbeRecords(records)
+	return records
+}
+
+func sortProbeRecords(records []*deacon.ProbeRecord) {
+	sort.Slice(records, func(i, j int) bool { return records[i].Name < records[j].Name })
+}
+
+// formatProbeSummary renders probe results for the gt status Probes line:
+// a count of healthy probes followed by each unhealthy one.
+func formatProbeSummary(records []*deacon.ProbeRecord) string {
+	ok := 0
+	var parts []string
+	for _, rec := range records {
+		if rec.Status == deacon.ProbeOK {
+			ok++
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("%s %s: %s", probeStatusIcon(rec.Status), rec.Name, rec.Message))
+	}
+	summary := fmt.Sprintf("%s %d ok", style.SuccessPrefix, ok)
+	if len(parts) == 0 {
+		return summary
+	}
+	return strings.Join(append([]string{summary}, parts...), "  ")
+}
+
+// probeStatusIcon renders a probe status as a status-line icon.
+func probeStatusIcon(status string) string {
+	switch status {
+	case deacon.ProbeOK:
+		return style.SuccessPrefix
+	case deacon.ProbeWarn:
+		return style.WarningPrefix
+	default:
+		return style.ErrorPrefix
+	}
+}
@@ -0,0 +1,399 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/disk"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/snapshot"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// diskReportMaxAge is how long preflight and postflight reuse a saved
+// report instead of rescanning the town.
+const diskReportMaxAge = 15 * time.Minute
+
+var (
+	diskReportJSON  bool
+	diskPruneDryRun bool
+	diskPruneJSON   bool
+)
+
+var diskCmd = &cobra.Command{
+	Use:     "disk",
+	GroupID: GroupDiag,
+	Short:   "Report town disk usage and enforce quotas",
+	Long: `Report disk usage by rig and kind, and enforce storage quotas.
+
+Usage is broken down into clones (each rig's bare repo and its mayor,
+refinery, polecat, crew, land, and dog worktrees), scrollback snapshots,
+mail archives, and everything else.
+
+Quotas are set in settings/config.json under "disk". Sizes take a unit
+(KB, MB, GB, TB; powers of 1024):
+  "disk": {"town": "200GB", "rigs": {"*": "40GB", "bigrepo": "120GB"},
+           "snapshots": "2GB", "mail_archive": "50MB", "warn_at": 0.9}
+
+gt done warns when usage is at warn_at of a quota or over it. Postflight
+(the end of gt done, and gt polecat gc) prunes scrollback snapshots and
+mail archives back under their quotas: the oldest snapshots go first, but
+each agent keeps its newest, and archives drop their oldest messages.
+Clones are never pruned automatically; use gt worktree gc.
+
+Examples:
+  gt disk report
+  gt disk report --json
+  gt disk prune --dry-run`,
+	RunE: requireSubcommand,
+}
+
+var diskReportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Show disk usage by rig and kind, with quota status",
+	Args:  cobra.NoArgs,
+	RunE:  runDiskReport,
+}
+
+var diskPruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Prune snapshots and mail archives back under their quotas",
+	Args:  cobra.NoArgs,
+	RunE:  runDiskPrune,
+}
+
+func init() {
+	diskReportCmd.Flags().BoolVar(&diskReportJSON, "json", false, "Output as JSON")
+	diskPruneCmd.Flags().BoolVar(&diskPruneDryRun, "dry-run", false, "Show what would be pruned without deleting")
+	diskPruneCmd.Flags().BoolVar(&diskPruneJSON, "json", false, "Output as JSON")
+
+	diskCmd.AddCommand(diskReportCmd)
+	diskCmd.AddCommand(diskPruneCmd)
+	rootCmd.AddCommand(diskCmd)
+}
+
+// loadDiskConfig returns the town's disk quotas, or nil when none are set.
+func loadDiskConfig(townRoot string) (*disk.Config, error) {
+	settings, err := config.LoadOrCreateTownSettings(config.TownSettingsPath(townRoot))
+	if err != nil {
+		return nil, fmt.Errorf("loading town settings: %w", err)
+	}
+	cfg := settings.Disk
+	if !cfg.Enabled() {
+		return nil, nil
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// scanDisk measures the town's storage and saves the report for preflight.
+func scanDisk(townRoot string) (*disk.Report, error) {
+	var rigNames []string
+	if rigsConfig, err := config.LoadRigsConfig(constants.MayorRigsPath(townRoot)); err == nil {
+		for name := range rigsConfig.Rigs {
+			rigNames = append(rigNames, name)
+		}
+	}
+	sort.Strings(rigNames)
+	report, err := disk.Scan(townRoot, rigNames, func(name string) string {
+		if id, err := session.ParseSessionName(name); err == nil {
+			return id.Rig
+		}
+		return ""
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scanning %s: %w", townRoot, err)
+	}
+	if err := disk.Save(townRoot, report); err != nil {
+		style.PrintWarning("could not save disk report: %v", err)
+	}
+	return report, nil
+}
+
+// rigDiskReport returns the saved town report if it is recent, else scans
+// only the rig: preflight and postflight must not walk every rig in the
+// town. The rig-only report is not saved, since it is not the town's.
+func rigDiskReport(townRoot, rig string) (*disk.Report, error) {
+	if r, err := disk.LoadCached(townRoot, diskReportMaxAge, time.Now()); err == nil && r != nil {
+		return r, nil
+	}
+	report, err := disk.ScanRig(townRoot, rig)
+	if err != nil {
+		return nil, fmt.Errorf("scanning rig %s: %w", rig, err)
+	}
+	return report, nil
+}
+
+// pruneDisk brings snapshots and the report's mail archives back under
+// their quotas.
+func pruneDisk(townRoot string, cfg *disk.Config, report *disk.Report, dryRun bool) (*disk.PruneResult, error) {
+	res := &disk.PruneResult{}
+
+	if limit := cfg.SnapshotQuota(); limit > 0 {
+		sessions, err := snapshot.Sessions(townRoot)
+		if err != nil {
+			return res, err
+		}
+		bySession := make(map[string][]disk.Snapshot, len(sessions))
+		for _, s := range sessions {
+			entries, err := snapshot.List(townRoot, s)
+			if err != nil {
+				return res, err
+			}
+			for _, e := range entries {
+				bySession[s] = append(bySession[s], disk.Snapshot{Session: s, At: e.At, Size: e.Size})
+			}
+		}
+		plan, freed := disk.PlanSnapshots(bySession, limit)
+		for _, s := range sessions {
+			n := plan[s]
+			if n == 0 {
+				continue
+			}
+			if !dryRun {
+				if n, err = snapshot.PruneOldest(townRoot, s, n); err != nil {
+					return res, fmt.Errorf("pruning snapshots for %s: %w", s, err)
+				}
+			}
+			res.Snapshots += n
+		}
+		res.SnapshotBytes = freed
+	}
+
+	if limit := cfg.MailArchiveQuota(); limit > 0 {
+		for _, a := range report.Archives {
+			if a.Size <= limit {
+				continue
+			}
+			res.Archives = append(res.Archives, a)
+			if dryRun {
+				continue
+			}
+			n, err := archiveMailbox(a.Path).TrimArchive(limit)
+			if err != nil {
+				return res, fmt.Errorf("trimming %s: %w", a.Path, err)
+			}
+			res.Messages += n
+		}
+	}
+	return res, nil
+}
+
+// archiveMailbox returns a mailbox whose archive is the file at path: a
+// legacy inbox.jsonl.archive, or a beads directory's archive.jsonl.
+func archiveMailbox(path string) *mail.Mailbox {
+	dir := filepath.Dir(path)
+	if filepath.Base(path) == "inbox.jsonl.archive" {
+		return mail.NewMailbox(dir)
+	}
+	return mail.NewMailboxWithBeadsDir("", filepath.Dir(dir), dir)
+}
+
+// diskPreflight warns about usage near or over quota. Non-fatal: quotas
+// never block gt done.
+func diskPreflight(townRoot, rig, beadID, branch, sender string) {
+	cfg, err := loadDiskConfig(townRoot)
+	if err != nil || cfg == nil {
+		return
+	}
+	report, err := rigDiskReport(townRoot, rig)
+	if err != nil {
+		return
+	}
+	for _, a := range disk.Evaluate(cfg, report) {
+		msg := diskAlertMessage(a)
+		style.PrintWarning("%s", msg)
+		_ = events.LogFeed(events.TypePreflightWarning, sender,
+			events.PreflightWarningPayload(beadID, branch, "disk_quota", msg))
+	}
+}
+
+// diskPostflight prunes snapshots and mail archives that are over quota.
+// Non-fatal: failures are printed as warnings.
+func diskPostflight(townRoot, rig string, dryRun bool) {
+	cfg, err := loadDiskConfig(townRoot)
+	if err != nil {
+		style.PrintWarning("disk quotas: %v", err)
+		return
+	}
+	if cfg == nil || (cfg.SnapshotQuota() == 0 && cfg.MailArchiveQuota() == 0) {
+		return
+	}
+	report, err := rigDiskReport(townRoot, rig)
+	if err != nil {
+		style.PrintWarning("disk quotas: %v", err)
+		return
+	}
+	res, err := pruneDisk(townRoot, cfg, report, dryRun)
+	if err != nil {
+		style.PrintWarning("disk quota pruning: %v", err)
+	}
+	if res.Empty() {
+		return
+	}
+	printDiskPrune(res, dryRun)
+	if !dryRun {
+		// Sizes changed; the next preflight should rescan.
+		_ = os.Remove(disk.CachePath(townRoot))
+	}
+}
+
+// diskAlertMessage describes a quota alert in one line.
+func diskAlertMessage(a disk.Alert) string {
+	state := "nearly full"
+	if a.Over {
+		state = "over quota"
+	}
+	msg := fmt.Sprintf("%s is %s: %s of %s", a.Label(), state, formatBytes(a.Used), formatBytes(a.Quota))
+	switch {
+	case a.Prunable():
+		msg += " (postflight prunes it)"
+	case a.Over:
+		msg += " (see gt disk report; gt worktree gc removes orphaned worktrees)"
+	}
+	return msg
+}
+
+func runDiskReport(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	cfg, err := loadDiskConfig(townRoot)
+	if err != nil {
+		return err
+	}
+	report, err := scanDisk(townRoot)
+	if err != nil {
+		return err
+	}
+	alerts := disk.Evaluate(cfg, report)
+
+	if diskReportJSON {
+		out := struct {
+			*disk.Report
+			Alerts []disk.Alert `json:"alerts,omitempty"`
+		}{report, alerts}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(out)
+	}
+
+	quotaCol := func(used, limit int64) string {
+		if limit <= 0 {
+			return "-"
+		}
+		s := fmt.Sprintf("%s (%d%%)", formatBytes(limit), used*100/limit)
+		if used > limit {
+			return style.Error.Render(s)
+		}
+		return s
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "SCOPE\tCLONES\tSNAPSHOTS\tMAIL\tOTHER\tTOTAL\tQUOTA")
+	row := func(name string, u disk.Usage, limit int64) {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", name,
+			formatBytes(u.Clones), formatBytes(u.Snapshots), formatBytes(u.MailArchives),
+			formatBytes(u.Other), formatBytes(u.Total()), quotaCol(u.Total(), limit))
+	}
+	for _, ru := range report.Rigs {
+		row(ru.Rig, ru.Usage, cfg.RigQuota(ru.Rig))
+	}
+	row("(town-level)", report.HQ, 0)
+	row("total", report.Town, cfg.TownQuota())
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if limit := cfg.SnapshotQuota(); limit > 0 {
+		fmt.Printf("\nScrollback snapshots: %s of %s\n", formatBytes(report.Town.Snapshots), quotaCol(report.Town.Snapshots, limit))
+	}
+	if len(report.Archives) > 0 {
+		fmt.Println("\nLargest mail archives:")
+		for i, a := range report.Archives {
+			if i == 5 {
+				break
+			}
+			rel, _ := filepath.Rel(townRoot, a.Path)
+			fmt.Printf("  %8s  %s\n", formatBytes(a.Size), rel)
+		}
+	}
+
+	if len(alerts) > 0 {
+		fmt.Println()
+		for _, a := range alerts {
+			fmt.Printf("%s %s\n", style.Warning.Render("⚠"), diskAlertMessage(a))
+		}
+	} else if cfg == nil {
+		fmt.Println(style.Dim.Render("\nNo disk quotas set (see gt disk --help)."))
+	}
+	return nil
+}
+
+func runDiskPrune(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	cfg, err := loadDiskConfig(townRoot)
+	if err != nil {
+		return err
+	}
+	if cfg == nil {
+		return fmt.Errorf("no disk quotas set in %s (see gt disk --help)", config.TownSettingsPath(townRoot))
+	}
+	report, err := scanDisk(townRoot)
+	if err != nil {
+		return err
+	}
+	res, err := pruneDisk(townRoot, cfg, report, diskPruneDryRun)
+	if err != nil {
+		return err
+	}
+	if !diskPruneDryRun && !res.Empty() {
+		_ = os.Remove(disk.CachePath(townRoot))
+	}
+
+	if diskPruneJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(res)
+	}
+	if res.Empty() {
+		fmt.Println("Snapshots and mail archives are within their quotas.")
+		return nil
+	}
+	printDiskPrune(res, diskPruneDryRun)
+	return nil
+}
+
+// printDiskPrune summarizes a prune.
+func printDiskPrune(res *disk.PruneResult, dryRun bool) {
+	verb := "Pruned"
+	if dryRun {
+		verb = "Would prune"
+	}
+	if res.Snapshots > 0 {
+		fmt.Printf("%s %d scrollback snapshot(s) (%s)\n", verb, res.Snapshots, formatBytes(res.SnapshotBytes))
+	}
+	for _, a := range res.Archives {
+		fmt.Printf("%s mail archive %s (%s)\n", verb, style.Dim.Render(a.Path), formatBytes(a.Size))
+	}
+	if !dryRun && res.Messages > 0 {
+		fmt.Printf("  %d archived message(s) dropped\n", res.Messages)
+	}
+}
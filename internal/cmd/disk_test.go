@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/disk"
+	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/snapshot"
+)
+
+func TestPruneDisk(t *testing.T) {
+	town := t.TempDir()
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	for _, s := range []string{"hq-mayor", "hq-deacon"} {
+		for i := 0; i < 4; i++ {
+			content := strings.Repeat(fmt.Sprintf("%s line %d\n", s, i), 200)
+			if _, err := snapshot.Save(town, s, content, base.Add(time.Duration(i)*time.Hour)); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	mbox := mail.NewMailbox(t.TempDir())
+	for i := 0; i < 10; i++ {
+		msg := &mail.Message{ID: fmt.Sprintf("msg-%02d", i), Subject: "old news", Timestamp: base}
+		if err := mbox.Append(msg); err != nil {
+			t.Fatal(err)
+		}
+		if err := mbox.Archive(msg.ID); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	report, err := disk.Scan(town, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	report.Archives = append(report.Archives, disk.Archive{Path: mbox.ArchivePath(), Size: 10_000})
+	cfg := &disk.Config{Snapshots: "1", MailArchive: "300"}
+
+	dry, err := pruneDisk(town, cfg, report, true)
+	if err != nil {
+		t.Fatalf("pruneDisk dry run: %v", err)
+	}
+	if dry.Snapshots != 6 || len(dry.Archives) != 1 || dry.Messages != 0 {
+		t.Errorf("dry run = %+v; want 6 snapshots, 1 archive, no messages", dry)
+	}
+	if entries, _ := snapshot.List(town, "hq-mayor"); len(entries) != 4 {
+		t.Errorf("dry run deleted snapshots: %d left", len(entries))
+	}
+
+	res, err := pruneDisk(town, cfg, report, false)
+	if err != nil {
+		t.Fatalf("pruneDisk: %v", err)
+	}
+	if res.Snapshots != 6 || res.Messages == 0 {
+		t.Errorf("prune = %+v; want 6 snapshots and some messages", res)
+	}
+	for _, s := range []string{"hq-mayor", "hq-deacon"} {
+		entries, _ := snapshot.List(town, s)
+		if len(entries) != 1 || !entries[0].At.Equal(base.Add(3*time.Hour)) {
+			t.Errorf("%s after prune = %+v; want only the newest", s, entries)
+		}
+	}
+	archived, _ := mbox.ListArchived()
+	if len(archived) == 0 || archived[len(archived)-1].ID != "msg-09" || len(archived)+res.Messages != 10 {
+		t.Errorf("archive after prune = %d messages (dropped %d); want the newest kept", len(archived), res.Messages)
+	}
+}
@@ -0,0 +1,405 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/budget"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/quiesce"
+	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/scheduler/assign"
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// dispatchSender is the From address on assignment mail.
+const dispatchSender = "gt-dispatch"
+
+var (
+	dispatchDryRun  bool
+	dispatchRig     string
+	dispatchJSON    bool
+	dispatchExplain bool
+)
+
+var dispatchCmd = &cobra.Command{
+	Use:     "dispatch",
+	GroupID: GroupWork,
+	Short:   "Assign ready beads to idle agents",
+	Long: `Hand unassigned ready beads to idle agents.
+
+An agent is idle when its session is running and nothing is hooked to it
+or in progress under its name. Each chosen bead is hooked to the agent,
+which then gets an assignment mail (delivered with the usual mail nudge).
+
+Beads are taken most urgent first. By default a bead goes to an idle crew
+member or polecat in its own rig; routing rules send labeled beads to
+specific agents anywhere in the town. Per-rig capacity caps how much work
+may be hooked in a rig at once, counting work already in flight.
+
+Configure in settings/config.json:
+
+  "dispatcher": {
+    "roles": ["crew", "polecat"],
+    "rig_capacity": {"gastown": 4, "*": 2},
+    "rules": [
+      {"label": "area:frontend", "agents": ["gastown/crew/*"]},
+      {"label": "size:small", "agents": ["*/polecats/*"], "prefer": true},
+      {"label": "security", "agents": ["gastown/crew/max"], "review": "witness"}
+    ],
+    "batch_size": 3
+  }
+
+The first rule whose label is on a bead applies. A rule's agents are the
+only ones that may take the bead, unless "prefer" is set: then they are
+tried first, and the bead falls back to its own rig. A rule with "review"
+labels the bead review:<role>; when the work is submitted with gt done, the
+reviewer (the rig's witness, say) is mailed, and the refinery holds the
+merge request until the reviewer runs gt review approve. Use --explain to see why each bead went where it did.
+
+Agents and rigs over their budget ("budget" in settings/config.json, see
+gt costs) get no new work until the budget period rolls over.
+
+Label a bead gt:no-dispatch to keep it out. The daemon's dispatcher patrol
+(opt-in, patrols.dispatcher in mayor/daemon.json) runs this on a schedule.
+
+Examples:
+  gt dispatch --dry-run          # Show what would be assigned
+  gt dispatch -n --explain       # ...and why, agent by agent
+  gt dispatch                    # Assign now
+  gt dispatch --rig gastown      # Only one rig's beads and agents`,
+	RunE: runDispatch,
+}
+
+func init() {
+	dispatchCmd.Flags().BoolVarP(&dispatchDryRun, "dry-run", "n", false, "Show the plan without assigning")
+	dispatchCmd.Flags().StringVar(&dispatchRig, "rig", "", "Only dispatch within this rig")
+	dispatchCmd.Flags().BoolVar(&dispatchJSON, "json", false, "Output the plan as JSON")
+	dispatchCmd.Flags().BoolVar(&dispatchExplain, "explain", false, "Show why each bead went to its agent or was skipped")
+	rootCmd.AddCommand(dispatchCmd)
+}
+
+// dispatchResult is the JSON form of a dispatch run.
+type dispatchResult struct {
+	Assigned []assign.Assignment `json:"assigned"`
+	Skipped  []assign.Skipped    `json:"skipped"`
+	Failed   map[string]string   `json:"failed,omitempty"`        // bead → error
+	Paused   map[string]string   `json:"budget_paused,omitempty"` // agent → exceeded budget
+}
+
+func runDispatch(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	if !dispatchDryRun {
+		if err := quiesce.CheckDispatch(townRoot); err != nil {
+			return err
+		}
+	}
+
+	var cfg *assign.DispatcherConfig
+	if settings, err := config.LoadOrCreateTownSettings(config.TownSettingsPath(townRoot)); err == nil {
+		cfg = settings.Dispatcher
+	}
+
+	rigsConfig, err := config.LoadRigsConfig(constants.MayorRigsPath(townRoot))
+	if err != nil {
+		rigsConfig = &config.RigsConfig{Rigs: make(map[string]config.RigEntry)}
+	}
+	rigs, err := rig.NewManager(townRoot, rigsConfig, git.NewGit(townRoot)).DiscoverRigs()
+	if err != nil {
+		return fmt.Errorf("discovering rigs: %w", err)
+	}
+
+	var pending []assign.Bead
+	load := make(map[string]int)
+	busy := make(map[string]bool)
+	beadsPath := make(map[string]string) // bead → beads dir it was found in
+
+	collect := func(rigName, path string) {
+		for _, b := range dispatchCandidates(path) {
+			pending = append(pending, assign.Bead{ID: b.ID, Title: b.Title, Rig: rigName, Priority: b.Priority, Labels: b.Labels})
+			beadsPath[b.ID] = path
+		}
+		n := markBusyAgents(path, busy)
+		if rigName != "" {
+			load[rigName] += n
+		}
+	}
+	for _, r := range rigs {
+		if dispatchRig != "" && r.Name != dispatchRig {
+			continue
+		}
+		collect(r.Name, r.BeadsPath())
+	}
+	if dispatchRig == "" {
+		collect("", beads.GetTownBeadsPath(townRoot))
+	}
+
+	t := tmux.NewTmux()
+	agents, err := idleAgents(t, busy)
+	if err != nil {
+		return err
+	}
+
+	agents, paused := filterBudgetAgents(enforceBudget(townRoot, dispatchDryRun), agents)
+
+	planned, skipped := assign.Plan(pending, agents, load, cfg)
+	result := dispatchResult{Assigned: planned, Skipped: skipped, Paused: paused}
+	if !dispatchDryRun {
+		result.Assigned = nil
+		result.Failed = make(map[string]string)
+		// One router for the batch, so mail notifications to different
+		// agents go out concurrently.
+		router := mail.NewRouter(townRoot)
+		for _, a := range planned {
+			if err := executeAssignment(townRoot, router, a, beadsPath[a.Bead.ID]); err != nil {
+				result.Failed[a.Bead.ID] = err.Error()
+				continue
+			}
+			result.Assigned = append(result.Assigned, a)
+		}
+		router.WaitPendingNotifications()
+	}
+
+	if !dispatchExplain {
+		for i := range result.Assigned {
+			result.Assigned[i].Trace = nil
+		}
+		for i := range result.Skipped {
+			result.Skipped[i].Trace = nil
+		}
+	}
+	if dispatchJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	}
+	printDispatchResult(result, len(pending), len(agents))
+	return nil
+}
+
+// dispatchCandidates returns the unassigned ready beads in a beads dir,
+// filtered the same way gt ready filters them. Errors yield no candidates.
+func dispatchCandidates(path string) []*beads.Issue {
+	issues, err := beads.New(path).Ready()
+	if err != nil {
+		return nil
+	}
+	issues = filterFormulaScaffolds(issues, getFormulaNames(path))
+	issues = filterWisps(issues, getWispIDs(path))
+	issues = filterIdentityBeads(issues)
+
+	var out []*beads.Issue
+	for _, is := range issues {
+		if is.Assignee == "" {
+			out = append(out, is)
+		}
+	}
+	return out
+}
+
+// markBusyAgents records every agent with hooked or in-progress work in a
+// beads dir and returns how many beads are hooked there.
+func markBusyAgents(path string, busy map[string]bool) int {
+	b := beads.New(path)
+	hooked := 0
+	for _, status := range []string{beads.StatusHooked, string(beads.StatusInProgress)} {
+		issues, err := b.List(beads.ListOptions{Status: status, Priority: -1})
+		if err != nil {
+			continue
+		}
+		for _, is := range issues {
+			if is.Assignee != "" {
+				busy[canonicalHookAgent(is.Assignee)] = true
+			}
+		}
+		if status == beads.StatusHooked {
+			hooked = len(issues)
+		}
+	}
+	return hooked
+}
+
+// idleAgents returns agents with a running session and no busy work.
+func idleAgents(t *tmux.Tmux, busy map[string]bool) ([]assign.Agent, error) {
+	sessions, err := t.ListSessions()
+	if err != nil {
+		return nil, fmt.Errorf("listing sessions: %w", err)
+	}
+	var agents []assign.Agent
+	for _, name := range sessions {
+		identity, err := session.ParseSessionName(name)
+		if err != nil {
+			continue
+		}
+		addr := identity.Address()
+		if addr == "" || busy[addr] {
+			continue
+		}
+		if dispatchRig != "" && identity.Rig != dispatchRig {
+			continue
+		}
+		agents = append(agents, assign.Agent{Address: addr, Rig: identity.Rig, Role: string(identity.Role), Session: name})
+	}
+	return agents, nil
+}
+
+// filterBudgetAgents drops agents whose own or rig budget is spent,
+// returning the rest and why each was dropped.
+func filterBudgetAgents(r *budget.Report, agents []assign.Agent) ([]assign.Agent, map[string]string) {
+	if r == nil {
+		return agents, nil
+	}
+	var kept []assign.Agent
+	paused := make(map[string]string)
+	for _, a := range agents {
+		if s, blocked := r.Blocks(a.Rig, a.Address); blocked {
+			paused[a.Address] = fmt.Sprintf("%s %s over budget ($%.2f of $%.2f)", s.Scope, s.Name, s.Spent, s.Cap)
+			continue
+		}
+		kept = append(kept, a)
+	}
+	return kept, paused
+}
+
+// executeAssignment hooks the bead to the agent, then sends assignment mail,
+// whose delivery notification is the agent's only nudge. Only the hook is
+// required; a mail failure is reported as a warning since the agent finds
+// hooked work on its own.
+func executeAssignment(townRoot string, router *mail.Router, a assign.Assignment, path string) error {
+	hookDir := beads.ResolveHookDir(townRoot, a.Bead.ID, path)
+	// Mark the review before hooking, so the work is never in flight
+	// without it.
+	if a.Review != "" {
+		if err := beads.New(hookDir).Update(a.Bead.ID, beads.UpdateOptions{
+			AddLabels: []string{beads.ReviewLabel(a.Review)},
+		}); err != nil {
+			return fmt.Errorf("marking for %s review: %w", a.Review, err)
+		}
+	}
+	if err := hookBeadWithRetry(a.Bead.ID, a.Agent.Address, hookDir); err != nil {
+		return fmt.Errorf("hooking: %w", err)
+	}
+	_ = events.LogFeed(events.TypeSling, dispatchSender, events.SlingPayload(a.Bead.ID, a.Agent.Address))
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "The dispatcher assigned %s to you: %s\n\n", a.Bead.ID, a.Bead.Title)
+	if a.Rule != "" {
+		fmt.Fprintf(&body, "Routed by rule for label %q.\n", a.Rule)
+	}
+	if a.Review != "" {
+		fmt.Fprintf(&body, "This work needs %s review: its merge waits until %s approves it.\n",
+			a.Review, reviewerAddress(a))
+	}
+	body.WriteString("It is on your hook now. Run `gt hook` to see it and get started.")
+
+	if err := router.Send(&mail.Message{
+		From:     dispatchSender,
+		To:       a.Agent.Address,
+		Subject:  fmt.Sprintf("ASSIGNED: %s %s", a.Bead.ID, a.Bead.Title),
+		Body:     body.String(),
+		Type:     mail.TypeTask,
+		Priority: mail.PriorityNormal,
+	}); err != nil {
+		style.PrintWarning("could not mail %s about %s: %v", a.Agent.Address, a.Bead.ID, err)
+	}
+	return nil
+}
+
+// reviewerAddress is the agent that reviews an assignment: the named role
+// in the assignee's rig (its witness, say), or a town agent such as the
+// mayor.
+func reviewerAddress(a assign.Assignment) string {
+	rig := a.Agent.Rig
+	if rig == "" {
+		rig = a.Bead.Rig
+	}
+	return reviewerFor(rig, a.Review)
+}
+
+// reviewerFor is the address of the agent in role that reviews work in rig.
+func reviewerFor(rig, role string) string {
+	if rig == "" || role == constants.RoleMayor || role == constants.RoleDeacon {
+		return role + "/"
+	}
+	return rig + "/" + role
+}
+
+func printDispatchResult(r dispatchResult, pending, idle int) {
+	verb := "Assigned"
+	if dispatchDryRun {
+		verb = "Would assign"
+	}
+	if len(r.Assigned) == 0 && len(r.Failed) == 0 {
+		fmt.Printf("%s Nothing to dispatch %s\n", style.Dim.Render("○"),
+			style.Dim.Render(fmt.Sprintf("(%d ready bead(s), %d idle agent(s))", pending, idle)))
+	}
+	for _, a := range r.Assigned {
+		rule := ""
+		if a.Rule != "" {
+			rule = style.Dim.Render(" [rule " + a.Rule + "]")
+		}
+		if a.Review != "" {
+			rule += style.Dim.Render(" [" + a.Review + " review]")
+		}
+		fmt.Printf("%s %s %s → %s%s\n", style.SuccessPrefix, verb, style.Bold.Render(a.Bead.ID), a.Agent.Address, rule)
+		if dispatchExplain {
+			printDispatchTrace(a.Why, a.Trace)
+		}
+	}
+	for bead, msg := range r.Failed {
+		fmt.Printf("%s %s: %s\n", style.ErrorPrefix, bead, msg)
+	}
+	if len(r.Paused) > 0 {
+		fmt.Printf("%s\n", style.Dim.Render(fmt.Sprintf("Budget paused %d agent(s):", len(r.Paused))))
+		for _, agent := range slices.Sorted(maps.Keys(r.Paused)) {
+			fmt.Printf("  %s %s\n", agent, style.Dim.Render("("+r.Paused[agent]+")"))
+		}
+	}
+	if dispatchExplain {
+		for _, s := range r.Skipped {
+			fmt.Printf("%s Skipped %s: %s\n", style.Dim.Render("○"), style.Bold.Render(s.Bead.ID), s.Reason)
+			printDispatchTrace("", s.Trace)
+		}
+		return
+	}
+	if len(r.Skipped) > 0 {
+		counts := make(map[string]int)
+		for _, s := range r.Skipped {
+			counts[s.Reason]++
+		}
+		var parts []string
+		for _, reason := range []string{assign.SkipNoAgent, assign.SkipCapacity, assign.SkipBatch, assign.SkipTownBead, assign.SkipOptedOut} {
+			if counts[reason] > 0 {
+				parts = append(parts, fmt.Sprintf("%d %s", counts[reason], reason))
+			}
+		}
+		fmt.Printf("%s\n", style.Dim.Render("Skipped: "+strings.Join(parts, ", ")))
+	}
+}
+
+// printDispatchTrace prints why a bead went where it did, then each agent
+// passed over on the way.
+func printDispatchTrace(why string, trace []string) {
+	if why != "" {
+		fmt.Printf("    %s\n", why)
+	}
+	for _, line := range trace {
+		fmt.Printf("    %s\n", style.Dim.Render("passed over "+line))
+	}
+}
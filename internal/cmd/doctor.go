@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"time"
@@ -16,7 +17,10 @@ var (
 	doctorRig             string
 	doctorRestartSessions bool
 	doctorNoStart         bool
+	doctorRemediate       bool
 	doctorSlow            string
+	doctorJSON            bool
+	doctorAllTowns        bool
 )
 
 var doctorCmd = &cobra.Command{
@@ -107,11 +111,21 @@ Patrol checks:
   - patrol-hooks-wired       Verify daemon triggers patrols
   - patrol-not-stuck         Detect stale wisps (>1h)
   - patrol-plugins-accessible Verify plugin directories
+  - stuck-workers            Detect stuck polecats (remediable with --fix --remediate)
 
 Use --fix to attempt automatic fixes for issues that support it.
 Use --no-start with --fix to suppress starting the daemon and agents.
+Use --remediate with --fix to recover stuck polecats: their panes are captured
+to .runtime/diagnostics/stuck/, a recovery nudge is sent, and polecats still
+stuck after operational.polecat.stuck_recovery_timeout are restarted with
+their hook bead re-attached.
 Use --rig to check a specific rig instead of the entire workspace.
-Use --slow to highlight slow checks (default threshold: 1s, e.g. --slow=500ms).`,
+Use --slow to highlight slow checks (default threshold: 1s, e.g. --slow=500ms).
+Use --json for machine-readable output.
+Use --all-towns to run doctor in every town registered with 'gt town add'
+(including peer towns registered by dashboard URL) and print one combined
+report. --fix applies to local towns only; a peer's dashboard runs doctor
+read-only, so fix peer towns on their own host.`,
 	RunE: runDoctor,
 }
 
@@ -121,13 +135,20 @@ func init() {
 	doctorCmd.Flags().StringVar(&doctorRig, "rig", "", "Check specific rig only")
 	doctorCmd.Flags().BoolVar(&doctorRestartSessions, "restart-sessions", false, "Restart patrol sessions when fixing stale settings (use with --fix)")
 	doctorCmd.Flags().BoolVar(&doctorNoStart, "no-start", false, "Suppress starting daemon/agents during --fix")
+	doctorCmd.Flags().BoolVar(&doctorRemediate, "remediate", false, "Nudge and restart stuck polecats (use with --fix)")
 	doctorCmd.Flags().StringVar(&doctorSlow, "slow", "", "Highlight slow checks (optional threshold, default 1s)")
+	doctorCmd.Flags().BoolVar(&doctorJSON, "json", false, "Output results as JSON")
+	doctorCmd.Flags().BoolVar(&doctorAllTowns, "all-towns", false, "Run in every registered town and aggregate the results")
 	// Allow --slow without a value (uses default 1s)
 	doctorCmd.Flags().Lookup("slow").NoOptDefVal = "1s"
 	rootCmd.AddCommand(doctorCmd)
 }
 
 func runDoctor(cmd *cobra.Command, args []string) error {
+	if doctorAllTowns {
+		return runDoctorAllTowns()
+	}
+
 	// Find town root
 	townRoot, err := workspace.FindFromCwdOrError()
 	if err != nil {
@@ -141,6 +162,7 @@ func runDoctor(cmd *cobra.Command, args []string) error {
 		Verbose:         doctorVerbose,
 		RestartSessions: doctorRestartSessions,
 		NoStart:         doctorNoStart,
+		Remediate:       doctorRemediate,
 	}
 
 	// Create doctor and register checks
@@ -188,6 +210,7 @@ func runDoctor(cmd *cobra.Command, args []string) error {
 	d.Register(doctor.NewMalformedSessionNameCheck())
 	d.Register(doctor.NewOrphanSessionCheck())
 	d.Register(doctor.NewZombieSessionCheck())
+	d.Register(doctor.NewAgentResourcesCheck())
 	d.Register(doctor.NewOrphanProcessCheck())
 	d.Register(doctor.NewWispGCCheck())
 	d.Register(doctor.NewCheckMisclassifiedWisps())
@@ -196,17 +219,21 @@ func runDoctor(cmd *cobra.Command, args []string) error {
 	d.Register(doctor.NewBeadsRedirectTargetCheck())
 	d.Register(doctor.NewBranchCheck())
 	d.Register(doctor.NewCloneDivergenceCheck())
+	d.Register(doctor.NewBeadsDivergenceCheck())
 	d.Register(doctor.NewDefaultBranchAllRigsCheck())
 	d.Register(doctor.NewIdentityCollisionCheck())
 	d.Register(doctor.NewLinkedPaneCheck())
 	d.Register(doctor.NewThemeCheck())
 	d.Register(doctor.NewCrashReportCheck())
 	d.Register(doctor.NewEnvVarsCheck())
+	d.Register(doctor.NewClockSkewCheck())
 
 	// Patrol system checks
 	d.Register(doctor.NewPatrolMoleculesExistCheck())
 	d.Register(doctor.NewPatrolHooksWiredCheck())
 	d.Register(doctor.NewPatrolNotStuckCheck())
+	d.Register(doctor.NewStuckWorkersCheck())
+	d.Register(doctor.NewMailLatencyCheck())
 	d.Register(doctor.NewPatrolPluginsAccessibleCheck())
 	d.Register(doctor.NewAgentBeadsCheck())
 	d.Register(doctor.NewStaleAgentBeadsCheck())
@@ -276,6 +303,29 @@ func runDoctor(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if doctorJSON {
+		// Checks that shell out may print; send that to stderr so stdout
+		// carries only the report.
+		stdout := os.Stdout
+		os.Stdout = os.Stderr
+		var report *doctor.Report
+		if doctorFix {
+			report = d.Fix(ctx)
+		} else {
+			report = d.Run(ctx)
+		}
+		os.Stdout = stdout
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report.ToJSON(townRoot)); err != nil {
+			return err
+		}
+		if report.HasErrors() {
+			return NewSilentExit(1)
+		}
+		return nil
+	}
+
 	// Run checks with streaming output
 	fmt.Println() // Initial blank line
 	var report *doctor.Report
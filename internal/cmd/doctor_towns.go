@@ -0,0 +1,212 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/doctor"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// doctorTownTimeout bounds a single town's doctor run, local or peer.
+const doctorTownTimeout = 3 * time.Minute
+
+// townDoctorResult is one town's outcome in an --all-towns run.
+type townDoctorResult struct {
+	Name     string
+	Location string
+	Report   *doctor.ReportJSON
+	Err      error // set when the town could not be checked at all
+
+	// FixSkipped is set for peer towns under --fix: their dashboard only
+	// runs doctor read-only.
+	FixSkipped bool
+}
+
+func runDoctorAllTowns() error {
+	reg, err := workspace.LoadTownRegistry()
+	if err != nil {
+		return err
+	}
+	if len(reg.Towns) == 0 {
+		return fmt.Errorf("no towns registered (use 'gt town add')")
+	}
+
+	names := reg.Names()
+	results := make([]townDoctorResult, len(names))
+	done := make(chan struct{}, len(names))
+	for i, name := range names {
+		go func(i int, name string) {
+			defer func() { done <- struct{}{} }()
+			loc := reg.Towns[name]
+			ctx, cancel := context.WithTimeout(context.Background(), doctorTownTimeout)
+			defer cancel()
+			var report *doctor.ReportJSON
+			var err error
+			if workspace.IsPeerTown(loc) {
+				report, err = fetchPeerDoctor(ctx, loc)
+			} else {
+				report, err = runLocalTownDoctor(ctx, loc)
+			}
+			results[i] = townDoctorResult{Name: name, Location: loc, Report: report, Err: err,
+				FixSkipped: doctorFix && workspace.IsPeerTown(loc)}
+		}(i, name)
+	}
+	for range names {
+		<-done
+	}
+
+	if doctorJSON {
+		return printTownDoctorJSON(results)
+	}
+	printTownDoctorResults(results)
+	if townDoctorFailed(results) {
+		return NewSilentExit(1)
+	}
+	return nil
+}
+
+// runLocalTownDoctor runs `gt doctor --json` inside a local town. doctor
+// exits non-zero when checks fail, so the output is parsed regardless.
+func runLocalTownDoctor(ctx context.Context, townRoot string) (*doctor.ReportJSON, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return nil, fmt.Errorf("finding gt executable: %w", err)
+	}
+	args := []string{"doctor", "--json"}
+	if doctorFix {
+		args = append(args, "--fix")
+	}
+	c := exec.CommandContext(ctx, exe, args...)
+	c.Dir = townRoot
+	c.Env = append(os.Environ(), "GT_TOWN_ROOT="+townRoot)
+	out, runErr := c.Output()
+	report, err := doctor.ParseReportJSON(out)
+	if err != nil && runErr != nil {
+		return nil, fmt.Errorf("gt doctor: %w", runErr)
+	}
+	return report, err
+}
+
+// fetchPeerDoctor asks a peer town's dashboard to run doctor.
+func fetchPeerDoctor(ctx context.Context, baseURL string) (*doctor.ReportJSON, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimRight(baseURL, "/")+"/api/doctor", nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	return doctor.ParseReportJSON(body)
+}
+
+func townDoctorFailed(results []townDoctorResult) bool {
+	for _, r := range results {
+		if r.Err != nil || r.Report.Summary.Errors > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func printTownDoctorJSON(results []townDoctorResult) error {
+	type townJSON struct {
+		Name       string             `json:"name"`
+		Location   string             `json:"location"`
+		Error      string             `json:"error,omitempty"`
+		FixSkipped bool               `json:"fix_skipped,omitempty"`
+		Report     *doctor.ReportJSON `json:"report,omitempty"`
+	}
+	out := make([]townJSON, 0, len(results))
+	for _, r := range results {
+		t := townJSON{Name: r.Name, Location: r.Location, Report: r.Report, FixSkipped: r.FixSkipped}
+		if r.Err != nil {
+			t.Error = r.Err.Error()
+		}
+		out = append(out, t)
+	}
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(out); err != nil {
+		return err
+	}
+	if townDoctorFailed(results) {
+		return NewSilentExit(1)
+	}
+	return nil
+}
+
+func printTownDoctorResults(results []townDoctorResult) {
+	var total doctor.ReportSummaryJSON
+	unreachable := 0
+	for _, r := range results {
+		if r.Err != nil {
+			unreachable++
+			fmt.Printf("%s %-16s %s\n", style.ErrorPrefix, r.Name, style.Error.Render("unreachable: "+r.Err.Error()))
+			continue
+		}
+		s := r.Report.Summary
+		total.Total += s.Total
+		total.OK += s.OK
+		total.Warnings += s.Warnings
+		total.Errors += s.Errors
+		total.Fixed += s.Fixed
+
+		prefix := style.SuccessPrefix
+		switch {
+		case s.Errors > 0:
+			prefix = style.ErrorPrefix
+		case s.Warnings > 0:
+			prefix = style.WarningPrefix
+		}
+		fmt.Printf("%s %-16s %d passed, %d warnings, %d errors%s\n",
+			prefix, r.Name, s.OK, s.Warnings, s.Errors, fixedSuffix(s.Fixed))
+		if r.FixSkipped {
+			fmt.Println(style.Dim.Render("    --fix not applied: peer towns are checked read-only (run 'gt doctor --fix' on their host)"))
+		}
+		for _, c := range r.Report.Checks {
+			if c.Status == "ok" || c.Fixed {
+				continue
+			}
+			line := fmt.Sprintf("    %s: %s", c.Name, c.Message)
+			if c.Status == "error" {
+				fmt.Println(style.Error.Render(line))
+			} else {
+				fmt.Println(style.Warning.Render(line))
+			}
+		}
+	}
+
+	fmt.Println()
+	fmt.Printf("%d towns: %d passed, %d warnings, %d errors%s",
+		len(results), total.OK, total.Warnings, total.Errors, fixedSuffix(total.Fixed))
+	if unreachable > 0 {
+		fmt.Printf(", %d unreachable", unreachable)
+	}
+	fmt.Println()
+}
+
+func fixedSuffix(n int) string {
+	if n == 0 {
+		return ""
+	}
+	return fmt.Sprintf(", %d fixed", n)
+}
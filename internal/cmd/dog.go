@@ -15,6 +15,7 @@ import (
 	"github.com/steveyegge/gastown/internal/dog"
 	"github.com/steveyegge/gastown/internal/mail"
 	"github.com/steveyegge/gastown/internal/plugin"
+	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/tmux"
 	"github.com/steveyegge/gastown/internal/util"
@@ -615,7 +616,7 @@ func runDogClear(cmd *cobra.Command, args []string) error {
 
 	// Check for live tmux session
 	if !dogForce {
-		sessionName := fmt.Sprintf("hq-dog-%s", name)
+		sessionName := session.DogSessionName(name)
 		tm := tmux.NewTmux()
 		if has, _ := tm.HasSession(sessionName); has {
 			return fmt.Errorf("dog %s has an active session (%s)\nUse --force to clear anyway", name, sessionName)
@@ -689,7 +690,7 @@ func runDogDone(cmd *cobra.Command, args []string) error {
 	//
 	// We disable remain-on-exit first — otherwise kill-session leaves a
 	// dead pane that the deacon's health-check reports as an orphan.
-	sessionID := fmt.Sprintf("hq-dog-%s", name)
+	sessionID := session.DogSessionName(name)
 	t := tmux.NewTmux()
 	_ = t.SetRemainOnExit(sessionID, false)
 	fmt.Printf("  Session %s will terminate in 3s\n", sessionID)
@@ -698,7 +699,7 @@ func runDogDone(cmd *cobra.Command, args []string) error {
 	util.SetProcessGroup(killCmd)
 	if err := killCmd.Start(); err != nil {
 		// Non-fatal: session may not be tmux-based (e.g., manual testing).
-		fmt.Fprintf(os.Stderr, "warning: failed to schedule session termination: %v\n", err)
+		logger.Warn("failed to schedule session termination", "err", err)
 	}
 
 	return nil
@@ -766,7 +767,7 @@ func showDogStatus(mgr *dog.Manager, name string) error {
 	}
 
 	// Check for tmux session
-	sessionName := fmt.Sprintf("hq-dog-%s", name)
+	sessionName := session.DogSessionName(name)
 	tm := tmux.NewTmux()
 	if has, _ := tm.HasSession(sessionName); has {
 		fmt.Printf("\nSession: %s (running)\n", sessionName)
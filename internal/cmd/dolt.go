@@ -484,7 +484,7 @@ func runDoltRestart(cmd *cobra.Command, args []string) error {
 	if running {
 		fmt.Printf("Stopping Dolt server (PID %d)...\n", pid)
 		if err := doltserver.Stop(townRoot); err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: stop failed: %v (continuing with imposter kill)\n", err)
+			logger.Warn("stop failed, continuing with imposter kill", "err", err)
 		} else {
 			fmt.Printf("%s Stopped\n", style.Bold.Render("✓"))
 		}
@@ -493,7 +493,7 @@ func runDoltRestart(cmd *cobra.Command, args []string) error {
 	// Step 2: Kill any imposters on the port
 	fmt.Println("Checking for imposter servers...")
 	if err := doltserver.KillImposters(townRoot); err != nil {
-		fmt.Fprintf(os.Stderr, "Warning: imposter kill failed: %v\n", err)
+		logger.Warn("imposter kill failed", "err", err)
 	}
 
 	// Brief pause to let port be released
@@ -1414,11 +1414,11 @@ func runDoltSync(cmd *cobra.Command, args []string) error {
 	})
 	if doltSyncGC {
 		if !wasRunning {
-			fmt.Fprintf(os.Stderr, "Warning: --gc requires a running Dolt server, skipping purge\n")
+			logger.Warn("--gc requires a running Dolt server, skipping purge")
 		} else {
 			databases, listErr := doltserver.ListDatabases(townRoot)
 			if listErr != nil {
-				fmt.Fprintf(os.Stderr, "Warning: --gc: could not list databases: %v\n", listErr)
+				logger.Warn("--gc: could not list databases", "err", listErr)
 			} else {
 				for _, db := range databases {
 					if doltSyncDB != "" && db != doltSyncDB {
@@ -473,10 +473,17 @@ func runDone(cmd *cobra.Command, args []string) (retErr error) {
 					"Fix: git fetch origin && git rebase origin/%s",
 					contam.Behind, originDefault, blockThreshold, defaultBranch)
 			} else if contam.Behind >= warnThreshold {
-				style.PrintWarning("branch is %d commits behind %s — consider rebasing to avoid PR contamination", contam.Behind, originDefault)
+				msg := fmt.Sprintf("branch is %d commits behind %s — consider rebasing to avoid PR contamination", contam.Behind, originDefault)
+				style.PrintWarning("%s", msg)
+				_ = events.LogFeed(events.TypePreflightWarning, sender,
+					events.PreflightWarningPayload(issueID, branch, "branch_contamination", msg))
 			}
 		}
 
+		// Disk quota preflight: warn (never block) when the rig's storage is
+		// near or over a quota in settings/config.json "disk".
+		diskPreflight(townRoot, rigName, issueID, branch, sender)
+
 		// Determine merge strategy from convoy (gt-myofa.3)
 		// Convoys can override the default MR-based workflow:
 		//   direct: push commits straight to target branch, bypass refinery
@@ -492,6 +499,7 @@ func runDone(cmd *cobra.Command, args []string) (retErr error) {
 		if convoyInfo == nil {
 			convoyInfo = getConvoyInfoForIssue(issueID)
 		}
+		enforceConvoyMergeGate(townRoot, convoyInfo)
 
 		// Handle "local" strategy: skip push and MR entirely
 		if convoyInfo != nil && convoyInfo.MergeStrategy == "local" {
@@ -673,13 +681,16 @@ func runDone(cmd *cobra.Command, args []string) (retErr error) {
 				if dispatcher := attachmentFields.DispatchedBy; dispatcher != "" {
 					townRouter := mail.NewRouter(townRoot)
 					defer townRouter.WaitPendingNotifications()
+					from, fromErr := resolveMailSender()
 					reviewMsg := &mail.Message{
 						To:      dispatcher,
-						From:    detectSender(),
+						From:    from,
 						Subject: fmt.Sprintf("READY_FOR_REVIEW: %s", issueID),
 						Body:    fmt.Sprintf("Branch: %s\nIssue: %s\nReady for review.", branch, issueID),
 					}
-					if err := townRouter.Send(reviewMsg); err != nil {
+					if fromErr != nil {
+						style.PrintWarning("could not notify dispatcher: %v", fromErr)
+					} else if err := townRouter.Send(reviewMsg); err != nil {
 						style.PrintWarning("could not notify dispatcher: %v", err)
 					} else {
 						fmt.Printf("%s Dispatcher notified: READY_FOR_REVIEW\n", style.Bold.Render("✓"))
@@ -700,6 +711,7 @@ func runDone(cmd *cobra.Command, args []string) (retErr error) {
 		if convoyInfo == nil {
 			convoyInfo = getConvoyInfoForIssue(issueID)
 		}
+		enforceConvoyMergeGate(townRoot, convoyInfo)
 		if convoyInfo != nil && convoyInfo.MergeStrategy == "direct" {
 			fmt.Printf("%s Late-detected direct merge strategy: pushing to %s\n", style.Bold.Render("→"), defaultBranch)
 			fmt.Printf("  Convoy: %s\n", convoyInfo.ID)
@@ -872,6 +884,10 @@ func runDone(cmd *cobra.Command, args []string) (retErr error) {
 			fmt.Printf("%s Work submitted to merge queue (verified)\n", style.Bold.Render("✓"))
 			fmt.Printf("  MR ID: %s\n", style.Bold.Render(mrID))
 
+			// Work a routing rule marked for review goes to its reviewers
+			// now that there is something to review.
+			requestPendingReviews(townRoot, rigName, bd, issueID, mrID, branch, sender)
+
 			// NOTE: Refinery nudge is deferred to AFTER the Dolt branch merge
 			// (see post-merge nudge below). Nudging here would race with the
 			// merge — refinery wakes up and queries main before the polecat's
@@ -997,6 +1013,9 @@ notifyWitness:
 		fmt.Printf("%s Polecat transitioned to IDLE — ready for new work\n", style.Bold.Render("✓"))
 	}
 
+	// Disk quota postflight: prune snapshots and mail archives over quota.
+	diskPostflight(townRoot, rigName, false)
+
 	fmt.Println()
 	if !isPolecat {
 		fmt.Printf("%s Session exiting\n", style.Bold.Render("→"))
@@ -1021,7 +1040,7 @@ func setDoneIntentLabel(bd *beads.Beads, agentBeadID, exitType string) {
 		AddLabels: []string{label},
 	}); err != nil {
 		// Non-fatal: warn but continue
-		fmt.Fprintf(os.Stderr, "Warning: couldn't set done-intent label on %s: %v\n", agentBeadID, err)
+		logger.Warn("couldn't set done-intent label", "agent", agentBeadID, "err", err)
 	}
 }
 
@@ -1050,7 +1069,7 @@ func clearDoneIntentLabel(bd *beads.Beads, agentBeadID string) {
 	if err := bd.Update(agentBeadID, beads.UpdateOptions{
 		RemoveLabels: toRemove,
 	}); err != nil {
-		fmt.Fprintf(os.Stderr, "Warning: couldn't clear done-intent label on %s: %v\n", agentBeadID, err)
+		logger.Warn("couldn't clear done-intent label", "agent", agentBeadID, "err", err)
 	}
 }
 
@@ -1076,7 +1095,7 @@ func writeDoneCheckpoint(bd *beads.Beads, agentBeadID string, cp DoneCheckpoint,
 	if err := bd.Update(agentBeadID, beads.UpdateOptions{
 		AddLabels: []string{label},
 	}); err != nil {
-		fmt.Fprintf(os.Stderr, "Warning: couldn't write checkpoint %s on %s: %v\n", cp, agentBeadID, err)
+		logger.Warn("couldn't write done checkpoint", "checkpoint", cp, "agent", agentBeadID, "err", err)
 	}
 }
 
@@ -1127,7 +1146,7 @@ func clearDoneCheckpoints(bd *beads.Beads, agentBeadID string) {
 	if err := bd.Update(agentBeadID, beads.UpdateOptions{
 		RemoveLabels: toRemove,
 	}); err != nil {
-		fmt.Fprintf(os.Stderr, "Warning: couldn't clear done checkpoints on %s: %v\n", agentBeadID, err)
+		logger.Warn("couldn't clear done checkpoints", "agent", agentBeadID, "err", err)
 	}
 }
 
@@ -1229,7 +1248,7 @@ func updateAgentStateOnDone(cwd, townRoot, exitType, issueID string) {
 				// from the molecule stay stuck forever after gt done completes.
 				// Order: step children -> wisp root -> base bead.
 				if n := closeDescendants(bd, attachment.AttachedMolecule); n > 0 {
-					fmt.Fprintf(os.Stderr, "Closed %d molecule step(s) for %s\n", n, attachment.AttachedMolecule)
+					logger.Info("closed molecule steps", "molecule", attachment.AttachedMolecule, "count", n)
 				}
 
 				// Close the wisp root with --force and audit reason.
@@ -1238,7 +1257,7 @@ func updateAgentStateOnDone(cwd, townRoot, exitType, issueID string) {
 				// Same pattern as gt mol burn/squash (#1879).
 				if closeErr := bd.ForceCloseWithReason("done", attachment.AttachedMolecule); closeErr != nil {
 					if !errors.Is(closeErr, beads.ErrNotFound) {
-						fmt.Fprintf(os.Stderr, "Warning: couldn't close attached molecule %s: %v\n", attachment.AttachedMolecule, closeErr)
+						logger.Warn("couldn't close attached molecule", "molecule", attachment.AttachedMolecule, "err", closeErr)
 						// Don't try to close hookedBeadID - it may still be blocked
 						// The Witness will clean up orphaned state
 						return
@@ -1253,7 +1272,7 @@ func updateAgentStateOnDone(cwd, townRoot, exitType, issueID string) {
 				fmt.Fprintf(os.Stderr, "  The bead will remain open for witness/mayor review.\n")
 			} else if err := bd.Close(hookedBeadID); err != nil {
 				// Non-fatal: warn but continue
-				fmt.Fprintf(os.Stderr, "Warning: couldn't close hooked bead %s: %v\n", hookedBeadID, err)
+				logger.Warn("couldn't close hooked bead", "bead", hookedBeadID, "err", err)
 			}
 		}
 	}
@@ -1271,7 +1290,7 @@ func updateAgentStateOnDone(cwd, townRoot, exitType, issueID string) {
 		doneState = "stuck"
 	}
 	if _, err := bd.Run("agent", "state", agentBeadID, doneState); err != nil {
-		fmt.Fprintf(os.Stderr, "Warning: couldn't set agent %s to %s: %v\n", agentBeadID, doneState, err)
+		logger.Warn("couldn't set agent state", "agent", agentBeadID, "state", doneState, "err", err)
 	}
 
 	// ZFC #10: Self-report cleanup status
@@ -1280,7 +1299,7 @@ func updateAgentStateOnDone(cwd, townRoot, exitType, issueID string) {
 		cleanupStatus := parseCleanupStatus(doneCleanupStatus)
 		if cleanupStatus != polecat.CleanupUnknown {
 			if err := bd.UpdateAgentCleanupStatus(agentBeadID, string(cleanupStatus)); err != nil {
-				fmt.Fprintf(os.Stderr, "Warning: couldn't update agent %s cleanup status: %v\n", agentBeadID, err)
+				logger.Warn("couldn't update agent cleanup status", "agent", agentBeadID, "err", err)
 				return
 			}
 		}
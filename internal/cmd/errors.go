@@ -3,6 +3,10 @@ package cmd
 import (
 	"errors"
 	"fmt"
+	"io"
+
+	"github.com/steveyegge/gastown/internal/errkind"
+	"github.com/steveyegge/gastown/internal/style"
 )
 
 // SilentExitError signals that the command should exit with a specific code
@@ -34,3 +38,29 @@ func IsSilentExit(err error) (int, bool) {
 	}
 	return 0, false
 }
+
+// kindHints are the remediation hints for each error category, shown when
+// the error carries no hint of its own.
+var kindHints = map[errkind.Kind]string{
+	errkind.Transport:    "check that the town's services are up with 'gt status' (start them with 'gt up')",
+	errkind.Protocol:     "an agent or tool answered unexpectedly; check the session with 'gt peek' and retry",
+	errkind.Config:       "run 'gt doctor' to check the town's configuration",
+	errkind.ExternalTool: "rerun with --verbose to see the commands gt ran; 'gt doctor' checks that the tools are installed",
+}
+
+// errorHint returns the remediation hint for err: its own if it has one,
+// otherwise its category's. Returns "" for uncategorized errors.
+func errorHint(err error) string {
+	if hint := errkind.HintOf(err); hint != "" {
+		return hint
+	}
+	return kindHints[errkind.Of(err)]
+}
+
+// printErrorHint prints the remediation hint for err, if any, under the
+// error message cobra printed.
+func printErrorHint(w io.Writer, err error) {
+	if hint := errorHint(err); hint != "" {
+		fmt.Fprintf(w, "%s %s\n", style.Dim.Render("Hint:"), hint)
+	}
+}
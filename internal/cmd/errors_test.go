@@ -1,9 +1,12 @@
 package cmd
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"testing"
+
+	"github.com/steveyegge/gastown/internal/errkind"
 )
 
 func TestSilentExitError_Error(t *testing.T) {
@@ -90,3 +93,28 @@ func TestSilentExitError_Is(t *testing.T) {
 		t.Errorf("errors.As extracted code = %d, want 1", target.Code)
 	}
 }
+
+func TestErrorHint(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"own hint", fmt.Errorf("nudging: %w", errkind.New(errkind.Protocol, "stalled", "try --no-restore")), "try --no-restore"},
+		{"category hint", errkind.Wrap(errkind.Config, errors.New("bad rig"), ""), kindHints[errkind.Config]},
+		{"uncategorized", errors.New("boom"), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errorHint(tt.err); got != tt.want {
+				t.Errorf("errorHint() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	var buf bytes.Buffer
+	printErrorHint(&buf, errors.New("boom"))
+	if buf.Len() != 0 {
+		t.Errorf("printErrorHint printed %q for an uncategorized error", buf.String())
+	}
+}
@@ -17,6 +17,9 @@ var (
 	escalateDryRun      bool
 	escalateCloseReason string
 	escalateStdin       bool // Read reason from stdin
+	escalateChain       string
+	escalateSLAJSON     bool
+	escalateSLAQuiet    bool
 )
 
 var escalateCmd = &cobra.Command{
@@ -48,6 +51,18 @@ CONFIGURATION:
   - contacts: Human email/SMS for external notifications
   - stale_threshold: When unacked escalations are re-escalated (default: 4h)
   - max_reescalations: How many times to bump severity (default: 2)
+  - chains: Named escalation chains with response-time SLAs (see below)
+
+ESCALATION CHAINS:
+  A chain notifies one level at a time instead of everyone at once. Each
+  level has an SLA; if nobody acks before it lapses, the next level is
+  mailed (checked by the daemon's escalation_sla patrol, or gt escalate sla).
+  Use a chain from settings/escalation.json by name, or give one inline:
+
+    gt escalate "Tests flaky" --chain "gastown/crew/max=30m,gastown/witness=1h,mayor/"
+
+  A chain named "default" applies when --chain is not given. With a chain,
+  only the first level gets mail; the route's other actions still run.
 
 Examples:
   gt escalate "Build failing" --severity critical --reason "CI blocked"
@@ -56,7 +71,8 @@ Examples:
   gt escalate list                          # Show open escalations
   gt escalate ack hq-abc123                 # Acknowledge
   gt escalate close hq-abc123 --reason "Fixed in commit abc"
-  gt escalate stale                         # Re-escalate stale escalations`,
+  gt escalate stale                         # Re-escalate stale escalations
+  gt escalate sla                           # Advance chains whose SLA lapsed`,
 }
 
 var escalateListCmd = &cobra.Command{
@@ -124,6 +140,27 @@ Examples:
 	RunE: runEscalateStale,
 }
 
+var escalateSLACmd = &cobra.Command{
+	Use:   "sla",
+	Short: "Notify the next chain level when an SLA lapses",
+	Long: `Advance unacknowledged escalations along their escalation chain.
+
+For each open escalation created with a chain, if the level notified last
+has not acknowledged within its SLA, the next level is mailed and the
+escalation's chain level is updated. One level is advanced per run, so
+each level gets its full SLA. Every advance is recorded in the audit log
+(gt audit --mutations).
+
+The daemon runs this every 2 minutes (escalation_sla patrol).
+
+Examples:
+  gt escalate sla              # Advance lapsed escalations
+  gt escalate sla --dry-run    # Show what would be done
+  gt escalate sla --json       # JSON output of results`,
+	Args: cobra.NoArgs,
+	RunE: runEscalateSLA,
+}
+
 var escalateShowCmd = &cobra.Command{
 	Use:   "show <escalation-id>",
 	Short: "Show details of an escalation",
@@ -145,6 +182,7 @@ func init() {
 	escalateCmd.Flags().BoolVar(&escalateJSON, "json", false, "Output as JSON")
 	escalateCmd.Flags().BoolVarP(&escalateDryRun, "dry-run", "n", false, "Show what would be done without executing")
 	escalateCmd.Flags().BoolVar(&escalateStdin, "stdin", false, "Read reason from stdin (avoids shell quoting issues)")
+	escalateCmd.Flags().StringVar(&escalateChain, "chain", "", "Escalation chain: a name from settings/escalation.json, or \"target=sla,...,target\"")
 
 	// List subcommand flags
 	escalateListCmd.Flags().BoolVar(&escalateListJSON, "json", false, "Output as JSON")
@@ -158,6 +196,11 @@ func init() {
 	escalateStaleCmd.Flags().BoolVar(&escalateStaleJSON, "json", false, "Output as JSON")
 	escalateStaleCmd.Flags().BoolVarP(&escalateDryRun, "dry-run", "n", false, "Show what would be re-escalated without acting")
 
+	// SLA subcommand flags
+	escalateSLACmd.Flags().BoolVar(&escalateSLAJSON, "json", false, "Output as JSON")
+	escalateSLACmd.Flags().BoolVarP(&escalateDryRun, "dry-run", "n", false, "Show what would be advanced without acting")
+	escalateSLACmd.Flags().BoolVarP(&escalateSLAQuiet, "quiet", "q", false, "Print nothing when no SLA has lapsed")
+
 	// Show subcommand flags
 	escalateShowCmd.Flags().BoolVar(&escalateJSON, "json", false, "Output as JSON")
 
@@ -166,6 +209,7 @@ func init() {
 	escalateCmd.AddCommand(escalateAckCmd)
 	escalateCmd.AddCommand(escalateCloseCmd)
 	escalateCmd.AddCommand(escalateStaleCmd)
+	escalateCmd.AddCommand(escalateSLACmd)
 	escalateCmd.AddCommand(escalateShowCmd)
 
 	rootCmd.AddCommand(escalateCmd)
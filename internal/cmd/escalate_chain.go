@@ -0,0 +1,248 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/auditlog"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// chainAdvance is one escalation moved (or due to move) to its next chain level.
+type chainAdvance struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Severity string `json:"severity"`
+	Level    int    `json:"level"` // 1-based level now notified
+	Levels   int    `json:"levels"`
+	From     string `json:"from"` // Target whose SLA lapsed
+	To       string `json:"to"`   // Target notified
+	SLA      string `json:"sla"`  // The lapsed SLA
+	Error    string `json:"error,omitempty"`
+}
+
+// resolveEscalationChain returns the chain for a new escalation: the named
+// chain from the config, an inline "target=sla,...,target" spec, or the
+// config's default chain when spec is empty. Returns nil for no chain.
+func resolveEscalationChain(cfg *config.EscalationConfig, spec string) ([]config.EscalationChainLevel, error) {
+	if spec == "" {
+		return cfg.GetChain(config.DefaultEscalationChain), nil
+	}
+	if chain := cfg.GetChain(spec); chain != nil {
+		return chain, nil
+	}
+	if !strings.ContainsAny(spec, "=,/") {
+		return nil, fmt.Errorf("unknown escalation chain %q (not in settings/escalation.json)", spec)
+	}
+	chain, err := config.ParseEscalationChain(spec)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --chain: %w", err)
+	}
+	return chain, nil
+}
+
+// nextChainLevel reports whether an escalation's current chain level has let
+// its SLA lapse at now, returning the parsed chain and the level to notify
+// next. Acknowledged escalations, escalations without a chain, and ones
+// already at the last level never advance.
+func nextChainLevel(issue *beads.Issue, fields *beads.EscalationFields, now time.Time) ([]config.EscalationChainLevel, int, bool) {
+	if fields.Chain == "" || fields.AckedBy != "" || beads.HasLabel(issue, "acked") {
+		return nil, 0, false
+	}
+	chain, err := config.ParseEscalationChain(fields.Chain)
+	if err != nil || fields.ChainLevel < 0 || fields.ChainLevel >= len(chain)-1 {
+		return nil, 0, false
+	}
+	sla, err := time.ParseDuration(chain[fields.ChainLevel].SLA)
+	if err != nil {
+		return nil, 0, false
+	}
+
+	notified := fields.LevelNotifiedAt
+	if notified == "" {
+		notified = fields.EscalatedAt
+	}
+	if notified == "" {
+		notified = issue.CreatedAt
+	}
+	notifiedAt, err := time.Parse(time.RFC3339, notified)
+	if err != nil {
+		return nil, 0, false
+	}
+	if now.Sub(notifiedAt) < sla {
+		return nil, 0, false
+	}
+	return chain, fields.ChainLevel + 1, true
+}
+
+func runEscalateSLA(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	bd := beads.New(beads.ResolveBeadsDir(townRoot))
+	escalations, err := bd.ListEscalations()
+	if err != nil {
+		return fmt.Errorf("listing escalations: %w", err)
+	}
+
+	advancedBy, err := resolveMailSender()
+	if err != nil {
+		return err
+	}
+	if advancedBy == "" {
+		advancedBy = "system"
+	}
+
+	now := time.Now()
+	var advances []chainAdvance
+	var router *mail.Router
+	for _, issue := range escalations {
+		fields := beads.ParseEscalationFields(issue.Description)
+		chain, next, due := nextChainLevel(issue, fields, now)
+		if !due {
+			continue
+		}
+		adv := chainAdvance{
+			ID:       issue.ID,
+			Title:    issue.Title,
+			Severity: fields.Severity,
+			Level:    next + 1,
+			Levels:   len(chain),
+			From:     chain[next-1].Target,
+			To:       chain[next].Target,
+			SLA:      chain[next-1].SLA,
+		}
+		if escalateDryRun {
+			advances = append(advances, adv)
+			continue
+		}
+
+		if router == nil {
+			router = mail.NewRouter(townRoot)
+			defer router.WaitPendingNotifications()
+		}
+		msg := &mail.Message{
+			From:     advancedBy,
+			To:       adv.To,
+			Subject:  fmt.Sprintf("[%s] SLA lapsed: %s", strings.ToUpper(fields.Severity), issue.Title),
+			Body:     formatChainMailBody(adv, fields),
+			Type:     mail.TypeTask,
+			Priority: escalationMailPriority(fields.Severity),
+		}
+		if err := router.Send(msg); err != nil {
+			// Leave the level alone so the next run retries the notification.
+			adv.Error = err.Error()
+		} else if err := bd.AdvanceEscalationChain(issue.ID, next, now); err != nil {
+			adv.Error = fmt.Sprintf("notified %s but failed to record the new level: %v", adv.To, err)
+		}
+		recordChainAdvance(townRoot, advancedBy, adv)
+		advances = append(advances, adv)
+	}
+
+	if escalateSLAJSON {
+		if advances == nil {
+			advances = []chainAdvance{}
+		}
+		out, _ := json.MarshalIndent(advances, "", "  ")
+		fmt.Println(string(out))
+		return nil
+	}
+
+	if len(advances) == 0 {
+		if !escalateSLAQuiet {
+			fmt.Println("No escalation SLAs have lapsed")
+		}
+		return nil
+	}
+
+	verb := "Advanced"
+	if escalateDryRun {
+		verb = "Would advance"
+	}
+	fmt.Printf("⏱️  %s %d escalation(s) along their chain:\n\n", verb, len(advances))
+	for _, adv := range advances {
+		fmt.Printf("  %s %s %s\n", severityEmoji(adv.Severity), adv.ID, adv.Title)
+		fmt.Printf("     %s → %s (level %d/%d, %s SLA lapsed)\n", adv.From, adv.To, adv.Level, adv.Levels, adv.SLA)
+		if adv.Error != "" {
+			fmt.Printf("     %s\n", style.Error.Render("failed: "+adv.Error))
+		}
+	}
+	return nil
+}
+
+// recordChainAdvance logs a chain advance to the audit log and the
+// activity feed.
+func recordChainAdvance(townRoot, by string, adv chainAdvance) {
+	rec := auditlog.Record{
+		Actor:   by,
+		Command: "escalate sla",
+		Args:    []string{adv.ID, "from=" + adv.From, "to=" + adv.To, fmt.Sprintf("level=%d/%d", adv.Level, adv.Levels)},
+		Result:  auditlog.ResultOK,
+		Session: os.Getenv("GT_SESSION"),
+	}
+	if adv.Error != "" {
+		rec.Result = auditlog.ResultError
+		rec.Error = adv.Error
+	}
+	_ = auditlog.Append(townRoot, rec)
+	if adv.Error != "" {
+		return
+	}
+
+	_ = events.LogFeed(events.TypeEscalationSent, by, map[string]interface{}{
+		"escalation_id": adv.ID,
+		"chain_level":   adv.Level,
+		"chain_levels":  adv.Levels,
+		"sla_lapsed":    adv.SLA,
+		"from":          adv.From,
+		"targets":       adv.To,
+	})
+}
+
+func formatChainMailBody(adv chainAdvance, fields *beads.EscalationFields) string {
+	var lines []string
+	lines = append(lines, fmt.Sprintf("Escalation ID: %s", adv.ID))
+	lines = append(lines, fmt.Sprintf("Severity: %s", fields.Severity))
+	lines = append(lines, fmt.Sprintf("From: %s", fields.EscalatedBy))
+	lines = append(lines, fmt.Sprintf("Chain level: %d of %d", adv.Level, adv.Levels))
+	if fields.Reason != "" {
+		lines = append(lines, "")
+		lines = append(lines, "Reason:")
+		lines = append(lines, fields.Reason)
+	}
+	if fields.RelatedBead != "" {
+		lines = append(lines, "")
+		lines = append(lines, fmt.Sprintf("Related: %s", fields.RelatedBead))
+	}
+	lines = append(lines, "")
+	lines = append(lines, fmt.Sprintf("%s did not acknowledge this escalation within its %s SLA, so it has moved to you.", adv.From, adv.SLA))
+	lines = append(lines, "")
+	lines = append(lines, "---")
+	lines = append(lines, "To acknowledge: gt escalate ack "+adv.ID)
+	lines = append(lines, "To close: gt escalate close "+adv.ID+" --reason \"resolution\"")
+	return strings.Join(lines, "\n")
+}
+
+// formatChainLevels renders a chain for display, e.g.
+// "gastown/crew/max (30m) → gastown/witness (1h) → mayor/".
+func formatChainLevels(chain []config.EscalationChainLevel) string {
+	parts := make([]string, len(chain))
+	for i, l := range chain {
+		parts[i] = l.Target
+		if l.SLA != "" && i < len(chain)-1 {
+			parts[i] += " (" + l.SLA + ")"
+		}
+	}
+	return strings.Join(parts, " → ")
+}
@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+func TestResolveEscalationChain(t *testing.T) {
+	cfg := &config.EscalationConfig{
+		Chains: map[string][]config.EscalationChainLevel{
+			"default": {{Target: "gastown/witness", SLA: "1h"}, {Target: "mayor/"}},
+			"ops":     {{Target: "gastown/crew/max", SLA: "15m"}, {Target: "deacon/"}},
+		},
+	}
+
+	chain, err := resolveEscalationChain(cfg, "")
+	if err != nil || len(chain) != 2 || chain[0].Target != "gastown/witness" {
+		t.Errorf("empty spec = %+v, %v; want the default chain", chain, err)
+	}
+	chain, err = resolveEscalationChain(cfg, "ops")
+	if err != nil || len(chain) != 2 || chain[0].Target != "gastown/crew/max" {
+		t.Errorf("named spec = %+v, %v; want the ops chain", chain, err)
+	}
+	chain, err = resolveEscalationChain(cfg, "gastown/crew/joe=10m,mayor/")
+	if err != nil || len(chain) != 2 || chain[0].SLA != "10m" {
+		t.Errorf("inline spec = %+v, %v", chain, err)
+	}
+	if _, err := resolveEscalationChain(cfg, "nightly"); err == nil {
+		t.Error("unknown chain name: want error")
+	}
+	if chain, err := resolveEscalationChain(&config.EscalationConfig{}, ""); err != nil || chain != nil {
+		t.Errorf("no default chain = %+v, %v; want nil", chain, err)
+	}
+}
+
+func TestNextChainLevel(t *testing.T) {
+	notified := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	chain := "gastown/crew/max=30m,gastown/witness=1h,mayor/"
+
+	tests := []struct {
+		name     string
+		fields   beads.EscalationFields
+		labels   []string
+		now      time.Time
+		wantNext int
+		wantDue  bool
+	}{
+		{
+			name:   "within sla",
+			fields: beads.EscalationFields{Chain: chain, LevelNotifiedAt: notified.Format(time.RFC3339)},
+			now:    notified.Add(29 * time.Minute),
+		},
+		{
+			name:     "first sla lapsed",
+			fields:   beads.EscalationFields{Chain: chain, LevelNotifiedAt: notified.Format(time.RFC3339)},
+			now:      notified.Add(30 * time.Minute),
+			wantNext: 1,
+			wantDue:  true,
+		},
+		{
+			name:   "second level uses its own sla",
+			fields: beads.EscalationFields{Chain: chain, ChainLevel: 1, LevelNotifiedAt: notified.Format(time.RFC3339)},
+			now:    notified.Add(45 * time.Minute),
+		},
+		{
+			name:     "second sla lapsed",
+			fields:   beads.EscalationFields{Chain: chain, ChainLevel: 1, LevelNotifiedAt: notified.Format(time.RFC3339)},
+			now:      notified.Add(2 * time.Hour),
+			wantNext: 2,
+			wantDue:  true,
+		},
+		{
+			name:   "last level never advances",
+			fields: beads.EscalationFields{Chain: chain, ChainLevel: 2, LevelNotifiedAt: notified.Format(time.RFC3339)},
+			now:    notified.Add(24 * time.Hour),
+		},
+		{
+			name:   "acked",
+			fields: beads.EscalationFields{Chain: chain, LevelNotifiedAt: notified.Format(time.RFC3339)},
+			labels: []string{"gt:escalation", "acked"},
+			now:    notified.Add(24 * time.Hour),
+		},
+		{
+			name:   "no chain",
+			fields: beads.EscalationFields{EscalatedAt: notified.Format(time.RFC3339)},
+			now:    notified.Add(24 * time.Hour),
+		},
+		{
+			name:     "falls back to escalated_at",
+			fields:   beads.EscalationFields{Chain: chain, EscalatedAt: notified.Format(time.RFC3339)},
+			now:      notified.Add(time.Hour),
+			wantNext: 1,
+			wantDue:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issue := &beads.Issue{ID: "hq-esc1", Labels: tt.labels}
+			levels, next, due := nextChainLevel(issue, &tt.fields, tt.now)
+			if due != tt.wantDue {
+				t.Fatalf("due = %v, want %v", due, tt.wantDue)
+			}
+			if !due {
+				return
+			}
+			if next != tt.wantNext {
+				t.Errorf("next = %d, want %d", next, tt.wantNext)
+			}
+			if len(levels) != 3 {
+				t.Errorf("got %d levels, want 3", len(levels))
+			}
+		})
+	}
+}
+
+func TestReescalationTargets(t *testing.T) {
+	cfg := &config.EscalationConfig{
+		Routes: map[string][]string{
+			config.SeverityHigh: {"bead", "mail:gastown/witness", "mail:mayor", "email:human"},
+		},
+	}
+	chain := "gastown/crew/max=30m,gastown/witness=1h,mayor/"
+
+	tests := []struct {
+		name        string
+		fields      *beads.EscalationFields
+		wantTargets []string
+		wantLevel   int
+	}{
+		{"no chain uses the route", &beads.EscalationFields{}, []string{"gastown/witness", "mayor"}, 0},
+		{"chain goes to the next tier", &beads.EscalationFields{Chain: chain, ChainLevel: 0}, []string{"gastown/witness"}, 1},
+		{"last tier is renotified", &beads.EscalationFields{Chain: chain, ChainLevel: 2}, []string{"mayor/"}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			targets, level := reescalationTargets(cfg, tt.fields, config.SeverityHigh)
+			if strings.Join(targets, ",") != strings.Join(tt.wantTargets, ",") || level != tt.wantLevel {
+				t.Errorf("reescalationTargets = %v, level %d; want %v, level %d", targets, level, tt.wantTargets, tt.wantLevel)
+			}
+		})
+	}
+}
@@ -55,16 +55,31 @@ func runEscalate(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("loading escalation config: %w", err)
 	}
 
+	// Resolve the escalation chain, if any
+	chain, err := resolveEscalationChain(escalationConfig, escalateChain)
+	if err != nil {
+		return err
+	}
+
 	// Detect agent identity
-	agentID := detectSender()
+	agentID, err := resolveMailSender()
+	if err != nil {
+		return err
+	}
 	if agentID == "" {
 		agentID = "unknown"
 	}
 
+	// Get routing actions for this severity. A chain replaces the route's
+	// mail targets with its first level.
+	actions := escalationConfig.GetRouteForSeverity(severity)
+	targets := extractMailTargetsFromActions(actions)
+	if chain != nil {
+		targets = []string{chain[0].Target}
+	}
+
 	// Dry run mode
 	if escalateDryRun {
-		actions := escalationConfig.GetRouteForSeverity(severity)
-		targets := extractMailTargetsFromActions(actions)
 		fmt.Printf("Would create escalation:\n")
 		fmt.Printf("  Severity: %s\n", severity)
 		fmt.Printf("  Description: %s\n", description)
@@ -76,6 +91,9 @@ func runEscalate(cmd *cobra.Command, args []string) error {
 		}
 		fmt.Printf("  Actions: %s\n", strings.Join(actions, ", "))
 		fmt.Printf("  Mail targets: %s\n", strings.Join(targets, ", "))
+		if chain != nil {
+			fmt.Printf("  Chain: %s\n", formatChainLevels(chain))
+		}
 		return nil
 	}
 
@@ -86,41 +104,30 @@ func runEscalate(cmd *cobra.Command, args []string) error {
 		Reason:      escalateReason,
 		Source:      escalateSource,
 		EscalatedBy: agentID,
-		EscalatedAt: time.Now().Format(time.RFC3339),
+		EscalatedAt: time.Now().UTC().Format(time.RFC3339),
 		RelatedBead: escalateRelatedBead,
 	}
+	if chain != nil {
+		fields.Chain = config.FormatEscalationChain(chain)
+		fields.LevelNotifiedAt = fields.EscalatedAt
+	}
 
 	issue, err := bd.CreateEscalationBead(description, fields)
 	if err != nil {
 		return fmt.Errorf("creating escalation bead: %w", err)
 	}
 
-	// Get routing actions for this severity
-	actions := escalationConfig.GetRouteForSeverity(severity)
-	targets := extractMailTargetsFromActions(actions)
-
 	// Send mail to each target (actions with "mail:" prefix)
 	router := mail.NewRouter(townRoot)
 	defer router.WaitPendingNotifications()
 	for _, target := range targets {
 		msg := &mail.Message{
-			From:    agentID,
-			To:      target,
-			Subject: fmt.Sprintf("[%s] %s", strings.ToUpper(severity), description),
-			Body:    formatEscalationMailBody(issue.ID, severity, escalateReason, agentID, escalateRelatedBead),
-			Type:    mail.TypeTask,
-		}
-
-		// Set priority based on severity
-		switch severity {
-		case config.SeverityCritical:
-			msg.Priority = mail.PriorityUrgent
-		case config.SeverityHigh:
-			msg.Priority = mail.PriorityHigh
-		case config.SeverityMedium:
-			msg.Priority = mail.PriorityNormal
-		default:
-			msg.Priority = mail.PriorityLow
+			From:     agentID,
+			To:       target,
+			Subject:  fmt.Sprintf("[%s] %s", strings.ToUpper(severity), description),
+			Body:     formatEscalationMailBody(issue.ID, severity, escalateReason, agentID, escalateRelatedBead),
+			Type:     mail.TypeTask,
+			Priority: escalationMailPriority(severity),
 		}
 
 		if err := router.Send(msg); err != nil {
@@ -148,6 +155,9 @@ func runEscalate(cmd *cobra.Command, args []string) error {
 			"actions":  actions,
 			"targets":  targets,
 		}
+		if chain != nil {
+			result["chain"] = fields.Chain
+		}
 		if escalateSource != "" {
 			result["source"] = escalateSource
 		}
@@ -315,7 +325,10 @@ func runEscalateStale(cmd *cobra.Command, args []string) error {
 	}
 
 	// Detect who is reescalating
-	reescalatedBy := detectSender()
+	reescalatedBy, err := resolveMailSender()
+	if err != nil {
+		return err
+	}
 	if reescalatedBy == "" {
 		reescalatedBy = "system"
 	}
@@ -343,6 +356,8 @@ func runEscalateStale(cmd *cobra.Command, args []string) error {
 				fmt.Printf("  %s %s %s\n", emoji, issue.ID, issue.Title)
 				fmt.Printf("     %s → %s (reescalation %d/%d)\n",
 					fields.Severity, newSeverity, fields.ReescalationCount+1, maxReescalations)
+				targets, _ := reescalationTargets(escalationConfig, fields, newSeverity)
+				fmt.Printf("     Mail targets: %s\n", strings.Join(targets, ", "))
 			}
 			fmt.Println()
 		}
@@ -354,7 +369,9 @@ func runEscalateStale(cmd *cobra.Command, args []string) error {
 	router := mail.NewRouter(townRoot)
 	defer router.WaitPendingNotifications()
 
+	now := time.Now()
 	for _, issue := range stale {
+		fields := beads.ParseEscalationFields(issue.Description)
 		result, err := bd.ReescalateEscalation(issue.ID, reescalatedBy, maxReescalations)
 		if err != nil {
 			style.PrintWarning("failed to reescalate %s: %v", issue.ID, err)
@@ -364,33 +381,31 @@ func runEscalateStale(cmd *cobra.Command, args []string) error {
 
 		// If not skipped, re-route to new severity targets
 		if !result.Skipped {
-			actions := escalationConfig.GetRouteForSeverity(result.NewSeverity)
-			targets := extractMailTargetsFromActions(actions)
+			targets, nextLevel := reescalationTargets(escalationConfig, fields, result.NewSeverity)
 
 			// Send mail to each target about the reescalation
+			sent := false
 			for _, target := range targets {
 				msg := &mail.Message{
-					From:    reescalatedBy,
-					To:      target,
-					Subject: fmt.Sprintf("[%s→%s] Re-escalated: %s", strings.ToUpper(result.OldSeverity), strings.ToUpper(result.NewSeverity), result.Title),
-					Body:    formatReescalationMailBody(result, reescalatedBy),
-					Type:    mail.TypeTask,
-				}
-
-				// Set priority based on new severity
-				switch result.NewSeverity {
-				case config.SeverityCritical:
-					msg.Priority = mail.PriorityUrgent
-				case config.SeverityHigh:
-					msg.Priority = mail.PriorityHigh
-				case config.SeverityMedium:
-					msg.Priority = mail.PriorityNormal
-				default:
-					msg.Priority = mail.PriorityLow
+					From:     reescalatedBy,
+					To:       target,
+					Subject:  fmt.Sprintf("[%s→%s] Re-escalated: %s", strings.ToUpper(result.OldSeverity), strings.ToUpper(result.NewSeverity), result.Title),
+					Body:     formatReescalationMailBody(result, reescalatedBy),
+					Type:     mail.TypeTask,
+					Priority: escalationMailPriority(result.NewSeverity),
 				}
-
 				if err := router.Send(msg); err != nil {
 					style.PrintWarning("failed to send reescalation to %s: %v", target, err)
+					continue
+				}
+				sent = true
+			}
+
+			// A chained escalation moved to its next tier; record it so the
+			// SLA timer starts over from that tier.
+			if sent && nextLevel > fields.ChainLevel {
+				if err := bd.AdvanceEscalationChain(issue.ID, nextLevel, now); err != nil {
+					style.PrintWarning("failed to record chain level for %s: %v", issue.ID, err)
 				}
 			}
 
@@ -506,6 +521,11 @@ func runEscalateShow(cmd *cobra.Command, args []string) error {
 			"closedReason": fields.ClosedReason,
 			"relatedBead": fields.RelatedBead,
 		}
+		if fields.Chain != "" {
+			data["chain"] = fields.Chain
+			data["chainLevel"] = fields.ChainLevel
+			data["levelNotifiedAt"] = fields.LevelNotifiedAt
+		}
 		out, _ := json.MarshalIndent(data, "", "  ")
 		fmt.Println(string(out))
 		return nil
@@ -531,6 +551,13 @@ func runEscalateShow(cmd *cobra.Command, args []string) error {
 	if fields.RelatedBead != "" {
 		fmt.Printf("  Related: %s\n", fields.RelatedBead)
 	}
+	if fields.Chain != "" {
+		if chain, err := config.ParseEscalationChain(fields.Chain); err == nil {
+			fmt.Printf("  Chain: %s\n", formatChainLevels(chain))
+			fmt.Printf("  Chain level: %d/%d (%s notified %s)\n", fields.ChainLevel+1, len(chain),
+				chain[min(fields.ChainLevel, len(chain)-1)].Target, formatRelativeTime(fields.LevelNotifiedAt))
+		}
+	}
 
 	return nil
 }
@@ -611,6 +638,41 @@ func formatEscalationMailBody(beadID, severity, reason, from, related string) st
 	return strings.Join(lines, "\n")
 }
 
+// reescalationTargets returns who is mailed when a stale escalation is
+// re-escalated to newSeverity, and the chain level that leaves it at. An
+// escalation on a chain goes to its next tier only (or its last tier again,
+// once there), as the SLA timer would; others go to every mail target of
+// the new severity's route.
+func reescalationTargets(cfg *config.EscalationConfig, fields *beads.EscalationFields, newSeverity string) ([]string, int) {
+	if fields.Chain != "" {
+		if chain, err := config.ParseEscalationChain(fields.Chain); err == nil && len(chain) > 0 {
+			next := fields.ChainLevel + 1
+			if next >= len(chain) {
+				next = len(chain) - 1
+			}
+			if next < 0 {
+				next = 0
+			}
+			return []string{chain[next].Target}, next
+		}
+	}
+	return extractMailTargetsFromActions(cfg.GetRouteForSeverity(newSeverity)), fields.ChainLevel
+}
+
+// escalationMailPriority maps an escalation severity to a mail priority.
+func escalationMailPriority(severity string) mail.Priority {
+	switch severity {
+	case config.SeverityCritical:
+		return mail.PriorityUrgent
+	case config.SeverityHigh:
+		return mail.PriorityHigh
+	case config.SeverityMedium:
+		return mail.PriorityNormal
+	default:
+		return mail.PriorityLow
+	}
+}
+
 func severityEmoji(severity string) string {
 	switch severity {
 	case config.SeverityCritical:
@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/util"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var (
+	eventsFollow bool
+	eventsTypes  []string
+	eventsTopics []string
+	eventsActor  string
+	eventsSince  string
+	eventsLimit  int
+)
+
+var eventsCmd = &cobra.Command{
+	Use:     "events",
+	GroupID: GroupDiag,
+	Short:   "Print or stream town events as JSON lines",
+	Long: `Print recent events from the town's event log (.events.jsonl), one JSON
+object per line, for scripts and other tools to consume.
+
+With --follow, keeps running and streams events as any gt process logs
+them, until interrupted.
+
+Events can be selected by type (patterns like "merge_*" work), by topic,
+and by actor. Topics group related types:
+  agent   session_start, session_end, spawn, kill, session_death, mass_death, crash_loop,
+          stuck_worker
+  mail    mail
+  nudge   nudge, nudge_failed, nudge_circuit, polecat_nudged
+  hook    hook, unhook, sling, hook_detached
+  merge   merge_started, merged, merge_failed, merge_skipped
+  config  config_reloaded
+  bead    bead_changed (logged by 'gt bead watch --emit' or the daemon's bead_watch patrol)
+
+Examples:
+  gt events                              # Last 20 events
+  gt events --follow                     # Stream new events
+  gt events -f --topic agent --topic merge
+  gt events --type 'merge_*' --since 24h --limit 0
+  gt events -f --actor 'gastown/*' | jq -r .type`,
+	Args: cobra.NoArgs,
+	RunE: runEvents,
+}
+
+func init() {
+	eventsCmd.Flags().BoolVarP(&eventsFollow, "follow", "f", false, "Stream new events until interrupted")
+	eventsCmd.Flags().StringSliceVar(&eventsTypes, "type", nil, "Only events of this type or pattern (repeatable)")
+	eventsCmd.Flags().StringSliceVar(&eventsTopics, "topic", nil, "Only events in this topic: agent, mail, nudge, hook, merge, config, bead (repeatable)")
+	eventsCmd.Flags().StringVar(&eventsActor, "actor", "", "Only events by actors matching this pattern")
+	eventsCmd.Flags().StringVar(&eventsSince, "since", "", "Only events newer than this (e.g., 30m, 24h, 7d)")
+	eventsCmd.Flags().IntVarP(&eventsLimit, "limit", "n", 20, "Print at most this many past events (0 for all)")
+	rootCmd.AddCommand(eventsCmd)
+}
+
+func runEvents(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	for _, topic := range eventsTopics {
+		if events.TopicTypes(topic) == nil {
+			return fmt.Errorf("unknown topic %q (valid: %s)", topic, strings.Join(events.Topics(), ", "))
+		}
+	}
+	filter := events.Filter{Types: eventsTypes, Topics: eventsTopics, Actor: eventsActor}
+	var since time.Time
+	if eventsSince != "" {
+		d, err := parseDuration(eventsSince)
+		if err != nil {
+			return fmt.Errorf("invalid --since: %w", err)
+		}
+		since = time.Now().Add(-d)
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	past, offset, err := readPastEvents(townRoot, filter, since)
+	if err != nil {
+		return err
+	}
+	if eventsLimit > 0 && len(past) > eventsLimit {
+		past = past[len(past)-eventsLimit:]
+	}
+	for _, e := range past {
+		if err := enc.Encode(e); err != nil {
+			return err
+		}
+	}
+	if !eventsFollow {
+		return nil
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	return events.Follow(ctx, townRoot, filter, offset, func(e events.Event) {
+		_ = enc.Encode(e)
+	})
+}
+
+// readPastEvents returns the logged events matching the filter since the
+// given time, and the log offset to follow from.
+func readPastEvents(townRoot string, filter events.Filter, since time.Time) ([]events.Event, int64, error) {
+	logPath := filepath.Join(townRoot, events.EventsFile)
+	var matched []events.Event
+	var offset int64
+	for {
+		batch, next, err := events.ReadFrom(logPath, offset, 1000)
+		if os.IsNotExist(err) {
+			return nil, 0, nil
+		} else if err != nil {
+			return nil, offset, fmt.Errorf("reading events: %w", err)
+		}
+		for _, e := range batch {
+			if !filter.Match(e) {
+				continue
+			}
+			if !since.IsZero() {
+				if ts, err := util.ParseTimestamp(e.Timestamp); err == nil && ts.Before(since) {
+					continue
+				}
+			}
+			matched = append(matched, e)
+		}
+		if next == offset {
+			return matched, offset, nil
+		}
+		offset = next
+	}
+}
@@ -0,0 +1,324 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+var (
+	execRig     string
+	execRoles   []string
+	execRunning bool
+	execNudge   bool
+	execLines   int
+	execWait    time.Duration
+	execJSON    bool
+	execDryRun  bool
+)
+
+// execScrollback is how many pane lines are captured before and after
+// sending, to find the new output.
+const execScrollback = 200
+
+// agentTypeRoles maps agent types to the role names --role accepts.
+var agentTypeRoles = map[AgentType]string{
+	AgentMayor:    constants.RoleMayor,
+	AgentDeacon:   constants.RoleDeacon,
+	AgentWitness:  constants.RoleWitness,
+	AgentRefinery: constants.RoleRefinery,
+	AgentCrew:     constants.RoleCrew,
+	AgentPolecat:  constants.RolePolecat,
+}
+
+var execCmd = &cobra.Command{
+	Use:     "exec [flags] [--] <command>",
+	GroupID: GroupAgents,
+	Short:   "Run a command in every matching agent session",
+	Long: `Send a command to every agent session matching the selectors, wait,
+and print what each pane output afterwards.
+
+The command is typed literally into each pane followed by Enter, as if
+you had typed it there. Use --nudge to deliver it as a nudge instead, for
+text meant for the agent rather than its shell.
+
+Selectors (combined with AND; none selects every agent):
+  --rig <rig>      Agents in this rig
+  --role <role>    Agents of this role: mayor, deacon, witness, refinery,
+                   crew, polecat (repeatable or comma-separated)
+  --running        Only sessions whose agent process is alive
+
+Your own session is never included. After --wait, the next --lines lines
+of each pane's output are collected into a per-agent table.
+
+Examples:
+  gt exec --rig gastown --role polecat -- '!git status --short'
+  gt exec --role crew,polecat --running --nudge "What are you working on?"
+  gt exec --role witness --wait 10s -n 20 -- '/status'
+  gt exec --dry-run --rig gastown -- pwd`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runExec,
+}
+
+func init() {
+	execCmd.Flags().StringVar(&execRig, "rig", "", "Only agents in this rig")
+	execCmd.Flags().StringSliceVar(&execRoles, "role", nil, "Only agents of these roles (mayor, deacon, witness, refinery, crew, polecat)")
+	execCmd.Flags().BoolVar(&execRunning, "running", false, "Only sessions whose agent process is alive")
+	execCmd.Flags().BoolVar(&execNudge, "nudge", false, "Deliver as a nudge to the agent instead of typing into the pane")
+	execCmd.Flags().IntVarP(&execLines, "lines", "n", 10, "Lines of output to collect from each pane")
+	execCmd.Flags().DurationVar(&execWait, "wait", 5*time.Second, "How long to wait for output before collecting it")
+	execCmd.Flags().BoolVar(&execJSON, "json", false, "Output results as JSON")
+	execCmd.Flags().BoolVar(&execDryRun, "dry-run", false, "Show the matching sessions without sending")
+	rootCmd.AddCommand(execCmd)
+}
+
+// execResult is one agent's outcome from gt exec.
+type execResult struct {
+	Agent   string   `json:"agent"`
+	Session string   `json:"session"`
+	Status  string   `json:"status"` // ok, error
+	Output  []string `json:"output"`
+	Error   string   `json:"error,omitempty"`
+}
+
+func runExec(cmd *cobra.Command, args []string) error {
+	command := strings.Join(args, " ")
+	if strings.TrimSpace(command) == "" {
+		return fmt.Errorf("command cannot be empty")
+	}
+	if execLines < 0 {
+		return fmt.Errorf("--lines must not be negative")
+	}
+	for _, role := range execRoles {
+		if !isExecRole(role) {
+			return fmt.Errorf("invalid --role %q: must be one of mayor, deacon, witness, refinery, crew, polecat", role)
+		}
+	}
+
+	agents, err := getAgentSessions(true)
+	if err != nil {
+		return fmt.Errorf("listing sessions: %w", err)
+	}
+
+	t := tmux.NewTmux()
+	self := tmux.CurrentSessionName()
+	var targets []*AgentSession
+	for _, agent := range agents {
+		if agent.Name == self || !matchesExecSelector(agent, execRig, execRoles) {
+			continue
+		}
+		if execRunning && !t.IsAgentAlive(agent.Name) {
+			continue
+		}
+		targets = append(targets, agent)
+	}
+
+	if len(targets) == 0 {
+		fmt.Println("No agent sessions match.")
+		return nil
+	}
+
+	if execDryRun {
+		verb := "send"
+		if execNudge {
+			verb = "nudge"
+		}
+		fmt.Printf("Would %s to %d agent(s):\n\n", verb, len(targets))
+		for _, agent := range targets {
+			fmt.Printf("  %s %s\n", AgentTypeIcons[agent.Type], formatAgentName(agent))
+		}
+		fmt.Printf("\nCommand: %s\n", command)
+		return nil
+	}
+
+	if !execJSON {
+		fmt.Printf("Sending to %d agent(s), collecting output after %s...\n\n", len(targets), execWait)
+	}
+
+	// Each agent gets its own goroutine so the waits overlap.
+	results := make([]execResult, len(targets))
+	var wg sync.WaitGroup
+	for i, agent := range targets {
+		wg.Add(1)
+		go func(i int, agent *AgentSession) {
+			defer wg.Done()
+			results[i] = execInSession(t, agent, command)
+		}(i, agent)
+	}
+	wg.Wait()
+
+	failed := 0
+	for _, r := range results {
+		if r.Status != "ok" {
+			failed++
+		}
+	}
+
+	if execJSON {
+		out, _ := json.MarshalIndent(results, "", "  ")
+		fmt.Println(string(out))
+	} else {
+		printExecResults(results)
+	}
+
+	if failed > 0 {
+		if !execJSON {
+			fmt.Printf("\n%s %d of %d agent(s) failed\n", style.WarningPrefix, failed, len(results))
+		}
+		return NewSilentExit(1)
+	}
+	return nil
+}
+
+// execInSession sends the command to one session and collects the output
+// that follows it.
+func execInSession(t *tmux.Tmux, agent *AgentSession, command string) execResult {
+	r := execResult{Agent: formatAgentName(agent), Session: agent.Name, Output: []string{}}
+
+	before, _ := t.CapturePaneLines(agent.Name, execScrollback)
+
+	var err error
+	if execNudge {
+		err = t.NudgeSession(agent.Name, command)
+	} else {
+		err = t.SendKeys(agent.Name, command)
+	}
+	if err != nil {
+		r.Status = "error"
+		r.Error = err.Error()
+		return r
+	}
+
+	time.Sleep(execWait)
+	after, err := t.CapturePaneLines(agent.Name, execScrollback)
+	if err != nil {
+		r.Status = "error"
+		r.Error = fmt.Sprintf("sent, but capturing output failed: %v", err)
+		return r
+	}
+	r.Status = "ok"
+	r.Output = newPaneLines(before, after, execLines)
+	return r
+}
+
+// newPaneLines returns the first n lines of after starting at the line the
+// command was typed on (before's last line, usually a prompt). The few
+// lines above it anchor where that is; when they have scrolled away, the
+// last n lines of after are returned instead.
+func newPaneLines(before, after []string, n int) []string {
+	before = trimTrailingBlank(before)
+	after = trimTrailingBlank(after)
+
+	const anchorLines = 3
+	var anchor []string
+	if len(before) > 0 {
+		anchor = before[:len(before)-1]
+	}
+	if len(anchor) > anchorLines {
+		anchor = anchor[len(anchor)-anchorLines:]
+	}
+
+	start := -1
+	if len(anchor) == 0 {
+		start = 0
+	} else {
+		for end := len(after); end >= len(anchor); end-- {
+			if equalLines(after[end-len(anchor):end], anchor) {
+				start = end
+				break
+			}
+		}
+	}
+
+	var out []string
+	if start < 0 {
+		out = after
+		if len(out) > n {
+			out = out[len(out)-n:]
+		}
+	} else {
+		out = after[start:]
+		if len(out) > n {
+			out = out[:n]
+		}
+	}
+	return append([]string{}, out...)
+}
+
+func trimTrailingBlank(lines []string) []string {
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+func equalLines(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if strings.TrimRight(a[i], " ") != strings.TrimRight(b[i], " ") {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesExecSelector reports whether an agent matches the --rig and --role
+// selectors. Empty selectors match everything.
+func matchesExecSelector(agent *AgentSession, rig string, roles []string) bool {
+	if rig != "" && agent.Rig != rig {
+		return false
+	}
+	if len(roles) == 0 {
+		return true
+	}
+	role := agentTypeRoles[agent.Type]
+	for _, r := range roles {
+		if strings.EqualFold(r, role) {
+			return true
+		}
+	}
+	return false
+}
+
+func isExecRole(role string) bool {
+	for _, r := range agentTypeRoles {
+		if strings.EqualFold(role, r) {
+			return true
+		}
+	}
+	return false
+}
+
+// printExecResults prints the per-agent table. Each agent's output lines
+// follow its row, indented under the OUTPUT column.
+func printExecResults(results []execResult) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "AGENT\tSTATUS\tOUTPUT")
+	for _, r := range results {
+		status := style.Success.Render(r.Status)
+		lines := r.Output
+		if r.Status != "ok" {
+			status = style.Error.Render(r.Status)
+			lines = []string{r.Error}
+		}
+		if len(lines) == 0 {
+			lines = []string{style.Dim.Render("(no new output)")}
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", r.Agent, status, lines[0])
+		for _, line := range lines[1:] {
+			fmt.Fprintf(w, "\t\t%s\n", line)
+		}
+	}
+	_ = w.Flush()
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewPaneLines(t *testing.T) {
+	tests := []struct {
+		name   string
+		before []string
+		after  []string
+		n      int
+		want   []string
+	}{
+		{
+			name:   "new output after the anchor",
+			before: []string{"a", "b", "$ ", ""},
+			after:  []string{"a", "b", "$ ls", "x.go", "y.go", "$ ", ""},
+			n:      10,
+			want:   []string{"$ ls", "x.go", "y.go", "$ "},
+		},
+		{
+			name:   "clipped to n lines",
+			before: []string{"out", "prompt>"},
+			after:  []string{"out", "prompt> cmd", "1", "2", "3"},
+			n:      2,
+			want:   []string{"prompt> cmd", "1"},
+		},
+		{
+			name:   "anchor scrolled away falls back to the tail",
+			before: []string{"old1", "old2", "old3"},
+			after:  []string{"new1", "new2", "new3"},
+			n:      2,
+			want:   []string{"new2", "new3"},
+		},
+		{
+			name:   "empty before takes everything",
+			before: nil,
+			after:  []string{"hello"},
+			n:      5,
+			want:   []string{"hello"},
+		},
+		{
+			name:   "no output past the prompt",
+			before: []string{"a", "b"},
+			after:  []string{"a", "b", "  "},
+			n:      5,
+			want:   []string{"b"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := newPaneLines(tt.before, tt.after, tt.n)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("newPaneLines() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchesExecSelector(t *testing.T) {
+	polecat := &AgentSession{Type: AgentPolecat, Rig: "gastown", AgentName: "nux"}
+	mayor := &AgentSession{Type: AgentMayor}
+
+	tests := []struct {
+		agent *AgentSession
+		rig   string
+		roles []string
+		want  bool
+	}{
+		{polecat, "", nil, true},
+		{polecat, "gastown", nil, true},
+		{polecat, "beads", nil, false},
+		{polecat, "gastown", []string{"crew", "polecat"}, true},
+		{polecat, "", []string{"Polecat"}, true},
+		{polecat, "", []string{"witness"}, false},
+		{mayor, "", []string{"mayor"}, true},
+		{mayor, "gastown", nil, false},
+	}
+	for _, tt := range tests {
+		if got := matchesExecSelector(tt.agent, tt.rig, tt.roles); got != tt.want {
+			t.Errorf("matchesExecSelector(%s, %q, %v) = %v, want %v", formatAgentName(tt.agent), tt.rig, tt.roles, got, tt.want)
+		}
+	}
+
+	if !isExecRole("refinery") || isExecRole("dog") {
+		t.Error("isExecRole accepts the wrong roles")
+	}
+}
@@ -0,0 +1,235 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/polecat"
+	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+var (
+	gitStatusJSON bool
+	gitStatusRig  string
+	gitStatusAll  bool
+)
+
+var gitCmd = &cobra.Command{
+	Use:     "git",
+	GroupID: GroupWorkspace,
+	Short:   "Git operations across the town's clones",
+	RunE:    requireSubcommand,
+}
+
+var gitStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show git status of every clone in the town",
+	Long: `Show the git status of every clone in the town in one table: each rig's
+mayor and refinery clones, crew checkouts, and polecat worktrees.
+
+For each clone it reports the branch (or a detached HEAD), commits ahead of
+and behind its upstream, and the number of dirty files. A branch with no
+upstream (e.g. an unpushed polecat branch) is compared with origin's
+default branch instead.
+
+By default only clones that need attention are listed: dirty, ahead,
+behind, detached, or unreadable. Use --all to list every clone.
+
+Examples:
+  gt git status
+  gt git status --all
+  gt git status --rig gastown --json`,
+	Args: cobra.NoArgs,
+	RunE: runGitStatus,
+}
+
+func init() {
+	gitStatusCmd.Flags().BoolVar(&gitStatusJSON, "json", false, "Output as JSON")
+	gitStatusCmd.Flags().StringVar(&gitStatusRig, "rig", "", "Only show clones of this rig")
+	gitStatusCmd.Flags().BoolVar(&gitStatusAll, "all", false, "List clean, up-to-date clones too")
+
+	gitCmd.AddCommand(gitStatusCmd)
+	rootCmd.AddCommand(gitCmd)
+}
+
+// townClone is a git clone or worktree belonging to an agent of a rig.
+type townClone struct {
+	Rig   string `json:"rig"`
+	Role  string `json:"role"` // mayor, refinery, crew, or polecat
+	Name  string `json:"name,omitempty"`
+	Path  string `json:"path"`
+	Agent string `json:"agent"`
+}
+
+// cloneGitStatus is the git status of one townClone.
+type cloneGitStatus struct {
+	townClone
+	Branch   string `json:"branch,omitempty"`
+	Detached bool   `json:"detached"`
+	Compare  string `json:"compare,omitempty"` // Ref ahead/behind are counted against
+	Ahead    int    `json:"ahead"`
+	Behind   int    `json:"behind"`
+	Dirty    int    `json:"dirty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// needsAttention reports whether a clone is anything other than clean and
+// in sync with its upstream.
+func (s cloneGitStatus) needsAttention() bool {
+	return s.Error != "" || s.Detached || s.Dirty > 0 || s.Ahead > 0 || s.Behind > 0
+}
+
+func runGitStatus(cmd *cobra.Command, args []string) error {
+	rigs, townRoot, err := getAllRigs()
+	if err != nil {
+		return err
+	}
+	if gitStatusRig != "" {
+		var filtered []*rig.Rig
+		for _, r := range rigs {
+			if r.Name == gitStatusRig {
+				filtered = append(filtered, r)
+			}
+		}
+		if len(filtered) == 0 {
+			return fmt.Errorf("rig '%s' not found", gitStatusRig)
+		}
+		rigs = filtered
+	}
+
+	var statuses []cloneGitStatus
+	for _, r := range rigs {
+		for _, c := range rigClones(r) {
+			s := readCloneGitStatus(c)
+			if rel, err := filepath.Rel(townRoot, c.Path); err == nil {
+				s.Path = rel
+			}
+			statuses = append(statuses, s)
+		}
+	}
+
+	if !gitStatusAll {
+		var shown []cloneGitStatus
+		for _, s := range statuses {
+			if s.needsAttention() {
+				shown = append(shown, s)
+			}
+		}
+		statuses = shown
+	}
+
+	if gitStatusJSON {
+		if statuses == nil {
+			statuses = []cloneGitStatus{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(statuses)
+	}
+
+	if len(statuses) == 0 {
+		fmt.Printf("%s All clones clean and in sync\n", style.Bold.Render("✓"))
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "AGENT\tBRANCH\tAHEAD\tBEHIND\tDIRTY\tPATH")
+	for _, s := range statuses {
+		branch := s.Branch
+		switch {
+		case s.Error != "":
+			branch = style.Error.Render("error: " + s.Error)
+		case s.Detached:
+			branch = style.Warning.Render("(detached " + s.Branch + ")")
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			s.Agent, branch, countCell(s.Ahead), countCell(s.Behind), countCell(s.Dirty), style.Dim.Render(s.Path))
+	}
+	return w.Flush()
+}
+
+// countCell renders a count for the status table, dimming zeros.
+func countCell(n int) string {
+	if n == 0 {
+		return style.Dim.Render("0")
+	}
+	return style.Warning.Render(fmt.Sprintf("%d", n))
+}
+
+// rigClones returns the clones of a rig's agents that exist on disk, sorted
+// by agent: the mayor and refinery clones, crew checkouts, and polecat
+// worktrees.
+func rigClones(r *rig.Rig) []townClone {
+	candidates := []townClone{
+		{Role: "mayor", Path: filepath.Join(r.Path, "mayor", "rig")},
+		{Role: "refinery", Path: filepath.Join(r.Path, "refinery", "rig")},
+	}
+	for _, name := range r.Crew {
+		candidates = append(candidates, townClone{Role: "crew", Name: name, Path: filepath.Join(r.Path, "crew", name)})
+	}
+	for _, name := range r.Polecats {
+		candidates = append(candidates, townClone{Role: "polecat", Name: name, Path: polecat.ClonePath(r, name)})
+	}
+
+	var clones []townClone
+	for _, c := range candidates {
+		if _, err := os.Stat(filepath.Join(c.Path, ".git")); err != nil {
+			continue
+		}
+		c.Rig = r.Name
+		c.Agent = r.Name + "/" + c.Role
+		if c.Name != "" {
+			c.Agent = r.Name + "/" + c.Role + "/" + c.Name
+		}
+		clones = append(clones, c)
+	}
+	sort.SliceStable(clones, func(i, j int) bool { return clones[i].Agent < clones[j].Agent })
+	return clones
+}
+
+// readCloneGitStatus reads a clone's branch, ahead/behind counts, and dirty
+// file count. Errors are recorded in the status rather than returned, so one
+// broken clone doesn't hide the rest.
+func readCloneGitStatus(c townClone) cloneGitStatus {
+	s := cloneGitStatus{townClone: c}
+	g := git.NewGit(c.Path)
+
+	branch, err := g.CurrentBranch()
+	if err != nil {
+		s.Error = "cannot read HEAD"
+		return s
+	}
+	if branch == "HEAD" {
+		s.Detached = true
+		if sha, err := g.Rev("HEAD"); err == nil && len(sha) >= 8 {
+			s.Branch = sha[:8]
+		}
+	} else {
+		s.Branch = branch
+	}
+
+	if st, err := g.Status(); err == nil {
+		s.Dirty = len(st.Modified) + len(st.Added) + len(st.Deleted) + len(st.Untracked)
+	} else {
+		s.Error = "cannot read status"
+		return s
+	}
+
+	s.Compare = g.Upstream()
+	if s.Compare == "" {
+		s.Compare = "origin/" + g.RemoteDefaultBranch()
+	}
+	if ahead, behind, err := g.AheadBehind(s.Compare, "HEAD"); err == nil {
+		s.Ahead, s.Behind = ahead, behind
+	} else {
+		s.Compare = ""
+	}
+	return s
+}
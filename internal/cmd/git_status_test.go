@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/rig"
+)
+
+func gitStatusTestRun(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=Test", "GIT_AUTHOR_EMAIL=test@test.com",
+		"GIT_COMMITTER_NAME=Test", "GIT_COMMITTER_EMAIL=test@test.com")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+}
+
+func TestRigClonesAndStatus(t *testing.T) {
+	root := t.TempDir()
+	origin := filepath.Join(root, "origin.git")
+	gitStatusTestRun(t, root, "init", "--bare", "--initial-branch=main", origin)
+
+	rigPath := filepath.Join(root, "gastown")
+	mayor := filepath.Join(rigPath, "mayor", "rig")
+	gitStatusTestRun(t, root, "clone", origin, mayor)
+	gitStatusTestRun(t, mayor, "checkout", "-b", "main")
+	if err := os.WriteFile(filepath.Join(mayor, "README.md"), []byte("# test\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	gitStatusTestRun(t, mayor, "add", ".")
+	gitStatusTestRun(t, mayor, "commit", "-m", "initial")
+	gitStatusTestRun(t, mayor, "push", "-u", "origin", "main")
+
+	// A crew checkout with an unpushed commit and an untracked file.
+	crew := filepath.Join(rigPath, "crew", "max")
+	gitStatusTestRun(t, root, "clone", origin, crew)
+	if err := os.WriteFile(filepath.Join(crew, "work.txt"), []byte("work\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	gitStatusTestRun(t, crew, "add", ".")
+	gitStatusTestRun(t, crew, "commit", "-m", "work")
+	if err := os.WriteFile(filepath.Join(crew, "scratch.txt"), []byte("wip\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// A polecat worktree with a detached HEAD.
+	nux := filepath.Join(rigPath, "polecats", "nux", "gastown")
+	gitStatusTestRun(t, mayor, "worktree", "add", "--detach", nux, "HEAD")
+
+	r := &rig.Rig{Name: "gastown", Path: rigPath, Crew: []string{"max", "gone"}, Polecats: []string{"nux"}}
+	clones := rigClones(r)
+	var agents []string
+	for _, c := range clones {
+		agents = append(agents, c.Agent)
+	}
+	want := []string{"gastown/crew/max", "gastown/mayor", "gastown/polecat/nux"}
+	if len(agents) != len(want) {
+		t.Fatalf("rigClones agents = %v, want %v", agents, want)
+	}
+	for i := range want {
+		if agents[i] != want[i] {
+			t.Fatalf("rigClones agents = %v, want %v", agents, want)
+		}
+	}
+
+	byAgent := make(map[string]cloneGitStatus)
+	for _, c := range clones {
+		byAgent[c.Agent] = readCloneGitStatus(c)
+	}
+
+	if s := byAgent["gastown/mayor"]; s.needsAttention() || s.Branch != "main" || s.Compare != "origin/main" {
+		t.Errorf("mayor clone should be clean and in sync, got %+v", s)
+	}
+	if s := byAgent["gastown/crew/max"]; s.Ahead != 1 || s.Behind != 0 || s.Dirty != 1 {
+		t.Errorf("crew clone: want ahead 1, behind 0, dirty 1, got %+v", s)
+	}
+	if s := byAgent["gastown/polecat/nux"]; !s.Detached || !s.needsAttention() {
+		t.Errorf("polecat worktree should be detached, got %+v", s)
+	}
+}
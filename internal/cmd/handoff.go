@@ -38,6 +38,7 @@ This is the canonical way to end any agent session. It handles all roles:
 When run without arguments, hands off the current session.
 When given a bead ID (gt-xxx, hq-xxx), hooks that work first, then restarts.
 When given a role name, hands off that role's session (and switches to it).
+When given a bead ID and --to, hands that bead to another agent instead (see below).
 
 Examples:
   gt handoff                          # Hand off current session
@@ -47,6 +48,15 @@ Examples:
   gt handoff -c                       # Collect state into handoff message
   gt handoff crew                     # Hand off crew session
   gt handoff mayor                    # Hand off mayor session
+  gt handoff gt-abc --to gastown/crew/max -m "Finish the tests"
+
+With --to, your current branch is pushed to origin (unless it is the
+default branch) and the bead moves from your hook to the target agent's
+hook. If the push fails, nothing is handed off. A handoff note is added to
+the bead as a comment: your branch, the files changed on it, uncommitted
+files (which are not transferred), and next steps (from -m/--stdin, or
+prompted for on a terminal). The target is nudged to read the note and
+continue. Your session keeps running.
 
 The --collect (-c) flag gathers current state (hooked work, inbox, ready beads,
 in-progress items) and includes it in the handoff mail. This provides context
@@ -73,6 +83,7 @@ var (
 	handoffCycle      bool
 	handoffReason     string
 	handoffNoGitCheck bool
+	handoffTo         string
 )
 
 func init() {
@@ -86,6 +97,7 @@ func init() {
 	handoffCmd.Flags().BoolVar(&handoffCycle, "cycle", false, "Auto-cycle session (for PreCompact hooks that want full session replacement)")
 	handoffCmd.Flags().StringVar(&handoffReason, "reason", "", "Reason for handoff (e.g., 'compaction', 'idle')")
 	handoffCmd.Flags().BoolVar(&handoffNoGitCheck, "no-git-check", false, "Skip git workspace cleanliness check")
+	handoffCmd.Flags().StringVar(&handoffTo, "to", "", "Hand the given bead to another agent instead of restarting (e.g., gastown/crew/max)")
 	rootCmd.AddCommand(handoffCmd)
 }
 
@@ -102,6 +114,17 @@ func runHandoff(cmd *cobra.Command, args []string) error {
 		handoffMessage = strings.TrimRight(string(data), "\n")
 	}
 
+	// --to: transfer a bead to another agent; no session cycling.
+	if handoffTo != "" {
+		if len(args) != 1 || !looksLikeBeadID(args[0]) {
+			return fmt.Errorf("--to needs a bead ID: gt handoff <bead> --to <agent>")
+		}
+		if handoffAuto || handoffCycle {
+			return fmt.Errorf("--to cannot be combined with --auto or --cycle")
+		}
+		return runHandoffTransfer(args[0], handoffTo)
+	}
+
 	// --auto mode: save state only, no session cycling.
 	// Used by PreCompact hook to preserve state before compaction.
 	// Note: auto-mode exits here, before the git-status warning check below.
@@ -379,9 +402,9 @@ func runHandoffAuto() error {
 	beadID, err := sendHandoffMail(subject, message)
 	if err != nil {
 		// Non-fatal — log and continue
-		fmt.Fprintf(os.Stderr, "auto-handoff: could not send mail: %v\n", err)
+		logger.Warn("auto-handoff: could not send mail", "err", err)
 	} else {
-		fmt.Fprintf(os.Stderr, "auto-handoff: saved state to %s\n", beadID)
+		logger.Info("auto-handoff: saved state", "bead", beadID)
 	}
 
 	// Write handoff marker so post-compact prime knows it's post-handoff
@@ -446,7 +469,7 @@ func runHandoffCycle() error {
 	// Must be in tmux to respawn
 	if !tmux.IsInsideTmux() {
 		// Fall back to auto mode (save state only) if not in tmux
-		fmt.Fprintf(os.Stderr, "handoff --cycle: not in tmux, falling back to state-save only\n")
+		logger.Warn("handoff --cycle: not in tmux, falling back to state-save only")
 		handoffMessage = message
 		handoffSubject = subject
 		return runHandoffAuto()
@@ -454,7 +477,7 @@ func runHandoffCycle() error {
 
 	pane := os.Getenv("TMUX_PANE")
 	if pane == "" {
-		fmt.Fprintf(os.Stderr, "handoff --cycle: TMUX_PANE not set, falling back to state-save only\n")
+		logger.Warn("handoff --cycle: TMUX_PANE not set, falling back to state-save only")
 		handoffMessage = message
 		handoffSubject = subject
 		return runHandoffAuto()
@@ -462,7 +485,7 @@ func runHandoffCycle() error {
 
 	currentSession, err := getCurrentTmuxSession()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "handoff --cycle: could not get session: %v, falling back to state-save only\n", err)
+		logger.Warn("handoff --cycle: could not get session, falling back to state-save only", "err", err)
 		handoffMessage = message
 		handoffSubject = subject
 		return runHandoffAuto()
@@ -486,10 +509,10 @@ func runHandoffCycle() error {
 	// Send handoff mail to self (auto-hooked for successor)
 	beadID, err := sendHandoffMail(subject, message)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "handoff --cycle: could not send mail: %v\n", err)
+		logger.Warn("handoff --cycle: could not send mail", "err", err)
 		// Continue — respawn is more important than mail
 	} else {
-		fmt.Fprintf(os.Stderr, "handoff --cycle: saved state to %s\n", beadID)
+		logger.Info("handoff --cycle: saved state", "bead", beadID)
 	}
 
 	// Write handoff marker so post-cycle prime knows it's post-handoff.
@@ -527,11 +550,11 @@ func runHandoffCycle() error {
 		ContinuePrompt:  "Context compacted. Continue your previous task.",
 	})
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "handoff --cycle: could not build restart command: %v\n", err)
+		logger.Error("handoff --cycle: could not build restart command", "err", err)
 		return err
 	}
 
-	fmt.Fprintf(os.Stderr, "handoff --cycle: cycling session %s\n", currentSession)
+	logger.Info("handoff --cycle: cycling session", "session", currentSession)
 
 	// Set remain-on-exit so the pane survives process death during handoff
 	if err := t.SetRemainOnExit(pane, true); err != nil {
@@ -1116,6 +1139,28 @@ func handoffRemoteSession(t *tmux.Tmux, targetSession, restartCmd string) error
 		return nil
 	}
 
+	if err := respawnSessionPane(t, targetSession, targetPane, restartCmd); err != nil {
+		return err
+	}
+
+	// If --watch, switch to that session
+	if handoffWatch {
+		fmt.Printf("Switching to %s...\n", targetSession)
+		// Use tmux switch-client to move our view to the target session
+		if err := tmux.BuildCommand("switch-client", "-t", targetSession).Run(); err != nil {
+			// Non-fatal - they can manually switch
+			fmt.Printf("Note: Could not auto-switch (use: tmux switch-client -t %s)\n", targetSession)
+		}
+	}
+
+	return nil
+}
+
+// respawnSessionPane replaces the process in another session's pane with
+// restartCmd: it kills the pane's processes, clears its history, and
+// respawns it, falling back to the town root if the pane's working
+// directory was deleted.
+func respawnSessionPane(t *tmux.Tmux, targetSession, targetPane, restartCmd string) error {
 	// Set remain-on-exit so the pane survives process death during handoff.
 	// Without this, killing processes causes tmux to destroy the pane before
 	// we can respawn it. This is essential for tmux session reuse.
@@ -1152,17 +1197,6 @@ func handoffRemoteSession(t *tmux.Tmux, targetSession, restartCmd string) error
 	if respawnErr != nil {
 		return fmt.Errorf("respawning pane: %w", respawnErr)
 	}
-
-	// If --watch, switch to that session
-	if handoffWatch {
-		fmt.Printf("Switching to %s...\n", targetSession)
-		// Use tmux switch-client to move our view to the target session
-		if err := tmux.BuildCommand("switch-client", "-t", targetSession).Run(); err != nil {
-			// Non-fatal - they can manually switch
-			fmt.Printf("Note: Could not auto-switch (use: tmux switch-client -t %s)\n", targetSession)
-		}
-	}
-
 	return nil
 }
 
@@ -1210,6 +1244,13 @@ func sendHandoffMail(subject, message string) (string, error) {
 		return "", fmt.Errorf("cannot detect town root")
 	}
 
+	return createHookedMail(townRoot, agentID, subject, message)
+}
+
+// createHookedMail creates a high-priority mail bead from agentID to itself
+// and hooks it, so the agent's next session picks it up via gt prime.
+// agentID must be in normalized mail identity form. Returns the bead ID.
+func createHookedMail(townRoot, agentID, subject, message string) (string, error) {
 	// Build labels for mail metadata (matches mail router format)
 	labels := fmt.Sprintf("from:%s", agentID)
 
@@ -1556,7 +1597,7 @@ func cleanupMoleculeOnHandoff() {
 
 	// Close descendant steps (the leaked wisps)
 	if n := closeDescendants(b, molID); n > 0 {
-		fmt.Fprintf(os.Stderr, "handoff: closed %d molecule step(s) for %s\n", n, molID)
+		logger.Info("handoff: closed molecule steps", "molecule", molID, "count", n)
 	}
 
 	// Detach molecule with audit trail
@@ -1564,7 +1605,7 @@ func cleanupMoleculeOnHandoff() {
 		Operation: "squash",
 		Reason:    "handoff: session cycling",
 	}); err != nil {
-		fmt.Fprintf(os.Stderr, "handoff: warning: detach molecule audit failed: %v\n", err)
+		logger.Warn("handoff: detach molecule audit failed", "molecule", molID, "err", err)
 	}
 
 	// Close all descendant wisps first, then the molecule root.
@@ -1576,7 +1617,7 @@ func cleanupMoleculeOnHandoff() {
 
 	// Force-close the molecule root wisp
 	if err := b.ForceCloseWithReason("handoff", molID); err != nil {
-		fmt.Fprintf(os.Stderr, "handoff: warning: couldn't close molecule %s: %v\n", molID, err)
+		logger.Warn("handoff: couldn't close molecule", "molecule", molID, "err", err)
 	}
 }
 
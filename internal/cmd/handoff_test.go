@@ -4,6 +4,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -859,3 +860,109 @@ func TestEnforceHandoffCooldown(t *testing.T) {
 		}
 	})
 }
+
+func TestHandoffToRequiresBead(t *testing.T) {
+	origTo := handoffTo
+	defer func() { handoffTo = origTo }()
+	handoffTo = "gastown/crew/max"
+
+	for _, args := range [][]string{nil, {"crew"}, {"gt-abc", "extra"}} {
+		err := runHandoff(handoffCmd, args)
+		if err == nil || !strings.Contains(err.Error(), "--to needs a bead ID") {
+			t.Errorf("runHandoff(%v) with --to = %v, want bead ID error", args, err)
+		}
+	}
+}
+
+func TestHandoffNoteString(t *testing.T) {
+	note := handoffNote{
+		BeadID:      "gt-abc",
+		Title:       "Fix login",
+		From:        "gastown/polecats/nux",
+		To:          "gastown/crew/max",
+		Branch:      "polecat/nux/gt-abc",
+		Changed:     []string{"auth/login.go", "auth/login_test.go"},
+		Uncommitted: []string{"auth/session.go"},
+		Unpushed:    2,
+		NextSteps:   "Finish the session expiry test\nThen run the full suite",
+	}
+	got := note.String()
+	for _, want := range []string{
+		"🤝 HANDOFF: gastown/polecats/nux → gastown/crew/max",
+		"Bead: gt-abc (Fix login)",
+		"Branch: polecat/nux/gt-abc (2 unpushed commit(s))",
+		"Changed files:\n  auth/login.go\n  auth/login_test.go\n",
+		"Uncommitted:\n  auth/session.go\n",
+		"Next steps:\nFinish the session expiry test\nThen run the full suite",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("note missing %q:\n%s", want, got)
+		}
+	}
+
+	var many []string
+	for i := 0; i < maxHandoffNoteFiles+3; i++ {
+		many = append(many, "f"+strconv.Itoa(i)+".go")
+	}
+	got = handoffNote{BeadID: "gt-abc", From: "a", To: "b", Changed: many}.String()
+	if !strings.Contains(got, "... (+3 more)") {
+		t.Errorf("long file list not truncated:\n%s", got)
+	}
+	if !strings.Contains(got, "Next steps:\n(none given)") {
+		t.Errorf("missing empty next steps placeholder:\n%s", got)
+	}
+}
+
+func TestPushHandoffBranch(t *testing.T) {
+	tmp := t.TempDir()
+	remote := filepath.Join(tmp, "remote.git")
+	clone := filepath.Join(tmp, "clone")
+	run := func(dir string, args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+	run(tmp, "init", "--bare", "-b", "main", remote)
+	run(tmp, "clone", remote, clone)
+	run(clone, "config", "user.email", "test@test.com")
+	run(clone, "config", "user.name", "Test")
+	run(clone, "commit", "--allow-empty", "-m", "initial")
+	run(clone, "push", "origin", "main")
+	run(clone, "checkout", "-b", "polecat/nux/gt-abc")
+	run(clone, "commit", "--allow-empty", "-m", "work")
+
+	note := handoffNote{Branch: "polecat/nux/gt-abc", Unpushed: 1}
+	if err := pushHandoffBranch(&note, clone); err != nil {
+		t.Fatalf("pushHandoffBranch: %v", err)
+	}
+	if !note.Pushed || note.Unpushed != 0 {
+		t.Errorf("note = %+v, want pushed with nothing unpushed", note)
+	}
+	if got, want := run(remote, "rev-parse", "polecat/nux/gt-abc"), run(clone, "rev-parse", "HEAD"); got != want {
+		t.Errorf("remote branch at %s, want %s", got, want)
+	}
+	if !strings.Contains(note.String(), "Branch: polecat/nux/gt-abc (pushed to origin)") {
+		t.Errorf("note doesn't say the branch was pushed:\n%s", note)
+	}
+
+	// The default branch isn't pushed by a handoff.
+	run(clone, "checkout", "main")
+	run(clone, "commit", "--allow-empty", "-m", "local only")
+	note = handoffNote{Branch: "main", Unpushed: 1}
+	if err := pushHandoffBranch(&note, clone); err != nil || note.Pushed {
+		t.Errorf("pushHandoffBranch(main) = %v, pushed %v; want it left alone", err, note.Pushed)
+	}
+
+	// A failed push is reported, so the bead isn't handed off.
+	run(clone, "remote", "set-url", "origin", filepath.Join(tmp, "missing.git"))
+	run(clone, "checkout", "polecat/nux/gt-abc")
+	note = handoffNote{Branch: "polecat/nux/gt-abc"}
+	if err := pushHandoffBranch(&note, clone); err == nil || note.Pushed {
+		t.Errorf("pushHandoffBranch to a missing remote = %v, pushed %v; want an error", err, note.Pushed)
+	}
+}
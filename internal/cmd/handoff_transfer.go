@@ -0,0 +1,260 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/workspace"
+	"golang.org/x/term"
+)
+
+// maxHandoffNoteFiles caps each file list in a handoff note.
+const maxHandoffNoteFiles = 20
+
+// handoffNote is the structured note left on a bead when it is handed to
+// another agent with gt handoff <bead> --to <agent>.
+type handoffNote struct {
+	BeadID      string
+	Title       string
+	From        string
+	To          string
+	Branch      string
+	Changed     []string // Files changed on the branch since it left the default branch
+	Uncommitted []string // Modified and untracked files in the working tree
+	Unpushed    int
+	Pushed      bool // Branch was pushed to origin for the receiver to fetch
+	NextSteps   string
+}
+
+// String renders the note as the bead comment the receiver reads.
+func (n handoffNote) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "🤝 HANDOFF: %s → %s\n", n.From, n.To)
+	fmt.Fprintf(&b, "Bead: %s", n.BeadID)
+	if n.Title != "" {
+		fmt.Fprintf(&b, " (%s)", n.Title)
+	}
+	b.WriteString("\n")
+	if n.Branch != "" {
+		fmt.Fprintf(&b, "Branch: %s", n.Branch)
+		if n.Pushed {
+			b.WriteString(" (pushed to origin)")
+		} else if n.Unpushed > 0 {
+			fmt.Fprintf(&b, " (%d unpushed commit(s))", n.Unpushed)
+		}
+		b.WriteString("\n")
+	}
+	writeHandoffFiles(&b, "Changed files", n.Changed)
+	writeHandoffFiles(&b, "Uncommitted", n.Uncommitted)
+	b.WriteString("\nNext steps:\n")
+	if n.NextSteps != "" {
+		b.WriteString(n.NextSteps)
+	} else {
+		b.WriteString("(none given)")
+	}
+	return b.String()
+}
+
+func writeHandoffFiles(b *strings.Builder, label string, files []string) {
+	if len(files) == 0 {
+		return
+	}
+	shown := files
+	if len(shown) > maxHandoffNoteFiles {
+		shown = shown[:maxHandoffNoteFiles]
+	}
+	fmt.Fprintf(b, "%s:\n", label)
+	for _, f := range shown {
+		fmt.Fprintf(b, "  %s\n", f)
+	}
+	if len(files) > len(shown) {
+		fmt.Fprintf(b, "  ... (+%d more)\n", len(files)-len(shown))
+	}
+}
+
+// collectHandoffGitState fills the note's branch and file lists from the
+// git repo in dir. Leaves them empty outside a repo.
+func collectHandoffGitState(n *handoffNote, dir string) {
+	g := git.NewGit(dir)
+	if !g.IsRepo() {
+		return
+	}
+	if branch, err := g.CurrentBranch(); err == nil {
+		n.Branch = branch
+	}
+	if base := g.RemoteDefaultBranch(); base != "" && n.Branch != "" && n.Branch != base {
+		if files, err := g.ChangedFiles("origin/"+base, "HEAD"); err == nil {
+			n.Changed = files
+		}
+	}
+	if work, err := g.CheckUncommittedWork(); err == nil {
+		n.Uncommitted = append(append([]string(nil), work.ModifiedFiles...), work.UntrackedFiles...)
+		n.Unpushed = work.UnpushedCommits
+	}
+}
+
+// pushHandoffBranch pushes the note's branch to origin so the receiving
+// agent, working in its own clone, can fetch it. The default branch is left
+// alone: work handed off there goes through the usual merge flow.
+func pushHandoffBranch(n *handoffNote, dir string) error {
+	g := git.NewGit(dir)
+	if n.Branch == "" || !g.IsRepo() || n.Branch == g.RemoteDefaultBranch() {
+		return nil
+	}
+	if err := g.Push("origin", n.Branch, false); err != nil {
+		return fmt.Errorf("pushing %s: %w", n.Branch, err)
+	}
+	n.Pushed = true
+	n.Unpushed = 0
+	return nil
+}
+
+// promptHandoffNextSteps asks for the next steps on a terminal, reading lines
+// until an empty one. Returns "" when stdin isn't a terminal.
+func promptHandoffNextSteps(to string) string {
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return ""
+	}
+	fmt.Printf("Next steps for %s (end with an empty line):\n", to)
+	var lines []string
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \t")
+		if line == "" {
+			break
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// runHandoffTransfer moves a bead from the caller's hook to another agent's:
+// it writes a handoff note on the bead, hooks the bead to the target, and
+// nudges the target to pick it up. The caller's session is left running.
+func runHandoffTransfer(beadID, to string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	fromID, _, _, err := resolveSelfTarget()
+	if err != nil {
+		return fmt.Errorf("detecting agent identity: %w", err)
+	}
+
+	info, err := getBeadInfo(beadID)
+	if err != nil {
+		return err
+	}
+	if info.Status == "closed" {
+		return fmt.Errorf("bead %s is closed", beadID)
+	}
+	if info.Assignee == "" || mail.AddressToIdentity(info.Assignee) != mail.AddressToIdentity(fromID) {
+		holder := info.Assignee
+		if holder == "" {
+			holder = "nobody"
+		}
+		return fmt.Errorf("bead %s is not on your hook (held by %s)\n  Use 'gt sling %s %s' to assign unowned work", beadID, holder, beadID, to)
+	}
+
+	targetID, pane, targetWorkDir, err := resolveTargetAgentFn(to)
+	if err != nil {
+		return fmt.Errorf("resolving target agent: %w", err)
+	}
+	if mail.AddressToIdentity(targetID) == mail.AddressToIdentity(fromID) {
+		return fmt.Errorf("cannot hand %s off to yourself", beadID)
+	}
+
+	note := handoffNote{
+		BeadID:    beadID,
+		Title:     info.Title,
+		From:      fromID,
+		To:        targetID,
+		NextSteps: handoffMessage,
+	}
+	cwd, _ := os.Getwd()
+	if cwd != "" {
+		collectHandoffGitState(&note, cwd)
+	}
+	if note.NextSteps == "" && !handoffDryRun {
+		note.NextSteps = promptHandoffNextSteps(targetID)
+	}
+
+	if handoffDryRun {
+		fmt.Printf("Would hand %s from %s to %s\n", beadID, fromID, targetID)
+		if note.Branch != "" {
+			fmt.Printf("Would push %s to origin\n", note.Branch)
+		}
+		fmt.Printf("Would comment on %s:\n%s\n", beadID, note)
+		fmt.Printf("Would nudge %s\n", targetID)
+		return nil
+	}
+
+	// Push before moving the hook: a branch only in our clone is work the
+	// target can't pick up. On failure the bead stays with us.
+	if cwd != "" {
+		if err := pushHandoffBranch(&note, cwd); err != nil {
+			return fmt.Errorf("%w\n  Push the branch yourself, then retry the handoff", err)
+		}
+		if note.Pushed {
+			fmt.Printf("%s Pushed %s to origin\n", style.Bold.Render("✓"), note.Branch)
+		}
+	}
+	if len(note.Uncommitted) > 0 {
+		style.PrintWarning("%d uncommitted file(s) stay in your working tree; commit and push them for %s to see them", len(note.Uncommitted), targetID)
+	}
+
+	// Reassigning the hook detaches the bead from us and attaches it to the
+	// target in one write, so it is never left unowned for the dispatcher
+	// to pick up in between.
+	fmt.Printf("%s Handing %s to %s...\n", style.Bold.Render("🤝"), beadID, targetID)
+	hookDir := beads.ResolveHookDir(townRoot, beadID, targetWorkDir)
+	if err := hookBeadWithRetry(beadID, targetID, hookDir); err != nil {
+		return err
+	}
+	fmt.Printf("%s Work moved to %s's hook\n", style.Bold.Render("✓"), targetID)
+
+	if err := BdCmd("comment", beadID, note.String()).
+		Dir(resolveBeadDir(beadID)).
+		StripBeadsDir().
+		WithAutoCommit().
+		Run(); err != nil {
+		style.PrintWarning("could not write handoff note on %s: %v", beadID, err)
+	} else {
+		fmt.Printf("%s Handoff note written to %s\n", style.Bold.Render("📝"), beadID)
+	}
+
+	subject := fmt.Sprintf("%s → %s", beadID, targetID)
+	_ = LogHandoff(townRoot, fromID, subject)
+	payload := events.HandoffPayload(subject, false)
+	payload["bead"] = beadID
+	payload["to"] = targetID
+	_ = events.LogFeed(events.TypeHandoff, fromID, payload)
+
+	if err := nudgeHandoffTarget(pane, fromID, beadID, info.Title); err != nil {
+		style.PrintWarning("could not nudge %s: %v", targetID, err)
+	} else {
+		fmt.Printf("%s Nudged %s\n", style.Bold.Render("📣"), targetID)
+	}
+	return nil
+}
+
+// nudgeHandoffTarget tells the receiving agent about the handoff.
+func nudgeHandoffTarget(pane, from, beadID, title string) error {
+	if pane == "" {
+		return fmt.Errorf("no target pane")
+	}
+	if os.Getenv("GT_TEST_NO_NUDGE") != "" {
+		return nil
+	}
+	prompt := fmt.Sprintf("Handoff from %s: %s (%s) is now on your hook. Read the handoff note with `bd comments %s`, then continue the work.", from, beadID, title, beadID)
+	return tmux.NewTmux().NudgePane(pane, prompt)
+}
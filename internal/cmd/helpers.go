@@ -81,21 +81,34 @@ func isInSameTmuxSocket() bool {
 
 // attachToTmuxSession attaches to a tmux session.
 // If already inside tmux, uses switch-client instead of attach-session.
+// Sessions on a remote rig host are attached with 'ssh -t host tmux attach'.
 // Uses syscall.Exec to replace the Go process with tmux for direct terminal
 // control, and passes -u for UTF-8 support regardless of locale settings.
 // See: https://github.com/steveyegge/gastown/issues/1219
 func attachToTmuxSession(sessionID string) error {
-	tmuxPath, err := exec.LookPath("tmux")
-	if err != nil {
-		return fmt.Errorf("tmux not found: %w", err)
-	}
-
 	// Base args with UTF-8 and socket support
 	baseArgs := []string{"tmux", "-u"}
 	if socket := tmux.GetDefaultSocket(); socket != "" {
 		baseArgs = append(baseArgs, "-L", socket)
 	}
 
+	if host := tmux.HostForSession(sessionID); host != "" {
+		sshPath, err := exec.LookPath("ssh")
+		if err != nil {
+			return fmt.Errorf("ssh not found: %w", err)
+		}
+		remote := make([]string, 0, len(baseArgs)+3)
+		for _, a := range append(baseArgs, "attach-session", "-t", sessionID) {
+			remote = append(remote, config.ShellQuote(a))
+		}
+		return syscall.Exec(sshPath, []string{"ssh", "-t", "--", host, strings.Join(remote, " ")}, os.Environ())
+	}
+
+	tmuxPath, err := exec.LookPath("tmux")
+	if err != nil {
+		return fmt.Errorf("tmux not found: %w", err)
+	}
+
 	var args []string
 	if isInSameTmuxSocket() {
 		// Same tmux socket: switch to the target session
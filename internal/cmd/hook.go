@@ -45,7 +45,8 @@ Examples:
 Related commands:
   gt sling <bead>    # Hook + start now (keep context)
   gt handoff <bead>  # Hook + restart (fresh context)
-  gt unsling         # Remove work from hook`,
+  gt unsling         # Remove work from hook
+  gt hook history    # Past assignments on a hook`,
 	Args: cobra.MaximumNArgs(2),
 	RunE: runHookOrStatus,
 }
@@ -344,6 +345,9 @@ func runHook(_ *cobra.Command, args []string) error {
 			return fmt.Errorf("existing hooked bead %s is incomplete (%s)\n  Use --force to replace, or complete the existing work first",
 				existing.ID, existing.Title)
 		}
+		if !hookDryRun {
+			logHookReplaced(agentID, existing.ID)
+		}
 	}
 
 	if targetAgent != "" {
@@ -406,14 +410,26 @@ func runHook(_ *cobra.Command, args []string) error {
 		fmt.Printf("  Use 'gt hook' to see hook status\n")
 	}
 
-	// Log hook event to activity feed (non-fatal)
-	if err := events.LogFeed(events.TypeHook, agentID, events.HookPayload(beadID)); err != nil {
-		fmt.Fprintf(os.Stderr, "%s Warning: failed to log hook event: %v\n", style.Dim.Render("⚠"), err)
+	// Log hook event to activity feed (non-fatal). The payload records who
+	// did the hooking so gt hook history can attribute remote dispatch.
+	payload := events.HookPayload(beadID)
+	payload["by"] = detectActor()
+	if err := events.LogFeed(events.TypeHook, agentID, payload); err != nil {
+		logger.Warn("failed to log hook event", "err", err)
 	}
 
 	return nil
 }
 
+// logHookReplaced records that beadID left agentID's hook because new work
+// replaced it, closing its assignment in gt hook history.
+func logHookReplaced(agentID, beadID string) {
+	payload := events.UnhookPayload(beadID)
+	payload["by"] = detectActor()
+	payload["reason"] = "replaced"
+	_ = events.LogFeed(events.TypeUnhook, agentID, payload)
+}
+
 // checkPinnedBeadComplete checks if a pinned bead's attached molecule is 100% complete.
 // Returns (isComplete, hasAttachment):
 // - isComplete=true if no molecule attached OR all molecule steps are closed
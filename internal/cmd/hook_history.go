@@ -0,0 +1,258 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var (
+	hookHistorySince string
+	hookHistoryLimit int
+	hookHistoryJSON  bool
+)
+
+var hookHistoryCmd = &cobra.Command{
+	Use:   "history [agent]",
+	Short: "Show the history of work pinned to an agent's hook",
+	Long: `Show every assignment that has been on an agent's hook: what was pinned,
+when, by whom, how it left the hook, and how long it stayed.
+
+History is rebuilt from hook, sling, unhook, and done events in the town's
+event log (.events.jsonl). Assignments are listed newest first.
+
+Examples:
+  gt hook history                        # My hook
+  gt hook history gastown/nux            # A polecat's hook
+  gt hook history mayor --since 7d       # Last week of mayor assignments`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runHookHistory,
+}
+
+func init() {
+	hookHistoryCmd.Flags().StringVar(&hookHistorySince, "since", "", "Only show assignments started since (e.g., 24h, 7d)")
+	hookHistoryCmd.Flags().IntVarP(&hookHistoryLimit, "limit", "n", 20, "Maximum number of assignments to show")
+	hookHistoryCmd.Flags().BoolVar(&hookHistoryJSON, "json", false, "Output as JSON")
+	hookCmd.AddCommand(hookHistoryCmd)
+}
+
+// HookAssignment is one stint of a bead on an agent's hook.
+type HookAssignment struct {
+	Bead       string     `json:"bead"`
+	Agent      string     `json:"agent"`
+	AttachedAt time.Time  `json:"attached_at"`
+	AttachedBy string     `json:"attached_by"`
+	Via        string     `json:"via"` // hook or sling
+	DetachedAt *time.Time `json:"detached_at,omitempty"`
+	DetachedBy string     `json:"detached_by,omitempty"`
+	Outcome    string     `json:"outcome,omitempty"` // unhooked, done, replaced
+}
+
+// Duration returns how long the bead was hooked, up to now if it still is.
+func (a HookAssignment) Duration(now time.Time) time.Duration {
+	if a.DetachedAt != nil {
+		return a.DetachedAt.Sub(a.AttachedAt)
+	}
+	return now.Sub(a.AttachedAt)
+}
+
+func runHookHistory(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	var agentID string
+	if len(args) > 0 {
+		agentID = args[0]
+	} else if agentID, _, _, err = resolveSelfTarget(); err != nil {
+		return fmt.Errorf("detecting agent identity: %w", err)
+	}
+
+	var since time.Time
+	if hookHistorySince != "" {
+		d, err := parseDuration(hookHistorySince)
+		if err != nil {
+			return fmt.Errorf("invalid --since value: %w", err)
+		}
+		since = time.Now().Add(-d)
+	}
+
+	evts, err := readEventsFile(filepath.Join(townRoot, events.EventsFile))
+	if err != nil {
+		return err
+	}
+	agent := canonicalHookAgent(agentID)
+	assignments := buildHookAssignments(evts, agent)
+
+	// Newest first, filtered and limited.
+	var shown []HookAssignment
+	for i := len(assignments) - 1; i >= 0 && len(shown) < hookHistoryLimit; i-- {
+		if !since.IsZero() && assignments[i].AttachedAt.Before(since) {
+			break
+		}
+		shown = append(shown, assignments[i])
+	}
+
+	if hookHistoryJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if shown == nil {
+			shown = []HookAssignment{}
+		}
+		return enc.Encode(shown)
+	}
+
+	if len(shown) == 0 {
+		fmt.Printf("%s No hook history for %s\n", style.Dim.Render("ℹ"), agent)
+		return nil
+	}
+
+	now := time.Now()
+	fmt.Printf("%s\n\n", style.Bold.Render("Hook history: "+agent))
+	for _, a := range shown {
+		verb := "hooked"
+		if a.Via == events.TypeSling {
+			verb = "slung"
+		}
+		fmt.Printf("%s  %s  %s by %s\n",
+			style.Dim.Render(a.AttachedAt.Local().Format("2006-01-02 15:04")),
+			style.Bold.Render(a.Bead), verb, a.AttachedBy)
+		if a.DetachedAt == nil {
+			fmt.Printf("    %s\n", style.Success.Render(fmt.Sprintf("still hooked (%s)", formatDuration(a.Duration(now)))))
+			continue
+		}
+		end := a.Outcome
+		if a.DetachedBy != "" && a.DetachedBy != agent {
+			end += " by " + a.DetachedBy
+		}
+		fmt.Printf("    %s\n", style.Dim.Render(fmt.Sprintf("%s at %s after %s",
+			end, a.DetachedAt.Local().Format("2006-01-02 15:04"), formatDuration(a.Duration(now)))))
+	}
+	return nil
+}
+
+// readEventsFile returns every parseable event in the raw events log, oldest
+// first. A missing log yields no events.
+func readEventsFile(path string) ([]events.Event, error) {
+	f, err := os.Open(path) //nolint:gosec // G304: path is constructed from trusted townRoot
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading events file: %w", err)
+	}
+	defer f.Close()
+
+	var evts []events.Event
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var e events.Event
+		if json.Unmarshal(scanner.Bytes(), &e) == nil {
+			evts = append(evts, e)
+		}
+	}
+	return evts, scanner.Err()
+}
+
+// canonicalHookAgent normalizes an agent address so the forms used by
+// different commands compare equal ("gastown/nux", "gastown/polecats/nux",
+// "mayor/" and "mayor").
+func canonicalHookAgent(addr string) string {
+	addr = strings.TrimSpace(addr)
+	if id, err := session.ParseAddress(addr); err == nil {
+		if a := id.Address(); a != "" {
+			return a
+		}
+	}
+	return strings.TrimSuffix(addr, "/")
+}
+
+// buildHookAssignments pairs the hook/sling events that pin work to agent
+// with the unhook/done events that remove it, oldest first. Work attached
+// while another bead is still open supersedes it.
+func buildHookAssignments(evts []events.Event, agent string) []HookAssignment {
+	var out []HookAssignment
+	open := -1 // Index in out of the assignment still on the hook
+
+	closeOpen := func(at time.Time, by, outcome string) {
+		if open < 0 {
+			return
+		}
+		out[open].DetachedAt = &at
+		out[open].DetachedBy = by
+		out[open].Outcome = outcome
+		open = -1
+	}
+
+	for _, e := range evts {
+		ts, err := time.Parse(time.RFC3339, e.Timestamp)
+		if err != nil {
+			continue
+		}
+		bead := payloadString(e.Payload, "bead")
+		actor := canonicalHookAgent(e.Actor)
+
+		switch e.Type {
+		case events.TypeHook, events.TypeSling:
+			target, by := actor, payloadString(e.Payload, "by")
+			if e.Type == events.TypeSling {
+				target, by = canonicalHookAgent(payloadString(e.Payload, "target")), actor
+			}
+			if target != agent || bead == "" {
+				continue
+			}
+			if open >= 0 {
+				if out[open].Bead == bead {
+					continue // Re-hook of the same work
+				}
+				closeOpen(ts, by, "replaced")
+			}
+			if by == "" {
+				by = target
+			}
+			out = append(out, HookAssignment{Bead: bead, Agent: agent, AttachedAt: ts, AttachedBy: canonicalHookAgent(by), Via: e.Type})
+			open = len(out) - 1
+
+		case events.TypeUnhook, events.TypeDone:
+			if actor != agent || open < 0 {
+				continue
+			}
+			if bead != "" && out[open].Bead != bead {
+				continue
+			}
+			outcome := "unhooked"
+			if e.Type == events.TypeDone {
+				outcome = "done"
+			} else if reason := payloadString(e.Payload, "reason"); reason != "" {
+				outcome = reason
+			}
+			by := payloadString(e.Payload, "by")
+			if by == "" {
+				by = actor
+			}
+			closeOpen(ts, canonicalHookAgent(by), outcome)
+		}
+	}
+	return out
+}
+
+// payloadString returns a payload field as a trimmed string.
+func payloadString(payload map[string]interface{}, key string) string {
+	v, ok := payload[key]
+	if !ok || v == nil {
+		return ""
+	}
+	return strings.TrimSpace(fmt.Sprint(v))
+}
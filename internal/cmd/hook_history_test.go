@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/events"
+)
+
+func TestBuildHookAssignments(t *testing.T) {
+	base := time.Date(2026, time.January, 2, 9, 0, 0, 0, time.UTC)
+	at := func(h int) string { return base.Add(time.Duration(h) * time.Hour).Format(time.RFC3339) }
+
+	evts := []events.Event{
+		// Slung to nux by the mayor, finished with gt done.
+		{Timestamp: at(0), Type: events.TypeSling, Actor: "mayor", Payload: map[string]interface{}{"bead": "gt-1", "target": "gastown/nux"}},
+		{Timestamp: at(2), Type: events.TypeDone, Actor: "gastown/polecats/nux", Payload: map[string]interface{}{"bead": "gt-1"}},
+		// Another agent's work is ignored.
+		{Timestamp: at(3), Type: events.TypeHook, Actor: "gastown/crew/max", Payload: map[string]interface{}{"bead": "gt-9"}},
+		// Hooked by the witness, then replaced.
+		{Timestamp: at(4), Type: events.TypeHook, Actor: "gastown/polecats/nux", Payload: map[string]interface{}{"bead": "gt-2", "by": "gastown/witness"}},
+		{Timestamp: at(5), Type: events.TypeUnhook, Actor: "gastown/polecats/nux", Payload: map[string]interface{}{"bead": "gt-2", "by": "mayor", "reason": "replaced"}},
+		// Hooked (twice; the repeat is ignored) and still on the hook.
+		{Timestamp: at(5), Type: events.TypeHook, Actor: "gastown/polecats/nux", Payload: map[string]interface{}{"bead": "gt-3", "by": "mayor"}},
+		{Timestamp: at(6), Type: events.TypeHook, Actor: "gastown/polecats/nux", Payload: map[string]interface{}{"bead": "gt-3", "by": "mayor"}},
+		// Unhook of a bead that isn't on the hook is ignored.
+		{Timestamp: at(7), Type: events.TypeUnhook, Actor: "gastown/polecats/nux", Payload: map[string]interface{}{"bead": "gt-2"}},
+	}
+
+	got := buildHookAssignments(evts, canonicalHookAgent("gastown/nux"))
+	if len(got) != 3 {
+		t.Fatalf("got %d assignments, want 3: %+v", len(got), got)
+	}
+
+	first := got[0]
+	if first.Bead != "gt-1" || first.Via != events.TypeSling || first.AttachedBy != "mayor" {
+		t.Errorf("first = %+v", first)
+	}
+	if first.Outcome != "done" || first.Duration(time.Now()) != 2*time.Hour {
+		t.Errorf("first outcome %q duration %v, want done after 2h", first.Outcome, first.Duration(time.Now()))
+	}
+
+	second := got[1]
+	if second.AttachedBy != "gastown/witness" || second.Outcome != "replaced" || second.DetachedBy != "mayor" {
+		t.Errorf("second = %+v", second)
+	}
+
+	third := got[2]
+	if third.Bead != "gt-3" || third.DetachedAt != nil {
+		t.Errorf("third = %+v, want gt-3 still hooked", third)
+	}
+	now := base.Add(10 * time.Hour)
+	if d := third.Duration(now); d != 5*time.Hour {
+		t.Errorf("open duration = %v, want 5h", d)
+	}
+}
+
+func TestBuildHookAssignmentsSupersede(t *testing.T) {
+	base := time.Date(2026, time.January, 2, 9, 0, 0, 0, time.UTC)
+	evts := []events.Event{
+		{Timestamp: base.Format(time.RFC3339), Type: events.TypeHook, Actor: "mayor/", Payload: map[string]interface{}{"bead": "hq-1"}},
+		{Timestamp: base.Add(time.Hour).Format(time.RFC3339), Type: events.TypeSling, Actor: "deacon", Payload: map[string]interface{}{"bead": "hq-2", "target": "mayor"}},
+	}
+
+	got := buildHookAssignments(evts, canonicalHookAgent("mayor"))
+	if len(got) != 2 {
+		t.Fatalf("got %d assignments, want 2", len(got))
+	}
+	if got[0].AttachedBy != "mayor" {
+		t.Errorf("self-hook attributed to %q, want mayor", got[0].AttachedBy)
+	}
+	if got[0].Outcome != "replaced" || got[0].DetachedBy != "deacon" {
+		t.Errorf("superseded = %+v", got[0])
+	}
+}
+
+func TestReadEventsFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), events.EventsFile)
+	if evts, err := readEventsFile(path); err != nil || evts != nil {
+		t.Fatalf("missing file = %v, %v; want nil, nil", evts, err)
+	}
+
+	writeTrailEventsFile(t, path, []events.Event{
+		{Timestamp: "2026-01-02T09:00:00Z", Type: events.TypeHook, Actor: "mayor"},
+		{Timestamp: "2026-01-02T10:00:00Z", Type: events.TypeUnhook, Actor: "mayor"},
+	})
+	evts, err := readEventsFile(path)
+	if err != nil {
+		t.Fatalf("readEventsFile: %v", err)
+	}
+	if len(evts) != 2 || evts[1].Type != events.TypeUnhook {
+		t.Errorf("events = %+v", evts)
+	}
+}
@@ -0,0 +1,218 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/incident"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var (
+	incidentListGrep     string
+	incidentListKind     string
+	incidentListBead     string
+	incidentListArchived bool
+	incidentListLimit    int
+	incidentListJSON     bool
+)
+
+var incidentCmd = &cobra.Command{
+	Use:     "incident",
+	GroupID: GroupDiag,
+	Short:   "List, search, and manage incident bundles",
+	RunE:    requireSubcommand,
+	Long: `Manage incident bundles: the pane captures saved when an agent is
+found stuck (gt doctor) or is restarted (gt agents restart).
+
+Bundles live under .runtime/diagnostics/ and are indexed by session, bead,
+and reason. Retention is applied each time a bundle is saved and by
+gt incident prune: bundles are compressed after 72h and deleted after 30
+days by default. When a bead is closed with gt close, its incidents are
+archived (moved under diagnostics/archive/).
+
+Configure in settings/config.json:
+
+  "operational": {
+    "incidents": {"compress_after": "24h", "retain_for": "336h", "archive_on_close": true}
+  }`,
+}
+
+var incidentListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List incident bundles",
+	Long: `List incident bundles, newest first.
+
+--grep matches a regular expression against each incident's session, bead,
+and reason, then against the bundle content.
+
+Examples:
+  gt incident list
+  gt incident list --grep "rate limit"
+  gt incident list --bead gt-abc --archived`,
+	Args: cobra.NoArgs,
+	RunE: runIncidentList,
+}
+
+var incidentShowCmd = &cobra.Command{
+	Use:   "show <id>",
+	Short: "Print an incident bundle",
+	Long: `Print an incident bundle, decompressing it if needed.
+
+The id is as shown by gt incident list (e.g., restart/gt-toast-1760000000).`,
+	Args: cobra.ExactArgs(1),
+	RunE: runIncidentShow,
+}
+
+var incidentPruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Apply incident retention now",
+	Args:  cobra.NoArgs,
+	RunE:  runIncidentPrune,
+}
+
+var incidentArchiveCmd = &cobra.Command{
+	Use:   "archive <bead-id>",
+	Short: "Archive a bead's incident bundles",
+	Long: `Move a bead's incident bundles under diagnostics/archive/, compressed.
+
+gt close does this automatically unless operational.incidents.archive_on_close
+is false.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runIncidentArchive,
+}
+
+func init() {
+	incidentListCmd.Flags().StringVar(&incidentListGrep, "grep", "", "Only incidents matching this regular expression")
+	incidentListCmd.Flags().StringVar(&incidentListKind, "kind", "", "Only incidents of this kind (stuck, restart)")
+	incidentListCmd.Flags().StringVar(&incidentListBead, "bead", "", "Only incidents for this bead")
+	incidentListCmd.Flags().BoolVar(&incidentListArchived, "archived", false, "Include archived incidents")
+	incidentListCmd.Flags().IntVarP(&incidentListLimit, "limit", "n", 0, "Show at most this many incidents (0 = all)")
+	incidentListCmd.Flags().BoolVar(&incidentListJSON, "json", false, "Output as JSON")
+
+	incidentCmd.AddCommand(incidentListCmd)
+	incidentCmd.AddCommand(incidentShowCmd)
+	incidentCmd.AddCommand(incidentPruneCmd)
+	incidentCmd.AddCommand(incidentArchiveCmd)
+	rootCmd.AddCommand(incidentCmd)
+}
+
+func runIncidentList(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	var re *regexp.Regexp
+	if incidentListGrep != "" {
+		if re, err = regexp.Compile(incidentListGrep); err != nil {
+			return fmt.Errorf("invalid --grep pattern: %w", err)
+		}
+	}
+
+	all, err := incident.List(townRoot)
+	if err != nil {
+		return err
+	}
+	var matched []incident.Incident
+	for _, inc := range all {
+		if inc.Archived && !incidentListArchived {
+			continue
+		}
+		if incidentListKind != "" && inc.Kind != incidentListKind {
+			continue
+		}
+		if incidentListBead != "" && inc.Bead != incidentListBead {
+			continue
+		}
+		if re != nil && !incident.Matches(townRoot, inc, re) {
+			continue
+		}
+		matched = append(matched, inc)
+		if incidentListLimit > 0 && len(matched) >= incidentListLimit {
+			break
+		}
+	}
+
+	if incidentListJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(matched)
+	}
+
+	if len(matched) == 0 {
+		fmt.Println("No incidents")
+		return nil
+	}
+	for _, inc := range matched {
+		line := fmt.Sprintf("%s  %s", inc.At.Format("2006-01-02 15:04"), style.Bold.Render(inc.ID))
+		if inc.Bead != "" {
+			line += "  " + inc.Bead
+		}
+		if inc.Reason != "" {
+			line += "  " + style.Dim.Render(inc.Reason)
+		}
+		if inc.Archived {
+			line += "  " + style.Dim.Render("(archived)")
+		}
+		fmt.Println(line)
+	}
+	return nil
+}
+
+func runIncidentShow(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	all, err := incident.List(townRoot)
+	if err != nil {
+		return err
+	}
+	for _, inc := range all {
+		if inc.ID != args[0] {
+			continue
+		}
+		content, err := incident.Read(townRoot, inc)
+		if err != nil {
+			return fmt.Errorf("reading incident %s: %w", inc.ID, err)
+		}
+		fmt.Print(content)
+		return nil
+	}
+	return fmt.Errorf("incident %q not found (see gt incident list)", args[0])
+}
+
+func runIncidentPrune(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	res, err := incident.ApplyRetention(townRoot, time.Now())
+	if err != nil {
+		return fmt.Errorf("pruning incidents: %w", err)
+	}
+	fmt.Printf("%s Compressed %d, deleted %d incident bundle(s)\n", style.SuccessPrefix, res.Compressed, res.Deleted)
+	return nil
+}
+
+func runIncidentArchive(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	n, err := incident.Archive(townRoot, args[0])
+	if err != nil {
+		return fmt.Errorf("archiving incidents for %s: %w", args[0], err)
+	}
+	fmt.Printf("%s Archived %d incident bundle(s) for %s\n", style.SuccessPrefix, n, args[0])
+	return nil
+}
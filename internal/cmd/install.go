@@ -481,6 +481,9 @@ func runInstall(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Register the town so 'gt town use' and --town can find it by name.
+	registerTown(absPath, townName)
+
 	fmt.Printf("\n%s HQ created successfully!\n", style.Bold.Render("✓"))
 	fmt.Println()
 	fmt.Println("Next steps:")
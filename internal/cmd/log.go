@@ -1,9 +1,11 @@
 package cmd
 
 import (
+	"bufio"
 	"fmt"
 	"os"
 	"os/exec"
+	"strconv"
 	"strings"
 	"time"
 
@@ -41,6 +43,7 @@ Events logged include:
   done    - agent finished work
   crash   - agent exited unexpectedly
   kill    - agent killed intentionally
+  annotate - operator note (gt annotate)
 
 Examples:
   gt log                     # Show last 20 events
@@ -71,7 +74,7 @@ Examples:
 
 func init() {
 	logCmd.Flags().IntVarP(&logTail, "tail", "n", 20, "Number of events to show")
-	logCmd.Flags().StringVarP(&logType, "type", "t", "", "Filter by event type (spawn,wake,nudge,handoff,done,crash,kill)")
+	logCmd.Flags().StringVarP(&logType, "type", "t", "", "Filter by event type (spawn,wake,nudge,handoff,done,crash,kill,annotate)")
 	logCmd.Flags().StringVarP(&logAgent, "agent", "a", "", "Filter by agent prefix (e.g., gastown/, greenplace/crew/max)")
 	logCmd.Flags().StringVar(&logSince, "since", "", "Show events since duration (e.g., 1h, 30m, 24h)")
 	logCmd.Flags().BoolVarP(&logFollow, "follow", "f", false, "Follow log output (like tail -f)")
@@ -94,9 +97,28 @@ func runLog(cmd *cobra.Command, args []string) error {
 
 	logPath := fmt.Sprintf("%s/logs/town.log", townRoot)
 
-	// If following, use tail -f
+	// Build filter
+	filter := townlog.Filter{}
+
+	if logType != "" {
+		filter.Type = townlog.EventType(logType)
+	}
+
+	if logAgent != "" {
+		filter.Agent = logAgent
+	}
+
+	if logSince != "" {
+		duration, err := time.ParseDuration(logSince)
+		if err != nil {
+			return fmt.Errorf("invalid --since duration: %w", err)
+		}
+		filter.Since = time.Now().Add(-duration)
+	}
+
+	// If following, stream new lines through the same filter and formatting
 	if logFollow {
-		return followLog(logPath)
+		return followLog(logPath, filter)
 	}
 
 	// Check if log file exists
@@ -116,25 +138,6 @@ func runLog(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	// Build filter
-	filter := townlog.Filter{}
-
-	if logType != "" {
-		filter.Type = townlog.EventType(logType)
-	}
-
-	if logAgent != "" {
-		filter.Agent = logAgent
-	}
-
-	if logSince != "" {
-		duration, err := time.ParseDuration(logSince)
-		if err != nil {
-			return fmt.Errorf("invalid --since duration: %w", err)
-		}
-		filter.Since = time.Now().Add(-duration)
-	}
-
 	// Apply filter
 	events = townlog.FilterEvents(events, filter)
 
@@ -156,8 +159,9 @@ func runLog(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-// followLog uses tail -f to follow the log file.
-func followLog(logPath string) error {
+// followLog uses tail -F to follow the log file, printing each line the
+// way gt log does. Lines that don't parse are printed as-is.
+func followLog(logPath string, filter townlog.Filter) error {
 	// Check if log file exists, create empty if not
 	if _, err := os.Stat(logPath); os.IsNotExist(err) {
 		// Create logs directory and empty file
@@ -171,16 +175,37 @@ func followLog(logPath string) error {
 
 	fmt.Printf("%s Following %s (Ctrl+C to stop)\n\n", style.Dim.Render("○"), logPath)
 
-	tailCmd := exec.Command("tail", "-f", logPath)
-	tailCmd.Stdout = os.Stdout
+	tailCmd := exec.Command("tail", "-n", strconv.Itoa(logTail), "-F", logPath)
 	tailCmd.Stderr = os.Stderr
+	stdout, err := tailCmd.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("opening tail output: %w", err)
+	}
+	if err := tailCmd.Start(); err != nil {
+		return fmt.Errorf("starting tail: %w", err)
+	}
+
+	scanner := bufio.NewScanner(stdout)
+	for scanner.Scan() {
+		line := scanner.Text()
+		events, _ := townlog.ParseLogLines(line)
+		if len(events) == 0 {
+			if strings.TrimSpace(line) != "" {
+				fmt.Println(line)
+			}
+			continue
+		}
+		for _, e := range townlog.FilterEvents(events, filter) {
+			printEvent(e)
+		}
+	}
 
-	return tailCmd.Run()
+	return tailCmd.Wait()
 }
 
 // printEvent prints a single event with styling.
 func printEvent(e townlog.Event) {
-	ts := e.Timestamp.Format("2006-01-02 15:04:05")
+	ts := e.Timestamp.Local().Format("2006-01-02 15:04:05")
 
 	// Color-code event types
 	var typeStr string
@@ -211,6 +236,8 @@ func printEvent(e townlog.Event) {
 		typeStr = style.Error.Render("[escalation_sent]")
 	case townlog.EventPatrolComplete:
 		typeStr = style.Success.Render("[patrol_complete]")
+	case townlog.EventAnnotate:
+		typeStr = style.Warning.Render("[annotate]")
 	default:
 		typeStr = fmt.Sprintf("[%s]", e.Type)
 	}
@@ -287,6 +314,8 @@ func formatEventDetail(e townlog.Event) string {
 			return fmt.Sprintf("patrol complete (%s)", e.Context)
 		}
 		return "patrol complete"
+	case townlog.EventAnnotate:
+		return "note: " + e.Context
 	default:
 		if e.Context != "" {
 			return fmt.Sprintf("%s (%s)", e.Type, e.Context)
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var mailACLCmd = &cobra.Command{
+	Use:   "acl [<from> <to>]",
+	Short: "Show who may send mail to whom",
+	Long: `Show the town's mail ACL, or check whether one address may mail another.
+
+The ACL lives in config/messaging.json under "acl". Entries are checked in
+order; the first whose from and to both match allows or denies the
+message, and mail no entry matches is allowed. Patterns are addresses
+with '*' per segment, or role:<role> for every agent of a role (mayor,
+deacon, witness, refinery, crew, polecat, overseer).
+
+  "acl": [
+    {"from": "role:witness", "to": "deacon/", "action": "allow"},
+    {"from": "role:polecat", "to": "deacon/", "action": "deny"},
+    {"from": "role:polecat", "to": "role:polecat", "action": "deny"}
+  ]
+
+The ACL covers direct mail, including each member of a group or list.
+Inside an agent's tmux session, mail is always sent as that agent: a
+GT_ROLE or working directory naming another agent is refused.
+
+Examples:
+  gt mail acl                              # List the ACL
+  gt mail acl gastown/polecats/nux deacon/ # May nux mail the deacon?`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 && len(args) != 2 {
+			return fmt.Errorf("want no arguments, or <from> <to>")
+		}
+		return nil
+	},
+	RunE: runMailACL,
+}
+
+func init() {
+	mailCmd.AddCommand(mailACLCmd)
+}
+
+func runMailACL(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	cfg, err := config.LoadMessagingConfig(config.MessagingConfigPath(townRoot))
+	if err != nil && !errors.Is(err, config.ErrNotFound) {
+		return err
+	}
+	var acl []config.MailACLRule
+	if cfg != nil {
+		acl = cfg.ACL
+	}
+
+	if len(args) == 2 {
+		if err := mail.CheckACL(acl, args[0], args[1], mail.CrewChecker(townRoot)); err != nil {
+			fmt.Printf("%s %v\n", style.ErrorPrefix, err)
+			return NewSilentExit(1)
+		}
+		fmt.Printf("%s %s may mail %s\n", style.SuccessPrefix, args[0], args[1])
+		return nil
+	}
+
+	if len(acl) == 0 {
+		fmt.Println("No mail ACL configured: anyone may mail anyone")
+		return nil
+	}
+	fmt.Printf("%s (first match wins; default allow)\n\n", style.Bold.Render("Mail ACL"))
+	for i, entry := range acl {
+		action := style.Success.Render(entry.Action)
+		if entry.Action == config.MailACLDeny {
+			action = style.Error.Render(entry.Action)
+		}
+		fmt.Printf("  #%d %s %s → %s\n", i+1, action, entry.From, entry.To)
+	}
+	return nil
+}
@@ -0,0 +1,284 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/redact"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// Attachment flags for mail send
+var (
+	mailAttachBeads []string
+	mailAttachFiles []string
+	mailAttachDiffs []string
+)
+
+// Flags for mail open-attachment command
+var (
+	mailOpenAttachmentStdout bool
+	mailOpenAttachmentDir    string
+)
+
+var mailOpenAttachmentCmd = &cobra.Command{
+	Use:   "open-attachment <message-id> <n>",
+	Short: "Write a message attachment to disk",
+	Long: `Write the n-th attachment of a message (numbered as 'gt mail read'
+shows them) to disk, or print it with --stdout.
+
+Attachments are written to .runtime/mail-attachments/<message-id>/ in the
+town unless --dir is given. The file name is built from the attachment's
+number and base name only, so an attachment can't write outside that
+directory, and existing files are never overwritten. Diffs are written as
+.patch files and never applied; review them, then 'git apply' yourself.
+
+Bead attachments have no content to write; use 'bd show <id>'.
+
+Examples:
+  gt mail open-attachment hq-abc123 1
+  gt mail open-attachment hq-abc123 2 --stdout | less
+  gt mail open-attachment hq-abc123 2 --dir /tmp/review`,
+	Args: cobra.ExactArgs(2),
+	RunE: runMailOpenAttachment,
+}
+
+func init() {
+	mailSendCmd.Flags().StringArrayVar(&mailAttachBeads, "attach-bead", nil, "Attach a bead by ID, shown inline on read (repeatable)")
+	mailSendCmd.Flags().StringArrayVar(&mailAttachFiles, "attach-file", nil, "Attach a file within the rig (repeatable)")
+	mailSendCmd.Flags().StringArrayVar(&mailAttachDiffs, "attach-diff", nil, "Attach a git diff of a rev range, or a .patch/.diff file (repeatable)")
+
+	mailOpenAttachmentCmd.Flags().BoolVar(&mailOpenAttachmentStdout, "stdout", false, "Print the attachment instead of writing it")
+	mailOpenAttachmentCmd.Flags().StringVar(&mailOpenAttachmentDir, "dir", "", "Directory to write the attachment to")
+
+	mailCmd.AddCommand(mailOpenAttachmentCmd)
+}
+
+// buildMailAttachments collects the attachments given to mail send. Files
+// are snapshotted now and must be within the rig the sender is in. Secrets
+// in file and diff content are redacted.
+func buildMailAttachments(townRoot string) ([]mail.Attachment, error) {
+	var attachments []mail.Attachment
+	for _, id := range mailAttachBeads {
+		attachments = append(attachments, mail.Attachment{Kind: mail.AttachBead, Ref: id})
+	}
+	if len(mailAttachFiles) > 0 {
+		rigName, err := inferRigFromCwd(townRoot)
+		if err != nil {
+			return nil, fmt.Errorf("--attach-file must be run from within a rig: %w", err)
+		}
+		for _, path := range mailAttachFiles {
+			a, err := fileAttachment(filepath.Join(townRoot, rigName), path)
+			if err != nil {
+				return nil, err
+			}
+			attachments = append(attachments, a)
+		}
+	}
+	for _, spec := range mailAttachDiffs {
+		a, err := diffAttachment(spec)
+		if err != nil {
+			return nil, err
+		}
+		attachments = append(attachments, a)
+	}
+	if err := mail.ValidateAttachments(attachments); err != nil {
+		return nil, err
+	}
+	redactor := redact.ForTown(townRoot)
+	for i := range attachments {
+		attachments[i].Content = redactor.String(attachments[i].Content)
+	}
+	return attachments, nil
+}
+
+// fileAttachment snapshots path, which must resolve (symlinks included) to
+// a regular file inside rigRoot.
+func fileAttachment(rigRoot, path string) (mail.Attachment, error) {
+	resolved, rel, err := resolveWithin(rigRoot, path)
+	if err != nil {
+		return mail.Attachment{}, err
+	}
+	if rel == "" {
+		return mail.Attachment{}, fmt.Errorf("attaching %s: not within the rig (%s)", path, rigRoot)
+	}
+	data, err := readAttachmentFile(path, resolved)
+	if err != nil {
+		return mail.Attachment{}, err
+	}
+	return mail.Attachment{Kind: mail.AttachFile, Ref: filepath.ToSlash(rel), Content: data}, nil
+}
+
+// diffAttachment attaches a patch file if spec names one, otherwise the
+// output of 'git diff <spec>' in the current repo. Patch files must be
+// inside the current worktree, so --attach-diff can't mail out arbitrary
+// files (e.g., ~/.ssh keys).
+func diffAttachment(spec string) (mail.Attachment, error) {
+	if info, err := os.Stat(spec); err == nil && info.Mode().IsRegular() {
+		root, err := getGitRoot()
+		if err != nil {
+			return mail.Attachment{}, fmt.Errorf("attaching %s: patch files must be inside a git worktree", spec)
+		}
+		resolved, rel, err := resolveWithin(root, spec)
+		if err != nil {
+			return mail.Attachment{}, err
+		}
+		if rel == "" {
+			return mail.Attachment{}, fmt.Errorf("attaching %s: not within the worktree (%s)", spec, root)
+		}
+		data, err := readAttachmentFile(spec, resolved)
+		if err != nil {
+			return mail.Attachment{}, err
+		}
+		return mail.Attachment{Kind: mail.AttachDiff, Ref: filepath.Base(spec), Content: data}, nil
+	}
+
+	if spec == "" || strings.HasPrefix(spec, "-") {
+		return mail.Attachment{}, fmt.Errorf("--attach-diff: %q is not a rev range or patch file", spec)
+	}
+	out, err := exec.Command("git", "diff", spec, "--").Output()
+	if err != nil {
+		return mail.Attachment{}, fmt.Errorf("git diff %s: %w", spec, err)
+	}
+	if len(out) == 0 {
+		return mail.Attachment{}, fmt.Errorf("git diff %s is empty", spec)
+	}
+	return mail.Attachment{Kind: mail.AttachDiff, Ref: spec, Content: string(out)}, nil
+}
+
+// resolveWithin resolves path, following symlinks, and returns it with its
+// path relative to root. rel is empty when path is outside root.
+func resolveWithin(root, path string) (resolved, rel string, err error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", "", err
+	}
+	resolved, err = filepath.EvalSymlinks(abs)
+	if err != nil {
+		return "", "", fmt.Errorf("attaching %s: %w", path, err)
+	}
+	realRoot, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		return "", "", fmt.Errorf("resolving %s: %w", root, err)
+	}
+	rel, err = filepath.Rel(realRoot, resolved)
+	if err != nil || !mail.IsRigRelativePath(rel) {
+		return resolved, "", nil
+	}
+	return resolved, rel, nil
+}
+
+// readAttachmentFile reads a regular file within the attachment size limit.
+// path is the name given by the user, for errors.
+func readAttachmentFile(path, resolved string) (string, error) {
+	info, err := os.Stat(resolved)
+	if err != nil {
+		return "", fmt.Errorf("attaching %s: %w", path, err)
+	}
+	if !info.Mode().IsRegular() {
+		return "", fmt.Errorf("attaching %s: not a regular file", path)
+	}
+	if info.Size() > mail.MaxAttachmentSize {
+		return "", fmt.Errorf("%w: %s is %d bytes, limit is %d", mail.ErrAttachmentTooLarge, path, info.Size(), mail.MaxAttachmentSize)
+	}
+	data, err := os.ReadFile(resolved)
+	if err != nil {
+		return "", fmt.Errorf("attaching %s: %w", path, err)
+	}
+	return string(data), nil
+}
+
+// printMailAttachments lists a message's attachments for 'gt mail read',
+// showing referenced beads inline.
+func printMailAttachments(msg *mail.Message) {
+	if len(msg.Attachments) == 0 {
+		return
+	}
+	townRoot, _ := workspace.FindFromCwd()
+
+	fmt.Printf("\n%s\n", style.Bold.Render("Attachments:"))
+	for i, a := range msg.Attachments {
+		switch a.Kind {
+		case mail.AttachBead:
+			fmt.Printf("  %d. bead %s\n", i+1, a.Ref)
+			if townRoot == "" {
+				continue
+			}
+			issue, err := beads.New(townRoot).Show(a.Ref)
+			if err != nil {
+				fmt.Printf("     %s\n", style.Dim.Render("(could not load: "+err.Error()+")"))
+				continue
+			}
+			fmt.Printf("     %s [%s]\n", issue.Title, issue.Status)
+			if desc := strings.TrimSpace(issue.Description); desc != "" {
+				for _, line := range strings.Split(truncateAttachmentPreview(desc, 5), "\n") {
+					fmt.Printf("     %s\n", style.Dim.Render(line))
+				}
+			}
+		default:
+			fmt.Printf("  %d. %s %s %s\n", i+1, a.Kind, a.Ref, style.Dim.Render(fmt.Sprintf("(%d bytes)", len(a.Content))))
+		}
+	}
+	fmt.Printf("\n%s\n", style.Dim.Render(fmt.Sprintf("Open with: gt mail open-attachment %s <n>", msg.ID)))
+}
+
+// truncateAttachmentPreview keeps the first maxLines lines of s.
+func truncateAttachmentPreview(s string, maxLines int) string {
+	lines := strings.Split(s, "\n")
+	if len(lines) <= maxLines {
+		return s
+	}
+	return strings.Join(lines[:maxLines], "\n") + "\n..."
+}
+
+func runMailOpenAttachment(cmd *cobra.Command, args []string) error {
+	msgID := args[0]
+	n, err := strconv.Atoi(args[1])
+	if err != nil || n < 1 {
+		return fmt.Errorf("attachment number must be a positive integer, got %q", args[1])
+	}
+
+	mailbox, err := getMailbox(detectSender())
+	if err != nil {
+		return err
+	}
+	msg, err := mailbox.Get(msgID)
+	if err != nil {
+		return fmt.Errorf("getting message: %w", err)
+	}
+	if n > len(msg.Attachments) {
+		return fmt.Errorf("message %s has %d attachment(s)", msgID, len(msg.Attachments))
+	}
+	a := msg.Attachments[n-1]
+
+	if mailOpenAttachmentStdout {
+		if a.Kind == mail.AttachBead {
+			return fmt.Errorf("bead attachments have no content; use 'bd show %s'", a.Ref)
+		}
+		fmt.Print(a.Content)
+		return nil
+	}
+
+	dir := mailOpenAttachmentDir
+	if dir == "" {
+		townRoot, err := workspace.FindFromCwdOrError()
+		if err != nil {
+			return fmt.Errorf("not in a Gas Town workspace: %w", err)
+		}
+		dir = filepath.Join(townRoot, ".runtime", "mail-attachments", filepath.Base(msg.ID))
+	}
+	path, err := mail.MaterializeAttachment(dir, n, a)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s Wrote %s %s to %s\n", style.Bold.Render("✓"), a.Kind, a.Ref, path)
+	return nil
+}
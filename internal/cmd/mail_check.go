@@ -26,7 +26,7 @@ func runMailCheck(cmd *cobra.Command, args []string) error {
 	workDir, err := findMailWorkDir()
 	if err != nil {
 		if mailCheckInject {
-			fmt.Fprintf(os.Stderr, "gt mail check: workspace lookup failed: %v\n", err)
+			logger.Warn("mail check: workspace lookup failed", "err", err)
 			return nil
 		}
 		return fmt.Errorf("not in a Gas Town workspace: %w", err)
@@ -37,7 +37,7 @@ func runMailCheck(cmd *cobra.Command, args []string) error {
 	mailbox, err := router.GetMailbox(address)
 	if err != nil {
 		if mailCheckInject {
-			fmt.Fprintf(os.Stderr, "gt mail check: mailbox error for %s: %v\n", address, err)
+			logger.Warn("mail check: mailbox error", "address", address, "err", err)
 			return nil
 		}
 		return fmt.Errorf("getting mailbox: %w", err)
@@ -47,7 +47,7 @@ func runMailCheck(cmd *cobra.Command, args []string) error {
 	_, unread, err := mailbox.Count()
 	if err != nil {
 		if mailCheckInject {
-			fmt.Fprintf(os.Stderr, "gt mail check: count error for %s: %v\n", address, err)
+			logger.Warn("mail check: count error", "address", address, "err", err)
 			return nil
 		}
 		return fmt.Errorf("counting messages: %w", err)
@@ -73,13 +73,13 @@ func runMailCheck(cmd *cobra.Command, args []string) error {
 		if unread > 0 {
 			messages, listErr := mailbox.ListUnread()
 			if listErr != nil {
-				fmt.Fprintf(os.Stderr, "gt mail check: could not list unread for %s: %v\n", address, listErr)
+				logger.Warn("mail check: could not list unread", "address", address, "err", listErr)
 				return nil
 			}
 			fmt.Print(formatInjectOutput(messages))
 			// Ack after output so message is delivered before being marked acked.
 			if ackErr := mailbox.AcknowledgeDeliveries(address, messages); ackErr != nil {
-				fmt.Fprintf(os.Stderr, "gt mail check: delivery ack update failed for %s: %v\n", address, ackErr)
+				logger.Warn("mail check: delivery ack update failed", "address", address, "err", ackErr)
 			}
 		}
 
@@ -89,7 +89,7 @@ func runMailCheck(cmd *cobra.Command, args []string) error {
 		if sessionName != "" {
 			queuedNudges, drainErr := nudge.Drain(workDir, sessionName)
 			if drainErr != nil {
-				fmt.Fprintf(os.Stderr, "gt mail check: nudge queue drain error: %v\n", drainErr)
+				logger.Warn("mail check: nudge queue drain error", "err", drainErr)
 			} else if len(queuedNudges) > 0 {
 				fmt.Print(nudge.FormatForInjection(queuedNudges))
 			}
@@ -55,7 +55,7 @@ func runMailDirectory(cmd *cobra.Command, args []string) error {
 	// 1. Agent addresses
 	agents, err := b.ListAgentBeads()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "warning: could not list agents: %v\n", err)
+		logger.Warn("could not list agents", "err", err)
 		warnings++
 	} else {
 		for id := range agents {
@@ -69,7 +69,7 @@ func runMailDirectory(cmd *cobra.Command, args []string) error {
 	// 2. Group addresses
 	groups, err := b.ListGroupBeads()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "warning: could not list groups: %v\n", err)
+		logger.Warn("could not list groups", "err", err)
 		warnings++
 	} else {
 		for name := range groups {
@@ -80,7 +80,7 @@ func runMailDirectory(cmd *cobra.Command, args []string) error {
 	// 3. Queue addresses
 	queues, err := b.ListQueueBeads()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "warning: could not list queues: %v\n", err)
+		logger.Warn("could not list queues", "err", err)
 		warnings++
 	} else {
 		for id, issue := range queues {
@@ -89,7 +89,7 @@ func runMailDirectory(cmd *cobra.Command, args []string) error {
 			}
 			fields := beads.ParseQueueFields(issue.Description)
 			if fields.Name == "" {
-				fmt.Fprintf(os.Stderr, "warning: queue %s has no name field, skipping\n", id)
+				logger.Warn("queue has no name field, skipping", "queue", id)
 				continue
 			}
 			entries = append(entries, DirectoryEntry{Address: "queue:" + fields.Name, Type: "queue"})
@@ -99,7 +99,7 @@ func runMailDirectory(cmd *cobra.Command, args []string) error {
 	// 4. Channel addresses
 	channels, err := b.ListChannelBeads()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "warning: could not list channels: %v\n", err)
+		logger.Warn("could not list channels", "err", err)
 		warnings++
 	} else {
 		for name := range channels {
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/mail"
 )
 
 // Flags for mail hook command (mirror of hook command flags)
@@ -54,5 +55,15 @@ func runMailHook(cmd *cobra.Command, args []string) error {
 	hookForce = mailHookForce
 
 	// Delegate to the hook command's run function
-	return runHook(cmd, args)
+	if err := runHook(cmd, args); err != nil {
+		return err
+	}
+
+	// Hooking mail is acting on it (best-effort, for 'gt mail status').
+	if !mailHookDryRun {
+		if mailbox, err := getMailbox(detectSender()); err == nil {
+			_ = mailbox.RecordState(args[0], mail.StateActed)
+		}
+	}
+	return nil
 }
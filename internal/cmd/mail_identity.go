@@ -4,10 +4,15 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/tmux"
 	"github.com/steveyegge/gastown/internal/workspace"
 )
 
@@ -257,3 +262,75 @@ func identityFromAgentFile(parsed agentIdentityFile) string {
 
 	return ""
 }
+
+// detectSessionSender returns the address of the Gas Town agent whose tmux
+// session this command runs in, or "" outside an agent session. The session
+// is found by walking up this process's ancestors to a pane of the town's
+// tmux server, not from $TMUX or $TMUX_PANE, which an agent can rewrite.
+func detectSessionSender() string {
+	out, err := exec.Command("ps", "-eo", "pid=,ppid=").Output()
+	if err != nil {
+		return ""
+	}
+	parents := make(map[int]int)
+	for _, line := range strings.Split(string(out), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		pid, err1 := strconv.Atoi(fields[0])
+		ppid, err2 := strconv.Atoi(fields[1])
+		if err1 == nil && err2 == nil {
+			parents[pid] = ppid
+		}
+	}
+	out, err = tmux.BuildCommand("list-panes", "-a", "-F", "#{pane_pid} #{session_name}").Output()
+	if err != nil {
+		return ""
+	}
+	panes := make(map[int]string)
+	for _, line := range strings.Split(string(out), "\n") {
+		pid, name, ok := strings.Cut(strings.TrimSpace(line), " ")
+		if n, err := strconv.Atoi(pid); ok && err == nil {
+			panes[n] = name
+		}
+	}
+
+	identity, err := session.ParseSessionName(paneSessionOf(os.Getpid(), parents, panes))
+	if err != nil {
+		return ""
+	}
+	switch identity.Role {
+	case session.RoleMayor:
+		return "mayor/"
+	case session.RoleDeacon:
+		return "deacon/"
+	}
+	return identity.Address()
+}
+
+// paneSessionOf returns the session of the nearest ancestor of pid (or pid
+// itself) that is a pane's process, or "" if there is none.
+func paneSessionOf(pid int, parents map[int]int, panes map[int]string) string {
+	for seen := make(map[int]bool); pid > 1 && !seen[pid]; pid = parents[pid] {
+		if name, ok := panes[pid]; ok {
+			return name
+		}
+		seen[pid] = true
+	}
+	return ""
+}
+
+// resolveMailSender returns the sender address for outgoing mail. Inside
+// an agent's tmux session the session decides who is sending: a GT_ROLE or
+// working directory claiming to be a different agent is refused, so agents
+// can't send mail as each other. Every command that sends mail under the
+// caller's name resolves it here rather than with detectSender.
+func resolveMailSender() (string, error) {
+	claimed := detectSender()
+	actual := detectSessionSender()
+	if actual == "" || mail.AddressToIdentity(actual) == mail.AddressToIdentity(claimed) {
+		return claimed, nil
+	}
+	return "", fmt.Errorf("sender %s does not match this tmux session's agent %s: mail from an agent session is sent as that agent", claimed, actual)
+}
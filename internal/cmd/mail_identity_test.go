@@ -73,3 +73,22 @@ func TestDetectSenderFromCwdUsesAgentFileRefineryIdentity(t *testing.T) {
 		t.Fatalf("detectSender() = %q, want %q", got, "x267/refinery")
 	}
 }
+
+func TestPaneSessionOf(t *testing.T) {
+	// 300 (gt) → 200 (shell) → 100 (pane process) → 1; 400 → 400 is a cycle.
+	parents := map[int]int{300: 200, 200: 100, 100: 1, 400: 400}
+	panes := map[int]string{100: "hq-deacon"}
+
+	if got := paneSessionOf(300, parents, panes); got != "hq-deacon" {
+		t.Errorf("paneSessionOf(300) = %q, want hq-deacon", got)
+	}
+	if got := paneSessionOf(100, parents, panes); got != "hq-deacon" {
+		t.Errorf("paneSessionOf(pane process) = %q, want hq-deacon", got)
+	}
+	if got := paneSessionOf(400, parents, panes); got != "" {
+		t.Errorf("paneSessionOf(cycle) = %q, want empty", got)
+	}
+	if got := paneSessionOf(500, parents, panes); got != "" {
+		t.Errorf("paneSessionOf(unknown) = %q, want empty", got)
+	}
+}
@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+var (
+	mailImportTo     string
+	mailImportDryRun bool
+	mailImportLimit  int
+)
+
+var mailImportCmd = &cobra.Command{
+	Use:   "import <slack|mbox|github> <path>",
+	Short: "Import conversation history from Slack, mbox, or GitHub as mail threads",
+	Long: `Convert coordination history from another tool into mail threads, so
+agents keep the context of conversations that happened before Gas Town.
+
+Formats:
+  slack   An unzipped Slack export directory, one channel's directory, or
+          a single day file. Slack threads become mail threads; other
+          messages are grouped into one thread per channel per day.
+  mbox    An mbox file ("-" for stdin). Threaded by References/In-Reply-To.
+  github  JSON from 'gh issue view N --json number,title,body,author,createdAt,url,comments',
+          'gh issue list --json ...,comments', or
+          'gh api repos/OWNER/REPO/issues/N/comments' ("-" for stdin).
+
+Each thread is delivered as one mail thread to --to (default: you). Mail
+records the import time, so every message starts with a header naming the
+original author and time. Imported mail is permanent, low priority, and
+sent without notifying the recipient.
+
+Examples:
+  gt mail import slack ~/Downloads/acme-slack-export --dry-run
+  gt mail import slack ~/Downloads/acme-slack-export/eng-infra --to gastown/crew/max
+  gt mail import mbox ~/mail/design-review.mbox --to mayor/
+  gh issue view 42 --json number,title,body,author,createdAt,url,comments | gt mail import github -`,
+	Args: cobra.ExactArgs(2),
+	RunE: runMailImport,
+}
+
+func init() {
+	mailImportCmd.Flags().StringVar(&mailImportTo, "to", "", "Recipient of the imported threads (default: your own mailbox)")
+	mailImportCmd.Flags().BoolVarP(&mailImportDryRun, "dry-run", "n", false, "List the threads that would be imported without sending")
+	mailImportCmd.Flags().IntVar(&mailImportLimit, "limit", 0, "Import at most this many threads, newest first (0 for all)")
+	mailCmd.AddCommand(mailImportCmd)
+}
+
+func runMailImport(cmd *cobra.Command, args []string) error {
+	threads, err := parseImport(args[0], args[1])
+	if err != nil {
+		return err
+	}
+	if len(threads) == 0 {
+		fmt.Printf("%s Nothing to import\n", style.Dim.Render("○"))
+		return nil
+	}
+	if mailImportLimit > 0 && len(threads) > mailImportLimit {
+		threads = threads[len(threads)-mailImportLimit:]
+	}
+
+	from, err := resolveMailSender()
+	if err != nil {
+		return err
+	}
+	to := mailImportTo
+	if to == "" {
+		to = from
+	}
+
+	if mailImportDryRun {
+		for _, t := range threads {
+			fmt.Printf("  %s %s %s\n", t.Messages[0].Time.Local().Format("2006-01-02 15:04"), t.Subject,
+				style.Dim.Render(fmt.Sprintf("(%d messages)", len(t.Messages))))
+		}
+		fmt.Printf("\n%s Would import %d thread(s) to %s\n", style.Dim.Render("○"), len(threads), to)
+		return nil
+	}
+
+	workDir, err := findMailWorkDir()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	router := mail.NewRouter(workDir)
+	var sent int
+	for _, t := range threads {
+		msgs := t.ToMessages(from, to)
+		for _, msg := range msgs {
+			if err := router.Send(msg); err != nil {
+				return fmt.Errorf("importing %q (after %d messages): %w", t.Subject, sent, err)
+			}
+			sent++
+		}
+		fmt.Printf("%s %s %s\n", style.Bold.Render("✓"), t.Subject, style.Dim.Render(msgs[0].ThreadID))
+	}
+	fmt.Printf("\nImported %d thread(s), %d message(s) to %s\n", len(threads), sent, to)
+	return nil
+}
+
+// parseImport runs the importer for format on path ("-" for stdin where
+// the format is a single stream).
+func parseImport(format, path string) ([]mail.ImportedThread, error) {
+	switch format {
+	case "slack":
+		return mail.ParseSlackExport(path)
+	case "mbox", "github":
+	default:
+		return nil, fmt.Errorf("unknown format %q (valid: slack, mbox, github)", format)
+	}
+
+	var r io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+	if format == "mbox" {
+		return mail.ParseMbox(r)
+	}
+	return mail.ParseGitHubComments(r)
+}
@@ -78,7 +78,7 @@ func runMailInbox(cmd *cobra.Command, args []string) error {
 		}
 		// Ack after output so JSON reflects accurate read-time state.
 		if ackErr := mailbox.AcknowledgeDeliveries(address, messages); ackErr != nil {
-			fmt.Fprintf(os.Stderr, "gt mail inbox: delivery ack failed: %v\n", ackErr)
+			logger.Warn("mail inbox: delivery ack failed", "address", address, "err", ackErr)
 		}
 		return nil
 	}
@@ -121,12 +121,12 @@ func runMailInbox(cmd *cobra.Command, args []string) error {
 			style.Dim.Render(msg.ID),
 			msg.From)
 		fmt.Printf("      %s\n",
-			style.Dim.Render(msg.Timestamp.Format("2006-01-02 15:04")))
+			style.Dim.Render(msg.Timestamp.Local().Format("2006-01-02 15:04")))
 	}
 
 	// Ack after output so human-readable display is not delayed by bd subprocesses.
 	if ackErr := mailbox.AcknowledgeDeliveries(address, messages); ackErr != nil {
-		fmt.Fprintf(os.Stderr, "gt mail inbox: delivery ack failed: %v\n", ackErr)
+		logger.Warn("mail inbox: delivery ack failed", "address", address, "err", ackErr)
 	}
 
 	return nil
@@ -174,6 +174,13 @@ func runMailRead(cmd *cobra.Command, args []string) error {
 		// Non-fatal: message was retrieved, just couldn't mark
 		style.PrintWarning("could not mark message as read: %v", err)
 	}
+	// Confirm the read to the sender ('gt mail status'). Only the recipient
+	// reading it counts.
+	if mail.AddressToIdentity(msg.To) == mail.AddressToIdentity(address) && !msg.State.Reached(mail.StateRead) {
+		if err := mailbox.RecordState(msgID, mail.StateRead); err != nil {
+			logger.Warn("mail read: recording read state failed", "message", msgID, "err", err)
+		}
+	}
 
 	// JSON output
 	if mailReadJSON {
@@ -184,7 +191,7 @@ func runMailRead(cmd *cobra.Command, args []string) error {
 		}
 		// Ack after output so JSON reflects accurate read-time state.
 		if ackErr := mailbox.AcknowledgeDeliveries(address, []*mail.Message{msg}); ackErr != nil {
-			fmt.Fprintf(os.Stderr, "gt mail read: delivery ack failed: %v\n", ackErr)
+			logger.Warn("mail read: delivery ack failed", "address", address, "err", ackErr)
 		}
 		return nil
 	}
@@ -205,7 +212,7 @@ func runMailRead(cmd *cobra.Command, args []string) error {
 	fmt.Printf("%s %s%s%s\n\n", style.Bold.Render("Subject:"), msg.Subject, typeStr, priorityStr)
 	fmt.Printf("From: %s\n", msg.From)
 	fmt.Printf("To: %s\n", msg.To)
-	fmt.Printf("Date: %s\n", msg.Timestamp.Format("2006-01-02 15:04:05"))
+	fmt.Printf("Date: %s\n", msg.Timestamp.Local().Format("2006-01-02 15:04:05"))
 	fmt.Printf("ID: %s\n", style.Dim.Render(msg.ID))
 
 	if msg.ThreadID != "" {
@@ -218,10 +225,11 @@ func runMailRead(cmd *cobra.Command, args []string) error {
 	if msg.Body != "" {
 		fmt.Printf("\n%s\n", msg.Body)
 	}
+	printMailAttachments(msg)
 
 	// Ack after output (non-fatal).
 	if ackErr := mailbox.AcknowledgeDeliveries(address, []*mail.Message{msg}); ackErr != nil {
-		fmt.Fprintf(os.Stderr, "gt mail read: delivery ack failed: %v\n", ackErr)
+		logger.Warn("mail read: delivery ack failed", "address", address, "err", ackErr)
 	}
 
 	return nil
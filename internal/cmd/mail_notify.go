@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/activity"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// Flags for mail notify command
+var (
+	mailNotifyOnce     bool
+	mailNotifyInterval time.Duration
+)
+
+var mailNotifyCmd = &cobra.Command{
+	Use:   "notify",
+	Short: "Show each agent's inbox backlog in its tmux status line",
+	Long: `Keep an inbox indicator in every agent session's status line.
+
+The indicator shows the unread count and the age of the oldest unread
+message (e.g. "📬 3 (2h)") and disappears when the inbox is empty. It is
+pushed into the session's @gt_mail option, and the status line shows it
+in place of reading the inbox itself.
+
+The indicator for a recipient refreshes as soon as mail to them appears on
+the event bus. Reading mail isn't an event, so every indicator is also
+refreshed on --interval.
+
+Examples:
+  gt mail notify                 # Run until interrupted
+  gt mail notify --once          # Refresh every indicator and exit
+  gt mail notify --interval 30s  # Refresh everything more often`,
+	Args: cobra.NoArgs,
+	RunE: runMailNotify,
+}
+
+func init() {
+	mailNotifyCmd.Flags().BoolVar(&mailNotifyOnce, "once", false, "Refresh every indicator once and exit")
+	mailNotifyCmd.Flags().DurationVar(&mailNotifyInterval, "interval", time.Minute, "How often to refresh every indicator")
+
+	mailCmd.AddCommand(mailNotifyCmd)
+}
+
+func runMailNotify(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	if mailNotifyInterval <= 0 {
+		return fmt.Errorf("interval must be positive, got %s", mailNotifyInterval)
+	}
+
+	t := tmux.NewTmux()
+	n := refreshMailIndicators(t, townRoot, "")
+	if mailNotifyOnce {
+		fmt.Printf("%s Refreshed mail indicators for %d session(s)\n", style.Bold.Render("✓"), n)
+		return nil
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Recipients of new mail, from the event bus. A full buffer just means
+	// a refresh is already due.
+	recipients := make(chan string, 64)
+	go func() {
+		_ = events.Follow(ctx, townRoot, events.Filter{Topics: []string{events.TopicMail}}, -1, func(e events.Event) {
+			to, _ := e.Payload["to"].(string)
+			select {
+			case recipients <- to:
+			default:
+			}
+		})
+	}()
+
+	ticker := time.NewTicker(mailNotifyInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			refreshMailIndicators(t, townRoot, "")
+		case to := <-recipients:
+			refreshMailIndicators(t, townRoot, to)
+		}
+	}
+}
+
+// refreshMailIndicators updates the mail indicator of each agent session
+// whose inbox is recipient, or of every agent session if recipient is empty
+// or isn't an agent (a group, queue, or channel). Returns the number of
+// sessions updated.
+func refreshMailIndicators(t *tmux.Tmux, townRoot, recipient string) int {
+	sessions, err := t.ListSessions()
+	if err != nil {
+		return 0
+	}
+
+	inboxes := make(map[string][]string) // identity -> sessions
+	for _, s := range sessions {
+		identity, err := session.ParseSessionName(s)
+		if err != nil {
+			continue
+		}
+		address := mail.AddressToIdentity(identity.Address())
+		inboxes[address] = append(inboxes[address], s)
+	}
+	if recipient != "" {
+		if matched, ok := inboxes[mail.AddressToIdentity(recipient)]; ok {
+			inboxes = map[string][]string{mail.AddressToIdentity(recipient): matched}
+		}
+	}
+
+	updated := 0
+	for address, targets := range inboxes {
+		msgs, err := mail.NewMailboxFromAddress(address, townRoot).ListUnread()
+		if err != nil {
+			continue
+		}
+		var oldest time.Time
+		for _, msg := range msgs {
+			if oldest.IsZero() || msg.Timestamp.Before(oldest) {
+				oldest = msg.Timestamp
+			}
+		}
+		text := mailIndicatorText(len(msgs), oldest)
+		for _, s := range targets {
+			if err := t.SetMailIndicator(s, text); err == nil {
+				updated++
+			}
+		}
+	}
+	return updated
+}
+
+// mailIndicatorText formats the status-line indicator for an inbox: empty
+// when there's nothing unread, otherwise the count and the oldest message's
+// age.
+func mailIndicatorText(unread int, oldest time.Time) string {
+	if unread == 0 {
+		return ""
+	}
+	if oldest.IsZero() {
+		return fmt.Sprintf("📬 %d", unread)
+	}
+	return fmt.Sprintf("📬 %d (%s)", unread, activity.Calculate(oldest).FormattedAge)
+}
@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMailIndicatorText(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name   string
+		unread int
+		oldest time.Time
+		want   string
+	}{
+		{"empty inbox", 0, time.Time{}, ""},
+		{"no timestamp", 2, time.Time{}, "📬 2"},
+		{"fresh", 1, now, "📬 1 (<1m)"},
+		{"hours old", 3, now.Add(-2*time.Hour - time.Minute), "📬 3 (2h)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mailIndicatorText(tt.unread, tt.oldest); got != tt.want {
+				t.Errorf("mailIndicatorText(%d) = %q, want %q", tt.unread, got, tt.want)
+			}
+		})
+	}
+}
@@ -121,7 +121,7 @@ func runMailClaim(cmd *cobra.Command, args []string) error {
 			// winning claimant writes ack labels. Non-fatal: the claim
 			// itself already succeeded.
 			if ackErr := mail.AcknowledgeDeliveryBead(townRoot, beadsDir, candidate.ID, mail.AddressToIdentity(caller)); ackErr != nil {
-				fmt.Fprintf(os.Stderr, "gt mail claim: delivery ack failed for %s: %v\n", candidate.ID, ackErr)
+				logger.Warn("mail claim: delivery ack failed", "message", candidate.ID, "err", ackErr)
 			}
 			claimed = candidate
 			break
@@ -582,7 +582,7 @@ func runMailQueueCreate(cmd *cobra.Command, args []string) error {
 		ClaimPattern: mailQueueClaimers,
 		Status:       beads.QueueStatusActive,
 		CreatedBy:    caller,
-		CreatedAt:    time.Now().Format(time.RFC3339),
+		CreatedAt:    time.Now().UTC().Format(time.RFC3339),
 	}
 
 	title := fmt.Sprintf("Queue: %s", queueName)
@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var (
+	mailRulesAll  bool
+	mailRulesJSON bool
+)
+
+var mailRulesCmd = &cobra.Command{
+	Use:   "rules [address]",
+	Short: "List the mail rules that apply to an agent",
+	Long: `List the mail rules applied to an agent's incoming mail (default: you).
+
+Rules live in config/messaging.json under "rules" and run, in order, when
+a message is delivered to a matching agent. Each rule matches on sender,
+subject (a regular expression), and labels, then archives, forwards,
+raises priority, auto-replies, or nudges someone. Mail sent by rules is
+never itself run through rules.
+
+  "rules": [
+    {"name": "patrol-reports", "agent": "mayor/",
+     "match": {"from": "*/witness", "subject": "^Patrol report"},
+     "archive": true, "stop": true},
+    {"agent": "mayor/", "match": {"subject": "(?i)blocked"},
+     "priority": "high", "nudge": "deacon/"}
+  ]
+
+Examples:
+  gt mail rules                 # Rules for your own mail
+  gt mail rules mayor/          # Rules for the mayor's mail
+  gt mail rules --all           # Every rule in the town`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runMailRules,
+}
+
+func init() {
+	mailRulesCmd.Flags().BoolVar(&mailRulesAll, "all", false, "List every rule, for any agent")
+	mailRulesCmd.Flags().BoolVar(&mailRulesJSON, "json", false, "Output as JSON")
+
+	mailCmd.AddCommand(mailRulesCmd)
+}
+
+func runMailRules(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	cfg, err := config.LoadMessagingConfig(config.MessagingConfigPath(townRoot))
+	if err != nil && !errors.Is(err, config.ErrNotFound) {
+		return err
+	}
+	var rules []config.MailRule
+	if cfg != nil {
+		rules = cfg.Rules
+	}
+
+	address := ""
+	if !mailRulesAll {
+		address = detectSender()
+		if len(args) > 0 {
+			address = args[0]
+		}
+	}
+	// Unnamed rules are numbered by position in the config, as in the
+	// warnings rules print on delivery.
+	applies := func(rule config.MailRule) bool {
+		return address == "" || mail.RuleAppliesTo(rule, address)
+	}
+
+	if mailRulesJSON {
+		matched := []config.MailRule{}
+		for _, rule := range rules {
+			if applies(rule) {
+				matched = append(matched, rule)
+			}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(matched)
+	}
+
+	count := 0
+	for _, rule := range rules {
+		if applies(rule) {
+			count++
+		}
+	}
+	if count == 0 {
+		if address != "" {
+			fmt.Printf("No mail rules for %s\n", address)
+		} else {
+			fmt.Println("No mail rules configured")
+		}
+		return nil
+	}
+	if address != "" {
+		fmt.Printf("%s for %s:\n\n", style.Bold.Render("Mail rules"), address)
+	}
+	for i, rule := range rules {
+		if !applies(rule) {
+			continue
+		}
+		name := rule.Name
+		if name == "" {
+			name = fmt.Sprintf("#%d", i+1)
+		}
+		fmt.Printf("  %s %s\n", style.Bold.Render(name), style.Dim.Render("(agent "+rule.Agent+")"))
+		fmt.Printf("    when:  %s\n", describeRuleMatch(rule.Match))
+		fmt.Printf("    then:  %s\n", describeRuleActions(rule))
+	}
+	return nil
+}
+
+// describeRuleMatch renders a rule's conditions for display.
+func describeRuleMatch(m config.MailRuleMatch) string {
+	var parts []string
+	if m.From != "" {
+		parts = append(parts, "from "+m.From)
+	}
+	if m.Subject != "" {
+		parts = append(parts, fmt.Sprintf("subject ~ /%s/", m.Subject))
+	}
+	if len(m.Labels) > 0 {
+		parts = append(parts, "labels "+strings.Join(m.Labels, ", "))
+	}
+	if len(parts) == 0 {
+		return "any message"
+	}
+	return strings.Join(parts, " and ")
+}
+
+// describeRuleActions renders a rule's actions for display.
+func describeRuleActions(rule config.MailRule) string {
+	var parts []string
+	if rule.Priority != "" {
+		parts = append(parts, "priority ≥ "+rule.Priority)
+	}
+	if rule.Forward != "" {
+		parts = append(parts, "forward to "+rule.Forward)
+	}
+	if rule.AutoReply != "" {
+		parts = append(parts, fmt.Sprintf("auto-reply %q", rule.AutoReply))
+	}
+	if rule.Nudge != "" {
+		parts = append(parts, "nudge "+rule.Nudge)
+	}
+	if rule.Archive {
+		parts = append(parts, "archive")
+	}
+	if rule.Stop {
+		parts = append(parts, "stop")
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,245 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// Scheduling flags for mail send
+var (
+	mailSendAt    string
+	mailSendEvery string
+)
+
+// Flags for mail schedule commands
+var mailScheduleJSON bool
+
+var mailScheduleCmd = &cobra.Command{
+	Use:   "schedule",
+	Short: "Manage scheduled and recurring mail",
+	Long: `Manage mail queued with 'gt mail send --at' or '--every'.
+
+Scheduled messages are kept in .runtime/mail-schedule.json in the town and
+delivered by the daemon's mail_schedule patrol, which checks for due
+messages every minute. A recurring message that was missed while the
+daemon was down is sent once when it comes back, not once per missed run.
+
+Recurrence specs (--every):
+  30m, 4h, 24h        Fixed interval (at least 1m)
+  @hourly, @daily     Named schedules (also @weekdays = 09:00 Mon-Fri,
+  @weekly, @monthly   @midnight)
+  "0 9 * * 1-5"       Cron fields: minute hour day-of-month month day-of-week
+
+Cron schedules use the local time zone of the machine running the daemon.
+
+Examples:
+  gt mail send gastown/crew/ -s "Standup" -m "Post status" --every "0 9 * * 1-5"
+  gt mail send deacon/ -s "Nightly cleanup" -m "Prune stale branches" --every @daily
+  gt mail send mayor/ -s "Check on the release" --at 17:30
+  gt mail schedule list
+  gt mail schedule cancel sched-abc123`,
+	RunE: requireSubcommand,
+}
+
+var mailScheduleListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List scheduled messages",
+	Args:  cobra.NoArgs,
+	RunE:  runMailScheduleList,
+}
+
+var mailScheduleCancelCmd = &cobra.Command{
+	Use:   "cancel <schedule-id>...",
+	Short: "Cancel scheduled messages",
+	Args:  cobra.MinimumNArgs(1),
+	RunE:  runMailScheduleCancel,
+}
+
+var mailScheduleRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Send scheduled messages that are due now",
+	Long: `Send every scheduled message that is due now.
+
+The daemon does this every minute; run it by hand when the daemon is
+not running or to flush the schedule immediately.`,
+	Args: cobra.NoArgs,
+	RunE: runMailScheduleRun,
+}
+
+func init() {
+	mailSendCmd.Flags().StringVar(&mailSendAt, "at", "", "Send later instead of now: HH:MM, +duration, or a date/time (\"2026-01-02 15:04\", RFC3339)")
+	mailSendCmd.Flags().StringVar(&mailSendEvery, "every", "", "Send repeatedly: interval (24h), @daily/@weekly/..., or cron fields (\"0 9 * * 1-5\")")
+
+	mailScheduleListCmd.Flags().BoolVar(&mailScheduleJSON, "json", false, "Output as JSON")
+
+	mailScheduleCmd.AddCommand(mailScheduleListCmd)
+	mailScheduleCmd.AddCommand(mailScheduleCancelCmd)
+	mailScheduleCmd.AddCommand(mailScheduleRunCmd)
+	mailCmd.AddCommand(mailScheduleCmd)
+}
+
+// scheduleMailSend stores msg in the town's schedule instead of sending it.
+func scheduleMailSend(msg *mail.Message) error {
+	if len(msg.CC) > 0 || mailReplyTo != "" || msg.Pinned {
+		return fmt.Errorf("--at/--every cannot be combined with --cc, --reply-to, or --pinned")
+	}
+	if len(mailAttachBeads)+len(mailAttachFiles)+len(mailAttachDiffs) > 0 {
+		return fmt.Errorf("--at/--every cannot be combined with attachments")
+	}
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	now := time.Now()
+	var next time.Time
+	if mailSendAt != "" {
+		if next, err = parseMailSendAt(mailSendAt, now); err != nil {
+			return err
+		}
+	}
+	if mailSendEvery != "" {
+		rec, err := mail.ParseRecurrence(mailSendEvery)
+		if err != nil {
+			return err
+		}
+		if next.IsZero() {
+			next = rec.Next(now)
+		}
+		if next.IsZero() {
+			return fmt.Errorf("recurrence %q never fires", mailSendEvery)
+		}
+	}
+
+	entry, err := mail.ScheduleMessage(townRoot, mail.ScheduledMessage{
+		From:     msg.From,
+		To:       msg.To,
+		Subject:  msg.Subject,
+		Body:     msg.Body,
+		Priority: msg.Priority,
+		Type:     msg.Type,
+		Wisp:     msg.Wisp,
+		Every:    mailSendEvery,
+		NextAt:   next,
+	})
+	if err != nil {
+		return fmt.Errorf("scheduling message: %w", err)
+	}
+
+	fmt.Printf("%s Message to %s scheduled for %s\n", style.Bold.Render("✓"), msg.To, entry.NextAt.Format("Mon Jan 2 15:04 MST"))
+	fmt.Printf("  Subject: %s\n", entry.Subject)
+	if entry.Recurring() {
+		fmt.Printf("  Repeats: %s\n", entry.Every)
+	}
+	fmt.Printf("  ID: %s\n", style.Dim.Render(entry.ID))
+	return nil
+}
+
+// parseMailSendAt parses a --at value relative to now: "+90m" or "90m"
+// from now, "HH:MM" (today, or tomorrow if that has passed), or an absolute
+// "2006-01-02 15:04" (local) or RFC3339 time. The time must be in the future.
+func parseMailSendAt(s string, now time.Time) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if d, err := time.ParseDuration(strings.TrimPrefix(s, "+")); err == nil {
+		if d <= 0 {
+			return time.Time{}, fmt.Errorf("--at %s: duration must be positive", s)
+		}
+		return now.Add(d), nil
+	}
+	if clock, err := time.ParseInLocation("15:04", s, now.Location()); err == nil {
+		t := time.Date(now.Year(), now.Month(), now.Day(), clock.Hour(), clock.Minute(), 0, 0, now.Location())
+		if !t.After(now) {
+			t = t.AddDate(0, 0, 1)
+		}
+		return t, nil
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02 15:04", "2006-01-02T15:04"} {
+		if t, err := time.ParseInLocation(layout, s, now.Location()); err == nil {
+			if !t.After(now) {
+				return time.Time{}, fmt.Errorf("--at %s is in the past", s)
+			}
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("--at %q: want HH:MM, +duration, \"2006-01-02 15:04\", or RFC3339", s)
+}
+
+func runMailScheduleList(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	entries, err := mail.LoadSchedule(townRoot)
+	if err != nil {
+		return err
+	}
+
+	if mailScheduleJSON {
+		if entries == nil {
+			entries = []mail.ScheduledMessage{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(entries)
+	}
+
+	if len(entries) == 0 {
+		fmt.Println("No scheduled mail")
+		return nil
+	}
+	fmt.Printf("%s (%d)\n\n", style.Bold.Render("Scheduled mail"), len(entries))
+	for _, e := range entries {
+		fmt.Printf("  %s %s → %s: %s\n", style.Bold.Render(e.ID), e.From, e.To, e.Subject)
+		when := "next " + e.NextAt.Local().Format("Mon Jan 2 15:04")
+		if e.Recurring() {
+			when += ", every " + e.Every
+		}
+		if e.SentCount > 0 {
+			when += fmt.Sprintf(", sent %d×", e.SentCount)
+		}
+		fmt.Printf("    %s\n", style.Dim.Render(when))
+		if e.LastError != "" {
+			fmt.Printf("    %s\n", style.Warning.Render("last attempt failed: "+e.LastError))
+		}
+	}
+	return nil
+}
+
+func runMailScheduleCancel(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	for _, id := range args {
+		if err := mail.CancelScheduled(townRoot, id); err != nil {
+			return err
+		}
+		fmt.Printf("%s Cancelled %s\n", style.Bold.Render("✓"), id)
+	}
+	return nil
+}
+
+func runMailScheduleRun(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	router := mail.NewRouterWithTownRoot(townRoot, townRoot)
+	defer router.WaitPendingNotifications()
+
+	sent, err := mail.DeliverDue(townRoot, time.Now(), router.Send)
+	if sent > 0 {
+		fmt.Printf("%s Sent %d scheduled message(s)\n", style.Bold.Render("✓"), sent)
+	} else if err == nil {
+		fmt.Println("No scheduled mail is due")
+	}
+	return err
+}
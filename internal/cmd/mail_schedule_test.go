@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseMailSendAt(t *testing.T) {
+	now := time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		in      string
+		want    time.Time
+		wantErr bool
+	}{
+		{"+90m", now.Add(90 * time.Minute), false},
+		{"2h", now.Add(2 * time.Hour), false},
+		{"17:30", time.Date(2026, 3, 4, 17, 30, 0, 0, time.UTC), false},
+		{"09:15", time.Date(2026, 3, 5, 9, 15, 0, 0, time.UTC), false}, // Passed today: tomorrow
+		{"2026-03-10 08:00", time.Date(2026, 3, 10, 8, 0, 0, 0, time.UTC), false},
+		{"2026-03-10T08:00:00Z", time.Date(2026, 3, 10, 8, 0, 0, 0, time.UTC), false},
+		{"2026-03-01 08:00", time.Time{}, true}, // Past
+		{"-5m", time.Time{}, true},
+		{"tomorrow", time.Time{}, true},
+	}
+	for _, tt := range tests {
+		got, err := parseMailSendAt(tt.in, now)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseMailSendAt(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && !got.Equal(tt.want) {
+			t.Errorf("parseMailSendAt(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
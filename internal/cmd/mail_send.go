@@ -71,8 +71,11 @@ func runMailSend(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("not in a Gas Town workspace: %w", err)
 	}
 
-	// Determine sender
-	from := detectSender()
+	// Determine sender (the tmux session's agent wins over GT_ROLE/cwd)
+	from, err := resolveMailSender()
+	if err != nil {
+		return err
+	}
 
 	// Create message with auto-generated ID and thread ID
 	msg := mail.NewMessage(from, to, mailSubject, mailBody)
@@ -99,6 +102,18 @@ func runMailSend(cmd *cobra.Command, args []string) error {
 	// Set CC recipients
 	msg.CC = mailCC
 
+	// --at/--every: queue in the town's schedule instead of sending now
+	if mailSendAt != "" || mailSendEvery != "" {
+		return scheduleMailSend(msg)
+	}
+
+	// Attachments: beads by reference, files and diffs by snapshot
+	attachments, err := buildMailAttachments(workDir)
+	if err != nil {
+		return err
+	}
+	msg.Attachments = attachments
+
 	// Suppress router-side notification when --no-notify is passed.
 	// Otherwise the router handles idle-aware notification per-recipient,
 	// which also works correctly for fan-out (groups, lists, channels).
@@ -157,6 +172,7 @@ func runMailSend(cmd *cobra.Command, args []string) error {
 		_ = events.LogFeed(events.TypeMail, from, events.MailPayload(to, mailSubject))
 		fmt.Printf("%s Message sent to %s\n", style.Bold.Render("✓"), to)
 		fmt.Printf("  Subject: %s\n", mailSubject)
+		fmt.Printf("  ID: %s\n", style.Dim.Render(msg.ID))
 		return nil
 	}
 
@@ -165,6 +181,7 @@ func runMailSend(cmd *cobra.Command, args []string) error {
 	defer router.WaitPendingNotifications()
 	var recipientAddrs []string
 	var sendErrs []string
+	var messageIDs []string // Direct messages, for 'gt mail status'
 
 	for _, rec := range recipients {
 		switch rec.Type {
@@ -196,6 +213,7 @@ func runMailSend(cmd *cobra.Command, args []string) error {
 				continue
 			}
 			recipientAddrs = append(recipientAddrs, rec.Address)
+			messageIDs = append(messageIDs, msgCopy.ID)
 		}
 	}
 
@@ -217,12 +235,18 @@ func runMailSend(cmd *cobra.Command, args []string) error {
 		fmt.Printf("  Recipients: %s\n", strings.Join(recipientAddrs, ", "))
 	}
 
+	if len(messageIDs) > 0 {
+		fmt.Printf("  ID: %s\n", style.Dim.Render(strings.Join(messageIDs, ", ")))
+	}
 	if len(msg.CC) > 0 {
 		fmt.Printf("  CC: %s\n", strings.Join(msg.CC, ", "))
 	}
 	if msg.Type != mail.TypeNotification {
 		fmt.Printf("  Type: %s\n", msg.Type)
 	}
+	if len(msg.Attachments) > 0 {
+		fmt.Printf("  Attachments: %d\n", len(msg.Attachments))
+	}
 
 	return nil
 }
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+var mailStatusJSON bool
+
+var mailStatusCmd = &cobra.Command{
+	Use:   "status <message-id>",
+	Short: "Show whether a message was delivered, read, and acted on",
+	Long: `Show where a message you sent is in its lifecycle.
+
+States only move forward:
+  queued     In the recipient's mailbox, not seen yet
+  delivered  The recipient was nudged about it, or saw it in their inbox
+  read       The recipient opened it with 'gt mail read'
+  acted      The recipient replied to it or hooked it
+
+The message ID is shown when the message is sent. Messages sent before
+state tracking have no state.
+
+Examples:
+  gt mail status hq-abc123
+  gt mail status hq-abc123 --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runMailStatus,
+}
+
+func init() {
+	mailStatusCmd.Flags().BoolVar(&mailStatusJSON, "json", false, "Output as JSON")
+
+	mailCmd.AddCommand(mailStatusCmd)
+}
+
+// mailStatusSteps are the lifecycle states in order, for display.
+var mailStatusSteps = []mail.MessageState{mail.StateQueued, mail.StateDelivered, mail.StateRead, mail.StateActed}
+
+func runMailStatus(cmd *cobra.Command, args []string) error {
+	mailbox, err := getMailbox(detectSender())
+	if err != nil {
+		return err
+	}
+	msg, err := mailbox.Get(args[0])
+	if err != nil {
+		return fmt.Errorf("getting message: %w", err)
+	}
+
+	if mailStatusJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(struct {
+			ID      string                          `json:"id"`
+			To      string                          `json:"to"`
+			Subject string                          `json:"subject"`
+			State   mail.MessageState               `json:"state"`
+			StateAt map[mail.MessageState]time.Time `json:"state_at,omitempty"`
+		}{msg.ID, msg.To, msg.Subject, msg.State, msg.StateAt})
+	}
+
+	fmt.Printf("%s %s\n", style.Bold.Render(msg.ID), msg.Subject)
+	fmt.Printf("  To: %s\n", msg.To)
+	if msg.State == "" {
+		fmt.Printf("  State: %s\n", style.Dim.Render("untracked (sent before state tracking)"))
+		return nil
+	}
+	fmt.Printf("  State: %s\n\n", style.Bold.Render(string(msg.State)))
+	for _, step := range mailStatusSteps {
+		mark, when := style.Dim.Render("○"), ""
+		if msg.State.Reached(step) {
+			mark = style.Success.Render("●")
+		}
+		if at, ok := msg.StateAt[step]; ok {
+			when = at.Local().Format("2006-01-02 15:04:05")
+		} else if step == mail.StateQueued {
+			when = msg.Timestamp.Local().Format("2006-01-02 15:04:05")
+		}
+		fmt.Printf("  %s %-10s %s\n", mark, step, style.Dim.Render(when))
+	}
+	return nil
+}
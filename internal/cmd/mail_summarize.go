@@ -0,0 +1,264 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+// Limits that keep the summarizer prompt within a cheap model's budget.
+// Long bodies are clipped individually, then the oldest messages are dropped.
+const (
+	summaryMaxBodyChars   = 4000
+	summaryMaxPromptChars = 60000
+	summaryTimeout        = 2 * time.Minute
+	summarySubjectPrefix  = "📝 Summary: "
+)
+
+var (
+	mailSummarizeAgent    string
+	mailSummarizeTo       []string
+	mailSummarizeNoAttach bool
+)
+
+var mailSummarizeCmd = &cobra.Command{
+	Use:   "summarize <thread-id>",
+	Short: "Summarize a long thread (decisions, open questions, action items)",
+	Long: `Summarize a mail thread with a cheap model.
+
+The summary lists decisions, open questions, and action items. It is
+printed and attached to the thread as a message to you, so 'gt mail thread'
+shows it alongside the conversation. Use --to to mail it to agents joining
+the conversation mid-thread; their copy is part of the same thread, so
+replies stay threaded.
+
+The model is the agent named by mail_summary_agent in settings/config.json
+(default: claude --model haiku). Override per run with --agent.
+
+Examples:
+  gt mail summarize thread-abc123
+  gt mail summarize thread-abc123 --to gastown/polecats/nux
+  gt mail summarize thread-abc123 --agent gemini --no-attach`,
+	Args: cobra.ExactArgs(1),
+	RunE: runMailSummarize,
+}
+
+func init() {
+	mailSummarizeCmd.Flags().StringVar(&mailSummarizeAgent, "agent", "", "Agent to summarize with (default: mail_summary_agent setting)")
+	mailSummarizeCmd.Flags().StringSliceVar(&mailSummarizeTo, "to", nil, "Also mail the summary to these addresses (repeatable)")
+	mailSummarizeCmd.Flags().BoolVar(&mailSummarizeNoAttach, "no-attach", false, "Print the summary without adding it to the thread")
+	mailCmd.AddCommand(mailSummarizeCmd)
+}
+
+func runMailSummarize(cmd *cobra.Command, args []string) error {
+	threadID := args[0]
+
+	workDir, err := findMailWorkDir()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	address := detectSender()
+
+	router := mail.NewRouter(workDir)
+	mailbox, err := router.GetMailbox(address)
+	if err != nil {
+		return fmt.Errorf("getting mailbox: %w", err)
+	}
+	messages, err := mailbox.ListByThread(threadID)
+	if err != nil {
+		return fmt.Errorf("getting thread: %w", err)
+	}
+	if len(messages) == 0 {
+		return fmt.Errorf("thread %s has no messages", threadID)
+	}
+
+	rc, err := resolveSummaryAgent(workDir, mailSummarizeAgent)
+	if err != nil {
+		return err
+	}
+	summary, err := runSummaryAgent(cmd.Context(), rc, buildThreadSummaryPrompt(messages))
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s Thread %s (%d messages)\n\n", style.Bold.Render("📝"), threadID, len(messages))
+	fmt.Println(summary)
+
+	if mailSummarizeNoAttach && len(mailSummarizeTo) == 0 {
+		return nil
+	}
+
+	from, err := resolveMailSender()
+	if err != nil {
+		return err
+	}
+	subject := summarySubjectPrefix + strings.TrimPrefix(threadSubject(messages), "Re: ")
+	last := messages[len(messages)-1]
+	recipients := mailSummarizeTo
+	if !mailSummarizeNoAttach {
+		recipients = append([]string{address}, recipients...)
+	}
+
+	defer router.WaitPendingNotifications()
+	fmt.Println()
+	for _, to := range recipients {
+		msg := &mail.Message{
+			From:     from,
+			To:       to,
+			Subject:  subject,
+			Body:     summary,
+			Type:     mail.TypeNotification,
+			Priority: mail.PriorityNormal,
+			ThreadID: threadID,
+			ReplyTo:  last.ID,
+		}
+		if err := router.Send(msg); err != nil {
+			return fmt.Errorf("sending summary to %s: %w", to, err)
+		}
+		if to == address {
+			fmt.Printf("%s Summary attached to thread %s\n", style.Bold.Render("✓"), threadID)
+		} else {
+			fmt.Printf("%s Summary sent to %s\n", style.Bold.Render("✓"), to)
+		}
+	}
+	return nil
+}
+
+// threadSubject returns the subject of the first message that isn't itself
+// a summary, so re-summarizing doesn't stack prefixes.
+func threadSubject(messages []*mail.Message) string {
+	for _, m := range messages {
+		if !strings.HasPrefix(m.Subject, summarySubjectPrefix) {
+			return m.Subject
+		}
+	}
+	return strings.TrimPrefix(messages[0].Subject, summarySubjectPrefix)
+}
+
+// buildThreadSummaryPrompt renders a thread (oldest first) into a prompt.
+// When the thread is too long, the oldest messages are dropped and the
+// prompt says how many were omitted.
+func buildThreadSummaryPrompt(messages []*mail.Message) string {
+	rendered := make([]string, len(messages))
+	for i, m := range messages {
+		body := m.Body
+		if len(body) > summaryMaxBodyChars {
+			cut := summaryMaxBodyChars
+			for cut > 0 && !utf8.RuneStart(body[cut]) {
+				cut-- // Don't split a multi-byte character
+			}
+			body = body[:cut] + "\n[...truncated]"
+		}
+		rendered[i] = fmt.Sprintf("--- %s | from %s to %s | %s\n%s\n",
+			m.Timestamp.Local().Format("2006-01-02 15:04"), m.From, m.To, m.Subject, body)
+	}
+
+	start, size := len(rendered), 0
+	for start > 0 && size+len(rendered[start-1]) <= summaryMaxPromptChars {
+		start--
+		size += len(rendered[start])
+	}
+	if start == len(rendered) {
+		start = len(rendered) - 1 // always include the latest message
+	}
+
+	var b strings.Builder
+	b.WriteString("Summarize this Gas Town mail thread between agents for someone joining it now.\n")
+	b.WriteString("Reply with exactly three short sections, as plain text:\n")
+	b.WriteString("Decisions:\nOpen questions:\nAction items: (with owner address when known)\n")
+	b.WriteString("Write \"none\" for an empty section. No preamble.\n\n")
+	if start > 0 {
+		fmt.Fprintf(&b, "[%d earlier messages omitted]\n\n", start)
+	}
+	for _, r := range rendered[start:] {
+		b.WriteString(r)
+	}
+	return b.String()
+}
+
+// resolveSummaryAgent picks the summarizer: --agent, then the
+// mail_summary_agent setting, then claude with haiku.
+func resolveSummaryAgent(townRoot, override string) (*config.RuntimeConfig, error) {
+	name := override
+	if name == "" {
+		if ts, err := config.LoadOrCreateTownSettings(config.TownSettingsPath(townRoot)); err == nil {
+			name = ts.MailSummaryAgent
+		}
+	}
+	if name == "" {
+		return &config.RuntimeConfig{
+			Command:       "claude",
+			Args:          []string{"--model", "haiku"},
+			ResolvedAgent: string(config.AgentClaude),
+		}, nil
+	}
+	rc, _, err := config.ResolveAgentConfigWithOverride(townRoot, "", name)
+	if err != nil {
+		return nil, fmt.Errorf("resolving summary agent %q: %w", name, err)
+	}
+	return rc, nil
+}
+
+// summaryAgentArgs builds the non-interactive invocation for an agent from
+// its preset's NonInteractive settings. Agents without them (claude) take
+// the prompt via -p.
+func summaryAgentArgs(rc *config.RuntimeConfig, prompt string) []string {
+	ni := &config.NonInteractiveConfig{PromptFlag: "-p"}
+	name := rc.ResolvedAgent
+	if name == "" {
+		name = rc.Provider
+	}
+	if preset := config.GetAgentPresetByName(name); preset != nil && preset.NonInteractive != nil {
+		ni = preset.NonInteractive
+	}
+
+	var args []string
+	if ni.Subcommand != "" {
+		args = append(args, ni.Subcommand)
+	}
+	args = append(args, rc.Args...)
+	if ni.PromptFlag != "" {
+		args = append(args, ni.PromptFlag)
+	}
+	return append(args, prompt)
+}
+
+// runSummaryAgent runs the summarizer in a scratch directory so it doesn't
+// pick up a workspace's instructions or hooks, and returns its output.
+func runSummaryAgent(ctx context.Context, rc *config.RuntimeConfig, prompt string) (string, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := context.WithTimeout(ctx, summaryTimeout)
+	defer cancel()
+
+	c := exec.CommandContext(ctx, rc.Command, summaryAgentArgs(rc, prompt)...)
+	c.Dir = os.TempDir()
+	c.Env = os.Environ()
+	for k, v := range rc.Env {
+		c.Env = append(c.Env, k+"="+v)
+	}
+	var stderr strings.Builder
+	c.Stderr = &stderr
+	out, err := c.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("summary agent %s failed: %w: %s", rc.Command, err, msg)
+		}
+		return "", fmt.Errorf("summary agent %s failed: %w", rc.Command, err)
+	}
+	summary := strings.TrimSpace(string(out))
+	if summary == "" {
+		return "", fmt.Errorf("summary agent %s returned no output", rc.Command)
+	}
+	return summary, nil
+}
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/mail"
+)
+
+func TestBuildThreadSummaryPrompt(t *testing.T) {
+	base := time.Date(2026, 1, 2, 15, 4, 0, 0, time.UTC)
+	msgs := []*mail.Message{
+		{From: "mayor/", To: "gastown/witness", Subject: "Merge plan", Body: "Land the parser first.", Timestamp: base},
+		{From: "gastown/witness", To: "mayor/", Subject: "Re: Merge plan", Body: strings.Repeat("x", summaryMaxBodyChars+10), Timestamp: base.Add(time.Minute)},
+	}
+	prompt := buildThreadSummaryPrompt(msgs)
+
+	for _, want := range []string{
+		"Decisions:", "Open questions:", "Action items:",
+		"--- 2026-01-02 15:04 | from mayor/ to gastown/witness | Merge plan\nLand the parser first.",
+		"[...truncated]",
+	} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("prompt missing %q", want)
+		}
+	}
+	if strings.Contains(prompt, "omitted") {
+		t.Error("short thread should not omit messages")
+	}
+}
+
+func TestBuildThreadSummaryPrompt_TruncatesOnRuneBoundary(t *testing.T) {
+	// An odd prefix puts the byte limit in the middle of a two-byte rune.
+	body := "x" + strings.Repeat("é", summaryMaxBodyChars)
+	prompt := buildThreadSummaryPrompt([]*mail.Message{{From: "a", To: "b", Subject: "s", Body: body}})
+	if !utf8.ValidString(prompt) {
+		t.Error("truncation split a multi-byte character")
+	}
+	if !strings.Contains(prompt, "[...truncated]") {
+		t.Error("long body should be truncated")
+	}
+}
+
+func TestBuildThreadSummaryPrompt_DropsOldest(t *testing.T) {
+	var msgs []*mail.Message
+	for i := 0; i < 40; i++ {
+		msgs = append(msgs, &mail.Message{From: "a", To: "b", Subject: "s", Body: strings.Repeat("y", summaryMaxBodyChars)})
+	}
+	msgs[39].Body = "latest decision"
+	prompt := buildThreadSummaryPrompt(msgs)
+
+	if len(prompt) > summaryMaxPromptChars+1000 {
+		t.Errorf("prompt length %d exceeds budget", len(prompt))
+	}
+	if !strings.Contains(prompt, "earlier messages omitted") || !strings.Contains(prompt, "latest decision") {
+		t.Error("long thread should keep the newest messages and note the omission")
+	}
+}
+
+func TestThreadSubjectSkipsSummaries(t *testing.T) {
+	msgs := []*mail.Message{
+		{Subject: summarySubjectPrefix + "Merge plan"},
+		{Subject: "Merge plan"},
+	}
+	if got := threadSubject(msgs); got != "Merge plan" {
+		t.Errorf("threadSubject = %q, want %q", got, "Merge plan")
+	}
+}
+
+func TestSummaryAgentArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		rc   *config.RuntimeConfig
+		want []string
+	}{
+		{"claude", &config.RuntimeConfig{Command: "claude", Args: []string{"--model", "haiku"}, ResolvedAgent: "claude"},
+			[]string{"--model", "haiku", "-p", "PROMPT"}},
+		{"gemini", &config.RuntimeConfig{Command: "gemini", ResolvedAgent: "gemini"},
+			[]string{"-p", "PROMPT"}},
+		{"codex", &config.RuntimeConfig{Command: "codex", ResolvedAgent: "codex"},
+			[]string{"exec", "PROMPT"}},
+	}
+	for _, tt := range tests {
+		if got := summaryAgentArgs(tt.rc, "PROMPT"); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: args = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
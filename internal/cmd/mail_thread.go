@@ -69,7 +69,7 @@ func runMailThread(cmd *cobra.Command, args []string) error {
 			style.Dim.Render(msg.ID),
 			msg.From, msg.To)
 		fmt.Printf("    %s\n",
-			style.Dim.Render(msg.Timestamp.Format("2006-01-02 15:04")))
+			style.Dim.Render(msg.Timestamp.Local().Format("2006-01-02 15:04")))
 
 		if msg.Body != "" {
 			fmt.Printf("    %s\n", msg.Body)
@@ -99,8 +99,11 @@ func runMailReply(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("not in a Gas Town workspace: %w", err)
 	}
 
-	// Determine current address
-	from := detectSender()
+	// Determine current address (the tmux session's agent wins over GT_ROLE/cwd)
+	from, err := resolveMailSender()
+	if err != nil {
+		return err
+	}
 
 	// Get the original message
 	router := mail.NewRouter(workDir)
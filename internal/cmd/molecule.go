@@ -37,6 +37,7 @@ LIFECYCLE:
   gt mol detach        Detach molecule from your hook
   gt mol burn          Discard attached molecule (no record)
   gt mol squash        Compress to digest (permanent record)
+  gt mol retro         Mail the mayor a retrospective of a molecule
 
 TO DISPATCH WORK (with molecules):
   gt sling mol-xxx target   # Pour formula + sling to agent
@@ -261,6 +262,7 @@ func init() {
 	moleculeCmd.AddCommand(moleculeSquashCmd)
 	moleculeCmd.AddCommand(moleculeProgressCmd)
 	moleculeCmd.AddCommand(moleculeDagCmd)
+	moleculeCmd.AddCommand(moleculeRunCmd)
 	moleculeCmd.AddCommand(moleculeAttachCmd)
 	moleculeCmd.AddCommand(moleculeDetachCmd)
 	moleculeCmd.AddCommand(moleculeAttachmentCmd)
@@ -17,6 +17,7 @@ type DAGNode struct {
 	ID           string     `json:"id"`
 	Title        string     `json:"title"`
 	Status       string     `json:"status"`
+	Assignee     string     `json:"assignee,omitempty"`
 	Parallel     bool       `json:"parallel,omitempty"`
 	Dependencies []string   `json:"dependencies,omitempty"`
 	Dependents   []string   `json:"dependents,omitempty"`
@@ -74,46 +75,49 @@ func runMoleculeDag(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("not in a beads workspace: %w", err)
 	}
 
-	b := beads.New(workDir)
+	dag, err := loadMoleculeDAG(beads.New(workDir), rootID)
+	if err != nil {
+		return err
+	}
+
+	// JSON output
+	if moleculeJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(dag)
+	}
+
+	// Human-readable output
+	if dagShowTiers {
+		return outputDAGTiers(dag)
+	}
+	return outputDAGTree(dag)
+}
 
-	// Get the root issue
+// loadMoleculeDAG fetches a molecule root and its steps and builds the DAG.
+func loadMoleculeDAG(b *beads.Beads, rootID string) (*DAGInfo, error) {
 	root, err := b.Show(rootID)
 	if err != nil {
-		return fmt.Errorf("getting root issue: %w", err)
+		return nil, fmt.Errorf("getting root issue: %w", err)
 	}
 
-	// Find all children of the root issue
 	children, err := b.List(beads.ListOptions{
 		Parent:   rootID,
 		Status:   "all",
 		Priority: -1,
 	})
 	if err != nil {
-		return fmt.Errorf("listing children: %w", err)
+		return nil, fmt.Errorf("listing children: %w", err)
 	}
-
 	if len(children) == 0 {
-		return fmt.Errorf("no steps found for %s (not a molecule root?)", rootID)
+		return nil, fmt.Errorf("no steps found for %s (not a molecule root?)", rootID)
 	}
 
-	// Build the DAG
 	dag, err := buildDAG(b, root, children)
 	if err != nil {
-		return fmt.Errorf("building DAG: %w", err)
-	}
-
-	// JSON output
-	if moleculeJSON {
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		return enc.Encode(dag)
+		return nil, fmt.Errorf("building DAG: %w", err)
 	}
-
-	// Human-readable output
-	if dagShowTiers {
-		return outputDAGTiers(dag)
-	}
-	return outputDAGTree(dag)
+	return dag, nil
 }
 
 // buildDAG constructs the DAG from molecule children.
@@ -152,9 +156,10 @@ func buildDAG(b *beads.Beads, root *beads.Issue, children []*beads.Issue) (*DAGI
 		}
 
 		node := &DAGNode{
-			ID:     child.ID,
-			Title:  child.Title,
-			Status: child.Status,
+			ID:       child.ID,
+			Title:    child.Title,
+			Status:   child.Status,
+			Assignee: child.Assignee,
 		}
 
 		// Extract dependencies (all blocking types)
@@ -0,0 +1,373 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var (
+	molRunMaxParallel int
+	molRunDryRun      bool
+	molRunWatch       bool
+	molRunInterval    time.Duration
+	molRunNoBoot      bool
+)
+
+var moleculeRunCmd = &cobra.Command{
+	Use:   "run <molecule-id>",
+	Short: "Dispatch a molecule's steps as their dependencies close",
+	Long: `Execute a molecule by dispatching each step to a polecat once every step it
+depends on is closed.
+
+Each run dispatches the current frontier: open, unassigned steps whose
+blocking dependencies (blocks, conditional-blocks, waits-for) are all
+closed. Steps already assigned or in progress count as in flight and are
+left alone, so running again picks up where the last run stopped. Each step
+is slung as a raw bead to the rig that owns its prefix.
+
+With --watch, the executor keeps polling and dispatches steps as they are
+unblocked, until every step is closed or nothing can make progress.
+
+Examples:
+  gt mol run gt-wisp-abc                    # Dispatch the ready steps
+  gt mol run gt-wisp-abc --max-parallel 3   # At most 3 steps in flight
+  gt mol run gt-wisp-abc --watch            # Run the molecule to completion
+  gt mol run gt-wisp-abc --dry-run
+
+Track progress with gt status --molecule <id>.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runMoleculeRun,
+}
+
+func init() {
+	moleculeRunCmd.Flags().IntVar(&molRunMaxParallel, "max-parallel", 0, "Max steps in flight at once (0 = unlimited)")
+	moleculeRunCmd.Flags().BoolVarP(&molRunDryRun, "dry-run", "n", false, "Show which steps would be dispatched")
+	moleculeRunCmd.Flags().BoolVarP(&molRunWatch, "watch", "w", false, "Keep dispatching as steps unblock until the molecule completes")
+	moleculeRunCmd.Flags().DurationVar(&molRunInterval, "interval", 30*time.Second, "Poll interval with --watch")
+	moleculeRunCmd.Flags().BoolVar(&molRunNoBoot, "no-boot", false, "Don't wake witness/refinery after dispatching")
+}
+
+// MoleculeFrontier partitions a molecule's steps by execution state. Each
+// list is ordered by tier, then ID.
+type MoleculeFrontier struct {
+	Ready    []string `json:"ready"`     // Dependencies closed, unassigned: dispatchable now
+	InFlight []string `json:"in_flight"` // Assigned, hooked, or in progress
+	Blocked  []string `json:"blocked"`   // Waiting on a dependency that isn't closed
+	Done     []string `json:"done"`
+}
+
+// Complete reports whether every step is closed.
+func (f MoleculeFrontier) Complete() bool {
+	return len(f.Ready) == 0 && len(f.InFlight) == 0 && len(f.Blocked) == 0
+}
+
+// Stalled reports whether open steps remain but none can make progress:
+// nothing is ready or in flight, so the blocked steps wait on something
+// outside the molecule (or on a cycle).
+func (f MoleculeFrontier) Stalled() bool {
+	return len(f.Ready) == 0 && len(f.InFlight) == 0 && len(f.Blocked) > 0
+}
+
+// moleculeFrontier computes the frontier from a DAG built by buildDAG.
+func moleculeFrontier(dag *DAGInfo) MoleculeFrontier {
+	var f MoleculeFrontier
+	for _, id := range sortedDAGNodes(dag) {
+		node := dag.Nodes[id]
+		switch node.Status {
+		case "closed", "tombstone":
+			f.Done = append(f.Done, id)
+		case "ready":
+			if node.Assignee != "" {
+				f.InFlight = append(f.InFlight, id)
+			} else {
+				f.Ready = append(f.Ready, id)
+			}
+		case "blocked":
+			f.Blocked = append(f.Blocked, id)
+		default: // in_progress, hooked, and other working states
+			f.InFlight = append(f.InFlight, id)
+		}
+	}
+	return f
+}
+
+// sortedDAGNodes returns node IDs ordered by tier, then ID.
+func sortedDAGNodes(dag *DAGInfo) []string {
+	ids := make([]string, 0, len(dag.Nodes))
+	for id := range dag.Nodes {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		a, b := dag.Nodes[ids[i]], dag.Nodes[ids[j]]
+		if a.Tier != b.Tier {
+			return a.Tier < b.Tier
+		}
+		return a.ID < b.ID
+	})
+	return ids
+}
+
+// planMoleculeDispatch picks the ready steps to dispatch now, keeping at
+// most maxParallel steps in flight (0 = unlimited).
+func planMoleculeDispatch(f MoleculeFrontier, maxParallel int) []string {
+	if maxParallel <= 0 {
+		return f.Ready
+	}
+	slots := maxParallel - len(f.InFlight)
+	if slots <= 0 {
+		return nil
+	}
+	if slots < len(f.Ready) {
+		return f.Ready[:slots]
+	}
+	return f.Ready
+}
+
+// CriticalPathProgress reports how far a molecule is along its critical path.
+type CriticalPathProgress struct {
+	Path      []string `json:"path"`                // Longest dependency chain in the molecule
+	Done      int      `json:"done"`                // Steps on Path that are closed
+	Remaining []string `json:"remaining,omitempty"` // Longest chain of unfinished steps
+}
+
+// criticalPathProgress measures progress along dag.CriticalPath and finds
+// the longest chain of steps still unfinished: however much runs in
+// parallel, at least that many steps must still complete one after another.
+func criticalPathProgress(dag *DAGInfo) CriticalPathProgress {
+	p := CriticalPathProgress{Path: dag.CriticalPath}
+	for _, id := range dag.CriticalPath {
+		if n := dag.Nodes[id]; n != nil && n.Status == "closed" {
+			p.Done++
+		}
+	}
+
+	open := func(id string) bool {
+		n := dag.Nodes[id]
+		return n != nil && n.Status != "closed" && n.Status != "tombstone"
+	}
+	memo := make(map[string][]string)
+	var chain func(id string) []string
+	chain = func(id string) []string {
+		if c, ok := memo[id]; ok {
+			return c
+		}
+		memo[id] = nil // Guards against cycles
+		var longest []string
+		dependents := append([]string(nil), dag.Nodes[id].Dependents...)
+		sort.Strings(dependents)
+		for _, dep := range dependents {
+			if !open(dep) {
+				continue
+			}
+			if c := chain(dep); len(c) > len(longest) {
+				longest = c
+			}
+		}
+		c := append([]string{id}, longest...)
+		memo[id] = c
+		return c
+	}
+	for _, id := range sortedDAGNodes(dag) {
+		if !open(id) {
+			continue
+		}
+		if c := chain(id); len(c) > len(p.Remaining) {
+			p.Remaining = c
+		}
+	}
+	return p
+}
+
+func runMoleculeRun(cmd *cobra.Command, args []string) error {
+	molID := args[0]
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	if molRunWatch && molRunInterval <= 0 {
+		return fmt.Errorf("interval must be positive, got %s", molRunInterval)
+	}
+	b := beads.New(resolveBeadDir(molID))
+
+	// watched is set once this run has seen the molecule incomplete, so
+	// completion is announced only when it happens under our watch.
+	watched := false
+	for {
+		dag, err := loadMoleculeDAG(b, molID)
+		if err != nil {
+			return err
+		}
+		f := moleculeFrontier(dag)
+		if f.Complete() {
+			fmt.Printf("%s Molecule %s complete (%d step(s))\n", style.SuccessPrefix, molID, len(f.Done))
+			if watched {
+				_ = events.LogFeed(events.TypeMoleculeComplete, detectSender(), events.MoleculeCompletePayload(molID, len(f.Done)))
+				fileMoleculeRetroOnComplete(townRoot, molID)
+			}
+			return nil
+		}
+		if f.Stalled() {
+			return fmt.Errorf("molecule %s is stalled: %d blocked step(s) wait on dependencies outside the molecule (%s)",
+				molID, len(f.Blocked), strings.Join(f.Blocked, ", "))
+		}
+
+		toDispatch := planMoleculeDispatch(f, molRunMaxParallel)
+		if err := dispatchMoleculeSteps(townRoot, dag, toDispatch); err != nil {
+			return err
+		}
+		if len(toDispatch) == 0 {
+			fmt.Printf("%s %s: %d in flight, %d blocked, %d/%d done\n", style.Dim.Render("○"), molID,
+				len(f.InFlight), len(f.Blocked), len(f.Done), dag.TotalNodes)
+		}
+
+		if !molRunWatch || molRunDryRun {
+			return nil
+		}
+		watched = true
+		time.Sleep(molRunInterval)
+	}
+}
+
+// dispatchMoleculeSteps slings each step to the rig that owns its prefix.
+func dispatchMoleculeSteps(townRoot string, dag *DAGInfo, stepIDs []string) error {
+	if len(stepIDs) == 0 {
+		return nil
+	}
+	if molRunDryRun {
+		fmt.Printf("%s Would dispatch %d step(s) from %s:\n", style.Bold.Render("DRY-RUN"), len(stepIDs), dag.RootID)
+		for _, id := range stepIDs {
+			fmt.Printf("  %s -> %s (%s)\n", id, resolveRigForBead(townRoot, id), dag.Nodes[id].Title)
+		}
+		return nil
+	}
+
+	successCount := 0
+	successfulRigs := make(map[string]bool)
+	for i, id := range stepIDs {
+		rigName := resolveRigForBead(townRoot, id)
+		if rigName == "" {
+			fmt.Printf("  %s %s: cannot resolve rig from prefix %q\n", style.Dim.Render("✗"), id, beads.ExtractPrefix(id))
+			continue
+		}
+		fmt.Printf("%s Dispatching %s → %s (%s)\n", style.Bold.Render("▶"), id, rigName, dag.Nodes[id].Title)
+		_, err := executeSling(SlingParams{
+			BeadID:        id,
+			RigName:       rigName,
+			HookRawBead:   true, // Steps are already part of the molecule
+			NoConvoy:      true, // The molecule is the organizing structure
+			NoBoot:        molRunNoBoot,
+			CallerContext: "mol-run",
+			TownRoot:      townRoot,
+			BeadsDir:      filepath.Join(townRoot, ".beads"),
+		})
+		if err != nil {
+			fmt.Printf("  %s %s: %v\n", style.Dim.Render("✗"), id, err)
+			continue
+		}
+		successCount++
+		successfulRigs[rigName] = true
+
+		// Brief delay between spawns to avoid Dolt contention
+		if i < len(stepIDs)-1 {
+			time.Sleep(500 * time.Millisecond)
+		}
+	}
+
+	if !molRunNoBoot {
+		for rig := range successfulRigs {
+			wakeRigAgents(rig)
+		}
+	}
+	if successCount == 0 {
+		return fmt.Errorf("all %d dispatch attempts failed for molecule %s", len(stepIDs), dag.RootID)
+	}
+	return nil
+}
+
+// MoleculeExecStatus is the execution state reported by gt status --molecule.
+type MoleculeExecStatus struct {
+	RootID       string               `json:"root_id"`
+	RootTitle    string               `json:"root_title"`
+	Total        int                  `json:"total"`
+	Frontier     MoleculeFrontier     `json:"frontier"`
+	CriticalPath CriticalPathProgress `json:"critical_path"`
+}
+
+// runMoleculeExecStatus prints a molecule's frontier and critical-path
+// progress for gt status --molecule.
+func runMoleculeExecStatus(molID string, asJSON bool) error {
+	if _, err := workspace.FindFromCwdOrError(); err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	dag, err := loadMoleculeDAG(beads.New(resolveBeadDir(molID)), molID)
+	if err != nil {
+		return err
+	}
+	st := MoleculeExecStatus{
+		RootID:       dag.RootID,
+		RootTitle:    dag.RootTitle,
+		Total:        dag.TotalNodes,
+		Frontier:     moleculeFrontier(dag),
+		CriticalPath: criticalPathProgress(dag),
+	}
+
+	if asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(st)
+	}
+
+	f := st.Frontier
+	fmt.Printf("%s %s: %s\n", style.Bold.Render("🧬 Molecule"), st.RootID, st.RootTitle)
+	fmt.Printf("   Steps: %d/%d done · %d in flight · %d ready · %d blocked\n",
+		len(f.Done), st.Total, len(f.InFlight), len(f.Ready), len(f.Blocked))
+
+	cp := st.CriticalPath
+	if len(cp.Path) > 0 {
+		fmt.Printf("   Critical path: %d/%d done  %s\n", cp.Done, len(cp.Path),
+			style.Dim.Render(strings.Join(cp.Path, " → ")))
+	}
+	if len(cp.Remaining) > 0 {
+		fmt.Printf("   Remaining chain: %d step(s)  %s\n", len(cp.Remaining),
+			style.Dim.Render(strings.Join(cp.Remaining, " → ")))
+	}
+
+	printSteps := func(label string, ids []string) {
+		if len(ids) == 0 {
+			return
+		}
+		fmt.Printf("\n   %s\n", style.Bold.Render(label))
+		for _, id := range ids {
+			n := dag.Nodes[id]
+			line := fmt.Sprintf("     %s %s", id, n.Title)
+			if n.Assignee != "" {
+				line += " " + style.Dim.Render("("+n.Assignee+")")
+			}
+			fmt.Println(line)
+		}
+	}
+	printSteps("In flight", f.InFlight)
+	printSteps("Ready", f.Ready)
+	printSteps("Blocked", f.Blocked)
+
+	switch {
+	case f.Complete():
+		fmt.Printf("\n%s All steps closed\n", style.SuccessPrefix)
+	case f.Stalled():
+		fmt.Printf("\n%s Stalled: blocked steps wait on dependencies outside the molecule\n", style.WarningPrefix)
+	case len(f.Ready) > 0:
+		fmt.Printf("\n   Dispatch ready steps with: gt mol run %s\n", st.RootID)
+	}
+	return nil
+}
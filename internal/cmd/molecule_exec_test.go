@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+// testMoleculeDAG builds a DAG the way buildDAG does, from step statuses and
+// dependency edges. Open steps become ready or blocked.
+func testMoleculeDAG(status map[string]string, assignee map[string]string, deps map[string][]string) *DAGInfo {
+	dag := &DAGInfo{RootID: "gt-mol", Nodes: make(map[string]*DAGNode)}
+	for id, st := range status {
+		dag.Nodes[id] = &DAGNode{ID: id, Status: st, Assignee: assignee[id], Dependencies: deps[id]}
+		dag.TotalNodes++
+	}
+	for id, node := range dag.Nodes {
+		for _, d := range node.Dependencies {
+			dag.Nodes[d].Dependents = append(dag.Nodes[d].Dependents, id)
+			if node.Status == "open" && status[d] != "closed" {
+				node.Status = "blocked"
+			}
+		}
+		if node.Status == "open" {
+			node.Status = "ready"
+		}
+	}
+	computeTiers(dag)
+	dag.CriticalPath = findCriticalPath(dag)
+	return dag
+}
+
+// Diamond with a tail: a → {b, c} → d → e.
+var diamondDeps = map[string][]string{"b": {"a"}, "c": {"a"}, "d": {"b", "c"}, "e": {"d"}}
+
+func TestMoleculeFrontier(t *testing.T) {
+	dag := testMoleculeDAG(
+		map[string]string{"a": "closed", "b": "open", "c": "in_progress", "d": "open", "e": "open"},
+		nil, diamondDeps)
+	f := moleculeFrontier(dag)
+	want := MoleculeFrontier{Ready: []string{"b"}, InFlight: []string{"c"}, Blocked: []string{"d", "e"}, Done: []string{"a"}}
+	if !reflect.DeepEqual(f, want) {
+		t.Errorf("frontier = %+v, want %+v", f, want)
+	}
+	if f.Complete() || f.Stalled() {
+		t.Errorf("Complete=%v Stalled=%v, want both false", f.Complete(), f.Stalled())
+	}
+
+	// A ready step someone already took is in flight, not dispatchable.
+	dag = testMoleculeDAG(
+		map[string]string{"a": "closed", "b": "open", "c": "open", "d": "open", "e": "open"},
+		map[string]string{"b": "gastown/polecats/nux"}, diamondDeps)
+	f = moleculeFrontier(dag)
+	if !reflect.DeepEqual(f.Ready, []string{"c"}) || !reflect.DeepEqual(f.InFlight, []string{"b"}) {
+		t.Errorf("ready %v in flight %v, want [c] [b]", f.Ready, f.InFlight)
+	}
+}
+
+func TestMoleculeFrontier_CompleteAndStalled(t *testing.T) {
+	done := moleculeFrontier(testMoleculeDAG(map[string]string{"a": "closed", "b": "closed"}, nil, map[string][]string{"b": {"a"}}))
+	if !done.Complete() {
+		t.Errorf("all closed: Complete() = false, frontier %+v", done)
+	}
+
+	// c depends on a bead outside the molecule, so it can never become ready.
+	dag := testMoleculeDAG(map[string]string{"a": "closed", "b": "closed"}, nil, nil)
+	dag.Nodes["c"] = &DAGNode{ID: "c", Status: "blocked", Dependencies: []string{"gt-elsewhere"}}
+	if f := moleculeFrontier(dag); !f.Stalled() {
+		t.Errorf("external dep: Stalled() = false, frontier %+v", f)
+	}
+}
+
+func TestPlanMoleculeDispatch(t *testing.T) {
+	f := MoleculeFrontier{Ready: []string{"a", "b", "c"}, InFlight: []string{"x"}}
+	tests := []struct {
+		max  int
+		want []string
+	}{
+		{0, []string{"a", "b", "c"}},
+		{1, nil},
+		{3, []string{"a", "b"}},
+		{10, []string{"a", "b", "c"}},
+	}
+	for _, tt := range tests {
+		if got := planMoleculeDispatch(f, tt.max); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("max %d: got %v, want %v", tt.max, got, tt.want)
+		}
+	}
+}
+
+func TestCriticalPathProgress(t *testing.T) {
+	dag := testMoleculeDAG(
+		map[string]string{"a": "closed", "b": "closed", "c": "in_progress", "d": "open", "e": "open"},
+		nil, diamondDeps)
+	p := criticalPathProgress(dag)
+	if len(p.Path) != 4 || p.Path[0] != "a" || p.Path[3] != "e" {
+		t.Errorf("path = %v, want a → (b|c) → d → e", p.Path)
+	}
+	// a and one of b/c are closed; whether c is on the path decides the count.
+	wantDone := 2
+	if p.Path[1] == "c" {
+		wantDone = 1
+	}
+	if p.Done != wantDone {
+		t.Errorf("done = %d, want %d on path %v", p.Done, wantDone, p.Path)
+	}
+	if want := []string{"c", "d", "e"}; !reflect.DeepEqual(p.Remaining, want) {
+		t.Errorf("remaining = %v, want %v", p.Remaining, want)
+	}
+
+	all := testMoleculeDAG(map[string]string{"a": "closed", "b": "closed"}, nil, map[string][]string{"b": {"a"}})
+	if p := criticalPathProgress(all); p.Done != 2 || len(p.Remaining) != 0 {
+		t.Errorf("complete molecule: %+v", p)
+	}
+}
@@ -230,7 +230,7 @@ func runMoleculeSquash(cmd *cobra.Command, args []string) (retErr error) {
 	if jitterMax > 0 {
 		//nolint:gosec // weak RNG is fine for jitter
 		sleep := time.Duration(rand.Int63n(int64(jitterMax)))
-		fmt.Fprintf(os.Stderr, "jitter: sleeping %v before squash\n", sleep)
+		logger.Debug("jitter: sleeping before squash", "sleep", sleep)
 		select {
 		case <-cmd.Context().Done():
 			return cmd.Context().Err()
@@ -0,0 +1,419 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/util"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var (
+	molRetroPrint bool
+	molRetroForce bool
+	molRetroJSON  bool
+)
+
+var moleculeRetroCmd = &cobra.Command{
+	Use:   "retro <molecule-id>",
+	Short: "File a retrospective for a completed molecule",
+	Long: `Summarize how a molecule ran and deliver the summary to the mayor.
+
+The retrospective covers wall-clock time, how long each step took, the
+agents involved, nudges sent to them, their branches and merges, and mail
+to and from them while the molecule ran. It is filed as a town bead
+(labeled gt:retro) and mailed to the mayor.
+
+A retrospective is filed automatically when a molecule completes under
+'gt mol step done' or 'gt mol run --watch'; this command files one by hand
+or regenerates it. A molecule gets one retrospective unless --force is used.
+
+Step durations run from the step being slung or hooked (or, failing that,
+created) to its close. Counts come from the town event log, so activity
+that wasn't logged there is not counted. Merges count when they name a
+branch the molecule's work was done on, or the molecule or one of its steps
+as their issue, up to the molecule's close.
+
+Examples:
+  gt mol retro gt-mol-abc           # File and mail the retrospective
+  gt mol retro gt-mol-abc --print   # Just print it
+  gt mol retro gt-mol-abc --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runMoleculeRetro,
+}
+
+func init() {
+	moleculeRetroCmd.Flags().BoolVar(&molRetroPrint, "print", false, "Print the retrospective without filing or mailing it")
+	moleculeRetroCmd.Flags().BoolVar(&molRetroForce, "force", false, "File a new retrospective even if one was filed already")
+	moleculeRetroCmd.Flags().BoolVar(&molRetroJSON, "json", false, "Print the retrospective as JSON (implies --print)")
+	moleculeCmd.AddCommand(moleculeRetroCmd)
+}
+
+// retroLabel marks retrospective beads; retroMoleculeLabel ties one to its
+// molecule, so a molecule gets one retrospective.
+const retroLabel = "gt:retro"
+
+func retroMoleculeLabel(molID string) string { return "retro:" + molID }
+
+// moleculeRetro is a completed molecule's retrospective.
+type moleculeRetro struct {
+	Molecule    string              `json:"molecule"`
+	Title       string              `json:"title"`
+	Start       time.Time           `json:"start"`
+	End         time.Time           `json:"end"`
+	Closed      time.Time           `json:"closed,omitempty"`
+	Steps       []moleculeRetroStep `json:"steps"`
+	Agents      []string            `json:"agents"`
+	Nudges      int                 `json:"nudges"`
+	Mail        int                 `json:"mail"`
+	Branches    []string            `json:"branches,omitempty"`
+	Merged      int                 `json:"merged"`
+	MergeFailed int                 `json:"merge_failed"`
+}
+
+// moleculeRetroStep is one step's line in a retrospective.
+type moleculeRetroStep struct {
+	ID       string        `json:"id"`
+	Title    string        `json:"title"`
+	Status   string        `json:"status"`
+	Assignee string        `json:"assignee,omitempty"`
+	Start    time.Time     `json:"start"`
+	End      time.Time     `json:"end,omitempty"`
+	Duration time.Duration `json:"duration_ns"`
+}
+
+// WallClock is the time from the molecule's creation to its last close.
+func (r *moleculeRetro) WallClock() time.Duration {
+	if r.Start.IsZero() || r.End.Before(r.Start) {
+		return 0
+	}
+	return r.End.Sub(r.Start)
+}
+
+func runMoleculeRetro(cmd *cobra.Command, args []string) error {
+	molID := args[0]
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	if molRetroPrint || molRetroJSON {
+		retro, err := buildMoleculeRetro(townRoot, molID)
+		if err != nil {
+			return err
+		}
+		if molRetroJSON {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			return enc.Encode(retro)
+		}
+		fmt.Print(renderMoleculeRetro(retro))
+		return nil
+	}
+
+	beadID, err := fileMoleculeRetro(townRoot, molID, molRetroForce)
+	if err != nil {
+		return err
+	}
+	if beadID == "" {
+		fmt.Printf("%s %s already has a retrospective (use --force to file another)\n", style.Dim.Render("○"), molID)
+		return nil
+	}
+	fmt.Printf("%s Retrospective %s filed and mailed to the mayor\n", style.SuccessPrefix, beadID)
+	return nil
+}
+
+// fileMoleculeRetroOnComplete files a just-completed molecule's
+// retrospective. Failures are warnings: completion has already happened.
+func fileMoleculeRetroOnComplete(townRoot, molID string) {
+	beadID, err := fileMoleculeRetro(townRoot, molID, false)
+	if err != nil {
+		style.PrintWarning("could not file retrospective for %s: %v", molID, err)
+		return
+	}
+	if beadID != "" {
+		fmt.Printf("%s Retrospective %s mailed to the mayor\n", style.SuccessPrefix, beadID)
+	}
+}
+
+// fileMoleculeRetro builds a molecule's retrospective, files it as a town
+// bead, and mails it to the mayor. Unless force is set, it returns "" without
+// filing if the molecule already has one.
+func fileMoleculeRetro(townRoot, molID string, force bool) (string, error) {
+	town := beads.New(townRoot)
+	if !force {
+		existing, err := town.List(beads.ListOptions{Label: retroMoleculeLabel(molID), Status: "all", Priority: -1})
+		if err != nil {
+			return "", fmt.Errorf("checking for an existing retrospective: %w", err)
+		}
+		if len(existing) > 0 {
+			return "", nil
+		}
+	}
+
+	retro, err := buildMoleculeRetro(townRoot, molID)
+	if err != nil {
+		return "", err
+	}
+	body := renderMoleculeRetro(retro)
+	sender, err := resolveMailSender()
+	if err != nil {
+		return "", err
+	}
+	issue, err := town.Create(beads.CreateOptions{
+		Title:       fmt.Sprintf("Retro: %s (%s)", retro.Title, molID),
+		Labels:      []string{retroLabel, retroMoleculeLabel(molID)},
+		Priority:    4,
+		Description: body,
+		Actor:       sender,
+	})
+	if err != nil {
+		return "", fmt.Errorf("filing retrospective bead: %w", err)
+	}
+
+	router := mail.NewRouter(townRoot)
+	defer router.WaitPendingNotifications()
+	if err := router.Send(&mail.Message{
+		From:     sender,
+		To:       "mayor/",
+		Subject:  fmt.Sprintf("RETRO: %s (%s, %s)", retro.Title, molID, formatDuration(retro.WallClock())),
+		Body:     body + fmt.Sprintf("\nFiled as %s.\n", issue.ID),
+		Type:     mail.TypeNotification,
+		Priority: mail.PriorityLow,
+	}); err != nil {
+		return issue.ID, fmt.Errorf("mailing retrospective %s to the mayor: %w", issue.ID, err)
+	}
+	return issue.ID, nil
+}
+
+// buildMoleculeRetro gathers a molecule's steps from beads and its agents'
+// activity from the town event log.
+func buildMoleculeRetro(townRoot, molID string) (*moleculeRetro, error) {
+	b := beads.New(resolveBeadDir(molID))
+	root, err := b.Show(molID)
+	if err != nil {
+		return nil, fmt.Errorf("getting molecule %s: %w", molID, err)
+	}
+	children, err := b.List(beads.ListOptions{Parent: molID, Status: "all", Priority: -1})
+	if err != nil {
+		return nil, fmt.Errorf("listing steps of %s: %w", molID, err)
+	}
+	if len(children) == 0 {
+		return nil, fmt.Errorf("no steps found for %s (not a molecule root?)", molID)
+	}
+
+	retro := &moleculeRetro{Molecule: molID, Title: root.Title}
+	retro.Start, _ = util.ParseTimestamp(root.CreatedAt)
+	retro.Closed, _ = util.ParseTimestamp(root.ClosedAt)
+	for _, c := range children {
+		step := moleculeRetroStep{ID: c.ID, Title: c.Title, Status: c.Status, Assignee: c.Assignee}
+		step.Start, _ = util.ParseTimestamp(c.CreatedAt)
+		step.End, _ = util.ParseTimestamp(c.ClosedAt)
+		retro.Steps = append(retro.Steps, step)
+		if retro.Start.IsZero() || (!step.Start.IsZero() && step.Start.Before(retro.Start)) {
+			retro.Start = step.Start
+		}
+	}
+
+	past, _, err := readPastEvents(townRoot, events.Filter{Types: []string{
+		events.TypeSling, events.TypeHook, events.TypeDone,
+		events.TypeNudge, events.TypeMail, events.TypeMerged, events.TypeMergeFailed,
+	}}, retro.Start)
+	if err != nil {
+		return nil, err
+	}
+	tallyMoleculeRetro(retro, past)
+	return retro, nil
+}
+
+// tallyMoleculeRetro fills in step starts, durations, agents, branches, and
+// the nudge, mail, and merge counts from the molecule's events, and sets the
+// end of the molecule to its last step close.
+func tallyMoleculeRetro(r *moleculeRetro, evs []events.Event) {
+	steps := make(map[string]*moleculeRetroStep, len(r.Steps))
+	agents := make(map[string]bool)
+	issues := map[string]bool{r.Molecule: true} // Beads a done or merge may name
+	for i := range r.Steps {
+		s := &r.Steps[i]
+		steps[s.ID] = s
+		issues[s.ID] = true
+		if s.Assignee != "" {
+			agents[retroAgentKey(s.Assignee)] = true
+		}
+	}
+	payload := func(e events.Event, key string) string {
+		v, _ := e.Payload[key].(string)
+		return v
+	}
+
+	// First pass: who worked on the steps, when each started, and branches.
+	branches := make(map[string]bool)
+	started := make(map[string]time.Time) // Step ID -> first sling or hook
+	for _, e := range evs {
+		// Work done on the molecule as a whole reports done against the
+		// molecule root rather than a step.
+		if e.Type == events.TypeDone && payload(e, "bead") == r.Molecule {
+			if e.Actor != "" {
+				agents[retroAgentKey(e.Actor)] = true
+			}
+			if branch := payload(e, "branch"); branch != "" {
+				branches[branch] = true
+			}
+			continue
+		}
+		s := steps[payload(e, "bead")]
+		if s == nil {
+			continue
+		}
+		switch e.Type {
+		case events.TypeSling, events.TypeHook:
+			if ts, err := util.ParseTimestamp(e.Timestamp); err == nil {
+				if first, ok := started[s.ID]; !ok || ts.Before(first) {
+					started[s.ID] = ts
+				}
+			}
+			if target := payload(e, "target"); e.Type == events.TypeSling && target != "" {
+				agents[retroAgentKey(target)] = true
+			}
+			if e.Type == events.TypeHook && e.Actor != "" {
+				agents[retroAgentKey(e.Actor)] = true
+			}
+		case events.TypeDone:
+			if e.Actor != "" {
+				agents[retroAgentKey(e.Actor)] = true
+			}
+			if branch := payload(e, "branch"); branch != "" {
+				branches[branch] = true
+			}
+		}
+	}
+
+	for i := range r.Steps {
+		s := &r.Steps[i]
+		if ts, ok := started[s.ID]; ok {
+			s.Start = ts
+		}
+		if !s.Start.IsZero() && s.End.After(s.Start) {
+			s.Duration = s.End.Sub(s.Start)
+		}
+		if s.End.After(r.End) {
+			r.End = s.End
+		}
+	}
+	sort.Slice(r.Steps, func(i, j int) bool {
+		if !r.Steps[i].Start.Equal(r.Steps[j].Start) {
+			return r.Steps[i].Start.Before(r.Steps[j].Start)
+		}
+		return r.Steps[i].ID < r.Steps[j].ID
+	})
+
+	// Second pass: traffic to and from those agents while the molecule ran,
+	// and merges of its branches or issues until it closed. Merges land after
+	// the work is done, so they are bounded by the molecule's close rather
+	// than its last step's.
+	end := r.End
+	if end.IsZero() {
+		end = time.Now()
+	}
+	mergeEnd := r.Closed
+	if mergeEnd.IsZero() {
+		mergeEnd = time.Now()
+	}
+	for _, e := range evs {
+		ts, err := util.ParseTimestamp(e.Timestamp)
+		if err != nil || ts.Before(r.Start) {
+			continue
+		}
+		switch e.Type {
+		case events.TypeNudge:
+			if !ts.After(end) && agents[retroAgentKey(payload(e, "target"))] {
+				r.Nudges++
+			}
+		case events.TypeMail:
+			if !ts.After(end) && (agents[retroAgentKey(e.Actor)] || agents[retroAgentKey(payload(e, "to"))]) {
+				r.Mail++
+			}
+		case events.TypeMerged, events.TypeMergeFailed:
+			if ts.After(mergeEnd) || !(branches[payload(e, "branch")] || issues[payload(e, "issue")]) {
+				continue
+			}
+			if e.Type == events.TypeMerged {
+				r.Merged++
+			} else {
+				r.MergeFailed++
+			}
+		}
+	}
+
+	for agent := range agents {
+		r.Agents = append(r.Agents, agent)
+	}
+	sort.Strings(r.Agents)
+	for branch := range branches {
+		r.Branches = append(r.Branches, branch)
+	}
+	sort.Strings(r.Branches)
+}
+
+// retroAgentKey reduces an agent address to the form shared by assignees,
+// nudge targets, and mail addresses ("rig/polecats/name" -> "rig/name").
+func retroAgentKey(addr string) string {
+	return mailNormalizedAgentID(normalizeAgentID(addr))
+}
+
+// renderMoleculeRetro formats a retrospective for the bead and the mail.
+func renderMoleculeRetro(r *moleculeRetro) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Retrospective: %s (%s)\n\n", r.Title, r.Molecule)
+	fmt.Fprintf(&sb, "Wall clock: %s", formatDuration(r.WallClock()))
+	if !r.Start.IsZero() && !r.End.IsZero() {
+		fmt.Fprintf(&sb, " (%s to %s)", r.Start.Format(time.RFC3339), r.End.Format(time.RFC3339))
+	}
+	sb.WriteString("\n")
+	fmt.Fprintf(&sb, "Steps:      %d\n", len(r.Steps))
+	fmt.Fprintf(&sb, "Agents:     %s\n", retroList(r.Agents))
+	fmt.Fprintf(&sb, "Nudges:     %d\n", r.Nudges)
+	fmt.Fprintf(&sb, "Mail:       %d message(s)\n", r.Mail)
+	fmt.Fprintf(&sb, "Branches:   %s\n", retroList(r.Branches))
+	fmt.Fprintf(&sb, "Merges:     %d merged, %d failed\n", r.Merged, r.MergeFailed)
+
+	sb.WriteString("\nSteps:\n")
+	var slowest *moleculeRetroStep
+	for i := range r.Steps {
+		s := &r.Steps[i]
+		took := "-"
+		if s.Duration > 0 {
+			took = formatDuration(s.Duration)
+			if slowest == nil || s.Duration > slowest.Duration {
+				slowest = s
+			}
+		} else if s.Status != "closed" {
+			took = s.Status
+		}
+		line := fmt.Sprintf("  %-12s %-10s %s", s.ID, took, s.Title)
+		if s.Assignee != "" {
+			line += "  (" + s.Assignee + ")"
+		}
+		sb.WriteString(line + "\n")
+	}
+	if slowest != nil && len(r.Steps) > 1 {
+		fmt.Fprintf(&sb, "\nSlowest step: %s (%s)\n", slowest.ID, formatDuration(slowest.Duration))
+	}
+	return sb.String()
+}
+
+func retroList(items []string) string {
+	if len(items) == 0 {
+		return "none"
+	}
+	return strings.Join(items, ", ")
+}
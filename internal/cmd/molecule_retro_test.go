@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/events"
+)
+
+func TestTallyMoleculeRetro(t *testing.T) {
+	t0 := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	at := func(min int) string { return t0.Add(time.Duration(min) * time.Minute).Format(time.RFC3339) }
+	ev := func(min int, typ, actor string, payload map[string]interface{}) events.Event {
+		return events.Event{Timestamp: at(min), Type: typ, Actor: actor, Payload: payload}
+	}
+
+	r := &moleculeRetro{
+		Molecule: "gt-mol-1",
+		Title:    "Ship it",
+		Start:    t0,
+		Steps: []moleculeRetroStep{
+			{ID: "gt-mol-1.2", Title: "Test", Status: "closed", Assignee: "gastown/polecats/nux", Start: t0, End: t0.Add(90 * time.Minute)},
+			{ID: "gt-mol-1.1", Title: "Build", Status: "closed", Assignee: "gastown/polecats/toast", Start: t0, End: t0.Add(40 * time.Minute)},
+		},
+	}
+	tallyMoleculeRetro(r, []events.Event{
+		ev(10, events.TypeSling, "mayor", events.SlingPayload("gt-mol-1.1", "gastown/toast")),
+		ev(30, events.TypeHook, "gastown/nux", events.HookPayload("gt-mol-1.2")),
+		ev(35, events.TypeDone, "gastown/polecats/toast", events.DonePayload("gt-mol-1.1", "polecat/toast")),
+		ev(20, events.TypeNudge, "witness", events.NudgePayload("gastown", "gastown/toast", "wake up")),
+		ev(25, events.TypeNudge, "witness", events.NudgePayload("gastown", "gastown/other", "not ours")),
+		ev(200, events.TypeNudge, "witness", events.NudgePayload("gastown", "gastown/toast", "after the end")),
+		ev(15, events.TypeMail, "gastown/toast", events.MailPayload("mayor/", "question")),
+		ev(16, events.TypeMail, "mayor/", events.MailPayload("gastown/polecats/nux", "answer")),
+		ev(17, events.TypeMail, "mayor/", events.MailPayload("deacon/", "unrelated")),
+		ev(50, events.TypeMergeFailed, "gastown/refinery", events.MergePayload("mr-1", "gastown/toast", "polecat/toast", "conflict")),
+		ev(60, events.TypeMerged, "gastown/refinery", events.MergePayload("mr-1", "gastown/toast", "polecat/toast", "")),
+		ev(61, events.TypeMerged, "gastown/refinery", events.MergePayload("mr-2", "gastown/x", "polecat/x", "")),
+	})
+
+	if !r.End.Equal(t0.Add(90 * time.Minute)) {
+		t.Errorf("End = %v, want the last step close", r.End)
+	}
+	if r.WallClock() != 90*time.Minute {
+		t.Errorf("WallClock() = %v, want 1h30m", r.WallClock())
+	}
+	// Steps are ordered by start: Build was slung at +10m, Test hooked at +30m.
+	if r.Steps[0].ID != "gt-mol-1.1" || r.Steps[0].Duration != 30*time.Minute {
+		t.Errorf("first step = %s (%v), want gt-mol-1.1 taking 30m", r.Steps[0].ID, r.Steps[0].Duration)
+	}
+	if r.Steps[1].ID != "gt-mol-1.2" || r.Steps[1].Duration != 60*time.Minute {
+		t.Errorf("second step = %s (%v), want gt-mol-1.2 taking 1h", r.Steps[1].ID, r.Steps[1].Duration)
+	}
+	if want := []string{"gastown/nux", "gastown/toast"}; !slices.Equal(r.Agents, want) {
+		t.Errorf("Agents = %v, want %v", r.Agents, want)
+	}
+	if r.Nudges != 1 || r.Mail != 2 {
+		t.Errorf("Nudges, Mail = %d, %d; want 1, 2", r.Nudges, r.Mail)
+	}
+	if !slices.Equal(r.Branches, []string{"polecat/toast"}) || r.Merged != 1 || r.MergeFailed != 1 {
+		t.Errorf("Branches %v, merged %d, failed %d; want polecat/toast, 1, 1", r.Branches, r.Merged, r.MergeFailed)
+	}
+
+	out := renderMoleculeRetro(r)
+	for _, want := range []string{"Ship it (gt-mol-1)", "Wall clock: 1h 30m", "gastown/nux, gastown/toast", "1 merged, 1 failed", "Slowest step: gt-mol-1.2 (1h 0m)"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("rendered retro missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestTallyMoleculeRetroMerges(t *testing.T) {
+	t0 := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	at := func(min int) string { return t0.Add(time.Duration(min) * time.Minute).Format(time.RFC3339) }
+	merge := func(min int, typ string, payload map[string]interface{}) events.Event {
+		return events.Event{Timestamp: at(min), Type: typ, Actor: "gastown/refinery", Payload: payload}
+	}
+
+	r := &moleculeRetro{
+		Molecule: "gt-mol-1",
+		Start:    t0,
+		Closed:   t0.Add(120 * time.Minute),
+		Steps: []moleculeRetroStep{
+			{ID: "gt-mol-1.1", Status: "closed", Assignee: "gastown/polecats/toast", Start: t0, End: t0.Add(40 * time.Minute)},
+		},
+	}
+	tallyMoleculeRetro(r, []events.Event{
+		// Done against the molecule root still names the branch.
+		{Timestamp: at(45), Type: events.TypeDone, Actor: "gastown/polecats/toast", Payload: events.DonePayload("gt-mol-1", "polecat/toast-abc")},
+		merge(60, events.TypeMerged, events.MergePayload("mr-1", "gastown/toast", "polecat/toast-abc", "")),
+		// Merges from `gt activity emit` may name the issue instead.
+		merge(70, events.TypeMergeFailed, map[string]interface{}{"issue": "gt-mol-1.1", "reason": "conflict"}),
+		// Same worker, unrelated branch and issue: not ours.
+		merge(80, events.TypeMerged, events.MergePayload("mr-2", "gastown/toast", "polecat/toast-xyz", "")),
+		// After the molecule closed.
+		merge(130, events.TypeMerged, events.MergePayload("mr-3", "gastown/toast", "polecat/toast-abc", "")),
+	})
+
+	if !slices.Equal(r.Branches, []string{"polecat/toast-abc"}) {
+		t.Errorf("Branches = %v, want [polecat/toast-abc]", r.Branches)
+	}
+	if r.Merged != 1 || r.MergeFailed != 1 {
+		t.Errorf("merged %d, failed %d; want 1, 1", r.Merged, r.MergeFailed)
+	}
+}
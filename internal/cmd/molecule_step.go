@@ -453,9 +453,12 @@ func handleMoleculeComplete(cwd, townRoot, moleculeID string, dryRun bool) error
 	if dryRun {
 		fmt.Printf("[dry-run] Would unpin work for %s\n", agentID)
 		fmt.Printf("[dry-run] Would send POLECAT_DONE to witness\n")
+		fmt.Printf("[dry-run] Would mail a retrospective of %s to the mayor\n", moleculeID)
 		return nil
 	}
 
+	fileMoleculeRetroOnComplete(townRoot, moleculeID)
+
 	// Unpin the molecule bead (set status to open, will be closed by gt done or manually)
 	workDir, err := findLocalBeadsDir()
 	if err == nil {
@@ -296,6 +296,9 @@ type IntegrationStatusOutput struct {
 	AutoLandEnabled bool                         `json:"auto_land_enabled"`
 	ChildrenTotal   int                          `json:"children_total"`
 	ChildrenClosed  int                          `json:"children_closed"`
+	ReviewRequired  bool                         `json:"review_required"`
+	Review          string                       `json:"review,omitempty"`      // none, pending, approved, changes-requested, or stale
+	ReviewBead      string                       `json:"review_bead,omitempty"` // Latest review request
 }
 
 // IntegrationStatusMRSummary represents a merge request in the integration status output.
@@ -584,6 +587,30 @@ func runMqIntegrationLand(cmd *cobra.Command, args []string) error {
 		fmt.Printf("  %s No children found (landing empty integration branch)\n", style.Dim.Render("ℹ"))
 	}
 
+	// Verify the witness approved this head, if the rig requires review
+	if integrationReviewRequired(r.Path) {
+		fmt.Printf("Checking witness review...\n")
+		head, err := g.Rev("origin/" + branchName)
+		if err != nil {
+			return fmt.Errorf("resolving %s: %w", branchName, err)
+		}
+		state, review, err := integrationReviewState(bd, epicID, head)
+		if err != nil {
+			return fmt.Errorf("checking review: %w", err)
+		}
+		if state != beads.ReviewApproved {
+			if review != nil {
+				fmt.Printf("\n  %s Review of %s at %s: %s (%s)\n\n", style.Bold.Render("⚠"), branchName, shortSHA(head), state, review.ID)
+			}
+			if !mqIntegrationLandForce {
+				return fmt.Errorf("cannot land: witness review is %s (gt review request %s; use --force to override)", state, epicID)
+			}
+			fmt.Printf("  %s Proceeding anyway (--force)\n", style.Dim.Render("⚠"))
+		} else {
+			fmt.Printf("  %s Approved at %s (%s)\n", style.Bold.Render("✓"), shortSHA(head), review.ID)
+		}
+	}
+
 	// Dry run stops here
 	if mqIntegrationLandDryRun {
 		fmt.Printf("\n%s Dry run complete. Would perform:\n", style.Bold.Render("🔍"))
@@ -936,6 +963,25 @@ func runMqIntegrationStatus(cmd *cobra.Command, args []string) error {
 
 	readyToLand := isReadyToLand(aheadCount, childrenTotal, childrenClosed, len(pendingMRs))
 
+	// With review required, the branch lands only once the witness has
+	// approved its current head.
+	reviewRequired := integrationReviewRequired(r.Path)
+	var reviewState, reviewBead string
+	if reviewRequired {
+		reviewState = reviewStateNone
+		if head, err := g.Rev("origin/" + branchName); err == nil {
+			state, review, err := integrationReviewState(bd, epicID, head)
+			if err != nil {
+				return err
+			}
+			reviewState = state
+			if review != nil {
+				reviewBead = review.ID
+			}
+		}
+		readyToLand = readyToLand && reviewState == beads.ReviewApproved
+	}
+
 	// Build output structure
 	output := IntegrationStatusOutput{
 		Epic:            epicID,
@@ -949,6 +995,9 @@ func runMqIntegrationStatus(cmd *cobra.Command, args []string) error {
 		AutoLandEnabled: autoLandEnabled,
 		ChildrenTotal:   childrenTotal,
 		ChildrenClosed:  childrenClosed,
+		ReviewRequired:  reviewRequired,
+		Review:          reviewState,
+		ReviewBead:      reviewBead,
 	}
 
 	for _, mr := range mergedMRs {
@@ -997,6 +1046,13 @@ func printIntegrationStatus(output *IntegrationStatusOutput) error {
 	}
 	fmt.Printf("Ahead of %s: %d commits\n", output.BaseBranch, output.AheadOfBase)
 	fmt.Printf("Epic children: %d/%d closed\n", output.ChildrenClosed, output.ChildrenTotal)
+	if output.ReviewRequired {
+		review := output.Review
+		if output.ReviewBead != "" {
+			review += " (" + output.ReviewBead + ")"
+		}
+		fmt.Printf("Witness review: %s\n", review)
+	}
 
 	// Merged MRs
 	fmt.Printf("\nMerged MRs (%d):\n", len(output.MergedMRs))
@@ -1043,6 +1099,15 @@ func printIntegrationStatus(output *IntegrationStatusOutput) error {
 				style.Dim.Render("○"), len(output.PendingMRs))
 		} else if output.AheadOfBase == 0 {
 			fmt.Printf("%s No commits ahead of %s.\n", style.Dim.Render("○"), output.BaseBranch)
+		} else if output.ReviewRequired {
+			switch output.Review {
+			case reviewStateNone, reviewStateStale:
+				fmt.Printf("%s Needs witness review. Run: gt review request %s\n", style.Dim.Render("○"), output.Epic)
+			case beads.ReviewChangesRequested:
+				fmt.Printf("%s Witness requested changes (%s).\n", style.Dim.Render("○"), output.ReviewBead)
+			default:
+				fmt.Printf("%s Waiting for witness review (%s).\n", style.Dim.Render("○"), output.ReviewBead)
+			}
 		}
 		// Show auto-land status even when not ready
 		if output.AutoLandEnabled {
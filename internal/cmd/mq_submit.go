@@ -31,12 +31,19 @@ var issuePattern = regexp.MustCompile(`([a-z]+-[a-z0-9]+(?:\.[0-9]+)?)`)
 
 // parseBranchName extracts issue ID and worker from a branch name.
 // Supports formats:
+//   - polecat/<rig>/<worker>/<issue>  → issue=<issue>, worker=<worker> (see git.PolecatBranchName)
 //   - polecat/<worker>/<issue>  → issue=<issue>, worker=<worker>
 //   - polecat/<worker>-<timestamp>  → issue="", worker=<worker> (modern polecat branches)
 //   - <issue>                   → issue=<issue>, worker=""
 func parseBranchName(branch string) branchInfo {
 	info := branchInfo{Branch: branch}
 
+	if _, worker, issue, ok := git.ParsePolecatBranch(branch); ok {
+		info.Worker = worker
+		info.Issue = issue
+		return info
+	}
+
 	// Try polecat/<worker>/<issue> or polecat/<worker>/<issue>@<timestamp> format
 	if strings.HasPrefix(branch, constants.BranchPolecatPrefix) {
 		parts := strings.SplitN(branch, "/", 3)
@@ -26,6 +26,18 @@ func TestParseBranchName(t *testing.T) {
 			wantIssue:  "gt-abc.1",
 			wantWorker: "Worker",
 		},
+		{
+			name:       "convention polecat branch",
+			branch:     "polecat/gastown/furiosa/gt-jns7.1",
+			wantIssue:  "gt-jns7.1",
+			wantWorker: "furiosa",
+		},
+		{
+			name:       "convention idle polecat branch",
+			branch:     "polecat/gastown/furiosa/idle",
+			wantIssue:  "",
+			wantWorker: "furiosa",
+		},
 		{
 			name:       "polecat branch with issue and timestamp",
 			branch:     "polecat/furiosa/gt-jns7.1@mk123456",
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -13,11 +14,13 @@ import (
 	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/errkind"
 	"github.com/steveyegge/gastown/internal/events"
 	"github.com/steveyegge/gastown/internal/nudge"
 	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/telemetry"
+	"github.com/steveyegge/gastown/internal/templates"
 	"github.com/steveyegge/gastown/internal/tmux"
 	"github.com/steveyegge/gastown/internal/workspace"
 )
@@ -29,19 +32,27 @@ var (
 	nudgeIfFreshFlag  bool
 	nudgeModeFlag     string
 	nudgePriorityFlag string
+	nudgeTemplateFlag string
+	nudgeVarsFlag     []string
+
+	nudgeNoRestoreFlag     bool
+	nudgeTimingProfileFlag string
+	nudgeDryRunFlag        bool
+	nudgeWaitDeliveryFlag  bool
+	nudgeJSONFlag          bool
 )
 
 // Nudge delivery modes.
 const (
 	// NudgeModeImmediate sends directly via tmux send-keys (current behavior).
 	// This interrupts in-flight work but guarantees immediate delivery.
-	NudgeModeImmediate = "immediate"
+	NudgeModeImmediate = nudge.ModeImmediate
 	// NudgeModeQueue writes to a file queue; agent picks up via hook at next
 	// turn boundary. Zero interruption but delivery depends on agent turn frequency.
-	NudgeModeQueue = "queue"
+	NudgeModeQueue = nudge.ModeQueue
 	// NudgeModeWaitIdle waits for the agent to become idle (prompt visible),
 	// then delivers directly. Falls back to queue on timeout. Best of both worlds.
-	NudgeModeWaitIdle = "wait-idle"
+	NudgeModeWaitIdle = nudge.ModeWaitIdle
 )
 
 func init() {
@@ -52,6 +63,13 @@ func init() {
 	nudgeCmd.Flags().BoolVar(&nudgeIfFreshFlag, "if-fresh", false, "Only send if caller's tmux session is <60s old (suppresses compaction nudges)")
 	nudgeCmd.Flags().StringVar(&nudgeModeFlag, "mode", NudgeModeWaitIdle, "Delivery mode: wait-idle (default), queue, or immediate")
 	nudgeCmd.Flags().StringVar(&nudgePriorityFlag, "priority", nudge.PriorityNormal, "Queue priority: normal (default) or urgent")
+	nudgeCmd.Flags().StringVar(&nudgeTemplateFlag, "template", "", "Render the message from a nudge template (see gt templates list)")
+	nudgeCmd.Flags().StringArrayVar(&nudgeVarsFlag, "var", nil, "Template variable (key=value) for --template, can be repeated")
+	nudgeCmd.Flags().BoolVar(&nudgeNoRestoreFlag, "no-restore", false, "Submit the nudge with any text already at the agent's prompt instead of setting it aside")
+	nudgeCmd.Flags().StringVar(&nudgeTimingProfileFlag, "timing-profile", "", "Type the message char-by-char: <delayMs>[,<jitterMs>] or \"default\"")
+	nudgeCmd.Flags().BoolVar(&nudgeDryRunFlag, "dry-run", false, "Resolve the target and message without sending")
+	nudgeCmd.Flags().BoolVar(&nudgeWaitDeliveryFlag, "wait-for-delivery", false, "If the nudge is queued, wait (up to 10m) for the agent to pick it up")
+	nudgeCmd.Flags().BoolVar(&nudgeJSONFlag, "json", false, "Output the result as JSON")
 }
 
 var nudgeCmd = &cobra.Command{
@@ -93,9 +111,27 @@ Channel syntax:
                   ~/gt/config/messaging.json under "nudge_channels".
                   Patterns like "gastown/polecats/*" are expanded.
 
+Text already typed at the agent's prompt (say, a half-written instruction
+from a human) is set aside before the nudge is typed and restored, without
+submitting it, afterwards; gt nudge undo brings it back if delivery fails
+midway. --no-restore appends the nudge to that text and submits both.
+
+Delivery options:
+  --timing-profile  Type the message char-by-char (<delayMs>[,<jitterMs>] or
+                    "default") for TUIs that drop bulk input
+  --dry-run         Resolve the target and render the message, but don't send
+  --wait-for-delivery
+                    If the nudge ends up queued (queue mode, or a busy agent
+                    in wait-idle mode), wait up to 10m for the agent's hook
+                    to pick it up
+  --json            Print the outcome (status: delivered, queued, held,
+                    dry-run, skipped, expired) as JSON
+
 DND (Do Not Disturb):
   If the target has DND enabled (gt dnd on), the nudge is skipped.
-  Use --force to override DND and send anyway.
+  If the target is quiet (gt quiet), a non-urgent nudge is held and
+  delivered in a digest when the quiet period ends.
+  Use --force to override DND or quiet and send anyway.
 
 Examples:
   gt nudge greenplace/furiosa "Check your mail and start working"
@@ -104,6 +140,11 @@ Examples:
   gt nudge witness "Check polecat health"
   gt nudge deacon session-started
   gt nudge channel:workers "New priority work available"
+  gt nudge gastown/alpha "Rebase on main" --mode=queue --wait-for-delivery --json
+  gt nudge mayor "Status?" --dry-run
+
+  # Render the message from a town-customizable template (gt templates):
+  gt nudge gastown/crew/max --template mayor-directive --var Directive="Pause the refactor" --var Bead=gt-abc12
 
   # Use --stdin for messages with special characters or formatting:
   gt nudge gastown/alpha --stdin <<'EOF'
@@ -119,66 +160,71 @@ Examples:
 // Sessions older than this are considered compaction/clear restarts, not new sessions.
 const ifFreshMaxAge = 60 * time.Second
 
+// nudgeDeliveryTimeout is how long --wait-for-delivery waits for a queued
+// nudge to be drained, and nudgeDeliveryPoll how often it checks.
+const (
+	nudgeDeliveryTimeout = 10 * time.Minute
+	nudgeDeliveryPoll    = time.Second
+)
+
 // waitIdleTimeout is how long --mode=wait-idle will poll before falling back to queue.
 // This is a var (not const) so tests can override it to avoid 15s waits.
-var waitIdleTimeout = 15 * time.Second
+var waitIdleTimeout = nudge.DefaultIdleTimeout
 
-// deliverNudge routes a nudge based on the --mode flag.
-// For "immediate" mode: sends directly via tmux (current behavior).
-// For "queue" mode: writes to the nudge queue for cooperative delivery.
-// For "wait-idle" mode: waits for idle, then delivers or falls back to queue.
-func deliverNudge(t *tmux.Tmux, sessionName, message, sender string) error {
+// deliverNudge delivers a nudge through the nudge scheduler, per the --mode,
+// --priority, and --force flags. Non-urgent nudges to a quiet session
+// (gt quiet) are held for its digest unless --force is set.
+func deliverNudge(t *tmux.Tmux, sessionName, message, sender string) (nudge.Status, error) {
 	townRoot, _ := workspace.FindFromCwd()
+	if townRoot == "" && nudgeModeFlag != NudgeModeImmediate {
+		// Queueing needs the workspace; fail explicitly rather than
+		// silently degrading to immediate (destructive) delivery.
+		return nudge.StatusFailed, fmt.Errorf("--mode=%s requires a Gas Town workspace", nudgeModeFlag)
+	}
+	if nudgeDryRunFlag {
+		return nudgeStatusDryRun, nil
+	}
 
-	// For direct tmux delivery, prefix with sender attribution.
-	// Queue-based delivery stores Sender as a separate field and
-	// FormatForInjection adds the prefix, so we must NOT double-prefix.
-	prefixedMessage := fmt.Sprintf("[from %s] %s", sender, message)
-
-	switch nudgeModeFlag {
-	case NudgeModeQueue:
-		if townRoot == "" {
-			return fmt.Errorf("--mode=queue requires a Gas Town workspace")
-		}
-		return nudge.Enqueue(townRoot, sessionName, nudge.QueuedNudge{
-			Sender:   sender,
-			Message:  message,
-			Priority: nudgePriorityFlag,
-		})
-
-	case NudgeModeWaitIdle:
-		if townRoot == "" {
-			// wait-idle needs workspace for queue fallback — fail explicitly
-			// rather than silently degrading to immediate (destructive) delivery.
-			return fmt.Errorf("--mode=wait-idle requires a Gas Town workspace")
-		}
-		// Try to wait for idle
-		err := t.WaitForIdle(sessionName, waitIdleTimeout)
-		if err == nil {
-			// Agent is idle — safe to deliver directly
-			return t.NudgeSession(sessionName, prefixedMessage)
-		}
-		// Terminal errors (session gone, no server) — propagate, don't queue.
-		// Queueing a nudge for a dead session means it will never be delivered.
-		if errors.Is(err, tmux.ErrSessionNotFound) || errors.Is(err, tmux.ErrNoServer) {
-			return fmt.Errorf("wait-idle: %w", err)
-		}
-		// Timeout (agent busy) — queue instead
-		if qErr := nudge.Enqueue(townRoot, sessionName, nudge.QueuedNudge{
-			Sender:   sender,
-			Message:  message,
-			Priority: nudgePriorityFlag,
-		}); qErr != nil {
-			// Queue failed — fall back to immediate as last resort.
-			// Better to interrupt than lose the message entirely.
-			fmt.Fprintf(os.Stderr, "Warning: queue fallback failed (%v), delivering immediately\n", qErr)
-			return t.NudgeSession(sessionName, prefixedMessage)
+	delivery := tmux.NudgeOptions{RestoreInput: !nudgeNoRestoreFlag}
+	if nudgeTimingProfileFlag != "" {
+		profile, err := parseNudgeTimingProfile(nudgeTimingProfileFlag)
+		if err != nil {
+			return nudge.StatusFailed, err
 		}
-		return nil
+		delivery.Typing = &profile
+	}
+
+	scheduler := nudge.NewScheduler(townRoot, t)
+	scheduler.Window = 0 // One nudge per call; nothing to coalesce
+	status, err := scheduler.Submit(sessionName, message, nudge.SubmitOptions{
+		Sender:      sender,
+		Priority:    nudgePriorityFlag,
+		Mode:        nudgeModeFlag,
+		Force:       nudgeForceFlag,
+		IdleTimeout: waitIdleTimeout,
+		Delivery:    delivery,
+	}).Wait()
+	if status == nudge.StatusHeld && !nudgeJSONFlag {
+		fmt.Printf("%s %s is quiet - nudge held for its digest\n", style.Dim.Render("○"), sessionName)
+	}
+	if errors.Is(err, nudge.ErrCircuitOpen) {
+		return status, errkind.Wrap(errkind.Transport, err,
+			fmt.Sprintf("the nudge was kept as a dead letter; once the session is fixed, run 'gt nudge circuit probe %s'", sessionName))
+	}
+	return status, err
+}
 
-	default: // NudgeModeImmediate
-		return t.NudgeSession(sessionName, prefixedMessage)
+// parseNudgeTimingProfile parses --timing-profile: "default" or an
+// EnvTypingProfile value, "<delayMs>[,<jitterMs>]".
+func parseNudgeTimingProfile(s string) (tmux.TypingProfile, error) {
+	if s == "default" {
+		return tmux.DefaultTypingProfile, nil
 	}
+	profile, ok := tmux.ParseTypingProfile(s)
+	if !ok {
+		return tmux.TypingProfile{}, fmt.Errorf("invalid --timing-profile %q: want <delayMs>[,<jitterMs>] (e.g. 25,15) or \"default\"", s)
+	}
+	return profile, nil
 }
 
 // validNudgeModes is the set of allowed --mode values.
@@ -209,6 +255,11 @@ func runNudge(cmd *cobra.Command, args []string) (retErr error) {
 	if !validNudgePriorities[nudgePriorityFlag] {
 		return fmt.Errorf("invalid --priority %q: must be one of normal, urgent", nudgePriorityFlag)
 	}
+	if nudgeTimingProfileFlag != "" {
+		if _, err := parseNudgeTimingProfile(nudgeTimingProfileFlag); err != nil {
+			return err
+		}
+	}
 
 	// --if-fresh: skip nudge if the caller's tmux session is older than 60s.
 	// This prevents compaction/clear SessionStart hooks from spamming the deacon.
@@ -241,9 +292,18 @@ func runNudge(cmd *cobra.Command, args []string) (retErr error) {
 		nudgeMessageFlag = strings.TrimRight(string(data), "\n")
 	}
 
-	// Get message from -m flag or positional arg
+	// Get message from --template, -m flag, or positional arg
 	var message string
-	if nudgeMessageFlag != "" {
+	if nudgeTemplateFlag != "" {
+		if nudgeMessageFlag != "" || len(args) >= 2 {
+			return fmt.Errorf("cannot use --template with a message")
+		}
+		rendered, err := renderNudgeTemplate(nudgeTemplateFlag, nudgeVarsFlag)
+		if err != nil {
+			return err
+		}
+		message = rendered
+	} else if nudgeMessageFlag != "" {
 		message = nudgeMessageFlag
 	} else if len(args) >= 2 {
 		message = args[1]
@@ -274,6 +334,9 @@ func runNudge(cmd *cobra.Command, args []string) (retErr error) {
 
 	// Handle channel syntax: channel:<name>
 	if strings.HasPrefix(target, "channel:") {
+		if nudgeJSONFlag || nudgeWaitDeliveryFlag {
+			return fmt.Errorf("--json and --wait-for-delivery are not supported for channel targets")
+		}
 		channelName := strings.TrimPrefix(target, "channel:")
 		return runNudgeChannel(channelName, message, sender)
 	}
@@ -283,6 +346,10 @@ func runNudge(cmd *cobra.Command, args []string) (retErr error) {
 	if townRoot != "" && !nudgeForceFlag {
 		shouldSend, level, _ := shouldNudgeTarget(townRoot, target, nudgeForceFlag)
 		if !shouldSend {
+			if nudgeJSONFlag {
+				return printNudgeResult(nudgeResult{Target: target, Mode: nudgeModeFlag,
+					Status: string(nudgeStatusSkipped), Reason: "DND " + level})
+			}
 			fmt.Printf("%s Target has DND enabled (%s) - nudge skipped\n", style.Dim.Render("○"), level)
 			fmt.Printf("  Use %s to override\n", style.Bold.Render("--force"))
 			return nil
@@ -323,22 +390,17 @@ func runNudge(cmd *cobra.Command, args []string) (retErr error) {
 		}
 		if !exists {
 			// Deacon not running - this is not an error, just log and return
+			if nudgeJSONFlag {
+				return printNudgeResult(nudgeResult{Target: target, Session: deaconSession, Mode: nudgeModeFlag,
+					Status: string(nudgeStatusSkipped), Reason: "deacon not running"})
+			}
 			fmt.Printf("%s Deacon not running, nudge skipped\n", style.Dim.Render("○"))
 			return nil
 		}
 
-		if err := deliverNudge(t, deaconSession, message, sender); err != nil {
-			return fmt.Errorf("nudging deacon: %w", err)
-		}
-
-		fmt.Printf("%s Nudged deacon (%s)\n", style.Bold.Render("✓"), nudgeModeFlag)
-
-		// Log nudge event
-		if townRoot, err := workspace.FindFromCwd(); err == nil && townRoot != "" {
-			_ = LogNudge(townRoot, constants.RoleDeacon, message)
-		}
-		_ = events.LogFeed(events.TypeNudge, sender, events.NudgePayload("", constants.RoleDeacon, message))
-		return nil
+		return sendNudge(t, nudgeTarget{
+			session: deaconSession, label: "deacon", logTarget: constants.RoleDeacon,
+		}, message, sender)
 	}
 
 	// Check if target is rig/polecat format or raw session name
@@ -395,41 +457,136 @@ func runNudge(cmd *cobra.Command, args []string) (retErr error) {
 		}
 
 		// Send nudge using the configured delivery mode
-		if err := deliverNudge(t, sessionName, message, sender); err != nil {
-			return fmt.Errorf("nudging session: %w", err)
-		}
+		return sendNudge(t, nudgeTarget{
+			session: sessionName, label: rigName + "/" + polecatName, rig: rigName, logTarget: target,
+		}, message, sender)
+	}
 
-		fmt.Printf("%s Nudged %s/%s (%s)\n", style.Bold.Render("✓"), rigName, polecatName, nudgeModeFlag)
+	// Raw session name (legacy)
+	exists, err := t.HasSession(target)
+	if err != nil {
+		return fmt.Errorf("checking session: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("session %q not found", target)
+	}
+	return sendNudge(t, nudgeTarget{session: target, label: target, logTarget: target}, message, sender)
+}
 
-		// Log nudge event
-		if townRoot, err := workspace.FindFromCwd(); err == nil && townRoot != "" {
-			_ = LogNudge(townRoot, target, message)
-		}
-		_ = events.LogFeed(events.TypeNudge, sender, events.NudgePayload(rigName, target, message))
-	} else {
-		// Raw session name (legacy)
-		exists, err := t.HasSession(target)
-		if err != nil {
-			return fmt.Errorf("checking session: %w", err)
+// nudgeTarget is a resolved gt nudge target.
+type nudgeTarget struct {
+	session   string // tmux session
+	label     string // As shown to the user
+	rig       string // For the feed event; empty for town-level targets
+	logTarget string // As recorded in the nudge log and feed
+}
+
+// sendNudge delivers a nudge to one resolved target, per the delivery flags,
+// then reports and logs it.
+func sendNudge(t *tmux.Tmux, nt nudgeTarget, message, sender string) error {
+	res := nudgeResult{Target: nt.label, Session: nt.session, Mode: nudgeModeFlag, Message: message}
+	submitted := time.Now()
+	status, err := deliverNudge(t, nt.session, message, sender)
+	if err == nil && status == nudge.StatusQueued && nudgeWaitDeliveryFlag {
+		if err = waitForNudgeDrain(nt.session, submitted); err == nil {
+			status = nudge.StatusDelivered
+			res.Drained = true
+		} else if errors.Is(err, errNudgeExpired) {
+			status = nudgeStatusExpired
+		}
+	}
+	res.Status = string(status)
+	if err != nil {
+		if nudgeJSONFlag {
+			res.Error = err.Error()
+			_ = printNudgeResult(res)
 		}
-		if !exists {
-			return fmt.Errorf("session %q not found", target)
+		return fmt.Errorf("nudging %s: %w", nt.label, err)
+	}
+	if status == nudgeStatusDryRun {
+		if nudgeJSONFlag {
+			return printNudgeResult(res)
 		}
+		fmt.Printf("%s Would nudge %s (%s, session %s):\n  %s\n", style.Dim.Render("○"), nt.label, nudgeModeFlag, nt.session, message)
+		return nil
+	}
 
-		if err := deliverNudge(t, target, message, sender); err != nil {
-			return fmt.Errorf("nudging session: %w", err)
+	if nudgeJSONFlag {
+		if err := printNudgeResult(res); err != nil {
+			return err
 		}
+	} else {
+		fmt.Printf("%s Nudged %s (%s)\n", style.Bold.Render("✓"), nt.label, nudgeModeFlag)
+	}
+
+	// Log nudge event
+	if townRoot, err := workspace.FindFromCwd(); err == nil && townRoot != "" {
+		_ = LogNudge(townRoot, nt.logTarget, message)
+	}
+	_ = events.LogFeed(events.TypeNudge, sender, events.NudgePayload(nt.rig, nt.logTarget, message))
+	return nil
+}
+
+// nudgeResult is the gt nudge --json output.
+type nudgeResult struct {
+	Target  string `json:"target"`
+	Session string `json:"session,omitempty"`
+	Mode    string `json:"mode"`
+	// Status is a nudge.Status, or "dry-run" or "skipped".
+	Status  string `json:"status"`
+	Drained bool   `json:"drained,omitempty"` // --wait-for-delivery saw the queued nudge picked up
+	Reason  string `json:"reason,omitempty"`  // Why it was skipped
+	Message string `json:"message,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// gt nudge outcomes beyond nudge.Status.
+const (
+	nudgeStatusDryRun  nudge.Status = "dry-run" // --dry-run: resolved, not sent
+	nudgeStatusSkipped nudge.Status = "skipped" // DND, or the target isn't running
+	nudgeStatusExpired nudge.Status = "expired" // --wait-for-delivery: dropped unread
+)
 
-		fmt.Printf("✓ Nudged %s (%s)\n", target, nudgeModeFlag)
+// errNudgeExpired reports a queued nudge discarded at its TTL before the
+// agent's hook picked it up.
+var errNudgeExpired = errors.New("queued nudge expired before it was picked up")
 
-		// Log nudge event
-		if townRoot, err := workspace.FindFromCwd(); err == nil && townRoot != "" {
-			_ = LogNudge(townRoot, target, message)
+func printNudgeResult(res nudgeResult) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(res)
+}
+
+// waitForNudgeDrain waits until the nudges queued for session since
+// submitted have left the queue. Drain discards expired nudges unread, so a
+// queue that empties after they expire returns errNudgeExpired rather than
+// counting as delivery.
+func waitForNudgeDrain(session string, submitted time.Time) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return err
+	}
+	deadline := time.Now().Add(nudgeDeliveryTimeout)
+	var expires time.Time
+	for {
+		n, exp, err := nudge.PendingSince(townRoot, session, submitted)
+		if err != nil {
+			return err
+		}
+		if exp.After(expires) {
+			expires = exp
+		}
+		if n == 0 {
+			if !expires.IsZero() && time.Now().After(expires) {
+				return fmt.Errorf("%w (%s)", errNudgeExpired, session)
+			}
+			return nil
 		}
-		_ = events.LogFeed(events.TypeNudge, sender, events.NudgePayload("", target, message))
+		if time.Now().After(deadline) {
+			return fmt.Errorf("queued nudge not picked up within %s (still queued for %s's next turn)", nudgeDeliveryTimeout, session)
+		}
+		time.Sleep(nudgeDeliveryPoll)
 	}
-
-	return nil
 }
 
 // runNudgeChannel nudges all members of a named channel.
@@ -502,7 +659,7 @@ func runNudgeChannel(channelName, message, sender string) error {
 			}
 		}
 
-		if err := deliverNudge(t, sessionName, message, sender); err != nil {
+		if _, err := deliverNudge(t, sessionName, message, sender); err != nil {
 			failed++
 			failures = append(failures, fmt.Sprintf("%s: %v", sessionName, err))
 			fmt.Printf("  %s %s\n", style.ErrorPrefix, sessionName)
@@ -724,3 +881,28 @@ func addressToAgentBeadID(address string) string {
 		return session.PolecatSessionName(session.PrefixFor(rig), role)
 	}
 }
+
+// renderNudgeTemplate renders a nudge template with the town's overrides
+// for gt nudge --template.
+func renderNudgeTemplate(name string, pairs []string) (string, error) {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return "", fmt.Errorf("--template requires a Gas Town workspace: %w", err)
+	}
+	vars, err := parseTemplateVars(pairs)
+	if err != nil {
+		return "", err
+	}
+	tmpl, err := templates.NewForTown(townRoot)
+	if err != nil {
+		return "", err
+	}
+	kind, name, err := tmpl.ParseRef(name)
+	if err != nil {
+		return "", err
+	}
+	if kind != templates.KindNudge {
+		return "", fmt.Errorf("%s/%s is not a nudge template", kind, name)
+	}
+	return tmpl.RenderNudge(name, vars)
+}
@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/nudge"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// nudgeProbeMessage is the harmless nudge sent to test a session.
+const nudgeProbeMessage = "[gt] nudge circuit probe - no action needed"
+
+var nudgeCircuitCmd = &cobra.Command{
+	Use:   "circuit",
+	Short: "Show nudge circuit breakers for sessions with failing deliveries",
+	Long: `Show sessions whose nudge deliveries are failing, and their circuit state.
+
+After nudge.circuit_threshold consecutive failed deliveries to a session
+(default 5; 0 disables), its circuit opens: further nudges fail fast with a
+"nudge circuit open" error instead of waiting on a dead session, and are
+saved to .runtime/nudge_dead_letter/<session>/. After
+nudge.circuit_cooldown (default 10m) the circuit is half-open and the next
+nudge is tried for real. A successful delivery closes the circuit and
+requeues the dead letters for the session's next turn.
+
+Use 'gt nudge circuit probe' to test a session right away.
+
+Examples:
+  gt nudge circuit
+  gt nudge circuit probe gastown/polecats/nux`,
+	Args: cobra.NoArgs,
+	RunE: runNudgeCircuit,
+}
+
+var nudgeCircuitProbeCmd = &cobra.Command{
+	Use:   "probe <agent>",
+	Short: "Send a test nudge to close an open circuit",
+	Long: `Send a harmless nudge to a session, bypassing its circuit.
+
+If it is delivered, the circuit closes and the session's dead letters are
+requeued. If it fails, the failure counts like any other and the circuit
+(re)opens for another cool-down.
+
+The agent can be a role shortcut, an address, or a session name.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runNudgeCircuitProbe,
+}
+
+func init() {
+	nudgeCircuitCmd.AddCommand(nudgeCircuitProbeCmd)
+	nudgeCmd.AddCommand(nudgeCircuitCmd)
+}
+
+func runNudgeCircuit(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	records, err := nudge.LoadFailures(townRoot)
+	if err != nil {
+		return fmt.Errorf("loading nudge failures: %w", err)
+	}
+	deadLetters, err := nudge.DeadLetterCounts(townRoot)
+	if err != nil {
+		return err
+	}
+
+	sessions := make(map[string]bool)
+	for s := range records {
+		sessions[s] = true
+	}
+	for s := range deadLetters {
+		sessions[s] = true
+	}
+	if len(sessions) == 0 {
+		fmt.Println("No failing nudge deliveries.")
+		return nil
+	}
+	names := make([]string, 0, len(sessions))
+	for s := range sessions {
+		names = append(names, s)
+	}
+	sort.Strings(names)
+
+	cooldown := config.LoadOperationalConfig(townRoot).GetNudgeConfig().CircuitCooldownD()
+	now := time.Now()
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "SESSION\tCIRCUIT\tFAILURES\tDEAD LETTERS\tLAST ERROR")
+	for _, s := range names {
+		r := records[s]
+		state := r.CircuitState(now, cooldown)
+		stateText := style.Success.Render(state)
+		switch state {
+		case nudge.CircuitOpen:
+			stateText = style.Error.Render(state) + style.Dim.Render(" (opened "+formatAge(r.OpenedAt)+")")
+		case nudge.CircuitHalfOpen:
+			stateText = style.Warning.Render(state)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%s\n", s, stateText, r.Count, deadLetters[s], r.LastError)
+	}
+	return w.Flush()
+}
+
+func runNudgeCircuitProbe(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	sessionName, err := resolveRoleToSession(args[0])
+	if err != nil {
+		return err
+	}
+
+	records, err := nudge.LoadFailures(townRoot)
+	if err != nil {
+		return fmt.Errorf("loading nudge failures: %w", err)
+	}
+	before, tracked := records[sessionName]
+
+	t := tmux.NewTmux()
+	probeErr := t.NudgeSession(sessionName, nudgeProbeMessage)
+	if err := nudge.RecordDelivery(townRoot, sessionName, probeErr); err != nil {
+		style.PrintWarning("could not record probe result: %v", err)
+	}
+	if probeErr != nil {
+		return fmt.Errorf("probe to %s failed: %w", sessionName, probeErr)
+	}
+
+	switch {
+	case tracked && !before.OpenedAt.IsZero():
+		fmt.Printf("%s Probe delivered to %s; circuit closed\n", style.SuccessPrefix, sessionName)
+		if letters, err := nudge.LoadDeadLetters(townRoot, sessionName); err == nil && len(letters) > 0 {
+			style.PrintWarning("%d dead letter(s) could not be requeued", len(letters))
+		}
+	case tracked:
+		fmt.Printf("%s Probe delivered to %s; failure count reset\n", style.SuccessPrefix, sessionName)
+	default:
+		fmt.Printf("%s Probe delivered to %s %s\n", style.SuccessPrefix, sessionName, style.Dim.Render("(circuit was closed)"))
+	}
+	return nil
+}
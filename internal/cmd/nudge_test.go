@@ -7,6 +7,7 @@ import (
 
 	"github.com/steveyegge/gastown/internal/nudge"
 	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/tmux"
 )
 
 func setupNudgeTestRegistry(t *testing.T) {
@@ -390,3 +391,46 @@ func TestValidModeMapsMatchConstants(t *testing.T) {
 		}
 	}
 }
+
+func TestParseNudgeTimingProfile(t *testing.T) {
+	if got, err := parseNudgeTimingProfile("default"); err != nil || got != tmux.DefaultTypingProfile {
+		t.Errorf("parseNudgeTimingProfile(default) = %+v, %v", got, err)
+	}
+	got, err := parseNudgeTimingProfile("25,15")
+	if err != nil || got.Delay != 25*time.Millisecond || got.Jitter != 15*time.Millisecond {
+		t.Errorf("parseNudgeTimingProfile(25,15) = %+v, %v", got, err)
+	}
+	for _, bad := range []string{"fast", "-5", "25,x"} {
+		if _, err := parseNudgeTimingProfile(bad); err == nil {
+			t.Errorf("parseNudgeTimingProfile(%q) should fail", bad)
+		}
+	}
+}
+
+func TestNudgeDeliveryFlagValidation(t *testing.T) {
+	origProfile := nudgeTimingProfileFlag
+	origJSON := nudgeJSONFlag
+	origMessage := nudgeMessageFlag
+	origStdin := nudgeStdinFlag
+	defer func() {
+		nudgeTimingProfileFlag = origProfile
+		nudgeJSONFlag = origJSON
+		nudgeMessageFlag = origMessage
+		nudgeStdinFlag = origStdin
+	}()
+	nudgeStdinFlag = false
+	nudgeMessageFlag = ""
+
+	nudgeTimingProfileFlag = "fast"
+	err := runNudge(nudgeCmd, []string{"gastown/alpha", "hello"})
+	if err == nil || !strings.Contains(err.Error(), `invalid --timing-profile "fast"`) {
+		t.Errorf("bad --timing-profile: got %v", err)
+	}
+
+	nudgeTimingProfileFlag = ""
+	nudgeJSONFlag = true
+	err = runNudge(nudgeCmd, []string{"channel:workers", "hello"})
+	if err == nil || !strings.Contains(err.Error(), "not supported for channel targets") {
+		t.Errorf("--json with a channel: got %v", err)
+	}
+}
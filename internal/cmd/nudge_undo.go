@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var (
+	nudgeUndoPrint bool
+	nudgeUndoRaw   bool
+)
+
+var nudgeUndoCmd = &cobra.Command{
+	Use:   "undo <agent>",
+	Short: "Restore input cleared by a replacing nudge",
+	Long: `Restore the input that was last cleared from an agent's prompt.
+
+Before a nudge clears an agent's input line (Ctrl-U), the text at the prompt
+is saved to .runtime/nudge_undo/<session>.json. This types it back into the
+prompt without submitting it, so a half-written instruction is not lost when
+the clear fires at the wrong moment. Secrets in the saved text (API keys,
+tokens) are redacted, so they are not restored.
+
+If no prompt line was visible at clear time, the bottom of the pane was
+saved instead. That snapshot is only printed, since typing it back could
+send pane output as input; pass --raw to type it anyway.
+
+The agent can be a role shortcut, an address, or a session name.
+
+Examples:
+  gt nudge undo gastown/crew/max
+  gt nudge undo mayor --print`,
+	Args: cobra.ExactArgs(1),
+	RunE: runNudgeUndo,
+}
+
+func init() {
+	nudgeUndoCmd.Flags().BoolVar(&nudgeUndoPrint, "print", false, "Print the saved input instead of restoring it")
+	nudgeUndoCmd.Flags().BoolVar(&nudgeUndoRaw, "raw", false, "Type back a raw pane snapshot (no prompt was found when it was saved)")
+	nudgeCmd.AddCommand(nudgeUndoCmd)
+}
+
+func runNudgeUndo(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	sessionName, err := resolveRoleToSession(args[0])
+	if err != nil {
+		return err
+	}
+
+	snap, err := tmux.LoadInputSnapshot(townRoot, sessionName)
+	if err != nil {
+		return err
+	}
+
+	if nudgeUndoPrint || (snap.Raw && !nudgeUndoRaw) {
+		if snap.Raw && !nudgeUndoPrint {
+			fmt.Printf("%s No prompt was visible when the input was cleared; saved pane region (use --raw to type it back):\n\n",
+				style.WarningPrefix)
+		}
+		fmt.Println(snap.Text)
+		return nil
+	}
+
+	t := tmux.NewTmux()
+	if exists, err := t.HasSession(sessionName); err != nil {
+		return fmt.Errorf("checking session: %w", err)
+	} else if !exists {
+		return fmt.Errorf("session %q not found", sessionName)
+	}
+	if err := t.RestoreInput(sessionName, snap.Text); err != nil {
+		return fmt.Errorf("restoring input: %w", err)
+	}
+	time.Sleep(200 * time.Millisecond)
+	if lines, err := t.CapturePaneLines(sessionName, 50); err == nil && !tmux.CaptureContainsText(lines, snap.Text) {
+		style.PrintWarning("restored text not visible in %s yet; check the prompt before submitting", sessionName)
+	}
+	fmt.Printf("%s Restored input to %s %s\n",
+		style.SuccessPrefix, sessionName, style.Dim.Render("(cleared "+formatAge(snap.At)+"; not submitted)"))
+	return nil
+}
@@ -4,7 +4,6 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
-	"os"
 	"os/exec"
 	"sort"
 	"strings"
@@ -109,9 +108,7 @@ func runPatrolDigest(cmd *cobra.Command, args []string) error {
 	existingID, err := findExistingPatrolDigest(dateStr)
 	if err != nil {
 		// Non-fatal: continue with creation attempt
-		if patrolDigestVerbose {
-			fmt.Fprintf(os.Stderr, "[patrol] warning: failed to check existing digest: %v\n", err)
-		}
+		logger.Debug("patrol: failed to check existing digest", "err", err)
 	} else if existingID != "" {
 		fmt.Printf("%s Patrol digest already exists for %s (bead: %s)\n",
 			style.Dim.Render("○"), dateStr, existingID)
@@ -165,7 +162,7 @@ func runPatrolDigest(cmd *cobra.Command, args []string) error {
 	// Delete source digests (they're ephemeral)
 	deletedCount, deleteErr := deletePatrolDigests(targetDate)
 	if deleteErr != nil {
-		fmt.Fprintf(os.Stderr, "warning: failed to delete some source digests: %v\n", deleteErr)
+		logger.Warn("failed to delete some source digests", "err", deleteErr)
 	}
 
 	fmt.Printf("%s Created Patrol Report %s (bead: %s)\n", style.Success.Render("✓"), dateStr, digestID)
@@ -192,9 +189,7 @@ func queryPatrolDigests(targetDate time.Time) ([]PatrolCycleEntry, error) {
 	)
 	listOutput, err := listCmd.Output()
 	if err != nil {
-		if patrolDigestVerbose {
-			fmt.Fprintf(os.Stderr, "[patrol] bd list failed: %v\n", err)
-		}
+		logger.Debug("patrol: bd list failed", "err", err)
 		return nil, nil
 	}
 
@@ -125,12 +125,13 @@ func TestBuildRefineryPatrolVars_FullConfig(t *testing.T) {
 	}
 	vars := buildRefineryPatrolVars(ctx)
 
-	// DefaultMergeQueueConfig: refinery_enabled=true, auto_land=false, run_tests=true,
+	// DefaultMergeQueueConfig: refinery_enabled=true, auto_land=false, review=false, run_tests=true,
 	// test_command="go test ./...", target_branch="main" (from rig config), delete_merged_branches=true
 	// New commands (setup, typecheck, lint, build) default to empty = omitted
 	expected := map[string]string{
 		"integration_branch_refinery_enabled": "true",
 		"integration_branch_auto_land":        "false",
+		"integration_branch_review":           "false",
 		"run_tests":                           "true",
 		"test_command":                        "go test ./...",
 		"target_branch":                       "main",
@@ -2,7 +2,6 @@ package cmd
 
 import (
 	"fmt"
-	"os"
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/constants"
@@ -80,7 +79,7 @@ func runPatrolNew(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		if patrolID != "" {
 			// Created but failed to hook
-			fmt.Fprintf(os.Stderr, "warning: %s\n", err.Error())
+			logger.Warn("patrol created but not hooked", "patrol", patrolID, "err", err)
 			fmt.Println(patrolID)
 			return nil
 		}
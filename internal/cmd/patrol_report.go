@@ -2,7 +2,6 @@ package cmd
 
 import (
 	"fmt"
-	"os"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -126,7 +125,7 @@ func runPatrolReport(cmd *cobra.Command, args []string) error {
 	newPatrolID, err := autoSpawnPatrol(cfg)
 	if err != nil {
 		if newPatrolID != "" {
-			fmt.Fprintf(os.Stderr, "warning: %s\n", err.Error())
+			logger.Warn("new patrol created but not hooked", "patrol", newPatrolID, "err", err)
 			fmt.Printf("New patrol: %s\n", newPatrolID)
 			return nil
 		}
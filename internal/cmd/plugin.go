@@ -395,7 +395,7 @@ func runPluginRun(cmd *cobra.Command, args []string) error {
 		count, err := recorder.CountRunsSince(p.Name, duration)
 		if err != nil {
 			// Log warning but continue
-			fmt.Fprintf(os.Stderr, "Warning: checking gate status: %v\n", err)
+			logger.Warn("checking gate status", "err", err)
 		} else if count > 0 {
 			gateOpen = false
 			gateReason = fmt.Sprintf("ran %d time(s) within %s cooldown", count, duration)
@@ -442,7 +442,7 @@ func runPluginRun(cmd *cobra.Command, args []string) error {
 		Body:       "Manual run via gt plugin run",
 	})
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Warning: failed to record run: %v\n", err)
+		logger.Warn("failed to record run", "err", err)
 	} else {
 		fmt.Printf("\n%s Recorded run: %s\n", style.Dim.Render("●"), beadID)
 	}
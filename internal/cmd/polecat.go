@@ -152,13 +152,17 @@ var polecatGCCmd = &cobra.Command{
 	Short: "Garbage collect stale polecat branches",
 	Long: `Garbage collect stale polecat branches in a rig.
 
-Polecats use unique timestamped branches (polecat/<name>-<timestamp>) to
-prevent drift issues. Over time, these branches accumulate when stale
-polecats are repaired.
+Polecats work each bead on its own branch (polecat/<rig>/<name>/<bead-id>).
+Over time, these branches accumulate as beads are finished and polecats are
+repaired.
 
-This command removes orphaned branches:
+This command removes:
+  - Worktrees and branches of closed beads (postflight cleanup), unless the
+    polecat's session is running or the worktree has unpushed work
   - Branches for polecats that no longer exist
-  - Old timestamped branches (keeps only the current one per polecat)
+  - Old branches no polecat has checked out, except bead branches whose
+    bead is still open; a branch with unmerged commits is kept (git
+    branch -d)
 
 Examples:
   gt polecat gc greenplace
@@ -450,7 +454,7 @@ func runPolecatList(cmd *cobra.Command, args []string) error {
 
 		polecats, err := mgr.List()
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "warning: failed to list polecats in %s: %v\n", r.Name, err)
+			logger.Warn("failed to list polecats", "rig", r.Name, "err", err)
 			continue
 		}
 
@@ -1094,44 +1098,46 @@ func runPolecatGC(cmd *cobra.Command, args []string) error {
 
 	fmt.Printf("Garbage collecting stale polecat branches in %s...\n\n", r.Name)
 
+	// Postflight: worktrees and branches of closed beads
+	results, err := mgr.Postflight(polecatGCDryRun)
+	if err != nil {
+		return fmt.Errorf("postflight cleanup failed: %w", err)
+	}
+	for _, res := range results {
+		switch {
+		case !res.Removed:
+			fmt.Printf("  Keep (%s): %s\n", res.Reason, style.Warning.Render(res.Branch))
+		case polecatGCDryRun:
+			fmt.Printf("  Would remove (bead closed): %s\n", style.Dim.Render(res.Branch))
+		default:
+			fmt.Printf("  Removed (bead closed): %s\n", style.Dim.Render(res.Branch))
+		}
+	}
+	if len(results) > 0 {
+		fmt.Println()
+	}
+
+	// Disk quotas: prune snapshots and mail archives over quota (rigs live
+	// directly under the town root)
+	diskPostflight(filepath.Dir(r.Path), r.Name, polecatGCDryRun)
+
 	if polecatGCDryRun {
 		// Dry run - list branches that would be deleted
-		repoGit := git.NewGit(r.Path)
-
-		// List all polecat branches
-		branches, err := repoGit.ListBranches("polecat/*")
+		stale, err := mgr.StaleBranches()
 		if err != nil {
 			return fmt.Errorf("listing branches: %w", err)
 		}
 
-		if len(branches) == 0 {
-			fmt.Println("No polecat branches found.")
+		if len(stale) == 0 {
+			fmt.Println("No stale polecat branches found.")
 			return nil
 		}
 
-		// Get current branches
-		polecats, err := mgr.List()
-		if err != nil {
-			return fmt.Errorf("listing polecats: %w", err)
-		}
-
-		currentBranches := make(map[string]bool)
-		for _, p := range polecats {
-			currentBranches[p.Branch] = true
+		for _, branch := range stale {
+			fmt.Printf("  Would delete (unless unmerged): %s\n", style.Dim.Render(branch))
 		}
 
-		// Show what would be deleted
-		toDelete := 0
-		for _, branch := range branches {
-			if !currentBranches[branch] {
-				fmt.Printf("  Would delete: %s\n", style.Dim.Render(branch))
-				toDelete++
-			} else {
-				fmt.Printf("  Keep (in use): %s\n", style.Success.Render(branch))
-			}
-		}
-
-		fmt.Printf("\nWould delete %d branch(es), keep %d\n", toDelete, len(branches)-toDelete)
+		fmt.Printf("\nWould delete up to %d branch(es)\n", len(stale))
 		return nil
 	}
 
@@ -1693,41 +1699,15 @@ func runPolecatPoolInit(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("listing existing polecats: %w", err)
 	}
-	existingNames := make(map[string]bool)
-	for _, p := range existing {
-		existingNames[p.Name] = true
-	}
 
 	fmt.Printf("Initializing persistent polecat pool for %s (target size: %d)\n", rigName, poolSize)
 	if len(existing) > 0 {
 		fmt.Printf("  Existing polecats: %d\n", len(existing))
 	}
 
-	// Build the list of names to create
-	var namesToCreate []string
-	if len(fixedNames) > 0 {
-		// Use configured names, skip ones that already exist
-		for _, name := range fixedNames {
-			if len(namesToCreate)+len(existingNames) >= poolSize {
-				break
-			}
-			if !existingNames[name] {
-				namesToCreate = append(namesToCreate, name)
-			}
-		}
-	} else {
-		// Use name pool allocation for new names
-		namePool := mgr.GetNamePool()
-		namePool.Reconcile(existingNamesList(existing))
-		for len(namesToCreate)+len(existingNames) < poolSize {
-			name, allocErr := namePool.Allocate()
-			if allocErr != nil {
-				return fmt.Errorf("allocating polecat name: %w", allocErr)
-			}
-			if !existingNames[name] {
-				namesToCreate = append(namesToCreate, name)
-			}
-		}
+	namesToCreate, err := polecatNamesToAdd(mgr, existing, fixedNames, poolSize)
+	if err != nil {
+		return err
 	}
 
 	if len(namesToCreate) == 0 {
@@ -1745,22 +1725,7 @@ func runPolecatPoolInit(cmd *cobra.Command, args []string) error {
 
 	// Create each polecat
 	fmt.Printf("\nCreating %d polecat(s)...\n", len(namesToCreate))
-	created := 0
-	for _, name := range namesToCreate {
-		fmt.Printf("  %s Creating %s...", style.Dim.Render("→"), name)
-		p, addErr := mgr.Add(name)
-		if addErr != nil {
-			fmt.Printf(" %s %v\n", style.Warning.Render("FAILED"), addErr)
-			continue
-		}
-		// Set agent state to idle (polecat was created without work)
-		if stateErr := mgr.SetAgentState(name, "idle"); stateErr != nil {
-			fmt.Printf(" %s (created but couldn't set idle state: %v)\n", style.Warning.Render("⚠"), stateErr)
-		} else {
-			fmt.Printf(" %s (%s)\n", style.Success.Render("✓"), style.Dim.Render(p.ClonePath))
-		}
-		created++
-	}
+	created := addIdlePolecats(mgr, namesToCreate)
 
 	fmt.Printf("\n%s Pool initialized: %d created, %d total (target: %d)\n",
 		style.Bold.Render("✓"), created, created+len(existing), poolSize)
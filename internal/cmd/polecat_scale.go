@@ -0,0 +1,303 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/polecat"
+	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+var (
+	polecatScaleDryRun       bool
+	polecatScaleDrainTimeout time.Duration
+)
+
+// polecatScalePollInterval is how often scale-down rechecks polecats that
+// are still draining.
+const polecatScalePollInterval = 10 * time.Second
+
+var polecatScaleCmd = &cobra.Command{
+	Use:   "scale <rig> <count>",
+	Short: "Grow or shrink a rig's persistent polecat pool",
+	Long: `Grow or shrink a rig's persistent polecat pool to <count> polecats.
+
+Scaling up creates polecats with identities and worktrees in IDLE state,
+like pool-init. Their sessions start when work is slung to them.
+
+Scaling down removes polecats, idle ones first, then the most recently
+created. Each is drained first: scale waits (up to --drain-timeout) until
+it has no work on its hook, no open MR, and nothing unpushed. Its state
+(the polecat record and agent bead fields) is then archived to
+<rig>/.runtime/polecat-archive/ and it is nuked. Polecats still busy at
+the timeout are left in place.
+
+Either way, polecat_pool_size in the rig's config.json is set to <count>,
+so later pool-init runs keep the new size.
+
+Examples:
+  gt polecat scale gastown 6
+  gt polecat scale gastown 2 --dry-run
+  gt polecat scale gastown 2 --drain-timeout 2h`,
+	Args: cobra.ExactArgs(2),
+	RunE: runPolecatScale,
+}
+
+func init() {
+	polecatScaleCmd.Flags().BoolVar(&polecatScaleDryRun, "dry-run", false, "Show what would be added or removed without doing it")
+	polecatScaleCmd.Flags().DurationVar(&polecatScaleDrainTimeout, "drain-timeout", 30*time.Minute, "How long to wait for removed polecats to finish their work")
+
+	polecatCmd.AddCommand(polecatScaleCmd)
+}
+
+func runPolecatScale(cmd *cobra.Command, args []string) error {
+	rigName := args[0]
+	target, err := strconv.Atoi(args[1])
+	if err != nil || target < 0 {
+		return fmt.Errorf("invalid count %q: must be a non-negative integer", args[1])
+	}
+
+	mgr, r, err := getPolecatManager(rigName)
+	if err != nil {
+		return err
+	}
+	existing, err := mgr.List()
+	if err != nil {
+		return fmt.Errorf("listing existing polecats: %w", err)
+	}
+
+	fmt.Printf("Scaling polecats in %s: %d → %d\n", rigName, len(existing), target)
+
+	// The configured size is updated even if some polecats can't be drained
+	// in time, so pool-init and later scale runs converge on it.
+	var drainErr error
+	switch {
+	case target > len(existing):
+		var fixedNames []string
+		if rigCfg, cfgErr := rig.LoadRigConfig(r.Path); cfgErr == nil {
+			fixedNames = rigCfg.PolecatNames
+		}
+		names, err := polecatNamesToAdd(mgr, existing, fixedNames, target)
+		if err != nil {
+			return err
+		}
+		if polecatScaleDryRun {
+			fmt.Printf("\nWould create %d polecat(s):\n", len(names))
+			for _, name := range names {
+				fmt.Printf("  %s %s\n", style.Dim.Render("→"), name)
+			}
+			return nil
+		}
+		fmt.Printf("\nCreating %d polecat(s)...\n", len(names))
+		if created := addIdlePolecats(mgr, names); created < len(names) {
+			defer fmt.Printf("%s %d of %d polecat(s) could not be created\n", style.Warning.Render("⚠"), len(names)-created, len(names))
+		}
+
+	case target < len(existing):
+		victims := scaleDownOrder(existing)[:len(existing)-target]
+		if polecatScaleDryRun {
+			fmt.Printf("\nWould drain and remove %d polecat(s):\n", len(victims))
+			for _, p := range victims {
+				fmt.Printf("  %s %s (%s)\n", style.Dim.Render("→"), p.Name, p.State)
+			}
+			return nil
+		}
+		fmt.Printf("\nDraining %d polecat(s)...\n", len(victims))
+		removed, pending := drainAndRemovePolecats(rigName, mgr, r, victims, polecatScaleDrainTimeout)
+		cleanupOrphanedProcesses()
+		if len(pending) > 0 {
+			drainErr = fmt.Errorf("removed %d of %d polecat(s); still busy after %s: %s",
+				removed, len(victims), polecatScaleDrainTimeout, strings.Join(pending, ", "))
+		}
+
+	default:
+		fmt.Printf("\n%s Already at %d polecat(s).\n", style.Bold.Render("✓"), target)
+	}
+
+	if polecatScaleDryRun {
+		return nil
+	}
+	if err := rig.SetPolecatPoolSize(r.Path, target); err != nil {
+		return fmt.Errorf("updating rig config: %w", err)
+	}
+	if drainErr != nil {
+		return drainErr
+	}
+	fmt.Printf("\n%s %s scaled to %d polecat(s)\n", style.SuccessPrefix, rigName, target)
+	return nil
+}
+
+// polecatNamesToAdd returns the names of the polecats to create to grow the
+// pool to size: fixed names from the rig config if it has any, otherwise
+// names allocated from the rig's name pool. Running out of fixed names is
+// an error, so the pool size is never recorded beyond what can be created.
+func polecatNamesToAdd(mgr *polecat.Manager, existing []*polecat.Polecat, fixedNames []string, size int) ([]string, error) {
+	existingNames := make(map[string]bool, len(existing))
+	for _, p := range existing {
+		existingNames[p.Name] = true
+	}
+
+	var names []string
+	if len(fixedNames) > 0 {
+		// Use configured names, skip ones that already exist
+		for _, name := range fixedNames {
+			if len(names)+len(existingNames) >= size {
+				break
+			}
+			if !existingNames[name] {
+				names = append(names, name)
+			}
+		}
+		if len(names)+len(existingNames) < size {
+			return nil, fmt.Errorf("rig config polecat_names lists %d name(s), too few for %d polecats; add names or scale to %d",
+				len(fixedNames), size, len(names)+len(existingNames))
+		}
+		return names, nil
+	}
+
+	// Use name pool allocation for new names
+	namePool := mgr.GetNamePool()
+	namePool.Reconcile(existingNamesList(existing))
+	for len(names)+len(existingNames) < size {
+		name, err := namePool.Allocate()
+		if err != nil {
+			return nil, fmt.Errorf("allocating polecat name: %w", err)
+		}
+		if !existingNames[name] {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// addIdlePolecats creates polecats in IDLE state, printing progress, and
+// returns how many were created.
+func addIdlePolecats(mgr *polecat.Manager, names []string) int {
+	created := 0
+	for _, name := range names {
+		fmt.Printf("  %s Creating %s...", style.Dim.Render("→"), name)
+		p, addErr := mgr.Add(name)
+		if addErr != nil {
+			fmt.Printf(" %s %v\n", style.Warning.Render("FAILED"), addErr)
+			continue
+		}
+		// Set agent state to idle (polecat was created without work)
+		if stateErr := mgr.SetAgentState(name, "idle"); stateErr != nil {
+			fmt.Printf(" %s (created but couldn't set idle state: %v)\n", style.Warning.Render("⚠"), stateErr)
+		} else {
+			fmt.Printf(" %s (%s)\n", style.Success.Render("✓"), style.Dim.Render(p.ClonePath))
+		}
+		created++
+	}
+	return created
+}
+
+// scaleDownOrder sorts polecats in the order scale-down removes them: idle
+// ones first, then done, then the rest; within each, the most recently
+// created first.
+func scaleDownOrder(polecats []*polecat.Polecat) []*polecat.Polecat {
+	rank := func(s polecat.State) int {
+		switch s {
+		case polecat.StateIdle:
+			return 0
+		case polecat.StateDone:
+			return 1
+		case polecat.StateWorking:
+			return 3
+		default:
+			return 2
+		}
+	}
+	sorted := append([]*polecat.Polecat(nil), polecats...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		ri, rj := rank(sorted[i].State), rank(sorted[j].State)
+		if ri != rj {
+			return ri < rj
+		}
+		if !sorted[i].CreatedAt.Equal(sorted[j].CreatedAt) {
+			return sorted[i].CreatedAt.After(sorted[j].CreatedAt)
+		}
+		return sorted[i].Name > sorted[j].Name
+	})
+	return sorted
+}
+
+// drainAndRemovePolecats waits, up to timeout, for each polecat to pass the
+// nuke safety checks, then archives and nukes it. It returns how many were
+// removed and the names of those still busy at the timeout.
+func drainAndRemovePolecats(rigName string, mgr *polecat.Manager, r *rig.Rig, victims []*polecat.Polecat, timeout time.Duration) (int, []string) {
+	pending := make([]*polecat.Polecat, len(victims))
+	copy(pending, victims)
+	removed := 0
+	deadline := time.Now().Add(timeout)
+	for {
+		var busy []*polecat.Polecat
+		for _, p := range pending {
+			target := polecatTarget{rigName: rigName, polecatName: p.Name, mgr: mgr, r: r}
+			if result := checkPolecatSafety(target); result.Blocked {
+				busy = append(busy, p)
+				fmt.Printf("  %s %s draining: %s\n", style.Dim.Render("○"), p.Name, strings.Join(result.Reasons, ", "))
+				continue
+			}
+			fmt.Printf("Removing %s/%s...\n", rigName, p.Name)
+			if err := archivePolecatState(r, rigName, p); err != nil {
+				fmt.Printf("  %s archive failed, keeping polecat: %v\n", style.Warning.Render("⚠"), err)
+				busy = append(busy, p)
+				continue
+			}
+			if err := nukePolecatFull(p.Name, rigName, mgr, r); err != nil {
+				fmt.Printf("  %s %v\n", style.Warning.Render("⚠"), err)
+				busy = append(busy, p)
+				continue
+			}
+			removed++
+		}
+		pending = busy
+		if len(pending) == 0 || !time.Now().Add(polecatScalePollInterval).Before(deadline) {
+			break
+		}
+		time.Sleep(polecatScalePollInterval)
+	}
+
+	names := make([]string, len(pending))
+	for i, p := range pending {
+		names[i] = p.Name
+	}
+	return removed, names
+}
+
+// polecatArchive is the record kept of a polecat removed by scale-down.
+type polecatArchive struct {
+	ArchivedAt time.Time          `json:"archived_at"`
+	Reason     string             `json:"reason"`
+	Polecat    *polecat.Polecat   `json:"polecat"`
+	AgentBead  string             `json:"agent_bead,omitempty"`
+	Fields     *beads.AgentFields `json:"agent_fields,omitempty"`
+}
+
+// archivePolecatState writes a polecat's record and agent bead fields to
+// <rig>/.runtime/polecat-archive/<name>-<timestamp>.json before it is nuked.
+func archivePolecatState(r *rig.Rig, rigName string, p *polecat.Polecat) error {
+	now := time.Now()
+	rec := polecatArchive{
+		ArchivedAt: now,
+		Reason:     "scaled down",
+		Polecat:    p,
+		AgentBead:  polecatBeadIDForRig(r, rigName, p.Name),
+	}
+	if _, fields, err := beads.New(r.Path).GetAgentBead(rec.AgentBead); err == nil {
+		rec.Fields = fields
+	}
+	path := filepath.Join(constants.RigRuntimePath(r.Path), "polecat-archive",
+		fmt.Sprintf("%s-%s.json", p.Name, now.UTC().Format("20060102T150405Z")))
+	return util.EnsureDirAndWriteJSON(path, rec)
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/polecat"
+)
+
+func TestScaleDownOrder(t *testing.T) {
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	polecats := []*polecat.Polecat{
+		{Name: "working", State: polecat.StateWorking, CreatedAt: base.Add(5 * time.Hour)},
+		{Name: "idle-old", State: polecat.StateIdle, CreatedAt: base},
+		{Name: "stuck", State: polecat.StateStuck, CreatedAt: base},
+		{Name: "done", State: polecat.StateDone, CreatedAt: base},
+		{Name: "idle-new", State: polecat.StateIdle, CreatedAt: base.Add(time.Hour)},
+	}
+
+	got := scaleDownOrder(polecats)
+
+	want := []string{"idle-new", "idle-old", "done", "stuck", "working"}
+	for i, p := range got {
+		if p.Name != want[i] {
+			t.Fatalf("order[%d] = %s, want %s (full: %v)", i, p.Name, want[i], want)
+		}
+	}
+	if polecats[0].Name != "working" {
+		t.Error("scaleDownOrder modified its input")
+	}
+}
+
+func TestPolecatNamesToAddFixedNames(t *testing.T) {
+	existing := []*polecat.Polecat{{Name: "alpha"}}
+	fixed := []string{"alpha", "bravo", "charlie"}
+
+	names, err := polecatNamesToAdd(nil, existing, fixed, 3)
+	if err != nil || len(names) != 2 || names[0] != "bravo" || names[1] != "charlie" {
+		t.Errorf("polecatNamesToAdd(3) = %v, %v", names, err)
+	}
+	if names, err := polecatNamesToAdd(nil, existing, fixed, 5); err == nil {
+		t.Errorf("polecatNamesToAdd(5) = %v, want error for too few names", names)
+	}
+}
@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/policy"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var (
+	policyTestAgainst string
+	policyTestSince   string
+	policyTestJSON    bool
+)
+
+var policyCmd = &cobra.Command{
+	Use:     "policy",
+	GroupID: GroupConfig,
+	Short:   "Validate witness, escalation, and dispatch policies",
+	RunE:    requireSubcommand,
+}
+
+var policyTestCmd = &cobra.Command{
+	Use:   "test <rules-file>",
+	Short: "Dry-run rules against recorded event history",
+	Long: `Replay recorded events against candidate rules and show which actions
+would have fired, so a policy change can be checked before it goes live.
+
+The rules file (YAML or JSON) has up to three sections, each in the same
+schema as the live configuration it mirrors:
+
+  witness:            # as "witness" in <rig>/settings/config.json
+    rules:
+      - {name: quiet, when: {idle_for: 30m}, do: mail_mayor, cooldown: 1h}
+  escalation:         # as settings/escalation.json
+    routes: {high: [bead, "mail:mayor", slack]}
+    stale_threshold: 2h
+  dispatcher:         # as "dispatcher" in settings/config.json
+    rig_capacity: {"*": 3}
+
+Witness rules apply to agents in every rig. Agent activity, sessions, and
+hooks are reconstructed from the log; conditions the log doesn't record
+(agent_state, nudge_failures, labels, no_progress_for) can't be replayed and
+are reported.
+Escalations are re-routed by severity and re-escalated on the policy's
+stale threshold. Dispatch plans open, unassigned beads (as the daemon's bead
+watcher logged them) onto idle agents, as gt dispatch plans bd ready's output;
+blocking dependencies aren't in the log, so every open bead counts as ready.
+
+Nothing is sent, hooked, or restarted.
+
+Examples:
+  gt policy test rules.yaml                       # Against this town's history
+  gt policy test rules.yaml --against events.log  # Against a saved log
+  gt policy test rules.yaml --since 7d --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPolicyTest,
+}
+
+func init() {
+	policyTestCmd.Flags().StringVar(&policyTestAgainst, "against", "", "Event log to replay (default: the town's .events.jsonl)")
+	policyTestCmd.Flags().StringVar(&policyTestSince, "since", "", "Only replay events newer than this (e.g., 24h, 7d)")
+	policyTestCmd.Flags().BoolVar(&policyTestJSON, "json", false, "Output as JSON")
+	policyCmd.AddCommand(policyTestCmd)
+	rootCmd.AddCommand(policyCmd)
+}
+
+func runPolicyTest(cmd *cobra.Command, args []string) error {
+	p, err := policy.Load(args[0])
+	if err != nil {
+		return fmt.Errorf("loading %s: %w", args[0], err)
+	}
+
+	eventsPath := policyTestAgainst
+	if eventsPath == "" {
+		townRoot, err := workspace.FindFromCwdOrError()
+		if err != nil {
+			return fmt.Errorf("not in a Gas Town workspace (use --against): %w", err)
+		}
+		eventsPath = filepath.Join(townRoot, events.EventsFile)
+	}
+	evts, err := policy.LoadEvents(eventsPath)
+	if err != nil {
+		return fmt.Errorf("reading events: %w", err)
+	}
+
+	if policyTestSince != "" {
+		d, err := parseDuration(policyTestSince)
+		if err != nil {
+			return fmt.Errorf("invalid --since: %w", err)
+		}
+		cutoff := time.Now().Add(-d)
+		var recent []events.Event
+		for _, e := range evts {
+			if ts, err := time.Parse(time.RFC3339, e.Timestamp); err == nil && !ts.Before(cutoff) {
+				recent = append(recent, e)
+			}
+		}
+		evts = recent
+	}
+
+	report := policy.Replay(p, evts)
+
+	if policyTestJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+
+	if report.Events == 0 {
+		fmt.Printf("%s No events to replay in %s\n", style.Dim.Render("○"), eventsPath)
+		return nil
+	}
+	fmt.Printf("%s Replayed %d event(s) from %s to %s\n", style.Bold.Render("⚖"), report.Events,
+		report.Start.Local().Format("2006-01-02 15:04"), report.End.Local().Format("2006-01-02 15:04"))
+	fmt.Printf("  witness: %d  escalation: %d  dispatch: %d\n\n",
+		report.Count(policy.KindWitness), report.Count(policy.KindEscalation), report.Count(policy.KindDispatch))
+
+	if len(report.Firings) == 0 {
+		fmt.Printf("%s No rules would have fired\n", style.Dim.Render("○"))
+	}
+	for _, f := range report.Firings {
+		line := fmt.Sprintf("%s %-10s %s → %s on %s", style.Dim.Render(f.At.Local().Format("01-02 15:04")),
+			f.Kind, policyRuleLabel(f), f.Action, f.Target)
+		if f.Reason != "" {
+			line += " " + style.Dim.Render("("+f.Reason+")")
+		}
+		fmt.Println("  " + line)
+	}
+	for _, n := range report.Notes {
+		style.PrintWarning("%s", n)
+	}
+	return nil
+}
+
+// policyRuleLabel names the rule behind a firing for display.
+func policyRuleLabel(f policy.Firing) string {
+	if f.Rule == "" {
+		return "(by rig)"
+	}
+	return f.Rule
+}
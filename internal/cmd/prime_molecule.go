@@ -307,6 +307,7 @@ func buildRefineryPatrolVars(ctx RoleContext) []string {
 
 	vars = append(vars, fmt.Sprintf("integration_branch_refinery_enabled=%t", mq.IsRefineryIntegrationEnabled()))
 	vars = append(vars, fmt.Sprintf("integration_branch_auto_land=%t", mq.IsIntegrationBranchAutoLandEnabled()))
+	vars = append(vars, fmt.Sprintf("integration_branch_review=%t", mq.IsIntegrationBranchReviewEnabled()))
 	vars = append(vars, fmt.Sprintf("run_tests=%t", mq.IsRunTestsEnabled()))
 	if mq.SetupCommand != "" {
 		vars = append(vars, fmt.Sprintf("setup_command=%s", mq.SetupCommand))
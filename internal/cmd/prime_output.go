@@ -22,8 +22,13 @@ import (
 // outputPrimeContext outputs the role-specific context using templates or fallback.
 // Returns the rendered template content (empty string when using fallback path).
 func outputPrimeContext(ctx RoleContext) (string, error) {
-	// Try to use templates first
-	tmpl, err := templates.New()
+	// Try to use templates first, with the town's overrides. A broken
+	// override must not leave the agent without its role context.
+	tmpl, err := templates.NewForTown(ctx.TownRoot)
+	if err != nil {
+		style.PrintWarning("%v; using built-in role templates", err)
+		tmpl, err = templates.New()
+	}
 	if err != nil {
 		// Fall back to hardcoded output if templates fail
 		outputPrimeContextFallback(ctx)
@@ -92,7 +92,7 @@ func persistSessionID(dir, sessionID string) {
 	}
 
 	sessionFile := filepath.Join(runtimeDir, "session_id")
-	content := fmt.Sprintf("%s\n%s\n", sessionID, time.Now().Format(time.RFC3339))
+	content := fmt.Sprintf("%s\n%s\n", sessionID, time.Now().UTC().Format(time.RFC3339))
 	_ = os.WriteFile(sessionFile, []byte(content), 0644) // Non-fatal
 }
 
@@ -0,0 +1,379 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/daemon"
+	"github.com/steveyegge/gastown/internal/deacon"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/quiesce"
+	"github.com/steveyegge/gastown/internal/scheduler/capacity"
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// quiescePollInterval is how often the drain checks whether agents have
+// reached a safe point.
+const quiescePollInterval = 2 * time.Second
+
+// quiesceNudge asks working agents to wind down before they are stopped.
+const quiesceNudge = "[QUIESCE] Gas Town is shutting down gracefully. Finish the step you are on, " +
+	"commit or stash work in progress, and stop at your prompt. Don't start anything new; " +
+	"your session will be checkpointed and resumed later."
+
+var (
+	quiesceTimeout   time.Duration
+	quiesceReason    string
+	quiesceDryRun    bool
+	quiesceSummarize bool
+)
+
+var quiesceCmd = &cobra.Command{
+	Use:     "quiesce",
+	GroupID: GroupServices,
+	Short:   "Gracefully shut down the town, recording how to resume it",
+	Long: `Shut the town down at a safe point, recording a manifest that
+'gt quiesce resume' uses to bring it back.
+
+  1. Block dispatch: gt sling and gt dispatch refuse new work, and the
+     scheduler and deacon are paused.
+  2. Drain: polecats and crew are asked to wind down, then given up to
+     --timeout to return to their prompt.
+  3. Checkpoint polecats, crew and the mayor (gt checkpoint), for
+     resume to restore.
+  4. Stop the daemon, so nothing restarts what follows.
+  5. Stop sessions in dependency order: polecats → crew →
+     witnesses/refineries → boot → deacon → mayor.
+
+The manifest (.runtime/quiesce.json) lists every agent with its hooked
+work, the safe point it reached (idle, or timeout if it was still busy)
+and its checkpoint. Dispatch stays blocked until it is resumed. Dolt is
+left running; use 'gt down' afterwards to stop it too.
+
+Examples:
+  gt quiesce --dry-run            # Show what would be stopped
+  gt quiesce --timeout 20m --reason "host maintenance"
+  gt quiesce status
+  gt quiesce resume`,
+	Args: cobra.NoArgs,
+	RunE: runQuiesce,
+}
+
+var quiesceStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the quiesce manifest",
+	Args:  cobra.NoArgs,
+	RunE:  runQuiesceStatus,
+}
+
+var quiesceResumeCmd = &cobra.Command{
+	Use:   "resume",
+	Short: "Cold-start the town from the quiesce manifest (same as gt resume --town)",
+	Args:  cobra.NoArgs,
+	RunE:  runTownResume,
+}
+
+func init() {
+	quiesceCmd.Flags().DurationVar(&quiesceTimeout, "timeout", 10*time.Minute, "How long to wait for agents to reach a safe point")
+	quiesceCmd.Flags().StringVar(&quiesceReason, "reason", "", "Why the town is being quiesced (recorded in the manifest)")
+	quiesceCmd.Flags().BoolVar(&quiesceDryRun, "dry-run", false, "Show what would be stopped without doing it")
+	quiesceCmd.Flags().BoolVar(&quiesceSummarize, "summarize", false, "Generate checkpoint summaries with the summary agent")
+	addTownResumeFlags(quiesceResumeCmd)
+
+	quiesceCmd.AddCommand(quiesceStatusCmd)
+	quiesceCmd.AddCommand(quiesceResumeCmd)
+	rootCmd.AddCommand(quiesceCmd)
+}
+
+func runQuiesce(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	m, err := quiesce.Load(townRoot)
+	if err != nil {
+		return err
+	}
+	if m != nil && m.Phase == quiesce.PhaseStopped {
+		return fmt.Errorf("town is already quiesced (since %s); see 'gt quiesce status'",
+			m.StartedAt.Local().Format("2006-01-02 15:04"))
+	}
+
+	t := tmux.NewTmux()
+	sessions, err := t.ListSessions()
+	if err != nil {
+		return fmt.Errorf("listing sessions: %w", err)
+	}
+	agents := quiesceAgents(sessions)
+	for i := range agents {
+		if isQuiesceWorker(agents[i]) {
+			if hooked := findAgentHookedWork(townRoot, agents[i].Address); hooked != nil {
+				agents[i].HookedBead = hooked.ID
+			}
+		}
+	}
+
+	if quiesceDryRun {
+		printQuiescePlan(agents)
+		return nil
+	}
+
+	lock, err := acquireShutdownLock(townRoot)
+	if err != nil {
+		return fmt.Errorf("cannot proceed: %w", err)
+	}
+	defer func() { _ = lock.Unlock() }()
+	_ = t.SetExitEmpty(false)
+
+	// Phase 1: block dispatch. A manifest left by an interrupted quiesce
+	// keeps its record of which pauses were ours.
+	if m == nil {
+		m = &quiesce.Manifest{StartedAt: time.Now().UTC(), StartedBy: detectSender()}
+	}
+	m.Phase = quiesce.PhaseDraining
+	m.Reason = quiesceReason
+	m.Agents = agents
+	if err := quiesce.Save(townRoot, m); err != nil {
+		return fmt.Errorf("writing manifest: %w", err)
+	}
+	fmt.Printf("%s Dispatch blocked\n", style.SuccessPrefix)
+	if state, err := capacity.LoadState(townRoot); err == nil && !state.Paused {
+		state.SetPaused("quiesce")
+		if err := capacity.SaveState(townRoot, state); err == nil {
+			m.PausedScheduler = true
+		}
+	}
+	if paused, _, _ := deacon.IsPaused(townRoot); !paused {
+		if err := deacon.Pause(townRoot, "town quiesce", "quiesce"); err == nil {
+			m.PausedDeacon = true
+		}
+	}
+	_ = quiesce.Save(townRoot, m)
+
+	// Phase 2: drain.
+	var workers []*quiesce.Agent
+	for i := range m.Agents {
+		if isQuiesceWorker(m.Agents[i]) {
+			workers = append(workers, &m.Agents[i])
+			_ = t.NudgeSession(m.Agents[i].Session, quiesceNudge)
+		}
+	}
+	if len(workers) > 0 {
+		fmt.Printf("Waiting up to %s for %d agent(s) to reach a safe point...\n", quiesceTimeout, len(workers))
+		// Give the nudge a moment to start a turn before polling for idle.
+		time.Sleep(quiescePollInterval)
+		waitForSafePoints(t, workers, quiesceTimeout, quiescePollInterval)
+		for _, a := range workers {
+			printDownStatus(a.Address, a.SafePoint != quiesce.SafePointTimeout, a.SafePoint)
+		}
+	}
+	_ = quiesce.Save(townRoot, m)
+
+	// Phase 3: checkpoint.
+	for i := range m.Agents {
+		a := &m.Agents[i]
+		if !isQuiesceWorker(*a) && session.Role(a.Role) != session.RoleMayor {
+			continue
+		}
+		if a.SafePoint == quiesce.SafePointNotRunning {
+			continue
+		}
+		id, _, err := checkpointSession(townRoot, a.Session, quiesceSummarize)
+		if err != nil {
+			a.Error = err.Error()
+			printDownStatus("Checkpoint "+a.Address, false, err.Error())
+			continue
+		}
+		a.Checkpoint = id
+		printDownStatus("Checkpoint "+a.Address, true, id)
+		_ = quiesce.Save(townRoot, m)
+	}
+
+	// Phase 4: stop the daemon before its sessions, or it restarts them.
+	if running, pid, err := daemon.IsRunning(townRoot); err == nil && running {
+		if err := daemon.StopDaemon(townRoot); err != nil {
+			printDownStatus("Daemon", false, err.Error())
+		} else {
+			m.DaemonWasRunning = true
+			printDownStatus("Daemon", true, fmt.Sprintf("stopped (was PID %d)", pid))
+		}
+	}
+
+	// Phase 5: stop sessions in dependency order.
+	quiesce.SortForStop(m.Agents)
+	allStopped := true
+	var stopped []string
+	for i := range m.Agents {
+		a := &m.Agents[i]
+		if running, _ := t.HasSession(a.Session); running {
+			if err := t.KillSessionWithProcesses(a.Session); err != nil {
+				if still, _ := t.HasSession(a.Session); still {
+					a.Error = err.Error()
+					allStopped = false
+					printDownStatus(a.Address, false, err.Error())
+					continue
+				}
+			}
+		}
+		a.Stopped = true
+		stopped = append(stopped, a.Address)
+		printDownStatus(a.Address, true, "stopped")
+	}
+
+	m.Phase = quiesce.PhaseStopped
+	m.StoppedAt = time.Now().UTC()
+	if err := quiesce.Save(townRoot, m); err != nil {
+		return fmt.Errorf("writing manifest: %w", err)
+	}
+	_ = events.LogFeed(events.TypeHalt, "gt", events.HaltPayload(stopped))
+
+	fmt.Println()
+	if !allStopped {
+		fmt.Printf("%s Town quiesced with errors; see 'gt quiesce status'\n", style.Bold.Render("⚠"))
+		return fmt.Errorf("not all sessions stopped")
+	}
+	fmt.Printf("%s Town quiesced (%d session(s) stopped)\n", style.Bold.Render("✓"), len(stopped))
+	fmt.Printf("  Resume with: %s\n", style.Dim.Render("gt quiesce resume"))
+	return nil
+}
+
+// quiesceAgents lists the town's agent sessions, in stop order.
+func quiesceAgents(sessions []string) []quiesce.Agent {
+	var agents []quiesce.Agent
+	for _, sess := range sessions {
+		if !session.IsKnownSession(sess) {
+			continue
+		}
+		a := quiesce.Agent{Address: sessionToAgentID(sess), Session: sess}
+		if id, err := session.ParseSessionName(sess); err == nil {
+			a.Role = string(id.Role)
+			a.Rig = id.Rig
+			a.Name = id.Name
+		}
+		agents = append(agents, a)
+	}
+	quiesce.SortForStop(agents)
+	return agents
+}
+
+// isQuiesceWorker reports whether an agent does hooked work, and so is
+// drained and checkpointed before it is stopped.
+func isQuiesceWorker(a quiesce.Agent) bool {
+	return a.Role == string(session.RolePolecat) || a.Role == string(session.RoleCrew)
+}
+
+// idleChecker is the tmux surface the drain needs.
+type idleChecker interface {
+	HasSession(name string) (bool, error)
+	IsIdle(session string) bool
+}
+
+// waitForSafePoints polls until every agent is idle at its prompt or gone,
+// or the timeout passes, and records the safe point each reached.
+func waitForSafePoints(t idleChecker, agents []*quiesce.Agent, timeout, poll time.Duration) {
+	deadline := time.Now().Add(timeout)
+	for {
+		pending := 0
+		for _, a := range agents {
+			if a.SafePoint != "" {
+				continue
+			}
+			if running, _ := t.HasSession(a.Session); !running {
+				a.SafePoint = quiesce.SafePointNotRunning
+			} else if t.IsIdle(a.Session) {
+				a.SafePoint = quiesce.SafePointIdle
+			} else {
+				pending++
+			}
+		}
+		if pending == 0 {
+			return
+		}
+		if !time.Now().Before(deadline) {
+			break
+		}
+		time.Sleep(poll)
+	}
+	for _, a := range agents {
+		if a.SafePoint == "" {
+			a.SafePoint = quiesce.SafePointTimeout
+		}
+	}
+}
+
+func printQuiescePlan(agents []quiesce.Agent) {
+	fmt.Println("═══ DRY RUN: Preview of quiesce ═══")
+	fmt.Println()
+	if len(agents) == 0 {
+		fmt.Printf("%s No agent sessions running\n", style.Dim.Render("○"))
+		return
+	}
+	fmt.Println("Would stop, in order:")
+	for _, a := range agents {
+		var notes []string
+		if isQuiesceWorker(a) {
+			notes = append(notes, "drain")
+		}
+		if isQuiesceWorker(a) || session.Role(a.Role) == session.RoleMayor {
+			notes = append(notes, "checkpoint")
+		}
+		if a.HookedBead != "" {
+			notes = append(notes, "hooked "+a.HookedBead)
+		}
+		line := fmt.Sprintf("  %s %s", style.Bold.Render("→"), a.Address)
+		if len(notes) > 0 {
+			line += " " + style.Dim.Render("("+strings.Join(notes, ", ")+")")
+		}
+		fmt.Println(line)
+	}
+}
+
+func runQuiesceStatus(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	m, err := quiesce.Load(townRoot)
+	if err != nil {
+		return err
+	}
+	if m == nil {
+		fmt.Printf("%s Town is not quiesced\n", style.Dim.Render("○"))
+		return nil
+	}
+
+	fmt.Printf("%s Town %s since %s", style.Bold.Render("⏸"), m.Phase, m.StartedAt.Local().Format("2006-01-02 15:04"))
+	if m.StartedBy != "" {
+		fmt.Printf(" by %s", m.StartedBy)
+	}
+	fmt.Println()
+	if m.Reason != "" {
+		fmt.Printf("  Reason: %s\n", m.Reason)
+	}
+	for _, a := range m.Agents {
+		detail := a.SafePoint
+		if a.HookedBead != "" {
+			detail += " hooked " + a.HookedBead
+		}
+		if a.Checkpoint != "" {
+			detail += " checkpoint " + a.Checkpoint
+		}
+		if a.Error != "" {
+			fmt.Printf("  %s %s %s\n", style.ErrorPrefix, a.Address, a.Error)
+			continue
+		}
+		marker := style.Dim.Render("○")
+		if a.Stopped {
+			marker = style.SuccessPrefix
+		}
+		fmt.Printf("  %s %s %s\n", marker, a.Address, style.Dim.Render(detail))
+	}
+	fmt.Printf("\nResume with: %s\n", style.Dim.Render("gt quiesce resume"))
+	return nil
+}
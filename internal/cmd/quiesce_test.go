@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/quiesce"
+)
+
+type fakeIdleSessions struct {
+	running map[string]bool
+	idleAt  map[string]int // Poll on which the session goes idle; absent = never
+	polls   map[string]int
+}
+
+func (f *fakeIdleSessions) HasSession(name string) (bool, error) { return f.running[name], nil }
+
+func (f *fakeIdleSessions) IsIdle(sess string) bool {
+	f.polls[sess]++
+	at, ok := f.idleAt[sess]
+	return ok && f.polls[sess] >= at
+}
+
+func TestWaitForSafePoints(t *testing.T) {
+	f := &fakeIdleSessions{
+		running: map[string]bool{"a": true, "b": true, "c": true},
+		idleAt:  map[string]int{"a": 1, "b": 3},
+		polls:   map[string]int{},
+	}
+	agents := []*quiesce.Agent{{Session: "a"}, {Session: "b"}, {Session: "c"}, {Session: "gone"}}
+	waitForSafePoints(f, agents, 20*time.Millisecond, time.Millisecond)
+
+	want := []string{quiesce.SafePointIdle, quiesce.SafePointIdle, quiesce.SafePointTimeout, quiesce.SafePointNotRunning}
+	for i, a := range agents {
+		if a.SafePoint != want[i] {
+			t.Errorf("%s safe point = %q, want %q", a.Session, a.SafePoint, want[i])
+		}
+	}
+	if f.polls["a"] != 1 {
+		t.Errorf("idle agent polled %d times after reaching its safe point", f.polls["a"])
+	}
+}
+
+func TestWaitForSafePoints_AllIdleReturnsEarly(t *testing.T) {
+	f := &fakeIdleSessions{
+		running: map[string]bool{"a": true},
+		idleAt:  map[string]int{"a": 1},
+		polls:   map[string]int{},
+	}
+	start := time.Now()
+	waitForSafePoints(f, []*quiesce.Agent{{Session: "a"}}, time.Minute, time.Second)
+	if time.Since(start) > 500*time.Millisecond {
+		t.Errorf("wait took %s with every agent idle", time.Since(start))
+	}
+}
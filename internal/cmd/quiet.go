@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/nudge"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// defaultQuietDuration is how long gt quiet mutes an agent without --for.
+const defaultQuietDuration = time.Hour
+
+var (
+	quietFor    time.Duration
+	quietReason string
+	quietOff    bool
+)
+
+var quietCmd = &cobra.Command{
+	Use:     "quiet [agent]",
+	GroupID: GroupComm,
+	Short:   "Temporarily mute an agent's nudges and notifications",
+	Long: `Hold non-urgent nudges and mail notifications to an agent for a while,
+e.g. while a human has taken over its session for manual debugging.
+
+Held nudges are collected in a digest and delivered as a single nudge when
+the quiet period ends, either when it expires or with --off. Urgent nudges
+and urgent mail still go through, as does gt nudge --force.
+
+Unlike gt dnd, which an agent sets on itself indefinitely, quiet is set on
+any agent from outside and always expires. Quiet agents are marked in
+gt status.
+
+The agent can be a role shortcut, an address, or a session name. Without
+an agent, lists quiet agents.
+
+Examples:
+  gt quiet gastown/crew/max --for 2h
+  gt quiet gastown/nux --for 30m --reason "debugging test flake"
+  gt quiet gastown/crew/max --off
+  gt quiet`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runQuiet,
+}
+
+func init() {
+	quietCmd.Flags().DurationVar(&quietFor, "for", defaultQuietDuration, "How long to stay quiet")
+	quietCmd.Flags().StringVar(&quietReason, "reason", "", "Why the agent is quiet (shown in gt quiet and gt status)")
+	quietCmd.Flags().BoolVar(&quietOff, "off", false, "End the quiet period now and deliver the digest")
+	rootCmd.AddCommand(quietCmd)
+}
+
+func runQuiet(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	// Every invocation releases expired periods, so digests aren't stuck
+	// waiting for the agent's next drain.
+	if _, err := nudge.ReleaseExpiredQuiet(townRoot); err != nil {
+		style.PrintWarning("%v", err)
+	}
+
+	if len(args) == 0 {
+		if quietOff {
+			return fmt.Errorf("--off requires an agent")
+		}
+		return listQuiet(townRoot)
+	}
+
+	sessionName, err := resolveRoleToSession(args[0])
+	if err != nil {
+		return err
+	}
+
+	if quietOff {
+		q, found, err := nudge.EndQuiet(townRoot, sessionName)
+		if err != nil {
+			return fmt.Errorf("ending quiet: %w", err)
+		}
+		if !found {
+			fmt.Printf("%s %s is not quiet\n", style.Dim.Render("○"), sessionName)
+			return nil
+		}
+		fmt.Printf("%s %s is no longer quiet; %d held nudge(s) delivered as a digest\n",
+			style.SuccessPrefix, sessionName, len(q.Digest))
+		return nil
+	}
+
+	if quietFor <= 0 {
+		return fmt.Errorf("--for must be positive")
+	}
+	q, err := nudge.SetQuiet(townRoot, sessionName, quietFor, quietReason)
+	if err != nil {
+		return fmt.Errorf("setting quiet: %w", err)
+	}
+	fmt.Printf("%s %s is quiet until %s\n", style.SuccessPrefix, sessionName, q.Until.Local().Format("15:04"))
+	fmt.Printf("  %s\n", style.Dim.Render("Non-urgent nudges and notifications are held for a digest"))
+	fmt.Printf("  Run %s to end early\n", style.Bold.Render("gt quiet "+args[0]+" --off"))
+	return nil
+}
+
+// listQuiet prints the active quiet periods.
+func listQuiet(townRoot string) error {
+	states, err := nudge.LoadQuiet(townRoot)
+	if err != nil {
+		return err
+	}
+	if len(states) == 0 {
+		fmt.Println("No quiet agents")
+		return nil
+	}
+	sessions := make([]string, 0, len(states))
+	for s := range states {
+		sessions = append(sessions, s)
+	}
+	sort.Strings(sessions)
+	for _, s := range sessions {
+		q := states[s]
+		line := fmt.Sprintf("🔕 %-28s until %s (%s left), %d held",
+			s, q.Until.Local().Format("15:04"), time.Until(q.Until).Round(time.Minute), len(q.Digest))
+		if q.Reason != "" {
+			line += style.Dim.Render(" — " + q.Reason)
+		}
+		fmt.Println(line)
+	}
+	return nil
+}
@@ -226,6 +226,34 @@ Examples:
 
 var refineryBlockedJSON bool
 
+var refineryProcessCmd = &cobra.Command{
+	Use:   "process [rig]",
+	Short: "Work through the merge queue",
+	Long: `Work through the rig's merge queue serially, highest score first.
+
+MRs are enqueued by 'gt done' and 'gt mq submit' (including integration
+branches bound for main). For each ready MR, the refinery:
+
+  1. Claims it and squash-merges the branch onto its target, freshly pulled
+  2. Runs the configured quality gates (merge_queue.gates, or test_command)
+     on the merged result
+  3. Pushes the target if the gates pass, closing the MR and its issue
+
+An MR that fails its gates is bounced back to its author: they get the
+failure log as mail, and the MR is closed as rejected until they fix the
+branch and resubmit. An MR that conflicts with its target is blocked on a
+conflict bead listing the conflicting files and hunks; the bead goes on the
+author's hook and they are nudged with the commands to rebase and resume.
+
+Examples:
+  gt refinery process
+  gt refinery process gastown --max 1`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runRefineryProcess,
+}
+
+var refineryProcessMax int
+
 func init() {
 	// Start flags
 	refineryStartCmd.Flags().BoolVar(&refineryForeground, "foreground", false, "Run in foreground (default: background)")
@@ -253,6 +281,9 @@ func init() {
 	// Blocked flags
 	refineryBlockedCmd.Flags().BoolVar(&refineryBlockedJSON, "json", false, "Output as JSON")
 
+	// Process flags
+	refineryProcessCmd.Flags().IntVar(&refineryProcessMax, "max", 0, "Process at most this many MRs (0 = until the queue is empty)")
+
 	// Add subcommands
 	refineryCmd.AddCommand(refineryStartCmd)
 	refineryCmd.AddCommand(refineryStopCmd)
@@ -265,6 +296,7 @@ func init() {
 	refineryCmd.AddCommand(refineryUnclaimedCmd)
 	refineryCmd.AddCommand(refineryReadyCmd)
 	refineryCmd.AddCommand(refineryBlockedCmd)
+	refineryCmd.AddCommand(refineryProcessCmd)
 
 	rootCmd.AddCommand(refineryCmd)
 }
@@ -857,3 +889,34 @@ func runRefineryBlocked(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+func runRefineryProcess(cmd *cobra.Command, args []string) error {
+	rigName := ""
+	if len(args) > 0 {
+		rigName = args[0]
+	}
+
+	_, r, rigName, err := getRefineryManager(rigName)
+	if err != nil {
+		return err
+	}
+
+	eng := refinery.NewEngineer(r)
+	if err := eng.LoadConfig(); err != nil {
+		return fmt.Errorf("loading merge queue config: %w", err)
+	}
+	if !eng.Config().Enabled {
+		fmt.Printf("Merge queue is disabled for %s (merge_queue.enabled=false)\n", rigName)
+		return nil
+	}
+
+	result, err := eng.ProcessQueue(cmd.Context(), refineryProcessMax)
+	if result != nil {
+		fmt.Printf("\n%s Merge queue for '%s': %d merged, %d bounced, %d failed\n",
+			style.Bold.Render("📋"), rigName, len(result.Merged), len(result.Bounced), len(result.Failed))
+	}
+	if err != nil {
+		return fmt.Errorf("processing merge queue: %w", err)
+	}
+	return nil
+}
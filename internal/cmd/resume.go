@@ -15,22 +15,43 @@ import (
 var resumeCmd = &cobra.Command{
 	Use:     "resume",
 	GroupID: GroupWork,
-	Short:   "Check for handoff messages",
+	Short:   "Check for handoff messages, or cold-start a quiesced town",
 	Long: `Check the inbox for handoff messages and display them for continuation.
 
 The resume command checks for messages with "HANDOFF" in the subject
 and displays them formatted for easy continuation.
 
+With --town, cold-start a town shut down by gt quiesce instead:
+
+  1. Lift the scheduler and deacon pauses quiesce made.
+  2. Hook each agent's quiesce checkpoint back to it, and re-hook work
+     that came off its hook, so each session starts from where it was.
+  3. Start the town (gt up), then the polecats and crew that were stopped.
+  4. Verify every agent reaches its prompt within --ready-timeout.
+  5. Wake agents with unread mail or queued nudges so they get them now.
+
+Agents that fail to come back are reported, and the dispatch block stays
+until they do: fix them and rerun, or use --force. --no-start only lifts
+the dispatch block and pauses.
+
 Examples:
-  gt resume    # Check inbox for handoff messages`,
+  gt resume                 # Check inbox for handoff messages
+  gt resume --town          # Bring a quiesced town back up`,
 	RunE: runResume,
 }
 
+var resumeTown bool
+
 func init() {
+	resumeCmd.Flags().BoolVar(&resumeTown, "town", false, "Cold-start the town from its gt quiesce manifest")
+	addTownResumeFlags(resumeCmd)
 	rootCmd.AddCommand(resumeCmd)
 }
 
 func runResume(cmd *cobra.Command, args []string) error {
+	if resumeTown {
+		return runTownResume(cmd, args)
+	}
 	return checkHandoffMessages()
 }
 
@@ -0,0 +1,272 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/deacon"
+	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/nudge"
+	"github.com/steveyegge/gastown/internal/polecat"
+	"github.com/steveyegge/gastown/internal/quiesce"
+	"github.com/steveyegge/gastown/internal/scheduler/capacity"
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var (
+	townResumeNoStart      bool
+	townResumeForce        bool
+	townResumeReadyTimeout time.Duration
+)
+
+// addTownResumeFlags registers the town resume flags on cmd. gt resume
+// --town and gt quiesce resume share them.
+func addTownResumeFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&townResumeNoStart, "no-start", false, "Only lift the dispatch block and pauses; start nothing")
+	cmd.Flags().BoolVar(&townResumeForce, "force", false, "Clear the manifest even if some agents fail to come back")
+	cmd.Flags().DurationVar(&townResumeReadyTimeout, "ready-timeout", constants.ClaudeStartTimeout, "How long each agent has to reach its prompt")
+}
+
+// townResumeResult is what happened to one agent on resume.
+type townResumeResult struct {
+	agent    quiesce.Agent
+	rehooked bool
+	flushed  string // What the wake-up nudge pointed at, if one was sent
+	err      error  // Why it failed to rehydrate
+}
+
+// runTownResume cold-starts a town from its quiesce manifest:
+//  1. Lift the scheduler and deacon pauses quiesce made.
+//  2. Hook each checkpoint back to its agent and re-hook work that came
+//     off its hook, before any session starts, so gt prime finds both.
+//  3. Start the town (gt up), then the polecats and crew that were stopped.
+//  4. Wait for every recorded agent to reach its prompt.
+//  5. Wake agents with unread mail or queued nudges, so they're delivered
+//     now rather than whenever the agent next takes a turn.
+//
+// The manifest, and with it the dispatch block, is cleared only when every
+// agent came back (or with --force); rerunning resumes what's left.
+func runTownResume(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	m, err := quiesce.Load(townRoot)
+	if err != nil {
+		return err
+	}
+	if m == nil {
+		fmt.Printf("%s Town is not quiesced\n", style.Dim.Render("○"))
+		return nil
+	}
+
+	if m.PausedScheduler {
+		if state, err := capacity.LoadState(townRoot); err == nil && state.Paused {
+			state.SetResumed()
+			_ = capacity.SaveState(townRoot, state)
+		}
+	}
+	if m.PausedDeacon {
+		_ = deacon.Resume(townRoot)
+	}
+
+	if townResumeNoStart || m.Phase != quiesce.PhaseStopped {
+		if m.Phase != quiesce.PhaseStopped {
+			fmt.Printf("%s Quiesce was interrupted while %s; sessions were left running\n", style.Dim.Render("○"), m.Phase)
+		}
+		if err := quiesce.Clear(townRoot); err != nil {
+			return fmt.Errorf("clearing manifest: %w", err)
+		}
+		fmt.Printf("%s Dispatch unblocked\n", style.Bold.Render("✓"))
+		return nil
+	}
+
+	results := make([]townResumeResult, len(m.Agents))
+	for i := range m.Agents {
+		results[i].agent = m.Agents[i]
+	}
+
+	// Restore before anything starts: a session primes once, at startup.
+	fmt.Println("Restoring checkpoints and hooks...")
+	for i := range m.Agents {
+		a := &m.Agents[i]
+		if a.HookedBead != "" && workNeedsRehook(a.HookedBead, a.Address) {
+			if err := rehookWork(townRoot, a.HookedBead, a.Address, a.Session); err != nil {
+				style.PrintWarning("could not re-hook %s to %s: %v", a.HookedBead, a.Address, err)
+			} else {
+				results[i].rehooked = true
+			}
+		}
+		if a.Checkpoint != "" && !a.Restored {
+			if err := restoreQuiesceCheckpoint(townRoot, *a); err != nil {
+				results[i].err = fmt.Errorf("restoring checkpoint %s: %w", a.Checkpoint, err)
+				continue
+			}
+			a.Restored = true
+			_ = quiesce.Save(townRoot, m)
+		}
+	}
+
+	gtPath, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	up := exec.Command(gtPath, "up") //nolint:gosec // G204: re-invoking gt
+	up.Stdout = os.Stdout
+	up.Stderr = os.Stderr
+	if err := up.Run(); err != nil {
+		style.PrintWarning("gt up: %v", err)
+	}
+
+	t := tmux.NewTmux()
+	for i, a := range m.Agents {
+		if results[i].err != nil || !isQuiesceWorker(a) {
+			continue
+		}
+		if err := startQuiescedWorker(t, townRoot, a); err != nil {
+			results[i].err = fmt.Errorf("starting session: %w", err)
+		}
+	}
+
+	fmt.Printf("\nWaiting up to %s for agents to reach their prompt...\n", townResumeReadyTimeout)
+	verifyAgentsReady(t, results, func(a quiesce.Agent) *config.RuntimeConfig {
+		return config.ResolveRoleAgentConfig(a.Role, townRoot, filepath.Join(townRoot, a.Rig))
+	}, townResumeReadyTimeout)
+
+	router := mail.NewRouter(townRoot)
+	for i := range results {
+		if results[i].err == nil {
+			results[i].flushed = flushAgentBacklog(t, router, townRoot, results[i].agent)
+		}
+	}
+
+	failed := printTownResumeReport(results)
+	if failed > 0 && !townResumeForce {
+		return fmt.Errorf("%d agent(s) failed to rehydrate; dispatch is still blocked. Fix them and rerun, or use --force", failed)
+	}
+	if err := quiesce.Clear(townRoot); err != nil {
+		return fmt.Errorf("clearing manifest: %w", err)
+	}
+	fmt.Printf("\n%s Town resumed; dispatch unblocked\n", style.Bold.Render("✓"))
+	return nil
+}
+
+// restoreQuiesceCheckpoint hooks an agent's quiesce checkpoint to it as mail.
+func restoreQuiesceCheckpoint(townRoot string, a quiesce.Agent) error {
+	snap, err := loadCheckpointSnapshot(townRoot, a.Checkpoint)
+	if err != nil {
+		return err
+	}
+	_, err = hookCheckpointMail(townRoot, a.Checkpoint, a.Address, snap)
+	return err
+}
+
+// startQuiescedWorker restarts a polecat or crew session stopped by quiesce.
+func startQuiescedWorker(t *tmux.Tmux, townRoot string, a quiesce.Agent) error {
+	if a.Role == string(session.RoleCrew) {
+		return startCrewMember(a.Rig, a.Name, townRoot)
+	}
+	_, r, err := getRig(a.Rig)
+	if err != nil {
+		return err
+	}
+	err = polecat.NewSessionManager(t, r).Start(a.Name, polecat.SessionStartOptions{})
+	if err == polecat.ErrSessionRunning {
+		return nil
+	}
+	return err
+}
+
+// readyWaiter is the tmux surface readiness checks need.
+type readyWaiter interface {
+	HasSession(name string) (bool, error)
+	WaitForRuntimeReady(session string, rc *config.RuntimeConfig, timeout time.Duration) error
+}
+
+// verifyAgentsReady waits, in parallel, for each agent without an error
+// yet to reach its prompt, recording an error for any that don't.
+func verifyAgentsReady(t readyWaiter, results []townResumeResult, runtimeFor func(quiesce.Agent) *config.RuntimeConfig, timeout time.Duration) {
+	var wg sync.WaitGroup
+	for i := range results {
+		if results[i].err != nil {
+			continue
+		}
+		wg.Add(1)
+		go func(r *townResumeResult) {
+			defer wg.Done()
+			if running, _ := t.HasSession(r.agent.Session); !running {
+				r.err = fmt.Errorf("session %s is not running", r.agent.Session)
+				return
+			}
+			if err := t.WaitForRuntimeReady(r.agent.Session, runtimeFor(r.agent), timeout); err != nil {
+				r.err = fmt.Errorf("not ready: %w", err)
+			}
+		}(&results[i])
+	}
+	wg.Wait()
+}
+
+// flushAgentBacklog wakes an agent with unread mail or queued nudges, so
+// its next turn (and the nudge-drain hook with it) happens now. Returns
+// what the agent was told about, or "" if nothing was waiting.
+func flushAgentBacklog(t *tmux.Tmux, router *mail.Router, townRoot string, a quiesce.Agent) string {
+	var waiting []string
+	if mbox, err := router.GetMailbox(a.Address); err == nil {
+		if _, unread, err := mbox.Count(); err == nil && unread > 0 {
+			waiting = append(waiting, fmt.Sprintf("%d unread mail", unread))
+		}
+	}
+	if n, err := nudge.Pending(townRoot, a.Session); err == nil && n > 0 {
+		waiting = append(waiting, fmt.Sprintf("%d queued nudge(s)", n))
+	}
+	if len(waiting) == 0 {
+		return ""
+	}
+	summary := strings.Join(waiting, ", ")
+	msg := fmt.Sprintf("[RESUME] Gas Town is back up. Waiting for you: %s. Check gt mail inbox, then carry on with your hooked work.", summary)
+	if err := t.NudgeSession(a.Session, msg); err != nil {
+		return ""
+	}
+	return summary
+}
+
+// printTownResumeReport prints one line per agent and returns how many
+// failed to rehydrate.
+func printTownResumeReport(results []townResumeResult) int {
+	fmt.Println()
+	failed := 0
+	for _, r := range results {
+		if r.err != nil {
+			failed++
+			fmt.Printf("%s %s: %v\n", style.ErrorPrefix, r.agent.Address, r.err)
+			continue
+		}
+		var notes []string
+		if r.agent.Checkpoint != "" {
+			notes = append(notes, "checkpoint "+r.agent.Checkpoint)
+		}
+		if r.rehooked {
+			notes = append(notes, "re-hooked "+r.agent.HookedBead)
+		}
+		if r.flushed != "" {
+			notes = append(notes, "woken for "+r.flushed)
+		}
+		detail := "ready"
+		if len(notes) > 0 {
+			detail += " (" + strings.Join(notes, ", ") + ")"
+		}
+		fmt.Printf("%s %s: %s\n", style.SuccessPrefix, r.agent.Address, style.Dim.Render(detail))
+	}
+	return failed
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/quiesce"
+)
+
+type fakeReadySessions struct {
+	running map[string]bool
+	ready   map[string]bool
+}
+
+func (f *fakeReadySessions) HasSession(name string) (bool, error) { return f.running[name], nil }
+
+func (f *fakeReadySessions) WaitForRuntimeReady(sess string, rc *config.RuntimeConfig, timeout time.Duration) error {
+	if rc == nil {
+		return errors.New("no runtime config")
+	}
+	if !f.ready[sess] {
+		return errors.New("timed out")
+	}
+	return nil
+}
+
+func TestVerifyAgentsReady(t *testing.T) {
+	f := &fakeReadySessions{
+		running: map[string]bool{"gt-nux": true, "gt-max": true},
+		ready:   map[string]bool{"gt-nux": true},
+	}
+	startErr := errors.New("starting session: boom")
+	results := []townResumeResult{
+		{agent: quiesce.Agent{Address: "gastown/polecats/nux", Session: "gt-nux"}},
+		{agent: quiesce.Agent{Address: "gastown/crew/max", Session: "gt-max"}},
+		{agent: quiesce.Agent{Address: "gastown/polecats/gone", Session: "gt-gone"}},
+		{agent: quiesce.Agent{Address: "gastown/polecats/bad", Session: "gt-bad"}, err: startErr},
+	}
+	verifyAgentsReady(f, results, func(quiesce.Agent) *config.RuntimeConfig {
+		return &config.RuntimeConfig{}
+	}, time.Second)
+
+	if results[0].err != nil {
+		t.Errorf("ready agent err = %v", results[0].err)
+	}
+	if results[1].err == nil || !strings.Contains(results[1].err.Error(), "not ready") {
+		t.Errorf("busy agent err = %v, want not ready", results[1].err)
+	}
+	if results[2].err == nil || !strings.Contains(results[2].err.Error(), "not running") {
+		t.Errorf("missing session err = %v, want not running", results[2].err)
+	}
+	if results[3].err != startErr {
+		t.Errorf("earlier failure overwritten: %v", results[3].err)
+	}
+	if failed := printTownResumeReport(results); failed != 3 {
+		t.Errorf("report counted %d failures, want 3", failed)
+	}
+}
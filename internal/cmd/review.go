@@ -0,0 +1,550 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// maxReviewDiffBytes caps the diff attached to a review bead. The bead's
+// description is passed to bd as an argument, so it must stay well under
+// the kernel's per-argument limit.
+const maxReviewDiffBytes = 64 * 1024
+
+// Integration review states besides the verdicts: never reviewed, or last
+// reviewed at an older head.
+const (
+	reviewStateNone  = "none"
+	reviewStateStale = "stale"
+)
+
+var (
+	reviewApproveRole  string
+	reviewChangesNotes string
+	reviewListJSON     bool
+)
+
+var reviewCmd = &cobra.Command{
+	Use:     "review",
+	GroupID: GroupWork,
+	Short:   "Request and respond to witness code reviews",
+	Long: `Review work before it merges.
+
+Integration branches: with integration_branch_review enabled in the rig's
+merge_queue settings, an integration branch can't land until the witness
+approves it. Once an epic's work is merged, the refinery runs gt review
+request, which files a review bead (label gt:review) with the branch's diff
+attached, assigns it to the rig's witness, and mails it. The witness
+responds with gt review approve or gt review request-changes. The verdict
+holds for the commit reviewed: new commits on the branch need a new review.
+
+Dispatched work: a dispatcher routing rule with "review" (see gt dispatch)
+labels the beads it assigns review:<role>, and gt done mails the reviewer
+when the work is submitted. The refinery holds a bead's merge request while
+any review label remains; the reviewer clears theirs with
+gt review approve <bead>.
+
+COMMANDS:
+  request           Ask the witness to review an epic's integration branch
+  list              Show open review requests in this rig
+  approve           Approve a review, or clear a bead's review labels
+  request-changes   Reject a review with notes for the refinery and mayor`,
+	RunE: requireSubcommand,
+}
+
+var reviewRequestCmd = &cobra.Command{
+	Use:   "request <epic-id>",
+	Short: "Ask the witness to review an epic's integration branch",
+	Long: `File a review bead for an epic's integration branch and mail the witness.
+
+The bead records the branch head being reviewed and carries the diff
+against the branch's target (truncated if very large). If a review of the
+current head is already pending or approved, nothing is filed; a pending
+review of an older head is closed as superseded.
+
+Run from within the rig (the refinery does this during patrol).
+
+Examples:
+  gt review request gt-epic-abc`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runReviewRequest,
+}
+
+var reviewListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Show open review requests in this rig",
+	Args:  cobra.NoArgs,
+	RunE:  runReviewList,
+}
+
+var reviewApproveCmd = &cobra.Command{
+	Use:   "approve <review-or-bead-id>",
+	Short: "Approve a review, or clear a bead's review labels",
+	Long: `Approve a review.
+
+Given a review bead (from gt review request), records the approval and
+closes the bead; the refinery lands the integration branch on its next
+patrol if nothing else is outstanding.
+
+Given any other bead, clears its review:<role> labels, releasing its merge
+request to the refinery once none remain. Only the reviewer for a role can
+clear it; without --role, every pending review that is yours is cleared.
+
+Only the review's assignee (the rig's witness) can approve or request
+changes on a review bead.
+
+Examples:
+  gt review approve gt-rev-abc
+  gt review approve gt-abc --role witness`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runReviewApprove,
+}
+
+var reviewRequestChangesCmd = &cobra.Command{
+	Use:   "request-changes <review-id>",
+	Short: "Reject a review with notes for the refinery and mayor",
+	Long: `Record that an integration branch needs changes before it lands.
+
+Closes the review bead with your notes and mails them to the rig's refinery
+and the mayor. The branch won't land until new commits are pushed and a new
+review is approved.
+
+Examples:
+  gt review request-changes gt-rev-abc -m "Migration drops the index the API relies on"`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runReviewRequestChanges,
+}
+
+func init() {
+	reviewApproveCmd.Flags().StringVar(&reviewApproveRole, "role", "", "Only clear this role's review (non-review beads)")
+	reviewRequestChangesCmd.Flags().StringVarP(&reviewChangesNotes, "message", "m", "", "What must change (required)")
+	_ = reviewRequestChangesCmd.MarkFlagRequired("message")
+	reviewListCmd.Flags().BoolVar(&reviewListJSON, "json", false, "Output as JSON")
+
+	reviewCmd.AddCommand(reviewRequestCmd)
+	reviewCmd.AddCommand(reviewListCmd)
+	reviewCmd.AddCommand(reviewApproveCmd)
+	reviewCmd.AddCommand(reviewRequestChangesCmd)
+	rootCmd.AddCommand(reviewCmd)
+}
+
+// reviewStateAt returns where an integration branch's review stands at head,
+// given its latest review bead (nil if none).
+func reviewStateAt(review *beads.Issue, fields *beads.ReviewFields, head string) string {
+	if review == nil || fields == nil {
+		return reviewStateNone
+	}
+	if fields.Head != head {
+		return reviewStateStale
+	}
+	return beads.ReviewVerdict(review)
+}
+
+// integrationReviewState looks up an epic's latest review and returns its
+// state at head, with the review bead.
+func integrationReviewState(bd *beads.Beads, epicID, head string) (string, *beads.Issue, error) {
+	review, fields, err := bd.LatestReview(epicID)
+	if err != nil {
+		return "", nil, err
+	}
+	return reviewStateAt(review, fields, head), review, nil
+}
+
+// integrationReviewRequired reports whether a rig's integration branches
+// need witness review before landing.
+func integrationReviewRequired(rigPath string) bool {
+	settings, err := config.LoadRigSettings(filepath.Join(rigPath, "settings", "config.json"))
+	return err == nil && settings != nil && settings.MergeQueue != nil &&
+		settings.MergeQueue.IsIntegrationBranchReviewEnabled()
+}
+
+// shortSHA abbreviates a commit hash for display.
+func shortSHA(sha string) string {
+	if len(sha) > 8 {
+		return sha[:8]
+	}
+	return sha
+}
+
+// truncateReviewDiff cuts a diff to maxReviewDiffBytes at a line boundary,
+// reporting whether it did.
+func truncateReviewDiff(diff string) (string, bool) {
+	if len(diff) <= maxReviewDiffBytes {
+		return diff, false
+	}
+	cut := diff[:maxReviewDiffBytes]
+	if i := strings.LastIndexByte(cut, '\n'); i > 0 {
+		cut = cut[:i]
+	}
+	return cut, true
+}
+
+func runReviewRequest(cmd *cobra.Command, args []string) error {
+	epicID := args[0]
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	_, r, err := findCurrentRig(townRoot)
+	if err != nil {
+		return err
+	}
+	bd := beads.New(r.Path)
+	g, err := getRigGit(r.Path)
+	if err != nil {
+		return fmt.Errorf("initializing git: %w", err)
+	}
+	if err := g.Fetch("origin"); err != nil {
+		return fmt.Errorf("fetching from origin: %w", err)
+	}
+
+	epic, err := bd.Show(epicID)
+	if err != nil {
+		return fmt.Errorf("fetching epic %s: %w", epicID, err)
+	}
+	branch := resolveEpicBranch(epic, r.Path, g)
+	target := beads.GetBaseBranchField(epic.Description)
+	if target == "" {
+		target = r.DefaultBranch()
+	}
+	head, err := g.Rev("origin/" + branch)
+	if err != nil {
+		return fmt.Errorf("integration branch '%s' is not on origin: %w", branch, err)
+	}
+
+	state, prev, err := integrationReviewState(bd, epicID, head)
+	if err != nil {
+		return err
+	}
+	switch state {
+	case beads.ReviewPending, beads.ReviewApproved:
+		fmt.Printf("%s Review of %s at %s already %s: %s\n", style.Dim.Render("•"), branch, shortSHA(head), state, prev.ID)
+		return nil
+	case reviewStateStale:
+		if beads.ReviewVerdict(prev) == beads.ReviewPending {
+			if err := bd.CloseWithReason("superseded: "+branch+" moved to "+shortSHA(head), prev.ID); err != nil {
+				style.PrintWarning("could not close superseded review %s: %v", prev.ID, err)
+			}
+		}
+	}
+
+	base, tip := "origin/"+target, "origin/"+branch
+	stat, err := g.DiffStat(base, tip)
+	if err != nil {
+		return fmt.Errorf("diffing %s against %s: %w", branch, target, err)
+	}
+	diff, err := g.Diff(base, tip)
+	if err != nil {
+		return fmt.Errorf("diffing %s against %s: %w", branch, target, err)
+	}
+	if d, truncated := truncateReviewDiff(diff); truncated {
+		diff = d + fmt.Sprintf("\n# ... diff truncated at %d KB; see git diff %s...%s", maxReviewDiffBytes/1024, base, tip)
+	}
+
+	sender, err := resolveMailSender()
+	if err != nil {
+		return err
+	}
+	witness := r.Name + "/witness"
+	fields := &beads.ReviewFields{Epic: epicID, Branch: branch, Target: target, Head: head}
+	review, err := bd.Create(beads.CreateOptions{
+		Title:       fmt.Sprintf("Review %s: %s", branch, epic.Title),
+		Labels:      []string{beads.LabelReview},
+		Priority:    epic.Priority,
+		Description: beads.FormatReviewFields(fields, stat, diff),
+		Actor:       sender,
+	})
+	if err != nil {
+		return fmt.Errorf("creating review bead: %w", err)
+	}
+	if err := bd.Update(review.ID, beads.UpdateOptions{Assignee: &witness}); err != nil {
+		style.PrintWarning("could not assign %s to %s: %v", review.ID, witness, err)
+	}
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "Please review integration branch %s before it lands on %s.\n\n", branch, target)
+	fmt.Fprintf(&body, "Epic:   %s %s\nHead:   %s\nReview: %s (diff attached: bd show %s)\n\n", epicID, epic.Title, head, review.ID, review.ID)
+	fmt.Fprintf(&body, "%s\n\n", stat)
+	fmt.Fprintf(&body, "Respond with one of:\n  gt review approve %s\n  gt review request-changes %s -m \"...\"\n", review.ID, review.ID)
+
+	router := mail.NewRouter(townRoot)
+	defer router.WaitPendingNotifications()
+	if err := router.Send(&mail.Message{
+		From:     sender,
+		To:       witness,
+		Subject:  fmt.Sprintf("REVIEW_REQUESTED: %s %s", branch, review.ID),
+		Body:     body.String(),
+		Type:     mail.TypeTask,
+		Priority: mail.PriorityNormal,
+	}); err != nil {
+		style.PrintWarning("could not mail %s: %v", witness, err)
+	}
+
+	fmt.Printf("%s Requested review of %s at %s: %s → %s\n", style.SuccessPrefix, style.Bold.Render(branch), shortSHA(head), review.ID, witness)
+	return nil
+}
+
+// requestPendingReviews mails each reviewer an issue still awaits (its
+// review:<role> labels, added by the dispatcher) now that its work has been
+// submitted to the merge queue as mrID. Failures are warnings: the labels
+// hold the merge regardless.
+func requestPendingReviews(townRoot, rigName string, bd *beads.Beads, issueID, mrID, branch, from string) {
+	issue, err := bd.Show(issueID)
+	if err != nil {
+		return
+	}
+	roles := beads.PendingReviews(issue)
+	if len(roles) == 0 {
+		return
+	}
+	router := mail.NewRouter(townRoot)
+	defer router.WaitPendingNotifications()
+	for _, role := range roles {
+		reviewer := reviewerFor(rigName, role)
+		body := fmt.Sprintf("%s submitted %s (%s) to the merge queue as %s, branch %s. It requires %s review.\n\n"+
+			"The merge request is held until you approve it:\n\n  gt review approve %s --role %s\n",
+			from, issue.ID, issue.Title, mrID, branch, role, issue.ID, role)
+		if err := router.Send(&mail.Message{
+			From:     from,
+			To:       reviewer,
+			Subject:  fmt.Sprintf("REVIEW_REQUIRED: %s %s", issue.ID, issue.Title),
+			Body:     body,
+			Type:     mail.TypeTask,
+			Priority: mail.PriorityNormal,
+		}); err != nil {
+			style.PrintWarning("could not mail %s about reviewing %s: %v", reviewer, issue.ID, err)
+		}
+	}
+}
+
+// reviewListItem is one open review request in gt review list --json.
+type reviewListItem struct {
+	ID       string `json:"id"`
+	Epic     string `json:"epic"`
+	Branch   string `json:"branch"`
+	Target   string `json:"target"`
+	Head     string `json:"head"`
+	Assignee string `json:"assignee,omitempty"`
+	Title    string `json:"title"`
+}
+
+func runReviewList(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	_, r, err := findCurrentRig(townRoot)
+	if err != nil {
+		return err
+	}
+	issues, err := beads.New(r.Path).List(beads.ListOptions{Status: "open", Label: beads.LabelReview, Priority: -1})
+	if err != nil {
+		return fmt.Errorf("listing reviews: %w", err)
+	}
+	items := make([]reviewListItem, 0, len(issues))
+	for _, is := range issues {
+		fields := beads.ParseReviewFields(is)
+		if fields == nil {
+			continue
+		}
+		items = append(items, reviewListItem{
+			ID: is.ID, Epic: fields.Epic, Branch: fields.Branch, Target: fields.Target,
+			Head: fields.Head, Assignee: is.Assignee, Title: is.Title,
+		})
+	}
+
+	if reviewListJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(items)
+	}
+	if len(items) == 0 {
+		fmt.Printf("%s No open review requests in %s\n", style.Dim.Render("○"), r.Name)
+		return nil
+	}
+	for _, it := range items {
+		fmt.Printf("  %s %s → %s at %s %s\n", style.Bold.Render(it.ID), it.Branch, it.Target, shortSHA(it.Head),
+			style.Dim.Render("(epic "+it.Epic+")"))
+	}
+	return nil
+}
+
+// showReviewTarget finds a bead for gt review approve/request-changes,
+// returning its beads client and the issue.
+func showReviewTarget(townRoot, id string) (*beads.Beads, *beads.Issue, error) {
+	bd := beads.New(beads.ResolveHookDir(townRoot, id, ""))
+	issue, err := bd.Show(id)
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting %s: %w", id, err)
+	}
+	return bd, issue, nil
+}
+
+func runReviewApprove(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	beadID := args[0]
+	bd, issue, err := showReviewTarget(townRoot, beadID)
+	if err != nil {
+		return err
+	}
+	if beads.HasLabel(issue, beads.LabelReview) {
+		return recordReviewVerdict(townRoot, bd, issue, beads.ReviewApproved, "")
+	}
+
+	pending := beads.PendingReviews(issue)
+	roles := pending
+	if reviewApproveRole != "" {
+		if !slices.Contains(pending, reviewApproveRole) {
+			return fmt.Errorf("%s is not awaiting %s review", beadID, reviewApproveRole)
+		}
+		roles = []string{reviewApproveRole}
+	}
+	if len(roles) == 0 {
+		fmt.Printf("%s %s is not awaiting review\n", style.Dim.Render("•"), beadID)
+		return nil
+	}
+
+	// Only the reviewer may clear a review.
+	caller, err := resolveMailSender()
+	if err != nil {
+		return err
+	}
+	rigName := resolveRigForBead(townRoot, beadID)
+	roles = reviewRolesFor(caller, rigName, roles)
+	if len(roles) == 0 {
+		var want []string
+		for _, role := range pending {
+			want = append(want, reviewerFor(rigName, role))
+		}
+		return fmt.Errorf("%s awaits review by %s, not %s", beadID, strings.Join(want, ", "), caller)
+	}
+
+	labels := make([]string, len(roles))
+	for i, role := range roles {
+		labels[i] = beads.ReviewLabel(role)
+	}
+	if err := bd.Update(beadID, beads.UpdateOptions{RemoveLabels: labels}); err != nil {
+		return fmt.Errorf("clearing review of %s: %w", beadID, err)
+	}
+
+	fmt.Printf("%s Approved %s %s\n", style.SuccessPrefix, style.Bold.Render(beadID), style.Dim.Render("("+strings.Join(roles, ", ")+" review)"))
+	if len(pending) > len(roles) {
+		fmt.Printf("  Still awaiting review by %d other role(s)\n", len(pending)-len(roles))
+	}
+	return nil
+}
+
+func runReviewRequestChanges(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	bd, issue, err := showReviewTarget(townRoot, args[0])
+	if err != nil {
+		return err
+	}
+	if !beads.HasLabel(issue, beads.LabelReview) {
+		return fmt.Errorf("%s is not a review request (label %s)", issue.ID, beads.LabelReview)
+	}
+	return recordReviewVerdict(townRoot, bd, issue, beads.ReviewChangesRequested, reviewChangesNotes)
+}
+
+// reviewRolesFor returns the roles, of those given, whose reviewer in
+// rigName is caller.
+func reviewRolesFor(caller, rigName string, roles []string) []string {
+	var mine []string
+	for _, role := range roles {
+		if mail.AddressToIdentity(reviewerFor(rigName, role)) == mail.AddressToIdentity(caller) {
+			mine = append(mine, role)
+		}
+	}
+	return mine
+}
+
+// recordReviewVerdict labels and closes a review bead with the verdict and
+// mails it to the rig's refinery, and for requested changes to the mayor.
+// Only the review's assignee (the rig's witness) may record a verdict.
+func recordReviewVerdict(townRoot string, bd *beads.Beads, review *beads.Issue, verdict, notes string) error {
+	if v := beads.ReviewVerdict(review); v != beads.ReviewPending {
+		return fmt.Errorf("review %s already has a verdict: %s", review.ID, v)
+	}
+	fields := beads.ParseReviewFields(review)
+	if fields == nil {
+		return fmt.Errorf("review %s has no branch fields", review.ID)
+	}
+
+	sender, err := resolveMailSender()
+	if err != nil {
+		return err
+	}
+	reviewer := review.Assignee
+	if reviewer == "" {
+		reviewer = reviewerFor(resolveRigForBead(townRoot, review.ID), constants.RoleWitness)
+	}
+	if mail.AddressToIdentity(reviewer) != mail.AddressToIdentity(sender) {
+		return fmt.Errorf("review %s is assigned to %s, not %s", review.ID, reviewer, sender)
+	}
+	reason := verdict + " by " + sender
+	if notes != "" {
+		reason += ": " + notes
+	}
+	if err := bd.Update(review.ID, beads.UpdateOptions{AddLabels: []string{beads.VerdictLabel(verdict)}}); err != nil {
+		return fmt.Errorf("recording verdict on %s: %w", review.ID, err)
+	}
+	if err := bd.CloseWithReason(reason, review.ID); err != nil {
+		return fmt.Errorf("closing %s: %w", review.ID, err)
+	}
+
+	recipients := []string{"mayor/"}
+	if rigName := resolveRigForBead(townRoot, review.ID); rigName != "" {
+		recipients = []string{rigName + "/" + constants.RoleRefinery}
+		if verdict == beads.ReviewChangesRequested {
+			recipients = append(recipients, "mayor/")
+		}
+	}
+	subject := fmt.Sprintf("REVIEW_%s: %s %s", strings.ToUpper(strings.ReplaceAll(verdict, "-", "_")), fields.Branch, review.ID)
+	body := fmt.Sprintf("%s reviewed %s at %s (epic %s): %s.\n", sender, fields.Branch, shortSHA(fields.Head), fields.Epic, verdict)
+	if notes != "" {
+		body += "\n" + notes + "\n\nThe branch won't land until new commits are pushed and a new review is approved.\n"
+	}
+
+	router := mail.NewRouter(townRoot)
+	defer router.WaitPendingNotifications()
+	for _, to := range recipients {
+		if err := router.Send(&mail.Message{
+			From:     sender,
+			To:       to,
+			Subject:  subject,
+			Body:     body,
+			Type:     mail.TypeNotification,
+			Priority: mail.PriorityNormal,
+		}); err != nil {
+			style.PrintWarning("could not mail %s: %v", to, err)
+		}
+	}
+
+	mark := style.SuccessPrefix
+	if verdict != beads.ReviewApproved {
+		mark = style.WarningPrefix
+	}
+	fmt.Printf("%s %s %s at %s %s\n", mark, strings.ToUpper(verdict[:1])+verdict[1:], style.Bold.Render(fields.Branch),
+		shortSHA(fields.Head), style.Dim.Render("("+review.ID+")"))
+	return nil
+}
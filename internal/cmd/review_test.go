@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/beads"
+)
+
+func TestReviewStateAt(t *testing.T) {
+	fields := &beads.ReviewFields{Epic: "gt-epic", Head: "abc"}
+	pending := &beads.Issue{ID: "gt-rev1", Labels: []string{beads.LabelReview}}
+	approved := &beads.Issue{ID: "gt-rev2", Labels: []string{beads.LabelReview, beads.VerdictLabel(beads.ReviewApproved)}}
+
+	tests := []struct {
+		name   string
+		review *beads.Issue
+		head   string
+		want   string
+	}{
+		{"never reviewed", nil, "abc", reviewStateNone},
+		{"pending at head", pending, "abc", beads.ReviewPending},
+		{"approved at head", approved, "abc", beads.ReviewApproved},
+		{"approved, then new commits", approved, "def", reviewStateStale},
+	}
+	for _, tt := range tests {
+		var f *beads.ReviewFields
+		if tt.review != nil {
+			f = fields
+		}
+		if got := reviewStateAt(tt.review, f, tt.head); got != tt.want {
+			t.Errorf("%s: state = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestTruncateReviewDiff(t *testing.T) {
+	small := "+one\n+two\n"
+	if got, cut := truncateReviewDiff(small); cut || got != small {
+		t.Errorf("small diff truncated: %q, %v", got, cut)
+	}
+
+	big := strings.Repeat("+0123456789abcdef\n", maxReviewDiffBytes/10)
+	got, cut := truncateReviewDiff(big)
+	if !cut || len(got) > maxReviewDiffBytes {
+		t.Fatalf("big diff: len %d, cut %v; want cut to at most %d", len(got), cut, maxReviewDiffBytes)
+	}
+	if !strings.HasSuffix(got, "+0123456789abcdef") {
+		t.Errorf("truncated diff should end on a whole line, ends %q", got[len(got)-20:])
+	}
+}
+
+func TestReviewRolesFor(t *testing.T) {
+	roles := []string{"witness", "mayor"}
+	tests := []struct {
+		caller string
+		want   []string
+	}{
+		{"gastown/witness", []string{"witness"}},
+		{"mayor/", []string{"mayor"}},
+		{"mayor", []string{"mayor"}},
+		{"gastown/polecats/nux", nil},
+		{"beads/witness", nil},
+		{"overseer", nil},
+	}
+	for _, tt := range tests {
+		if got := reviewRolesFor(tt.caller, "gastown", roles); !slices.Equal(got, tt.want) {
+			t.Errorf("reviewRolesFor(%q) = %v, want %v", tt.caller, got, tt.want)
+		}
+	}
+}
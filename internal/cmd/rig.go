@@ -69,6 +69,19 @@ The command also:
   - Creates ~/gt/plugins/ (town-level) if it doesn't exist
   - Creates <rig>/plugins/ (rig-level)
 
+Use --template to bootstrap the rig from a template in settings/rig-templates/
+(crew, polecat pool, witness/refinery patrols, agents, beads prefix); see
+'gt rig template list'.
+
+For large repos, tune what gets cloned (stored as "clone" in config.json;
+a template's clone settings apply unless these flags are given):
+  --depth N        Commits of history in the bare repo and mayor clone
+                   (default 1; -1 for full history)
+  --filter SPEC    Partial clone filter, e.g. blob:none (file contents
+                   fetched on demand) or tree:0
+  --sparse PATHS   Directories the mayor clone and polecat worktrees check
+                   out (sparse-checkout, cone mode)
+
 Use --adopt to register an existing directory instead of creating new:
   - Reads existing config.json if present
   - Auto-detects git URL from origin remote (git-url argument not required)
@@ -77,6 +90,8 @@ Use --adopt to register an existing directory instead of creating new:
 Example:
   gt rig add gastown https://github.com/steveyegge/gastown
   gt rig add my-project git@github.com:user/repo.git --prefix mp
+  gt rig add api git@github.com:user/api.git --template backend-service
+  gt rig add mono git@github.com:org/mono.git --filter blob:none --sparse services/api,libs
   gt rig add existing-rig --adopt`,
 	Args: cobra.RangeArgs(1, 2),
 	RunE: runRigAdd,
@@ -303,6 +318,10 @@ var (
 	rigAddAdopt        bool
 	rigAddAdoptURL     string
 	rigAddAdoptForce   bool
+	rigAddTemplate     string
+	rigAddDepth        int
+	rigAddFilter       string
+	rigAddSparse       []string
 	rigResetHandoff    bool
 	rigResetMail       bool
 	rigResetStale      bool
@@ -363,6 +382,10 @@ func init() {
 	rigAddCmd.Flags().BoolVar(&rigAddAdopt, "adopt", false, "Adopt an existing directory instead of creating new")
 	rigAddCmd.Flags().StringVar(&rigAddAdoptURL, "url", "", "Git remote URL for --adopt (default: auto-detected from origin)")
 	rigAddCmd.Flags().BoolVar(&rigAddAdoptForce, "force", false, "With --adopt, register even if git remote cannot be detected")
+	rigAddCmd.Flags().StringVar(&rigAddTemplate, "template", "", "Rig template to apply (from settings/rig-templates/)")
+	rigAddCmd.Flags().IntVar(&rigAddDepth, "depth", 0, "Commits of history to clone (default 1; -1 for full history)")
+	rigAddCmd.Flags().StringVar(&rigAddFilter, "filter", "", "Partial clone filter (e.g., blob:none)")
+	rigAddCmd.Flags().StringSliceVar(&rigAddSparse, "sparse", nil, "Directories to check out in the mayor clone and polecat worktrees")
 
 	rigResetCmd.Flags().BoolVar(&rigResetHandoff, "handoff", false, "Clear handoff content")
 	rigResetCmd.Flags().BoolVar(&rigResetMail, "mail", false, "Clear stale mail messages")
@@ -470,11 +493,41 @@ func checkUncommittedWork(r *rig.Rig, rigName, operation string, force bool) (pr
 	return confirmUnsafeProceed(force)
 }
 
+// rigAddCloneStrategy returns the clone strategy for gt rig add: the
+// template's, with --depth, --filter, and --sparse overriding it. Nil means
+// the default.
+func rigAddCloneStrategy(cmd *cobra.Command, tmpl *rig.Template) (*git.CloneStrategy, error) {
+	var s git.CloneStrategy
+	set := false
+	if tmpl != nil && tmpl.Clone != nil {
+		s, set = *tmpl.Clone, true
+	}
+	if cmd.Flags().Changed("depth") {
+		s.Depth, set = rigAddDepth, true
+	}
+	if cmd.Flags().Changed("filter") {
+		s.Filter, set = rigAddFilter, true
+	}
+	if cmd.Flags().Changed("sparse") {
+		s.Sparse, set = rigAddSparse, true
+	}
+	if !set {
+		return nil, nil
+	}
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
 func runRigAdd(cmd *cobra.Command, args []string) error {
 	name := args[0]
 
 	// Handle --adopt mode: register existing directory
 	if rigAddAdopt {
+		if rigAddTemplate != "" {
+			return fmt.Errorf("--template cannot be used with --adopt")
+		}
 		return runRigAdopt(cmd, args)
 	}
 
@@ -499,6 +552,28 @@ func runRigAdd(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("not in a Gas Town workspace: %w", err)
 	}
 
+	// Load the template first so a typo fails before anything is created.
+	// Explicit flags win over template values.
+	prefix, branch := rigAddPrefix, rigAddBranch
+	var tmpl *rig.Template
+	if rigAddTemplate != "" {
+		tmpl, err = rig.LoadTemplate(townRoot, rigAddTemplate)
+		if err != nil {
+			return err
+		}
+		if prefix == "" {
+			prefix = tmpl.Prefix
+		}
+		if branch == "" {
+			branch = tmpl.Branch
+		}
+	}
+
+	clone, err := rigAddCloneStrategy(cmd, tmpl)
+	if err != nil {
+		return err
+	}
+
 	// Load rigs config
 	rigsPath := filepath.Join(townRoot, "mayor", "rigs.json")
 	rigsConfig, err := config.LoadRigsConfig(rigsPath)
@@ -516,9 +591,15 @@ func runRigAdd(cmd *cobra.Command, args []string) error {
 
 	fmt.Printf("Creating rig %s...\n", style.Bold.Render(name))
 	fmt.Printf("  Repository: %s\n", gitURL)
+	if tmpl != nil {
+		fmt.Printf("  Template:   %s\n", tmpl.Name)
+	}
 	if rigAddLocalRepo != "" {
 		fmt.Printf("  Local repo: %s\n", rigAddLocalRepo)
 	}
+	if clone != nil {
+		fmt.Printf("  Clone:      %s\n", clone)
+	}
 
 	// Validate push URL if provided
 	rigAddPushURL = strings.TrimSpace(rigAddPushURL)
@@ -535,15 +616,21 @@ func runRigAdd(cmd *cobra.Command, args []string) error {
 	startTime := time.Now()
 
 	// Add the rig
-	newRig, err := mgr.AddRig(rig.AddRigOptions{
+	addOpts := rig.AddRigOptions{
 		Name:          name,
 		GitURL:        gitURL,
 		PushURL:       rigAddPushURL,
 		UpstreamURL:   rigAddUpstreamURL,
-		BeadsPrefix:   rigAddPrefix,
+		BeadsPrefix:   prefix,
 		LocalRepo:     rigAddLocalRepo,
-		DefaultBranch: rigAddBranch,
-	})
+		DefaultBranch: branch,
+		Clone:         clone,
+	}
+	if tmpl != nil && tmpl.Polecats != nil {
+		addOpts.PolecatPoolSize = tmpl.Polecats.Count
+		addOpts.PolecatNames = tmpl.Polecats.Names
+	}
+	newRig, err := mgr.AddRig(addOpts)
 	if err != nil {
 		return fmt.Errorf("adding rig: %w", err)
 	}
@@ -590,7 +677,7 @@ func runRigAdd(cmd *cobra.Command, args []string) error {
 
 	// Sync hooks for the new rig's targets
 	if err := syncRigHooks(townRoot, name); err != nil {
-		fmt.Fprintf(os.Stderr, "Warning: failed to sync hooks for new rig: %v\n", err)
+		logger.Warn("failed to sync hooks for new rig", "rig", name, "err", err)
 	}
 
 	// Refresh tmux cycle bindings on all running sessions so the new rig's
@@ -599,6 +686,12 @@ func runRigAdd(cmd *cobra.Command, args []string) error {
 	// See: https://github.com/steveyegge/gastown/issues/2299
 	refreshCycleBindingsOnExistingSessions()
 
+	// Apply the template's settings, patrols, crew, and polecat pool.
+	var crewNames []string
+	if tmpl != nil {
+		crewNames = applyRigTemplate(cmd, townRoot, name, tmpl)
+	}
+
 	elapsed := time.Since(startTime)
 
 	// Read default branch from rig config
@@ -616,11 +709,19 @@ func runRigAdd(cmd *cobra.Command, args []string) error {
 	fmt.Printf("  ├── plugins/          (rig-level plugins)\n")
 	fmt.Printf("  ├── mayor/rig/        (clone: %s)\n", defaultBranch)
 	fmt.Printf("  ├── refinery/rig/     (worktree: %s, sees polecat branches)\n", defaultBranch)
-	fmt.Printf("  ├── crew/             (empty - add crew with 'gt crew add')\n")
+	if len(crewNames) > 0 {
+		fmt.Printf("  ├── crew/             (%s)\n", strings.Join(crewNames, ", "))
+	} else {
+		fmt.Printf("  ├── crew/             (empty - add crew with 'gt crew add')\n")
+	}
 	fmt.Printf("  ├── witness/\n")
 	fmt.Printf("  └── polecats/         (.claude/ scaffolded for polecat sessions)\n")
 
 	fmt.Printf("\nNext steps:\n")
+	if len(crewNames) > 0 {
+		fmt.Printf("  cd %s   # Start working\n", filepath.Join(townRoot, name, "crew", crewNames[0]))
+		return nil
+	}
 	fmt.Printf("  gt crew add <name> --rig %s   # Create your personal workspace\n", name)
 	fmt.Printf("  cd %s/crew/<name>              # Start working\n", filepath.Join(townRoot, name))
 
@@ -2074,7 +2175,7 @@ func syncRigHooks(townRoot, rigName string) error {
 			continue
 		}
 		if _, err := syncTarget(target, false); err != nil {
-			fmt.Fprintf(os.Stderr, "  Warning: failed to sync hooks for %s: %v\n", target.DisplayKey(), err)
+			logger.Warn("failed to sync hooks", "target", target.DisplayKey(), "err", err)
 			continue
 		}
 		synced++
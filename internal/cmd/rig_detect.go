@@ -75,7 +75,7 @@ func runRigDetect(cmd *cobra.Command, args []string) error {
 
 	if rigDetectCache != "" {
 		if err := updateRigCache(rigDetectCache, townRoot, rigName); err != nil {
-			fmt.Fprintf(os.Stderr, "warning: could not update cache: %v\n", err)
+			logger.Warn("could not update rig cache", "rig", rigName, "err", err)
 		}
 	}
 
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var rigHostClear bool
+
+var rigHostCmd = &cobra.Command{
+	Use:   "host <rig> [ssh-destination]",
+	Short: "Show or set the machine a rig's agents run on",
+	Long: `Show or set the SSH host for a rig whose agents run on another machine.
+
+When a rig has a host, tmux commands for its sessions are run as
+'ssh <host> tmux ...' on that machine: status, peek, nudge, capture, and
+session start/stop work the same as for local rigs, and 'gt status' lists
+the remote sessions alongside local ones.
+
+Requirements on the remote host:
+  - Passwordless SSH (commands run with BatchMode=yes and a 5s connect
+    timeout, so an unreachable host fails fast)
+  - tmux and gt installed
+  - The town directory on a filesystem shared with this machine at the same
+    path (e.g. NFS): only tmux goes over SSH, and worktrees, mail, logs, and
+    runtime state are read and written through local paths on both sides
+  - Access to the town's beads database, so mail and hooks resolve
+
+The host is stored in mayor/rigs.json and takes effect on the next command.
+
+Examples:
+  gt rig host gastown                    # Show current host
+  gt rig host gastown builder@gpu-box    # Run gastown's agents on gpu-box
+  gt rig host gastown --clear            # Back to this machine`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runRigHost,
+}
+
+func init() {
+	rigHostCmd.Flags().BoolVar(&rigHostClear, "clear", false, "Remove the host (run the rig locally)")
+	rigCmd.AddCommand(rigHostCmd)
+}
+
+func runRigHost(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	rigName := args[0]
+	rigsPath := constants.MayorRigsPath(townRoot)
+	rigsConfig, err := config.LoadRigsConfig(rigsPath)
+	if err != nil {
+		return fmt.Errorf("loading rigs config: %w", err)
+	}
+	entry, ok := rigsConfig.Rigs[rigName]
+	if !ok {
+		return fmt.Errorf("rig %q not found", rigName)
+	}
+
+	if len(args) == 1 && !rigHostClear {
+		if entry.Host == "" {
+			fmt.Printf("%s runs locally\n", rigName)
+		} else {
+			fmt.Printf("%s runs on %s\n", rigName, entry.Host)
+		}
+		return nil
+	}
+
+	host := ""
+	if !rigHostClear {
+		if len(args) < 2 {
+			return fmt.Errorf("specify an SSH destination or --clear")
+		}
+		host = strings.TrimSpace(args[1])
+		if host == "" || strings.HasPrefix(host, "-") || strings.ContainsAny(host, " \t") {
+			return fmt.Errorf("invalid SSH destination %q", args[1])
+		}
+	}
+	if host != "" && (entry.BeadsConfig == nil || entry.BeadsConfig.Prefix == "") {
+		return fmt.Errorf("rig %q has no beads prefix; remote sessions are routed by prefix", rigName)
+	}
+
+	entry.Host = host
+	rigsConfig.Rigs[rigName] = entry
+	if err := config.SaveRigsConfig(rigsPath, rigsConfig); err != nil {
+		return fmt.Errorf("saving rigs config: %w", err)
+	}
+
+	if host == "" {
+		fmt.Printf("%s %s now runs locally\n", style.SuccessPrefix, rigName)
+	} else {
+		fmt.Printf("%s %s now runs on %s\n", style.SuccessPrefix, rigName, host)
+	}
+	return nil
+}
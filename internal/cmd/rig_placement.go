@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/placement"
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var (
+	rigPlacementHosts []string
+	rigPlacementProbe bool
+)
+
+var rigPlacementCmd = &cobra.Command{
+	Use:   "placement <rig> [local|least-loaded|gpu]",
+	Short: "Show or set where a rig's new polecats are provisioned",
+	Long: `Show or set the placement policy for a rig's polecats.
+
+By default every polecat runs on the rig's host (see 'gt rig host'). With a
+placement policy, each new polecat is started on whichever host in the
+rig's pool has the most spare capacity when it spawns:
+
+  local          Always the rig's host (default)
+  least-loaded   Lowest (load average + running polecats) per CPU
+  gpu            A host with an idle GPU, else any GPU host, else least-loaded
+
+The pool is the rig's host plus --hosts (SSH destinations; "local" means
+this machine). Hosts are probed over SSH when a polecat starts; unreachable
+hosts are skipped. The chosen host is remembered per session, so nudge,
+peek, and kill reach the polecat wherever it landed.
+
+Pool hosts have the same requirements as 'gt rig host': passwordless SSH,
+and tmux, gt, and the rig checkout at the same paths.
+
+Examples:
+  gt rig placement gastown                                  # Show policy
+  gt rig placement gastown least-loaded --hosts local,box2  # Spread load
+  gt rig placement gastown gpu --hosts gpu1,gpu2            # Prefer idle GPUs
+  gt rig placement gastown --probe                          # Show host scores
+  gt rig placement gastown local                            # Turn off`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runRigPlacement,
+}
+
+func init() {
+	rigPlacementCmd.Flags().StringSliceVar(&rigPlacementHosts, "hosts", nil, "Extra hosts in the placement pool (comma-separated)")
+	rigPlacementCmd.Flags().BoolVar(&rigPlacementProbe, "probe", false, "Probe the pool and show each host's score")
+	rigCmd.AddCommand(rigPlacementCmd)
+}
+
+func runRigPlacement(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	rigName := args[0]
+	rigsPath := constants.MayorRigsPath(townRoot)
+	rigsConfig, err := config.LoadRigsConfig(rigsPath)
+	if err != nil {
+		return fmt.Errorf("loading rigs config: %w", err)
+	}
+	entry, ok := rigsConfig.Rigs[rigName]
+	if !ok {
+		return fmt.Errorf("rig %q not found", rigName)
+	}
+
+	if len(args) == 2 || cmd.Flags().Changed("hosts") {
+		p := config.PlacementConfig{}
+		if entry.Placement != nil {
+			p = *entry.Placement
+		}
+		if len(args) == 2 {
+			p.Policy = args[1]
+		}
+		switch p.Policy {
+		case "", config.PlacementLocal, config.PlacementLeastLoaded, config.PlacementGPU:
+		default:
+			return fmt.Errorf("unknown policy %q (want local, least-loaded, or gpu)", p.Policy)
+		}
+		if cmd.Flags().Changed("hosts") {
+			p.Hosts = nil
+			for _, h := range rigPlacementHosts {
+				h = strings.TrimSpace(h)
+				if h == "" {
+					continue
+				}
+				if strings.HasPrefix(h, "-") || strings.ContainsAny(h, " \t") {
+					return fmt.Errorf("invalid SSH destination %q", h)
+				}
+				p.Hosts = append(p.Hosts, h)
+			}
+		}
+		if p.Policy != "" && p.Policy != config.PlacementLocal && (entry.BeadsConfig == nil || entry.BeadsConfig.Prefix == "") {
+			return fmt.Errorf("rig %q has no beads prefix; placed sessions are routed by name", rigName)
+		}
+
+		if (p.Policy == "" || p.Policy == config.PlacementLocal) && len(p.Hosts) == 0 {
+			entry.Placement = nil
+		} else {
+			entry.Placement = &p
+		}
+		rigsConfig.Rigs[rigName] = entry
+		if err := config.SaveRigsConfig(rigsPath, rigsConfig); err != nil {
+			return fmt.Errorf("saving rigs config: %w", err)
+		}
+		fmt.Printf("%s %s placement updated\n", style.SuccessPrefix, rigName)
+	}
+
+	policy := config.PlacementLocal
+	if entry.Placement != nil && entry.Placement.Policy != "" {
+		policy = entry.Placement.Policy
+	}
+	var names []string
+	for _, h := range placement.Candidates(entry) {
+		names = append(names, placement.Candidate{Host: h}.Name())
+	}
+	fmt.Printf("%s placement: %s\n", rigName, style.Bold.Render(policy))
+	fmt.Printf("  pool: %s\n", strings.Join(names, ", "))
+
+	if !rigPlacementProbe {
+		return nil
+	}
+	candidates := placement.ProbeAll(context.Background(), townRoot,
+		session.PrefixFor(rigName)+"-", placement.Candidates(entry))
+	fmt.Println()
+	for _, c := range candidates {
+		fmt.Printf("  %s\n", c)
+	}
+	if policy != config.PlacementLocal {
+		if chosen, err := placement.Choose(policy, candidates); err == nil {
+			fmt.Printf("\nNext polecat would go to %s\n", style.Bold.Render(chosen.Name()))
+		} else {
+			fmt.Printf("\n%s %v\n", style.WarningPrefix, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,244 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var rigTemplateCmd = &cobra.Command{
+	Use:   "template",
+	Short: "List and inspect rig templates",
+	Long: `Rig templates are YAML files in <town>/settings/rig-templates/ that
+describe a rig's defaults, applied with 'gt rig add --template <name>':
+
+  description: Go API service
+  prefix: api                 # beads prefix (when --prefix is not given)
+  branch: main                # default branch (when --branch is not given)
+  crew:
+    size: 2                   # crew workspaces to create
+    names: [max]              # names to use first (the rest are generated)
+    startup: max              # rig settings crew.startup
+  polecats:
+    count: 4                  # persistent polecat pool, created with the rig
+    style: minerals           # name pool theme
+  witness: true               # daemon keeps a witness running (default true)
+  refinery: false             # daemon keeps a refinery running (default true)
+  agents:
+    default: claude           # rig settings agent
+    roles:                    # rig settings role_agents
+      witness: claude-haiku
+    custom:                   # rig settings agents (launch commands)
+      claude-haiku:
+        command: claude
+        args: [--model, haiku, --dangerously-skip-permissions]`,
+	RunE: requireSubcommand,
+}
+
+var rigTemplateListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List rig templates",
+	Args:  cobra.NoArgs,
+	RunE:  runRigTemplateList,
+}
+
+var rigTemplateShowCmd = &cobra.Command{
+	Use:   "show <name>",
+	Short: "Show what a rig template sets up",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runRigTemplateShow,
+}
+
+func init() {
+	rigTemplateCmd.AddCommand(rigTemplateListCmd)
+	rigTemplateCmd.AddCommand(rigTemplateShowCmd)
+	rigCmd.AddCommand(rigTemplateCmd)
+}
+
+func runRigTemplateList(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	templates, broken, err := rig.ListTemplates(townRoot)
+	if err != nil {
+		return fmt.Errorf("listing rig templates: %w", err)
+	}
+	if len(templates) == 0 && len(broken) == 0 {
+		fmt.Printf("No rig templates in %s\n", rig.TemplatesDir(townRoot))
+		fmt.Printf("\nSee %s for the format.\n", style.Dim.Render("gt rig template --help"))
+		return nil
+	}
+	for _, t := range templates {
+		fmt.Printf("  %-20s %s\n", style.Bold.Render(t.Name), t.Description)
+	}
+	var files []string
+	for file := range broken {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	for _, file := range files {
+		style.PrintWarning("%s: %v", file, broken[file])
+	}
+	return nil
+}
+
+func runRigTemplateShow(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	t, err := rig.LoadTemplate(townRoot, args[0])
+	if err != nil {
+		return err
+	}
+	fmt.Print(renderRigTemplate(t))
+	return nil
+}
+
+// renderRigTemplate describes what gt rig add --template sets up.
+func renderRigTemplate(t *rig.Template) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n", style.Bold.Render(t.Name))
+	if t.Description != "" {
+		fmt.Fprintf(&b, "  %s\n", t.Description)
+	}
+	b.WriteString("\n")
+	orDefault := func(v, def string) string {
+		if v == "" {
+			return style.Dim.Render(def)
+		}
+		return v
+	}
+	fmt.Fprintf(&b, "  Prefix:   %s\n", orDefault(t.Prefix, "derived from rig name"))
+	fmt.Fprintf(&b, "  Branch:   %s\n", orDefault(t.Branch, "auto-detected"))
+	if names := t.CrewNames(); len(names) > 0 {
+		fmt.Fprintf(&b, "  Crew:     %s\n", strings.Join(names, ", "))
+	} else {
+		fmt.Fprintf(&b, "  Crew:     %s\n", style.Dim.Render("none"))
+	}
+	if t.Crew != nil && t.Crew.Startup != "" {
+		fmt.Fprintf(&b, "  Startup:  %s\n", t.Crew.Startup)
+	}
+	if p := t.Polecats; p != nil && p.Count > 0 {
+		pool := fmt.Sprintf("%d", p.Count)
+		if len(p.Names) > 0 {
+			pool += " (" + strings.Join(p.Names, ", ") + ")"
+		} else if p.Style != "" {
+			pool += " (" + p.Style + " names)"
+		}
+		fmt.Fprintf(&b, "  Polecats: %s\n", pool)
+	} else {
+		fmt.Fprintf(&b, "  Polecats: %s\n", style.Dim.Render("spawned on demand"))
+	}
+	fmt.Fprintf(&b, "  Witness:  %s\n", formatEnabled(t.WitnessEnabled()))
+	fmt.Fprintf(&b, "  Refinery: %s\n", formatEnabled(t.RefineryEnabled()))
+	if t.Clone != nil {
+		fmt.Fprintf(&b, "  Clone:    %s\n", t.Clone)
+	}
+	if a := t.Agents; a != nil {
+		fmt.Fprintf(&b, "  Agent:    %s\n", orDefault(a.Default, "town default"))
+		var roles []string
+		for role := range a.Roles {
+			roles = append(roles, role)
+		}
+		sort.Strings(roles)
+		for _, role := range roles {
+			fmt.Fprintf(&b, "    %-9s %s\n", role+":", a.Roles[role])
+		}
+		var custom []string
+		for name := range a.Custom {
+			custom = append(custom, name)
+		}
+		sort.Strings(custom)
+		for _, name := range custom {
+			rc := a.Custom[name]
+			fmt.Fprintf(&b, "    %s = %s\n", name, strings.TrimSpace(rc.Command+" "+strings.Join(rc.Args, " ")))
+		}
+	}
+	return b.String()
+}
+
+func formatEnabled(on bool) string {
+	if on {
+		return "enabled"
+	}
+	return "disabled"
+}
+
+// applyRigTemplate applies the parts of a template that need the rig to
+// exist: rig settings, daemon patrols, crew workspaces, and the polecat pool.
+// Failures are warnings: the rig itself is already usable. Returns the crew
+// workspaces created.
+func applyRigTemplate(cmd *cobra.Command, townRoot, rigName string, t *rig.Template) []string {
+	fmt.Printf("\nApplying template %s...\n", style.Bold.Render(t.Name))
+	rigPath := filepath.Join(townRoot, rigName)
+
+	// Rig settings: agents, name pool, crew startup.
+	settingsPath := config.RigSettingsPath(rigPath)
+	settings, err := config.LoadRigSettings(settingsPath)
+	if errors.Is(err, config.ErrNotFound) {
+		settings, err = config.NewRigSettings(), nil
+	}
+	if err != nil {
+		style.PrintWarning("could not load rig settings: %v", err)
+	} else {
+		t.ApplySettings(settings)
+		if err := config.SaveRigSettings(settingsPath, settings); err != nil {
+			style.PrintWarning("could not save rig settings: %v", err)
+		} else {
+			fmt.Printf("  %s Rig settings: %s\n", style.Success.Render("✓"), settingsPath)
+		}
+	}
+
+	// Daemon patrols: rig add enrolls both; drop the disabled ones.
+	for _, p := range []struct {
+		name    string
+		enabled bool
+	}{{"witness", t.WitnessEnabled()}, {"refinery", t.RefineryEnabled()}} {
+		if p.enabled {
+			continue
+		}
+		if err := config.RemoveRigFromDaemonPatrol(townRoot, rigName, p.name); err != nil {
+			style.PrintWarning("could not disable %s patrol: %v", p.name, err)
+		} else {
+			fmt.Printf("  %s %s disabled (daemon will not auto-start it)\n", style.Success.Render("✓"), p.name)
+		}
+	}
+
+	// Crew workspaces.
+	var created []string
+	if names := t.CrewNames(); len(names) > 0 {
+		fmt.Println()
+		prevRig := crewRig
+		crewRig = rigName
+		err := runCrewAdd(cmd, names)
+		crewRig = prevRig
+		if err != nil {
+			style.PrintWarning("creating crew: %v", err)
+		}
+		for _, n := range names {
+			if _, err := os.Stat(filepath.Join(rigPath, "crew", n)); err == nil {
+				created = append(created, n)
+			}
+		}
+	}
+
+	// Persistent polecat pool (size and names were written to config.json).
+	if p := t.Polecats; p != nil && p.Count > 0 {
+		fmt.Println()
+		if err := runPolecatPoolInit(cmd, []string{rigName}); err != nil {
+			style.PrintWarning("creating polecat pool: %v (retry with: gt polecat pool-init %s)", err, rigName)
+		}
+	}
+	return created
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/cli"
 	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/logging"
 	"github.com/steveyegge/gastown/internal/polecat"
 	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/style"
@@ -21,6 +22,9 @@ import (
 	"github.com/steveyegge/gastown/internal/workspace"
 )
 
+// logger is the cmd subsystem logger, for diagnostics that aren't command output.
+var logger = logging.For("cmd")
+
 var rootCmd = &cobra.Command{
 	Use:     "gt", // Updated in init() based on GT_COMMAND
 	Short:   "Gas Town - Multi-agent workspace manager",
@@ -101,6 +105,15 @@ func persistentPreRun(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Resolve --town / GT_TOWN / the current registered town before anything
+	// below discovers the town from the working directory.
+	if err := applyTownSelection(); err != nil {
+		return err
+	}
+
+	// Apply --verbose/--quiet and the town's logging config
+	setupLogging(cmd)
+
 	// Initialize CLI theme (dark/light mode support)
 	initCLITheme()
 
@@ -114,7 +127,7 @@ func persistentPreRun(cmd *cobra.Command, args []string) error {
 	// the correct town socket rather than silently using the wrong server.
 	if townRoot := detectTownRootFromCwd(); townRoot != "" {
 		if err := session.InitRegistry(townRoot); err != nil {
-			fmt.Fprintf(os.Stderr, "WARNING: failed to initialize town registry: %v\n", err)
+			logger.Warn("failed to initialize town registry", "town", townRoot, "err", err)
 		}
 	}
 
@@ -179,6 +192,28 @@ func initCLITheme() {
 	ui.ApplyThemeMode()
 }
 
+// setupLogging sets log levels from --verbose/--quiet and the town's logging
+// config. Commands with their own --verbose or --quiet flag shadow the global
+// one, so the flag is looked up on the command actually run.
+func setupLogging(cmd *cobra.Command) {
+	var cfg *logging.Config
+	if townRoot, err := workspace.FindFromCwd(); err == nil && townRoot != "" {
+		if settings, err := config.LoadOrCreateTownSettings(config.TownSettingsPath(townRoot)); err == nil {
+			cfg = settings.Logging
+		}
+	}
+	logging.Setup(logging.OptionsFromConfig(cfg, logging.DefaultLevel, boolFlag(cmd, "verbose"), boolFlag(cmd, "quiet")))
+	if err := cfg.Validate(); err != nil {
+		logger.Warn("ignoring invalid town logging config", "err", err)
+	}
+}
+
+// boolFlag reports whether a boolean flag is set on cmd.
+func boolFlag(cmd *cobra.Command, name string) bool {
+	f := cmd.Flags().Lookup(name)
+	return f != nil && f.Value.Type() == "bool" && f.Value.String() == "true"
+}
+
 // touchPolecatHeartbeat touches the session heartbeat file for polecat agents.
 // Called from persistentPreRun on every gt command. The heartbeat signals that
 // the agent process is alive and actively running gt commands. Used by
@@ -287,7 +322,7 @@ func Execute() int {
 	ctx := context.Background()
 	provider, err := telemetry.Init(ctx, "gastown", Version)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "warning: telemetry init: %v\n", err)
+		logger.Warn("telemetry init failed", "err", err)
 	}
 	if provider != nil {
 		defer func() {
@@ -300,12 +335,19 @@ func Execute() int {
 		telemetry.SetProcessOTELAttrs()
 	}
 
-	if err := rootCmd.Execute(); err != nil {
+	started := time.Now()
+	cmd, err := rootCmd.ExecuteC()
+	recordAudit(cmd, started, err)
+	if err != nil {
 		// Check for silent exit (scripting commands that signal status via exit code)
 		if code, ok := IsSilentExit(err); ok {
 			return code
 		}
-		// Other errors already printed by cobra
+		// Other errors already printed by cobra; follow with a remediation
+		// hint when the error's category suggests one.
+		if cmd != nil && !cmd.SilenceErrors && !rootCmd.SilenceErrors {
+			printErrorHint(os.Stderr, err)
+		}
 		return 1
 	}
 	return 0
@@ -341,8 +383,9 @@ func init() {
 	rootCmd.SetHelpCommandGroupID(GroupDiag)
 	rootCmd.SetCompletionCommandGroupID(GroupConfig)
 
-	// Global flags can be added here
-	// rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file")
+	// Global flags
+	rootCmd.PersistentFlags().Bool("verbose", false, "Log debug diagnostics to stderr")
+	rootCmd.PersistentFlags().Bool("quiet", false, "Log errors only")
 }
 
 // buildCommandPath walks the command hierarchy to build the full command path.
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var (
+	sessionMigrateDryRun      bool
+	sessionMigrateIncludeCrew bool
+)
+
+var sessionMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Rename sessions that use legacy names",
+	Long: `Rename tmux sessions still using the legacy {prefix}-{rig}-{role} names
+(e.g., "gt-whatsapp_automation-witness") to the current short-prefix
+names (e.g., "wa-witness"), so status, nudge, and mail find them.
+
+Crew sessions are skipped unless --include-crew is given, since a human
+may be attached to them. A rename is skipped when the new name is
+already taken. GT_SESSION in the session environment is updated so
+respawned panes see the new name.
+
+Examples:
+  gt session migrate --dry-run
+  gt session migrate
+  gt session migrate --include-crew`,
+	Args: cobra.NoArgs,
+	RunE: runSessionMigrate,
+}
+
+func init() {
+	sessionMigrateCmd.Flags().BoolVarP(&sessionMigrateDryRun, "dry-run", "n", false, "Show renames without applying them")
+	sessionMigrateCmd.Flags().BoolVar(&sessionMigrateIncludeCrew, "include-crew", false, "Also rename crew sessions")
+	sessionCmd.AddCommand(sessionMigrateCmd)
+}
+
+func runSessionMigrate(cmd *cobra.Command, args []string) error {
+	if _, err := workspace.FindFromCwdOrError(); err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	t := tmux.NewTmux()
+	sessions, err := t.ListSessions()
+	if err != nil {
+		return fmt.Errorf("listing sessions: %w", err)
+	}
+
+	renames := session.DetectLegacyNames(sessions, nil)
+	if len(renames) == 0 {
+		fmt.Printf("%s All sessions use current names\n", style.SuccessPrefix)
+		return nil
+	}
+
+	existing := make(map[string]bool, len(sessions))
+	for _, s := range sessions {
+		existing[s] = true
+	}
+
+	var failed int
+	for _, r := range renames {
+		switch {
+		case r.Crew && !sessionMigrateIncludeCrew:
+			fmt.Printf("  %s %s → %s %s\n", style.Dim.Render("○"), r.Old, r.New, style.Dim.Render("(crew; use --include-crew)"))
+			continue
+		case existing[r.New]:
+			fmt.Printf("  %s %s → %s %s\n", style.WarningPrefix, r.Old, r.New, style.Dim.Render("(target exists, skipped)"))
+			continue
+		case sessionMigrateDryRun:
+			fmt.Printf("  %s → %s\n", r.Old, r.New)
+			continue
+		}
+
+		if err := t.RenameSession(r.Old, r.New); err != nil {
+			fmt.Printf("  %s %s → %s: %v\n", style.ErrorPrefix, r.Old, r.New, err)
+			failed++
+			continue
+		}
+		existing[r.New] = true
+		if v, err := t.GetEnvironment(r.New, "GT_SESSION"); err == nil && v == r.Old {
+			_ = t.SetEnvironment(r.New, "GT_SESSION", r.New)
+		}
+		fmt.Printf("  %s %s → %s\n", style.SuccessPrefix, r.Old, r.New)
+	}
+
+	if sessionMigrateDryRun {
+		fmt.Printf("\n%s Dry run: no sessions renamed\n", style.Dim.Render("ℹ"))
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d rename(s) failed", failed)
+	}
+	return nil
+}
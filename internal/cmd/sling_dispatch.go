@@ -9,6 +9,7 @@ import (
 	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/events"
 	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/quiesce"
 	"github.com/steveyegge/gastown/internal/style"
 )
 
@@ -111,7 +112,12 @@ func executeSling(params SlingParams) (*SlingResult, error) {
 		BeadID: params.BeadID,
 	}
 
-	// 0. Check if rig is parked or docked before dispatching (gt-4owfd.1, gt-11y)
+	// 0. Check the town isn't quiesced, and the rig isn't parked or docked
+	// (gt-4owfd.1, gt-11y)
+	if err := quiesce.CheckDispatch(townRoot); err != nil {
+		result.ErrMsg = "town quiesced"
+		return result, err
+	}
 	if params.RigName != "" {
 		if blocked, reason := IsRigParkedOrDocked(townRoot, params.RigName); blocked {
 			result.ErrMsg = "rig " + reason
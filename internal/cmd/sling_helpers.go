@@ -460,7 +460,7 @@ func ensureAgentReady(sessionName string) error {
 	}
 	if err := t.WaitForRuntimeReady(sessionName, rc, constants.ClaudeStartTimeout); err != nil {
 		// Graceful degradation: warn but proceed (matches original behavior of always continuing)
-		fmt.Fprintf(os.Stderr, "Warning: agent readiness detection timed out for %s: %v\n", sessionName, err)
+		logger.Warn("agent readiness detection timed out", "session", sessionName, "err", err)
 	}
 
 	return nil
@@ -576,7 +576,7 @@ func wakeRigAgents(rigName string) {
 	witnessSession := session.WitnessSessionName(session.PrefixFor(rigName))
 	t := tmux.NewTmux()
 	if err := t.NudgeSession(witnessSession, "Polecat dispatched - check for work"); err != nil {
-		fmt.Fprintf(os.Stderr, "Warning: failed to nudge witness %s: %v\n", witnessSession, err)
+		logger.Warn("failed to nudge witness", "session", witnessSession, "err", err)
 	}
 }
 
@@ -608,7 +608,7 @@ func nudgeWitness(rigName, message string) {
 
 	t := tmux.NewTmux()
 	if err := t.NudgeSession(witnessSession, message); err != nil {
-		fmt.Fprintf(os.Stderr, "Warning: failed to nudge witness %s: %v\n", witnessSession, err)
+		logger.Warn("failed to nudge witness", "session", witnessSession, "err", err)
 	}
 }
 
@@ -643,7 +643,7 @@ func nudgeRefinery(rigName, message string) {
 
 	t := tmux.NewTmux()
 	if err := t.NudgeSession(refinerySession, message); err != nil {
-		fmt.Fprintf(os.Stderr, "Warning: failed to nudge refinery %s: %v\n", refinerySession, err)
+		logger.Warn("failed to nudge refinery", "session", refinerySession, "err", err)
 	}
 }
 
@@ -756,7 +756,7 @@ func InstantiateFormulaOnBead(ctx context.Context, formulaName, beadID, title, h
 	// but proceed — they are valid in the DB and bond correctly. The bd-side fix is ef57293e
 	// (not yet released).
 	if isMalformedWispID(wispRootID) {
-		fmt.Fprintf(os.Stderr, "Warning: bd mol wisp returned malformed ID %q (known bd bug, proceeding with bond)\n", wispRootID)
+		logger.Warn("bd mol wisp returned malformed ID (known bd bug, proceeding with bond)", "id", wispRootID)
 	}
 
 	bondArgs := []string{"mol", "bond", wispRootID, beadID, "--json"}
@@ -1139,7 +1139,7 @@ func cleanupOrphanedWisp(wispID, formulaWorkDir string) {
 		// Non-fatal: the wisp may not exist (phantom ID from bd bug),
 		// or it may be in a different database. Orphaned wisps will be
 		// caught by the doctor's DetectOrphanedMolecules.
-		fmt.Fprintf(os.Stderr, "Warning: could not clean up orphaned wisp %s: %v\n", wispID, err)
+		logger.Warn("could not clean up orphaned wisp", "wisp", wispID, "err", err)
 	}
 }
 
@@ -1165,6 +1165,6 @@ func updateAgentMode(agentID, mode, workDir, townBeadsDir string) {
 	agentWorkDir := beads.ResolveHookDir(townRoot, agentBeadID, workDir)
 	bd := beads.New(agentWorkDir)
 	if err := bd.UpdateAgentDescriptionFields(agentBeadID, beads.AgentFieldUpdates{Mode: &mode}); err != nil {
-		fmt.Fprintf(os.Stderr, "Warning: couldn't set agent %s mode: %v\n", agentBeadID, err)
+		logger.Warn("couldn't set agent mode", "agent", agentBeadID, "err", err)
 	}
 }
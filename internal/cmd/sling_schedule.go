@@ -2,7 +2,6 @@ package cmd
 
 import (
 	"fmt"
-	"os"
 	"path/filepath"
 	"strings"
 	"time"
@@ -295,8 +294,7 @@ func areScheduled(beadIDs []string) map[string]bool {
 	townBeads := beads.NewWithBeadsDir(townRoot, filepath.Join(townRoot, ".beads"))
 	contexts, err := townBeads.ListOpenSlingContexts()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "%s Warning: could not list sling contexts: %v (treating all as scheduled)\n",
-			style.Dim.Render("⚠"), err)
+		logger.Warn("could not list sling contexts, treating all as scheduled", "err", err)
 		// Fail closed: treat all as scheduled to avoid duplicate scheduling
 		for _, id := range beadIDs {
 			result[id] = true
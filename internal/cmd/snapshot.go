@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/snapshot"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var snapshotShowAt string
+
+var snapshotCmd = &cobra.Command{
+	Use:     "snapshot",
+	GroupID: GroupDiag,
+	Short:   "Browse archived agent scrollback",
+	Long: `Browse the scrollback archive kept by the daemon's scrollback_snapshot
+patrol.
+
+Every 10 minutes the daemon saves each running agent's full scrollback
+under .runtime/snapshots/, compressed and stored as a diff against the
+previous snapshot; unchanged panes add nothing. Secrets are redacted
+before saving. Snapshots are kept for 72h, up to 500 per agent.
+
+Configure in mayor/daemon.json:
+  "scrollback_snapshot": {"enabled": true, "interval": "10m",
+                          "retain": "72h", "max_per_agent": 500}
+
+Examples:
+  gt snapshot list
+  gt snapshot list gastown/polecats/nux
+  gt snapshot show gastown/polecats/nux --at 2h
+  gt snapshot show mayor --at "2026-03-01 14:30"`,
+	RunE: requireSubcommand,
+}
+
+var snapshotListCmd = &cobra.Command{
+	Use:   "list [<agent>]",
+	Short: "List archived sessions, or one agent's snapshots",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runSnapshotList,
+}
+
+var snapshotShowCmd = &cobra.Command{
+	Use:   "show <agent>",
+	Short: "Print an agent's archived scrollback",
+	Long: `Print an agent's scrollback as of a time: the newest snapshot taken at or
+before --at (default: the newest snapshot).
+
+--at accepts a duration ago ("90m", "2h"), a clock time today ("14:30"),
+"2006-01-02 15:04" (local), or RFC3339. The agent can be a role shortcut,
+an address, or a session name.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSnapshotShow,
+}
+
+func init() {
+	snapshotShowCmd.Flags().StringVar(&snapshotShowAt, "at", "", "Show the scrollback as of this time (default: newest)")
+
+	snapshotCmd.AddCommand(snapshotListCmd)
+	snapshotCmd.AddCommand(snapshotShowCmd)
+	rootCmd.AddCommand(snapshotCmd)
+}
+
+func runSnapshotList(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	if len(args) == 1 {
+		sessionName, err := resolveRoleToSession(args[0])
+		if err != nil {
+			return err
+		}
+		entries, err := snapshot.List(townRoot, sessionName)
+		if err != nil {
+			return err
+		}
+		if len(entries) == 0 {
+			fmt.Printf("No snapshots for %s.\n", sessionName)
+			return nil
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "TIME\tAGE\tSIZE")
+		for _, e := range entries {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", e.At.Local().Format("2006-01-02 15:04:05"), formatAge(e.At), formatBytes(e.Size))
+		}
+		return w.Flush()
+	}
+
+	sessions, err := snapshot.Sessions(townRoot)
+	if err != nil {
+		return err
+	}
+	if len(sessions) == 0 {
+		fmt.Println("No scrollback snapshots yet (the daemon's scrollback_snapshot patrol takes them).")
+		return nil
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "SESSION\tSNAPSHOTS\tOLDEST\tNEWEST\tSIZE")
+	for _, s := range sessions {
+		entries, err := snapshot.List(townRoot, s)
+		if err != nil || len(entries) == 0 {
+			continue
+		}
+		var size int64
+		for _, e := range entries {
+			size += e.Size
+		}
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\n", s, len(entries),
+			formatAge(entries[0].At), formatAge(entries[len(entries)-1].At), formatBytes(size))
+	}
+	return w.Flush()
+}
+
+func runSnapshotShow(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	sessionName, err := resolveRoleToSession(args[0])
+	if err != nil {
+		return err
+	}
+
+	var at time.Time
+	if snapshotShowAt != "" {
+		if at, err = parseSnapshotAt(snapshotShowAt, time.Now()); err != nil {
+			return err
+		}
+	}
+	view, err := snapshot.At(townRoot, sessionName, at)
+	if err != nil {
+		return err
+	}
+
+	// The header goes to stderr so the scrollback can be piped cleanly.
+	if view.Early {
+		style.PrintWarning("no snapshot of %s before %s; showing the oldest", sessionName, at.Local().Format("2006-01-02 15:04"))
+	}
+	fmt.Fprintln(os.Stderr, style.Dim.Render(fmt.Sprintf("── %s at %s (%s) ──",
+		sessionName, view.At.Local().Format("2006-01-02 15:04:05"), formatAge(view.At))))
+	fmt.Println(view.Content)
+	return nil
+}
+
+// parseSnapshotAt parses a --at value relative to now: a duration ago
+// ("2h"), "HH:MM" (today, or yesterday if that is still ahead), or an
+// absolute "2006-01-02 15:04" (local) or RFC3339 time.
+func parseSnapshotAt(s string, now time.Time) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if d, err := time.ParseDuration(s); err == nil {
+		if d < 0 {
+			return time.Time{}, fmt.Errorf("--at %s: duration must not be negative", s)
+		}
+		return now.Add(-d), nil
+	}
+	if clock, err := time.ParseInLocation("15:04", s, now.Location()); err == nil {
+		t := time.Date(now.Year(), now.Month(), now.Day(), clock.Hour(), clock.Minute(), 0, 0, now.Location())
+		if t.After(now) {
+			t = t.AddDate(0, 0, -1)
+		}
+		return t, nil
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02 15:04", "2006-01-02T15:04"} {
+		if t, err := time.ParseInLocation(layout, s, now.Location()); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("--at %q: want a duration ago, HH:MM, \"2006-01-02 15:04\", or RFC3339", s)
+}
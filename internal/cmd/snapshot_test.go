@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseSnapshotAt(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{"2h", now.Add(-2 * time.Hour)},
+		{"90m", now.Add(-90 * time.Minute)},
+		{"09:30", time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)},
+		{"14:00", time.Date(2026, 2, 28, 14, 0, 0, 0, time.UTC)}, // Still ahead today
+		{"2026-02-27 08:15", time.Date(2026, 2, 27, 8, 15, 0, 0, time.UTC)},
+		{"2026-02-27T08:15:00Z", time.Date(2026, 2, 27, 8, 15, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		got, err := parseSnapshotAt(tt.in, now)
+		if err != nil {
+			t.Errorf("parseSnapshotAt(%q): %v", tt.in, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("parseSnapshotAt(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+
+	for _, bad := range []string{"", "yesterday", "-1h", "25:99"} {
+		if _, err := parseSnapshotAt(bad, now); err == nil {
+			t.Errorf("parseSnapshotAt(%q) should fail", bad)
+		}
+	}
+}
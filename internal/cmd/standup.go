@@ -0,0 +1,389 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+	"text/template"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var (
+	standupRig      string
+	standupAll      bool
+	standupTimeout  time.Duration
+	standupTemplate string
+	standupTo       string
+	standupDryRun   bool
+)
+
+// standupPollInterval is how often replies are checked while waiting.
+const standupPollInterval = 15 * time.Second
+
+// defaultStandupPrompt is the prompt each agent gets, as a text/template
+// with .ID, .Agent, .Collector, .Timeout, .ReplyCommand, and .CommentCommand.
+const defaultStandupPrompt = `Standup {{.ID}}: please post a short status within {{.Timeout}}.
+
+1. Done: what you finished since your last standup
+2. Doing: what you're working on now (bead IDs help)
+3. Blocked: anything you need from someone else, or "nothing"
+
+Reply with:
+  {{.ReplyCommand}}
+or comment on this message:
+  {{.CommentCommand}}`
+
+var standupCmd = &cobra.Command{
+	Use:     "standup",
+	GroupID: GroupComm,
+	Short:   "Collect a status report from every running agent",
+	Long: `Run a standup: send every running agent a standup prompt, collect their
+answers until all have replied or the timeout passes, and mail a
+consolidated report with a section per agent to the mayor.
+
+Each agent gets the prompt as mail (which nudges its session) and answers
+by replying to it ('gt mail reply') or by commenting on the prompt's bead
+('bd comment'). Agents that don't answer in time are listed as missing.
+Press Ctrl-C while waiting to send the report with the answers so far.
+
+By default only workers (polecats and crew) are asked; use --all to
+include the mayor, deacon, witnesses, and refineries.
+
+The prompt is a Go text/template; --template replaces it with a file.
+Fields: {{.ID}}, {{.Agent}}, {{.Collector}}, {{.Timeout}},
+{{.ReplyCommand}}, {{.CommentCommand}}.
+
+Examples:
+  gt standup
+  gt standup --rig gastown --timeout 5m
+  gt standup --all --to overseer
+  gt standup --template ~/gt/standup.tmpl --dry-run`,
+	Args: cobra.NoArgs,
+	RunE: runStandup,
+}
+
+func init() {
+	standupCmd.Flags().StringVar(&standupRig, "rig", "", "Only ask agents in this rig")
+	standupCmd.Flags().BoolVar(&standupAll, "all", false, "Include all agents (mayor, witness, etc.), not just workers")
+	standupCmd.Flags().DurationVar(&standupTimeout, "timeout", 10*time.Minute, "How long to wait for answers")
+	standupCmd.Flags().StringVar(&standupTemplate, "template", "", "File with the prompt template (default: built-in)")
+	standupCmd.Flags().StringVar(&standupTo, "to", "mayor/", "Where to send the consolidated report")
+	standupCmd.Flags().BoolVar(&standupDryRun, "dry-run", false, "Show the agents and prompt without sending")
+	rootCmd.AddCommand(standupCmd)
+}
+
+// standupAnswer is one agent's standup response.
+type standupAnswer struct {
+	Text string
+	At   time.Time
+	Via  string // "mail" or "comment"
+}
+
+// standupPromptData is the data the prompt template is executed with.
+type standupPromptData struct {
+	ID             string
+	Agent          string
+	Collector      string
+	Timeout        time.Duration
+	ReplyCommand   string
+	CommentCommand string
+}
+
+func runStandup(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	promptText := defaultStandupPrompt
+	if standupTemplate != "" {
+		data, err := os.ReadFile(standupTemplate)
+		if err != nil {
+			return fmt.Errorf("reading template: %w", err)
+		}
+		promptText = string(data)
+	}
+	prompt, err := template.New("standup").Parse(promptText)
+	if err != nil {
+		return fmt.Errorf("parsing template: %w", err)
+	}
+
+	collector, err := resolveMailSender()
+	if err != nil {
+		return err
+	}
+	agents, err := standupTargets(collector)
+	if err != nil {
+		return err
+	}
+	if len(agents) == 0 {
+		fmt.Println("No agents running to ask for a standup.")
+		if standupRig != "" {
+			fmt.Printf("  (filtered by rig: %s)\n", standupRig)
+		}
+		return nil
+	}
+
+	startedAt := time.Now()
+	id := "standup-" + startedAt.Format("20060102-1504")
+
+	if standupDryRun {
+		fmt.Printf("Would ask %d agent(s) for standup %s:\n\n", len(agents), id)
+		for _, agent := range agents {
+			fmt.Printf("  %s\n", agent)
+		}
+		body, err := renderStandupPrompt(prompt, id, agents[0], collector)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("\nPrompt (for %s):\n\n%s\n", agents[0], body)
+		return nil
+	}
+
+	// Send the prompts. Each prompt's bead ID maps answers back to the agent.
+	router := mail.NewRouterWithTownRoot(townRoot, townRoot)
+	defer router.WaitPendingNotifications()
+	prompts := make(map[string]string) // prompt message ID -> agent
+	var asked []string
+	fmt.Printf("Asking %d agent(s) for standup %s...\n\n", len(agents), id)
+	for _, agent := range agents {
+		body, err := renderStandupPrompt(prompt, id, agent, collector)
+		if err != nil {
+			return err
+		}
+		msg := mail.NewMessage(collector, agent, "Standup "+id, body)
+		msg.Priority = mail.PriorityHigh
+		msg.Type = mail.TypeTask
+		msg.Wisp = true
+		if err := router.Send(msg); err != nil {
+			fmt.Printf("  %s %s %s\n", style.ErrorPrefix, agent, style.Dim.Render(err.Error()))
+			continue
+		}
+		prompts[msg.ID] = agent
+		asked = append(asked, agent)
+		fmt.Printf("  %s %s\n", style.SuccessPrefix, agent)
+	}
+	if len(asked) == 0 {
+		return fmt.Errorf("could not send the standup prompt to any agent")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	answers := collectStandupAnswers(ctx, townRoot, router, collector, id, prompts, standupTimeout)
+
+	report := buildStandupReport(id, startedAt, asked, answers)
+	fmt.Printf("\n%s\n", report)
+
+	reportMsg := mail.NewMessage(collector, standupTo, fmt.Sprintf("Standup report %s (%d/%d answered)", id, len(answers), len(asked)), report)
+	reportMsg.Type = mail.TypeNotification
+	if err := router.Send(reportMsg); err != nil {
+		return fmt.Errorf("sending standup report to %s: %w", standupTo, err)
+	}
+	fmt.Printf("%s Standup report sent to %s\n", style.SuccessPrefix, standupTo)
+	return nil
+}
+
+// standupTargets returns the mail addresses of the running agents to ask,
+// excluding the collector itself.
+func standupTargets(collector string) ([]string, error) {
+	sessions, err := getAgentSessions(true)
+	if err != nil {
+		return nil, fmt.Errorf("listing sessions: %w", err)
+	}
+	self := mail.AddressToIdentity(collector)
+	seen := make(map[string]bool)
+	var targets []string
+	for _, agent := range sessions {
+		if standupRig != "" && agent.Rig != standupRig {
+			continue
+		}
+		if !standupAll && agent.Type != AgentCrew && agent.Type != AgentPolecat {
+			continue
+		}
+		identity, err := session.ParseSessionName(agent.Name)
+		if err != nil {
+			continue
+		}
+		address := identity.Address()
+		if mail.AddressToIdentity(address) == self || seen[address] {
+			continue
+		}
+		seen[address] = true
+		targets = append(targets, address)
+	}
+	return targets, nil
+}
+
+// renderStandupPrompt executes the prompt template for one agent. Replies
+// are matched by the standup ID in their subject, so the reply command
+// works without the prompt's message ID, which only exists once it is sent.
+func renderStandupPrompt(prompt *template.Template, id, agent, collector string) (string, error) {
+	var buf bytes.Buffer
+	err := prompt.Execute(&buf, standupPromptData{
+		ID:             id,
+		Agent:          agent,
+		Collector:      collector,
+		Timeout:        standupTimeout,
+		ReplyCommand:   fmt.Sprintf("gt mail send %s -s \"Standup %s\" -m \"...\"", collector, id),
+		CommentCommand: `bd comment <this message's ID> "..."`,
+	})
+	if err != nil {
+		return "", fmt.Errorf("rendering standup prompt: %w", err)
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
+
+// collectStandupAnswers polls the collector's inbox and the prompt beads'
+// comments until every agent has answered, the timeout passes, or ctx is
+// cancelled, and returns the first answer from each agent.
+func collectStandupAnswers(ctx context.Context, townRoot string, router *mail.Router, collector, id string, prompts map[string]string, timeout time.Duration) map[string]standupAnswer {
+	answers := make(map[string]standupAnswer)
+	mailbox, err := router.GetMailbox(collector)
+	if err != nil {
+		style.PrintWarning("could not open %s's mailbox; only comments will be collected: %v", collector, err)
+	}
+	bd := beads.New(filepath.Join(townRoot, ".beads"))
+
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+	ticker := time.NewTicker(standupPollInterval)
+	defer ticker.Stop()
+
+	fmt.Printf("\nWaiting up to %s for answers (Ctrl-C to report now)...\n", timeout)
+	for {
+		if mailbox != nil {
+			if msgs, err := mailbox.List(); err == nil {
+				for agent, answer := range matchStandupReplies(msgs, id, prompts) {
+					if _, done := answers[agent]; !done {
+						answers[agent] = answer.standupAnswer
+						_ = mailbox.MarkRead(answer.msgID)
+						fmt.Printf("  %s %s answered\n", style.SuccessPrefix, agent)
+					}
+				}
+			}
+		}
+		for promptID, agent := range prompts {
+			if _, done := answers[agent]; done {
+				continue
+			}
+			if answer, ok := standupComment(bd, promptID, collector); ok {
+				answers[agent] = answer
+				fmt.Printf("  %s %s answered\n", style.SuccessPrefix, agent)
+			}
+		}
+		if len(answers) == len(prompts) {
+			return answers
+		}
+
+		select {
+		case <-ctx.Done():
+			fmt.Println("\nInterrupted; reporting the answers so far.")
+			return answers
+		case <-deadline.C:
+			return answers
+		case <-ticker.C:
+		}
+	}
+}
+
+// standupMatch is an answer found in the collector's inbox.
+type standupMatch struct {
+	standupAnswer
+	msgID string
+}
+
+// matchStandupReplies finds standup answers among the collector's mail: a
+// reply to one of the prompts, or mail whose subject names the standup
+// from an agent that was asked. The earliest answer per agent wins.
+func matchStandupReplies(msgs []*mail.Message, id string, prompts map[string]string) map[string]standupMatch {
+	asked := make(map[string]string) // identity -> agent address
+	for _, agent := range prompts {
+		asked[mail.AddressToIdentity(agent)] = agent
+	}
+
+	found := make(map[string]standupMatch)
+	for _, msg := range msgs {
+		agent, ok := prompts[msg.ReplyTo]
+		if !ok && strings.Contains(msg.Subject, id) {
+			agent, ok = asked[mail.AddressToIdentity(msg.From)]
+		}
+		if !ok {
+			continue
+		}
+		if prev, seen := found[agent]; seen && !msg.Timestamp.Before(prev.At) {
+			continue
+		}
+		found[agent] = standupMatch{
+			standupAnswer: standupAnswer{Text: strings.TrimSpace(msg.Body), At: msg.Timestamp, Via: "mail"},
+			msgID:         msg.ID,
+		}
+	}
+	return found
+}
+
+// standupComment returns the first comment on a prompt bead not written by
+// the collector. Best-effort: bd failures mean no answer yet.
+func standupComment(bd *beads.Beads, promptID, collector string) (standupAnswer, bool) {
+	out, err := bd.Run("comments", promptID, "--json")
+	if err != nil {
+		return standupAnswer{}, false
+	}
+	var comments []struct {
+		Author    string    `json:"author"`
+		Text      string    `json:"text"`
+		CreatedAt time.Time `json:"created_at"`
+	}
+	if err := json.Unmarshal(out, &comments); err != nil {
+		return standupAnswer{}, false
+	}
+	for _, c := range comments {
+		if mail.AddressToIdentity(c.Author) == mail.AddressToIdentity(collector) || strings.TrimSpace(c.Text) == "" {
+			continue
+		}
+		return standupAnswer{Text: strings.TrimSpace(c.Text), At: c.CreatedAt, Via: "comment"}, true
+	}
+	return standupAnswer{}, false
+}
+
+// buildStandupReport assembles the consolidated report: a summary line,
+// then one section per agent in the order asked, answered or not.
+func buildStandupReport(id string, startedAt time.Time, asked []string, answers map[string]standupAnswer) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Standup %s (%s)\n", id, startedAt.Format("Mon Jan 2 15:04"))
+	fmt.Fprintf(&sb, "%d of %d agents answered.\n", len(answers), len(asked))
+
+	var missing []string
+	for _, agent := range asked {
+		answer, ok := answers[agent]
+		if !ok {
+			missing = append(missing, agent)
+			continue
+		}
+		fmt.Fprintf(&sb, "\n## %s\n", agent)
+		fmt.Fprintf(&sb, "(%s, %s)\n\n", answer.Via, answer.At.Local().Format("15:04"))
+		sb.WriteString(answer.Text)
+		sb.WriteString("\n")
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		fmt.Fprintf(&sb, "\n## No answer\n\n")
+		for _, agent := range missing {
+			fmt.Fprintf(&sb, "- %s\n", agent)
+		}
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"text/template"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/mail"
+)
+
+func TestMatchStandupReplies(t *testing.T) {
+	id := "standup-20260304-0900"
+	prompts := map[string]string{
+		"hq-p1": "gastown/nux",
+		"hq-p2": "gastown/crew/max",
+		"hq-p3": "beads/toast",
+	}
+	t0 := time.Date(2026, 3, 4, 9, 5, 0, 0, time.UTC)
+	msgs := []*mail.Message{
+		{ID: "hq-r1", From: "gastown/nux", Subject: "Re: Standup " + id, Body: "late answer", ReplyTo: "hq-p1", Timestamp: t0.Add(time.Minute)},
+		{ID: "hq-r2", From: "gastown/nux", Subject: "Re: Standup " + id, Body: "first answer\n", ReplyTo: "hq-p1", Timestamp: t0},
+		// Matched by subject from the canonical address form.
+		{ID: "hq-r3", From: "gastown/max", Subject: "Standup " + id, Body: "working on gt-12", Timestamp: t0},
+		// Wrong standup, and an agent that wasn't asked.
+		{ID: "hq-r4", From: "beads/toast", Subject: "Standup standup-20260303-0900", Body: "old", Timestamp: t0},
+		{ID: "hq-r5", From: "gastown/slit", Subject: "Standup " + id, Body: "uninvited", Timestamp: t0},
+	}
+
+	got := matchStandupReplies(msgs, id, prompts)
+	if len(got) != 2 {
+		t.Fatalf("matched %d agents, want 2: %+v", len(got), got)
+	}
+	if a := got["gastown/nux"]; a.Text != "first answer" || a.msgID != "hq-r2" || a.Via != "mail" {
+		t.Errorf("nux answer = %+v, want the earliest reply", a)
+	}
+	if a := got["gastown/crew/max"]; a.Text != "working on gt-12" {
+		t.Errorf("max answer = %+v", a)
+	}
+}
+
+func TestBuildStandupReport(t *testing.T) {
+	asked := []string{"gastown/nux", "gastown/crew/max", "beads/toast"}
+	answers := map[string]standupAnswer{
+		"gastown/crew/max": {Text: "Done: gt-1\nBlocked: nothing", At: time.Now(), Via: "comment"},
+	}
+	report := buildStandupReport("standup-x", time.Now(), asked, answers)
+
+	for _, want := range []string{"1 of 3 agents answered", "## gastown/crew/max", "Blocked: nothing", "## No answer", "- beads/toast", "- gastown/nux"} {
+		if !strings.Contains(report, want) {
+			t.Errorf("report missing %q:\n%s", want, report)
+		}
+	}
+	if strings.Index(report, "- beads/toast") > strings.Index(report, "- gastown/nux") {
+		t.Error("agents without an answer should be sorted")
+	}
+}
+
+func TestRenderStandupPrompt(t *testing.T) {
+	prompt := template.Must(template.New("standup").Parse(defaultStandupPrompt))
+	got, err := renderStandupPrompt(prompt, "standup-x", "gastown/nux", "mayor/")
+	if err != nil {
+		t.Fatalf("renderStandupPrompt: %v", err)
+	}
+	if !strings.Contains(got, `gt mail send mayor/ -s "Standup standup-x"`) {
+		t.Errorf("prompt lacks the reply command:\n%s", got)
+	}
+}
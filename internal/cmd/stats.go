@@ -0,0 +1,183 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/utilization"
+	"github.com/steveyegge/gastown/internal/workspace"
+	"golang.org/x/term"
+)
+
+var (
+	statsHeatmapPeriod  string
+	statsHeatmapBuckets int
+	statsHeatmapRig     string
+	statsHeatmapJSON    bool
+)
+
+var statsCmd = &cobra.Command{
+	Use:     "stats",
+	GroupID: GroupDiag,
+	Short:   "Show town statistics over time",
+	RunE:    requireSubcommand,
+}
+
+var statsHeatmapCmd = &cobra.Command{
+	Use:   "heatmap",
+	Short: "Show per-agent busy/idle/stuck states as a heatmap over time",
+	Long: `Render a terminal heatmap of each agent's state over time.
+
+Each row is an agent; each column is a time bucket showing the state the
+agent was in most often during that bucket:
+
+  █  busy   hooked work making progress
+  ░  idle   no hooked work
+  █  stuck  hooked work stalled (red)
+  ·  down   session dead
+
+Blank columns mean no samples (daemon not running). Samples are recorded
+by the daemon's utilization_sampler patrol every 5 minutes into
+.runtime/utilization.jsonl.
+
+Examples:
+  gt stats heatmap                   # Last 7 days
+  gt stats heatmap --period 24h      # Last day
+  gt stats heatmap --rig gastown     # One rig's agents
+  gt stats heatmap --buckets 168     # Hourly buckets over 7 days`,
+	RunE: runStatsHeatmap,
+}
+
+func init() {
+	statsHeatmapCmd.Flags().StringVar(&statsHeatmapPeriod, "period", "7d", "Time range to show (e.g., 24h, 7d)")
+	statsHeatmapCmd.Flags().IntVar(&statsHeatmapBuckets, "buckets", 0, "Number of time buckets (default: fit terminal width)")
+	statsHeatmapCmd.Flags().StringVar(&statsHeatmapRig, "rig", "", "Only show agents in this rig")
+	statsHeatmapCmd.Flags().BoolVar(&statsHeatmapJSON, "json", false, "Output as JSON")
+	statsCmd.AddCommand(statsHeatmapCmd)
+	rootCmd.AddCommand(statsCmd)
+}
+
+func runStatsHeatmap(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	period, err := parseDuration(statsHeatmapPeriod)
+	if err != nil || period <= 0 {
+		return fmt.Errorf("invalid --period %q", statsHeatmapPeriod)
+	}
+	if statsHeatmapBuckets < 0 {
+		return fmt.Errorf("--buckets must be positive")
+	}
+
+	end := time.Now().UTC()
+	start := end.Add(-period)
+	samples, err := utilization.Load(townRoot, start)
+	if err != nil {
+		return fmt.Errorf("loading utilization samples: %w", err)
+	}
+
+	var include func(string) bool
+	if statsHeatmapRig != "" {
+		include = func(agent string) bool { return strings.HasPrefix(agent, statsHeatmapRig+"/") }
+	}
+	h := utilization.Build(samples, start, end, heatmapBucketCount(), include)
+
+	if statsHeatmapJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(h)
+	}
+
+	if len(h.Agents) == 0 {
+		fmt.Printf("%s No utilization samples in the last %s\n", style.Dim.Render("○"), statsHeatmapPeriod)
+		fmt.Printf("  %s\n", style.Dim.Render("The daemon's utilization_sampler patrol records agent states every 5m (gt daemon start)"))
+		return nil
+	}
+	renderHeatmap(h)
+	return nil
+}
+
+// heatmapBucketCount returns --buckets, or as many columns as fit beside
+// the agent labels and totals in the terminal.
+func heatmapBucketCount() int {
+	if statsHeatmapBuckets > 0 {
+		return statsHeatmapBuckets
+	}
+	width, _, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil || width <= 0 {
+		width = 100
+	}
+	n := width - 44 // agent label + busy/stuck totals
+	if n < 12 {
+		n = 12
+	}
+	if n > 168 {
+		n = 168
+	}
+	return n
+}
+
+// heatmapCell renders one bucket's state as a colored block.
+func heatmapCell(state string) string {
+	switch state {
+	case utilization.StateBusy:
+		return style.Success.Render("█")
+	case utilization.StateIdle:
+		return style.Dim.Render("░")
+	case utilization.StateStuck:
+		return style.Error.Render("█")
+	case utilization.StateDown:
+		return style.Dim.Render("·")
+	default:
+		return " "
+	}
+}
+
+func renderHeatmap(h *utilization.Heatmap) {
+	labelWidth := len("agent")
+	for _, a := range h.Agents {
+		if len(a) > labelWidth {
+			labelWidth = len(a)
+		}
+	}
+
+	fmt.Printf("%s  %s\n\n", style.Bold.Render("Agent utilization"),
+		style.Dim.Render(fmt.Sprintf("(%s buckets)", h.BucketWidth().Round(time.Minute))))
+
+	// Time axis: start label on the left, end label on the right.
+	startLabel := h.Start.Local().Format("Jan 02 15:04")
+	endLabel := h.End.Local().Format("Jan 02 15:04")
+	axis := startLabel
+	if gap := h.Buckets - len(startLabel) - len(endLabel); gap > 0 {
+		axis += strings.Repeat(" ", gap) + endLabel
+	}
+	fmt.Printf("%-*s  %s\n", labelWidth, "", style.Dim.Render(axis))
+
+	for _, agent := range h.Agents {
+		var row strings.Builder
+		for _, state := range h.Cells[agent] {
+			row.WriteString(heatmapCell(state))
+		}
+		busy := h.Share(agent, utilization.StateBusy)
+		stuck := h.Share(agent, utilization.StateStuck)
+		stuckStr := fmt.Sprintf("stuck %3.0f%%", stuck*100)
+		if stuck > 0 {
+			stuckStr = style.Error.Render(stuckStr)
+		} else {
+			stuckStr = style.Dim.Render(stuckStr)
+		}
+		fmt.Printf("%-*s  %s  busy %3.0f%%  %s\n", labelWidth, agent, row.String(), busy*100, stuckStr)
+	}
+
+	fmt.Printf("\n%s busy  %s idle  %s stuck  %s down  %s\n",
+		heatmapCell(utilization.StateBusy), heatmapCell(utilization.StateIdle),
+		heatmapCell(utilization.StateStuck), heatmapCell(utilization.StateDown),
+		style.Dim.Render("(blank = no samples)"))
+}
@@ -15,17 +15,21 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/budget"
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/constants"
 	"github.com/steveyegge/gastown/internal/crew"
 	"github.com/steveyegge/gastown/internal/daemon"
+	"github.com/steveyegge/gastown/internal/deacon"
 	"github.com/steveyegge/gastown/internal/doltserver"
 	"github.com/steveyegge/gastown/internal/git"
 	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/nudge"
 	"github.com/steveyegge/gastown/internal/rig"
 	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/util"
 	"github.com/steveyegge/gastown/internal/workspace"
 	"golang.org/x/term"
 )
@@ -35,6 +39,16 @@ var statusFast bool
 var statusWatch bool
 var statusInterval int
 var statusVerbose bool
+var statusResources bool
+var statusMolecule string
+var statusStream string
+var statusNoCache bool
+
+// statusBeadsCacheTTL is how long status reuses a bead it already looked
+// up. Watch mode and the background collector refresh every few seconds and
+// see the same agent and hook beads each time; mutations this process
+// makes invalidate them sooner.
+const statusBeadsCacheTTL = 5 * time.Second
 
 var statusCmd = &cobra.Command{
 	Use:         "status",
@@ -47,7 +61,17 @@ var statusCmd = &cobra.Command{
 Shows town name, registered rigs, polecats, and witness status.
 
 Use --fast to skip mail lookups for faster execution.
-Use --watch to continuously refresh status at regular intervals.`,
+Use --watch to continuously refresh status at regular intervals.
+Use --resources to show CPU and memory per agent session. Agents over the
+limits in operational.resources (settings/config.json) are highlighted.
+Use --molecule <id> to show a molecule's execution frontier and
+critical-path progress instead of town status.
+Use --watch --stream <url> to follow a running dashboard's status stream
+(e.g. http://localhost:8080) instead of polling discovery locally.
+
+If the background status collector is running ('gt status cache start'),
+status is read from its snapshot and returns instantly. Use --no-cache to
+collect live anyway, without reusing beads looked up in the last few seconds.`,
 	RunE: runStatus,
 }
 
@@ -57,20 +81,26 @@ func init() {
 	statusCmd.Flags().BoolVarP(&statusWatch, "watch", "w", false, "Watch mode: refresh status continuously")
 	statusCmd.Flags().IntVarP(&statusInterval, "interval", "n", 2, "Refresh interval in seconds")
 	statusCmd.Flags().BoolVarP(&statusVerbose, "verbose", "v", false, "Show detailed multi-line output per agent")
+	statusCmd.Flags().BoolVar(&statusResources, "resources", false, "Show CPU and memory usage per agent session")
+	statusCmd.Flags().StringVar(&statusMolecule, "molecule", "", "Show execution progress of a molecule")
+	statusCmd.Flags().StringVar(&statusStream, "stream", "", "With --watch: follow status deltas from a gt dashboard URL")
+	statusCmd.Flags().BoolVar(&statusNoCache, "no-cache", false, "Collect status live, ignoring the status cache")
 	rootCmd.AddCommand(statusCmd)
 }
 
 // TownStatus represents the overall status of the workspace.
 type TownStatus struct {
-	Name     string         `json:"name"`
-	Location string         `json:"location"`
-	Overseer *OverseerInfo  `json:"overseer,omitempty"` // Human operator
-	Daemon   *ServiceInfo   `json:"daemon,omitempty"`   // Daemon status
-	Dolt     *DoltInfo      `json:"dolt,omitempty"`     // Dolt server status
-	Tmux     *TmuxInfo      `json:"tmux,omitempty"`     // Tmux server status
-	Agents   []AgentRuntime `json:"agents"`             // Global agents (Mayor, Deacon)
-	Rigs     []RigStatus    `json:"rigs"`
-	Summary  StatusSum      `json:"summary"`
+	Name     string                `json:"name"`
+	Location string                `json:"location"`
+	Overseer *OverseerInfo         `json:"overseer,omitempty"` // Human operator
+	Daemon   *ServiceInfo          `json:"daemon,omitempty"`   // Daemon status
+	Dolt     *DoltInfo             `json:"dolt,omitempty"`     // Dolt server status
+	Tmux     *TmuxInfo             `json:"tmux,omitempty"`     // Tmux server status
+	Probes   []*deacon.ProbeRecord `json:"probes,omitempty"`   // Latest deacon probe results
+	Budget   *budget.Report        `json:"budget,omitempty"`   // Spend against budget caps, if configured
+	Agents   []AgentRuntime        `json:"agents"`             // Global agents (Mayor, Deacon)
+	Rigs     []RigStatus           `json:"rigs"`
+	Summary  StatusSum             `json:"summary"`
 }
 
 // ServiceInfo represents a background service status.
@@ -86,8 +116,8 @@ type DoltInfo struct {
 	Port          int    `json:"port"`
 	Remote        bool   `json:"remote,omitempty"`
 	DataDir       string `json:"data_dir,omitempty"`
-	PortConflict  bool   `json:"port_conflict,omitempty"`   // Port taken by another town's Dolt
-	ConflictOwner string `json:"conflict_owner,omitempty"`  // --data-dir of the process holding the port
+	PortConflict  bool   `json:"port_conflict,omitempty"`  // Port taken by another town's Dolt
+	ConflictOwner string `json:"conflict_owner,omitempty"` // --data-dir of the process holding the port
 }
 
 // TmuxInfo represents the tmux server status.
@@ -110,19 +140,23 @@ type OverseerInfo struct {
 
 // AgentRuntime represents the runtime state of an agent.
 type AgentRuntime struct {
-	Name         string `json:"name"`                    // Display name (e.g., "mayor", "witness")
-	Address      string `json:"address"`                 // Full address (e.g., "greenplace/witness")
-	Session      string `json:"session"`                 // tmux session name
-	Role         string `json:"role"`                    // Role type
-	Running      bool   `json:"running"`                 // Is tmux session running?
-	HasWork      bool   `json:"has_work"`                // Has pinned work?
-	WorkTitle    string `json:"work_title,omitempty"`    // Title of pinned work
-	HookBead     string `json:"hook_bead,omitempty"`     // Pinned bead ID from agent bead
-	State        string `json:"state,omitempty"`         // Agent state from agent bead
-	UnreadMail   int    `json:"unread_mail"`             // Number of unread messages
-	FirstSubject string `json:"first_subject,omitempty"` // Subject of first unread message
-	AgentAlias   string `json:"agent_alias,omitempty"`   // Configured agent name (e.g., "opus-46", "pi")
-	AgentInfo    string `json:"agent_info,omitempty"`    // Runtime summary (e.g., "claude/opus", "pi/kimi-k2p5")
+	Name         string     `json:"name"`                    // Display name (e.g., "mayor", "witness")
+	Address      string     `json:"address"`                 // Full address (e.g., "greenplace/witness")
+	Session      string     `json:"session"`                 // tmux session name
+	Role         string     `json:"role"`                    // Role type
+	Running      bool       `json:"running"`                 // Is tmux session running?
+	HasWork      bool       `json:"has_work"`                // Has pinned work?
+	WorkTitle    string     `json:"work_title,omitempty"`    // Title of pinned work
+	HookBead     string     `json:"hook_bead,omitempty"`     // Pinned bead ID from agent bead
+	State        string     `json:"state,omitempty"`         // Agent state from agent bead
+	UnreadMail   int        `json:"unread_mail"`             // Number of unread messages
+	FirstSubject string     `json:"first_subject,omitempty"` // Subject of first unread message
+	AgentAlias   string     `json:"agent_alias,omitempty"`   // Configured agent name (e.g., "opus-46", "pi")
+	AgentInfo    string     `json:"agent_info,omitempty"`    // Runtime summary (e.g., "claude/opus", "pi/kimi-k2p5")
+	QuietUntil   *time.Time `json:"quiet_until,omitempty"`   // Set while muted by gt quiet
+
+	Resources *util.ResourceUsage `json:"resources,omitempty"`  // Process-tree CPU/memory (--resources)
+	OverLimit bool                `json:"over_limit,omitempty"` // Resources exceed configured limits
 }
 
 // RigStatus represents status of a single rig.
@@ -436,7 +470,22 @@ func buildInfoFromConfig(rc *config.RuntimeConfig) string {
 }
 
 func runStatus(cmd *cobra.Command, args []string) error {
+	if !statusNoCache {
+		beads.EnableIssueCache(statusBeadsCacheTTL)
+	}
+	if statusMolecule != "" {
+		if statusWatch {
+			return fmt.Errorf("--molecule and --watch cannot be used together")
+		}
+		return runMoleculeExecStatus(statusMolecule, statusJSON)
+	}
+	if statusStream != "" && !statusWatch {
+		return fmt.Errorf("--stream requires --watch")
+	}
 	if statusWatch {
+		if statusStream != "" {
+			return runStatusStream(statusStream)
+		}
 		return runStatusWatch(cmd, args)
 	}
 	return runStatusOnce(cmd, args)
@@ -482,7 +531,7 @@ func runStatusWatch(_ *cobra.Command, _ []string) error {
 			fmt.Fprintf(&buf, "%s\n\n", header)
 		}
 
-		status, err := gatherStatus()
+		status, err := loadStatus()
 		usedCache := false
 
 		// On error, retry once before giving up.
@@ -575,7 +624,7 @@ func countRunningAgents(s TownStatus) int {
 }
 
 func runStatusOnce(_ *cobra.Command, _ []string) error {
-	status, err := gatherStatus()
+	status, err := loadStatus()
 	if err != nil {
 		return err
 	}
@@ -623,25 +672,21 @@ func gatherStatus() (TownStatus, error) {
 	// alive inside it, not merely if the tmux session exists. This prevents
 	// zombie sessions (tmux alive, agent dead) from showing as running.
 	// See: gt-bd6i3
+	// AgentsAlive checks them all with a couple of tmux calls rather than
+	// several per session.
 	allSessions := make(map[string]bool)
 	if sessions, err := t.ListSessions(); err == nil {
-		var sessionMu sync.Mutex
-		var sessionWg sync.WaitGroup
+		var known []string
 		for _, s := range sessions {
 			if session.IsKnownSession(s) {
-				sessionWg.Add(1)
-				go func(name string) {
-					defer sessionWg.Done()
-					alive := t.IsAgentAlive(name)
-					sessionMu.Lock()
-					allSessions[name] = alive
-					sessionMu.Unlock()
-				}(s)
+				known = append(known, s)
 			} else {
 				allSessions[s] = true
 			}
 		}
-		sessionWg.Wait()
+		for name, alive := range t.AgentsAlive(known) {
+			allSessions[name] = alive
+		}
 	}
 
 	// Discover rigs
@@ -653,8 +698,7 @@ func gatherStatus() (TownStatus, error) {
 	// Pre-fetch agent beads across all rig-specific beads DBs.
 	// In --fast mode, parallelize these fetches for better performance.
 	allAgentBeads := make(map[string]*beads.Issue)
-	allHookBeads := make(map[string]*beads.Issue)
-	var beadsMu sync.Mutex // Protects allAgentBeads and allHookBeads
+	var beadsMu sync.Mutex // Protects allAgentBeads
 
 	// Helper to safely merge beads into the shared maps
 	mergeAgentBeads := func(beadsMap map[string]*beads.Issue) {
@@ -664,13 +708,6 @@ func gatherStatus() (TownStatus, error) {
 		}
 		beadsMu.Unlock()
 	}
-	mergeHookBeads := func(beadsMap map[string]*beads.Issue) {
-		beadsMu.Lock()
-		for id, issue := range beadsMap {
-			allHookBeads[id] = issue
-		}
-		beadsMu.Unlock()
-	}
 
 	var beadsWg sync.WaitGroup
 
@@ -682,25 +719,6 @@ func gatherStatus() (TownStatus, error) {
 		townBeadsClient := beads.New(townBeadsPath)
 		townAgentBeads, _ := townBeadsClient.ListAgentBeads()
 		mergeAgentBeads(townAgentBeads)
-
-		// Fetch hook beads from town beads
-		var townHookIDs []string
-		for _, issue := range townAgentBeads {
-			hookID := issue.HookBead
-			if hookID == "" {
-				fields := beads.ParseAgentFields(issue.Description)
-				if fields != nil {
-					hookID = fields.HookBead
-				}
-			}
-			if hookID != "" {
-				townHookIDs = append(townHookIDs, hookID)
-			}
-		}
-		if len(townHookIDs) > 0 {
-			townHookBeads, _ := townBeadsClient.ShowMultiple(townHookIDs)
-			mergeHookBeads(townHookBeads)
-		}
 	}()
 
 	// Fetch rig-level agent beads in parallel
@@ -711,36 +729,23 @@ func gatherStatus() (TownStatus, error) {
 			rigBeadsPath := filepath.Join(r.Path, "mayor", "rig")
 			rigBeads := beads.New(rigBeadsPath)
 			rigAgentBeads, _ := rigBeads.ListAgentBeads()
-			if rigAgentBeads == nil {
-				return
-			}
 			mergeAgentBeads(rigAgentBeads)
-
-			var hookIDs []string
-			for _, issue := range rigAgentBeads {
-				// Use the HookBead field from the database column; fall back for legacy beads.
-				hookID := issue.HookBead
-				if hookID == "" {
-					fields := beads.ParseAgentFields(issue.Description)
-					if fields != nil {
-						hookID = fields.HookBead
-					}
-				}
-				if hookID != "" {
-					hookIDs = append(hookIDs, hookID)
-				}
-			}
-
-			if len(hookIDs) == 0 {
-				return
-			}
-			hookBeads, _ := rigBeads.ShowMultiple(hookIDs)
-			mergeHookBeads(hookBeads)
 		}(r)
 	}
 
 	beadsWg.Wait()
 
+	// Resolve every agent's hook bead in one batch: one bd show per
+	// database the hooks live in, routed by prefix, so a rig agent hooked
+	// to an hq- bead (or another rig's bead) still gets its title.
+	hookIDs := make([]string, 0, len(allAgentBeads))
+	for _, issue := range allAgentBeads {
+		if hookID := beads.HookBeadID(issue); hookID != "" {
+			hookIDs = append(hookIDs, hookID)
+		}
+	}
+	allHookBeads, _ := beads.New(townBeadsPath).ShowMany(hookIDs)
+
 	// Create mail router for inbox lookups
 	mailRouter := mail.NewRouter(townRoot)
 
@@ -824,6 +829,7 @@ func gatherStatus() (TownStatus, error) {
 		tmuxInfo.PID = tmux.NewTmux().ServerPID()
 	}
 	status.Tmux = tmuxInfo
+	status.Probes = loadProbeRecords(townRoot)
 
 	var wg sync.WaitGroup
 
@@ -904,6 +910,18 @@ func gatherStatus() (TownStatus, error) {
 		}
 	}
 
+	if quiet, err := nudge.LoadQuiet(townRoot); err == nil && len(quiet) > 0 {
+		attachQuietState(&status, quiet, time.Now())
+	}
+
+	if statusResources {
+		attachAgentResources(&status, townRoot, t)
+	}
+
+	if r, err := loadBudgetReport(townRoot, time.Now()); err == nil && r != nil {
+		status.Budget = r
+	}
+
 	// Aggregate summary (after parallel work completes)
 	for i, rs := range status.Rigs {
 		status.Summary.PolecatCount += rs.PolecatCount
@@ -984,6 +1002,13 @@ func outputStatusText(w io.Writer, status TownStatus) error {
 		fmt.Fprintln(w)
 	}
 
+	// Deacon health probes
+	if len(status.Probes) > 0 {
+		fmt.Fprintf(w, "%s %s\n\n", style.Bold.Render("Probes:"), formatProbeSummary(status.Probes))
+	}
+
+	outputBudgetText(w, status.Budget)
+
 	// Role icons - uses centralized emojis from constants package
 	roleIcons := map[string]string{
 		constants.RoleMayor:    constants.EmojiMayor,
@@ -1157,8 +1182,11 @@ func renderAgentDetails(w io.Writer, agent AgentRuntime, indent string, hooks []
 	case "muted", "paused", "degraded":
 		// Other intentional non-observable states
 		stateInfo = style.Dim.Render(fmt.Sprintf(" [%s]", beadState))
-	// Ignore observable states: "running", "idle", "dead", "done", "stopped", ""
-	// These should be derived from tmux, not bead.
+		// Ignore observable states: "running", "idle", "dead", "done", "stopped", ""
+		// These should be derived from tmux, not bead.
+	}
+	if agent.QuietUntil != nil {
+		stateInfo += style.Dim.Render(" [quiet until " + agent.QuietUntil.Local().Format("15:04") + "]")
 	}
 
 	// Build agent bead ID using canonical naming: prefix-rig-role-name
@@ -1192,6 +1220,9 @@ func renderAgentDetails(w io.Writer, agent AgentRuntime, indent string, hooks []
 	if agent.AgentInfo != "" {
 		fmt.Printf("%s  agent: %s\n", indent, agent.AgentInfo)
 	}
+	if agent.Resources != nil {
+		fmt.Fprintf(w, "%s  resources:%s\n", indent, formatResourceSuffix(agent))
+	}
 
 	// Line 3: Hook bead (pinned work)
 	hookStr := style.Dim.Render("(none)")
@@ -1325,8 +1356,8 @@ func renderAgentCompactWithSuffix(w io.Writer, agent AgentRuntime, indent string
 		agentSuffix = " " + style.Dim.Render("["+agent.AgentInfo+"]")
 	}
 
-	// Print single line: name + status + agent-info + hook + mail + suffix
-	fmt.Fprintf(w, "%s%-12s %s%s%s%s%s\n", indent, agent.Name, statusIndicator, agentSuffix, hookSuffix, mailSuffix, suffix)
+	// Print single line: name + status + agent-info + resources + hook + mail + suffix
+	fmt.Fprintf(w, "%s%-12s %s%s%s%s%s%s\n", indent, agent.Name, statusIndicator, agentSuffix, formatResourceSuffix(agent), hookSuffix, mailSuffix, suffix)
 }
 
 // renderAgentCompact renders a single-line agent status
@@ -1371,8 +1402,26 @@ func renderAgentCompact(w io.Writer, agent AgentRuntime, indent string, hooks []
 		agentSuffix = " " + style.Dim.Render("["+agent.AgentInfo+"]")
 	}
 
-	// Print single line: name + status + agent-info + hook + mail
-	fmt.Fprintf(w, "%s%-12s %s%s%s%s\n", indent, agent.Name, statusIndicator, agentSuffix, hookSuffix, mailSuffix)
+	// Print single line: name + status + agent-info + resources + hook + mail
+	fmt.Fprintf(w, "%s%-12s %s%s%s%s%s\n", indent, agent.Name, statusIndicator, agentSuffix, formatResourceSuffix(agent), hookSuffix, mailSuffix)
+}
+
+// attachQuietState marks agents whose sessions are in an active quiet period.
+func attachQuietState(status *TownStatus, quiet map[string]nudge.QuietState, now time.Time) {
+	mark := func(a *AgentRuntime) {
+		if q, ok := quiet[a.Session]; ok && q.Active(now) {
+			until := q.Until
+			a.QuietUntil = &until
+		}
+	}
+	for i := range status.Agents {
+		mark(&status.Agents[i])
+	}
+	for i := range status.Rigs {
+		for j := range status.Rigs[i].Agents {
+			mark(&status.Rigs[i].Agents[j])
+		}
+	}
 }
 
 // buildStatusIndicator creates the visual status indicator for an agent.
@@ -1398,7 +1447,10 @@ func buildStatusIndicator(agent AgentRuntime) string {
 		indicator += style.Dim.Render(" gate")
 	case "muted", "paused", "degraded":
 		indicator += style.Dim.Render(" " + beadState)
-	// Ignore observable states: running, idle, dead, done, stopped, ""
+		// Ignore observable states: running, idle, dead, done, stopped, ""
+	}
+	if agent.QuietUntil != nil {
+		indicator += style.Dim.Render(" quiet")
 	}
 
 	return indicator
@@ -1517,27 +1569,7 @@ func discoverGlobalAgents(allSessions map[string]bool, allAgentBeads map[string]
 			// Check tmux session from preloaded map (O(1))
 			agent.Running = allSessions[d.session]
 
-			// Look up agent bead from preloaded map (O(1))
-			if issue, ok := allAgentBeads[d.beadID]; ok {
-				// Prefer database columns over description parsing
-				// HookBead column is authoritative (cleared by unsling)
-				agent.HookBead = issue.HookBead
-				agent.State = issue.AgentState
-				if agent.HookBead != "" {
-					agent.HasWork = true
-					// Get hook title from preloaded map
-					if pinnedIssue, ok := allHookBeads[agent.HookBead]; ok {
-						agent.WorkTitle = pinnedIssue.Title
-					}
-				}
-				// Fallback to description for legacy beads without database columns
-				if agent.State == "" {
-					fields := beads.ParseAgentFields(issue.Description)
-					if fields != nil {
-						agent.State = fields.AgentState
-					}
-				}
-			}
+			applyAgentBead(&agent, allAgentBeads[d.beadID], allHookBeads)
 
 			// Get mail info (skip if --fast)
 			if !skipMail {
@@ -1552,6 +1584,32 @@ func discoverGlobalAgents(allSessions map[string]bool, allAgentBeads map[string]
 	return agents
 }
 
+// applyAgentBead fills in an agent's hook and state from its agent bead,
+// looking the hook's title up in the batch-resolved hook beads. A nil bead
+// leaves the agent as is.
+func applyAgentBead(agent *AgentRuntime, issue *beads.Issue, allHookBeads map[string]*beads.Issue) {
+	if issue == nil {
+		return
+	}
+	// Prefer database columns over description parsing
+	// HookBead column is authoritative (cleared by unsling)
+	agent.HookBead = issue.HookBead
+	agent.State = issue.AgentState
+	if agent.HookBead != "" {
+		agent.HasWork = true
+		if pinnedIssue, ok := allHookBeads[agent.HookBead]; ok {
+			agent.WorkTitle = pinnedIssue.Title
+		}
+	}
+	// Fallback to description for legacy beads without database columns
+	if agent.State == "" {
+		fields := beads.ParseAgentFields(issue.Description)
+		if fields != nil {
+			agent.State = fields.AgentState
+		}
+	}
+}
+
 // populateMailInfo fetches unread mail count and first subject for an agent
 func populateMailInfo(agent *AgentRuntime, router *mail.Router) {
 	if router == nil {
@@ -1657,27 +1715,7 @@ func discoverRigAgents(allSessions map[string]bool, r *rig.Rig, crews []string,
 			// Check tmux session from preloaded map (O(1))
 			agent.Running = allSessions[d.session]
 
-			// Look up agent bead from preloaded map (O(1))
-			if issue, ok := allAgentBeads[d.beadID]; ok {
-				// Prefer database columns over description parsing
-				// HookBead column is authoritative (cleared by unsling)
-				agent.HookBead = issue.HookBead
-				agent.State = issue.AgentState
-				if agent.HookBead != "" {
-					agent.HasWork = true
-					// Get hook title from preloaded map
-					if pinnedIssue, ok := allHookBeads[agent.HookBead]; ok {
-						agent.WorkTitle = pinnedIssue.Title
-					}
-				}
-				// Fallback to description for legacy beads without database columns
-				if agent.State == "" {
-					fields := beads.ParseAgentFields(issue.Description)
-					if fields != nil {
-						agent.State = fields.AgentState
-					}
-				}
-			}
+			applyAgentBead(&agent, allAgentBeads[d.beadID], allHookBeads)
 
 			// Get mail info (skip if --fast)
 			if !skipMail {
@@ -0,0 +1,221 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/statuscache"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// statusCacheTmuxPoll is how often the collector checks the tmux session
+// list, refreshing as soon as sessions come or go.
+const statusCacheTmuxPoll = 2 * time.Second
+
+var statusCacheInterval time.Duration
+
+var statusCacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Manage the background status collector",
+	Long: `Manage the optional background status collector.
+
+The collector keeps a TownStatus snapshot warm, refreshing it on a timer and
+whenever tmux sessions start or stop, and serves it on a unix socket in
+.runtime/. While it runs, 'gt status' reads the snapshot instead of
+collecting live, so it answers instantly. Snapshots older than 30s are
+ignored, and 'gt status --no-cache' always collects live.`,
+	RunE: requireSubcommand,
+}
+
+var statusCacheStartCmd = &cobra.Command{
+	Use:   "start",
+	Short: "Start the status collector in the background",
+	Args:  cobra.NoArgs,
+	RunE:  runStatusCacheStart,
+}
+
+var statusCacheStopCmd = &cobra.Command{
+	Use:   "stop",
+	Short: "Stop the status collector",
+	Args:  cobra.NoArgs,
+	RunE:  runStatusCacheStop,
+}
+
+var statusCacheStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show whether the status collector is running",
+	Args:  cobra.NoArgs,
+	RunE:  runStatusCacheStatus,
+}
+
+var statusCacheRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Run the status collector in the foreground (internal)",
+	Long: `Run the status collector in the foreground.
+
+This is called by 'gt status cache start'. Use that to start the collector
+in the background.`,
+	Hidden: true,
+	Args:   cobra.NoArgs,
+	RunE:   runStatusCacheRun,
+}
+
+func init() {
+	statusCacheStartCmd.Flags().DurationVar(&statusCacheInterval, "interval", 10*time.Second, "How often to refresh the snapshot")
+	statusCacheRunCmd.Flags().DurationVar(&statusCacheInterval, "interval", 10*time.Second, "How often to refresh the snapshot")
+
+	statusCacheCmd.AddCommand(statusCacheStartCmd)
+	statusCacheCmd.AddCommand(statusCacheStopCmd)
+	statusCacheCmd.AddCommand(statusCacheStatusCmd)
+	statusCacheCmd.AddCommand(statusCacheRunCmd)
+	statusCmd.AddCommand(statusCacheCmd)
+}
+
+// loadStatus returns the town status: the collector's snapshot if one is
+// running and fresh, otherwise a live collection. Resource usage is never
+// cached, so --resources always collects live.
+func loadStatus() (TownStatus, error) {
+	if !statusNoCache && !statusResources {
+		if townRoot, err := workspace.FindFromCwd(); err == nil && townRoot != "" {
+			if snap, ok := statuscache.Get(townRoot, statuscache.DefaultMaxAge); ok {
+				var status TownStatus
+				if err := json.Unmarshal(snap.Status, &status); err == nil {
+					return status, nil
+				}
+			}
+		}
+	}
+	return gatherStatus()
+}
+
+func runStatusCacheStart(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	socketPath := statuscache.SocketPath(townRoot)
+	if _, err := statuscache.Query(socketPath, "get", time.Second); err == nil {
+		fmt.Printf("%s Status cache already running\n", style.Bold.Render("●"))
+		return nil
+	}
+
+	gtPath, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("finding executable: %w", err)
+	}
+	runCmd := exec.Command(gtPath, "status", "cache", "run", "--interval", statusCacheInterval.String())
+	runCmd.Dir = townRoot
+	if err := runCmd.Start(); err != nil {
+		return fmt.Errorf("starting status cache: %w", err)
+	}
+	_ = runCmd.Process.Release()
+
+	// The socket answers once the first collection is done, which can take
+	// a few seconds on a big town.
+	deadline := time.Now().Add(30 * time.Second)
+	for time.Now().Before(deadline) {
+		if _, err := statuscache.Query(socketPath, "get", time.Second); err == nil {
+			fmt.Printf("%s Status cache started (refresh every %s)\n", style.Bold.Render("✓"), statusCacheInterval)
+			return nil
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+	return fmt.Errorf("status cache did not come up on %s", socketPath)
+}
+
+func runStatusCacheStop(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	if err := statuscache.Stop(townRoot); err != nil {
+		return err
+	}
+	fmt.Printf("%s Status cache stopped\n", style.Bold.Render("✓"))
+	return nil
+}
+
+func runStatusCacheStatus(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	snap, err := statuscache.Query(statuscache.SocketPath(townRoot), "get", time.Second)
+	if err != nil {
+		fmt.Printf("%s Status cache is %s\n", style.Dim.Render("○"), "not running")
+		fmt.Printf("\nStart with: %s\n", style.Dim.Render("gt status cache start"))
+		return nil
+	}
+	fmt.Printf("%s Status cache is %s\n", style.Bold.Render("●"), style.Bold.Render("running"))
+	fmt.Printf("  Socket: %s\n", statuscache.SocketPath(townRoot))
+	if !snap.CollectedAt.IsZero() {
+		fmt.Printf("  Last snapshot: %s (%s ago)\n", snap.CollectedAt.Format("15:04:05"),
+			time.Since(snap.CollectedAt).Round(time.Second))
+	}
+	if snap.Error != "" {
+		fmt.Printf("  %s Last collection failed: %s\n", style.Warning.Render("⚠"), snap.Error)
+	}
+	return nil
+}
+
+func runStatusCacheRun(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	if statusCacheInterval <= 0 {
+		return fmt.Errorf("interval must be positive, got %s", statusCacheInterval)
+	}
+
+	beads.EnableIssueCache(statusBeadsCacheTTL)
+	srv := statuscache.NewServer(statuscache.SocketPath(townRoot), func() (json.RawMessage, error) {
+		status, err := gatherStatus()
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(status)
+	}, statusCacheInterval)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go watchTmuxSessions(ctx, tmux.NewTmux(), srv.Refresh)
+	return srv.Run(ctx)
+}
+
+// watchTmuxSessions calls onChange whenever the set of tmux sessions
+// changes, until ctx is done.
+func watchTmuxSessions(ctx context.Context, t *tmux.Tmux, onChange func()) {
+	ticker := time.NewTicker(statusCacheTmuxPoll)
+	defer ticker.Stop()
+	last, seen := "", false
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		sessions, err := t.ListSessions()
+		if err != nil {
+			continue
+		}
+		sort.Strings(sessions)
+		if key := strings.Join(sessions, "\n"); !seen || key != last {
+			if seen {
+				onChange()
+			}
+			last, seen = key, true
+		}
+	}
+}
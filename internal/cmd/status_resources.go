@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// resourceSampleInterval is how long CPU is measured over for --resources.
+const resourceSampleInterval = 500 * time.Millisecond
+
+// attachAgentResources samples every running agent session's process tree
+// and records its usage, flagging agents over the configured limits.
+// Sampling failures leave Resources unset rather than failing status.
+func attachAgentResources(status *TownStatus, townRoot string, t *tmux.Tmux) {
+	var agents []*AgentRuntime
+	for i := range status.Agents {
+		agents = append(agents, &status.Agents[i])
+	}
+	for i := range status.Rigs {
+		for j := range status.Rigs[i].Agents {
+			agents = append(agents, &status.Rigs[i].Agents[j])
+		}
+	}
+
+	var sessions []string
+	for _, a := range agents {
+		if a.Running && a.Session != "" {
+			sessions = append(sessions, a.Session)
+		}
+	}
+	usage, err := util.SampleSessions(t, sessions, resourceSampleInterval)
+	if err != nil {
+		return
+	}
+
+	limits := config.LoadOperationalConfig(townRoot).GetResourcesConfig()
+	for _, a := range agents {
+		if u, ok := usage[a.Session]; ok {
+			a.Resources = &u
+			a.OverLimit = limits.Exceeded(u.CPUPercent, u.RSSMB()) != ""
+		}
+	}
+}
+
+// formatResourceSuffix renders an agent's resource usage for status lines.
+func formatResourceSuffix(agent AgentRuntime) string {
+	if agent.Resources == nil {
+		return ""
+	}
+	text := " " + agent.Resources.String()
+	if agent.OverLimit {
+		return style.Warning.Render(text + " ⚠")
+	}
+	return style.Dim.Render(text)
+}
@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/web"
+	"golang.org/x/term"
+)
+
+// runStatusStream renders gt status --watch from a dashboard's status
+// stream (gt dashboard, /api/status/stream) instead of running discovery
+// locally each interval. The screen is redrawn whenever deltas arrive. A
+// dropped connection is retried with a fresh snapshot.
+func runStatusStream(baseURL string) error {
+	if statusJSON {
+		return fmt.Errorf("--json and --watch cannot be used together")
+	}
+	url := strings.TrimSuffix(baseURL, "/") + "/api/status/stream"
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	isTTY := term.IsTerminal(int(os.Stdout.Fd()))
+
+	for {
+		err := followStatusStream(ctx, url, isTTY)
+		if ctx.Err() != nil {
+			if isTTY {
+				fmt.Println("\nStopped.")
+			}
+			return nil
+		}
+		fmt.Fprintf(os.Stderr, "%s status stream: %v (reconnecting)\n", style.WarningPrefix, err)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(2 * time.Second):
+		}
+	}
+}
+
+// followStatusStream reads one stream connection until it ends.
+func followStatusStream(ctx context.Context, url string, isTTY bool) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s: %s", url, resp.Status)
+	}
+
+	var doc map[string]interface{}
+	var seq uint64
+	err = web.ReadSSE(resp.Body, func(event, data string) error {
+		switch event {
+		case "snapshot":
+			doc = nil
+			if err := json.Unmarshal([]byte(data), &doc); err != nil {
+				return fmt.Errorf("parsing snapshot: %w", err)
+			}
+			seq = 0
+		case "delta":
+			var update web.StatusUpdate
+			if err := json.Unmarshal([]byte(data), &update); err != nil {
+				return fmt.Errorf("parsing delta: %w", err)
+			}
+			if doc == nil {
+				return nil
+			}
+			if seq != 0 && update.Seq != seq+1 {
+				return fmt.Errorf("missed updates %d..%d", seq+1, update.Seq-1)
+			}
+			web.ApplyStatusDeltas(doc, update.Deltas)
+			seq = update.Seq
+		default:
+			return nil
+		}
+		return renderStreamedStatus(doc, url, isTTY)
+	})
+	if err != nil {
+		return err
+	}
+	return fmt.Errorf("stream closed")
+}
+
+// renderStreamedStatus redraws the screen from a status document.
+func renderStreamedStatus(doc map[string]interface{}, url string, isTTY bool) error {
+	raw, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	var status TownStatus
+	if err := json.Unmarshal(raw, &status); err != nil {
+		return fmt.Errorf("decoding status: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if isTTY {
+		buf.WriteString("\033[H\033[2J") // ANSI: cursor home + clear screen
+	}
+	header := fmt.Sprintf("[%s] gt status --watch (streaming from %s, Ctrl+C to stop)", time.Now().Format("15:04:05"), url)
+	if isTTY {
+		header = style.Dim.Render(header)
+	}
+	fmt.Fprintf(&buf, "%s\n\n", header)
+	if err := outputStatusText(&buf, status); err != nil {
+		fmt.Fprintf(&buf, "Error: %v\n", err)
+	}
+	_, _ = os.Stdout.Write(buf.Bytes())
+	return nil
+}
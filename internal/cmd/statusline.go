@@ -144,13 +144,8 @@ func runWorkerStatusLine(t *tmux.Tmux, session, rigName, polecat, crew, issue st
 
 	// Mail preview - only show if hook is empty
 	if hookedWork == "" && identity != "" && townRoot != "" {
-		unread, subject := getMailPreviewWithRoot(identity, 45, townRoot)
-		if unread > 0 {
-			if subject != "" {
-				parts = append(parts, fmt.Sprintf("\U0001F4EC %s", subject))
-			} else {
-				parts = append(parts, fmt.Sprintf("\U0001F4EC %d", unread))
-			}
+		if m := mailStatusPart(t, session, identity, 45, townRoot); m != "" {
+			parts = append(parts, m)
 		}
 	}
 
@@ -370,13 +365,8 @@ func runMayorStatusLine(t *tmux.Tmux) error {
 		parts = append(parts, fmt.Sprintf("🪝 %s", hookedWork))
 	} else if townRoot != "" {
 		// Priority 2: Fall back to mail preview
-		unread, subject := getMailPreviewWithRoot("mayor/", 45, townRoot)
-		if unread > 0 {
-			if subject != "" {
-				parts = append(parts, fmt.Sprintf("\U0001F4EC %s", subject))
-			} else {
-				parts = append(parts, fmt.Sprintf("\U0001F4EC %d", unread))
-			}
+		if m := mailStatusPart(t, statusLineSession, "mayor/", 45, townRoot); m != "" {
+			parts = append(parts, m)
 		}
 	}
 
@@ -439,13 +429,8 @@ func runDeaconStatusLine(t *tmux.Tmux) error {
 		parts = append(parts, fmt.Sprintf("🪝 %s", hookedWork))
 	} else if townRoot != "" {
 		// Priority 2: Fall back to mail preview
-		unread, subject := getMailPreviewWithRoot("deacon/", 40, townRoot)
-		if unread > 0 {
-			if subject != "" {
-				parts = append(parts, fmt.Sprintf("\U0001F4EC %s", subject))
-			} else {
-				parts = append(parts, fmt.Sprintf("\U0001F4EC %d", unread))
-			}
+		if m := mailStatusPart(t, statusLineSession, "deacon/", 40, townRoot); m != "" {
+			parts = append(parts, m)
 		}
 	}
 
@@ -507,13 +492,8 @@ func runWitnessStatusLine(t *tmux.Tmux, rigName string) error {
 		parts = append(parts, fmt.Sprintf("🪝 %s", hookedWork))
 	} else if townRoot != "" {
 		// Priority 2: Fall back to mail preview
-		unread, subject := getMailPreviewWithRoot(identity, 35, townRoot)
-		if unread > 0 {
-			if subject != "" {
-				parts = append(parts, fmt.Sprintf("\U0001F4EC %s", subject))
-			} else {
-				parts = append(parts, fmt.Sprintf("\U0001F4EC %d", unread))
-			}
+		if m := mailStatusPart(t, statusLineSession, identity, 35, townRoot); m != "" {
+			parts = append(parts, m)
 		}
 	}
 
@@ -597,13 +577,8 @@ func runRefineryStatusLine(t *tmux.Tmux, rigName string) error {
 		parts = append(parts, fmt.Sprintf("🪝 %s", hookedWork))
 	} else if townRoot != "" {
 		// Priority 2: Fall back to mail preview
-		unread, subject := getMailPreviewWithRoot(identity, 30, townRoot)
-		if unread > 0 {
-			if subject != "" {
-				parts = append(parts, fmt.Sprintf("\U0001F4EC %s", subject))
-			} else {
-				parts = append(parts, fmt.Sprintf("\U0001F4EC %d", unread))
-			}
+		if m := mailStatusPart(t, statusLineSession, identity, 30, townRoot); m != "" {
+			parts = append(parts, m)
 		}
 	}
 
@@ -632,6 +607,27 @@ func isSessionWorking(t *tmux.Tmux, session string) bool {
 	return false
 }
 
+// mailStatusPart returns the status line's 📬 part for identity's inbox, or
+// "" when nothing is unread. While 'gt mail notify' runs it has already
+// pushed the indicator into the session's @gt_mail option, which is used
+// as is; otherwise the inbox is read for a preview of the first subject.
+func mailStatusPart(t *tmux.Tmux, session, identity string, maxLen int, townRoot string) string {
+	if session != "" {
+		if text, ok, _ := t.GetMailIndicator(session); ok {
+			return text
+		}
+	}
+	unread, subject := getMailPreviewWithRoot(identity, maxLen, townRoot)
+	switch {
+	case unread == 0:
+		return ""
+	case subject != "":
+		return fmt.Sprintf("\U0001F4EC %s", subject)
+	default:
+		return fmt.Sprintf("\U0001F4EC %d", unread)
+	}
+}
+
 // getMailPreviewWithRoot returns unread count and a truncated subject of the first unread message,
 // using an explicit town root.
 func getMailPreviewWithRoot(identity string, maxLen int, townRoot string) (int, string) {
@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/templates"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var templatesCmd = &cobra.Command{
+	Use:     "templates",
+	GroupID: GroupConfig,
+	Short:   "List and preview role, message, and nudge templates",
+	Long: `Gas Town renders agents' role prompts (gt prime), protocol messages, and
+nudges from templates. Each has a built-in default; a file in
+<town>/settings/templates/ replaces it for the whole town:
+
+  roles/<role>.md.tmpl       System prompt for a role (mayor, witness, polecat, ...)
+  messages/<name>.md.tmpl    Protocol messages (spawn, nudge, escalation, handoff)
+  nudges/<name>.tmpl         One-line nudges:
+                               refinery-bounce    merge failed, fix and resubmit
+                               refinery-rejected  MR rejected
+                               witness-recovery   dead polecat needs recovery
+                               mayor-directive    gt nudge --template
+
+Templates use Go text/template syntax. Variables are fields like
+{{ .RigName }} or {{ .Branch }}; {{ cmd }} is the gt command name. A
+variable a nudge's sender doesn't set renders empty. If an override fails
+to parse, the built-in template is used and gt prime warns.
+
+Examples:
+  gt templates list
+  gt templates render roles/polecat --var RigName=gastown --var Polecat=nux
+  gt templates render refinery-bounce --var Branch=polecat/nux --var Error="tests failed"`,
+	RunE: requireSubcommand,
+}
+
+var templatesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List templates and which the town overrides",
+	Args:  cobra.NoArgs,
+	RunE:  runTemplatesList,
+}
+
+var templatesRenderCmd = &cobra.Command{
+	Use:   "render <kind>/<name>",
+	Short: "Render a template to preview it",
+	Long: `Render a template with the town's overrides applied, to preview it.
+
+Variables are set with --var key=value. Role templates are filled in for
+the current town; --var sets the rest (RigName, Polecat, DogName, WorkDir,
+DefaultBranch, BeadsDir, IssuePrefix). A bare name is looked up as a
+nudge, then a role, then a message.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTemplatesRender,
+}
+
+var templatesRenderVars []string
+
+func init() {
+	templatesRenderCmd.Flags().StringArrayVar(&templatesRenderVars, "var", nil, "Template variable (key=value), can be repeated")
+
+	templatesCmd.AddCommand(templatesListCmd)
+	templatesCmd.AddCommand(templatesRenderCmd)
+	rootCmd.AddCommand(templatesCmd)
+}
+
+func runTemplatesList(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	tmpl, err := templates.NewForTown(townRoot)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s (overrides in %s)\n\n", style.Bold.Render("Templates"), templates.TownDir(townRoot))
+	for _, info := range tmpl.List() {
+		source := style.Dim.Render("built-in")
+		if info.Override != "" {
+			source = style.Success.Render("town") + " " + style.Dim.Render(info.Override)
+		}
+		fmt.Printf("  %-28s %s\n", info.Ref(), source)
+	}
+	return nil
+}
+
+func runTemplatesRender(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	vars, err := parseTemplateVars(templatesRenderVars)
+	if err != nil {
+		return err
+	}
+	tmpl, err := templates.NewForTown(townRoot)
+	if err != nil {
+		return err
+	}
+	kind, name, err := tmpl.ParseRef(args[0])
+	if err != nil {
+		return err
+	}
+
+	var out string
+	switch kind {
+	case templates.KindRole:
+		out, err = tmpl.RenderRole(name, roleDataFromVars(townRoot, name, vars))
+	case templates.KindMessage:
+		out, err = tmpl.RenderMessage(name, vars)
+	case templates.KindNudge:
+		out, err = tmpl.RenderNudge(name, vars)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Println(strings.TrimRight(out, "\n"))
+	return nil
+}
+
+// parseTemplateVars parses repeated key=value flags.
+func parseTemplateVars(pairs []string) (map[string]string, error) {
+	vars := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid --var %q: want key=value", pair)
+		}
+		vars[key] = value
+	}
+	return vars, nil
+}
+
+// roleDataFromVars fills in role template data for previewing: the town's
+// own values, then whatever --var sets.
+func roleDataFromVars(townRoot, role string, vars map[string]string) templates.RoleData {
+	townName, _ := workspace.GetTownName(townRoot)
+	data := templates.RoleData{
+		Role:          role,
+		TownRoot:      townRoot,
+		TownName:      townName,
+		WorkDir:       townRoot,
+		DefaultBranch: "main",
+		MayorSession:  session.MayorSessionName(),
+		DeaconSession: session.DeaconSessionName(),
+	}
+	fields := map[string]*string{
+		"RigName":       &data.RigName,
+		"Polecat":       &data.Polecat,
+		"DogName":       &data.DogName,
+		"WorkDir":       &data.WorkDir,
+		"DefaultBranch": &data.DefaultBranch,
+		"BeadsDir":      &data.BeadsDir,
+		"IssuePrefix":   &data.IssuePrefix,
+		"TownName":      &data.TownName,
+	}
+	for key, value := range vars {
+		if field, ok := fields[key]; ok {
+			*field = value
+		}
+	}
+	return data
+}
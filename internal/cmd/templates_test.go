@@ -0,0 +1,29 @@
+package cmd
+
+import "testing"
+
+func TestParseTemplateVars(t *testing.T) {
+	vars, err := parseTemplateVars([]string{"Branch=polecat/nux", "Error=a=b", "Empty="})
+	if err != nil {
+		t.Fatalf("parseTemplateVars() error = %v", err)
+	}
+	if vars["Branch"] != "polecat/nux" || vars["Error"] != "a=b" || vars["Empty"] != "" {
+		t.Errorf("parseTemplateVars() = %v", vars)
+	}
+
+	for _, bad := range []string{"novalue", "=value"} {
+		if _, err := parseTemplateVars([]string{bad}); err == nil {
+			t.Errorf("parseTemplateVars(%q) should fail", bad)
+		}
+	}
+}
+
+func TestRoleDataFromVars(t *testing.T) {
+	data := roleDataFromVars("/town", "polecat", map[string]string{"RigName": "gastown", "Polecat": "nux", "Unknown": "x"})
+	if data.Role != "polecat" || data.TownRoot != "/town" || data.RigName != "gastown" || data.Polecat != "nux" {
+		t.Errorf("roleDataFromVars() = %+v", data)
+	}
+	if data.DefaultBranch != "main" {
+		t.Errorf("DefaultBranch = %q, want main", data.DefaultBranch)
+	}
+}
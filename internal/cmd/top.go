@@ -0,0 +1,341 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/activity"
+	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/util"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// gt top tuning.
+const (
+	// topOutputWindow is how far back pane output volume is summed.
+	topOutputWindow = 10 * time.Second
+
+	// topCPUWindow is how far back CPU time is averaged.
+	topCPUWindow = 5 * time.Second
+
+	// topCaptureLines is how much of each pane is compared between frames.
+	topCaptureLines = 200
+
+	// topMailEvery limits mailbox counts, which query beads, to this often.
+	topMailEvery = 10 * time.Second
+)
+
+var (
+	topInterval time.Duration
+	topSort     string
+	topRig      string
+	topOnce     bool
+)
+
+var topCmd = &cobra.Command{
+	Use:     "top",
+	GroupID: GroupDiag,
+	Short:   "Live leaderboard of agent output, CPU, and mail backlog",
+	Long: `Show a live, htop-style view of the town's agents.
+
+Each refresh lists every running agent session with:
+
+  OUTPUT   lines of new pane output in the last 10s
+  CPU      CPU use of the session's process tree (averaged over 5s)
+  MEM      resident memory of the process tree
+  MAIL     unread messages in the agent's mailbox
+  ACTIVE   time since the session last produced output
+
+Agents are sorted by output volume by default. Press Ctrl-C to exit.
+
+Examples:
+  gt top                     # Refresh every second, busiest first
+  gt top --sort cpu          # Heaviest processes first
+  gt top --sort mail         # Biggest mail backlog first
+  gt top --rig gastown       # One rig's agents
+  gt top --once              # Print one frame and exit`,
+	RunE: runTop,
+}
+
+func init() {
+	topCmd.Flags().DurationVar(&topInterval, "interval", time.Second, "Refresh interval")
+	topCmd.Flags().StringVar(&topSort, "sort", "output", "Sort by: output, cpu, mem, mail")
+	topCmd.Flags().StringVar(&topRig, "rig", "", "Only show agents in this rig")
+	topCmd.Flags().BoolVar(&topOnce, "once", false, "Print a single frame and exit")
+	rootCmd.AddCommand(topCmd)
+}
+
+// topRow is one agent's line in gt top.
+type topRow struct {
+	Address  string
+	Session  string
+	Output   int // New pane lines within topOutputWindow
+	Usage    *util.ResourceUsage
+	Unread   int
+	Activity time.Time
+}
+
+// paneOutputTracker measures new pane output between successive captures.
+type paneOutputTracker struct {
+	prev   map[string][]string
+	counts map[string][]timedCount
+}
+
+type timedCount struct {
+	at    time.Time
+	lines int
+}
+
+func newPaneOutputTracker() *paneOutputTracker {
+	return &paneOutputTracker{prev: make(map[string][]string), counts: make(map[string][]timedCount)}
+}
+
+// observe records a capture of session taken at now and returns the number
+// of new lines seen within window. The first capture of a session is the
+// baseline and counts nothing.
+func (p *paneOutputTracker) observe(session string, lines []string, now time.Time, window time.Duration) int {
+	if prev, ok := p.prev[session]; ok {
+		if n := countNewLines(prev, lines); n > 0 {
+			p.counts[session] = append(p.counts[session], timedCount{at: now, lines: n})
+		}
+	}
+	p.prev[session] = lines
+
+	kept := p.counts[session][:0]
+	total := 0
+	for _, c := range p.counts[session] {
+		if now.Sub(c.at) < window {
+			kept = append(kept, c)
+			total += c.lines
+		}
+	}
+	p.counts[session] = kept
+	return total
+}
+
+// countNewLines counts non-blank lines in cur that were not in prev,
+// treating each capture as a multiset. This counts both scrolled-in output
+// and lines redrawn in place (spinners, status bars, streaming text).
+func countNewLines(prev, cur []string) int {
+	seen := make(map[string]int, len(prev))
+	for _, l := range prev {
+		seen[strings.TrimRight(l, " ")]++
+	}
+	n := 0
+	for _, l := range cur {
+		l = strings.TrimRight(l, " ")
+		if l == "" {
+			continue
+		}
+		if seen[l] > 0 {
+			seen[l]--
+			continue
+		}
+		n++
+	}
+	return n
+}
+
+// sortTopRows orders rows by the given key, busiest first, breaking ties
+// by address.
+func sortTopRows(rows []*topRow, key string) {
+	metric := func(r *topRow) float64 {
+		switch key {
+		case "cpu":
+			if r.Usage != nil {
+				return r.Usage.CPUPercent
+			}
+		case "mem":
+			if r.Usage != nil {
+				return float64(r.Usage.RSSBytes)
+			}
+		case "mail":
+			return float64(r.Unread)
+		default:
+			return float64(r.Output)
+		}
+		return 0
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		mi, mj := metric(rows[i]), metric(rows[j])
+		if mi != mj {
+			return mi > mj
+		}
+		return rows[i].Address < rows[j].Address
+	})
+}
+
+func runTop(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	switch topSort {
+	case "output", "cpu", "mem", "mail":
+	default:
+		return fmt.Errorf("invalid --sort %q (want output, cpu, mem, or mail)", topSort)
+	}
+	if topInterval < 100*time.Millisecond {
+		return fmt.Errorf("--interval must be at least 100ms")
+	}
+
+	t := tmux.NewTmux()
+	sampler := util.NewSessionSampler(t, topCPUWindow)
+	tracker := newPaneOutputTracker()
+	router := mail.NewRouter(townRoot)
+	unread := make(map[string]int)
+	var lastMail time.Time
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	ticker := time.NewTicker(topInterval)
+	defer ticker.Stop()
+	for {
+		now := time.Now()
+		rows, err := collectTopRows(t, sampler, tracker, now)
+		if err != nil {
+			return err
+		}
+		if now.Sub(lastMail) >= topMailEvery {
+			for _, r := range rows {
+				if mb, err := router.GetMailbox(r.Address); err == nil {
+					if _, n, err := mb.Count(); err == nil {
+						unread[r.Address] = n
+					}
+				}
+			}
+			lastMail = now
+		}
+		for _, r := range rows {
+			r.Unread = unread[r.Address]
+		}
+		sortTopRows(rows, topSort)
+
+		if topOnce {
+			renderTop(rows, now)
+			return nil
+		}
+		fmt.Print("\033[H\033[2J")
+		renderTop(rows, now)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// collectTopRows gathers output volume, resource usage, and last activity
+// for every running agent session.
+func collectTopRows(t *tmux.Tmux, sampler *util.SessionSampler, tracker *paneOutputTracker, now time.Time) ([]*topRow, error) {
+	sessions, err := t.ListSessions()
+	if err != nil {
+		return nil, fmt.Errorf("listing sessions: %w", err)
+	}
+
+	var rows []*topRow
+	var names []string
+	for _, name := range sessions {
+		identity, err := session.ParseSessionName(name)
+		if err != nil {
+			continue
+		}
+		if topRig != "" && identity.Rig != topRig {
+			continue
+		}
+		rows = append(rows, &topRow{Address: identity.Address(), Session: name})
+		names = append(names, name)
+	}
+
+	// Panes and activity times come from one batched call each rather than
+	// two tmux calls per agent.
+	captures := t.CapturePanes(names, topCaptureLines)
+	activeAt := make(map[string]time.Time)
+	if infos, err := t.ListSessionInfos(); err == nil {
+		for _, info := range infos {
+			activeAt[info.Name] = info.ActivityAt
+		}
+	}
+	for _, row := range rows {
+		if out, ok := captures[row.Session]; ok {
+			var lines []string
+			if out != "" {
+				lines = strings.Split(out, "\n")
+			}
+			row.Output = tracker.observe(row.Session, lines, now, topOutputWindow)
+		}
+		row.Activity = activeAt[row.Session]
+	}
+
+	// Resource sampling failures leave Usage unset rather than stopping top.
+	if usage, err := sampler.Sample(names); err == nil {
+		for _, r := range rows {
+			if u, ok := usage[r.Session]; ok {
+				r.Usage = &u
+			}
+		}
+	}
+	return rows, nil
+}
+
+func renderTop(rows []*topRow, now time.Time) {
+	fmt.Printf("%s  %s\n\n", style.Bold.Render(fmt.Sprintf("gt top — %d agent(s)", len(rows))),
+		style.Dim.Render(fmt.Sprintf("%s  sorted by %s", now.Format("15:04:05"), topSort)))
+	if len(rows) == 0 {
+		fmt.Printf("%s No agent sessions running\n", style.Dim.Render("○"))
+		return
+	}
+
+	width := len("AGENT")
+	for _, r := range rows {
+		if len(r.Address) > width {
+			width = len(r.Address)
+		}
+	}
+	fmt.Printf("%s\n", style.Bold.Render(fmt.Sprintf("%-*s  %7s  %6s  %7s  %5s  %7s", width, "AGENT", "OUTPUT", "CPU", "MEM", "MAIL", "ACTIVE")))
+
+	for _, r := range rows {
+		cpu, mem := "-", "-"
+		if r.Usage != nil {
+			cpu = fmt.Sprintf("%.0f%%", r.Usage.CPUPercent)
+			mem = fmt.Sprintf("%dMB", r.Usage.RSSMB())
+		}
+		output := fmt.Sprintf("%7d", r.Output)
+		if r.Output == 0 {
+			output = style.Dim.Render(output)
+		}
+		mailStr := fmt.Sprintf("%5d", r.Unread)
+		if r.Unread == 0 {
+			mailStr = style.Dim.Render(mailStr)
+		} else if r.Unread >= 10 {
+			mailStr = style.Warning.Render(mailStr)
+		}
+
+		info := activity.Calculate(r.Activity)
+		active := fmt.Sprintf("%7s", info.FormattedAge)
+		switch info.ColorClass {
+		case activity.ColorGreen:
+			active = style.Success.Render(active)
+		case activity.ColorYellow:
+			active = style.Warning.Render(active)
+		case activity.ColorRed:
+			active = style.Error.Render(active)
+		default:
+			active = style.Dim.Render(active)
+		}
+
+		fmt.Printf("%-*s  %s  %6s  %7s  %s  %s\n", width, r.Address, output, cpu, mem, mailStr, active)
+	}
+}
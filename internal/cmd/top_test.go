@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+func TestCountNewLines(t *testing.T) {
+	tests := []struct {
+		name      string
+		prev, cur []string
+		want      int
+	}{
+		{"unchanged", []string{"a", "b"}, []string{"a", "b"}, 0},
+		{"scrolled", []string{"a", "b", "c"}, []string{"b", "c", "d", "e"}, 2},
+		{"redrawn in place", []string{"a", "⠋ Thinking"}, []string{"a", "⠙ Thinking"}, 1},
+		{"blank lines ignored", []string{"a"}, []string{"a", "", "   "}, 0},
+		{"trailing spaces ignored", []string{"a   "}, []string{"a"}, 0},
+		{"repeated lines counted", []string{"ok"}, []string{"ok", "ok", "ok"}, 2},
+	}
+	for _, tt := range tests {
+		if got := countNewLines(tt.prev, tt.cur); got != tt.want {
+			t.Errorf("%s: countNewLines() = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestPaneOutputTracker(t *testing.T) {
+	p := newPaneOutputTracker()
+	t0 := time.Now()
+	window := 10 * time.Second
+
+	if got := p.observe("gt-nux", []string{"a"}, t0, window); got != 0 {
+		t.Errorf("baseline capture = %d, want 0", got)
+	}
+	if got := p.observe("gt-nux", []string{"a", "b", "c"}, t0.Add(time.Second), window); got != 2 {
+		t.Errorf("after 2 new lines = %d, want 2", got)
+	}
+	if got := p.observe("gt-nux", []string{"a", "b", "c", "d"}, t0.Add(2*time.Second), window); got != 3 {
+		t.Errorf("within window = %d, want 3", got)
+	}
+	// The first burst ages out of the window.
+	if got := p.observe("gt-nux", []string{"a", "b", "c", "d"}, t0.Add(11*time.Second), window); got != 1 {
+		t.Errorf("after window = %d, want 1", got)
+	}
+}
+
+func TestSortTopRows(t *testing.T) {
+	rows := []*topRow{
+		{Address: "gastown/witness", Output: 5, Unread: 0, Usage: &util.ResourceUsage{CPUPercent: 80}},
+		{Address: "mayor", Output: 20, Unread: 3},
+		{Address: "gastown/polecats/nux", Output: 5, Unread: 12, Usage: &util.ResourceUsage{CPUPercent: 10}},
+	}
+
+	sortTopRows(rows, "output")
+	if rows[0].Address != "mayor" || rows[1].Address != "gastown/polecats/nux" {
+		t.Errorf("by output = %s, %s, %s", rows[0].Address, rows[1].Address, rows[2].Address)
+	}
+	sortTopRows(rows, "cpu")
+	if rows[0].Address != "gastown/witness" || rows[2].Address != "mayor" {
+		t.Errorf("by cpu = %s, %s, %s", rows[0].Address, rows[1].Address, rows[2].Address)
+	}
+	sortTopRows(rows, "mail")
+	if rows[0].Address != "gastown/polecats/nux" {
+		t.Errorf("by mail = %s, %s, %s", rows[0].Address, rows[1].Address, rows[2].Address)
+	}
+}
@@ -0,0 +1,306 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/backup"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/townbundle"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var (
+	townExportOutput string
+	townExportClosed bool
+	townImportMaps   []string
+	townImportDryRun bool
+)
+
+var townExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Write the town's logical state to a portable bundle",
+	Long: `Write the town's logical state to a bundle that 'gt town import' can replay
+into a town on another machine.
+
+Unlike 'gt backup', which copies files (including raw Dolt databases), a
+bundle carries the state itself as JSON:
+
+  - rigs (git URL, default branch, beads prefix, crew names)
+  - every live bead in the town and rig databases: agents and their hooks,
+    hooked and in-progress work, mail, convoys, and molecules with their
+    wisps (closed beads too with --closed)
+  - town and rig settings and plugins
+
+Absolute paths are recorded relative to the old town root and home
+directory so import can rewrite them for the new location. Git checkouts
+are not carried; import re-clones them.
+
+The Dolt server must be running.
+
+Examples:
+  gt town export                       # ./gt-export-<town>-<time>.json.gz
+  gt town export -o ~/move/town.json.gz
+  gt town export --closed              # include closed history`,
+	Args: cobra.NoArgs,
+	RunE: runTownExport,
+}
+
+var townImportCmd = &cobra.Command{
+	Use:   "import <bundle>",
+	Short: "Replay a town bundle into this town",
+	Long: `Replay a bundle written by 'gt town export' into the current town.
+
+Install the new town first ('gt install <path>', then 'gt dolt start'), and
+run the import from inside it. Import then:
+
+  1. adds each rig in the bundle that isn't registered yet (gt rig add,
+     with the same beads prefix) and re-creates its crew workspaces
+  2. writes the bundle's settings and plugins
+  3. re-creates every bead under its original ID, with its status,
+     assignee, labels, agent state, and dependencies — so hooks, mail, and
+     in-flight molecules pick up where they left off
+
+The old town root and home directory are rewritten to this town's root and
+your home directory everywhere they appear. Add more rewrites with --map.
+Beads that already exist are updated from the bundle, so an interrupted
+import can simply be re-run. Dependencies on beads the bundle left out
+(closed work, unless exported with --closed) are skipped with a
+warning.
+
+Polecat worktrees don't travel: work hooked to a polecat stays hooked to
+it. Once the rig is up, re-sling that work to start it on this machine.
+
+Examples:
+  gt town import gt-export-hq-20261017-093000.json.gz
+  gt town import town.json.gz --map /mnt/src=/Users/me/src
+  gt town import town.json.gz --dry-run`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTownImport,
+}
+
+func init() {
+	townExportCmd.Flags().StringVarP(&townExportOutput, "output", "o", "", "Output file (default: ./gt-export-<town>-<time>.json.gz)")
+	townExportCmd.Flags().BoolVar(&townExportClosed, "closed", false, "Include closed beads")
+	townImportCmd.Flags().StringArrayVar(&townImportMaps, "map", nil, "Rewrite an absolute path prefix (old=new), can be repeated")
+	townImportCmd.Flags().BoolVarP(&townImportDryRun, "dry-run", "n", false, "Show what would be imported without changing anything")
+
+	townCmd.AddCommand(townExportCmd)
+	townCmd.AddCommand(townImportCmd)
+}
+
+func runTownExport(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	b, err := townbundle.Export(townRoot, townbundle.ExportOptions{IncludeClosed: townExportClosed})
+	if err != nil {
+		return err
+	}
+
+	out := townExportOutput
+	if out == "" {
+		name := b.Town
+		if name == "" {
+			name = filepath.Base(townRoot)
+		}
+		out = fmt.Sprintf("gt-export-%s-%s.json.gz", name, b.CreatedAt.Format("20060102-150405"))
+	}
+	if abs, err := filepath.Abs(out); err == nil {
+		out = abs
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(out), ".gt-export-*.tmp")
+	if err != nil {
+		return fmt.Errorf("creating bundle file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if err := townbundle.Write(tmp, b); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("writing bundle: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("writing bundle: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), out); err != nil {
+		return fmt.Errorf("writing bundle: %w", err)
+	}
+
+	fmt.Printf("%s Wrote %s\n", style.SuccessPrefix, out)
+	printBundleSummary(b)
+	fmt.Printf("\nOn the new machine: %s\n", style.Dim.Render("gt install <path> && cd <path> && gt dolt start && gt town import "+filepath.Base(out)))
+	return nil
+}
+
+func printBundleSummary(b *townbundle.Bundle) {
+	s := b.Summarize()
+	var rigs []string
+	for _, r := range b.Rigs {
+		rigs = append(rigs, r.Name)
+	}
+	if len(rigs) > 0 {
+		fmt.Printf("  Rigs:  %s\n", strings.Join(rigs, ", "))
+	}
+	fmt.Printf("  Beads: %d (%d agents, %d hooked, %d mail, %d molecule)\n", s.Issues, s.Agents, s.Hooked, s.Mail, s.Molecules)
+	fmt.Printf("  Files: %d\n", len(b.Files))
+}
+
+func runTownImport(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace (run 'gt install' first): %w", err)
+	}
+	b, err := townbundle.ReadFile(args[0])
+	if err != nil {
+		return err
+	}
+	remap, err := townImportRemapper(b, townRoot, townImportMaps)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Importing town %s from %s (exported %s)\n", style.Bold.Render(b.Town), b.Host, b.CreatedAt.Local().Format("2006-01-02 15:04"))
+	printBundleSummary(b)
+	fmt.Printf("  Paths: %s → %s\n", b.TownRoot, townRoot)
+
+	if townImportDryRun {
+		for _, r := range townImportMissingRigs(townRoot, b.Rigs) {
+			fmt.Printf("  would add rig %s (%s, prefix %s)\n", r.Name, remap.Apply(r.GitURL), r.Prefix)
+		}
+		for _, f := range b.Files {
+			fmt.Printf("  would write %s\n", f.Path)
+		}
+		return nil
+	}
+
+	if err := enterTown(townRoot); err != nil {
+		return err
+	}
+
+	fmt.Printf("\nRigs...\n")
+	for _, r := range townImportMissingRigs(townRoot, b.Rigs) {
+		if err := townImportRig(cmd, r, remap); err != nil {
+			style.PrintWarning("%s: %v", r.Name, err)
+		}
+	}
+
+	written, err := townbundle.WriteFiles(townRoot, b.Files, remap)
+	if err != nil {
+		return fmt.Errorf("writing config files: %w", err)
+	}
+	fmt.Printf("  %s Wrote %d config files\n", style.SuccessPrefix, len(written))
+
+	fmt.Printf("\nBeads...\n")
+	var failed int
+	for _, db := range b.Databases {
+		name, workDir := "hq", townRoot
+		if db.Rig != "" {
+			name, workDir = db.Rig, filepath.Join(townRoot, db.Rig)
+			if _, err := os.Stat(workDir); err != nil {
+				style.PrintWarning("%s: rig not present; skipping %d beads", db.Rig, len(db.Issues))
+				continue
+			}
+		}
+		if err := beads.EnsureCustomTypes(beads.ResolveBeadsDir(workDir)); err != nil {
+			style.PrintWarning("%s: %v", name, err)
+		}
+		res := townbundle.ImportIssues(townbundle.BeadsStore{B: beads.New(workDir)}, db.Issues, remap)
+		fmt.Printf("  %s %s: %d created, %d updated\n", style.SuccessPrefix, name, res.Created, res.Updated)
+		if len(res.Dangling) > 0 {
+			style.PrintWarning("%s: skipped %d dependencies on beads not in the bundle: %s",
+				name, len(res.Dangling), strings.Join(res.Dangling, ", "))
+		}
+		for _, e := range res.Errors {
+			style.PrintWarning("%s: %s", name, e)
+		}
+		failed += len(res.Errors)
+	}
+
+	fmt.Printf("\nNext steps:\n")
+	fmt.Printf("  gt doctor --fix   # repair anything machine-specific\n")
+	fmt.Printf("  gt up             # start the daemon and agents\n")
+	if failed > 0 {
+		return fmt.Errorf("%d beads did not import cleanly; re-run 'gt town import' to retry", failed)
+	}
+	return nil
+}
+
+// townImportRemapper maps the bundle's town root and home directory to
+// their counterparts here, plus any --map old=new pairs.
+func townImportRemapper(b *townbundle.Bundle, townRoot string, maps []string) (*townbundle.Remapper, error) {
+	pairs := map[string]string{b.TownRoot: townRoot}
+	if home, err := os.UserHomeDir(); err == nil && b.Home != "" {
+		pairs[b.Home] = home
+	}
+	for _, m := range maps {
+		from, to, ok := strings.Cut(m, "=")
+		if !ok || !filepath.IsAbs(from) || !filepath.IsAbs(to) {
+			return nil, fmt.Errorf("invalid --map %q: want /old/path=/new/path", m)
+		}
+		pairs[from] = to
+	}
+	return townbundle.NewRemapper(pairs), nil
+}
+
+// townImportMissingRigs returns the bundle's rigs not yet registered here.
+func townImportMissingRigs(townRoot string, rigs []backup.RigInfo) []backup.RigInfo {
+	registered := map[string]bool{}
+	if rigsConfig, err := config.LoadRigsConfig(constants.MayorRigsPath(townRoot)); err == nil {
+		for name := range rigsConfig.Rigs {
+			registered[name] = true
+		}
+	} else if !errors.Is(err, config.ErrNotFound) {
+		style.PrintWarning("loading rigs registry: %v", err)
+	}
+	var missing []backup.RigInfo
+	for _, r := range rigs {
+		if !registered[r.Name] {
+			missing = append(missing, r)
+		}
+	}
+	return missing
+}
+
+// townImportRig adds a rig with gt rig add, keeping its beads prefix so
+// imported bead IDs land in the right database, then re-creates its crew.
+func townImportRig(cmd *cobra.Command, r backup.RigInfo, remap *townbundle.Remapper) error {
+	gtPath, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	addArgs := []string{"rig", "add", r.Name, remap.Apply(r.GitURL)}
+	if r.Prefix != "" {
+		addArgs = append(addArgs, "--prefix", r.Prefix)
+	}
+	if r.DefaultBranch != "" {
+		addArgs = append(addArgs, "--branch", r.DefaultBranch)
+	}
+	add := exec.Command(gtPath, addArgs...) //nolint:gosec // G204: re-invoking gt with bundle values
+	add.Stdout = os.Stdout
+	add.Stderr = os.Stderr
+	if err := add.Run(); err != nil {
+		return fmt.Errorf("gt rig add: %w", err)
+	}
+
+	if len(r.Crew) == 0 {
+		return nil
+	}
+	prevRig := crewRig
+	crewRig = r.Name
+	err = runCrewAdd(cmd, r.Crew)
+	crewRig = prevRig
+	if err != nil {
+		return fmt.Errorf("creating crew: %w", err)
+	}
+	return nil
+}
@@ -41,7 +41,8 @@ func init() {
 var townCmd = &cobra.Command{
 	Use:   "town",
 	Short: "Town-level operations",
-	Long:  `Commands for town-level operations including session cycling.`,
+	Long:  `Commands for town-level operations: the per-user town registry (list, add,
+use), moving a town between machines (export, import), and session cycling.`,
 }
 
 var townNextCmd = &cobra.Command{
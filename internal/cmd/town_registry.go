@@ -0,0 +1,286 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// townFlag is the global --town flag: a registered town name or a path.
+var townFlag string
+
+var townListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List registered towns",
+	Long: `List the towns in your per-user registry (~/.config/gastown/towns.json).
+
+The current town (set with 'gt town use') is marked with *. The town the
+working directory is in, if any, is marked with (here).`,
+	Args: cobra.NoArgs,
+	RunE: runTownList,
+}
+
+var townAddCmd = &cobra.Command{
+	Use:   "add <name> [path]",
+	Short: "Register a town under a short name",
+	Long: `Register a town so commands can target it with --town <name>.
+
+Path defaults to the town containing the current directory. A peer town on
+another machine is registered by the URL of its 'gt dashboard'; peers are
+used by commands that aggregate across towns, such as 'gt doctor --all-towns'.
+
+Examples:
+  gt town add work
+  gt town add home ~/gt-home
+  gt town add buildbox http://buildbox:8080`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runTownAdd,
+}
+
+var townRemoveCmd = &cobra.Command{
+	Use:   "remove <name>",
+	Short: "Unregister a town (files are not touched)",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runTownRemove,
+}
+
+var townUseCmd = &cobra.Command{
+	Use:     "use <name>",
+	Aliases: []string{"switch"},
+	Short:   "Set the town used outside any town directory",
+	Long: `Set the current town. Commands run from a directory that isn't inside a
+town use the current town instead of failing with "not in a Gas Town
+workspace". Commands run inside a town still use that town, and --town
+always wins.
+
+Towns are registered automatically by 'gt install' and 'gt restore'; use
+'gt town add' for towns created before the registry existed.
+
+Examples:
+  gt town use work
+  gt town use home && gt status   # status of the home town, from anywhere
+  gt status --town work           # one-off, without changing the current town`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTownUse,
+}
+
+var townCurrentCmd = &cobra.Command{
+	Use:   "current",
+	Short: "Show which town commands will target",
+	Args:  cobra.NoArgs,
+	RunE:  runTownCurrent,
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&townFlag, "town", "", "Target a town by registered name or path (default: from current directory)")
+
+	townCmd.AddCommand(townListCmd)
+	townCmd.AddCommand(townAddCmd)
+	townCmd.AddCommand(townRemoveCmd)
+	townCmd.AddCommand(townUseCmd)
+	townCmd.AddCommand(townCurrentCmd)
+}
+
+// applyTownSelection points the process at the selected town before any
+// command runs. Precedence: --town, then the directory the command runs in,
+// then the registry's current town. Selections chdir to the town root (unless
+// already inside it) so cwd-based discovery and subprocesses agree on the town.
+func applyTownSelection() error {
+	ref := townFlag
+	if ref == "" {
+		// Agents and shell integration already pin a town; leave them alone.
+		if _, err := workspace.FindFromCwd(); err == nil {
+			return nil
+		}
+		if os.Getenv("GT_TOWN_ROOT") != "" || os.Getenv("GT_ROOT") != "" {
+			return nil
+		}
+		reg, err := workspace.LoadTownRegistry()
+		if err != nil || reg.Current == "" {
+			return nil
+		}
+		root, err := reg.Resolve(reg.Current)
+		if err != nil {
+			return nil
+		}
+		return enterTown(root)
+	}
+
+	reg, err := workspace.LoadTownRegistry()
+	if err != nil {
+		return err
+	}
+	root, err := reg.Resolve(ref)
+	if err != nil {
+		return err
+	}
+	return enterTown(root)
+}
+
+// enterTown makes townRoot the working town for this process.
+func enterTown(townRoot string) error {
+	if cwdRoot, err := workspace.FindFromCwd(); err != nil || filepath.Clean(cwdRoot) != filepath.Clean(townRoot) {
+		if err := os.Chdir(townRoot); err != nil {
+			return fmt.Errorf("entering town %s: %w", townRoot, err)
+		}
+	}
+	return os.Setenv("GT_TOWN_ROOT", townRoot)
+}
+
+// registerTown adds a newly created or restored town to the per-user
+// registry under name. Best-effort: a registry problem only warns, since the
+// town itself is fine.
+func registerTown(townRoot, name string) {
+	if name == "" {
+		return
+	}
+	reg, err := workspace.LoadTownRegistry()
+	if err != nil {
+		style.PrintWarning("loading town registry: %v", err)
+		return
+	}
+	if reg.NameFor(townRoot) != "" {
+		return
+	}
+	if _, err := reg.Register(name, townRoot); err != nil {
+		style.PrintWarning("%v; register this one with 'gt town add <name> %s'", err, townRoot)
+		return
+	}
+	if err := workspace.SaveTownRegistry(reg); err != nil {
+		style.PrintWarning("saving town registry: %v", err)
+		return
+	}
+	fmt.Printf("\n%s Registered town %s → %s\n", style.SuccessPrefix, name, townRoot)
+}
+
+func runTownList(cmd *cobra.Command, args []string) error {
+	reg, err := workspace.LoadTownRegistry()
+	if err != nil {
+		return err
+	}
+	if len(reg.Towns) == 0 {
+		fmt.Println("No towns registered. Use 'gt town add <name>' from inside a town.")
+		return nil
+	}
+
+	here, _ := workspace.FindFromCwd()
+	for _, name := range reg.Names() {
+		root := reg.Towns[name]
+		marker := " "
+		if name == reg.Current {
+			marker = "*"
+		}
+		suffix := ""
+		if here != "" && filepath.Clean(here) == filepath.Clean(root) {
+			suffix = style.Dim.Render(" (here)")
+		}
+		if workspace.IsPeerTown(root) {
+			suffix += style.Dim.Render(" (peer)")
+		} else if _, err := os.Stat(filepath.Join(root, workspace.PrimaryMarker)); err != nil {
+			suffix += style.Dim.Render(" (missing)")
+		}
+		fmt.Printf("%s %-16s %s%s\n", marker, name, root, suffix)
+	}
+	return nil
+}
+
+func runTownAdd(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	var root string
+	var err error
+	switch {
+	case len(args) == 2 && workspace.IsPeerTown(args[1]):
+		root = strings.TrimRight(args[1], "/")
+	case len(args) == 2:
+		root, err = workspace.FindOrError(args[1])
+	default:
+		root, err = workspace.FindFromCwdOrError()
+	}
+	if err != nil {
+		return err
+	}
+	if !workspace.IsPeerTown(root) {
+		if root, err = filepath.Abs(root); err != nil {
+			return err
+		}
+	}
+
+	reg, err := workspace.LoadTownRegistry()
+	if err != nil {
+		return err
+	}
+	if existing, ok := reg.Towns[name]; ok && filepath.Clean(existing) != root {
+		return fmt.Errorf("town %q already registered at %s (remove it first)", name, existing)
+	}
+	reg.Towns[name] = root
+	if reg.Current == "" {
+		reg.Current = name
+	}
+	if err := workspace.SaveTownRegistry(reg); err != nil {
+		return err
+	}
+	fmt.Printf("%s Registered town %s → %s\n", style.SuccessPrefix, name, root)
+	return nil
+}
+
+func runTownRemove(cmd *cobra.Command, args []string) error {
+	reg, err := workspace.LoadTownRegistry()
+	if err != nil {
+		return err
+	}
+	name := args[0]
+	if _, ok := reg.Towns[name]; !ok {
+		return fmt.Errorf("town %q is not registered", name)
+	}
+	delete(reg.Towns, name)
+	if reg.Current == name {
+		reg.Current = ""
+	}
+	if err := workspace.SaveTownRegistry(reg); err != nil {
+		return err
+	}
+	fmt.Printf("%s Unregistered town %s\n", style.SuccessPrefix, name)
+	return nil
+}
+
+func runTownUse(cmd *cobra.Command, args []string) error {
+	reg, err := workspace.LoadTownRegistry()
+	if err != nil {
+		return err
+	}
+	name := args[0]
+	if _, err := reg.Resolve(name); err != nil {
+		return err
+	}
+	if _, ok := reg.Towns[name]; !ok {
+		return fmt.Errorf("town %q is not registered (use 'gt town add' first)", name)
+	}
+	reg.Current = name
+	if err := workspace.SaveTownRegistry(reg); err != nil {
+		return err
+	}
+	fmt.Printf("%s Current town: %s (%s)\n", style.SuccessPrefix, name, reg.Towns[name])
+	return nil
+}
+
+func runTownCurrent(cmd *cobra.Command, args []string) error {
+	root, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return err
+	}
+	reg, err := workspace.LoadTownRegistry()
+	if err != nil {
+		return err
+	}
+	if name := reg.NameFor(root); name != "" {
+		fmt.Printf("%s (%s)\n", name, root)
+	} else {
+		fmt.Println(root)
+	}
+	return nil
+}
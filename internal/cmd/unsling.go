@@ -235,7 +235,9 @@ func runUnslingWith(cmd *cobra.Command, args []string, dryRun, force bool) error
 	}
 
 	// Log unhook event
-	_ = events.LogFeed(events.TypeUnhook, agentID, events.UnhookPayload(hookedBeadID))
+	payload := events.UnhookPayload(hookedBeadID)
+	payload["by"] = detectActor()
+	_ = events.LogFeed(events.TypeUnhook, agentID, payload)
 
 	fmt.Printf("%s Work removed from hook\n", style.Bold.Render("✓"))
 	fmt.Printf("  Agent %s hook cleared (was: %s)\n", agentID, hookedBeadID)
@@ -158,7 +158,7 @@ func runUp(cmd *cobra.Command, args []string) error {
 	// existing config. Errors are non-fatal — the town can run without lifecycle
 	// automation, it just won't have automated maintenance.
 	if err := daemon.EnsureLifecycleConfigFile(townRoot); err != nil {
-		fmt.Fprintf(os.Stderr, "Warning: could not configure lifecycle defaults: %v\n", err)
+		logger.Warn("could not configure lifecycle defaults", "err", err)
 	}
 
 	// Load daemon.json env vars so services (Dolt, etc.) use the right config.
@@ -901,7 +901,7 @@ const doltReadyTimeout = 10 * time.Second
 // expires, logs a warning and continues (graceful degradation). (gt-zou1n)
 func waitForDoltReady(townRoot string) {
 	if err := doltserver.WaitForReady(townRoot, doltReadyTimeout); err != nil {
-		fmt.Fprintf(os.Stderr, "Warning: %v (agents may see connection errors)\n", err)
+		logger.Warn("dolt server not ready; agents may see connection errors", "err", err)
 	}
 }
 
@@ -390,7 +390,7 @@ func targetToSessionName(target string) (string, error) {
 		return "", fmt.Errorf("invalid target: need dog name (e.g., deacon/dogs/alpha)")
 	case len(parts) == 3 && parts[0] == "deacon" && parts[1] == "dogs":
 		// deacon/dogs/alpha -> hq-dog-alpha
-		return fmt.Sprintf("hq-dog-%s", parts[2]), nil
+		return session.DogSessionName(parts[2]), nil
 	default:
 		prefix := session.DefaultPrefix
 		if len(parts) > 0 {
@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/notify"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var webhookTestEvent string
+
+var webhookCmd = &cobra.Command{
+	Use:     "webhook",
+	GroupID: GroupConfig,
+	Short:   "List and test webhook notifications",
+	Long: `Webhooks post selected town events to Slack, Discord, or any endpoint
+that accepts JSON. They are configured under "notifications" in
+settings/config.json:
+
+  "notifications": {
+    "webhooks": [
+      {"name": "ops", "url": "https://hooks.slack.com/services/...", "format": "slack"},
+      {"name": "pager", "url": "https://example.com/hook",
+       "events": ["session_death", "mass_death"],
+       "headers": {"Authorization": "Bearer ..."}, "retries": 5}
+    ]
+  }
+
+By default a webhook receives nudge circuit changes (a session that keeps
+failing nudges), agent deaths, stuck workers, molecule completions, and
+preflight warnings. "events" takes
+event types or patterns such as "merge_*". "template" is a Go text/template
+over the event (.Type, .Actor, .Timestamp, .Payload, .Summary, .Town).
+
+The daemon's webhooks patrol delivers new events every 30 seconds, retrying
+failures with exponential backoff.`,
+	RunE: requireSubcommand,
+}
+
+var webhookListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Show configured webhooks",
+	Args:  cobra.NoArgs,
+	RunE:  runWebhookList,
+}
+
+var webhookTestCmd = &cobra.Command{
+	Use:   "test <name>",
+	Short: "Send a sample event to a webhook",
+	Long: `Render a sample event with the webhook's format and template and post it,
+with the webhook's retries.
+
+Examples:
+  gt webhook test ops
+  gt webhook test ops --event stuck_worker`,
+	Args: cobra.ExactArgs(1),
+	RunE: runWebhookTest,
+}
+
+func init() {
+	webhookTestCmd.Flags().StringVar(&webhookTestEvent, "event", events.TypeSessionDeath, "Event type to simulate")
+	webhookCmd.AddCommand(webhookListCmd)
+	webhookCmd.AddCommand(webhookTestCmd)
+	rootCmd.AddCommand(webhookCmd)
+}
+
+// loadNotificationsConfig loads and validates the town's webhook config.
+func loadNotificationsConfig() (string, *config.NotificationsConfig, error) {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return "", nil, fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	settings, err := config.LoadOrCreateTownSettings(config.TownSettingsPath(townRoot))
+	if err != nil {
+		return "", nil, fmt.Errorf("loading town settings: %w", err)
+	}
+	if err := settings.Notifications.Validate(); err != nil {
+		return "", nil, err
+	}
+	return townRoot, settings.Notifications, nil
+}
+
+func runWebhookList(cmd *cobra.Command, args []string) error {
+	_, cfg, err := loadNotificationsConfig()
+	if err != nil {
+		return err
+	}
+	if !cfg.Enabled() {
+		fmt.Println("No webhooks configured (see gt webhook --help)")
+		return nil
+	}
+	for _, w := range cfg.Webhooks {
+		evs := "default events"
+		if len(w.Events) > 0 {
+			evs = fmt.Sprint(w.Events)
+		}
+		fmt.Printf("%s %s %s\n", style.Bold.Render(w.Name), style.Dim.Render("("+w.GetFormat()+")"), w.URL)
+		fmt.Printf("    %s, %d retries\n", evs, w.GetRetries())
+	}
+	return nil
+}
+
+func runWebhookTest(cmd *cobra.Command, args []string) error {
+	townRoot, cfg, err := loadNotificationsConfig()
+	if err != nil {
+		return err
+	}
+	w, ok := cfg.Find(args[0])
+	if !ok {
+		return fmt.Errorf("no webhook named %q", args[0])
+	}
+
+	e := sampleWebhookEvent(webhookTestEvent)
+	if !notify.Matches(w, e.Type) {
+		style.PrintWarning("%s does not subscribe to %s; sending anyway", w.Name, e.Type)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+	if err := notify.NewSender().Send(ctx, w, e, filepath.Base(townRoot)); err != nil {
+		return fmt.Errorf("sending to %s: %w", w.Name, err)
+	}
+	fmt.Printf("%s Sent %s test event to %s\n", style.SuccessPrefix, e.Type, w.Name)
+	return nil
+}
+
+// sampleWebhookEvent builds a plausible event of the given type for testing.
+func sampleWebhookEvent(eventType string) events.Event {
+	e := events.Event{
+		Timestamp:  time.Now().UTC().Format(time.RFC3339),
+		Source:     "gt",
+		Type:       eventType,
+		Actor:      "gastown/polecats/example",
+		Visibility: events.VisibilityFeed,
+	}
+	switch eventType {
+	case events.TypeNudgeFailed:
+		e.Payload = events.NudgeFailedPayload("gt-gastown-example", "test: session not found", 3)
+	case events.TypeNudgeCircuit:
+		e.Payload = events.NudgeCircuitPayload("gt-gastown-example", "open", "test: session not found", 5)
+	case events.TypeStuckWorker:
+		e.Payload = events.StuckWorkerPayload("gastown", "example", "test", "gt webhook test")
+	case events.TypePaneAnomaly:
+		e.Payload = events.PaneAnomalyPayload("gastown", "gastown/polecats/example", "gt-gastown-example", "rate_limit", "API Error: Rate limit reached")
+	case events.TypePermissionAnswer:
+		e.Payload = events.PermissionAnswerPayload("gastown", "gastown/polecats/example", "Bash", "yes")
+	case events.TypeMoleculeComplete:
+		e.Payload = events.MoleculeCompletePayload("gt-mol-example", 4)
+	case events.TypePreflightWarning:
+		e.Payload = events.PreflightWarningPayload("gt-example", "polecat/example", "test", "this is a test warning")
+	case events.TypeCrashLoop:
+		e.Payload = events.CrashLoopPayload("gastown/witness", 5, "15m0s")
+	case events.TypeHookDetached:
+		e.Payload = events.HookDetachedPayload("gt-example", "gastown/polecats/example", "stale-hook", "no progress 4h0m0s")
+	case events.TypeBeadChanged:
+		e.Payload = events.BeadChangedPayload("gastown", "gt-example", "closed", "closed", "in_progress", "Example bead", "gastown/polecats/example")
+	default:
+		e.Payload = map[string]interface{}{"agent": e.Actor, "reason": "this is a test notification"}
+	}
+	return e
+}
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/witness"
+)
+
+var witnessAnomaliesRun bool
+
+var witnessAnomaliesCmd = &cobra.Command{
+	Use:   "anomalies <rig>",
+	Short: "Scan the rig's agent panes for anomalies",
+	Long: `Capture each running polecat and crew pane in a rig and look for signs
+an agent needs help, so nobody has to read the panes:
+
+  looping            the same output block repeated 3+ times in a row
+  stack_trace        a stack trace of 8+ frames appeared
+  permission_prompt  a permission or y/n prompt has waited since the last scan
+  rate_limit         a rate-limit message appeared
+
+Each capture is compared with the one from the previous scan, so only new
+output is flagged. The daemon's witness_rules patrol scans every 2 minutes
+and logs a pane_anomaly event per finding (at most once per kind per agent
+every 30m), which webhooks receive by default.
+
+A waiting permission prompt is handled per the rig's witness.permissions
+policy in settings/config.json. Tools are matched by name ("Bash", "Edit",
+"mcp__github__*"); a generic y/n gate is "y/n". Deny is checked first. A
+prompt for a tool in neither list is mailed to the mayor with the captured
+prompt:
+
+  "witness": {"permissions": {"allow": ["Read", "Edit", "Bash"], "deny": ["WebFetch"]}}
+
+By default this is a dry run against the last recorded captures. Use --run
+to record the captures, act on prompts and log events now.
+
+Examples:
+  gt witness anomalies greenplace
+  gt witness anomalies greenplace --run`,
+	Args: cobra.ExactArgs(1),
+	RunE: runWitnessAnomalies,
+}
+
+func init() {
+	witnessAnomaliesCmd.Flags().BoolVar(&witnessAnomaliesRun, "run", false, "Record captures, answer or escalate prompts, and log events (respects cooldowns)")
+	witnessCmd.AddCommand(witnessAnomaliesCmd)
+}
+
+func runWitnessAnomalies(cmd *cobra.Command, args []string) error {
+	rigName := args[0]
+	townRoot, _, err := getRig(rigName)
+	if err != nil {
+		return err
+	}
+
+	result := witness.DetectAnomalies(townRoot, rigName, !witnessAnomaliesRun)
+
+	fmt.Printf("%s Pane anomalies for %s: %d session(s) checked, %d found\n",
+		style.Bold.Render("🔍"), rigName, result.Checked, len(result.Found))
+	for _, a := range result.Found {
+		line := fmt.Sprintf("%s on %s: %s", a.Kind, a.Agent, a.Detail)
+		if a.Action != "" {
+			line += " → " + a.Action
+		}
+		if a.Skipped != "" {
+			fmt.Printf("  %s %s %s\n", style.Dim.Render("○"), line, style.Dim.Render("("+a.Skipped+")"))
+		} else {
+			fmt.Printf("  %s %s\n", style.WarningPrefix, line)
+		}
+	}
+	for _, e := range result.Errors {
+		style.PrintWarning("%v", e)
+	}
+	return nil
+}
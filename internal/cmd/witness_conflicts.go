@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/witness"
+)
+
+var witnessConflictsNotify bool
+
+var witnessConflictsCmd = &cobra.Command{
+	Use:   "conflicts <rig>",
+	Short: "Trial-merge in-flight agent branches to find conflicts early",
+	Long: `Find agent branches on a collision course before the merge queue does.
+
+For each polecat and crew worktree in the rig, lists the files its branch
+changes relative to the rig's default branch. Every pair of branches that
+touch a common file is trial-merged in a scratch worktree (nothing is
+committed, and no agent worktree is touched); pairs whose merge conflicts
+are reported along with the conflicting files.
+
+With --notify, both owning agents are mailed about each conflict. A
+collision is mailed once, and again only if its conflicting files change or
+a day passes. The daemon's merge_watch patrol (opt-in, every 10 minutes)
+runs this with --notify for each witness-patrolled rig.
+
+Examples:
+  gt witness conflicts greenplace
+  gt witness conflicts greenplace --notify`,
+	Args: cobra.ExactArgs(1),
+	RunE: runWitnessConflicts,
+}
+
+func init() {
+	witnessConflictsCmd.Flags().BoolVar(&witnessConflictsNotify, "notify", false, "Mail the owners of conflicting branches")
+	witnessCmd.AddCommand(witnessConflictsCmd)
+}
+
+func runWitnessConflicts(cmd *cobra.Command, args []string) error {
+	rigName := args[0]
+	townRoot, _, err := getRig(rigName)
+	if err != nil {
+		return err
+	}
+
+	result, err := witness.CheckCollisions(townRoot, rigName, !witnessConflictsNotify)
+	if err != nil {
+		return fmt.Errorf("checking branch collisions: %w", err)
+	}
+
+	conflicts := 0
+	for _, c := range result.Collisions {
+		if len(c.Conflicts) > 0 {
+			conflicts++
+		}
+	}
+	fmt.Printf("%s Merge watch for %s: %d branch(es) ahead of %s, %d overlapping pair(s), %d conflict(s)\n",
+		style.Bold.Render("⚔"), rigName, result.Branches, result.Target, len(result.Collisions), conflicts)
+	for _, c := range result.Collisions {
+		line := fmt.Sprintf("%s (%s) ↔ %s (%s)", c.A.Address(rigName), c.A.Branch, c.B.Address(rigName), c.B.Branch)
+		switch {
+		case c.Error != nil:
+			fmt.Printf("  %s %s: %v\n", style.ErrorPrefix, line, c.Error)
+		case len(c.Conflicts) == 0:
+			fmt.Printf("  %s %s %s\n", style.Dim.Render("○"), line,
+				style.Dim.Render(fmt.Sprintf("(merges cleanly; %d shared file(s))", len(c.Overlap))))
+		default:
+			note := "notified"
+			if c.Skipped != "" {
+				note = c.Skipped
+			}
+			fmt.Printf("  %s %s %s\n", style.Warning.Render("⚠"), line, style.Dim.Render("("+note+")"))
+			fmt.Printf("      %s\n", strings.Join(c.Conflicts, ", "))
+		}
+	}
+	for _, e := range result.Errors {
+		style.PrintWarning("%v", e)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/witness"
+)
+
+var witnessRulesRun bool
+
+var witnessRulesCmd = &cobra.Command{
+	Use:   "rules <rig>",
+	Short: "Evaluate the rig's witness rules",
+	Long: `Evaluate a rig's witness rules against its agents and show what matches.
+
+Rules are defined under "witness.rules" in <rig>/settings/config.json. Each
+rule has conditions on agent state, idle time, consecutive nudge failures,
+hooked bead labels, or time without progress on hooked work, and an
+action: mail_mayor, restart, escalate (raise the hooked bead's priority
+one level), or detach. The daemon's witness_rules patrol evaluates them
+every 2 minutes; a rule fires at most once per cooldown (default 30m) per
+agent.
+
+  "witness": {
+    "rules": [
+      {"name": "idle-p0", "when": {"idle_for": "20m", "labels": ["p0"]}, "do": "mail_mayor"},
+      {"name": "unreachable", "when": {"nudge_failures": 3}, "do": "restart", "cooldown": "1h"},
+      {"name": "stale-hook", "when": {"no_progress_for": "4h"}, "do": "detach"}
+    ]
+  }
+
+no_progress_for catches work rotting on a forgotten hook: it matches when
+the agent has hooked work but no commit in its worktree, no update to the
+hooked bead, and no session activity for that long. detach unhooks the
+bead and returns it to the ready queue (open, unassigned), mails the
+mayor, and emits a hook_detached event (which webhooks receive by default).
+
+By default this is a dry run. Use --run to perform the actions now.
+
+Examples:
+  gt witness rules greenplace
+  gt witness rules greenplace --run`,
+	Args: cobra.ExactArgs(1),
+	RunE: runWitnessRules,
+}
+
+func init() {
+	witnessRulesCmd.Flags().BoolVar(&witnessRulesRun, "run", false, "Perform the actions of matching rules (respects cooldowns)")
+	witnessCmd.AddCommand(witnessRulesCmd)
+}
+
+func runWitnessRules(cmd *cobra.Command, args []string) error {
+	rigName := args[0]
+	townRoot, _, err := getRig(rigName)
+	if err != nil {
+		return err
+	}
+
+	result, err := witness.EvaluateRules(witness.DefaultBdCli(), townRoot, rigName, !witnessRulesRun)
+	if err != nil {
+		return fmt.Errorf("evaluating witness rules: %w", err)
+	}
+
+	fmt.Printf("%s Witness rules for %s: %d agent(s) checked, %d match(es)\n",
+		style.Bold.Render("⚖"), rigName, result.Checked, len(result.Fired))
+	for _, f := range result.Fired {
+		line := fmt.Sprintf("%s → %s on %s (%s)", f.Rule.Name, f.Rule.Do, f.Agent.Address(rigName), f.Reason)
+		switch {
+		case f.Error != nil:
+			fmt.Printf("  %s %s: %v\n", style.ErrorPrefix, line, f.Error)
+		case f.Skipped != "":
+			fmt.Printf("  %s %s %s\n", style.Dim.Render("○"), line, style.Dim.Render("("+f.Skipped+")"))
+		default:
+			fmt.Printf("  %s %s\n", style.SuccessPrefix, line)
+		}
+	}
+	for _, e := range result.Errors {
+		style.PrintWarning("%v", e)
+	}
+	return nil
+}
@@ -0,0 +1,475 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/gofrs/flock"
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/dog"
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// Worktree gc command flags
+var (
+	worktreeGCDryRun bool
+	worktreeGCJSON   bool
+	worktreeGCRig    string
+)
+
+var worktreeGCCmd = &cobra.Command{
+	Use:   "gc",
+	Short: "Remove orphaned git worktrees across the town",
+	Long: `Find every git worktree under the town and remove the orphaned ones.
+
+Worktrees are enumerated from each rig's shared repo (.repo.git) and mayor
+clone, then cross-referenced with live agent sessions, hooked and in-progress
+work, and the agents that own them. A worktree is removed only when it is
+provably orphaned:
+
+  - Its directory is gone (git's stale record is pruned), or
+  - Its owner no longer exists: a polecat with no open agent bead, a dog no
+    longer in the kennel, a cross-rig worktree whose crew member is gone,
+    or a land worktree with no land in progress
+  - And its owner has no running session and nothing hooked or in progress
+  - And it holds no uncommitted changes or commits that exist on no remote
+    (Gas Town runtime files such as .beads/ and .claude/ aside)
+
+Everything else is kept and reported with the reason: refinery worktrees,
+idle polecats (their sandbox is kept for reuse; use gt polecat nuke),
+locked worktrees, the current directory, and locations gt does not manage.
+Branches are never deleted; gt polecat gc prunes polecat branches.
+
+Examples:
+  gt worktree gc --dry-run     # Show what would be removed, and why
+  gt worktree gc               # Remove orphaned worktrees
+  gt worktree gc --rig gastown # Only one rig's worktrees
+  gt worktree gc --json        # Machine-readable report`,
+	Args: cobra.NoArgs,
+	RunE: runWorktreeGC,
+}
+
+func init() {
+	worktreeGCCmd.Flags().BoolVar(&worktreeGCDryRun, "dry-run", false, "Show what would be removed without removing")
+	worktreeGCCmd.Flags().BoolVar(&worktreeGCJSON, "json", false, "Output as JSON")
+	worktreeGCCmd.Flags().StringVar(&worktreeGCRig, "rig", "", "Only consider worktrees of this rig")
+	worktreeCmd.AddCommand(worktreeGCCmd)
+}
+
+// Worktree kinds, from where the worktree lives.
+const (
+	worktreeKindPolecat  = "polecat"
+	worktreeKindCrew     = "crew"
+	worktreeKindDog      = "dog"
+	worktreeKindRefinery = "refinery"
+	worktreeKindLand     = "land"
+	worktreeKindOther    = "other"
+)
+
+// worktreeFacts is what gc learned about a worktree and its owner.
+type worktreeFacts struct {
+	Missing bool   // Directory is gone; only git's record remains
+	Locked  bool   // Locked with git worktree lock
+	Session bool   // Owner's session is running
+	Hook    string // Owner's hooked or in-progress bead
+	Owned   string // Why the owner may still want it; "" once the owner is provably gone
+	Gone    string // How the owner is known to be gone
+	Work    string // Uncommitted or unpushed work, "" if none
+}
+
+// assessWorktree decides whether a worktree is provably orphaned and says
+// why, or why it must be kept. Anything uncertain keeps the worktree.
+func assessWorktree(f worktreeFacts) (bool, string) {
+	switch {
+	case f.Locked:
+		return false, "locked"
+	case f.Missing:
+		return true, "directory missing"
+	case f.Session:
+		return false, "owner's session is running"
+	case f.Hook != "":
+		return false, "owner has " + f.Hook + " on its hook"
+	case f.Owned != "":
+		return false, f.Owned
+	case f.Work != "":
+		return false, f.Work
+	}
+	return true, f.Gone
+}
+
+// worktreeGCEntry is one worktree considered by gt worktree gc.
+type worktreeGCEntry struct {
+	Path     string `json:"path"`
+	Rig      string `json:"rig"`
+	Branch   string `json:"branch,omitempty"`
+	Kind     string `json:"kind"`
+	Owner    string `json:"owner,omitempty"` // Agent address, if any
+	Orphaned bool   `json:"orphaned"`
+	Removed  bool   `json:"removed"`
+	Reason   string `json:"reason"` // Why it is orphaned, or why it was kept
+	Error    string `json:"error,omitempty"`
+
+	repo *git.Git // Repo the worktree belongs to
+}
+
+// classifyWorktree names a worktree's kind and owning agent from its path,
+// relative to the town root. rigs are the town's rig names, used to find the
+// source rig of a cross-rig crew worktree (<rig>/crew/<source-rig>-<name>).
+func classifyWorktree(townRoot, path string, rigs []string) (kind, owner string) {
+	rel, err := filepath.Rel(townRoot, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return worktreeKindOther, ""
+	}
+	parts := strings.Split(filepath.ToSlash(rel), "/")
+	switch {
+	case len(parts) == 4 && parts[0] == "deacon" && parts[1] == "dogs":
+		return worktreeKindDog, "deacon/dogs/" + parts[2]
+	case len(parts) == 2 && parts[1] == ".land-worktree":
+		return worktreeKindLand, ""
+	case len(parts) == 3 && parts[1] == "refinery" && parts[2] == "rig":
+		return worktreeKindRefinery, parts[0] + "/refinery"
+	case len(parts) >= 3 && parts[1] == "polecats":
+		return worktreeKindPolecat, parts[0] + "/polecats/" + parts[2]
+	case len(parts) == 3 && parts[1] == "crew":
+		for _, src := range rigs {
+			if name, ok := strings.CutPrefix(parts[2], src+"-"); ok && name != "" {
+				return worktreeKindCrew, src + "/crew/" + name
+			}
+		}
+	}
+	return worktreeKindOther, ""
+}
+
+func runWorktreeGC(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	rigsConfig, err := config.LoadRigsConfig(constants.MayorRigsPath(townRoot))
+	if err != nil {
+		rigsConfig = &config.RigsConfig{Rigs: make(map[string]config.RigEntry)}
+	}
+	rigs, err := rig.NewManager(townRoot, rigsConfig, git.NewGit(townRoot)).DiscoverRigs()
+	if err != nil {
+		return fmt.Errorf("discovering rigs: %w", err)
+	}
+	var rigNames []string
+	for _, r := range rigs {
+		rigNames = append(rigNames, r.Name)
+	}
+	if worktreeGCRig != "" && !slices.Contains(rigNames, worktreeGCRig) {
+		return fmt.Errorf("rig '%s' not found - run 'gt rig list' to see available rigs", worktreeGCRig)
+	}
+
+	// Who is alive: running sessions, and hooked or in-progress work.
+	sessions, err := tmux.NewTmux().ListSessions()
+	if err != nil {
+		return fmt.Errorf("listing sessions: %w", err)
+	}
+	live := make(map[string]bool)
+	for _, name := range sessions {
+		if identity, err := session.ParseSessionName(name); err == nil && identity.Address() != "" {
+			live[identity.Address()] = true
+		}
+	}
+	hooks := make(map[string]string)
+	var hooksErr error
+	paths := []string{beads.GetTownBeadsPath(townRoot)}
+	for _, r := range rigs {
+		paths = append(paths, r.BeadsPath())
+	}
+	for _, p := range paths {
+		if err := collectHookedWork(p, hooks); err != nil && hooksErr == nil {
+			hooksErr = err
+		}
+	}
+
+	// git reports worktree paths with symlinks resolved.
+	scan := &worktreeScan{townRoot: townRoot, realTown: townRoot, rigNames: rigNames,
+		live: live, hooks: hooks, hooksErr: hooksErr, kennel: dog.NewManager(townRoot, rigsConfig)}
+	if p, err := filepath.EvalSymlinks(townRoot); err == nil {
+		scan.realTown = p
+	}
+	scan.cwd, _ = os.Getwd()
+	if p, err := filepath.EvalSymlinks(scan.cwd); err == nil {
+		scan.cwd = p
+	}
+	defer scan.releaseLocks()
+
+	var entries []*worktreeGCEntry
+	seen := make(map[string]bool)
+	for _, r := range rigs {
+		if worktreeGCRig != "" && r.Name != worktreeGCRig {
+			continue
+		}
+		for _, base := range []string{filepath.Join(r.Path, ".repo.git"), constants.RigMayorPath(r.Path)} {
+			if _, err := os.Stat(base); err != nil {
+				continue
+			}
+			repo := git.NewGit(base)
+			if strings.HasSuffix(base, ".repo.git") {
+				repo = git.NewGitWithDir(base, "")
+			}
+			worktrees, err := repo.WorktreeList()
+			if err != nil {
+				style.PrintWarning("could not list worktrees of %s: %v", base, err)
+				continue
+			}
+			for _, wt := range worktrees {
+				if wt.Bare || filepath.Clean(wt.Path) == filepath.Clean(base) || seen[wt.Path] {
+					continue
+				}
+				seen[wt.Path] = true
+				entries = append(entries, scan.inspect(r, repo, wt))
+			}
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Path < entries[j].Path })
+
+	if !worktreeGCDryRun {
+		pruned := make(map[*git.Git]bool)
+		for _, e := range entries {
+			if !e.Orphaned {
+				continue
+			}
+			if _, err := os.Stat(e.Path); err == nil {
+				// --force: only runtime files can remain, and they are disposable.
+				if err := e.repo.WorktreeRemove(e.Path, true); err != nil {
+					e.Error = err.Error()
+					continue
+				}
+			}
+			e.Removed = true
+			pruned[e.repo] = true
+		}
+		for repo := range pruned {
+			_ = repo.WorktreePrune()
+		}
+	}
+
+	if worktreeGCJSON {
+		if entries == nil {
+			entries = []*worktreeGCEntry{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(entries)
+	}
+	printWorktreeGC(townRoot, entries)
+	return nil
+}
+
+// worktreeScan holds what gt worktree gc knows about the town while it
+// inspects worktrees one at a time.
+type worktreeScan struct {
+	townRoot string
+	realTown string // townRoot with symlinks resolved, as git reports paths
+	cwd      string
+	rigNames []string
+	live     map[string]bool   // Addresses of agents with a running session
+	hooks    map[string]string // Agent address → hooked or in-progress bead
+	hooksErr error             // Set if some beads dir could not be read
+	kennel   *dog.Manager
+	release  []func() // Unlocks land locks held until gc finishes
+}
+
+// inspect gathers the facts about one worktree and assesses it.
+func (s *worktreeScan) inspect(r *rig.Rig, repo *git.Git, wt git.Worktree) *worktreeGCEntry {
+	e := &worktreeGCEntry{Path: wt.Path, Rig: r.Name, Branch: wt.Branch, repo: repo}
+	e.Kind, e.Owner = classifyWorktree(s.realTown, wt.Path, s.rigNames)
+	f := worktreeFacts{Locked: wt.Locked}
+	if _, err := os.Stat(wt.Path); os.IsNotExist(err) {
+		f.Missing = true
+	}
+	if e.Owner != "" {
+		f.Session = s.live[e.Owner]
+		f.Hook = s.hooks[e.Owner]
+	}
+	if s.cwd == wt.Path || strings.HasPrefix(s.cwd, wt.Path+string(filepath.Separator)) {
+		f.Owned = "current directory"
+	}
+	if f.Owned == "" {
+		s.checkOwner(r, e, &f)
+	}
+	if f.Owned == "" && e.Owner != "" && s.hooksErr != nil {
+		f.Owned = fmt.Sprintf("could not read hooked work: %v", s.hooksErr)
+	}
+	if !f.Missing && f.Owned == "" {
+		f.Work = worktreeUnsavedWork(wt.Path)
+	}
+	e.Orphaned, e.Reason = assessWorktree(f)
+	return e
+}
+
+// checkOwner fills in whether the worktree's owner still exists (Owned) or
+// is provably gone (Gone), and any work the owner holds.
+func (s *worktreeScan) checkOwner(r *rig.Rig, e *worktreeGCEntry, f *worktreeFacts) {
+	switch e.Kind {
+	case worktreeKindPolecat:
+		var hook string
+		f.Owned, f.Gone, hook = polecatWorktreeOwner(s.townRoot, r, e.Owner)
+		if f.Hook == "" {
+			f.Hook = hook
+		}
+	case worktreeKindCrew:
+		if _, err := os.Stat(filepath.Join(s.realTown, e.Owner)); err == nil {
+			f.Owned = "crew member still exists (gt worktree remove drops it)"
+		} else {
+			f.Gone = "crew member " + e.Owner + " no longer exists"
+		}
+	case worktreeKindDog:
+		name := filepath.Base(e.Owner)
+		d, err := s.kennel.Get(name)
+		switch {
+		case errors.Is(err, dog.ErrDogNotFound):
+			f.Gone = "dog " + name + " is no longer in the kennel"
+		case err != nil:
+			f.Owned = fmt.Sprintf("could not read dog %s: %v", name, err)
+		default:
+			if f.Hook == "" {
+				f.Hook = d.Work
+			}
+			f.Owned = "dog " + name + " is still in the kennel"
+		}
+	case worktreeKindLand:
+		fl := flock.New(filepath.Join(r.Path, ".runtime", "locks", "land-worktree.lock"))
+		if ok, err := fl.TryLock(); err != nil || !ok {
+			f.Owned = "land in progress"
+			return
+		}
+		// Hold the lock so no land starts while gc removes the worktree.
+		s.release = append(s.release, func() { _ = fl.Unlock() })
+		f.Gone = "no land in progress"
+	case worktreeKindRefinery:
+		f.Owned = "rig infrastructure"
+	default:
+		f.Owned = "not a location gt manages"
+	}
+}
+
+func (s *worktreeScan) releaseLocks() {
+	for _, unlock := range s.release {
+		unlock()
+	}
+}
+
+// collectHookedWork records, for every agent with hooked or in-progress
+// work in a beads dir, one such bead.
+func collectHookedWork(path string, hooks map[string]string) error {
+	b := beads.New(path)
+	for _, status := range []string{beads.StatusHooked, string(beads.StatusInProgress)} {
+		issues, err := b.List(beads.ListOptions{Status: status, Priority: -1})
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		for _, is := range issues {
+			if is.Assignee != "" {
+				hooks[canonicalHookAgent(is.Assignee)] = is.ID
+			}
+		}
+	}
+	return nil
+}
+
+// polecatWorktreeOwner checks whether the polecat that owns a worktree still
+// exists. A polecat's identity is its agent bead; idle polecats keep their
+// sandbox for reuse, so only a polecat whose bead is gone or closed has
+// provably abandoned its worktree. hook is the bead on the polecat's hook.
+func polecatWorktreeOwner(townRoot string, r *rig.Rig, owner string) (owned, gone, hook string) {
+	name := filepath.Base(owner)
+	id := beads.PolecatBeadIDWithPrefix(beads.GetPrefixForRig(townRoot, r.Name), r.Name, name)
+	issue, fields, err := beads.New(r.BeadsPath()).GetAgentBead(id)
+	switch {
+	case err != nil:
+		return fmt.Sprintf("could not read agent bead %s: %v", id, err), "", ""
+	case issue == nil:
+		return "", "polecat " + name + " has no agent bead", ""
+	case issue.Status == "closed":
+		return "", "polecat " + name + "'s agent bead is closed", ""
+	}
+	if fields != nil {
+		hook = fields.HookBead
+	}
+	return "idle polecat keeps its sandbox for reuse (gt polecat nuke retires it)", "", hook
+}
+
+// worktreeUnsavedWork summarizes work that removing a worktree would lose:
+// uncommitted changes and commits on no remote. Stashes are shared by every
+// worktree of a repo, so they survive removal and are not counted.
+func worktreeUnsavedWork(path string) string {
+	g := git.NewGit(path)
+	status, err := g.Status()
+	if err != nil {
+		return fmt.Sprintf("could not check for uncommitted work: %v", err)
+	}
+	unpushed, err := g.CommitsNotOnRemotes()
+	if err != nil {
+		return fmt.Sprintf("could not check for unpushed commits: %v", err)
+	}
+	work := &git.UncommittedWorkStatus{
+		HasUncommittedChanges: !status.Clean,
+		ModifiedFiles:         append(append(append([]string(nil), status.Modified...), status.Added...), status.Deleted...),
+		UntrackedFiles:        status.Untracked,
+		UnpushedCommits:       unpushed,
+	}
+	if work.CleanExcludingRuntime() {
+		return ""
+	}
+	return work.String()
+}
+
+// printWorktreeGC reports removed (or removable) worktrees, then the kept
+// ones with the reason each was skipped.
+func printWorktreeGC(townRoot string, entries []*worktreeGCEntry) {
+	display := func(path string) string {
+		if rel, err := filepath.Rel(townRoot, path); err == nil && !strings.HasPrefix(rel, "..") {
+			return rel
+		}
+		return path
+	}
+
+	var orphaned, kept []*worktreeGCEntry
+	for _, e := range entries {
+		if e.Orphaned {
+			orphaned = append(orphaned, e)
+		} else {
+			kept = append(kept, e)
+		}
+	}
+	fmt.Printf("Worktrees under %s: %d (%d orphaned)\n", townRoot, len(entries), len(orphaned))
+
+	if len(orphaned) > 0 {
+		fmt.Println()
+		for _, e := range orphaned {
+			switch {
+			case e.Error != "":
+				fmt.Printf("  %s %s: %s\n", style.Error.Render("✗"), display(e.Path), e.Error)
+			case worktreeGCDryRun:
+				fmt.Printf("  Would remove: %s %s\n", display(e.Path), style.Dim.Render("("+e.Reason+")"))
+			default:
+				fmt.Printf("  %s Removed %s %s\n", style.Success.Render("✓"), display(e.Path), style.Dim.Render("("+e.Reason+")"))
+			}
+		}
+	}
+
+	if len(kept) > 0 {
+		fmt.Printf("\nSkipped:\n")
+		for _, e := range kept {
+			fmt.Printf("  %-40s %s\n", display(e.Path), style.Dim.Render(e.Reason))
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestClassifyWorktree(t *testing.T) {
+	town := filepath.FromSlash("/gt")
+	rigs := []string{"gastown", "beads"}
+	tests := []struct {
+		path, kind, owner string
+	}{
+		{"/gt/gastown/polecats/nux/gastown", worktreeKindPolecat, "gastown/polecats/nux"},
+		{"/gt/gastown/polecats/nux", worktreeKindPolecat, "gastown/polecats/nux"},
+		{"/gt/gastown/refinery/rig", worktreeKindRefinery, "gastown/refinery"},
+		{"/gt/beads/crew/gastown-joe", worktreeKindCrew, "gastown/crew/joe"},
+		{"/gt/beads/crew/max", worktreeKindOther, ""},
+		{"/gt/deacon/dogs/alpha/gastown", worktreeKindDog, "deacon/dogs/alpha"},
+		{"/gt/gastown/.land-worktree", worktreeKindLand, ""},
+		{"/tmp/gt-merge-watch-123/scratch", worktreeKindOther, ""},
+		{"/gt/gastown/mayor/rig", worktreeKindOther, ""},
+	}
+	for _, tt := range tests {
+		kind, owner := classifyWorktree(town, filepath.FromSlash(tt.path), rigs)
+		if kind != tt.kind || owner != tt.owner {
+			t.Errorf("classifyWorktree(%s) = %s, %q; want %s, %q", tt.path, kind, owner, tt.kind, tt.owner)
+		}
+	}
+}
+
+func TestAssessWorktree(t *testing.T) {
+	gone := "polecat nux has no agent bead"
+	tests := []struct {
+		name     string
+		facts    worktreeFacts
+		orphaned bool
+		reason   string
+	}{
+		{"owner gone and clean", worktreeFacts{Gone: gone}, true, gone},
+		{"directory missing", worktreeFacts{Missing: true, Owned: "rig infrastructure"}, true, "directory missing"},
+		{"locked beats missing", worktreeFacts{Missing: true, Locked: true}, false, "locked"},
+		{"session running", worktreeFacts{Session: true, Gone: gone}, false, "owner's session is running"},
+		{"hooked work", worktreeFacts{Hook: "gt-1", Gone: gone}, false, "owner has gt-1 on its hook"},
+		{"owner exists", worktreeFacts{Owned: "dog alpha is still in the kennel"}, false, "dog alpha is still in the kennel"},
+		{"unsaved work", worktreeFacts{Work: "2 unpushed commit(s)", Gone: gone}, false, "2 unpushed commit(s)"},
+	}
+	for _, tt := range tests {
+		orphaned, reason := assessWorktree(tt.facts)
+		if orphaned != tt.orphaned || reason != tt.reason {
+			t.Errorf("%s: assessWorktree = %v, %q; want %v, %q", tt.name, orphaned, reason, tt.orphaned, tt.reason)
+		}
+	}
+}
@@ -36,8 +36,18 @@ const (
 	// AgentOmp is Oh My Pi (OMP) — Pi fork with hook-based lifecycle.
 	// Inspired by github.com/ProbabilityEngineer/pi-mono gastown integration.
 	AgentOmp AgentPreset = "omp"
+	// AgentAider is Aider (no hooks; startup context arrives by nudge).
+	AgentAider AgentPreset = "aider"
 )
 
+// presetAliases maps alternate names to built-in presets, so role_agents
+// and --agent accept a runtime's package name as well as its preset name.
+var presetAliases = map[string]AgentPreset{
+	"claude-code": AgentClaude,
+	"gemini-cli":  AgentGemini,
+	"codex-cli":   AgentCodex,
+}
+
 // AgentPresetInfo contains the configuration details for an agent preset.
 // This is the single source of truth for all agent-specific behavior.
 // Adding a new agent = adding a builtinPresets entry + optional hook installer.
@@ -97,6 +107,10 @@ type AgentPresetInfo struct {
 	// Defaults to "arg" if empty.
 	PromptMode string `json:"prompt_mode,omitempty"`
 
+	// PromptFlag is the flag that passes the initial prompt in interactive
+	// mode (e.g., "--prompt" for opencode). Empty passes it positionally.
+	PromptFlag string `json:"prompt_flag,omitempty"`
+
 	// ConfigDirEnv is the env var for the agent's config directory (e.g., "CLAUDE_CONFIG_DIR").
 	ConfigDirEnv string `json:"config_dir_env,omitempty"`
 
@@ -137,6 +151,10 @@ type AgentPresetInfo struct {
 	// EmitsPermissionWarning indicates the agent shows a bypass-permissions warning on startup
 	// that needs to be acknowledged via tmux.
 	EmitsPermissionWarning bool `json:"emits_permission_warning,omitempty"`
+
+	// ClientHints tells nudge delivery how the agent's input box takes
+	// typed text. Nil means the defaults, which suit Claude Code.
+	ClientHints *RuntimeClientHints `json:"client_hints,omitempty"`
 }
 
 // NonInteractiveConfig contains settings for running agents non-interactively.
@@ -304,6 +322,7 @@ var builtinPresets = map[AgentPreset]*AgentPresetInfo{
 		},
 		// Runtime defaults
 		PromptMode:        "arg",
+		PromptFlag:        "--prompt", // A positional prompt makes opencode exit immediately
 		ConfigDir:         ".opencode",
 		HooksProvider:     "opencode",
 		HooksDir:          ".opencode/plugins",
@@ -326,6 +345,7 @@ var builtinPresets = map[AgentPreset]*AgentPresetInfo{
 		},
 		// Runtime defaults
 		PromptMode:         "arg",
+		PromptFlag:         "-i",
 		ConfigDir:          ".copilot",
 		HooksProvider:      "copilot",
 		HooksDir:           ".copilot",
@@ -372,6 +392,25 @@ var builtinPresets = map[AgentPreset]*AgentPresetInfo{
 			PromptFlag: "--prompt",
 		},
 	},
+	AgentAider: {
+		Name:                AgentAider,
+		Command:             "aider",
+		Args:                []string{"--yes-always"},
+		ProcessNames:        []string{"aider", "python", "python3"}, // Aider is a Python app
+		SupportsHooks:       false,
+		SupportsForkSession: false,
+		NonInteractive: &NonInteractiveConfig{
+			PromptFlag: "--message",
+		},
+		// Runtime defaults
+		PromptMode:        "none", // --message runs one prompt and exits
+		ReadyPromptPrefix: "> ",
+		ReadyDelayMs:      5000,
+		InstructionsFile:  "AGENTS.md",
+		// The Escape nudges send is for Claude Code's vim mode; aider has
+		// no use for it.
+		ClientHints: &RuntimeClientHints{SkipEscape: true},
+	},
 }
 
 // Registry state with proper synchronization.
@@ -474,19 +513,23 @@ func LoadRigAgentRegistry(path string) error {
 // GetAgentPreset returns the preset info for a given agent name.
 // Returns nil if the preset is not found.
 func GetAgentPreset(name AgentPreset) *AgentPresetInfo {
-	registryMu.Lock()
-	initRegistryLocked()
-	defer registryMu.Unlock()
-	return globalRegistry.Agents[string(name)]
+	return GetAgentPresetByName(string(name))
 }
 
-// GetAgentPresetByName returns the preset info by string name.
-// Returns nil if not found, allowing caller to fall back to defaults.
+// GetAgentPresetByName returns the preset info by string name or alias
+// (e.g., "gemini-cli"). Returns nil if not found, allowing caller to fall
+// back to defaults.
 func GetAgentPresetByName(name string) *AgentPresetInfo {
 	registryMu.Lock()
 	initRegistryLocked()
 	defer registryMu.Unlock()
-	return globalRegistry.Agents[name]
+	if info, ok := globalRegistry.Agents[name]; ok {
+		return info
+	}
+	if alias, ok := presetAliases[name]; ok {
+		return globalRegistry.Agents[string(alias)]
+	}
+	return nil
 }
 
 // ListAgentPresets returns all known agent preset names.
@@ -17,7 +17,7 @@ func isClaudeCmd(cmd string) bool {
 func TestBuiltinPresets(t *testing.T) {
 	t.Parallel()
 	// Ensure all built-in presets are accessible
-	presets := []AgentPreset{AgentClaude, AgentGemini, AgentCodex, AgentCursor, AgentAuggie, AgentAmp, AgentOpenCode, AgentCopilot, AgentPi, AgentOmp}
+	presets := []AgentPreset{AgentClaude, AgentGemini, AgentCodex, AgentCursor, AgentAuggie, AgentAmp, AgentOpenCode, AgentCopilot, AgentPi, AgentOmp, AgentAider}
 
 	for _, preset := range presets {
 		info := GetAgentPreset(preset)
@@ -50,11 +50,13 @@ func TestGetAgentPresetByName(t *testing.T) {
 		{"cursor", AgentCursor, false},
 		{"auggie", AgentAuggie, false},
 		{"amp", AgentAmp, false},
-		{"aider", "", true},               // Not built-in, can be added via config
-		{"opencode", AgentOpenCode, false}, // Built-in multi-model CLI agent
-		{"copilot", AgentCopilot, false},   // Built-in GitHub Copilot CLI agent
-		{"pi", AgentPi, false},             // Pi Coding Agent
-		{"omp", AgentOmp, false},           // Oh My Pi
+		{"aider", AgentAider, false},
+		{"opencode", AgentOpenCode, false},  // Built-in multi-model CLI agent
+		{"copilot", AgentCopilot, false},    // Built-in GitHub Copilot CLI agent
+		{"pi", AgentPi, false},              // Pi Coding Agent
+		{"omp", AgentOmp, false},            // Oh My Pi
+		{"claude-code", AgentClaude, false}, // Alias
+		{"gemini-cli", AgentGemini, false},  // Alias
 		{"unknown", "", true},
 	}
 
@@ -133,7 +135,7 @@ func TestIsKnownPreset(t *testing.T) {
 		{"cursor", true},
 		{"auggie", true},
 		{"amp", true},
-		{"aider", false},    // Not built-in, can be added via config
+		{"aider", true},
 		{"opencode", true},  // Built-in multi-model CLI agent
 		{"copilot", true},   // Built-in GitHub Copilot CLI agent
 		{"pi", true},        // Pi Coding Agent
@@ -630,7 +632,7 @@ func TestGetProcessNames(t *testing.T) {
 func TestListAgentPresetsMatchesConstants(t *testing.T) {
 	t.Parallel()
 	// Ensure all AgentPreset constants are returned by ListAgentPresets
-	allConstants := []AgentPreset{AgentClaude, AgentGemini, AgentCodex, AgentCursor, AgentAuggie, AgentAmp, AgentOpenCode, AgentCopilot, AgentPi}
+	allConstants := []AgentPreset{AgentClaude, AgentGemini, AgentCodex, AgentCursor, AgentAuggie, AgentAmp, AgentOpenCode, AgentCopilot, AgentPi, AgentAider}
 	presets := ListAgentPresets()
 
 	// Convert to map for quick lookup
@@ -1053,6 +1055,31 @@ func TestCopilotAgentPreset(t *testing.T) {
 	}
 }
 
+func TestAiderAgentPreset(t *testing.T) {
+	t.Parallel()
+	info := GetAgentPreset(AgentAider)
+	if info == nil {
+		t.Fatal("aider preset not found")
+	}
+	if info.Command != "aider" {
+		t.Errorf("aider command = %q, want aider", info.Command)
+	}
+	if info.NonInteractive == nil || info.NonInteractive.PromptFlag != "--message" {
+		t.Errorf("aider NonInteractive = %+v, want PromptFlag --message", info.NonInteractive)
+	}
+	if info.ClientHints == nil || !info.ClientHints.SkipEscape {
+		t.Errorf("aider ClientHints = %+v, want SkipEscape", info.ClientHints)
+	}
+
+	rc := RuntimeConfigFromPreset(AgentAider)
+	if rc.ClientHints == nil || !rc.ClientHints.SkipEscape {
+		t.Errorf("aider runtime ClientHints = %+v, want SkipEscape", rc.ClientHints)
+	}
+	if rc.Tmux == nil || rc.Tmux.ReadyPromptPrefix != "> " {
+		t.Errorf("aider runtime Tmux = %+v, want ReadyPromptPrefix %q", rc.Tmux, "> ")
+	}
+}
+
 func TestPiAgentPreset(t *testing.T) {
 	t.Parallel()
 	info := GetAgentPreset(AgentPi)
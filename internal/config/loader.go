@@ -7,12 +7,14 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
 	"sort"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/errkind"
 )
 
 // resolveConfigMu serializes agent config resolution across all callers.
@@ -23,16 +25,17 @@ var resolveConfigMu sync.Mutex
 
 var (
 	// ErrNotFound indicates the config file does not exist.
-	ErrNotFound = errors.New("config file not found")
+	ErrNotFound = errkind.New(errkind.Config, "config file not found", "")
 
 	// ErrInvalidVersion indicates an unsupported schema version.
-	ErrInvalidVersion = errors.New("unsupported config version")
+	ErrInvalidVersion = errkind.New(errkind.Config, "unsupported config version",
+		"this gt may be older than the town; upgrade gt")
 
 	// ErrInvalidType indicates an unexpected config type.
-	ErrInvalidType = errors.New("invalid config type")
+	ErrInvalidType = errkind.New(errkind.Config, "invalid config type", "")
 
 	// ErrMissingField indicates a required field is missing.
-	ErrMissingField = errors.New("missing required field")
+	ErrMissingField = errkind.New(errkind.Config, "missing required field", "")
 )
 
 // LoadTownConfig loads and validates a town configuration file.
@@ -219,6 +222,54 @@ func validateRigSettings(c *RigSettings) error {
 			return err
 		}
 	}
+	if c.Witness != nil {
+		if err := ValidateWitnessRules(c.Witness.Rules); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ErrInvalidWitnessRule indicates a malformed witness rule.
+var ErrInvalidWitnessRule = errors.New("invalid witness rule")
+
+// ValidateWitnessRules checks that each rule is named, has a known action
+// and roles, parses, and has at least one condition (an unconditional rule
+// would fire for every agent on every patrol).
+func ValidateWitnessRules(rules []WitnessRule) error {
+	seen := make(map[string]bool, len(rules))
+	for _, r := range rules {
+		if r.Name == "" {
+			return fmt.Errorf("%w: missing name", ErrInvalidWitnessRule)
+		}
+		if seen[r.Name] {
+			return fmt.Errorf("%w: duplicate name %q", ErrInvalidWitnessRule, r.Name)
+		}
+		seen[r.Name] = true
+		switch r.Do {
+		case WitnessActionMailMayor, WitnessActionRestart, WitnessActionEscalate, WitnessActionDetach:
+		default:
+			return fmt.Errorf("%w %q: unknown action %q (want %s, %s, %s, or %s)", ErrInvalidWitnessRule, r.Name,
+				r.Do, WitnessActionMailMayor, WitnessActionRestart, WitnessActionEscalate, WitnessActionDetach)
+		}
+		for _, role := range r.When.Roles {
+			if role != "polecat" && role != "crew" {
+				return fmt.Errorf("%w %q: unsupported role %q (want polecat or crew)", ErrInvalidWitnessRule, r.Name, role)
+			}
+		}
+		for field, v := range map[string]string{"idle_for": r.When.IdleFor, "no_progress_for": r.When.NoProgressFor, "cooldown": r.Cooldown} {
+			if v == "" {
+				continue
+			}
+			if d, err := time.ParseDuration(v); err != nil || d <= 0 {
+				return fmt.Errorf("%w %q: invalid %s %q", ErrInvalidWitnessRule, r.Name, field, v)
+			}
+		}
+		w := r.When
+		if len(w.AgentState) == 0 && w.IdleFor == "" && w.NudgeFailures <= 0 && len(w.Labels) == 0 && w.NoProgressFor == "" {
+			return fmt.Errorf("%w %q: needs at least one condition", ErrInvalidWitnessRule, r.Name)
+		}
+	}
 	return nil
 }
 
@@ -587,6 +638,24 @@ func AddRigToDaemonPatrols(townRoot string, rigName string) error {
 // in daemon.json. Uses raw JSON manipulation to preserve fields not in PatrolConfig
 // (e.g., dolt_server config). If daemon.json doesn't exist, this is a no-op.
 func RemoveRigFromDaemonPatrols(townRoot string, rigName string) error {
+	return removeRigFromPatrols(townRoot, rigName, []string{"witness", "refinery"})
+}
+
+// RemoveRigFromDaemonPatrol removes a rig from a single patrol's rigs array
+// (e.g., "witness") so the daemon stops auto-starting that agent for the rig.
+// An emptied array means "all rigs" to the daemon, so removing the only
+// listed rig is an error rather than a silent widening.
+func RemoveRigFromDaemonPatrol(townRoot, rigName, patrolName string) error {
+	cfg, err := LoadDaemonPatrolConfig(DaemonPatrolConfigPath(townRoot))
+	if err == nil && cfg.Patrols != nil {
+		if p, ok := cfg.Patrols[patrolName]; ok && len(p.Rigs) == 1 && p.Rigs[0] == rigName {
+			return fmt.Errorf("%s is the only rig in the %s patrol; an empty list patrols all rigs", rigName, patrolName)
+		}
+	}
+	return removeRigFromPatrols(townRoot, rigName, []string{patrolName})
+}
+
+func removeRigFromPatrols(townRoot string, rigName string, patrolNames []string) error {
 	path := DaemonPatrolConfigPath(townRoot)
 	data, err := os.ReadFile(path) //nolint:gosec // G304: path is constructed internally
 	if err != nil {
@@ -612,7 +681,7 @@ func RemoveRigFromDaemonPatrols(townRoot string, rigName string) error {
 	}
 
 	modified := false
-	for _, patrolName := range []string{"witness", "refinery"} {
+	for _, patrolName := range patrolNames {
 		pRaw, ok := patrols[patrolName]
 		if !ok {
 			continue
@@ -931,6 +1000,40 @@ func validateMessagingConfig(c *MessagingConfig) error {
 		}
 	}
 
+	// Validate mail rules: an agent, a valid match, and at least one action
+	for i, rule := range c.Rules {
+		name := rule.Name
+		if name == "" {
+			name = fmt.Sprintf("#%d", i+1)
+		}
+		if rule.Agent == "" {
+			return fmt.Errorf("%w: rule '%s' agent", ErrMissingField, name)
+		}
+		if rule.Match.Subject != "" {
+			if _, err := regexp.Compile(rule.Match.Subject); err != nil {
+				return fmt.Errorf("rule '%s': invalid subject pattern: %w", name, err)
+			}
+		}
+		switch rule.Priority {
+		case "", "low", "normal", "high", "urgent":
+		default:
+			return fmt.Errorf("rule '%s': invalid priority %q (want low, normal, high, or urgent)", name, rule.Priority)
+		}
+		if !rule.Archive && rule.Forward == "" && rule.Priority == "" && rule.AutoReply == "" && rule.Nudge == "" && !rule.Stop {
+			return fmt.Errorf("%w: rule '%s' has no action", ErrMissingField, name)
+		}
+	}
+
+	// Validate mail ACL entries: both patterns and a known action
+	for i, entry := range c.ACL {
+		if entry.From == "" || entry.To == "" {
+			return fmt.Errorf("%w: acl entry #%d from/to", ErrMissingField, i+1)
+		}
+		if entry.Action != MailACLAllow && entry.Action != MailACLDeny {
+			return fmt.Errorf("acl entry #%d: invalid action %q (want allow or deny)", i+1, entry.Action)
+		}
+	}
+
 	return nil
 }
 
@@ -1594,6 +1697,7 @@ func fillRuntimeDefaults(rc *RuntimeConfig) *RuntimeConfig {
 		Command:       rc.Command,
 		InitialPrompt: rc.InitialPrompt,
 		PromptMode:    rc.PromptMode,
+		PromptFlag:    rc.PromptFlag,
 		ResolvedAgent: rc.ResolvedAgent,
 	}
 
@@ -1631,6 +1735,9 @@ func fillRuntimeDefaults(rc *RuntimeConfig) *RuntimeConfig {
 		result.Tmux = &RuntimeTmuxConfig{
 			ReadyPromptPrefix: rc.Tmux.ReadyPromptPrefix,
 			ReadyDelayMs:      rc.Tmux.ReadyDelayMs,
+			ReadyStableMs:     rc.Tmux.ReadyStableMs,
+			TypingDelayMs:     rc.Tmux.TypingDelayMs,
+			TypingJitterMs:    rc.Tmux.TypingJitterMs,
 		}
 		// Deep copy ProcessNames slice
 		if rc.Tmux.ProcessNames != nil {
@@ -1645,6 +1752,18 @@ func fillRuntimeDefaults(rc *RuntimeConfig) *RuntimeConfig {
 		}
 	}
 
+	if rc.ClientHints != nil {
+		h := *rc.ClientHints
+		result.ClientHints = &h
+	}
+
+	if rc.Container != nil {
+		c := *rc.Container
+		c.Mounts = append([]string(nil), rc.Container.Mounts...)
+		c.Args = append([]string(nil), rc.Container.Args...)
+		result.Container = &c
+	}
+
 	// Resolve preset for data-driven defaults.
 	// Use provider if set, otherwise try to match by command name.
 	presetName := result.Provider
@@ -1686,6 +1805,12 @@ func fillRuntimeDefaults(rc *RuntimeConfig) *RuntimeConfig {
 		}
 	}
 
+	// Auto-fill nudge client hints from preset.
+	if result.ClientHints == nil && preset != nil && preset.ClientHints != nil {
+		h := *preset.ClientHints
+		result.ClientHints = &h
+	}
+
 	// Auto-fill Env defaults from preset.
 	if preset != nil && len(preset.Env) > 0 {
 		if result.Env == nil {
@@ -2402,6 +2527,12 @@ func validateEscalationConfig(c *EscalationConfig) error {
 		return fmt.Errorf("%w: max_reescalations must be non-negative", ErrMissingField)
 	}
 
+	for name, levels := range c.Chains {
+		if err := ValidateEscalationChain(levels); err != nil {
+			return fmt.Errorf("chains.%s: %w", name, err)
+		}
+	}
+
 	return nil
 }
 
@@ -2428,6 +2559,11 @@ func (c *EscalationConfig) GetRouteForSeverity(severity string) []string {
 	return []string{"bead", "mail:mayor"}
 }
 
+// GetChain returns the named escalation chain, or nil if there is none.
+func (c *EscalationConfig) GetChain(name string) []EscalationChainLevel {
+	return c.Chains[name]
+}
+
 // GetMaxReescalations returns the maximum number of re-escalations allowed.
 // Returns 2 if not configured (nil). Explicit 0 means "never re-escalate".
 func (c *EscalationConfig) GetMaxReescalations() int {
@@ -431,6 +431,37 @@ func TestLoadRigConfigNotFound(t *testing.T) {
 	}
 }
 
+func TestValidateWitnessRules(t *testing.T) {
+	t.Parallel()
+	valid := WitnessRule{Name: "idle", When: WitnessRuleCondition{IdleFor: "20m"}, Do: WitnessActionMailMayor}
+	tests := []struct {
+		name    string
+		rules   []WitnessRule
+		wantErr bool
+	}{
+		{"valid", []WitnessRule{valid}, false},
+		{"valid crew restart", []WitnessRule{{Name: "r", When: WitnessRuleCondition{Roles: []string{"crew"}, NudgeFailures: 3}, Do: WitnessActionRestart, Cooldown: "1h"}}, false},
+		{"missing name", []WitnessRule{{When: valid.When, Do: valid.Do}}, true},
+		{"duplicate name", []WitnessRule{valid, valid}, true},
+		{"unknown action", []WitnessRule{{Name: "x", When: valid.When, Do: "nuke"}}, true},
+		{"unknown role", []WitnessRule{{Name: "x", When: WitnessRuleCondition{Roles: []string{"mayor"}, IdleFor: "1m"}, Do: valid.Do}}, true},
+		{"bad idle_for", []WitnessRule{{Name: "x", When: WitnessRuleCondition{IdleFor: "soon"}, Do: valid.Do}}, true},
+		{"valid detach", []WitnessRule{{Name: "stale", When: WitnessRuleCondition{NoProgressFor: "4h"}, Do: WitnessActionDetach}}, false},
+		{"bad no_progress_for", []WitnessRule{{Name: "x", When: WitnessRuleCondition{NoProgressFor: "0s"}, Do: WitnessActionDetach}}, true},
+		{"bad cooldown", []WitnessRule{{Name: "x", When: valid.When, Do: valid.Do, Cooldown: "-5m"}}, true},
+		{"no conditions", []WitnessRule{{Name: "x", Do: valid.Do}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := &RigSettings{Type: "rig-settings", Version: 1, Witness: &WitnessSettings{Rules: tt.rules}}
+			err := validateRigSettings(settings)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateRigSettings() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestLoadRigSettingsNotFound(t *testing.T) {
 	t.Parallel()
 	_, err := LoadRigSettings("/nonexistent/path.json")
@@ -778,6 +809,75 @@ func TestMessagingConfigValidation(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "valid mail rule",
+			config: &MessagingConfig{
+				Version: 1,
+				Rules: []MailRule{
+					{Agent: "mayor/", Match: MailRuleMatch{From: "*/witness", Subject: "^Patrol"}, Archive: true},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "mail rule without agent",
+			config: &MessagingConfig{
+				Version: 1,
+				Rules:   []MailRule{{Archive: true}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "mail rule with bad subject pattern",
+			config: &MessagingConfig{
+				Version: 1,
+				Rules:   []MailRule{{Agent: "mayor/", Match: MailRuleMatch{Subject: "("}, Archive: true}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "mail rule with bad priority",
+			config: &MessagingConfig{
+				Version: 1,
+				Rules:   []MailRule{{Agent: "mayor/", Priority: "critical"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "mail rule without action",
+			config: &MessagingConfig{
+				Version: 1,
+				Rules:   []MailRule{{Agent: "mayor/", Match: MailRuleMatch{From: "*/witness"}}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid mail acl",
+			config: &MessagingConfig{
+				Type:    "messaging",
+				Version: 1,
+				ACL:     []MailACLRule{{From: "role:polecat", To: "deacon/", Action: MailACLDeny}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "mail acl without to",
+			config: &MessagingConfig{
+				Type:    "messaging",
+				Version: 1,
+				ACL:     []MailACLRule{{From: "role:polecat", Action: MailACLDeny}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "mail acl with bad action",
+			config: &MessagingConfig{
+				Type:    "messaging",
+				Version: 1,
+				ACL:     []MailACLRule{{From: "*", To: "*", Action: "block"}},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -2430,6 +2530,62 @@ func TestRemoveRigFromDaemonPatrols(t *testing.T) {
 	})
 }
 
+func TestRemoveRigFromDaemonPatrol(t *testing.T) {
+	t.Parallel()
+
+	write := func(t *testing.T, daemonJSON string) string {
+		t.Helper()
+		townRoot := t.TempDir()
+		mayorDir := filepath.Join(townRoot, "mayor")
+		if err := os.MkdirAll(mayorDir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(mayorDir, "daemon.json"), []byte(daemonJSON), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return townRoot
+	}
+
+	t.Run("removes rig from one patrol only", func(t *testing.T) {
+		t.Parallel()
+		townRoot := write(t, `{
+  "type": "daemon-patrol-config",
+  "version": 1,
+  "patrols": {
+    "witness": {"enabled": true, "rigs": ["gastown", "api"]},
+    "refinery": {"enabled": true, "rigs": ["gastown", "api"]}
+  }
+}`)
+		if err := RemoveRigFromDaemonPatrol(townRoot, "api", "refinery"); err != nil {
+			t.Fatalf("RemoveRigFromDaemonPatrol: %v", err)
+		}
+		cfg, err := LoadDaemonPatrolConfig(DaemonPatrolConfigPath(townRoot))
+		if err != nil {
+			t.Fatalf("LoadDaemonPatrolConfig: %v", err)
+		}
+		if got := cfg.Patrols["refinery"].Rigs; len(got) != 1 || got[0] != "gastown" {
+			t.Errorf("refinery rigs = %v, want [gastown]", got)
+		}
+		if got := cfg.Patrols["witness"].Rigs; len(got) != 2 {
+			t.Errorf("witness rigs = %v, want unchanged", got)
+		}
+	})
+
+	t.Run("refuses to empty the list", func(t *testing.T) {
+		t.Parallel()
+		townRoot := write(t, `{
+  "type": "daemon-patrol-config",
+  "version": 1,
+  "patrols": {
+    "witness": {"enabled": true, "rigs": ["api"]}
+  }
+}`)
+		if err := RemoveRigFromDaemonPatrol(townRoot, "api", "witness"); err == nil {
+			t.Fatal("expected error removing the only patrolled rig")
+		}
+	})
+}
+
 func TestSaveTownSettings(t *testing.T) {
 	t.Parallel()
 	t.Run("saves valid town settings", func(t *testing.T) {
@@ -3898,6 +4054,29 @@ func TestEscalationConfigValidation(t *testing.T) {
 			wantErr: true,
 			errMsg:  "max_reescalations must be non-negative",
 		},
+		{
+			name: "valid chain",
+			config: &EscalationConfig{
+				Type:    "escalation",
+				Version: 1,
+				Chains: map[string][]EscalationChainLevel{
+					"default": {{Target: "gastown/crew/max", SLA: "30m"}, {Target: "mayor/"}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "chain level without sla",
+			config: &EscalationConfig{
+				Type:    "escalation",
+				Version: 1,
+				Chains: map[string][]EscalationChainLevel{
+					"ops": {{Target: "gastown/witness"}, {Target: "mayor/"}},
+				},
+			},
+			wantErr: true,
+			errMsg:  "chains.ops: escalation chain level 1 (gastown/witness): missing sla",
+		},
 	}
 
 	for _, tt := range tests {
@@ -3918,6 +4097,37 @@ func TestEscalationConfigValidation(t *testing.T) {
 	}
 }
 
+func TestParseEscalationChain(t *testing.T) {
+	t.Parallel()
+
+	chain, err := ParseEscalationChain("gastown/crew/max=30m, gastown/witness=1h,mayor/")
+	if err != nil {
+		t.Fatalf("ParseEscalationChain: %v", err)
+	}
+	want := []EscalationChainLevel{
+		{Target: "gastown/crew/max", SLA: "30m"},
+		{Target: "gastown/witness", SLA: "1h"},
+		{Target: "mayor/"},
+	}
+	if len(chain) != len(want) {
+		t.Fatalf("got %d levels, want %d: %+v", len(chain), len(want), chain)
+	}
+	for i := range want {
+		if chain[i] != want[i] {
+			t.Errorf("level %d = %+v, want %+v", i, chain[i], want[i])
+		}
+	}
+	if got := FormatEscalationChain(chain); got != "gastown/crew/max=30m,gastown/witness=1h,mayor/" {
+		t.Errorf("FormatEscalationChain = %q", got)
+	}
+
+	for _, spec := range []string{"", "gastown/witness,mayor/", "gastown/witness=soon,mayor/", "gastown/witness=-5m,mayor/", "=30m,mayor/"} {
+		if _, err := ParseEscalationChain(spec); err == nil {
+			t.Errorf("ParseEscalationChain(%q) succeeded, want error", spec)
+		}
+	}
+}
+
 func TestEscalationConfigGetStaleThreshold(t *testing.T) {
 	t.Parallel()
 
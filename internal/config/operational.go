@@ -1,6 +1,7 @@
 package config
 
 import (
+	"fmt"
 	"path/filepath"
 	"time"
 )
@@ -24,13 +25,18 @@ const (
 
 // Nudge defaults.
 const (
-	DefaultNudgeReadyTimeout      = 10 * time.Second
-	DefaultNudgeRetryInterval     = 500 * time.Millisecond
-	DefaultNudgeLockTimeout       = 30 * time.Second
-	DefaultNudgeNormalTTL         = 30 * time.Minute
-	DefaultNudgeUrgentTTL         = 2 * time.Hour
-	DefaultNudgeMaxQueueDepth     = 50
-	DefaultNudgeStaleClaimTimeout = 5 * time.Minute
+	DefaultNudgeReadyTimeout         = 10 * time.Second
+	DefaultNudgeRetryInterval        = 500 * time.Millisecond
+	DefaultNudgeLockTimeout          = 30 * time.Second
+	DefaultNudgeNormalTTL            = 30 * time.Minute
+	DefaultNudgeUrgentTTL            = 2 * time.Hour
+	DefaultNudgeMaxQueueDepth        = 50
+	DefaultNudgeStaleClaimTimeout    = 5 * time.Minute
+	DefaultNudgePasteBufferThreshold = 2048
+	DefaultNudgeBatchWindow          = 500 * time.Millisecond
+	DefaultNudgeDeliveryAttempts     = 3
+	DefaultNudgeCircuitThreshold     = 5
+	DefaultNudgeCircuitCooldown      = 10 * time.Minute
 )
 
 // Daemon defaults.
@@ -70,22 +76,32 @@ const (
 	DefaultPolecatDoltBackoffMax  = 30 * time.Second
 	DefaultPolecatPendingMaxAge   = 5 * time.Minute
 	DefaultPolecatNamepoolSize    = 50
+	DefaultPolecatStuckRecoveryTimeout = 2 * time.Minute
 )
 
+// DefaultPolecatStuckRecoveryNudge is the recovery nudge sent to stuck polecats
+// by `gt doctor --fix --remediate` before falling back to a session restart.
+const DefaultPolecatStuckRecoveryNudge = "Health check: you appear to be stuck. Re-read your assignment with " +
+	"`gt hook` and continue, or run `gt escalate` if you are blocked."
+
 // Dolt defaults.
 const (
-	DefaultDoltHealthCheckInterval = 30 * time.Second
-	DefaultDoltCmdTimeout          = 15 * time.Second
-	DefaultDoltMaxConnections      = 1000
-	DefaultDoltSlowQueryThreshold  = 1 * time.Second
+	DefaultDoltHealthCheckInterval  = 30 * time.Second
+	DefaultDoltCmdTimeout           = 15 * time.Second
+	DefaultDoltMaxConnections       = 1000
+	DefaultDoltSlowQueryThreshold   = 1 * time.Second
+	DefaultDoltDivergenceMaxCommits = 50
+	DefaultDoltDivergenceMaxSyncAge = 24 * time.Hour
 )
 
 // Mail defaults.
 const (
-	DefaultMailIdleNotifyTimeout  = 3 * time.Second
-	DefaultMailBdReadTimeout      = 60 * time.Second
-	DefaultMailBdWriteTimeout     = 60 * time.Second
-	DefaultMailMaxConcurrentAcks  = 8
+	DefaultMailIdleNotifyTimeout   = 3 * time.Second
+	DefaultMailBdReadTimeout       = 60 * time.Second
+	DefaultMailBdWriteTimeout      = 60 * time.Second
+	DefaultMailMaxConcurrentAcks   = 8
+	DefaultMailNotifyLatencySLO    = 2 * time.Minute
+	DefaultMailNotifyLatencyWindow = 24 * time.Hour
 )
 
 // Web defaults.
@@ -104,6 +120,18 @@ const (
 	DefaultWitnessDoneIntentRecentGrace  = 30 * time.Second
 )
 
+// Resource defaults.
+const (
+	DefaultAgentMaxCPUPercent = 200
+	DefaultAgentMaxRSSMB      = 4096
+)
+
+// Incident defaults.
+const (
+	DefaultIncidentCompressAfter = 72 * time.Hour
+	DefaultIncidentRetainFor     = 30 * 24 * time.Hour
+)
+
 // LoadOperationalConfig loads operational config from a town root.
 // Returns a valid (possibly empty) config — never nil, never errors.
 // Callers can use accessor methods that return defaults for nil sub-configs.
@@ -266,6 +294,49 @@ func (n *NudgeThresholds) StaleClaimThresholdD() time.Duration {
 	return DefaultNudgeStaleClaimTimeout
 }
 
+// PasteBufferThresholdV returns the configured or default size in bytes
+// above which nudges are pasted from a tmux buffer (0 disables).
+func (n *NudgeThresholds) PasteBufferThresholdV() int {
+	if n != nil && n.PasteBufferThreshold != nil {
+		return *n.PasteBufferThreshold
+	}
+	return DefaultNudgePasteBufferThreshold
+}
+
+// BatchWindowD returns the configured or default nudge batching window.
+func (n *NudgeThresholds) BatchWindowD() time.Duration {
+	if n != nil {
+		return ParseDurationOrDefault(n.BatchWindow, DefaultNudgeBatchWindow)
+	}
+	return DefaultNudgeBatchWindow
+}
+
+// DeliveryAttemptsV returns the configured or default number of direct
+// delivery attempts (at least 1).
+func (n *NudgeThresholds) DeliveryAttemptsV() int {
+	if n != nil && n.DeliveryAttempts != nil && *n.DeliveryAttempts > 0 {
+		return *n.DeliveryAttempts
+	}
+	return DefaultNudgeDeliveryAttempts
+}
+
+// CircuitThresholdV returns the configured or default number of consecutive
+// delivery failures that open a session's nudge circuit (0 disables).
+func (n *NudgeThresholds) CircuitThresholdV() int {
+	if n != nil && n.CircuitThreshold != nil && *n.CircuitThreshold >= 0 {
+		return *n.CircuitThreshold
+	}
+	return DefaultNudgeCircuitThreshold
+}
+
+// CircuitCooldownD returns the configured or default nudge circuit cool-down.
+func (n *NudgeThresholds) CircuitCooldownD() time.Duration {
+	if n != nil {
+		return ParseDurationOrDefault(n.CircuitCooldown, DefaultNudgeCircuitCooldown)
+	}
+	return DefaultNudgeCircuitCooldown
+}
+
 // --- Daemon accessors ---
 
 // GetDaemonConfig returns the daemon thresholds, never nil.
@@ -454,6 +525,23 @@ func (d *DeaconThresholds) FeedCooldownD() time.Duration {
 	return DefaultFeedCooldown
 }
 
+// ProbeEnabled reports whether the named deacon probe is enabled.
+func (d *DeaconThresholds) ProbeEnabled(name string) bool {
+	if d != nil && d.Probes[name] != nil && d.Probes[name].Enabled != nil {
+		return *d.Probes[name].Enabled
+	}
+	return true
+}
+
+// ProbeIntervalD returns the configured interval for the named deacon
+// probe, or def.
+func (d *DeaconThresholds) ProbeIntervalD(name string, def time.Duration) time.Duration {
+	if d != nil && d.Probes[name] != nil {
+		return ParseDurationOrDefault(d.Probes[name].Interval, def)
+	}
+	return def
+}
+
 // --- Polecat accessors ---
 
 // GetPolecatConfig returns the polecat thresholds, never nil.
@@ -512,6 +600,22 @@ func (p *PolecatThresholds) NamepoolSizeV() int {
 	return DefaultPolecatNamepoolSize
 }
 
+// StuckRecoveryNudgeV returns the configured or default stuck-worker recovery nudge.
+func (p *PolecatThresholds) StuckRecoveryNudgeV() string {
+	if p != nil && p.StuckRecoveryNudge != "" {
+		return p.StuckRecoveryNudge
+	}
+	return DefaultPolecatStuckRecoveryNudge
+}
+
+// StuckRecoveryTimeoutD returns the configured or default stuck-worker recovery timeout.
+func (p *PolecatThresholds) StuckRecoveryTimeoutD() time.Duration {
+	if p != nil {
+		return ParseDurationOrDefault(p.StuckRecoveryTimeout, DefaultPolecatStuckRecoveryTimeout)
+	}
+	return DefaultPolecatStuckRecoveryTimeout
+}
+
 // --- Dolt accessors ---
 
 // GetDoltConfig returns the dolt thresholds, never nil.
@@ -554,6 +658,24 @@ func (dt *DoltThresholds) SlowQueryThresholdD() time.Duration {
 	return DefaultDoltSlowQueryThreshold
 }
 
+// DivergenceMaxCommitsV returns the configured or default number of
+// unpushed or unpulled beads commits tolerated before doctor warns.
+func (dt *DoltThresholds) DivergenceMaxCommitsV() int {
+	if dt != nil && dt.DivergenceMaxCommits != nil {
+		return *dt.DivergenceMaxCommits
+	}
+	return DefaultDoltDivergenceMaxCommits
+}
+
+// DivergenceMaxSyncAgeD returns the configured or default age after which
+// unpushed beads commits are flagged.
+func (dt *DoltThresholds) DivergenceMaxSyncAgeD() time.Duration {
+	if dt != nil {
+		return ParseDurationOrDefault(dt.DivergenceMaxSyncAge, DefaultDoltDivergenceMaxSyncAge)
+	}
+	return DefaultDoltDivergenceMaxSyncAge
+}
+
 // --- Mail accessors ---
 
 // GetMailConfig returns the mail thresholds, never nil.
@@ -596,6 +718,24 @@ func (m *MailThresholds) MaxConcurrentAckOpsV() int {
 	return DefaultMailMaxConcurrentAcks
 }
 
+// NotifyLatencySLOD returns the configured or default p95 limit on urgent
+// mail-to-nudge delivery latency.
+func (m *MailThresholds) NotifyLatencySLOD() time.Duration {
+	if m != nil {
+		return ParseDurationOrDefault(m.NotifyLatencySLO, DefaultMailNotifyLatencySLO)
+	}
+	return DefaultMailNotifyLatencySLO
+}
+
+// NotifyLatencyWindowD returns the configured or default window of delivery
+// samples the latency SLO is evaluated over.
+func (m *MailThresholds) NotifyLatencyWindowD() time.Duration {
+	if m != nil {
+		return ParseDurationOrDefault(m.NotifyLatencyWindow, DefaultMailNotifyLatencyWindow)
+	}
+	return DefaultMailNotifyLatencyWindow
+}
+
 // --- Web accessors ---
 
 // GetWebConfig returns the web thresholds, never nil.
@@ -679,3 +819,91 @@ func (wt *WitnessThresholds) DoneIntentRecentGraceD() time.Duration {
 	}
 	return DefaultWitnessDoneIntentRecentGrace
 }
+
+// --- Resource accessors ---
+
+// GetResourcesConfig returns the resource thresholds, never nil.
+func (c *OperationalConfig) GetResourcesConfig() *ResourceThresholds {
+	if c != nil && c.Resources != nil {
+		return c.Resources
+	}
+	return &ResourceThresholds{}
+}
+
+// AgentMaxCPUPercentV returns the configured or default per-agent CPU limit.
+func (r *ResourceThresholds) AgentMaxCPUPercentV() int {
+	if r != nil && r.AgentMaxCPUPercent != nil {
+		return *r.AgentMaxCPUPercent
+	}
+	return DefaultAgentMaxCPUPercent
+}
+
+// AgentMaxRSSMBV returns the configured or default per-agent memory limit in MB.
+func (r *ResourceThresholds) AgentMaxRSSMBV() int {
+	if r != nil && r.AgentMaxRSSMB != nil {
+		return *r.AgentMaxRSSMB
+	}
+	return DefaultAgentMaxRSSMB
+}
+
+// Exceeded describes which limit an agent's usage exceeds, or "" if none.
+// A limit of zero or less disables that check.
+func (r *ResourceThresholds) Exceeded(cpuPercent float64, rssMB int64) string {
+	if maxCPU := r.AgentMaxCPUPercentV(); maxCPU > 0 && cpuPercent > float64(maxCPU) {
+		return fmt.Sprintf("cpu %.0f%% > %d%%", cpuPercent, maxCPU)
+	}
+	if maxRSS := r.AgentMaxRSSMBV(); maxRSS > 0 && rssMB > int64(maxRSS) {
+		return fmt.Sprintf("mem %dMB > %dMB", rssMB, maxRSS)
+	}
+	return ""
+}
+
+// GetIncidentsConfig returns the incident thresholds, never nil.
+func (c *OperationalConfig) GetIncidentsConfig() *IncidentThresholds {
+	if c != nil && c.Incidents != nil {
+		return c.Incidents
+	}
+	return &IncidentThresholds{}
+}
+
+// CompressAfterD returns the configured or default age at which incident
+// bundles are compressed.
+func (i *IncidentThresholds) CompressAfterD() time.Duration {
+	if i != nil {
+		return ParseDurationOrDefault(i.CompressAfter, DefaultIncidentCompressAfter)
+	}
+	return DefaultIncidentCompressAfter
+}
+
+// RetainForD returns the configured or default age at which incident
+// bundles are deleted.
+func (i *IncidentThresholds) RetainForD() time.Duration {
+	if i != nil {
+		return ParseDurationOrDefault(i.RetainFor, DefaultIncidentRetainFor)
+	}
+	return DefaultIncidentRetainFor
+}
+
+// ArchiveOnCloseV reports whether closing a bead archives its incidents.
+func (i *IncidentThresholds) ArchiveOnCloseV() bool {
+	if i != nil && i.ArchiveOnClose != nil {
+		return *i.ArchiveOnClose
+	}
+	return true
+}
+
+// GetRedactionConfig returns the redaction config, never nil.
+func (c *OperationalConfig) GetRedactionConfig() *RedactionConfig {
+	if c != nil && c.Redaction != nil {
+		return c.Redaction
+	}
+	return &RedactionConfig{}
+}
+
+// BuiltinV reports whether the built-in redaction patterns apply.
+func (r *RedactionConfig) BuiltinV() bool {
+	if r != nil && r.Builtin != nil {
+		return *r.Builtin
+	}
+	return true
+}
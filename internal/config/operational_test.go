@@ -87,6 +87,12 @@ func TestNudgeThresholds_Defaults(t *testing.T) {
 	if got := nudge.StaleClaimThresholdD(); got != DefaultNudgeStaleClaimTimeout {
 		t.Errorf("StaleClaimThreshold: got %v, want %v", got, DefaultNudgeStaleClaimTimeout)
 	}
+	if got := nudge.BatchWindowD(); got != DefaultNudgeBatchWindow {
+		t.Errorf("BatchWindow: got %v, want %v", got, DefaultNudgeBatchWindow)
+	}
+	if got := nudge.DeliveryAttemptsV(); got != DefaultNudgeDeliveryAttempts {
+		t.Errorf("DeliveryAttempts: got %v, want %v", got, DefaultNudgeDeliveryAttempts)
+	}
 }
 
 func TestDaemonThresholds_Defaults(t *testing.T) {
@@ -176,6 +182,34 @@ func TestDeaconThresholds_Defaults(t *testing.T) {
 	}
 }
 
+func TestDeaconThresholds_Probes(t *testing.T) {
+	t.Parallel()
+
+	disabled := false
+	deacon := &DeaconThresholds{Probes: map[string]*DeaconProbeConfig{
+		"disk_space":   {Interval: "1h"},
+		"mail_backlog": {Enabled: &disabled},
+	}}
+
+	if got := deacon.ProbeIntervalD("disk_space", 10*time.Minute); got != time.Hour {
+		t.Errorf("disk_space interval: got %v, want 1h", got)
+	}
+	if got := deacon.ProbeIntervalD("tmux", time.Minute); got != time.Minute {
+		t.Errorf("tmux interval: got %v, want default 1m", got)
+	}
+	if deacon.ProbeEnabled("mail_backlog") {
+		t.Error("mail_backlog should be disabled")
+	}
+	if !deacon.ProbeEnabled("tmux") {
+		t.Error("tmux should default to enabled")
+	}
+
+	var nilDeacon *DeaconThresholds
+	if !nilDeacon.ProbeEnabled("tmux") || nilDeacon.ProbeIntervalD("tmux", time.Minute) != time.Minute {
+		t.Error("nil thresholds should use defaults")
+	}
+}
+
 func TestPolecatThresholds_Defaults(t *testing.T) {
 	t.Parallel()
 
@@ -364,3 +398,24 @@ func TestWitnessThresholds_Overrides(t *testing.T) {
 		t.Errorf("DoneIntentRecentGrace: got %v, want 15s", got)
 	}
 }
+
+func TestResourceThresholds_Exceeded(t *testing.T) {
+	var op *OperationalConfig
+	res := op.GetResourcesConfig()
+	if got := res.Exceeded(150, 1024); got != "" {
+		t.Errorf("within defaults: got %q, want none", got)
+	}
+	if got := res.Exceeded(250, 1024); got != "cpu 250% > 200%" {
+		t.Errorf("cpu over default: got %q", got)
+	}
+
+	zero, mem := 0, 512
+	op = &OperationalConfig{Resources: &ResourceThresholds{AgentMaxCPUPercent: &zero, AgentMaxRSSMB: &mem}}
+	res = op.GetResourcesConfig()
+	if got := res.Exceeded(900, 100); got != "" {
+		t.Errorf("disabled cpu limit: got %q, want none", got)
+	}
+	if got := res.Exceeded(10, 600); got != "mem 600MB > 512MB" {
+		t.Errorf("mem over override: got %q", got)
+	}
+}
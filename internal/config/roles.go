@@ -58,6 +58,38 @@ type RoleSessionConfig struct {
 	// StartCommand is the command to run after creating the session.
 	// Default: "exec claude --dangerously-skip-permissions"
 	StartCommand string `toml:"start_command,omitempty"`
+
+	// Layout declares extra panes opened beside the agent's pane, e.g. a
+	// log tail and a shell. The agent pane stays first and focused, and
+	// stays the target for nudges and captures.
+	Layout []RolePaneConfig `toml:"layout,omitempty"`
+}
+
+// RolePaneConfig declares one extra pane in a role's session layout.
+//
+//	[[session.layout]]
+//	name = "logs"
+//	command = "tail -F {town}/daemon/daemon.log"
+//	split = "right"
+//	size = 40
+type RolePaneConfig struct {
+	// Name identifies the pane (e.g., "logs", "shell"). The pane's ID is
+	// recorded in the session environment as GT_LAYOUT_PANE_<NAME>.
+	Name string `toml:"name"`
+
+	// Command runs in the pane. Empty starts the default shell.
+	// Supports the same placeholders as WorkDir.
+	Command string `toml:"command,omitempty"`
+
+	// Split is where the pane opens relative to Target: "right" (default)
+	// or "below".
+	Split string `toml:"split,omitempty"`
+
+	// Size is the pane's share of the split, in percent. Default 30.
+	Size int `toml:"size,omitempty"`
+
+	// Target is the name of an earlier pane to split. Default "agent".
+	Target string `toml:"target,omitempty"`
 }
 
 // RoleHealthConfig contains health check thresholds.
@@ -235,6 +267,10 @@ func mergeRoleDefinition(base, override *RoleDefinition) {
 	if override.Session.StartCommand != "" {
 		base.Session.StartCommand = override.Session.StartCommand
 	}
+	// A layout is replaced as a whole; panes don't merge by name.
+	if len(override.Session.Layout) > 0 {
+		base.Session.Layout = override.Session.Layout
+	}
 
 	// Env vars (merge, don't replace)
 	if override.Env != nil {
@@ -2,6 +2,7 @@ package config
 
 import (
 	"os"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
@@ -309,6 +310,57 @@ func TestLoadRoleDefinition_ValidOverride(t *testing.T) {
 	}
 }
 
+func TestLoadRoleDefinition_LayoutOverride(t *testing.T) {
+	townRoot := t.TempDir()
+	rigPath := t.TempDir()
+	for _, dir := range []string{townRoot, rigPath} {
+		if err := os.MkdirAll(dir+"/roles", 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	town := `[[session.layout]]
+name = "logs"
+command = "tail -F {town}/daemon/daemon.log"
+size = 40
+
+[[session.layout]]
+name = "shell"
+split = "below"
+target = "logs"
+`
+	if err := os.WriteFile(townRoot+"/roles/crew.toml", []byte(town), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	def, err := LoadRoleDefinition(townRoot, "", "crew")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []RolePaneConfig{
+		{Name: "logs", Command: "tail -F {town}/daemon/daemon.log", Size: 40},
+		{Name: "shell", Split: "below", Target: "logs"},
+	}
+	if !reflect.DeepEqual(def.Session.Layout, want) {
+		t.Errorf("Layout = %+v, want %+v", def.Session.Layout, want)
+	}
+	if def.Session.StartCommand == "" {
+		t.Error("layout override should not clear the builtin start command")
+	}
+
+	// A rig layout replaces the town's rather than merging pane by pane.
+	rig := "[[session.layout]]\nname = \"shell\"\n"
+	if err := os.WriteFile(rigPath+"/roles/crew.toml", []byte(rig), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	def, err = LoadRoleDefinition(townRoot, rigPath, "crew")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(def.Session.Layout) != 1 || def.Session.Layout[0].Name != "shell" {
+		t.Errorf("Layout after rig override = %+v, want just shell", def.Session.Layout)
+	}
+}
+
 func TestLoadRoleDefinition_NoOverrideFiles(t *testing.T) {
 	// Use temp dirs with no roles/ subdirectory - should succeed with defaults only
 	townRoot := t.TempDir()
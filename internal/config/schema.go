@@ -0,0 +1,187 @@
+package config
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// JSONSchemaDialect is the JSON Schema draft Schema emits.
+const JSONSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+var (
+	timeType            = reflect.TypeOf(time.Time{})
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	anyType             = reflect.TypeOf((*interface{})(nil)).Elem()
+)
+
+// Schema returns a JSON Schema describing how v's type is encoded, derived
+// from its json struct tags. Objects reject unknown properties, so editors
+// flag misspelled keys.
+func Schema(v interface{}, title string) map[string]interface{} {
+	s := schemaFor(reflect.TypeOf(v), map[reflect.Type]bool{})
+	s["$schema"] = JSONSchemaDialect
+	if title != "" {
+		s["title"] = title
+	}
+	return s
+}
+
+func schemaFor(t reflect.Type, visiting map[reflect.Type]bool) map[string]interface{} {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch {
+	case t == timeType:
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	case reflect.PointerTo(t).Implements(textUnmarshalerType):
+		return map[string]interface{}{"type": "string"}
+	case reflect.PointerTo(t).Implements(jsonUnmarshalerType):
+		return map[string]interface{}{} // Custom encoding; accept anything
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]interface{}{"type": "string"} // []byte is base64
+		}
+		return map[string]interface{}{"type": "array", "items": schemaFor(t.Elem(), visiting)}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": schemaFor(t.Elem(), visiting)}
+	case reflect.Struct:
+		if visiting[t] {
+			return map[string]interface{}{"type": "object"} // Recursive type
+		}
+		visiting[t] = true
+		defer delete(visiting, t)
+		props := map[string]interface{}{}
+		for name, ft := range jsonFields(t) {
+			props[name] = schemaFor(ft, visiting)
+		}
+		return map[string]interface{}{"type": "object", "properties": props, "additionalProperties": false}
+	}
+	return map[string]interface{}{}
+}
+
+// UnknownKeys returns the dotted paths of object keys in data that v's type
+// doesn't decode, such as misspelled settings that encoding/json would
+// silently drop. Paths are sorted.
+func UnknownKeys(data []byte, v interface{}) ([]string, error) {
+	var raw interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	var unknown []string
+	collectUnknownKeys(raw, reflect.TypeOf(v), "", &unknown)
+	sort.Strings(unknown)
+	return unknown, nil
+}
+
+func collectUnknownKeys(raw interface{}, t reflect.Type, path string, unknown *[]string) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == timeType || t == anyType ||
+		reflect.PointerTo(t).Implements(jsonUnmarshalerType) || reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		return
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		obj, ok := raw.(map[string]interface{})
+		if !ok {
+			return
+		}
+		fields := jsonFields(t)
+		for key, val := range obj {
+			ft, ok := lookupField(fields, key)
+			if !ok {
+				*unknown = append(*unknown, joinKeyPath(path, key))
+				continue
+			}
+			collectUnknownKeys(val, ft, joinKeyPath(path, key), unknown)
+		}
+	case reflect.Map:
+		if obj, ok := raw.(map[string]interface{}); ok {
+			for key, val := range obj {
+				collectUnknownKeys(val, t.Elem(), joinKeyPath(path, key), unknown)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		if arr, ok := raw.([]interface{}); ok {
+			for i, val := range arr {
+				collectUnknownKeys(val, t.Elem(), fmt.Sprintf("%s[%d]", path, i), unknown)
+			}
+		}
+	}
+}
+
+// lookupField finds a field by JSON key the way encoding/json does: an exact
+// match first, then a case-insensitive one.
+func lookupField(fields map[string]reflect.Type, key string) (reflect.Type, bool) {
+	if ft, ok := fields[key]; ok {
+		return ft, true
+	}
+	for name, ft := range fields {
+		if strings.EqualFold(name, key) {
+			return ft, true
+		}
+	}
+	return nil, false
+}
+
+func joinKeyPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// jsonFields returns a struct's JSON keys and their types, flattening
+// embedded structs as encoding/json does.
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if f.Anonymous && name == "" {
+			et := f.Type
+			if et.Kind() == reflect.Pointer {
+				et = et.Elem()
+			}
+			if et.Kind() == reflect.Struct {
+				for k, v := range jsonFields(et) {
+					if _, ok := fields[k]; !ok {
+						fields[k] = v
+					}
+				}
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[name] = f.Type
+	}
+	return fields
+}
@@ -0,0 +1,53 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSchema(t *testing.T) {
+	t.Parallel()
+	s := Schema(&RigsConfig{}, "rigs")
+	if s["$schema"] != JSONSchemaDialect || s["title"] != "rigs" || s["type"] != "object" {
+		t.Fatalf("schema header = %v", s)
+	}
+	rigs := s["properties"].(map[string]interface{})["rigs"].(map[string]interface{})
+	entry := rigs["additionalProperties"].(map[string]interface{})
+	if entry["additionalProperties"] != false {
+		t.Error("rig entry schema allows unknown keys")
+	}
+	props := entry["properties"].(map[string]interface{})
+	if got := props["added_at"]; !reflect.DeepEqual(got, map[string]interface{}{"type": "string", "format": "date-time"}) {
+		t.Errorf("added_at = %v, want date-time string", got)
+	}
+	if got := props["beads"].(map[string]interface{})["type"]; got != "object" {
+		t.Errorf("beads type = %v, want object", got)
+	}
+
+	// Types with their own encoding and recursive types must not panic.
+	_ = Schema(&RigSettings{}, "")
+	_ = Schema(&TownSettings{}, "")
+}
+
+func TestUnknownKeys(t *testing.T) {
+	t.Parallel()
+	data := []byte(`{
+		"version": 1,
+		"Rigs": {
+			"gastown": {"git_url": "x", "gitURL": "y", "beads": {"prefix": "gt", "prefx": "g"}}
+		},
+		"extra": true
+	}`)
+	got, err := UnknownKeys(data, &RigsConfig{})
+	if err != nil {
+		t.Fatalf("UnknownKeys: %v", err)
+	}
+	want := []string{"Rigs.gastown.beads.prefx", "Rigs.gastown.gitURL", "extra"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("UnknownKeys = %v, want %v", got, want)
+	}
+
+	if _, err := UnknownKeys([]byte(`{`), &RigsConfig{}); err == nil {
+		t.Error("UnknownKeys accepted invalid JSON")
+	}
+}
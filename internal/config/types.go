@@ -3,12 +3,19 @@ package config
 
 import (
 	"fmt"
+	"net/url"
 	"os"
 	"os/exec"
+	"path"
 	"path/filepath"
 	"strings"
+	"text/template"
 	"time"
 
+	"github.com/steveyegge/gastown/internal/budget"
+	"github.com/steveyegge/gastown/internal/disk"
+	"github.com/steveyegge/gastown/internal/logging"
+	"github.com/steveyegge/gastown/internal/scheduler/assign"
 	"github.com/steveyegge/gastown/internal/scheduler/capacity"
 )
 
@@ -67,6 +74,11 @@ type TownSettings struct {
 	// Example: {"mayor": "claude-opus", "witness": "claude-haiku", "polecat": "claude-sonnet"}
 	RoleAgents map[string]string `json:"role_agents,omitempty"`
 
+	// MailSummaryAgent is the agent used by 'gt mail summarize'. Pick a cheap
+	// model; summaries only need reading comprehension.
+	// Default: claude with --model haiku.
+	MailSummaryAgent string `json:"mail_summary_agent,omitempty"`
+
 	// AgentEmailDomain is the domain used for agent git identity emails.
 	// Agent addresses like "gastown/crew/jack" become "gastown.crew.jack@{domain}".
 	// Default: "gastown.local"
@@ -92,6 +104,27 @@ type TownSettings struct {
 	// Scheduler configures the capacity scheduler for polecat dispatch.
 	Scheduler *capacity.SchedulerConfig `json:"scheduler,omitempty"`
 
+	// Dispatcher configures auto-dispatch of ready beads to idle agents.
+	Dispatcher *assign.DispatcherConfig `json:"dispatcher,omitempty"`
+
+	// Budget caps spend per rig and per agent. Over-budget rigs and agents
+	// get no new work from dispatch until the period rolls over.
+	Budget *budget.Config `json:"budget,omitempty"`
+
+	// Disk sets storage quotas for the town, rigs, scrollback snapshots,
+	// and mail archives. gt done warns when over; postflight prunes
+	// snapshots and mail archives back under their quotas.
+	Disk *disk.Config `json:"disk,omitempty"`
+
+	// Logging sets log levels (town-wide and per subsystem) and the log
+	// format of gt daemon and gt dashboard. --verbose and --quiet override
+	// the levels for a single command.
+	Logging *logging.Config `json:"logging,omitempty"`
+
+	// Notifications configures webhooks (Slack, Discord, generic JSON) fired
+	// on events such as agent crashes and stuck workers.
+	Notifications *NotificationsConfig `json:"notifications,omitempty"`
+
 	// Operational configures operational thresholds (timeouts, retries, intervals).
 	// These were previously hardcoded as Go constants throughout the codebase.
 	// All values are optional — omitted values use compiled-in defaults.
@@ -215,6 +248,15 @@ type OperationalConfig struct {
 
 	// Witness configures witness patrol thresholds.
 	Witness *WitnessThresholds `json:"witness,omitempty"`
+
+	// Resources configures per-agent resource usage limits.
+	Resources *ResourceThresholds `json:"resources,omitempty"`
+
+	// Incidents configures incident bundle retention.
+	Incidents *IncidentThresholds `json:"incidents,omitempty"`
+
+	// Redaction configures secret masking in persisted captures.
+	Redaction *RedactionConfig `json:"redaction,omitempty"`
 }
 
 // SessionThresholds configures session management timeouts.
@@ -272,6 +314,28 @@ type NudgeThresholds struct {
 	// StaleClaimThreshold is how long a .claimed file must be untouched
 	// before treated as orphan (default "5m").
 	StaleClaimThreshold string `json:"stale_claim_threshold,omitempty"`
+
+	// PasteBufferThreshold is the message size in bytes above which nudges
+	// are delivered via a tmux paste buffer instead of send-keys
+	// (default 2048; 0 disables).
+	PasteBufferThreshold *int `json:"paste_buffer_threshold,omitempty"`
+
+	// BatchWindow is how long the nudge scheduler collects nudges to the
+	// same session before delivering them as one (default "500ms").
+	BatchWindow string `json:"batch_window,omitempty"`
+
+	// DeliveryAttempts is how many times the scheduler tries a direct
+	// delivery before falling back to the queue (default 3).
+	DeliveryAttempts *int `json:"delivery_attempts,omitempty"`
+
+	// CircuitThreshold is how many consecutive failed deliveries to a
+	// session open its circuit, fast-failing further nudges to it
+	// (default 5; 0 disables).
+	CircuitThreshold *int `json:"circuit_threshold,omitempty"`
+
+	// CircuitCooldown is how long an open circuit fast-fails before it lets
+	// a delivery through to test the session again (default "10m").
+	CircuitCooldown string `json:"circuit_cooldown,omitempty"`
 }
 
 // DaemonThresholds configures daemon lifecycle and patrol thresholds.
@@ -343,6 +407,19 @@ type DeaconThresholds struct {
 
 	// FeedCooldown is min time between feeding same convoy (default "10m").
 	FeedCooldown string `json:"feed_cooldown,omitempty"`
+
+	// Probes configures the deacon's scheduled health probes by name
+	// (tmux, bd_sync, disk_space, orphan_processes, mail_backlog).
+	Probes map[string]*DeaconProbeConfig `json:"probes,omitempty"`
+}
+
+// DeaconProbeConfig overrides a deacon health probe's schedule.
+type DeaconProbeConfig struct {
+	// Enabled turns the probe off when false (default true).
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Interval is how often the probe runs (default per probe).
+	Interval string `json:"interval,omitempty"`
 }
 
 // PolecatThresholds configures polecat session and retry thresholds.
@@ -364,6 +441,14 @@ type PolecatThresholds struct {
 
 	// NamepoolSize is number of name slots in pool (default 50).
 	NamepoolSize *int `json:"namepool_size,omitempty"`
+
+	// StuckRecoveryNudge is the message sent to a stuck polecat during
+	// doctor remediation (default: DefaultPolecatStuckRecoveryNudge).
+	StuckRecoveryNudge string `json:"stuck_recovery_nudge,omitempty"`
+
+	// StuckRecoveryTimeout is how long remediation waits after the recovery
+	// nudge before restarting the session (default "2m").
+	StuckRecoveryTimeout string `json:"stuck_recovery_timeout,omitempty"`
 }
 
 // DoltThresholds configures Dolt server operation thresholds.
@@ -379,6 +464,14 @@ type DoltThresholds struct {
 
 	// SlowQueryThreshold is duration above which a query is flagged slow (default "1s").
 	SlowQueryThreshold string `json:"slow_query_threshold,omitempty"`
+
+	// DivergenceMaxCommits is how many beads commits a database may be ahead
+	// of or behind its remote before doctor warns (default 50).
+	DivergenceMaxCommits *int `json:"divergence_max_commits,omitempty"`
+
+	// DivergenceMaxSyncAge is how long unpushed beads commits may wait for
+	// a sync before doctor warns (default "24h").
+	DivergenceMaxSyncAge string `json:"divergence_max_sync_age,omitempty"`
 }
 
 // MailThresholds configures mail system thresholds.
@@ -394,6 +487,14 @@ type MailThresholds struct {
 
 	// MaxConcurrentAckOps is max concurrent mail acknowledge operations (default 8).
 	MaxConcurrentAckOps *int `json:"max_concurrent_ack_ops,omitempty"`
+
+	// NotifyLatencySLO is the p95 limit on urgent mail-to-nudge delivery
+	// latency checked by gt doctor (default "2m").
+	NotifyLatencySLO string `json:"notify_latency_slo,omitempty"`
+
+	// NotifyLatencyWindow is how far back delivery samples count toward the
+	// SLO (default "24h").
+	NotifyLatencyWindow string `json:"notify_latency_window,omitempty"`
 }
 
 // WebThresholds configures web API thresholds.
@@ -408,6 +509,45 @@ type WebThresholds struct {
 	MaxBodyLen *int `json:"max_body_len,omitempty"`
 }
 
+// ResourceThresholds configures the per-agent resource limits that
+// gt status --resources highlights and gt doctor warns about.
+type ResourceThresholds struct {
+	// AgentMaxCPUPercent is the CPU usage (percent of one core, summed over the
+	// session's process tree) above which an agent is flagged (default 200).
+	AgentMaxCPUPercent *int `json:"agent_max_cpu_percent,omitempty"`
+
+	// AgentMaxRSSMB is the resident memory, in MB, summed over the session's
+	// process tree, above which an agent is flagged (default 4096).
+	AgentMaxRSSMB *int `json:"agent_max_rss_mb,omitempty"`
+}
+
+// IncidentThresholds configures retention of incident bundles (diagnostic
+// captures such as stuck-worker and restart scrollback).
+type IncidentThresholds struct {
+	// CompressAfter is the age after which bundles are gzipped (default "72h").
+	CompressAfter string `json:"compress_after,omitempty"`
+
+	// RetainFor is the age after which bundles are deleted (default "720h").
+	RetainFor string `json:"retain_for,omitempty"`
+
+	// ArchiveOnClose archives a bead's incidents when gt close closes the
+	// bead (default true).
+	ArchiveOnClose *bool `json:"archive_on_close,omitempty"`
+}
+
+// RedactionConfig configures the masking of secrets in pane captures,
+// diffs, and other text before it is saved to incident bundles, checkpoint
+// beads, or mail attachments.
+type RedactionConfig struct {
+	// Patterns are extra regular expressions whose matches are masked. A
+	// named group "secret" masks only that part of the match.
+	Patterns []string `json:"patterns,omitempty"`
+
+	// Builtin applies the built-in patterns for common token formats
+	// (default true).
+	Builtin *bool `json:"builtin,omitempty"`
+}
+
 // WitnessThresholds configures witness patrol detection thresholds.
 type WitnessThresholds struct {
 	// StartupStallThreshold is the minimum session age before a session with no
@@ -544,6 +684,28 @@ type RigEntry struct {
 	LocalRepo   string       `json:"local_repo,omitempty"`
 	AddedAt     time.Time    `json:"added_at"`
 	BeadsConfig *BeadsConfig `json:"beads,omitempty"`
+	Host        string       `json:"host,omitempty"` // SSH destination when the rig's agents run on another machine
+
+	// Placement spreads new polecats across several machines by load.
+	Placement *PlacementConfig `json:"placement,omitempty"`
+}
+
+// Placement policies for PlacementConfig.Policy.
+const (
+	PlacementLocal       = "local"        // Always use the rig's host (default)
+	PlacementLeastLoaded = "least-loaded" // Lowest (load + polecats) per CPU
+	PlacementGPU         = "gpu"          // Prefer hosts with an idle GPU
+)
+
+// PlacementConfig selects the machine each new polecat session runs on.
+// Candidate hosts need the same tmux/gt/checkout setup as a rig host.
+type PlacementConfig struct {
+	// Policy is one of the Placement* constants. Default: "local".
+	Policy string `json:"policy,omitempty"`
+
+	// Hosts are additional SSH destinations eligible for polecats. The rig's
+	// own host (or this machine, when the rig has none) is always a candidate.
+	Hosts []string `json:"hosts,omitempty"`
 }
 
 // BeadsConfig represents beads configuration for a rig.
@@ -596,6 +758,7 @@ type RigSettings struct {
 	Crew       *CrewConfig       `json:"crew,omitempty"`        // crew startup settings
 	Workflow   *WorkflowConfig   `json:"workflow,omitempty"`    // workflow settings
 	Runtime    *RuntimeConfig    `json:"runtime,omitempty"`     // LLM runtime settings (deprecated: use Agent)
+	Witness    *WitnessSettings  `json:"witness,omitempty"`     // witness escalation rules
 
 	// Agent selects which agent preset to use for this rig.
 	// Can be a built-in preset ("claude", "gemini", "codex", "cursor", "auggie", "amp", "opencode", "copilot")
@@ -637,6 +800,115 @@ type CrewConfig struct {
 	Startup string `json:"startup,omitempty"`
 }
 
+// Witness rule actions.
+const (
+	WitnessActionMailMayor = "mail_mayor" // Mail the mayor about the agent
+	WitnessActionRestart   = "restart"    // Restart the agent's session (polecats only)
+	WitnessActionEscalate  = "escalate"   // Raise the hooked bead's priority one level
+	WitnessActionDetach    = "detach"     // Unhook the agent's bead and return it to the ready queue
+)
+
+// WitnessSettings configures a rig's witness beyond its built-in patrol.
+type WitnessSettings struct {
+	// Rules are evaluated against every agent in the rig on the daemon's
+	// witness_rules patrol timer. Each matching rule fires its action, at
+	// most once per cooldown per agent.
+	Rules []WitnessRule `json:"rules,omitempty"`
+
+	// Permissions is how the witness answers permission prompts an agent
+	// has sat waiting on. Nil leaves them to pane_anomaly events.
+	Permissions *WitnessPermissionPolicy `json:"permissions,omitempty"`
+}
+
+// WitnessPermissionPolicy answers the permission prompts the witness finds
+// agents waiting on: Claude Code tool approvals and other runtimes' y/n
+// gates. Patterns are path.Match patterns on the tool name ("Bash", "Edit",
+// "mcp__github__*"); a generic y/n gate has the tool name "y/n". Deny is
+// checked before Allow. A prompt for a tool in neither list is mailed to the
+// mayor with the captured prompt, at most once per 30m per agent.
+//
+// Example:
+//
+//	{"allow": ["Read", "Edit", "Write", "Bash"], "deny": ["WebFetch"]}
+type WitnessPermissionPolicy struct {
+	Allow []string `json:"allow,omitempty"` // Answered yes
+	Deny  []string `json:"deny,omitempty"`  // Answered no
+}
+
+// Permission decisions returned by WitnessPermissionPolicy.Decide.
+const (
+	PermissionAllow    = "allow"
+	PermissionDeny     = "deny"
+	PermissionEscalate = "escalate"
+)
+
+// Decide returns how the policy answers a prompt for tool.
+func (p *WitnessPermissionPolicy) Decide(tool string) string {
+	match := func(patterns []string) bool {
+		for _, pat := range patterns {
+			if ok, _ := path.Match(pat, tool); ok {
+				return true
+			}
+		}
+		return false
+	}
+	switch {
+	case p == nil || tool == "":
+		return PermissionEscalate
+	case match(p.Deny):
+		return PermissionDeny
+	case match(p.Allow):
+		return PermissionAllow
+	}
+	return PermissionEscalate
+}
+
+// WitnessRule is a declarative witness rule: when every condition in When
+// holds for an agent, the witness performs Do.
+//
+// Example:
+//
+//	{"name": "idle-p0", "when": {"idle_for": "20m", "labels": ["p0"]}, "do": "mail_mayor"}
+type WitnessRule struct {
+	Name     string               `json:"name"`
+	When     WitnessRuleCondition `json:"when"`
+	Do       string               `json:"do"`                 // mail_mayor, restart, escalate, or detach
+	Cooldown string               `json:"cooldown,omitempty"` // Min time between firings per agent (default 30m)
+	Message  string               `json:"message,omitempty"`  // Extra text included in mail
+}
+
+// WitnessRuleCondition lists the conditions of a witness rule. Unset fields
+// match anything; set fields must all hold.
+type WitnessRuleCondition struct {
+	Roles         []string `json:"roles,omitempty"`          // polecat, crew (default: polecat)
+	AgentState    []string `json:"agent_state,omitempty"`    // Agent bead state is one of these
+	IdleFor       string   `json:"idle_for,omitempty"`       // No session activity for at least this long
+	NudgeFailures int      `json:"nudge_failures,omitempty"` // At least this many consecutive failed nudges
+	Labels        []string `json:"labels,omitempty"`         // Hooked bead has all of these labels
+
+	// NoProgressFor matches an agent with hooked work that has shown no
+	// progress (no commits in its worktree, no update to the hooked bead,
+	// no session activity) for at least this long.
+	NoProgressFor string `json:"no_progress_for,omitempty"`
+}
+
+// DefaultWitnessRuleCooldown is how long a rule waits before firing again
+// for the same agent when no cooldown is configured.
+const DefaultWitnessRuleCooldown = 30 * time.Minute
+
+// CooldownD returns the rule's cooldown, or DefaultWitnessRuleCooldown.
+func (r *WitnessRule) CooldownD() time.Duration {
+	return ParseDurationOrDefault(r.Cooldown, DefaultWitnessRuleCooldown)
+}
+
+// RolesOrDefault returns the roles the rule applies to (polecat if unset).
+func (c *WitnessRuleCondition) RolesOrDefault() []string {
+	if len(c.Roles) == 0 {
+		return []string{"polecat"}
+	}
+	return c.Roles
+}
+
 // RuntimeConfig represents LLM runtime configuration for agent sessions.
 // This allows switching between different LLM backends (claude, aider, etc.)
 // without modifying startup code.
@@ -669,6 +941,11 @@ type RuntimeConfig struct {
 	// Default: "arg" for claude/generic, "none" for codex.
 	PromptMode string `json:"prompt_mode,omitempty"`
 
+	// PromptFlag is the flag that passes the initial prompt (e.g., "--prompt").
+	// Empty passes it as a positional argument.
+	// Default: the preset's, by command or provider.
+	PromptFlag string `json:"prompt_flag,omitempty"`
+
 	// Session config controls environment integration for runtime session IDs.
 	Session *RuntimeSessionConfig `json:"session,omitempty"`
 
@@ -678,9 +955,16 @@ type RuntimeConfig struct {
 	// Tmux config controls process detection and readiness heuristics.
 	Tmux *RuntimeTmuxConfig `json:"tmux,omitempty"`
 
+	// ClientHints controls how nudges are typed into the runtime's input.
+	ClientHints *RuntimeClientHints `json:"client_hints,omitempty"`
+
 	// Instructions controls the per-workspace instruction file name.
 	Instructions *RuntimeInstructionsConfig `json:"instructions,omitempty"`
 
+	// Container runs the agent inside a container instead of directly in the
+	// tmux pane. Only polecat sessions honor it.
+	Container *RuntimeContainerConfig `json:"container,omitempty"`
+
 	// ResolvedAgent is the agent name that was resolved during config lookup.
 	// Set by ResolveRoleAgentConfig / resolveAgentConfigInternal so that
 	// BuildStartupCommand can export GT_AGENT for process detection.
@@ -727,6 +1011,31 @@ type RuntimeTmuxConfig struct {
 
 	// ReadyDelayMs is a fixed delay used when prompt detection is unavailable.
 	ReadyDelayMs int `json:"ready_delay_ms,omitempty"`
+
+	// ReadyStableMs, when prompt detection is unavailable, waits (after
+	// ReadyDelayMs) until the pane output has been unchanged this long.
+	// Runtimes with neither a prompt prefix nor a delay use a 2s default.
+	ReadyStableMs int `json:"ready_stable_ms,omitempty"`
+
+	// TypingDelayMs enables char-by-char nudge delivery with this mean delay
+	// between keystrokes, for TUIs that drop or mangle bulk send-keys input.
+	// Zero means bulk delivery (the default).
+	TypingDelayMs int `json:"typing_delay_ms,omitempty"`
+
+	// TypingJitterMs is the maximum random deviation applied to TypingDelayMs.
+	TypingJitterMs int `json:"typing_jitter_ms,omitempty"`
+}
+
+// RuntimeClientHints tells nudge delivery how a runtime's input box takes
+// typed text. The zero value suits Claude Code.
+type RuntimeClientHints struct {
+	// SubmitKey is the tmux key that submits typed input. Default: "Enter".
+	SubmitKey string `json:"submit_key,omitempty"`
+
+	// SkipEscape skips the Escape sent before submitting, which leaves
+	// vim INSERT mode in Claude Code. Set it for runtimes where Escape
+	// clears or cancels the input.
+	SkipEscape bool `json:"skip_escape,omitempty"`
 }
 
 // RuntimeInstructionsConfig controls the name of the role instruction file.
@@ -735,6 +1044,45 @@ type RuntimeInstructionsConfig struct {
 	File string `json:"file,omitempty"`
 }
 
+// RuntimeContainerConfig launches the agent in a container. The polecat's
+// worktree and the town root are bind-mounted at their host paths, so gt, bd,
+// and git inside the container see the same layout as on the host.
+type RuntimeContainerConfig struct {
+	// Engine is the container CLI: "docker" (default) or "podman".
+	Engine string `json:"engine,omitempty"`
+
+	// Image is the container image. It must provide the agent command,
+	// gt, bd, git, and a POSIX shell.
+	Image string `json:"image"`
+
+	// User is passed to --user. Default: the host uid:gid, so files written
+	// to the worktree stay owned by the host user.
+	User string `json:"user,omitempty"`
+
+	// Network is passed to --network. Default: "host", so the agent can reach
+	// the town's Dolt server on localhost.
+	Network string `json:"network,omitempty"`
+
+	// CPUs, Memory, and PidsLimit map to --cpus, --memory, and --pids-limit.
+	CPUs      string `json:"cpus,omitempty"`
+	Memory    string `json:"memory,omitempty"`
+	PidsLimit int    `json:"pids_limit,omitempty"`
+
+	// Mounts are extra -v specs (e.g., "~/.claude:/home/agent/.claude").
+	Mounts []string `json:"mounts,omitempty"`
+
+	// Args are extra arguments inserted before the image.
+	Args []string `json:"args,omitempty"`
+}
+
+// EngineOrDefault returns the container engine, defaulting to docker.
+func (c *RuntimeContainerConfig) EngineOrDefault() string {
+	if c.Engine == "" {
+		return "docker"
+	}
+	return c.Engine
+}
+
 // DefaultRuntimeConfig returns a RuntimeConfig with sensible defaults.
 func DefaultRuntimeConfig() *RuntimeConfig {
 	return normalizeRuntimeConfig(&RuntimeConfig{Provider: "claude"})
@@ -773,15 +1121,10 @@ func (rc *RuntimeConfig) BuildCommandWithPrompt(prompt string) string {
 		return base
 	}
 
-	// OpenCode requires --prompt flag for initial prompt in interactive mode.
-	// Positional argument causes opencode to exit immediately.
-	if resolved.Command == "opencode" {
-		return base + " --prompt " + quoteForShell(p)
-	}
-
-	// Copilot  requires -i flag for initial prompt in interactive mode.
-	if resolved.Command == "copilot" {
-		return base + " -i " + quoteForShell(p)
+	// Some runtimes take the interactive prompt by flag (opencode exits
+	// immediately on a positional one).
+	if resolved.PromptFlag != "" {
+		return base + " " + resolved.PromptFlag + " " + quoteForShell(p)
 	}
 
 	// Quote the prompt for shell safety (positional arg for claude and others)
@@ -799,6 +1142,9 @@ func (rc *RuntimeConfig) BuildArgsWithPrompt(prompt string) []string {
 	}
 
 	if p != "" && resolved.PromptMode != "none" {
+		if resolved.PromptFlag != "" {
+			args = append(args, resolved.PromptFlag)
+		}
 		args = append(args, p)
 	}
 
@@ -831,6 +1177,10 @@ func normalizeRuntimeConfig(rc *RuntimeConfig) *RuntimeConfig {
 		i := *rc.Instructions
 		rc.Instructions = &i
 	}
+	if rc.ClientHints != nil {
+		h := *rc.ClientHints
+		rc.ClientHints = &h
+	}
 
 	if rc.Provider == "" {
 		rc.Provider = "claude"
@@ -848,6 +1198,10 @@ func normalizeRuntimeConfig(rc *RuntimeConfig) *RuntimeConfig {
 		rc.PromptMode = defaultPromptMode(rc.Provider)
 	}
 
+	if rc.PromptFlag == "" {
+		rc.PromptFlag = defaultPromptFlag(rc.Provider, rc.Command)
+	}
+
 	if rc.Session == nil {
 		rc.Session = &RuntimeSessionConfig{}
 	}
@@ -899,6 +1253,10 @@ func normalizeRuntimeConfig(rc *RuntimeConfig) *RuntimeConfig {
 		rc.Tmux.ReadyDelayMs = defaultReadyDelayMs(rc.Provider)
 	}
 
+	if rc.ClientHints == nil {
+		rc.ClientHints = defaultClientHints(rc.Provider, rc.Command)
+	}
+
 	if rc.Instructions == nil {
 		rc.Instructions = &RuntimeInstructionsConfig{}
 	}
@@ -964,6 +1322,33 @@ func defaultPromptMode(provider string) string {
 	return "arg"
 }
 
+// commandPreset returns the preset for a runtime's command when it is a
+// known agent binary, else the provider's preset. A custom agent that runs
+// opencode gets opencode's prompt handling even under another provider.
+func commandPreset(provider, command string) *AgentPresetInfo {
+	if command != "" {
+		if preset := GetAgentPresetByName(filepath.Base(command)); preset != nil {
+			return preset
+		}
+	}
+	return GetAgentPresetByName(provider)
+}
+
+func defaultPromptFlag(provider, command string) string {
+	if preset := commandPreset(provider, command); preset != nil {
+		return preset.PromptFlag
+	}
+	return ""
+}
+
+func defaultClientHints(provider, command string) *RuntimeClientHints {
+	if preset := commandPreset(provider, command); preset != nil && preset.ClientHints != nil {
+		h := *preset.ClientHints
+		return &h
+	}
+	return nil
+}
+
 func defaultSessionIDEnv(provider string) string {
 	if preset := GetAgentPresetByName(provider); preset != nil {
 		return preset.SessionIDEnv
@@ -1119,6 +1504,18 @@ type MergeQueueConfig struct {
 	// Nil defaults to false (manual landing required).
 	IntegrationBranchAutoLand *bool `json:"integration_branch_auto_land,omitempty"`
 
+	// IntegrationBranchReview controls whether an integration branch needs
+	// the witness's approval (gt review) before it lands. The refinery
+	// requests the review once the epic's work is merged.
+	// Nil defaults to false (no review required).
+	IntegrationBranchReview *bool `json:"integration_branch_review,omitempty"`
+
+	// IntegrationBranchAging sets the policies `gt branches` applies to
+	// unmerged integration branches as they age.
+	// Nil uses DefaultBranchAgingConfig (warn at 3 days, mail at 7, flag
+	// for deletion at 14).
+	IntegrationBranchAging *BranchAgingConfig `json:"integration_branch_aging,omitempty"`
+
 	// OnConflict specifies conflict resolution strategy: "assign_back" or "auto_rebase".
 	OnConflict string `json:"on_conflict"`
 
@@ -1129,16 +1526,20 @@ type MergeQueueConfig struct {
 	// TestCommand is the command to run for tests.
 	TestCommand string `json:"test_command,omitempty"`
 
-	// LintCommand is the command to run for linting (used by formulas).
+	// LintCommand is the command to run for linting. The refinery runs it
+	// before merging, after setup and typecheck (and formulas use it).
 	LintCommand string `json:"lint_command,omitempty"`
 
-	// BuildCommand is the command to run for building (used by formulas).
+	// BuildCommand is the command to run for building. The refinery runs it
+	// before merging, after lint (and formulas use it).
 	BuildCommand string `json:"build_command,omitempty"`
 
 	// SetupCommand is the command to run for project setup (e.g., pnpm install).
+	// The refinery runs it first, before the other check commands.
 	SetupCommand string `json:"setup_command,omitempty"`
 
 	// TypecheckCommand is the command to run for type checking (e.g., tsc --noEmit).
+	// The refinery runs it before merging, after setup.
 	TypecheckCommand string `json:"typecheck_command,omitempty"`
 
 	// DeleteMergedBranches controls whether to delete branches after merging.
@@ -1193,6 +1594,49 @@ func (c *MergeQueueConfig) IsIntegrationBranchAutoLandEnabled() bool {
 	return *c.IntegrationBranchAutoLand
 }
 
+// IsIntegrationBranchReviewEnabled returns whether integration branches need
+// witness review before landing. Nil-safe, defaults to false.
+func (c *MergeQueueConfig) IsIntegrationBranchReviewEnabled() bool {
+	if c.IntegrationBranchReview == nil {
+		return false
+	}
+	return *c.IntegrationBranchReview
+}
+
+// BranchAgingConfig sets age thresholds, in days, for unmerged integration
+// branches. A zero threshold disables that policy.
+type BranchAgingConfig struct {
+	// WarnDays is the age at which a branch is reported as stale.
+	WarnDays int `json:"warn_days"`
+
+	// MailDays is the age at which the branch's owning agent is mailed
+	// (once per branch) by `gt branches --notify`, which the daemon's
+	// branch_aging patrol runs.
+	MailDays int `json:"mail_days"`
+
+	// DeleteDays is the age at which a branch is flagged for deletion.
+	DeleteDays int `json:"delete_days"`
+}
+
+// DefaultBranchAgingConfig returns the default integration branch aging
+// policies.
+func DefaultBranchAgingConfig() *BranchAgingConfig {
+	return &BranchAgingConfig{
+		WarnDays:   3,
+		MailDays:   7,
+		DeleteDays: 14,
+	}
+}
+
+// GetIntegrationBranchAging returns the integration branch aging policies.
+// Nil-safe, defaults to DefaultBranchAgingConfig.
+func (c *MergeQueueConfig) GetIntegrationBranchAging() *BranchAgingConfig {
+	if c == nil || c.IntegrationBranchAging == nil {
+		return DefaultBranchAgingConfig()
+	}
+	return c.IntegrationBranchAging
+}
+
 // IsRunTestsEnabled returns whether tests should run before merging.
 // Nil-safe, defaults to true.
 func (c *MergeQueueConfig) IsRunTestsEnabled() bool {
@@ -1340,6 +1784,80 @@ type MessagingConfig struct {
 	// Like mailing lists but for tmux send-keys instead of durable mail.
 	// Example: {"workers": ["gastown/polecats/*", "gastown/crew/*"], "witnesses": ["*/witness"]}
 	NudgeChannels map[string][]string `json:"nudge_channels,omitempty"`
+
+	// Rules are per-agent mail rules, evaluated in order when a message is
+	// delivered to an agent they apply to. Every matching rule's actions are
+	// applied unless a rule sets stop.
+	// Example: [{"agent": "mayor/", "match": {"from": "*/witness", "subject": "^Patrol"}, "archive": true}]
+	Rules []MailRule `json:"rules,omitempty"`
+
+	// ACL restricts who may send direct mail to whom. Entries are checked
+	// in order and the first whose from and to both match decides; mail
+	// no entry matches is allowed.
+	// Example: [{"from": "role:polecat", "to": "deacon/", "action": "deny"}]
+	ACL []MailACLRule `json:"acl,omitempty"`
+}
+
+// MailRule is a filter on an agent's incoming mail: when a message matches,
+// its actions run on delivery.
+type MailRule struct {
+	// Name identifies the rule in output (optional).
+	Name string `json:"name,omitempty"`
+
+	// Agent is the recipient address the rule applies to. '*' matches any
+	// single path segment: "mayor/", "*/witness", "gastown/polecats/*".
+	Agent string `json:"agent"`
+
+	// Match selects messages. Every field that is set must match; an empty
+	// match matches all mail to the agent.
+	Match MailRuleMatch `json:"match"`
+
+	// Archive files the message without notifying the agent.
+	Archive bool `json:"archive,omitempty"`
+
+	// Forward sends a copy to this address.
+	Forward string `json:"forward,omitempty"`
+
+	// Priority raises the message to at least this priority
+	// (low, normal, high, urgent). It never lowers it.
+	Priority string `json:"priority,omitempty"`
+
+	// AutoReply sends this text back to the sender.
+	AutoReply string `json:"auto_reply,omitempty"`
+
+	// Nudge alerts this address about the message.
+	Nudge string `json:"nudge,omitempty"`
+
+	// Stop skips the rules after this one when it matches.
+	Stop bool `json:"stop,omitempty"`
+}
+
+// MailRuleMatch is the condition part of a MailRule.
+type MailRuleMatch struct {
+	// From is a sender address pattern, with '*' per segment.
+	From string `json:"from,omitempty"`
+
+	// Subject is a regular expression matched against the subject.
+	Subject string `json:"subject,omitempty"`
+
+	// Labels must all be on the message (e.g., "thread:t-123").
+	Labels []string `json:"labels,omitempty"`
+}
+
+// Mail ACL actions.
+const (
+	MailACLAllow = "allow"
+	MailACLDeny  = "deny"
+)
+
+// MailACLRule allows or denies direct mail between matching addresses.
+// From and To are address patterns with '*' per segment ("gastown/*",
+// "*/witness"), or "role:<role>" for every agent of a role (mayor, deacon,
+// witness, refinery, crew, polecat, overseer).
+type MailACLRule struct {
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Action string `json:"action"` // allow or deny
 }
 
 // QueueConfig represents a work queue configuration.
@@ -1407,8 +1925,30 @@ type EscalationConfig struct {
 	// re-escalated. Default: 2 (low→medium→high, then stops)
 	// Pointer type to distinguish "not configured" (nil) from explicit 0.
 	MaxReescalations *int `json:"max_reescalations,omitempty"`
+
+	// Chains are named escalation chains: who hears about an escalation
+	// first, and how long each level has to acknowledge it before the next
+	// level is notified. Selected with gt escalate --chain; a chain named
+	// "default" applies to escalations created without --chain.
+	// Example: {"default": [{"target": "gastown/crew/max", "sla": "30m"},
+	//   {"target": "gastown/witness", "sla": "1h"}, {"target": "mayor/"}]}
+	Chains map[string][]EscalationChainLevel `json:"chains,omitempty"`
+}
+
+// EscalationChainLevel is one step of an escalation chain.
+type EscalationChainLevel struct {
+	// Target is the mail address notified at this level.
+	Target string `json:"target"`
+
+	// SLA is how long this level has to acknowledge before the escalation
+	// moves to the next level (Go duration). Required on every level but
+	// the last, where it is ignored.
+	SLA string `json:"sla,omitempty"`
 }
 
+// DefaultEscalationChain is the chain used when gt escalate gets no --chain.
+const DefaultEscalationChain = "default"
+
 // EscalationContacts contains contact information for external notification channels.
 type EscalationContacts struct {
 	HumanEmail   string `json:"human_email,omitempty"`   // email address for email:human action
@@ -1457,6 +1997,67 @@ func NextSeverity(severity string) string {
 	}
 }
 
+// ParseEscalationChain parses an inline chain spec: comma-separated
+// levels of "<target>=<sla>", the last of which may omit the SLA
+// (e.g., "gastown/crew/max=30m,gastown/witness=1h,mayor/").
+func ParseEscalationChain(spec string) ([]EscalationChainLevel, error) {
+	var levels []EscalationChainLevel
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		target, sla, _ := strings.Cut(part, "=")
+		levels = append(levels, EscalationChainLevel{
+			Target: strings.TrimSpace(target),
+			SLA:    strings.TrimSpace(sla),
+		})
+	}
+	if err := ValidateEscalationChain(levels); err != nil {
+		return nil, err
+	}
+	return levels, nil
+}
+
+// FormatEscalationChain is the inverse of ParseEscalationChain.
+func FormatEscalationChain(levels []EscalationChainLevel) string {
+	parts := make([]string, len(levels))
+	for i, l := range levels {
+		parts[i] = l.Target
+		if l.SLA != "" && i < len(levels)-1 {
+			parts[i] += "=" + l.SLA
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// ValidateEscalationChain checks that a chain has at least one level, every
+// level has a target, and every level but the last has a positive SLA.
+func ValidateEscalationChain(levels []EscalationChainLevel) error {
+	if len(levels) == 0 {
+		return fmt.Errorf("escalation chain has no levels")
+	}
+	for i, l := range levels {
+		if l.Target == "" {
+			return fmt.Errorf("escalation chain level %d: missing target", i+1)
+		}
+		if i == len(levels)-1 && l.SLA == "" {
+			continue
+		}
+		if l.SLA == "" {
+			return fmt.Errorf("escalation chain level %d (%s): missing sla", i+1, l.Target)
+		}
+		d, err := time.ParseDuration(l.SLA)
+		if err != nil {
+			return fmt.Errorf("escalation chain level %d (%s): invalid sla: %w", i+1, l.Target, err)
+		}
+		if d <= 0 {
+			return fmt.Errorf("escalation chain level %d (%s): sla must be positive", i+1, l.Target)
+		}
+	}
+	return nil
+}
+
 // intPtr returns a pointer to the given int value.
 func intPtr(v int) *int { return &v }
 
@@ -1476,3 +2077,129 @@ func NewEscalationConfig() *EscalationConfig {
 		MaxReescalations: intPtr(2),
 	}
 }
+
+// Webhook payload formats.
+const (
+	WebhookFormatJSON    = "json"    // The event as JSON (default)
+	WebhookFormatSlack   = "slack"   // Slack incoming webhook: {"text": ...}
+	WebhookFormatDiscord = "discord" // Discord webhook: {"content": ...}
+)
+
+// DefaultWebhookRetries is how many times a failed delivery is retried when
+// WebhookConfig.Retries is unset.
+const DefaultWebhookRetries = 3
+
+// NotificationsConfig configures webhook notifications for town events.
+// Stored as "notifications" in settings/config.json; delivered by the
+// daemon's webhooks patrol.
+type NotificationsConfig struct {
+	Webhooks []WebhookConfig `json:"webhooks,omitempty"`
+}
+
+// WebhookConfig is one notification endpoint.
+type WebhookConfig struct {
+	// Name identifies the webhook in logs and gt webhook test.
+	Name string `json:"name"`
+
+	// URL receives an HTTP POST per matching event.
+	URL string `json:"url"`
+
+	// Format is "json" (default), "slack", or "discord".
+	Format string `json:"format,omitempty"`
+
+	// Events lists the event types to send, as path.Match patterns
+	// (e.g., "session_death", "merge_*", "*"). Defaults to the events that
+	// usually need a human: open nudge circuits, agent deaths, stuck workers,
+	// molecule completion, and preflight warnings.
+	Events []string `json:"events,omitempty"`
+
+	// Template is a text/template for the message. It sees the event's
+	// fields (.Type, .Actor, .Timestamp, .Payload) plus .Summary and .Town.
+	// For slack and discord it renders the message text; for json it renders
+	// the entire request body.
+	Template string `json:"template,omitempty"`
+
+	// Headers are added to each request (e.g., an Authorization header).
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// Retries is how many times a failed delivery is retried, with
+	// exponential backoff (default 3; -1 disables retries).
+	Retries int `json:"retries,omitempty"`
+}
+
+// Validate checks the webhook config for errors.
+func (c *NotificationsConfig) Validate() error {
+	if c == nil {
+		return nil
+	}
+	names := make(map[string]bool)
+	for i, w := range c.Webhooks {
+		label := w.Name
+		if label == "" {
+			label = fmt.Sprintf("#%d", i)
+		}
+		if w.Name != "" {
+			if names[w.Name] {
+				return fmt.Errorf("notifications.webhooks: duplicate name %q", w.Name)
+			}
+			names[w.Name] = true
+		}
+		u, err := url.Parse(w.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("notifications.webhooks[%s]: url %q is not an http(s) URL", label, w.URL)
+		}
+		switch w.Format {
+		case "", WebhookFormatJSON, WebhookFormatSlack, WebhookFormatDiscord:
+		default:
+			return fmt.Errorf("notifications.webhooks[%s]: format %q is not json, slack, or discord", label, w.Format)
+		}
+		for _, p := range w.Events {
+			if _, err := path.Match(p, ""); err != nil {
+				return fmt.Errorf("notifications.webhooks[%s]: bad event pattern %q: %w", label, p, err)
+			}
+		}
+		if w.Template != "" {
+			if _, err := template.New(label).Parse(w.Template); err != nil {
+				return fmt.Errorf("notifications.webhooks[%s]: template: %w", label, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Enabled reports whether any webhook is configured.
+func (c *NotificationsConfig) Enabled() bool {
+	return c != nil && len(c.Webhooks) > 0
+}
+
+// Find returns the webhook with the given name.
+func (c *NotificationsConfig) Find(name string) (WebhookConfig, bool) {
+	if c != nil {
+		for _, w := range c.Webhooks {
+			if w.Name == name {
+				return w, true
+			}
+		}
+	}
+	return WebhookConfig{}, false
+}
+
+// GetFormat returns Format or WebhookFormatJSON if unset.
+func (w WebhookConfig) GetFormat() string {
+	if w.Format == "" {
+		return WebhookFormatJSON
+	}
+	return w.Format
+}
+
+// GetRetries returns Retries, DefaultWebhookRetries if unset, or 0 if negative.
+func (w WebhookConfig) GetRetries() int {
+	switch {
+	case w.Retries < 0:
+		return 0
+	case w.Retries == 0:
+		return DefaultWebhookRetries
+	default:
+		return w.Retries
+	}
+}
@@ -581,4 +581,29 @@ func TestParseDurationOrDefault_AllWebTimeoutDefaults(t *testing.T) {
 	}
 }
 
-
+func TestWitnessPermissionPolicyDecide(t *testing.T) {
+	p := &WitnessPermissionPolicy{
+		Allow: []string{"Read", "Bash", "mcp__github__*", "y/n"},
+		Deny:  []string{"Bash", "WebFetch"},
+	}
+	tests := []struct {
+		tool, want string
+	}{
+		{"Read", PermissionAllow},
+		{"mcp__github__create_issue", PermissionAllow},
+		{"y/n", PermissionAllow},
+		{"Bash", PermissionDeny}, // Deny wins
+		{"WebFetch", PermissionDeny},
+		{"Edit", PermissionEscalate},
+		{"", PermissionEscalate},
+	}
+	for _, tt := range tests {
+		if got := p.Decide(tt.tool); got != tt.want {
+			t.Errorf("Decide(%q) = %q, want %q", tt.tool, got, tt.want)
+		}
+	}
+	var none *WitnessPermissionPolicy
+	if got := none.Decide("Read"); got != PermissionEscalate {
+		t.Errorf("nil policy Decide = %q, want escalate", got)
+	}
+}
@@ -0,0 +1,271 @@
+// Package configcheck validates a town's configuration files as a whole:
+// each file against its schema (including keys gt would silently ignore),
+// and the files against each other and the disk — rig names, role names,
+// tmux session names that would collide, and paths that don't exist.
+package configcheck
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/session"
+)
+
+// Severities of an Issue.
+const (
+	SeverityError   = "error"   // gt will fail or misbehave
+	SeverityWarning = "warning" // Probably a mistake, but gt carries on
+)
+
+// Issue is one problem found in the configuration.
+type Issue struct {
+	Severity string `json:"severity"`
+	File     string `json:"file"`          // Relative to the town root
+	Key      string `json:"key,omitempty"` // Dotted path within the file
+	Message  string `json:"message"`
+}
+
+// Report is the outcome of Check.
+type Report struct {
+	TownRoot string   `json:"town_root"`
+	Files    []string `json:"files"` // Files checked, relative to the town root
+	Issues   []Issue  `json:"issues"`
+}
+
+// Errors returns the number of error-severity issues.
+func (r *Report) Errors() int {
+	n := 0
+	for _, i := range r.Issues {
+		if i.Severity == SeverityError {
+			n++
+		}
+	}
+	return n
+}
+
+// IssuesFor returns the issues found in file.
+func (r *Report) IssuesFor(file string) []Issue {
+	var out []Issue
+	for _, i := range r.Issues {
+		if i.File == file {
+			out = append(out, i)
+		}
+	}
+	return out
+}
+
+func (r *Report) add(severity, file, key, format string, args ...interface{}) {
+	r.Issues = append(r.Issues, Issue{Severity: severity, File: file, Key: key, Message: fmt.Sprintf(format, args...)})
+}
+
+// Check validates the configuration of the town at townRoot: mayor/town.json,
+// mayor/rigs.json, settings/config.json, and each rig's settings/config.json.
+func Check(townRoot string) *Report {
+	r := &Report{TownRoot: townRoot}
+
+	townFile := filepath.Join("mayor", "town.json")
+	if r.checkFile(townFile, &config.TownConfig{}, true) {
+		if _, err := config.LoadTownConfig(filepath.Join(townRoot, townFile)); err != nil {
+			r.add(SeverityError, townFile, "", "%v", err)
+		}
+	}
+
+	rigsFile := filepath.Join("mayor", "rigs.json")
+	var rigs *config.RigsConfig
+	if r.checkFile(rigsFile, &config.RigsConfig{}, true) {
+		var err error
+		if rigs, err = config.LoadRigsConfig(filepath.Join(townRoot, rigsFile)); err != nil {
+			r.add(SeverityError, rigsFile, "", "%v", err)
+		}
+	}
+
+	settingsFile := filepath.Join("settings", "config.json")
+	if r.checkFile(settingsFile, &config.TownSettings{}, false) {
+		if s, err := config.LoadOrCreateTownSettings(filepath.Join(townRoot, settingsFile)); err == nil {
+			r.checkRoleKeys(settingsFile, "role_agents", s.RoleAgents)
+			r.checkRedactionPatterns(settingsFile, s.Operational.GetRedactionConfig().Patterns)
+		}
+	}
+
+	if rigs == nil {
+		return r
+	}
+	names := make([]string, 0, len(rigs.Rigs))
+	for name := range rigs.Rigs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		r.checkRig(rigsFile, name, rigs.Rigs[name])
+	}
+	r.checkSessionNames(rigsFile, names, rigs)
+	return r
+}
+
+// checkFile records file as checked and reports keys its type doesn't
+// decode. Returns false if the file is missing or isn't valid JSON, in
+// which case there's nothing more to check.
+func (r *Report) checkFile(file string, v interface{}, required bool) bool {
+	data, err := os.ReadFile(filepath.Join(r.TownRoot, file)) //nolint:gosec // G304: town config files
+	if err != nil {
+		if os.IsNotExist(err) {
+			if required {
+				r.Files = append(r.Files, file)
+				r.add(SeverityError, file, "", "missing")
+			}
+			return false
+		}
+		r.Files = append(r.Files, file)
+		r.add(SeverityError, file, "", "%v", err)
+		return false
+	}
+	r.Files = append(r.Files, file)
+	unknown, err := config.UnknownKeys(data, v)
+	if err != nil {
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			r.add(SeverityError, file, "", "invalid JSON at byte %d: %v", syntaxErr.Offset, err)
+		} else {
+			r.add(SeverityError, file, "", "invalid JSON: %v", err)
+		}
+		return false
+	}
+	for _, key := range unknown {
+		r.add(SeverityWarning, file, key, "unknown key (ignored by gt)")
+	}
+	return true
+}
+
+// checkRedactionPatterns reports redaction patterns that don't compile;
+// gt skips them, so those secrets would go unmasked.
+func (r *Report) checkRedactionPatterns(file string, patterns []string) {
+	for i, p := range patterns {
+		if _, err := regexp.Compile(p); err != nil {
+			r.add(SeverityError, file, fmt.Sprintf("operational.redaction.patterns.%d", i), "invalid regex: %v", err)
+		}
+	}
+}
+
+// checkRoleKeys reports keys of a role-keyed map that aren't roles.
+func (r *Report) checkRoleKeys(file, key string, m map[string]string) {
+	roles := config.AllRoles()
+	for role := range m {
+		if !slices.Contains(roles, role) {
+			r.add(SeverityError, file, key+"."+role, "unknown role %q (want one of %s)", role, strings.Join(roles, ", "))
+		}
+	}
+}
+
+// checkRig checks one rigs.json entry against the disk and the rig's own
+// settings.
+func (r *Report) checkRig(rigsFile, name string, entry config.RigEntry) {
+	key := "rigs." + name
+	if strings.ContainsAny(name, "-. ") {
+		r.add(SeverityError, rigsFile, key, "invalid rig name: hyphens, dots, and spaces break agent IDs")
+	}
+	if strings.EqualFold(name, "hq") {
+		r.add(SeverityError, rigsFile, key, "rig name %q is reserved for the town", name)
+	}
+	if entry.Host == "" {
+		if info, err := os.Stat(filepath.Join(r.TownRoot, name)); err != nil || !info.IsDir() {
+			r.add(SeverityError, rigsFile, key, "rig directory %s does not exist", filepath.Join(r.TownRoot, name))
+			return
+		}
+	}
+	if entry.LocalRepo != "" {
+		if _, err := os.Stat(entry.LocalRepo); err != nil {
+			r.add(SeverityWarning, rigsFile, key+".local_repo", "%s does not exist", entry.LocalRepo)
+		}
+	}
+	if p := localGitPath(entry.GitURL); p != "" {
+		if _, err := os.Stat(p); err != nil {
+			r.add(SeverityWarning, rigsFile, key+".git_url", "%s does not exist", p)
+		}
+	}
+	if entry.BeadsConfig == nil || entry.BeadsConfig.Prefix == "" {
+		r.add(SeverityWarning, rigsFile, key+".beads.prefix", "no beads prefix; sessions fall back to %q", session.DefaultPrefix)
+	}
+
+	settingsFile := filepath.Join(name, "settings", "config.json")
+	if !r.checkFile(settingsFile, &config.RigSettings{}, false) {
+		return
+	}
+	settings, err := config.LoadRigSettings(filepath.Join(r.TownRoot, settingsFile))
+	if err != nil {
+		r.add(SeverityError, settingsFile, "", "%v", err)
+		return
+	}
+	r.checkRoleKeys(settingsFile, "role_agents", settings.RoleAgents)
+}
+
+// localGitPath returns the filesystem path of a local git URL (an absolute
+// path or file:// URL), or "" for remote URLs.
+func localGitPath(url string) string {
+	if p, ok := strings.CutPrefix(url, "file://"); ok {
+		return p
+	}
+	if filepath.IsAbs(url) {
+		return url
+	}
+	return ""
+}
+
+// checkSessionNames reports agents that would get the same tmux session
+// name, which makes one shadow the other: two rigs sharing a beads prefix,
+// or a polecat named after a rig role ("witness") or a crew session.
+func (r *Report) checkSessionNames(rigsFile string, rigNames []string, rigs *config.RigsConfig) {
+	owners := map[string]string{
+		session.MayorSessionName():    "mayor",
+		session.DeaconSessionName():   "deacon",
+		session.OverseerSessionName(): "overseer",
+	}
+	claim := func(sess, owner, file, key string) {
+		if prev, ok := owners[sess]; ok && prev != owner {
+			r.add(SeverityError, file, key, "session name %q is used by both %s and %s", sess, prev, owner)
+			return
+		}
+		owners[sess] = owner
+	}
+
+	for _, name := range rigNames {
+		prefix := session.DefaultPrefix
+		if bc := rigs.Rigs[name].BeadsConfig; bc != nil && bc.Prefix != "" {
+			prefix = bc.Prefix
+		}
+		key := "rigs." + name + ".beads.prefix"
+		claim(session.WitnessSessionName(prefix), name+"/witness", rigsFile, key)
+		claim(session.RefinerySessionName(prefix), name+"/refinery", rigsFile, key)
+		for _, crew := range agentDirs(filepath.Join(r.TownRoot, name, constants.DirCrew)) {
+			claim(session.CrewSessionName(prefix, crew), name+"/crew/"+crew,
+				filepath.Join(name, constants.DirCrew, crew), "")
+		}
+		for _, polecat := range agentDirs(filepath.Join(r.TownRoot, name, constants.DirPolecats)) {
+			claim(session.PolecatSessionName(prefix, polecat), name+"/polecats/"+polecat,
+				filepath.Join(name, constants.DirPolecats, polecat), "")
+		}
+	}
+}
+
+// agentDirs lists the agent workspaces in dir.
+func agentDirs(dir string) []string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, e := range entries {
+		if e.IsDir() && !strings.HasPrefix(e.Name(), ".") {
+			names = append(names, e.Name())
+		}
+	}
+	return names
+}
@@ -0,0 +1,104 @@
+package configcheck
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeFile(t *testing.T, path, data string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func hasIssue(r *Report, severity, file, key string) bool {
+	for _, i := range r.Issues {
+		if i.Severity == severity && i.File == file && i.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+func TestCheck_Clean(t *testing.T) {
+	town := t.TempDir()
+	writeFile(t, filepath.Join(town, "mayor", "town.json"), `{"type": "town", "version": 2, "name": "hq"}`)
+	writeFile(t, filepath.Join(town, "mayor", "rigs.json"), `{"version": 1, "rigs": {"gastown": {"git_url": "https://example.com/g.git", "beads": {"repo": "local", "prefix": "gt"}}}}`)
+	if err := os.MkdirAll(filepath.Join(town, "gastown", "crew", "max"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	r := Check(town)
+	if len(r.Issues) != 0 {
+		t.Errorf("Issues = %+v, want none", r.Issues)
+	}
+	if len(r.Files) != 2 {
+		t.Errorf("Files = %v, want town.json and rigs.json", r.Files)
+	}
+}
+
+func TestCheck_Problems(t *testing.T) {
+	town := t.TempDir()
+	rigsFile := filepath.Join("mayor", "rigs.json")
+	writeFile(t, filepath.Join(town, "mayor", "town.json"), `{"type": "town", "version": 2, "name": "hq", "ownr": "x"}`)
+	writeFile(t, filepath.Join(town, rigsFile), `{"version": 1, "rigs": {
+		"alpha": {"git_url": "/nonexistent/alpha.git", "beads": {"prefix": "ap", "prefx": "x"}},
+		"beta":  {"git_url": "https://example.com/b.git", "beads": {"prefix": "ap"}},
+		"my-rig": {"git_url": "https://example.com/m.git", "beads": {"prefix": "mr"}}
+	}}`)
+	for _, dir := range []string{"alpha/polecats/witness", "beta", "my-rig"} {
+		if err := os.MkdirAll(filepath.Join(town, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeFile(t, filepath.Join(town, "settings", "config.json"), `{"type": "town-settings", "version": 1, "role_agents": {"polecats": "claude"},
+		"operational": {"redaction": {"patterns": ["ok-[0-9]+", "(unclosed"]}}}`)
+
+	r := Check(town)
+	for _, want := range []struct{ severity, file, key string }{
+		{SeverityWarning, filepath.Join("mayor", "town.json"), "ownr"},
+		{SeverityWarning, rigsFile, "rigs.alpha.beads.prefx"},
+		{SeverityWarning, rigsFile, "rigs.alpha.git_url"},
+		{SeverityError, rigsFile, "rigs.beta.beads.prefix"},                // Shares alpha's prefix
+		{SeverityError, filepath.Join("alpha", "polecats", "witness"), ""}, // Collides with the witness session
+		{SeverityError, rigsFile, "rigs.my-rig"},                           // Invalid rig name
+		{SeverityError, filepath.Join("settings", "config.json"), "role_agents.polecats"},
+		{SeverityError, filepath.Join("settings", "config.json"), "operational.redaction.patterns.1"},
+	} {
+		if !hasIssue(r, want.severity, want.file, want.key) {
+			t.Errorf("missing %s for %s %s; issues: %+v", want.severity, want.file, want.key, r.Issues)
+		}
+	}
+	if r.Errors() == 0 {
+		t.Error("Errors() = 0, want errors")
+	}
+}
+
+func TestCheck_MissingRigDir(t *testing.T) {
+	town := t.TempDir()
+	writeFile(t, filepath.Join(town, "mayor", "town.json"), `{"name": "hq"}`)
+	writeFile(t, filepath.Join(town, "mayor", "rigs.json"), `{"rigs": {"gone": {"git_url": "x", "beads": {"prefix": "gn"}}}}`)
+
+	r := Check(town)
+	if !hasIssue(r, SeverityError, filepath.Join("mayor", "rigs.json"), "rigs.gone") {
+		t.Errorf("issues = %+v, want missing rig directory error", r.Issues)
+	}
+}
+
+func TestCheck_InvalidJSON(t *testing.T) {
+	town := t.TempDir()
+	writeFile(t, filepath.Join(town, "mayor", "town.json"), `{"name": "hq",}`)
+
+	r := Check(town)
+	if !hasIssue(r, SeverityError, filepath.Join("mayor", "town.json"), "") {
+		t.Errorf("issues = %+v, want invalid JSON error", r.Issues)
+	}
+	if !hasIssue(r, SeverityError, filepath.Join("mayor", "rigs.json"), "") {
+		t.Errorf("issues = %+v, want missing rigs.json error", r.Issues)
+	}
+}
@@ -0,0 +1,322 @@
+// Package configwatch keeps long-running processes in step with the town's
+// configuration files. A Watcher loads them into a versioned Snapshot,
+// watches them with fsnotify, and when one changes reloads, hands the new
+// snapshot to subscribers, and logs a config_reloaded event.
+package configwatch
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/events"
+)
+
+// Files watched, relative to the town root. Each rig's settings file
+// (<rig>/settings/config.json) is watched too.
+var (
+	TownFile     = filepath.Join("mayor", "town.json")
+	RigsFile     = filepath.Join("mayor", "rigs.json")
+	DaemonFile   = filepath.Join("mayor", "daemon.json")
+	SettingsFile = filepath.Join("settings", "config.json")
+)
+
+// RigSettingsFile returns the path of a rig's settings file, relative to
+// the town root.
+func RigSettingsFile(rig string) string {
+	return filepath.Join(rig, "settings", "config.json")
+}
+
+// DefaultDebounce is how long a Watcher waits after a change for further
+// changes before reloading. Editors often save a file in several writes.
+const DefaultDebounce = 250 * time.Millisecond
+
+// Snapshot is the town's configuration as loaded at one point in time.
+// Snapshots are never modified; a reload produces a new one.
+type Snapshot struct {
+	Version  int // 1 for the initial load, incremented by each reload
+	LoadedAt time.Time
+	Changed  []string // Files that differ from the previous snapshot (all files for the first)
+
+	Town        *config.TownConfig
+	Rigs        *config.RigsConfig
+	Settings    *config.TownSettings           // Defaults if the town has no settings file
+	RigSettings map[string]*config.RigSettings // By rig name; rigs without a settings file are absent
+
+	digests map[string][sha256.Size]byte // File contents, by file; missing files are absent
+}
+
+// HasChanged reports whether file (relative to the town root) changed in
+// this snapshot.
+func (s *Snapshot) HasChanged(file string) bool {
+	return slices.Contains(s.Changed, file)
+}
+
+// files returns the files a snapshot depends on, relative to the town root.
+func (s *Snapshot) files() []string {
+	files := []string{TownFile, RigsFile, DaemonFile, SettingsFile}
+	if s != nil && s.Rigs != nil {
+		for rig := range s.Rigs.Rigs {
+			files = append(files, RigSettingsFile(rig))
+		}
+	}
+	return files
+}
+
+// Watcher keeps a current Snapshot of a town's configuration.
+type Watcher struct {
+	// Debounce is how long to wait after a change for more before reloading.
+	Debounce time.Duration
+	// OnError, if set, is called when a reload fails or the file watch
+	// reports an error. The previous snapshot stays current, so a file saved
+	// mid-edit with a syntax error doesn't take effect.
+	OnError func(error)
+	// Actor is the actor of the config_reloaded events logged on reload.
+	Actor string
+
+	townRoot string
+
+	mu      sync.Mutex
+	current *Snapshot
+	subs    map[int]func(*Snapshot)
+	nextID  int
+}
+
+// New loads the configuration of the town at townRoot and returns a
+// watcher for it. Call Run to start watching.
+func New(townRoot string) (*Watcher, error) {
+	snap, err := load(townRoot, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &Watcher{
+		Debounce: DefaultDebounce,
+		Actor:    "gt",
+		townRoot: townRoot,
+		current:  snap,
+		subs:     make(map[int]func(*Snapshot)),
+	}, nil
+}
+
+// Current returns the latest snapshot.
+func (w *Watcher) Current() *Snapshot {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.current
+}
+
+// Subscribe calls fn with each new snapshot after a reload. fn runs on the
+// watcher's goroutine, so long work should be handed off. The returned
+// function unsubscribes.
+func (w *Watcher) Subscribe(fn func(*Snapshot)) (unsubscribe func()) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	id := w.nextID
+	w.nextID++
+	w.subs[id] = fn
+	return func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		delete(w.subs, id)
+	}
+}
+
+// Reload loads the configuration now. If any file changed, the result
+// becomes the current snapshot, subscribers are called, and a
+// config_reloaded event is logged; otherwise the current snapshot is
+// returned unchanged.
+func (w *Watcher) Reload() (*Snapshot, error) {
+	prev := w.Current()
+	snap, err := load(w.townRoot, prev)
+	if err != nil {
+		return prev, err
+	}
+	if snap == prev {
+		return prev, nil
+	}
+
+	w.mu.Lock()
+	w.current = snap
+	subs := make([]func(*Snapshot), 0, len(w.subs))
+	for _, fn := range w.subs {
+		subs = append(subs, fn)
+	}
+	w.mu.Unlock()
+
+	for _, fn := range subs {
+		fn(snap)
+	}
+	_ = events.LogAudit(events.TypeConfigReloaded, w.Actor, events.ConfigReloadedPayload(snap.Version, snap.Changed))
+	return snap, nil
+}
+
+// Run watches the configuration files and reloads when they change, until
+// ctx is done. It watches directories rather than files, so files replaced
+// by rename (as editors and gt itself save them) stay watched.
+func (w *Watcher) Run(ctx context.Context) error {
+	fw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("watching config: %w", err)
+	}
+	defer fw.Close()
+	w.watchDirs(fw, w.Current())
+
+	debounce := time.NewTimer(time.Hour)
+	debounce.Stop()
+	defer debounce.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case ev, ok := <-fw.Events:
+			if !ok {
+				return nil
+			}
+			if w.relevant(ev.Name) {
+				debounce.Reset(w.Debounce)
+			}
+
+		case err, ok := <-fw.Errors:
+			if !ok {
+				return nil
+			}
+			w.reportError(err)
+
+		case <-debounce.C:
+			if _, err := w.Reload(); err != nil {
+				w.reportError(err)
+			}
+			// Rigs may have come or gone, or a settings directory appeared.
+			w.watchDirs(fw, w.Current())
+		}
+	}
+}
+
+// watchDirs adds a watch on the directory of each file the snapshot
+// depends on. A directory that doesn't exist yet is covered by watching its
+// parent, so its creation is noticed.
+func (w *Watcher) watchDirs(fw *fsnotify.Watcher, snap *Snapshot) {
+	seen := make(map[string]bool)
+	for _, file := range snap.files() {
+		dir := filepath.Join(w.townRoot, filepath.Dir(file))
+		if seen[dir] {
+			continue
+		}
+		seen[dir] = true
+		if err := fw.Add(dir); err != nil && errors.Is(err, os.ErrNotExist) {
+			_ = fw.Add(filepath.Dir(dir))
+		}
+	}
+}
+
+// relevant reports whether a change to path could affect the snapshot: a
+// watched file, or a directory that holds one.
+func (w *Watcher) relevant(path string) bool {
+	rel, err := filepath.Rel(w.townRoot, path)
+	if err != nil {
+		return false
+	}
+	for _, file := range w.Current().files() {
+		if rel == file || rel == filepath.Dir(file) {
+			return true
+		}
+	}
+	return false
+}
+
+func (w *Watcher) reportError(err error) {
+	if w.OnError != nil {
+		w.OnError(err)
+	}
+}
+
+// load reads the town's configuration files. If prev is non-nil and no file
+// changed since it was loaded, prev is returned.
+func load(townRoot string, prev *Snapshot) (*Snapshot, error) {
+	digests := make(map[string][sha256.Size]byte)
+	read := func(file string) ([]byte, error) {
+		data, err := os.ReadFile(filepath.Join(townRoot, file)) //nolint:gosec // G304: town config files
+		if err == nil {
+			digests[file] = sha256.Sum256(data)
+		}
+		return data, err
+	}
+
+	snap := &Snapshot{RigSettings: make(map[string]*config.RigSettings)}
+	var err error
+	if _, err = read(TownFile); err == nil {
+		snap.Town, err = config.LoadTownConfig(filepath.Join(townRoot, TownFile))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", TownFile, err)
+	}
+	if _, err = read(RigsFile); err == nil {
+		snap.Rigs, err = config.LoadRigsConfig(filepath.Join(townRoot, RigsFile))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", RigsFile, err)
+	}
+	if _, err := read(SettingsFile); err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("%s: %w", SettingsFile, err)
+	}
+	if snap.Settings, err = config.LoadOrCreateTownSettings(filepath.Join(townRoot, SettingsFile)); err != nil {
+		return nil, fmt.Errorf("%s: %w", SettingsFile, err)
+	}
+	// daemon.json is parsed by the daemon, but a half-written one must not
+	// become current: the daemon would read it as "no patrols".
+	if data, err := read(DaemonFile); err == nil && !json.Valid(data) {
+		return nil, fmt.Errorf("%s: invalid JSON", DaemonFile)
+	}
+	for rig := range snap.Rigs.Rigs {
+		file := RigSettingsFile(rig)
+		if _, err := read(file); err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		settings, err := config.LoadRigSettings(filepath.Join(townRoot, file))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		snap.RigSettings[rig] = settings
+	}
+	snap.digests = digests
+
+	snap.Version = 1
+	var before map[string][sha256.Size]byte
+	if prev != nil {
+		snap.Version = prev.Version + 1
+		before = prev.digests
+	}
+	files := snap.files()
+	for _, file := range files {
+		d, ok := digests[file]
+		p, wasOK := before[file]
+		if ok != wasOK || d != p {
+			snap.Changed = append(snap.Changed, file)
+		}
+	}
+	for file := range before {
+		if !slices.Contains(files, file) {
+			snap.Changed = append(snap.Changed, file) // Settings of a rig dropped from rigs.json
+		}
+	}
+	if prev != nil && len(snap.Changed) == 0 {
+		return prev, nil
+	}
+	sort.Strings(snap.Changed)
+	snap.LoadedAt = time.Now()
+	return snap, nil
+}
@@ -0,0 +1,110 @@
+package configwatch
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func writeFile(t *testing.T, path, data string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func newTown(t *testing.T) string {
+	t.Helper()
+	town := t.TempDir()
+	writeFile(t, filepath.Join(town, TownFile), `{"type": "town", "version": 2, "name": "hq"}`)
+	writeFile(t, filepath.Join(town, RigsFile), `{"version": 1, "rigs": {"gastown": {"git_url": "x", "beads": {"prefix": "gt"}}}}`)
+	return town
+}
+
+func TestReload(t *testing.T) {
+	town := newTown(t)
+	w, err := New(town)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	first := w.Current()
+	if first.Version != 1 || !slices.Equal(first.Changed, []string{RigsFile, TownFile}) {
+		t.Errorf("initial snapshot = v%d %v, want v1 with town.json and rigs.json", first.Version, first.Changed)
+	}
+
+	if snap, err := w.Reload(); err != nil || snap != first {
+		t.Errorf("Reload() with no changes = v%d, %v; want the same snapshot", snap.Version, err)
+	}
+
+	var got []*Snapshot
+	w.Subscribe(func(s *Snapshot) { got = append(got, s) })
+	writeFile(t, filepath.Join(town, RigSettingsFile("gastown")), `{"type": "rig-settings", "version": 1}`)
+	snap, err := w.Reload()
+	if err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+	if snap.Version != 2 || !slices.Equal(snap.Changed, []string{RigSettingsFile("gastown")}) {
+		t.Errorf("reloaded snapshot = v%d %v, want v2 with the rig settings", snap.Version, snap.Changed)
+	}
+	if snap.RigSettings["gastown"] == nil {
+		t.Error("RigSettings[gastown] = nil")
+	}
+	if len(got) != 1 || got[0] != snap || w.Current() != snap {
+		t.Errorf("subscriber got %d snapshot(s); want the reloaded one", len(got))
+	}
+}
+
+func TestReload_KeepsSnapshotOnError(t *testing.T) {
+	town := newTown(t)
+	w, err := New(town)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	first := w.Current()
+
+	for _, file := range []string{RigsFile, DaemonFile} {
+		writeFile(t, filepath.Join(town, file), `{"rigs": {`)
+		if snap, err := w.Reload(); err == nil || snap != first || w.Current() != first {
+			t.Errorf("Reload() with invalid %s = v%d, %v; want an error and the previous snapshot", file, snap.Version, err)
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	town := newTown(t)
+	w, err := New(town)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	w.Debounce = 10 * time.Millisecond
+	reloaded := make(chan *Snapshot, 1)
+	w.Subscribe(func(s *Snapshot) { reloaded <- s })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- w.Run(ctx) }()
+	defer func() {
+		cancel()
+		if err := <-done; err != nil {
+			t.Errorf("Run: %v", err)
+		}
+	}()
+
+	// The settings directory doesn't exist yet; its creation must be noticed.
+	time.Sleep(50 * time.Millisecond)
+	writeFile(t, filepath.Join(town, SettingsFile), `{"type": "town-settings", "version": 1}`)
+	select {
+	case snap := <-reloaded:
+		if !snap.HasChanged(SettingsFile) {
+			t.Errorf("Changed = %v, want %s", snap.Changed, SettingsFile)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no reload after writing settings")
+	}
+}
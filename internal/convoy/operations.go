@@ -224,8 +224,10 @@ func isIssueBlocked(ctx context.Context, store beadsdk.Storage, issueID string)
 // blocked by unclosed dependencies. This provides reactive (event-driven)
 // convoy feeding instead of waiting for polling-based patrol cycles.
 //
-// Only one issue is dispatched per call. When that issue completes, the
-// next close event triggers another feed cycle.
+// An unthrottled convoy gets one issue per call: when that issue completes,
+// the next close event triggers another feed cycle. A throttled convoy
+// (Max-Active set at create or launch) is topped up to its limit, so raising
+// the limit mid-flight starts more work on the next feed.
 // gtPath is the resolved path to the gt binary.
 func feedNextReadyIssue(ctx context.Context, store beadsdk.Storage, townRoot, convoyID, caller string, logger func(format string, args ...interface{}), gtPath string, isRigParked func(string) bool) {
 	tracked := getConvoyTrackedIssues(ctx, store, convoyID, townRoot)
@@ -233,6 +235,14 @@ func feedNextReadyIssue(ctx context.Context, store beadsdk.Storage, townRoot, co
 		return
 	}
 
+	maxActive := convoyMaxActive(ctx, store, convoyID)
+	inFlight := countInFlight(tracked)
+	budget := FeedBudget(maxActive, inFlight)
+	if budget == 0 {
+		logger("%s: convoy %s: %d issue(s) in flight (max-active %d), holding", caller, convoyID, inFlight, maxActive)
+		return
+	}
+
 	// Sort by priority (lower = higher) then by ID for deterministic tie-breaking.
 	sort.Slice(tracked, func(i, j int) bool {
 		if tracked[i].Priority != tracked[j].Priority {
@@ -241,8 +251,12 @@ func feedNextReadyIssue(ctx context.Context, store beadsdk.Storage, townRoot, co
 		return tracked[i].ID < tracked[j].ID
 	})
 
-	// Find the first ready issue (open, no assignee, not blocked).
+	// Dispatch ready issues (open, no assignee, not blocked) up to the budget.
+	fed := 0
 	for _, issue := range tracked {
+		if fed >= budget {
+			return
+		}
 		if issue.Status != "open" || issue.Assignee != "" {
 			continue
 		}
@@ -280,10 +294,56 @@ func feedNextReadyIssue(ctx context.Context, store beadsdk.Storage, townRoot, co
 			logger("%s: convoy %s: dispatch %s failed: %s", caller, convoyID, issue.ID, util.FirstLine(err.Error()))
 			continue // Try next issue on dispatch failure
 		}
-		return // Successfully dispatched one issue
+		fed++
+	}
+
+	if fed == 0 {
+		logger("%s: convoy %s: no ready issues to feed", caller, convoyID)
 	}
+}
+
+// FeedBudget returns how many more issues a convoy may start. Unthrottled
+// convoys (maxActive <= 0) are fed one issue per completion; throttled ones
+// are topped up to maxActive.
+func FeedBudget(maxActive, inFlight int) int {
+	if maxActive <= 0 {
+		return 1
+	}
+	if inFlight >= maxActive {
+		return 0
+	}
+	return maxActive - inFlight
+}
 
-	logger("%s: convoy %s: no ready issues to feed", caller, convoyID)
+// InFlightStatuses are tracked-issue statuses that occupy an agent.
+var InFlightStatuses = map[string]bool{
+	"in_progress": true,
+	"hooked":      true,
+}
+
+// countInFlight counts tracked issues that are being worked: slung to an
+// agent (hooked, in progress) or open with an assignee.
+func countInFlight(tracked []trackedIssue) int {
+	n := 0
+	for _, t := range tracked {
+		if InFlightStatuses[t.Status] || (t.Status == "open" && t.Assignee != "") {
+			n++
+		}
+	}
+	return n
+}
+
+// convoyMaxActive reads a convoy's Max-Active throttle (0 if unthrottled).
+func convoyMaxActive(ctx context.Context, store beadsdk.Storage, convoyID string) int {
+	issue, err := store.GetIssue(ctx, convoyID)
+	if err != nil || issue == nil {
+		return 0
+	}
+	fields := beads.ParseConvoyFields(&beads.Issue{Description: issue.Description})
+	if fields == nil {
+		return 0
+	}
+	return fields.MaxActive
 }
 
 // getConvoyTrackedIssues returns issues tracked by a convoy with fresh status.
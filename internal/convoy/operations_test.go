@@ -1684,3 +1684,105 @@ func TestFetchCrossRigBeadStatus_EmptyInput(t *testing.T) {
 		t.Errorf("expected 0 results for empty input, got %d", len(result))
 	}
 }
+
+func TestFeedBudget(t *testing.T) {
+	tests := []struct {
+		maxActive, inFlight, want int
+	}{
+		{0, 0, 1}, // Unthrottled: one per completion
+		{0, 7, 1}, // Unthrottled ignores in-flight count
+		{4, 0, 4}, // Throttled launch fills the limit
+		{4, 3, 1}, // One slot free
+		{4, 4, 0}, // At limit: hold
+		{2, 5, 0}, // Limit lowered mid-flight: hold until drained
+	}
+	for _, tt := range tests {
+		if got := FeedBudget(tt.maxActive, tt.inFlight); got != tt.want {
+			t.Errorf("FeedBudget(%d, %d) = %d, want %d", tt.maxActive, tt.inFlight, got, tt.want)
+		}
+	}
+}
+
+func TestCountInFlight(t *testing.T) {
+	tracked := []trackedIssue{
+		{ID: "a", Status: "open"},
+		{ID: "b", Status: "open", Assignee: "gastown/polecats/nux"},
+		{ID: "c", Status: "hooked"},
+		{ID: "d", Status: "in_progress"},
+		{ID: "e", Status: "closed", Assignee: "gastown/polecats/toast"},
+	}
+	if got := countInFlight(tracked); got != 3 {
+		t.Errorf("countInFlight = %d, want 3", got)
+	}
+}
+
+func TestFeedNextReadyIssue_ThrottledConvoyTopsUpToMaxActive(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("skipping on windows")
+	}
+
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	convoy := &beadsdk.Issue{
+		ID:          "test-convoy1",
+		Title:       "Throttled Convoy",
+		Description: "Owner: mayor/\nMax-Active: 3",
+		Status:      beadsdk.StatusOpen,
+		Priority:    2,
+		IssueType:   beadsdk.TypeTask,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	issues := []*beadsdk.Issue{convoy, {
+		ID: "test-busy1", Title: "Busy", Status: beadsdk.StatusOpen, Assignee: "gastown/polecats/alpha",
+		Priority: 2, IssueType: beadsdk.TypeTask, CreatedAt: now, UpdatedAt: now,
+	}}
+	for _, id := range []string{"test-ready1", "test-ready2", "test-ready3", "test-ready4"} {
+		issues = append(issues, &beadsdk.Issue{
+			ID: id, Title: id, Status: beadsdk.StatusOpen,
+			Priority: 2, IssueType: beadsdk.TypeTask, CreatedAt: now, UpdatedAt: now,
+		})
+	}
+	for _, iss := range issues {
+		if err := store.CreateIssue(ctx, iss, "test"); err != nil {
+			t.Fatalf("CreateIssue %s: %v", iss.ID, err)
+		}
+	}
+	for _, iss := range issues[1:] {
+		dep := &beadsdk.Dependency{
+			IssueID:     convoy.ID,
+			DependsOnID: iss.ID,
+			Type:        beadsdk.DependencyType("tracks"),
+			CreatedAt:   now,
+			CreatedBy:   "test",
+		}
+		if err := store.AddDependency(ctx, dep, "test"); err != nil {
+			t.Fatalf("AddDependency %s: %v", iss.ID, err)
+		}
+	}
+
+	townRoot := setupTownRoot(t)
+	gtPath, logPath := makeGTStub(t, 0)
+	logger, _ := makeLogger()
+
+	feedNextReadyIssue(ctx, store, townRoot, convoy.ID, "test", logger, gtPath, func(string) bool { return false })
+
+	logData, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("gt stub was not called (no log file): %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(logData)), "\n")
+	// One issue is in flight and the limit is 3, so two more start.
+	if len(lines) != 2 {
+		t.Fatalf("got %d dispatches, want 2: %q", len(lines), lines)
+	}
+	for _, line := range lines {
+		if !strings.HasPrefix(line, "sling test-ready") {
+			t.Errorf("unexpected dispatch: %q", line)
+		}
+	}
+}
@@ -835,6 +835,19 @@ func (m *Manager) Start(name string, opts StartOptions) error {
 		_ = t.SetEnvironment(sessionID, "GT_PANE_ID", paneID)
 	}
 
+	// Open the crew role's declared pane layout (non-fatal)
+	if err := session.ApplyRoleLayout(t, session.LayoutConfig{
+		SessionID: sessionID,
+		WorkDir:   worker.ClonePath,
+		Role:      "crew",
+		TownRoot:  townRoot,
+		RigPath:   m.rig.Path,
+		RigName:   m.rig.Name,
+		AgentName: name,
+	}); err != nil {
+		style.PrintWarning("session layout for %s: %v", sessionID, err)
+	}
+
 	// Apply rig-based theming (non-fatal: theming failure doesn't affect operation)
 	theme := tmux.AssignTheme(m.rig.Name)
 	_ = t.ConfigureGasTownSession(sessionID, theme, m.rig.Name, name, "crew")
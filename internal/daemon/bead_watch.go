@@ -0,0 +1,80 @@
+package daemon
+
+import (
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beadwatch"
+)
+
+// beadWatchDispatchGap is the least time between dispatcher runs triggered
+// by bead changes. A burst of changes (a convoy being filed, a molecule
+// closing out) then costs one gt dispatch, and the dispatcher patrol's own
+// ticker picks up anything freed during the gap.
+const beadWatchDispatchGap = 30 * time.Second
+
+// BeadWatchConfig holds configuration for the bead_watch patrol, which
+// watches the town's beads for changes, logs them as bead_changed events,
+// and runs the dispatcher as soon as work may have been freed instead of
+// waiting for its next tick. Opt-in: each scan lists every bead in a
+// database. Read at startup; enabling or disabling it needs a daemon
+// restart.
+type BeadWatchConfig struct {
+	Enabled     bool   `json:"enabled"`
+	IntervalStr string `json:"interval,omitempty"` // Full rescan interval (default 5m)
+}
+
+// beadWatchInterval returns the configured full rescan interval, or the
+// default (5m).
+func beadWatchInterval(config *DaemonPatrolConfig) time.Duration {
+	if config != nil && config.Patrols != nil && config.Patrols.BeadWatch != nil {
+		if config.Patrols.BeadWatch.IntervalStr != "" {
+			if d, err := time.ParseDuration(config.Patrols.BeadWatch.IntervalStr); err == nil && d > 0 {
+				return d
+			}
+		}
+	}
+	return beadwatch.DefaultInterval
+}
+
+// watchBeads starts the bead watcher if the bead_watch patrol is enabled.
+// The returned channel receives a value (coalesced) whenever a change may
+// have freed work; it never receives if the patrol is disabled.
+func (d *Daemon) watchBeads() <-chan struct{} {
+	freed := make(chan struct{}, 1)
+	if !IsPatrolEnabled(d.patrolConfig, "bead_watch") {
+		return freed
+	}
+	sources, err := beadwatch.Sources(d.config.TownRoot)
+	if err != nil {
+		d.logger.Printf("Warning: bead watch disabled: %v", err)
+		return freed
+	}
+	w := beadwatch.New(sources)
+	w.Interval = beadWatchInterval(d.patrolConfig)
+	w.Actor = "daemon"
+	w.LogEvents = true
+	w.OnError = func(err error) {
+		d.logger.Printf("bead_watch: %v", err)
+	}
+	w.Subscribe(func(changes []beadwatch.Change) {
+		for _, c := range changes {
+			if c.MayFreeWork() {
+				select {
+				case freed <- struct{}{}:
+				default:
+				}
+				return
+			}
+		}
+	})
+	go func() {
+		if err := w.Prime(); err != nil {
+			w.OnError(err)
+		}
+		if err := w.Run(d.ctx); err != nil {
+			d.logger.Printf("Warning: bead watch stopped: %v", err)
+		}
+	}()
+	d.logger.Printf("Watching %d beads database(s) for changes", len(sources))
+	return freed
+}
@@ -0,0 +1,51 @@
+package daemon
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"time"
+)
+
+// defaultBranchAgingInterval is how often unmerged integration branches are
+// checked against their rigs' aging policies. Ages are counted in days, so
+// a few checks a day is plenty, and each check fetches every rig's origin.
+const defaultBranchAgingInterval = 6 * time.Hour
+
+// BranchAgingConfig holds configuration for the branch_aging patrol, which
+// mails the owners of integration branches that have sat unmerged past
+// their rig's mail threshold (see gt branches). Enabled by default: owners
+// are mailed once per branch.
+type BranchAgingConfig struct {
+	Enabled     bool   `json:"enabled"`
+	IntervalStr string `json:"interval,omitempty"`
+}
+
+// branchAgingInterval returns the configured interval, or the default (6h).
+func branchAgingInterval(config *DaemonPatrolConfig) time.Duration {
+	if config != nil && config.Patrols != nil && config.Patrols.BranchAging != nil {
+		if config.Patrols.BranchAging.IntervalStr != "" {
+			if d, err := time.ParseDuration(config.Patrols.BranchAging.IntervalStr); err == nil && d > 0 {
+				return d
+			}
+		}
+	}
+	return defaultBranchAgingInterval
+}
+
+// runBranchAging shells out to `gt branches --all --fetch --notify` to mail
+// owners of aging integration branches, as runEscalationSLA does for
+// escalations. The report itself is discarded; only failures are logged.
+func (d *Daemon) runBranchAging() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "gt", "branches", "--all", "--fetch", "--notify", "--json")
+	cmd.Dir = d.config.TownRoot
+	cmd.Env = append(os.Environ(), "GT_DAEMON=1")
+	out, err := cmd.CombinedOutput()
+	if ctx.Err() == context.DeadlineExceeded {
+		d.logger.Printf("branch_aging: timed out after 5m")
+	} else if err != nil {
+		d.logger.Printf("branch_aging: failed: %v (output: %s)", err, string(out))
+	}
+}
@@ -0,0 +1,128 @@
+package daemon
+
+import (
+	"os"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/configwatch"
+	"github.com/steveyegge/gastown/internal/session"
+)
+
+// patrolTicker drives one patrol's ticker. It is re-armed whenever the
+// patrol config is reloaded, so the patrol starts, stops, or changes
+// interval to match daemon.json.
+type patrolTicker struct {
+	patrol   string // Patrol name, as in daemon.json
+	label    string // For log lines
+	interval func(*DaemonPatrolConfig) time.Duration
+
+	// C delivers the patrol's ticks. Nil while the patrol is disabled, so
+	// a select case on it never fires.
+	C <-chan time.Time
+
+	ticker  *time.Ticker
+	current time.Duration
+}
+
+// arm starts, stops, or retimes the ticker to match config.
+func (p *patrolTicker) arm(config *DaemonPatrolConfig, logf func(string, ...interface{})) {
+	if !IsPatrolEnabled(config, p.patrol) {
+		if p.ticker != nil {
+			p.stop()
+			logf("%s ticker stopped (disabled in daemon.json)", p.label)
+		}
+		return
+	}
+	interval := p.interval(config)
+	switch {
+	case p.ticker == nil:
+		p.ticker = time.NewTicker(interval)
+		p.C = p.ticker.C
+		logf("%s ticker started (interval %v)", p.label, interval)
+	case interval != p.current:
+		p.ticker.Reset(interval)
+		logf("%s ticker interval changed to %v", p.label, interval)
+	}
+	p.current = interval
+}
+
+func (p *patrolTicker) stop() {
+	if p.ticker != nil {
+		p.ticker.Stop()
+	}
+	p.ticker, p.C = nil, nil
+}
+
+// watchConfig starts watching the town's config files and returns a channel
+// of reloaded snapshots for the main loop. A reload that arrives while the
+// previous one is still unhandled is dropped: applyConfigReload reads the
+// files afresh, so the pending one covers it. If the watcher can't start,
+// the channel never delivers and config changes need a restart, as before.
+func (d *Daemon) watchConfig() <-chan *configwatch.Snapshot {
+	reloads := make(chan *configwatch.Snapshot, 1)
+	w, err := configwatch.New(d.config.TownRoot)
+	if err != nil {
+		d.logger.Printf("Warning: config hot-reload disabled: %v", err)
+		return reloads
+	}
+	w.Actor = "daemon"
+	d.configWatch = w
+	w.OnError = func(err error) {
+		d.logger.Printf("Warning: config reload: %v (keeping previous config)", err)
+	}
+	w.Subscribe(func(snap *configwatch.Snapshot) {
+		select {
+		case reloads <- snap:
+		default:
+		}
+	})
+	go func() {
+		if err := w.Run(d.ctx); err != nil {
+			d.logger.Printf("Warning: config hot-reload stopped: %v", err)
+		}
+	}()
+	d.logger.Println("Watching town config for changes")
+	return reloads
+}
+
+// applyConfigReload applies the files changed in snap: daemon.json re-arms
+// the patrol tickers and re-exports its env, and rigs.json refreshes the
+// session prefix registry. Town settings and rigs are read from the
+// watcher's current snapshot (see townSettings and loadRigsConfig), so the
+// patrols using them see a reload on their next run. Dolt server settings
+// still need a restart.
+func (d *Daemon) applyConfigReload(snap *configwatch.Snapshot, patrols []*patrolTicker) {
+	d.logger.Printf("Config reloaded (version %d): %s", snap.Version, strings.Join(snap.Changed, ", "))
+
+	if snap.HasChanged(configwatch.DaemonFile) {
+		d.patrolConfig = LoadPatrolConfig(d.config.TownRoot)
+		if d.patrolConfig != nil {
+			for k, v := range d.patrolConfig.Env {
+				if os.Getenv(k) != v {
+					os.Setenv(k, v)
+					d.logger.Printf("Set env %s=%s from daemon.json", k, v)
+				}
+			}
+		}
+		for _, p := range patrols {
+			p.arm(d.patrolConfig, d.logger.Printf)
+		}
+	}
+
+	if snap.HasChanged(configwatch.RigsFile) {
+		if err := session.InitRegistry(d.config.TownRoot); err != nil {
+			d.logger.Printf("Warning: failed to reload prefix registry: %v", err)
+		}
+	}
+}
+
+// townSettings returns the town settings from the current config snapshot,
+// or loads them from disk if hot-reload isn't running.
+func (d *Daemon) townSettings() (*config.TownSettings, error) {
+	if d.configWatch != nil {
+		return d.configWatch.Current().Settings, nil
+	}
+	return config.LoadOrCreateTownSettings(config.TownSettingsPath(d.config.TownRoot))
+}
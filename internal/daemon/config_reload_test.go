@@ -0,0 +1,96 @@
+package daemon
+
+import (
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/configwatch"
+)
+
+func TestPatrolTickerArm(t *testing.T) {
+	p := &patrolTicker{patrol: "dispatcher", label: "Dispatcher", interval: dispatcherInterval}
+	defer p.stop()
+	var logs []string
+	logf := func(format string, args ...interface{}) { logs = append(logs, format) }
+	dispatcher := func(enabled bool, interval string) *DaemonPatrolConfig {
+		return &DaemonPatrolConfig{Patrols: &PatrolsConfig{
+			Dispatcher: &DispatcherConfig{Enabled: enabled, IntervalStr: interval},
+		}}
+	}
+
+	p.arm(nil, logf)
+	if p.C != nil {
+		t.Fatal("opt-in dispatcher armed without config")
+	}
+
+	p.arm(dispatcher(true, "1m"), logf)
+	if p.C == nil || p.current != time.Minute {
+		t.Fatalf("after enabling: C = %v, interval = %v; want armed at 1m", p.C, p.current)
+	}
+	c := p.C
+
+	p.arm(dispatcher(true, "5m"), logf)
+	if p.C != c || p.current != 5*time.Minute {
+		t.Errorf("after retiming: interval = %v, want the same ticker at 5m", p.current)
+	}
+
+	p.arm(dispatcher(false, "5m"), logf)
+	if p.C != nil {
+		t.Error("disabled dispatcher still armed")
+	}
+	if len(logs) != 3 {
+		t.Errorf("logged %d lines, want started, changed, stopped", len(logs))
+	}
+}
+
+func TestDaemonReadsConfigSnapshot(t *testing.T) {
+	town := t.TempDir()
+	write := func(file, data string) {
+		t.Helper()
+		path := filepath.Join(town, file)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(configwatch.TownFile, `{"type": "town", "version": 2, "name": "hq"}`)
+	write(configwatch.RigsFile, `{"version": 1, "rigs": {"gastown": {"git_url": "x", "beads": {"prefix": "gt"}}}}`)
+
+	w, err := configwatch.New(town)
+	if err != nil {
+		t.Fatalf("configwatch.New: %v", err)
+	}
+	d := &Daemon{
+		config:      &Config{TownRoot: town},
+		logger:      log.New(io.Discard, "", 0),
+		configWatch: w,
+	}
+
+	rigs, err := d.loadRigsConfig()
+	if err != nil || rigs != w.Current().Rigs {
+		t.Fatalf("loadRigsConfig() = %v, %v; want the snapshot's rigs", rigs, err)
+	}
+
+	write(configwatch.SettingsFile, `{"type": "town-settings", "version": 1, "notifications": {"webhooks": [{"url": "https://example.com/hook"}]}}`)
+	snap, err := w.Reload()
+	if err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+	settings, err := d.townSettings()
+	if err != nil || settings != snap.Settings || len(settings.Notifications.Webhooks) != 1 {
+		t.Errorf("townSettings() = %+v, %v; want the reloaded snapshot's settings", settings, err)
+	}
+
+	// A settings-only reload leaves the patrol config alone.
+	d.patrolConfig = &DaemonPatrolConfig{Env: map[string]string{"GT_TEST_KEEP": "1"}}
+	d.applyConfigReload(snap, nil)
+	if d.patrolConfig == nil || d.patrolConfig.Env["GT_TEST_KEEP"] != "1" {
+		t.Error("applyConfigReload reloaded daemon.json for a settings-only change")
+	}
+}
@@ -0,0 +1,89 @@
+package daemon
+
+import (
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/events"
+)
+
+// restartHeld reports whether the restart tracker is holding off restarts
+// of an agent, because it is crash-looping or still in backoff, and logs
+// why. agentID is the tracker key: "deacon", "mayor", "<rig>/witness", or
+// "<rig>/refinery".
+func (d *Daemon) restartHeld(agentID string) bool {
+	if d.restartTracker == nil {
+		return false
+	}
+	if d.restartTracker.IsInCrashLoop(agentID) {
+		d.logger.Printf("%s is in crash loop, skipping restart (use 'gt daemon clear-backoff %s' to reset)", agentID, agentID)
+		return true
+	}
+	if !d.restartTracker.CanRestart(agentID) {
+		remaining := d.restartTracker.GetBackoffRemaining(agentID)
+		d.logger.Printf("%s restart in backoff, %s remaining", agentID, remaining.Round(time.Second))
+		return true
+	}
+	return false
+}
+
+// recordAgentRunning tells the restart tracker an agent was found running,
+// so a stable agent's backoff resets.
+func (d *Daemon) recordAgentRunning(agentID string) {
+	if d.restartTracker != nil {
+		d.restartTracker.RecordSuccess(agentID)
+	}
+}
+
+// recordAgentRestart records that the daemon (re)started an agent's session.
+// If that makes the agent crash-looping, the daemon stops restarting it and
+// raises the alarm: a crash_loop event (which webhooks receive by default)
+// and a crash-loop mark on the agent's bead.
+func (d *Daemon) recordAgentRestart(agentID string) {
+	if d.restartTracker == nil {
+		return
+	}
+	entered := d.restartTracker.RecordRestart(agentID)
+	if err := d.restartTracker.Save(); err != nil {
+		d.logger.Printf("Warning: failed to save restart state: %v", err)
+	}
+	if !entered {
+		return
+	}
+
+	window := d.restartTracker.config.CrashLoopWindow
+	restarts := d.restartTracker.config.CrashLoopCount
+	d.logger.Printf("CRASH LOOP DETECTED: %s restarted %d times within %s, no longer restarting it (use 'gt daemon clear-backoff %s' to reset)",
+		agentID, restarts, window, agentID)
+	_ = events.LogFeed(events.TypeCrashLoop, "daemon", events.CrashLoopPayload(agentID, restarts, window.String()))
+
+	if beadID := crashLoopBeadID(d.config.TownRoot, agentID); beadID != "" {
+		if err := beads.New(d.config.TownRoot).MarkAgentCrashLoop(beadID, true); err != nil {
+			d.logger.Printf("Warning: failed to mark %s as crash-looping: %v", beadID, err)
+		}
+	}
+}
+
+// crashLoopBeadID returns the agent bead of a restart tracker agent, or ""
+// if it has none.
+func crashLoopBeadID(townRoot, agentID string) string {
+	switch agentID {
+	case "deacon":
+		return beads.DeaconBeadIDTown()
+	case "mayor":
+		return beads.MayorBeadIDTown()
+	}
+	rigName, role, ok := strings.Cut(agentID, "/")
+	if !ok {
+		return ""
+	}
+	prefix := beads.GetPrefixForRig(townRoot, rigName)
+	switch role {
+	case "witness":
+		return beads.WitnessBeadIDWithPrefix(prefix, rigName)
+	case "refinery":
+		return beads.RefineryBeadIDWithPrefix(prefix, rigName)
+	}
+	return ""
+}
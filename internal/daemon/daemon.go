@@ -12,6 +12,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
@@ -20,6 +21,7 @@ import (
 	"gopkg.in/natefinch/lumberjack.v2"
 	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/boot"
+	"github.com/steveyegge/gastown/internal/configwatch"
 	"github.com/steveyegge/gastown/internal/constants"
 	"github.com/steveyegge/gastown/internal/deacon"
 	"github.com/steveyegge/gastown/internal/doltserver"
@@ -27,6 +29,7 @@ import (
 	"github.com/steveyegge/gastown/internal/feed"
 	gitpkg "github.com/steveyegge/gastown/internal/git"
 	"github.com/steveyegge/gastown/internal/mayor"
+	"github.com/steveyegge/gastown/internal/nudge"
 	"github.com/steveyegge/gastown/internal/refinery"
 	"github.com/steveyegge/gastown/internal/rig"
 	"github.com/steveyegge/gastown/internal/session"
@@ -97,6 +100,14 @@ type Daemon struct {
 	// lastMaintenanceRun tracks when scheduled maintenance last ran.
 	// Only accessed from heartbeat loop goroutine - no sync needed.
 	lastMaintenanceRun time.Time
+
+	// webhooksRunning is set while a webhooks pass delivers in the
+	// background, so a slow endpoint never stacks up passes.
+	webhooksRunning atomic.Bool
+
+	// configWatch keeps the current snapshot of the town's config files.
+	// Nil if hot-reload couldn't start; readers then load from disk.
+	configWatch *configwatch.Watcher
 }
 
 // sessionDeath records a detected session death for mass death analysis.
@@ -134,7 +145,7 @@ func New(config *Config) (*Daemon, error) {
 		Compress:   true,
 	}
 
-	logger := log.New(logWriter, "", log.LstdFlags)
+	logger := newDaemonLogger(config.TownRoot, logWriter)
 	ctx, cancel := context.WithCancel(context.Background())
 
 	// Initialize session prefix and agent registries from town root.
@@ -262,6 +273,11 @@ func (d *Daemon) Run() error {
 	}
 	defer func() { _ = fileLock.Unlock() }()
 
+	// The daemon polls tmux constantly, so it keeps control connections
+	// open instead of exec'ing tmux for every command.
+	tmux.EnableControlConnections()
+	defer tmux.CloseControlConnections()
+
 	// Pre-flight check: all rigs must be on Dolt backend.
 	if err := d.checkAllRigsDolt(); err != nil {
 		return err
@@ -366,93 +382,52 @@ func (d *Daemon) Run() error {
 		d.logger.Printf("Dolt health check ticker started (interval %v)", interval)
 	}
 
-	// Start dedicated Dolt remotes push ticker if configured.
-	// This runs at a lower frequency (default 15 min) than the heartbeat (3 min)
-	// to periodically push databases to their git remotes.
-	var doltRemotesTicker *time.Ticker
-	var doltRemotesChan <-chan time.Time
-	if IsPatrolEnabled(d.patrolConfig, "dolt_remotes") {
-		interval := doltRemotesInterval(d.patrolConfig)
-		doltRemotesTicker = time.NewTicker(interval)
-		doltRemotesChan = doltRemotesTicker.C
-		defer doltRemotesTicker.Stop()
-		d.logger.Printf("Dolt remotes push ticker started (interval %v)", interval)
-	}
-
-	// Start dedicated Dolt backup ticker if configured.
-	// Runs filesystem backup sync (dolt backup sync) for production databases.
-	var doltBackupTicker *time.Ticker
-	var doltBackupChan <-chan time.Time
-	if IsPatrolEnabled(d.patrolConfig, "dolt_backup") {
-		interval := doltBackupInterval(d.patrolConfig)
-		doltBackupTicker = time.NewTicker(interval)
-		doltBackupChan = doltBackupTicker.C
-		defer doltBackupTicker.Stop()
-		d.logger.Printf("Dolt backup ticker started (interval %v)", interval)
-	}
-
-	// Start JSONL git backup ticker if configured.
-	// Exports issues to JSONL, scrubs ephemeral data, pushes to git repo.
-	var jsonlGitBackupTicker *time.Ticker
-	var jsonlGitBackupChan <-chan time.Time
-	if IsPatrolEnabled(d.patrolConfig, "jsonl_git_backup") {
-		interval := jsonlGitBackupInterval(d.patrolConfig)
-		jsonlGitBackupTicker = time.NewTicker(interval)
-		jsonlGitBackupChan = jsonlGitBackupTicker.C
-		defer jsonlGitBackupTicker.Stop()
-		d.logger.Printf("JSONL git backup ticker started (interval %v)", interval)
-	}
-
-	// Start wisp reaper ticker if configured.
-	// Closes stale wisps (abandoned molecule steps, old patrol data) across all databases.
-	var wispReaperTicker *time.Ticker
-	var wispReaperChan <-chan time.Time
-	if IsPatrolEnabled(d.patrolConfig, "wisp_reaper") {
-		interval := wispReaperInterval(d.patrolConfig)
-		wispReaperTicker = time.NewTicker(interval)
-		wispReaperChan = wispReaperTicker.C
-		defer wispReaperTicker.Stop()
-		d.logger.Printf("Wisp reaper ticker started (interval %v)", interval)
-	}
-
-	// Start doctor dog ticker if configured.
-	// Health monitor: TCP check, latency, DB count, gc, zombie detection, backup/disk checks.
-	var doctorDogTicker *time.Ticker
-	var doctorDogChan <-chan time.Time
-	if IsPatrolEnabled(d.patrolConfig, "doctor_dog") {
-		interval := doctorDogInterval(d.patrolConfig)
-		doctorDogTicker = time.NewTicker(interval)
-		doctorDogChan = doctorDogTicker.C
-		defer doctorDogTicker.Stop()
-		d.logger.Printf("Doctor dog ticker started (interval %v)", interval)
-	}
-
-	// Start compactor dog ticker if configured.
-	// Flattens Dolt commit history to reclaim graph storage (daily).
-	var compactorDogTicker *time.Ticker
-	var compactorDogChan <-chan time.Time
-	if IsPatrolEnabled(d.patrolConfig, "compactor_dog") {
-		interval := compactorDogInterval(d.patrolConfig)
-		compactorDogTicker = time.NewTicker(interval)
-		compactorDogChan = compactorDogTicker.C
-		defer compactorDogTicker.Stop()
-		d.logger.Printf("Compactor dog ticker started (interval %v)", interval)
-	}
-
-	// Start scheduled maintenance ticker if configured.
-	// Checks periodically whether we're in the maintenance window and
-	// runs `gt maintain --force` when commit counts exceed threshold.
-	var scheduledMaintenanceTicker *time.Ticker
-	var scheduledMaintenanceChan <-chan time.Time
-	if IsPatrolEnabled(d.patrolConfig, "scheduled_maintenance") {
-		interval := maintenanceCheckInterval(d.patrolConfig)
-		scheduledMaintenanceTicker = time.NewTicker(interval)
-		scheduledMaintenanceChan = scheduledMaintenanceTicker.C
-		defer scheduledMaintenanceTicker.Stop()
-		window := maintenanceWindow(d.patrolConfig)
-		d.logger.Printf("Scheduled maintenance ticker started (check interval %v, window %s)", interval, window)
+	// Start patrol tickers. Each runs only while its patrol is enabled in
+	// daemon.json, and is re-armed when daemon.json changes (see
+	// applyConfigReload), so patrols can be enabled, disabled, or retimed
+	// without a daemon restart.
+	doltRemotes := &patrolTicker{patrol: "dolt_remotes", label: "Dolt remotes push", interval: doltRemotesInterval}
+	doltBackup := &patrolTicker{patrol: "dolt_backup", label: "Dolt backup", interval: doltBackupInterval}
+	jsonlGitBackup := &patrolTicker{patrol: "jsonl_git_backup", label: "JSONL git backup", interval: jsonlGitBackupInterval}
+	wispReaper := &patrolTicker{patrol: "wisp_reaper", label: "Wisp reaper", interval: wispReaperInterval}
+	doctorDog := &patrolTicker{patrol: "doctor_dog", label: "Doctor dog", interval: doctorDogInterval}
+	compactorDog := &patrolTicker{patrol: "compactor_dog", label: "Compactor dog", interval: compactorDogInterval}
+	scheduledMaintenance := &patrolTicker{patrol: "scheduled_maintenance", label: "Scheduled maintenance", interval: maintenanceCheckInterval}
+	witnessRules := &patrolTicker{patrol: "witness_rules", label: "Witness rules", interval: witnessRulesInterval}
+	mergeWatch := &patrolTicker{patrol: "merge_watch", label: "Merge watch", interval: mergeWatchInterval}
+	webhooks := &patrolTicker{patrol: "webhooks", label: "Webhooks", interval: webhooksInterval}
+	deaconProbes := &patrolTicker{patrol: "deacon_probes", label: "Deacon probes", interval: deaconProbesInterval}
+	utilization := &patrolTicker{patrol: "utilization_sampler", label: "Utilization sampler", interval: utilizationSamplerInterval}
+	dispatcher := &patrolTicker{patrol: "dispatcher", label: "Dispatcher", interval: dispatcherInterval}
+	mailSchedule := &patrolTicker{patrol: "mail_schedule", label: "Mail schedule", interval: mailScheduleInterval}
+	escalationSLA := &patrolTicker{patrol: "escalation_sla", label: "Escalation SLA", interval: escalationSLAInterval}
+	scrollbackSnapshot := &patrolTicker{patrol: "scrollback_snapshot", label: "Scrollback snapshot", interval: scrollbackSnapshotInterval}
+	branchAging := &patrolTicker{patrol: "branch_aging", label: "Branch aging", interval: branchAgingInterval}
+	patrols := []*patrolTicker{
+		doltRemotes, doltBackup, jsonlGitBackup, wispReaper, doctorDog, compactorDog,
+		scheduledMaintenance, witnessRules, mergeWatch, webhooks, deaconProbes, utilization, dispatcher, mailSchedule,
+		escalationSLA, scrollbackSnapshot, branchAging,
+	}
+	for _, p := range patrols {
+		p.arm(d.patrolConfig, d.logger.Printf)
+	}
+	defer func() {
+		for _, p := range patrols {
+			p.stop()
+		}
+	}()
+	if scheduledMaintenance.C != nil {
+		d.logger.Printf("Scheduled maintenance window %s", maintenanceWindow(d.patrolConfig))
 	}
 
+	// Watch the town's config files and apply changes on the main loop.
+	configReloads := d.watchConfig()
+
+	// Watch beads for changes that may free work, so the dispatcher runs
+	// right away instead of on its next tick.
+	beadsFreed := d.watchBeads()
+	var lastBeadDispatch time.Time
+
 	// Note: PATCH-010 uses per-session hooks in deacon/manager.go (SetAutoRespawnHook).
 	// Global pane-died hooks don't fire reliably in tmux 3.2a, so we rely on the
 	// per-session approach which has been tested to work for continuous recovery.
@@ -491,55 +466,136 @@ func (d *Daemon) Run() error {
 				d.ensureDoltServerRunning()
 			}
 
-		case <-doltRemotesChan:
+		case <-doltRemotes.C:
 			// Periodic Dolt remote push — pushes databases to their configured
 			// git remotes on a 15-minute cadence (independent of heartbeat).
 			if !d.isShutdownInProgress() {
 				d.pushDoltRemotes()
 			}
 
-		case <-doltBackupChan:
+		case <-doltBackup.C:
 			// Periodic Dolt filesystem backup — syncs production databases to
 			// local backup directory on a 15-minute cadence.
 			if !d.isShutdownInProgress() {
 				d.syncDoltBackups()
 			}
 
-		case <-jsonlGitBackupChan:
+		case <-jsonlGitBackup.C:
 			// Periodic JSONL git backup — exports issues, scrubs ephemeral data,
 			// commits and pushes to git repo.
 			if !d.isShutdownInProgress() {
 				d.syncJsonlGitBackup()
 			}
 
-		case <-wispReaperChan:
+		case <-wispReaper.C:
 			// Periodic wisp reaper — closes stale wisps (abandoned molecule steps,
 			// old patrol data) to prevent unbounded table growth (Clown Show audit).
 			if !d.isShutdownInProgress() {
 				d.reapWisps()
 			}
 
-		case <-doctorDogChan:
+		case <-doctorDog.C:
 			// Doctor dog — comprehensive Dolt health monitor: connectivity, latency,
 			// gc, zombie detection, backup staleness, and disk usage checks.
 			if !d.isShutdownInProgress() {
 				d.runDoctorDog()
 			}
 
-		case <-compactorDogChan:
+		case <-compactorDog.C:
 			// Compactor dog — flattens Dolt commit history on production databases.
 			// Reclaims commit graph storage, then runs gc to reclaim chunks.
 			if !d.isShutdownInProgress() {
 				d.runCompactorDog()
 			}
 
-		case <-scheduledMaintenanceChan:
+		case <-witnessRules.C:
+			// Witness rules — declarative per-rig rules that mail the mayor,
+			// restart agents, or escalate bead priority.
+			if !d.isShutdownInProgress() {
+				d.runWitnessRules()
+			}
+
+		case <-mergeWatch.C:
+			// Merge watch — warns agents whose in-flight branches conflict
+			// with each other, ahead of the merge queue.
+			if !d.isShutdownInProgress() {
+				d.runMergeWatch()
+			}
+
+		case <-webhooks.C:
+			// Webhooks — posts nudge failures, agent deaths, stuck workers,
+			// and other selected events to Slack/Discord/JSON endpoints.
+			if !d.isShutdownInProgress() {
+				d.runWebhooks()
+			}
+
+		case <-deaconProbes.C:
+			// Deacon probes — scheduled health checks (tmux, bd sync, disk,
+			// orphans, mail backlog) recorded to deacon/probe-state.json.
+			if !d.isShutdownInProgress() {
+				d.runDeaconProbes()
+			}
+
+		case <-utilization.C:
+			// Utilization sampler — records each agent's busy/idle/stuck/down
+			// state for the capacity heatmap.
+			if !d.isShutdownInProgress() {
+				d.runUtilizationSampler()
+			}
+
+		case <-dispatcher.C:
+			// Dispatcher — hands unassigned ready beads to idle agents.
+			if !d.isShutdownInProgress() {
+				d.runDispatcher()
+			}
+
+		case <-beadsFreed:
+			// Bead watch — a bead was created, reopened, or closed; run the
+			// dispatcher now if it's enabled, at most once per gap.
+			if !d.isShutdownInProgress() && IsPatrolEnabled(d.patrolConfig, "dispatcher") &&
+				time.Since(lastBeadDispatch) >= beadWatchDispatchGap {
+				lastBeadDispatch = time.Now()
+				d.runDispatcher()
+			}
+
+		case <-mailSchedule.C:
+			// Mail schedule — sends mail queued with gt mail send --at/--every.
+			if !d.isShutdownInProgress() {
+				d.runMailSchedule()
+			}
+
+		case <-escalationSLA.C:
+			// Escalation SLA — notifies the next level of an escalation chain
+			// when the current level hasn't acknowledged in time.
+			if !d.isShutdownInProgress() {
+				d.runEscalationSLA()
+			}
+
+		case <-scrollbackSnapshot.C:
+			// Scrollback snapshot — archives each agent's scrollback for
+			// gt snapshot show, and applies snapshot retention.
+			if !d.isShutdownInProgress() {
+				d.runScrollbackSnapshot()
+			}
+
+		case <-branchAging.C:
+			// Branch aging — mails owners of integration branches that have
+			// sat unmerged past their rig's mail threshold.
+			if !d.isShutdownInProgress() {
+				d.runBranchAging()
+			}
+
+		case <-scheduledMaintenance.C:
 			// Scheduled maintenance — checks if we're in the maintenance window
 			// and runs `gt maintain --force` when commit counts exceed threshold.
 			if !d.isShutdownInProgress() {
 				d.runScheduledMaintenance()
 			}
 
+		case snap := <-configReloads:
+			// Config hot-reload — town config changed on disk.
+			d.applyConfigReload(snap, patrols)
+
 		case <-timer.C:
 			d.heartbeat(state)
 
@@ -680,6 +736,10 @@ func (d *Daemon) heartbeat(state *State) {
 	// daemon.log uses lumberjack for automatic rotation; this handles Dolt server logs.
 	d.rotateOversizedLogs()
 
+	// 16. End expired gt quiet periods so their digests reach the agent's
+	// queue even if it hasn't drained since.
+	d.releaseExpiredQuiet()
+
 	// Update state
 	state.LastHeartbeat = time.Now()
 	state.HeartbeatCount++
@@ -703,6 +763,18 @@ func (d *Daemon) rotateOversizedLogs() {
 	}
 }
 
+// releaseExpiredQuiet ends expired quiet periods (gt quiet) and queues their
+// digests. Cheap: one small file read when nothing is quiet.
+func (d *Daemon) releaseExpiredQuiet() {
+	released, err := nudge.ReleaseExpiredQuiet(d.config.TownRoot)
+	for _, q := range released {
+		d.logger.Printf("quiet: %s quiet period ended, %d held nudge(s) queued", q.Session, len(q.Digest))
+	}
+	if err != nil {
+		d.logger.Printf("quiet: %v", err)
+	}
+}
+
 // ensureDoltServerRunning ensures the Dolt SQL server is running if configured.
 // This provides the backend for beads database access in server mode.
 // Option B throttling: pours a mol-dog-doctor molecule only when health check
@@ -894,16 +966,8 @@ func (d *Daemon) ensureDeaconRunning() {
 	const agentID = "deacon"
 
 	// Check restart tracker for backoff/crash loop
-	if d.restartTracker != nil {
-		if d.restartTracker.IsInCrashLoop(agentID) {
-			d.logger.Printf("Deacon is in crash loop, skipping restart (use 'gt daemon clear-backoff deacon' to reset)")
-			return
-		}
-		if !d.restartTracker.CanRestart(agentID) {
-			remaining := d.restartTracker.GetBackoffRemaining(agentID)
-			d.logger.Printf("Deacon restart in backoff, %s remaining", remaining.Round(time.Second))
-			return
-		}
+	if d.restartHeld(agentID) {
+		return
 	}
 
 	mgr := deacon.NewManager(d.config.TownRoot)
@@ -911,9 +975,7 @@ func (d *Daemon) ensureDeaconRunning() {
 	if err := mgr.Start(""); err != nil {
 		if err == deacon.ErrAlreadyRunning {
 			// Deacon is running - record success to reset backoff
-			if d.restartTracker != nil {
-				d.restartTracker.RecordSuccess(agentID)
-			}
+			d.recordAgentRunning(agentID)
 			return
 		}
 		d.logger.Printf("Error starting Deacon: %v", err)
@@ -921,12 +983,7 @@ func (d *Daemon) ensureDeaconRunning() {
 	}
 
 	// Record this restart attempt for backoff tracking
-	if d.restartTracker != nil {
-		d.restartTracker.RecordRestart(agentID)
-		if err := d.restartTracker.Save(); err != nil {
-			d.logger.Printf("Warning: failed to save restart state: %v", err)
-		}
-	}
+	d.recordAgentRestart(agentID)
 
 	// Track when we started the Deacon to prevent race condition in checkDeaconHeartbeat.
 	// The heartbeat file will still be stale until the Deacon runs a full patrol cycle.
@@ -1091,6 +1148,10 @@ func (d *Daemon) ensureWitnessRunning(rigName string) {
 		Path: filepath.Join(d.config.TownRoot, rigName),
 	}
 	mgr := witness.NewManager(r)
+	agentID := rigName + "/witness"
+	if d.restartHeld(agentID) {
+		return
+	}
 
 	// NOTE: Hung session detection removed for witnesses (serial killer bug).
 	// Idle witnesses legitimately produce no tmux output while waiting for work.
@@ -1102,12 +1163,15 @@ func (d *Daemon) ensureWitnessRunning(rigName string) {
 		if err == witness.ErrAlreadyRunning {
 			// Already running - this is the expected case
 			d.logger.Printf("Witness for %s already running, skipping spawn", rigName)
+			d.recordAgentRunning(agentID)
 			return
 		}
 		d.logger.Printf("Error starting witness for %s: %v", rigName, err)
 		return
 	}
 
+	d.recordAgentRestart(agentID)
+
 	d.metrics.recordRestart(d.ctx, "witness")
 	telemetry.RecordDaemonRestart(d.ctx, "witness-"+rigName)
 	d.logger.Printf("Witness session for %s started successfully", rigName)
@@ -1157,6 +1221,10 @@ func (d *Daemon) ensureRefineryRunning(rigName string) {
 		Path: filepath.Join(d.config.TownRoot, rigName),
 	}
 	mgr := refinery.NewManager(r)
+	agentID := rigName + "/refinery"
+	if d.restartHeld(agentID) {
+		return
+	}
 
 	// NOTE: Hung session detection removed for refineries (serial killer bug).
 	// Idle refineries legitimately produce no tmux output while waiting for MRs.
@@ -1168,12 +1236,15 @@ func (d *Daemon) ensureRefineryRunning(rigName string) {
 		if err == refinery.ErrAlreadyRunning {
 			// Already running - this is the expected case when fix is working
 			d.logger.Printf("Refinery for %s already running, skipping spawn", rigName)
+			d.recordAgentRunning(agentID)
 			return
 		}
 		d.logger.Printf("Error starting refinery for %s: %v", rigName, err)
 		return
 	}
 
+	d.recordAgentRestart(agentID)
+
 	d.metrics.recordRestart(d.ctx, "refinery")
 	telemetry.RecordDaemonRestart(d.ctx, "refinery-"+rigName)
 	d.logger.Printf("Refinery session for %s started successfully", rigName)
@@ -1182,17 +1253,24 @@ func (d *Daemon) ensureRefineryRunning(rigName string) {
 // ensureMayorRunning ensures the Mayor is running.
 // Uses mayor.Manager for consistent startup behavior (zombie detection, GUPP, etc.).
 func (d *Daemon) ensureMayorRunning() {
+	const agentID = "mayor"
+	if d.restartHeld(agentID) {
+		return
+	}
 	mgr := mayor.NewManager(d.config.TownRoot)
 
 	if err := mgr.Start(""); err != nil {
 		if err == mayor.ErrAlreadyRunning {
 			// Mayor is running - nothing to do
+			d.recordAgentRunning(agentID)
 			return
 		}
 		d.logger.Printf("Error starting Mayor: %v", err)
 		return
 	}
 
+	d.recordAgentRestart(agentID)
+
 	d.logger.Println("Mayor started successfully")
 }
 
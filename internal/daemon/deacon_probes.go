@@ -0,0 +1,45 @@
+package daemon
+
+import (
+	"time"
+
+	"github.com/steveyegge/gastown/internal/deacon"
+)
+
+// defaultDeaconProbesInterval is how often the probe scheduler wakes. Each
+// probe runs on its own interval (operational.deacon.probes); this only
+// bounds how late a due probe can start.
+const defaultDeaconProbesInterval = time.Minute
+
+// DeaconProbesConfig holds configuration for the deacon_probes patrol.
+// Enabled by default.
+type DeaconProbesConfig struct {
+	Enabled     bool   `json:"enabled"`
+	IntervalStr string `json:"interval,omitempty"`
+}
+
+// deaconProbesInterval returns the configured interval, or the default (1m).
+func deaconProbesInterval(config *DaemonPatrolConfig) time.Duration {
+	if config != nil && config.Patrols != nil && config.Patrols.DeaconProbes != nil {
+		if config.Patrols.DeaconProbes.IntervalStr != "" {
+			if d, err := time.ParseDuration(config.Patrols.DeaconProbes.IntervalStr); err == nil && d > 0 {
+				return d
+			}
+		}
+	}
+	return defaultDeaconProbesInterval
+}
+
+// runDeaconProbes runs the deacon health probes that are due and logs any
+// that are unhealthy.
+func (d *Daemon) runDeaconProbes() {
+	records, err := deacon.RunProbes(d.config.TownRoot, deacon.ProbeRunOptions{})
+	if err != nil {
+		d.logger.Printf("deacon_probes: %v", err)
+	}
+	for _, rec := range records {
+		if rec.Status != deacon.ProbeOK {
+			d.logger.Printf("deacon_probes: %s %s: %s", rec.Name, rec.Status, rec.Message)
+		}
+	}
+}
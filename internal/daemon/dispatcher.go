@@ -0,0 +1,51 @@
+package daemon
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"time"
+)
+
+// defaultDispatcherInterval is how often idle agents are offered ready work.
+const defaultDispatcherInterval = 2 * time.Minute
+
+// DispatcherConfig holds configuration for the dispatcher patrol, which
+// assigns unassigned ready beads to idle agents (see gt dispatch). Opt-in:
+// it hooks work onto agents without a human in the loop. Routing rules and
+// rig capacity live in the town settings' "dispatcher" block.
+type DispatcherConfig struct {
+	Enabled     bool   `json:"enabled"`
+	IntervalStr string `json:"interval,omitempty"`
+}
+
+// dispatcherInterval returns the configured interval, or the default (2m).
+func dispatcherInterval(config *DaemonPatrolConfig) time.Duration {
+	if config != nil && config.Patrols != nil && config.Patrols.Dispatcher != nil {
+		if config.Patrols.Dispatcher.IntervalStr != "" {
+			if d, err := time.ParseDuration(config.Patrols.Dispatcher.IntervalStr); err == nil && d > 0 {
+				return d
+			}
+		}
+	}
+	return defaultDispatcherInterval
+}
+
+// runDispatcher shells out to `gt dispatch` to assign ready beads to idle
+// agents. This avoids circular import between the daemon and cmd packages,
+// as with dispatchQueuedWork.
+func (d *Daemon) runDispatcher() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "gt", "dispatch")
+	cmd.Dir = d.config.TownRoot
+	cmd.Env = append(os.Environ(), "GT_DAEMON=1")
+	out, err := cmd.CombinedOutput()
+	if ctx.Err() == context.DeadlineExceeded {
+		d.logger.Printf("dispatcher: timed out after 5m")
+	} else if err != nil {
+		d.logger.Printf("dispatcher: failed: %v (output: %s)", err, string(out))
+	} else if len(out) > 0 {
+		d.logger.Printf("dispatcher: %s", string(out))
+	}
+}
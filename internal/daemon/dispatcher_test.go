@@ -0,0 +1,35 @@
+package daemon
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIsPatrolEnabled_Dispatcher(t *testing.T) {
+	// Opt-in: it hooks work onto agents unattended.
+	if IsPatrolEnabled(nil, "dispatcher") {
+		t.Error("expected dispatcher to be disabled with nil config")
+	}
+	config := &DaemonPatrolConfig{Patrols: &PatrolsConfig{}}
+	if IsPatrolEnabled(config, "dispatcher") {
+		t.Error("expected dispatcher to be disabled by default")
+	}
+	config.Patrols.Dispatcher = &DispatcherConfig{Enabled: true}
+	if !IsPatrolEnabled(config, "dispatcher") {
+		t.Error("expected dispatcher to be enabled when configured")
+	}
+}
+
+func TestDispatcherInterval(t *testing.T) {
+	if got := dispatcherInterval(nil); got != defaultDispatcherInterval {
+		t.Errorf("nil config: got %v, want %v", got, defaultDispatcherInterval)
+	}
+	config := &DaemonPatrolConfig{Patrols: &PatrolsConfig{Dispatcher: &DispatcherConfig{Enabled: true, IntervalStr: "30s"}}}
+	if got := dispatcherInterval(config); got != 30*time.Second {
+		t.Errorf("custom interval: got %v, want 30s", got)
+	}
+	config.Patrols.Dispatcher.IntervalStr = "bogus"
+	if got := dispatcherInterval(config); got != defaultDispatcherInterval {
+		t.Errorf("invalid interval: got %v, want default", got)
+	}
+}
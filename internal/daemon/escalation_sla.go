@@ -0,0 +1,52 @@
+package daemon
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"time"
+)
+
+// defaultEscalationSLAInterval is how often escalation chains are checked
+// for lapsed SLAs.
+const defaultEscalationSLAInterval = 2 * time.Minute
+
+// EscalationSLAConfig holds configuration for the escalation_sla patrol,
+// which moves unacknowledged escalations along their chain when a level's
+// SLA lapses (see gt escalate sla). Enabled by default: it only acts on
+// escalations created with a chain.
+type EscalationSLAConfig struct {
+	Enabled     bool   `json:"enabled"`
+	IntervalStr string `json:"interval,omitempty"`
+}
+
+// escalationSLAInterval returns the configured interval, or the default (2m).
+func escalationSLAInterval(config *DaemonPatrolConfig) time.Duration {
+	if config != nil && config.Patrols != nil && config.Patrols.EscalationSLA != nil {
+		if config.Patrols.EscalationSLA.IntervalStr != "" {
+			if d, err := time.ParseDuration(config.Patrols.EscalationSLA.IntervalStr); err == nil && d > 0 {
+				return d
+			}
+		}
+	}
+	return defaultEscalationSLAInterval
+}
+
+// runEscalationSLA shells out to `gt escalate sla` to notify the next chain
+// level of escalations whose SLA lapsed. This avoids circular import between
+// the daemon and cmd packages, as with runDispatcher.
+func (d *Daemon) runEscalationSLA() {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "gt", "escalate", "sla", "--quiet")
+	cmd.Dir = d.config.TownRoot
+	cmd.Env = append(os.Environ(), "GT_DAEMON=1")
+	out, err := cmd.CombinedOutput()
+	if ctx.Err() == context.DeadlineExceeded {
+		d.logger.Printf("escalation_sla: timed out after 2m")
+	} else if err != nil {
+		d.logger.Printf("escalation_sla: failed: %v (output: %s)", err, string(out))
+	} else if len(out) > 0 {
+		d.logger.Printf("escalation_sla: %s", string(out))
+	}
+}
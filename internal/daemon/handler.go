@@ -282,8 +282,12 @@ func (d *Daemon) dispatchPlugins(mgr *dog.Manager, sm *dog.SessionManager, rigsC
 	}
 }
 
-// loadRigsConfig loads the rigs configuration from mayor/rigs.json.
+// loadRigsConfig returns the rigs configuration from the current config
+// snapshot, or loads mayor/rigs.json if hot-reload isn't running.
 func (d *Daemon) loadRigsConfig() (*config.RigsConfig, error) {
+	if d.configWatch != nil {
+		return d.configWatch.Current().Rigs, nil
+	}
 	rigsPath := filepath.Join(d.config.TownRoot, "mayor", "rigs.json")
 	return config.LoadRigsConfig(rigsPath)
 }
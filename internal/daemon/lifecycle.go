@@ -389,6 +389,23 @@ func (d *Daemon) restartSession(sessionName, identity string) error {
 	// Apply theme (non-fatal: theming failure doesn't affect operation)
 	d.applySessionTheme(sessionName, parsed)
 
+	// Open the role's declared pane layout (non-fatal)
+	rigPath := ""
+	if parsed.RigName != "" {
+		rigPath = filepath.Join(d.config.TownRoot, parsed.RigName)
+	}
+	if err := session.ApplyRoleLayout(d.tmux, session.LayoutConfig{
+		SessionID: sessionName,
+		WorkDir:   workDir,
+		Role:      parsed.RoleType,
+		TownRoot:  d.config.TownRoot,
+		RigPath:   rigPath,
+		RigName:   parsed.RigName,
+		AgentName: parsed.AgentName,
+	}); err != nil {
+		d.logger.Printf("Warning: session layout for %s: %v", sessionName, err)
+	}
+
 	// Wait for Claude to start, then accept startup dialogs if they appear.
 	if err := d.tmux.WaitForCommand(sessionName, constants.SupportedShells, constants.ClaudeStartTimeout); err != nil {
 		// Non-fatal - Claude might still start
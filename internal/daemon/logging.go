@@ -0,0 +1,33 @@
+package daemon
+
+import (
+	"io"
+	"log"
+	"log/slog"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/logging"
+)
+
+// newDaemonLogger returns the daemon's logger, writing to w in the format
+// set by the town's logging config, and points the subsystem loggers (tmux,
+// beads, mail) and the standard log package at the same output. Text logs
+// keep the daemon's usual timestamped lines; JSON logs carry the daemon's
+// lines as records of the "daemon" subsystem.
+func newDaemonLogger(townRoot string, w io.Writer) *log.Logger {
+	var cfg *logging.Config
+	if settings, err := config.LoadOrCreateTownSettings(config.TownSettingsPath(townRoot)); err == nil {
+		cfg = settings.Logging
+	}
+	opts := logging.OptionsFromConfig(cfg, slog.LevelInfo, false, false)
+	opts.Writer = w
+	opts.JSON = cfg.JSON()
+	opts.Timestamps = true
+	opts.CaptureStdLog = true
+	logging.Setup(opts)
+
+	if opts.JSON {
+		return logging.StdLogger(logging.For("daemon"))
+	}
+	return log.New(w, "", log.LstdFlags)
+}
@@ -0,0 +1,44 @@
+package daemon
+
+import (
+	"time"
+
+	"github.com/steveyegge/gastown/internal/mail"
+)
+
+// defaultMailScheduleInterval is how often the mail schedule is checked for
+// due messages. Schedules have minute resolution.
+const defaultMailScheduleInterval = time.Minute
+
+// MailScheduleConfig holds configuration for the mail_schedule patrol, which
+// delivers mail queued with gt mail send --at/--every. Enabled by default:
+// it only sends what someone scheduled.
+type MailScheduleConfig struct {
+	Enabled     bool   `json:"enabled"`
+	IntervalStr string `json:"interval,omitempty"`
+}
+
+// mailScheduleInterval returns the configured interval, or the default (1m).
+func mailScheduleInterval(config *DaemonPatrolConfig) time.Duration {
+	if config != nil && config.Patrols != nil && config.Patrols.MailSchedule != nil {
+		if config.Patrols.MailSchedule.IntervalStr != "" {
+			if d, err := time.ParseDuration(config.Patrols.MailSchedule.IntervalStr); err == nil && d > 0 {
+				return d
+			}
+		}
+	}
+	return defaultMailScheduleInterval
+}
+
+// runMailSchedule sends scheduled mail that has come due.
+func (d *Daemon) runMailSchedule() {
+	router := mail.NewRouterWithTownRoot(d.config.TownRoot, d.config.TownRoot)
+	sent, err := mail.DeliverDue(d.config.TownRoot, time.Now(), router.Send)
+	router.WaitPendingNotifications()
+	if sent > 0 {
+		d.logger.Printf("mail_schedule: sent %d scheduled message(s)", sent)
+	}
+	if err != nil {
+		d.logger.Printf("mail_schedule: %v", err)
+	}
+}
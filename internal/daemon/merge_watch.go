@@ -0,0 +1,58 @@
+package daemon
+
+import (
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/witness"
+)
+
+// defaultMergeWatchInterval is how often in-flight branches are trial-merged.
+// Each pass fetches and merges every overlapping pair, so this is kept well
+// above the witness rules interval.
+const defaultMergeWatchInterval = 10 * time.Minute
+
+// MergeWatchConfig holds configuration for the merge_watch patrol, which
+// warns agents whose branches will conflict with each other.
+// Opt-in: disabled unless explicitly enabled.
+type MergeWatchConfig struct {
+	Enabled     bool   `json:"enabled"`
+	IntervalStr string `json:"interval,omitempty"`
+}
+
+// mergeWatchInterval returns the configured interval, or the default (10m).
+func mergeWatchInterval(config *DaemonPatrolConfig) time.Duration {
+	if config != nil && config.Patrols != nil && config.Patrols.MergeWatch != nil {
+		if config.Patrols.MergeWatch.IntervalStr != "" {
+			if d, err := time.ParseDuration(config.Patrols.MergeWatch.IntervalStr); err == nil && d > 0 {
+				return d
+			}
+		}
+	}
+	return defaultMergeWatchInterval
+}
+
+// runMergeWatch trial-merges each witness-patrolled rig's overlapping agent
+// branches and logs the conflicts it mailed about.
+func (d *Daemon) runMergeWatch() {
+	for _, rigName := range d.getPatrolRigs(constants.RoleWitness) {
+		result, err := witness.CheckCollisions(d.config.TownRoot, rigName, false)
+		if err != nil {
+			d.logger.Printf("merge_watch: %s: %v", rigName, err)
+			continue
+		}
+		for _, c := range result.Collisions {
+			switch {
+			case c.Error != nil:
+				d.logger.Printf("merge_watch: %s: %s ↔ %s: %v", rigName, c.A.Name, c.B.Name, c.Error)
+			case c.Warned:
+				d.logger.Printf("merge_watch: %s: warned %s and %s: %s conflict",
+					rigName, c.A.Name, c.B.Name, strings.Join(c.Conflicts, ", "))
+			}
+		}
+		for _, err := range result.Errors {
+			d.logger.Printf("merge_watch: %s: %v", rigName, err)
+		}
+	}
+}
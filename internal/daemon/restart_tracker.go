@@ -7,6 +7,8 @@ import (
 	"path/filepath"
 	"sync"
 	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
 )
 
 // RestartTrackerConfig holds configurable parameters for restart tracking.
@@ -88,6 +90,9 @@ type AgentRestartInfo struct {
 	RestartCount   int       `json:"restart_count"`
 	BackoffUntil   time.Time `json:"backoff_until"`
 	CrashLoopSince time.Time `json:"crash_loop_since,omitempty"`
+
+	// Restarts are the restart times within the crash-loop window.
+	Restarts []time.Time `json:"restarts,omitempty"`
 }
 
 // NewRestartTracker creates a new restart tracker with the given config.
@@ -154,7 +159,9 @@ func (rt *RestartTracker) CanRestart(agentID string) bool {
 }
 
 // RecordRestart records a restart attempt and calculates next backoff.
-func (rt *RestartTracker) RecordRestart(agentID string) {
+// It returns true if this restart put the agent into crash-loop state:
+// CrashLoopCount restarts within CrashLoopWindow.
+func (rt *RestartTracker) RecordRestart(agentID string) (enteredCrashLoop bool) {
 	rt.mu.Lock()
 	defer rt.mu.Unlock()
 
@@ -170,6 +177,7 @@ func (rt *RestartTracker) RecordRestart(agentID string) {
 		// Reset backoff - agent was stable
 		info.RestartCount = 0
 		info.CrashLoopSince = time.Time{}
+		info.Restarts = nil
 	}
 
 	info.LastRestart = now
@@ -185,13 +193,20 @@ func (rt *RestartTracker) RecordRestart(agentID string) {
 	}
 	info.BackoffUntil = now.Add(backoffDuration)
 
-	// Check for crash loop
-	if info.RestartCount >= rt.config.CrashLoopCount {
-		windowStart := now.Add(-rt.config.CrashLoopWindow)
-		if info.LastRestart.After(windowStart) {
-			info.CrashLoopSince = now
+	// Check for crash loop: count only the restarts inside the window
+	windowStart := now.Add(-rt.config.CrashLoopWindow)
+	recent := info.Restarts[:0]
+	for _, t := range info.Restarts {
+		if t.After(windowStart) {
+			recent = append(recent, t)
 		}
 	}
+	info.Restarts = append(recent, now)
+	if info.CrashLoopSince.IsZero() && len(info.Restarts) >= rt.config.CrashLoopCount {
+		info.CrashLoopSince = now
+		return true
+	}
+	return false
 }
 
 // RecordSuccess records that an agent is running successfully.
@@ -210,6 +225,7 @@ func (rt *RestartTracker) RecordSuccess(agentID string) {
 		info.RestartCount = 0
 		info.CrashLoopSince = time.Time{}
 		info.BackoffUntil = time.Time{}
+		info.Restarts = nil
 	}
 }
 
@@ -252,10 +268,12 @@ func (rt *RestartTracker) ClearCrashLoop(agentID string) {
 		info.CrashLoopSince = time.Time{}
 		info.RestartCount = 0
 		info.BackoffUntil = time.Time{}
+		info.Restarts = nil
 	}
 }
 
-// ClearAgentBackoff clears the crash loop and backoff state for an agent on disk.
+// ClearAgentBackoff clears the crash loop and backoff state for an agent on disk,
+// and the crash-loop mark on its agent bead.
 // Used by 'gt daemon clear-backoff' to reset an agent stuck in crash loop.
 // The daemon reloads this on next heartbeat (or immediately on SIGUSR2).
 func ClearAgentBackoff(townRoot, agentID string) error {
@@ -263,6 +281,29 @@ func ClearAgentBackoff(townRoot, agentID string) error {
 	if err := rt.Load(); err != nil {
 		return fmt.Errorf("loading restart state: %w", err)
 	}
+	wasLooping := rt.IsInCrashLoop(agentID)
 	rt.ClearCrashLoop(agentID)
-	return rt.Save()
+	if err := rt.Save(); err != nil {
+		return err
+	}
+	if beadID := crashLoopBeadID(townRoot, agentID); wasLooping && beadID != "" {
+		_ = beads.New(townRoot).MarkAgentCrashLoop(beadID, false) // Best-effort: the bead may not exist
+	}
+	return nil
+}
+
+// CrashLoopingAgents returns the agents the daemon has stopped restarting
+// because they are crash-looping, with when each entered that state.
+func CrashLoopingAgents(townRoot string) (map[string]time.Time, error) {
+	rt := NewRestartTracker(townRoot, RestartTrackerConfig{})
+	if err := rt.Load(); err != nil {
+		return nil, fmt.Errorf("loading restart state: %w", err)
+	}
+	looping := make(map[string]time.Time)
+	for agentID, info := range rt.state.Agents {
+		if !info.CrashLoopSince.IsZero() {
+			looping[agentID] = info.CrashLoopSince
+		}
+	}
+	return looping, nil
 }
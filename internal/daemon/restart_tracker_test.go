@@ -0,0 +1,84 @@
+package daemon
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRecordRestart_CrashLoopWindow(t *testing.T) {
+	rt := NewRestartTracker(t.TempDir(), RestartTrackerConfig{CrashLoopCount: 3, CrashLoopWindow: time.Minute})
+
+	// Restarts that fell outside the window don't count.
+	rt.state.Agents["gastown/witness"] = &AgentRestartInfo{
+		LastRestart: time.Now().Add(-2 * time.Minute),
+		Restarts:    []time.Time{time.Now().Add(-5 * time.Minute), time.Now().Add(-2 * time.Minute)},
+	}
+	if rt.RecordRestart("gastown/witness") {
+		t.Fatal("entered crash loop with only one restart inside the window")
+	}
+	if got := len(rt.state.Agents["gastown/witness"].Restarts); got != 1 {
+		t.Errorf("kept %d restart times, want only the one inside the window", got)
+	}
+
+	if rt.RecordRestart("gastown/witness") {
+		t.Fatal("entered crash loop after two restarts, want three")
+	}
+	if !rt.RecordRestart("gastown/witness") {
+		t.Fatal("third restart within the window didn't enter crash loop")
+	}
+	if !rt.IsInCrashLoop("gastown/witness") || rt.CanRestart("gastown/witness") {
+		t.Error("crash-looping agent can still be restarted")
+	}
+	if rt.RecordRestart("gastown/witness") {
+		t.Error("RecordRestart reported entering a crash loop the agent was already in")
+	}
+
+	rt.ClearCrashLoop("gastown/witness")
+	if rt.IsInCrashLoop("gastown/witness") || len(rt.state.Agents["gastown/witness"].Restarts) != 0 {
+		t.Error("ClearCrashLoop left crash-loop state behind")
+	}
+}
+
+func TestCrashLoopingAgents(t *testing.T) {
+	townRoot := t.TempDir()
+	rt := NewRestartTracker(townRoot, RestartTrackerConfig{CrashLoopCount: 1})
+	if err := os.MkdirAll(filepath.Join(townRoot, "daemon"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	rt.RecordRestart("deacon")
+	rt.state.Agents["mayor"] = &AgentRestartInfo{LastRestart: time.Now()}
+	if err := rt.Save(); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	looping, err := CrashLoopingAgents(townRoot)
+	if err != nil {
+		t.Fatalf("CrashLoopingAgents: %v", err)
+	}
+	if _, ok := looping["deacon"]; !ok || len(looping) != 1 {
+		t.Errorf("CrashLoopingAgents = %v, want only deacon", looping)
+	}
+}
+
+func TestCrashLoopBeadID(t *testing.T) {
+	townRoot := t.TempDir()
+	tests := []struct {
+		agentID string
+		want    string
+	}{
+		{"deacon", "hq-deacon"},
+		{"mayor", "hq-mayor"},
+		{"boot", ""},
+		{"gastown/polecats", ""},
+	}
+	for _, tt := range tests {
+		if got := crashLoopBeadID(townRoot, tt.agentID); got != tt.want {
+			t.Errorf("crashLoopBeadID(%q) = %q, want %q", tt.agentID, got, tt.want)
+		}
+	}
+	if got := crashLoopBeadID(townRoot, "gastown/witness"); got == "" {
+		t.Error("crashLoopBeadID(gastown/witness) is empty")
+	}
+}
@@ -0,0 +1,75 @@
+package daemon
+
+import (
+	"time"
+
+	"github.com/steveyegge/gastown/internal/snapshot"
+)
+
+const (
+	// defaultScrollbackSnapshotInterval is how often agent scrollback is
+	// archived. Unchanged panes cost nothing, and a busy pane's snapshot
+	// is a small diff.
+	defaultScrollbackSnapshotInterval = 10 * time.Minute
+
+	// defaultScrollbackSnapshotRetain is how long snapshots are kept.
+	defaultScrollbackSnapshotRetain = 72 * time.Hour
+
+	// defaultScrollbackSnapshotMaxPerAgent caps snapshots per session.
+	defaultScrollbackSnapshotMaxPerAgent = 500
+)
+
+// ScrollbackSnapshotConfig holds configuration for the scrollback_snapshot
+// patrol, which archives every agent's scrollback for gt snapshot show.
+// Enabled by default.
+type ScrollbackSnapshotConfig struct {
+	Enabled     bool   `json:"enabled"`
+	IntervalStr string `json:"interval,omitempty"`
+	// RetainStr is how long snapshots are kept (default "72h").
+	RetainStr string `json:"retain,omitempty"`
+	// MaxPerAgent caps the snapshots kept per session (default 500).
+	MaxPerAgent int `json:"max_per_agent,omitempty"`
+}
+
+// scrollbackSnapshotInterval returns the configured interval, or the default (10m).
+func scrollbackSnapshotInterval(config *DaemonPatrolConfig) time.Duration {
+	if config != nil && config.Patrols != nil && config.Patrols.ScrollbackSnapshot != nil {
+		if config.Patrols.ScrollbackSnapshot.IntervalStr != "" {
+			if d, err := time.ParseDuration(config.Patrols.ScrollbackSnapshot.IntervalStr); err == nil && d > 0 {
+				return d
+			}
+		}
+	}
+	return defaultScrollbackSnapshotInterval
+}
+
+// scrollbackSnapshotRetention returns the configured retention limits, or
+// the defaults (72h, 500 per session).
+func scrollbackSnapshotRetention(config *DaemonPatrolConfig) snapshot.Retention {
+	keep := snapshot.Retention{MaxAge: defaultScrollbackSnapshotRetain, MaxCount: defaultScrollbackSnapshotMaxPerAgent}
+	if config != nil && config.Patrols != nil && config.Patrols.ScrollbackSnapshot != nil {
+		c := config.Patrols.ScrollbackSnapshot
+		if d, err := time.ParseDuration(c.RetainStr); err == nil && d > 0 {
+			keep.MaxAge = d
+		}
+		if c.MaxPerAgent > 0 {
+			keep.MaxCount = c.MaxPerAgent
+		}
+	}
+	return keep
+}
+
+// runScrollbackSnapshot archives every running agent's scrollback and
+// prunes old snapshots.
+func (d *Daemon) runScrollbackSnapshot() {
+	res := snapshot.Archive(d.config.TownRoot, d.tmux, scrollbackSnapshotRetention(d.patrolConfig), time.Now())
+	for _, err := range res.Errors {
+		d.logger.Printf("scrollback_snapshot: %v", err)
+	}
+	for _, name := range res.Coarse {
+		d.logger.Printf("scrollback_snapshot: %s changed too much to diff, stored in full", name)
+	}
+	if res.Saved > 0 || res.Pruned > 0 {
+		d.logger.Printf("scrollback_snapshot: %d saved, %d unchanged, %d pruned", res.Saved, res.Unchanged, res.Pruned)
+	}
+}
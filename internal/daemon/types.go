@@ -128,6 +128,17 @@ type PatrolsConfig struct {
 	CompactorDog           *CompactorDogConfig            `json:"compactor_dog,omitempty"`
 	ScheduledMaintenance   *ScheduledMaintenanceConfig    `json:"scheduled_maintenance,omitempty"`
 	RestartTracker         *RestartTrackerConfig          `json:"restart_tracker,omitempty"`
+	WitnessRules           *WitnessRulesConfig            `json:"witness_rules,omitempty"`
+	MergeWatch             *MergeWatchConfig              `json:"merge_watch,omitempty"`
+	BeadWatch              *BeadWatchConfig               `json:"bead_watch,omitempty"`
+	Webhooks               *WebhooksConfig                `json:"webhooks,omitempty"`
+	DeaconProbes           *DeaconProbesConfig            `json:"deacon_probes,omitempty"`
+	UtilizationSampler     *UtilizationSamplerConfig      `json:"utilization_sampler,omitempty"`
+	Dispatcher             *DispatcherConfig              `json:"dispatcher,omitempty"`
+	MailSchedule           *MailScheduleConfig            `json:"mail_schedule,omitempty"`
+	EscalationSLA          *EscalationSLAConfig           `json:"escalation_sla,omitempty"`
+	ScrollbackSnapshot     *ScrollbackSnapshotConfig      `json:"scrollback_snapshot,omitempty"`
+	BranchAging            *BranchAgingConfig             `json:"branch_aging,omitempty"`
 }
 
 // DoltRemotesConfig holds configuration for the dolt_remotes patrol.
@@ -281,6 +292,24 @@ func IsPatrolEnabled(config *DaemonPatrolConfig, patrol string) bool {
 		}
 		return config.Patrols.CompactorDog.Enabled
 	}
+	if patrol == "dispatcher" {
+		if config == nil || config.Patrols == nil || config.Patrols.Dispatcher == nil {
+			return false
+		}
+		return config.Patrols.Dispatcher.Enabled
+	}
+	if patrol == "merge_watch" {
+		if config == nil || config.Patrols == nil || config.Patrols.MergeWatch == nil {
+			return false
+		}
+		return config.Patrols.MergeWatch.Enabled
+	}
+	if patrol == "bead_watch" {
+		if config == nil || config.Patrols == nil || config.Patrols.BeadWatch == nil {
+			return false
+		}
+		return config.Patrols.BeadWatch.Enabled
+	}
 	if patrol == "scheduled_maintenance" {
 		if config == nil || config.Patrols == nil || config.Patrols.ScheduledMaintenance == nil {
 			return false
@@ -309,6 +338,38 @@ func IsPatrolEnabled(config *DaemonPatrolConfig, patrol string) bool {
 		if config.Patrols.Handler != nil {
 			return config.Patrols.Handler.Enabled
 		}
+	case "witness_rules":
+		if config.Patrols.WitnessRules != nil {
+			return config.Patrols.WitnessRules.Enabled
+		}
+	case "webhooks":
+		if config.Patrols.Webhooks != nil {
+			return config.Patrols.Webhooks.Enabled
+		}
+	case "deacon_probes":
+		if config.Patrols.DeaconProbes != nil {
+			return config.Patrols.DeaconProbes.Enabled
+		}
+	case "utilization_sampler":
+		if config.Patrols.UtilizationSampler != nil {
+			return config.Patrols.UtilizationSampler.Enabled
+		}
+	case "mail_schedule":
+		if config.Patrols.MailSchedule != nil {
+			return config.Patrols.MailSchedule.Enabled
+		}
+	case "escalation_sla":
+		if config.Patrols.EscalationSLA != nil {
+			return config.Patrols.EscalationSLA.Enabled
+		}
+	case "scrollback_snapshot":
+		if config.Patrols.ScrollbackSnapshot != nil {
+			return config.Patrols.ScrollbackSnapshot.Enabled
+		}
+	case "branch_aging":
+		if config.Patrols.BranchAging != nil {
+			return config.Patrols.BranchAging.Enabled
+		}
 	}
 	return true // Default: enabled
 }
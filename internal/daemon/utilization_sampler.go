@@ -0,0 +1,45 @@
+package daemon
+
+import (
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/utilization"
+)
+
+// defaultUtilizationSamplerInterval is how often agent states are sampled
+// for the capacity heatmap. Heatmap buckets are rarely finer than an hour,
+// so a few samples per bucket is plenty.
+const defaultUtilizationSamplerInterval = 5 * time.Minute
+
+// UtilizationSamplerConfig holds configuration for the utilization_sampler
+// patrol. Enabled by default.
+type UtilizationSamplerConfig struct {
+	Enabled     bool   `json:"enabled"`
+	IntervalStr string `json:"interval,omitempty"`
+}
+
+// utilizationSamplerInterval returns the configured interval, or the default (5m).
+func utilizationSamplerInterval(config *DaemonPatrolConfig) time.Duration {
+	if config != nil && config.Patrols != nil && config.Patrols.UtilizationSampler != nil {
+		if config.Patrols.UtilizationSampler.IntervalStr != "" {
+			if d, err := time.ParseDuration(config.Patrols.UtilizationSampler.IntervalStr); err == nil && d > 0 {
+				return d
+			}
+		}
+	}
+	return defaultUtilizationSamplerInterval
+}
+
+// runUtilizationSampler records every agent's current state to the
+// utilization log read by gt stats heatmap.
+func (d *Daemon) runUtilizationSampler() {
+	sample, err := utilization.Take(beads.New(d.config.TownRoot))
+	if err != nil {
+		d.logger.Printf("utilization_sampler: %v", err)
+		return
+	}
+	if err := utilization.Record(d.config.TownRoot, sample); err != nil {
+		d.logger.Printf("utilization_sampler: recording sample: %v", err)
+	}
+}
@@ -0,0 +1,83 @@
+package daemon
+
+import (
+	"context"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/notify"
+)
+
+// defaultWebhooksInterval is how often new events are checked for webhook
+// notifications. Kept short: these are the events a human wants promptly.
+const defaultWebhooksInterval = 30 * time.Second
+
+// WebhooksConfig holds configuration for the webhooks patrol.
+// Enabled by default; it is a no-op unless "notifications.webhooks" is set
+// in settings/config.json.
+type WebhooksConfig struct {
+	Enabled     bool   `json:"enabled"`
+	IntervalStr string `json:"interval,omitempty"`
+}
+
+// webhooksInterval returns the configured interval, or the default (30s).
+func webhooksInterval(config *DaemonPatrolConfig) time.Duration {
+	if config != nil && config.Patrols != nil && config.Patrols.Webhooks != nil {
+		if config.Patrols.Webhooks.IntervalStr != "" {
+			if d, err := time.ParseDuration(config.Patrols.Webhooks.IntervalStr); err == nil && d > 0 {
+				return d
+			}
+		}
+	}
+	return defaultWebhooksInterval
+}
+
+// webhooksPassTimeout bounds one background delivery pass. Events the pass
+// doesn't get to are delivered by the next one.
+const webhooksPassTimeout = 2 * time.Minute
+
+// runWebhooks fires configured webhooks for events logged since the last
+// pass. Settings come from the current config snapshot, so webhook edits
+// apply without a daemon restart. Delivery runs in the background so a slow or unreachable
+// endpoint never stalls the heartbeat loop; a pass is skipped while the
+// previous one is still delivering.
+func (d *Daemon) runWebhooks() {
+	settings, err := d.townSettings()
+	if err != nil {
+		d.logger.Printf("webhooks: loading town settings: %v", err)
+		return
+	}
+	cfg := settings.Notifications
+	if !cfg.Enabled() {
+		return
+	}
+	if err := cfg.Validate(); err != nil {
+		d.logger.Printf("webhooks: %v", err)
+		return
+	}
+
+	if !d.webhooksRunning.CompareAndSwap(false, true) {
+		return
+	}
+	go func() {
+		defer d.webhooksRunning.Store(false)
+		ctx, cancel := context.WithTimeout(d.ctx, webhooksPassTimeout)
+		defer cancel()
+		d.dispatchWebhooks(ctx, cfg)
+	}()
+}
+
+// dispatchWebhooks runs one delivery pass and logs its outcome.
+func (d *Daemon) dispatchWebhooks(ctx context.Context, cfg *config.NotificationsConfig) {
+	result, err := notify.Dispatch(ctx, d.config.TownRoot, cfg, notify.NewSender())
+	if err != nil {
+		d.logger.Printf("webhooks: %v", err)
+		return
+	}
+	for _, err := range result.Errors {
+		d.logger.Printf("webhooks: delivery failed: %v", err)
+	}
+	if result.Sent > 0 {
+		d.logger.Printf("webhooks: sent %d notification(s) for %d new event(s)", result.Sent, result.Events)
+	}
+}
@@ -0,0 +1,76 @@
+package daemon
+
+import (
+	"time"
+
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/witness"
+)
+
+// defaultWitnessRulesInterval is how often witness rules are evaluated.
+// Rules express idle and failure thresholds in minutes, so evaluating more
+// often buys little.
+const defaultWitnessRulesInterval = 2 * time.Minute
+
+// WitnessRulesConfig holds configuration for the witness_rules patrol.
+// Enabled by default; the rules themselves are defined per rig under
+// "witness.rules" in <rig>/settings/config.json.
+type WitnessRulesConfig struct {
+	Enabled     bool   `json:"enabled"`
+	IntervalStr string `json:"interval,omitempty"`
+}
+
+// witnessRulesInterval returns the configured interval, or the default (2m).
+func witnessRulesInterval(config *DaemonPatrolConfig) time.Duration {
+	if config != nil && config.Patrols != nil && config.Patrols.WitnessRules != nil {
+		if config.Patrols.WitnessRules.IntervalStr != "" {
+			if d, err := time.ParseDuration(config.Patrols.WitnessRules.IntervalStr); err == nil && d > 0 {
+				return d
+			}
+		}
+	}
+	return defaultWitnessRulesInterval
+}
+
+// runWitnessRules scans each witness-patrolled rig's agent panes for
+// anomalies, then evaluates its rules, and logs what was found and what
+// fired. Rigs excluded from the witness patrol are skipped.
+func (d *Daemon) runWitnessRules() {
+	bd := witness.DefaultBdCli()
+	for _, rigName := range d.getPatrolRigs(constants.RoleWitness) {
+		anomalies := witness.DetectAnomalies(d.config.TownRoot, rigName, false)
+		for _, a := range anomalies.Found {
+			switch {
+			case a.Skipped != "":
+			case a.Action != "":
+				d.logger.Printf("witness_rules: %s: %s pane shows %s: %s (%s)", rigName, a.Agent, a.Kind, a.Detail, a.Action)
+			default:
+				d.logger.Printf("witness_rules: %s: %s pane shows %s: %s", rigName, a.Agent, a.Kind, a.Detail)
+			}
+		}
+		for _, err := range anomalies.Errors {
+			d.logger.Printf("witness_rules: %s: %v", rigName, err)
+		}
+
+		result, err := witness.EvaluateRules(bd, d.config.TownRoot, rigName, false)
+		if err != nil {
+			d.logger.Printf("witness_rules: %s: %v", rigName, err)
+			continue
+		}
+		for _, f := range result.Fired {
+			switch {
+			case f.Skipped != "":
+				continue
+			case f.Error != nil:
+				d.logger.Printf("witness_rules: %s: rule %s on %s (%s): %s failed: %v",
+					rigName, f.Rule.Name, f.Agent.Name, f.Reason, f.Rule.Do, f.Error)
+			default:
+				d.logger.Printf("witness_rules: %s: rule %s on %s (%s): %s",
+					rigName, f.Rule.Name, f.Agent.Name, f.Reason, f.Rule.Do)
+			}
+		}
+		for _, err := range result.Errors {
+			d.logger.Printf("witness_rules: %s: %v", rigName, err)
+		}
+	}
+}
@@ -149,6 +149,14 @@ func (m *Manager) Start(agentOverride string) error {
 	if paneID, err := t.GetPaneID(sessionID); err == nil {
 		_ = t.SetEnvironment(sessionID, "GT_PANE_ID", paneID)
 	}
+	// Open the deacon's declared pane layout (real tmux only; non-fatal)
+	if real, ok := t.(*tmux.Tmux); ok {
+		if err := session.ApplyRoleLayout(real, session.LayoutConfig{
+			SessionID: sessionID, WorkDir: deaconDir, Role: "deacon", TownRoot: m.townRoot,
+		}); err != nil {
+			fmt.Fprintf(os.Stderr, "warning: session layout for %s: %v\n", sessionID, err)
+		}
+	}
 
 	// Apply Deacon theming (non-fatal: theming failure doesn't affect operation)
 	theme := tmux.DeaconTheme()
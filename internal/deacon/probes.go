@@ -0,0 +1,246 @@
+package deacon
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+// Probe statuses.
+const (
+	ProbeOK   = "ok"
+	ProbeWarn = "warn"
+	ProbeFail = "fail"
+)
+
+// ProbeResult is the outcome of one probe run.
+type ProbeResult struct {
+	Status  string   `json:"status"`
+	Message string   `json:"message"`
+	Details []string `json:"details,omitempty"`
+}
+
+// Probe is a named health check run on a schedule by RunProbes.
+type Probe struct {
+	// Name identifies the probe in config (operational.deacon.probes) and state.
+	Name string
+
+	// Description is a one-line summary for listings.
+	Description string
+
+	// Interval is the default time between runs.
+	Interval time.Duration
+
+	// Run performs the check against the town.
+	Run func(townRoot string) ProbeResult
+}
+
+var (
+	probesMu sync.Mutex
+	probes   []Probe
+)
+
+// RegisterProbe adds a probe to the schedule, replacing any probe with the
+// same name.
+func RegisterProbe(p Probe) {
+	probesMu.Lock()
+	defer probesMu.Unlock()
+	for i := range probes {
+		if probes[i].Name == p.Name {
+			probes[i] = p
+			return
+		}
+	}
+	probes = append(probes, p)
+}
+
+// Probes returns the registered probes in registration order.
+func Probes() []Probe {
+	probesMu.Lock()
+	defer probesMu.Unlock()
+	return slices.Clone(probes)
+}
+
+// ProbeRecord is the latest result of a probe plus its schedule state.
+type ProbeRecord struct {
+	Name string `json:"name"`
+	ProbeResult
+
+	// LastRun is when the probe last ran.
+	LastRun time.Time `json:"last_run"`
+
+	// Since is when the probe entered its current status.
+	Since time.Time `json:"since"`
+
+	// BeadID is the open bead recording a warn/fail status; cleared (and
+	// the bead closed) when the probe recovers.
+	BeadID string `json:"bead_id,omitempty"`
+}
+
+// ProbeState holds the latest record of every probe that has run.
+// Persisted to deacon/probe-state.json.
+type ProbeState struct {
+	Probes      map[string]*ProbeRecord `json:"probes"`
+	LastUpdated time.Time               `json:"last_updated"`
+}
+
+// ProbeStateFile returns the path to the probe state file.
+func ProbeStateFile(townRoot string) string {
+	return filepath.Join(townRoot, "deacon", "probe-state.json")
+}
+
+// LoadProbeState loads probe state from disk.
+// Returns empty state if file doesn't exist.
+func LoadProbeState(townRoot string) (*ProbeState, error) {
+	data, err := os.ReadFile(ProbeStateFile(townRoot)) //nolint:gosec // G304: path is constructed from trusted townRoot
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &ProbeState{Probes: make(map[string]*ProbeRecord)}, nil
+		}
+		return nil, fmt.Errorf("reading probe state: %w", err)
+	}
+
+	var state ProbeState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("parsing probe state: %w", err)
+	}
+	if state.Probes == nil {
+		state.Probes = make(map[string]*ProbeRecord)
+	}
+	return &state, nil
+}
+
+// SaveProbeState saves probe state to disk.
+func SaveProbeState(townRoot string, state *ProbeState) error {
+	stateFile := ProbeStateFile(townRoot)
+	if err := os.MkdirAll(filepath.Dir(stateFile), 0755); err != nil {
+		return fmt.Errorf("creating deacon directory: %w", err)
+	}
+
+	state.LastUpdated = time.Now().UTC()
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling probe state: %w", err)
+	}
+	return os.WriteFile(stateFile, data, 0600)
+}
+
+// ProbeRunOptions selects which probes RunProbes runs.
+type ProbeRunOptions struct {
+	// Force runs probes even if their interval hasn't elapsed.
+	Force bool
+
+	// Only restricts the run to these probe names (empty = all).
+	Only []string
+}
+
+// probeDue reports whether a probe last run at lastRun is due at now.
+func probeDue(lastRun time.Time, interval time.Duration, now time.Time) bool {
+	return lastRun.IsZero() || now.Sub(lastRun) >= interval
+}
+
+// Probe bead transitions returned by applyProbeResult.
+const (
+	probeBeadNone    = ""
+	probeBeadOpen    = "open"    // Probe went unhealthy; record it
+	probeBeadResolve = "resolve" // Probe recovered; close the record
+)
+
+// applyProbeResult folds a result into rec and reports what should happen
+// to the probe's bead.
+func applyProbeResult(rec *ProbeRecord, res ProbeResult, now time.Time) string {
+	if rec.Status != res.Status || rec.Since.IsZero() {
+		rec.Since = now
+	}
+	rec.ProbeResult = res
+	rec.LastRun = now
+
+	switch {
+	case res.Status == ProbeOK && rec.BeadID != "":
+		return probeBeadResolve
+	case res.Status != ProbeOK && rec.BeadID == "":
+		return probeBeadOpen
+	}
+	return probeBeadNone
+}
+
+// RunProbes runs every enabled probe that is due (or all selected probes
+// with Force), saves their results, and returns the records of the probes
+// that ran. An unhealthy result opens a town bead labeled gt:probe; the
+// bead is closed when the probe recovers. Bead errors are reported in the
+// record's details rather than failing the run.
+func RunProbes(townRoot string, opts ProbeRunOptions) ([]*ProbeRecord, error) {
+	state, err := LoadProbeState(townRoot)
+	if err != nil {
+		return nil, err
+	}
+	cfg := config.LoadOperationalConfig(townRoot).GetDeaconConfig()
+	bd := beads.New(townRoot)
+	now := time.Now().UTC()
+
+	var ran []*ProbeRecord
+	for _, p := range Probes() {
+		if len(opts.Only) > 0 && !slices.Contains(opts.Only, p.Name) {
+			continue
+		}
+		if !cfg.ProbeEnabled(p.Name) {
+			continue
+		}
+		rec := state.Probes[p.Name]
+		if rec == nil {
+			rec = &ProbeRecord{Name: p.Name}
+			state.Probes[p.Name] = rec
+		}
+		if !opts.Force && !probeDue(rec.LastRun, cfg.ProbeIntervalD(p.Name, p.Interval), now) {
+			continue
+		}
+
+		switch applyProbeResult(rec, p.Run(townRoot), now) {
+		case probeBeadOpen:
+			issue, err := bd.Create(beads.CreateOptions{
+				Title:       fmt.Sprintf("Probe %s: %s", p.Name, rec.Message),
+				Labels:      []string{"gt:probe", "probe:" + p.Name},
+				Priority:    probeBeadPriority(rec.Status),
+				Description: strings.Join(append([]string{rec.Message}, rec.Details...), "\n"),
+				Actor:       "deacon",
+				Ephemeral:   true,
+			})
+			if err != nil {
+				rec.Details = append(rec.Details, fmt.Sprintf("recording bead: %v", err))
+			} else {
+				rec.BeadID = issue.ID
+			}
+		case probeBeadResolve:
+			if err := bd.CloseWithReason("probe recovered: "+rec.Message, rec.BeadID); err != nil {
+				rec.Details = append(rec.Details, fmt.Sprintf("closing bead %s: %v", rec.BeadID, err))
+			} else {
+				rec.BeadID = ""
+			}
+		}
+		ran = append(ran, rec)
+	}
+
+	if len(ran) > 0 {
+		if err := SaveProbeState(townRoot, state); err != nil {
+			return ran, err
+		}
+	}
+	return ran, nil
+}
+
+// probeBeadPriority maps a probe status to a bead priority.
+func probeBeadPriority(status string) int {
+	if status == ProbeFail {
+		return 1
+	}
+	return 2
+}
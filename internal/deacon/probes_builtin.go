@@ -0,0 +1,169 @@
+package deacon
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/doltserver"
+	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// Thresholds for the built-in probes.
+const (
+	// BdSyncMaxAge is how long a database with a remote may go unpushed.
+	BdSyncMaxAge = 24 * time.Hour
+
+	// DiskMinFreeBytes fails the disk probe below this much free space.
+	DiskMinFreeBytes = 1 << 30 // 1 GiB
+
+	// DiskMinFreePercent warns below this share of free space.
+	DiskMinFreePercent = 10
+
+	// MailBacklogMax warns when a town mailbox has this many unread messages.
+	MailBacklogMax = 20
+)
+
+func init() {
+	RegisterProbe(Probe{
+		Name:        "tmux",
+		Description: "tmux server reachable and mayor/deacon sessions alive",
+		Interval:    time.Minute,
+		Run:         probeTmux,
+	})
+	RegisterProbe(Probe{
+		Name:        "bd_sync",
+		Description: "beads databases pushed to their remotes recently",
+		Interval:    30 * time.Minute,
+		Run:         probeBdSync,
+	})
+	RegisterProbe(Probe{
+		Name:        "disk_space",
+		Description: "free space on the town's filesystem",
+		Interval:    10 * time.Minute,
+		Run:         probeDiskSpace,
+	})
+	RegisterProbe(Probe{
+		Name:        "orphan_processes",
+		Description: "agent processes detached from any session",
+		Interval:    10 * time.Minute,
+		Run:         probeOrphanProcesses,
+	})
+	RegisterProbe(Probe{
+		Name:        "mail_backlog",
+		Description: "unread mail piling up for the mayor or deacon",
+		Interval:    5 * time.Minute,
+		Run:         probeMailBacklog,
+	})
+}
+
+func probeTmux(townRoot string) ProbeResult {
+	t := tmux.NewTmux()
+	if !t.IsAvailable() {
+		return ProbeResult{Status: ProbeFail, Message: "tmux not installed"}
+	}
+	var missing []string
+	for _, name := range []string{session.MayorSessionName(), session.DeaconSessionName()} {
+		alive, err := t.HasSession(name)
+		if err != nil {
+			return ProbeResult{Status: ProbeFail, Message: fmt.Sprintf("tmux server unreachable: %v", err)}
+		}
+		if !alive {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return ProbeResult{Status: ProbeWarn, Message: fmt.Sprintf("%d town session(s) down", len(missing)), Details: missing}
+	}
+	return ProbeResult{Status: ProbeOK, Message: "mayor and deacon sessions alive"}
+}
+
+func probeBdSync(townRoot string) ProbeResult {
+	dbs, err := doltserver.ListDatabases(townRoot)
+	if err != nil {
+		return ProbeResult{Status: ProbeWarn, Message: fmt.Sprintf("listing databases: %v", err)}
+	}
+	var stale []string
+	withRemote := 0
+	for _, db := range dbs {
+		remote, _, err := doltserver.FindRemote(doltserver.RigDatabaseDir(townRoot, db))
+		if err != nil || remote == "" {
+			continue
+		}
+		withRemote++
+		last := doltserver.LastSync(townRoot, db)
+		switch {
+		case last.IsZero():
+			stale = append(stale, db+": never synced")
+		case time.Since(last) > BdSyncMaxAge:
+			stale = append(stale, fmt.Sprintf("%s: last synced %s ago", db, time.Since(last).Round(time.Hour)))
+		}
+	}
+	if len(stale) > 0 {
+		return ProbeResult{Status: ProbeWarn, Message: fmt.Sprintf("%d database(s) not synced in %s", len(stale), BdSyncMaxAge), Details: stale}
+	}
+	return ProbeResult{Status: ProbeOK, Message: fmt.Sprintf("%d database(s) with remotes in sync", withRemote)}
+}
+
+func probeDiskSpace(townRoot string) ProbeResult {
+	free, total, err := diskSpace(townRoot)
+	if err != nil {
+		return ProbeResult{Status: ProbeWarn, Message: fmt.Sprintf("checking disk space: %v", err)}
+	}
+	return diskSpaceResult(free, total)
+}
+
+// diskSpaceResult grades free space against the disk thresholds.
+func diskSpaceResult(free, total uint64) ProbeResult {
+	msg := fmt.Sprintf("%.1f GiB free", float64(free)/(1<<30))
+	switch {
+	case free < DiskMinFreeBytes:
+		return ProbeResult{Status: ProbeFail, Message: msg}
+	case total > 0 && free*100/total < DiskMinFreePercent:
+		return ProbeResult{Status: ProbeWarn, Message: fmt.Sprintf("%s (%d%%)", msg, free*100/total)}
+	}
+	return ProbeResult{Status: ProbeOK, Message: msg}
+}
+
+func probeOrphanProcesses(townRoot string) ProbeResult {
+	orphans, err := util.FindOrphanedClaudeProcesses()
+	if err != nil {
+		return ProbeResult{Status: ProbeWarn, Message: fmt.Sprintf("scanning processes: %v", err)}
+	}
+	if len(orphans) == 0 {
+		return ProbeResult{Status: ProbeOK, Message: "no orphaned agent processes"}
+	}
+	var details []string
+	for _, o := range orphans {
+		details = append(details, fmt.Sprintf("pid %d: %s", o.PID, o.Cmd))
+	}
+	return ProbeResult{
+		Status:  ProbeWarn,
+		Message: fmt.Sprintf("%d orphaned agent process(es); run gt deacon cleanup-orphans", len(orphans)),
+		Details: details,
+	}
+}
+
+func probeMailBacklog(townRoot string) ProbeResult {
+	router := mail.NewRouter(townRoot)
+	var backlog []string
+	for _, addr := range []string{"mayor/", "deacon/"} {
+		mb, err := router.GetMailbox(addr)
+		if err != nil {
+			return ProbeResult{Status: ProbeWarn, Message: fmt.Sprintf("opening %s mailbox: %v", addr, err)}
+		}
+		_, unread, err := mb.Count()
+		if err != nil {
+			return ProbeResult{Status: ProbeWarn, Message: fmt.Sprintf("counting %s mail: %v", addr, err)}
+		}
+		if unread >= MailBacklogMax {
+			backlog = append(backlog, fmt.Sprintf("%s: %d unread", addr, unread))
+		}
+	}
+	if len(backlog) > 0 {
+		return ProbeResult{Status: ProbeWarn, Message: "mail backlog", Details: backlog}
+	}
+	return ProbeResult{Status: ProbeOK, Message: "no mail backlog"}
+}
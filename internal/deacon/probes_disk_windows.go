@@ -0,0 +1,10 @@
+//go:build windows
+
+package deacon
+
+import "errors"
+
+// diskSpace is not implemented on Windows.
+func diskSpace(path string) (free, total uint64, err error) {
+	return 0, 0, errors.New("disk space probe not supported on Windows")
+}
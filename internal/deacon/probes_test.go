@@ -0,0 +1,155 @@
+package deacon
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBuiltinProbesRegistered(t *testing.T) {
+	want := []string{"tmux", "bd_sync", "disk_space", "orphan_processes", "mail_backlog"}
+	got := make(map[string]Probe)
+	for _, p := range Probes() {
+		got[p.Name] = p
+	}
+	for _, name := range want {
+		p, ok := got[name]
+		if !ok {
+			t.Errorf("probe %q not registered", name)
+			continue
+		}
+		if p.Interval <= 0 || p.Run == nil {
+			t.Errorf("probe %q has interval %v, run set %v", name, p.Interval, p.Run != nil)
+		}
+	}
+}
+
+func TestRegisterProbeReplacesByName(t *testing.T) {
+	saved := Probes()
+	t.Cleanup(func() {
+		probesMu.Lock()
+		probes = saved
+		probesMu.Unlock()
+	})
+
+	RegisterProbe(Probe{Name: "custom", Interval: time.Minute, Run: func(string) ProbeResult { return ProbeResult{Status: ProbeOK} }})
+	RegisterProbe(Probe{Name: "custom", Interval: time.Hour, Run: func(string) ProbeResult { return ProbeResult{Status: ProbeWarn} }})
+
+	var matches []Probe
+	for _, p := range Probes() {
+		if p.Name == "custom" {
+			matches = append(matches, p)
+		}
+	}
+	if len(matches) != 1 || matches[0].Interval != time.Hour {
+		t.Errorf("custom probes = %+v, want one with 1h interval", matches)
+	}
+}
+
+func TestProbeDue(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name    string
+		lastRun time.Time
+		want    bool
+	}{
+		{"never run", time.Time{}, true},
+		{"interval elapsed", now.Add(-10 * time.Minute), true},
+		{"not yet", now.Add(-time.Minute), false},
+	}
+	for _, tt := range tests {
+		if got := probeDue(tt.lastRun, 5*time.Minute, now); got != tt.want {
+			t.Errorf("%s: probeDue() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestApplyProbeResult(t *testing.T) {
+	t0 := time.Now()
+	rec := &ProbeRecord{Name: "disk_space"}
+
+	if got := applyProbeResult(rec, ProbeResult{Status: ProbeOK, Message: "fine"}, t0); got != probeBeadNone {
+		t.Errorf("first ok: transition = %q, want none", got)
+	}
+	if !rec.Since.Equal(t0) || !rec.LastRun.Equal(t0) {
+		t.Errorf("first ok: since %v last run %v, want %v", rec.Since, rec.LastRun, t0)
+	}
+
+	t1 := t0.Add(time.Minute)
+	if got := applyProbeResult(rec, ProbeResult{Status: ProbeWarn, Message: "low"}, t1); got != probeBeadOpen {
+		t.Errorf("ok→warn: transition = %q, want open", got)
+	}
+	if !rec.Since.Equal(t1) {
+		t.Errorf("ok→warn: since = %v, want %v", rec.Since, t1)
+	}
+
+	// With a bead recorded, staying unhealthy doesn't open another.
+	rec.BeadID = "hq-wisp-abc"
+	t2 := t1.Add(time.Minute)
+	if got := applyProbeResult(rec, ProbeResult{Status: ProbeFail, Message: "full"}, t2); got != probeBeadNone {
+		t.Errorf("warn→fail with bead: transition = %q, want none", got)
+	}
+
+	t3 := t2.Add(time.Minute)
+	if got := applyProbeResult(rec, ProbeResult{Status: ProbeFail, Message: "full"}, t3); got != probeBeadNone {
+		t.Errorf("fail→fail: transition = %q, want none", got)
+	}
+	if !rec.Since.Equal(t2) || !rec.LastRun.Equal(t3) {
+		t.Errorf("fail→fail: since %v last run %v, want %v and %v", rec.Since, rec.LastRun, t2, t3)
+	}
+
+	if got := applyProbeResult(rec, ProbeResult{Status: ProbeOK, Message: "fine"}, t3.Add(time.Minute)); got != probeBeadResolve {
+		t.Errorf("fail→ok: transition = %q, want resolve", got)
+	}
+}
+
+func TestProbeState_LoadSave(t *testing.T) {
+	town := t.TempDir()
+
+	state, err := LoadProbeState(town)
+	if err != nil {
+		t.Fatalf("LoadProbeState (missing): %v", err)
+	}
+	if len(state.Probes) != 0 {
+		t.Fatalf("expected empty state, got %d probes", len(state.Probes))
+	}
+
+	state.Probes["tmux"] = &ProbeRecord{
+		Name:        "tmux",
+		ProbeResult: ProbeResult{Status: ProbeWarn, Message: "1 town session(s) down", Details: []string{"hq-deacon"}},
+		LastRun:     time.Now().UTC().Truncate(time.Second),
+		BeadID:      "hq-wisp-1",
+	}
+	if err := SaveProbeState(town, state); err != nil {
+		t.Fatalf("SaveProbeState: %v", err)
+	}
+
+	loaded, err := LoadProbeState(town)
+	if err != nil {
+		t.Fatalf("LoadProbeState: %v", err)
+	}
+	rec := loaded.Probes["tmux"]
+	if rec == nil || rec.Status != ProbeWarn || rec.BeadID != "hq-wisp-1" || len(rec.Details) != 1 {
+		t.Errorf("loaded record = %+v", rec)
+	}
+	if loaded.LastUpdated.IsZero() {
+		t.Error("LastUpdated not set")
+	}
+}
+
+func TestDiskSpaceResult(t *testing.T) {
+	const gib = 1 << 30
+	tests := []struct {
+		free, total uint64
+		want        string
+	}{
+		{50 * gib, 100 * gib, ProbeOK},
+		{5 * gib, 100 * gib, ProbeWarn},
+		{gib / 2, 100 * gib, ProbeFail},
+		{2 * gib, 0, ProbeOK},
+	}
+	for _, tt := range tests {
+		if got := diskSpaceResult(tt.free, tt.total); got.Status != tt.want {
+			t.Errorf("diskSpaceResult(%d, %d) = %+v, want %s", tt.free, tt.total, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,220 @@
+// Package disk reports town storage (clones, scrollback snapshots, mail
+// archives) by rig and checks it against quotas. Snapshots and mail
+// archives over quota can be pruned; clones are only reported, since
+// removing them is gt worktree gc's job.
+package disk
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultWarnAt is the fraction of a quota at which usage is reported as
+// nearly full when Config.WarnAt is unset.
+const DefaultWarnAt = 0.9
+
+// Config sets disk quotas. Town-wide, stored as "disk" in
+// settings/config.json. Sizes are byte counts with an optional unit
+// (e.g., "500MB", "20GB", "1.5T"); units are powers of 1024.
+type Config struct {
+	// Town caps the town's total usage, rigs included.
+	Town string `json:"town,omitempty"`
+
+	// Rigs caps usage per rig. "*" sets the quota for rigs not listed.
+	Rigs map[string]string `json:"rigs,omitempty"`
+
+	// Snapshots caps scrollback snapshot storage. Postflight prunes the
+	// oldest snapshots, keeping each session's newest, to get back under it.
+	Snapshots string `json:"snapshots,omitempty"`
+
+	// MailArchive caps each mail archive file. Postflight drops the oldest
+	// archived messages to get back under it.
+	MailArchive string `json:"mail_archive,omitempty"`
+
+	// WarnAt is the fraction of a quota at which preflight warns that
+	// usage is nearly full (default 0.9).
+	WarnAt float64 `json:"warn_at,omitempty"`
+}
+
+// Validate checks the config for errors.
+func (c *Config) Validate() error {
+	if c == nil {
+		return nil
+	}
+	check := func(key, s string) error {
+		if s == "" {
+			return nil
+		}
+		if _, err := ParseSize(s); err != nil {
+			return fmt.Errorf("disk.%s: %w", key, err)
+		}
+		return nil
+	}
+	if err := check("town", c.Town); err != nil {
+		return err
+	}
+	for rig, s := range c.Rigs {
+		if err := check("rigs["+rig+"]", s); err != nil {
+			return err
+		}
+	}
+	if err := check("snapshots", c.Snapshots); err != nil {
+		return err
+	}
+	if err := check("mail_archive", c.MailArchive); err != nil {
+		return err
+	}
+	if c.WarnAt < 0 || c.WarnAt > 1 {
+		return fmt.Errorf("disk.warn_at: %v is not between 0 and 1", c.WarnAt)
+	}
+	return nil
+}
+
+// Enabled reports whether any quota is set.
+func (c *Config) Enabled() bool {
+	return c != nil && (c.Town != "" || len(c.Rigs) > 0 || c.Snapshots != "" || c.MailArchive != "")
+}
+
+// GetWarnAt returns WarnAt or DefaultWarnAt if unset.
+func (c *Config) GetWarnAt() float64 {
+	if c == nil || c.WarnAt == 0 {
+		return DefaultWarnAt
+	}
+	return c.WarnAt
+}
+
+// TownQuota returns the town quota in bytes, or 0 if unset.
+func (c *Config) TownQuota() int64 {
+	if c == nil {
+		return 0
+	}
+	return quota(c.Town)
+}
+
+// RigQuota returns the quota in bytes for a rig, or 0 if none applies.
+func (c *Config) RigQuota(rig string) int64 {
+	if c == nil {
+		return 0
+	}
+	if s, ok := c.Rigs[rig]; ok {
+		return quota(s)
+	}
+	return quota(c.Rigs["*"])
+}
+
+// SnapshotQuota returns the snapshot quota in bytes, or 0 if unset.
+func (c *Config) SnapshotQuota() int64 {
+	if c == nil {
+		return 0
+	}
+	return quota(c.Snapshots)
+}
+
+// MailArchiveQuota returns the per-archive quota in bytes, or 0 if unset.
+func (c *Config) MailArchiveQuota() int64 {
+	if c == nil {
+		return 0
+	}
+	return quota(c.MailArchive)
+}
+
+// quota parses a validated size, treating unset or invalid as no quota.
+func quota(s string) int64 {
+	n, err := ParseSize(s)
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+// sizeUnits maps unit suffixes to multipliers.
+var sizeUnits = map[string]float64{
+	"":  1,
+	"b": 1,
+	"k": 1 << 10, "kb": 1 << 10, "kib": 1 << 10,
+	"m": 1 << 20, "mb": 1 << 20, "mib": 1 << 20,
+	"g": 1 << 30, "gb": 1 << 30, "gib": 1 << 30,
+	"t": 1 << 40, "tb": 1 << 40, "tib": 1 << 40,
+}
+
+// ParseSize parses a size such as "512", "500MB", or "1.5g" into bytes.
+func ParseSize(s string) (int64, error) {
+	t := strings.ToLower(strings.TrimSpace(s))
+	i := strings.IndexFunc(t, func(r rune) bool { return (r < '0' || r > '9') && r != '.' })
+	num, unit := t, ""
+	if i >= 0 {
+		num, unit = t[:i], strings.TrimSpace(t[i:])
+	}
+	mult, ok := sizeUnits[unit]
+	if !ok || num == "" {
+		return 0, fmt.Errorf("invalid size %q (want e.g. 500MB or 20GB)", s)
+	}
+	n, err := strconv.ParseFloat(num, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid size %q (want e.g. 500MB or 20GB)", s)
+	}
+	return int64(n * mult), nil
+}
+
+// Quota scopes.
+const (
+	ScopeTown        = "town"
+	ScopeRig         = "rig"
+	ScopeSnapshots   = "snapshots"
+	ScopeMailArchive = "mail_archive"
+)
+
+// Alert is usage at or past the warning fraction of a quota.
+type Alert struct {
+	Scope string `json:"scope"`
+	Name  string `json:"name,omitempty"` // Rig name or archive path
+	Used  int64  `json:"used"`
+	Quota int64  `json:"quota"`
+	Over  bool   `json:"over"`
+}
+
+// Prunable reports whether postflight pruning can bring this usage down.
+func (a Alert) Prunable() bool {
+	return a.Scope == ScopeSnapshots || a.Scope == ScopeMailArchive
+}
+
+// Label names what the alert is about (e.g., "rig gastown").
+func (a Alert) Label() string {
+	switch a.Scope {
+	case ScopeRig:
+		return "rig " + a.Name
+	case ScopeSnapshots:
+		return "scrollback snapshots"
+	case ScopeMailArchive:
+		return "mail archive " + a.Name
+	}
+	return a.Scope
+}
+
+// Evaluate checks a report against the config's quotas, returning alerts
+// for usage at or past the warning fraction, over-quota first.
+func Evaluate(c *Config, r *Report) []Alert {
+	if !c.Enabled() || r == nil {
+		return nil
+	}
+	warnAt := c.GetWarnAt()
+	var alerts []Alert
+	check := func(scope, name string, used, limit int64) {
+		if limit <= 0 || float64(used) < warnAt*float64(limit) {
+			return
+		}
+		alerts = append(alerts, Alert{Scope: scope, Name: name, Used: used, Quota: limit, Over: used > limit})
+	}
+	check(ScopeTown, "", r.Town.Total(), c.TownQuota())
+	for _, ru := range r.Rigs {
+		check(ScopeRig, ru.Rig, ru.Total(), c.RigQuota(ru.Rig))
+	}
+	check(ScopeSnapshots, "", r.Town.Snapshots, c.SnapshotQuota())
+	for _, a := range r.Archives {
+		check(ScopeMailArchive, a.Path, a.Size, c.MailArchiveQuota())
+	}
+	sort.SliceStable(alerts, func(i, j int) bool { return alerts[i].Over && !alerts[j].Over })
+	return alerts
+}
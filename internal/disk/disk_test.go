@@ -0,0 +1,198 @@
+package disk
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseSize(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int64
+		err  bool
+	}{
+		{"512", 512, false},
+		{"500MB", 500 << 20, false},
+		{"20 GB", 20 << 30, false},
+		{"1.5g", 3 << 29, false},
+		{"2TiB", 2 << 40, false},
+		{"10k", 10 << 10, false},
+		{"", 0, true},
+		{"GB", 0, true},
+		{"12 parsecs", 0, true},
+		{"1.2.3MB", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseSize(tt.in)
+		if (err != nil) != tt.err || got != tt.want {
+			t.Errorf("ParseSize(%q) = %d, %v; want %d, err %v", tt.in, got, err, tt.want, tt.err)
+		}
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	good := &Config{Town: "100GB", Rigs: map[string]string{"*": "10GB"}, Snapshots: "1G", MailArchive: "5MB", WarnAt: 0.8}
+	if err := good.Validate(); err != nil {
+		t.Errorf("Validate(good) = %v", err)
+	}
+	for name, c := range map[string]*Config{
+		"rig":     {Rigs: map[string]string{"gastown": "lots"}},
+		"town":    {Town: "-1GB"},
+		"warn_at": {Town: "1GB", WarnAt: 1.5},
+	} {
+		if err := c.Validate(); err == nil {
+			t.Errorf("Validate(%s) = nil, want error", name)
+		}
+	}
+	if (&Config{WarnAt: 0.5}).Enabled() {
+		t.Error("Enabled() with no quotas = true")
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	c := &Config{
+		Town:        "100",
+		Rigs:        map[string]string{"*": "50", "big": "80"},
+		Snapshots:   "10",
+		MailArchive: "20",
+	}
+	r := &Report{
+		Town: Usage{Clones: 60, Snapshots: 12, MailArchives: 10, Other: 5}, // 87: under town quota
+		Rigs: []RigUsage{
+			{Rig: "big", Usage: Usage{Clones: 60}},   // under 80
+			{Rig: "small", Usage: Usage{Clones: 46}}, // 92% of 50: warn
+		},
+		Archives: []Archive{{Path: "/t/.beads/archive.jsonl", Size: 25}, {Path: "/t/r/.beads/archive.jsonl", Size: 5}},
+	}
+	alerts := Evaluate(c, r)
+	got := make([]string, len(alerts))
+	for i, a := range alerts {
+		got[i] = a.Label()
+		if a.Over {
+			got[i] += " (over)"
+		}
+	}
+	want := "scrollback snapshots (over), mail archive /t/.beads/archive.jsonl (over), rig small"
+	if strings.Join(got, ", ") != want {
+		t.Errorf("Evaluate = %q, want %q", strings.Join(got, ", "), want)
+	}
+	if !alerts[0].Prunable() || alerts[2].Prunable() {
+		t.Error("snapshots should be prunable, rigs not")
+	}
+	if Evaluate(nil, r) != nil {
+		t.Error("Evaluate with no config should return nil")
+	}
+}
+
+func TestScan(t *testing.T) {
+	town := t.TempDir()
+	write := func(rel string, size int) {
+		path := filepath.Join(town, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, make([]byte, size), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("gastown/.repo.git/objects/pack/p.pack", 1000)
+	write("gastown/polecats/nux/gastown/main.go", 200)
+	write("gastown/mayor/rig/README", 100)
+	write("gastown/.beads/archive.jsonl", 50)
+	write("gastown/.beads/issues.jsonl", 30)
+	write("gastown/crew/max/inbox.jsonl.archive", 7)
+	write("deacon/dogs/alpha/gastown/main.go", 300)
+	write(".runtime/snapshots/gt-nux/a.json.gz", 40)
+	write(".runtime/snapshots/hq-mayor/a.json.gz", 20)
+	write(".beads/archive.jsonl", 60)
+	write("mayor/town.json", 10)
+
+	sessionRig := func(s string) string {
+		if strings.HasPrefix(s, "gt-") {
+			return "gastown"
+		}
+		return ""
+	}
+	r, err := Scan(town, []string{"gastown", "empty"}, sessionRig)
+	if err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+
+	wantRig := Usage{Clones: 1000 + 200 + 100 + 300, Snapshots: 40, MailArchives: 50 + 7, Other: 30}
+	if len(r.Rigs) != 2 || r.Rigs[0].Rig != "gastown" || r.Rigs[0].Usage != wantRig {
+		t.Errorf("Rigs[0] = %+v, want gastown %+v", r.Rigs, wantRig)
+	}
+	if r.Rigs[1].Total() != 0 {
+		t.Errorf("empty rig usage = %+v", r.Rigs[1])
+	}
+	wantHQ := Usage{Snapshots: 20, MailArchives: 60, Other: 10}
+	if r.HQ != wantHQ {
+		t.Errorf("HQ = %+v, want %+v", r.HQ, wantHQ)
+	}
+	if r.Town.Total() != wantRig.Total()+wantHQ.Total() {
+		t.Errorf("Town total = %d, want %d", r.Town.Total(), wantRig.Total()+wantHQ.Total())
+	}
+	if len(r.Archives) != 3 || r.Archives[0].Size != 60 || r.Archives[1].Rig != "gastown" {
+		t.Errorf("Archives = %+v", r.Archives)
+	}
+
+	// A rig scan counts only the rig's directory.
+	rr, err := ScanRig(town, "gastown")
+	if err != nil {
+		t.Fatalf("ScanRig: %v", err)
+	}
+	wantOnly := Usage{Clones: 1000 + 200 + 100, MailArchives: 50 + 7, Other: 30}
+	if rr.Rig != "gastown" || len(rr.Rigs) != 1 || rr.Rigs[0].Usage != wantOnly || rr.Town != wantOnly {
+		t.Errorf("ScanRig = %+v, want gastown %+v", rr, wantOnly)
+	}
+	if len(rr.Archives) != 2 {
+		t.Errorf("ScanRig archives = %+v", rr.Archives)
+	}
+
+	// The saved report round-trips while fresh.
+	if err := Save(town, r); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := LoadCached(town, time.Minute, r.At.Add(30*time.Second)); err != nil || got == nil || got.Town != r.Town {
+		t.Errorf("LoadCached fresh = %+v, %v", got, err)
+	}
+	if got, _ := LoadCached(town, time.Minute, r.At.Add(2*time.Minute)); got != nil {
+		t.Error("LoadCached returned a stale report")
+	}
+}
+
+func TestPlanSnapshots(t *testing.T) {
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	snaps := func(session string, hours ...int) []Snapshot {
+		var out []Snapshot
+		for _, h := range hours {
+			out = append(out, Snapshot{Session: session, At: base.Add(time.Duration(h) * time.Hour), Size: 10})
+		}
+		return out
+	}
+	sessions := map[string][]Snapshot{
+		"hq-mayor": snaps("hq-mayor", 0, 2, 4),
+		"gt-nux":   snaps("gt-nux", 1, 3),
+		"gt-slit":  snaps("gt-slit", 0),
+	}
+
+	// 60 bytes stored, cap 35: the three oldest non-newest go (mayor 0h,
+	// nux 1h, mayor 2h).
+	plan, freed := PlanSnapshots(sessions, 35)
+	if plan["hq-mayor"] != 2 || plan["gt-nux"] != 1 || plan["gt-slit"] != 0 || freed != 30 {
+		t.Errorf("PlanSnapshots(35) = %v, %d", plan, freed)
+	}
+
+	// A cap below the newest snapshots' total still keeps one per session.
+	plan, freed = PlanSnapshots(sessions, 0)
+	if plan["hq-mayor"] != 2 || plan["gt-nux"] != 1 || freed != 30 {
+		t.Errorf("PlanSnapshots(0) = %v, %d", plan, freed)
+	}
+
+	if plan, _ := PlanSnapshots(sessions, 100); len(plan) != 0 {
+		t.Errorf("PlanSnapshots under cap = %v", plan)
+	}
+}
@@ -0,0 +1,55 @@
+package disk
+
+import (
+	"sort"
+	"time"
+)
+
+// PruneResult describes what pruning removed, or would remove in a dry run.
+type PruneResult struct {
+	Snapshots     int       `json:"snapshots"`          // Snapshots deleted
+	SnapshotBytes int64     `json:"snapshot_bytes"`     // Snapshot bytes freed (before keyframe rewrites)
+	Messages      int       `json:"messages"`           // Archived messages dropped (0 in a dry run)
+	Archives      []Archive `json:"archives,omitempty"` // Archives over quota, with their size before trimming
+}
+
+// Empty reports whether nothing was pruned.
+func (p *PruneResult) Empty() bool {
+	return p.Snapshots == 0 && len(p.Archives) == 0
+}
+
+// Snapshot is one stored scrollback snapshot.
+type Snapshot struct {
+	Session string
+	At      time.Time
+	Size    int64
+}
+
+// PlanSnapshots picks the oldest snapshots across sessions to delete until
+// the total fits in limit, never taking a session's newest. Sessions are
+// given oldest snapshot first. It returns how many to delete from the front
+// of each session and the bytes that frees.
+func PlanSnapshots(sessions map[string][]Snapshot, limit int64) (map[string]int, int64) {
+	var total int64
+	var candidates []Snapshot
+	for _, entries := range sessions {
+		for i, e := range entries {
+			total += e.Size
+			if i < len(entries)-1 {
+				candidates = append(candidates, e)
+			}
+		}
+	}
+	// Oldest first across sessions, so each session's picks are a prefix.
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].At.Before(candidates[j].At) })
+	plan := make(map[string]int)
+	var freed int64
+	for _, e := range candidates {
+		if total-freed <= limit {
+			break
+		}
+		plan[e.Session]++
+		freed += e.Size
+	}
+	return plan, freed
+}
@@ -0,0 +1,240 @@
+package disk
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/constants"
+)
+
+// Mail archive file names: beads mailboxes share archive.jsonl in the
+// .beads directory; legacy JSONL mailboxes keep inbox.jsonl.archive.
+const (
+	beadsArchiveName  = "archive.jsonl"
+	legacyArchiveName = "inbox.jsonl.archive"
+)
+
+// cloneDirs are the rig subdirectories holding clones and worktrees.
+var cloneDirs = map[string]bool{
+	".repo.git":      true,
+	".land-worktree": true,
+	"mayor":          true,
+	"refinery":       true,
+	"polecats":       true,
+	"crew":           true,
+}
+
+// Usage is storage in bytes by kind.
+type Usage struct {
+	Clones       int64 `json:"clones"`
+	Snapshots    int64 `json:"snapshots"`
+	MailArchives int64 `json:"mail_archives"`
+	Other        int64 `json:"other"`
+}
+
+// Total returns the sum of all kinds.
+func (u Usage) Total() int64 {
+	return u.Clones + u.Snapshots + u.MailArchives + u.Other
+}
+
+// RigUsage is one rig's storage.
+type RigUsage struct {
+	Rig string `json:"rig"`
+	Usage
+}
+
+// Archive is a mail archive file.
+type Archive struct {
+	Path string `json:"path"`
+	Rig  string `json:"rig,omitempty"` // Empty for town-level archives
+	Size int64  `json:"size"`
+}
+
+// Report is a town's storage broken down by rig and kind.
+type Report struct {
+	At       time.Time  `json:"at"`
+	Rig      string     `json:"rig,omitempty"` // Set when only this rig was scanned
+	Town     Usage      `json:"town"`          // Everything scanned, rigs included
+	HQ       Usage      `json:"hq"`            // Town-level storage outside rigs
+	Rigs     []RigUsage `json:"rigs"`
+	Archives []Archive  `json:"archives,omitempty"`
+}
+
+// Scan measures the town's storage. Clones are a rig's bare repo and its
+// mayor, refinery, polecat, crew, and land worktrees, plus dog worktrees
+// of the rig; snapshots are attributed to the rig their session belongs
+// to, as named by sessionRig (nil or "" for town-level). Unreadable
+// directories are skipped.
+func Scan(townRoot string, rigs []string, sessionRig func(session string) string) (*Report, error) {
+	return scan(townRoot, townRoot, rigs, sessionRig)
+}
+
+// ScanRig measures one rig's directory only, for callers that cannot
+// afford a walk of the whole town. Town is the rig's usage, a lower bound
+// on the town's, so town quota alerts it raises are still real; dog
+// worktrees and snapshots are not counted.
+func ScanRig(townRoot, rig string) (*Report, error) {
+	r, err := scan(townRoot, filepath.Join(townRoot, rig), []string{rig}, nil)
+	if err != nil {
+		return nil, err
+	}
+	r.Rig = rig
+	return r, nil
+}
+
+// scan walks root, classifying files by their path relative to townRoot.
+func scan(townRoot, root string, rigs []string, sessionRig func(session string) string) (*Report, error) {
+	rigSet := make(map[string]bool, len(rigs))
+	byRig := make(map[string]*Usage, len(rigs))
+	for _, r := range rigs {
+		rigSet[r] = true
+		byRig[r] = &Usage{}
+	}
+	r := &Report{At: time.Now()}
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path == root {
+				return err
+			}
+			if d != nil && d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		rel, err := filepath.Rel(townRoot, path)
+		if err != nil {
+			return nil
+		}
+		rig, kind := classify(strings.Split(filepath.ToSlash(rel), "/"), rigSet, sessionRig)
+		if kind == kindArchive {
+			r.Archives = append(r.Archives, Archive{Path: path, Rig: rig, Size: info.Size()})
+		}
+		u := &r.HQ
+		if rig != "" {
+			u = byRig[rig]
+		}
+		u.add(kind, info.Size())
+		r.Town.add(kind, info.Size())
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range rigs {
+		r.Rigs = append(r.Rigs, RigUsage{Rig: name, Usage: *byRig[name]})
+	}
+	sort.Slice(r.Rigs, func(i, j int) bool { return r.Rigs[i].Total() > r.Rigs[j].Total() })
+	sort.Slice(r.Archives, func(i, j int) bool { return r.Archives[i].Size > r.Archives[j].Size })
+	return r, nil
+}
+
+// Storage kinds, as classified by path.
+const (
+	kindOther = iota
+	kindClone
+	kindSnapshot
+	kindArchive
+)
+
+func (u *Usage) add(kind int, n int64) {
+	switch kind {
+	case kindClone:
+		u.Clones += n
+	case kindSnapshot:
+		u.Snapshots += n
+	case kindArchive:
+		u.MailArchives += n
+	default:
+		u.Other += n
+	}
+}
+
+// classify returns the rig (empty for town-level) and kind of a file from
+// its path components relative to the town root.
+func classify(parts []string, rigs map[string]bool, sessionRig func(string) string) (string, int) {
+	name := parts[len(parts)-1]
+	isArchive := name == legacyArchiveName ||
+		(name == beadsArchiveName && len(parts) >= 2 && parts[len(parts)-2] == ".beads")
+
+	switch {
+	case rigs[parts[0]]:
+		if isArchive {
+			return parts[0], kindArchive
+		}
+		if len(parts) > 2 && cloneDirs[parts[1]] {
+			return parts[0], kindClone
+		}
+		return parts[0], kindOther
+	case isArchive:
+		return "", kindArchive
+	case len(parts) > 4 && parts[0] == "deacon" && parts[1] == "dogs":
+		// deacon/dogs/<dog>/<rig>/...
+		if rigs[parts[3]] {
+			return parts[3], kindClone
+		}
+	case len(parts) > 3 && parts[0] == constants.DirRuntime && parts[1] == "snapshots":
+		if sessionRig != nil {
+			if rig := sessionRig(parts[2]); rigs[rig] {
+				return rig, kindSnapshot
+			}
+		}
+		return "", kindSnapshot
+	}
+	return "", kindOther
+}
+
+// CachePath returns where the last report is saved.
+func CachePath(townRoot string) string {
+	return filepath.Join(townRoot, constants.DirRuntime, "disk-report.json")
+}
+
+// Save writes the report to the town's cache.
+func Save(townRoot string, r *Report) error {
+	path := CachePath(townRoot)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil { //nolint:gosec // G306: report is non-sensitive
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// LoadCached returns the cached report if it is younger than maxAge, or nil.
+func LoadCached(townRoot string, maxAge time.Duration, now time.Time) (*Report, error) {
+	data, err := os.ReadFile(CachePath(townRoot))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var r Report
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, nil // Corrupt cache: rescan
+	}
+	if now.Sub(r.At) > maxAge {
+		return nil, nil
+	}
+	return &r, nil
+}
@@ -0,0 +1,82 @@
+package doctor
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// AgentResourcesCheck warns when an agent session's process tree uses more
+// CPU or memory than the limits in operational.resources. Runaway agents
+// (leaked test servers, build loops) otherwise go unnoticed until the host
+// slows down for every other agent.
+type AgentResourcesCheck struct {
+	BaseCheck
+}
+
+// NewAgentResourcesCheck creates a new agent resource usage check.
+func NewAgentResourcesCheck() *AgentResourcesCheck {
+	return &AgentResourcesCheck{
+		BaseCheck: BaseCheck{
+			CheckName:        "agent-resources",
+			CheckDescription: "Check agent sessions are within CPU/memory limits",
+			CheckCategory:    CategoryCleanup,
+		},
+	}
+}
+
+// Run samples every Gas Town session and reports those over the limits.
+func (c *AgentResourcesCheck) Run(ctx *CheckContext) *CheckResult {
+	t := tmux.NewTmux()
+	sessions, err := t.ListSessions()
+	if err != nil {
+		return &CheckResult{Name: c.Name(), Status: StatusOK, Message: "No tmux sessions running"}
+	}
+	var gtSessions []string
+	for _, s := range sessions {
+		if session.IsKnownSession(s) {
+			gtSessions = append(gtSessions, s)
+		}
+	}
+	if len(gtSessions) == 0 {
+		return &CheckResult{Name: c.Name(), Status: StatusOK, Message: "No Gas Town sessions running"}
+	}
+
+	usage, err := util.SampleSessions(t, gtSessions, time.Second)
+	if err != nil {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusOK,
+			Message: fmt.Sprintf("Resource sampling unavailable: %v", err),
+		}
+	}
+
+	limits := config.LoadOperationalConfig(ctx.TownRoot).GetResourcesConfig()
+	var details []string
+	for s, u := range usage {
+		if reason := limits.Exceeded(u.CPUPercent, u.RSSMB()); reason != "" {
+			details = append(details, fmt.Sprintf("%s: %s (%d processes)", s, reason, u.Processes))
+		}
+	}
+	sort.Strings(details)
+
+	if len(details) > 0 {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusWarning,
+			Message: fmt.Sprintf("%d agent session(s) over resource limits", len(details)),
+			Details: details,
+			FixHint: "Inspect with 'gt status --resources'; raise operational.resources limits in settings/config.json if expected",
+		}
+	}
+	return &CheckResult{
+		Name:    c.Name(),
+		Status:  StatusOK,
+		Message: fmt.Sprintf("%d session(s) within resource limits", len(usage)),
+	}
+}
@@ -0,0 +1,149 @@
+package doctor
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/doltserver"
+)
+
+// BeadsDivergenceCheck detects beads databases that have drifted from their
+// remote: local bead commits that were never pushed, commits pushed by
+// another town or clone that were never pulled, or both at once (conflicting
+// edits). Starting batch work on a drifted database means agents act on
+// stale state and later syncs must merge around their changes.
+//
+// Only the remote gt dolt sync pushes to is compared. It is fetched when gt
+// hasn't fetched it within divergenceFetchInterval, so repeated doctor runs
+// don't each reach the network. While the Dolt server runs, everything goes
+// through it as SQL rather than the dolt CLI on its live data directory.
+// The fix commits, pulls, and pushes each flagged database.
+type BeadsDivergenceCheck struct {
+	FixableCheck
+	flagged []doltserver.Divergence
+}
+
+// divergenceFetchInterval is how long a database's last fetch is trusted
+// before the check fetches its remote again.
+const divergenceFetchInterval = 15 * time.Minute
+
+// NewBeadsDivergenceCheck creates a new beads divergence check.
+func NewBeadsDivergenceCheck() *BeadsDivergenceCheck {
+	return &BeadsDivergenceCheck{
+		FixableCheck: FixableCheck{
+			BaseCheck: BaseCheck{
+				CheckName:        "beads-divergence",
+				CheckDescription: "Detect beads databases diverged from their remotes",
+				CheckCategory:    CategoryInfrastructure,
+			},
+		},
+	}
+}
+
+// Run compares each database with its remote, fetching stale remotes first.
+func (c *BeadsDivergenceCheck) Run(ctx *CheckContext) *CheckResult {
+	c.flagged = nil
+
+	if doltserver.DefaultConfig(ctx.TownRoot).IsRemote() {
+		return &CheckResult{Name: c.Name(), Status: StatusOK, Message: "Dolt server is remote (skipping divergence check)"}
+	}
+	if _, err := exec.LookPath("dolt"); err != nil {
+		return &CheckResult{Name: c.Name(), Status: StatusOK, Message: "dolt not installed (skipping divergence check)"}
+	}
+	databases, err := doltserver.ListDatabases(ctx.TownRoot)
+	if err != nil || len(databases) == 0 {
+		return &CheckResult{Name: c.Name(), Status: StatusOK, Message: "No beads databases found"}
+	}
+
+	cfg := config.LoadOperationalConfig(ctx.TownRoot).GetDoltConfig()
+	maxCommits, maxAge := cfg.DivergenceMaxCommitsV(), cfg.DivergenceMaxSyncAgeD()
+
+	var details, fetchErrs []string
+	withRemote := 0
+	for _, db := range databases {
+		d, err := doltserver.InspectDivergence(ctx.TownRoot, db, divergenceFetchInterval)
+		if d.Remote == "" {
+			continue
+		}
+		withRemote++
+		if d.FetchErr != nil {
+			fetchErrs = append(fetchErrs, fmt.Sprintf("%s: %v", db, d.FetchErr))
+		}
+		if err != nil {
+			details = append(details, fmt.Sprintf("%s: %v", db, err))
+			continue
+		}
+		if reason := divergenceProblem(d, maxCommits, maxAge, time.Now()); reason != "" {
+			c.flagged = append(c.flagged, d)
+			details = append(details, d.String()+" — "+reason)
+		} else if ctx.Verbose {
+			details = append(details, d.String())
+		}
+	}
+
+	if withRemote == 0 {
+		return &CheckResult{Name: c.Name(), Status: StatusOK, Message: "No beads databases have remotes (nothing to diverge from)"}
+	}
+	if len(fetchErrs) > 0 {
+		details = append(details, "fetch failed (results use the last fetch):")
+		for _, e := range fetchErrs {
+			details = append(details, "  "+e)
+		}
+	}
+
+	if len(c.flagged) > 0 {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusWarning,
+			Message: fmt.Sprintf("%d beads database(s) diverged from their remote", len(c.flagged)),
+			Details: details,
+			FixHint: "Run 'gt doctor --fix' to pull and push (or 'gt dolt sync' to push only)",
+		}
+	}
+	return &CheckResult{
+		Name:    c.Name(),
+		Status:  StatusOK,
+		Message: fmt.Sprintf("%d beads database(s) in sync with their remotes", withRemote),
+		Details: details,
+	}
+}
+
+// Fix reconciles each flagged database with its remote.
+func (c *BeadsDivergenceCheck) Fix(ctx *CheckContext) error {
+	var errs []string
+	for _, d := range c.flagged {
+		if err := doltserver.ReconcileDatabase(ctx.TownRoot, d); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", d.Database, err))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("reconciling beads databases: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// divergenceProblem returns why a database's drift needs attention, or ""
+// if it is within limits. Any two-way divergence is flagged, since
+// conflicting edits only get harder to merge.
+func divergenceProblem(d doltserver.Divergence, maxCommits int, maxAge time.Duration, now time.Time) string {
+	switch {
+	case d.Diverged():
+		return "local and remote both have unmerged commits"
+	case d.Ahead > maxCommits:
+		return fmt.Sprintf("more than %d unpushed commits", maxCommits)
+	case d.Behind > maxCommits:
+		return fmt.Sprintf("more than %d commits behind remote", maxCommits)
+	case d.Ahead > 0 && d.LastSync.IsZero():
+		return "unpushed commits and no recorded sync"
+	case d.Ahead > 0 && now.Sub(d.LastSync) > maxAge:
+		return fmt.Sprintf("unpushed commits and last sync over %s ago", maxAge)
+	case d.Dirty && d.LastSync.IsZero():
+		return "uncommitted changes and no recorded sync"
+	case d.Dirty && now.Sub(d.LastSync) > maxAge:
+		return fmt.Sprintf("uncommitted changes and last sync over %s ago", maxAge)
+	}
+	return ""
+}
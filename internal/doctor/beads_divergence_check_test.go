@@ -0,0 +1,62 @@
+package doctor
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/doltserver"
+)
+
+func TestDivergenceProblem(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	recent := now.Add(-time.Hour)
+	old := now.Add(-48 * time.Hour)
+
+	tests := []struct {
+		name string
+		d    doltserver.Divergence
+		want string // substring; "" means no problem
+	}{
+		{"in sync", doltserver.Divergence{LastSync: old}, ""},
+		{"few unpushed, recent sync", doltserver.Divergence{Ahead: 3, LastSync: recent}, ""},
+		{"few unpulled", doltserver.Divergence{Behind: 3}, ""},
+		{"dirty only", doltserver.Divergence{Dirty: true, LastSync: recent}, ""},
+		{"dirty never synced", doltserver.Divergence{Dirty: true}, "uncommitted changes and no recorded sync"},
+		{"dirty stale sync", doltserver.Divergence{Dirty: true, LastSync: old}, "uncommitted changes and last sync over"},
+		{"conflicting edits", doltserver.Divergence{Ahead: 1, Behind: 1, LastSync: recent}, "both have unmerged"},
+		{"too many unpushed", doltserver.Divergence{Ahead: 51, LastSync: recent}, "more than 50 unpushed"},
+		{"too far behind", doltserver.Divergence{Behind: 51}, "more than 50 commits behind"},
+		{"unpushed never synced", doltserver.Divergence{Ahead: 2}, "no recorded sync"},
+		{"unpushed stale sync", doltserver.Divergence{Ahead: 2, LastSync: old}, "last sync over"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := divergenceProblem(tt.d, 50, 24*time.Hour, now)
+			if tt.want == "" {
+				if got != "" {
+					t.Errorf("divergenceProblem() = %q, want no problem", got)
+				}
+				return
+			}
+			if !strings.Contains(got, tt.want) {
+				t.Errorf("divergenceProblem() = %q, want it to contain %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBeadsDivergenceCheck_NoDatabases(t *testing.T) {
+	check := NewBeadsDivergenceCheck()
+	if check.Name() != "beads-divergence" {
+		t.Errorf("Name() = %q, want %q", check.Name(), "beads-divergence")
+	}
+	if !check.CanFix() {
+		t.Error("CanFix() = false, want true")
+	}
+
+	result := check.Run(&CheckContext{TownRoot: t.TempDir()})
+	if result.Status != StatusOK {
+		t.Errorf("Status = %v, want OK: %s", result.Status, result.Message)
+	}
+}
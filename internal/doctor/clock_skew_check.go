@@ -0,0 +1,220 @@
+package doctor
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// Clock skew thresholds. Agents compare timestamps written by other
+// processes (heartbeats, mail, bead updates), so a few seconds of skew
+// already distorts staleness checks; a minute breaks them.
+const (
+	clockSkewWarn  = 5 * time.Second
+	clockSkewError = time.Minute
+)
+
+// clockSkewCacheTTL is how long a measurement (or a failure to reach NTP)
+// is reused. gt doctor runs often; querying public pools on every run is
+// slow offline and abuses the pools, and clocks don't drift that fast.
+const clockSkewCacheTTL = time.Hour
+
+// clockSkewCacheFile holds the last measurement under the town's .runtime/.
+const clockSkewCacheFile = "clock_skew.json"
+
+// defaultNTPServers are queried in order until one answers.
+var defaultNTPServers = []string{"pool.ntp.org:123", "time.google.com:123", "time.cloudflare.com:123"}
+
+// ntpEpochOffset is the number of seconds between the NTP epoch (1900) and
+// the Unix epoch (1970).
+const ntpEpochOffset = 2208988800
+
+// ClockSkewCheck compares the host clock with NTP. Gas Town persists
+// timestamps in UTC and judges staleness by comparing them with the local
+// clock, so a skewed host makes healthy agents look hung (or hung agents
+// look healthy) and expires cleanup cutoffs early or late.
+//
+// Hosts without network access to NTP pass with a note; the check only
+// fails on a measured skew. Results are cached for clockSkewCacheTTL.
+type ClockSkewCheck struct {
+	BaseCheck
+	Servers []string      // NTP servers (host:port); defaults to public pools
+	Timeout time.Duration // Per-server query timeout
+}
+
+// NewClockSkewCheck creates a new host clock skew check.
+func NewClockSkewCheck() *ClockSkewCheck {
+	return &ClockSkewCheck{
+		BaseCheck: BaseCheck{
+			CheckName:        "clock-skew",
+			CheckDescription: "Check host clock against NTP and report the local timezone",
+			CheckCategory:    CategoryInfrastructure,
+		},
+		Servers: defaultNTPServers,
+		Timeout: 3 * time.Second,
+	}
+}
+
+// Run queries NTP servers until one answers and grades the measured offset.
+func (c *ClockSkewCheck) Run(ctx *CheckContext) *CheckResult {
+	zone, offset := time.Now().Zone()
+	details := []string{fmt.Sprintf("Local timezone: %s (%s, UTC%+03d:%02d); timestamps are stored in UTC",
+		time.Local.String(), zone, offset/3600, absInt(offset%3600)/60)}
+
+	m := c.measure(ctx.TownRoot)
+	if ago := time.Since(m.CheckedAt); ago >= time.Second {
+		details = append(details, fmt.Sprintf("NTP checked %s ago (cached for %s)", ago.Round(time.Second), clockSkewCacheTTL))
+	}
+	if m.Server == "" {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusOK,
+			Message: "Could not reach an NTP server (skipping clock skew check)",
+			Details: append(details, m.Errors...),
+		}
+	}
+
+	skew := m.Offset
+	details = append(details, fmt.Sprintf("Offset from %s: %s", m.Server, skew.Round(time.Millisecond)))
+	magnitude := skew
+	if magnitude < 0 {
+		magnitude = -magnitude
+	}
+	direction := "ahead of"
+	if skew > 0 {
+		direction = "behind" // Positive offset: NTP time is later than ours
+	}
+	switch {
+	case magnitude >= clockSkewError:
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusError,
+			Message: fmt.Sprintf("Host clock is %s %s NTP", magnitude.Round(time.Second), direction),
+			Details: details,
+			FixHint: "Enable time sync (e.g., timedatectl set-ntp true, or start chronyd/ntpd); staleness and cleanup cutoffs are unreliable until fixed",
+		}
+	case magnitude >= clockSkewWarn:
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusWarning,
+			Message: fmt.Sprintf("Host clock is %s %s NTP", magnitude.Round(time.Millisecond), direction),
+			Details: details,
+			FixHint: "Enable time sync (e.g., timedatectl set-ntp true, or start chronyd/ntpd)",
+		}
+	}
+	return &CheckResult{
+		Name:    c.Name(),
+		Status:  StatusOK,
+		Message: fmt.Sprintf("Host clock within %s of NTP", magnitude.Round(time.Millisecond)),
+		Details: details,
+	}
+}
+
+// clockSkewMeasurement is one NTP query round, as cached on disk. Server is
+// empty when no server answered.
+type clockSkewMeasurement struct {
+	CheckedAt time.Time     `json:"checked_at"`
+	Servers   string        `json:"servers"`
+	Server    string        `json:"server,omitempty"`
+	Offset    time.Duration `json:"offset,omitempty"`
+	Errors    []string      `json:"errors,omitempty"`
+}
+
+// measure returns a cached measurement for the configured servers if one is
+// fresh, otherwise queries NTP and caches the result. Without a town root
+// nothing is cached.
+func (c *ClockSkewCheck) measure(townRoot string) clockSkewMeasurement {
+	servers := strings.Join(c.Servers, ",")
+	var path string
+	if townRoot != "" {
+		path = filepath.Join(constants.TownRuntimePath(townRoot), clockSkewCacheFile)
+		var cached clockSkewMeasurement
+		if data, err := os.ReadFile(path); err == nil && json.Unmarshal(data, &cached) == nil { //nolint:gosec // G304: path is under townRoot
+			if cached.Servers == servers && time.Since(cached.CheckedAt) < clockSkewCacheTTL {
+				return cached
+			}
+		}
+	}
+
+	m := clockSkewMeasurement{CheckedAt: time.Now(), Servers: servers}
+	for _, s := range c.Servers {
+		d, err := queryNTPOffset(s, c.Timeout)
+		if err != nil {
+			m.Errors = append(m.Errors, fmt.Sprintf("%s: %v", s, err))
+			continue
+		}
+		m.Server, m.Offset, m.Errors = s, d, nil
+		break
+	}
+	if path != "" {
+		_ = util.EnsureDirAndWriteJSON(path, m) // Best effort: the next run re-queries
+	}
+	return m
+}
+
+// queryNTPOffset makes one SNTP (RFC 4330) request and returns how far the
+// server's clock is ahead of the local clock.
+func queryNTPOffset(server string, timeout time.Duration) (time.Duration, error) {
+	conn, err := net.DialTimeout("udp", server, timeout)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+	if err := conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		return 0, err
+	}
+
+	req := make([]byte, 48)
+	req[0] = 0x23 // LI 0, version 4, mode 3 (client)
+	t1 := time.Now()
+	if _, err := conn.Write(req); err != nil {
+		return 0, err
+	}
+	resp := make([]byte, 48)
+	n, err := conn.Read(resp)
+	t4 := time.Now()
+	if err != nil {
+		return 0, err
+	}
+	if n < 48 {
+		return 0, errors.New("short NTP response")
+	}
+	if mode := resp[0] & 0x07; mode != 4 {
+		return 0, fmt.Errorf("unexpected NTP mode %d", mode)
+	}
+	if stratum := resp[1]; stratum == 0 || stratum > 15 {
+		return 0, fmt.Errorf("server unsynchronized (stratum %d)", stratum)
+	}
+	t2 := ntpTime(resp[32:40])
+	t3 := ntpTime(resp[40:48])
+	return ntpOffset(t1, t2, t3, t4), nil
+}
+
+// ntpOffset computes the clock offset from the four SNTP timestamps: client
+// send (t1), server receive (t2), server send (t3), client receive (t4).
+func ntpOffset(t1, t2, t3, t4 time.Time) time.Duration {
+	return (t2.Sub(t1) + t3.Sub(t4)) / 2
+}
+
+// ntpTime decodes a 64-bit NTP timestamp.
+func ntpTime(b []byte) time.Time {
+	secs := int64(binary.BigEndian.Uint32(b[0:4])) - ntpEpochOffset
+	frac := int64(binary.BigEndian.Uint32(b[4:8]))
+	return time.Unix(secs, (frac*1e9)>>32).UTC()
+}
+
+func absInt(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
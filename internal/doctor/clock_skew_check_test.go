@@ -0,0 +1,142 @@
+package doctor
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+func putNTPTime(b []byte, t time.Time) {
+	binary.BigEndian.PutUint32(b[0:4], uint32(t.Unix()+ntpEpochOffset))
+	binary.BigEndian.PutUint32(b[4:8], uint32((int64(t.Nanosecond())<<32)/1e9))
+}
+
+// fakeNTPServer answers SNTP requests with a clock shifted by skew.
+func fakeNTPServer(t *testing.T, skew time.Duration) string {
+	t.Helper()
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("cannot listen on UDP: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	go func() {
+		buf := make([]byte, 48)
+		for {
+			_, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			resp := make([]byte, 48)
+			resp[0] = 0x24 // version 4, mode 4 (server)
+			resp[1] = 2    // stratum
+			now := time.Now().Add(skew)
+			putNTPTime(resp[32:40], now)
+			putNTPTime(resp[40:48], now)
+			_, _ = conn.WriteTo(resp, addr)
+		}
+	}()
+	return conn.LocalAddr().String()
+}
+
+func TestNTPOffset(t *testing.T) {
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	// Server 10s ahead, 100ms each way.
+	t1 := base
+	t2 := base.Add(10*time.Second + 100*time.Millisecond)
+	t3 := t2
+	t4 := base.Add(200 * time.Millisecond)
+	if got := ntpOffset(t1, t2, t3, t4); got != 10*time.Second {
+		t.Errorf("ntpOffset = %v, want 10s", got)
+	}
+}
+
+func TestNTPTime_RoundTrip(t *testing.T) {
+	want := time.Date(2026, 10, 16, 12, 34, 56, 500_000_000, time.UTC)
+	b := make([]byte, 8)
+	putNTPTime(b, want)
+	if got := ntpTime(b); got.Sub(want).Abs() > time.Microsecond {
+		t.Errorf("ntpTime = %v, want %v", got, want)
+	}
+}
+
+func TestClockSkewCheck(t *testing.T) {
+	tests := []struct {
+		name string
+		skew time.Duration
+		want CheckStatus
+	}{
+		{"in sync", 0, StatusOK},
+		{"drifted", 10 * time.Second, StatusWarning},
+		{"way off", -5 * time.Minute, StatusError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := NewClockSkewCheck()
+			check.Servers = []string{fakeNTPServer(t, tt.skew)}
+			result := check.Run(&CheckContext{})
+			if result.Status != tt.want {
+				t.Errorf("status = %v, want %v (%s)", result.Status, tt.want, result.Message)
+			}
+		})
+	}
+}
+
+func TestClockSkewCheck_Unreachable(t *testing.T) {
+	check := NewClockSkewCheck()
+	check.Servers = []string{"127.0.0.1:1"}
+	check.Timeout = 200 * time.Millisecond
+	result := check.Run(&CheckContext{})
+	if result.Status != StatusOK {
+		t.Errorf("status = %v, want OK when NTP is unreachable", result.Status)
+	}
+}
+
+func TestClockSkewCheck_CachesMeasurement(t *testing.T) {
+	townRoot := t.TempDir()
+	check := NewClockSkewCheck()
+	check.Servers = []string{fakeNTPServer(t, 10*time.Second)}
+	if result := check.Run(&CheckContext{TownRoot: townRoot}); result.Status != StatusWarning {
+		t.Fatalf("first run status = %v, want warning (%s)", result.Status, result.Message)
+	}
+
+	// A second check against the same servers must reuse the cached offset
+	// rather than query again; doctor the cache so the difference shows.
+	cachePath := filepath.Join(townRoot, constants.DirRuntime, clockSkewCacheFile)
+	data, err := os.ReadFile(cachePath)
+	if err != nil {
+		t.Fatalf("cache not written: %v", err)
+	}
+	var m clockSkewMeasurement
+	if err := json.Unmarshal(data, &m); err != nil {
+		t.Fatal(err)
+	}
+	m.CheckedAt = m.CheckedAt.Add(-time.Minute)
+	m.Offset = 2 * time.Minute
+	if err := util.AtomicWriteJSON(cachePath, m); err != nil {
+		t.Fatal(err)
+	}
+	result := check.Run(&CheckContext{TownRoot: townRoot})
+	if result.Status != StatusError {
+		t.Errorf("cached run status = %v, want error from the cached offset (%s)", result.Status, result.Message)
+	}
+	if !strings.Contains(strings.Join(result.Details, "\n"), "checked 1m0s ago") {
+		t.Errorf("details should say when NTP was checked: %v", result.Details)
+	}
+
+	// An expired entry is re-measured.
+	m.CheckedAt = time.Now().Add(-2 * clockSkewCacheTTL)
+	if err := util.AtomicWriteJSON(cachePath, m); err != nil {
+		t.Fatal(err)
+	}
+	if result := check.Run(&CheckContext{TownRoot: townRoot}); result.Status != StatusWarning {
+		t.Errorf("expired cache status = %v, want warning from a fresh query (%s)", result.Status, result.Message)
+	}
+}
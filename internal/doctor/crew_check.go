@@ -154,8 +154,8 @@ func (c *CrewStateCheck) Fix(ctx *CheckContext) error {
 			"rig":        ic.rigName,
 			"clone_path": ic.path,
 			"branch":     "main",
-			"created_at": time.Now().Format(time.RFC3339),
-			"updated_at": time.Now().Format(time.RFC3339),
+			"created_at": time.Now().UTC().Format(time.RFC3339),
+			"updated_at": time.Now().UTC().Format(time.RFC3339),
 		}
 
 		data, err := json.MarshalIndent(state, "", "  ")
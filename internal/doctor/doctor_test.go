@@ -2,6 +2,7 @@ package doctor
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"testing"
@@ -423,3 +424,33 @@ func TestFixableCheck(t *testing.T) {
 		t.Error("FixableCheck.CanFix() should return true")
 	}
 }
+
+func TestReportJSONRoundTrip(t *testing.T) {
+	d := NewDoctor()
+	d.Register(newMockCheck("good", StatusOK))
+	d.Register(newMockCheck("bad", StatusError))
+	report := d.Run(&CheckContext{TownRoot: "/test"})
+
+	data, err := json.Marshal(report.ToJSON("/test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Callers that combine stdout and stderr append stderr after the report.
+	parsed, err := ParseReportJSON(append(data, []byte("\nwarning: slow check\n")...))
+	if err != nil {
+		t.Fatalf("ParseReportJSON: %v", err)
+	}
+	if parsed.Town != "/test" || parsed.Summary.Total != 2 || parsed.Summary.Errors != 1 {
+		t.Errorf("unexpected summary: town=%q %+v", parsed.Town, parsed.Summary)
+	}
+	if len(parsed.Checks) != 2 || parsed.Checks[1].Status != "error" {
+		t.Errorf("unexpected checks: %+v", parsed.Checks)
+	}
+
+	if _, err := ParseReportJSON([]byte("fatal: no town")); err == nil {
+		t.Error("ParseReportJSON should fail without a report")
+	}
+	if _, err := ParseReportJSON([]byte(`{"error": "not a report"}`)); err == nil {
+		t.Error("ParseReportJSON should fail on JSON that isn't a report")
+	}
+}
@@ -0,0 +1,108 @@
+package doctor
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/nudge"
+)
+
+// minLatencySamples is how many urgent deliveries the window needs before
+// the SLO is judged; a p95 over a handful of samples is noise.
+const minLatencySamples = 5
+
+// MailLatencyCheck enforces an SLO on how quickly urgent mail reaches its
+// recipient: the time from 'gt mail send' to the notification nudge landing
+// in the recipient's session, either typed directly into an idle session or
+// drained from the nudge queue at a busy agent's next turn. Slow delivery
+// means coordination stalls even though every agent looks healthy.
+type MailLatencyCheck struct {
+	BaseCheck
+}
+
+// NewMailLatencyCheck creates a new mail-to-nudge latency SLO check.
+func NewMailLatencyCheck() *MailLatencyCheck {
+	return &MailLatencyCheck{
+		BaseCheck: BaseCheck{
+			CheckName:        "mail-notify-latency",
+			CheckDescription: "Check urgent mail-to-nudge delivery latency against the SLO",
+			CheckCategory:    CategoryPatrol,
+		},
+	}
+}
+
+// Run computes p95 delivery latency for urgent mail in the SLO window.
+func (c *MailLatencyCheck) Run(ctx *CheckContext) *CheckResult {
+	cfg := config.LoadOperationalConfig(ctx.TownRoot).GetMailConfig()
+	slo, window := cfg.NotifyLatencySLOD(), cfg.NotifyLatencyWindowD()
+
+	samples, err := nudge.LoadLatencies(ctx.TownRoot, time.Now().Add(-window))
+	if err != nil {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusWarning,
+			Message: fmt.Sprintf("Could not read delivery latency log: %v", err),
+		}
+	}
+
+	var urgent, direct, queued []nudge.LatencySample
+	for _, s := range samples {
+		if s.Priority == string(mail.PriorityUrgent) {
+			urgent = append(urgent, s)
+		}
+		switch s.Path {
+		case nudge.PathDirect:
+			direct = append(direct, s)
+		case nudge.PathQueued:
+			queued = append(queued, s)
+		}
+	}
+
+	details := []string{
+		latencySummary("urgent", urgent),
+		latencySummary("all", samples),
+		latencySummary("direct", direct),
+		latencySummary("queued", queued),
+	}
+
+	if len(urgent) < minLatencySamples {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusOK,
+			Message: fmt.Sprintf("%d urgent deliveries in the last %s (need %d to judge SLO)", len(urgent), window, minLatencySamples),
+			Details: details,
+		}
+	}
+
+	p95 := nudge.LatencyPercentile(urgent, 95)
+	if p95 > slo {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusWarning,
+			Message: fmt.Sprintf("Urgent mail p95 delivery %s exceeds SLO %s", p95.Round(time.Second), slo),
+			Details: details,
+			FixHint: "Busy agents only see queued nudges at turn boundaries; check for stuck or long-running agents, or raise operational.mail.notify_latency_slo",
+		}
+	}
+	return &CheckResult{
+		Name:    c.Name(),
+		Status:  StatusOK,
+		Message: fmt.Sprintf("Urgent mail p95 delivery %s (SLO %s, %d deliveries)", p95.Round(time.Second), slo, len(urgent)),
+		Details: details,
+	}
+}
+
+// latencySummary renders a sample set's distribution for check details.
+func latencySummary(label string, samples []nudge.LatencySample) string {
+	if len(samples) == 0 {
+		return fmt.Sprintf("%s: no deliveries", label)
+	}
+	round := func(d time.Duration) time.Duration { return d.Round(100 * time.Millisecond) }
+	return fmt.Sprintf("%s: n=%d p50=%s p95=%s p99=%s max=%s", label, len(samples),
+		round(nudge.LatencyPercentile(samples, 50)),
+		round(nudge.LatencyPercentile(samples, 95)),
+		round(nudge.LatencyPercentile(samples, 99)),
+		round(nudge.LatencyPercentile(samples, 100)))
+}
@@ -0,0 +1,45 @@
+package doctor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/nudge"
+)
+
+func TestMailLatencyCheck(t *testing.T) {
+	townRoot := t.TempDir()
+	ctx := &CheckContext{TownRoot: townRoot}
+	check := NewMailLatencyCheck()
+
+	record := func(latency time.Duration, n int) {
+		for i := 0; i < n; i++ {
+			if err := nudge.RecordLatency(townRoot, nudge.LatencySample{
+				At:        time.Now(),
+				Priority:  "urgent",
+				Path:      nudge.PathQueued,
+				LatencyMs: float64(latency / time.Millisecond),
+			}); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	// Too few samples to judge, even if slow.
+	record(10*time.Minute, 2)
+	if r := check.Run(ctx); r.Status != StatusOK {
+		t.Errorf("few samples: status %v, want OK (%s)", r.Status, r.Message)
+	}
+
+	// Mostly fast: p95 within the 2m default SLO.
+	record(5*time.Second, 60)
+	if r := check.Run(ctx); r.Status != StatusOK {
+		t.Errorf("fast deliveries: status %v, want OK (%s)", r.Status, r.Message)
+	}
+
+	// Enough slow deliveries to push p95 past the SLO.
+	record(10*time.Minute, 10)
+	if r := check.Run(ctx); r.Status != StatusWarning {
+		t.Errorf("slow deliveries: status %v, want Warning (%s)", r.Status, r.Message)
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/constants"
 	"github.com/steveyegge/gastown/internal/formula"
+	"github.com/steveyegge/gastown/internal/util"
 )
 
 // PatrolMoleculesExistCheck verifies that patrol formulas are accessible.
@@ -333,18 +334,14 @@ func (c *PatrolNotStuckCheck) checkStuckWispsDolt(rigPath string, rigName string
 		title := strings.TrimSpace(rec[1])
 		updatedAt := strings.TrimSpace(rec[3])
 
-		t, err := time.Parse("2006-01-02 15:04:05", updatedAt)
+		t, err := util.ParseTimestamp(updatedAt)
 		if err != nil {
-			// Try RFC3339 as fallback
-			t, err = time.Parse(time.RFC3339, updatedAt)
-			if err != nil {
-				continue
-			}
+			continue
 		}
 
 		if !t.IsZero() && t.Before(cutoff) {
 			stuck = append(stuck, fmt.Sprintf("%s: %s (%s) - stale since %s",
-				rigName, id, title, t.Format("2006-01-02 15:04")))
+				rigName, id, title, t.Local().Format("2006-01-02 15:04")))
 		}
 	}
 
@@ -0,0 +1,83 @@
+package doctor
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ReportJSON is the machine-readable form of a Report, emitted by
+// `gt doctor --json` and served by the dashboard's /api/doctor endpoint so
+// reports from several towns can be aggregated.
+type ReportJSON struct {
+	Town      string            `json:"town,omitempty"`
+	Timestamp time.Time         `json:"timestamp"`
+	Summary   ReportSummaryJSON `json:"summary"`
+	Checks    []CheckResultJSON `json:"checks"`
+}
+
+// ReportSummaryJSON mirrors ReportSummary's counters.
+type ReportSummaryJSON struct {
+	Total    int `json:"total"`
+	OK       int `json:"ok"`
+	Warnings int `json:"warnings"`
+	Errors   int `json:"errors"`
+	Fixed    int `json:"fixed"`
+}
+
+// CheckResultJSON mirrors CheckResult with the status as a lowercase string.
+type CheckResultJSON struct {
+	Name      string   `json:"name"`
+	Status    string   `json:"status"`
+	Message   string   `json:"message"`
+	Details   []string `json:"details,omitempty"`
+	FixHint   string   `json:"fix_hint,omitempty"`
+	Category  string   `json:"category,omitempty"`
+	ElapsedMs int64    `json:"elapsed_ms"`
+	Fixed     bool     `json:"fixed,omitempty"`
+}
+
+// ToJSON converts the report for serialization.
+func (r *Report) ToJSON(townRoot string) *ReportJSON {
+	out := &ReportJSON{
+		Town:      townRoot,
+		Timestamp: r.Timestamp,
+		Summary: ReportSummaryJSON{
+			Total:    r.Summary.Total,
+			OK:       r.Summary.OK,
+			Warnings: r.Summary.Warnings,
+			Errors:   r.Summary.Errors,
+			Fixed:    r.Summary.Fixed,
+		},
+		Checks: make([]CheckResultJSON, 0, len(r.Checks)),
+	}
+	for _, c := range r.Checks {
+		out.Checks = append(out.Checks, CheckResultJSON{
+			Name:      c.Name,
+			Status:    strings.ToLower(c.Status.String()),
+			Message:   c.Message,
+			Details:   c.Details,
+			FixHint:   c.FixHint,
+			Category:  c.Category,
+			ElapsedMs: c.Elapsed.Milliseconds(),
+			Fixed:     c.Fixed,
+		})
+	}
+	return out
+}
+
+// ParseReportJSON decodes `gt doctor --json` output: a single report, which
+// doctor keeps alone on stdout. Anything after the report (stderr appended by
+// a caller that combines the streams) is ignored.
+func ParseReportJSON(data []byte) (*ReportJSON, error) {
+	var r ReportJSON
+	if err := json.NewDecoder(bytes.NewReader(data)).Decode(&r); err != nil {
+		return nil, fmt.Errorf("parsing doctor report: %w", err)
+	}
+	if r.Checks == nil {
+		return nil, fmt.Errorf("no doctor report in output")
+	}
+	return &r, nil
+}
@@ -2,7 +2,6 @@ package doctor
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/tmux"
@@ -111,9 +110,9 @@ func (c *MalformedSessionNameCheck) Run(ctx *CheckContext) *CheckResult {
 
 	fixHint := "Run 'gt doctor --fix' to rename sessions to current format"
 	if len(autoFixable) == 0 && len(needsManual) > 0 {
-		fixHint = "Crew sessions must be renamed manually: tmux rename-session -t OLD NEW"
+		fixHint = "Crew sessions are not renamed by --fix; run 'gt session migrate --include-crew'"
 	} else if len(needsManual) > 0 {
-		fixHint = "Run 'gt doctor --fix' for patrol sessions; crew sessions must be renamed manually"
+		fixHint = "Run 'gt doctor --fix' for patrol sessions; crew sessions with 'gt session migrate --include-crew'"
 	}
 
 	return &CheckResult{
@@ -176,106 +175,12 @@ func (c *MalformedSessionNameCheck) Fix(ctx *CheckContext) error {
 	return lastErr
 }
 
-// knownRoleSuffixes are the simple role keywords that appear at the end of a
-// Gas Town session name (after the rig prefix).
-var knownRoleSuffixes = []string{"witness", "refinery"}
-
-// detectLegacySessionNames scans sessions for the legacy
-//
-//	{any_prefix}-{registered_rig_name}-{role_suffix}
-//
-// pattern. For each match it computes the canonical name
-//
-//	{rig_short_prefix}-{role_suffix}
-//
-// and returns the rename list.
+// detectLegacySessionNames returns the renames needed for sessions using the
+// legacy naming scheme. See session.DetectLegacyNames.
 func detectLegacySessionNames(sessions []string, reg *session.PrefixRegistry) []sessionRename {
-	rigs := reg.AllRigs() // rigName → shortPrefix
-	if len(rigs) == 0 {
-		return nil
-	}
-
-	// Build set of known Gastown prefixes for ownership gating.
-	knownPrefixes := make(map[string]bool)
-	for _, prefix := range rigs {
-		knownPrefixes[prefix] = true
-	}
-
 	var result []sessionRename
-	seen := make(map[string]bool)
-
-	for _, sess := range sessions {
-		if sess == "" || seen[sess] {
-			continue
-		}
-		r, ok := matchLegacyName(sess, rigs, knownPrefixes)
-		if ok {
-			seen[sess] = true
-			result = append(result, r)
-		}
+	for _, r := range session.DetectLegacyNames(sessions, reg) {
+		result = append(result, sessionRename{oldName: r.Old, newName: r.New, isCrew: r.Crew})
 	}
 	return result
 }
-
-// matchLegacyName checks whether sess matches the old
-//
-//	{known_prefix}-{rig_name}-{role_suffix}  or  {known_prefix}-{rig_name}-crew-{name}
-//
-// pattern for any known rig, and returns the canonical rename if so.
-// The prefix before the rig name must be a known Gastown prefix to avoid
-// false-positives on non-Gastown sessions (e.g., "my-niflheim-witness")
-// and polecat sessions whose names embed rig names (e.g., "gt-fix-gastown-witness").
-func matchLegacyName(sess string, rigs map[string]string, knownPrefixes map[string]bool) (sessionRename, bool) {
-	for rigName, shortPrefix := range rigs {
-		// Look for "-{rigName}-" anywhere in the session name.
-		needle := "-" + rigName + "-"
-		idx := strings.Index(sess, needle)
-		if idx < 0 {
-			continue
-		}
-
-		// Ownership guard: the part before the rig name must be a known
-		// Gastown prefix. This prevents matching non-Gastown sessions
-		// and polecat sessions whose names happen to contain a rig name.
-		sessionPrefix := sess[:idx]
-		if !knownPrefixes[sessionPrefix] {
-			continue
-		}
-
-		// Skip if the session already uses the correct prefix for this rig.
-		if sessionPrefix == shortPrefix {
-			continue
-		}
-
-		// The part after the rig name is the role suffix.
-		roleSuffix := sess[idx+len(needle):]
-		if roleSuffix == "" {
-			continue
-		}
-
-		// Validate: must be a known Gas Town role suffix.
-		if !isValidRoleSuffix(roleSuffix) {
-			continue
-		}
-
-		canonical := shortPrefix + "-" + roleSuffix
-		isCrew := strings.HasPrefix(roleSuffix, "crew-")
-
-		return sessionRename{
-			oldName: sess,
-			newName: canonical,
-			isCrew:  isCrew,
-		}, true
-	}
-	return sessionRename{}, false
-}
-
-// isValidRoleSuffix returns true if suffix is a known Gas Town role identifier.
-func isValidRoleSuffix(suffix string) bool {
-	for _, role := range knownRoleSuffixes {
-		if suffix == role {
-			return true
-		}
-	}
-	return strings.HasPrefix(suffix, "crew-") && len(suffix) > len("crew-")
-}
@@ -0,0 +1,276 @@
+package doctor
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/incident"
+	"github.com/steveyegge/gastown/internal/polecat"
+	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+// stuckRecoveryPollInterval is how often remediation re-reads the heartbeat
+// while waiting for a nudged polecat to recover.
+const stuckRecoveryPollInterval = 5 * time.Second
+
+// stuckWorker describes a polecat session identified as stuck during Run.
+type stuckWorker struct {
+	session string
+	rig     string
+	name    string
+	bead    string
+	reason  string
+}
+
+// StuckWorkersCheck detects polecats that are stuck: either they self-reported
+// stuck via `gt heartbeat --state=stuck`, or their heartbeat has not been
+// touched for longer than the hung-session threshold while the session lives.
+//
+// By default the check only warns. With `gt doctor --fix --remediate`, Fix
+// captures each stuck pane for diagnostics, sends a recovery nudge, and if the
+// polecat is still stuck after the configured timeout, restarts its session
+// with the hook bead re-attached.
+type StuckWorkersCheck struct {
+	FixableCheck
+	sessionLister SessionLister
+	stuck         []stuckWorker // Cached during Run for use in Fix
+
+	// remediateWorker overrides the capture → nudge → restart sequence (for
+	// testing).
+	remediateWorker func(ctx *CheckContext, w stuckWorker) error
+}
+
+// NewStuckWorkersCheck creates a new stuck workers check.
+func NewStuckWorkersCheck() *StuckWorkersCheck {
+	return &StuckWorkersCheck{
+		FixableCheck: FixableCheck{
+			BaseCheck: BaseCheck{
+				CheckName:        "stuck-workers",
+				CheckDescription: "Detect stuck polecats (remediate with --fix --remediate)",
+				CheckCategory:    CategoryPatrol,
+			},
+		},
+	}
+}
+
+// NewStuckWorkersCheckWithSessionLister creates a check with a custom session lister (for testing).
+func NewStuckWorkersCheckWithSessionLister(lister SessionLister) *StuckWorkersCheck {
+	check := NewStuckWorkersCheck()
+	check.sessionLister = lister
+	return check
+}
+
+// stuckReason classifies a polecat heartbeat. Returns a human-readable reason
+// if the polecat is stuck, or "" if it is healthy or there is not enough
+// information to decide (no heartbeat, or the agent is exiting).
+func stuckReason(hb *polecat.SessionHeartbeat, now time.Time, hungThreshold time.Duration) string {
+	if hb == nil {
+		return ""
+	}
+	switch hb.EffectiveState() {
+	case polecat.HeartbeatStuck:
+		if hb.Context != "" {
+			return fmt.Sprintf("self-reported stuck: %s", hb.Context)
+		}
+		return "self-reported stuck"
+	case polecat.HeartbeatExiting:
+		return ""
+	}
+	if age := now.Sub(hb.Timestamp); age >= hungThreshold {
+		return fmt.Sprintf("no heartbeat for %s", age.Round(time.Minute))
+	}
+	return ""
+}
+
+// Run checks all polecat sessions for stuck heartbeats.
+func (c *StuckWorkersCheck) Run(ctx *CheckContext) *CheckResult {
+	lister := c.sessionLister
+	if lister == nil {
+		lister = &realSessionLister{t: tmux.NewTmux()}
+	}
+
+	sessions, err := lister.ListSessions()
+	if err != nil {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusOK,
+			Message: "No tmux server running",
+		}
+	}
+
+	hungThreshold := config.LoadOperationalConfig(ctx.TownRoot).GetSessionConfig().HungSessionThresholdD()
+	now := time.Now()
+
+	var stuck []stuckWorker
+	checked := 0
+	for _, sess := range sessions {
+		identity, err := session.ParseSessionName(sess)
+		if err != nil || identity.Role != session.RolePolecat {
+			continue
+		}
+		if ctx.RigName != "" && identity.Rig != ctx.RigName {
+			continue
+		}
+		checked++
+
+		hb := polecat.ReadSessionHeartbeat(ctx.TownRoot, sess)
+		reason := stuckReason(hb, now, hungThreshold)
+		if reason == "" {
+			continue
+		}
+		stuck = append(stuck, stuckWorker{
+			session: sess,
+			rig:     identity.Rig,
+			name:    identity.Name,
+			bead:    hb.Bead,
+			reason:  reason,
+		})
+	}
+
+	c.stuck = stuck
+
+	if len(stuck) == 0 {
+		msg := "No stuck polecats"
+		if checked > 0 {
+			msg = fmt.Sprintf("All %d polecat(s) reporting healthy heartbeats", checked)
+		}
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusOK,
+			Message: msg,
+		}
+	}
+
+	details := make([]string, len(stuck))
+	for i, w := range stuck {
+		details[i] = fmt.Sprintf("%s/%s: %s", w.rig, w.name, w.reason)
+	}
+
+	return &CheckResult{
+		Name:    c.Name(),
+		Status:  StatusWarning,
+		Message: fmt.Sprintf("Found %d stuck polecat(s)", len(stuck)),
+		Details: details,
+		FixHint: "Run 'gt doctor --fix --remediate' to nudge and, if needed, restart stuck polecats",
+	}
+}
+
+// Fix remediates stuck polecats. It is a no-op unless --remediate was passed,
+// since nudging and restarting agents is more invasive than a routine --fix.
+// Each remediation can wait out the full recovery timeout, so polecats are
+// remediated in parallel.
+func (c *StuckWorkersCheck) Fix(ctx *CheckContext) error {
+	if !ctx.Remediate || len(c.stuck) == 0 {
+		return nil
+	}
+
+	remediate := c.remediateWorker
+	if remediate == nil {
+		t := tmux.NewTmux()
+		opCfg := config.LoadOperationalConfig(ctx.TownRoot)
+		polecatCfg := opCfg.GetPolecatConfig()
+		hungThreshold := opCfg.GetSessionConfig().HungSessionThresholdD()
+		nudgeMsg := polecatCfg.StuckRecoveryNudgeV()
+		timeout := polecatCfg.StuckRecoveryTimeoutD()
+		remediate = func(ctx *CheckContext, w stuckWorker) error {
+			return c.remediate(ctx, t, w, nudgeMsg, timeout, hungThreshold)
+		}
+	}
+
+	errs := make([]error, len(c.stuck))
+	var wg sync.WaitGroup
+	for i, w := range c.stuck {
+		wg.Add(1)
+		go func(i int, w stuckWorker) {
+			defer wg.Done()
+			if err := remediate(ctx, w); err != nil {
+				errs[i] = fmt.Errorf("%s/%s: %w", w.rig, w.name, err)
+			}
+		}(i, w)
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// remediate runs the capture → nudge → wait → restart sequence for one polecat.
+func (c *StuckWorkersCheck) remediate(ctx *CheckContext, t *tmux.Tmux, w stuckWorker, nudgeMsg string, timeout, hungThreshold time.Duration) error {
+	_ = events.LogFeed(events.TypeStuckWorker, w.session,
+		events.StuckWorkerPayload(w.rig, w.name, w.reason, "gt doctor"))
+
+	// 1. Capture the pane before touching anything, so the state that led to
+	//    the stall survives the nudge or restart.
+	if path, err := saveStuckDiagnostics(ctx.TownRoot, t, w); err != nil {
+		fmt.Printf("  Warning: could not capture %s: %v\n", w.session, err)
+	} else if ctx.Verbose {
+		fmt.Printf("  Captured %s to %s\n", w.session, path)
+	}
+
+	// 2. Recovery nudge.
+	nudgedAt := time.Now()
+	if err := t.NudgeSession(w.session, nudgeMsg); err != nil {
+		return fmt.Errorf("sending recovery nudge: %w", err)
+	}
+
+	// 3. Wait for the heartbeat to show progress after the nudge.
+	deadline := nudgedAt.Add(timeout)
+	for time.Now().Before(deadline) {
+		time.Sleep(stuckRecoveryPollInterval)
+		hb := polecat.ReadSessionHeartbeat(ctx.TownRoot, w.session)
+		if hb != nil && hb.Timestamp.After(nudgedAt) && stuckReason(hb, time.Now(), hungThreshold) == "" {
+			return nil
+		}
+	}
+
+	// 4. Still stuck: restart the session with the hook bead re-attached.
+	_ = events.LogFeed(events.TypeSessionDeath, w.session,
+		events.SessionDeathPayload(w.session, w.rig+"/"+w.name, "stuck worker remediation", "gt doctor"))
+
+	r, err := loadRigForRemediation(ctx.TownRoot, w.rig)
+	if err != nil {
+		return err
+	}
+	mgr := polecat.NewSessionManager(t, r)
+	if err := mgr.Stop(w.name, true); err != nil && !errors.Is(err, polecat.ErrSessionNotFound) {
+		return fmt.Errorf("stopping session: %w", err)
+	}
+	if err := mgr.Start(w.name, polecat.SessionStartOptions{Issue: w.bead}); err != nil {
+		return fmt.Errorf("restarting session: %w", err)
+	}
+	return nil
+}
+
+// saveStuckDiagnostics saves the full pane scrollback for a stuck polecat as
+// an incident bundle under <townRoot>/.runtime/diagnostics/stuck/.
+func saveStuckDiagnostics(townRoot string, t *tmux.Tmux, w stuckWorker) (string, error) {
+	content, err := t.CapturePaneAll(w.session)
+	if err != nil {
+		return "", err
+	}
+	return incident.Save(townRoot, incident.Incident{
+		Kind:    incident.KindStuck,
+		Session: w.session,
+		Bead:    w.bead,
+		Reason:  w.reason,
+	}, content)
+}
+
+// loadRigForRemediation loads a rig from the town's rigs registry.
+func loadRigForRemediation(townRoot, rigName string) (*rig.Rig, error) {
+	rigsConfig, err := config.LoadRigsConfig(constants.MayorRigsPath(townRoot))
+	if err != nil {
+		return nil, fmt.Errorf("loading rigs config: %w", err)
+	}
+	r, err := rig.NewManager(townRoot, rigsConfig, git.NewGit(townRoot)).GetRig(rigName)
+	if err != nil {
+		return nil, fmt.Errorf("loading rig %s: %w", rigName, err)
+	}
+	return r, nil
+}
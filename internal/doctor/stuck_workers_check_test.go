@@ -0,0 +1,153 @@
+package doctor
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/polecat"
+)
+
+func TestStuckReason(t *testing.T) {
+	now := time.Now()
+	hung := 30 * time.Minute
+
+	tests := []struct {
+		name      string
+		hb        *polecat.SessionHeartbeat
+		wantStuck bool
+		contains  string
+	}{
+		{name: "no heartbeat", hb: nil},
+		{name: "fresh working", hb: &polecat.SessionHeartbeat{Timestamp: now, State: polecat.HeartbeatWorking}},
+		{name: "fresh v1", hb: &polecat.SessionHeartbeat{Timestamp: now.Add(-time.Minute)}},
+		{
+			name:      "self-reported stuck",
+			hb:        &polecat.SessionHeartbeat{Timestamp: now, State: polecat.HeartbeatStuck, Context: "auth broken"},
+			wantStuck: true,
+			contains:  "auth broken",
+		},
+		{
+			name:      "stale working",
+			hb:        &polecat.SessionHeartbeat{Timestamp: now.Add(-45 * time.Minute), State: polecat.HeartbeatWorking},
+			wantStuck: true,
+			contains:  "no heartbeat",
+		},
+		{name: "stale exiting", hb: &polecat.SessionHeartbeat{Timestamp: now.Add(-45 * time.Minute), State: polecat.HeartbeatExiting}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := stuckReason(tt.hb, now, hung)
+			if (got != "") != tt.wantStuck {
+				t.Fatalf("stuckReason() = %q, wantStuck %v", got, tt.wantStuck)
+			}
+			if tt.contains != "" && !strings.Contains(got, tt.contains) {
+				t.Errorf("stuckReason() = %q, want it to contain %q", got, tt.contains)
+			}
+		})
+	}
+}
+
+func writeTestHeartbeat(t *testing.T, townRoot, sessionName string, hb polecat.SessionHeartbeat) {
+	t.Helper()
+	dir := filepath.Join(townRoot, ".runtime", "heartbeats")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(hb)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, sessionName+".json"), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestStuckWorkersCheck_Run(t *testing.T) {
+	setupTestRegistry(t)
+	townRoot := t.TempDir()
+
+	writeTestHeartbeat(t, townRoot, "gt-furiosa", polecat.SessionHeartbeat{
+		Timestamp: time.Now(), State: polecat.HeartbeatStuck, Context: "merge conflict", Bead: "gt-abc",
+	})
+	writeTestHeartbeat(t, townRoot, "gt-nux", polecat.SessionHeartbeat{
+		Timestamp: time.Now(), State: polecat.HeartbeatWorking,
+	})
+
+	lister := &mockSessionLister{sessions: []string{"gt-furiosa", "gt-nux", "gt-witness", "hq-mayor"}}
+	check := NewStuckWorkersCheckWithSessionLister(lister)
+
+	result := check.Run(&CheckContext{TownRoot: townRoot})
+	if result.Status != StatusWarning {
+		t.Fatalf("expected StatusWarning, got %v: %s", result.Status, result.Message)
+	}
+	if len(check.stuck) != 1 || check.stuck[0].session != "gt-furiosa" || check.stuck[0].bead != "gt-abc" {
+		t.Errorf("unexpected stuck workers: %+v", check.stuck)
+	}
+
+	// Fix without --remediate must not touch anything.
+	if err := check.Fix(&CheckContext{TownRoot: townRoot}); err != nil {
+		t.Errorf("Fix without Remediate should be a no-op, got %v", err)
+	}
+}
+
+func TestStuckWorkersCheck_RunHealthy(t *testing.T) {
+	setupTestRegistry(t)
+	check := NewStuckWorkersCheckWithSessionLister(&mockSessionLister{sessions: []string{"gt-nux"}})
+
+	result := check.Run(&CheckContext{TownRoot: t.TempDir()})
+	if result.Status != StatusOK {
+		t.Errorf("expected StatusOK, got %v: %s", result.Status, result.Message)
+	}
+}
+
+func TestStuckWorkersCheck_FixRemediatesInParallel(t *testing.T) {
+	check := NewStuckWorkersCheck()
+	check.stuck = []stuckWorker{
+		{session: "gt-furiosa", rig: "gastown", name: "furiosa"},
+		{session: "gt-nux", rig: "gastown", name: "nux"},
+		{session: "gt-slit", rig: "gastown", name: "slit"},
+	}
+
+	// Each remediation waits until all have started, so a serial Fix would
+	// time out here.
+	var started sync.WaitGroup
+	started.Add(len(check.stuck))
+	var mu sync.Mutex
+	var remediated []string
+	check.remediateWorker = func(ctx *CheckContext, w stuckWorker) error {
+		started.Done()
+		done := make(chan struct{})
+		go func() { started.Wait(); close(done) }()
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			return errors.New("remediations ran one at a time")
+		}
+		mu.Lock()
+		remediated = append(remediated, w.name)
+		mu.Unlock()
+		if w.name == "nux" {
+			return errors.New("restart failed")
+		}
+		return nil
+	}
+
+	err := check.Fix(&CheckContext{TownRoot: t.TempDir(), Remediate: true})
+	if err == nil || !strings.Contains(err.Error(), "gastown/nux: restart failed") {
+		t.Errorf("Fix() = %v, want nux's failure", err)
+	}
+	if strings.Contains(fmt.Sprint(err), "furiosa") || strings.Contains(fmt.Sprint(err), "one at a time") {
+		t.Errorf("Fix() = %v, want only nux's failure", err)
+	}
+	if len(remediated) != 3 {
+		t.Errorf("remediated %v, want all three", remediated)
+	}
+}
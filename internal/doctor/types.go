@@ -64,6 +64,7 @@ type CheckContext struct {
 	Verbose         bool   // Enable verbose output
 	RestartSessions bool   // Restart patrol sessions when fixing (requires explicit --restart-sessions flag)
 	NoStart         bool   // Suppress starting daemon/agents during --fix
+	Remediate       bool   // Nudge/restart stuck workers when fixing (requires explicit --remediate flag)
 }
 
 // RigPath returns the full path to the rig directory.
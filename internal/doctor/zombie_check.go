@@ -51,10 +51,8 @@ func (c *ZombieSessionCheck) Run(ctx *CheckContext) *CheckResult {
 		}
 	}
 
-	// Check each Gas Town session for zombie status
-	var zombies []string
-	var healthyCount int
-
+	// Collect the Gas Town sessions to check, then check them all at once
+	var candidates []string
 	for _, sess := range sessions {
 		if sess == "" {
 			continue
@@ -71,8 +69,15 @@ func (c *ZombieSessionCheck) Run(ctx *CheckContext) *CheckResult {
 			continue
 		}
 
-		// Check if Claude is running in this session
-		if t.IsAgentAlive(sess) {
+		candidates = append(candidates, sess)
+	}
+
+	// Check if Claude is running in each session
+	var zombies []string
+	var healthyCount int
+	alive := t.AgentsAlive(candidates)
+	for _, sess := range candidates {
+		if alive[sess] {
 			healthyCount++
 		} else {
 			zombies = append(zombies, sess)
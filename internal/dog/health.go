@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/tmux"
 )
 
@@ -39,7 +40,7 @@ func NewHealthChecker(mgr *Manager, checker sessionChecker) *HealthChecker {
 
 // dogSessionName returns the tmux session name for a dog.
 func dogSessionName(name string) string {
-	return fmt.Sprintf("hq-dog-%s", name)
+	return session.DogSessionName(name)
 }
 
 // Check performs a health check on a single dog.
@@ -72,7 +72,7 @@ type SessionInfo struct {
 // We use "hq-dog-" instead of "hq-deacon-" to avoid tmux prefix-matching
 // collisions with the "hq-deacon" session.
 func (m *SessionManager) SessionName(dogName string) string {
-	return fmt.Sprintf("hq-dog-%s", dogName)
+	return session.DogSessionName(dogName)
 }
 
 // kennelPath returns the path to the dog's kennel directory.
@@ -0,0 +1,353 @@
+package doltserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// Divergence describes how a beads database differs from its remote, as of
+// the last fetch. Ahead commits are local bead changes not yet pushed;
+// Behind commits were pushed from elsewhere (another town or clone) and not
+// yet pulled. Both at once means conflicting edits that need a merge.
+type Divergence struct {
+	Database  string
+	Remote    string // Remote gt dolt sync pushes to; empty when none is configured
+	Ahead     int
+	Behind    int
+	Dirty     bool      // Uncommitted working-set changes
+	LastSync  time.Time // Last successful gt dolt sync push; zero if never
+	LastFetch time.Time // When the remote-tracking ref was last refreshed; zero if never by gt
+	FetchErr  error     // Set when refreshing the remote failed; counts use the last fetch
+}
+
+// Diverged reports whether local and remote both have commits the other lacks.
+func (d Divergence) Diverged() bool {
+	return d.Ahead > 0 && d.Behind > 0
+}
+
+// String summarizes the divergence for reports.
+func (d Divergence) String() string {
+	var parts []string
+	if d.Ahead > 0 {
+		parts = append(parts, fmt.Sprintf("%d unpushed", d.Ahead))
+	}
+	if d.Behind > 0 {
+		parts = append(parts, fmt.Sprintf("%d unpulled", d.Behind))
+	}
+	if d.Dirty {
+		parts = append(parts, "uncommitted changes")
+	}
+	if len(parts) == 0 {
+		parts = append(parts, "in sync")
+	}
+	synced := "never synced"
+	if !d.LastSync.IsZero() {
+		synced = "synced " + time.Since(d.LastSync).Round(time.Minute).String() + " ago"
+	}
+	return fmt.Sprintf("%s: %s (%s)", d.Database, strings.Join(parts, ", "), synced)
+}
+
+// InspectDivergence compares a database with the main branch of the remote
+// gt dolt sync pushes to. The remote is fetched first unless gt fetched it
+// within fetchMaxAge (zero never fetches); a failed fetch is reported in
+// FetchErr and the last fetch's tracking ref is used. Databases without a
+// remote are returned with an empty Remote and nothing else inspected.
+func InspectDivergence(townRoot, db string, fetchMaxAge time.Duration) (Divergence, error) {
+	d := Divergence{Database: db, LastSync: LastSync(townRoot, db), LastFetch: lastFetch(townRoot, db)}
+	repo := openBeadsRepo(townRoot, db)
+
+	remote, err := repo.remote()
+	if err != nil {
+		return d, err
+	}
+	d.Remote = remote
+	if remote == "" {
+		return d, nil
+	}
+
+	if fetchMaxAge > 0 && time.Since(d.LastFetch) >= fetchMaxAge {
+		if d.FetchErr = repo.fetch(remote); d.FetchErr == nil {
+			d.LastFetch = time.Now().UTC()
+			_ = recordFetch(townRoot, db, d.LastFetch)
+		}
+	}
+
+	if d.Dirty, err = repo.dirty(); err != nil {
+		return d, err
+	}
+	tracking := remote + "/main"
+	if d.Ahead, err = repo.countCommits(tracking + "..main"); err != nil {
+		// No tracking ref yet: the remote was never fetched or pushed.
+		return d, nil
+	}
+	d.Behind, _ = repo.countCommits("main.." + tracking)
+	return d, nil
+}
+
+// beadsRepo runs the dolt operations the divergence check and its fix need
+// on one database. While the Dolt server is running they go through it as
+// SQL, since the dolt CLI must not work on a data directory the server has
+// open; with the server stopped they use the CLI on the database directory.
+type beadsRepo struct {
+	townRoot string
+	db       string
+	server   bool
+}
+
+func openBeadsRepo(townRoot, db string) beadsRepo {
+	running, _, _ := IsRunning(townRoot)
+	return beadsRepo{townRoot: townRoot, db: db, server: running}
+}
+
+func (r beadsRepo) dir() string {
+	return RigDatabaseDir(r.townRoot, r.db)
+}
+
+// query runs SQL against the database on the server and returns the values
+// of its result's first column.
+func (r beadsRepo) query(timeout time.Duration, q string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	cmd := buildDoltSQLCmd(ctx, DefaultConfig(r.townRoot), "-r", "csv", "-q", fmt.Sprintf("USE `%s`; %s", r.db, q))
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("dolt sql: %w (%s)", err, strings.TrimSpace(string(out)))
+	}
+	return csvColumn(string(out)), nil
+}
+
+// csvColumn returns the first field of each row of dolt's CSV output,
+// skipping the header.
+func csvColumn(out string) []string {
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) < 2 {
+		return nil
+	}
+	var vals []string
+	for _, line := range lines[1:] {
+		field, _, _ := strings.Cut(strings.TrimSpace(line), ",")
+		vals = append(vals, strings.Trim(field, `"`))
+	}
+	return vals
+}
+
+// count runs a single-value COUNT query on the server.
+func (r beadsRepo) count(q string) (int, error) {
+	vals, err := r.query(sqlTimeout, q)
+	if err != nil {
+		return 0, err
+	}
+	if len(vals) == 0 {
+		return 0, fmt.Errorf("no result from %q", q)
+	}
+	return strconv.Atoi(vals[0])
+}
+
+// sqlTimeout bounds a divergence query that doesn't touch the network.
+const sqlTimeout = 15 * time.Second
+
+// remote returns the remote gt dolt sync pushes to: the first configured
+// one, or "" if there is none.
+func (r beadsRepo) remote() (string, error) {
+	if !r.server {
+		name, _, err := FindRemote(r.dir())
+		return name, err
+	}
+	vals, err := r.query(sqlTimeout, "SELECT name FROM dolt_remotes ORDER BY name LIMIT 1")
+	if err != nil || len(vals) == 0 {
+		return "", err
+	}
+	return vals[0], nil
+}
+
+func (r beadsRepo) fetch(remote string) error {
+	if !r.server {
+		return FetchDatabase(r.dir(), remote)
+	}
+	_, err := r.query(fetchTimeout, fmt.Sprintf("CALL DOLT_FETCH('%s')", EscapeSQL(remote)))
+	return err
+}
+
+func (r beadsRepo) dirty() (bool, error) {
+	if !r.server {
+		status, err := doltOutput(r.dir(), "status")
+		return !strings.Contains(status, "working tree clean"), err
+	}
+	n, err := r.count("SELECT COUNT(*) FROM dolt_status")
+	return n > 0, err
+}
+
+// countCommits counts commits in a dolt log revision range.
+func (r beadsRepo) countCommits(rng string) (int, error) {
+	if !r.server {
+		out, err := doltOutput(r.dir(), "log", "--oneline", rng)
+		if err != nil {
+			return 0, err
+		}
+		return countLogLines(out), nil
+	}
+	return r.count(fmt.Sprintf("SELECT COUNT(*) FROM dolt_log('%s')", EscapeSQL(rng)))
+}
+
+func (r beadsRepo) commit() error {
+	if !r.server {
+		return CommitWorkingSet(r.dir())
+	}
+	dirty, err := r.dirty()
+	if err != nil || !dirty {
+		return err
+	}
+	return CommitServerWorkingSet(r.townRoot, r.db, "gt dolt sync: auto-commit working changes")
+}
+
+func (r beadsRepo) pull(remote string) error {
+	if !r.server {
+		return PullDatabase(r.dir(), remote)
+	}
+	_, err := r.query(fetchTimeout, fmt.Sprintf("CALL DOLT_PULL('%s', 'main')", EscapeSQL(remote)))
+	return err
+}
+
+func (r beadsRepo) push(remote string) error {
+	if !r.server {
+		return PushDatabase(r.dir(), remote, false)
+	}
+	_, err := r.query(fetchTimeout, fmt.Sprintf("CALL DOLT_PUSH('%s', 'main')", EscapeSQL(remote)))
+	return err
+}
+
+// countLogLines counts non-empty lines of `dolt log --oneline` output.
+func countLogLines(out string) int {
+	n := 0
+	for _, line := range strings.Split(out, "\n") {
+		if strings.TrimSpace(line) != "" {
+			n++
+		}
+	}
+	return n
+}
+
+// doltOutput runs a dolt CLI command in dbDir and returns its output.
+func doltOutput(dbDir string, args ...string) (string, error) {
+	cmd := exec.Command("dolt", args...)
+	cmd.Dir = dbDir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("dolt %s: %w (%s)", args[0], err, strings.TrimSpace(string(out)))
+	}
+	return string(out), nil
+}
+
+// fetchTimeout bounds a single dolt fetch, pull, or push so an unreachable
+// remote doesn't stall callers.
+const fetchTimeout = 30 * time.Second
+
+// FetchDatabase updates a database's remote-tracking refs with the dolt CLI.
+// The Dolt server must not be running on the database.
+func FetchDatabase(dbDir, remote string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), fetchTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "dolt", "fetch", remote)
+	cmd.Dir = dbDir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("dolt fetch: %w (%s)", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// PullDatabase merges the remote's main branch into the local one with the
+// dolt CLI. The Dolt server must not be running on the database.
+func PullDatabase(dbDir, remote string) error {
+	_, err := doltOutput(dbDir, "pull", remote, "main")
+	return err
+}
+
+// ReconcileDatabase brings a database level with its remote: commit the
+// working set, pull (merging remote edits) when behind, then push when
+// ahead. Merge conflicts surface as a pull error and stop the sync.
+func ReconcileDatabase(townRoot string, d Divergence) error {
+	if d.Remote == "" {
+		return nil
+	}
+	repo := openBeadsRepo(townRoot, d.Database)
+	if err := repo.commit(); err != nil {
+		return err
+	}
+	if d.Behind > 0 {
+		if err := repo.pull(d.Remote); err != nil {
+			return err
+		}
+	}
+	if err := repo.push(d.Remote); err != nil {
+		return err
+	}
+	return RecordSync(townRoot, d.Database)
+}
+
+// syncStateMu serializes read-modify-write of the sync and fetch state files.
+var syncStateMu sync.Mutex
+
+// syncStatePath returns <townRoot>/.runtime/dolt-sync.json, which records
+// when each database was last pushed by gt.
+func syncStatePath(townRoot string) string {
+	return filepath.Join(constants.TownRuntimePath(townRoot), "dolt-sync.json")
+}
+
+func loadSyncState(townRoot string) map[string]time.Time {
+	state := make(map[string]time.Time)
+	if data, err := os.ReadFile(syncStatePath(townRoot)); err == nil {
+		_ = json.Unmarshal(data, &state)
+	}
+	return state
+}
+
+// RecordSync notes that db was just pushed to its remote.
+func RecordSync(townRoot, db string) error {
+	syncStateMu.Lock()
+	defer syncStateMu.Unlock()
+	state := loadSyncState(townRoot)
+	state[db] = time.Now().UTC()
+	return util.EnsureDirAndWriteJSON(syncStatePath(townRoot), state)
+}
+
+// LastSync returns when db was last pushed by gt, or zero if never.
+func LastSync(townRoot, db string) time.Time {
+	return loadSyncState(townRoot)[db]
+}
+
+// fetchStatePath returns <townRoot>/.runtime/dolt-fetch.json, which records
+// when each database's remote was last fetched by gt.
+func fetchStatePath(townRoot string) string {
+	return filepath.Join(constants.TownRuntimePath(townRoot), "dolt-fetch.json")
+}
+
+func lastFetch(townRoot, db string) time.Time {
+	state := make(map[string]time.Time)
+	if data, err := os.ReadFile(fetchStatePath(townRoot)); err == nil {
+		_ = json.Unmarshal(data, &state)
+	}
+	return state[db]
+}
+
+// recordFetch notes that db's remote was fetched at t.
+func recordFetch(townRoot, db string, t time.Time) error {
+	syncStateMu.Lock()
+	defer syncStateMu.Unlock()
+	state := make(map[string]time.Time)
+	if data, err := os.ReadFile(fetchStatePath(townRoot)); err == nil {
+		_ = json.Unmarshal(data, &state)
+	}
+	state[db] = t
+	return util.EnsureDirAndWriteJSON(fetchStatePath(townRoot), state)
+}
@@ -0,0 +1,95 @@
+package doltserver
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCountLogLines(t *testing.T) {
+	tests := []struct {
+		out  string
+		want int
+	}{
+		{"", 0},
+		{"\n\n", 0},
+		{"abc123 add bead", 1},
+		{"abc123 add bead\ndef456 close bead\n", 2},
+	}
+	for _, tt := range tests {
+		if got := countLogLines(tt.out); got != tt.want {
+			t.Errorf("countLogLines(%q) = %d, want %d", tt.out, got, tt.want)
+		}
+	}
+}
+
+func TestCSVColumn(t *testing.T) {
+	if got := csvColumn("COUNT(*)\n3\n"); len(got) != 1 || got[0] != "3" {
+		t.Errorf("csvColumn(count) = %q", got)
+	}
+	if got := csvColumn("name,url\norigin,https://x\n\"up\",y\n"); len(got) != 2 || got[0] != "origin" || got[1] != "up" {
+		t.Errorf("csvColumn(remotes) = %q", got)
+	}
+	if got := csvColumn("name\n"); got != nil {
+		t.Errorf("csvColumn(no rows) = %q, want nil", got)
+	}
+}
+
+func TestDivergenceString(t *testing.T) {
+	d := Divergence{Database: "gastown", Ahead: 2, Behind: 1, Dirty: true}
+	if !d.Diverged() {
+		t.Error("Diverged() = false, want true")
+	}
+	s := d.String()
+	for _, want := range []string{"gastown:", "2 unpushed", "1 unpulled", "uncommitted changes", "never synced"} {
+		if !strings.Contains(s, want) {
+			t.Errorf("String() = %q, missing %q", s, want)
+		}
+	}
+
+	clean := Divergence{Database: "hq", LastSync: time.Now().Add(-time.Hour)}
+	if clean.Diverged() {
+		t.Error("Diverged() = true for clean database")
+	}
+	if s := clean.String(); !strings.Contains(s, "in sync") || !strings.Contains(s, "synced 1h0m0s ago") {
+		t.Errorf("String() = %q", s)
+	}
+}
+
+func TestRecordFetch(t *testing.T) {
+	townRoot := t.TempDir()
+	at := time.Now().UTC().Truncate(time.Second)
+	if err := recordFetch(townRoot, "gastown", at); err != nil {
+		t.Fatalf("recordFetch: %v", err)
+	}
+	if got := lastFetch(townRoot, "gastown"); !got.Equal(at) {
+		t.Errorf("lastFetch(gastown) = %v, want %v", got, at)
+	}
+	if got := lastFetch(townRoot, "hq"); !got.IsZero() {
+		t.Errorf("lastFetch(hq) = %v, want zero", got)
+	}
+}
+
+func TestRecordSync(t *testing.T) {
+	townRoot := t.TempDir()
+	if got := LastSync(townRoot, "gastown"); !got.IsZero() {
+		t.Fatalf("LastSync before record = %v, want zero", got)
+	}
+
+	before := time.Now().Add(-time.Second)
+	if err := RecordSync(townRoot, "gastown"); err != nil {
+		t.Fatalf("RecordSync: %v", err)
+	}
+	if err := RecordSync(townRoot, "hq"); err != nil {
+		t.Fatalf("RecordSync: %v", err)
+	}
+	if got := LastSync(townRoot, "gastown"); got.Before(before) {
+		t.Errorf("LastSync(gastown) = %v, want after %v", got, before)
+	}
+	if got := LastSync(townRoot, "hq"); got.IsZero() {
+		t.Error("LastSync(hq) is zero after RecordSync")
+	}
+	if got := LastSync(townRoot, "other"); !got.IsZero() {
+		t.Errorf("LastSync(other) = %v, want zero", got)
+	}
+}
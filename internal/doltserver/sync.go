@@ -206,6 +206,7 @@ func SyncDatabases(townRoot string, opts SyncOptions) []SyncResult {
 		}
 
 		result.Pushed = true
+		_ = RecordSync(townRoot, db)
 		results = append(results, result)
 	}
 
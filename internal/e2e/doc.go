@@ -0,0 +1,11 @@
+// Package e2e holds the end-to-end test suite: real gt, bd, dolt, and tmux
+// running inside the Dockerfile.e2e container, with gt-fake-agent (see the
+// fakeagent subpackage) standing in for Claude in every session.
+//
+// The suite is opt-in:
+//
+//	go test -tags=integration ./internal/e2e/...
+//
+// Tests skip when Docker is unavailable. The image is built on first use and
+// reused after that; run make test-e2e-harness after Dockerfile changes.
+package e2e
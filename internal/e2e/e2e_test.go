@@ -0,0 +1,211 @@
+//go:build integration && !windows
+
+package e2e
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/testutil"
+)
+
+const (
+	townRoot = "/town"
+	rigName  = "app"
+	prefix   = "ap"
+	logDir   = "/tmp/fake-agents"
+)
+
+// agentRecord mirrors the fake agent's log record.
+type agentRecord struct {
+	Kind  string            `json:"kind"`
+	Input string            `json:"input"`
+	Env   map[string]string `json:"env"`
+	Error string            `json:"error"`
+}
+
+// onStart runs in every fake agent at startup. Polecats wait for their hook,
+// commit a change, and call gt done — the same lifecycle a real polecat walks
+// through, minus the thinking.
+const onStart = `#!/bin/bash
+[ -n "$GT_POLECAT" ] || exit 0
+for i in $(seq 1 60); do
+  gt hook 2>/dev/null | grep -q "` + prefix + `-" && break
+  sleep 0.5
+done
+echo "e2e change from $GT_POLECAT" > e2e.txt
+git add e2e.txt
+git commit -qm "e2e: change from $GT_POLECAT"
+gt done
+`
+
+// newTown starts a container and installs a town with one rig whose agents
+// are all gt-fake-agent.
+func newTown(t *testing.T) *testutil.TmuxContainer {
+	t.Helper()
+	c := testutil.StartTmuxContainer(t)
+
+	c.WriteFile("/e2e/on-start.sh", onStart, 0755)
+	c.MustExec(`set -e
+mkdir -p ` + logDir + ` /repos
+git init -q --bare /repos/app.git
+git clone -q /repos/app.git /tmp/seed
+cd /tmp/seed
+echo "# app" > README.md
+git add README.md
+git commit -qm "initial commit"
+git push -q origin HEAD:main`)
+
+	c.MustExec(`set -e
+gt install ` + townRoot + ` --name e2e
+cd ` + townRoot + `
+gt rig add ` + rigName + ` file:///repos/app.git --prefix ` + prefix + ` --branch main
+gt config agent set fake "gt-fake-agent --log-dir ` + logDir + ` --on-start /e2e/on-start.sh"
+gt config default-agent fake`)
+	return c
+}
+
+// agentLog reads the fake agent records for a session.
+func agentLog(c *testutil.TmuxContainer, session string) ([]agentRecord, string) {
+	out, err := c.Exec("cat " + logDir + "/" + session + ".jsonl")
+	if err != nil {
+		return nil, out
+	}
+	var records []agentRecord
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		var r agentRecord
+		if json.Unmarshal([]byte(line), &r) == nil {
+			records = append(records, r)
+		}
+	}
+	return records, out
+}
+
+// waitForInput waits until the agent in session has received input containing want.
+func waitForInput(t *testing.T, c *testutil.TmuxContainer, session, want string) {
+	t.Helper()
+	c.WaitFor(session+" to receive "+want, 60*time.Second, func() (bool, string) {
+		records, raw := agentLog(c, session)
+		for _, r := range records {
+			if r.Kind == "input" && strings.Contains(r.Input, want) {
+				return true, raw
+			}
+		}
+		return false, raw
+	})
+}
+
+func TestSessionCreation(t *testing.T) {
+	c := newTown(t)
+	c.MustExec("cd " + townRoot + " && gt crew add max --rig " + rigName + " && gt crew start " + rigName + " max")
+
+	session := prefix + "-crew-max"
+	c.MustExec("tmux has-session -t " + session)
+	c.WaitForPane(session, "gt-fake-agent ready", 30*time.Second)
+
+	records, raw := agentLog(c, session)
+	if len(records) == 0 || records[0].Kind != "start" {
+		t.Fatalf("no start record for %s:\n%s", session, raw)
+	}
+	env := records[0].Env
+	for key, want := range map[string]string{
+		"GT_ROLE":    rigName + "/crew/max",
+		"GT_RIG":     rigName,
+		"GT_CREW":    "max",
+		"GT_ROOT":    townRoot,
+		"GT_SESSION": session,
+	} {
+		if env[key] != want {
+			t.Errorf("%s = %q, want %q", key, env[key], want)
+		}
+	}
+}
+
+func TestMailNudgesRecipient(t *testing.T) {
+	c := newTown(t)
+	c.MustExec("cd " + townRoot + " && gt crew add max --rig " + rigName + " && gt crew start " + rigName + " max")
+	session := prefix + "-crew-max"
+	c.WaitForPane(session, "❯", 30*time.Second)
+
+	c.MustExec("cd " + townRoot + ` && gt mail send ` + rigName + `/crew/max -s "e2e ping" -m "are you there?"`)
+
+	waitForInput(t, c, session, "You have new mail")
+	waitForInput(t, c, session, "e2e ping")
+	if out := c.MustExec("cd " + townRoot + " && gt mail inbox " + rigName + "/crew/max"); !strings.Contains(out, "e2e ping") {
+		t.Errorf("inbox does not show the message:\n%s", out)
+	}
+}
+
+// slingBead creates a bead in the rig and slings it, returning the bead ID
+// and the spawned polecat's session name.
+func slingBead(t *testing.T, c *testutil.TmuxContainer) (string, string) {
+	t.Helper()
+	out := c.MustExec("cd " + townRoot + "/" + rigName + ` && bd create --json --title="e2e task" --description="write e2e.txt"`)
+	var issue struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal([]byte(out[strings.Index(out, "{"):]), &issue); err != nil || issue.ID == "" {
+		t.Fatalf("parsing bd create output: %v\n%s", err, out)
+	}
+
+	c.MustExec("cd " + townRoot + " && gt sling " + issue.ID + " " + rigName + " --no-boot")
+
+	var session string
+	c.WaitFor("polecat session", 60*time.Second, func() (bool, string) {
+		out, _ := c.Exec("tmux list-sessions -F '#S'")
+		for _, s := range strings.Fields(out) {
+			if strings.HasPrefix(s, prefix+"-") && !strings.Contains(s, "-crew-") &&
+				!strings.HasSuffix(s, "-witness") && !strings.HasSuffix(s, "-refinery") {
+				session = s
+				return true, out
+			}
+		}
+		return false, out
+	})
+	return issue.ID, session
+}
+
+func TestDispatch(t *testing.T) {
+	c := newTown(t)
+	beadID, session := slingBead(t, c)
+
+	c.WaitForPane(session, "gt-fake-agent ready", 30*time.Second)
+	records, raw := agentLog(c, session)
+	if len(records) == 0 || records[0].Env["GT_POLECAT"] == "" {
+		t.Fatalf("polecat started without GT_POLECAT:\n%s", raw)
+	}
+	polecat := records[0].Env["GT_POLECAT"]
+
+	out := c.MustExec("cd " + townRoot + "/" + rigName + " && bd show " + beadID + " --json")
+	if !strings.Contains(out, rigName+"/polecats/"+polecat) {
+		t.Errorf("bead %s not assigned to %s:\n%s", beadID, polecat, out)
+	}
+}
+
+func TestPolecatPreflightAndDone(t *testing.T) {
+	c := newTown(t)
+	beadID, session := slingBead(t, c)
+
+	// The on-start script runs gt done once the hook shows the bead; the
+	// branch it pushes is the postflight's visible result.
+	c.WaitFor("polecat branch on origin", 120*time.Second, func() (bool, string) {
+		out, _ := c.Exec("git --git-dir=/repos/app.git branch --list 'polecat/*'")
+		return strings.TrimSpace(out) != "", out
+	})
+	out := c.MustExec("git --git-dir=/repos/app.git log --all --format=%s")
+	if !strings.Contains(out, "e2e: change from") {
+		t.Errorf("polecat commit missing from origin:\n%s", out)
+	}
+
+	records, raw := agentLog(c, session)
+	for _, r := range records {
+		if r.Kind == "run" && r.Error != "" {
+			t.Errorf("on-start script failed (%s):\n%s", r.Error, raw)
+		}
+	}
+	if out, _ := c.Exec("cd " + townRoot + "/" + rigName + " && bd show " + beadID); strings.Contains(out, "hooked") {
+		t.Errorf("bead %s still hooked after gt done:\n%s", beadID, out)
+	}
+}
@@ -0,0 +1,195 @@
+// Command gt-fake-agent is a scripted stand-in for an agent TUI, used by the
+// end-to-end test suite (internal/e2e). It prints a banner and a ready prompt
+// like Claude Code does, records every line typed into it, and runs shell
+// commands when input matches rules from a script file — enough for gt to
+// start it, see it go idle, nudge it, and watch it call gt done.
+//
+// Script files hold one rule per line:
+//
+//	<regexp> => <shell command>
+//
+// Blank lines and lines starting with # are ignored. The first matching rule
+// wins; its command runs via sh -c with the matched input in $FAKE_INPUT.
+// A startup prompt passed as arguments (as gt does for Claude) is treated as
+// the first line of input.
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Rule maps input matching Pattern to a shell command.
+type Rule struct {
+	Pattern *regexp.Regexp
+	Command string
+}
+
+// Record is one line of the input log.
+type Record struct {
+	Time  time.Time         `json:"ts"`
+	Kind  string            `json:"kind"` // "start", "input", or "run"
+	Input string            `json:"input,omitempty"`
+	Env   map[string]string `json:"env,omitempty"`
+	Error string            `json:"error,omitempty"`
+}
+
+func main() {
+	prompt := flag.String("prompt", "❯ ", "ready prompt to print while idle")
+	logPath := flag.String("log", os.Getenv("GT_FAKE_AGENT_LOG"), "append input records (JSONL) to this file")
+	logDir := flag.String("log-dir", os.Getenv("GT_FAKE_AGENT_LOG_DIR"), "log to <dir>/<GT_SESSION>.jsonl when --log is unset")
+	scriptPath := flag.String("script", os.Getenv("GT_FAKE_AGENT_SCRIPT"), "rule file: <regexp> => <shell command>")
+	onStart := flag.String("on-start", os.Getenv("GT_FAKE_AGENT_ON_START"), "shell command to run once at startup")
+	flag.Parse()
+
+	var rules []Rule
+	if *scriptPath != "" {
+		f, err := os.Open(*scriptPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "gt-fake-agent: %v\n", err)
+			os.Exit(1)
+		}
+		rules, err = ParseScript(f)
+		_ = f.Close()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "gt-fake-agent: %s: %v\n", *scriptPath, err)
+			os.Exit(1)
+		}
+	}
+
+	if *logPath == "" && *logDir != "" {
+		name := os.Getenv("GT_SESSION")
+		if name == "" {
+			name = "agent"
+		}
+		*logPath = filepath.Join(*logDir, name+".jsonl")
+	}
+
+	a := &agent{prompt: *prompt, logPath: *logPath, rules: rules, out: os.Stdout}
+	a.run(os.Stdin, *onStart, strings.Join(flag.Args(), " "))
+}
+
+type agent struct {
+	prompt  string
+	logPath string
+	rules   []Rule
+	out     io.Writer
+}
+
+func (a *agent) run(in io.Reader, onStart, startPrompt string) {
+	fmt.Fprintln(a.out, "gt-fake-agent ready")
+	a.record(Record{Kind: "start", Env: gtEnv()})
+	if onStart != "" {
+		a.exec(onStart, "")
+	}
+	if startPrompt = strings.TrimSpace(startPrompt); startPrompt != "" {
+		a.handle(startPrompt)
+	}
+	fmt.Fprint(a.out, a.prompt)
+
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			a.handle(line)
+		}
+		fmt.Fprint(a.out, a.prompt)
+	}
+}
+
+// handle records one line of input and runs the first rule it matches.
+func (a *agent) handle(line string) {
+	a.record(Record{Kind: "input", Input: line})
+	if rule, ok := Match(a.rules, line); ok {
+		a.exec(rule.Command, line)
+	}
+}
+
+// exec runs a rule command, echoing its output into the pane.
+func (a *agent) exec(command, input string) {
+	cmd := exec.Command("sh", "-c", command)
+	cmd.Env = append(os.Environ(), "FAKE_INPUT="+input)
+	cmd.Stdout = a.out
+	cmd.Stderr = a.out
+	rec := Record{Kind: "run", Input: command}
+	if err := cmd.Run(); err != nil {
+		rec.Error = err.Error()
+	}
+	a.record(rec)
+}
+
+func (a *agent) record(r Record) {
+	if a.logPath == "" {
+		return
+	}
+	r.Time = time.Now().UTC()
+	data, err := json.Marshal(r)
+	if err != nil {
+		return
+	}
+	f, err := os.OpenFile(a.logPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	_, _ = f.Write(append(data, '\n'))
+}
+
+// ParseScript reads rules from a script file.
+func ParseScript(r io.Reader) ([]Rule, error) {
+	var rules []Rule
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		pattern, command, ok := strings.Cut(line, "=>")
+		if !ok {
+			return nil, fmt.Errorf("line %d: missing \"=>\"", lineNo)
+		}
+		pattern, command = strings.TrimSpace(pattern), strings.TrimSpace(command)
+		if command == "" {
+			return nil, fmt.Errorf("line %d: empty command", lineNo)
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+		rules = append(rules, Rule{Pattern: re, Command: command})
+	}
+	return rules, scanner.Err()
+}
+
+// Match returns the first rule whose pattern matches input.
+func Match(rules []Rule, input string) (Rule, bool) {
+	for _, r := range rules {
+		if r.Pattern.MatchString(input) {
+			return r, true
+		}
+	}
+	return Rule{}, false
+}
+
+// gtEnv returns the GT_* and BD_* variables the agent was started with, so
+// tests can check what gt put in the session environment.
+func gtEnv() map[string]string {
+	env := make(map[string]string)
+	for _, kv := range os.Environ() {
+		k, v, _ := strings.Cut(kv, "=")
+		if strings.HasPrefix(k, "GT_") || strings.HasPrefix(k, "BD_") {
+			env[k] = v
+		}
+	}
+	return env
+}
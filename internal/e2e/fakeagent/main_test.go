@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseScript(t *testing.T) {
+	script := `# comment
+
+You have new mail => gt mail inbox
+^gt done$ => gt done --status COMPLETED
+`
+	rules, err := ParseScript(strings.NewReader(script))
+	if err != nil {
+		t.Fatalf("ParseScript: %v", err)
+	}
+	if len(rules) != 2 {
+		t.Fatalf("got %d rules, want 2", len(rules))
+	}
+	if rules[1].Command != "gt done --status COMPLETED" {
+		t.Errorf("command = %q", rules[1].Command)
+	}
+
+	r, ok := Match(rules, "📬 You have new mail from mayor/. Subject: hi")
+	if !ok || r.Command != "gt mail inbox" {
+		t.Errorf("Match = %+v, %v", r, ok)
+	}
+	if _, ok := Match(rules, "please gt done now"); ok {
+		t.Error("anchored rule should not match")
+	}
+}
+
+func TestParseScriptErrors(t *testing.T) {
+	for _, script := range []string{"no arrow here", "foo =>", "([ => echo"} {
+		if _, err := ParseScript(strings.NewReader(script)); err == nil {
+			t.Errorf("ParseScript(%q) succeeded, want error", script)
+		}
+	}
+}
+
+func TestAgentRun(t *testing.T) {
+	dir := t.TempDir()
+	logPath := filepath.Join(dir, "agent.jsonl")
+	marker := filepath.Join(dir, "ran")
+	rules, err := ParseScript(strings.NewReader("^touch$ => touch " + marker))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	a := &agent{prompt: "> ", logPath: logPath, rules: rules, out: &out}
+	a.run(strings.NewReader("hello\n\ntouch\n"), "", "work on ap-1")
+
+	if _, err := os.Stat(marker); err != nil {
+		t.Errorf("rule command did not run: %v", err)
+	}
+	if got := strings.Count(out.String(), "> "); got != 4 {
+		t.Errorf("printed %d prompts, want 4:\n%s", got, out.String())
+	}
+
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var kinds []string
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		var r Record
+		if err := json.Unmarshal([]byte(line), &r); err != nil {
+			t.Fatalf("bad record %q: %v", line, err)
+		}
+		kinds = append(kinds, r.Kind+":"+r.Input)
+	}
+	want := "start:,input:work on ap-1,input:hello,input:touch,run:touch " + marker
+	if strings.Join(kinds, ",") != want {
+		t.Errorf("records = %v, want %s", kinds, want)
+	}
+}
@@ -0,0 +1,102 @@
+// Package errkind sorts errors into a few categories by what the user can do
+// about them, so the CLI can follow a failure with a remediation hint:
+//
+//	var ErrNoServer = errkind.New(errkind.Transport, "no tmux server running",
+//		"start the town with 'gt up'")
+//
+// Sentinels made with New still work with == and errors.Is. Existing error
+// types join in by implementing Kinded. Errors that aren't categorized get no
+// hint; that is the common case and fine.
+package errkind
+
+import "errors"
+
+// Kind is an error category.
+type Kind string
+
+// Error categories.
+const (
+	// Transport: another process couldn't be reached (the tmux server, the
+	// Dolt server, a socket or the network).
+	Transport Kind = "transport"
+
+	// Protocol: the other side answered, but not as expected (an agent TUI
+	// in an unexpected mode, a stalled prompt, malformed output).
+	Protocol Kind = "protocol"
+
+	// Config: town or rig settings, or the workspace layout, are missing or
+	// invalid.
+	Config Kind = "config"
+
+	// ExternalTool: a program gt shells out to (tmux, bd, git, dolt) is
+	// missing or failed.
+	ExternalTool Kind = "external-tool"
+)
+
+// Kinded is implemented by error types that know their category.
+type Kinded interface {
+	ErrorKind() Kind
+}
+
+// Error is a categorized error with an optional remediation hint.
+type Error struct {
+	Kind Kind
+	Err  error
+	Hint string // What to try, shown by the CLI (e.g., "run 'gt doctor --fix'")
+}
+
+func (e *Error) Error() string { return e.Err.Error() }
+
+// Unwrap returns the underlying error for errors.Is/As compatibility.
+func (e *Error) Unwrap() error { return e.Err }
+
+// ErrorKind implements Kinded.
+func (e *Error) ErrorKind() Kind { return e.Kind }
+
+// New returns a categorized sentinel error with the given message and hint.
+func New(kind Kind, msg, hint string) *Error {
+	return &Error{Kind: kind, Err: errors.New(msg), Hint: hint}
+}
+
+// Wrap categorizes err, keeping its message. Returns nil if err is nil.
+func Wrap(kind Kind, err error, hint string) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{Kind: kind, Err: err, Hint: hint}
+}
+
+// Of returns the category of the outermost categorized error in err's
+// chain, or "" if there is none.
+func Of(err error) Kind {
+	var k Kinded
+	if errors.As(err, &k) {
+		return k.ErrorKind()
+	}
+	return ""
+}
+
+// HintOf returns the hint of the outermost *Error in err's chain that has
+// one, or "". Outer errors are added closer to the caller and know more
+// about what was being attempted, so their hint wins.
+func HintOf(err error) string {
+	for err != nil {
+		if e, ok := err.(*Error); ok && e.Hint != "" {
+			return e.Hint
+		}
+		switch u := err.(type) {
+		case interface{ Unwrap() error }:
+			err = u.Unwrap()
+		case interface{ Unwrap() []error }:
+			for _, inner := range u.Unwrap() {
+				if h := HintOf(inner); h != "" {
+					return h
+				}
+			}
+			return ""
+		default:
+			return ""
+		}
+	}
+	return ""
+}
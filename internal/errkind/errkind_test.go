@@ -0,0 +1,56 @@
+package errkind
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// toolError is an existing error type that reports its own kind.
+type toolError struct{}
+
+func (toolError) Error() string   { return "tool failed" }
+func (toolError) ErrorKind() Kind { return ExternalTool }
+
+func TestNewSentinel(t *testing.T) {
+	errNoServer := New(Transport, "no server running", "start it")
+	wrapped := fmt.Errorf("listing sessions: %w", errNoServer)
+
+	if !errors.Is(wrapped, errNoServer) {
+		t.Error("errors.Is lost the sentinel")
+	}
+	if wrapped.Error() != "listing sessions: no server running" {
+		t.Errorf("Error() = %q", wrapped.Error())
+	}
+	if Of(wrapped) != Transport || HintOf(wrapped) != "start it" {
+		t.Errorf("Of, HintOf = %q, %q", Of(wrapped), HintOf(wrapped))
+	}
+}
+
+func TestOuterHintWins(t *testing.T) {
+	inner := New(Transport, "circuit open", "list circuits")
+	outer := Wrap(Transport, fmt.Errorf("nudge: %w", inner), "probe the session")
+	if HintOf(outer) != "probe the session" {
+		t.Errorf("HintOf = %q, want the outer hint", HintOf(outer))
+	}
+	// An outer error without a hint defers to the inner one.
+	if h := HintOf(Wrap(Protocol, inner, "")); h != "list circuits" {
+		t.Errorf("HintOf = %q, want the inner hint", h)
+	}
+}
+
+func TestOf(t *testing.T) {
+	if Of(fmt.Errorf("git merge: %w", toolError{})) != ExternalTool {
+		t.Error("Kinded error type not categorized")
+	}
+	if Of(errors.New("plain")) != "" || Of(nil) != "" || HintOf(nil) != "" {
+		t.Error("uncategorized errors should have no kind or hint")
+	}
+	if Wrap(Config, nil, "hint") != nil {
+		t.Error("Wrap(nil) != nil")
+	}
+	joined := errors.Join(errors.New("a"), New(Config, "b", "fix b"))
+	if Of(joined) != Config || HintOf(joined) != "fix b" {
+		t.Errorf("joined: Of, HintOf = %q, %q", Of(joined), HintOf(joined))
+	}
+}
@@ -0,0 +1,170 @@
+package events
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+)
+
+// Topics group event types by subject, for subscribers that care about a
+// kind of activity rather than individual types.
+const (
+	TopicAgent  = "agent"  // Agent sessions started, stopped, or died
+	TopicMail   = "mail"   // Mail sent
+	TopicNudge  = "nudge"  // Nudges delivered or failed
+	TopicHook   = "hook"   // Work hooked, unhooked, or slung
+	TopicMerge  = "merge"  // Branches merged or failed to merge
+	TopicConfig = "config" // Config files reloaded by a long-running process
+	TopicBead   = "bead"   // Beads created, changed, or closed
+)
+
+// topicTypes lists the event types in each topic.
+var topicTypes = map[string][]string{
+	TopicAgent:  {TypeSessionStart, TypeSessionEnd, TypeSpawn, TypeKill, TypeSessionDeath, TypeMassDeath, TypeCrashLoop, TypeStuckWorker, TypePaneAnomaly, TypePermissionAnswer},
+	TopicMail:   {TypeMail},
+	TopicNudge:  {TypeNudge, TypeNudgeFailed, TypeNudgeCircuit, TypePolecatNudged},
+	TopicHook:   {TypeHook, TypeUnhook, TypeSling, TypeHookDetached},
+	TopicMerge:  {TypeMergeStarted, TypeMerged, TypeMergeFailed, TypeMergeSkipped},
+	TopicConfig: {TypeConfigReloaded},
+	TopicBead:   {TypeBeadChanged},
+}
+
+// Topics returns the known topic names.
+func Topics() []string {
+	return []string{TopicAgent, TopicMail, TopicNudge, TopicHook, TopicMerge, TopicConfig, TopicBead}
+}
+
+// TopicTypes returns the event types in a topic, or nil if it is unknown.
+func TopicTypes(topic string) []string {
+	return topicTypes[topic]
+}
+
+// Filter selects events for a subscriber. The zero Filter matches every
+// event; otherwise an event must match one of Types or Topics (if either is
+// set) and Actor (if set).
+type Filter struct {
+	Types  []string // Event types, as path.Match patterns (e.g., "merge_*")
+	Topics []string // Topic names (TopicAgent, ...)
+	Actor  string   // Actor, as a path.Match pattern (e.g., "gastown/*")
+}
+
+// Match reports whether an event passes the filter.
+func (f Filter) Match(e Event) bool {
+	if f.Actor != "" {
+		if ok, _ := path.Match(f.Actor, e.Actor); !ok {
+			return false
+		}
+	}
+	if len(f.Types) == 0 && len(f.Topics) == 0 {
+		return true
+	}
+	for _, p := range f.Types {
+		if ok, _ := path.Match(p, e.Type); ok {
+			return true
+		}
+	}
+	for _, topic := range f.Topics {
+		for _, t := range topicTypes[topic] {
+			if t == e.Type {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ReadFrom reads up to limit complete events from an events log starting at
+// offset, returning them and the offset just past the last one read. A
+// trailing partial line (a write in progress) is left for the next call;
+// malformed lines are skipped.
+func ReadFrom(logPath string, offset int64, limit int) ([]Event, int64, error) {
+	f, err := os.Open(logPath) //nolint:gosec // G304: path is constructed internally
+	if err != nil {
+		return nil, offset, err
+	}
+	defer f.Close()
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return nil, offset, err
+	}
+
+	var evs []Event
+	r := bufio.NewReader(f)
+	for len(evs) < limit {
+		line, err := r.ReadBytes('\n')
+		if err == io.EOF {
+			break // Partial or no line: wait for the writer
+		}
+		if err != nil {
+			return evs, offset, err
+		}
+		offset += int64(len(line))
+		var e Event
+		if json.Unmarshal(line, &e) == nil && e.Type != "" {
+			evs = append(evs, e)
+		}
+	}
+	return evs, offset, nil
+}
+
+// followInterval is how often Follow polls the log for new events.
+// A var so tests can shorten it.
+var followInterval = 250 * time.Millisecond
+
+// followBatch bounds how many events Follow reads per poll.
+const followBatch = 1000
+
+// Follow tails the town's event log, calling fn for each matching event
+// appended by any gt process, until ctx is done. It starts at offset, or at
+// the end of the log if offset is negative. A log that shrinks (rotation or
+// pruning) is followed from its start.
+func Follow(ctx context.Context, townRoot string, f Filter, offset int64, fn func(Event)) error {
+	logPath := filepath.Join(townRoot, EventsFile)
+	if offset < 0 {
+		offset = 0
+		if info, err := os.Stat(logPath); err == nil {
+			offset = info.Size()
+		}
+	}
+
+	ticker := time.NewTicker(followInterval)
+	defer ticker.Stop()
+	for {
+		info, err := os.Stat(logPath)
+		switch {
+		case os.IsNotExist(err):
+			offset = 0
+		case err != nil:
+			return err
+		default:
+			if info.Size() < offset {
+				offset = 0
+			}
+			for info.Size() > offset {
+				evs, next, err := ReadFrom(logPath, offset, followBatch)
+				if err != nil {
+					return err
+				}
+				for _, e := range evs {
+					if f.Match(e) {
+						fn(e)
+					}
+				}
+				if next == offset {
+					break // Only a partial line remains
+				}
+				offset = next
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestFilterMatch(t *testing.T) {
+	merged := Event{Type: TypeMerged, Actor: "gastown/refinery"}
+	mail := Event{Type: TypeMail, Actor: "mayor"}
+
+	tests := []struct {
+		name   string
+		filter Filter
+		event  Event
+		want   bool
+	}{
+		{"zero matches all", Filter{}, mail, true},
+		{"type pattern", Filter{Types: []string{"merge*"}}, merged, true},
+		{"type miss", Filter{Types: []string{"merge*"}}, mail, false},
+		{"topic", Filter{Topics: []string{TopicMerge}}, merged, true},
+		{"topic miss", Filter{Topics: []string{TopicAgent}}, merged, false},
+		{"type or topic", Filter{Types: []string{TypeMail}, Topics: []string{TopicMerge}}, mail, true},
+		{"actor", Filter{Actor: "gastown/*"}, merged, true},
+		{"actor miss", Filter{Actor: "gastown/*", Topics: []string{TopicMail}}, mail, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Match(tt.event); got != tt.want {
+				t.Errorf("Match = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFollow(t *testing.T) {
+	old := followInterval
+	followInterval = 5 * time.Millisecond
+	defer func() { followInterval = old }()
+
+	townRoot := t.TempDir()
+	logPath := filepath.Join(townRoot, EventsFile)
+	appendLine := func(s string) {
+		t.Helper()
+		f, err := os.OpenFile(logPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+		if _, err := f.WriteString(s); err != nil {
+			t.Fatal(err)
+		}
+	}
+	line := func(e Event) string {
+		data, _ := json.Marshal(e)
+		return string(data) + "\n"
+	}
+	appendLine(line(Event{Type: TypeMerged, Actor: "old"})) // Before Follow: skipped
+
+	var mu sync.Mutex
+	var got []string
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- Follow(ctx, townRoot, Filter{Topics: []string{TopicMerge}}, -1, func(e Event) {
+			mu.Lock()
+			defer mu.Unlock()
+			got = append(got, e.Actor)
+		})
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	appendLine(line(Event{Type: TypeMail, Actor: "ignored"}))
+	appendLine(line(Event{Type: TypeMergeFailed, Actor: "a"}))
+	partial := line(Event{Type: TypeMerged, Actor: "b"})
+	appendLine(partial[:10]) // A write in progress is not read early
+	time.Sleep(20 * time.Millisecond)
+	appendLine(partial[10:])
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		mu.Lock()
+		n := len(got)
+		mu.Unlock()
+		if n >= 2 || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("Follow: %v", err)
+	}
+	if len(got) != 2 || got[0] != "a" || got[1] != "b" {
+		t.Errorf("followed %v, want [a b]", got)
+	}
+}
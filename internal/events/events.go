@@ -2,6 +2,9 @@
 //
 // Events are written to ~/gt/.events.jsonl (raw audit log) and later
 // curated by the feed daemon into ~/.feed.jsonl (user-facing).
+//
+// The log doubles as the town's event bus: Follow tails it and delivers
+// matching events from every gt process to a subscriber.
 package events
 
 import (
@@ -53,6 +56,7 @@ const (
 	// Session death events (for crash investigation)
 	TypeSessionDeath = "session_death" // Feed-visible session termination
 	TypeMassDeath    = "mass_death"    // Multiple sessions died in short window
+	TypeCrashLoop    = "crash_loop"    // Agent restarted too often; the daemon stopped restarting it
 
 	// Witness patrol events
 	TypePatrolStarted   = "patrol_started"
@@ -74,12 +78,32 @@ const (
 	TypeSchedulerDispatch       = "scheduler_dispatch"        // Bead dispatched from scheduler
 	TypeSchedulerDispatchFailed = "scheduler_dispatch_failed" // Bead dispatch failed (requeued)
 	TypeSchedulerCloseRetry     = "scheduler_close_retry"     // Context close needed last-resort attempt
+
+	// Health and lifecycle events (also fire webhook notifications)
+	TypeNudgeFailed      = "nudge_failed"      // Nudge delivery to a session failed
+	TypeNudgeCircuit     = "nudge_circuit"     // A session's nudge circuit opened or closed
+	TypeStuckWorker      = "stuck_worker"      // Live agent detected as stalled or hung
+	TypePaneAnomaly      = "pane_anomaly"      // Witness spotted looping, a stack trace, a waiting prompt, or a rate limit in a pane
+	TypePermissionAnswer = "permission_answer" // Witness answered an agent's permission prompt per rig policy
+	TypeMoleculeComplete = "molecule_complete" // Every step of a molecule closed
+	TypePreflightWarning = "preflight_warning" // gt done preflight found a problem
+	TypeHookDetached     = "hook_detached"     // Witness rule unhooked stale work and requeued it
+
+	// Operator events
+	TypeAnnotate = "annotate" // Operator note on an agent's timeline (gt annotate)
+
+	// Config events
+	TypeConfigReloaded = "config_reloaded" // A long-running process picked up config file changes
+
+	// Bead events
+	TypeBeadChanged = "bead_changed" // A bead was created, changed, closed, reopened, or deleted (bead watcher)
 )
 
 // EventsFile is the name of the raw events log.
 const EventsFile = ".events.jsonl"
 
-// Log writes an event to the events log.
+// Log writes an event to the events log, where Follow delivers it to
+// subscribers in any process.
 // The event is appended to ~/gt/.events.jsonl.
 // Returns nil if logging fails (events are best-effort).
 func Log(eventType, actor string, payload map[string]interface{}, visibility string) error {
@@ -368,3 +392,136 @@ func SchedulerDispatchFailedPayload(beadID, rig, errMsg string) map[string]inter
 		"error": errMsg,
 	}
 }
+
+// NudgeFailedPayload creates a payload for nudge delivery failures.
+func NudgeFailedPayload(session, errMsg string, consecutive int) map[string]interface{} {
+	return map[string]interface{}{
+		"session":     session,
+		"error":       errMsg,
+		"consecutive": consecutive,
+	}
+}
+
+// NudgeCircuitPayload creates a payload for a session's nudge circuit
+// opening ("open") or closing ("closed").
+func NudgeCircuitPayload(session, state, errMsg string, consecutive int) map[string]interface{} {
+	return map[string]interface{}{
+		"session":     session,
+		"state":       state,
+		"error":       errMsg,
+		"consecutive": consecutive,
+	}
+}
+
+// StuckWorkerPayload creates a payload for stuck worker detection.
+func StuckWorkerPayload(rig, worker, reason, detector string) map[string]interface{} {
+	return map[string]interface{}{
+		"rig":      rig,
+		"worker":   worker,
+		"reason":   reason,
+		"detector": detector,
+	}
+}
+
+// PaneAnomalyPayload creates a payload for an anomaly the witness found in
+// an agent's pane output. kind is one of looping, stack_trace,
+// permission_prompt, or rate_limit.
+func PaneAnomalyPayload(rig, agent, session, kind, detail string) map[string]interface{} {
+	return map[string]interface{}{
+		"rig":     rig,
+		"agent":   agent,
+		"session": session,
+		"kind":    kind,
+		"detail":  detail,
+	}
+}
+
+// PermissionAnswerPayload creates a payload for a permission prompt the
+// witness answered for an agent. answer is "yes" or "no".
+func PermissionAnswerPayload(rig, agent, tool, answer string) map[string]interface{} {
+	return map[string]interface{}{
+		"rig":    rig,
+		"agent":  agent,
+		"tool":   tool,
+		"answer": answer,
+	}
+}
+
+// BeadChangedPayload creates a payload for a bead mutation seen by the bead
+// watcher. change is created, updated, status, closed, reopened, or
+// deleted; prevStatus is set when the status changed.
+func BeadChangedPayload(source, bead, change, status, prevStatus, title, assignee string) map[string]interface{} {
+	p := map[string]interface{}{
+		"source": source,
+		"bead":   bead,
+		"change": change,
+		"status": status,
+		"title":  title,
+	}
+	if prevStatus != "" {
+		p["prev_status"] = prevStatus
+	}
+	if assignee != "" {
+		p["assignee"] = assignee
+	}
+	return p
+}
+
+// MoleculeCompletePayload creates a payload for molecule completion.
+func MoleculeCompletePayload(moleculeID string, steps int) map[string]interface{} {
+	return map[string]interface{}{
+		"molecule": moleculeID,
+		"steps":    steps,
+	}
+}
+
+// PreflightWarningPayload creates a payload for preflight warnings.
+func PreflightWarningPayload(beadID, branch, check, message string) map[string]interface{} {
+	return map[string]interface{}{
+		"bead":    beadID,
+		"branch":  branch,
+		"check":   check,
+		"message": message,
+	}
+}
+
+// CrashLoopPayload creates a payload for an agent detected as crash-looping.
+// restarts: restarts within the window; window: the crash-loop window (e.g., "15m0s").
+func CrashLoopPayload(agent string, restarts int, window string) map[string]interface{} {
+	return map[string]interface{}{
+		"agent":    agent,
+		"restarts": restarts,
+		"window":   window,
+	}
+}
+
+// HookDetachedPayload creates a payload for stale work detached from an agent.
+func HookDetachedPayload(beadID, agent, rule, reason string) map[string]interface{} {
+	return map[string]interface{}{
+		"bead":   beadID,
+		"agent":  agent,
+		"rule":   rule,
+		"reason": reason,
+	}
+}
+
+// AnnotatePayload creates a payload for operator annotations.
+func AnnotatePayload(agent, session, note, beadID string) map[string]interface{} {
+	p := map[string]interface{}{
+		"agent":   agent,
+		"session": session,
+		"note":    note,
+	}
+	if beadID != "" {
+		p["bead"] = beadID
+	}
+	return p
+}
+
+// ConfigReloadedPayload creates a payload for a config reload.
+func ConfigReloadedPayload(version int, files []string) map[string]interface{} {
+	return map[string]interface{}{
+		"version": version,
+		"files":   files,
+	}
+}
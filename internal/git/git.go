@@ -9,7 +9,11 @@ import (
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/errkind"
 )
 
 // GitError contains raw output from a git command for agent observation.
@@ -35,6 +39,11 @@ func (e *GitError) Unwrap() error {
 	return e.Err
 }
 
+// ErrorKind implements errkind.Kinded: git is an external tool.
+func (e *GitError) ErrorKind() errkind.Kind {
+	return errkind.ExternalTool
+}
+
 // moveDir moves a directory from src to dest. It first tries os.Rename for
 // efficiency, but falls back to copy+delete if src and dest are on different
 // filesystems (which causes EXDEV error on rename).
@@ -164,6 +173,73 @@ type cloneOptions struct {
 	singleBranch bool   // Pass --single-branch to git clone (only fetch default branch)
 	depth        int    // Pass --depth N to git clone (shallow clone); 0 means full history
 	branch       string // Pass --branch <name> to git clone (checkout specific branch)
+	filter       string   // Pass --filter=<spec> to git clone (partial clone)
+	sparse       []string // Check out only these directories (sparse-checkout, cone mode)
+}
+
+// CloneStrategy tunes how much of a large repository a rig clones and checks
+// out. The zero value is the default: a shallow (depth 1), single-branch
+// clone with every file checked out.
+type CloneStrategy struct {
+	// Depth is the commits of history to fetch: 0 for the default of 1,
+	// -1 for full history.
+	Depth int `json:"depth,omitempty"`
+
+	// Filter is a partial clone filter, e.g. "blob:none" (blobless: file
+	// contents are fetched on demand) or "tree:0" (treeless).
+	Filter string `json:"filter,omitempty"`
+
+	// Sparse lists the directories to check out (sparse-checkout in cone
+	// mode). Empty checks out everything.
+	Sparse []string `json:"sparse,omitempty"`
+}
+
+// Validate reports a depth below -1 or a sparse path that is absolute or
+// leaves the repository.
+func (s CloneStrategy) Validate() error {
+	if s.Depth < -1 {
+		return fmt.Errorf("invalid clone depth %d: must be positive, 0 (default), or -1 (full history)", s.Depth)
+	}
+	for _, p := range s.Sparse {
+		clean := filepath.ToSlash(filepath.Clean(p))
+		if p == "" || filepath.IsAbs(p) || clean == ".." || strings.HasPrefix(clean, "../") {
+			return fmt.Errorf("invalid sparse path %q: must be a directory inside the repository", p)
+		}
+	}
+	return nil
+}
+
+// String describes s for display, e.g. "full history, filter blob:none,
+// sparse api, web".
+func (s CloneStrategy) String() string {
+	var parts []string
+	switch {
+	case s.Depth == -1:
+		parts = append(parts, "full history")
+	case s.Depth > 0:
+		parts = append(parts, fmt.Sprintf("depth %d", s.Depth))
+	default:
+		parts = append(parts, "depth 1")
+	}
+	if s.Filter != "" {
+		parts = append(parts, "filter "+s.Filter)
+	}
+	if len(s.Sparse) > 0 {
+		parts = append(parts, "sparse "+strings.Join(s.Sparse, ", "))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// options returns the clone options for s: single-branch, at s's depth.
+func (s CloneStrategy) options() cloneOptions {
+	opts := cloneOptions{singleBranch: true, depth: 1, filter: s.Filter, sparse: s.Sparse}
+	switch {
+	case s.Depth > 0:
+		opts.depth = s.Depth
+	case s.Depth == -1:
+		opts.depth = 0
+	}
+	return opts
 }
 
 // cloneInternal runs `git clone` in an isolated temp directory, moves the result
@@ -203,6 +279,13 @@ func (g *Git) cloneInternal(url, dest string, opts cloneOptions) error {
 	if opts.branch != "" {
 		args = append(args, "--branch", opts.branch)
 	}
+	if opts.filter != "" {
+		args = append(args, "--filter="+opts.filter)
+	}
+	if len(opts.sparse) > 0 && !opts.bare {
+		// Check out only top-level files; the sparse paths follow below.
+		args = append(args, "--sparse")
+	}
 	if opts.reference != "" {
 		args = append(args, "--reference-if-able", opts.reference)
 	}
@@ -228,7 +311,12 @@ func (g *Git) cloneInternal(url, dest string, opts cloneOptions) error {
 		// Configure refspec so worktrees can fetch and see origin/* refs.
 		// For single-branch shallow clones, only set the config without
 		// fetching all branches (which would defeat the purpose of --single-branch).
-		return configureRefspec(dest, opts.singleBranch)
+		return configureRefspec(dest, opts.singleBranch, opts.depth)
+	}
+	if len(opts.sparse) > 0 {
+		if _, err := NewGit(dest).run(append([]string{"sparse-checkout", "set", "--cone", "--"}, opts.sparse...)...); err != nil {
+			return fmt.Errorf("configuring sparse checkout: %w", err)
+		}
 	}
 	// Configure hooks path for Gas Town clones
 	if err := configureHooksPath(dest); err != nil {
@@ -268,6 +356,25 @@ func (g *Git) CloneBare(url, dest string) error {
 	return g.cloneInternal(url, dest, cloneOptions{bare: true, singleBranch: true, depth: 1})
 }
 
+// CloneBareWithStrategy clones a bare repo with a rig's clone strategy
+// (depth and partial clone filter; sparse paths apply to checkouts only).
+// reference, if not empty, is a local repo to borrow objects from.
+func (g *Git) CloneBareWithStrategy(url, dest, reference string, s CloneStrategy) error {
+	opts := s.options()
+	opts.bare = true
+	opts.reference = reference
+	return g.cloneInternal(url, dest, opts)
+}
+
+// CloneBranchWithStrategy clones branch with a rig's clone strategy.
+// reference, if not empty, is a local repo to borrow objects from.
+func (g *Git) CloneBranchWithStrategy(url, dest, branch, reference string, s CloneStrategy) error {
+	opts := s.options()
+	opts.branch = branch
+	opts.reference = reference
+	return g.cloneInternal(url, dest, opts)
+}
+
 // configureHooksPath sets core.hooksPath to use the repo's .githooks directory
 // if it exists. This ensures Gas Town agents use the pre-push hook that blocks
 // pushes to non-main branches (internal PRs are not allowed).
@@ -300,8 +407,9 @@ func (g *Git) ConfigureHooksPath() error {
 //
 // When singleBranch is true, fetches only the default branch's ref instead of all
 // branches. This prevents failures on repos with many branches where a full fetch
-// would error with "some local refs could not be updated".
-func configureRefspec(repoPath string, singleBranch bool) error {
+// would error with "some local refs could not be updated". depth is the clone's
+// depth, kept for that fetch; 0 means full history.
+func configureRefspec(repoPath string, singleBranch bool, depth int) error {
 	gitDir := repoPath
 	if _, err := os.Stat(filepath.Join(repoPath, ".git")); err == nil {
 		gitDir = filepath.Join(repoPath, ".git")
@@ -315,6 +423,11 @@ func configureRefspec(repoPath string, singleBranch bool) error {
 		return fmt.Errorf("configuring refspec: %s", strings.TrimSpace(stderr.String()))
 	}
 
+	fetchArgs := []string{"--git-dir", gitDir, "fetch"}
+	if depth > 0 {
+		fetchArgs = append(fetchArgs, "--depth", strconv.Itoa(depth))
+	}
+
 	if singleBranch {
 		// For shallow single-branch clones, fetch only the HEAD branch to create
 		// the origin/<branch> ref that worktrees need. A full `git fetch origin`
@@ -328,7 +441,7 @@ func configureRefspec(repoPath string, singleBranch bool) error {
 		headCmd.Stderr = &stderr
 		if err := headCmd.Run(); err != nil {
 			// Fallback: if HEAD is detached, try fetching all (shouldn't happen for clones)
-			fetchCmd := exec.Command("git", "--git-dir", gitDir, "fetch", "--depth", "1", "origin")
+			fetchCmd := exec.Command("git", append(fetchArgs, "origin")...)
 			fetchCmd.Stderr = &stderr
 			if fetchErr := fetchCmd.Run(); fetchErr != nil {
 				return fmt.Errorf("fetching origin: %s", strings.TrimSpace(stderr.String()))
@@ -339,7 +452,7 @@ func configureRefspec(repoPath string, singleBranch bool) error {
 		branch := strings.TrimPrefix(headRef, "refs/heads/")  // e.g. "main"
 		refspec := branch + ":refs/remotes/origin/" + branch   // e.g. "main:refs/remotes/origin/main"
 
-		fetchCmd := exec.Command("git", "--git-dir", gitDir, "fetch", "--depth", "1", "origin", refspec)
+		fetchCmd := exec.Command("git", append(fetchArgs, "origin", refspec)...)
 		fetchCmd.Stderr = &stderr
 		if err := fetchCmd.Run(); err != nil {
 			return fmt.Errorf("fetching origin %s: %s", branch, strings.TrimSpace(stderr.String()))
@@ -729,6 +842,19 @@ func (g *Git) RecentCommits(n int) (string, error) {
 	return g.run("log", "--oneline", fmt.Sprintf("-%d", n))
 }
 
+// LastCommitTime returns the committer time of HEAD.
+func (g *Git) LastCommitTime() (time.Time, error) {
+	out, err := g.run("log", "-1", "--format=%ct")
+	if err != nil {
+		return time.Time{}, err
+	}
+	secs, err := strconv.ParseInt(strings.TrimSpace(out), 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parsing commit time %q: %w", out, err)
+	}
+	return time.Unix(secs, 0), nil
+}
+
 // DeleteRemoteBranch deletes a branch on the remote.
 func (g *Git) DeleteRemoteBranch(remote, branch string) error {
 	_, err := g.run("push", remote, "--delete", branch)
@@ -825,6 +951,88 @@ func (g *Git) CheckConflicts(source, target string) ([]string, error) {
 	return nil, nil
 }
 
+// Limits on how much of a conflict CheckConflictDetails reports per file.
+const (
+	maxConflictHunksPerFile = 5
+	maxConflictHunkLines    = 40
+)
+
+// ConflictFile is a file that conflicts in a test merge, with the conflict
+// hunks (the <<<<<<< ... >>>>>>> regions git wrote into it).
+type ConflictFile struct {
+	Path  string
+	Hunks []string
+}
+
+// CheckConflictDetails is CheckConflicts with the conflict hunks of each
+// file: it test-merges source into target, reads the conflict regions from
+// the working tree, and aborts the merge. Returns nil if the merge is clean.
+func (g *Git) CheckConflictDetails(source, target string) ([]ConflictFile, error) {
+	if err := g.Checkout(target); err != nil {
+		return nil, fmt.Errorf("checkout target %s: %w", target, err)
+	}
+
+	_, mergeErr := g.runMergeCheck("merge", "--no-commit", "--no-ff", source)
+	if mergeErr == nil {
+		_, _ = g.run("reset", "--hard", "HEAD")
+		return nil, nil
+	}
+	defer func() { _ = g.AbortMerge() }()
+
+	files, err := g.GetConflictDetails()
+	if err != nil || len(files) == 0 {
+		return nil, mergeErr
+	}
+	return files, nil
+}
+
+// GetConflictDetails returns the files with merge conflicts in the current
+// (stopped) merge, with their conflict hunks.
+func (g *Git) GetConflictDetails() ([]ConflictFile, error) {
+	paths, err := g.GetConflictingFiles()
+	if err != nil {
+		return nil, err
+	}
+	files := make([]ConflictFile, 0, len(paths))
+	for _, path := range paths {
+		cf := ConflictFile{Path: path}
+		// Binary files and delete/modify conflicts have no markers to show.
+		if data, readErr := os.ReadFile(filepath.Join(g.workDir, path)); readErr == nil {
+			cf.Hunks = conflictHunks(string(data))
+		}
+		files = append(files, cf)
+	}
+	return files, nil
+}
+
+// conflictHunks extracts the conflict marker regions from a file's content,
+// keeping at most maxConflictHunksPerFile hunks of maxConflictHunkLines lines.
+func conflictHunks(content string) []string {
+	var hunks []string
+	var cur []string
+	for _, line := range strings.Split(content, "\n") {
+		if cur == nil {
+			if strings.HasPrefix(line, "<<<<<<< ") {
+				cur = []string{line}
+			}
+			continue
+		}
+		cur = append(cur, line)
+		if !strings.HasPrefix(line, ">>>>>>> ") {
+			continue
+		}
+		if len(cur) > maxConflictHunkLines {
+			cur = append(append(cur[:maxConflictHunkLines-2:maxConflictHunkLines-2], "..."), line)
+		}
+		hunks = append(hunks, strings.Join(cur, "\n"))
+		cur = nil
+		if len(hunks) == maxConflictHunksPerFile {
+			break
+		}
+	}
+	return hunks
+}
+
 // runMergeCheck runs a git merge command and returns error info from both stdout and stderr.
 // ZFC: Returns GitError with raw output for agent observation.
 func (g *Git) runMergeCheck(args ...string) (string, error) {
@@ -996,6 +1204,23 @@ func (g *Git) ListBranches(pattern string) ([]string, error) {
 	return strings.Split(out, "\n"), nil
 }
 
+// ListRemoteBranches lists the remote-tracking branches of remote matching
+// pattern (e.g. "integration/*"), without the "<remote>/" prefix.
+func (g *Git) ListRemoteBranches(remote, pattern string) ([]string, error) {
+	out, err := g.run("branch", "-r", "--list", "--format=%(refname:short)", remote+"/"+pattern)
+	if err != nil {
+		return nil, err
+	}
+	if out == "" {
+		return nil, nil
+	}
+	var branches []string
+	for _, ref := range strings.Split(out, "\n") {
+		branches = append(branches, strings.TrimPrefix(ref, remote+"/"))
+	}
+	return branches, nil
+}
+
 // ResetBranch force-updates a branch to point to a ref.
 // This is useful for resetting stale polecat branches to main.
 // NOTE: This uses `git branch -f` which fails on the currently checked-out branch.
@@ -1017,6 +1242,17 @@ func (g *Git) Rev(ref string) (string, error) {
 	return g.run("rev-parse", ref)
 }
 
+// Diff returns the changes head makes since it diverged from base
+// (git diff base...head).
+func (g *Git) Diff(base, head string) (string, error) {
+	return g.run("diff", base+"..."+head)
+}
+
+// DiffStat returns the per-file summary of Diff(base, head).
+func (g *Git) DiffStat(base, head string) (string, error) {
+	return g.run("diff", "--stat", base+"..."+head)
+}
+
 // IsAncestor checks if ancestor is an ancestor of descendant.
 func (g *Git) IsAncestor(ancestor, descendant string) (bool, error) {
 	_, err := g.run("merge-base", "--is-ancestor", ancestor, descendant)
@@ -1141,6 +1377,8 @@ type Worktree struct {
 	Path   string
 	Branch string
 	Commit string
+	Bare   bool // The repository itself, when it is bare
+	Locked bool // Locked with git worktree lock
 }
 
 // WorktreeList returns all worktrees for this repository.
@@ -1169,6 +1407,10 @@ func (g *Git) WorktreeList() ([]Worktree, error) {
 			current.Commit = strings.TrimPrefix(line, "HEAD ")
 		case strings.HasPrefix(line, "branch "):
 			current.Branch = strings.TrimPrefix(line, "branch refs/heads/")
+		case line == "bare":
+			current.Bare = true
+		case line == "locked" || strings.HasPrefix(line, "locked "):
+			current.Locked = true
 		}
 	}
 
@@ -1218,6 +1460,33 @@ func (g *Git) BranchCreatedDate(branch string) (string, error) {
 	return out, nil
 }
 
+// BranchCreatedTime returns the committer time of the first commit on
+// branch that isn't on base, or of the branch tip if there is none.
+func (g *Git) BranchCreatedTime(base, branch string) (time.Time, error) {
+	if mergeBase, err := g.run("merge-base", base, branch); err == nil {
+		out, err := g.run("log", "--format=%ct", "--reverse", mergeBase+".."+branch)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if first, _, _ := strings.Cut(out, "\n"); first != "" {
+			return parseUnixTime(first)
+		}
+	}
+	out, err := g.run("log", "-1", "--format=%ct", branch)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return parseUnixTime(out)
+}
+
+func parseUnixTime(s string) (time.Time, error) {
+	secs, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parsing commit time %q: %w", s, err)
+	}
+	return time.Unix(secs, 0), nil
+}
+
 // CommitsAhead returns the number of commits that branch has ahead of base.
 // For example, CommitsAhead("main", "feature") returns how many commits
 // are on feature that are not on main.
@@ -1236,6 +1505,60 @@ func (g *Git) CommitsAhead(base, branch string) (int, error) {
 	return count, nil
 }
 
+// CommitsNotOnRemotes returns the number of commits on HEAD that no
+// remote-tracking branch contains: work that exists only in this repo.
+// Unlike UnpushedCommits it needs no upstream, so it covers polecat branches.
+func (g *Git) CommitsNotOnRemotes() (int, error) {
+	out, err := g.run("rev-list", "--count", "HEAD", "--not", "--remotes")
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	_, err = fmt.Sscanf(out, "%d", &count)
+	if err != nil {
+		return 0, fmt.Errorf("parsing commit count: %w", err)
+	}
+
+	return count, nil
+}
+
+// AheadBehind returns how many commits head has that base doesn't (ahead),
+// and how many base has that head doesn't (behind).
+func (g *Git) AheadBehind(base, head string) (ahead, behind int, err error) {
+	out, err := g.run("rev-list", "--left-right", "--count", base+"..."+head)
+	if err != nil {
+		return 0, 0, err
+	}
+	if _, err := fmt.Sscanf(out, "%d\t%d", &behind, &ahead); err != nil {
+		return 0, 0, fmt.Errorf("parsing ahead/behind counts %q: %w", out, err)
+	}
+	return ahead, behind, nil
+}
+
+// Upstream returns the upstream of the current branch (e.g. "origin/main"),
+// or "" if it has none or HEAD is detached.
+func (g *Git) Upstream() string {
+	out, err := g.run("rev-parse", "--abbrev-ref", "--symbolic-full-name", "@{upstream}")
+	if err != nil {
+		return ""
+	}
+	return out
+}
+
+// ChangedFiles returns the files changed on branch since it diverged from
+// base (git diff --name-only base...branch).
+func (g *Git) ChangedFiles(base, branch string) ([]string, error) {
+	out, err := g.run("diff", "--name-only", base+"..."+branch)
+	if err != nil {
+		return nil, err
+	}
+	if out == "" {
+		return nil, nil
+	}
+	return strings.Split(out, "\n"), nil
+}
+
 // CountCommitsBehind returns the number of commits that HEAD is behind the given ref.
 // For example, CountCommitsBehind("origin/main") returns how many commits
 // are on origin/main that are not on the current HEAD.
@@ -4,8 +4,10 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 )
 
 func initTestRepo(t *testing.T) string {
@@ -159,6 +161,19 @@ func TestCurrentBranch(t *testing.T) {
 	}
 }
 
+func TestLastCommitTime(t *testing.T) {
+	dir := initTestRepo(t)
+	g := NewGit(dir)
+
+	got, err := g.LastCommitTime()
+	if err != nil {
+		t.Fatalf("LastCommitTime: %v", err)
+	}
+	if age := time.Since(got); age < 0 || age > time.Hour {
+		t.Errorf("LastCommitTime = %v, want about now", got)
+	}
+}
+
 func TestStatus(t *testing.T) {
 	dir := initTestRepo(t)
 	g := NewGit(dir)
@@ -485,6 +500,83 @@ func TestCheckConflicts_WithConflict(t *testing.T) {
 	}
 }
 
+func TestCheckConflictDetails(t *testing.T) {
+	dir := initTestRepo(t)
+	g := NewGit(dir)
+	mainBranch, _ := g.CurrentBranch()
+	readmeFile := filepath.Join(dir, "README.md")
+
+	commitReadme := func(content, msg string) {
+		t.Helper()
+		if err := os.WriteFile(readmeFile, []byte(content), 0644); err != nil {
+			t.Fatalf("write file: %v", err)
+		}
+		if err := g.Add("README.md"); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+		if err := g.Commit(msg); err != nil {
+			t.Fatalf("Commit: %v", err)
+		}
+	}
+
+	if err := g.CreateBranch("feature"); err != nil {
+		t.Fatalf("CreateBranch: %v", err)
+	}
+	if err := g.Checkout("feature"); err != nil {
+		t.Fatalf("Checkout feature: %v", err)
+	}
+	commitReadme("# Feature changes\n", "modify readme on feature")
+	if err := g.Checkout(mainBranch); err != nil {
+		t.Fatalf("Checkout main: %v", err)
+	}
+	commitReadme("# Main changes\n", "modify readme on main")
+
+	files, err := g.CheckConflictDetails("feature", mainBranch)
+	if err != nil {
+		t.Fatalf("CheckConflictDetails: %v", err)
+	}
+	if len(files) != 1 || files[0].Path != "README.md" {
+		t.Fatalf("expected README.md to conflict, got %+v", files)
+	}
+	if len(files[0].Hunks) != 1 {
+		t.Fatalf("expected 1 hunk, got %d", len(files[0].Hunks))
+	}
+	hunk := files[0].Hunks[0]
+	if !strings.Contains(hunk, "# Main changes") || !strings.Contains(hunk, "# Feature changes") {
+		t.Errorf("hunk should show both sides, got:\n%s", hunk)
+	}
+
+	status, _ := g.Status()
+	if !status.Clean {
+		t.Error("expected clean working directory after CheckConflictDetails")
+	}
+}
+
+func TestConflictHunks(t *testing.T) {
+	var long strings.Builder
+	long.WriteString("<<<<<<< HEAD\n")
+	for i := 0; i < maxConflictHunkLines*2; i++ {
+		long.WriteString("ours\n")
+	}
+	long.WriteString("=======\ntheirs\n>>>>>>> feature\n")
+
+	content := "a\n<<<<<<< HEAD\nx\n=======\ny\n>>>>>>> feature\nb\n" + long.String()
+	hunks := conflictHunks(content)
+	if len(hunks) != 2 {
+		t.Fatalf("expected 2 hunks, got %d", len(hunks))
+	}
+	if hunks[0] != "<<<<<<< HEAD\nx\n=======\ny\n>>>>>>> feature" {
+		t.Errorf("hunk 0 = %q", hunks[0])
+	}
+	lines := strings.Split(hunks[1], "\n")
+	if len(lines) != maxConflictHunkLines {
+		t.Errorf("long hunk has %d lines, want %d", len(lines), maxConflictHunkLines)
+	}
+	if lines[len(lines)-2] != "..." || lines[len(lines)-1] != ">>>>>>> feature" {
+		t.Errorf("long hunk should end with an ellipsis and the closing marker, got %q", lines[len(lines)-2:])
+	}
+}
+
 // TestCloneBareHasOriginRefs verifies that after CloneBare, origin/* refs
 // are available for worktree creation. This was broken before the fix:
 // bare clones had refspec configured but no fetch was run, so origin/main
@@ -1758,3 +1850,104 @@ func TestCheckBranchContamination(t *testing.T) {
 		t.Errorf("Ahead (from main) = %d, want 5", contam.Ahead)
 	}
 }
+
+func TestBranchCreatedTime(t *testing.T) {
+	dir := initTestRepo(t)
+	g := NewGit(dir)
+	mainBranch, _ := g.CurrentBranch()
+
+	if err := g.CreateBranch("integration/epic"); err != nil {
+		t.Fatalf("CreateBranch: %v", err)
+	}
+	if err := g.Checkout("integration/epic"); err != nil {
+		t.Fatalf("Checkout: %v", err)
+	}
+	for i, date := range []string{"2026-01-02T10:00:00Z", "2026-01-05T10:00:00Z"} {
+		name := "f" + strconv.Itoa(i) + ".txt"
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("x\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := g.Add(name); err != nil {
+			t.Fatal(err)
+		}
+		cmd := exec.Command("git", "commit", "-m", name)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "GIT_COMMITTER_DATE="+date)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("commit: %v\n%s", err, out)
+		}
+	}
+
+	got, err := g.BranchCreatedTime(mainBranch, "integration/epic")
+	if err != nil {
+		t.Fatalf("BranchCreatedTime: %v", err)
+	}
+	want := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	if !got.Equal(want) {
+		t.Errorf("BranchCreatedTime() = %v, want %v (first commit on the branch)", got, want)
+	}
+}
+
+func TestCloneWithStrategy(t *testing.T) {
+	remote := initTestRepo(t)
+	rg := NewGit(remote)
+	for i, f := range []string{"api/server.go", "web/app.js"} {
+		if err := os.MkdirAll(filepath.Join(remote, filepath.Dir(f)), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(remote, f), []byte("x\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := rg.Add("."); err != nil {
+			t.Fatal(err)
+		}
+		if err := rg.Commit("commit " + strconv.Itoa(i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	branch, err := rg.CurrentBranch()
+	if err != nil {
+		t.Fatal(err)
+	}
+	url := "file://" + filepath.ToSlash(remote)
+	tmp := t.TempDir()
+	g := NewGit(tmp)
+
+	// Default strategy: shallow.
+	bare := filepath.Join(tmp, "bare.git")
+	if err := g.CloneBareWithStrategy(url, bare, "", CloneStrategy{}); err != nil {
+		t.Fatalf("CloneBareWithStrategy: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(bare, "shallow")); err != nil {
+		t.Errorf("default strategy should clone shallow: %v", err)
+	}
+
+	// Full history, sparse checkout.
+	dest := filepath.Join(tmp, "mayor")
+	s := CloneStrategy{Depth: -1, Sparse: []string{"api"}}
+	if err := g.CloneBranchWithStrategy(url, dest, branch, "", s); err != nil {
+		t.Fatalf("CloneBranchWithStrategy: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dest, ".git", "shallow")); !os.IsNotExist(err) {
+		t.Errorf("depth -1 should clone full history, shallow stat err = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dest, "api", "server.go")); err != nil {
+		t.Errorf("sparse path should be checked out: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dest, "web")); !os.IsNotExist(err) {
+		t.Errorf("web/ should not be checked out, stat err = %v", err)
+	}
+}
+
+func TestCloneStrategyValidate(t *testing.T) {
+	for _, s := range []CloneStrategy{{}, {Depth: 50, Filter: "blob:none", Sparse: []string{"api", "web/app"}}, {Depth: -1}} {
+		if err := s.Validate(); err != nil {
+			t.Errorf("Validate(%+v) = %v, want nil", s, err)
+		}
+	}
+	for _, s := range []CloneStrategy{{Depth: -2}, {Sparse: []string{"/abs"}}, {Sparse: []string{"../up"}}, {Sparse: []string{""}}} {
+		if err := s.Validate(); err == nil {
+			t.Errorf("Validate(%+v) = nil, want error", s)
+		}
+	}
+}
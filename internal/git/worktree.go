@@ -0,0 +1,263 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+)
+
+// polecatBranchPrefix is the prefix of every polecat work branch.
+const polecatBranchPrefix = "polecat/"
+
+// PolecatIdleBranch is the bead segment of the branch a polecat sits on
+// while it has no work hooked (e.g. right after pool-init).
+const PolecatIdleBranch = "idle"
+
+// PolecatBranchName returns the branch a polecat works a bead on:
+// polecat/<rig>/<name>/<bead-id>. With no bead it returns the polecat's idle
+// branch, polecat/<rig>/<name>/idle.
+//
+// The name is stable per polecat and bead, so a polecat that comes back to
+// a bead (after a repair, or a re-sling of the same bead) resumes the branch
+// instead of forking a second one.
+func PolecatBranchName(rig, polecat, beadID string) string {
+	if beadID == "" {
+		beadID = PolecatIdleBranch
+	}
+	return polecatBranchPrefix + rig + "/" + polecat + "/" + beadID
+}
+
+// ParsePolecatBranch splits a branch that follows the polecat naming
+// convention into its rig, polecat, and bead ID. beadID is "" for an idle
+// branch. ok is false for any other branch, including the older
+// polecat/<name>-<timestamp> and polecat/<name>/<issue>@<timestamp> forms.
+func ParsePolecatBranch(branch string) (rig, polecat, beadID string, ok bool) {
+	rest, found := strings.CutPrefix(branch, polecatBranchPrefix)
+	if !found {
+		return "", "", "", false
+	}
+	parts := strings.Split(rest, "/")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return "", "", "", false
+	}
+	if strings.Contains(parts[2], "@") {
+		return "", "", "", false
+	}
+	beadID = parts[2]
+	if beadID == PolecatIdleBranch {
+		beadID = ""
+	}
+	return parts[0], parts[1], beadID, true
+}
+
+// PolecatWorktree is a worktree (or a bare branch, if Path is empty) that
+// follows the polecat naming convention.
+type PolecatWorktree struct {
+	Path    string // Worktree path, "" for a branch not checked out anywhere
+	Branch  string
+	Polecat string
+	BeadID  string // "" for an idle branch
+}
+
+// WorktreeManager gives each polecat of a rig its own worktree of the rig's
+// shared repo and keeps polecat branches on the convention
+// polecat/<rig>/<name>/<bead-id> (see PolecatBranchName).
+type WorktreeManager struct {
+	repo   *Git
+	rig    string
+	sparse []string // Directories to check out; empty checks out everything
+}
+
+// NewWorktreeManager returns a WorktreeManager for the polecats of rig,
+// whose worktrees hang off repo (the rig's .repo.git or mayor clone).
+func NewWorktreeManager(repo *Git, rig string) *WorktreeManager {
+	return &WorktreeManager{repo: repo, rig: rig}
+}
+
+// SetSparse makes new worktrees check out only paths (sparse-checkout in
+// cone mode), per the rig's clone strategy. Empty checks out everything.
+func (w *WorktreeManager) SetSparse(paths []string) {
+	w.sparse = paths
+}
+
+// BranchName returns the branch polecat works beadID on.
+func (w *WorktreeManager) BranchName(polecat, beadID string) string {
+	return PolecatBranchName(w.rig, polecat, beadID)
+}
+
+// Add creates a worktree at path on branch.
+//
+// For a convention bead branch that already exists, the worktree resumes it.
+// An idle branch is reset to startPoint. Either may still be checked out in
+// the polecat's old worktree during a repair, so both are forced. Any other
+// branch (e.g. from a custom polecat_branch_template) is created fresh from
+// startPoint, as before. With sparse paths set, only those are checked out.
+// Skips LFS smudge filter during checkout (see WorktreeAddFromRef).
+func (w *WorktreeManager) Add(path, branch, startPoint string) error {
+	args, err := w.addArgs(path, branch, startPoint)
+	if err != nil {
+		return err
+	}
+	if len(w.sparse) > 0 {
+		args = append([]string{"worktree", "add", "--no-checkout"}, args[2:]...)
+	}
+	if _, err := w.repo.runWithEnv(args, []string{"GIT_LFS_SKIP_SMUDGE=1"}); err != nil {
+		return err
+	}
+	if len(w.sparse) > 0 {
+		wt := NewGit(path)
+		if _, err := wt.run(append([]string{"sparse-checkout", "set", "--cone", "--"}, w.sparse...)...); err != nil {
+			return fmt.Errorf("configuring sparse checkout: %w", err)
+		}
+		// --no-checkout left the index empty; populate the sparse paths.
+		if _, err := wt.runWithEnv([]string{"read-tree", "-mu", "HEAD"}, []string{"GIT_LFS_SKIP_SMUDGE=1"}); err != nil {
+			return fmt.Errorf("checking out sparse paths: %w", err)
+		}
+	}
+	return InitSubmodules(path)
+}
+
+func (w *WorktreeManager) addArgs(path, branch, startPoint string) ([]string, error) {
+	_, _, beadID, ok := ParsePolecatBranch(branch)
+	if !ok {
+		return []string{"worktree", "add", "-b", branch, path, startPoint}, nil
+	}
+	if beadID == "" {
+		return []string{"worktree", "add", "--force", "-B", branch, path, startPoint}, nil
+	}
+	exists, err := w.repo.BranchExists(branch)
+	if err != nil {
+		return nil, fmt.Errorf("checking branch %s: %w", branch, err)
+	}
+	if exists {
+		return []string{"worktree", "add", "--force", path, branch}, nil
+	}
+	return []string{"worktree", "add", "-b", branch, path, startPoint}, nil
+}
+
+// Checkout switches the existing worktree at path to branch, with the same
+// resume/reset rules as Add.
+func (w *WorktreeManager) Checkout(path, branch, startPoint string) error {
+	wt := NewGit(path)
+	_, _, beadID, ok := ParsePolecatBranch(branch)
+	switch {
+	case !ok:
+		return wt.CheckoutNewBranch(branch, startPoint)
+	case beadID == "":
+		_, err := wt.run("checkout", "-B", branch, startPoint)
+		return err
+	}
+	exists, err := wt.BranchExists(branch)
+	if err != nil {
+		return fmt.Errorf("checking branch %s: %w", branch, err)
+	}
+	if exists {
+		return wt.Checkout(branch)
+	}
+	return wt.CheckoutNewBranch(branch, startPoint)
+}
+
+// List returns the rig's convention polecat branches, with the worktree each
+// is checked out in, if any.
+func (w *WorktreeManager) List() ([]PolecatWorktree, error) {
+	worktrees, err := w.repo.WorktreeList()
+	if err != nil {
+		return nil, fmt.Errorf("listing worktrees: %w", err)
+	}
+	checkedOut := make(map[string]string, len(worktrees))
+	for _, wt := range worktrees {
+		if wt.Branch != "" {
+			checkedOut[wt.Branch] = wt.Path
+		}
+	}
+
+	branches, err := w.repo.ListBranches(polecatBranchPrefix + w.rig + "/*")
+	if err != nil {
+		return nil, fmt.Errorf("listing branches: %w", err)
+	}
+	var result []PolecatWorktree
+	for _, branch := range branches {
+		rig, polecat, beadID, ok := ParsePolecatBranch(branch)
+		if !ok || rig != w.rig {
+			continue
+		}
+		result = append(result, PolecatWorktree{
+			Path:    checkedOut[branch],
+			Branch:  branch,
+			Polecat: polecat,
+			BeadID:  beadID,
+		})
+	}
+	return result, nil
+}
+
+// PostflightResult records what Postflight did with one polecat branch.
+type PostflightResult struct {
+	PolecatWorktree
+	Removed bool
+	Reason  string // Why it was kept, if Removed is false
+}
+
+// Postflight cleans up after finished work: for every bead branch of the
+// rig that finished reports done (normally: its bead is closed), it removes
+// the worktree the branch is checked out in and deletes the branch. A
+// worktree with uncommitted, stashed, or unpushed work (beads files aside)
+// is kept. With dryRun it only reports.
+//
+// A branch for which finished returns an error is left alone.
+func (w *WorktreeManager) Postflight(finished func(PolecatWorktree) (bool, error), dryRun bool) ([]PostflightResult, error) {
+	branches, err := w.List()
+	if err != nil {
+		return nil, err
+	}
+
+	var results []PostflightResult
+	for _, pw := range branches {
+		if pw.BeadID == "" {
+			continue
+		}
+		done, err := finished(pw)
+		if err != nil || !done {
+			continue
+		}
+		res := PostflightResult{PolecatWorktree: pw}
+		if pw.Path != "" {
+			status, err := NewGit(pw.Path).CheckUncommittedWork()
+			if err != nil {
+				res.Reason = fmt.Sprintf("checking work: %v", err)
+				results = append(results, res)
+				continue
+			}
+			if !status.CleanExcludingBeads() {
+				res.Reason = status.String()
+				results = append(results, res)
+				continue
+			}
+		}
+		if dryRun {
+			res.Removed = true
+			results = append(results, res)
+			continue
+		}
+		if pw.Path != "" {
+			if err := w.repo.WorktreeRemove(pw.Path, true); err != nil {
+				res.Reason = fmt.Sprintf("removing worktree: %v", err)
+				results = append(results, res)
+				continue
+			}
+		}
+		// The bead is closed, so its work has merged (possibly squashed,
+		// which a safe -d delete wouldn't recognize).
+		if err := w.repo.DeleteBranch(pw.Branch, true); err != nil {
+			res.Reason = fmt.Sprintf("deleting branch: %v", err)
+			results = append(results, res)
+			continue
+		}
+		res.Removed = true
+		results = append(results, res)
+	}
+
+	if !dryRun {
+		_ = w.repo.WorktreePrune()
+	}
+	return results, nil
+}
@@ -0,0 +1,173 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPolecatBranchName(t *testing.T) {
+	if got := PolecatBranchName("gastown", "furiosa", "gt-abc.1"); got != "polecat/gastown/furiosa/gt-abc.1" {
+		t.Errorf("PolecatBranchName() = %q", got)
+	}
+	if got := PolecatBranchName("gastown", "furiosa", ""); got != "polecat/gastown/furiosa/idle" {
+		t.Errorf("PolecatBranchName() idle = %q", got)
+	}
+}
+
+func TestParsePolecatBranch(t *testing.T) {
+	tests := []struct {
+		branch      string
+		wantRig     string
+		wantPolecat string
+		wantBead    string
+		wantOK      bool
+	}{
+		{"polecat/gastown/furiosa/gt-abc.1", "gastown", "furiosa", "gt-abc.1", true},
+		{"polecat/gastown/furiosa/idle", "gastown", "furiosa", "", true},
+		{"polecat/furiosa-mkc36bb9", "", "", "", false},
+		{"polecat/furiosa/gt-abc", "", "", "", false},
+		{"polecat/gastown/furiosa/gt-abc@mk123", "", "", "", false},
+		{"polecat/gastown/furiosa/gt-abc/extra", "", "", "", false},
+		{"polecat/gastown//gt-abc", "", "", "", false},
+		{"main", "", "", "", false},
+	}
+	for _, tt := range tests {
+		rig, polecat, bead, ok := ParsePolecatBranch(tt.branch)
+		if rig != tt.wantRig || polecat != tt.wantPolecat || bead != tt.wantBead || ok != tt.wantOK {
+			t.Errorf("ParsePolecatBranch(%q) = (%q, %q, %q, %v), want (%q, %q, %q, %v)",
+				tt.branch, rig, polecat, bead, ok, tt.wantRig, tt.wantPolecat, tt.wantBead, tt.wantOK)
+		}
+	}
+}
+
+func TestWorktreeManager_AddResumesBeadBranch(t *testing.T) {
+	repo := NewGit(initTestRepo(t))
+	w := NewWorktreeManager(repo, "gastown")
+	branch := w.BranchName("furiosa", "gt-abc")
+	path := filepath.Join(t.TempDir(), "furiosa")
+
+	if err := w.Add(path, branch, "HEAD"); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	wt := NewGit(path)
+	if err := os.WriteFile(filepath.Join(path, "work.txt"), []byte("work\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := wt.Add("work.txt"); err != nil {
+		t.Fatal(err)
+	}
+	if err := wt.Commit("work"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Repair: a second worktree for the same bead picks up the branch, even
+	// while the old worktree still has it checked out.
+	repaired := path + ".repair-tmp"
+	if err := w.Add(repaired, branch, "HEAD"); err != nil {
+		t.Fatalf("Add (repair): %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(repaired, "work.txt")); err != nil {
+		t.Errorf("repaired worktree lost the bead branch's work: %v", err)
+	}
+
+	// Idle branches are reset to the start point instead.
+	base, err := repo.CurrentBranch()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Checkout(repaired, w.BranchName("furiosa", ""), base); err != nil {
+		t.Fatalf("Checkout idle: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(repaired, "work.txt")); !os.IsNotExist(err) {
+		t.Errorf("idle branch should start clean, work.txt stat err = %v", err)
+	}
+}
+
+func TestWorktreeManager_Postflight(t *testing.T) {
+	repo := NewGit(initTestRepo(t))
+	w := NewWorktreeManager(repo, "gastown")
+	root := t.TempDir()
+
+	closedPath := filepath.Join(root, "furiosa")
+	openPath := filepath.Join(root, "nux")
+	dirtyPath := filepath.Join(root, "slit")
+	for path, branch := range map[string]string{
+		closedPath: w.BranchName("furiosa", "gt-closed"),
+		openPath:   w.BranchName("nux", "gt-open"),
+		dirtyPath:  w.BranchName("slit", "gt-dirty"),
+	} {
+		if err := w.Add(path, branch, "HEAD"); err != nil {
+			t.Fatalf("Add %s: %v", branch, err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dirtyPath, "wip.txt"), []byte("wip\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	closed := map[string]bool{"gt-closed": true, "gt-dirty": true}
+	results, err := w.Postflight(func(pw PolecatWorktree) (bool, error) {
+		return closed[pw.BeadID], nil
+	}, false)
+	if err != nil {
+		t.Fatalf("Postflight: %v", err)
+	}
+
+	got := make(map[string]bool)
+	for _, res := range results {
+		got[res.BeadID] = res.Removed
+	}
+	if len(got) != 2 || !got["gt-closed"] || got["gt-dirty"] {
+		t.Errorf("Postflight results = %+v, want gt-closed removed and gt-dirty kept", results)
+	}
+	if _, err := os.Stat(closedPath); !os.IsNotExist(err) {
+		t.Errorf("closed bead's worktree should be removed, stat err = %v", err)
+	}
+	if exists, _ := repo.BranchExists(w.BranchName("furiosa", "gt-closed")); exists {
+		t.Error("closed bead's branch should be deleted")
+	}
+	for _, path := range []string{openPath, dirtyPath} {
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("%s should be kept: %v", path, err)
+		}
+	}
+}
+
+func TestWorktreeManager_AddSparse(t *testing.T) {
+	dir := initTestRepo(t)
+	repo := NewGit(dir)
+	for _, f := range []string{"api/server.go", "web/app.js"} {
+		if err := os.MkdirAll(filepath.Join(dir, filepath.Dir(f)), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, f), []byte("x\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := repo.Add("."); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Commit("add dirs"); err != nil {
+		t.Fatal(err)
+	}
+
+	w := NewWorktreeManager(repo, "gastown")
+	w.SetSparse([]string{"api"})
+	path := filepath.Join(t.TempDir(), "furiosa")
+	if err := w.Add(path, w.BranchName("furiosa", "gt-abc"), "HEAD"); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	for f, want := range map[string]bool{"README.md": true, "api/server.go": true, "web/app.js": false} {
+		_, err := os.Stat(filepath.Join(path, f))
+		if got := err == nil; got != want {
+			t.Errorf("%s checked out = %v, want %v", f, got, want)
+		}
+	}
+	status, err := NewGit(path).Status()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !status.Clean {
+		t.Errorf("sparse worktree should be clean, got %+v", status)
+	}
+}
@@ -0,0 +1,377 @@
+// Package incident manages incident bundles: diagnostic captures (pane
+// scrollback plus a short header) saved when an agent is found stuck or is
+// restarted. Bundles live under <town>/.runtime/diagnostics/<kind>/ and are
+// indexed in diagnostics/index.json so they can be listed and searched by
+// session, bead, and reason without opening every file.
+//
+// Lifecycle: bundles are gzipped once older than the compress-after age,
+// deleted once older than the retention age, and archived (moved under
+// diagnostics/archive/ and compressed) when their bead is closed.
+package incident
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/lock"
+	"github.com/steveyegge/gastown/internal/redact"
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// Incident kinds.
+const (
+	KindStuck   = "stuck"   // Captured by the stuck-workers doctor check
+	KindRestart = "restart" // Captured by gt agents restart
+)
+
+// archiveDir is the diagnostics subdirectory archived bundles move to.
+const archiveDir = "archive"
+
+// Incident is one indexed bundle.
+type Incident struct {
+	ID         string    `json:"id"` // <kind>/<session>-<unix>
+	Kind       string    `json:"kind"`
+	Session    string    `json:"session"`
+	Bead       string    `json:"bead,omitempty"`
+	Reason     string    `json:"reason,omitempty"`
+	At         time.Time `json:"at"`
+	Path       string    `json:"path"` // Relative to the diagnostics dir
+	Compressed bool      `json:"compressed,omitempty"`
+	Archived   bool      `json:"archived,omitempty"`
+}
+
+// Dir returns <townRoot>/.runtime/diagnostics.
+func Dir(townRoot string) string {
+	return filepath.Join(townRoot, constants.DirRuntime, "diagnostics")
+}
+
+func indexPath(townRoot string) string {
+	return filepath.Join(Dir(townRoot), "index.json")
+}
+
+// AbsPath returns the bundle's absolute path.
+func (inc Incident) AbsPath(townRoot string) string {
+	return filepath.Join(Dir(townRoot), inc.Path)
+}
+
+// Save writes a bundle (header plus content) for inc and indexes it. Kind
+// and Session are required; ID, At, and Path are filled in. Secrets in
+// content are redacted first. Returns the bundle's absolute path.
+func Save(townRoot string, inc Incident, content string) (string, error) {
+	if inc.Kind == "" || inc.Session == "" {
+		return "", fmt.Errorf("incident needs a kind and session")
+	}
+	if inc.At.IsZero() {
+		inc.At = time.Now()
+	}
+	name := fmt.Sprintf("%s-%d", inc.Session, inc.At.Unix())
+	inc.ID = inc.Kind + "/" + name
+	inc.Path = filepath.Join(inc.Kind, name+".log")
+
+	path := inc.AbsPath(townRoot)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", err
+	}
+	var header strings.Builder
+	fmt.Fprintf(&header, "# session: %s\n", inc.Session)
+	if inc.Bead != "" {
+		fmt.Fprintf(&header, "# bead: %s\n", inc.Bead)
+	}
+	if inc.Reason != "" {
+		fmt.Fprintf(&header, "# reason: %s\n", inc.Reason)
+	}
+	fmt.Fprintf(&header, "# at: %s\n\n", inc.At.Format(time.RFC3339))
+	content = redact.String(townRoot, content)
+	if err := os.WriteFile(path, []byte(header.String()+content), 0644); err != nil { //nolint:gosec // G306: diagnostics are non-sensitive
+		return "", err
+	}
+
+	err := update(townRoot, func(index []Incident) []Incident {
+		return append(index, inc)
+	})
+	if err != nil {
+		return path, err
+	}
+	// Retention runs opportunistically: incidents are rare, so a pass on
+	// each save keeps the directory bounded without a patrol.
+	_, _ = ApplyRetention(townRoot, time.Now())
+	return path, nil
+}
+
+// List returns indexed incidents, newest first. Bundles saved before the
+// index existed are picked up from their file names.
+func List(townRoot string) ([]Incident, error) {
+	index, err := load(townRoot)
+	if err != nil {
+		return nil, err
+	}
+	index = append(index, unindexed(townRoot, index)...)
+	sort.Slice(index, func(i, j int) bool { return index[i].At.After(index[j].At) })
+	return index, nil
+}
+
+// Read returns a bundle's content, decompressing if needed.
+func Read(townRoot string, inc Incident) (string, error) {
+	f, err := os.Open(inc.AbsPath(townRoot))
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	var r io.Reader = f
+	if inc.Compressed {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return "", err
+		}
+		defer gz.Close()
+		r = gz
+	}
+	data, err := io.ReadAll(r)
+	return string(data), err
+}
+
+// Matches reports whether re matches the incident's metadata or, failing
+// that, its bundle content.
+func Matches(townRoot string, inc Incident, re *regexp.Regexp) bool {
+	for _, field := range []string{inc.ID, inc.Session, inc.Bead, inc.Reason} {
+		if field != "" && re.MatchString(field) {
+			return true
+		}
+	}
+	content, err := Read(townRoot, inc)
+	return err == nil && re.MatchString(content)
+}
+
+// Archive archives every unarchived incident for beadID: the bundle moves
+// under diagnostics/archive/ and is compressed. Returns how many were
+// archived.
+func Archive(townRoot, beadID string) (int, error) {
+	if beadID == "" {
+		return 0, nil
+	}
+	archived := 0
+	var firstErr error
+	err := update(townRoot, func(index []Incident) []Incident {
+		for i := range index {
+			inc := &index[i]
+			if inc.Bead != beadID || inc.Archived {
+				continue
+			}
+			if err := archiveBundle(townRoot, inc); err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				continue
+			}
+			archived++
+		}
+		return index
+	})
+	if err != nil {
+		return archived, err
+	}
+	return archived, firstErr
+}
+
+// archiveBundle moves a bundle under the archive dir, compressing it.
+func archiveBundle(townRoot string, inc *Incident) error {
+	dest := filepath.Join(archiveDir, inc.Path)
+	if !inc.Compressed {
+		dest += ".gz"
+	}
+	destAbs := filepath.Join(Dir(townRoot), dest)
+	if err := os.MkdirAll(filepath.Dir(destAbs), 0755); err != nil {
+		return err
+	}
+	if inc.Compressed {
+		if err := os.Rename(inc.AbsPath(townRoot), destAbs); err != nil {
+			return err
+		}
+	} else if err := gzipFile(inc.AbsPath(townRoot), destAbs); err != nil {
+		return err
+	}
+	inc.Path, inc.Compressed, inc.Archived = dest, true, true
+	return nil
+}
+
+// PruneResult counts what a retention pass did.
+type PruneResult struct {
+	Compressed int
+	Deleted    int
+}
+
+// Prune applies retention: bundles older than retainFor are deleted, and
+// remaining bundles older than compressAfter are gzipped. A non-positive
+// age disables that step.
+func Prune(townRoot string, compressAfter, retainFor time.Duration, now time.Time) (PruneResult, error) {
+	var res PruneResult
+	var firstErr error
+	err := update(townRoot, func(index []Incident) []Incident {
+		index = append(index, unindexed(townRoot, index)...)
+		kept := index[:0]
+		for _, inc := range index {
+			age := now.Sub(inc.At)
+			if retainFor > 0 && age > retainFor {
+				if err := os.Remove(inc.AbsPath(townRoot)); err != nil && !os.IsNotExist(err) {
+					if firstErr == nil {
+						firstErr = err
+					}
+					kept = append(kept, inc)
+					continue
+				}
+				res.Deleted++
+				continue
+			}
+			if compressAfter > 0 && age > compressAfter && !inc.Compressed {
+				if err := gzipFile(inc.AbsPath(townRoot), inc.AbsPath(townRoot)+".gz"); err != nil {
+					if firstErr == nil && !os.IsNotExist(err) {
+						firstErr = err
+					}
+				} else {
+					inc.Path += ".gz"
+					inc.Compressed = true
+					res.Compressed++
+				}
+			}
+			kept = append(kept, inc)
+		}
+		return kept
+	})
+	if err != nil {
+		return res, err
+	}
+	return res, firstErr
+}
+
+// ApplyRetention prunes with the town's configured incident thresholds
+// (operational.incidents in settings/config.json).
+func ApplyRetention(townRoot string, now time.Time) (PruneResult, error) {
+	cfg := config.LoadOperationalConfig(townRoot).GetIncidentsConfig()
+	return Prune(townRoot, cfg.CompressAfterD(), cfg.RetainForD(), now)
+}
+
+// gzipFile compresses src into dst and removes src.
+func gzipFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	gz := gzip.NewWriter(out)
+	if _, err := io.Copy(gz, in); err != nil {
+		out.Close()
+		os.Remove(dst)
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		out.Close()
+		os.Remove(dst)
+		return err
+	}
+	if err := out.Close(); err != nil {
+		os.Remove(dst)
+		return err
+	}
+	return os.Remove(src)
+}
+
+// update applies fn to the index under an exclusive lock and saves it.
+func update(townRoot string, fn func([]Incident) []Incident) error {
+	if err := os.MkdirAll(Dir(townRoot), 0755); err != nil {
+		return err
+	}
+	unlock, err := lock.FlockAcquire(indexPath(townRoot) + ".lock")
+	if err != nil {
+		return fmt.Errorf("locking incident index: %w", err)
+	}
+	defer unlock()
+
+	index, err := load(townRoot)
+	if err != nil {
+		return err
+	}
+	return util.AtomicWriteJSON(indexPath(townRoot), fn(index))
+}
+
+// load reads the index; a missing index is empty.
+func load(townRoot string) ([]Incident, error) {
+	data, err := os.ReadFile(indexPath(townRoot))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var index []Incident
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("parsing incident index: %w", err)
+	}
+	return index, nil
+}
+
+// unindexed returns bundles on disk (outside the archive) that the index
+// doesn't know about, parsed from their <session>-<unix>.log[.gz] names.
+func unindexed(townRoot string, index []Incident) []Incident {
+	known := make(map[string]bool, len(index))
+	for _, inc := range index {
+		known[inc.Path] = true
+	}
+	var found []Incident
+	kinds, _ := os.ReadDir(Dir(townRoot))
+	for _, k := range kinds {
+		if !k.IsDir() || k.Name() == archiveDir {
+			continue
+		}
+		files, _ := os.ReadDir(filepath.Join(Dir(townRoot), k.Name()))
+		for _, f := range files {
+			rel := filepath.Join(k.Name(), f.Name())
+			if f.IsDir() || known[rel] {
+				continue
+			}
+			if inc, ok := parseBundleName(k.Name(), f.Name()); ok {
+				found = append(found, inc)
+			}
+		}
+	}
+	return found
+}
+
+// parseBundleName parses "<session>-<unix>.log" or "...log.gz".
+func parseBundleName(kind, name string) (Incident, bool) {
+	compressed := strings.HasSuffix(name, ".gz")
+	base := strings.TrimSuffix(strings.TrimSuffix(name, ".gz"), ".log")
+	if base == name {
+		return Incident{}, false
+	}
+	idx := strings.LastIndex(base, "-")
+	if idx <= 0 {
+		return Incident{}, false
+	}
+	unix, err := strconv.ParseInt(base[idx+1:], 10, 64)
+	if err != nil {
+		return Incident{}, false
+	}
+	return Incident{
+		ID:         kind + "/" + base,
+		Kind:       kind,
+		Session:    base[:idx],
+		At:         time.Unix(unix, 0),
+		Path:       filepath.Join(kind, name),
+		Compressed: compressed,
+	}, true
+}
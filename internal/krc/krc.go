@@ -77,6 +77,7 @@ func DefaultConfig() *Config {
 			// Death events - keep for forensics
 			"session_death": 30 * 24 * time.Hour, // 30 days
 			"mass_death":    90 * 24 * time.Hour, // 90 days
+			"crash_loop":    90 * 24 * time.Hour, // 90 days
 
 			// Merge events - important for audit
 			"merge_*":       30 * 24 * time.Hour, // 30 days
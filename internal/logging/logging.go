@@ -0,0 +1,261 @@
+// Package logging provides gt's leveled, structured logger, built on slog.
+//
+// Packages get a logger per subsystem with For and log diagnostics through it
+// instead of printing to stderr:
+//
+//	var logger = logging.For("beads")
+//	logger.Warn("no route found for prefix", "prefix", prefix, "fallback", dir)
+//
+// Loggers can be created at package init; Setup (called once gt knows its
+// flags and town settings) swaps the output and levels underneath them. CLI
+// commands log human-readable lines to stderr; the daemon and dashboard can
+// log JSON instead. User-facing command output stays on fmt/style.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"log/slog"
+	"os"
+	"strings"
+	"sync/atomic"
+)
+
+// Output formats for Config.Format.
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// DefaultLevel is the level used when neither flags nor config set one:
+// warnings and errors only, so routine CLI output stays clean.
+const DefaultLevel = slog.LevelWarn
+
+// Config configures logging. Town-wide, stored as "logging" in
+// settings/config.json.
+type Config struct {
+	// Level is the minimum level logged: "debug", "info", "warn" (default),
+	// or "error". --verbose and --quiet override it.
+	Level string `json:"level,omitempty"`
+
+	// Subsystems sets levels per subsystem (e.g., {"tmux": "debug",
+	// "beads": "error"}), overriding Level. --verbose and --quiet override
+	// these too.
+	Subsystems map[string]string `json:"subsystems,omitempty"`
+
+	// Format is the log format of the long-running modes (gt daemon run,
+	// gt dashboard): "text" (default) or "json". CLI commands always log
+	// text to stderr.
+	Format string `json:"format,omitempty"`
+}
+
+// Validate checks the config for errors.
+func (c *Config) Validate() error {
+	if c == nil {
+		return nil
+	}
+	if c.Level != "" {
+		if _, err := ParseLevel(c.Level); err != nil {
+			return fmt.Errorf("logging.level: %w", err)
+		}
+	}
+	for name, lvl := range c.Subsystems {
+		if _, err := ParseLevel(lvl); err != nil {
+			return fmt.Errorf("logging.subsystems[%s]: %w", name, err)
+		}
+	}
+	switch c.Format {
+	case "", FormatText, FormatJSON:
+	default:
+		return fmt.Errorf("logging.format: %q is not text or json", c.Format)
+	}
+	return nil
+}
+
+// JSON reports whether long-running modes should log JSON.
+func (c *Config) JSON() bool {
+	return c != nil && c.Format == FormatJSON
+}
+
+// ParseLevel parses a level name: debug, info, warn (or warning), error.
+func ParseLevel(s string) (slog.Level, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "info":
+		return slog.LevelInfo, nil
+	case "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	}
+	return 0, fmt.Errorf("unknown level %q (want debug, info, warn, or error)", s)
+}
+
+// Options selects where and how logs are written.
+type Options struct {
+	Writer     io.Writer             // Destination (default os.Stderr)
+	JSON       bool                  // JSON records instead of text
+	Timestamps bool                  // Prefix text lines with the time, as log files want
+	Level      slog.Level            // Minimum level for subsystems not listed
+	Subsystems map[string]slog.Level // Per-subsystem minimum levels
+
+	// CaptureStdLog routes the standard log package and slog's default
+	// logger through this output too, so long-running modes get one
+	// consistent stream. CLI commands leave them alone.
+	CaptureStdLog bool
+}
+
+// OptionsFromConfig builds options from config, with verbose (debug) or
+// quiet (errors only) overriding every configured level. def is the level
+// when config sets none. Invalid levels fall back to def; run
+// Config.Validate to report them.
+func OptionsFromConfig(c *Config, def slog.Level, verbose, quiet bool) Options {
+	opts := Options{Level: def}
+	if c != nil {
+		if lvl, err := ParseLevel(c.Level); err == nil {
+			opts.Level = lvl
+		}
+		for name, s := range c.Subsystems {
+			if lvl, err := ParseLevel(s); err == nil {
+				if opts.Subsystems == nil {
+					opts.Subsystems = make(map[string]slog.Level)
+				}
+				opts.Subsystems[name] = lvl
+			}
+		}
+	}
+	switch {
+	case verbose:
+		opts.Level, opts.Subsystems = slog.LevelDebug, nil
+	case quiet:
+		opts.Level, opts.Subsystems = slog.LevelError, nil
+	}
+	return opts
+}
+
+// state is the active output and levels shared by every subsystem logger.
+type state struct {
+	handler    slog.Handler
+	level      slog.Level
+	subsystems map[string]slog.Level
+}
+
+func (s *state) levelFor(subsystem string) slog.Level {
+	if lvl, ok := s.subsystems[subsystem]; ok {
+		return lvl
+	}
+	return s.level
+}
+
+var current atomic.Pointer[state]
+
+func init() {
+	current.Store(&state{handler: newTextHandler(os.Stderr, false), level: DefaultLevel})
+}
+
+// Setup replaces the output and levels of all loggers, including those
+// already returned by For.
+func Setup(opts Options) {
+	w := opts.Writer
+	if w == nil {
+		w = os.Stderr
+	}
+	var h slog.Handler
+	if opts.JSON {
+		// Level filtering happens per subsystem, so the handler passes all.
+		h = slog.NewJSONHandler(w, &slog.HandlerOptions{Level: slog.LevelDebug})
+	} else {
+		h = newTextHandler(w, opts.Timestamps)
+	}
+	current.Store(&state{handler: h, level: opts.Level, subsystems: opts.Subsystems})
+
+	if opts.CaptureStdLog {
+		slog.SetDefault(For(""))
+		log.SetFlags(0)
+		log.SetOutput(stdWriter{For("")})
+	}
+}
+
+// For returns the logger for a subsystem (e.g., "tmux", "mail", "beads").
+// Records carry a "subsystem" attribute.
+func For(subsystem string) *slog.Logger {
+	return slog.New(&subsystemHandler{name: subsystem})
+}
+
+// StdLogger returns a *log.Logger that writes through l, for code built on
+// the standard log package. Lines starting with "Warning" or "Error" (any
+// case) are logged at that level, with a "Warning:"-style prefix dropped;
+// the rest at info.
+func StdLogger(l *slog.Logger) *log.Logger {
+	return log.New(stdWriter{l}, "", 0)
+}
+
+// stdWriter adapts log package output to slog records.
+type stdWriter struct{ l *slog.Logger }
+
+func (w stdWriter) Write(p []byte) (int, error) {
+	lvl, msg := stdLevel(strings.TrimRight(string(p), "\n"))
+	w.l.Log(context.Background(), lvl, msg)
+	return len(p), nil
+}
+
+// stdLevel infers a level from a log line's leading word, returning the
+// line without it when it is a "Word:" prefix.
+func stdLevel(msg string) (slog.Level, string) {
+	trimmed := strings.TrimLeft(msg, " ")
+	lower := strings.ToLower(trimmed)
+	lvl := slog.LevelInfo
+	switch {
+	case strings.HasPrefix(lower, "error"), strings.HasPrefix(lower, "fatal"):
+		lvl = slog.LevelError
+	case strings.HasPrefix(lower, "warn"):
+		lvl = slog.LevelWarn
+	case strings.HasPrefix(lower, "debug"):
+		lvl = slog.LevelDebug
+	default:
+		return lvl, msg
+	}
+	if word, rest, ok := strings.Cut(trimmed, ":"); ok && !strings.ContainsAny(word, " \t") {
+		return lvl, strings.TrimLeft(rest, " ")
+	}
+	return lvl, msg
+}
+
+// subsystemHandler filters by its subsystem's level and forwards to the
+// current output, resolved on every call so Setup applies to loggers made
+// before it ran.
+type subsystemHandler struct {
+	name string
+	ops  []func(slog.Handler) slog.Handler // WithAttrs/WithGroup, in order
+}
+
+func (h *subsystemHandler) Enabled(_ context.Context, lvl slog.Level) bool {
+	return lvl >= current.Load().levelFor(h.name)
+}
+
+func (h *subsystemHandler) Handle(ctx context.Context, r slog.Record) error {
+	out := current.Load().handler
+	if h.name != "" {
+		out = out.WithAttrs([]slog.Attr{slog.String("subsystem", h.name)})
+	}
+	for _, op := range h.ops {
+		out = op(out)
+	}
+	return out.Handle(ctx, r)
+}
+
+func (h *subsystemHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return h.with(func(out slog.Handler) slog.Handler { return out.WithAttrs(attrs) })
+}
+
+func (h *subsystemHandler) WithGroup(name string) slog.Handler {
+	return h.with(func(out slog.Handler) slog.Handler { return out.WithGroup(name) })
+}
+
+func (h *subsystemHandler) with(op func(slog.Handler) slog.Handler) slog.Handler {
+	ops := append(append([]func(slog.Handler) slog.Handler(nil), h.ops...), op)
+	return &subsystemHandler{name: h.name, ops: ops}
+}
@@ -0,0 +1,112 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+// capture points all loggers at a buffer for the test, restoring the
+// defaults afterwards.
+func capture(t *testing.T, opts Options) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	opts.Writer = &buf
+	Setup(opts)
+	t.Cleanup(func() { Setup(Options{Level: DefaultLevel}) })
+	return &buf
+}
+
+func TestParseLevel(t *testing.T) {
+	for in, want := range map[string]slog.Level{
+		"debug": slog.LevelDebug, "INFO": slog.LevelInfo, "warn": slog.LevelWarn,
+		"warning": slog.LevelWarn, " error ": slog.LevelError,
+	} {
+		if got, err := ParseLevel(in); err != nil || got != want {
+			t.Errorf("ParseLevel(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+	if _, err := ParseLevel("loud"); err == nil {
+		t.Error("ParseLevel(loud) = nil error")
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	good := &Config{Level: "info", Subsystems: map[string]string{"tmux": "debug"}, Format: FormatJSON}
+	if err := good.Validate(); err != nil {
+		t.Errorf("Validate(good) = %v", err)
+	}
+	for name, c := range map[string]*Config{
+		"level":     {Level: "loud"},
+		"subsystem": {Subsystems: map[string]string{"beads": "chatty"}},
+		"format":    {Format: "xml"},
+	} {
+		if err := c.Validate(); err == nil {
+			t.Errorf("Validate(%s) = nil, want error", name)
+		}
+	}
+}
+
+func TestOptionsFromConfig(t *testing.T) {
+	c := &Config{Level: "error", Subsystems: map[string]string{"tmux": "debug", "mail": "bogus"}}
+	opts := OptionsFromConfig(c, DefaultLevel, false, false)
+	if opts.Level != slog.LevelError || opts.Subsystems["tmux"] != slog.LevelDebug || len(opts.Subsystems) != 1 {
+		t.Errorf("OptionsFromConfig = %+v", opts)
+	}
+	if opts := OptionsFromConfig(nil, slog.LevelInfo, false, false); opts.Level != slog.LevelInfo {
+		t.Errorf("nil config level = %v, want info", opts.Level)
+	}
+	if opts := OptionsFromConfig(c, DefaultLevel, true, false); opts.Level != slog.LevelDebug || opts.Subsystems != nil {
+		t.Errorf("verbose = %+v", opts)
+	}
+	if opts := OptionsFromConfig(c, DefaultLevel, false, true); opts.Level != slog.LevelError || opts.Subsystems != nil {
+		t.Errorf("quiet = %+v", opts)
+	}
+}
+
+func TestSubsystemLevels(t *testing.T) {
+	// Loggers made before Setup pick up its output and levels.
+	tmux, beads := For("tmux"), For("beads")
+	buf := capture(t, Options{Level: slog.LevelWarn, Subsystems: map[string]slog.Level{"tmux": slog.LevelDebug}})
+
+	tmux.Debug("ran tmux", "args", "has-session -t gt-nux")
+	beads.Info("hidden")
+	beads.Warn("no route found", "prefix", "gt")
+
+	want := "Debug: ran tmux (tmux) args=\"has-session -t gt-nux\"\n" +
+		"Warning: no route found (beads) prefix=gt\n"
+	if buf.String() != want {
+		t.Errorf("output =\n%s\nwant\n%s", buf.String(), want)
+	}
+}
+
+func TestJSON(t *testing.T) {
+	buf := capture(t, Options{JSON: true, Level: slog.LevelInfo})
+	For("mail").With("bead", "hq-1").Warn("delivery ack failed", "err", "timeout")
+
+	var rec map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &rec); err != nil {
+		t.Fatalf("output %q is not JSON: %v", buf.String(), err)
+	}
+	for k, want := range map[string]string{"level": "WARN", "msg": "delivery ack failed", "subsystem": "mail", "bead": "hq-1", "err": "timeout"} {
+		if rec[k] != want {
+			t.Errorf("%s = %v, want %q", k, rec[k], want)
+		}
+	}
+}
+
+func TestStdLogger(t *testing.T) {
+	buf := capture(t, Options{Level: slog.LevelWarn})
+	l := StdLogger(For("daemon"))
+	l.Printf("Heartbeat complete")
+	l.Printf("Warning: failed to load restart state: %v", "EOF")
+	l.Printf("ERROR: dolt server down")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	want := []string{"Warning: failed to load restart state: EOF (daemon)", "Error: dolt server down (daemon)"}
+	if strings.Join(lines, "\n") != strings.Join(want, "\n") {
+		t.Errorf("output = %q", buf.String())
+	}
+}
@@ -0,0 +1,108 @@
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// textHandler writes one human-readable line per record:
+//
+//	Warning: no route found for prefix (beads) prefix=gt fallback=/town
+//
+// Info messages carry no level prefix. The subsystem attribute is shown in
+// parentheses after the message rather than as key=value.
+type textHandler struct {
+	mu         *sync.Mutex
+	w          io.Writer
+	timestamps bool
+	subsystem  string
+	attrs      string // Preformatted " key=value" pairs from WithAttrs
+	group      string // Key prefix from WithGroup, with trailing dot
+}
+
+func newTextHandler(w io.Writer, timestamps bool) *textHandler {
+	return &textHandler{mu: &sync.Mutex{}, w: w, timestamps: timestamps}
+}
+
+func (h *textHandler) Enabled(context.Context, slog.Level) bool { return true }
+
+func (h *textHandler) Handle(_ context.Context, r slog.Record) error {
+	var b strings.Builder
+	if h.timestamps && !r.Time.IsZero() {
+		b.WriteString(r.Time.Format("2006/01/02 15:04:05 "))
+	}
+	switch {
+	case r.Level >= slog.LevelError:
+		b.WriteString("Error: ")
+	case r.Level >= slog.LevelWarn:
+		b.WriteString("Warning: ")
+	case r.Level < slog.LevelInfo:
+		b.WriteString("Debug: ")
+	}
+	b.WriteString(r.Message)
+	if h.subsystem != "" {
+		b.WriteString(" (" + h.subsystem + ")")
+	}
+	b.WriteString(h.attrs)
+	r.Attrs(func(a slog.Attr) bool {
+		appendAttr(&b, h.group, a)
+		return true
+	})
+	b.WriteByte('\n')
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, err := io.WriteString(h.w, b.String())
+	return err
+}
+
+func (h *textHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	h2 := *h
+	var b strings.Builder
+	for _, a := range attrs {
+		if h.group == "" && a.Key == "subsystem" {
+			h2.subsystem = a.Value.String()
+			continue
+		}
+		appendAttr(&b, h.group, a)
+	}
+	h2.attrs += b.String()
+	return &h2
+}
+
+func (h *textHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	h2 := *h
+	h2.group += name + "."
+	return &h2
+}
+
+// appendAttr writes " key=value", flattening groups into dotted keys and
+// quoting values that contain spaces.
+func appendAttr(b *strings.Builder, prefix string, a slog.Attr) {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return
+	}
+	if a.Value.Kind() == slog.KindGroup {
+		p := prefix
+		if a.Key != "" {
+			p += a.Key + "."
+		}
+		for _, ga := range a.Value.Group() {
+			appendAttr(b, p, ga)
+		}
+		return
+	}
+	v := a.Value.String()
+	if v == "" || strings.ContainsAny(v, " \t\n\"=") {
+		v = strconv.Quote(v)
+	}
+	b.WriteString(" " + prefix + a.Key + "=" + v)
+}
@@ -0,0 +1,101 @@
+package mail
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+// ErrMailDenied is returned when the town's mail ACL forbids a message.
+var ErrMailDenied = errors.New("mail denied by ACL")
+
+// aclRolePrefix marks an ACL pattern that matches every agent of a role.
+const aclRolePrefix = "role:"
+
+// CheckACL applies the ACL to direct mail from one address to another. The
+// first entry whose from and to both match decides; mail no entry matches
+// is allowed. isCrew tells a crew member from a polecat when an address is
+// in the canonical "<rig>/<name>" form; nil treats such addresses as
+// polecats.
+func CheckACL(acl []config.MailACLRule, from, to string, isCrew func(rig, name string) bool) error {
+	for i, entry := range acl {
+		if !aclMatches(entry.From, from, isCrew) || !aclMatches(entry.To, to, isCrew) {
+			continue
+		}
+		if entry.Action == config.MailACLDeny {
+			return fmt.Errorf("%w: %s may not message %s (acl entry #%d: %s → %s)", ErrMailDenied, from, to, i+1, entry.From, entry.To)
+		}
+		return nil
+	}
+	return nil
+}
+
+// aclMatches matches an ACL pattern against an address.
+func aclMatches(pattern, address string, isCrew func(rig, name string) bool) bool {
+	if role, ok := strings.CutPrefix(pattern, aclRolePrefix); ok {
+		return AddressRole(address, isCrew) == role
+	}
+	if pattern == "*" || matchRuleAddress(pattern, address) {
+		return true
+	}
+	// An exact address matches in any form ("gastown/polecats/nux" and
+	// "gastown/nux" are the same agent).
+	return !strings.Contains(pattern, "*") && AddressToIdentity(pattern) == AddressToIdentity(address)
+}
+
+// AddressRole returns the role of the agent an address names (mayor,
+// deacon, overseer, witness, refinery, crew, polecat), or "" for addresses
+// that aren't a single agent. See CheckACL for isCrew.
+func AddressRole(address string, isCrew func(rig, name string) bool) string {
+	switch identity := AddressToIdentity(address); identity {
+	case "mayor/":
+		return "mayor"
+	case "deacon/":
+		return "deacon"
+	case "overseer":
+		return "overseer"
+	}
+
+	parts := strings.Split(strings.TrimSuffix(address, "/"), "/")
+	switch {
+	case len(parts) == 3 && parts[1] == "crew":
+		return "crew"
+	case len(parts) == 3 && parts[1] == "polecats":
+		return "polecat"
+	case len(parts) == 2 && parts[1] == "witness":
+		return "witness"
+	case len(parts) == 2 && parts[1] == "refinery":
+		return "refinery"
+	case len(parts) == 2 && parts[0] != "" && parts[1] != "":
+		if isCrew != nil && isCrew(parts[0], parts[1]) {
+			return "crew"
+		}
+		return "polecat"
+	}
+	return ""
+}
+
+// checkACL applies the town's mail ACL to a direct message.
+func (r *Router) checkACL(from, to string) error {
+	cfg := r.loadMessagingConfig()
+	if cfg == nil || len(cfg.ACL) == 0 {
+		return nil
+	}
+	return CheckACL(cfg.ACL, from, to, CrewChecker(r.townRoot))
+}
+
+// CrewChecker returns an isCrew function for CheckACL that looks for the
+// crew member's directory in the town.
+func CrewChecker(townRoot string) func(rig, name string) bool {
+	return func(rig, name string) bool {
+		if townRoot == "" {
+			return false
+		}
+		info, err := os.Stat(filepath.Join(townRoot, rig, "crew", name))
+		return err == nil && info.IsDir()
+	}
+}
@@ -0,0 +1,62 @@
+package mail
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+func TestAddressRole(t *testing.T) {
+	isCrew := func(rig, name string) bool { return rig == "gastown" && name == "max" }
+	tests := map[string]string{
+		"mayor/":                 "mayor",
+		"mayor":                  "mayor",
+		"deacon/":                "deacon",
+		"overseer":               "overseer",
+		"gastown/witness":        "witness",
+		"gastown/refinery":       "refinery",
+		"gastown/crew/max":       "crew",
+		"gastown/max":            "crew",
+		"gastown/polecats/Toast": "polecat",
+		"gastown/Toast":          "polecat",
+		"gastown/":               "",
+		"@town":                  "",
+	}
+	for addr, want := range tests {
+		if got := AddressRole(addr, isCrew); got != want {
+			t.Errorf("AddressRole(%q) = %q, want %q", addr, got, want)
+		}
+	}
+}
+
+func TestCheckACL(t *testing.T) {
+	acl := []config.MailACLRule{
+		{From: "gastown/polecats/nux", To: "deacon/", Action: config.MailACLAllow},
+		{From: "role:polecat", To: "deacon/", Action: config.MailACLDeny},
+		{From: "*/witness", To: "role:crew", Action: config.MailACLDeny},
+	}
+	tests := []struct {
+		from, to string
+		denied   bool
+	}{
+		{"gastown/polecats/Toast", "deacon/", true},
+		{"gastown/Toast", "deacon", true},
+		{"gastown/polecats/nux", "deacon/", false}, // Earlier allow wins
+		{"gastown/nux", "deacon/", false},          // Canonical form matches too
+		{"gastown/crew/max", "deacon/", false},
+		{"gastown/witness", "gastown/crew/max", true},
+		{"gastown/witness", "gastown/Toast", false},
+		{"mayor/", "gastown/crew/max", false}, // No entry matches: allowed
+	}
+	for _, tt := range tests {
+		err := CheckACL(acl, tt.from, tt.to, nil)
+		if tt.denied != errors.Is(err, ErrMailDenied) {
+			t.Errorf("CheckACL(%s → %s) = %v, want denied=%v", tt.from, tt.to, err, tt.denied)
+		}
+	}
+
+	if err := CheckACL(nil, "gastown/Toast", "deacon/", nil); err != nil {
+		t.Errorf("empty ACL denied mail: %v", err)
+	}
+}
@@ -0,0 +1,207 @@
+package mail
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+)
+
+// AttachmentKind is what a mail attachment carries.
+type AttachmentKind string
+
+const (
+	// AttachBead references a bead by ID; it is rendered inline on read.
+	AttachBead AttachmentKind = "bead"
+
+	// AttachFile carries a snapshot of a file within the sender's rig.
+	AttachFile AttachmentKind = "file"
+
+	// AttachDiff carries a git diff or patch.
+	AttachDiff AttachmentKind = "diff"
+)
+
+// Attachment size limits. Attachments travel inside the message bead, so
+// they are kept small; send a bead or a branch for anything bigger.
+const (
+	// MaxAttachmentSize is the largest content a single attachment may carry.
+	MaxAttachmentSize = 64 * 1024
+
+	// MaxAttachmentsSize is the largest total content per message.
+	MaxAttachmentsSize = 256 * 1024
+
+	// MaxAttachments is the most attachments per message.
+	MaxAttachments = 16
+)
+
+// ErrAttachmentTooLarge is returned when attachments exceed the size limits.
+var ErrAttachmentTooLarge = errors.New("attachment too large")
+
+// Attachment is a typed item carried by a message.
+type Attachment struct {
+	// Kind is the attachment type (bead, file, diff).
+	Kind AttachmentKind `json:"kind"`
+
+	// Ref identifies the attachment: the bead ID, the file's path relative
+	// to its rig, or what the diff was taken from (a rev range or a patch
+	// file name).
+	Ref string `json:"ref"`
+
+	// Content is the file or diff text. Empty for bead attachments, which
+	// are looked up when the message is read.
+	Content string `json:"content,omitempty"`
+}
+
+// ValidateAttachments checks kinds, refs, and size limits.
+func ValidateAttachments(attachments []Attachment) error {
+	if len(attachments) > MaxAttachments {
+		return fmt.Errorf("%d attachments, at most %d allowed", len(attachments), MaxAttachments)
+	}
+	total := 0
+	for i, a := range attachments {
+		switch a.Kind {
+		case AttachBead, AttachFile, AttachDiff:
+		default:
+			return fmt.Errorf("attachment %d: unknown kind %q", i+1, a.Kind)
+		}
+		if a.Ref == "" {
+			return fmt.Errorf("attachment %d: missing ref", i+1)
+		}
+		if a.Kind == AttachBead && a.Content != "" {
+			return fmt.Errorf("attachment %d: bead attachments carry no content", i+1)
+		}
+		if a.Kind == AttachFile && !IsRigRelativePath(a.Ref) {
+			return fmt.Errorf("attachment %d: file path %q must be relative to the rig", i+1, a.Ref)
+		}
+		if !utf8.ValidString(a.Content) {
+			return fmt.Errorf("attachment %d (%s): binary content is not supported", i+1, a.Ref)
+		}
+		if len(a.Content) > MaxAttachmentSize {
+			return fmt.Errorf("%w: %s is %d bytes, limit is %d", ErrAttachmentTooLarge, a.Ref, len(a.Content), MaxAttachmentSize)
+		}
+		total += len(a.Content)
+	}
+	if total > MaxAttachmentsSize {
+		return fmt.Errorf("%w: %d bytes in total, limit is %d", ErrAttachmentTooLarge, total, MaxAttachmentsSize)
+	}
+	return nil
+}
+
+// IsRigRelativePath reports whether p is a clean relative path that stays
+// inside the directory it is relative to.
+func IsRigRelativePath(p string) bool {
+	if p == "" || filepath.IsAbs(p) || strings.HasPrefix(p, "/") {
+		return false
+	}
+	clean := filepath.Clean(filepath.FromSlash(p))
+	return clean != "." && clean != ".." && !strings.HasPrefix(clean, ".."+string(filepath.Separator))
+}
+
+// Attachments are stored in the message bead's description, after the body,
+// as base64-encoded JSON between these marker lines. Base64 keeps patch text
+// from being mistaken for a marker.
+const (
+	attachmentsBegin = "-----BEGIN GT ATTACHMENTS-----"
+	attachmentsEnd   = "-----END GT ATTACHMENTS-----"
+)
+
+// EncodeBody returns the bead description for a body with attachments.
+func EncodeBody(body string, attachments []Attachment) string {
+	if len(attachments) == 0 {
+		return body
+	}
+	data, _ := json.Marshal(attachments) // Attachment always marshals
+	encoded := base64.StdEncoding.EncodeToString(data)
+
+	var sb strings.Builder
+	sb.WriteString(body)
+	sb.WriteString("\n\n")
+	sb.WriteString(attachmentsBegin)
+	sb.WriteString("\n")
+	for len(encoded) > 76 {
+		sb.WriteString(encoded[:76])
+		sb.WriteString("\n")
+		encoded = encoded[76:]
+	}
+	sb.WriteString(encoded)
+	sb.WriteString("\n")
+	sb.WriteString(attachmentsEnd)
+	return sb.String()
+}
+
+// DecodeBody splits a bead description into the body and its attachments.
+// A description without a well-formed attachment block is all body.
+func DecodeBody(description string) (string, []Attachment) {
+	start := strings.LastIndex(description, "\n\n"+attachmentsBegin+"\n")
+	if start < 0 || !strings.HasSuffix(description, "\n"+attachmentsEnd) {
+		return description, nil
+	}
+	block := description[start+len("\n\n"+attachmentsBegin+"\n") : len(description)-len("\n"+attachmentsEnd)]
+	data, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(block, "\n", ""))
+	if err != nil {
+		return description, nil
+	}
+	var attachments []Attachment
+	if err := json.Unmarshal(data, &attachments); err != nil {
+		return description, nil
+	}
+	return description[:start], attachments
+}
+
+// MaterializeAttachment writes a file or diff attachment into dir and
+// returns the path written. The file name comes from the attachment's index
+// and the base name of its ref only, so a crafted ref can't write outside
+// dir; existing files are never overwritten. Bead attachments have nothing
+// to write.
+func MaterializeAttachment(dir string, index int, a Attachment) (string, error) {
+	if a.Kind == AttachBead {
+		return "", fmt.Errorf("bead attachments have no content to write (see 'bd show %s')", a.Ref)
+	}
+	if err := ValidateAttachments([]Attachment{a}); err != nil {
+		return "", err
+	}
+
+	name := attachmentFileName(index, a)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("creating %s: %w", dir, err)
+	}
+	path := filepath.Join(dir, name)
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return "", fmt.Errorf("writing attachment: %w", err)
+	}
+	if _, err := f.WriteString(a.Content); err != nil {
+		_ = f.Close()
+		return "", fmt.Errorf("writing attachment: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return "", fmt.Errorf("writing attachment: %w", err)
+	}
+	return path, nil
+}
+
+// attachmentFileName is "<index>-<base name>", with diffs always ending in
+// .patch. index is 1-based, as shown by 'gt mail read'.
+func attachmentFileName(index int, a Attachment) string {
+	base := a.Ref // A rev range like main..fix/x, flattened below
+	if a.Kind == AttachFile {
+		base = filepath.Base(filepath.Clean(filepath.FromSlash(a.Ref)))
+	}
+	base = strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r == ':' || r < ' ' {
+			return '_'
+		}
+		return r
+	}, base)
+	if base == "." || base == ".." || base == "" {
+		base = string(a.Kind)
+	}
+	if a.Kind == AttachDiff && !strings.HasSuffix(base, ".patch") && !strings.HasSuffix(base, ".diff") {
+		base += ".patch"
+	}
+	return fmt.Sprintf("%d-%s", index, base)
+}
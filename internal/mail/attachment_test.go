@@ -0,0 +1,118 @@
+package mail
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestEncodeDecodeBody(t *testing.T) {
+	attachments := []Attachment{
+		{Kind: AttachBead, Ref: "gt-abc"},
+		{Kind: AttachFile, Ref: "internal/x.go", Content: "package x\n"},
+		{Kind: AttachDiff, Ref: "HEAD~1..HEAD", Content: strings.Repeat("+line\n", 100) + attachmentsEnd + "\n"},
+	}
+	body := "Please review.\n\nThanks"
+
+	desc := EncodeBody(body, attachments)
+	gotBody, got := DecodeBody(desc)
+	if gotBody != body {
+		t.Errorf("body = %q, want %q", gotBody, body)
+	}
+	if !reflect.DeepEqual(got, attachments) {
+		t.Errorf("attachments = %+v, want %+v", got, attachments)
+	}
+
+	if EncodeBody(body, nil) != body {
+		t.Error("EncodeBody without attachments should return the body unchanged")
+	}
+
+	// A body that merely mentions the markers is all body.
+	plain := "see\n\n" + attachmentsBegin + "\nnot base64!\n" + attachmentsEnd
+	if gotBody, got := DecodeBody(plain); gotBody != plain || got != nil {
+		t.Errorf("DecodeBody(plain) = %q, %v", gotBody, got)
+	}
+}
+
+func TestToMessage_DecodesAttachments(t *testing.T) {
+	attachments := []Attachment{{Kind: AttachBead, Ref: "gt-abc"}}
+	bm := BeadsMessage{ID: "hq-1", Title: "s", Description: EncodeBody("hi", attachments)}
+	msg := bm.ToMessage()
+	if msg.Body != "hi" || !reflect.DeepEqual(msg.Attachments, attachments) {
+		t.Errorf("ToMessage body = %q, attachments = %+v", msg.Body, msg.Attachments)
+	}
+}
+
+func TestValidateAttachments(t *testing.T) {
+	big := strings.Repeat("x", MaxAttachmentSize+1)
+	chunk := strings.Repeat("x", MaxAttachmentSize)
+	tests := []struct {
+		name    string
+		in      []Attachment
+		wantErr bool
+		tooBig  bool
+	}{
+		{"ok", []Attachment{{Kind: AttachFile, Ref: "a/b.go", Content: "x"}}, false, false},
+		{"unknown kind", []Attachment{{Kind: "url", Ref: "x"}}, true, false},
+		{"missing ref", []Attachment{{Kind: AttachDiff, Content: "x"}}, true, false},
+		{"bead with content", []Attachment{{Kind: AttachBead, Ref: "gt-1", Content: "x"}}, true, false},
+		{"absolute path", []Attachment{{Kind: AttachFile, Ref: "/etc/passwd"}}, true, false},
+		{"escaping path", []Attachment{{Kind: AttachFile, Ref: "a/../../b"}}, true, false},
+		{"binary", []Attachment{{Kind: AttachDiff, Ref: "x", Content: "\xff\xfe"}}, true, false},
+		{"one too large", []Attachment{{Kind: AttachDiff, Ref: "x", Content: big}}, true, true},
+		{"total too large", []Attachment{
+			{Kind: AttachDiff, Ref: "1", Content: chunk},
+			{Kind: AttachDiff, Ref: "2", Content: chunk},
+			{Kind: AttachDiff, Ref: "3", Content: chunk},
+			{Kind: AttachDiff, Ref: "4", Content: chunk},
+			{Kind: AttachDiff, Ref: "5", Content: "x"},
+		}, true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateAttachments(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateAttachments() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.tooBig && !errors.Is(err, ErrAttachmentTooLarge) {
+				t.Errorf("error = %v, want ErrAttachmentTooLarge", err)
+			}
+		})
+	}
+}
+
+func TestMaterializeAttachment(t *testing.T) {
+	dir := t.TempDir()
+
+	path, err := MaterializeAttachment(dir, 1, Attachment{Kind: AttachFile, Ref: "internal/x.go", Content: "package x\n"})
+	if err != nil {
+		t.Fatalf("MaterializeAttachment: %v", err)
+	}
+	if path != filepath.Join(dir, "1-x.go") {
+		t.Errorf("path = %s, want 1-x.go in %s", path, dir)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "package x\n" {
+		t.Errorf("content = %q", data)
+	}
+
+	// Never overwrites.
+	if _, err := MaterializeAttachment(dir, 1, Attachment{Kind: AttachFile, Ref: "x.go", Content: "other"}); err == nil {
+		t.Error("second write to the same name succeeded")
+	}
+
+	// Rev ranges are flattened into one file name inside dir.
+	path, err = MaterializeAttachment(dir, 2, Attachment{Kind: AttachDiff, Ref: "main..fix/../../x", Content: "diff"})
+	if err != nil {
+		t.Fatalf("MaterializeAttachment(diff): %v", err)
+	}
+	if filepath.Dir(path) != dir || !strings.HasSuffix(path, ".patch") {
+		t.Errorf("diff written to %s, want a .patch in %s", path, dir)
+	}
+
+	if _, err := MaterializeAttachment(dir, 3, Attachment{Kind: AttachBead, Ref: "gt-1"}); err == nil {
+		t.Error("materializing a bead attachment succeeded")
+	}
+}
@@ -8,9 +8,14 @@ import (
 	"time"
 
 	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/errkind"
+	"github.com/steveyegge/gastown/internal/logging"
 	"github.com/steveyegge/gastown/internal/telemetry"
 )
 
+// logger is the mail subsystem logger.
+var logger = logging.For("mail")
+
 const (
 	// bdReadTimeout is the timeout for bd read operations (list, show, query).
 	// 60s accommodates concurrent agent load where multiple bd processes compete
@@ -43,6 +48,11 @@ func (e *bdError) Unwrap() error {
 	return e.Err
 }
 
+// ErrorKind implements errkind.Kinded: bd is an external tool.
+func (e *bdError) ErrorKind() errkind.Kind {
+	return errkind.ExternalTool
+}
+
 // ContainsError checks if the stderr message contains the given substring.
 func (e *bdError) ContainsError(substr string) bool {
 	return strings.Contains(e.Stderr, substr)
@@ -54,7 +64,14 @@ func (e *bdError) ContainsError(substr string) bool {
 // beadsDir is the BEADS_DIR environment variable value.
 // extraEnv contains additional environment variables to set (e.g., "BD_IDENTITY=...").
 // Returns stdout bytes on success, or a *bdError on failure.
-func runBdCommand(ctx context.Context, args []string, workDir, beadsDir string, extraEnv ...string) (_ []byte, retErr error) {
+func runBdCommand(ctx context.Context, args []string, workDir, beadsDir string, extraEnv ...string) ([]byte, error) {
+	return runBdCommandInput(ctx, args, workDir, beadsDir, "", extraEnv...)
+}
+
+// runBdCommandInput is runBdCommand with stdin connected to input (none if
+// empty). Message bodies go this way ("bd create --body-file -"): with
+// attachments they can exceed the kernel's per-argument limit (128KiB).
+func runBdCommandInput(ctx context.Context, args []string, workDir, beadsDir, input string, extraEnv ...string) (_ []byte, retErr error) {
 	defer func() { telemetry.RecordMail(ctx, "bd."+firstArg(args), retErr) }()
 
 	// Remove stale dolt-server.pid before spawning bd. A stale PID file causes
@@ -70,11 +87,16 @@ func runBdCommand(ctx context.Context, args []string, workDir, beadsDir string,
 	env = append(env, telemetry.OTELEnvForSubprocess()...)
 	cmd.Env = env
 
+	if input != "" {
+		cmd.Stdin = strings.NewReader(input)
+	}
+
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
 
 	runErr := cmd.Run()
+	logger.Debug("ran bd", "args", strings.Join(args, " "), "beads_dir", beadsDir, "err", runErr)
 
 	if runErr != nil {
 		return nil, &bdError{
@@ -3,7 +3,6 @@ package mail
 import (
 	"encoding/json"
 	"fmt"
-	"os"
 	"strings"
 	"time"
 )
@@ -83,7 +82,7 @@ func AcknowledgeDeliveryBead(workDir, beadsDir, beadID, recipientIdentity string
 	if readErr != nil {
 		// Log but proceed with empty labels — fresh timestamp is acceptable
 		// degradation vs blocking the ack entirely.
-		fmt.Fprintf(os.Stderr, "delivery ack: could not read labels for %s: %v (proceeding with fresh timestamp)\n", beadID, readErr)
+		logger.Warn("delivery ack: could not read labels, proceeding with fresh timestamp", "bead", beadID, "err", readErr)
 	}
 
 	for _, label := range DeliveryAckLabelSequenceIdempotent(recipientIdentity, timeNow().UTC(), existingLabels) {
@@ -158,3 +157,122 @@ func ParseDeliveryLabels(labels []string) (state, ackedBy string, ackedAt *time.
 	}
 	return "", "", nil
 }
+
+// MessageState is where a message is in its lifecycle, as seen by the
+// sender: queued → delivered → read → acted. States only move forward.
+type MessageState string
+
+const (
+	// StateQueued means the message is in the recipient's mailbox but hasn't
+	// reached them yet.
+	StateQueued MessageState = "queued"
+	// StateDelivered means the recipient was nudged about the message, or
+	// saw it in their inbox (delivery:acked).
+	StateDelivered MessageState = "delivered"
+	// StateRead means the recipient opened the message with 'gt mail read'.
+	StateRead MessageState = "read"
+	// StateActed means the recipient replied to the message or hooked it.
+	StateActed MessageState = "acted"
+
+	// Label prefixes for lifecycle tracking: "msg-state:<state>" records
+	// that a message reached a state and "msg-state-at:<state>:<RFC3339>"
+	// when it did.
+	StateLabelPrefix   = "msg-state:"
+	StateAtLabelPrefix = "msg-state-at:"
+)
+
+// messageStateRank orders states; an unknown state ranks lowest.
+var messageStateRank = map[MessageState]int{
+	StateQueued:    1,
+	StateDelivered: 2,
+	StateRead:      3,
+	StateActed:     4,
+}
+
+// Reached reports whether s is at or past other.
+func (s MessageState) Reached(other MessageState) bool {
+	return messageStateRank[s] >= messageStateRank[other]
+}
+
+// MessageStateLabels returns the labels that record reaching state at time
+// at. The timestamp label comes first so a crash never leaves a state
+// without its time.
+func MessageStateLabels(state MessageState, at time.Time) []string {
+	return []string{
+		StateAtLabelPrefix + string(state) + ":" + at.UTC().Format(time.RFC3339),
+		StateLabelPrefix + string(state),
+	}
+}
+
+// ParseMessageState derives a message's lifecycle state, and when it
+// entered each state, from its labels. Messages from before state tracking
+// have no labels and no state. Delivery acks and the "read" label count
+// as delivered and read; when a state was recorded more than once, the
+// earliest time wins.
+func ParseMessageState(labels []string) (MessageState, map[MessageState]time.Time) {
+	var state MessageState
+	at := make(map[MessageState]time.Time)
+	reach := func(s MessageState) {
+		if messageStateRank[s] > messageStateRank[state] {
+			state = s
+		}
+	}
+
+	deliveryState, _, ackedAt := ParseDeliveryLabels(labels)
+	switch deliveryState {
+	case DeliveryStatePending:
+		reach(StateQueued)
+	case DeliveryStateAcked:
+		reach(StateDelivered)
+		if ackedAt != nil {
+			at[StateDelivered] = *ackedAt
+		}
+	}
+
+	for _, label := range labels {
+		switch {
+		case label == "read":
+			reach(StateRead)
+		case strings.HasPrefix(label, StateLabelPrefix):
+			s := MessageState(strings.TrimPrefix(label, StateLabelPrefix))
+			if _, ok := messageStateRank[s]; ok {
+				reach(s)
+			}
+		case strings.HasPrefix(label, StateAtLabelPrefix):
+			s, ts, ok := strings.Cut(strings.TrimPrefix(label, StateAtLabelPrefix), ":")
+			if !ok {
+				continue
+			}
+			t, err := time.Parse(time.RFC3339, ts)
+			if err != nil {
+				continue
+			}
+			if prev, seen := at[MessageState(s)]; !seen || t.Before(prev) {
+				at[MessageState(s)] = t
+			}
+		}
+	}
+	if len(at) == 0 {
+		at = nil
+	}
+	return state, at
+}
+
+// RecordMessageState writes the labels for a message reaching state. bd
+// label add is idempotent for the state label; a repeat only adds a later
+// timestamp, which ParseMessageState ignores.
+func RecordMessageState(workDir, beadsDir, beadID string, state MessageState) error {
+	for _, label := range MessageStateLabels(state, timeNow()) {
+		ctx, cancel := bdWriteCtx()
+		_, err := runBdCommand(ctx, []string{"label", "add", beadID, label}, workDir, beadsDir)
+		cancel()
+		if err == nil {
+			continue
+		}
+		if bdErr, ok := err.(*bdError); ok && bdErr.ContainsError("not found") {
+			return ErrMessageNotFound
+		}
+		return err
+	}
+	return nil
+}
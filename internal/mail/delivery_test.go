@@ -180,3 +180,67 @@ func TestDeliveryAckLabelSequenceIdempotent(t *testing.T) {
 		}
 	})
 }
+
+func TestParseMessageState(t *testing.T) {
+	t1 := time.Date(2026, 2, 17, 12, 0, 0, 0, time.UTC)
+	t2 := t1.Add(time.Hour)
+	readAt := MessageStateLabels(StateRead, t2)
+
+	tests := []struct {
+		name      string
+		labels    []string
+		wantState MessageState
+		wantAt    map[MessageState]time.Time
+	}{
+		{"untracked", []string{"gt:message"}, "", nil},
+		{"queued", []string{DeliveryLabelPending}, StateQueued, nil},
+		{
+			"acked counts as delivered",
+			append([]string{DeliveryLabelPending}, DeliveryAckLabelSequence("gastown/worker", t1)...),
+			StateDelivered,
+			map[MessageState]time.Time{StateDelivered: t1},
+		},
+		{"read label", []string{DeliveryLabelPending, "read"}, StateRead, nil},
+		{
+			"recorded read",
+			append([]string{DeliveryLabelPending}, readAt...),
+			StateRead,
+			map[MessageState]time.Time{StateRead: t2},
+		},
+		{
+			// Half-written: the timestamp lands before the state label.
+			"crash before state label",
+			[]string{DeliveryLabelPending, readAt[0]},
+			StateQueued,
+			map[MessageState]time.Time{StateRead: t2},
+		},
+		{
+			"states only move forward, earliest time wins",
+			append(append([]string{DeliveryLabelPending, StateLabelPrefix + "acted"}, MessageStateLabels(StateActed, t2)...),
+				MessageStateLabels(StateActed, t1)...),
+			StateActed,
+			map[MessageState]time.Time{StateActed: t1},
+		},
+		{"unknown state ignored", []string{DeliveryLabelPending, StateLabelPrefix + "bogus"}, StateQueued, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state, at := ParseMessageState(tt.labels)
+			if state != tt.wantState {
+				t.Errorf("state = %q, want %q", state, tt.wantState)
+			}
+			if !reflect.DeepEqual(at, tt.wantAt) {
+				t.Errorf("at = %v, want %v", at, tt.wantAt)
+			}
+		})
+	}
+}
+
+func TestMessageStateReached(t *testing.T) {
+	if !StateActed.Reached(StateRead) || !StateRead.Reached(StateRead) {
+		t.Error("acted and read should have reached read")
+	}
+	if StateDelivered.Reached(StateRead) || MessageState("").Reached(StateQueued) {
+		t.Error("delivered and untracked should not have reached read/queued")
+	}
+}
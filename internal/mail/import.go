@@ -0,0 +1,655 @@
+package mail
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	netmail "net/mail"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Importers convert conversation history from other tools into mail
+// threads, so teams moving to Gas Town keep their context. Each parser
+// returns ImportedThreads; ToMessages turns a thread into mail messages that
+// share a thread ID. Beads stamps messages with the import time, so each
+// imported body starts with a header naming the original author and time.
+
+// ImportedMessage is one message from a foreign system.
+type ImportedMessage struct {
+	Author string
+	Time   time.Time
+	Body   string
+	Link   string // Permalink or original message ID, if known
+}
+
+// ImportedThread is a conversation to recreate as one mail thread.
+type ImportedThread struct {
+	Subject  string
+	Origin   string // Where it came from, e.g. "slack #general"
+	Messages []ImportedMessage
+}
+
+// importSubjectLen caps subjects derived from message text.
+const importSubjectLen = 72
+
+// ToMessages converts the thread to mail from the importer to a recipient.
+// Messages are oldest first; all but the first are replies in the same
+// thread. Imported mail is permanent, low priority, and sent without
+// notifying the recipient.
+func (t ImportedThread) ToMessages(from, to string) []*Message {
+	threadID := generateThreadID()
+	msgs := make([]*Message, 0, len(t.Messages))
+	for i, im := range t.Messages {
+		subject, typ := t.Subject, TypeNotification
+		if i > 0 {
+			subject, typ = "Re: "+t.Subject, TypeReply
+		}
+		header := fmt.Sprintf("[Imported from %s] %s, %s", t.Origin, im.Author, im.Time.UTC().Format(time.RFC3339))
+		if im.Link != "" {
+			header += "\n" + im.Link
+		}
+		msgs = append(msgs, &Message{
+			ID:             GenerateID(),
+			From:           from,
+			To:             to,
+			Subject:        subject,
+			Body:           header + "\n\n" + im.Body,
+			Timestamp:      im.Time.UTC(),
+			Priority:       PriorityLow,
+			Type:           typ,
+			ThreadID:       threadID,
+			SuppressNotify: true,
+		})
+	}
+	return msgs
+}
+
+// sortImported orders messages within each thread, then threads, oldest first.
+func sortImported(threads []ImportedThread) []ImportedThread {
+	kept := threads[:0]
+	for _, t := range threads {
+		if len(t.Messages) == 0 {
+			continue
+		}
+		sort.SliceStable(t.Messages, func(i, j int) bool { return t.Messages[i].Time.Before(t.Messages[j].Time) })
+		kept = append(kept, t)
+	}
+	sort.SliceStable(kept, func(i, j int) bool { return kept[i].Messages[0].Time.Before(kept[j].Messages[0].Time) })
+	return kept
+}
+
+// subjectFromText derives a subject from the first line of a message.
+func subjectFromText(text string) string {
+	line := strings.TrimSpace(strings.SplitN(strings.TrimSpace(text), "\n", 2)[0])
+	if r := []rune(line); len(r) > importSubjectLen {
+		line = string(r[:importSubjectLen-1]) + "…"
+	}
+	return line
+}
+
+// --- Slack ---
+
+// slackMessage is a message in a Slack export day file.
+type slackMessage struct {
+	Type        string `json:"type"`
+	Subtype     string `json:"subtype"`
+	User        string `json:"user"`
+	Username    string `json:"username"`
+	Text        string `json:"text"`
+	Ts          string `json:"ts"`
+	ThreadTs    string `json:"thread_ts"`
+	UserProfile struct {
+		RealName    string `json:"real_name"`
+		DisplayName string `json:"display_name"`
+	} `json:"user_profile"`
+}
+
+type slackUser struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Profile struct {
+		RealName    string `json:"real_name"`
+		DisplayName string `json:"display_name"`
+	} `json:"profile"`
+}
+
+// slackSkipSubtypes are membership and housekeeping events, not conversation.
+var slackSkipSubtypes = map[string]bool{
+	"channel_join": true, "channel_leave": true, "channel_topic": true,
+	"channel_purpose": true, "channel_name": true, "channel_archive": true,
+	"bot_add": true, "bot_remove": true, "pinned_item": true,
+}
+
+var (
+	slackMentionRe = regexp.MustCompile(`<@([A-Z0-9]+)(?:\|[^>]*)?>`)
+	slackLinkRe    = regexp.MustCompile(`<((?:https?|mailto):[^|>]+)(?:\|([^>]+))?>`)
+)
+
+// ParseSlackExport reads a Slack workspace export. path may be the unzipped
+// export directory (every channel), one channel's directory, or a single
+// day file. Slack threads become mail threads; messages outside a thread
+// are grouped into one thread per channel per day.
+func ParseSlackExport(path string) ([]ImportedThread, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var channelDirs []string
+	var dayFiles []string
+	exportRoot := path
+	switch {
+	case !info.IsDir():
+		dayFiles = []string{path}
+		exportRoot = filepath.Dir(filepath.Dir(path))
+	case fileExists(filepath.Join(path, "channels.json")) || fileExists(filepath.Join(path, "users.json")):
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range entries {
+			if e.IsDir() {
+				channelDirs = append(channelDirs, filepath.Join(path, e.Name()))
+			}
+		}
+	default:
+		channelDirs = []string{path}
+		exportRoot = filepath.Dir(path)
+	}
+	for _, dir := range channelDirs {
+		files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+		if err != nil {
+			return nil, err
+		}
+		dayFiles = append(dayFiles, files...)
+	}
+	if len(dayFiles) == 0 {
+		return nil, fmt.Errorf("no Slack day files found in %s", path)
+	}
+
+	users := loadSlackUsers(filepath.Join(exportRoot, "users.json"))
+	threads := make(map[string]*ImportedThread)
+	var order []string
+	for _, file := range dayFiles {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		var msgs []slackMessage
+		if err := json.Unmarshal(data, &msgs); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", file, err)
+		}
+		channel := filepath.Base(filepath.Dir(file))
+		day := strings.TrimSuffix(filepath.Base(file), ".json")
+		for _, m := range msgs {
+			if m.Type != "message" || slackSkipSubtypes[m.Subtype] || strings.TrimSpace(m.Text) == "" {
+				continue
+			}
+			key, subject := channel+"/day/"+day, fmt.Sprintf("#%s %s", channel, day)
+			if m.ThreadTs != "" {
+				key = channel + "/thread/" + m.ThreadTs
+				subject = "#" + channel + ": " + subjectFromText(slackText(m.Text, users))
+			}
+			t, ok := threads[key]
+			if !ok {
+				t = &ImportedThread{Subject: subject, Origin: "slack #" + channel}
+				threads[key] = t
+				order = append(order, key)
+			}
+			if m.ThreadTs != "" && m.Ts == m.ThreadTs {
+				t.Subject = subject // The thread root names the thread
+			}
+			t.Messages = append(t.Messages, ImportedMessage{
+				Author: slackAuthor(m, users),
+				Time:   slackTime(m.Ts),
+				Body:   slackText(m.Text, users),
+			})
+		}
+	}
+
+	result := make([]ImportedThread, 0, len(order))
+	for _, key := range order {
+		result = append(result, *threads[key])
+	}
+	return sortImported(result), nil
+}
+
+// loadSlackUsers maps user IDs to display names. A missing users.json
+// leaves raw IDs in place.
+func loadSlackUsers(path string) map[string]string {
+	names := make(map[string]string)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return names
+	}
+	var users []slackUser
+	if json.Unmarshal(data, &users) != nil {
+		return names
+	}
+	for _, u := range users {
+		switch {
+		case u.Profile.DisplayName != "":
+			names[u.ID] = u.Profile.DisplayName
+		case u.Profile.RealName != "":
+			names[u.ID] = u.Profile.RealName
+		default:
+			names[u.ID] = u.Name
+		}
+	}
+	return names
+}
+
+func slackAuthor(m slackMessage, users map[string]string) string {
+	if name, ok := users[m.User]; ok && name != "" {
+		return name
+	}
+	for _, name := range []string{m.UserProfile.DisplayName, m.UserProfile.RealName, m.Username, m.User} {
+		if name != "" {
+			return name
+		}
+	}
+	return "unknown"
+}
+
+// slackText resolves user mentions and unwraps Slack's <url|label> links.
+func slackText(text string, users map[string]string) string {
+	text = slackMentionRe.ReplaceAllStringFunc(text, func(s string) string {
+		id := slackMentionRe.FindStringSubmatch(s)[1]
+		if name, ok := users[id]; ok {
+			return "@" + name
+		}
+		return "@" + id
+	})
+	text = slackLinkRe.ReplaceAllStringFunc(text, func(s string) string {
+		m := slackLinkRe.FindStringSubmatch(s)
+		if m[2] != "" && m[2] != m[1] {
+			return m[2] + " (" + m[1] + ")"
+		}
+		return m[1]
+	})
+	r := strings.NewReplacer("&lt;", "<", "&gt;", ">", "&amp;", "&")
+	return r.Replace(text)
+}
+
+// slackTime converts a Slack "seconds.micros" timestamp.
+func slackTime(ts string) time.Time {
+	secs, frac, _ := strings.Cut(ts, ".")
+	s, err := strconv.ParseInt(secs, 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	var ns int64
+	if frac != "" {
+		frac = (frac + "000000000")[:9]
+		ns, _ = strconv.ParseInt(frac, 10, 64)
+	}
+	return time.Unix(s, ns).UTC()
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// --- mbox ---
+
+// ParseMbox reads an mbox file. Messages are threaded by their References
+// and In-Reply-To headers; messages without either start a new thread.
+func ParseMbox(r io.Reader) ([]ImportedThread, error) {
+	raw, err := splitMbox(r)
+	if err != nil {
+		return nil, err
+	}
+
+	threads := make(map[string]*ImportedThread)
+	threadOf := make(map[string]string) // Message-ID -> thread key
+	var order []string
+	for i, data := range raw {
+		msg, err := netmail.ReadMessage(bytes.NewReader(data))
+		if err != nil {
+			continue // Skip unparseable messages rather than abandoning the import
+		}
+		h := msg.Header
+		id := strings.TrimSpace(h.Get("Message-ID"))
+		if id == "" {
+			id = fmt.Sprintf("<mbox-%d>", i)
+		}
+
+		// Join the thread of the first ancestor we've seen; otherwise the
+		// oldest referenced ID roots a thread on its own.
+		refs := strings.Fields(h.Get("References"))
+		if irt := strings.TrimSpace(h.Get("In-Reply-To")); irt != "" {
+			refs = append(refs, strings.Fields(irt)...)
+		}
+		key := id
+		for _, ref := range refs {
+			if k, ok := threadOf[ref]; ok {
+				key = k
+				break
+			}
+		}
+		if key == id && len(refs) > 0 {
+			key = refs[0]
+		}
+		threadOf[id] = key
+
+		subject := decodeMIMEHeader(h.Get("Subject"))
+		t, ok := threads[key]
+		if !ok {
+			t = &ImportedThread{Subject: normalizeSubject(subject), Origin: "mbox"}
+			if t.Subject == "" {
+				t.Subject = "(no subject)"
+			}
+			threads[key] = t
+			order = append(order, key)
+		}
+		when, _ := h.Date()
+		body, err := mboxBody(msg)
+		if err != nil {
+			body = fmt.Sprintf("(could not decode body: %v)", err)
+		}
+		t.Messages = append(t.Messages, ImportedMessage{
+			Author: mboxAuthor(decodeMIMEHeader(h.Get("From"))),
+			Time:   when.UTC(),
+			Body:   strings.TrimSpace(body),
+			Link:   id,
+		})
+	}
+
+	result := make([]ImportedThread, 0, len(order))
+	for _, key := range order {
+		result = append(result, *threads[key])
+	}
+	return sortImported(result), nil
+}
+
+// splitMbox splits an mbox stream on its "From " separator lines and
+// undoes ">From " quoting.
+func splitMbox(r io.Reader) ([][]byte, error) {
+	var msgs [][]byte
+	var cur *bytes.Buffer
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	prevBlank := true
+	for scanner.Scan() {
+		line := scanner.Text()
+		if prevBlank && strings.HasPrefix(line, "From ") {
+			if cur != nil {
+				msgs = append(msgs, cur.Bytes())
+			}
+			cur = &bytes.Buffer{}
+			prevBlank = false
+			continue
+		}
+		prevBlank = line == ""
+		if cur == nil {
+			continue // Preamble before the first separator
+		}
+		if strings.HasPrefix(line, ">") && strings.HasPrefix(strings.TrimLeft(line, ">"), "From ") {
+			line = line[1:]
+		}
+		cur.WriteString(line)
+		cur.WriteByte('\n')
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if cur != nil {
+		msgs = append(msgs, cur.Bytes())
+	}
+	if len(msgs) == 0 {
+		return nil, fmt.Errorf("no messages found (is this an mbox file?)")
+	}
+	return msgs, nil
+}
+
+// mboxBody returns the message's plain-text body, taking the first
+// text/plain part of a multipart message.
+func mboxBody(msg *netmail.Message) (string, error) {
+	return decodeBodyPart(msg.Header.Get("Content-Type"), msg.Header.Get("Content-Transfer-Encoding"), msg.Body)
+}
+
+func decodeBodyPart(contentType, encoding string, body io.Reader) (string, error) {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = "text/plain"
+	}
+	if strings.HasPrefix(mediaType, "multipart/") {
+		mr := multipart.NewReader(body, params["boundary"])
+		var fallback string
+		for {
+			part, err := mr.NextPart()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return fallback, err
+			}
+			text, err := decodeBodyPart(part.Header.Get("Content-Type"), part.Header.Get("Content-Transfer-Encoding"), part)
+			if err != nil {
+				continue
+			}
+			partType, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+			if partType == "text/plain" || partType == "" {
+				return text, nil
+			}
+			if fallback == "" {
+				fallback = text
+			}
+		}
+		return fallback, nil
+	}
+
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "quoted-printable":
+		body = quotedprintable.NewReader(body)
+	case "base64":
+		body = base64.NewDecoder(base64.StdEncoding, newlineStripper{body})
+	}
+	data, err := io.ReadAll(body)
+	return string(data), err
+}
+
+// newlineStripper drops line breaks so base64 bodies decode.
+type newlineStripper struct{ r io.Reader }
+
+func (n newlineStripper) Read(p []byte) (int, error) {
+	c, err := n.r.Read(p)
+	out := p[:0]
+	for _, b := range p[:c] {
+		if b != '\r' && b != '\n' {
+			out = append(out, b)
+		}
+	}
+	return len(out), err
+}
+
+func decodeMIMEHeader(s string) string {
+	dec := new(mime.WordDecoder)
+	if out, err := dec.DecodeHeader(s); err == nil {
+		return out
+	}
+	return s
+}
+
+func mboxAuthor(from string) string {
+	addr, err := netmail.ParseAddress(from)
+	if err != nil {
+		if from == "" {
+			return "unknown"
+		}
+		return from
+	}
+	if addr.Name != "" {
+		return fmt.Sprintf("%s <%s>", addr.Name, addr.Address)
+	}
+	return addr.Address
+}
+
+// normalizeSubject strips reply and forward prefixes.
+func normalizeSubject(s string) string {
+	s = strings.TrimSpace(s)
+	for {
+		lower := strings.ToLower(s)
+		trimmed := false
+		for _, p := range []string{"re:", "fwd:", "fw:"} {
+			if strings.HasPrefix(lower, p) {
+				s = strings.TrimSpace(s[len(p):])
+				trimmed = true
+			}
+		}
+		if !trimmed {
+			return s
+		}
+	}
+}
+
+// --- GitHub ---
+
+// ghIssue is the output of gh issue view/list --json with comments.
+type ghIssue struct {
+	Number    int       `json:"number"`
+	Title     string    `json:"title"`
+	Body      string    `json:"body"`
+	URL       string    `json:"url"`
+	CreatedAt time.Time `json:"createdAt"`
+	Author    struct {
+		Login string `json:"login"`
+	} `json:"author"`
+	Comments []struct {
+		Body      string    `json:"body"`
+		URL       string    `json:"url"`
+		CreatedAt time.Time `json:"createdAt"`
+		Author    struct {
+			Login string `json:"login"`
+		} `json:"author"`
+	} `json:"comments"`
+}
+
+// ghRESTComment is an issue comment from the REST API
+// (GET /repos/{owner}/{repo}/issues/{n}/comments).
+type ghRESTComment struct {
+	Body      string    `json:"body"`
+	HTMLURL   string    `json:"html_url"`
+	IssueURL  string    `json:"issue_url"`
+	CreatedAt time.Time `json:"created_at"`
+	User      struct {
+		Login string `json:"login"`
+	} `json:"user"`
+}
+
+// ParseGitHubComments reads GitHub issue discussion as JSON, in either of
+// the forms the gh CLI produces:
+//
+//	gh issue view N --json number,title,body,author,createdAt,url,comments
+//	gh api repos/OWNER/REPO/issues/N/comments
+//
+// An array of issues (gh issue list --json ...,comments) is also accepted.
+// Each issue becomes one thread.
+func ParseGitHubComments(r io.Reader) ([]ImportedThread, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return nil, fmt.Errorf("empty input")
+	}
+
+	if data[0] == '{' {
+		var issue ghIssue
+		if err := json.Unmarshal(data, &issue); err != nil {
+			return nil, fmt.Errorf("parsing GitHub issue: %w", err)
+		}
+		return sortImported([]ImportedThread{ghIssueThread(issue)}), nil
+	}
+
+	// An array: REST comments carry issue_url; gh issues carry a title.
+	var probe []map[string]json.RawMessage
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return nil, fmt.Errorf("parsing GitHub JSON: %w", err)
+	}
+	if len(probe) == 0 {
+		return nil, nil
+	}
+	if _, ok := probe[0]["issue_url"]; ok {
+		var comments []ghRESTComment
+		if err := json.Unmarshal(data, &comments); err != nil {
+			return nil, fmt.Errorf("parsing GitHub comments: %w", err)
+		}
+		return sortImported(ghRESTThreads(comments)), nil
+	}
+	var issues []ghIssue
+	if err := json.Unmarshal(data, &issues); err != nil {
+		return nil, fmt.Errorf("parsing GitHub issues: %w", err)
+	}
+	threads := make([]ImportedThread, 0, len(issues))
+	for _, issue := range issues {
+		threads = append(threads, ghIssueThread(issue))
+	}
+	return sortImported(threads), nil
+}
+
+func ghIssueThread(issue ghIssue) ImportedThread {
+	t := ImportedThread{
+		Subject: fmt.Sprintf("#%d %s", issue.Number, issue.Title),
+		Origin:  "github " + ghIssueRef(issue.URL),
+	}
+	if strings.TrimSpace(issue.Body) != "" {
+		t.Messages = append(t.Messages, ImportedMessage{
+			Author: issue.Author.Login, Time: issue.CreatedAt, Body: issue.Body, Link: issue.URL,
+		})
+	}
+	for _, c := range issue.Comments {
+		t.Messages = append(t.Messages, ImportedMessage{
+			Author: c.Author.Login, Time: c.CreatedAt, Body: c.Body, Link: c.URL,
+		})
+	}
+	return t
+}
+
+func ghRESTThreads(comments []ghRESTComment) []ImportedThread {
+	byIssue := make(map[string]*ImportedThread)
+	var order []string
+	for _, c := range comments {
+		t, ok := byIssue[c.IssueURL]
+		if !ok {
+			ref := ghIssueRef(c.IssueURL)
+			t = &ImportedThread{Subject: "Comments on " + ref, Origin: "github " + ref}
+			byIssue[c.IssueURL] = t
+			order = append(order, c.IssueURL)
+		}
+		t.Messages = append(t.Messages, ImportedMessage{
+			Author: c.User.Login, Time: c.CreatedAt, Body: c.Body, Link: c.HTMLURL,
+		})
+	}
+	threads := make([]ImportedThread, 0, len(order))
+	for _, key := range order {
+		threads = append(threads, *byIssue[key])
+	}
+	return threads
+}
+
+// ghIssueRef turns an issue URL (web or API) into "owner/repo#N".
+func ghIssueRef(url string) string {
+	parts := strings.Split(strings.TrimSuffix(url, "/"), "/")
+	if len(parts) >= 4 && (parts[len(parts)-2] == "issues" || parts[len(parts)-2] == "pull") {
+		return fmt.Sprintf("%s/%s#%s", parts[len(parts)-4], parts[len(parts)-3], parts[len(parts)-1])
+	}
+	if url == "" {
+		return "issue"
+	}
+	return url
+}
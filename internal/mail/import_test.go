@@ -0,0 +1,191 @@
+package mail
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestParseSlackExport(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "channels.json"), `[{"id":"C1","name":"eng"}]`)
+	writeFile(t, filepath.Join(root, "users.json"), `[
+		{"id":"U1","name":"alice","profile":{"display_name":"Alice"}},
+		{"id":"U2","name":"bob","profile":{"real_name":"Bob B"}}]`)
+	writeFile(t, filepath.Join(root, "eng", "2026-05-01.json"), `[
+		{"type":"message","subtype":"channel_join","user":"U2","text":"<@U2> has joined","ts":"1777600000.000100"},
+		{"type":"message","user":"U1","text":"morning all","ts":"1777600100.000100"},
+		{"type":"message","user":"U1","text":"Deploy plan for v2\nsee <https://example.com/plan|the plan>","ts":"1777600200.000100","thread_ts":"1777600200.000100"},
+		{"type":"message","user":"U2","text":"<@U1> LGTM","ts":"1777600300.000100","thread_ts":"1777600200.000100"},
+		{"type":"message","user":"U2","text":"lunch?","ts":"1777600400.000100"}]`)
+
+	threads, err := ParseSlackExport(root)
+	if err != nil {
+		t.Fatalf("ParseSlackExport: %v", err)
+	}
+	if len(threads) != 2 {
+		t.Fatalf("got %d threads, want 2: %+v", len(threads), threads)
+	}
+
+	day, thread := threads[0], threads[1]
+	if day.Subject != "#eng 2026-05-01" || len(day.Messages) != 2 {
+		t.Errorf("day thread = %q with %d messages", day.Subject, len(day.Messages))
+	}
+	if thread.Subject != "#eng: Deploy plan for v2" || thread.Origin != "slack #eng" {
+		t.Errorf("thread = %q from %q", thread.Subject, thread.Origin)
+	}
+	if got := thread.Messages[0].Body; !strings.Contains(got, "the plan (https://example.com/plan)") {
+		t.Errorf("link not unwrapped: %q", got)
+	}
+	if got := thread.Messages[1]; got.Author != "Bob B" || got.Body != "@Alice LGTM" {
+		t.Errorf("reply = %+v", got)
+	}
+	if want := time.Unix(1777600300, 100000).UTC(); !thread.Messages[1].Time.Equal(want) {
+		t.Errorf("reply time = %v, want %v", thread.Messages[1].Time, want)
+	}
+
+	// A single channel directory works too.
+	chanThreads, err := ParseSlackExport(filepath.Join(root, "eng"))
+	if err != nil || len(chanThreads) != 2 {
+		t.Errorf("channel dir: %d threads, err %v", len(chanThreads), err)
+	}
+}
+
+const testMbox = `From alice@example.com Mon May  4 10:00:00 2026
+From: Alice <alice@example.com>
+To: team@example.com
+Subject: Release checklist
+Date: Mon, 04 May 2026 10:00:00 +0000
+Message-ID: <a1@example.com>
+
+Here is the checklist.
+>From the top, please.
+
+From bob@example.com Mon May  4 11:00:00 2026
+From: bob@example.com
+Subject: Re: Release checklist
+Date: Mon, 04 May 2026 07:00:00 -0400
+Message-ID: <b1@example.com>
+In-Reply-To: <a1@example.com>
+References: <a1@example.com>
+Content-Type: multipart/alternative; boundary="XX"
+
+--XX
+Content-Type: text/html
+
+<p>html version</p>
+--XX
+Content-Type: text/plain
+Content-Transfer-Encoding: quoted-printable
+
+Looks good =E2=9C=93
+--XX--
+
+From carol@example.com Mon May  4 12:00:00 2026
+From: =?UTF-8?Q?Carol_=C3=85?= <carol@example.com>
+Subject: Unrelated
+Date: Mon, 04 May 2026 12:00:00 +0000
+Message-ID: <c1@example.com>
+
+Different topic.
+`
+
+func TestParseMbox(t *testing.T) {
+	threads, err := ParseMbox(strings.NewReader(testMbox))
+	if err != nil {
+		t.Fatalf("ParseMbox: %v", err)
+	}
+	if len(threads) != 2 {
+		t.Fatalf("got %d threads, want 2", len(threads))
+	}
+	release := threads[0]
+	if release.Subject != "Release checklist" || len(release.Messages) != 2 {
+		t.Fatalf("thread = %q with %d messages", release.Subject, len(release.Messages))
+	}
+	if got := release.Messages[0].Body; got != "Here is the checklist.\nFrom the top, please." {
+		t.Errorf("body = %q", got)
+	}
+	reply := release.Messages[1]
+	if reply.Body != "Looks good ✓" || reply.Author != "bob@example.com" {
+		t.Errorf("reply = %+v", reply)
+	}
+	if want := time.Date(2026, 5, 4, 11, 0, 0, 0, time.UTC); !reply.Time.Equal(want) || reply.Time.Location() != time.UTC {
+		t.Errorf("reply time = %v, want %v in UTC", reply.Time, want)
+	}
+	if got := threads[1].Messages[0].Author; got != "Carol Å <carol@example.com>" {
+		t.Errorf("author = %q", got)
+	}
+}
+
+func TestParseGitHubComments(t *testing.T) {
+	view := `{"number":42,"title":"Flaky test","body":"It fails sometimes","url":"https://github.com/acme/app/issues/42",
+		"createdAt":"2026-05-01T10:00:00Z","author":{"login":"alice"},
+		"comments":[{"body":"Seen it too","url":"https://github.com/acme/app/issues/42#c1","createdAt":"2026-05-01T11:00:00Z","author":{"login":"bob"}}]}`
+	threads, err := ParseGitHubComments(strings.NewReader(view))
+	if err != nil {
+		t.Fatalf("gh issue view: %v", err)
+	}
+	if len(threads) != 1 || threads[0].Subject != "#42 Flaky test" || threads[0].Origin != "github acme/app#42" || len(threads[0].Messages) != 2 {
+		t.Errorf("gh issue view threads = %+v", threads)
+	}
+
+	rest := `[{"body":"first","html_url":"https://github.com/acme/app/issues/7#c1","issue_url":"https://api.github.com/repos/acme/app/issues/7",
+		"created_at":"2026-05-02T10:00:00Z","user":{"login":"carol"}},
+		{"body":"second","html_url":"https://github.com/acme/app/issues/7#c2","issue_url":"https://api.github.com/repos/acme/app/issues/7",
+		"created_at":"2026-05-02T09:00:00Z","user":{"login":"dan"}}]`
+	threads, err = ParseGitHubComments(strings.NewReader(rest))
+	if err != nil {
+		t.Fatalf("REST comments: %v", err)
+	}
+	if len(threads) != 1 || threads[0].Subject != "Comments on acme/app#7" {
+		t.Fatalf("REST threads = %+v", threads)
+	}
+	if threads[0].Messages[0].Author != "dan" {
+		t.Errorf("messages not sorted oldest first: %+v", threads[0].Messages)
+	}
+}
+
+func TestImportedThreadToMessages(t *testing.T) {
+	thread := ImportedThread{
+		Subject: "Release checklist",
+		Origin:  "mbox",
+		Messages: []ImportedMessage{
+			{Author: "alice", Time: time.Date(2026, 5, 4, 10, 0, 0, 0, time.UTC), Body: "first", Link: "<a1@example.com>"},
+			{Author: "bob", Time: time.Date(2026, 5, 4, 11, 0, 0, 0, time.UTC), Body: "second"},
+		},
+	}
+	msgs := thread.ToMessages("mayor/", "gastown/crew/max")
+	if len(msgs) != 2 {
+		t.Fatalf("got %d messages", len(msgs))
+	}
+	if msgs[0].ThreadID == "" || msgs[0].ThreadID != msgs[1].ThreadID {
+		t.Errorf("thread IDs %q, %q should match", msgs[0].ThreadID, msgs[1].ThreadID)
+	}
+	if msgs[1].Subject != "Re: Release checklist" || msgs[1].Type != TypeReply {
+		t.Errorf("reply = %q (%s)", msgs[1].Subject, msgs[1].Type)
+	}
+	want := "[Imported from mbox] alice, 2026-05-04T10:00:00Z\n<a1@example.com>\n\nfirst"
+	if msgs[0].Body != want {
+		t.Errorf("body = %q, want %q", msgs[0].Body, want)
+	}
+	for _, m := range msgs {
+		if !m.SuppressNotify || m.Wisp || m.Priority != PriorityLow {
+			t.Errorf("imported message flags wrong: %+v", m)
+		}
+		if err := m.Validate(); err != nil {
+			t.Errorf("Validate: %v", err)
+		}
+	}
+}
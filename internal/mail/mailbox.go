@@ -632,14 +632,30 @@ func (m *Mailbox) ArchivePath() string {
 	return filepath.Join(m.beadsDir, "archive.jsonl")
 }
 
+// lockArchive takes an exclusive lock on the archive file. Beads mailboxes
+// share one archive per .beads directory, so every agent archiving or
+// trimming goes through this lock; otherwise a trim's rewrite could drop
+// messages appended while it ran. Callers must defer Unlock.
+func (m *Mailbox) lockArchive() (*flock.Flock, error) {
+	archivePath := m.ArchivePath()
+	if err := os.MkdirAll(filepath.Dir(archivePath), 0755); err != nil {
+		return nil, err
+	}
+	fl := flock.New(archivePath + ".lock")
+	if err := fl.Lock(); err != nil {
+		return nil, fmt.Errorf("acquiring archive lock: %w", err)
+	}
+	return fl, nil
+}
+
 func (m *Mailbox) appendToArchive(msg *Message) error {
 	archivePath := m.ArchivePath()
 
-	// Ensure directory exists
-	dir := filepath.Dir(archivePath)
-	if err := os.MkdirAll(dir, 0755); err != nil {
+	fl, err := m.lockArchive()
+	if err != nil {
 		return err
 	}
+	defer func() { _ = fl.Unlock() }()
 
 	// Open for append
 	file, err := os.OpenFile(archivePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644) //nolint:gosec // G302: archive is non-sensitive operational data
@@ -704,6 +720,11 @@ func (m *Mailbox) PurgeArchive(olderThanDays int) (int, error) {
 		}
 		defer func() { _ = fl.Unlock() }()
 	}
+	al, err := m.lockArchive()
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = al.Unlock() }()
 
 	messages, err := m.ListArchived()
 	if err != nil {
@@ -749,6 +770,55 @@ func (m *Mailbox) PurgeArchive(olderThanDays int) (int, error) {
 	return purged, nil
 }
 
+// TrimArchive drops the oldest archived messages until the archive is at
+// most maxBytes. Returns the number of messages removed.
+func (m *Mailbox) TrimArchive(maxBytes int64) (int, error) {
+	if m.legacy {
+		fl, err := m.lockLegacy()
+		if err != nil {
+			return 0, err
+		}
+		defer func() { _ = fl.Unlock() }()
+	}
+	al, err := m.lockArchive()
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = al.Unlock() }()
+
+	messages, err := m.ListArchived()
+	if err != nil {
+		return 0, err
+	}
+
+	// Keep the newest messages that fit, walking back from the end
+	var size int64
+	keepFrom := len(messages)
+	for i := len(messages) - 1; i >= 0; i-- {
+		data, err := json.Marshal(messages[i])
+		if err != nil {
+			return 0, err
+		}
+		size += int64(len(data)) + 1
+		if size > maxBytes {
+			break
+		}
+		keepFrom = i
+	}
+	if keepFrom == 0 {
+		return 0, nil
+	}
+
+	if keepFrom == len(messages) {
+		if err := os.Remove(m.ArchivePath()); err != nil && !os.IsNotExist(err) {
+			return 0, err
+		}
+	} else if err := m.rewriteArchive(messages[keepFrom:]); err != nil {
+		return 0, err
+	}
+	return keepFrom, nil
+}
+
 func (m *Mailbox) rewriteArchive(messages []*Message) error {
 	archivePath := m.ArchivePath()
 	tmpPath := archivePath + ".tmp"
@@ -933,6 +1003,15 @@ func (m *Mailbox) AcknowledgeDeliveries(recipientAddress string, messages []*Mes
 	return nil
 }
 
+// RecordState records that a message reached a lifecycle state (see
+// MessageState). Legacy mailboxes don't track state.
+func (m *Mailbox) RecordState(id string, state MessageState) error {
+	if m.legacy {
+		return nil
+	}
+	return RecordMessageState(m.workDir, m.beadsDir, id, state)
+}
+
 // Append adds a message to the mailbox (legacy mode only).
 // For beads mode, use Router.Send() instead.
 func (m *Mailbox) Append(msg *Message) error {
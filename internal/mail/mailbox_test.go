@@ -704,3 +704,48 @@ func TestMailboxLegacyAtomicArchive(t *testing.T) {
 	}
 }
 
+
+func TestMailboxLegacyTrimArchive(t *testing.T) {
+	tmpDir := t.TempDir()
+	m := NewMailbox(tmpDir)
+
+	for i := 1; i <= 5; i++ {
+		msg := &Message{ID: fmt.Sprintf("msg-%03d", i), Subject: "Archived", Timestamp: time.Date(2026, 3, 1, 12, 0, i, 0, time.UTC)}
+		if err := m.Append(msg); err != nil {
+			t.Fatalf("Append error: %v", err)
+		}
+		if err := m.Archive(msg.ID); err != nil {
+			t.Fatalf("Archive error: %v", err)
+		}
+	}
+	info, err := os.Stat(m.ArchivePath())
+	if err != nil {
+		t.Fatalf("Stat archive: %v", err)
+	}
+
+	// Room for about two messages: the three oldest go.
+	n, err := m.TrimArchive(info.Size() * 2 / 5)
+	if err != nil || n != 3 {
+		t.Fatalf("TrimArchive = %d, %v; want 3", n, err)
+	}
+	archived, err := m.ListArchived()
+	if err != nil {
+		t.Fatalf("ListArchived error: %v", err)
+	}
+	if len(archived) != 2 || archived[0].ID != "msg-004" || archived[1].ID != "msg-005" {
+		t.Errorf("archive after trim = %v; want msg-004, msg-005", archived)
+	}
+
+	// Already under the cap: nothing to do.
+	if n, err := m.TrimArchive(info.Size()); err != nil || n != 0 {
+		t.Errorf("TrimArchive under cap = %d, %v; want 0", n, err)
+	}
+
+	// No room at all: the archive goes.
+	if n, err := m.TrimArchive(0); err != nil || n != 2 {
+		t.Errorf("TrimArchive(0) = %d, %v; want 2", n, err)
+	}
+	if _, err := os.Stat(m.ArchivePath()); !os.IsNotExist(err) {
+		t.Errorf("archive still exists after TrimArchive(0): %v", err)
+	}
+}
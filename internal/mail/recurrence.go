@@ -0,0 +1,214 @@
+package mail
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Recurrence is how often a scheduled message repeats: either a fixed
+// interval ("30m", "24h") or a cron-like schedule in local time.
+type Recurrence struct {
+	spec  string
+	every time.Duration
+	cron  *cronSchedule
+}
+
+// recurrenceAliases are the named schedules accepted in place of cron fields.
+var recurrenceAliases = map[string]string{
+	"@hourly":   "0 * * * *",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@weekdays": "0 9 * * 1-5",
+	"@weekly":   "0 0 * * 0",
+	"@monthly":  "0 0 1 * *",
+}
+
+// minRecurrenceInterval keeps interval schedules from flooding inboxes; the
+// scheduler only runs about once a minute anyway.
+const minRecurrenceInterval = time.Minute
+
+// ParseRecurrence parses a recurrence spec: a Go duration of at least a
+// minute ("90m", "24h"), a named schedule (@hourly, @daily, @weekdays,
+// @weekly, @monthly), or five cron fields "minute hour day-of-month month
+// day-of-week" supporting *, lists, ranges, and steps ("0 9 * * 1-5").
+func ParseRecurrence(spec string) (*Recurrence, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return nil, fmt.Errorf("empty recurrence")
+	}
+	if d, err := time.ParseDuration(spec); err == nil {
+		if d < minRecurrenceInterval {
+			return nil, fmt.Errorf("recurrence %q: interval must be at least %s", spec, minRecurrenceInterval)
+		}
+		return &Recurrence{spec: spec, every: d}, nil
+	}
+
+	fields := spec
+	if strings.HasPrefix(spec, "@") {
+		expanded, ok := recurrenceAliases[spec]
+		if !ok {
+			return nil, fmt.Errorf("recurrence %q: unknown schedule name", spec)
+		}
+		fields = expanded
+	}
+	cron, err := parseCron(fields)
+	if err != nil {
+		return nil, fmt.Errorf("recurrence %q: %w", spec, err)
+	}
+	return &Recurrence{spec: spec, cron: cron}, nil
+}
+
+// String returns the spec the recurrence was parsed from.
+func (r *Recurrence) String() string {
+	return r.spec
+}
+
+// Next returns the first occurrence strictly after t.
+func (r *Recurrence) Next(t time.Time) time.Time {
+	if r.cron == nil {
+		return t.Add(r.every)
+	}
+	return r.cron.next(t)
+}
+
+// cronSchedule holds the allowed values of each cron field.
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64 // Bit n set when value n is allowed
+	domAny, dowAny                bool
+}
+
+// cronField describes the range of one cron field.
+type cronField struct {
+	name     string
+	min, max int
+}
+
+var cronFields = []cronField{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7}, // 0 and 7 are both Sunday
+}
+
+func parseCron(spec string) (*cronSchedule, error) {
+	parts := strings.Fields(spec)
+	if len(parts) != len(cronFields) {
+		return nil, fmt.Errorf("want a duration, @name, or 5 cron fields, got %d fields", len(parts))
+	}
+	var bits [5]uint64
+	for i, part := range parts {
+		b, err := parseCronField(part, cronFields[i])
+		if err != nil {
+			return nil, err
+		}
+		bits[i] = b
+	}
+	if bits[4]&(1<<7) != 0 {
+		bits[4] |= 1
+	}
+	return &cronSchedule{
+		minute: bits[0],
+		hour:   bits[1],
+		dom:    bits[2],
+		month:  bits[3],
+		dow:    bits[4],
+		domAny: parts[2] == "*",
+		dowAny: parts[4] == "*",
+	}, nil
+}
+
+// parseCronField parses a comma-separated list of "*", "n", "a-b", each
+// optionally followed by "/step".
+func parseCronField(s string, f cronField) (uint64, error) {
+	var bits uint64
+	for _, item := range strings.Split(s, ",") {
+		rangePart, step := item, 1
+		if i := strings.Index(item, "/"); i >= 0 {
+			n, err := strconv.Atoi(item[i+1:])
+			if err != nil || n < 1 {
+				return 0, fmt.Errorf("%s: bad step in %q", f.name, item)
+			}
+			rangePart, step = item[:i], n
+		}
+
+		lo, hi := f.min, f.max
+		switch {
+		case rangePart == "*":
+		case strings.Contains(rangePart, "-"):
+			a, b, _ := strings.Cut(rangePart, "-")
+			var errA, errB error
+			lo, errA = strconv.Atoi(a)
+			hi, errB = strconv.Atoi(b)
+			if errA != nil || errB != nil || lo > hi {
+				return 0, fmt.Errorf("%s: bad range %q", f.name, rangePart)
+			}
+		default:
+			n, err := strconv.Atoi(rangePart)
+			if err != nil {
+				return 0, fmt.Errorf("%s: bad value %q", f.name, rangePart)
+			}
+			lo, hi = n, n
+			if step > 1 {
+				hi = f.max // "5/15" means 5, 20, 35, ...
+			}
+		}
+		if lo < f.min || hi > f.max {
+			return 0, fmt.Errorf("%s: %q out of range %d-%d", f.name, rangePart, f.min, f.max)
+		}
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+// maxCronSearch bounds the search for the next occurrence; any valid
+// schedule fires within a few years (Feb 29 is the worst case).
+const maxCronSearch = 5 * 366 * 24 * time.Hour
+
+// next returns the first minute strictly after t matching the schedule, in
+// t's location, or the zero time if none is found (e.g. "0 0 31 2 *").
+func (c *cronSchedule) next(t time.Time) time.Time {
+	limit := t.Add(maxCronSearch)
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	for t.Before(limit) {
+		if c.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !c.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if c.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if c.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// dayMatches applies cron's day rule: when both day of month and day of
+// week are restricted, either may match.
+func (c *cronSchedule) dayMatches(t time.Time) bool {
+	domOK := c.dom&(1<<uint(t.Day())) != 0
+	dowOK := c.dow&(1<<uint(t.Weekday())) != 0
+	switch {
+	case c.domAny && c.dowAny:
+		return true
+	case c.domAny:
+		return dowOK
+	case c.dowAny:
+		return domOK
+	default:
+		return domOK || dowOK
+	}
+}
@@ -0,0 +1,56 @@
+package mail
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRecurrence(t *testing.T) {
+	valid := []string{"30m", "24h", "@daily", "@weekdays", "0 9 * * 1-5", "*/15 * * * *", "0 0 1,15 * *", "30 2 * * 7"}
+	for _, spec := range valid {
+		if _, err := ParseRecurrence(spec); err != nil {
+			t.Errorf("ParseRecurrence(%q) error = %v", spec, err)
+		}
+	}
+	invalid := []string{"", "10s", "-1h", "@fortnightly", "0 9 * *", "60 * * * *", "0 24 * * *", "0 0 0 * *", "* * * 13 *", "5-1 * * * *", "*/0 * * * *", "a * * * *"}
+	for _, spec := range invalid {
+		if _, err := ParseRecurrence(spec); err == nil {
+			t.Errorf("ParseRecurrence(%q) succeeded, want error", spec)
+		}
+	}
+}
+
+func TestRecurrenceNext(t *testing.T) {
+	// Wednesday, 2026-03-04 10:20 UTC
+	base := time.Date(2026, 3, 4, 10, 20, 30, 0, time.UTC)
+	tests := []struct {
+		spec string
+		want time.Time
+	}{
+		{"90m", base.Add(90 * time.Minute)},
+		{"*/15 * * * *", time.Date(2026, 3, 4, 10, 30, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2026, 3, 4, 11, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC)},
+		{"0 9 * * 1-5", time.Date(2026, 3, 5, 9, 0, 0, 0, time.UTC)},
+		{"0 9 * * 0", time.Date(2026, 3, 8, 9, 0, 0, 0, time.UTC)},
+		{"0 9 * * 7", time.Date(2026, 3, 8, 9, 0, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		// Day of month or day of week when both are restricted.
+		{"0 0 10 * 5", time.Date(2026, 3, 6, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		rec, err := ParseRecurrence(tt.spec)
+		if err != nil {
+			t.Fatalf("ParseRecurrence(%q): %v", tt.spec, err)
+		}
+		if got := rec.Next(base); !got.Equal(tt.want) {
+			t.Errorf("Next(%q) = %v, want %v", tt.spec, got, tt.want)
+		}
+	}
+
+	never, _ := ParseRecurrence("0 0 31 2 *")
+	if got := never.Next(base); !got.IsZero() {
+		t.Errorf("Next(Feb 31) = %v, want zero", got)
+	}
+}
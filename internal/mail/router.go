@@ -15,6 +15,7 @@ import (
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/constants"
 	"github.com/steveyegge/gastown/internal/nudge"
+	"github.com/steveyegge/gastown/internal/redact"
 	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/telemetry"
 	"github.com/steveyegge/gastown/internal/tmux"
@@ -47,7 +48,12 @@ type Router struct {
 	// idle before falling back to a queued nudge. Zero uses the default.
 	IdleNotifyTimeout time.Duration
 
-	notifyWg sync.WaitGroup // tracks in-flight async notifications
+	notifyWg   sync.WaitGroup   // tracks in-flight async notifications
+	scheduler  *nudge.Scheduler // delivers notifications; see nudges()
+	nudgesOnce sync.Once
+
+	redactor     *redact.Redactor // masks secrets in bodies; see redactBody()
+	redactorOnce sync.Once
 }
 
 // NewRouter creates a new mail router.
@@ -58,18 +64,20 @@ func NewRouter(workDir string) *Router {
 	townRoot := detectTownRoot(workDir)
 
 	return &Router{
-		workDir:  workDir,
-		townRoot: townRoot,
-		tmux:     tmux.NewTmux(),
+		workDir:   workDir,
+		townRoot:  townRoot,
+		tmux:      tmux.NewTmux(),
+		scheduler: nudge.SchedulerFor(townRoot),
 	}
 }
 
 // NewRouterWithTownRoot creates a router with an explicit town root.
 func NewRouterWithTownRoot(workDir, townRoot string) *Router {
 	return &Router{
-		workDir:  workDir,
-		townRoot: townRoot,
-		tmux:     tmux.NewTmux(),
+		workDir:   workDir,
+		townRoot:  townRoot,
+		tmux:      tmux.NewTmux(),
+		scheduler: nudge.SchedulerFor(townRoot),
 	}
 }
 
@@ -821,7 +829,11 @@ func (r *Router) shouldBeWisp(msg *Message) bool {
 // Supports single-copy delivery for:
 // - Queues (queue:name) - stores single message for worker claiming
 // - Announces (announce:name) - bulletin board, no claiming, retention-limited
+//
+// Secrets in the body are redacted before it is stored.
 func (r *Router) Send(msg *Message) error {
+	msg.Body = r.redactBody(msg.Body)
+
 	// Check for mailing list address
 	if isListAddress(msg.To) {
 		return r.sendToList(msg)
@@ -851,6 +863,17 @@ func (r *Router) Send(msg *Message) error {
 	return r.sendToSingle(msg)
 }
 
+// redactBody masks secrets in a message body with the town's redactor.
+// Bodies often quote pane output or logs, and mail is stored in beads that
+// every agent can read.
+func (r *Router) redactBody(body string) string {
+	if body == "" {
+		return body
+	}
+	r.redactorOnce.Do(func() { r.redactor = redact.ForTown(r.townRoot) })
+	return r.redactor.String(body)
+}
+
 // sendToGroup resolves a @group address and sends individual messages to each member.
 func (r *Router) sendToGroup(msg *Message) error {
 	group := parseGroupAddress(msg.To)
@@ -1056,6 +1079,7 @@ func (r *Router) resolveCrewShorthand(identity string) string {
 
 // sendToSingle sends a message to a single recipient.
 func (r *Router) sendToSingle(msg *Message) error {
+	sentAt := time.Now() // start of the send-to-notify latency measurement
 	// Ensure message has an ID for in-memory tracking (notifications, logging).
 	// We no longer pass --id to bd create; bd auto-generates the correct prefix.
 	if msg.ID == "" {
@@ -1077,6 +1101,16 @@ func (r *Router) sendToSingle(msg *Message) error {
 		return fmt.Errorf("invalid recipient %q: %w", msg.To, err)
 	}
 
+	// Enforce the town's mail ACL (config/messaging.json "acl"). Check the
+	// address as written unless crew shorthand was expanded.
+	aclTo := msg.To
+	if AddressToIdentity(msg.To) != toIdentity {
+		aclTo = toIdentity
+	}
+	if err := r.checkACL(msg.From, aclTo); err != nil {
+		return err
+	}
+
 	// Build labels for type, from/thread/reply-to/cc
 	var labels []string
 	labels = append(labels, "gt:message")
@@ -1094,13 +1128,23 @@ func (r *Router) sendToSingle(msg *Message) error {
 		labels = append(labels, "cc:"+ccIdentity)
 	}
 
-	// Build command: bd create --assignee=<recipient> -d <body> --labels=gt:message,... -- <subject>
+	// Evaluate the recipient's mail rules (never on mail the rules send).
+	// Priority is applied before the write; the other actions after it.
+	var actions RuleActions
+	if !msg.viaRule {
+		actions = EvaluateRules(r.loadRules(), msg, labels)
+		if actions.Priority != "" {
+			msg.Priority = actions.Priority
+		}
+	}
+
+	// Build command: bd create --assignee=<recipient> --body-file - --labels=gt:message,... -- <subject>
 	// Flags go first, then -- to end flag parsing, then the positional subject.
 	// This prevents subjects like "--help" from being parsed as flags (see web/api.go).
 	// Let bd auto-generate the ID with the correct database prefix.
-	args := []string{"create",
+	args := []string{"create", "--json",
 		"--assignee", toIdentity,
-		"-d", msg.Body,
+		"--body-file", "-", // Body on stdin: attachments can exceed the argv limit
 	}
 
 	// Add priority flag
@@ -1135,7 +1179,7 @@ func (r *Router) sendToSingle(msg *Message) error {
 	}
 	ctx, cancel := bdWriteCtx()
 	defer cancel()
-	_, err := runBdCommand(ctx, args, filepath.Dir(beadsDir), beadsDir)
+	out, err := runBdCommandInput(ctx, args, filepath.Dir(beadsDir), beadsDir, EncodeBody(msg.Body, msg.Attachments))
 	telemetry.RecordMailMessage(context.Background(), "send", telemetry.MailMessageInfo{
 		ID:       msg.ID,
 		From:     msg.From,
@@ -1150,18 +1194,46 @@ func (r *Router) sendToSingle(msg *Message) error {
 		return fmt.Errorf("sending message: %w", err)
 	}
 
+	// From here on msg.ID is the bead ID, so senders can track the message
+	// with 'gt mail status'. Older bd versions print no JSON; the in-memory
+	// ID stays and state tracking is skipped.
+	beadID := ""
+	var created struct {
+		ID string `json:"id"`
+	}
+	if json.Unmarshal(out, &created) == nil && created.ID != "" {
+		beadID = created.ID
+		msg.ID = beadID
+	}
+
+	// A reply means the recipient acted on the original (best-effort).
+	// An auto-reply from a mail rule doesn't.
+	if msg.ReplyTo != "" && !msg.viaRule {
+		_ = RecordMessageState(filepath.Dir(beadsDir), beadsDir, msg.ReplyTo, StateActed)
+	}
+
+	if !actions.Empty() {
+		if err := r.applyRuleActions(msg, beadID, beadsDir, actions); err != nil {
+			logger.Warn("applying mail rule actions", "bead", beadID, "err", err)
+		}
+	}
+
 	// Notify recipient if they have an active session (best-effort notification).
-	// Skip when the caller explicitly suppressed notification (--no-notify)
+	// Skip when the caller explicitly suppressed notification (--no-notify),
+	// when a mail rule archived the message,
 	// or for self-mail (handoffs to future-self don't need present-self notified).
 	// Notification is async: the durable write is complete, so the caller
 	// doesn't block on idle probing (up to 1s per recipient in fan-out).
 	// Callers that exit soon after Send should call WaitPendingNotifications.
-	if !msg.SuppressNotify && !isSelfMail(msg.From, msg.To) {
+	if !msg.SuppressNotify && !actions.Archive && !isSelfMail(msg.From, msg.To) {
 		msgCopy := *msg // copy to avoid data race if caller mutates msg
 		r.notifyWg.Add(1)
 		go func() {
 			defer r.notifyWg.Done()
-			r.notifyRecipient(&msgCopy) //nolint:errcheck
+			delivered, _ := r.deliverNotification(&msgCopy, sentAt)
+			if delivered && beadID != "" {
+				_ = RecordMessageState(filepath.Dir(beadsDir), beadsDir, beadID, StateDelivered)
+			}
 		}()
 	}
 
@@ -1238,13 +1310,13 @@ func (r *Router) sendToQueue(msg *Message) error {
 		labels = append(labels, "cc:"+ccIdentity)
 	}
 
-	// Build command: bd create --assignee=queue:<name> -d <body> ... -- <subject>
+	// Build command: bd create --assignee=queue:<name> --body-file - ... -- <subject>
 	// Flags go first, then -- to end flag parsing, then the positional subject.
 	// This prevents subjects like "--help" from being parsed as flags.
 	// Use queue:<name> as assignee so inbox queries can filter by queue
 	args := []string{"create",
 		"--assignee", msg.To, // queue:name
-		"-d", msg.Body,
+		"--body-file", "-",
 	}
 
 	// Add priority flag
@@ -1272,7 +1344,7 @@ func (r *Router) sendToQueue(msg *Message) error {
 	}
 	ctx, cancel := bdWriteCtx()
 	defer cancel()
-	_, err = runBdCommand(ctx, args, filepath.Dir(beadsDir), beadsDir)
+	_, err = runBdCommandInput(ctx, args, filepath.Dir(beadsDir), beadsDir, EncodeBody(msg.Body, msg.Attachments))
 	if err != nil {
 		return fmt.Errorf("sending to queue %s: %w", queueName, err)
 	}
@@ -1322,13 +1394,13 @@ func (r *Router) sendToAnnounce(msg *Message) error {
 		labels = append(labels, "cc:"+ccIdentity)
 	}
 
-	// Build command: bd create --assignee=announce:<name> -d <body> ... -- <subject>
+	// Build command: bd create --assignee=announce:<name> --body-file - ... -- <subject>
 	// Flags go first, then -- to end flag parsing, then the positional subject.
 	// This prevents subjects like "--help" from being parsed as flags.
 	// Use announce:<name> as assignee so queries can filter by channel
 	args := []string{"create",
 		"--assignee", msg.To, // announce:name
-		"-d", msg.Body,
+		"--body-file", "-",
 	}
 
 	// Add priority flag
@@ -1356,7 +1428,7 @@ func (r *Router) sendToAnnounce(msg *Message) error {
 	}
 	ctx, cancel := bdWriteCtx()
 	defer cancel()
-	_, err = runBdCommand(ctx, args, filepath.Dir(beadsDir), beadsDir)
+	_, err = runBdCommandInput(ctx, args, filepath.Dir(beadsDir), beadsDir, EncodeBody(msg.Body, msg.Attachments))
 	if err != nil {
 		return fmt.Errorf("sending to announce %s: %w", announceName, err)
 	}
@@ -1408,13 +1480,13 @@ func (r *Router) sendToChannel(msg *Message) error {
 		labels = append(labels, "cc:"+ccIdentity)
 	}
 
-	// Build command: bd create --assignee=channel:<name> -d <body> ... -- <subject>
+	// Build command: bd create --assignee=channel:<name> --body-file - ... -- <subject>
 	// Flags go first, then -- to end flag parsing, then the positional subject.
 	// This prevents subjects like "--help" from being parsed as flags.
 	// Use channel:<name> as assignee so queries can filter by channel
 	args := []string{"create",
 		"--assignee", msg.To, // channel:name
-		"-d", msg.Body,
+		"--body-file", "-",
 	}
 
 	// Add priority flag
@@ -1442,7 +1514,7 @@ func (r *Router) sendToChannel(msg *Message) error {
 	}
 	ctx, cancel := bdWriteCtx()
 	defer cancel()
-	_, err = runBdCommand(ctx, args, filepath.Dir(beadsDir), beadsDir)
+	_, err = runBdCommandInput(ctx, args, filepath.Dir(beadsDir), beadsDir, EncodeBody(msg.Body, msg.Attachments))
 	if err != nil {
 		return fmt.Errorf("sending to channel %s: %w", channelName, err)
 	}
@@ -1558,17 +1630,30 @@ func (r *Router) GetMailbox(address string) (*Mailbox, error) {
 //
 // Supports mayor/, deacon/, rig/crew/name, rig/polecats/name, and rig/name addresses.
 // Respects agent DND/muted state - skips notification if recipient has DND enabled.
-func (r *Router) notifyRecipient(msg *Message) error {
+// Holds non-urgent notifications for a quiet recipient's digest (gt quiet).
+//
+// sentAt is when the send began; delivery latency from it is recorded for
+// direct nudges here and for queued nudges when they are drained. A zero
+// sentAt records nothing.
+func (r *Router) notifyRecipient(msg *Message, sentAt time.Time) error {
+	_, err := r.deliverNotification(msg, sentAt)
+	return err
+}
+
+// deliverNotification is notifyRecipient, also reporting whether the
+// notification reached the recipient's session right away (as opposed to
+// being queued, held, or skipped), which moves the message to delivered.
+func (r *Router) deliverNotification(msg *Message, sentAt time.Time) (bool, error) {
 	// Check DND status before attempting notification
 	if r.townRoot != "" {
 		if r.isRecipientMuted(msg.To) {
-			return nil // Recipient has DND enabled, skip notification
+			return false, nil // Recipient has DND enabled, skip notification
 		}
 	}
 
 	sessionIDs := AddressToSessionIDs(msg.To)
 	if len(sessionIDs) == 0 {
-		return nil // Unable to determine session ID
+		return false, nil // Unable to determine session ID
 	}
 
 	timeout := r.IdleNotifyTimeout
@@ -1588,43 +1673,45 @@ func (r *Router) notifyRecipient(msg *Message) error {
 		// Overseer is a human operator - use a visible banner instead of NudgeSession
 		// (which types into Claude's input and would disrupt the human's terminal).
 		if msg.To == "overseer" {
-			return r.tmux.SendNotificationBanner(sessionID, msg.From, msg.Subject)
+			err := r.tmux.SendNotificationBanner(sessionID, msg.From, msg.Subject)
+			return err == nil, err
 		}
 
 		notification := fmt.Sprintf("📬 You have new mail from %s. Subject: %s. Run 'gt mail inbox' to read.", msg.From, msg.Subject)
 
-		// Wait-idle-first delivery: try direct nudge if the agent is idle,
-		// fall back to cooperative queue if busy. WaitForIdle requires 2
-		// consecutive idle polls (prompt visible + no "esc to interrupt"
+		// The scheduler holds non-urgent notifications for a quiet session
+		// (gt quiet), delivers directly once the agent is idle, and queues
+		// for the next turn boundary if it stays busy. WaitForIdle requires
+		// 2 consecutive idle polls (prompt visible + no "esc to interrupt"
 		// in the status bar) to distinguish genuine idle from brief
 		// inter-tool-call gaps. See: https://github.com/steveyegge/gastown/issues/2032
-		waitErr := r.tmux.WaitForIdle(sessionID, timeout)
-		if waitErr == nil {
-			// Agent is idle — deliver directly for immediate wakeup.
-			if err := r.tmux.NudgeSession(sessionID, notification); err == nil {
-				return nil
-			} else if errors.Is(err, tmux.ErrSessionNotFound) {
-				continue
-			} else if errors.Is(err, tmux.ErrNoServer) {
-				return nil
-			}
-		} else if errors.Is(waitErr, tmux.ErrSessionNotFound) {
+		opts := nudge.SubmitOptions{Sender: msg.From, IdleTimeout: timeout}
+		if msg.Priority == PriorityUrgent {
+			opts.Priority = nudge.PriorityUrgent
+		}
+		if !sentAt.IsZero() {
+			opts.Trace = &nudge.DeliveryTrace{SentAt: sentAt, Priority: string(msg.Priority)}
+		}
+		status, err := r.nudges().Submit(sessionID, notification, opts).Wait()
+		if errors.Is(err, tmux.ErrSessionNotFound) {
 			continue
-		} else if errors.Is(waitErr, tmux.ErrNoServer) {
-			return nil
-		} else if r.townRoot != "" {
-			// Timeout (agent busy) — queue for cooperative delivery
-			// at the next turn boundary.
-			return nudge.Enqueue(r.townRoot, sessionID, nudge.QueuedNudge{
-				Sender:  msg.From,
-				Message: notification,
-			})
+		} else if errors.Is(err, tmux.ErrNoServer) {
+			return false, nil
 		}
-		// No town root available — last resort direct delivery.
-		return r.tmux.NudgeSession(sessionID, notification)
+		return status == nudge.StatusDelivered, err
 	}
 
-	return nil // No active session found
+	return false, nil // No active session found
+}
+
+// nudges returns the scheduler the router delivers notifications through.
+func (r *Router) nudges() *nudge.Scheduler {
+	r.nudgesOnce.Do(func() {
+		if r.scheduler == nil {
+			r.scheduler = nudge.NewScheduler(r.townRoot, r.tmux)
+		}
+	})
+	return r.scheduler
 }
 
 // IsRecipientMuted checks if a mail recipient has DND/muted notifications enabled.
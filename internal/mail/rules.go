@@ -0,0 +1,212 @@
+package mail
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/nudge"
+)
+
+// RuleActions is what the mail rules matching one delivery decided.
+type RuleActions struct {
+	Matched    []string // Names of the matching rules ("#n" when unnamed)
+	Archive    bool
+	Priority   Priority // Raised priority, or "" to leave it
+	Forward    []string
+	AutoReply  []string
+	NudgeAddrs []string
+}
+
+// Empty reports whether no rule matched.
+func (a RuleActions) Empty() bool {
+	return len(a.Matched) == 0
+}
+
+// RuleAppliesTo reports whether rule runs on mail for recipient.
+func RuleAppliesTo(rule config.MailRule, recipient string) bool {
+	return matchRuleAddress(rule.Agent, recipient)
+}
+
+// EvaluateRules runs the rules against a message about to be delivered to
+// msg.To, whose bead will carry labels, and combines the actions of every
+// matching rule up to the first matching rule with stop set.
+func EvaluateRules(rules []config.MailRule, msg *Message, labels []string) RuleActions {
+	var actions RuleActions
+	for i, rule := range rules {
+		if !RuleAppliesTo(rule, msg.To) || !ruleMatches(rule.Match, msg, labels) {
+			continue
+		}
+		name := rule.Name
+		if name == "" {
+			name = fmt.Sprintf("#%d", i+1)
+		}
+		actions.Matched = append(actions.Matched, name)
+		actions.Archive = actions.Archive || rule.Archive
+		if rule.Priority != "" {
+			p := ParsePriority(rule.Priority)
+			current := msg.Priority
+			if actions.Priority != "" {
+				current = actions.Priority
+			}
+			if PriorityToBeads(p) < PriorityToBeads(current) {
+				actions.Priority = p
+			}
+		}
+		if rule.Forward != "" {
+			actions.Forward = append(actions.Forward, rule.Forward)
+		}
+		if rule.AutoReply != "" {
+			actions.AutoReply = append(actions.AutoReply, rule.AutoReply)
+		}
+		if rule.Nudge != "" {
+			actions.NudgeAddrs = append(actions.NudgeAddrs, rule.Nudge)
+		}
+		if rule.Stop {
+			break
+		}
+	}
+	return actions
+}
+
+// ruleMatches reports whether every condition set in m holds for msg.
+func ruleMatches(m config.MailRuleMatch, msg *Message, labels []string) bool {
+	if m.From != "" && !matchRuleAddress(m.From, msg.From) {
+		return false
+	}
+	if m.Subject != "" {
+		re, err := regexp.Compile(m.Subject)
+		if err != nil || !re.MatchString(msg.Subject) {
+			return false // Invalid patterns are rejected when config loads
+		}
+	}
+	for _, want := range m.Labels {
+		found := false
+		for _, label := range labels {
+			if label == want {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// matchRuleAddress matches an address pattern against an address in either
+// its full ("gastown/crew/max") or canonical ("gastown/max") form.
+func matchRuleAddress(pattern, address string) bool {
+	if pattern == address || matchPattern(pattern, address) {
+		return true
+	}
+	identity := AddressToIdentity(address)
+	return pattern == identity || matchPattern(pattern, identity)
+}
+
+// loadRules returns the town's mail rules. Rules are best-effort: without a
+// town root or a readable config there are none.
+func (r *Router) loadRules() []config.MailRule {
+	if cfg := r.loadMessagingConfig(); cfg != nil {
+		return cfg.Rules
+	}
+	return nil
+}
+
+// loadMessagingConfig returns the town's messaging config, or nil without a
+// town root or a readable config.
+func (r *Router) loadMessagingConfig() *config.MessagingConfig {
+	if r.townRoot == "" {
+		return nil
+	}
+	cfg, err := config.LoadMessagingConfig(config.MessagingConfigPath(r.townRoot))
+	if err != nil {
+		return nil
+	}
+	return cfg
+}
+
+// applyRuleActions carries out the post-delivery rule actions for msg,
+// whose bead is beadID (empty if unknown). Mail and nudges the rules send
+// are marked so rules never run on them, which keeps rules from looping.
+// Failures are collected, not fatal: the message itself was delivered.
+func (r *Router) applyRuleActions(msg *Message, beadID, beadsDir string, actions RuleActions) error {
+	var errs []string
+
+	if actions.Archive && beadID != "" {
+		ctx, cancel := bdWriteCtx()
+		_, err := runBdCommand(ctx, []string{"close", beadID}, filepath.Dir(beadsDir), beadsDir)
+		cancel()
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("archive: %v", err))
+		}
+	}
+
+	for _, to := range actions.Forward {
+		fwd := &Message{
+			ID:          GenerateID(),
+			From:        msg.To,
+			To:          to,
+			Subject:     "Fwd: " + msg.Subject,
+			Body:        fmt.Sprintf("Forwarded by mail rule (%s).\nOriginally from %s to %s.\n\n%s", strings.Join(actions.Matched, ", "), msg.From, msg.To, msg.Body),
+			Priority:    msg.Priority,
+			Type:        msg.Type,
+			ThreadID:    msg.ThreadID,
+			Attachments: msg.Attachments,
+			viaRule:     true,
+		}
+		if err := r.Send(fwd); err != nil {
+			errs = append(errs, fmt.Sprintf("forward to %s: %v", to, err))
+		}
+	}
+
+	for _, text := range actions.AutoReply {
+		if isSelfMail(msg.From, msg.To) {
+			break
+		}
+		reply := &Message{
+			ID:       GenerateID(),
+			From:     msg.To,
+			To:       msg.From,
+			Subject:  "Re: " + strings.TrimPrefix(msg.Subject, "Re: "),
+			Body:     text,
+			Priority: PriorityNormal,
+			Type:     TypeReply,
+			ReplyTo:  beadID,
+			ThreadID: msg.ThreadID,
+			viaRule:  true,
+		}
+		if err := r.Send(reply); err != nil {
+			errs = append(errs, fmt.Sprintf("auto-reply: %v", err))
+		}
+	}
+
+	sender := msg.From
+	text := fmt.Sprintf("📬 Mail rule %s: %s got mail from %s. Subject: %s", strings.Join(actions.Matched, ", "), msg.To, msg.From, msg.Subject)
+	for _, addr := range actions.NudgeAddrs {
+		r.notifyWg.Add(1)
+		go func(addr string) {
+			defer r.notifyWg.Done()
+			r.nudgeAddress(addr, text, sender)
+		}(addr)
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("mail rules for %s: %s", msg.To, strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// nudgeAddress nudges the first live session of an agent address.
+func (r *Router) nudgeAddress(address, text, sender string) {
+	for _, sessionID := range AddressToSessionIDs(address) {
+		if ok, err := r.tmux.HasSession(sessionID); err != nil || !ok {
+			continue
+		}
+		_, _ = r.nudges().Submit(sessionID, text, nudge.SubmitOptions{Sender: sender}).Wait()
+		return
+	}
+}
@@ -0,0 +1,60 @@
+package mail
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+func TestEvaluateRules(t *testing.T) {
+	rules := []config.MailRule{
+		{Name: "patrol", Agent: "mayor/", Match: config.MailRuleMatch{From: "*/witness", Subject: "^Patrol report"}, Archive: true, Stop: true},
+		{Agent: "mayor/", Match: config.MailRuleMatch{Subject: "(?i)blocked"}, Priority: "high", Nudge: "deacon/"},
+		{Name: "thread", Agent: "mayor/", Match: config.MailRuleMatch{Labels: []string{"thread:t-1"}}, Forward: "gastown/crew/max"},
+		{Name: "crew", Agent: "gastown/*", AutoReply: "Away until Monday"},
+		{Name: "lower", Agent: "mayor/", Match: config.MailRuleMatch{Subject: "FYI"}, Priority: "low"},
+	}
+
+	tests := []struct {
+		name   string
+		msg    *Message
+		labels []string
+		want   RuleActions
+	}{
+		{
+			name: "stop after first match",
+			msg:  &Message{From: "gastown/witness", To: "mayor/", Subject: "Patrol report: blocked"},
+			want: RuleActions{Matched: []string{"patrol"}, Archive: true},
+		},
+		{
+			name:   "several rules combine",
+			msg:    &Message{From: "gastown/polecats/toast", To: "mayor/", Subject: "I'm BLOCKED", Priority: PriorityNormal},
+			labels: []string{"gt:message", "thread:t-1"},
+			want:   RuleActions{Matched: []string{"#2", "thread"}, Priority: PriorityHigh, Forward: []string{"gastown/crew/max"}, NudgeAddrs: []string{"deacon/"}},
+		},
+		{
+			name: "priority is never lowered",
+			msg:  &Message{From: "x/y", To: "mayor/", Subject: "FYI", Priority: PriorityHigh},
+			want: RuleActions{Matched: []string{"lower"}},
+		},
+		{
+			name: "crew address matches in canonical form",
+			msg:  &Message{From: "mayor/", To: "gastown/crew/max", Subject: "hi"},
+			want: RuleActions{Matched: []string{"crew"}, AutoReply: []string{"Away until Monday"}},
+		},
+		{
+			name: "no match",
+			msg:  &Message{From: "gastown/witness", To: "deacon/", Subject: "Patrol report"},
+			want: RuleActions{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := EvaluateRules(rules, tt.msg, tt.labels)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("EvaluateRules() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,252 @@
+package mail
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gofrs/flock"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// ErrScheduleNotFound is returned when a scheduled message ID is unknown.
+var ErrScheduleNotFound = errors.New("scheduled message not found")
+
+// maxScheduleFailures is how many consecutive failed deliveries a one-shot
+// scheduled message gets before it is dropped. Recurring messages move on
+// to their next occurrence instead.
+const maxScheduleFailures = 5
+
+// ScheduledMessage is a message waiting in the town's schedule to be sent at
+// NextAt, and again at every later occurrence of Every if that is set.
+type ScheduledMessage struct {
+	ID        string      `json:"id"`
+	From      string      `json:"from"`
+	To        string      `json:"to"`
+	Subject   string      `json:"subject"`
+	Body      string      `json:"body,omitempty"`
+	Priority  Priority    `json:"priority"`
+	Type      MessageType `json:"type"`
+	Wisp      bool        `json:"wisp,omitempty"`
+	Every     string      `json:"every,omitempty"` // Recurrence spec; empty for one-shot
+	NextAt    time.Time   `json:"next_at"`
+	CreatedAt time.Time   `json:"created_at"`
+
+	// Delivery bookkeeping
+	LastSentAt time.Time `json:"last_sent_at,omitempty"`
+	SentCount  int       `json:"sent_count,omitempty"`
+	Failures   int       `json:"failures,omitempty"`
+	LastError  string    `json:"last_error,omitempty"`
+}
+
+// Recurring reports whether the message repeats.
+func (s *ScheduledMessage) Recurring() bool {
+	return s.Every != ""
+}
+
+// message builds the mail for one delivery of s.
+func (s *ScheduledMessage) message(now time.Time) *Message {
+	msg := NewMessage(s.From, s.To, s.Subject, s.Body)
+	msg.Timestamp = now.UTC()
+	msg.Priority = s.Priority
+	msg.Type = s.Type
+	msg.Wisp = s.Wisp
+	return msg
+}
+
+// schedulePath returns <townRoot>/.runtime/mail-schedule.json.
+func schedulePath(townRoot string) string {
+	return filepath.Join(townRoot, constants.DirRuntime, "mail-schedule.json")
+}
+
+// lockSchedule takes the schedule's file lock. The lock is held across
+// deliveries so two schedulers (the daemon and a manual run) never send
+// the same message twice.
+func lockSchedule(townRoot string) (func(), error) {
+	path := schedulePath(townRoot) + ".lock"
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("creating schedule lock dir: %w", err)
+	}
+	fl := flock.New(path)
+	if err := fl.Lock(); err != nil {
+		return nil, fmt.Errorf("acquiring schedule lock: %w", err)
+	}
+	return func() { _ = fl.Unlock() }, nil
+}
+
+func readSchedule(townRoot string) ([]ScheduledMessage, error) {
+	data, err := os.ReadFile(schedulePath(townRoot))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading mail schedule: %w", err)
+	}
+	var entries []ScheduledMessage
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("parsing mail schedule: %w", err)
+	}
+	return entries, nil
+}
+
+func writeSchedule(townRoot string, entries []ScheduledMessage) error {
+	if entries == nil {
+		entries = []ScheduledMessage{}
+	}
+	return util.EnsureDirAndWriteJSON(schedulePath(townRoot), entries)
+}
+
+// LoadSchedule returns the town's scheduled messages, soonest first.
+func LoadSchedule(townRoot string) ([]ScheduledMessage, error) {
+	entries, err := readSchedule(townRoot)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].NextAt.Before(entries[j].NextAt)
+	})
+	return entries, nil
+}
+
+// ScheduleMessage adds s to the town's schedule, assigning its ID and
+// creation time, and returns the stored entry.
+func ScheduleMessage(townRoot string, s ScheduledMessage) (ScheduledMessage, error) {
+	if s.Every != "" {
+		if _, err := ParseRecurrence(s.Every); err != nil {
+			return ScheduledMessage{}, err
+		}
+	}
+	if s.NextAt.IsZero() {
+		return ScheduledMessage{}, fmt.Errorf("scheduled message has no send time")
+	}
+	if s.Priority == "" {
+		s.Priority = PriorityNormal
+	}
+	if s.Type == "" {
+		s.Type = TypeNotification
+	}
+	s.ID = "sched-" + strings.TrimPrefix(GenerateID(), "msg-")
+	s.CreatedAt = timeNow().UTC()
+
+	unlock, err := lockSchedule(townRoot)
+	if err != nil {
+		return ScheduledMessage{}, err
+	}
+	defer unlock()
+
+	entries, err := readSchedule(townRoot)
+	if err != nil {
+		return ScheduledMessage{}, err
+	}
+	entries = append(entries, s)
+	if err := writeSchedule(townRoot, entries); err != nil {
+		return ScheduledMessage{}, fmt.Errorf("writing mail schedule: %w", err)
+	}
+	return s, nil
+}
+
+// CancelScheduled removes a scheduled message.
+func CancelScheduled(townRoot, id string) error {
+	unlock, err := lockSchedule(townRoot)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	entries, err := readSchedule(townRoot)
+	if err != nil {
+		return err
+	}
+	for i := range entries {
+		if entries[i].ID == id {
+			entries = append(entries[:i], entries[i+1:]...)
+			return writeSchedule(townRoot, entries)
+		}
+	}
+	return fmt.Errorf("%w: %s", ErrScheduleNotFound, id)
+}
+
+// DeliverDue sends every scheduled message due at now using send, then
+// reschedules recurring messages and drops delivered one-shots. A recurring
+// message that fell behind (the scheduler was down) is sent once and moved
+// to its next occurrence after now, not replayed for every missed one.
+// It returns how many messages were sent; failures are retried on the next
+// run and reported together in the error.
+func DeliverDue(townRoot string, now time.Time, send func(*Message) error) (int, error) {
+	unlock, err := lockSchedule(townRoot)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
+	entries, err := readSchedule(townRoot)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	var errs []string
+	kept := entries[:0]
+	for _, s := range entries {
+		if s.NextAt.After(now) {
+			kept = append(kept, s)
+			continue
+		}
+
+		var rec *Recurrence
+		if s.Recurring() {
+			if rec, err = ParseRecurrence(s.Every); err != nil {
+				errs = append(errs, fmt.Sprintf("%s: dropped: %v", s.ID, err))
+				continue
+			}
+		}
+
+		if err := send(s.message(now)); err != nil {
+			s.Failures++
+			s.LastError = err.Error()
+			errs = append(errs, fmt.Sprintf("%s (to %s): %v", s.ID, s.To, err))
+			if rec == nil && s.Failures >= maxScheduleFailures {
+				errs = append(errs, fmt.Sprintf("%s: dropped after %d failed attempts", s.ID, s.Failures))
+				continue
+			}
+			if rec == nil {
+				kept = append(kept, s)
+				continue
+			}
+		} else {
+			sent++
+			s.SentCount++
+			s.LastSentAt = now.UTC()
+			s.Failures = 0
+			s.LastError = ""
+			if rec == nil {
+				continue
+			}
+		}
+
+		next := rec.Next(s.NextAt.In(now.Location()))
+		if !next.IsZero() && !next.After(now) {
+			next = rec.Next(now)
+		}
+		if next.IsZero() {
+			errs = append(errs, fmt.Sprintf("%s: dropped: %q never fires again", s.ID, s.Every))
+			continue
+		}
+		s.NextAt = next
+		kept = append(kept, s)
+	}
+
+	if err := writeSchedule(townRoot, kept); err != nil {
+		return sent, fmt.Errorf("writing mail schedule: %w", err)
+	}
+	if len(errs) > 0 {
+		return sent, fmt.Errorf("scheduled mail: %s", strings.Join(errs, "; "))
+	}
+	return sent, nil
+}
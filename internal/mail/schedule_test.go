@@ -0,0 +1,76 @@
+package mail
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDeliverDue(t *testing.T) {
+	townRoot := t.TempDir()
+	now := time.Date(2026, 3, 4, 9, 0, 0, 0, time.UTC)
+
+	once, err := ScheduleMessage(townRoot, ScheduledMessage{From: "mayor/", To: "gastown/witness", Subject: "once", NextAt: now.Add(-time.Minute)})
+	if err != nil {
+		t.Fatalf("ScheduleMessage: %v", err)
+	}
+	// Missed for several days: sent once, then moved past now.
+	daily, _ := ScheduleMessage(townRoot, ScheduledMessage{From: "mayor/", To: "gastown/crew/max", Subject: "standup", Every: "0 9 * * *", NextAt: now.AddDate(0, 0, -3)})
+	later, _ := ScheduleMessage(townRoot, ScheduledMessage{From: "mayor/", To: "deacon/", Subject: "later", NextAt: now.Add(time.Hour)})
+	failing, _ := ScheduleMessage(townRoot, ScheduledMessage{From: "mayor/", To: "nobody/", Subject: "fails", NextAt: now})
+
+	var sent []string
+	send := func(msg *Message) error {
+		if msg.To == "nobody/" {
+			return errors.New("unknown recipient")
+		}
+		sent = append(sent, msg.Subject)
+		return nil
+	}
+	n, err := DeliverDue(townRoot, now, send)
+	if n != 2 || len(sent) != 2 {
+		t.Errorf("sent %d (%v), want once and standup", n, sent)
+	}
+	if err == nil {
+		t.Error("DeliverDue error = nil, want the failed delivery reported")
+	}
+
+	entries, err := LoadSchedule(townRoot)
+	if err != nil {
+		t.Fatalf("LoadSchedule: %v", err)
+	}
+	byID := map[string]ScheduledMessage{}
+	for _, e := range entries {
+		byID[e.ID] = e
+	}
+	if _, ok := byID[once.ID]; ok {
+		t.Error("delivered one-shot message still scheduled")
+	}
+	if got := byID[daily.ID]; !got.NextAt.Equal(now.AddDate(0, 0, 1)) || got.SentCount != 1 {
+		t.Errorf("daily entry = next %v sent %d, want tomorrow 09:00 sent 1", got.NextAt, got.SentCount)
+	}
+	if _, ok := byID[later.ID]; !ok {
+		t.Error("message not yet due was removed")
+	}
+	if got := byID[failing.ID]; got.Failures != 1 || got.LastError == "" {
+		t.Errorf("failing entry = %+v, want one recorded failure", got)
+	}
+
+	// Failed one-shots are dropped after maxScheduleFailures attempts.
+	for i := 1; i < maxScheduleFailures; i++ {
+		_, _ = DeliverDue(townRoot, now, send)
+	}
+	entries, _ = LoadSchedule(townRoot)
+	for _, e := range entries {
+		if e.ID == failing.ID {
+			t.Errorf("failing entry kept after %d attempts", e.Failures)
+		}
+	}
+
+	if err := CancelScheduled(townRoot, later.ID); err != nil {
+		t.Errorf("CancelScheduled: %v", err)
+	}
+	if err := CancelScheduled(townRoot, later.ID); !errors.Is(err, ErrScheduleNotFound) {
+		t.Errorf("second CancelScheduled error = %v, want ErrScheduleNotFound", err)
+	}
+}
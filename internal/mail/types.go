@@ -130,10 +130,24 @@ type Message struct {
 	// DeliveryAckedAt is when receipt was acknowledged.
 	DeliveryAckedAt *time.Time `json:"delivery_acked_at,omitempty"`
 
+	// Attachments are typed items carried with the message (beads, files,
+	// diffs). Stored after the body in the bead description.
+	Attachments []Attachment `json:"attachments,omitempty"`
+
+	// State is where the message is in its lifecycle (queued, delivered,
+	// read, acted). Empty for messages sent before state tracking.
+	State MessageState `json:"state,omitempty"`
+	// StateAt is when the message entered each state, where known.
+	StateAt map[MessageState]time.Time `json:"state_at,omitempty"`
+
 	// SuppressNotify tells the router to skip all recipient notification
 	// (no nudge, no banner). Set by the CLI when --no-notify is passed.
 	// In-memory only — not serialized.
 	SuppressNotify bool `json:"-"`
+
+	// viaRule marks mail sent by a mail rule (forward, auto-reply); rules
+	// don't run on it, so they can't loop. In-memory only.
+	viaRule bool
 }
 
 // NewMessage creates a new message with a generated ID and thread ID.
@@ -144,7 +158,7 @@ func NewMessage(from, to, subject, body string) *Message {
 		To:        to,
 		Subject:   subject,
 		Body:      body,
-		Timestamp: time.Now(),
+		Timestamp: time.Now().UTC(),
 		Read:      false,
 		Priority:  PriorityNormal,
 		Type:      TypeNotification,
@@ -160,7 +174,7 @@ func NewReplyMessage(from, to, subject, body string, original *Message) *Message
 		To:        to,
 		Subject:   subject,
 		Body:      body,
-		Timestamp: time.Now(),
+		Timestamp: time.Now().UTC(),
 		Read:      false,
 		Priority:  PriorityNormal,
 		Type:      TypeReply,
@@ -178,7 +192,7 @@ func NewQueueMessage(from, queue, subject, body string) *Message {
 		Queue:     queue,
 		Subject:   subject,
 		Body:      body,
-		Timestamp: time.Now(),
+		Timestamp: time.Now().UTC(),
 		Read:      false,
 		Priority:  PriorityNormal,
 		Type:      TypeTask, // Queue messages are typically tasks
@@ -195,7 +209,7 @@ func NewChannelMessage(from, channel, subject, body string) *Message {
 		Channel:   channel,
 		Subject:   subject,
 		Body:      body,
-		Timestamp: time.Now(),
+		Timestamp: time.Now().UTC(),
 		Read:      false,
 		Priority:  PriorityNormal,
 		Type:      TypeNotification,
@@ -264,6 +278,10 @@ func (m *Message) Validate() error {
 		return fmt.Errorf("claimed_at is only valid for queue messages")
 	}
 
+	if err := ValidateAttachments(m.Attachments); err != nil {
+		return fmt.Errorf("invalid attachments: %w", err)
+	}
+
 	return nil
 }
 
@@ -411,12 +429,20 @@ func (bm *BeadsMessage) ToMessage() *Message {
 		ccAddrs = append(ccAddrs, identityToAddress(cc))
 	}
 
+	// A closed (archived) message was at least read.
+	state, stateAt := ParseMessageState(bm.Labels)
+	if bm.Status == "closed" && state != "" && !state.Reached(StateRead) {
+		state = StateRead
+	}
+
+	body, attachments := DecodeBody(bm.Description)
+
 	return &Message{
 		ID:              bm.ID,
 		From:            identityToAddress(bm.sender),
 		To:              identityToAddress(bm.Assignee),
 		Subject:         bm.Title,
-		Body:            bm.Description,
+		Body:            body,
 		Timestamp:       bm.CreatedAt,
 		Read:            bm.Status == "closed" || bm.HasLabel("read"),
 		Priority:        priority,
@@ -432,6 +458,9 @@ func (bm *BeadsMessage) ToMessage() *Message {
 		DeliveryState:   bm.deliveryState,
 		DeliveryAckedBy: bm.deliveryAckedBy,
 		DeliveryAckedAt: bm.deliveryAckedAt,
+		Attachments:     attachments,
+		State:           state,
+		StateAt:         stateAt,
 	}
 }
 
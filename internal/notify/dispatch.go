@@ -0,0 +1,127 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// maxEventsPerPass bounds how many new events one Dispatch call reads, so a
+// backlog (e.g., after the daemon was down) drains over several passes.
+const maxEventsPerPass = 500
+
+// cursor is how far into the events log webhooks have been fired.
+type cursor struct {
+	Offset int64 `json:"offset"`
+}
+
+// cursorPath returns <townRoot>/.runtime/notify_cursor.json.
+func cursorPath(townRoot string) string {
+	return filepath.Join(townRoot, constants.DirRuntime, "notify_cursor.json")
+}
+
+// Result is the outcome of a Dispatch pass.
+type Result struct {
+	Events int     // New events read
+	Sent   int     // Successful deliveries
+	Errors []error // Failed deliveries (after retries)
+}
+
+// Dispatch fires webhooks for events appended to the town's event log since
+// the last pass. The first pass starts at the end of the log, so enabling
+// webhooks doesn't replay history. A delivery that still fails after its
+// retries is reported and dropped. If ctx ends mid-pass, the cursor stops
+// before the event being delivered, so the next pass sends it again (a
+// webhook that already got it may see it twice).
+func Dispatch(ctx context.Context, townRoot string, cfg *config.NotificationsConfig, s *Sender) (*Result, error) {
+	result := &Result{}
+	if !cfg.Enabled() {
+		return result, nil
+	}
+	logPath := filepath.Join(townRoot, events.EventsFile)
+	info, err := os.Stat(logPath)
+	if os.IsNotExist(err) {
+		return result, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var cur cursor
+	data, err := os.ReadFile(cursorPath(townRoot))
+	switch {
+	case os.IsNotExist(err):
+		return result, saveCursor(townRoot, cursor{Offset: info.Size()})
+	case err != nil:
+		return nil, err
+	default:
+		if err := json.Unmarshal(data, &cur); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", cursorPath(townRoot), err)
+		}
+	}
+	if cur.Offset > info.Size() {
+		cur.Offset = 0 // Log was rotated or truncated
+	}
+
+	town := filepath.Base(townRoot)
+	offset := cur.Offset
+	var readErr error
+	for result.Events < maxEventsPerPass && ctx.Err() == nil {
+		// One event at a time, so the cursor can stop just before it.
+		evs, next, err := events.ReadFrom(logPath, offset, 1)
+		if err != nil {
+			readErr = err
+			break
+		}
+		if next == offset {
+			break
+		}
+		if len(evs) > 0 {
+			result.Events++
+			if !deliver(ctx, s, cfg, evs[0], town, result) {
+				break
+			}
+		}
+		offset = next
+	}
+	if offset != cur.Offset {
+		if err := saveCursor(townRoot, cursor{Offset: offset}); err != nil {
+			return result, err
+		}
+	}
+	return result, readErr
+}
+
+// deliver sends an event to every webhook that wants it, recording the
+// outcome in result. It returns false if ctx ended before the event was
+// fully delivered.
+func deliver(ctx context.Context, s *Sender, cfg *config.NotificationsConfig, e events.Event, town string, result *Result) bool {
+	for _, w := range cfg.Webhooks {
+		if !Matches(w, e.Type) {
+			continue
+		}
+		if err := s.Send(ctx, w, e, town); err != nil {
+			if ctx.Err() != nil {
+				return false
+			}
+			result.Errors = append(result.Errors, fmt.Errorf("%s: %s: %w", w.Name, e.Type, err))
+			continue
+		}
+		result.Sent++
+	}
+	return true
+}
+
+func saveCursor(townRoot string, c cursor) error {
+	path := cursorPath(townRoot)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return util.AtomicWriteJSON(path, c)
+}
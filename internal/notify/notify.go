@@ -0,0 +1,227 @@
+// Package notify delivers selected Gas Town events to webhooks (Slack,
+// Discord, or generic JSON). Events come from the town's event log
+// (.events.jsonl); the daemon's webhooks patrol tails it and calls Dispatch.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/events"
+)
+
+// DefaultEvents are the event types a webhook receives when it lists none:
+// the ones that usually want a human's attention. Single nudge failures are
+// left out: one is logged per failed attempt, and a session that keeps
+// failing opens its nudge circuit, which is reported once.
+var DefaultEvents = []string{
+	events.TypeNudgeCircuit,
+	events.TypeSessionDeath,
+	events.TypeMassDeath,
+	events.TypeCrashLoop,
+	events.TypeStuckWorker,
+	events.TypePaneAnomaly,
+	events.TypeMoleculeComplete,
+	events.TypePreflightWarning,
+	events.TypeHookDetached,
+}
+
+// Matches reports whether a webhook wants events of this type.
+func Matches(w config.WebhookConfig, eventType string) bool {
+	patterns := w.Events
+	if len(patterns) == 0 {
+		patterns = DefaultEvents
+	}
+	for _, p := range patterns {
+		if ok, _ := path.Match(p, eventType); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// TemplateData is what a webhook template sees.
+type TemplateData struct {
+	events.Event
+	Summary string
+	Town    string
+}
+
+// Summary renders an event as one line of text.
+func Summary(e events.Event) string {
+	p := func(key string) string {
+		if v, ok := e.Payload[key]; ok {
+			return fmt.Sprint(v)
+		}
+		return ""
+	}
+	switch e.Type {
+	case events.TypeNudgeFailed:
+		return fmt.Sprintf("Nudge to %s failed (%s in a row): %s", p("session"), p("consecutive"), p("error"))
+	case events.TypeNudgeCircuit:
+		if p("state") == "closed" {
+			return fmt.Sprintf("Nudge circuit to %s closed; deliveries resumed", p("session"))
+		}
+		return fmt.Sprintf("Nudge circuit to %s opened after %s failures in a row; nudges are dead-lettered: %s", p("session"), p("consecutive"), p("error"))
+	case events.TypeSessionDeath:
+		return fmt.Sprintf("Agent %s died: %s", firstNonEmpty(p("agent"), p("session"), e.Actor), p("reason"))
+	case events.TypeMassDeath:
+		s := fmt.Sprintf("%s sessions died within %s", p("count"), p("window"))
+		if cause := p("possible_cause"); cause != "" {
+			s += ": " + cause
+		}
+		return s
+	case events.TypeCrashLoop:
+		return fmt.Sprintf("Agent %s is crash-looping (%s restarts within %s); the daemon stopped restarting it", p("agent"), p("restarts"), p("window"))
+	case events.TypeStuckWorker:
+		return fmt.Sprintf("%s/%s is stuck (%s), detected by %s", p("rig"), p("worker"), p("reason"), p("detector"))
+	case events.TypePaneAnomaly:
+		return fmt.Sprintf("%s pane shows %s: %s", p("agent"), strings.ReplaceAll(p("kind"), "_", " "), p("detail"))
+	case events.TypePermissionAnswer:
+		return fmt.Sprintf("Answered %s to %s's %s permission prompt (rig policy)", p("answer"), p("agent"), p("tool"))
+	case events.TypeMoleculeComplete:
+		return fmt.Sprintf("Molecule %s complete (%s steps)", p("molecule"), p("steps"))
+	case events.TypePreflightWarning:
+		return fmt.Sprintf("Preflight warning for %s on %s: %s", e.Actor, p("branch"), p("message"))
+	case events.TypeHookDetached:
+		return fmt.Sprintf("Detached %s from %s and requeued it (%s, rule %s)", p("bead"), p("agent"), p("reason"), p("rule"))
+	case events.TypeBeadChanged:
+		return fmt.Sprintf("Bead %s %s: %s", p("bead"), p("change"), p("title"))
+	}
+
+	keys := make([]string, 0, len(e.Payload))
+	for k := range e.Payload {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = fmt.Sprintf("%s=%v", k, e.Payload[k])
+	}
+	s := e.Type + " by " + e.Actor
+	if len(parts) > 0 {
+		s += ": " + strings.Join(parts, " ")
+	}
+	return s
+}
+
+func firstNonEmpty(vals ...string) string {
+	for _, v := range vals {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// Render builds the request body for an event.
+func Render(w config.WebhookConfig, e events.Event, town string) ([]byte, error) {
+	data := TemplateData{Event: e, Summary: Summary(e), Town: town}
+	text := "[" + town + "] " + data.Summary
+	if town == "" {
+		text = data.Summary
+	}
+	if w.Template != "" {
+		tmpl, err := template.New(w.Name).Parse(w.Template)
+		if err != nil {
+			return nil, fmt.Errorf("parsing template: %w", err)
+		}
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return nil, fmt.Errorf("rendering template: %w", err)
+		}
+		text = buf.String()
+	}
+
+	switch w.GetFormat() {
+	case config.WebhookFormatSlack:
+		return json.Marshal(map[string]string{"text": text})
+	case config.WebhookFormatDiscord:
+		return json.Marshal(map[string]string{"content": text})
+	default:
+		if w.Template != "" {
+			return []byte(text), nil
+		}
+		return json.Marshal(struct {
+			events.Event
+			Summary string `json:"summary"`
+			Town    string `json:"town,omitempty"`
+		}{e, data.Summary, town})
+	}
+}
+
+// Sender posts rendered events to webhooks.
+type Sender struct {
+	Client  *http.Client
+	Backoff time.Duration // Delay before the first retry; doubles each attempt
+}
+
+// NewSender returns a Sender with a 10s request timeout and 1s initial backoff.
+func NewSender() *Sender {
+	return &Sender{Client: &http.Client{Timeout: 10 * time.Second}, Backoff: time.Second}
+}
+
+// Send delivers an event to a webhook, retrying network errors, 429s, and
+// 5xx responses with exponential backoff. Other 4xx responses fail at once.
+func (s *Sender) Send(ctx context.Context, w config.WebhookConfig, e events.Event, town string) error {
+	body, err := Render(w, e, town)
+	if err != nil {
+		return err
+	}
+	backoff := s.Backoff
+	var lastErr error
+	for attempt := 0; attempt <= w.GetRetries(); attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+		retry, err := s.post(ctx, w, body)
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+		if !retry {
+			break
+		}
+	}
+	return lastErr
+}
+
+// post makes one delivery attempt and reports whether a failure is worth
+// retrying.
+func (s *Sender) post(ctx context.Context, w config.WebhookConfig, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "gastown-notify")
+	for k, v := range w.Headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+	return retry, fmt.Errorf("webhook %s: HTTP %s", w.Name, resp.Status)
+}
@@ -0,0 +1,205 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/events"
+)
+
+func testSender() *Sender {
+	return &Sender{Client: http.DefaultClient, Backoff: time.Millisecond}
+}
+
+func TestMatches(t *testing.T) {
+	def := config.WebhookConfig{Name: "def"}
+	if !Matches(def, events.TypeSessionDeath) {
+		t.Error("default events should include session_death")
+	}
+	if Matches(def, events.TypeSling) {
+		t.Error("default events should not include sling")
+	}
+	glob := config.WebhookConfig{Name: "glob", Events: []string{"merge_*"}}
+	if !Matches(glob, events.TypeMergeFailed) || Matches(glob, events.TypeSessionDeath) {
+		t.Error("merge_* pattern matched wrong events")
+	}
+}
+
+func TestRender(t *testing.T) {
+	e := events.Event{
+		Type:    events.TypeStuckWorker,
+		Actor:   "gt-gastown-nux",
+		Payload: events.StuckWorkerPayload("gastown", "nux", "hung", "witness"),
+	}
+	want := "[town] gastown/nux is stuck (hung), detected by witness"
+
+	body, err := Render(config.WebhookConfig{Format: config.WebhookFormatSlack}, e, "town")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var slack map[string]string
+	if err := json.Unmarshal(body, &slack); err != nil || slack["text"] != want {
+		t.Errorf("slack body = %s, want text %q", body, want)
+	}
+
+	body, err = Render(config.WebhookConfig{Format: config.WebhookFormatDiscord, Template: "{{.Payload.worker}}: {{.Type}}"}, e, "town")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(body) != `{"content":"nux: stuck_worker"}` {
+		t.Errorf("discord body = %s", body)
+	}
+
+	body, err = Render(config.WebhookConfig{}, e, "town")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var generic map[string]interface{}
+	if err := json.Unmarshal(body, &generic); err != nil {
+		t.Fatal(err)
+	}
+	if generic["type"] != events.TypeStuckWorker || generic["town"] != "town" || !strings.Contains(generic["summary"].(string), "nux") {
+		t.Errorf("json body = %s", body)
+	}
+}
+
+func TestSendRetries(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Token") != "secret" {
+			t.Errorf("missing configured header")
+		}
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = io.Copy(io.Discard, r.Body)
+	}))
+	defer srv.Close()
+
+	w := config.WebhookConfig{Name: "t", URL: srv.URL, Headers: map[string]string{"X-Token": "secret"}}
+	if err := testSender().Send(context.Background(), w, events.Event{Type: "x"}, ""); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("calls = %d, want 3", calls)
+	}
+}
+
+func TestSendNoRetryOnClientError(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	w := config.WebhookConfig{Name: "t", URL: srv.URL}
+	if err := testSender().Send(context.Background(), w, events.Event{Type: "x"}, ""); err == nil {
+		t.Fatal("expected error for 400")
+	}
+	if calls != 1 {
+		t.Errorf("calls = %d, want 1", calls)
+	}
+}
+
+func TestDispatch(t *testing.T) {
+	townRoot := t.TempDir()
+	logPath := filepath.Join(townRoot, events.EventsFile)
+	appendEvent := func(e events.Event) {
+		t.Helper()
+		data, _ := json.Marshal(e)
+		f, err := os.OpenFile(logPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+		_, _ = f.Write(append(data, '\n'))
+	}
+
+	var received []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var e events.Event
+		_ = json.NewDecoder(r.Body).Decode(&e)
+		received = append(received, e.Type)
+	}))
+	defer srv.Close()
+	cfg := &config.NotificationsConfig{Webhooks: []config.WebhookConfig{{Name: "t", URL: srv.URL}}}
+
+	// History before the first pass is not replayed.
+	appendEvent(events.Event{Type: events.TypeSessionDeath, Actor: "old"})
+	if _, err := Dispatch(context.Background(), townRoot, cfg, testSender()); err != nil {
+		t.Fatal(err)
+	}
+	if len(received) != 0 {
+		t.Fatalf("first pass sent %v, want nothing", received)
+	}
+
+	appendEvent(events.Event{Type: events.TypeSling, Actor: "a"})
+	appendEvent(events.Event{Type: events.TypeStuckWorker, Actor: "b"})
+	result, err := Dispatch(context.Background(), townRoot, cfg, testSender())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Events != 2 || result.Sent != 1 || len(received) != 1 || received[0] != events.TypeStuckWorker {
+		t.Errorf("result = %+v, received = %v", result, received)
+	}
+
+	// Nothing new: nothing sent.
+	if result, _ := Dispatch(context.Background(), townRoot, cfg, testSender()); result.Events != 0 {
+		t.Errorf("third pass read %d events, want 0", result.Events)
+	}
+}
+
+func TestDispatchCutShortKeepsEvent(t *testing.T) {
+	townRoot := t.TempDir()
+	logPath := filepath.Join(townRoot, events.EventsFile)
+	if err := os.WriteFile(logPath, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := saveCursor(townRoot, cursor{}); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := json.Marshal(events.Event{Type: events.TypeSessionDeath, Actor: "a"})
+	if err := os.WriteFile(logPath, append(data, '\n'), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			cancel() // The pass times out while this delivery is retried
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+	cfg := &config.NotificationsConfig{Webhooks: []config.WebhookConfig{{Name: "t", URL: srv.URL}}}
+
+	result, err := Dispatch(ctx, townRoot, cfg, &Sender{Client: http.DefaultClient, Backoff: time.Minute})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Sent != 0 || len(result.Errors) != 0 {
+		t.Errorf("cut-short pass = %+v, want nothing sent or dropped", result)
+	}
+
+	// The next pass delivers the event the cut-short one didn't.
+	result, err = Dispatch(context.Background(), townRoot, cfg, testSender())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Events != 1 || result.Sent != 1 {
+		t.Errorf("next pass = %+v, want the event sent", result)
+	}
+}
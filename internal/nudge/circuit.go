@@ -0,0 +1,236 @@
+package nudge
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/errkind"
+	"github.com/steveyegge/gastown/internal/events"
+)
+
+// ErrCircuitOpen is returned for nudges to a session whose circuit is open:
+// enough consecutive deliveries to it failed that further nudges fail fast
+// (and go to the dead-letter store) until the cool-down passes or a probe
+// gets through.
+var ErrCircuitOpen = errkind.New(errkind.Transport, "nudge circuit open",
+	"see failing sessions with 'gt nudge circuit'")
+
+// Circuit states.
+const (
+	CircuitClosed   = "closed"    // Nudges are delivered normally
+	CircuitOpen     = "open"      // Nudges fail fast and are dead-lettered
+	CircuitHalfOpen = "half-open" // Cool-down over; the next delivery tests the session
+)
+
+// CircuitState returns the state of the session's circuit at now.
+func (r FailureRecord) CircuitState(now time.Time, cooldown time.Duration) string {
+	switch {
+	case r.OpenedAt.IsZero():
+		return CircuitClosed
+	case now.Sub(r.OpenedAt) < cooldown:
+		return CircuitOpen
+	default:
+		return CircuitHalfOpen
+	}
+}
+
+// CheckCircuit returns ErrCircuitOpen (wrapped with the session's failure
+// details) if the session's circuit is open, or nil if nudges may be tried.
+func CheckCircuit(townRoot, session string) error {
+	if townRoot == "" {
+		return nil
+	}
+	records, err := LoadFailures(townRoot)
+	if err != nil {
+		return nil
+	}
+	r, ok := records[session]
+	if !ok {
+		return nil
+	}
+	cooldown := nudgeConfig(townRoot).CircuitCooldownD()
+	if r.CircuitState(time.Now(), cooldown) != CircuitOpen {
+		return nil
+	}
+	retry := r.OpenedAt.Add(cooldown).Sub(time.Now()).Round(time.Second)
+	return fmt.Errorf("%w for %s after %d consecutive failures (last: %s); retrying in %s",
+		ErrCircuitOpen, session, r.Count, r.LastError, retry)
+}
+
+// updateCircuit opens or re-opens the circuit on a failure record that has
+// reached the threshold. Returns true if the circuit was closed before.
+func updateCircuit(r *FailureRecord, threshold int, cooldown time.Duration, now time.Time) bool {
+	if threshold <= 0 || r.Count < threshold {
+		return false
+	}
+	switch r.CircuitState(now, cooldown) {
+	case CircuitClosed:
+		r.OpenedAt = now
+		return true
+	case CircuitHalfOpen:
+		// The test delivery failed: back to open for another cool-down.
+		r.OpenedAt = now
+	}
+	return false
+}
+
+// DeadLetter is a nudge that couldn't be delivered because the session's
+// circuit was open.
+type DeadLetter struct {
+	QueuedNudge
+	Reason string    `json:"reason"`
+	DeadAt time.Time `json:"dead_at"`
+}
+
+// deadLetterDir returns <townRoot>/.runtime/nudge_dead_letter/<session>/.
+func deadLetterDir(townRoot, session string) string {
+	safe := strings.ReplaceAll(session, "/", "_")
+	return filepath.Join(townRoot, constants.DirRuntime, "nudge_dead_letter", safe)
+}
+
+// StoreDeadLetter saves an undeliverable nudge to the session's dead-letter
+// store. It is requeued when the session's circuit closes. Expired letters
+// are dropped, and the store is capped at the session's queue depth
+// (operational.nudge max_queue_depth) by dropping the oldest.
+func StoreDeadLetter(townRoot, session string, n QueuedNudge, reason string) error {
+	dir := deadLetterDir(townRoot, session)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("creating dead-letter dir: %w", err)
+	}
+	now := time.Now().UTC()
+	if n.Timestamp.IsZero() {
+		n.Timestamp = now
+	}
+	data, err := json.Marshal(DeadLetter{QueuedNudge: n, Reason: reason, DeadAt: now})
+	if err != nil {
+		return err
+	}
+	pruneDeadLetters(townRoot, session, nudgeConfig(townRoot).MaxQueueDepthV()-1, now)
+	name := fmt.Sprintf("%d-%s.json", now.UnixNano(), randomSuffix())
+	return os.WriteFile(filepath.Join(dir, name), data, 0644)
+}
+
+// pruneDeadLetters removes a session's expired dead letters, then the oldest
+// until at most keep remain.
+func pruneDeadLetters(townRoot, session string, keep int, now time.Time) {
+	files, err := deadLetterFiles(townRoot, session)
+	if err != nil {
+		return
+	}
+	var live []string
+	for _, path := range files {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		var dl DeadLetter
+		if json.Unmarshal(data, &dl) != nil || (!dl.ExpiresAt.IsZero() && now.After(dl.ExpiresAt)) {
+			_ = os.Remove(path)
+			continue
+		}
+		live = append(live, path)
+	}
+	for len(live) > max(keep, 0) {
+		_ = os.Remove(live[0])
+		live = live[1:]
+	}
+}
+
+// LoadDeadLetters returns a session's dead letters, oldest first.
+func LoadDeadLetters(townRoot, session string) ([]DeadLetter, error) {
+	files, err := deadLetterFiles(townRoot, session)
+	if err != nil {
+		return nil, err
+	}
+	var letters []DeadLetter
+	for _, path := range files {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		var dl DeadLetter
+		if json.Unmarshal(data, &dl) == nil {
+			letters = append(letters, dl)
+		}
+	}
+	return letters, nil
+}
+
+// DeadLetterCounts returns the number of dead letters per session.
+func DeadLetterCounts(townRoot string) (map[string]int, error) {
+	root := filepath.Join(townRoot, constants.DirRuntime, "nudge_dead_letter")
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return map[string]int{}, nil
+		}
+		return nil, err
+	}
+	counts := make(map[string]int)
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		if files, err := deadLetterFiles(townRoot, e.Name()); err == nil && len(files) > 0 {
+			counts[e.Name()] = len(files)
+		}
+	}
+	return counts, nil
+}
+
+func deadLetterFiles(townRoot, session string) ([]string, error) {
+	dir := deadLetterDir(townRoot, session)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading dead letters: %w", err)
+	}
+	var files []string
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), ".json") {
+			files = append(files, filepath.Join(dir, e.Name()))
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// RequeueDeadLetters moves a session's dead letters into its nudge queue,
+// to be drained at its next turn boundary. Nudges past their TTL are
+// dropped by the drain. Stops at the first one the queue refuses, leaving
+// the rest stored.
+func RequeueDeadLetters(townRoot, session string) (int, error) {
+	files, err := deadLetterFiles(townRoot, session)
+	if err != nil {
+		return 0, err
+	}
+	moved := 0
+	for _, path := range files {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		var dl DeadLetter
+		if json.Unmarshal(data, &dl) == nil {
+			if err := Enqueue(townRoot, session, dl.QueuedNudge); err != nil {
+				return moved, err
+			}
+			moved++
+		}
+		_ = os.Remove(path)
+	}
+	return moved, nil
+}
+
+// logCircuit records a circuit opening or closing on the activity feed.
+func logCircuit(session, state string, r FailureRecord) {
+	_ = events.LogFeed(events.TypeNudgeCircuit, session, events.NudgeCircuitPayload(session, state, r.LastError, r.Count))
+}
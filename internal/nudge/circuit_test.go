@@ -0,0 +1,172 @@
+package nudge
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+func TestUpdateCircuit(t *testing.T) {
+	now := time.Now()
+	cooldown := 10 * time.Minute
+
+	r := FailureRecord{Count: 4}
+	if updateCircuit(&r, 5, cooldown, now) || !r.OpenedAt.IsZero() {
+		t.Fatalf("circuit opened below the threshold: %+v", r)
+	}
+	r.Count = 5
+	if !updateCircuit(&r, 5, cooldown, now) {
+		t.Fatalf("circuit did not open at the threshold")
+	}
+	if got := r.CircuitState(now.Add(time.Minute), cooldown); got != CircuitOpen {
+		t.Errorf("state during cool-down = %s, want open", got)
+	}
+
+	// A failure while open doesn't extend the cool-down.
+	r.Count = 6
+	if updateCircuit(&r, 5, cooldown, now.Add(time.Minute)) || !r.OpenedAt.Equal(now) {
+		t.Errorf("failure while open changed the record: %+v", r)
+	}
+
+	// A failure while half-open re-opens for another cool-down.
+	later := now.Add(cooldown + time.Minute)
+	if got := r.CircuitState(later, cooldown); got != CircuitHalfOpen {
+		t.Fatalf("state after cool-down = %s, want half-open", got)
+	}
+	r.Count = 7
+	if updateCircuit(&r, 5, cooldown, later) || !r.OpenedAt.Equal(later) {
+		t.Errorf("half-open failure did not re-open: %+v", r)
+	}
+
+	// A threshold of 0 disables the breaker.
+	r = FailureRecord{Count: 100}
+	if updateCircuit(&r, 0, cooldown, now) || !r.OpenedAt.IsZero() {
+		t.Errorf("disabled breaker opened: %+v", r)
+	}
+}
+
+func TestCircuitOpensAndCloses(t *testing.T) {
+	townRoot := t.TempDir()
+	boom := errors.New("pane not responding")
+
+	for i := 0; i < config.DefaultNudgeCircuitThreshold; i++ {
+		if err := CheckCircuit(townRoot, "gt-nux"); err != nil {
+			t.Fatalf("circuit open after %d failure(s): %v", i, err)
+		}
+		_ = RecordDelivery(townRoot, "gt-nux", boom)
+	}
+	err := CheckCircuit(townRoot, "gt-nux")
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("CheckCircuit = %v, want ErrCircuitOpen", err)
+	}
+
+	if err := StoreDeadLetter(townRoot, "gt-nux", QueuedNudge{Sender: "mayor", Message: "hello"}, err.Error()); err != nil {
+		t.Fatalf("StoreDeadLetter: %v", err)
+	}
+	if counts, _ := DeadLetterCounts(townRoot); counts["gt-nux"] != 1 {
+		t.Fatalf("DeadLetterCounts = %v, want 1 for gt-nux", counts)
+	}
+
+	// A successful delivery closes the circuit and requeues the dead letter.
+	_ = RecordDelivery(townRoot, "gt-nux", nil)
+	if err := CheckCircuit(townRoot, "gt-nux"); err != nil {
+		t.Errorf("circuit still open after success: %v", err)
+	}
+	if letters, _ := LoadDeadLetters(townRoot, "gt-nux"); len(letters) != 0 {
+		t.Errorf("%d dead letter(s) left after the circuit closed", len(letters))
+	}
+	if n, _ := Pending(townRoot, "gt-nux"); n != 1 {
+		t.Errorf("Pending = %d, want the dead letter requeued", n)
+	}
+}
+
+func TestSchedulerCircuitOpen(t *testing.T) {
+	townRoot := t.TempDir()
+	for i := 0; i < config.DefaultNudgeCircuitThreshold; i++ {
+		_ = RecordDelivery(townRoot, "gt-nux", errors.New("boom"))
+	}
+
+	fake := &fakeSessions{}
+	s := newTestScheduler(townRoot, fake)
+	status, err := s.Submit("gt-nux", "hello", SubmitOptions{Sender: "mayor"}).Wait()
+	if status != StatusFailed || !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("Wait() = %s, %v; want failed with ErrCircuitOpen", status, err)
+	}
+	if len(fake.typed) != 0 {
+		t.Errorf("typed %q into a session with an open circuit", fake.typed)
+	}
+	letters, _ := LoadDeadLetters(townRoot, "gt-nux")
+	if len(letters) != 1 || letters[0].Message != "hello" || letters[0].Sender != "mayor" {
+		t.Errorf("dead letters = %+v, want the nudge", letters)
+	}
+}
+
+// createdFakeSessions reports a session creation time.
+type createdFakeSessions struct {
+	fakeSessions
+	created int64
+}
+
+func (f *createdFakeSessions) GetSessionCreatedUnix(session string) (int64, error) {
+	return f.created, nil
+}
+
+func TestSchedulerResetsCircuitOnRespawn(t *testing.T) {
+	townRoot := t.TempDir()
+	for i := 0; i < config.DefaultNudgeCircuitThreshold; i++ {
+		_ = RecordDelivery(townRoot, "gt-nux", errors.New("boom"))
+	}
+	_ = StoreDeadLetter(townRoot, "gt-nux", QueuedNudge{Message: "for the old session"}, "circuit open")
+	lastAt := time.Now()
+
+	// Same session: still open.
+	fake := &createdFakeSessions{created: lastAt.Add(-time.Hour).Unix()}
+	s := newTestScheduler(townRoot, fake)
+	if _, err := s.Submit("gt-nux", "hello", SubmitOptions{}).Wait(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("Wait() err = %v, want ErrCircuitOpen before the respawn", err)
+	}
+
+	// Recreated after the last failure: the circuit and dead letters go.
+	fake.created = lastAt.Add(time.Hour).Unix()
+	if status, err := s.Submit("gt-nux", "hello", SubmitOptions{Mode: ModeImmediate}).Wait(); status != StatusDelivered {
+		t.Fatalf("Wait() = %s, %v; want delivered to the new session", status, err)
+	}
+	if letters, _ := LoadDeadLetters(townRoot, "gt-nux"); len(letters) != 0 {
+		t.Errorf("%d dead letter(s) kept for the old session", len(letters))
+	}
+	if n, _ := Pending(townRoot, "gt-nux"); n != 0 {
+		t.Errorf("Pending = %d, want old dead letters dropped, not requeued", n)
+	}
+}
+
+func TestSchedulerQueueModeDoesNotCloseCircuit(t *testing.T) {
+	townRoot := t.TempDir()
+	_ = RecordDelivery(townRoot, "gt-nux", errors.New("boom"))
+
+	s := newTestScheduler(townRoot, &fakeSessions{})
+	if status, _ := s.Submit("gt-nux", "hello", SubmitOptions{Mode: ModeQueue}).Wait(); status != StatusQueued {
+		t.Fatalf("status = %s, want queued", status)
+	}
+	if records, _ := LoadFailures(townRoot); records["gt-nux"].Count != 1 {
+		t.Errorf("failure count = %d after a queued nudge, want 1 (queueing isn't a delivery)", records["gt-nux"].Count)
+	}
+}
+
+func TestStoreDeadLetterCapsAndExpires(t *testing.T) {
+	townRoot := t.TempDir()
+	limit := config.DefaultNudgeMaxQueueDepth
+	_ = StoreDeadLetter(townRoot, "gt-nux", QueuedNudge{Message: "expired", ExpiresAt: time.Now().Add(-time.Minute)}, "open")
+	for i := 0; i < limit+5; i++ {
+		_ = StoreDeadLetter(townRoot, "gt-nux", QueuedNudge{Message: fmt.Sprintf("n%d", i)}, "open")
+	}
+	letters, _ := LoadDeadLetters(townRoot, "gt-nux")
+	if len(letters) != limit {
+		t.Fatalf("%d dead letters stored, want the cap of %d", len(letters), limit)
+	}
+	if letters[0].Message != "n5" || letters[len(letters)-1].Message != fmt.Sprintf("n%d", limit+4) {
+		t.Errorf("kept %s..%s, want the newest", letters[0].Message, letters[len(letters)-1].Message)
+	}
+}
@@ -0,0 +1,118 @@
+package nudge
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// FailureRecord tracks consecutive failed nudge deliveries to a session.
+// A successful delivery clears it.
+type FailureRecord struct {
+	Count     int       `json:"count"`
+	LastError string    `json:"last_error,omitempty"`
+	LastAt    time.Time `json:"last_at"`
+	OpenedAt  time.Time `json:"opened_at,omitempty"` // When the circuit last opened; zero while closed
+}
+
+var failuresMu sync.Mutex
+
+// failuresPath returns <townRoot>/.runtime/nudge_failures.json.
+func failuresPath(townRoot string) string {
+	return filepath.Join(townRoot, constants.DirRuntime, "nudge_failures.json")
+}
+
+// RecordDelivery updates a session's consecutive failure count after a nudge
+// attempt: err != nil increments it, nil clears it. Best-effort, like the
+// latency log; the counts feed witness rules and diagnostics. Reaching the
+// operational.nudge circuit_threshold opens the session's circuit (see
+// CheckCircuit); a success closes it and requeues its dead letters.
+func RecordDelivery(townRoot, session string, err error) error {
+	if townRoot == "" || session == "" {
+		return nil
+	}
+	failuresMu.Lock()
+	defer failuresMu.Unlock()
+
+	records, _ := LoadFailures(townRoot)
+	if err == nil {
+		r, ok := records[session]
+		if !ok {
+			return nil // Nothing to clear; avoid rewriting on every success
+		}
+		delete(records, session)
+		if !r.OpenedAt.IsZero() {
+			logCircuit(session, CircuitClosed, r)
+			defer func() { _, _ = RequeueDeadLetters(townRoot, session) }()
+		}
+	} else {
+		if records == nil {
+			records = make(map[string]FailureRecord)
+		}
+		cfg := nudgeConfig(townRoot)
+		r := records[session]
+		r.Count++
+		r.LastError = err.Error()
+		r.LastAt = time.Now()
+		opened := updateCircuit(&r, cfg.CircuitThresholdV(), cfg.CircuitCooldownD(), r.LastAt)
+		records[session] = r
+		_ = events.LogFeed(events.TypeNudgeFailed, session, events.NudgeFailedPayload(session, r.LastError, r.Count))
+		if opened {
+			logCircuit(session, CircuitOpen, r)
+		}
+	}
+
+	path := failuresPath(townRoot)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return util.AtomicWriteJSON(path, records)
+}
+
+// ResetSession forgets a session's delivery failures, closing its circuit,
+// and drops its dead letters. Used when the session has been recreated.
+func ResetSession(townRoot, session string) error {
+	if townRoot == "" || session == "" {
+		return nil
+	}
+	failuresMu.Lock()
+	defer failuresMu.Unlock()
+
+	_ = os.RemoveAll(deadLetterDir(townRoot, session))
+	records, err := LoadFailures(townRoot)
+	if err != nil {
+		return err
+	}
+	r, ok := records[session]
+	if !ok {
+		return nil
+	}
+	delete(records, session)
+	if !r.OpenedAt.IsZero() {
+		logCircuit(session, CircuitClosed, r)
+	}
+	return util.AtomicWriteJSON(failuresPath(townRoot), records)
+}
+
+// LoadFailures returns the consecutive nudge failure records by session.
+// A missing file yields an empty map.
+func LoadFailures(townRoot string) (map[string]FailureRecord, error) {
+	data, err := os.ReadFile(failuresPath(townRoot))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return map[string]FailureRecord{}, nil
+		}
+		return nil, err
+	}
+	records := make(map[string]FailureRecord)
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
@@ -0,0 +1,46 @@
+package nudge
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRecordDelivery(t *testing.T) {
+	townRoot := t.TempDir()
+
+	// Success with no record is a no-op.
+	if err := RecordDelivery(townRoot, "gt-alpha", nil); err != nil {
+		t.Fatalf("RecordDelivery: %v", err)
+	}
+	if got, _ := LoadFailures(townRoot); len(got) != 0 {
+		t.Fatalf("LoadFailures = %v, want empty", got)
+	}
+
+	for i := 0; i < 3; i++ {
+		if err := RecordDelivery(townRoot, "gt-alpha", errors.New("pane not responding")); err != nil {
+			t.Fatalf("RecordDelivery: %v", err)
+		}
+	}
+	_ = RecordDelivery(townRoot, "gt-beta", errors.New("boom"))
+
+	got, err := LoadFailures(townRoot)
+	if err != nil {
+		t.Fatalf("LoadFailures: %v", err)
+	}
+	if got["gt-alpha"].Count != 3 || got["gt-alpha"].LastError != "pane not responding" {
+		t.Errorf("gt-alpha = %+v, want 3 failures", got["gt-alpha"])
+	}
+	if got["gt-beta"].Count != 1 {
+		t.Errorf("gt-beta = %+v, want 1 failure", got["gt-beta"])
+	}
+
+	// A success clears only that session's count.
+	_ = RecordDelivery(townRoot, "gt-alpha", nil)
+	got, _ = LoadFailures(townRoot)
+	if _, ok := got["gt-alpha"]; ok {
+		t.Errorf("gt-alpha still recorded after success: %+v", got["gt-alpha"])
+	}
+	if got["gt-beta"].Count != 1 {
+		t.Errorf("gt-beta = %+v, want 1 failure", got["gt-beta"])
+	}
+}
@@ -0,0 +1,109 @@
+package nudge
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/telemetry"
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// Delivery paths for mail notifications.
+const (
+	// PathDirect is a nudge typed into an idle session right after send.
+	PathDirect = "direct"
+	// PathQueued is a nudge queued for a busy session and drained at its
+	// next turn boundary.
+	PathQueued = "queued"
+)
+
+// maxLatencyLogBytes caps the latency log; when exceeded, the older half
+// is dropped on the next write.
+const maxLatencyLogBytes = 512 * 1024
+
+// DeliveryTrace carries mail send metadata on a queued nudge so that the
+// drain that finally delivers it can record end-to-end latency.
+type DeliveryTrace struct {
+	SentAt   time.Time `json:"sent_at"`
+	Priority string    `json:"priority,omitempty"` // Mail priority (urgent, high, normal, low)
+}
+
+// LatencySample is one mail-to-nudge delivery: the time from the mail being
+// sent to the notification reaching the recipient's session.
+type LatencySample struct {
+	At        time.Time `json:"at"` // Delivery time
+	Session   string    `json:"session"`
+	Priority  string    `json:"priority,omitempty"`
+	Path      string    `json:"path"` // PathDirect or PathQueued
+	LatencyMs float64   `json:"latency_ms"`
+}
+
+// Latency returns the sample's latency as a duration.
+func (s LatencySample) Latency() time.Duration {
+	return time.Duration(s.LatencyMs * float64(time.Millisecond))
+}
+
+// latencyLogPath returns <townRoot>/.runtime/nudge_latency.jsonl.
+func latencyLogPath(townRoot string) string {
+	return filepath.Join(townRoot, constants.DirRuntime, "nudge_latency.jsonl")
+}
+
+// RecordLatency appends a delivery sample to the town's latency log and
+// emits it as telemetry. Best-effort: the log is diagnostic, so callers may
+// ignore the error.
+func RecordLatency(townRoot string, s LatencySample) error {
+	telemetry.RecordMailNotifyLatency(context.Background(), s.Session, s.Priority, s.Path, s.LatencyMs)
+
+	return util.AppendJSONLine(latencyLogPath(townRoot), s, maxLatencyLogBytes)
+}
+
+// LoadLatencies returns delivery samples recorded at or after since, oldest
+// first. A missing log yields no samples.
+func LoadLatencies(townRoot string, since time.Time) ([]LatencySample, error) {
+	f, err := os.Open(latencyLogPath(townRoot))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var samples []LatencySample
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var s LatencySample
+		if json.Unmarshal(scanner.Bytes(), &s) != nil || s.At.Before(since) {
+			continue
+		}
+		samples = append(samples, s)
+	}
+	return samples, scanner.Err()
+}
+
+// LatencyPercentile returns the p-th percentile (0-100) latency of samples
+// using nearest-rank, or 0 for no samples.
+func LatencyPercentile(samples []LatencySample, p float64) time.Duration {
+	if len(samples) == 0 {
+		return 0
+	}
+	ms := make([]float64, len(samples))
+	for i, s := range samples {
+		ms[i] = s.LatencyMs
+	}
+	sort.Float64s(ms)
+	rank := int(p/100*float64(len(ms))+0.999999) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	if rank >= len(ms) {
+		rank = len(ms) - 1
+	}
+	return time.Duration(ms[rank] * float64(time.Millisecond))
+}
@@ -0,0 +1,75 @@
+package nudge
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestLatencyPercentile(t *testing.T) {
+	var samples []LatencySample
+	for i := 1; i <= 100; i++ {
+		samples = append(samples, LatencySample{LatencyMs: float64(i * 1000)})
+	}
+	if got := LatencyPercentile(samples, 95); got != 95*time.Second {
+		t.Errorf("p95 = %s, want 95s", got)
+	}
+	if got := LatencyPercentile(samples, 100); got != 100*time.Second {
+		t.Errorf("max = %s, want 100s", got)
+	}
+	if got := LatencyPercentile(nil, 95); got != 0 {
+		t.Errorf("empty p95 = %s, want 0", got)
+	}
+}
+
+func TestRecordAndLoadLatencies(t *testing.T) {
+	townRoot := t.TempDir()
+	old := time.Now().Add(-2 * time.Hour)
+	if err := RecordLatency(townRoot, LatencySample{At: old, Path: PathDirect, LatencyMs: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if err := RecordLatency(townRoot, LatencySample{At: time.Now(), Path: PathQueued, LatencyMs: 2}); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := LoadLatencies(townRoot, time.Now().Add(-time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].Path != PathQueued {
+		t.Errorf("LoadLatencies = %+v, want only the recent queued sample", got)
+	}
+}
+
+func TestDrainRecordsTracedLatency(t *testing.T) {
+	townRoot := t.TempDir()
+	sentAt := time.Now().Add(-30 * time.Second)
+	if err := Enqueue(townRoot, "gt-toast", QueuedNudge{
+		Sender:  "mayor",
+		Message: "mail",
+		Trace:   &DeliveryTrace{SentAt: sentAt, Priority: "urgent"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := Enqueue(townRoot, "gt-toast", QueuedNudge{Sender: "mayor", Message: "plain"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Drain(townRoot, "gt-toast"); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := LoadLatencies(townRoot, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("recorded %d samples, want 1 (untraced nudges are not mail)", len(got))
+	}
+	s := got[0]
+	if s.Path != PathQueued || s.Priority != "urgent" || s.Session != "gt-toast" || s.Latency() < 30*time.Second {
+		t.Errorf("sample = %+v", s)
+	}
+	if _, err := os.Stat(latencyLogPath(townRoot)); err != nil {
+		t.Errorf("latency log missing: %v", err)
+	}
+}
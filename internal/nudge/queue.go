@@ -7,6 +7,10 @@
 //
 // Queue location: <townRoot>/.runtime/nudge_queue/<session>/
 // Each nudge is a JSON file named by timestamp for FIFO ordering.
+//
+// Subsystems that nudge agents (gt nudge, mail notifications, the
+// dispatcher) submit through a Scheduler, which picks between direct and
+// queued delivery and coalesces nudges that arrive together.
 package nudge
 
 import (
@@ -17,6 +21,7 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -62,6 +67,10 @@ type QueuedNudge struct {
 	Priority  string    `json:"priority"`
 	Timestamp time.Time `json:"timestamp"`
 	ExpiresAt time.Time `json:"expires_at,omitempty"`
+
+	// Trace is set for mail notifications so delivery latency can be
+	// recorded when the nudge is drained.
+	Trace *DeliveryTrace `json:"trace,omitempty"`
 }
 
 // queueDir returns the nudge queue directory for a given session.
@@ -97,7 +106,7 @@ func Enqueue(townRoot, session string, nudge QueuedNudge) error {
 	}
 
 	if nudge.Timestamp.IsZero() {
-		nudge.Timestamp = time.Now()
+		nudge.Timestamp = time.Now().UTC()
 	}
 	if nudge.Priority == "" {
 		nudge.Priority = PriorityNormal
@@ -139,8 +148,14 @@ func Enqueue(townRoot, session string, nudge QueuedNudge) error {
 // before reading, so only one caller can claim each nudge.
 //
 // Expired nudges (past ExpiresAt) are silently discarded during drain.
+// While the session is quiet (gt quiet), non-urgent nudges are moved into
+// its digest instead of being returned.
 // Orphaned .claimed files from crashed drainers are swept if older than 5 minutes.
 func Drain(townRoot, session string) ([]QueuedNudge, error) {
+	// Checked first: an expired quiet period releases its digest into the
+	// queue, to be drained below.
+	_, quiet := CheckQuiet(townRoot, session)
+
 	dir := queueDir(townRoot, session)
 
 	entries, err := os.ReadDir(dir)
@@ -239,6 +254,15 @@ func Drain(townRoot, session string) ([]QueuedNudge, error) {
 		}
 
 		nudges = append(nudges, n)
+		if n.Trace != nil && !n.Trace.SentAt.IsZero() {
+			_ = RecordLatency(townRoot, LatencySample{
+				At:        now,
+				Session:   session,
+				Priority:  n.Trace.Priority,
+				Path:      PathQueued,
+				LatencyMs: float64(now.Sub(n.Trace.SentAt)) / float64(time.Millisecond),
+			})
+		}
 
 		// Remove the claimed file after successful processing
 		if rmErr := os.Remove(claimPath); rmErr != nil {
@@ -246,9 +270,24 @@ func Drain(townRoot, session string) ([]QueuedNudge, error) {
 		}
 	}
 
+	if quiet {
+		nudges = holdForQuiet(townRoot, session, nudges)
+	}
 	return nudges, nil
 }
 
+// holdForQuiet moves non-urgent nudges drained from a quiet session into
+// its digest, returning the ones to deliver now.
+func holdForQuiet(townRoot, session string, nudges []QueuedNudge) []QueuedNudge {
+	var deliver []QueuedNudge
+	for _, n := range nudges {
+		if deferred, err := DeferIfQuiet(townRoot, session, n); err != nil || !deferred {
+			deliver = append(deliver, n)
+		}
+	}
+	return deliver
+}
+
 // Pending returns the count of queued nudges for a session without draining.
 // This is an approximate count — it does not check expiry or read file contents.
 func Pending(townRoot, session string) (int, error) {
@@ -272,6 +311,41 @@ func Pending(townRoot, session string) (int, error) {
 	return count, nil
 }
 
+// PendingSince counts the nudges queued for a session at or after since
+// that haven't been drained yet, including ones a drain has claimed but not
+// finished, and returns the latest of their expiry times. Queue file names
+// start with the enqueue time, so only matching files are read; one a drain
+// removes mid-read still counts but adds no expiry.
+func PendingSince(townRoot, session string, since time.Time) (int, time.Time, error) {
+	dir := queueDir(townRoot, session)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, time.Time{}, nil
+		}
+		return 0, time.Time{}, fmt.Errorf("reading nudge queue: %w", err)
+	}
+	count := 0
+	var expires time.Time
+	for _, entry := range entries {
+		stamp, _, ok := strings.Cut(entry.Name(), "-")
+		if !ok || entry.IsDir() || !strings.Contains(entry.Name(), ".json") {
+			continue
+		}
+		if ns, err := strconv.ParseInt(stamp, 10, 64); err != nil || ns < since.UnixNano() {
+			continue
+		}
+		count++
+		var n QueuedNudge
+		if data, err := os.ReadFile(filepath.Join(dir, entry.Name())); err == nil && json.Unmarshal(data, &n) == nil {
+			if n.ExpiresAt.After(expires) {
+				expires = n.ExpiresAt
+			}
+		}
+	}
+	return count, expires, nil
+}
+
 // FormatForInjection formats queued nudges as a system-reminder block
 // suitable for Claude Code hook output.
 func FormatForInjection(nudges []QueuedNudge) string {
@@ -564,3 +564,32 @@ func TestConcurrentDrainNoDoubleDeli(t *testing.T) {
 		t.Errorf("double delivery detected: got %d total nudges, want exactly %d", total, count)
 	}
 }
+
+func TestPendingSince(t *testing.T) {
+	townRoot := t.TempDir()
+	session := "gt-gastown-nux"
+	early := time.Now().Add(-time.Hour)
+	if err := Enqueue(townRoot, session, QueuedNudge{Message: "old", Timestamp: early}); err != nil {
+		t.Fatal(err)
+	}
+	since := time.Now()
+	if n, _, err := PendingSince(townRoot, session, since); err != nil || n != 0 {
+		t.Fatalf("PendingSince before enqueue = %d, %v; want 0", n, err)
+	}
+	expires := time.Now().Add(time.Minute).UTC().Truncate(time.Second)
+	if err := Enqueue(townRoot, session, QueuedNudge{Message: "new", ExpiresAt: expires}); err != nil {
+		t.Fatal(err)
+	}
+	if n, exp, _ := PendingSince(townRoot, session, since); n != 1 || !exp.Equal(expires) {
+		t.Errorf("PendingSince after enqueue = %d, expiring %v; want 1, %v", n, exp, expires)
+	}
+	if _, err := Drain(townRoot, session); err != nil {
+		t.Fatal(err)
+	}
+	if n, _, _ := PendingSince(townRoot, session, since); n != 0 {
+		t.Errorf("PendingSince after drain = %d, want 0", n)
+	}
+	if n, _, err := PendingSince(townRoot, "gt-nobody", since); err != nil || n != 0 {
+		t.Errorf("PendingSince for an empty queue = %d, %v", n, err)
+	}
+}
@@ -0,0 +1,214 @@
+package nudge
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/lock"
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// QuietSender is the sender of the digest delivered when quiet ends.
+const QuietSender = "gt-quiet"
+
+// QuietState is a temporary mute on a session, set by gt quiet while a human
+// works in it. Non-urgent nudges and mail notifications are held in Digest
+// and delivered as one nudge when the quiet period ends.
+type QuietState struct {
+	Session string        `json:"session"`
+	Since   time.Time     `json:"since"`
+	Until   time.Time     `json:"until"`
+	Reason  string        `json:"reason,omitempty"`
+	Digest  []QueuedNudge `json:"digest,omitempty"`
+}
+
+// Active reports whether the quiet period is still in effect at now.
+func (q QuietState) Active(now time.Time) bool {
+	return now.Before(q.Until)
+}
+
+// quietPath returns <townRoot>/.runtime/quiet.json.
+func quietPath(townRoot string) string {
+	return filepath.Join(townRoot, constants.DirRuntime, "quiet.json")
+}
+
+// withQuietLock runs fn on the quiet states under a cross-process lock and
+// saves them afterwards if fn reports a change.
+func withQuietLock(townRoot string, fn func(states map[string]QuietState) bool) error {
+	path := quietPath(townRoot)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	unlock, err := lock.FlockAcquire(path + ".flock")
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	states, err := LoadQuiet(townRoot)
+	if err != nil {
+		return err
+	}
+	if !fn(states) {
+		return nil
+	}
+	return util.AtomicWriteJSON(path, states)
+}
+
+// LoadQuiet returns quiet states by session, including expired ones not yet
+// released. A missing file yields an empty map.
+func LoadQuiet(townRoot string) (map[string]QuietState, error) {
+	data, err := os.ReadFile(quietPath(townRoot))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return map[string]QuietState{}, nil
+		}
+		return nil, err
+	}
+	states := make(map[string]QuietState)
+	if err := json.Unmarshal(data, &states); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", quietPath(townRoot), err)
+	}
+	return states, nil
+}
+
+// SetQuiet mutes a session for d. Quieting an already quiet session moves
+// its end time and keeps the digest collected so far.
+func SetQuiet(townRoot, session string, d time.Duration, reason string) (QuietState, error) {
+	now := time.Now().UTC()
+	var q QuietState
+	err := withQuietLock(townRoot, func(states map[string]QuietState) bool {
+		q = states[session]
+		if q.Session == "" {
+			q = QuietState{Session: session, Since: now}
+		}
+		q.Until = now.Add(d)
+		if reason != "" {
+			q.Reason = reason
+		}
+		states[session] = q
+		return true
+	})
+	return q, err
+}
+
+// EndQuiet lifts a session's quiet period and delivers its digest. It
+// reports whether the session was quiet.
+func EndQuiet(townRoot, session string) (QuietState, bool, error) {
+	var q QuietState
+	var found bool
+	err := withQuietLock(townRoot, func(states map[string]QuietState) bool {
+		q, found = states[session]
+		delete(states, session)
+		return found
+	})
+	if err != nil || !found {
+		return q, found, err
+	}
+	return q, true, releaseDigest(townRoot, q)
+}
+
+// CheckQuiet reports whether a session is quiet. A quiet period found
+// expired is released here, delivering its digest.
+func CheckQuiet(townRoot, session string) (QuietState, bool) {
+	if townRoot == "" || session == "" {
+		return QuietState{}, false
+	}
+	states, err := LoadQuiet(townRoot)
+	if err != nil {
+		return QuietState{}, false
+	}
+	q, ok := states[session]
+	if !ok {
+		return QuietState{}, false
+	}
+	if q.Active(time.Now()) {
+		return q, true
+	}
+	_, _, _ = EndQuiet(townRoot, session)
+	return QuietState{}, false
+}
+
+// ReleaseExpiredQuiet ends every expired quiet period and delivers their
+// digests. It returns the states it released.
+func ReleaseExpiredQuiet(townRoot string) ([]QuietState, error) {
+	now := time.Now()
+	var expired []QuietState
+	err := withQuietLock(townRoot, func(states map[string]QuietState) bool {
+		for session, q := range states {
+			if !q.Active(now) {
+				expired = append(expired, q)
+				delete(states, session)
+			}
+		}
+		return len(expired) > 0
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(expired, func(i, j int) bool { return expired[i].Session < expired[j].Session })
+	var errs []string
+	for _, q := range expired {
+		if err := releaseDigest(townRoot, q); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", q.Session, err))
+		}
+	}
+	if len(errs) > 0 {
+		return expired, fmt.Errorf("releasing quiet digests: %s", strings.Join(errs, "; "))
+	}
+	return expired, nil
+}
+
+// DeferIfQuiet holds a non-urgent nudge in the session's digest if the
+// session is quiet, and reports whether it did. Urgent nudges always pass.
+func DeferIfQuiet(townRoot, session string, n QueuedNudge) (bool, error) {
+	if n.Priority == PriorityUrgent {
+		return false, nil
+	}
+	if _, quiet := CheckQuiet(townRoot, session); !quiet {
+		return false, nil
+	}
+	if n.Timestamp.IsZero() {
+		n.Timestamp = time.Now().UTC()
+	}
+	deferred := false
+	err := withQuietLock(townRoot, func(states map[string]QuietState) bool {
+		q, ok := states[session]
+		if !ok || !q.Active(time.Now()) {
+			return false // Ended while we weren't holding the lock
+		}
+		q.Digest = append(q.Digest, n)
+		states[session] = q
+		deferred = true
+		return true
+	})
+	return deferred, err
+}
+
+// releaseDigest enqueues a quiet period's held nudges as one summary nudge.
+func releaseDigest(townRoot string, q QuietState) error {
+	if len(q.Digest) == 0 {
+		return nil
+	}
+	return Enqueue(townRoot, q.Session, QueuedNudge{
+		Sender:   QuietSender,
+		Message:  FormatDigest(q),
+		Priority: PriorityNormal,
+	})
+}
+
+// FormatDigest renders the nudges held during a quiet period.
+func FormatDigest(q QuietState) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Quiet period over (since %s). %d nudge(s) were held:", q.Since.Local().Format("15:04"), len(q.Digest))
+	for _, n := range q.Digest {
+		fmt.Fprintf(&b, "\n  [%s from %s] %s", n.Timestamp.Local().Format("15:04"), n.Sender, n.Message)
+	}
+	return b.String()
+}
@@ -0,0 +1,82 @@
+package nudge
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestQuietDefersNonUrgent(t *testing.T) {
+	townRoot := t.TempDir()
+	session := "gt-gastown-crew-max"
+
+	if _, err := SetQuiet(townRoot, session, time.Hour, "debugging"); err != nil {
+		t.Fatalf("SetQuiet: %v", err)
+	}
+
+	deferred, err := DeferIfQuiet(townRoot, session, QueuedNudge{Sender: "mayor", Message: "check mail"})
+	if err != nil || !deferred {
+		t.Fatalf("DeferIfQuiet(normal) = %v, %v; want deferred", deferred, err)
+	}
+	deferred, _ = DeferIfQuiet(townRoot, session, QueuedNudge{Sender: "witness", Message: "fire", Priority: PriorityUrgent})
+	if deferred {
+		t.Error("urgent nudge should not be deferred")
+	}
+	if deferred, _ := DeferIfQuiet(townRoot, "gt-gastown-other", QueuedNudge{Message: "hi"}); deferred {
+		t.Error("nudge to a session that isn't quiet should not be deferred")
+	}
+
+	// Queued nudges drained while quiet: urgent delivered, normal held.
+	_ = Enqueue(townRoot, session, QueuedNudge{Sender: "a", Message: "later"})
+	_ = Enqueue(townRoot, session, QueuedNudge{Sender: "b", Message: "now", Priority: PriorityUrgent})
+	got, err := Drain(townRoot, session)
+	if err != nil {
+		t.Fatalf("Drain: %v", err)
+	}
+	if len(got) != 1 || got[0].Message != "now" {
+		t.Fatalf("Drain while quiet = %+v, want only the urgent nudge", got)
+	}
+
+	q, found, err := EndQuiet(townRoot, session)
+	if err != nil || !found {
+		t.Fatalf("EndQuiet = %v, %v", found, err)
+	}
+	if len(q.Digest) != 2 {
+		t.Errorf("digest has %d nudges, want 2", len(q.Digest))
+	}
+	got, _ = Drain(townRoot, session)
+	if len(got) != 1 || got[0].Sender != QuietSender {
+		t.Fatalf("Drain after quiet = %+v, want one digest nudge", got)
+	}
+	if !strings.Contains(got[0].Message, "check mail") || !strings.Contains(got[0].Message, "later") {
+		t.Errorf("digest message missing held nudges: %q", got[0].Message)
+	}
+}
+
+func TestQuietExpires(t *testing.T) {
+	townRoot := t.TempDir()
+	session := "gt-gastown-nux"
+
+	if _, err := SetQuiet(townRoot, session, time.Hour, ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := DeferIfQuiet(townRoot, session, QueuedNudge{Sender: "mayor", Message: "held"}); err != nil {
+		t.Fatal(err)
+	}
+	// Move the end time into the past.
+	if _, err := SetQuiet(townRoot, session, -time.Minute, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, quiet := CheckQuiet(townRoot, session); quiet {
+		t.Fatal("expired quiet period still reported active")
+	}
+	states, _ := LoadQuiet(townRoot)
+	if _, ok := states[session]; ok {
+		t.Error("expired quiet period was not released")
+	}
+	got, _ := Drain(townRoot, session)
+	if len(got) != 1 || !strings.Contains(got[0].Message, "held") {
+		t.Errorf("Drain after expiry = %+v, want the digest", got)
+	}
+}
@@ -0,0 +1,419 @@
+package nudge
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+// Delivery modes for Scheduler.Submit.
+const (
+	// ModeWaitIdle waits for the session's prompt, then types the nudge.
+	// A session still busy after the idle timeout gets it queued instead.
+	ModeWaitIdle = "wait-idle"
+	// ModeQueue queues the nudge for the session's next turn boundary.
+	ModeQueue = "queue"
+	// ModeImmediate types the nudge right away, interrupting in-flight work.
+	ModeImmediate = "immediate"
+)
+
+// DefaultIdleTimeout is how long wait-idle delivery polls for an idle
+// prompt before queueing instead.
+const DefaultIdleTimeout = 15 * time.Second
+
+// Status is the outcome of a submitted nudge.
+type Status string
+
+// Nudge outcomes.
+const (
+	StatusPending   Status = "pending"   // Not delivered yet
+	StatusDelivered Status = "delivered" // Typed into the session
+	StatusQueued    Status = "queued"    // Queued for the session's next turn
+	StatusHeld      Status = "held"      // Held for a quiet session's digest
+	StatusFailed    Status = "failed"
+)
+
+// Sessions is the part of tmux the scheduler delivers through.
+type Sessions interface {
+	WaitForIdle(session string, timeout time.Duration) error
+	NudgeSession(session, message string) error
+}
+
+// optionSessions is implemented by Sessions that honor delivery options,
+// as *tmux.Tmux does.
+type optionSessions interface {
+	NudgeSessionWith(session, message string, opts tmux.NudgeOptions) error
+}
+
+// createdSessions is implemented by Sessions that can tell when a session
+// was created, as *tmux.Tmux does. The scheduler uses it to forget the
+// failures of a session that has since been recreated.
+type createdSessions interface {
+	GetSessionCreatedUnix(session string) (int64, error)
+}
+
+// SubmitOptions controls how a nudge is delivered.
+type SubmitOptions struct {
+	Sender      string         // Shown as "[from <sender>]"
+	Priority    string         // PriorityNormal (default) or PriorityUrgent
+	Mode        string         // ModeWaitIdle (default), ModeQueue, or ModeImmediate
+	Force       bool           // Deliver even while the session is quiet
+	IdleTimeout time.Duration  // Wait-idle poll limit (default DefaultIdleTimeout)
+	Trace       *DeliveryTrace // Records send-to-delivery latency (mail notifications)
+	// Delivery adjusts direct delivery (typing profile, restoring pending
+	// input). A batch is delivered with its first nudge's options.
+	Delivery tmux.NudgeOptions
+}
+
+// Future is the eventual outcome of a submitted nudge.
+type Future struct {
+	done   chan struct{}
+	status Status
+	err    error
+}
+
+func newFuture() *Future {
+	return &Future{done: make(chan struct{}), status: StatusPending}
+}
+
+func (f *Future) resolve(status Status, err error) {
+	f.status, f.err = status, err
+	close(f.done)
+}
+
+// Done is closed once the nudge's outcome is known.
+func (f *Future) Done() <-chan struct{} {
+	return f.done
+}
+
+// Wait blocks until the outcome is known and returns it. The error is
+// non-nil only with StatusFailed.
+func (f *Future) Wait() (Status, error) {
+	<-f.done
+	return f.status, f.err
+}
+
+// Status returns the outcome so far: StatusPending until it is known.
+func (f *Future) Status() Status {
+	select {
+	case <-f.done:
+		return f.status
+	default:
+		return StatusPending
+	}
+}
+
+type pendingNudge struct {
+	message string
+	opts    SubmitOptions
+	future  *Future
+}
+
+// batch is the nudges to one session, in one mode, collected during a
+// batching window.
+type batch struct {
+	session     string
+	mode        string
+	idleTimeout time.Duration
+	items       []pendingNudge
+}
+
+// Scheduler delivers nudges for any subsystem that needs to get an agent's
+// attention. It owns the delivery protocol: quiet-hour holds, waiting for an
+// idle prompt, queueing for busy sessions, retrying direct delivery, and
+// recording failures and latency. Nudges to the same session within the
+// batching window are coalesced into one delivery, so an agent that gets
+// mail, an assignment, and a reminder at once is interrupted once.
+type Scheduler struct {
+	// Window is how long to collect nudges to a session before delivering
+	// them. Zero delivers each nudge on its own.
+	Window time.Duration
+	// Attempts is how many times to try a direct delivery.
+	Attempts int
+	// RetryBackoff is the delay before the second attempt; it grows
+	// linearly with each further attempt.
+	RetryBackoff time.Duration
+
+	townRoot string
+	sessions Sessions
+
+	mu      sync.Mutex
+	batches map[string]*batch
+	wg      sync.WaitGroup
+}
+
+// NewScheduler returns a scheduler for the town at townRoot, delivering
+// through sessions, with its window and attempts from the town's
+// operational.nudge settings. Without a town root nudges can't be queued or
+// held, so busy sessions get them directly.
+func NewScheduler(townRoot string, sessions Sessions) *Scheduler {
+	cfg := &config.NudgeThresholds{}
+	if townRoot != "" {
+		cfg = nudgeConfig(townRoot)
+	}
+	return &Scheduler{
+		Window:       cfg.BatchWindowD(),
+		Attempts:     cfg.DeliveryAttemptsV(),
+		RetryBackoff: cfg.RetryIntervalD(),
+		townRoot:     townRoot,
+		sessions:     sessions,
+		batches:      make(map[string]*batch),
+	}
+}
+
+var (
+	schedulersMu sync.Mutex
+	schedulers   = make(map[string]*Scheduler)
+)
+
+// SchedulerFor returns this process's shared scheduler for townRoot,
+// delivering through tmux. Subsystems sharing it get their nudges to the
+// same session coalesced.
+func SchedulerFor(townRoot string) *Scheduler {
+	schedulersMu.Lock()
+	defer schedulersMu.Unlock()
+	s, ok := schedulers[townRoot]
+	if !ok {
+		s = NewScheduler(townRoot, tmux.NewTmux())
+		schedulers[townRoot] = s
+	}
+	return s
+}
+
+// Submit schedules a nudge to a tmux session and returns its future. The
+// nudge is delivered after the batching window unless it is held for a
+// quiet session or queued, which happen right away. A nudge to a session
+// whose circuit is open fails at once with ErrCircuitOpen and is stored
+// as a dead letter.
+func (s *Scheduler) Submit(session, message string, opts SubmitOptions) *Future {
+	if opts.Priority == "" {
+		opts.Priority = PriorityNormal
+	}
+	if opts.Mode == "" {
+		opts.Mode = ModeWaitIdle
+	}
+	if opts.IdleTimeout <= 0 {
+		opts.IdleTimeout = DefaultIdleTimeout
+	}
+	p := pendingNudge{message: message, opts: opts, future: newFuture()}
+
+	if s.townRoot != "" && !opts.Force {
+		if deferred, err := DeferIfQuiet(s.townRoot, session, p.queued()); err == nil && deferred {
+			p.future.resolve(StatusHeld, nil)
+			return p.future
+		}
+	}
+
+	// An open circuit fails fast rather than waiting on a session that has
+	// kept failing; the nudge is kept for when the circuit closes.
+	if s.townRoot != "" {
+		s.resetIfRespawned(session)
+		if err := CheckCircuit(s.townRoot, session); err != nil {
+			_ = StoreDeadLetter(s.townRoot, session, p.queued(), err.Error())
+			p.future.resolve(StatusFailed, err)
+			return p.future
+		}
+	}
+
+	if opts.Mode == ModeQueue {
+		// Queued nudges are already coalesced by the hook that drains them.
+		var err error
+		if s.townRoot == "" {
+			err = fmt.Errorf("queued delivery requires a Gas Town workspace")
+		} else {
+			err = Enqueue(s.townRoot, session, p.queued())
+		}
+		if err != nil {
+			// Only failures count: a queued nudge hasn't reached the
+			// session, so it says nothing about whether delivery works.
+			_ = RecordDelivery(s.townRoot, session, err)
+			p.future.resolve(StatusFailed, err)
+		} else {
+			p.future.resolve(StatusQueued, nil)
+		}
+		return p.future
+	}
+
+	key := session + "\x00" + opts.Mode
+	s.mu.Lock()
+	b, ok := s.batches[key]
+	if !ok {
+		b = &batch{session: session, mode: opts.Mode}
+		s.batches[key] = b
+		s.wg.Add(1)
+		time.AfterFunc(s.Window, func() { s.flush(key) })
+	}
+	b.items = append(b.items, p)
+	b.idleTimeout = max(b.idleTimeout, opts.IdleTimeout)
+	s.mu.Unlock()
+	return p.future
+}
+
+// Wait blocks until every nudge submitted so far has an outcome. Short-lived
+// processes call it before exiting so batched nudges aren't lost.
+func (s *Scheduler) Wait() {
+	s.wg.Wait()
+}
+
+func (s *Scheduler) flush(key string) {
+	defer s.wg.Done()
+	s.mu.Lock()
+	b := s.batches[key]
+	delete(s.batches, key)
+	s.mu.Unlock()
+	s.deliver(b)
+}
+
+// deliver runs the delivery protocol for one batch and resolves its futures.
+// The outcome is recorded before any future resolves, so a caller that
+// waits sees the failure count already updated. A batch that was only
+// queued records nothing.
+func (s *Scheduler) deliver(b *batch) {
+	var err error
+	var queued, failed []pendingNudge
+	delivered := b.items
+	defer func() {
+		if err != nil || len(delivered) > 0 {
+			_ = RecordDelivery(s.townRoot, b.session, err)
+		}
+		resolveAll(queued, StatusQueued, nil)
+		resolveAll(failed, StatusFailed, err)
+		resolveAll(delivered, StatusDelivered, nil)
+	}()
+
+	if b.mode == ModeWaitIdle {
+		waitErr := s.sessions.WaitForIdle(b.session, b.idleTimeout)
+		if isTerminal(waitErr) {
+			// Queueing for a dead session means it would never be delivered.
+			err = fmt.Errorf("wait-idle: %w", waitErr)
+			failed, delivered = delivered, nil
+			return
+		}
+		if waitErr != nil && s.townRoot != "" {
+			// Busy: queue for the next turn boundary rather than interrupt.
+			// Anything the queue refuses is delivered directly below;
+			// better to interrupt than lose it.
+			if queued, delivered = s.enqueue(b.session, delivered); len(delivered) == 0 {
+				return
+			}
+		}
+	}
+
+	if err = s.direct(b.session, coalesce(delivered), delivered[0].opts.Delivery); err != nil {
+		failed, delivered = delivered, nil
+		if b.mode == ModeWaitIdle && s.townRoot != "" && !isTerminal(err) {
+			var more []pendingNudge
+			more, failed = s.enqueue(b.session, failed)
+			queued = append(queued, more...)
+		}
+		return
+	}
+	now := time.Now()
+	for _, p := range delivered {
+		if p.opts.Trace != nil && !p.opts.Trace.SentAt.IsZero() && s.townRoot != "" {
+			_ = RecordLatency(s.townRoot, LatencySample{
+				At:        now,
+				Session:   b.session,
+				Priority:  p.opts.Trace.Priority,
+				Path:      PathDirect,
+				LatencyMs: float64(now.Sub(p.opts.Trace.SentAt)) / float64(time.Millisecond),
+			})
+		}
+	}
+}
+
+// resetIfRespawned clears the session's failure record, closing its circuit,
+// and drops its dead letters if the session was created after its last
+// failure: those failures were the previous session's, and the nudges
+// stored for it are stale for the new one.
+func (s *Scheduler) resetIfRespawned(session string) {
+	cs, ok := s.sessions.(createdSessions)
+	if !ok {
+		return
+	}
+	records, err := LoadFailures(s.townRoot)
+	if err != nil {
+		return
+	}
+	r, ok := records[session]
+	if !ok {
+		return
+	}
+	created, err := cs.GetSessionCreatedUnix(session)
+	if err != nil || created <= r.LastAt.Unix() {
+		return
+	}
+	_ = ResetSession(s.townRoot, session)
+}
+
+// direct types text into the session, retrying failures that might pass.
+func (s *Scheduler) direct(session, text string, opts tmux.NudgeOptions) error {
+	send := s.sessions.NudgeSession
+	if withOpts, ok := s.sessions.(optionSessions); ok && opts != (tmux.NudgeOptions{}) {
+		send = func(session, message string) error { return withOpts.NudgeSessionWith(session, message, opts) }
+	}
+	attempts := max(s.Attempts, 1)
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if err = send(session, text); err == nil || isTerminal(err) {
+			return err
+		}
+		if attempt < attempts {
+			time.Sleep(s.RetryBackoff * time.Duration(attempt))
+		}
+	}
+	return err
+}
+
+// enqueue queues items for the session and returns the ones queued and the
+// ones the queue refused.
+func (s *Scheduler) enqueue(session string, items []pendingNudge) (queued, refused []pendingNudge) {
+	for _, p := range items {
+		if err := Enqueue(s.townRoot, session, p.queued()); err != nil {
+			refused = append(refused, p)
+			continue
+		}
+		queued = append(queued, p)
+	}
+	return queued, refused
+}
+
+func (p pendingNudge) queued() QueuedNudge {
+	return QueuedNudge{Sender: p.opts.Sender, Message: p.message, Priority: p.opts.Priority, Trace: p.opts.Trace}
+}
+
+// coalesce renders a batch as the text of one direct nudge, attributing
+// each message to its sender and dropping exact repeats. Messages are
+// joined on one line, since a newline typed into the session submits it.
+func coalesce(items []pendingNudge) string {
+	seen := make(map[string]bool, len(items))
+	var parts []string
+	for _, p := range items {
+		text := p.message
+		if p.opts.Sender != "" {
+			text = fmt.Sprintf("[from %s] %s", p.opts.Sender, p.message)
+		}
+		if !seen[text] {
+			seen[text] = true
+			parts = append(parts, text)
+		}
+	}
+	return strings.Join(parts, " | ")
+}
+
+func resolveAll(items []pendingNudge, status Status, err error) {
+	for _, p := range items {
+		p.future.resolve(status, err)
+	}
+}
+
+// isTerminal reports whether a delivery error means the session can't be
+// reached at all, so retrying or queueing is pointless.
+func isTerminal(err error) bool {
+	return errors.Is(err, tmux.ErrSessionNotFound) || errors.Is(err, tmux.ErrNoServer)
+}
@@ -0,0 +1,203 @@
+package nudge
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+// fakeSessions records deliveries and returns scripted errors.
+type fakeSessions struct {
+	mu       sync.Mutex
+	waitErr  error
+	nudgeErr []error // Returned in order by NudgeSession; nil once exhausted
+	typed    []string
+}
+
+func (f *fakeSessions) WaitForIdle(session string, timeout time.Duration) error {
+	return f.waitErr
+}
+
+func (f *fakeSessions) NudgeSession(session, message string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(f.nudgeErr) > 0 {
+		err := f.nudgeErr[0]
+		f.nudgeErr = f.nudgeErr[1:]
+		if err != nil {
+			return err
+		}
+	}
+	f.typed = append(f.typed, message)
+	return nil
+}
+
+func newTestScheduler(townRoot string, sessions Sessions) *Scheduler {
+	s := NewScheduler(townRoot, sessions)
+	s.Window = 20 * time.Millisecond
+	s.RetryBackoff = time.Millisecond
+	return s
+}
+
+func TestSchedulerCoalesces(t *testing.T) {
+	fake := &fakeSessions{}
+	s := newTestScheduler(t.TempDir(), fake)
+
+	f1 := s.Submit("gt-nux", "You have mail", SubmitOptions{Sender: "mayor/"})
+	f2 := s.Submit("gt-nux", "New work on your hook", SubmitOptions{Sender: "dispatcher"})
+	f3 := s.Submit("gt-nux", "You have mail", SubmitOptions{Sender: "mayor/"})
+	if f1.Status() != StatusPending {
+		t.Errorf("Status before window = %s, want pending", f1.Status())
+	}
+	s.Wait()
+
+	for _, f := range []*Future{f1, f2, f3} {
+		if status, err := f.Wait(); status != StatusDelivered || err != nil {
+			t.Errorf("Wait() = %s, %v; want delivered", status, err)
+		}
+	}
+	want := "[from mayor/] You have mail | [from dispatcher] New work on your hook"
+	if len(fake.typed) != 1 || fake.typed[0] != want {
+		t.Errorf("typed = %q, want one nudge %q", fake.typed, want)
+	}
+}
+
+func TestSchedulerQueuesWhenBusy(t *testing.T) {
+	townRoot := t.TempDir()
+	fake := &fakeSessions{waitErr: tmux.ErrIdleTimeout}
+	s := newTestScheduler(townRoot, fake)
+
+	status, err := s.Submit("gt-nux", "hello", SubmitOptions{Sender: "mayor/"}).Wait()
+	if status != StatusQueued || err != nil {
+		t.Fatalf("Wait() = %s, %v; want queued", status, err)
+	}
+	if len(fake.typed) != 0 {
+		t.Errorf("typed %q into a busy session", fake.typed)
+	}
+	if n, _ := Pending(townRoot, "gt-nux"); n != 1 {
+		t.Errorf("Pending = %d, want 1", n)
+	}
+}
+
+func TestSchedulerDeadSession(t *testing.T) {
+	townRoot := t.TempDir()
+	fake := &fakeSessions{waitErr: tmux.ErrSessionNotFound}
+	s := newTestScheduler(townRoot, fake)
+
+	status, err := s.Submit("gt-nux", "hello", SubmitOptions{}).Wait()
+	if status != StatusFailed || !errors.Is(err, tmux.ErrSessionNotFound) {
+		t.Fatalf("Wait() = %s, %v; want failed with ErrSessionNotFound", status, err)
+	}
+	if n, _ := Pending(townRoot, "gt-nux"); n != 0 {
+		t.Errorf("queued %d nudge(s) for a dead session", n)
+	}
+	if records, _ := LoadFailures(townRoot); records["gt-nux"].Count != 1 {
+		t.Errorf("failure count = %d, want 1", records["gt-nux"].Count)
+	}
+}
+
+func TestSchedulerRetries(t *testing.T) {
+	townRoot := t.TempDir()
+	flaky := errors.New("send-keys failed")
+
+	fake := &fakeSessions{nudgeErr: []error{flaky, flaky}}
+	s := newTestScheduler(townRoot, fake)
+	status, err := s.Submit("gt-nux", "hello", SubmitOptions{Mode: ModeImmediate}).Wait()
+	if status != StatusDelivered || err != nil {
+		t.Fatalf("Wait() = %s, %v; want delivered on the third attempt", status, err)
+	}
+
+	// Out of attempts in wait-idle mode: fall back to the queue.
+	fake = &fakeSessions{nudgeErr: []error{flaky, flaky, flaky}}
+	s = newTestScheduler(townRoot, fake)
+	status, err = s.Submit("gt-nux", "hello", SubmitOptions{}).Wait()
+	if status != StatusQueued || err != nil {
+		t.Fatalf("Wait() = %s, %v; want queued after failed attempts", status, err)
+	}
+
+	// Immediate mode doesn't queue.
+	fake = &fakeSessions{nudgeErr: []error{flaky, flaky, flaky}}
+	s = newTestScheduler(townRoot, fake)
+	status, err = s.Submit("gt-nux", "hello", SubmitOptions{Mode: ModeImmediate}).Wait()
+	if status != StatusFailed || !errors.Is(err, flaky) {
+		t.Fatalf("Wait() = %s, %v; want failed", status, err)
+	}
+}
+
+func TestSchedulerQueueMode(t *testing.T) {
+	townRoot := t.TempDir()
+	fake := &fakeSessions{}
+	s := newTestScheduler(townRoot, fake)
+
+	f := s.Submit("gt-nux", "hello", SubmitOptions{Mode: ModeQueue, Priority: PriorityUrgent})
+	if f.Status() != StatusQueued {
+		t.Fatalf("Status() = %s, want queued without waiting for the window", f.Status())
+	}
+	nudges, err := Drain(townRoot, "gt-nux")
+	if err != nil || len(nudges) != 1 || nudges[0].Priority != PriorityUrgent {
+		t.Errorf("Drain() = %+v, %v; want one urgent nudge", nudges, err)
+	}
+
+	if status, err := NewScheduler("", fake).Submit("gt-nux", "hello", SubmitOptions{Mode: ModeQueue}).Wait(); status != StatusFailed || err == nil {
+		t.Errorf("queue without a town = %s, %v; want failed", status, err)
+	}
+}
+
+func TestSchedulerHoldsForQuiet(t *testing.T) {
+	townRoot := t.TempDir()
+	if _, err := SetQuiet(townRoot, "gt-nux", time.Hour, "focus"); err != nil {
+		t.Fatalf("SetQuiet: %v", err)
+	}
+	fake := &fakeSessions{}
+	s := newTestScheduler(townRoot, fake)
+
+	if status := s.Submit("gt-nux", "hello", SubmitOptions{}).Status(); status != StatusHeld {
+		t.Errorf("normal nudge to quiet session = %s, want held", status)
+	}
+	f := s.Submit("gt-nux", "fire", SubmitOptions{Priority: PriorityUrgent})
+	if status, _ := f.Wait(); status != StatusDelivered {
+		t.Errorf("urgent nudge to quiet session = %s, want delivered", status)
+	}
+	f = s.Submit("gt-nux", "forced", SubmitOptions{Force: true})
+	if status, _ := f.Wait(); status != StatusDelivered {
+		t.Errorf("forced nudge to quiet session = %s, want delivered", status)
+	}
+}
+
+// optionFakeSessions also honors delivery options, as *tmux.Tmux does.
+type optionFakeSessions struct {
+	fakeSessions
+	opts []tmux.NudgeOptions
+}
+
+func (f *optionFakeSessions) NudgeSessionWith(session, message string, opts tmux.NudgeOptions) error {
+	f.mu.Lock()
+	f.opts = append(f.opts, opts)
+	f.mu.Unlock()
+	return f.NudgeSession(session, message)
+}
+
+func TestSchedulerPassesDeliveryOptions(t *testing.T) {
+	fake := &optionFakeSessions{}
+	s := newTestScheduler(t.TempDir(), fake)
+	profile := tmux.DefaultTypingProfile
+	delivery := tmux.NudgeOptions{Typing: &profile, RestoreInput: true}
+
+	if status, err := s.Submit("gt-nux", "hello", SubmitOptions{Mode: ModeImmediate, Delivery: delivery}).Wait(); status != StatusDelivered || err != nil {
+		t.Fatalf("Wait() = %s, %v; want delivered", status, err)
+	}
+	if len(fake.opts) != 1 || fake.opts[0] != delivery {
+		t.Errorf("delivered with %+v, want %+v", fake.opts, delivery)
+	}
+
+	// Without options the plain NudgeSession path is used.
+	if _, err := s.Submit("gt-nux", "again", SubmitOptions{Mode: ModeImmediate}).Wait(); err != nil {
+		t.Fatal(err)
+	}
+	if len(fake.opts) != 1 || len(fake.typed) != 2 {
+		t.Errorf("opts = %d, typed = %d; want the second nudge sent without options", len(fake.opts), len(fake.typed))
+	}
+}
@@ -0,0 +1,232 @@
+// Package placement chooses which machine a new polecat session runs on.
+//
+// A rig with a placement policy (rigs.json "placement") has a pool of
+// candidate hosts: its own host, or this machine, plus any extra SSH hosts.
+// When a polecat starts, each candidate is probed for CPU count, load, and
+// GPU utilization, and the policy picks one. The choice is recorded in
+// .runtime/placements.json and installed into tmux's routing, so every later
+// command for that session (nudge, capture, kill) reaches the same machine.
+package placement
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+// idleGPUPercent is the utilization at or below which a GPU counts as idle.
+const idleGPUPercent = 10
+
+// Candidate is a host's probed capacity.
+type Candidate struct {
+	Host     string // SSH destination, "" for this machine
+	CPUs     int
+	Load     float64 // 1-minute load average
+	GPUUtil  []int   // Per-GPU utilization percent; empty when no GPUs
+	Polecats int     // Running polecat sessions already placed here
+	Err      error   // Set when the host couldn't be probed
+}
+
+// Name returns a display name for the candidate.
+func (c Candidate) Name() string {
+	if c.Host == "" {
+		return "local"
+	}
+	return c.Host
+}
+
+// Score is the host's (load + polecats) per CPU; lower is better. Counting
+// placed polecats keeps a burst of starts from piling onto one host before
+// its load average catches up.
+func (c Candidate) Score() float64 {
+	cpus := c.CPUs
+	if cpus < 1 {
+		cpus = 1
+	}
+	return (c.Load + float64(c.Polecats)) / float64(cpus)
+}
+
+// IdleGPUs returns how many GPUs are at or below idleGPUPercent.
+func (c Candidate) IdleGPUs() int {
+	n := 0
+	for _, u := range c.GPUUtil {
+		if u <= idleGPUPercent {
+			n++
+		}
+	}
+	return n
+}
+
+// String summarizes the candidate for logs and `gt rig placement`.
+func (c Candidate) String() string {
+	if c.Err != nil {
+		return fmt.Sprintf("%s: unreachable (%v)", c.Name(), c.Err)
+	}
+	s := fmt.Sprintf("%s: load %.2f on %d cpus, %d polecats, score %.2f", c.Name(), c.Load, c.CPUs, c.Polecats, c.Score())
+	if len(c.GPUUtil) > 0 {
+		s += fmt.Sprintf(", %d/%d GPUs idle", c.IdleGPUs(), len(c.GPUUtil))
+	}
+	return s
+}
+
+// Choose picks a candidate under policy. Unreachable candidates are
+// skipped; ties keep candidate order, so the rig's own host wins them.
+func Choose(policy string, candidates []Candidate) (Candidate, error) {
+	var ok []Candidate
+	for _, c := range candidates {
+		if c.Err == nil && c.CPUs > 0 {
+			ok = append(ok, c)
+		}
+	}
+	if len(ok) == 0 {
+		return Candidate{}, fmt.Errorf("no reachable placement hosts")
+	}
+
+	switch policy {
+	case config.PlacementLeastLoaded:
+		sort.SliceStable(ok, func(i, j int) bool { return ok[i].Score() < ok[j].Score() })
+	case config.PlacementGPU:
+		// Idle GPUs first, then any GPU, then by score.
+		sort.SliceStable(ok, func(i, j int) bool {
+			gi, gj := ok[i].IdleGPUs() > 0, ok[j].IdleGPUs() > 0
+			if gi != gj {
+				return gi
+			}
+			hi, hj := len(ok[i].GPUUtil) > 0, len(ok[j].GPUUtil) > 0
+			if hi != hj {
+				return hi
+			}
+			return ok[i].Score() < ok[j].Score()
+		})
+	default:
+		return Candidate{}, fmt.Errorf("unknown placement policy %q", policy)
+	}
+	return ok[0], nil
+}
+
+// Candidates returns the hosts eligible for a rig's polecats: the rig's own
+// host ("" for this machine) followed by the configured extra hosts.
+func Candidates(entry config.RigEntry) []string {
+	hosts := []string{entry.Host}
+	seen := map[string]bool{entry.Host: true}
+	if entry.Placement != nil {
+		for _, h := range entry.Placement.Hosts {
+			if h == "local" {
+				h = ""
+			}
+			if !seen[h] {
+				seen[h] = true
+				hosts = append(hosts, h)
+			}
+		}
+	}
+	return hosts
+}
+
+// ProbeAll probes every candidate concurrently and counts the running
+// sessions with sessionPrefix already placed on each. It always probes
+// afresh, refreshing the cache Place reads.
+func ProbeAll(ctx context.Context, townRoot, sessionPrefix string, hosts []string) []Candidate {
+	out, _ := probeAll(ctx, townRoot, sessionPrefix, hosts, 0)
+	return out
+}
+
+// probeAll is ProbeAll, reusing cached probes younger than maxAge. It also
+// returns the running sessions, or nil when tmux couldn't list them.
+func probeAll(ctx context.Context, townRoot, sessionPrefix string, hosts []string, maxAge time.Duration) ([]Candidate, map[string]bool) {
+	cache := loadProbeCache(townRoot)
+	now := time.Now()
+	out := make([]Candidate, len(hosts))
+	fresh := make([]bool, len(hosts))
+	var wg sync.WaitGroup
+	for i, h := range hosts {
+		if p, ok := cache[h]; ok && maxAge > 0 && now.Sub(p.At) < maxAge {
+			out[i] = p.candidate(h)
+			continue
+		}
+		fresh[i] = true
+		wg.Add(1)
+		go func(i int, h string) {
+			defer wg.Done()
+			out[i] = Probe(ctx, h)
+		}(i, h)
+	}
+	wg.Wait()
+	if slices.Contains(fresh, true) {
+		for i, c := range out {
+			if fresh[i] {
+				cache[c.Host] = newCachedProbe(c, now)
+			}
+		}
+		saveProbeCache(townRoot, cache)
+	}
+
+	var running map[string]bool
+	if sessions, err := tmux.NewTmux().ListSessions(); err == nil {
+		running = make(map[string]bool, len(sessions))
+		for _, s := range sessions {
+			running[s] = true
+		}
+	}
+	perHost := make(map[string]int)
+	for s := range running {
+		if strings.HasPrefix(s, sessionPrefix) {
+			perHost[tmux.HostForSession(s)]++
+		}
+	}
+	for i := range out {
+		out[i].Polecats = perHost[out[i].Host]
+	}
+	return out, running
+}
+
+// Place chooses a host for a new session of rigName and records it. It
+// returns ok=false, leaving routing untouched, when the rig has no
+// placement policy (or "local").
+func Place(ctx context.Context, townRoot, rigName, sessionPrefix, session string) (chosen Candidate, ok bool, err error) {
+	rigs, err := config.LoadRigsConfig(constants.MayorRigsPath(townRoot))
+	if err != nil {
+		return Candidate{}, false, nil
+	}
+	entry, found := rigs.Rigs[rigName]
+	if !found || entry.Placement == nil || entry.Placement.Policy == "" || entry.Placement.Policy == config.PlacementLocal {
+		return Candidate{}, false, nil
+	}
+
+	candidates, running := probeAll(ctx, townRoot, sessionPrefix, Candidates(entry), probeCacheTTL)
+	chosen, err = Choose(entry.Placement.Policy, candidates)
+	if err != nil {
+		return Candidate{}, false, err
+	}
+	if err := Record(townRoot, session, chosen.Host); err != nil {
+		return Candidate{}, false, fmt.Errorf("recording placement: %w", err)
+	}
+	if running != nil {
+		_, _ = Prune(townRoot, staleFilter(session, running, candidates))
+	}
+	tmux.SetSessionHost(session, chosen.Host)
+	return chosen, true, nil
+}
+
+// staleFilter keeps a placement unless its session is gone from a host that
+// just answered a probe. Sessions on unreachable or unprobed hosts keep
+// their placement so routing survives a host blip.
+func staleFilter(placed string, running map[string]bool, candidates []Candidate) func(session, host string) bool {
+	reachable := make(map[string]bool)
+	for _, c := range candidates {
+		if c.Err == nil {
+			reachable[c.Host] = true
+		}
+	}
+	return func(session, host string) bool {
+		return session == placed || running[session] || !reachable[host]
+	}
+}
@@ -0,0 +1,130 @@
+package placement
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+func TestParseProbe(t *testing.T) {
+	var c Candidate
+	if err := parseProbe("16\n3.50\n5 97 \n", &c); err != nil {
+		t.Fatal(err)
+	}
+	if c.CPUs != 16 || c.Load != 3.5 || len(c.GPUUtil) != 2 || c.IdleGPUs() != 1 {
+		t.Errorf("got %+v", c)
+	}
+
+	c = Candidate{}
+	if err := parseProbe("4\n0.10\n\n", &c); err != nil || len(c.GPUUtil) != 0 {
+		t.Errorf("no-GPU host: %+v, %v", c, err)
+	}
+	if err := parseProbe("garbage\n", &c); err == nil {
+		t.Error("expected error for malformed output")
+	}
+}
+
+func TestChoose(t *testing.T) {
+	busy := Candidate{Host: "", CPUs: 8, Load: 7}
+	idle := Candidate{Host: "box2", CPUs: 8, Load: 1}
+	crowded := Candidate{Host: "box3", CPUs: 8, Load: 0, Polecats: 6}
+	gpuBusy := Candidate{Host: "gpu1", CPUs: 8, Load: 0.5, GPUUtil: []int{95}}
+	gpuIdle := Candidate{Host: "gpu2", CPUs: 8, Load: 6, GPUUtil: []int{90, 2}}
+	down := Candidate{Host: "down", Err: errors.New("timeout")}
+
+	tests := []struct {
+		name   string
+		policy string
+		cands  []Candidate
+		want   string
+	}{
+		{"least loaded", config.PlacementLeastLoaded, []Candidate{busy, idle, crowded}, "box2"},
+		{"skips unreachable", config.PlacementLeastLoaded, []Candidate{down, busy}, ""},
+		{"idle gpu wins over load", config.PlacementGPU, []Candidate{idle, gpuBusy, gpuIdle}, "gpu2"},
+		{"busy gpu beats no gpu", config.PlacementGPU, []Candidate{idle, gpuBusy}, "gpu1"},
+		{"gpu falls back to load", config.PlacementGPU, []Candidate{busy, idle}, "box2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Choose(tt.policy, tt.cands)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Host != tt.want {
+				t.Errorf("chose %q, want %q", got.Host, tt.want)
+			}
+		})
+	}
+
+	if _, err := Choose(config.PlacementLeastLoaded, []Candidate{down}); err == nil {
+		t.Error("expected error when no host is reachable")
+	}
+}
+
+func TestCandidates(t *testing.T) {
+	entry := config.RigEntry{
+		Host:      "box1",
+		Placement: &config.PlacementConfig{Hosts: []string{"local", "box1", "box2"}},
+	}
+	got := Candidates(entry)
+	want := []string{"box1", "", "box2"}
+	if len(got) != len(want) {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got %q, want %q", got, want)
+		}
+	}
+}
+
+func TestRecordAndLoad(t *testing.T) {
+	dir := t.TempDir()
+	if err := Record(dir, "gt-toast", "box2"); err != nil {
+		t.Fatal(err)
+	}
+	if err := Record(dir, "gt-nux", ""); err != nil {
+		t.Fatal(err)
+	}
+	got := Load(dir)
+	if got["gt-toast"] != "box2" {
+		t.Errorf("Load = %v", got)
+	}
+	if h, ok := got["gt-nux"]; !ok || h != "" {
+		t.Errorf("local placement should be recorded explicitly: %v", got)
+	}
+}
+
+func TestPruneStalePlacements(t *testing.T) {
+	dir := t.TempDir()
+	for s, h := range map[string]string{"gt-a": "box1", "gt-b": "box1", "gt-c": "box2", "gt-new": "box1"} {
+		if err := Record(dir, s, h); err != nil {
+			t.Fatal(err)
+		}
+	}
+	running := map[string]bool{"gt-a": true}
+	candidates := []Candidate{{Host: "box1"}, {Host: "box2", Err: errors.New("down")}}
+	n, err := Prune(dir, staleFilter("gt-new", running, candidates))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := Load(dir)
+	// gt-b is gone from a reachable host; gt-c's host is down, so it stays.
+	if n != 1 || len(got) != 3 || got["gt-a"] != "box1" || got["gt-c"] != "box2" || got["gt-new"] != "box1" {
+		t.Errorf("Prune = %d, placements %v", n, got)
+	}
+}
+
+func TestProbeAllUsesFreshCache(t *testing.T) {
+	dir := t.TempDir()
+	host := "placement-test.invalid"
+	saveProbeCache(dir, map[string]cachedProbe{host: {At: time.Now(), CPUs: 8, Load: 1.5}})
+
+	got, _ := probeAll(context.Background(), dir, "gt-", []string{host}, time.Minute)
+	if got[0].Err != nil || got[0].CPUs != 8 || got[0].Load != 1.5 {
+		t.Errorf("cached probe = %+v", got[0])
+	}
+}
@@ -0,0 +1,128 @@
+package placement
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// probeTimeout bounds a single host probe, including the SSH handshake.
+const probeTimeout = 10 * time.Second
+
+// probeCacheTTL is how long Place reuses a host's last probe, so a burst of
+// slings doesn't SSH to every candidate for each polecat. Polecat counts
+// are always taken fresh from tmux, which keeps the burst spread out.
+const probeCacheTTL = 30 * time.Second
+
+// probeScript prints CPU count, 1-minute load average, and per-GPU
+// utilization (empty line without nvidia-smi), one per line. It sticks to
+// POSIX tools and falls back to sysctl for macOS hosts.
+const probeScript = `getconf _NPROCESSORS_ONLN 2>/dev/null || nproc
+if [ -r /proc/loadavg ]; then cut -d' ' -f1 /proc/loadavg; else sysctl -n vm.loadavg | awk '{print $2}'; fi
+nvidia-smi --query-gpu=utilization.gpu --format=csv,noheader,nounits 2>/dev/null | tr '\n' ' '; echo`
+
+// Probe measures a host's capacity. host is an SSH destination, or "" for
+// this machine. Failures are reported in the returned Candidate's Err.
+func Probe(ctx context.Context, host string) Candidate {
+	c := Candidate{Host: host}
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	if host == "" {
+		cmd = exec.CommandContext(ctx, "sh", "-c", probeScript)
+	} else {
+		cmd = exec.CommandContext(ctx, "ssh", "-o", "BatchMode=yes", "-o", "ConnectTimeout=5",
+			"--", host, "sh -c '"+strings.ReplaceAll(probeScript, "'", `'\''`)+"'")
+	}
+	out, err := cmd.Output()
+	if err != nil {
+		c.Err = fmt.Errorf("probing %s: %w", c.Name(), err)
+		return c
+	}
+	if err := parseProbe(string(out), &c); err != nil {
+		c.Err = fmt.Errorf("probing %s: %w", c.Name(), err)
+	}
+	return c
+}
+
+// parseProbe fills c from probeScript output.
+func parseProbe(out string, c *Candidate) error {
+	lines := strings.Split(strings.TrimRight(out, "\n"), "\n")
+	if len(lines) < 2 {
+		return fmt.Errorf("unexpected probe output %q", out)
+	}
+	cpus, err := strconv.Atoi(strings.TrimSpace(lines[0]))
+	if err != nil || cpus <= 0 {
+		return fmt.Errorf("bad cpu count %q", lines[0])
+	}
+	load, err := strconv.ParseFloat(strings.TrimSpace(lines[1]), 64)
+	if err != nil {
+		return fmt.Errorf("bad load average %q", lines[1])
+	}
+	c.CPUs, c.Load = cpus, load
+	c.GPUUtil = nil
+	if len(lines) > 2 {
+		for _, f := range strings.Fields(lines[2]) {
+			if u, err := strconv.Atoi(f); err == nil {
+				c.GPUUtil = append(c.GPUUtil, u)
+			}
+		}
+	}
+	return nil
+}
+
+// cachedProbe is a host's last probe result.
+type cachedProbe struct {
+	At      time.Time `json:"at"`
+	CPUs    int       `json:"cpus"`
+	Load    float64   `json:"load"`
+	GPUUtil []int     `json:"gpu_util,omitempty"`
+	Err     string    `json:"error,omitempty"`
+}
+
+func probeCachePath(townRoot string) string {
+	return filepath.Join(constants.TownRuntimePath(townRoot), "placement_probes.json")
+}
+
+// loadProbeCache returns the cached probes by host. A missing or
+// unreadable cache is empty.
+func loadProbeCache(townRoot string) map[string]cachedProbe {
+	cache := make(map[string]cachedProbe)
+	if data, err := os.ReadFile(probeCachePath(townRoot)); err == nil {
+		_ = json.Unmarshal(data, &cache)
+	}
+	return cache
+}
+
+// saveProbeCache writes the cache. Concurrent writers may drop each
+// other's entries, which only costs a re-probe.
+func saveProbeCache(townRoot string, cache map[string]cachedProbe) {
+	_ = util.EnsureDirAndWriteJSON(probeCachePath(townRoot), cache)
+}
+
+func (p cachedProbe) candidate(host string) Candidate {
+	c := Candidate{Host: host, CPUs: p.CPUs, Load: p.Load, GPUUtil: p.GPUUtil}
+	if p.Err != "" {
+		c.Err = errors.New(p.Err)
+	}
+	return c
+}
+
+func newCachedProbe(c Candidate, at time.Time) cachedProbe {
+	p := cachedProbe{At: at, CPUs: c.CPUs, Load: c.Load, GPUUtil: c.GPUUtil}
+	if c.Err != nil {
+		p.Err = c.Err.Error()
+	}
+	return p
+}
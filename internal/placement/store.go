@@ -0,0 +1,80 @@
+package placement
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/gofrs/flock"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// placementsFile records where placed sessions run. It is read by
+// session.InitRegistry so every gt process routes those sessions' tmux
+// commands to the right machine.
+type placementsFile struct {
+	Sessions map[string]string `json:"sessions"` // session → SSH host ("" = this machine)
+}
+
+// Path returns the placements file for a town.
+func Path(townRoot string) string {
+	return filepath.Join(constants.TownRuntimePath(townRoot), "placements.json")
+}
+
+// Load returns the recorded session → host placements. A missing or
+// unreadable file yields no placements.
+func Load(townRoot string) map[string]string {
+	data, err := os.ReadFile(Path(townRoot))
+	if err != nil {
+		return nil
+	}
+	var f placementsFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil
+	}
+	return f.Sessions
+}
+
+// Record stores a session's placement.
+func Record(townRoot, session, host string) error {
+	return update(townRoot, func(sessions map[string]string) {
+		sessions[session] = host
+	})
+}
+
+// Prune drops the placements keep rejects and returns how many it dropped.
+func Prune(townRoot string, keep func(session, host string) bool) (int, error) {
+	dropped := 0
+	err := update(townRoot, func(sessions map[string]string) {
+		for s, h := range sessions {
+			if !keep(s, h) {
+				delete(sessions, s)
+				dropped++
+			}
+		}
+	})
+	return dropped, err
+}
+
+// update applies fn to the placements under an exclusive lock, so
+// concurrent slings don't lose each other's records.
+func update(townRoot string, fn func(sessions map[string]string)) error {
+	path := Path(townRoot)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	fl := flock.New(path + ".lock")
+	if err := fl.Lock(); err != nil {
+		return fmt.Errorf("acquiring placements lock: %w", err)
+	}
+	defer func() { _ = fl.Unlock() }()
+
+	f := placementsFile{Sessions: Load(townRoot)}
+	if f.Sessions == nil {
+		f.Sessions = make(map[string]string)
+	}
+	fn(f.Sessions)
+	return util.AtomicWriteJSON(path, f)
+}
@@ -0,0 +1,149 @@
+package polecat
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+// EnvContainer records "<engine> <container-name>" in a containerized polecat
+// session's environment so cleanup can remove the container after the tmux
+// session is gone.
+const EnvContainer = "GT_CONTAINER"
+
+// containerName returns the container name for a polecat session.
+func containerName(sessionID string) string {
+	return "gt-" + sessionID
+}
+
+// buildContainerCommand wraps a polecat startup command so it runs inside a
+// container. The pane runs the engine CLI attached to the container's TTY, so
+// capture, nudge, and attach work unchanged. The worktree and town root are
+// mounted at their host paths; the inner command (including its exported GT_*
+// variables) runs under sh -c in the worktree.
+func buildContainerCommand(c *config.RuntimeContainerConfig, sessionID, workDir, townRoot, command string) string {
+	user := c.User
+	if user == "" {
+		user = fmt.Sprintf("%d:%d", os.Getuid(), os.Getgid())
+	}
+	network := c.Network
+	if network == "" {
+		network = "host"
+	}
+
+	args := []string{
+		c.EngineOrDefault(), "run", "--rm", "-it", "--init",
+		"--name", containerName(sessionID),
+		"--label", "gastown.session=" + sessionID,
+		"--user", user,
+		"--network", network,
+		"-v", townRoot + ":" + townRoot,
+		"-w", workDir,
+		"-e", "TERM",
+		"-e", "TMUX",
+		"-e", "TMUX_PANE",
+	}
+	// gt inside the container nudges, notifies, and self-terminates through
+	// the host tmux server, so its socket directory must be visible.
+	socketDir := tmuxSocketDir()
+	args = append(args, "-v", socketDir+":"+socketDir)
+	if !strings.HasPrefix(workDir, strings.TrimSuffix(townRoot, "/")+"/") {
+		args = append(args, "-v", workDir+":"+workDir)
+	}
+	if c.CPUs != "" {
+		args = append(args, "--cpus", c.CPUs)
+	}
+	if c.Memory != "" {
+		args = append(args, "--memory", c.Memory)
+	}
+	if c.PidsLimit > 0 {
+		args = append(args, "--pids-limit", fmt.Sprintf("%d", c.PidsLimit))
+	}
+	for _, m := range c.Mounts {
+		args = append(args, "-v", expandHome(m))
+	}
+	args = append(args, c.Args...)
+	args = append(args, c.Image, "sh", "-c", command)
+
+	quoted := make([]string, len(args))
+	for i, a := range args {
+		quoted[i] = config.ShellQuote(a)
+	}
+	return "exec " + strings.Join(quoted, " ")
+}
+
+// expandHome expands a leading "~/" in a mount spec's host path.
+func expandHome(spec string) string {
+	if !strings.HasPrefix(spec, "~/") {
+		return spec
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return spec
+	}
+	return home + spec[1:]
+}
+
+// tmuxSocketDir returns the directory holding this user's default tmux
+// sockets (tmux uses $TMUX_TMPDIR, else /tmp, plus tmux-<uid>).
+func tmuxSocketDir() string {
+	base := os.Getenv("TMUX_TMPDIR")
+	if base == "" {
+		base = "/tmp"
+	}
+	return fmt.Sprintf("%s/tmux-%d", strings.TrimSuffix(base, "/"), os.Getuid())
+}
+
+// SessionContainer returns the container record for a polecat session, or ""
+// if the session isn't containerized. Read it before killing the session.
+func SessionContainer(t *tmux.Tmux, sessionID string) string {
+	record, _ := t.GetEnvironment(sessionID, EnvContainer)
+	return record
+}
+
+// killPolecatSession kills a polecat's tmux session and, for containerized
+// sessions, force-removes the container. Killing the engine CLI in the pane
+// does not reliably stop the container, which would otherwise keep running
+// (and holding its resource reservation) after the session is gone.
+func killPolecatSession(t *tmux.Tmux, sessionID string) error {
+	container := SessionContainer(t, sessionID)
+	err := t.KillSessionWithProcesses(sessionID)
+	RemoveContainer(container)
+	return err
+}
+
+// RemoveContainer force-removes a container recorded as "<engine> <name>".
+// Best-effort: the container may already be gone via --rm.
+func RemoveContainer(record string) {
+	engine, name, ok := strings.Cut(strings.TrimSpace(record), " ")
+	if !ok || engine == "" || name == "" {
+		return
+	}
+	_ = exec.Command(engine, "rm", "-f", name).Run()
+}
+
+// reapOrphanContainers removes this rig's polecat containers whose tmux
+// session no longer exists. Sessions that end from inside the container
+// (gt done) can't run the engine CLI themselves, so their containers are
+// collected here.
+func reapOrphanContainers(t *tmux.Tmux, engine, sessionPrefix string) {
+	out, err := exec.Command(engine, "ps", "-a",
+		"--filter", "label=gastown.session",
+		"--format", `{{.Names}} {{.Label "gastown.session"}}`).Output()
+	if err != nil {
+		return
+	}
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		name, sessionID, ok := strings.Cut(strings.TrimSpace(line), " ")
+		if !ok || !strings.HasPrefix(sessionID, sessionPrefix) {
+			continue
+		}
+		if alive, err := t.HasSession(sessionID); err == nil && !alive {
+			RemoveContainer(engine + " " + name)
+		}
+	}
+}
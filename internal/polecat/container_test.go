@@ -0,0 +1,76 @@
+package polecat
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+func TestBuildContainerCommand(t *testing.T) {
+	c := &config.RuntimeContainerConfig{
+		Image:     "ghcr.io/example/agent:latest",
+		User:      "1000:1000",
+		CPUs:      "2",
+		Memory:    "4g",
+		PidsLimit: 512,
+		Mounts:    []string{"/opt/cache:/cache:ro"},
+	}
+	inner := "export GT_POLECAT=toast && exec claude 'do it'"
+	got := buildContainerCommand(c, "gt-toast", "/town/gastown/polecats/toast/gastown", "/town", inner)
+
+	for _, want := range []string{
+		"exec docker run --rm -it --init",
+		"--name gt-gt-toast",
+		"--label gastown.session=gt-toast",
+		"--user 1000:1000",
+		"--network host",
+		"-v /town:/town",
+		"-w /town/gastown/polecats/toast/gastown",
+		"--cpus 2 --memory 4g --pids-limit 512",
+		"-v /opt/cache:/cache:ro",
+		"ghcr.io/example/agent:latest sh -c ",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("command missing %q:\n%s", want, got)
+		}
+	}
+	// The worktree lives under the town root, so it isn't mounted twice.
+	if strings.Contains(got, "-v /town/gastown/polecats/toast/gastown:") {
+		t.Errorf("worktree inside town should not get its own mount:\n%s", got)
+	}
+
+	// The wrapped command must survive shell parsing intact.
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+	out, err := exec.Command("sh", "-c", `set -- `+strings.TrimPrefix(got, "exec ")+`; for a; do last=$a; done; printf %s "$last"`).Output()
+	if err != nil {
+		t.Fatalf("parsing command: %v", err)
+	}
+	if string(out) != inner {
+		t.Errorf("inner command = %q, want %q", out, inner)
+	}
+}
+
+func TestBuildContainerCommandDefaults(t *testing.T) {
+	c := &config.RuntimeContainerConfig{Engine: "podman", Image: "agent"}
+	got := buildContainerCommand(c, "gt-toast", "/elsewhere/toast", "/town", "claude")
+
+	if !strings.HasPrefix(got, "exec podman run ") {
+		t.Errorf("engine not honored: %s", got)
+	}
+	if !strings.Contains(got, "--user ") || strings.Contains(got, "--cpus") {
+		t.Errorf("unexpected defaults: %s", got)
+	}
+	if !strings.Contains(got, "-v /elsewhere/toast:/elsewhere/toast") {
+		t.Errorf("worktree outside town should be mounted: %s", got)
+	}
+}
+
+func TestRemoveContainerIgnoresEmptyRecord(t *testing.T) {
+	// Must not attempt to run anything for non-containerized sessions.
+	RemoveContainer("")
+	RemoveContainer("docker")
+}
@@ -448,6 +448,17 @@ func (m *Manager) repoBase() (*git.Git, error) {
 	return git.NewGit(mayorPath), nil
 }
 
+// worktrees returns the WorktreeManager for this rig's polecat worktrees,
+// which hang off repoGit (see repoBase) and check out only the rig's sparse
+// paths, if its clone strategy sets any.
+func (m *Manager) worktrees(repoGit *git.Git) *git.WorktreeManager {
+	w := git.NewWorktreeManager(repoGit, m.rig.Name)
+	if cfg, err := rig.LoadRigConfig(m.rig.Path); err == nil {
+		w.SetSparse(cfg.CloneStrategy().Sparse)
+	}
+	return w
+}
+
 // polecatDir returns the parent directory for a polecat.
 // This is polecats/<name>/ - the polecat's home directory.
 func (m *Manager) polecatDir(name string) string {
@@ -466,14 +477,21 @@ func (m *Manager) pendingPath(name string) string {
 // New structure: polecats/<name>/<rigname>/ - gives LLMs recognizable repo context.
 // Falls back to old structure: polecats/<name>/ for backward compatibility.
 func (m *Manager) clonePath(name string) string {
+	return ClonePath(m.rig, name)
+}
+
+// ClonePath returns the worktree path of polecat name in r, without needing
+// a Manager: polecats/<name>/<rigname>/, or polecats/<name>/ for polecats
+// created before that layout.
+func ClonePath(r *rig.Rig, name string) string {
 	// New structure: polecats/<name>/<rigname>/
-	newPath := filepath.Join(m.rig.Path, "polecats", name, m.rig.Name)
+	newPath := filepath.Join(r.Path, "polecats", name, r.Name)
 	if info, err := os.Stat(newPath); err == nil && info.IsDir() {
 		return newPath
 	}
 
 	// Old structure: polecats/<name>/ (backward compat)
-	oldPath := filepath.Join(m.rig.Path, "polecats", name)
+	oldPath := filepath.Join(r.Path, "polecats", name)
 	if info, err := os.Stat(oldPath); err == nil && info.IsDir() {
 		// Check if this is actually a git worktree (has .git file or dir)
 		gitPath := filepath.Join(oldPath, ".git")
@@ -516,19 +534,16 @@ type AddOptions struct {
 // - {description}: sanitized issue title
 // - {timestamp}: unique timestamp
 //
-// If no template is configured or template is empty, uses default format:
-// - polecat/{name}/{issue}@{timestamp} when issue is available
-// - polecat/{name}-{timestamp} otherwise
+// If no template is configured or template is empty, uses the polecat
+// branch convention (see git.PolecatBranchName):
+// - polecat/{rig}/{name}/{issue} when issue is available
+// - polecat/{rig}/{name}/idle otherwise
 func (m *Manager) buildBranchName(name, issue string) string {
 	template := m.rig.GetStringConfig("polecat_branch_template")
 
-	// No template configured - use default behavior for backward compatibility
+	// No template configured - use the polecat branch convention
 	if template == "" {
-		timestamp := strconv.FormatInt(time.Now().UnixMilli(), 36)
-		if issue != "" {
-			return fmt.Sprintf("polecat/%s/%s@%s", name, issue, timestamp)
-		}
-		return fmt.Sprintf("polecat/%s-%s", name, timestamp)
+		return git.PolecatBranchName(m.rig.Name, name, issue)
 	}
 
 	// Build template variables
@@ -613,9 +628,9 @@ func (m *Manager) buildBranchName(name, issue string) string {
 
 // Polecat state is derived from beads assignee field, not state.json.
 //
-// Branch naming: Each polecat run gets a unique branch (polecat/<name>-<timestamp>).
-// This prevents drift issues from stale branches and ensures a clean starting state.
-// Old branches are ephemeral and never pushed to origin.
+// Branch naming: Each polecat works a bead on its own branch
+// (polecat/<rig>/<name>/<bead-id>, see git.PolecatBranchName). A polecat with
+// no work sits on polecat/<rig>/<name>/idle, reset to the start point each time.
 func (m *Manager) Add(name string) (*Polecat, error) {
 	return m.AddWithOptions(name, AddOptions{})
 }
@@ -665,7 +680,7 @@ func (m *Manager) AllocateAndAdd(opts AddOptions) (string, *Polecat, error) {
 	if m.tmux != nil {
 		sessionName := session.PolecatSessionName(session.PrefixFor(m.rig.Name), name)
 		if alive, _ := m.tmux.HasSession(sessionName); alive {
-			_ = m.tmux.KillSessionWithProcesses(sessionName)
+			_ = killPolecatSession(m.tmux, sessionName)
 		}
 	}
 
@@ -746,7 +761,7 @@ func (m *Manager) addWithOptionsLocked(name string, opts AddOptions, polecatDir
 			startPoint, m.rig.Path, filepath.Join(m.rig.Path, ".repo.git"))
 	}
 
-	if err := repoGit.WorktreeAddFromRef(clonePath, branchName, startPoint); err != nil {
+	if err := m.worktrees(repoGit).Add(clonePath, branchName, startPoint); err != nil {
 		cleanupOnError()
 		return nil, fmt.Errorf("creating worktree from %s: %w", startPoint, err)
 	}
@@ -908,10 +923,10 @@ func (m *Manager) AddWithOptions(name string, opts AddOptions) (_ *Polecat, retE
 			startPoint, m.rig.Path, filepath.Join(m.rig.Path, ".repo.git"))
 	}
 
-	// Always create fresh branch - unique name guarantees no collision
-	// git worktree add -b polecat/<name>-<timestamp> <path> <startpoint>
+	// git worktree add -b polecat/<rig>/<name>/<bead-id> <path> <startpoint>
+	// (resumes the bead's branch if it already exists, see git.WorktreeManager)
 	// Worktree goes in polecats/<name>/<rigname>/ for LLM ergonomics
-	if err := repoGit.WorktreeAddFromRef(clonePath, branchName, startPoint); err != nil {
+	if err := m.worktrees(repoGit).Add(clonePath, branchName, startPoint); err != nil {
 		cleanupOnError()
 		return nil, fmt.Errorf("creating worktree from %s: %w", startPoint, err)
 	}
@@ -1286,7 +1301,7 @@ func (m *Manager) AllocateName() (string, error) {
 	if m.tmux != nil {
 		sessionName := session.PolecatSessionName(session.PrefixFor(m.rig.Name), name)
 		if alive, _ := m.tmux.HasSession(sessionName); alive {
-			_ = m.tmux.KillSessionWithProcesses(sessionName)
+			_ = killPolecatSession(m.tmux, sessionName)
 		}
 	}
 
@@ -1308,8 +1323,9 @@ func (m *Manager) ReleaseName(name string) {
 // The name is preserved (not released to pool) since we're repairing immediately.
 // force controls whether to bypass uncommitted changes check.
 //
-// Branch naming: Each repair gets a unique branch (polecat/<name>-<timestamp>).
-// Old branches are left for garbage collection - they're never pushed to origin.
+// Branch naming: The repaired worktree resumes the bead's branch
+// (polecat/<rig>/<name>/<bead-id>) if it exists, so repair keeps the
+// polecat's commits. Branches of closed beads are left for gt polecat gc.
 func (m *Manager) RepairWorktree(name string, force bool) (*Polecat, error) {
 	return m.RepairWorktreeWithOptions(name, force, AddOptions{})
 }
@@ -1390,7 +1406,7 @@ func (m *Manager) RepairWorktreeWithOptions(name string, force bool, opts AddOpt
 	branchName := m.buildBranchName(name, opts.HookBead)
 	tmpClonePath := newClonePath + ".repair-tmp"
 	_ = os.RemoveAll(tmpClonePath) // clean up any leftover temp dir
-	if err := repoGit.WorktreeAddFromRef(tmpClonePath, branchName, startPoint); err != nil {
+	if err := m.worktrees(repoGit).Add(tmpClonePath, branchName, startPoint); err != nil {
 		return nil, fmt.Errorf("creating fresh worktree from %s: %w", startPoint, err)
 	}
 
@@ -1493,7 +1509,7 @@ func (m *Manager) RepairWorktreeWithOptions(name string, force bool, opts AddOpt
 // Steps:
 //  1. Verify polecat exists and worktree is accessible
 //  2. Fetch latest from origin
-//  3. Create fresh branch: git checkout -b <branch> <startPoint>
+//  3. Switch to the bead's branch (git checkout -b <branch> <startPoint> if new)
 //  4. Reset agent bead and set hook_bead atomically
 //  5. Return polecat in working state
 func (m *Manager) ReuseIdlePolecat(name string, opts AddOptions) (*Polecat, error) {
@@ -1543,9 +1559,10 @@ func (m *Manager) ReuseIdlePolecat(name string, opts AddOptions) (*Polecat, erro
 		return nil, fmt.Errorf("start point %s not found — fall back to full repair", startPoint)
 	}
 
-	// Create fresh branch from start point (branch-only, no worktree add/remove)
+	// Switch to the bead's branch, created from start point unless it already
+	// exists (branch-only, no worktree add/remove)
 	branchName := m.buildBranchName(name, opts.HookBead)
-	if err := polecatGit.CheckoutNewBranch(branchName, startPoint); err != nil {
+	if err := m.worktrees(polecatGit).Checkout(clonePath, branchName, startPoint); err != nil {
 		return nil, fmt.Errorf("creating branch %s from %s: %w", branchName, startPoint, err)
 	}
 
@@ -1667,14 +1684,20 @@ func (m *Manager) ReconcilePoolWith(namesWithDirs, namesWithSessions []string) {
 			sessionName := session.PolecatSessionName(session.PrefixFor(m.rig.Name), name)
 			if !dirSet[name] {
 				// Orphan: session exists but no directory
-				_ = m.tmux.KillSessionWithProcesses(sessionName)
+				_ = killPolecatSession(m.tmux, sessionName)
 				RemoveSessionHeartbeat(townRoot, sessionName)
 			} else if isSessionProcessDead(m.tmux, sessionName, townRoot) {
 				// Stale: directory exists but session's process has died
-				_ = m.tmux.KillSessionWithProcesses(sessionName)
+				_ = killPolecatSession(m.tmux, sessionName)
 				RemoveSessionHeartbeat(townRoot, sessionName)
 			}
 		}
+
+		// Containerized polecats that exited on their own leave containers
+		// behind; collect them once their sessions are gone.
+		if rc := config.ResolveRoleAgentConfig("polecat", townRoot, m.rig.Path); rc.Container != nil && rc.Container.Image != "" {
+			reapOrphanContainers(m.tmux, rc.Container.EngineOrDefault(), session.PrefixFor(m.rig.Name)+"-")
+		}
 	}
 
 	m.namePool.Reconcile(namesWithDirs)
@@ -2145,31 +2168,63 @@ func (m *Manager) setupSharedBeads(clonePath string) error {
 	return nil
 }
 
-// CleanupStaleBranches removes orphaned polecat branches that are no longer in use.
-// This includes:
-// - Branches for polecats that no longer exist
-// - Old timestamped branches (keeps only the most recent per polecat name)
-// Returns the number of branches deleted.
-func (m *Manager) CleanupStaleBranches() (int, error) {
+// Postflight removes the worktrees and branches of finished work: polecat
+// branches whose bead is closed, unless the polecat's session is still
+// running or the worktree has work that isn't pushed (see
+// git.WorktreeManager.Postflight). A polecat whose worktree was removed is
+// given a fresh one by RepairWorktree the next time it is reused.
+func (m *Manager) Postflight(dryRun bool) ([]git.PostflightResult, error) {
 	repoGit, err := m.repoBase()
 	if err != nil {
-		return 0, fmt.Errorf("finding repo base: %w", err)
+		return nil, fmt.Errorf("finding repo base: %w", err)
+	}
+
+	// One session listing up front instead of a has-session per worktree.
+	var sessions *tmux.SessionSet
+	if m.tmux != nil {
+		sessions, _ = m.tmux.GetSessionSet()
+	}
+
+	return m.worktrees(repoGit).Postflight(func(pw git.PolecatWorktree) (bool, error) {
+		if sessions != nil {
+			sessionName := session.PolecatSessionName(session.PrefixFor(m.rig.Name), pw.Polecat)
+			if sessions.Has(sessionName) {
+				return false, nil
+			}
+		}
+		issue, err := m.beads.Show(pw.BeadID)
+		if err != nil {
+			return false, err
+		}
+		return issue.Status == "closed", nil
+	}, dryRun)
+}
+
+// StaleBranches returns the polecat branches CleanupStaleBranches would try
+// to delete: branches no polecat has checked out, other than bead branches
+// (polecat/<rig>/<name>/<bead-id>) whose bead is still open or can't be
+// looked up. A polecat may be back on another bead while an earlier one
+// waits, and that branch holds the earlier bead's work.
+func (m *Manager) StaleBranches() ([]string, error) {
+	repoGit, err := m.repoBase()
+	if err != nil {
+		return nil, fmt.Errorf("finding repo base: %w", err)
 	}
 
 	// List all polecat branches
 	branches, err := repoGit.ListBranches("polecat/*")
 	if err != nil {
-		return 0, fmt.Errorf("listing branches: %w", err)
+		return nil, fmt.Errorf("listing branches: %w", err)
 	}
 
 	if len(branches) == 0 {
-		return 0, nil
+		return nil, nil
 	}
 
 	// Get list of existing polecats
 	polecats, err := m.List()
 	if err != nil {
-		return 0, fmt.Errorf("listing polecats: %w", err)
+		return nil, fmt.Errorf("listing polecats: %w", err)
 	}
 
 	// Build set of current polecat branches (from actual polecat objects)
@@ -2178,16 +2233,44 @@ func (m *Manager) CleanupStaleBranches() (int, error) {
 		currentBranches[p.Branch] = true
 	}
 
-	// Delete branches not in current set
-	deleted := 0
+	var stale []string
 	for _, branch := range branches {
 		if currentBranches[branch] {
 			continue // This branch is in use
 		}
-		// Delete orphaned branch
-		if err := repoGit.DeleteBranch(branch, true); err != nil {
+		if rigName, _, beadID, ok := git.ParsePolecatBranch(branch); ok && beadID != "" && rigName == m.rig.Name {
+			issue, err := m.beads.Show(beadID)
+			if err != nil || issue.Status != "closed" {
+				continue // The bead's work may still be wanted
+			}
+		}
+		stale = append(stale, branch)
+	}
+	return stale, nil
+}
+
+// CleanupStaleBranches removes orphaned polecat branches that are no longer
+// in use (see StaleBranches). Branches are deleted with git branch -d, so
+// one with commits not merged into the repo's HEAD or its upstream is kept
+// with a warning. Returns the number of branches deleted.
+func (m *Manager) CleanupStaleBranches() (int, error) {
+	stale, err := m.StaleBranches()
+	if err != nil {
+		return 0, err
+	}
+	if len(stale) == 0 {
+		return 0, nil
+	}
+	repoGit, err := m.repoBase()
+	if err != nil {
+		return 0, fmt.Errorf("finding repo base: %w", err)
+	}
+
+	deleted := 0
+	for _, branch := range stale {
+		if err := repoGit.DeleteBranch(branch, false); err != nil {
 			// Log but continue - non-fatal
-			style.PrintWarning("could not delete branch %s: %v", branch, err)
+			style.PrintWarning("kept branch %s: %v", branch, err)
 			continue
 		}
 		deleted++
@@ -17,6 +17,7 @@ import (
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/constants"
 	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/placement"
 	"github.com/steveyegge/gastown/internal/rig"
 	"github.com/steveyegge/gastown/internal/runtime"
 	"github.com/steveyegge/gastown/internal/session"
@@ -222,7 +223,7 @@ func (m *SessionManager) Start(polecat string, opts SessionStartOptions) error {
 	}
 	if running {
 		if m.isSessionStale(sessionID) {
-			if err := m.tmux.KillSessionWithProcesses(sessionID); err != nil {
+			if err := killPolecatSession(m.tmux, sessionID); err != nil {
 				return fmt.Errorf("killing stale session %s: %w", sessionID, err)
 			}
 		} else {
@@ -344,6 +345,30 @@ func (m *SessionManager) Start(polecat string, opts SessionStartOptions) error {
 	}
 	command = config.PrependEnv(command, envVarsToInject)
 
+	// Placement policy: pick the least-loaded (or GPU-idle) host in the rig's
+	// pool. Failure to place falls back to the rig's default routing.
+	if chosen, placed, err := placement.Place(context.Background(), townRoot, m.rig.Name,
+		session.PrefixFor(m.rig.Name)+"-", sessionID); err != nil {
+		style.PrintWarning("placement for %s: %v (using default host)", sessionID, err)
+	} else if placed {
+		fmt.Printf("✓ Placed %s on %s\n", sessionID, chosen.Name())
+	}
+
+	// Containerized runtime: run the agent inside a container attached to the
+	// pane. Any leftover container from a crashed previous run is removed first
+	// so the fixed container name is free.
+	container := runtimeConfig.Container
+	if container != nil && container.Image != "" {
+		engine := container.EngineOrDefault()
+		if _, err := exec.LookPath(engine); err != nil {
+			return fmt.Errorf("container runtime %q not found: %w", engine, err)
+		}
+		RemoveContainer(engine + " " + containerName(sessionID))
+		command = buildContainerCommand(container, sessionID, workDir, townRoot, command)
+	} else {
+		container = nil
+	}
+
 	// Create session with command directly to avoid send-keys race condition.
 	// See: https://github.com/anthropics/gastown/issues/280
 	if err := m.tmux.NewSessionWithCommand(sessionID, workDir, command); err != nil {
@@ -395,7 +420,19 @@ func (m *SessionManager) Start(polecat string, opts SessionStartOptions) error {
 	// shadow built-in preset names (e.g., custom "codex" running "opencode"),
 	// so we resolve process names from both agent name and actual command.
 	processNames := config.ResolveProcessNames(runtimeConfig.ResolvedAgent, runtimeConfig.Command)
+	if container != nil {
+		// The pane's process is the engine CLI, not the agent.
+		engine := container.EngineOrDefault()
+		processNames = append(processNames, engine)
+		debugSession("SetEnvironment "+EnvContainer, m.tmux.SetEnvironment(sessionID, EnvContainer, engine+" "+containerName(sessionID)))
+	}
 	debugSession("SetEnvironment GT_PROCESS_NAMES", m.tmux.SetEnvironment(sessionID, "GT_PROCESS_NAMES", strings.Join(processNames, ",")))
+	if typing := session.RuntimeTypingProfile(runtimeConfig); typing != "" {
+		debugSession("SetEnvironment GT_TYPING", m.tmux.SetEnvironment(sessionID, tmux.EnvTypingProfile, typing))
+	}
+	if hints := session.RuntimeClientHints(runtimeConfig); hints != "" {
+		debugSession("SetEnvironment GT_CLIENT_HINTS", m.tmux.SetEnvironment(sessionID, tmux.EnvClientHints, hints))
+	}
 
 	// Record agent's pane_id for ZFC-compliant liveness checks (gt-qmsx).
 	// Declared pane identity replaces process-tree inference in IsRuntimeRunning
@@ -403,6 +440,15 @@ func (m *SessionManager) Start(polecat string, opts SessionStartOptions) error {
 	if paneID, err := m.tmux.GetPaneID(sessionID); err == nil {
 		debugSession("SetEnvironment GT_PANE_ID", m.tmux.SetEnvironment(sessionID, "GT_PANE_ID", paneID))
 	}
+	debugSession("ApplyRoleLayout", session.ApplyRoleLayout(m.tmux, session.LayoutConfig{
+		SessionID: sessionID,
+		WorkDir:   workDir,
+		Role:      "polecat",
+		TownRoot:  townRoot,
+		RigPath:   m.rig.Path,
+		RigName:   m.rig.Name,
+		AgentName: polecat,
+	}))
 
 	// Hook the issue to the polecat if provided via --issue flag
 	if opts.Issue != "" {
@@ -420,6 +466,9 @@ func (m *SessionManager) Start(polecat string, opts SessionStartOptions) error {
 	agentID := fmt.Sprintf("%s/%s", m.rig.Name, polecat)
 	debugSession("SetPaneDiedHook", m.tmux.SetPaneDiedHook(sessionID, agentID))
 
+	// Hold nudges (e.g. mail notifications) until the runtime is ready.
+	debugSession("MarkStarting", m.tmux.MarkStarting(sessionID, constants.ClaudeStartTimeout))
+
 	// Wait for Claude to start (non-fatal)
 	debugSession("WaitForCommand", m.tmux.WaitForCommand(sessionID, constants.SupportedShells, constants.ClaudeStartTimeout))
 
@@ -428,8 +477,10 @@ func (m *SessionManager) Start(polecat string, opts SessionStartOptions) error {
 
 	// Wait for runtime to be fully ready at the prompt (not just started).
 	// Uses prompt-based polling for agents with ReadyPromptPrefix (e.g., Claude "❯ "),
-	// falling back to ReadyDelayMs sleep for agents without prompt detection.
+	// falling back to ReadyDelayMs sleep and output stabilization for agents
+	// without prompt detection.
 	debugSession("WaitForRuntimeReady", m.tmux.WaitForRuntimeReady(sessionID, runtimeConfig, constants.ClaudeStartTimeout))
+	debugSession("MarkStarted", m.tmux.MarkStarted(sessionID))
 
 	// Handle fallback nudges for non-hook agents.
 	// See StartupFallbackInfo in runtime package for the fallback matrix.
@@ -481,7 +532,7 @@ func (m *SessionManager) Start(polecat string, opts SessionStartOptions) error {
 	// polecats running non-Claude agents (e.g., opencode). Fail fast.
 	gtAgent, _ := m.tmux.GetEnvironment(sessionID, "GT_AGENT")
 	if gtAgent == "" {
-		_ = m.tmux.KillSessionWithProcesses(sessionID)
+		_ = killPolecatSession(m.tmux, sessionID)
 		return fmt.Errorf("GT_AGENT not set in session %s (command=%q); "+
 			"witness patrol will misidentify this polecat as a zombie and auto-nuke it. "+
 			"Ensure RuntimeConfig.ResolvedAgent is set during agent config resolution",
@@ -536,9 +587,10 @@ func (m *SessionManager) Stop(polecat string, force bool) error {
 		session.WaitForSessionExit(m.tmux, sessionID, constants.GracefulShutdownTimeout)
 	}
 
-	// Use KillSessionWithProcesses to ensure all descendant processes are killed.
-	// This prevents orphan bash processes from Claude's Bash tool surviving session termination.
-	if err := m.tmux.KillSessionWithProcesses(sessionID); err != nil {
+	// Use KillSessionWithProcesses (via killPolecatSession) to ensure all descendant
+	// processes are killed. This prevents orphan bash processes from Claude's Bash
+	// tool surviving session termination, and removes the container if any.
+	if err := killPolecatSession(m.tmux, sessionID); err != nil {
 		return fmt.Errorf("killing session: %w", err)
 	}
 
@@ -582,7 +634,9 @@ func (m *SessionManager) Status(polecat string) (*SessionInfo, error) {
 	info.Attached = tmuxInfo.Attached
 	info.Windows = tmuxInfo.Windows
 
-	if tmuxInfo.Created != "" {
+	if !tmuxInfo.CreatedAt.IsZero() {
+		info.Created = tmuxInfo.CreatedAt
+	} else if tmuxInfo.Created != "" {
 		formats := []string{
 			"2006-01-02 15:04:05",
 			"Mon Jan 2 15:04:05 2006",
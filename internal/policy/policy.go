@@ -0,0 +1,516 @@
+// Package policy replays a town's recorded event history against candidate
+// witness, escalation, and dispatch rules (gt policy test), so rule changes
+// can be checked before they act on a live town. Replay is pure: it reads
+// events and reports what would have fired; it never touches agents, beads,
+// or mail.
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/beadwatch"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/scheduler/assign"
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/witness"
+	"gopkg.in/yaml.v3"
+)
+
+// Policy is a candidate rule set. Each section uses the same schema as the
+// live configuration it mirrors: witness as rig settings "witness",
+// escalation as settings/escalation.json, dispatcher as town settings
+// "dispatcher". Sections left out are not replayed.
+type Policy struct {
+	Witness    *config.WitnessSettings  `json:"witness,omitempty"`
+	Escalation *config.EscalationConfig `json:"escalation,omitempty"`
+	Dispatcher *assign.DispatcherConfig `json:"dispatcher,omitempty"`
+}
+
+// Policy kinds reported in firings.
+const (
+	KindWitness    = "witness"
+	KindEscalation = "escalation"
+	KindDispatch   = "dispatch"
+)
+
+// Firing is one action the policy would have taken.
+type Firing struct {
+	At     time.Time `json:"at"`
+	Kind   string    `json:"kind"`   // witness, escalation, or dispatch
+	Rule   string    `json:"rule"`   // Witness rule name, severity, or routing label
+	Target string    `json:"target"` // Agent address or escalation ID
+	Action string    `json:"action"`
+	Reason string    `json:"reason,omitempty"`
+}
+
+// Report is the outcome of replaying a policy over an event history.
+type Report struct {
+	Start   time.Time `json:"start"`
+	End     time.Time `json:"end"`
+	Events  int       `json:"events"`
+	Firings []Firing  `json:"firings"`
+	Notes   []string  `json:"notes,omitempty"` // Rules or conditions the history can't exercise
+}
+
+// Count returns the number of firings of kind.
+func (r *Report) Count(kind string) int {
+	n := 0
+	for _, f := range r.Firings {
+		if f.Kind == kind {
+			n++
+		}
+	}
+	return n
+}
+
+// Load reads a policy from a YAML or JSON file (JSON is valid YAML) and
+// validates its witness rules.
+func Load(path string) (*Policy, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is supplied by the user
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+// Parse decodes a policy from YAML or JSON. YAML is converted to JSON first
+// so the config types' json tags apply to both.
+func Parse(data []byte) (*Policy, error) {
+	var raw interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("parsing policy: %w", err)
+	}
+	if raw == nil {
+		return nil, fmt.Errorf("parsing policy: empty file")
+	}
+	asJSON, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("parsing policy: %w", err)
+	}
+	var p Policy
+	if err := json.Unmarshal(asJSON, &p); err != nil {
+		return nil, fmt.Errorf("parsing policy: %w", err)
+	}
+	if p.Witness == nil && p.Escalation == nil && p.Dispatcher == nil {
+		return nil, fmt.Errorf("parsing policy: no witness, escalation, or dispatcher section")
+	}
+	if p.Witness != nil {
+		if err := config.ValidateWitnessRules(p.Witness.Rules); err != nil {
+			return nil, err
+		}
+	}
+	if p.Escalation != nil {
+		for severity := range p.Escalation.Routes {
+			if !config.IsValidSeverity(severity) {
+				return nil, fmt.Errorf("invalid escalation route severity %q", severity)
+			}
+		}
+	}
+	return &p, nil
+}
+
+// LoadEvents reads an events log in the .events.jsonl format, skipping
+// malformed lines.
+func LoadEvents(path string) ([]events.Event, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is supplied by the user
+	if err != nil {
+		return nil, err
+	}
+	var evts []events.Event
+	for _, line := range strings.Split(string(data), "\n") {
+		var e events.Event
+		if strings.TrimSpace(line) == "" || json.Unmarshal([]byte(line), &e) != nil {
+			continue
+		}
+		evts = append(evts, e)
+	}
+	return evts, nil
+}
+
+// agentState is what the replay knows about an agent at a point in history.
+type agentState struct {
+	id         *session.AgentIdentity
+	running    bool
+	lastActive time.Time
+	hook       string
+}
+
+// openEscalation is an escalation not yet acknowledged or closed.
+type openEscalation struct {
+	id       string
+	severity string
+	raisedAt time.Time // When it was sent or last re-escalated
+	count    int       // Re-escalations so far
+}
+
+// replay holds simulation state while walking the history.
+type replay struct {
+	policy      *Policy
+	report      *Report
+	agents      map[string]*agentState
+	witnessFire map[string]time.Time // Rule/agent → last firing
+	escalations map[string]*openEscalation
+	ready       map[string]string // Open, unassigned bead → rig ("" for town beads)
+}
+
+// Replay walks evts in time order, simulating agent sessions, hooks,
+// escalations, and ready work, and reports the actions the policy would
+// have taken:
+//
+//   - witness: a rule fires once an agent's session has been quiet for its
+//     idle_for, then again every cooldown while the agent stays quiet.
+//     Activity is any event the agent is the actor of. A restart counts as
+//     activity.
+//   - escalation: every escalation is routed by the policy's routes for
+//     its severity, then re-escalated each stale_threshold until
+//     acknowledged or closed (up to max_reescalations).
+//   - dispatch: after each event, open unassigned beads are planned onto
+//     idle agents, as gt dispatch plans bd ready's output. Bead status and
+//     assignee come from the bead watcher's bead_changed events. Work
+//     dispatched this way stays hooked until the log shows the agent's hook
+//     change.
+//
+// Conditions the event log doesn't record (agent bead state, nudge
+// failures, bead labels, blocking dependencies) can't be replayed; rules
+// that need them are skipped and listed in the report's notes.
+func Replay(p *Policy, evts []events.Event) *Report {
+	type timed struct {
+		at time.Time
+		e  events.Event
+	}
+	var history []timed
+	for _, e := range evts {
+		if at, err := time.Parse(time.RFC3339, e.Timestamp); err == nil {
+			history = append(history, timed{at, e})
+		}
+	}
+	sort.SliceStable(history, func(i, j int) bool { return history[i].at.Before(history[j].at) })
+
+	r := &replay{
+		policy:      p,
+		report:      &Report{Events: len(history)},
+		agents:      make(map[string]*agentState),
+		witnessFire: make(map[string]time.Time),
+		escalations: make(map[string]*openEscalation),
+		ready:       make(map[string]string),
+	}
+	r.noteUnreplayable()
+	if len(history) == 0 {
+		return r.report
+	}
+	r.report.Start = history[0].at
+	r.report.End = history[len(history)-1].at
+
+	for _, h := range history {
+		r.advance(h.at)
+		r.apply(h.at, h.e)
+		r.dispatch(h.at)
+	}
+
+	sort.SliceStable(r.report.Firings, func(i, j int) bool {
+		return r.report.Firings[i].At.Before(r.report.Firings[j].At)
+	})
+	return r.report
+}
+
+// replayableWitnessRule reports whether every condition of rule can be
+// reconstructed from events.
+func replayableWitnessRule(rule config.WitnessRule) bool {
+	w := rule.When
+	return w.IdleFor != "" && len(w.AgentState) == 0 && w.NudgeFailures <= 0 && len(w.Labels) == 0 && w.NoProgressFor == ""
+}
+
+func (r *replay) noteUnreplayable() {
+	if r.policy.Witness != nil {
+		for _, rule := range r.policy.Witness.Rules {
+			if !replayableWitnessRule(rule) {
+				r.report.Notes = append(r.report.Notes, fmt.Sprintf(
+					"witness rule %q skipped: agent_state, nudge_failures, labels, and no_progress_for are not in the event log", rule.Name))
+			}
+		}
+	}
+	if r.policy.Dispatcher != nil {
+		r.report.Notes = append(r.report.Notes,
+			"dispatch treats every open unassigned bead as ready: blocking dependencies are not in the event log")
+		if len(r.policy.Dispatcher.Rules) > 0 {
+			r.report.Notes = append(r.report.Notes,
+				"dispatch routing rules not exercised: bead labels are not in the event log")
+		}
+	}
+}
+
+func (r *replay) fire(f Firing) {
+	r.report.Firings = append(r.report.Firings, f)
+}
+
+// agent returns the state for an agent address, creating it on first
+// sight, or nil if addr isn't an agent.
+func (r *replay) agent(addr string) *agentState {
+	id, err := session.ParseAddress(strings.TrimSpace(addr))
+	if err != nil || id.Address() == "" {
+		return nil
+	}
+	key := id.Address()
+	a := r.agents[key]
+	if a == nil {
+		a = &agentState{id: id}
+		r.agents[key] = a
+	}
+	return a
+}
+
+// sessionAgent resolves the agent a session event is about. Events name it
+// by address or by tmux session, depending on who logged them.
+func (r *replay) sessionAgent(names ...string) *agentState {
+	for _, name := range names {
+		if a := r.agent(name); a != nil {
+			return a
+		}
+	}
+	for _, name := range names {
+		if id, err := session.ParseSessionName(name); err == nil {
+			return r.agent(id.Address())
+		}
+	}
+	return nil
+}
+
+// apply updates simulation state with one recorded event.
+func (r *replay) apply(at time.Time, e events.Event) {
+	bead := payloadString(e.Payload, "bead")
+
+	switch e.Type {
+	case events.TypeSessionEnd, events.TypeSessionDeath, events.TypeKill:
+		a := r.sessionAgent(payloadString(e.Payload, "agent"), payloadString(e.Payload, "session"), e.Actor)
+		if e.Type == events.TypeKill {
+			a = r.sessionAgent(payloadString(e.Payload, "target"))
+		}
+		if a != nil {
+			a.running = false
+		}
+		return
+	case events.TypeSpawn:
+		if a := r.agent(payloadString(e.Payload, "rig") + "/polecats/" + payloadString(e.Payload, "polecat")); a != nil {
+			a.running, a.lastActive = true, at
+		}
+		return
+	case events.TypeBeadChanged:
+		r.beadChanged(e)
+		return
+	case events.TypeEscalationSent:
+		r.escalate(at, e)
+		return
+	case events.TypeEscalationAcked, events.TypeEscalationClosed:
+		delete(r.escalations, payloadString(e.Payload, "escalation_id"))
+		return
+	}
+
+	// Anything else an agent does is activity.
+	actor := r.agent(e.Actor)
+	if actor != nil {
+		actor.running, actor.lastActive = true, at
+	}
+
+	switch e.Type {
+	case events.TypeHook:
+		if actor != nil && bead != "" {
+			actor.hook = bead
+			delete(r.ready, bead)
+		}
+	case events.TypeSling:
+		if a := r.agent(payloadString(e.Payload, "target")); a != nil && bead != "" {
+			a.hook = bead
+		}
+		delete(r.ready, bead)
+	case events.TypeUnhook, events.TypeDone:
+		if actor != nil && (bead == "" || actor.hook == bead) {
+			actor.hook = ""
+		}
+	}
+}
+
+// beadChanged tracks which beads bd ready would list for gt dispatch: open
+// and unassigned. The bead watcher logs a bead_changed event for every
+// create, status change, assignment, and delete.
+func (r *replay) beadChanged(e events.Event) {
+	bead := payloadString(e.Payload, "bead")
+	if bead == "" {
+		return
+	}
+	if payloadString(e.Payload, "change") == beadwatch.Deleted ||
+		beads.IssueStatus(payloadString(e.Payload, "status")) != beads.StatusOpen ||
+		payloadString(e.Payload, "assignee") != "" {
+		delete(r.ready, bead)
+		return
+	}
+	rig := payloadString(e.Payload, "source")
+	if rig == beadwatch.TownSource {
+		rig = ""
+	}
+	r.ready[bead] = rig
+}
+
+// advance fires the time-driven rules due up to now.
+func (r *replay) advance(now time.Time) {
+	r.advanceWitness(now)
+	r.advanceEscalations(now)
+}
+
+func (r *replay) advanceWitness(now time.Time) {
+	if r.policy.Witness == nil {
+		return
+	}
+	keys := make([]string, 0, len(r.agents))
+	for k := range r.agents {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, addr := range keys {
+		a := r.agents[addr]
+		if !a.running || a.lastActive.IsZero() {
+			continue
+		}
+		for _, rule := range r.policy.Witness.Rules {
+			if !replayableWitnessRule(rule) {
+				continue
+			}
+			idleFor := config.ParseDurationOrDefault(rule.When.IdleFor, 0)
+			key := rule.Name + "/" + addr
+			for {
+				due := a.lastActive.Add(idleFor)
+				if last, ok := r.witnessFire[key]; ok && last.Add(rule.CooldownD()).After(due) {
+					due = last.Add(rule.CooldownD())
+				}
+				if due.After(now) {
+					break
+				}
+				facts := witness.AgentFacts{
+					Role: string(a.id.Role), Name: a.id.Name, Running: true,
+					Idle: due.Sub(a.lastActive), HookBead: a.hook, Priority: -1,
+				}
+				reason, ok := witness.MatchRule(rule, facts)
+				if !ok {
+					break
+				}
+				r.fire(Firing{At: due, Kind: KindWitness, Rule: rule.Name, Target: addr, Action: rule.Do, Reason: reason})
+				r.witnessFire[key] = due
+				if rule.Do == config.WitnessActionRestart {
+					a.lastActive = due
+				}
+			}
+		}
+	}
+}
+
+// escalate routes a recorded escalation through the policy. Recorded
+// re-escalations are ignored: the policy's own stale threshold decides
+// those.
+func (r *replay) escalate(at time.Time, e events.Event) {
+	if r.policy.Escalation == nil || payloadString(e.Payload, "reescalated") == "true" {
+		return
+	}
+	// gt escalate records the escalation bead under "rig".
+	id := payloadString(e.Payload, "escalation_id")
+	if id == "" {
+		id = payloadString(e.Payload, "rig")
+	}
+	severity := payloadString(e.Payload, "severity")
+	if !config.IsValidSeverity(severity) {
+		severity = config.SeverityMedium
+	}
+	r.fire(Firing{
+		At: at, Kind: KindEscalation, Rule: severity, Target: id,
+		Action: strings.Join(r.policy.Escalation.GetRouteForSeverity(severity), ","),
+		Reason: payloadString(e.Payload, "reason"),
+	})
+	if id != "" {
+		r.escalations[id] = &openEscalation{id: id, severity: severity, raisedAt: at}
+	}
+}
+
+func (r *replay) advanceEscalations(now time.Time) {
+	if r.policy.Escalation == nil {
+		return
+	}
+	stale := r.policy.Escalation.GetStaleThreshold()
+	maxCount := r.policy.Escalation.GetMaxReescalations()
+	ids := make([]string, 0, len(r.escalations))
+	for id := range r.escalations {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		esc := r.escalations[id]
+		for esc.count < maxCount && esc.severity != config.SeverityCritical && !esc.raisedAt.Add(stale).After(now) {
+			esc.raisedAt = esc.raisedAt.Add(stale)
+			esc.severity = nextSeverity(esc.severity)
+			esc.count++
+			r.fire(Firing{
+				At: esc.raisedAt, Kind: KindEscalation, Rule: esc.severity, Target: id,
+				Action: strings.Join(r.policy.Escalation.GetRouteForSeverity(esc.severity), ","),
+				Reason: fmt.Sprintf("re-escalation %d: unacknowledged for %s", esc.count, stale),
+			})
+		}
+	}
+}
+
+// nextSeverity returns the severity above s.
+func nextSeverity(s string) string {
+	severities := config.ValidSeverities()
+	i := slices.Index(severities, s)
+	if i < 0 || i == len(severities)-1 {
+		return config.SeverityCritical
+	}
+	return severities[i+1]
+}
+
+// dispatch plans ready beads onto idle agents.
+func (r *replay) dispatch(at time.Time) {
+	if r.policy.Dispatcher == nil || len(r.ready) == 0 {
+		return
+	}
+	var pending []assign.Bead
+	for id, rig := range r.ready {
+		pending = append(pending, assign.Bead{ID: id, Rig: rig})
+	}
+	var idle []assign.Agent
+	load := make(map[string]int)
+	for addr, a := range r.agents {
+		if a.hook != "" {
+			load[a.id.Rig]++
+			continue
+		}
+		if a.running {
+			idle = append(idle, assign.Agent{Address: addr, Rig: a.id.Rig, Role: string(a.id.Role)})
+		}
+	}
+
+	planned, _ := assign.Plan(pending, idle, load, r.policy.Dispatcher)
+	for _, p := range planned {
+		reason := "idle in " + p.Agent.Rig
+		if p.Rule != "" {
+			reason = "rule " + p.Rule
+		}
+		r.fire(Firing{At: at, Kind: KindDispatch, Rule: p.Rule, Target: p.Agent.Address, Action: "hook " + p.Bead.ID, Reason: reason})
+		r.agents[p.Agent.Address].hook = p.Bead.ID
+		delete(r.ready, p.Bead.ID)
+	}
+}
+
+func payloadString(payload map[string]interface{}, key string) string {
+	v, ok := payload[key]
+	if !ok || v == nil {
+		return ""
+	}
+	return strings.TrimSpace(fmt.Sprint(v))
+}
@@ -0,0 +1,257 @@
+package policy
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beadwatch"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/scheduler/assign"
+)
+
+var t0 = time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+
+func ev(offset time.Duration, typ, actor string, payload map[string]interface{}) events.Event {
+	return events.Event{Timestamp: t0.Add(offset).Format(time.RFC3339), Type: typ, Actor: actor, Payload: payload}
+}
+
+func TestParse_YAMLAndJSON(t *testing.T) {
+	yamlPolicy := `
+witness:
+  rules:
+    - name: quiet
+      when: {idle_for: 30m}
+      do: mail_mayor
+escalation:
+  routes:
+    high: [bead, "mail:mayor", slack]
+dispatcher:
+  rig_capacity: {"*": 2}
+`
+	p, err := Parse([]byte(yamlPolicy))
+	if err != nil {
+		t.Fatalf("Parse(yaml): %v", err)
+	}
+	if len(p.Witness.Rules) != 1 || p.Witness.Rules[0].When.IdleFor != "30m" {
+		t.Errorf("witness rules = %+v", p.Witness.Rules)
+	}
+	if got := p.Escalation.GetRouteForSeverity("high"); len(got) != 3 || got[2] != "slack" {
+		t.Errorf("high route = %v", got)
+	}
+	if p.Dispatcher.CapacityFor("gastown") != 2 {
+		t.Errorf("capacity = %d, want 2", p.Dispatcher.CapacityFor("gastown"))
+	}
+
+	if _, err := Parse([]byte(`{"dispatcher": {"batch_size": 1}}`)); err != nil {
+		t.Errorf("Parse(json): %v", err)
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"empty":            ``,
+		"no sections":      `foo: 1`,
+		"bad witness rule": `{"witness": {"rules": [{"name": "x", "when": {}, "do": "mail_mayor"}]}}`,
+		"bad severity":     `{"escalation": {"routes": {"urgent": ["bead"]}}}`,
+	}
+	for name, data := range tests {
+		if _, err := Parse([]byte(data)); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+	_, err := Parse([]byte(`{"witness": {"rules": [{"name": "x", "when": {"idle_for": "5m"}, "do": "nap"}]}}`))
+	if !errors.Is(err, config.ErrInvalidWitnessRule) {
+		t.Errorf("unknown action: err = %v, want ErrInvalidWitnessRule", err)
+	}
+}
+
+func TestLoadEvents_SkipsMalformed(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	data := `{"ts":"2026-03-01T09:00:00Z","type":"hook","actor":"gastown/polecats/nux"}
+not json
+
+{"ts":"2026-03-01T09:01:00Z","type":"done","actor":"gastown/polecats/nux"}
+`
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	evts, err := LoadEvents(path)
+	if err != nil {
+		t.Fatalf("LoadEvents: %v", err)
+	}
+	if len(evts) != 2 {
+		t.Errorf("got %d events, want 2", len(evts))
+	}
+}
+
+func TestReplay_WitnessIdleWithCooldown(t *testing.T) {
+	p := &Policy{Witness: &config.WitnessSettings{Rules: []config.WitnessRule{
+		{Name: "quiet", When: config.WitnessRuleCondition{IdleFor: "30m"}, Do: config.WitnessActionMailMayor, Cooldown: "1h"},
+	}}}
+	evts := []events.Event{
+		ev(0, events.TypeSpawn, "gt", events.SpawnPayload("gastown", "nux")),
+		ev(10*time.Minute, events.TypeHook, "gastown/polecats/nux", events.HookPayload("gt-1")),
+		// Quiet from 0:10; rule due at 0:40 and again at 1:40, then nux wakes.
+		ev(2*time.Hour, events.TypeDone, "gastown/polecats/nux", events.DonePayload("gt-1", "b")),
+		// Crew is not in the rule's default roles.
+		ev(2*time.Hour, events.TypeSessionStart, "gastown/crew/max", nil),
+		ev(3*time.Hour, events.TypeMail, "mayor", nil),
+	}
+	r := Replay(p, evts)
+	var at []time.Duration
+	for _, f := range r.Firings {
+		if f.Kind != KindWitness || f.Target != "gastown/polecats/nux" || f.Action != config.WitnessActionMailMayor {
+			t.Errorf("unexpected firing %+v", f)
+		}
+		at = append(at, f.At.Sub(t0))
+	}
+	// 0:40 and 1:40; after done at 2:00 nux is quiet again by 2:30, but the
+	// cooldown holds the next firing to 2:40.
+	want := []time.Duration{40 * time.Minute, 100 * time.Minute, 160 * time.Minute}
+	if len(at) != len(want) {
+		t.Fatalf("firings at %v, want %v", at, want)
+	}
+	for i := range want {
+		if at[i] != want[i] {
+			t.Errorf("firing %d at %v, want %v", i, at[i], want[i])
+		}
+	}
+	if r.Events != len(evts) || !r.Start.Equal(t0) || !r.End.Equal(t0.Add(3*time.Hour)) {
+		t.Errorf("report bounds = %d events %v..%v", r.Events, r.Start, r.End)
+	}
+}
+
+func TestReplay_WitnessStopsWhenSessionDies(t *testing.T) {
+	p := &Policy{Witness: &config.WitnessSettings{Rules: []config.WitnessRule{
+		{Name: "quiet", When: config.WitnessRuleCondition{IdleFor: "30m"}, Do: config.WitnessActionRestart},
+	}}}
+	evts := []events.Event{
+		ev(0, events.TypeSpawn, "gt", events.SpawnPayload("gastown", "nux")),
+		ev(10*time.Minute, events.TypeKill, "gt", events.KillPayload("gastown", "gastown/polecats/nux", "user request")),
+		ev(5*time.Hour, events.TypeMail, "mayor", nil),
+	}
+	if r := Replay(p, evts); len(r.Firings) != 0 {
+		t.Errorf("firings = %+v, want none after kill", r.Firings)
+	}
+}
+
+func TestReplay_WitnessUnreplayableRuleNoted(t *testing.T) {
+	p := &Policy{Witness: &config.WitnessSettings{Rules: []config.WitnessRule{
+		{Name: "stuck-p0", When: config.WitnessRuleCondition{IdleFor: "10m", Labels: []string{"p0"}}, Do: config.WitnessActionEscalate},
+	}}}
+	evts := []events.Event{
+		ev(0, events.TypeSpawn, "gt", events.SpawnPayload("gastown", "nux")),
+		ev(time.Hour, events.TypeMail, "mayor", nil),
+	}
+	r := Replay(p, evts)
+	if len(r.Firings) != 0 {
+		t.Errorf("firings = %+v, want none", r.Firings)
+	}
+	if len(r.Notes) != 1 {
+		t.Errorf("notes = %v, want one", r.Notes)
+	}
+}
+
+func TestReplay_EscalationRoutesAndReescalates(t *testing.T) {
+	maxRe := 2
+	p := &Policy{Escalation: &config.EscalationConfig{
+		Routes: map[string][]string{
+			config.SeverityMedium:   {"bead", "mail:mayor"},
+			config.SeverityHigh:     {"bead", "mail:mayor", "slack"},
+			config.SeverityCritical: {"bead", "mail:mayor", "sms:human"},
+		},
+		StaleThreshold:   "1h",
+		MaxReescalations: &maxRe,
+	}}
+	sent := func(id, severity string) map[string]interface{} {
+		pl := events.EscalationPayload(id, "gastown/polecats/nux", "mayor", "tests failing")
+		pl["severity"] = severity
+		return pl
+	}
+	evts := []events.Event{
+		ev(0, events.TypeEscalationSent, "gastown/polecats/nux", sent("hq-e1", "medium")),
+		ev(0, events.TypeEscalationSent, "gastown/polecats/nux", sent("hq-e2", "medium")),
+		ev(30*time.Minute, events.TypeEscalationAcked, "mayor", map[string]interface{}{"escalation_id": "hq-e2"}),
+		// Recorded re-escalations came from the old policy and are ignored.
+		ev(4*time.Hour, events.TypeEscalationSent, "deacon", map[string]interface{}{
+			"escalation_id": "hq-e1", "reescalated": true, "new_severity": "high",
+		}),
+		ev(5*time.Hour, events.TypeMail, "mayor", nil),
+	}
+	r := Replay(p, evts)
+
+	type want struct {
+		at      time.Duration
+		id, sev string
+		actions string
+	}
+	wants := []want{
+		{0, "hq-e1", "medium", "bead,mail:mayor"},
+		{0, "hq-e2", "medium", "bead,mail:mayor"},
+		{time.Hour, "hq-e1", "high", "bead,mail:mayor,slack"},
+		{2 * time.Hour, "hq-e1", "critical", "bead,mail:mayor,sms:human"},
+	}
+	if len(r.Firings) != len(wants) {
+		t.Fatalf("firings = %+v, want %d", r.Firings, len(wants))
+	}
+	for i, w := range wants {
+		f := r.Firings[i]
+		if f.At.Sub(t0) != w.at || f.Target != w.id || f.Rule != w.sev || f.Action != w.actions {
+			t.Errorf("firing %d = %+v, want %+v", i, f, w)
+		}
+	}
+}
+
+func TestReplay_DispatchReadyWork(t *testing.T) {
+	created := func(bead, source string) map[string]interface{} {
+		return events.BeadChangedPayload(source, bead, beadwatch.Created, "open", "", bead, "")
+	}
+	evts := []events.Event{
+		ev(0, events.TypeSessionStart, "gastown/crew/max", nil),
+		ev(time.Minute, events.TypeBeadChanged, "daemon", created("gt-1", "gastown")),
+	}
+	// No dispatcher section: nothing is dispatched.
+	if r := Replay(&Policy{Escalation: &config.EscalationConfig{}}, evts); r.Count(KindDispatch) != 0 {
+		t.Errorf("dispatch firings without dispatcher policy: %+v", r.Firings)
+	}
+
+	p := &Policy{Dispatcher: &assign.DispatcherConfig{RigCapacity: map[string]int{"*": 1}}}
+	evts = append(evts,
+		ev(2*time.Minute, events.TypeSpawn, "gt", events.SpawnPayload("gastown", "nux")),
+		ev(3*time.Minute, events.TypeBeadChanged, "daemon", created("gt-2", "gastown")),
+		// Assigned or in progress beads aren't ready.
+		ev(3*time.Minute, events.TypeBeadChanged, "daemon",
+			events.BeadChangedPayload("gastown", "gt-3", beadwatch.Created, "open", "", "gt-3", "gastown/crew/max")),
+		ev(3*time.Minute, events.TypeBeadChanged, "daemon",
+			events.BeadChangedPayload("gastown", "gt-4", beadwatch.Created, "in_progress", "", "gt-4", "")),
+		ev(4*time.Minute, events.TypeDone, "gastown/crew/max", events.DonePayload("gt-1", "b")),
+	)
+	r := Replay(p, evts)
+	var got []string
+	for _, f := range r.Firings {
+		got = append(got, f.At.Sub(t0).String()+" "+f.Target+" "+f.Action)
+	}
+	// Capacity 1: gt-1 goes to max at once; gt-2 waits until max is done.
+	want := []string{"1m0s gastown/crew/max hook gt-1", "4m0s gastown/crew/max hook gt-2"}
+	if len(got) != len(want) {
+		t.Fatalf("dispatches = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("dispatch %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+
+	// Sling-queued work isn't what gt dispatch reads.
+	queued := []events.Event{
+		ev(0, events.TypeSessionStart, "gastown/crew/max", nil),
+		ev(time.Minute, events.TypeSchedulerEnqueue, "mayor", events.SchedulerEnqueuePayload("gt-9", "gastown")),
+	}
+	if r := Replay(p, queued); r.Count(KindDispatch) != 0 {
+		t.Errorf("dispatched sling-queued work: %+v", r.Firings)
+	}
+}
@@ -31,8 +31,9 @@
 //     response body if repoPath is missing (avoids corrupt partial responses).
 //   - git-receive-pack requires a valid mTLS client cert.  The cert CN
 //     (format: "gt-<rig>-<name>") is parsed to extract the polecat name.
-//     Every pushed ref must match refs/heads/polecat/<name>-*; any other
-//     ref is rejected with 403 before git ever sees the request body.
+//     Every pushed ref must match refs/heads/polecat/<name>-* or
+//     refs/heads/polecat/<rig>/<name>/<bead-id>; any other ref is rejected
+//     with 403 before git ever sees the request body.
 //   - git-upload-pack is unrestricted for any authenticated client (read-only).
 //   - Subprocesses inherit only HOME and PATH from the server environment;
 //     no credentials, tokens, or secrets are visible to git.
@@ -60,6 +61,8 @@ import (
 	"path/filepath"
 	"regexp"
 	"strings"
+
+	"github.com/steveyegge/gastown/internal/git"
 )
 
 // rigNameRe matches valid rig names: alphanumeric, hyphens, and underscores only.
@@ -204,7 +207,8 @@ func (s *Server) handlePack(w http.ResponseWriter, r *http.Request, repoPath, se
 	}
 }
 
-// authorizeReceivePack checks that the push only touches refs/heads/polecat/<cn-name>-*.
+// authorizeReceivePack checks that the push only touches the CN's polecat branches
+// (see validateReceivePackRefs).
 // It reads the pkt-line stream to extract ref names, then rewinds the body.
 // It returns (true, refs) on success, or (false, refs) on failure; refs may be
 // non-nil on failure when the body was read but contained a disallowed ref.
@@ -257,7 +261,8 @@ func (s *Server) authorizeReceivePack(w http.ResponseWriter, r *http.Request, cl
 	// when authorization is denied.
 	refs := collectReceivePackRefs(pktBytes)
 
-	if err := validateReceivePackRefs(pktBytes, cnName); err != nil {
+	cnRig := strings.TrimSuffix(cnToIdentity(clientCN), "/"+cnName)
+	if err := validateReceivePackRefs(pktBytes, cnRig, cnName); err != nil {
 		http.Error(w, err.Error(), http.StatusForbidden)
 		return false, refs
 	}
@@ -307,8 +312,10 @@ func collectReceivePackRefs(body []byte) []string {
 }
 
 // validateReceivePackRefs parses the git-receive-pack pkt-line stream and validates
-// that all pushed refs are under refs/heads/polecat/<cnName>-* (prefix form only).
-func validateReceivePackRefs(body []byte, cnName string) error {
+// that all pushed refs are the polecat's own branches: refs/heads/polecat/<cnName>-*
+// (prefix form only) or refs/heads/polecat/<cnRig>/<cnName>/<bead-id>
+// (see git.PolecatBranchName).
+func validateReceivePackRefs(body []byte, cnRig, cnName string) error {
 	// The pkt-line wire format: each record is a 4-hex-digit length (including the
 	// length field itself) followed by that many bytes of payload.  "0000" is a
 	// flush packet that terminates the ref list.  Any binary pack data that follows
@@ -353,11 +360,17 @@ func validateReceivePackRefs(body []byte, cnName string) error {
 		}
 		ref := string(parts[2])
 
-		// Only allow refs/heads/polecat/<cnName>-* (prefix form).
-		// Exact-name pushes (without timestamp suffix) are not permitted.
-		if !strings.HasPrefix(ref, allowed) {
-			return fmt.Errorf("push to %q denied: only refs/heads/polecat/%s-* allowed", ref, cnName)
+		// Only allow refs/heads/polecat/<cnName>-* (prefix form) or the
+		// polecat's convention branches. Exact-name pushes (without timestamp
+		// suffix) are not permitted.
+		if strings.HasPrefix(ref, allowed) {
+			continue
+		}
+		branch, isBranch := strings.CutPrefix(ref, "refs/heads/")
+		if rig, name, _, ok := git.ParsePolecatBranch(branch); isBranch && ok && rig == cnRig && name == cnName {
+			continue
 		}
+		return fmt.Errorf("push to %q denied: only refs/heads/polecat/%s-* or refs/heads/polecat/%s/%s/* allowed", ref, cnName, cnRig, cnName)
 	}
 	return nil
 }
@@ -51,20 +51,21 @@ func (e errReadCloser) Close() error               { return nil }
 // ---- validateReceivePackRefs ----
 
 func TestValidateReceivePackRefs(t *testing.T) {
+	const rig = "gastown"
 	const polecat = "furiosa"
 
 	t.Run("empty body returns nil", func(t *testing.T) {
-		assert.NoError(t, validateReceivePackRefs(nil, polecat))
-		assert.NoError(t, validateReceivePackRefs([]byte{}, polecat))
+		assert.NoError(t, validateReceivePackRefs(nil, rig, polecat))
+		assert.NoError(t, validateReceivePackRefs([]byte{}, rig, polecat))
 	})
 
 	t.Run("flush-only body returns nil", func(t *testing.T) {
-		assert.NoError(t, validateReceivePackRefs([]byte("0000"), polecat))
+		assert.NoError(t, validateReceivePackRefs([]byte("0000"), rig, polecat))
 	})
 
 	t.Run("single valid ref returns nil", func(t *testing.T) {
 		body := receivePackBody("refs/heads/polecat/furiosa-abc123")
-		assert.NoError(t, validateReceivePackRefs(body, polecat))
+		assert.NoError(t, validateReceivePackRefs(body, rig, polecat))
 	})
 
 	t.Run("multiple valid refs return nil", func(t *testing.T) {
@@ -72,12 +73,31 @@ func TestValidateReceivePackRefs(t *testing.T) {
 			"refs/heads/polecat/furiosa-abc123",
 			"refs/heads/polecat/furiosa-def456",
 		)
-		assert.NoError(t, validateReceivePackRefs(body, polecat))
+		assert.NoError(t, validateReceivePackRefs(body, rig, polecat))
+	})
+
+	t.Run("convention branch returns nil", func(t *testing.T) {
+		body := receivePackBody(
+			"refs/heads/polecat/gastown/furiosa/gt-abc.1",
+			"refs/heads/polecat/gastown/furiosa/idle",
+		)
+		assert.NoError(t, validateReceivePackRefs(body, rig, polecat))
+	})
+
+	t.Run("convention branch of another rig or polecat is denied", func(t *testing.T) {
+		for _, ref := range []string{
+			"refs/heads/polecat/otherrig/furiosa/gt-abc",
+			"refs/heads/polecat/gastown/nux/gt-abc",
+			"refs/heads/polecat/gastown/furiosa/gt-abc/extra",
+		} {
+			err := validateReceivePackRefs(receivePackBody(ref), rig, polecat)
+			assert.Error(t, err, ref)
+		}
 	})
 
 	t.Run("ref to main is denied", func(t *testing.T) {
 		body := receivePackBody("refs/heads/main")
-		err := validateReceivePackRefs(body, polecat)
+		err := validateReceivePackRefs(body, rig, polecat)
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "main")
 	})
@@ -85,14 +105,14 @@ func TestValidateReceivePackRefs(t *testing.T) {
 	t.Run("exact polecat ref without dash suffix is denied", func(t *testing.T) {
 		// "refs/heads/polecat/furiosa" has no dash suffix → denied.
 		body := receivePackBody("refs/heads/polecat/furiosa")
-		err := validateReceivePackRefs(body, polecat)
+		err := validateReceivePackRefs(body, rig, polecat)
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "refs/heads/polecat/furiosa")
 	})
 
 	t.Run("wrong polecat name is denied", func(t *testing.T) {
 		body := receivePackBody("refs/heads/polecat/otherpolecat-abc")
-		err := validateReceivePackRefs(body, polecat)
+		err := validateReceivePackRefs(body, rig, polecat)
 		require.Error(t, err)
 	})
 
@@ -101,7 +121,7 @@ func TestValidateReceivePackRefs(t *testing.T) {
 			"refs/heads/polecat/furiosa-ok",
 			"refs/heads/main",
 		)
-		err := validateReceivePackRefs(body, polecat)
+		err := validateReceivePackRefs(body, rig, polecat)
 		assert.Error(t, err)
 	})
 
@@ -110,7 +130,7 @@ func TestValidateReceivePackRefs(t *testing.T) {
 		body := []byte("00")
 		var err error
 		assert.NotPanics(t, func() {
-			err = validateReceivePackRefs(body, polecat)
+			err = validateReceivePackRefs(body, rig, polecat)
 		})
 		require.Error(t, err, "truncated length field must be rejected (fail-closed)")
 	})
@@ -120,7 +140,7 @@ func TestValidateReceivePackRefs(t *testing.T) {
 		body := []byte("0010hello")
 		var err error
 		assert.NotPanics(t, func() {
-			err = validateReceivePackRefs(body, polecat)
+			err = validateReceivePackRefs(body, rig, polecat)
 		})
 		require.Error(t, err, "truncated pkt-line body must be rejected (fail-closed)")
 	})
@@ -131,20 +151,20 @@ func TestValidateReceivePackRefs(t *testing.T) {
 		// ref line with NUL-separated capability string
 		line := zeroSHA + " " + newSHA + " refs/heads/polecat/furiosa-abc\x00side-band-64k\n"
 		body := []byte(pktLine(line) + "0000")
-		assert.NoError(t, validateReceivePackRefs(body, polecat))
+		assert.NoError(t, validateReceivePackRefs(body, rig, polecat))
 	})
 
 	t.Run("line with fewer than 3 fields is skipped without error", func(t *testing.T) {
 		line := "onlyone\n"
 		body := []byte(pktLine(line) + "0000")
-		assert.NoError(t, validateReceivePackRefs(body, polecat))
+		assert.NoError(t, validateReceivePackRefs(body, rig, polecat))
 	})
 
 	t.Run("pktLen==4 empty payload does not spin", func(t *testing.T) {
 		// "0004" means a packet with only the length field (no payload).
 		body := []byte("0004" + "0000")
 		assert.NotPanics(t, func() {
-			_ = validateReceivePackRefs(body, polecat)
+			_ = validateReceivePackRefs(body, rig, polecat)
 		})
 	})
 
@@ -152,7 +172,7 @@ func TestValidateReceivePackRefs(t *testing.T) {
 		// "0000" flush followed by raw binary pack data. Must not panic or error.
 		binaryJunk := []byte("0000\x00\x00\x00\x02\xff\xfe\xfd\xfc PACK binary garbage")
 		assert.NotPanics(t, func() {
-			err := validateReceivePackRefs(binaryJunk, polecat)
+			err := validateReceivePackRefs(binaryJunk, rig, polecat)
 			assert.NoError(t, err)
 		})
 	})
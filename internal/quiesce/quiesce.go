@@ -0,0 +1,143 @@
+// Package quiesce records a graceful town shutdown so it can be resumed.
+//
+// While a manifest exists the town is quiesced: new dispatch is refused
+// until the manifest is cleared by gt quiesce resume.
+package quiesce
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// Manifest phases.
+const (
+	// PhaseDraining means dispatch is blocked and agents are being brought
+	// to a safe point; sessions are still running.
+	PhaseDraining = "draining"
+	// PhaseStopped means every recorded session has been stopped.
+	PhaseStopped = "stopped"
+)
+
+// Safe points an agent reached before it was stopped.
+const (
+	SafePointIdle       = "idle"        // At its prompt, between turns
+	SafePointTimeout    = "timeout"     // Still busy when the wait ran out
+	SafePointNotRunning = "not_running" // Session was gone before the wait ended
+)
+
+// Manifest describes a quiesced town: what was running and where each
+// agent was left, so resume can bring it back.
+type Manifest struct {
+	Phase     string    `json:"phase"`
+	StartedAt time.Time `json:"started_at"`
+	StartedBy string    `json:"started_by,omitempty"`
+	StoppedAt time.Time `json:"stopped_at,omitempty"`
+	Reason    string    `json:"reason,omitempty"`
+	Agents    []Agent   `json:"agents"`
+	// DaemonWasRunning records that the daemon was stopped, so resume
+	// starts it again.
+	DaemonWasRunning bool `json:"daemon_was_running,omitempty"`
+	// PausedScheduler and PausedDeacon record pauses quiesce made itself;
+	// resume lifts only those, not pauses that were already in place.
+	PausedScheduler bool `json:"paused_scheduler,omitempty"`
+	PausedDeacon    bool `json:"paused_deacon,omitempty"`
+}
+
+// Agent is one session recorded in a manifest.
+type Agent struct {
+	Address    string `json:"address"` // e.g. "gastown/polecats/nux", "mayor"
+	Session    string `json:"session"`
+	Role       string `json:"role"`
+	Rig        string `json:"rig,omitempty"`
+	Name       string `json:"name,omitempty"` // Polecat or crew name
+	HookedBead string `json:"hooked_bead,omitempty"`
+	SafePoint  string `json:"safe_point,omitempty"`
+	Checkpoint string `json:"checkpoint,omitempty"` // Checkpoint bead ID
+	Restored   bool   `json:"restored,omitempty"`   // Checkpoint hooked back by resume
+	Stopped    bool   `json:"stopped,omitempty"`
+	Error      string `json:"error,omitempty"` // Why it couldn't be checkpointed or stopped
+}
+
+// ManifestPath returns the path to the town's quiesce manifest.
+func ManifestPath(townRoot string) string {
+	return filepath.Join(townRoot, ".runtime", "quiesce.json")
+}
+
+// Load reads the town's quiesce manifest. It returns nil, nil when the town
+// is not quiesced.
+func Load(townRoot string) (*Manifest, error) {
+	data, err := os.ReadFile(ManifestPath(townRoot)) //nolint:gosec // G304: path is constructed from trusted townRoot
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", ManifestPath(townRoot), err)
+	}
+	return &m, nil
+}
+
+// Save writes the manifest atomically.
+func Save(townRoot string, m *Manifest) error {
+	return util.EnsureDirAndWriteJSON(ManifestPath(townRoot), m)
+}
+
+// Clear removes the manifest, lifting the dispatch block.
+func Clear(townRoot string) error {
+	if err := os.Remove(ManifestPath(townRoot)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// CheckDispatch returns an error if the town is quiesced. Dispatch paths
+// call it before handing out work. An unreadable manifest counts as
+// quiesced.
+func CheckDispatch(townRoot string) error {
+	m, err := Load(townRoot)
+	if err != nil {
+		return fmt.Errorf("town may be quiesced: %w", err)
+	}
+	if m == nil {
+		return nil
+	}
+	return fmt.Errorf("town is quiesced (%s since %s); run 'gt quiesce resume' first",
+		m.Phase, m.StartedAt.Local().Format("2006-01-02 15:04"))
+}
+
+// stopRank orders agents for shutdown: workers first, so nothing is left
+// to restart them, then the agents that watch them.
+func stopRank(a Agent) int {
+	switch session.Role(a.Role) {
+	case session.RoleCrew:
+		return 1
+	case session.RoleWitness, session.RoleRefinery:
+		return 2
+	case session.RoleDeacon:
+		if a.Name == "boot" {
+			return 3 // Boot watches the deacon, so it goes first
+		}
+		return 4
+	case session.RoleMayor:
+		return 5
+	}
+	return 0 // Polecats, dogs, anything unrecognized
+}
+
+// SortForStop orders agents for shutdown: polecats, crew,
+// witnesses/refineries, boot, deacon, mayor.
+func SortForStop(agents []Agent) {
+	sort.SliceStable(agents, func(i, j int) bool {
+		return stopRank(agents[i]) < stopRank(agents[j])
+	})
+}
@@ -0,0 +1,74 @@
+package quiesce
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestManifestRoundTrip(t *testing.T) {
+	townRoot := t.TempDir()
+	if m, err := Load(townRoot); err != nil || m != nil {
+		t.Fatalf("Load with no manifest = %+v, %v; want nil, nil", m, err)
+	}
+	if err := CheckDispatch(townRoot); err != nil {
+		t.Fatalf("CheckDispatch with no manifest = %v", err)
+	}
+
+	want := &Manifest{
+		Phase:     PhaseStopped,
+		StartedAt: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC),
+		Reason:    "host maintenance",
+		Agents: []Agent{
+			{Address: "gastown/polecats/nux", Session: "gt-nux", Role: "polecat", Rig: "gastown", Name: "nux",
+				HookedBead: "gt-abc", SafePoint: SafePointIdle, Checkpoint: "hq-cp1", Stopped: true},
+		},
+		PausedScheduler: true,
+	}
+	if err := Save(townRoot, want); err != nil {
+		t.Fatal(err)
+	}
+	got, err := Load(townRoot)
+	if err != nil || got == nil {
+		t.Fatalf("Load = %+v, %v", got, err)
+	}
+	if got.Phase != want.Phase || !got.StartedAt.Equal(want.StartedAt) || len(got.Agents) != 1 ||
+		got.Agents[0] != want.Agents[0] || !got.PausedScheduler {
+		t.Errorf("Load = %+v, want %+v", got, want)
+	}
+
+	if err := CheckDispatch(townRoot); err == nil || !strings.Contains(err.Error(), "gt quiesce resume") {
+		t.Errorf("CheckDispatch while quiesced = %v", err)
+	}
+	if err := Clear(townRoot); err != nil {
+		t.Fatal(err)
+	}
+	if err := CheckDispatch(townRoot); err != nil {
+		t.Errorf("CheckDispatch after Clear = %v", err)
+	}
+	if err := Clear(townRoot); err != nil {
+		t.Errorf("second Clear = %v", err)
+	}
+}
+
+func TestSortForStop(t *testing.T) {
+	agents := []Agent{
+		{Address: "mayor", Role: "mayor"},
+		{Address: "deacon", Role: "deacon"},
+		{Address: "gastown/witness", Role: "witness"},
+		{Address: "boot", Role: "deacon", Name: "boot"},
+		{Address: "gastown/crew/max", Role: "crew"},
+		{Address: "gastown/refinery", Role: "refinery"},
+		{Address: "gastown/polecats/nux", Role: "polecat"},
+		{Address: "dog-alpha"},
+	}
+	SortForStop(agents)
+	var got []string
+	for _, a := range agents {
+		got = append(got, a.Address)
+	}
+	want := "gastown/polecats/nux dog-alpha gastown/crew/max gastown/witness gastown/refinery boot deacon mayor"
+	if strings.Join(got, " ") != want {
+		t.Errorf("stop order = %v, want %s", got, want)
+	}
+}
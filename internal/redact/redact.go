@@ -0,0 +1,142 @@
+// Package redact masks secrets such as API keys and tokens in text captured
+// from agent panes and worktrees before it is persisted: incident bundles,
+// checkpoint beads, mail bodies and attachments, and nudge undo snapshots.
+// Agents print credentials more often than anyone would like (env dumps,
+// curl -v, failed auth output), and those captures outlive the session and
+// are readable by every other agent.
+//
+// A built-in set covers common token formats; towns add their own regexes
+// under operational.redaction in settings/config.json.
+package redact
+
+import (
+	"regexp"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+// Mask replaces each redacted secret.
+const Mask = "[REDACTED]"
+
+// secretGroup names the capture group holding the secret in patterns where
+// only part of the match is sensitive (e.g. the value of "token=..."). The
+// rest of the match is kept so the redacted text still makes sense.
+const secretGroup = "secret"
+
+// builtinPatterns match common credential formats.
+var builtinPatterns = []string{
+	// Provider API keys and tokens
+	`\bsk-ant-[A-Za-z0-9_-]{20,}`,                                      // Anthropic
+	`\bsk-(?:proj-)?[A-Za-z0-9_-]{20,}`,                                // OpenAI
+	`gh[pousr]_[A-Za-z0-9]{30,}`,                                       // GitHub
+	`github_pat_[A-Za-z0-9_]{30,}`,                                     // GitHub fine-grained
+	`glpat-[A-Za-z0-9_-]{20,}`,                                         // GitLab
+	`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`,                                    // AWS access key ID
+	`xox[abposr]-[A-Za-z0-9-]{10,}`,                                    // Slack
+	`AIza[0-9A-Za-z_-]{35}`,                                            // Google
+	`\b[rs]k_live_[0-9A-Za-z]{20,}`,                                    // Stripe
+	`\bnpm_[A-Za-z0-9]{36}\b`,                                          // npm
+	`eyJ[A-Za-z0-9_-]{10,}\.eyJ[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}`, // JWT
+
+	// PEM private keys
+	`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`,
+
+	// Bearer tokens and credentials in URLs
+	`(?i)\bbearer\s+(?P<secret>[A-Za-z0-9._~+/=-]{16,})`,
+	`\b[a-zA-Z][a-zA-Z0-9+.-]*://[^/\s:@]+:(?P<secret>[^/\s@]+)@`,
+
+	// KEY=value and "key": "value" assignments of secret-looking names
+	`(?i)\b[a-z0-9_.-]*(?:api[_-]?key|secret|token|passw(?:or)?d|credential)[a-z0-9_.-]*["']?\s*[:=]\s*["']?(?P<secret>[^\s"']{8,})`,
+}
+
+// Redactor masks secrets matched by a set of patterns.
+type Redactor struct {
+	patterns []*regexp.Regexp
+}
+
+// New returns a Redactor for the built-in patterns (unless builtin is
+// false) plus extra. An invalid extra pattern is an error.
+func New(extra []string, builtin bool) (*Redactor, error) {
+	r := &Redactor{}
+	if builtin {
+		r.patterns = append(r.patterns, compiledBuiltins...)
+	}
+	for _, p := range extra {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, err
+		}
+		r.patterns = append(r.patterns, re)
+	}
+	return r, nil
+}
+
+var compiledBuiltins = func() []*regexp.Regexp {
+	res := make([]*regexp.Regexp, len(builtinPatterns))
+	for i, p := range builtinPatterns {
+		res[i] = regexp.MustCompile(p)
+	}
+	return res
+}()
+
+// ForTown returns the town's Redactor, configured by operational.redaction.
+// Invalid town patterns are skipped rather than failing the capture;
+// gt config validate reports them.
+func ForTown(townRoot string) *Redactor {
+	cfg := config.LoadOperationalConfig(townRoot).GetRedactionConfig()
+	r := &Redactor{}
+	if cfg.BuiltinV() {
+		r.patterns = append(r.patterns, compiledBuiltins...)
+	}
+	for _, p := range cfg.Patterns {
+		if re, err := regexp.Compile(p); err == nil {
+			r.patterns = append(r.patterns, re)
+		}
+	}
+	return r
+}
+
+// String returns s with every secret replaced by Mask.
+func (r *Redactor) String(s string) string {
+	if r == nil {
+		return s
+	}
+	for _, re := range r.patterns {
+		s = redactPattern(re, s)
+	}
+	return s
+}
+
+// String masks secrets in s with the town's Redactor.
+func String(townRoot, s string) string {
+	if s == "" {
+		return s
+	}
+	return ForTown(townRoot).String(s)
+}
+
+// redactPattern masks re's matches in s: the "secret" group if the pattern
+// has one, otherwise the whole match.
+func redactPattern(re *regexp.Regexp, s string) string {
+	group := re.SubexpIndex(secretGroup)
+	if group < 0 {
+		return re.ReplaceAllLiteralString(s, Mask)
+	}
+	matches := re.FindAllStringSubmatchIndex(s, -1)
+	if matches == nil {
+		return s
+	}
+	var out []byte
+	last := 0
+	for _, m := range matches {
+		start, end := m[2*group], m[2*group+1]
+		if start < 0 {
+			continue
+		}
+		out = append(out, s[last:start]...)
+		out = append(out, Mask...)
+		last = end
+	}
+	out = append(out, s[last:]...)
+	return string(out)
+}
@@ -205,6 +205,13 @@ func (e *Engineer) ProcessBatch(ctx context.Context, batch []*MRInfo, target str
 		return result
 	}
 
+	// A missing gate tool would fail every stack and bisect down to blaming
+	// each MR in turn.
+	if err := e.checkPreflight(); err != nil {
+		result.Error = err
+		return result
+	}
+
 	// Single MR: use existing doMerge path (no batch overhead)
 	if len(batch) == 1 {
 		return e.processSingleMR(ctx, batch[0], target)
@@ -235,6 +242,7 @@ func (e *Engineer) ProcessBatch(ctx context.Context, batch []*MRInfo, target str
 	// Step 2: Run gates on the stack tip
 	_, _ = fmt.Fprintf(e.output, "[Batch] Running gates on stack tip (%d MRs)...\n", len(stacked))
 	gateResult := e.runBatchGates(ctx)
+	e.attachCheckResults(mrSourceIssues(stacked), target, gateResult)
 
 	// Step 3: Happy path — all green
 	if gateResult.Success {
@@ -274,6 +282,7 @@ func (e *Engineer) ProcessBatch(ctx context.Context, batch []*MRInfo, target str
 		}
 		// Verify the good subset actually passes
 		verifyResult := e.runBatchGates(ctx)
+		e.attachCheckResults(mrSourceIssues(good), target, verifyResult)
 		if verifyResult.Success {
 			return e.fastForwardBatch(ctx, good, target, result)
 		}
@@ -302,24 +311,36 @@ func (e *Engineer) processSingleMR(ctx context.Context, mr *MRInfo, target strin
 	return result
 }
 
-// runBatchGates runs quality gates (or legacy tests) on the current working tree.
+// runBatchGates runs quality gates (or the rig's check commands and tests)
+// on the current working tree. doMerge uses it too, for a single MR. Callers
+// check that the gates' programs exist once per cycle, with checkPreflight.
 func (e *Engineer) runBatchGates(ctx context.Context) ProcessResult {
 	if len(e.config.Gates) > 0 {
 		return e.runGates(ctx)
 	}
+	result := e.runCheckCommands(ctx)
+	if !result.Success {
+		return result
+	}
 	if e.config.RunTests && e.config.TestCommand != "" {
-		result := e.runTests(ctx)
-		if !result.Success {
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Running tests: %s\n", e.config.TestCommand)
+		start := time.Now()
+		tests := e.runTests(ctx)
+		check := GateResult{Name: "test", Success: tests.Success, Error: tests.Error, Output: tests.Log, Elapsed: time.Since(start)}
+		result.Checks = append(result.Checks, check)
+		if !tests.Success {
 			return ProcessResult{
 				Success:     false,
 				TestsFailed: true,
-				Error:       result.Error,
+				Error:       tests.Error,
+				Log:         tests.Log,
+				Checks:      result.Checks,
 			}
 		}
-		return ProcessResult{Success: true}
+		_, _ = fmt.Fprintln(e.output, "[Engineer] Tests passed")
 	}
-	// No gates configured — pass by default
-	return ProcessResult{Success: true}
+	// Nothing configured passes by default
+	return result
 }
 
 // verifyAndPush runs gates and pushes the current state for a set of stacked MRs.
@@ -327,6 +348,7 @@ func (e *Engineer) verifyAndPush(ctx context.Context, stacked []*MRInfo, target
 	result := &BatchResult{}
 
 	gateResult := e.runBatchGates(ctx)
+	e.attachCheckResults(mrSourceIssues(stacked), target, gateResult)
 	if !gateResult.Success {
 		if gateResult.TestsFailed {
 			result.Culprits = stacked
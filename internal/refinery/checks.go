@@ -0,0 +1,219 @@
+package refinery
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// checkCommand is one of a rig's configured check commands.
+type checkCommand struct {
+	Name string
+	Cmd  string
+}
+
+// checkCommands returns the rig's configured check commands, excluding
+// tests, in the order they run: setup, typecheck, lint, build.
+func (c *MergeQueueConfig) checkCommands() []checkCommand {
+	var checks []checkCommand
+	for _, cc := range []checkCommand{
+		{"setup", c.SetupCommand},
+		{"typecheck", c.TypecheckCommand},
+		{"lint", c.LintCommand},
+		{"build", c.BuildCommand},
+	} {
+		if strings.TrimSpace(cc.Cmd) != "" {
+			checks = append(checks, cc)
+		}
+	}
+	return checks
+}
+
+// runCheckCommands runs the rig's check commands in order on the current
+// working tree, stopping at the first failure. A failure is the MR's fault,
+// like a failing test.
+func (e *Engineer) runCheckCommands(ctx context.Context) ProcessResult {
+	result := ProcessResult{Success: true}
+	for _, cc := range e.config.checkCommands() {
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Check %q: starting (%s)\n", cc.Name, cc.Cmd)
+		r := e.runGate(ctx, cc.Name, &GateConfig{Cmd: cc.Cmd})
+		result.Checks = append(result.Checks, r)
+		if !r.Success {
+			_, _ = fmt.Fprintf(e.output, "[Engineer] Check %q: FAILED (%v) - %s\n", r.Name, r.Elapsed.Truncate(time.Millisecond), r.Error)
+			result.Success = false
+			result.TestsFailed = true
+			result.Error = fmt.Sprintf("check %s failed: %s", r.Name, r.Error)
+			result.Log = fmt.Sprintf("=== check %s (%s) ===\n%s", r.Name, cc.Cmd, r.Output)
+			return result
+		}
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Check %q: passed (%v)\n", r.Name, r.Elapsed.Truncate(time.Millisecond))
+	}
+	return result
+}
+
+// shellBuiltins are command words a shell runs itself, so there is no
+// program to look for.
+var shellBuiltins = map[string]bool{
+	"cd": true, "export": true, "set": true, "unset": true, "true": true, "false": true,
+	"test": true, "[": true, ":": true, ".": true, "source": true, "exec": true, "eval": true,
+	"exit": true, "echo": true, "printf": true, "command": true, "type": true, "time": true,
+	"ulimit": true, "umask": true, "trap": true, "if": true, "for": true, "while": true,
+	"until": true, "case": true, "{": true, "(": true, "!": true,
+}
+
+// shellSyntax are characters that make a command word more than a plain
+// word: expansions, quoting, redirection, and control operators.
+const shellSyntax = "$`()<>;|&\"'\\"
+
+// commandProgram returns the program a shell command runs first, skipping
+// leading VAR=value assignments. It returns "" when there is no program to
+// look for: the command starts with a shell builtin or keyword, or with
+// shell syntax whose program can't be known without running it.
+func commandProgram(cmd string) string {
+	for _, word := range strings.Fields(cmd) {
+		if k, v, ok := strings.Cut(word, "="); ok && k != "" && !strings.ContainsAny(k, "/-."+shellSyntax) {
+			if strings.ContainsAny(v, shellSyntax) {
+				return ""
+			}
+			continue // Environment assignment
+		}
+		if shellBuiltins[word] || strings.ContainsAny(word, shellSyntax) {
+			return ""
+		}
+		return word
+	}
+	return ""
+}
+
+// preflightChecks verifies that the program each configured gate, check
+// command, and test command runs can be found, so a missing tool fails
+// loudly as a refinery problem instead of bouncing every MR as broken.
+// Programs given as relative paths are looked up in the refinery worktree.
+func (e *Engineer) preflightChecks() error {
+	cmds := make(map[string]string) // name → command
+	for name, gate := range e.config.Gates {
+		cmds[name] = gate.Cmd
+	}
+	if len(e.config.Gates) == 0 {
+		for _, cc := range e.config.checkCommands() {
+			cmds[cc.Name] = cc.Cmd
+		}
+		if e.config.RunTests && e.config.TestCommand != "" {
+			cmds["test"] = e.config.TestCommand
+		}
+	}
+	names := make([]string, 0, len(cmds))
+	for name := range cmds {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var missing []string
+	for _, name := range names {
+		prog := commandProgram(cmds[name])
+		if prog == "" {
+			continue
+		}
+		if strings.Contains(prog, "/") {
+			if !filepath.IsAbs(prog) {
+				prog = filepath.Join(e.workDir, prog)
+			}
+			if _, err := os.Stat(prog); err != nil {
+				missing = append(missing, fmt.Sprintf("%s: %s not found", name, prog))
+			}
+			continue
+		}
+		if _, err := exec.LookPath(prog); err != nil {
+			missing = append(missing, fmt.Sprintf("%s: %s not found in PATH", name, prog))
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%s", strings.Join(missing, "; "))
+	}
+	return nil
+}
+
+// checkPreflight runs preflightChecks before a merge cycle. On failure the
+// cycle must be skipped: the mayor is mailed, since no MR author can fix a
+// missing tool, and the returned error says why nothing was merged.
+func (e *Engineer) checkPreflight() error {
+	err := e.preflightChecks()
+	if err == nil {
+		return nil
+	}
+	_, _ = fmt.Fprintf(e.output, "[Engineer] Check preflight failed, skipping merge cycle: %v\n", err)
+	body := fmt.Sprintf("The %s refinery can't run its merge gates, so the merge queue is paused. No MRs were claimed or bounced.\n\n%v\n\nInstall the missing tools or fix merge_queue in the rig's config.json; the queue resumes on the next cycle.\n",
+		e.rig.Name, err)
+	if e.escalate != nil {
+		if mailErr := e.escalate(fmt.Sprintf("REFINERY_PREFLIGHT_FAILED: %s", e.rig.Name), body); mailErr != nil {
+			_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to escalate preflight failure: %v\n", mailErr)
+		}
+	}
+	return fmt.Errorf("check preflight: %w", err)
+}
+
+// formatCheckResults renders what ran on a merge candidate, for the beads
+// whose work it contains.
+func formatCheckResults(target, sha string, result ProcessResult) string {
+	verdict := "passed"
+	if !result.Success {
+		verdict = "FAILED"
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "Refinery checks on %s", target)
+	if sha != "" {
+		fmt.Fprintf(&b, " @ %s", sha)
+	}
+	fmt.Fprintf(&b, ": %s\n", verdict)
+	for _, c := range result.Checks {
+		mark := "✓"
+		if !c.Success {
+			mark = "✗"
+		}
+		fmt.Fprintf(&b, "  %s %s (%v)", mark, c.Name, c.Elapsed.Truncate(time.Millisecond))
+		if c.Error != "" {
+			fmt.Fprintf(&b, ": %s", c.Error)
+		}
+		b.WriteString("\n")
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// attachCheckResults comments the results of a check run on each source
+// issue, so a bead records how its work was verified before it merged (or
+// why it bounced). Runs with no checks configured record nothing. Comment
+// failures are logged, not fatal.
+func (e *Engineer) attachCheckResults(issueIDs []string, target string, result ProcessResult) {
+	if e.beads == nil || len(result.Checks) == 0 {
+		return
+	}
+	var sha string
+	if e.git != nil {
+		if head, err := e.git.Rev("HEAD"); err == nil && len(head) >= 8 {
+			sha = head[:8]
+		}
+	}
+	text := formatCheckResults(target, sha, result)
+	for _, id := range issueIDs {
+		if id == "" {
+			continue
+		}
+		if _, err := e.beads.Run("comment", id, text); err != nil {
+			_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: could not attach check results to %s: %v\n", id, err)
+		}
+	}
+}
+
+// mrSourceIssues returns the source issues of a set of MRs.
+func mrSourceIssues(mrs []*MRInfo) []string {
+	ids := make([]string, 0, len(mrs))
+	for _, mr := range mrs {
+		ids = append(ids, mr.SourceIssue)
+	}
+	return ids
+}
@@ -0,0 +1,107 @@
+package refinery
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/rig"
+)
+
+func TestCommandProgram(t *testing.T) {
+	tests := map[string]string{
+		"go build ./...":                  "go",
+		"CGO_ENABLED=0 GOOS=linux go vet": "go",
+		"./scripts/lint.sh --strict":      "./scripts/lint.sh",
+		"cd web && pnpm build":            "",
+		"exit 1":                          "",
+		"count=$(cat f); test $count":     "",
+		"$(npm bin)/eslint .":             "",
+		"":                                "",
+	}
+	for cmd, want := range tests {
+		if got := commandProgram(cmd); got != want {
+			t.Errorf("commandProgram(%q) = %q, want %q", cmd, got, want)
+		}
+	}
+}
+
+func TestRunBatchGates_CheckCommandsInOrder(t *testing.T) {
+	r := &rig.Rig{Name: "test-rig", Path: t.TempDir()}
+	e := NewEngineer(r)
+	e.workDir = t.TempDir()
+	e.output = io.Discard
+	e.config.SetupCommand = "true"
+	e.config.LintCommand = "exit 3"
+	e.config.BuildCommand = "true"
+	e.config.RunTests = true
+	e.config.TestCommand = "true"
+
+	result := e.runBatchGates(context.Background())
+	if result.Success || !result.TestsFailed {
+		t.Fatalf("lint failure should fail the MR, got %+v", result)
+	}
+	var ran []string
+	for _, c := range result.Checks {
+		ran = append(ran, c.Name)
+	}
+	if got := strings.Join(ran, ","); got != "setup,lint" {
+		t.Errorf("checks ran = %s, want setup,lint (stop at first failure)", got)
+	}
+
+	e.config.LintCommand = "true"
+	result = e.runBatchGates(context.Background())
+	ran = nil
+	for _, c := range result.Checks {
+		ran = append(ran, c.Name)
+	}
+	if !result.Success || strings.Join(ran, ",") != "setup,lint,build,test" {
+		t.Errorf("all passing: success=%v ran=%v, want setup,lint,build,test", result.Success, ran)
+	}
+}
+
+func TestProcessBatch_PreflightMissingProgram(t *testing.T) {
+	r := &rig.Rig{Name: "test-rig", Path: t.TempDir()}
+	e := NewEngineer(r)
+	e.workDir = t.TempDir()
+	e.output = io.Discard
+	e.config.BuildCommand = "gt-no-such-build-tool --all"
+	e.config.LintCommand = "./scripts/missing-lint.sh"
+	var escalated []string
+	e.escalate = func(subject, body string) error {
+		escalated = append(escalated, subject+"\n"+body)
+		return nil
+	}
+
+	batch := []*MRInfo{{ID: "mr-1", Branch: "polecat/a"}, {ID: "mr-2", Branch: "polecat/b"}}
+	result := e.ProcessBatch(context.Background(), batch, "main", nil)
+	if result.Error == nil {
+		t.Fatal("missing tool should fail the batch")
+	}
+	for _, want := range []string{"build: gt-no-such-build-tool not found", "lint: "} {
+		if !strings.Contains(result.Error.Error(), want) {
+			t.Errorf("error %q should mention %q", result.Error, want)
+		}
+	}
+	if len(result.Culprits) != 0 || len(result.Merged) != 0 || len(result.Conflicts) != 0 {
+		t.Errorf("no MR should be blamed or merged after a failed preflight, got %+v", result)
+	}
+	if len(escalated) != 1 || !strings.Contains(escalated[0], "REFINERY_PREFLIGHT_FAILED: test-rig") {
+		t.Errorf("preflight failure should be escalated once, got %q", escalated)
+	}
+}
+
+func TestFormatCheckResults(t *testing.T) {
+	got := formatCheckResults("main", "abc12345", ProcessResult{Checks: []GateResult{
+		{Name: "build", Success: true, Elapsed: 1500 * time.Millisecond},
+		{Name: "test", Error: "exit status 1", Elapsed: 2 * time.Second},
+	}})
+	want := "Refinery checks on main @ abc12345: FAILED\n" +
+		"  ✓ build (1.5s)\n" +
+		"  ✗ test (2s): exit status 1"
+	if got != want {
+		t.Errorf("formatCheckResults =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -23,6 +23,10 @@ import (
 	"github.com/steveyegge/gastown/internal/git"
 	"github.com/steveyegge/gastown/internal/mail"
 	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/templates"
+	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/util"
 )
 
 // DefaultStaleClaimTimeout is the default duration after which a claimed MR
@@ -61,6 +65,7 @@ type GateResult struct {
 	Name    string
 	Success bool
 	Error   string
+	Output  string // Tail of the gate's combined output, set on failure
 	Elapsed time.Duration
 }
 
@@ -83,6 +88,14 @@ type MergeQueueConfig struct {
 	// TestCommand is the command to run for testing.
 	TestCommand string `json:"test_command"`
 
+	// SetupCommand, TypecheckCommand, LintCommand, and BuildCommand are the
+	// rig's check commands. Unless Gates is set, they run in that order, each
+	// only if configured, before the tests (see runCheckCommands).
+	SetupCommand     string `json:"setup_command"`
+	TypecheckCommand string `json:"typecheck_command"`
+	LintCommand      string `json:"lint_command"`
+	BuildCommand     string `json:"build_command"`
+
 	// DeleteMergedBranches controls whether to delete branches after merge.
 	DeleteMergedBranches bool `json:"delete_merged_branches"`
 
@@ -216,6 +229,10 @@ type Engineer struct {
 	mergeSlotRelease      func(holder string) error
 	mergeSlotMaxRetries   int           // Max retries for slot acquisition (0 = no retry)
 	mergeSlotRetryBackoff time.Duration // Initial backoff between retries
+	convoyGate            func(convoyID string) (*beads.ConvoyGate, error)
+	showIssues            func(ids []string) (map[string]*beads.Issue, error)
+	escalate              func(subject, body string) error // Mails the mayor about problems no MR author can fix
+	workerAlive           func(polecat string) bool        // Reports whether a polecat's session is running
 }
 
 // NewEngineer creates a new Engineer for the given rig.
@@ -230,6 +247,7 @@ func NewEngineer(r *rig.Rig) *Engineer {
 		gitDir = filepath.Join(r.Path, "mayor", "rig")
 	}
 	beadsClient := beads.New(r.Path)
+	router := mail.NewRouter(r.Path)
 
 	return &Engineer{
 		rig:     r,
@@ -238,7 +256,7 @@ func NewEngineer(r *rig.Rig) *Engineer {
 		config:  cfg,
 		workDir: gitDir,
 		output:  os.Stdout,
-		router:  mail.NewRouter(r.Path),
+		router:  router,
 		mergeSlotEnsureExists: func() (string, error) {
 			return beadsClient.MergeSlotEnsureExists()
 		},
@@ -250,6 +268,25 @@ func NewEngineer(r *rig.Rig) *Engineer {
 		},
 		mergeSlotMaxRetries:   10,
 		mergeSlotRetryBackoff: 500 * time.Millisecond,
+		convoyGate: func(convoyID string) (*beads.ConvoyGate, error) {
+			// Convoys live in town beads (rig is at ~/gt/<rigname>, town is ~/gt)
+			return beads.New(filepath.Dir(r.Path)).ConvoyGate(convoyID)
+		},
+		showIssues: beadsClient.ShowMany,
+		escalate: func(subject, body string) error {
+			return router.Send(&mail.Message{
+				From:     r.Name + "/refinery",
+				To:       "mayor/",
+				Subject:  subject,
+				Priority: mail.PriorityHigh,
+				Type:     mail.TypeNotification,
+				Body:     body,
+			})
+		},
+		workerAlive: func(polecat string) bool {
+			alive, _ := tmux.NewTmux().HasSession(session.PolecatSessionName(session.PrefixFor(r.Name), polecat))
+			return alive
+		},
 	}
 }
 
@@ -291,6 +328,10 @@ func (e *Engineer) LoadConfig() error {
 		OnConflict           *string                    `json:"on_conflict"`
 		RunTests             *bool                      `json:"run_tests"`
 		TestCommand          *string                    `json:"test_command"`
+		SetupCommand         *string                    `json:"setup_command"`
+		TypecheckCommand     *string                    `json:"typecheck_command"`
+		LintCommand          *string                    `json:"lint_command"`
+		BuildCommand         *string                    `json:"build_command"`
 		DeleteMergedBranches *bool                      `json:"delete_merged_branches"`
 		RetryFlakyTests      *int                       `json:"retry_flaky_tests"`
 		PollInterval         *string                    `json:"poll_interval"`
@@ -317,6 +358,18 @@ func (e *Engineer) LoadConfig() error {
 	if mqRaw.TestCommand != nil {
 		e.config.TestCommand = *mqRaw.TestCommand
 	}
+	if mqRaw.SetupCommand != nil {
+		e.config.SetupCommand = *mqRaw.SetupCommand
+	}
+	if mqRaw.TypecheckCommand != nil {
+		e.config.TypecheckCommand = *mqRaw.TypecheckCommand
+	}
+	if mqRaw.LintCommand != nil {
+		e.config.LintCommand = *mqRaw.LintCommand
+	}
+	if mqRaw.BuildCommand != nil {
+		e.config.BuildCommand = *mqRaw.BuildCommand
+	}
 	if mqRaw.DeleteMergedBranches != nil {
 		e.config.DeleteMergedBranches = *mqRaw.DeleteMergedBranches
 	}
@@ -388,7 +441,15 @@ type ProcessResult struct {
 	Error       string
 	Conflict    bool
 	TestsFailed bool
-	SlotTimeout bool // Merge slot contention timeout (distinct from build/test failure)
+	SlotTimeout bool   // Merge slot contention timeout (distinct from build/test failure)
+	Log         string // Output of the failing gates or tests, mailed to the author on bounce
+
+	// Checks are the gates, check commands, and tests that ran, in order.
+	Checks []GateResult
+
+	// ConflictFiles lists the conflicting files and their hunks when
+	// Conflict is set.
+	ConflictFiles []git.ConflictFile
 }
 
 // doMerge performs the actual git merge operation.
@@ -426,7 +487,7 @@ func (e *Engineer) doMerge(ctx context.Context, branch, target, sourceIssue stri
 
 	// Step 3: Check for merge conflicts (using local branch)
 	_, _ = fmt.Fprintf(e.output, "[Engineer] Checking for conflicts...\n")
-	conflicts, err := e.git.CheckConflicts(branch, target)
+	conflicts, err := e.git.CheckConflictDetails(branch, target)
 	if err != nil {
 		return ProcessResult{
 			Success:  false,
@@ -436,9 +497,10 @@ func (e *Engineer) doMerge(ctx context.Context, branch, target, sourceIssue stri
 	}
 	if len(conflicts) > 0 {
 		return ProcessResult{
-			Success:  false,
-			Conflict: true,
-			Error:    fmt.Sprintf("merge conflicts in: %v", conflicts),
+			Success:       false,
+			Conflict:      true,
+			Error:         fmt.Sprintf("merge conflicts in: %v", conflictPaths(conflicts)),
+			ConflictFiles: conflicts,
 		}
 	}
 
@@ -472,33 +534,16 @@ func (e *Engineer) doMerge(ctx context.Context, branch, target, sourceIssue stri
 		_, _ = fmt.Fprintf(e.output, "[Engineer] Pushed %d submodule(s)\n", len(subChanges))
 	}
 
-	// Step 4: Run quality gates (or legacy tests) if configured.
-	// Phase 3 fast-path: if skipGates is true (pre-verified MR with matching base),
-	// skip all gate execution — the polecat already ran gates after rebasing.
-	shouldSkipGates := len(skipGates) > 0 && skipGates[0]
-	if shouldSkipGates {
-		_, _ = fmt.Fprintln(e.output, "[Engineer] Skipping gates (pre-verified by polecat)")
-	} else if len(e.config.Gates) > 0 {
-		// New gates system: run configured quality gates
-		gateResult := e.runGates(ctx)
-		if !gateResult.Success {
-			return gateResult
-		}
-	} else if e.config.RunTests && e.config.TestCommand != "" {
-		// Legacy test command path (backward compatible)
-		_, _ = fmt.Fprintf(e.output, "[Engineer] Running tests: %s\n", e.config.TestCommand)
-		result := e.runTests(ctx)
-		if !result.Success {
-			return ProcessResult{
-				Success:     false,
-				TestsFailed: true,
-				Error:       result.Error,
-			}
+	// Remember the target tip so a failed gate run can undo the local merge.
+	baseSHA, err := e.git.Rev("HEAD")
+	if err != nil {
+		return ProcessResult{
+			Success: false,
+			Error:   fmt.Sprintf("failed to get %s SHA: %v", target, err),
 		}
-		_, _ = fmt.Fprintln(e.output, "[Engineer] Tests passed")
 	}
 
-	// Step 5: Perform the actual merge using squash merge
+	// Step 4: Perform the actual merge using squash merge
 	// Get the original commit message from the polecat branch to preserve the
 	// conventional commit format (feat:/fix:) instead of creating redundant merge commits
 	originalMsg, err := e.git.GetBranchCommitMessage(branch)
@@ -514,13 +559,14 @@ func (e *Engineer) doMerge(ctx context.Context, branch, target, sourceIssue stri
 	if err := e.git.MergeSquash(branch, originalMsg); err != nil {
 		// ZFC: Use git's porcelain output to detect conflicts instead of parsing stderr.
 		// GetConflictingFiles() uses `git diff --diff-filter=U` which is proper.
-		conflicts, conflictErr := e.git.GetConflictingFiles()
+		conflicts, conflictErr := e.git.GetConflictDetails()
 		if conflictErr == nil && len(conflicts) > 0 {
 			_ = e.git.AbortMerge()
 			return ProcessResult{
-				Success:  false,
-				Conflict: true,
-				Error:    "merge conflict during actual merge",
+				Success:       false,
+				Conflict:      true,
+				Error:         "merge conflict during actual merge",
+				ConflictFiles: conflicts,
 			}
 		}
 		// Non-conflict failure: still need to abort to clean up dirty merge state
@@ -531,6 +577,24 @@ func (e *Engineer) doMerge(ctx context.Context, branch, target, sourceIssue stri
 		}
 	}
 
+	// Step 5: Run quality gates (or legacy tests) if configured, on the merged
+	// result — the target with this MR applied — so only what was tested lands.
+	// Phase 3 fast-path: if skipGates is true (pre-verified MR with matching base),
+	// skip all gate execution — the polecat already ran gates after rebasing.
+	shouldSkipGates := len(skipGates) > 0 && skipGates[0]
+	if shouldSkipGates {
+		_, _ = fmt.Fprintln(e.output, "[Engineer] Skipping gates (pre-verified by polecat)")
+	} else {
+		gateResult := e.runBatchGates(ctx)
+		e.attachCheckResults([]string{sourceIssue}, target, gateResult)
+		if !gateResult.Success {
+			if resetErr := e.git.ResetHard(baseSHA); resetErr != nil {
+				_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to reset %s after gate failure: %v\n", target, resetErr)
+			}
+			return gateResult
+		}
+	}
+
 	// Step 6: Get the merge commit SHA
 	mergeCommit, err := e.git.Rev("HEAD")
 	if err != nil {
@@ -672,6 +736,7 @@ func (e *Engineer) runTests(ctx context.Context) ProcessResult {
 	}
 
 	var lastErr error
+	var lastOutput string
 	for attempt := 1; attempt <= maxRetries; attempt++ {
 		if attempt > 1 {
 			_, _ = fmt.Fprintf(e.output, "[Engineer] Retrying tests (attempt %d/%d)...\n", attempt, maxRetries)
@@ -683,15 +748,16 @@ func (e *Engineer) runTests(ctx context.Context) ProcessResult {
 		_, _ = fmt.Fprintf(e.output, "[Engineer] Executing test command: %s\n", e.config.TestCommand)
 		cmd := exec.CommandContext(ctx, "sh", "-c", e.config.TestCommand) //nolint:gosec // G204: TestCommand is from trusted rig config
 		cmd.Dir = e.workDir
-		var stdout, stderr bytes.Buffer
-		cmd.Stdout = &stdout
-		cmd.Stderr = &stderr
+		var output bytes.Buffer
+		cmd.Stdout = &output
+		cmd.Stderr = &output
 
 		err := cmd.Run()
 		if err == nil {
 			return ProcessResult{Success: true}
 		}
 		lastErr = err
+		lastOutput = output.String()
 
 		// Check if context was canceled
 		if ctx.Err() != nil {
@@ -706,9 +772,24 @@ func (e *Engineer) runTests(ctx context.Context) ProcessResult {
 		Success:     false,
 		TestsFailed: true,
 		Error:       fmt.Sprintf("tests failed after %d attempts: %v", maxRetries, lastErr),
+		Log:         tailLog(lastOutput),
 	}
 }
 
+// maxFailureLogLines caps how much of a failing gate's or test run's output
+// is kept for the bounce mail; the end of the output is where the failure is.
+const maxFailureLogLines = 200
+
+// tailLog returns the last maxFailureLogLines lines of output.
+func tailLog(output string) string {
+	lines := strings.Split(strings.TrimRight(output, "\n"), "\n")
+	if len(lines) > maxFailureLogLines {
+		lines = append([]string{fmt.Sprintf("... (%d lines omitted)", len(lines)-maxFailureLogLines)},
+			lines[len(lines)-maxFailureLogLines:]...)
+	}
+	return strings.Join(lines, "\n")
+}
+
 // runGate executes a single quality gate command and returns the result.
 func (e *Engineer) runGate(ctx context.Context, name string, gate *GateConfig) GateResult {
 	start := time.Now()
@@ -732,9 +813,9 @@ func (e *Engineer) runGate(ctx context.Context, name string, gate *GateConfig) G
 
 	cmd := exec.CommandContext(gateCtx, "sh", "-c", gate.Cmd) //nolint:gosec // G204: Gate commands are from trusted rig config
 	cmd.Dir = e.workDir
-	var stdout, stderr bytes.Buffer
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
+	var stdout, stderr, output bytes.Buffer
+	cmd.Stdout = io.MultiWriter(&stdout, &output)
+	cmd.Stderr = io.MultiWriter(&stderr, &output)
 
 	err := cmd.Run()
 	elapsed := time.Since(start)
@@ -763,6 +844,7 @@ func (e *Engineer) runGate(ctx context.Context, name string, gate *GateConfig) G
 		Name:    name,
 		Success: false,
 		Error:   errMsg,
+		Output:  tailLog(output.String()),
 		Elapsed: elapsed,
 	}
 }
@@ -812,13 +894,14 @@ func (e *Engineer) runGates(ctx context.Context) ProcessResult {
 	}
 
 	// Report results
-	var failures []string
+	var failures, logs []string
 	for _, r := range results {
 		if r.Success {
 			_, _ = fmt.Fprintf(e.output, "[Engineer] Gate %q: passed (%v)\n", r.Name, r.Elapsed.Truncate(time.Millisecond))
 		} else {
 			_, _ = fmt.Fprintf(e.output, "[Engineer] Gate %q: FAILED (%v) - %s\n", r.Name, r.Elapsed.Truncate(time.Millisecond), r.Error)
 			failures = append(failures, fmt.Sprintf("%s: %s", r.Name, r.Error))
+			logs = append(logs, fmt.Sprintf("=== gate %s (%s) ===\n%s", r.Name, gates[r.Name].Cmd, r.Output))
 		}
 	}
 
@@ -827,11 +910,13 @@ func (e *Engineer) runGates(ctx context.Context) ProcessResult {
 			Success:     false,
 			TestsFailed: true,
 			Error:       fmt.Sprintf("quality gates failed: %s", strings.Join(failures, "; ")),
+			Log:         strings.Join(logs, "\n\n"),
+			Checks:      results,
 		}
 	}
 
 	_, _ = fmt.Fprintln(e.output, "[Engineer] All quality gates passed")
-	return ProcessResult{Success: true}
+	return ProcessResult{Success: true, Checks: results}
 }
 
 // syncCrewWorkspaces pulls latest changes to all crew workspaces.
@@ -989,6 +1074,7 @@ func (e *Engineer) HandleMRInfoSuccess(mr *MRInfo, result ProcessResult) {
 
 // HandleMRInfoFailure handles a failed merge from MRInfo.
 // For conflicts, creates a resolution task and blocks the MR until resolved.
+// For gate and test failures, bounces the MR back to its author (see bounceMR).
 // For slot timeouts, the MR stays in queue for automatic retry without notifying polecats.
 // This enables non-blocking delegation: the queue continues to the next MR.
 func (e *Engineer) HandleMRInfoFailure(mr *MRInfo, result ProcessResult) {
@@ -1001,51 +1087,215 @@ func (e *Engineer) HandleMRInfoFailure(mr *MRInfo, result ProcessResult) {
 		return
 	}
 
-	// Nudge polecat directly about the merge failure.
-	// Previously sent MERGE_FAILED mail to witness (which relayed to polecat),
-	// but that created permanent Dolt commits for routine protocol signals.
-	// The witness discovers merge failures from MR bead status during patrol.
-	failureType := "build"
-	if result.Conflict {
-		failureType = "conflict"
-	} else if result.TestsFailed {
-		failureType = "tests"
-	}
-	polecatName := strings.TrimPrefix(mr.Worker, "polecats/")
-	nudgeTarget := fmt.Sprintf("%s/%s", e.rig.Name, polecatName)
-	nudgeMsg := fmt.Sprintf("MERGE_FAILED: branch=%s issue=%s type=%s error=%s — fix and resubmit with 'gt done'",
-		mr.Branch, mr.SourceIssue, failureType, result.Error)
-	nudgeCmd := exec.Command("gt", "nudge", nudgeTarget, nudgeMsg)
-	nudgeCmd.Dir = e.workDir
-	if err := nudgeCmd.Run(); err != nil {
-		_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to nudge %s about merge failure: %v\n", polecatName, err)
+	// Each failure reaches the author once. Conflicts get a conflict bead on
+	// the author's hook and a nudge telling them how to resume. Gate and test
+	// failures are the author's to fix: the MR is bounced back to them with
+	// the failure log by mail (whose delivery nudges them) rather than
+	// retried every cycle. Other failures get a plain MERGE_FAILED nudge.
+	switch {
+	case result.Conflict:
+		e.handleMRConflict(mr, result)
+	case result.TestsFailed:
+		e.bounceMR(mr, result)
+		_, _ = fmt.Fprintf(e.output, "[Engineer] ✗ Bounced: %s - %s\n", mr.ID, result.Error)
+		return
+	default:
+		e.nudgeMergeFailure(mr, result)
+	}
+
+	// Log the failure - MR stays in queue but may be blocked
+	_, _ = fmt.Fprintf(e.output, "[Engineer] ✗ Failed: %s - %s\n", mr.ID, result.Error)
+	if mr.BlockedBy != "" {
+		_, _ = fmt.Fprintln(e.output, "[Engineer] MR blocked pending conflict resolution - queue continues to next MR")
 	} else {
-		_, _ = fmt.Fprintf(e.output, "[Engineer] Nudged %s about merge failure (%s)\n", polecatName, failureType)
+		_, _ = fmt.Fprintln(e.output, "[Engineer] MR remains in queue for retry")
 	}
+}
 
-	// If this was a conflict, create a conflict-resolution task for dispatch
-	// and block the MR until the task is resolved (non-blocking delegation)
-	if result.Conflict {
-		taskID, err := e.createConflictResolutionTaskForMR(mr, result)
-		if err != nil {
-			_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to create conflict resolution task: %v\n", err)
-		} else if taskID != "" {
-			// Block the MR on the conflict resolution task using beads dependency
-			// When the task closes, the MR unblocks and re-enters the ready queue
-			if err := e.beads.AddDependency(mr.ID, taskID); err != nil {
-				_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to block MR on task: %v\n", err)
+// nudgeMergeFailure nudges the author of an MR about a merge failure that
+// is neither a conflict nor a gate failure (those are bounced by mail).
+// Previously sent MERGE_FAILED mail to witness (which relayed to polecat),
+// but that created permanent Dolt commits for routine protocol signals.
+// The witness discovers merge failures from MR bead status during patrol.
+func (e *Engineer) nudgeMergeFailure(mr *MRInfo, result ProcessResult) {
+	const failureType = "build"
+	nudgeMsg, err := templates.RenderTownNudge(filepath.Dir(e.rig.Path), templates.NudgeRefineryBounce, map[string]string{
+		"Branch":      mr.Branch,
+		"Issue":       mr.SourceIssue,
+		"FailureType": failureType,
+		"Error":       result.Error,
+	})
+	if err != nil {
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: rendering merge failure nudge: %v\n", err)
+		return
+	}
+	if e.nudgeWorker(mr, nudgeMsg) {
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Nudged %s about merge failure (%s)\n", e.workerAddress(mr), failureType)
+	}
+}
+
+// handleMRConflict deals with an MR that conflicts with its target: it
+// creates a conflict bead with the conflicting files and hunks, blocks the MR
+// on it, puts it on the author's hook, and nudges the author with a summary
+// and the commands to resume. If the author's session is gone, the bead is
+// left unassigned for dispatch instead, since nothing would work a dead
+// polecat's hook. If the bead can't be created (or is deferred because
+// another conflict holds the merge slot), a live author is still nudged.
+func (e *Engineer) handleMRConflict(mr *MRInfo, result ProcessResult) {
+	taskID, err := e.createConflictResolutionTaskForMR(mr, result)
+	if err != nil {
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to create conflict resolution task: %v\n", err)
+	}
+
+	hooked := false
+	if taskID != "" {
+		// Block the MR on the conflict resolution task using beads dependency
+		// When the task closes, the MR unblocks and re-enters the ready queue
+		if err := e.beads.AddDependency(mr.ID, taskID); err != nil {
+			_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to block MR on task: %v\n", err)
+		} else {
+			mr.BlockedBy = taskID
+			_, _ = fmt.Fprintf(e.output, "[Engineer] MR %s blocked on conflict task %s (non-blocking delegation)\n", mr.ID, taskID)
+		}
+
+		// The author knows the change best, so the conflict is theirs. Without
+		// a known, running author the task stays unassigned for dispatch.
+		if agent := e.conflictAssignee(mr); agent != "" {
+			status := beads.StatusHooked
+			if err := e.beads.Update(taskID, beads.UpdateOptions{Status: &status, Assignee: &agent}); err != nil {
+				_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to hook %s to %s: %v\n", taskID, agent, err)
 			} else {
-				_, _ = fmt.Fprintf(e.output, "[Engineer] MR %s blocked on conflict task %s (non-blocking delegation)\n", mr.ID, taskID)
+				hooked = true
+				_, _ = fmt.Fprintf(e.output, "[Engineer] Hooked conflict task %s to %s\n", taskID, agent)
 			}
+		} else {
+			_, _ = fmt.Fprintf(e.output, "[Engineer] No running author for %s; conflict task %s left for dispatch\n", mr.ID, taskID)
 		}
 	}
 
-	// Log the failure - MR stays in queue but may be blocked
-	_, _ = fmt.Fprintf(e.output, "[Engineer] ✗ Failed: %s - %s\n", mr.ID, result.Error)
-	if mr.BlockedBy != "" {
-		_, _ = fmt.Fprintln(e.output, "[Engineer] MR blocked pending conflict resolution - queue continues to next MR")
+	if !e.workerRunning(mr) {
+		return
+	}
+	if e.nudgeWorker(mr, conflictNudgeMessage(mr, result, taskID, hooked)) {
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Nudged %s about merge conflict\n", e.workerAddress(mr))
+	}
+}
+
+// conflictNudgeMessage builds the nudge sent to an MR's author when it
+// conflicts: what conflicts, where the conflict bead is, and how to resume.
+func conflictNudgeMessage(mr *MRInfo, result ProcessResult, taskID string, hooked bool) string {
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "MERGE_CONFLICT: branch=%s issue=%s conflicts with %s", mr.Branch, mr.SourceIssue, mr.Target)
+	if paths := conflictPaths(result.ConflictFiles); len(paths) > 0 {
+		const maxListed = 5
+		if len(paths) > maxListed {
+			paths = append(paths[:maxListed:maxListed], fmt.Sprintf("+%d more", len(paths)-maxListed))
+		}
+		fmt.Fprintf(&msg, " in %d file(s): %s", len(result.ConflictFiles), strings.Join(paths, ", "))
+	}
+	msg.WriteString(".")
+	switch {
+	case hooked:
+		fmt.Fprintf(&msg, " Conflict bead %s with the hunks is on your hook (bd show %s).", taskID, taskID)
+	case taskID != "":
+		fmt.Fprintf(&msg, " Conflict bead: %s (bd show %s).", taskID, taskID)
+	}
+	fmt.Fprintf(&msg, " To resume: git checkout %s && git fetch origin && git rebase origin/%s, resolve, git add . && git rebase --continue, git push -f",
+		mr.Branch, mr.Target)
+	if taskID != "" {
+		fmt.Fprintf(&msg, ", then bd close %s. The refinery retries the merge once the bead closes.", taskID)
 	} else {
-		_, _ = fmt.Fprintln(e.output, "[Engineer] MR remains in queue for retry")
+		msg.WriteString(". The refinery retries the merge on its next pass.")
+	}
+	return msg.String()
+}
+
+// conflictPaths returns the paths of conflicting files.
+func conflictPaths(files []git.ConflictFile) []string {
+	paths := make([]string, len(files))
+	for i, f := range files {
+		paths[i] = f.Path
+	}
+	return paths
+}
+
+// nudgeWorker nudges the author of an MR, reporting whether it went through.
+func (e *Engineer) nudgeWorker(mr *MRInfo, msg string) bool {
+	if mr.Worker == "" {
+		return false
+	}
+	target := e.workerAddress(mr)
+	nudgeCmd := exec.Command("gt", "nudge", target, msg)
+	nudgeCmd.Dir = e.workDir
+	if err := nudgeCmd.Run(); err != nil {
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to nudge %s: %v\n", target, err)
+		return false
+	}
+	return true
+}
+
+// workerHookAgent returns the agent whose hook work for an MR's author goes
+// on, or "" if the MR has no known author.
+func (e *Engineer) workerHookAgent(mr *MRInfo) string {
+	name := strings.TrimPrefix(mr.Worker, "polecats/")
+	if name == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/polecats/%s", e.rig.Name, name)
+}
+
+// workerRunning reports whether an MR's author has a running session.
+func (e *Engineer) workerRunning(mr *MRInfo) bool {
+	name := strings.TrimPrefix(mr.Worker, "polecats/")
+	return name != "" && e.workerAlive != nil && e.workerAlive(name)
+}
+
+// conflictAssignee returns the agent whose hook an MR's conflict task goes
+// on: the author, if their session is running, else "".
+func (e *Engineer) conflictAssignee(mr *MRInfo) string {
+	if !e.workerRunning(mr) {
+		return ""
+	}
+	return e.workerHookAgent(mr)
+}
+
+// workerAddress returns the address of the agent that submitted an MR.
+func (e *Engineer) workerAddress(mr *MRInfo) string {
+	return fmt.Sprintf("%s/%s", e.rig.Name, strings.TrimPrefix(mr.Worker, "polecats/"))
+}
+
+// bounceMR sends an MR that failed its quality gates back to its author: the
+// failure log goes to them as mail, and the MR is closed as rejected so it
+// leaves the queue until they fix the branch and resubmit with 'gt done'.
+func (e *Engineer) bounceMR(mr *MRInfo, result ProcessResult) {
+	if mr.Worker != "" {
+		var body strings.Builder
+		fmt.Fprintf(&body, "Your merge request %s did not pass the merge queue's quality gates.\n\n", mr.ID)
+		fmt.Fprintf(&body, "Branch: %s\nTarget: %s\n", mr.Branch, mr.Target)
+		if mr.SourceIssue != "" {
+			fmt.Fprintf(&body, "Issue:  %s\n", mr.SourceIssue)
+		}
+		fmt.Fprintf(&body, "Error:  %s\n\n", result.Error)
+		fmt.Fprintf(&body, "The gates ran on %s with your branch merged in. Fix the failures, rebase onto %s, and resubmit with 'gt done'.\n", mr.Target, mr.Target)
+		if result.Log != "" {
+			fmt.Fprintf(&body, "\n--- failure log ---\n%s\n", result.Log)
+		}
+
+		msg := mail.NewMessage(e.rig.Name+"/refinery", e.workerAddress(mr),
+			fmt.Sprintf("MERGE_BOUNCED: %s failed quality gates", mr.Branch), body.String())
+		msg.Type = mail.TypeTask
+		msg.Priority = mail.PriorityHigh
+		if err := e.router.Send(msg); err != nil {
+			_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to mail failure log to %s: %v\n", msg.To, err)
+		} else {
+			_, _ = fmt.Fprintf(e.output, "[Engineer] Mailed failure log to %s\n", msg.To)
+		}
+	}
+
+	if mr.ID != "" {
+		if err := e.beads.CloseWithReason("rejected: quality gates failed", mr.ID); err != nil {
+			_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to close bounced MR %s: %v\n", mr.ID, err)
+		}
 	}
 }
 
@@ -1059,14 +1309,15 @@ func (e *Engineer) HandleMRInfoFailure(mr *MRInfo, result ProcessResult) {
 //	Type: task
 //	Priority: inherit from original (ZFC: agent decides boost strategy)
 //	Parent: original MR bead
-//	Description: metadata including branch, conflict SHA, etc.
+//	Description: metadata including branch, conflict SHA, etc., and the
+//	             conflicting files with their hunks
 //
 // Merge Slot Integration:
 // Before creating a conflict resolution task, we acquire the merge-slot for this rig.
 // This serializes conflict resolution - only one polecat can resolve conflicts at a time.
 // If the slot is already held, we skip creating the task and let the MR stay in queue.
 // When the current resolution completes and merges, the slot is released.
-func (e *Engineer) createConflictResolutionTaskForMR(mr *MRInfo, _ ProcessResult) (string, error) { // result unused but kept for future merge diagnostics
+func (e *Engineer) createConflictResolutionTaskForMR(mr *MRInfo, result ProcessResult) (string, error) {
 	// === MERGE SLOT GATE: Serialize conflict resolution ===
 	// Ensure merge slot exists (idempotent)
 	slotID, err := e.mergeSlotEnsureExists()
@@ -1149,6 +1400,7 @@ The Refinery will automatically retry the merge after you force-push.`,
 		mr.Branch,
 		mr.Target,
 	)
+	description += formatConflictFiles(result.ConflictFiles)
 
 	// Create the conflict resolution task
 	taskTitle := fmt.Sprintf("Resolve merge conflicts: %s", originalTitle)
@@ -1172,6 +1424,27 @@ The Refinery will automatically retry the merge after you force-push.`,
 	return task.ID, nil
 }
 
+// formatConflictFiles renders the conflicting files and hunks for a
+// conflict resolution task's description, or "" if there are none.
+func formatConflictFiles(files []git.ConflictFile) string {
+	if len(files) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("\n\n## Conflicts\n")
+	for _, f := range files {
+		fmt.Fprintf(&b, "\n### %s\n", f.Path)
+		if len(f.Hunks) == 0 {
+			b.WriteString("(no conflict markers: binary, rename, or delete/modify conflict)\n")
+			continue
+		}
+		for _, hunk := range f.Hunks {
+			fmt.Fprintf(&b, "```\n%s\n```\n", hunk)
+		}
+	}
+	return b.String()
+}
+
 // IsBeadOpen checks if a bead is still open (not closed).
 // This is used as a status checker to filter blocked MRs.
 func (e *Engineer) IsBeadOpen(beadID string) (bool, error) {
@@ -1250,9 +1523,114 @@ func (e *Engineer) firstOpenBlocker(issue *beads.Issue) string {
 	return ""
 }
 
+// convoyGateHold reports why an MR in a merge-gated convoy must wait: the
+// convoy members that aren't ready to land yet. It returns "" if the MR may
+// merge. Gates are looked up once per convoy per queue scan via cache. A
+// convoy that no longer exists doesn't hold anything; one that can't be
+// read holds its MRs until it can. Members that can't be found will never
+// be ready, so with escalate the mayor is told about them (once per set of
+// missing members) rather than the MRs waiting silently forever.
+func (e *Engineer) convoyGateHold(convoyID string, cache map[string]string, escalate bool) string {
+	if convoyID == "" || e.convoyGate == nil {
+		return ""
+	}
+	if hold, ok := cache[convoyID]; ok {
+		return hold
+	}
+	var hold string
+	gate, err := e.convoyGate(convoyID)
+	switch {
+	case errors.Is(err, beads.ErrNotFound):
+	case err != nil:
+		hold = fmt.Sprintf("convoy gate unreadable: %v", err)
+	case !gate.Open():
+		hold = "waiting on " + strings.Join(gate.Waiting(), ", ")
+		if missing := gate.Missing(); len(missing) > 0 {
+			hold = fmt.Sprintf("gate failed: member(s) %s missing", strings.Join(missing, ", "))
+			if escalate {
+				e.escalateMissingConvoyMembers(convoyID, missing)
+			}
+		}
+	}
+	cache[convoyID] = hold
+	return hold
+}
+
+// convoyGateEscalationsPath records which missing convoy members the mayor
+// has been told about, so each queue scan doesn't mail again.
+func (e *Engineer) convoyGateEscalationsPath() string {
+	return filepath.Join(e.rig.Path, ".runtime", "convoy-gate-escalations.json")
+}
+
+// escalateMissingConvoyMembers mails the mayor that a gated convoy holds
+// merges on members that can't be found, unless it already has for the same
+// members.
+func (e *Engineer) escalateMissingConvoyMembers(convoyID string, missing []string) {
+	if e.escalate == nil || e.rig == nil {
+		return
+	}
+	path := e.convoyGateEscalationsPath()
+	sent := make(map[string]string) // convoy ID → missing members mailed about
+	if data, err := os.ReadFile(path); err == nil {
+		_ = json.Unmarshal(data, &sent)
+	}
+	key := strings.Join(missing, ",")
+	if sent[convoyID] == key {
+		return
+	}
+
+	body := fmt.Sprintf("Convoy %s gates merges until every member is ready, but these members can't be found in any rig's beads:\n\n  %s\n\n"+
+		"They can never become ready, so the %s refinery is holding the convoy's MRs. Restore the missing beads, "+
+		"untrack them from the convoy, or ungate it with 'gt convoy gate %s off'.\n",
+		convoyID, strings.Join(missing, "\n  "), e.rig.Name, convoyID)
+	if err := e.escalate(fmt.Sprintf("CONVOY_GATE_FAILED: %s", convoyID), body); err != nil {
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to escalate missing members of convoy %s: %v\n", convoyID, err)
+		return
+	}
+	sent[convoyID] = key
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err == nil {
+		_ = util.AtomicWriteJSON(path, sent)
+	}
+}
+
+// reviewHolds reports, for each MR's source issue still awaiting review
+// (a review:<role> label set by dispatch routing rules), which review it
+// awaits. Source issues are looked up in one batch per queue scan; if they
+// can't be read, every MR with a source issue is held until they can.
+func (e *Engineer) reviewHolds(issues []*beads.Issue) map[string]string {
+	holds := make(map[string]string)
+	if e.showIssues == nil {
+		return holds
+	}
+	var ids []string
+	for _, issue := range issues {
+		if fields := beads.ParseMRFields(issue); fields != nil && fields.SourceIssue != "" {
+			ids = append(ids, fields.SourceIssue)
+		}
+	}
+	if len(ids) == 0 {
+		return holds
+	}
+	sources, err := e.showIssues(ids)
+	if err != nil {
+		for _, id := range ids {
+			holds[id] = fmt.Sprintf("review status unreadable: %v", err)
+		}
+		return holds
+	}
+	for id, source := range sources {
+		if roles := beads.PendingReviews(source); len(roles) > 0 {
+			holds[id] = "awaiting review by " + strings.Join(roles, ", ")
+		}
+	}
+	return holds
+}
+
 // ListReadyMRs returns MRs that are ready for processing:
 // - Not claimed by another worker (checked via assignee field)
 // - Not blocked by an open task (checked via firstOpenBlocker)
+// - Not held by a merge-gated convoy (checked via convoyGateHold)
+// - Not awaiting review of the source issue (checked via reviewHolds)
 // Sorted by priority (highest first).
 //
 // Uses bd list instead of bd ready because MRs are ephemeral beads and
@@ -1273,6 +1651,8 @@ func (e *Engineer) ListReadyMRs() ([]*MRInfo, error) {
 
 	// Convert beads issues to MRInfo
 	var mrs []*MRInfo
+	gateHolds := make(map[string]string)
+	reviewHolds := e.reviewHolds(issues)
 	for _, issue := range issues {
 		// Skip closed MRs (workaround for bd list not respecting --status filter)
 		if issue.Status != "open" {
@@ -1313,14 +1693,26 @@ func (e *Engineer) ListReadyMRs() ([]*MRInfo, error) {
 				issue.ID, issue.Assignee, issue.UpdatedAt)
 		}
 
+		// Skip MRs whose convoy gates merges until every member is ready.
+		if hold := e.convoyGateHold(fields.ConvoyID, gateHolds, true); hold != "" {
+			_, _ = fmt.Fprintf(e.output, "[Engineer] Holding MR %s for convoy %s: %s\n", issue.ID, fields.ConvoyID, hold)
+			continue
+		}
+
+		// Skip MRs whose source issue a routing rule sent for review.
+		if hold := reviewHolds[fields.SourceIssue]; hold != "" {
+			_, _ = fmt.Fprintf(e.output, "[Engineer] Holding MR %s for %s: %s\n", issue.ID, fields.SourceIssue, hold)
+			continue
+		}
+
 		mrs = append(mrs, issueToMRInfo(issue, fields))
 	}
 
 	return mrs, nil
 }
 
-// ListBlockedMRs returns MRs that are blocked by open tasks.
-// Useful for monitoring/reporting.
+// ListBlockedMRs returns MRs that are blocked by open tasks, held by a
+// merge-gated convoy, or awaiting review. Useful for monitoring/reporting.
 //
 // This queries beads for blocked merge-request issues.
 func (e *Engineer) ListBlockedMRs() ([]*MRInfo, error) {
@@ -1334,23 +1726,28 @@ func (e *Engineer) ListBlockedMRs() ([]*MRInfo, error) {
 		return nil, fmt.Errorf("querying beads for merge-requests: %w", err)
 	}
 
-	// Filter for blocked issues (those with open blockers)
+	// Filter for blocked issues (those with open blockers, held by a
+	// merge-gated convoy, reported as blocked by the convoy, or awaiting
+	// review, reported as blocked by the source issue)
 	var mrs []*MRInfo
+	gateHolds := make(map[string]string)
+	reviewHolds := e.reviewHolds(issues)
 	for _, issue := range issues {
-		// Skip if not blocked
-		if len(issue.BlockedBy) == 0 {
+		fields := beads.ParseMRFields(issue)
+		if fields == nil {
 			continue
 		}
 
 		// Check if any blocker is still open
 		blockedBy := e.firstOpenBlocker(issue)
-		if blockedBy == "" {
-			continue // All blockers are closed, not blocked
+		if blockedBy == "" && e.convoyGateHold(fields.ConvoyID, gateHolds, false) != "" {
+			blockedBy = fields.ConvoyID
 		}
-
-		fields := beads.ParseMRFields(issue)
-		if fields == nil {
-			continue
+		if blockedBy == "" && reviewHolds[fields.SourceIssue] != "" {
+			blockedBy = fields.SourceIssue
+		}
+		if blockedBy == "" {
+			continue // All blockers are closed and no gate holds it
 		}
 
 		mr := issueToMRInfo(issue, fields)
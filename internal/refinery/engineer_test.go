@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/steveyegge/gastown/internal/beads"
+	gitpkg "github.com/steveyegge/gastown/internal/git"
 	"github.com/steveyegge/gastown/internal/rig"
 )
 
@@ -395,6 +396,71 @@ func TestRunGate_Failure(t *testing.T) {
 	}
 }
 
+func TestRunGate_FailureCapturesOutput(t *testing.T) {
+	r := &rig.Rig{Name: "test-rig", Path: t.TempDir()}
+	e := NewEngineer(r)
+	e.workDir = t.TempDir()
+	e.output = io.Discard
+
+	result := e.runGate(context.Background(), "lint", &GateConfig{
+		Cmd: "echo 'main.go:3: unused variable'; exit 1",
+	})
+
+	if result.Success {
+		t.Fatal("expected failure")
+	}
+	if !strings.Contains(result.Output, "unused variable") {
+		t.Errorf("expected gate output in result, got %q", result.Output)
+	}
+}
+
+func TestTailLog(t *testing.T) {
+	var b strings.Builder
+	for i := 1; i <= maxFailureLogLines+50; i++ {
+		fmt.Fprintf(&b, "line %d\n", i)
+	}
+
+	got := tailLog(b.String())
+	lines := strings.Split(got, "\n")
+	if !strings.Contains(lines[0], "50 lines omitted") {
+		t.Errorf("expected truncation notice, got %q", lines[0])
+	}
+	if lines[len(lines)-1] != fmt.Sprintf("line %d", maxFailureLogLines+50) {
+		t.Errorf("expected last line kept, got %q", lines[len(lines)-1])
+	}
+	if tailLog("short\n") != "short" {
+		t.Errorf("short output should be kept as is, got %q", tailLog("short\n"))
+	}
+}
+
+func TestDoMerge_GatesRunOnMergedResult(t *testing.T) {
+	workDir, g, cleanup := testGitRepo(t)
+	defer cleanup()
+
+	createFeatureBranch(t, workDir, "polecat/nux", "FAIL_MARKER", "breaks the build\n")
+
+	e := newTestEngineer(t, workDir, g)
+	e.config.Gates = map[string]*GateConfig{
+		"check": {Cmd: fmt.Sprintf("test ! -f %s/FAIL_MARKER || { echo 'FAIL_MARKER present'; exit 1; }", workDir)},
+	}
+	before := run(t, workDir, "git", "rev-parse", "main")
+
+	result := e.doMerge(context.Background(), "polecat/nux", "main", "")
+
+	if result.Success || !result.TestsFailed {
+		t.Fatalf("expected gate failure, got %+v", result)
+	}
+	if !strings.Contains(result.Log, "FAIL_MARKER present") {
+		t.Errorf("expected failure log in result, got %q", result.Log)
+	}
+	if after := run(t, workDir, "git", "rev-parse", "main"); after != before {
+		t.Errorf("main should be reset after a gate failure: %s -> %s", before, after)
+	}
+	if remote := run(t, workDir, "git", "rev-parse", "origin/main"); remote != before {
+		t.Errorf("origin/main should not be pushed: %s -> %s", before, remote)
+	}
+}
+
 func TestRunGate_EmptyCmd(t *testing.T) {
 	r := &rig.Rig{Name: "test-rig", Path: t.TempDir()}
 	e := NewEngineer(r)
@@ -804,3 +870,188 @@ func TestIsClaimStale(t *testing.T) {
 		})
 	}
 }
+
+func TestDoMerge_ConflictReportsHunks(t *testing.T) {
+	workDir, g, cleanup := testGitRepo(t)
+	defer cleanup()
+
+	createConflictingBranch(t, workDir, "polecat/nux", "README.md", "# From nux\n")
+	writeFile(t, workDir, "README.md", "# From main\n")
+	run(t, workDir, "git", "commit", "-am", "main changes readme")
+	run(t, workDir, "git", "push", "origin", "main")
+
+	e := newTestEngineer(t, workDir, g)
+	result := e.doMerge(context.Background(), "polecat/nux", "main", "")
+
+	if !result.Conflict {
+		t.Fatalf("expected conflict, got %+v", result)
+	}
+	if len(result.ConflictFiles) != 1 || result.ConflictFiles[0].Path != "README.md" {
+		t.Fatalf("expected README.md conflict, got %+v", result.ConflictFiles)
+	}
+	desc := formatConflictFiles(result.ConflictFiles)
+	if !strings.Contains(desc, "### README.md") || !strings.Contains(desc, "# From nux") || !strings.Contains(desc, "# From main") {
+		t.Errorf("conflict description should show the file and both sides, got:\n%s", desc)
+	}
+}
+
+func TestConflictNudgeMessage(t *testing.T) {
+	mr := &MRInfo{Branch: "polecat/gastown/nux/gt-abc", Target: "main", SourceIssue: "gt-abc", Worker: "nux"}
+	result := ProcessResult{Conflict: true}
+	for _, p := range []string{"a.go", "b.go", "c.go", "d.go", "e.go", "f.go"} {
+		result.ConflictFiles = append(result.ConflictFiles, gitpkg.ConflictFile{Path: p})
+	}
+
+	msg := conflictNudgeMessage(mr, result, "gt-task1", true)
+	for _, want := range []string{
+		"MERGE_CONFLICT: branch=polecat/gastown/nux/gt-abc",
+		"in 6 file(s): a.go, b.go, c.go, d.go, e.go, +1 more",
+		"gt-task1 with the hunks is on your hook",
+		"git rebase origin/main",
+		"bd close gt-task1",
+	} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("nudge missing %q:\n%s", want, msg)
+		}
+	}
+
+	msg = conflictNudgeMessage(mr, result, "", false)
+	if strings.Contains(msg, "bd close") || !strings.Contains(msg, "retries the merge on its next pass") {
+		t.Errorf("nudge without a conflict bead should not mention one:\n%s", msg)
+	}
+}
+
+func TestWorkerHookAgent(t *testing.T) {
+	e := NewEngineer(&rig.Rig{Name: "gastown", Path: t.TempDir()})
+	if got := e.workerHookAgent(&MRInfo{Worker: "nux"}); got != "gastown/polecats/nux" {
+		t.Errorf("workerHookAgent() = %q", got)
+	}
+	if got := e.workerHookAgent(&MRInfo{Worker: "polecats/nux"}); got != "gastown/polecats/nux" {
+		t.Errorf("workerHookAgent() with prefix = %q", got)
+	}
+	if got := e.workerHookAgent(&MRInfo{}); got != "" {
+		t.Errorf("workerHookAgent() without worker = %q, want empty", got)
+	}
+}
+
+func TestConflictAssignee(t *testing.T) {
+	e := NewEngineer(&rig.Rig{Name: "gastown", Path: t.TempDir()})
+	e.workerAlive = func(polecat string) bool { return polecat == "nux" }
+	if got := e.conflictAssignee(&MRInfo{Worker: "polecats/nux"}); got != "gastown/polecats/nux" {
+		t.Errorf("conflictAssignee() for a running author = %q", got)
+	}
+	if got := e.conflictAssignee(&MRInfo{Worker: "toast"}); got != "" {
+		t.Errorf("conflictAssignee() for a dead author = %q, want empty", got)
+	}
+	if got := e.conflictAssignee(&MRInfo{}); got != "" {
+		t.Errorf("conflictAssignee() without worker = %q, want empty", got)
+	}
+}
+
+func TestConvoyGateHold(t *testing.T) {
+	lookups := 0
+	e := &Engineer{convoyGate: func(convoyID string) (*beads.ConvoyGate, error) {
+		lookups++
+		switch convoyID {
+		case "hq-cv-waiting":
+			return &beads.ConvoyGate{ConvoyID: convoyID, Gated: true, Members: []beads.ConvoyMember{
+				{ID: "gt-api", Status: "in_progress", MR: "gt-mr1"},
+				{ID: "bd-client", Status: "in_progress"},
+				{ID: "bd-docs", Status: "open"},
+			}}, nil
+		case "hq-cv-ready":
+			return &beads.ConvoyGate{ConvoyID: convoyID, Gated: true, Members: []beads.ConvoyMember{
+				{ID: "gt-api", Status: "in_progress", MR: "gt-mr1"},
+				{ID: "bd-client", Status: "closed"},
+			}}, nil
+		case "hq-cv-ungated":
+			return &beads.ConvoyGate{ConvoyID: convoyID, Members: []beads.ConvoyMember{{ID: "bd-client", Status: "open"}}}, nil
+		case "hq-cv-gone":
+			return nil, beads.ErrNotFound
+		}
+		return nil, fmt.Errorf("bd unavailable")
+	}}
+
+	cache := make(map[string]string)
+	tests := []struct {
+		convoy string
+		want   string
+	}{
+		{"", ""},
+		{"hq-cv-waiting", "waiting on bd-client, bd-docs"},
+		{"hq-cv-ready", ""},
+		{"hq-cv-ungated", ""},
+		{"hq-cv-gone", ""},
+		{"hq-cv-broken", "convoy gate unreadable: bd unavailable"},
+		{"hq-cv-waiting", "waiting on bd-client, bd-docs"},
+	}
+	for _, tt := range tests {
+		if got := e.convoyGateHold(tt.convoy, cache, false); got != tt.want {
+			t.Errorf("convoyGateHold(%q) = %q, want %q", tt.convoy, got, tt.want)
+		}
+	}
+	if lookups != 5 {
+		t.Errorf("gate looked up %d times, want 5 (once per convoy)", lookups)
+	}
+}
+
+func TestConvoyGateHoldEscalatesMissingMembers(t *testing.T) {
+	var mails []string
+	e := &Engineer{
+		rig:    &rig.Rig{Name: "gastown", Path: t.TempDir()},
+		output: io.Discard,
+		convoyGate: func(convoyID string) (*beads.ConvoyGate, error) {
+			return &beads.ConvoyGate{ConvoyID: convoyID, Gated: true, Members: []beads.ConvoyMember{
+				{ID: "gt-api", Status: "in_progress", MR: "gt-mr1"},
+				{ID: "bd-client", Status: beads.ConvoyMemberMissing},
+			}}, nil
+		},
+		escalate: func(subject, body string) error {
+			mails = append(mails, subject)
+			return nil
+		},
+	}
+
+	want := "gate failed: member(s) bd-client missing"
+	if got := e.convoyGateHold("hq-cv-broken", map[string]string{}, false); got != want {
+		t.Errorf("convoyGateHold() = %q, want %q", got, want)
+	}
+	if len(mails) != 0 {
+		t.Errorf("reporting escalated: %v", mails)
+	}
+
+	// Each queue scan uses a fresh cache; the mayor hears about it once.
+	for i := 0; i < 2; i++ {
+		if got := e.convoyGateHold("hq-cv-broken", map[string]string{}, true); got != want {
+			t.Errorf("convoyGateHold() = %q, want %q", got, want)
+		}
+	}
+	if len(mails) != 1 || mails[0] != "CONVOY_GATE_FAILED: hq-cv-broken" {
+		t.Errorf("escalations = %v, want one CONVOY_GATE_FAILED", mails)
+	}
+}
+
+func TestReviewHolds(t *testing.T) {
+	mrs := []*beads.Issue{
+		{ID: "gt-mr1", Description: "branch: polecat/nux\nsource_issue: gt-sec"},
+		{ID: "gt-mr2", Description: "branch: polecat/ann\nsource_issue: gt-plain"},
+		{ID: "gt-mr3", Description: "branch: polecat/max\nsource_issue: gt-approved"},
+	}
+	e := &Engineer{showIssues: func(ids []string) (map[string]*beads.Issue, error) {
+		return map[string]*beads.Issue{
+			"gt-sec":      {ID: "gt-sec", Labels: []string{"security", beads.ReviewLabel("witness")}},
+			"gt-plain":    {ID: "gt-plain"},
+			"gt-approved": {ID: "gt-approved", Labels: []string{"security"}},
+		}, nil
+	}}
+	holds := e.reviewHolds(mrs)
+	if len(holds) != 1 || holds["gt-sec"] != "awaiting review by witness" {
+		t.Errorf("reviewHolds = %v, want only gt-sec awaiting witness", holds)
+	}
+
+	// Unreadable source issues hold every MR until they can be read.
+	e.showIssues = func([]string) (map[string]*beads.Issue, error) { return nil, fmt.Errorf("bd unavailable") }
+	if holds := e.reviewHolds(mrs); len(holds) != 3 {
+		t.Errorf("reviewHolds with bd down = %v, want all 3 held", holds)
+	}
+}
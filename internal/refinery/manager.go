@@ -21,6 +21,7 @@ import (
 	"github.com/steveyegge/gastown/internal/runtime"
 	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/templates"
 	"github.com/steveyegge/gastown/internal/tmux"
 )
 
@@ -208,6 +209,18 @@ func (m *Manager) Start(foreground bool, agentOverride string) error {
 	theme := tmux.AssignTheme(m.rig.Name)
 	_ = t.ConfigureGasTownSession(sessionID, theme, m.rig.Name, "refinery", "refinery")
 
+	// Open the refinery's declared pane layout (non-fatal)
+	if err := session.ApplyRoleLayout(t, session.LayoutConfig{
+		SessionID: sessionID,
+		WorkDir:   refineryRigDir,
+		Role:      "refinery",
+		TownRoot:  townRoot,
+		RigPath:   m.rig.Path,
+		RigName:   m.rig.Name,
+	}); err != nil {
+		log.Printf("warning: session layout for %s: %v", sessionID, err)
+	}
+
 	// Accept startup dialogs (workspace trust + bypass permissions) if they appear.
 	// Must be before WaitForRuntimeReady to avoid race where dialog blocks prompt detection.
 	_ = t.AcceptStartupDialogs(sessionID)
@@ -568,8 +581,15 @@ func (m *Manager) notifyWorkerRejected(mr *MergeRequest, reason string) {
 	// Nudge polecat about rejection instead of sending permanent mail.
 	polecatName := strings.TrimPrefix(mr.Worker, "polecats/")
 	target := fmt.Sprintf("%s/%s", m.rig.Name, polecatName)
-	nudgeMsg := fmt.Sprintf("MR rejected: branch=%s issue=%s reason=%s — review feedback and resubmit with 'gt done'",
-		mr.Branch, mr.IssueID, reason)
+	nudgeMsg, err := templates.RenderTownNudge(filepath.Dir(m.rig.Path), templates.NudgeRefineryRejected, map[string]string{
+		"Branch": mr.Branch,
+		"Issue":  mr.IssueID,
+		"Reason": reason,
+	})
+	if err != nil {
+		log.Printf("warning: rendering rejection nudge for %s: %v", mr.IssueID, err)
+		return
+	}
 	nudgeCmd := exec.Command("gt", "nudge", target, nudgeMsg)
 	nudgeCmd.Dir = m.workDir
 	if err := nudgeCmd.Run(); err != nil {
@@ -0,0 +1,96 @@
+package refinery
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// QueueRunResult summarizes one pass over the merge queue.
+type QueueRunResult struct {
+	Merged  []*MRInfo
+	Bounced []*MRInfo // Failed quality gates, sent back to their authors
+	Failed  []*MRInfo // Conflicts and other failures, left in (or blocked in) the queue
+}
+
+// ProcessQueue works through the ready merge queue serially, highest score
+// first: each MR is claimed, squash-merged onto its target (main or an
+// integration branch), gated by the configured checks on the merged result,
+// and pushed — or handed to HandleMRInfoFailure, which bounces gate failures
+// back to the author with the log attached. MRs are taken one at a time, so
+// each is tested against a target that already includes the one before it.
+//
+// max limits how many MRs are processed (0 = until the queue is empty).
+func (e *Engineer) ProcessQueue(ctx context.Context, max int) (*QueueRunResult, error) {
+	result := &QueueRunResult{}
+	attempted := make(map[string]bool)
+	holder := e.rig.Name + "/refinery"
+
+	// Checked once, before anything is claimed: a missing gate tool is the
+	// refinery's problem, and would otherwise bounce every MR in the queue.
+	if err := e.checkPreflight(); err != nil {
+		return result, err
+	}
+
+	for max <= 0 || len(attempted) < max {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		mr, err := e.nextQueuedMR(attempted)
+		if err != nil {
+			return result, err
+		}
+		if mr == nil {
+			break
+		}
+		attempted[mr.ID] = true
+
+		if err := e.ClaimMR(mr.ID, holder); err != nil {
+			_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to claim %s: %v (skipping)\n", mr.ID, err)
+			continue
+		}
+
+		processed := e.ProcessMRInfo(ctx, mr)
+		switch {
+		case processed.Success:
+			e.HandleMRInfoSuccess(mr, processed)
+			result.Merged = append(result.Merged, mr)
+		case processed.TestsFailed:
+			e.HandleMRInfoFailure(mr, processed)
+			result.Bounced = append(result.Bounced, mr)
+		default:
+			e.HandleMRInfoFailure(mr, processed)
+			// Back to the queue for the next pass (or the resolution task
+			// HandleMRInfoFailure blocked it on).
+			if err := e.ReleaseMR(mr.ID); err != nil {
+				_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to release %s: %v\n", mr.ID, err)
+			}
+			result.Failed = append(result.Failed, mr)
+		}
+	}
+	return result, nil
+}
+
+// nextQueuedMR returns the highest-scoring ready MR not yet attempted in this
+// pass, or nil if there is none.
+func (e *Engineer) nextQueuedMR(attempted map[string]bool) (*MRInfo, error) {
+	ready, err := e.ListReadyMRs()
+	if err != nil {
+		return nil, err
+	}
+	var candidates []*MRInfo
+	for _, mr := range ready {
+		if !attempted[mr.ID] {
+			candidates = append(candidates, mr)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+	now := time.Now()
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].ScoreAt(now) > candidates[j].ScoreAt(now)
+	})
+	return candidates[0], nil
+}
@@ -97,6 +97,19 @@ type RigConfig struct {
 	// PolecatNames optionally specifies fixed names (overrides theme-based naming).
 	PolecatPoolSize int      `json:"polecat_pool_size,omitempty"`
 	PolecatNames    []string `json:"polecat_names,omitempty"`
+
+	// Clone tunes how much of a large repo the shared bare repo and mayor
+	// clone fetch, and which paths polecat worktrees check out. Nil keeps
+	// the default shallow, full-checkout clone.
+	Clone *git.CloneStrategy `json:"clone,omitempty"`
+}
+
+// CloneStrategy returns the rig's clone strategy, or the default if unset.
+func (c *RigConfig) CloneStrategy() git.CloneStrategy {
+	if c == nil || c.Clone == nil {
+		return git.CloneStrategy{}
+	}
+	return *c.Clone
 }
 
 // BeadsConfig represents beads configuration for the rig.
@@ -234,6 +247,13 @@ type AddRigOptions struct {
 	LocalRepo     string // Optional local repo for reference clones
 	DefaultBranch string // Default branch (defaults to auto-detected from remote)
 	SkipDoltCheck bool   // Skip Dolt server availability check (for tests with mocked beads)
+
+	// Persistent polecat pool (from a rig template); see RigConfig.
+	PolecatPoolSize int
+	PolecatNames    []string
+
+	// Clone strategy for large repos (depth, partial clone, sparse paths); see RigConfig.
+	Clone *git.CloneStrategy
 }
 
 func resolveLocalRepo(path, gitURL string) (string, string) {
@@ -325,6 +345,12 @@ func (m *Manager) AddRig(opts AddRigOptions) (*Rig, error) {
 		opts.BeadsPrefix = deriveBeadsPrefix(opts.Name)
 	}
 
+	if opts.Clone != nil {
+		if err := opts.Clone.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
 	localRepo, warn := resolveLocalRepo(opts.LocalRepo, opts.GitURL)
 	if warn != "" {
 		fmt.Printf("  Warning: %s\n", warn)
@@ -357,10 +383,14 @@ func (m *Manager) AddRig(opts AddRigOptions) (*Rig, error) {
 		Beads: &BeadsConfig{
 			Prefix: opts.BeadsPrefix,
 		},
+		PolecatPoolSize: opts.PolecatPoolSize,
+		PolecatNames:    opts.PolecatNames,
+		Clone:           opts.Clone,
 	}
 	if err := m.saveRigConfig(rigPath, rigConfig); err != nil {
 		return nil, fmt.Errorf("saving rig config: %w", err)
 	}
+	strategy := rigConfig.CloneStrategy()
 
 	// Create shared bare repo as source of truth for refinery and polecats.
 	// This allows refinery to see polecat branches without pushing to remote.
@@ -368,15 +398,15 @@ func (m *Manager) AddRig(opts AddRigOptions) (*Rig, error) {
 	fmt.Printf("  Cloning repository (this may take a moment)...\n")
 	bareRepoPath := filepath.Join(rigPath, ".repo.git")
 	if localRepo != "" {
-		if err := m.git.CloneBareWithReference(opts.GitURL, bareRepoPath, localRepo); err != nil {
+		if err := m.git.CloneBareWithStrategy(opts.GitURL, bareRepoPath, localRepo, strategy); err != nil {
 			fmt.Printf("  Warning: could not use local repo reference: %v\n", err)
 			_ = os.RemoveAll(bareRepoPath)
-			if err := m.git.CloneBare(opts.GitURL, bareRepoPath); err != nil {
+			if err := m.git.CloneBareWithStrategy(opts.GitURL, bareRepoPath, "", strategy); err != nil {
 				return nil, wrapCloneError(err, opts.GitURL)
 			}
 		}
 	} else {
-		if err := m.git.CloneBare(opts.GitURL, bareRepoPath); err != nil {
+		if err := m.git.CloneBareWithStrategy(opts.GitURL, bareRepoPath, "", strategy); err != nil {
 			return nil, wrapCloneError(err, opts.GitURL)
 		}
 	}
@@ -447,10 +477,10 @@ func (m *Manager) AddRig(opts AddRigOptions) (*Rig, error) {
 	if err := os.MkdirAll(filepath.Dir(mayorRigPath), 0755); err != nil {
 		return nil, fmt.Errorf("creating mayor dir: %w", err)
 	}
-	if err := m.git.CloneBranchWithReference(opts.GitURL, mayorRigPath, defaultBranch, bareRepoPath); err != nil {
+	if err := m.git.CloneBranchWithStrategy(opts.GitURL, mayorRigPath, defaultBranch, bareRepoPath, strategy); err != nil {
 		fmt.Printf("  Warning: could not use bare repo as reference: %v\n", err)
 		_ = os.RemoveAll(mayorRigPath)
-		if err := m.git.CloneBranch(opts.GitURL, mayorRigPath, defaultBranch); err != nil {
+		if err := m.git.CloneBranchWithStrategy(opts.GitURL, mayorRigPath, defaultBranch, "", strategy); err != nil {
 			return nil, fmt.Errorf("cloning for mayor: %w", err)
 		}
 	}
@@ -804,6 +834,22 @@ func LoadRigConfig(rigPath string) (*RigConfig, error) {
 	return &cfg, nil
 }
 
+// SetPolecatPoolSize sets polecat_pool_size in the rig's config.json. The
+// file is rewritten atomically and keys RigConfig doesn't know are kept.
+func SetPolecatPoolSize(rigPath string, size int) error {
+	configPath := filepath.Join(rigPath, "config.json")
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return err
+	}
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("parsing %s: %w", configPath, err)
+	}
+	raw["polecat_pool_size"] = json.RawMessage(fmt.Sprint(size))
+	return util.AtomicWriteJSON(configPath, raw)
+}
+
 // warnDeprecatedRigConfigKeys detects merge_queue keys in rig root config.json
 // that are silently ignored by json.Unmarshal (RigConfig has no merge_queue field).
 // Without this warning, users can set merge_queue.target_branch believing it
@@ -1415,3 +1415,34 @@ func TestBareCloneDefaultBranch(t *testing.T) {
 		t.Errorf("DefaultBranch() = %q, want %q", got, "master")
 	}
 }
+
+func TestSetPolecatPoolSize_PreservesOtherKeys(t *testing.T) {
+	rigPath := t.TempDir()
+	configPath := filepath.Join(rigPath, "config.json")
+	orig := `{"type":"rig","name":"gastown","polecat_pool_size":4,"merge_queue":{"enabled":true}}`
+	if err := os.WriteFile(configPath, []byte(orig), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := SetPolecatPoolSize(rigPath, 0); err != nil {
+		t.Fatalf("SetPolecatPoolSize: %v", err)
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]any
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("parsing rewritten config: %v", err)
+	}
+	if got["polecat_pool_size"] != float64(0) {
+		t.Errorf("polecat_pool_size = %v, want 0", got["polecat_pool_size"])
+	}
+	if got["name"] != "gastown" {
+		t.Errorf("name = %v, want gastown", got["name"])
+	}
+	if _, ok := got["merge_queue"]; !ok {
+		t.Error("merge_queue was dropped")
+	}
+}
@@ -0,0 +1,106 @@
+package rig
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/git"
+)
+
+// RestoreClones re-creates a registered rig's git checkouts — the shared bare
+// repo, the mayor clone, and the refinery worktree — from the URLs in its
+// config.json. Backups carry rig state but not clones, so gt restore calls
+// this for every rig. Checkouts that already exist are left alone.
+func (m *Manager) RestoreClones(name string) error {
+	rigPath := filepath.Join(m.townRoot, name)
+	cfg, err := LoadRigConfig(rigPath)
+	if err != nil {
+		return fmt.Errorf("loading rig config: %w", err)
+	}
+	if cfg.GitURL == "" {
+		return fmt.Errorf("rig config has no git_url")
+	}
+
+	bareRepoPath := filepath.Join(rigPath, ".repo.git")
+	bareGit := git.NewGitWithDir(bareRepoPath, "")
+	if _, err := os.Stat(bareRepoPath); os.IsNotExist(err) {
+		if err := m.git.CloneBareWithStrategy(cfg.GitURL, bareRepoPath, "", cfg.CloneStrategy()); err != nil {
+			_ = os.RemoveAll(bareRepoPath)
+			return wrapCloneError(err, cfg.GitURL)
+		}
+		if cfg.PushURL != "" {
+			if err := bareGit.ConfigurePushURL("origin", cfg.PushURL); err != nil {
+				return fmt.Errorf("configuring push URL: %w", err)
+			}
+		}
+		if cfg.UpstreamURL != "" {
+			if err := bareGit.AddUpstreamRemote(cfg.UpstreamURL); err != nil {
+				return fmt.Errorf("configuring upstream remote: %w", err)
+			}
+		}
+	}
+
+	defaultBranch := cfg.DefaultBranch
+	if defaultBranch == "" {
+		defaultBranch = bareGit.DefaultBranch()
+	} else if exists, _ := bareGit.RefExists("origin/" + defaultBranch); !exists {
+		// The bare clone only carries the remote HEAD branch.
+		if err := bareGit.FetchBranchShallow("origin", defaultBranch); err != nil {
+			return fmt.Errorf("fetching default branch %q: %w", defaultBranch, err)
+		}
+	}
+
+	mayorRigPath := filepath.Join(rigPath, "mayor", "rig")
+	if _, err := os.Stat(mayorRigPath); os.IsNotExist(err) {
+		if err := os.MkdirAll(filepath.Dir(mayorRigPath), 0755); err != nil {
+			return fmt.Errorf("creating mayor dir: %w", err)
+		}
+		if err := m.git.CloneBranchWithStrategy(cfg.GitURL, mayorRigPath, defaultBranch, bareRepoPath, cfg.CloneStrategy()); err != nil {
+			_ = os.RemoveAll(mayorRigPath)
+			if err := m.git.CloneBranchWithStrategy(cfg.GitURL, mayorRigPath, defaultBranch, "", cfg.CloneStrategy()); err != nil {
+				return fmt.Errorf("cloning for mayor: %w", err)
+			}
+		}
+		mayorGit := git.NewGitWithDir("", mayorRigPath)
+		if cfg.PushURL != "" {
+			if err := mayorGit.ConfigurePushURL("origin", cfg.PushURL); err != nil {
+				return fmt.Errorf("configuring mayor push URL: %w", err)
+			}
+		}
+		if cfg.UpstreamURL != "" {
+			if err := mayorGit.AddUpstreamRemote(cfg.UpstreamURL); err != nil {
+				return fmt.Errorf("configuring mayor upstream remote: %w", err)
+			}
+		}
+		// A tracked redirect would loop back to the rig's .beads (see AddRig).
+		_ = os.Remove(filepath.Join(mayorRigPath, ".beads", "redirect"))
+	}
+
+	refineryRigPath := filepath.Join(rigPath, "refinery", "rig")
+	if _, err := os.Stat(refineryRigPath); os.IsNotExist(err) {
+		if err := os.MkdirAll(filepath.Dir(refineryRigPath), 0755); err != nil {
+			return fmt.Errorf("creating refinery dir: %w", err)
+		}
+		if err := bareGit.WorktreeAddExisting(refineryRigPath, defaultBranch); err != nil {
+			return fmt.Errorf("creating refinery worktree: %w", err)
+		}
+		if err := git.NewGit(refineryRigPath).ConfigureHooksPath(); err != nil {
+			return fmt.Errorf("configuring hooks for refinery: %w", err)
+		}
+		if err := beads.SetupRedirect(m.townRoot, refineryRigPath); err != nil {
+			fmt.Printf("  Warning: Could not set up refinery beads redirect: %v\n", err)
+		}
+		if err := CopyOverlay(rigPath, refineryRigPath); err != nil {
+			fmt.Printf("  Warning: Could not copy overlay files to refinery: %v\n", err)
+		}
+	}
+
+	for _, dir := range []string{"crew", "polecats", "witness"} {
+		if err := os.MkdirAll(filepath.Join(rigPath, dir), 0755); err != nil {
+			return fmt.Errorf("creating %s dir: %w", dir, err)
+		}
+	}
+	return nil
+}
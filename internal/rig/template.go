@@ -0,0 +1,284 @@
+package rig
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/git"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrTemplateNotFound is returned when no template file matches a name.
+var ErrTemplateNotFound = errors.New("rig template not found")
+
+// Template is a reusable rig profile applied by gt rig add --template.
+// Templates are YAML files in <town>/settings/rig-templates/<name>.yaml and
+// use the same keys as the JSON configuration they fill in.
+//
+// Example:
+//
+//	description: Go API service
+//	prefix: api
+//	crew:
+//	  size: 2
+//	  names: [max]
+//	  startup: max
+//	polecats:
+//	  count: 4
+//	witness: true
+//	refinery: false
+//	agents:
+//	  default: claude
+//	  roles:
+//	    witness: claude-haiku
+//	clone:
+//	  filter: blob:none
+//	  sparse: [services/api, libs]
+type Template struct {
+	Name        string             `json:"name,omitempty"` // Defaults to the file name
+	Description string             `json:"description,omitempty"`
+	Prefix      string             `json:"prefix,omitempty"` // Beads prefix (used when --prefix is not given)
+	Branch      string             `json:"branch,omitempty"` // Default branch (used when --branch is not given)
+	Crew        *TemplateCrew      `json:"crew,omitempty"`
+	Polecats    *TemplatePolecats  `json:"polecats,omitempty"`
+	Witness     *bool              `json:"witness,omitempty"`  // Daemon keeps a witness running (default true)
+	Refinery    *bool              `json:"refinery,omitempty"` // Daemon keeps a refinery running (default true)
+	Agents      *TemplateAgents    `json:"agents,omitempty"`
+	Clone       *git.CloneStrategy `json:"clone,omitempty"` // Clone strategy for large repos (flags override)
+}
+
+// TemplateCrew describes the crew workspaces created with the rig.
+type TemplateCrew struct {
+	Size    int      `json:"size,omitempty"`    // Number of crew workspaces (default: len(names))
+	Names   []string `json:"names,omitempty"`   // Names to use first; the rest are generated
+	Startup string   `json:"startup,omitempty"` // Rig settings crew.startup
+}
+
+// TemplatePolecats describes the rig's persistent polecat pool.
+type TemplatePolecats struct {
+	Count int      `json:"count,omitempty"` // Pool size, created with the rig
+	Names []string `json:"names,omitempty"` // Fixed polecat names (overrides the name pool)
+	Style string   `json:"style,omitempty"` // Name pool theme (e.g., "minerals")
+}
+
+// TemplateAgents selects and defines the agents the rig's roles launch.
+type TemplateAgents struct {
+	Default string                           `json:"default,omitempty"` // Rig settings agent
+	Roles   map[string]string                `json:"roles,omitempty"`   // Rig settings role_agents
+	Custom  map[string]*config.RuntimeConfig `json:"custom,omitempty"`  // Rig settings agents (launch commands)
+}
+
+// templateRoles are the roles a rig template may assign agents to.
+var templateRoles = map[string]bool{
+	constants.RoleWitness:  true,
+	constants.RoleRefinery: true,
+	constants.RolePolecat:  true,
+	constants.RoleCrew:     true,
+}
+
+// TemplatesDir returns the directory holding rig templates.
+func TemplatesDir(townRoot string) string {
+	return filepath.Join(townRoot, "settings", "rig-templates")
+}
+
+// ParseTemplate parses and validates a template from YAML (or JSON).
+func ParseTemplate(data []byte) (*Template, error) {
+	var raw interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("parsing rig template: %w", err)
+	}
+	if raw == nil {
+		return nil, fmt.Errorf("parsing rig template: empty file")
+	}
+	asJSON, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("parsing rig template: %w", err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(asJSON))
+	dec.DisallowUnknownFields()
+	var t Template
+	if err := dec.Decode(&t); err != nil {
+		return nil, fmt.Errorf("parsing rig template: %w", err)
+	}
+	if err := t.Validate(); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// Validate checks a template for values gt rig add cannot apply.
+func (t *Template) Validate() error {
+	if t.Prefix != "" && !isValidBeadsPrefix(strings.TrimSuffix(t.Prefix, "-")) {
+		return fmt.Errorf("rig template: invalid prefix %q (letters, digits, and hyphens; must start with a letter)", t.Prefix)
+	}
+	if c := t.Crew; c != nil {
+		if c.Size < 0 {
+			return fmt.Errorf("rig template: crew.size must not be negative")
+		}
+		if c.Size > 0 && len(c.Names) > c.Size {
+			return fmt.Errorf("rig template: crew.names lists %d names but crew.size is %d", len(c.Names), c.Size)
+		}
+	}
+	if p := t.Polecats; p != nil && p.Count < 0 {
+		return fmt.Errorf("rig template: polecats.count must not be negative")
+	}
+	if t.Clone != nil {
+		if err := t.Clone.Validate(); err != nil {
+			return fmt.Errorf("rig template: clone: %w", err)
+		}
+	}
+	if a := t.Agents; a != nil {
+		for role := range a.Roles {
+			if !templateRoles[role] {
+				return fmt.Errorf("rig template: agents.roles: unknown role %q (want witness, refinery, polecat, or crew)", role)
+			}
+		}
+		for name, rc := range a.Custom {
+			if rc == nil || rc.Command == "" {
+				return fmt.Errorf("rig template: agents.custom.%s: command is required", name)
+			}
+		}
+	}
+	return nil
+}
+
+// LoadTemplate reads the named template from the town's template directory.
+func LoadTemplate(townRoot, name string) (*Template, error) {
+	if name == "" || strings.ContainsAny(name, `/\`) || strings.HasPrefix(name, ".") {
+		return nil, fmt.Errorf("invalid rig template name %q", name)
+	}
+	dir := TemplatesDir(townRoot)
+	for _, ext := range []string{".yaml", ".yml", ".json"} {
+		path := filepath.Join(dir, name+ext)
+		data, err := os.ReadFile(path) //nolint:gosec // G304: path is within the town settings dir
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		t, err := ParseTemplate(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		if t.Name == "" {
+			t.Name = name
+		}
+		return t, nil
+	}
+	return nil, fmt.Errorf("%w: %s (looked in %s)", ErrTemplateNotFound, name, dir)
+}
+
+// ListTemplates returns the town's rig templates sorted by name. Templates
+// that fail to parse are returned in the error map by file name.
+func ListTemplates(townRoot string) ([]*Template, map[string]error, error) {
+	entries, err := os.ReadDir(TemplatesDir(townRoot))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+	var templates []*Template
+	broken := make(map[string]error)
+	seen := make(map[string]bool)
+	for _, e := range entries {
+		ext := filepath.Ext(e.Name())
+		if e.IsDir() || (ext != ".yaml" && ext != ".yml" && ext != ".json") {
+			continue
+		}
+		name := strings.TrimSuffix(e.Name(), ext)
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		t, err := LoadTemplate(townRoot, name)
+		if err != nil {
+			broken[e.Name()] = err
+			continue
+		}
+		templates = append(templates, t)
+	}
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Name < templates[j].Name })
+	return templates, broken, nil
+}
+
+// CrewNames returns the crew workspace names the template creates: the
+// listed names, then generated ones (max, max2, ...) up to crew.size.
+func (t *Template) CrewNames() []string {
+	if t.Crew == nil {
+		return nil
+	}
+	names := append([]string(nil), t.Crew.Names...)
+	taken := make(map[string]bool, len(names))
+	for _, n := range names {
+		taken[n] = true
+	}
+	for i := 1; len(names) < t.Crew.Size; i++ {
+		n := config.DefaultCrewName
+		if i > 1 {
+			n = fmt.Sprintf("%s%d", config.DefaultCrewName, i)
+		}
+		if !taken[n] {
+			names = append(names, n)
+			taken[n] = true
+		}
+	}
+	return names
+}
+
+// WitnessEnabled reports whether the daemon should keep a witness running.
+func (t *Template) WitnessEnabled() bool {
+	return t.Witness == nil || *t.Witness
+}
+
+// RefineryEnabled reports whether the daemon should keep a refinery running.
+func (t *Template) RefineryEnabled() bool {
+	return t.Refinery == nil || *t.Refinery
+}
+
+// ApplySettings fills rig settings from the template's agent, name pool,
+// and crew startup sections. Values the template leaves out are kept.
+func (t *Template) ApplySettings(s *config.RigSettings) {
+	if a := t.Agents; a != nil {
+		if a.Default != "" {
+			s.Agent = a.Default
+		}
+		if len(a.Roles) > 0 {
+			if s.RoleAgents == nil {
+				s.RoleAgents = make(map[string]string)
+			}
+			for role, agent := range a.Roles {
+				s.RoleAgents[role] = agent
+			}
+		}
+		if len(a.Custom) > 0 {
+			if s.Agents == nil {
+				s.Agents = make(map[string]*config.RuntimeConfig)
+			}
+			for name, rc := range a.Custom {
+				s.Agents[name] = rc
+			}
+		}
+	}
+	if p := t.Polecats; p != nil && p.Style != "" {
+		if s.Namepool == nil {
+			s.Namepool = config.DefaultNamepoolConfig()
+		}
+		s.Namepool.Style = p.Style
+	}
+	if c := t.Crew; c != nil && c.Startup != "" {
+		if s.Crew == nil {
+			s.Crew = &config.CrewConfig{}
+		}
+		s.Crew.Startup = c.Startup
+	}
+}
@@ -0,0 +1,180 @@
+package rig
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+const backendTemplate = `
+description: Go API service
+prefix: api
+branch: main
+crew:
+  size: 3
+  names: [alice]
+  startup: alice
+polecats:
+  count: 4
+  style: minerals
+witness: true
+refinery: false
+agents:
+  default: claude
+  roles:
+    witness: haiku
+  custom:
+    haiku:
+      command: claude
+      args: [--model, haiku]
+clone:
+  depth: -1
+  filter: blob:none
+  sparse: [services/api, libs]
+`
+
+func TestParseTemplate(t *testing.T) {
+	tmpl, err := ParseTemplate([]byte(backendTemplate))
+	if err != nil {
+		t.Fatalf("ParseTemplate: %v", err)
+	}
+	if tmpl.Prefix != "api" || tmpl.Branch != "main" || tmpl.Description != "Go API service" {
+		t.Errorf("unexpected header fields: %+v", tmpl)
+	}
+	if !tmpl.WitnessEnabled() || tmpl.RefineryEnabled() {
+		t.Errorf("witness/refinery = %v/%v, want true/false", tmpl.WitnessEnabled(), tmpl.RefineryEnabled())
+	}
+	if got, want := tmpl.CrewNames(), []string{"alice", "max", "max2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("CrewNames = %v, want %v", got, want)
+	}
+	if tmpl.Polecats.Count != 4 {
+		t.Errorf("Polecats.Count = %d, want 4", tmpl.Polecats.Count)
+	}
+	rc := tmpl.Agents.Custom["haiku"]
+	if rc == nil || rc.Command != "claude" || !reflect.DeepEqual(rc.Args, []string{"--model", "haiku"}) {
+		t.Errorf("custom agent = %+v", rc)
+	}
+	if c := tmpl.Clone; c == nil || c.Depth != -1 || c.Filter != "blob:none" || !reflect.DeepEqual(c.Sparse, []string{"services/api", "libs"}) {
+		t.Errorf("Clone = %+v", c)
+	}
+}
+
+func TestParseTemplate_Defaults(t *testing.T) {
+	tmpl, err := ParseTemplate([]byte("description: bare\n"))
+	if err != nil {
+		t.Fatalf("ParseTemplate: %v", err)
+	}
+	if !tmpl.WitnessEnabled() || !tmpl.RefineryEnabled() {
+		t.Error("witness and refinery should default to enabled")
+	}
+	if names := tmpl.CrewNames(); len(names) != 0 {
+		t.Errorf("CrewNames = %v, want none", names)
+	}
+}
+
+func TestParseTemplate_Invalid(t *testing.T) {
+	tests := []struct {
+		name, yaml, want string
+	}{
+		{"empty", "", "empty file"},
+		{"unknown key", "crew_size: 2\n", "unknown field"},
+		{"bad prefix", "prefix: 1abc\n", "invalid prefix"},
+		{"negative crew", "crew:\n  size: -1\n", "crew.size"},
+		{"too many names", "crew:\n  size: 1\n  names: [a, b]\n", "crew.names"},
+		{"negative polecats", "polecats:\n  count: -2\n", "polecats.count"},
+		{"unknown role", "agents:\n  roles:\n    mayor: claude\n", "unknown role"},
+		{"custom without command", "agents:\n  custom:\n    x:\n      args: [a]\n", "command is required"},
+		{"sparse outside repo", "clone:\n  sparse: [../other]\n", "invalid sparse path"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseTemplate([]byte(tt.yaml))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("err = %v, want containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadAndListTemplates(t *testing.T) {
+	townRoot := t.TempDir()
+	dir := TemplatesDir(townRoot)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"backend-service.yaml": backendTemplate,
+		"docs.yml":             "description: Docs site\nrefinery: false\n",
+		"broken.yaml":          "crew: [not, a, map]\n",
+		"README.md":            "not a template",
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tmpl, err := LoadTemplate(townRoot, "backend-service")
+	if err != nil {
+		t.Fatalf("LoadTemplate: %v", err)
+	}
+	if tmpl.Name != "backend-service" {
+		t.Errorf("Name = %q, want file name", tmpl.Name)
+	}
+
+	if _, err := LoadTemplate(townRoot, "missing"); !errors.Is(err, ErrTemplateNotFound) {
+		t.Errorf("missing template err = %v, want ErrTemplateNotFound", err)
+	}
+	if _, err := LoadTemplate(townRoot, "../secrets"); err == nil {
+		t.Error("expected error for path-like template name")
+	}
+
+	templates, broken, err := ListTemplates(townRoot)
+	if err != nil {
+		t.Fatalf("ListTemplates: %v", err)
+	}
+	var names []string
+	for _, tm := range templates {
+		names = append(names, tm.Name)
+	}
+	if !reflect.DeepEqual(names, []string{"backend-service", "docs"}) {
+		t.Errorf("templates = %v, want [backend-service docs]", names)
+	}
+	if _, ok := broken["broken.yaml"]; !ok || len(broken) != 1 {
+		t.Errorf("broken = %v, want only broken.yaml", broken)
+	}
+}
+
+func TestTemplateApplySettings(t *testing.T) {
+	tmpl, err := ParseTemplate([]byte(backendTemplate))
+	if err != nil {
+		t.Fatalf("ParseTemplate: %v", err)
+	}
+	s := config.NewRigSettings()
+	s.RoleAgents = map[string]string{"polecat": "sonnet"}
+	tmpl.ApplySettings(s)
+
+	if s.Agent != "claude" {
+		t.Errorf("Agent = %q, want claude", s.Agent)
+	}
+	if want := map[string]string{"polecat": "sonnet", "witness": "haiku"}; !reflect.DeepEqual(s.RoleAgents, want) {
+		t.Errorf("RoleAgents = %v, want %v", s.RoleAgents, want)
+	}
+	if s.Agents["haiku"] == nil {
+		t.Error("custom agent not added to rig settings")
+	}
+	if s.Namepool.Style != "minerals" {
+		t.Errorf("Namepool.Style = %q, want minerals", s.Namepool.Style)
+	}
+	if s.Crew == nil || s.Crew.Startup != "alice" {
+		t.Errorf("Crew = %+v, want startup alice", s.Crew)
+	}
+	if s.MergeQueue == nil {
+		t.Error("ApplySettings dropped existing merge queue settings")
+	}
+}
@@ -0,0 +1,264 @@
+// Package assign provides types and pure functions for the work dispatcher,
+// which hands unassigned ready beads to idle agents. The impure side
+// (querying beads and sessions, hooking, mail, nudges) lives in cmd, as with
+// the capacity scheduler.
+package assign
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// LabelNoDispatch opts a bead out of auto-dispatch.
+const LabelNoDispatch = "gt:no-dispatch"
+
+// DefaultRoles are the agent roles the dispatcher assigns work to when
+// DispatcherConfig.Roles is unset.
+var DefaultRoles = []string{"crew", "polecat"}
+
+// DispatcherConfig configures auto-dispatch of ready beads to idle agents.
+// Town-wide, stored as "dispatcher" in settings/config.json. The daemon's
+// dispatcher patrol (opt-in) runs it on a schedule; gt dispatch runs it once.
+type DispatcherConfig struct {
+	// Roles lists the agent roles that receive work (default: crew, polecat).
+	Roles []string `json:"roles,omitempty"`
+
+	// RigCapacity caps how many beads may be hooked at once in each rig,
+	// counting work already in flight. "*" sets the default for rigs not
+	// listed. Absent or 0 = unlimited.
+	RigCapacity map[string]int `json:"rig_capacity,omitempty"`
+
+	// Rules route beads by label. The first rule whose label is on a bead
+	// decides which agents may (or, for a Prefer rule, should) take it;
+	// beads matching no rule go to idle agents in the bead's own rig.
+	Rules []RoutingRule `json:"rules,omitempty"`
+
+	// BatchSize caps assignments per run. Absent or 0 = unlimited.
+	BatchSize int `json:"batch_size,omitempty"`
+}
+
+// RoutingRule sends beads carrying Label to agents matching any of Agents.
+type RoutingRule struct {
+	// Label is the bead label the rule matches (e.g., "area:frontend").
+	Label string `json:"label"`
+
+	// Agents are address patterns in path.Match syntax
+	// (e.g., "gastown/crew/*", "*/polecats/*", "beads/crew/max").
+	Agents []string `json:"agents"`
+
+	// Prefer makes Agents a preference rather than a requirement: when no
+	// matching agent is free, the bead falls back to the bead's own rig.
+	Prefer bool `json:"prefer,omitempty"`
+
+	// Review names the role that must review the work before it merges
+	// (e.g., "witness"). The dispatcher labels the bead beads.ReviewLabel(Review)
+	// and the refinery holds its merge request until the label is cleared.
+	Review string `json:"review,omitempty"`
+}
+
+// GetRoles returns Roles or DefaultRoles if unset.
+func (c *DispatcherConfig) GetRoles() []string {
+	if c == nil || len(c.Roles) == 0 {
+		return DefaultRoles
+	}
+	return c.Roles
+}
+
+// CapacityFor returns the rig's capacity, or 0 for unlimited.
+func (c *DispatcherConfig) CapacityFor(rig string) int {
+	if c == nil || c.RigCapacity == nil {
+		return 0
+	}
+	if n, ok := c.RigCapacity[rig]; ok {
+		return n
+	}
+	return c.RigCapacity["*"]
+}
+
+// RuleFor returns the first rule whose label is on the bead, or nil.
+func (c *DispatcherConfig) RuleFor(labels []string) *RoutingRule {
+	if c == nil {
+		return nil
+	}
+	for i := range c.Rules {
+		if slices.Contains(labels, c.Rules[i].Label) {
+			return &c.Rules[i]
+		}
+	}
+	return nil
+}
+
+// Matches reports whether an agent address matches one of the rule's patterns.
+func (r *RoutingRule) Matches(address string) bool {
+	for _, p := range r.Agents {
+		if ok, _ := path.Match(p, address); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Bead is an unassigned ready bead considered for dispatch.
+type Bead struct {
+	ID       string
+	Title    string
+	Rig      string // Empty for town beads
+	Priority int    // Lower is more urgent
+	Labels   []string
+}
+
+// Agent is an idle agent: running session, nothing on its hook.
+type Agent struct {
+	Address string // e.g., "gastown/crew/max"
+	Rig     string // Empty for town agents
+	Role    string
+	Session string
+}
+
+// Assignment pairs a bead with the agent chosen for it.
+type Assignment struct {
+	Bead   Bead
+	Agent  Agent
+	Rule   string   // Label of the routing rule used, if any
+	Review string   // Role that must review the work, if the rule requires one
+	Why    string   // How the agent was chosen
+	Trace  []string `json:",omitempty"` // Agents passed over first, and why
+}
+
+// Skip reasons reported by Plan.
+const (
+	SkipNoAgent  = "no idle agent"
+	SkipCapacity = "rig at capacity"
+	SkipOptedOut = "labeled " + LabelNoDispatch
+	SkipTownBead = "town bead with no routing rule"
+	SkipBatch    = "batch limit reached"
+)
+
+// Skipped is a bead Plan did not assign, and why.
+type Skipped struct {
+	Bead   Bead
+	Reason string
+	Trace  []string `json:",omitempty"` // Agents considered, and why each was passed over
+}
+
+// Plan assigns beads to agents: most urgent beads first (then by ID), each
+// agent at most once, never exceeding a rig's capacity. load is the number
+// of beads already hooked in each rig. Agents are tried in address order so
+// plans are deterministic. Each assignment says why its agent was chosen and
+// each skip which agents were passed over, for gt dispatch --explain.
+func Plan(beads []Bead, agents []Agent, load map[string]int, cfg *DispatcherConfig) ([]Assignment, []Skipped) {
+	beads = slices.Clone(beads)
+	sort.SliceStable(beads, func(i, j int) bool {
+		if beads[i].Priority != beads[j].Priority {
+			return beads[i].Priority < beads[j].Priority
+		}
+		return beads[i].ID < beads[j].ID
+	})
+	agents = slices.Clone(agents)
+	sort.Slice(agents, func(i, j int) bool { return agents[i].Address < agents[j].Address })
+
+	roles := cfg.GetRoles()
+	usedBy := make(map[string]string) // agent → bead assigned this run
+	inFlight := make(map[string]int, len(load))
+	for rig, n := range load {
+		inFlight[rig] = n
+	}
+
+	var planned []Assignment
+	var skipped []Skipped
+	for _, b := range beads {
+		if slices.Contains(b.Labels, LabelNoDispatch) {
+			skipped = append(skipped, Skipped{Bead: b, Reason: SkipOptedOut})
+			continue
+		}
+		if cfg != nil && cfg.BatchSize > 0 && len(planned) >= cfg.BatchSize {
+			skipped = append(skipped, Skipped{Bead: b, Reason: SkipBatch})
+			continue
+		}
+		rule := cfg.RuleFor(b.Labels)
+		if rule == nil && b.Rig == "" {
+			skipped = append(skipped, Skipped{Bead: b, Reason: SkipTownBead})
+			continue
+		}
+
+		reason := SkipNoAgent
+		var trace []string
+		unfree := make(map[string]bool)
+		// pick returns the first free agent that fits, noting in trace why
+		// each other candidate was passed over. Agents found not free are
+		// noted once and not reconsidered.
+		pick := func(fits func(a *Agent) string) *Agent {
+			for i := range agents {
+				a := &agents[i]
+				if !slices.Contains(roles, a.Role) || unfree[a.Address] {
+					continue
+				}
+				if why := fits(a); why != "" {
+					trace = append(trace, a.Address+": "+why)
+					continue
+				}
+				if other, ok := usedBy[a.Address]; ok {
+					trace = append(trace, a.Address+": assigned "+other+" this run")
+					unfree[a.Address] = true
+					continue
+				}
+				if c := cfg.CapacityFor(a.Rig); c > 0 && inFlight[a.Rig] >= c {
+					trace = append(trace, fmt.Sprintf("%s: rig %s at capacity (%d/%d)", a.Address, a.Rig, inFlight[a.Rig], c))
+					unfree[a.Address] = true
+					reason = SkipCapacity
+					continue
+				}
+				return a
+			}
+			return nil
+		}
+		matchesRule := func(a *Agent) string {
+			if !rule.Matches(a.Address) {
+				return "does not match rule " + rule.Label
+			}
+			return ""
+		}
+		inBeadRig := func(a *Agent) string {
+			if a.Rig != b.Rig {
+				return "not in rig " + b.Rig
+			}
+			return ""
+		}
+
+		var chosen *Agent
+		var why string
+		switch {
+		case rule == nil:
+			chosen = pick(inBeadRig)
+			why = "free in the bead's rig " + b.Rig
+		case !rule.Prefer:
+			chosen = pick(matchesRule)
+			why = fmt.Sprintf("rule %s routes to %s", rule.Label, strings.Join(rule.Agents, ", "))
+		default:
+			chosen = pick(matchesRule)
+			why = fmt.Sprintf("rule %s prefers %s", rule.Label, strings.Join(rule.Agents, ", "))
+			if chosen == nil && b.Rig != "" {
+				chosen = pick(inBeadRig)
+				why = fmt.Sprintf("rule %s prefers %s, none free; fell back to the bead's rig %s",
+					rule.Label, strings.Join(rule.Agents, ", "), b.Rig)
+			}
+		}
+		if chosen == nil {
+			skipped = append(skipped, Skipped{Bead: b, Reason: reason, Trace: trace})
+			continue
+		}
+
+		usedBy[chosen.Address] = b.ID
+		inFlight[chosen.Rig]++
+		a := Assignment{Bead: b, Agent: *chosen, Why: why, Trace: trace}
+		if rule != nil {
+			a.Rule = rule.Label
+			a.Review = rule.Review
+		}
+		planned = append(planned, a)
+	}
+	return planned, skipped
+}
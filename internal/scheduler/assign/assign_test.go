@@ -0,0 +1,177 @@
+package assign
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func agent(addr, rig, role string) Agent {
+	return Agent{Address: addr, Rig: rig, Role: role, Session: addr}
+}
+
+func TestPlan_SameRigByPriority(t *testing.T) {
+	beads := []Bead{
+		{ID: "gt-low", Rig: "gastown", Priority: 3},
+		{ID: "gt-urgent", Rig: "gastown", Priority: 0},
+		{ID: "bd-1", Rig: "beads", Priority: 1},
+	}
+	agents := []Agent{
+		agent("gastown/crew/max", "gastown", "crew"),
+		agent("gastown/witness", "gastown", "witness"), // role not eligible
+	}
+
+	planned, skipped := Plan(beads, agents, nil, nil)
+	if len(planned) != 1 || planned[0].Bead.ID != "gt-urgent" || planned[0].Agent.Address != "gastown/crew/max" {
+		t.Fatalf("planned = %+v, want gt-urgent → gastown/crew/max", planned)
+	}
+	if len(skipped) != 2 {
+		t.Fatalf("skipped = %+v, want 2", skipped)
+	}
+	for _, s := range skipped {
+		if s.Reason != SkipNoAgent {
+			t.Errorf("%s skipped for %q, want %q", s.Bead.ID, s.Reason, SkipNoAgent)
+		}
+	}
+}
+
+func TestPlan_RoutingRules(t *testing.T) {
+	cfg := &DispatcherConfig{Rules: []RoutingRule{
+		{Label: "area:docs", Agents: []string{"beads/crew/*"}},
+		{Label: "town", Agents: []string{"gastown/polecats/*"}},
+	}}
+	beads := []Bead{
+		{ID: "gt-docs", Rig: "gastown", Labels: []string{"area:docs"}},
+		{ID: "hq-ops", Labels: []string{"town"}},
+		{ID: "hq-unrouted"},
+	}
+	agents := []Agent{
+		agent("gastown/crew/max", "gastown", "crew"),
+		agent("beads/crew/ann", "beads", "crew"),
+		agent("gastown/polecats/nux", "gastown", "polecat"),
+	}
+
+	planned, skipped := Plan(beads, agents, nil, cfg)
+	got := make(map[string]string)
+	for _, a := range planned {
+		got[a.Bead.ID] = a.Agent.Address
+	}
+	if got["gt-docs"] != "beads/crew/ann" {
+		t.Errorf("gt-docs → %q, want beads/crew/ann (rule crosses rigs)", got["gt-docs"])
+	}
+	if got["hq-ops"] != "gastown/polecats/nux" {
+		t.Errorf("hq-ops → %q, want gastown/polecats/nux", got["hq-ops"])
+	}
+	if len(skipped) != 1 || skipped[0].Reason != SkipTownBead {
+		t.Errorf("skipped = %+v, want hq-unrouted as town bead", skipped)
+	}
+}
+
+func TestPlan_RigCapacity(t *testing.T) {
+	cfg := &DispatcherConfig{RigCapacity: map[string]int{"gastown": 2, "*": 1}}
+	beads := []Bead{
+		{ID: "gt-1", Rig: "gastown"},
+		{ID: "gt-2", Rig: "gastown"},
+		{ID: "bd-1", Rig: "beads"},
+	}
+	agents := []Agent{
+		agent("gastown/crew/a", "gastown", "crew"),
+		agent("gastown/crew/b", "gastown", "crew"),
+		agent("beads/crew/c", "beads", "crew"),
+	}
+	// gastown already has one hooked bead; beads is full.
+	load := map[string]int{"gastown": 1, "beads": 1}
+
+	planned, skipped := Plan(beads, agents, load, cfg)
+	if len(planned) != 1 || planned[0].Bead.ID != "gt-1" {
+		t.Fatalf("planned = %+v, want only gt-1", planned)
+	}
+	for _, s := range skipped {
+		if s.Reason != SkipCapacity {
+			t.Errorf("%s skipped for %q, want %q", s.Bead.ID, s.Reason, SkipCapacity)
+		}
+	}
+}
+
+func TestPlan_OptOutAndBatch(t *testing.T) {
+	cfg := &DispatcherConfig{BatchSize: 1}
+	beads := []Bead{
+		{ID: "gt-1", Rig: "gastown", Labels: []string{LabelNoDispatch}},
+		{ID: "gt-2", Rig: "gastown"},
+		{ID: "gt-3", Rig: "gastown"},
+	}
+	agents := []Agent{agent("gastown/crew/a", "gastown", "crew"), agent("gastown/crew/b", "gastown", "crew")}
+
+	planned, skipped := Plan(beads, agents, nil, cfg)
+	if len(planned) != 1 || planned[0].Bead.ID != "gt-2" {
+		t.Fatalf("planned = %+v, want only gt-2", planned)
+	}
+	reasons := map[string]string{}
+	for _, s := range skipped {
+		reasons[s.Bead.ID] = s.Reason
+	}
+	if reasons["gt-1"] != SkipOptedOut || reasons["gt-3"] != SkipBatch {
+		t.Errorf("skip reasons = %v", reasons)
+	}
+}
+
+func TestDispatcherConfigDefaults(t *testing.T) {
+	var cfg *DispatcherConfig
+	if roles := cfg.GetRoles(); len(roles) != 2 {
+		t.Errorf("nil config roles = %v, want defaults", roles)
+	}
+	if cfg.CapacityFor("gastown") != 0 || cfg.RuleFor([]string{"x"}) != nil {
+		t.Error("nil config should be unlimited with no rules")
+	}
+}
+
+func TestPlan_PreferAndReview(t *testing.T) {
+	cfg := &DispatcherConfig{Rules: []RoutingRule{
+		{Label: "size:small", Agents: []string{"*/polecats/*"}, Prefer: true},
+		{Label: "security", Agents: []string{"gastown/crew/max"}, Review: "witness"},
+	}}
+	beads := []Bead{
+		{ID: "gt-sec", Rig: "gastown", Priority: 0, Labels: []string{"security"}},
+		{ID: "gt-small1", Rig: "gastown", Priority: 1, Labels: []string{"size:small"}},
+		{ID: "gt-small2", Rig: "gastown", Priority: 2, Labels: []string{"size:small"}},
+		{ID: "hq-small", Priority: 3, Labels: []string{"size:small"}},
+	}
+	agents := []Agent{
+		agent("gastown/crew/max", "gastown", "crew"),
+		agent("gastown/crew/ann", "gastown", "crew"),
+		agent("gastown/polecats/nux", "gastown", "polecat"),
+	}
+
+	planned, skipped := Plan(beads, agents, nil, cfg)
+	got := make(map[string]Assignment)
+	for _, a := range planned {
+		got[a.Bead.ID] = a
+	}
+	if a := got["gt-sec"]; a.Agent.Address != "gastown/crew/max" || a.Review != "witness" {
+		t.Errorf("gt-sec = %+v, want gastown/crew/max with witness review", a)
+	}
+	if a := got["gt-small1"]; a.Agent.Address != "gastown/polecats/nux" || a.Review != "" {
+		t.Errorf("gt-small1 = %+v, want preferred polecat", a)
+	}
+	// The only polecat is taken, so the preference falls back to the rig.
+	a := got["gt-small2"]
+	if a.Agent.Address != "gastown/crew/ann" || a.Rule != "size:small" {
+		t.Errorf("gt-small2 = %+v, want fallback to gastown/crew/ann", a)
+	}
+	if !strings.Contains(a.Why, "fell back") {
+		t.Errorf("gt-small2 why = %q, want a fallback explanation", a.Why)
+	}
+	wantTrace := []string{
+		"gastown/crew/ann: does not match rule size:small",
+		"gastown/crew/max: does not match rule size:small",
+		"gastown/polecats/nux: assigned gt-small1 this run",
+	}
+	if !slices.Equal(a.Trace, wantTrace) {
+		t.Errorf("gt-small2 trace = %q, want %q", a.Trace, wantTrace)
+	}
+
+	// A town bead has no rig to fall back to.
+	if len(skipped) != 1 || skipped[0].Bead.ID != "hq-small" || skipped[0].Reason != SkipNoAgent {
+		t.Errorf("skipped = %+v, want hq-small with no agent", skipped)
+	}
+}
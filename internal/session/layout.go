@@ -0,0 +1,47 @@
+package session
+
+import (
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+// LayoutConfig identifies the agent whose role layout ApplyRoleLayout opens.
+type LayoutConfig struct {
+	SessionID string
+	WorkDir   string
+	Role      string
+	TownRoot  string
+	RigPath   string // empty for town-level agents
+	RigName   string
+	AgentName string
+}
+
+// ApplyRoleLayout opens the extra panes declared in the role's
+// [[session.layout]] config beside the agent pane. Sessions without a
+// declared layout, or whose role has no definition (boot), are left
+// untouched; a broken role override is reported where the role's session
+// config is loaded, not here.
+//
+// Before opening any pane it records GT_PANE_ID, if the caller hasn't, so
+// nudges, captures and health checks keep targeting the agent pane rather
+// than whichever pane has focus.
+func ApplyRoleLayout(t *tmux.Tmux, cfg LayoutConfig) error {
+	def, err := config.LoadRoleDefinition(cfg.TownRoot, cfg.RigPath, cfg.Role)
+	if err != nil || len(def.Session.Layout) == 0 {
+		return nil
+	}
+
+	if declared, _ := t.GetEnvironment(cfg.SessionID, "GT_PANE_ID"); declared == "" {
+		if paneID, err := t.GetPaneID(cfg.SessionID); err == nil {
+			_ = t.SetEnvironment(cfg.SessionID, "GT_PANE_ID", paneID)
+		}
+	}
+
+	prefix := PrefixFor(cfg.RigName)
+	panes := make([]config.RolePaneConfig, len(def.Session.Layout))
+	for i, p := range def.Session.Layout {
+		p.Command = config.ExpandPattern(p.Command, cfg.TownRoot, cfg.RigName, cfg.AgentName, cfg.Role, prefix)
+		panes[i] = p
+	}
+	return t.ApplyLayout(cfg.SessionID, cfg.WorkDir, panes)
+}
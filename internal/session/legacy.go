@@ -0,0 +1,111 @@
+package session
+
+import "strings"
+
+// LegacyRename maps a session using the legacy {prefix}-{rig_name}-{role}
+// naming scheme (e.g., "gt-whatsapp_automation-witness") to its current
+// short-prefix name (e.g., "wa-witness").
+type LegacyRename struct {
+	Old  string
+	New  string
+	Crew bool // Crew sessions are human-attached; callers may want to confirm first
+}
+
+// legacyRoleSuffixes are the simple role keywords that end a legacy session
+// name (after the rig name).
+var legacyRoleSuffixes = []string{"witness", "refinery"}
+
+// DetectLegacyNames scans sessions for the legacy
+//
+//	{any_prefix}-{registered_rig_name}-{role_suffix}
+//
+// pattern. For each match it computes the canonical name
+//
+//	{rig_short_prefix}-{role_suffix}
+//
+// Detection uses explicit legacy-name matching rather than a parse round-trip:
+// "gt-whatsapp_automation-witness" parses as a polecat named
+// "whatsapp_automation-witness" and round-trips to the same string.
+func DetectLegacyNames(sessions []string, reg *PrefixRegistry) []LegacyRename {
+	if reg == nil {
+		reg = DefaultRegistry()
+	}
+	rigs := reg.AllRigs() // rigName → shortPrefix
+	if len(rigs) == 0 {
+		return nil
+	}
+
+	// Build set of known Gastown prefixes for ownership gating.
+	knownPrefixes := make(map[string]bool)
+	for _, prefix := range rigs {
+		knownPrefixes[prefix] = true
+	}
+
+	var result []LegacyRename
+	seen := make(map[string]bool)
+	for _, sess := range sessions {
+		if sess == "" || seen[sess] {
+			continue
+		}
+		if r, ok := matchLegacyName(sess, rigs, knownPrefixes); ok {
+			seen[sess] = true
+			result = append(result, r)
+		}
+	}
+	return result
+}
+
+// matchLegacyName checks whether sess matches the old
+//
+//	{known_prefix}-{rig_name}-{role_suffix}  or  {known_prefix}-{rig_name}-crew-{name}
+//
+// pattern for any known rig, and returns the canonical rename if so.
+// The prefix before the rig name must be a known Gastown prefix to avoid
+// false-positives on non-Gastown sessions (e.g., "my-niflheim-witness")
+// and polecat sessions whose names embed rig names (e.g., "gt-fix-gastown-witness").
+func matchLegacyName(sess string, rigs map[string]string, knownPrefixes map[string]bool) (LegacyRename, bool) {
+	for rigName, shortPrefix := range rigs {
+		// Look for "-{rigName}-" anywhere in the session name.
+		needle := "-" + rigName + "-"
+		idx := strings.Index(sess, needle)
+		if idx < 0 {
+			continue
+		}
+
+		// Ownership guard: the part before the rig name must be a known
+		// Gastown prefix. This prevents matching non-Gastown sessions
+		// and polecat sessions whose names happen to contain a rig name.
+		sessionPrefix := sess[:idx]
+		if !knownPrefixes[sessionPrefix] {
+			continue
+		}
+
+		// Skip if the session already uses the correct prefix for this rig.
+		if sessionPrefix == shortPrefix {
+			continue
+		}
+
+		// The part after the rig name is the role suffix.
+		roleSuffix := sess[idx+len(needle):]
+		if roleSuffix == "" || !isLegacyRoleSuffix(roleSuffix) {
+			continue
+		}
+
+		return LegacyRename{
+			Old:  sess,
+			New:  shortPrefix + "-" + roleSuffix,
+			Crew: strings.HasPrefix(roleSuffix, "crew-"),
+		}, true
+	}
+	return LegacyRename{}, false
+}
+
+// isLegacyRoleSuffix returns true if suffix is a known Gas Town role identifier.
+func isLegacyRoleSuffix(suffix string) bool {
+	for _, role := range legacyRoleSuffixes {
+		if suffix == role {
+			return true
+		}
+	}
+	return strings.HasPrefix(suffix, "crew-") && len(suffix) > len("crew-")
+}
@@ -0,0 +1,38 @@
+package session
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDetectLegacyNames(t *testing.T) {
+	reg := NewPrefixRegistry()
+	reg.Register("gt", "gastown")
+	reg.Register("wa", "whatsapp_automation")
+
+	sessions := []string{
+		"hq-mayor",
+		"gt-witness",
+		"gt-whatsapp_automation-witness",
+		"gt-whatsapp_automation-crew-bob",
+		"gt-whatsapp_automation-witness", // duplicate
+		"wa-whatsapp_automation-refinery",
+		"my-whatsapp_automation-witness", // not a Gas Town prefix
+		"gt-fix-gastown-witness",         // polecat whose name embeds a rig
+		"gt-whatsapp_automation-crew-",   // empty crew name
+	}
+	got := DetectLegacyNames(sessions, reg)
+	want := []LegacyRename{
+		{Old: "gt-whatsapp_automation-witness", New: "wa-witness"},
+		{Old: "gt-whatsapp_automation-crew-bob", New: "wa-crew-bob", Crew: true},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DetectLegacyNames() = %+v, want %+v", got, want)
+	}
+}
+
+func TestDetectLegacyNames_EmptyRegistry(t *testing.T) {
+	if got := DetectLegacyNames([]string{"gt-gastown-witness"}, NewPrefixRegistry()); got != nil {
+		t.Errorf("DetectLegacyNames() = %+v, want nil", got)
+	}
+}
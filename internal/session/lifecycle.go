@@ -207,6 +207,11 @@ func StartSession(t *tmux.Tmux, cfg SessionConfig) (_ *StartResult, retErr error
 		_ = t.SetRemainOnExit(cfg.SessionID, true)
 	}
 
+	// Hold nudges until the runtime is ready (cleared in step 11).
+	if cfg.ReadyDelay {
+		_ = t.MarkStarting(cfg.SessionID, constants.ClaudeStartTimeout)
+	}
+
 	// 6. Set environment variables.
 	envVars := config.AgentEnv(config.AgentEnvConfig{
 		Role:             cfg.Role,
@@ -256,11 +261,15 @@ func StartSession(t *tmux.Tmux, cfg SessionConfig) (_ *StartResult, retErr error
 
 	// 11. Ready delay: wait for agent to be fully ready at the prompt.
 	// Uses prompt-based polling for agents with ReadyPromptPrefix,
-	// falling back to ReadyDelayMs sleep for agents without prompt detection.
+	// falling back to ReadyDelayMs sleep and output stabilization for
+	// agents without prompt detection.
+	// Nudges are released either way: a runtime that never shows its prompt
+	// is better nudged late than never.
 	if cfg.ReadyDelay {
 		if err := t.WaitForRuntimeReady(cfg.SessionID, runtimeConfig, constants.ClaudeStartTimeout); err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: agent readiness detection timed out for %s: %v\n", cfg.SessionID, err)
+			fmt.Fprintf(os.Stderr, "Warning: %s not ready: %v\n", cfg.SessionID, err)
 		}
+		_ = t.MarkStarted(cfg.SessionID)
 	}
 
 	// 12. Verify session survived startup.
@@ -282,6 +291,18 @@ func StartSession(t *tmux.Tmux, cfg SessionConfig) (_ *StartResult, retErr error
 	if paneID, err := t.GetPaneID(cfg.SessionID); err == nil {
 		_ = t.SetEnvironment(cfg.SessionID, "GT_PANE_ID", paneID)
 	}
+	// Open the role's declared pane layout beside the agent pane.
+	if err := ApplyRoleLayout(t, LayoutConfig{
+		SessionID: cfg.SessionID,
+		WorkDir:   cfg.WorkDir,
+		Role:      cfg.Role,
+		TownRoot:  cfg.TownRoot,
+		RigPath:   cfg.RigPath,
+		RigName:   cfg.RigName,
+		AgentName: cfg.AgentName,
+	}); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: session layout for %s: %v\n", cfg.SessionID, err)
+	}
 
 	// 14. Track PID for defense-in-depth orphan cleanup.
 	if cfg.TrackPID && cfg.TownRoot != "" {
@@ -382,8 +403,10 @@ func mapKeysSorted(m map[string]string) []string {
 // tmux session environment table, even when agent resolution came from
 // workspace/default settings rather than an explicit --agent override.
 //
-// Call this after config.AgentEnv() to add GT_AGENT and GT_PROCESS_NAMES
-// before writing env vars to the tmux session via SetEnvironment.
+// Call this after config.AgentEnv() to add GT_AGENT, GT_PROCESS_NAMES and
+// (when the runtime asks for typed nudges or other client hints) GT_TYPING
+// and GT_CLIENT_HINTS before writing env vars to the tmux session via
+// SetEnvironment.
 func MergeRuntimeLivenessEnv(envVars map[string]string, runtimeConfig *config.RuntimeConfig) map[string]string {
 	if envVars == nil {
 		envVars = make(map[string]string)
@@ -415,9 +438,45 @@ func MergeRuntimeLivenessEnv(envVars map[string]string, runtimeConfig *config.Ru
 		}
 	}
 
+	if _, hasTyping := envVars[tmux.EnvTypingProfile]; !hasTyping {
+		if profile := RuntimeTypingProfile(runtimeConfig); profile != "" {
+			envVars[tmux.EnvTypingProfile] = profile
+		}
+	}
+
+	if _, hasHints := envVars[tmux.EnvClientHints]; !hasHints {
+		if hints := RuntimeClientHints(runtimeConfig); hints != "" {
+			envVars[tmux.EnvClientHints] = hints
+		}
+	}
+
 	return envVars
 }
 
+// RuntimeTypingProfile returns the GT_TYPING value for a runtime config, or ""
+// if the runtime uses bulk nudge delivery.
+func RuntimeTypingProfile(runtimeConfig *config.RuntimeConfig) string {
+	if runtimeConfig == nil || runtimeConfig.Tmux == nil || runtimeConfig.Tmux.TypingDelayMs <= 0 {
+		return ""
+	}
+	return tmux.TypingProfile{
+		Delay:  time.Duration(runtimeConfig.Tmux.TypingDelayMs) * time.Millisecond,
+		Jitter: time.Duration(runtimeConfig.Tmux.TypingJitterMs) * time.Millisecond,
+	}.String()
+}
+
+// RuntimeClientHints returns the GT_CLIENT_HINTS value for a runtime config,
+// or "" if the runtime takes nudges the default way.
+func RuntimeClientHints(runtimeConfig *config.RuntimeConfig) string {
+	if runtimeConfig == nil || runtimeConfig.ClientHints == nil {
+		return ""
+	}
+	return tmux.ClientHints{
+		SubmitKey:  runtimeConfig.ClientHints.SubmitKey,
+		SkipEscape: runtimeConfig.ClientHints.SkipEscape,
+	}.String()
+}
+
 // KillExistingSession kills an existing session if one is found.
 // Returns true if a session was killed.
 //
@@ -195,6 +195,25 @@ func TestMergeRuntimeLivenessEnv_UsesEffectiveAgentForProcessNames(t *testing.T)
 	}
 }
 
+func TestMergeRuntimeLivenessEnv_SetsClientHints(t *testing.T) {
+	rc := &config.RuntimeConfig{
+		Command:       "aider",
+		ResolvedAgent: "aider",
+		ClientHints:   &config.RuntimeClientHints{SkipEscape: true},
+	}
+
+	got := MergeRuntimeLivenessEnv(map[string]string{}, rc)
+
+	if got["GT_CLIENT_HINTS"] != "escape=0" {
+		t.Fatalf("GT_CLIENT_HINTS = %q, want %q", got["GT_CLIENT_HINTS"], "escape=0")
+	}
+
+	got = MergeRuntimeLivenessEnv(map[string]string{}, &config.RuntimeConfig{Command: "claude"})
+	if v, ok := got["GT_CLIENT_HINTS"]; ok {
+		t.Fatalf("GT_CLIENT_HINTS = %q for default hints, want unset", v)
+	}
+}
+
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(s) > 0 && containsHelper(s, substr))
 }
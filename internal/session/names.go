@@ -5,6 +5,11 @@ import (
 	"fmt"
 )
 
+// DogPrefix is the session prefix for the deacon's dogs (hq-dog-<name>).
+// "hq-dog-" rather than "hq-deacon-" avoids tmux prefix-matching collisions
+// with the "hq-deacon" session.
+const DogPrefix = HQPrefix + "dog-"
+
 // DefaultPrefix is the default beads prefix used when no rig-specific prefix is known.
 const DefaultPrefix = "gt"
 
@@ -59,3 +64,53 @@ func OverseerSessionName() string {
 func BootSessionName() string {
 	return HQPrefix + "boot"
 }
+
+// DogSessionName returns the session name for one of the deacon's dogs.
+func DogSessionName(name string) string {
+	return DogPrefix + name
+}
+
+// SessionName returns the tmux session name for an agent, given its role
+// (mayor, deacon, boot, dog, overseer, witness, refinery, crew, polecat),
+// rig name, and worker name. Unlike the per-role helpers it takes the rig
+// name, not its beads prefix, and resolves the prefix from the default
+// registry. Arguments a role doesn't use are ignored; missing ones are an
+// error rather than a malformed name.
+func SessionName(role, rig, name string) (string, error) {
+	switch role {
+	case string(RoleMayor):
+		return MayorSessionName(), nil
+	case string(RoleDeacon):
+		return DeaconSessionName(), nil
+	case "boot":
+		return BootSessionName(), nil
+	case string(RoleOverseer):
+		return OverseerSessionName(), nil
+	case "dog":
+		if name == "" {
+			return "", fmt.Errorf("dog session needs a name")
+		}
+		return DogSessionName(name), nil
+	}
+
+	if rig == "" {
+		return "", fmt.Errorf("%s session needs a rig", role)
+	}
+	prefix := PrefixFor(rig)
+	switch role {
+	case string(RoleWitness):
+		return WitnessSessionName(prefix), nil
+	case string(RoleRefinery):
+		return RefinerySessionName(prefix), nil
+	case string(RoleCrew), string(RolePolecat):
+		if name == "" {
+			return "", fmt.Errorf("%s session needs a name", role)
+		}
+		if role == string(RoleCrew) {
+			return CrewSessionName(prefix, name), nil
+		}
+		return PolecatSessionName(prefix, name), nil
+	default:
+		return "", fmt.Errorf("unknown role %q", role)
+	}
+}
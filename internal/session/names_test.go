@@ -116,3 +116,50 @@ func TestDefaultPrefix(t *testing.T) {
 		t.Errorf("DefaultPrefix = %q, want %q", DefaultPrefix, want)
 	}
 }
+
+func TestDogSessionName(t *testing.T) {
+	if got := DogSessionName("alpha"); got != "hq-dog-alpha" {
+		t.Errorf("DogSessionName(alpha) = %q, want %q", got, "hq-dog-alpha")
+	}
+}
+
+func TestSessionName(t *testing.T) {
+	old := DefaultRegistry()
+	reg := NewPrefixRegistry()
+	reg.Register("gt", "gastown")
+	reg.Register("bd", "beads")
+	SetDefaultRegistry(reg)
+	defer SetDefaultRegistry(old)
+
+	tests := []struct {
+		role, rig, name string
+		want            string
+		wantErr         bool
+	}{
+		{"mayor", "", "", "hq-mayor", false},
+		{"deacon", "gastown", "", "hq-deacon", false},
+		{"boot", "", "", "hq-boot", false},
+		{"overseer", "", "", "hq-overseer", false},
+		{"dog", "", "alpha", "hq-dog-alpha", false},
+		{"dog", "", "", "", true},
+		{"witness", "gastown", "", "gt-witness", false},
+		{"refinery", "beads", "", "bd-refinery", false},
+		{"crew", "gastown", "max", "gt-crew-max", false},
+		{"polecat", "beads", "Toast", "bd-Toast", false},
+		{"witness", "", "", "", true},
+		{"crew", "gastown", "", "", true},
+		{"polecat", "gastown", "", "", true},
+		{"janitor", "gastown", "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.role+"/"+tt.rig+"/"+tt.name, func(t *testing.T) {
+			got, err := SessionName(tt.role, tt.rig, tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SessionName(%q, %q, %q) error = %v, wantErr %v", tt.role, tt.rig, tt.name, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("SessionName(%q, %q, %q) = %q, want %q", tt.role, tt.rig, tt.name, got, tt.want)
+			}
+		})
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"regexp"
 
 	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/placement"
 	"github.com/steveyegge/gastown/internal/tmux"
 )
 
@@ -138,6 +139,17 @@ func InitRegistry(townRoot string) error {
 		SetDefaultRegistry(r)
 	}
 
+	// Route tmux commands for rigs that live on other machines over SSH.
+	hosts, err := RemoteHostsFromFile(filepath.Join(townRoot, "mayor", "rigs.json"))
+	if err != nil {
+		errs = append(errs, fmt.Errorf("remote hosts: %w", err))
+	}
+	tmux.SetRemoteHosts(hosts)
+	// Polecats placed on another host by a rig placement policy.
+	tmux.SetSessionHosts(placement.Load(townRoot))
+
+	tmux.SetPasteBufferThreshold(config.LoadOperationalConfig(townRoot).GetNudgeConfig().PasteBufferThresholdV())
+
 	// Load agent registry so all entry points (CLI, daemon, witness) respect
 	// user-configured overrides like custom process_names.
 	if err := config.LoadAgentRegistry(config.DefaultAgentRegistryPath(townRoot)); err != nil {
@@ -182,6 +194,7 @@ type rigsJSON struct {
 
 type rigEntry struct {
 	Beads *beadsEntry `json:"beads,omitempty"`
+	Host  string      `json:"host,omitempty"`
 }
 
 type beadsEntry struct {
@@ -214,6 +227,31 @@ func BuildPrefixRegistryFromFile(path string) (*PrefixRegistry, error) {
 	return r, nil
 }
 
+// RemoteHostsFromFile reads a rigs.json file and returns the session prefix →
+// SSH host map for rigs configured to run on another machine.
+func RemoteHostsFromFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var rigs rigsJSON
+	if err := json.Unmarshal(data, &rigs); err != nil {
+		return nil, err
+	}
+
+	hosts := make(map[string]string)
+	for _, entry := range rigs.Rigs {
+		if entry.Host != "" && entry.Beads != nil && entry.Beads.Prefix != "" {
+			hosts[entry.Beads.Prefix] = entry.Host
+		}
+	}
+	return hosts, nil
+}
+
 // LegacyPrefixes are prefixes accepted as valid even when the registry is empty.
 // gt = default rig, bd = beads, hq = town-level HQ services, gthq = gastown HQ.
 var LegacyPrefixes = []string{"gt", "bd", "hq", "gthq"}
@@ -120,3 +120,28 @@ func TestInitRegistryNoAgentsJSON(t *testing.T) {
 		t.Fatalf("InitRegistry with no agents.json: %v", err)
 	}
 }
+
+func TestRemoteHostsFromFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "rigs.json")
+	data := `{"rigs": {
+		"gastown": {"beads": {"prefix": "gt"}, "host": "alice@box"},
+		"beads":   {"beads": {"prefix": "bd"}},
+		"orphan":  {"host": "bob@gpu"}
+	}}`
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	hosts, err := RemoteHostsFromFile(path)
+	if err != nil {
+		t.Fatalf("RemoteHostsFromFile: %v", err)
+	}
+	if len(hosts) != 1 || hosts["gt"] != "alice@box" {
+		t.Errorf("RemoteHostsFromFile() = %v, want map[gt:alice@box]", hosts)
+	}
+
+	if hosts, err := RemoteHostsFromFile(filepath.Join(dir, "missing.json")); err != nil || len(hosts) != 0 {
+		t.Errorf("missing file: got %v, %v; want empty, nil", hosts, err)
+	}
+}
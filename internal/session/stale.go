@@ -16,6 +16,9 @@ func SessionCreatedAt(sessionName string) (time.Time, error) {
 		return time.Time{}, err
 	}
 
+	if !info.CreatedAt.IsZero() {
+		return info.CreatedAt, nil
+	}
 	return ParseTmuxSessionCreated(info.Created)
 }
 
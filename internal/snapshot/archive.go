@@ -0,0 +1,76 @@
+package snapshot
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/session"
+)
+
+// Capturer reads agent pane scrollback. *tmux.Tmux satisfies it.
+type Capturer interface {
+	ListSessions() ([]string, error)
+	CapturePaneAll(session string) (string, error)
+}
+
+// Retention limits how much scrollback history is kept per session.
+type Retention struct {
+	MaxAge   time.Duration // Snapshots older than this are deleted
+	MaxCount int           // At most this many per session (0 for no limit)
+}
+
+// Result summarizes one archive pass.
+type Result struct {
+	Saved     int // Sessions with a new snapshot
+	Unchanged int // Sessions whose scrollback matched the last snapshot
+	Pruned    int // Snapshots deleted by retention
+	// Coarse lists sessions whose capture was too different from the last
+	// snapshot to diff within the search limits, and was stored in full.
+	Coarse []string
+	Errors []error
+}
+
+// Archive snapshots the scrollback of every running agent session, then
+// applies retention to every archived session, running or not.
+func Archive(townRoot string, c Capturer, keep Retention, now time.Time) Result {
+	var res Result
+	names, err := c.ListSessions()
+	if err != nil {
+		res.Errors = append(res.Errors, fmt.Errorf("listing sessions: %w", err))
+	}
+	for _, name := range names {
+		if !session.IsKnownSession(name) {
+			continue // Not a Gas Town session
+		}
+		content, err := c.CapturePaneAll(name)
+		if err != nil {
+			res.Errors = append(res.Errors, fmt.Errorf("capturing %s: %w", name, err))
+			continue
+		}
+		saved, coarse, err := save(townRoot, name, content, now)
+		switch {
+		case err != nil:
+			res.Errors = append(res.Errors, fmt.Errorf("saving %s: %w", name, err))
+		case saved:
+			res.Saved++
+		default:
+			res.Unchanged++
+		}
+		if coarse {
+			res.Coarse = append(res.Coarse, name)
+		}
+	}
+
+	archived, err := Sessions(townRoot)
+	if err != nil {
+		res.Errors = append(res.Errors, err)
+	}
+	for _, name := range archived {
+		n, err := Prune(townRoot, name, keep.MaxAge, keep.MaxCount, now)
+		if err != nil {
+			res.Errors = append(res.Errors, fmt.Errorf("pruning %s: %w", name, err))
+		}
+		res.Pruned += n
+	}
+	return res
+}
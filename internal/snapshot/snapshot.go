@@ -0,0 +1,361 @@
+// Package snapshot archives agents' full pane scrollback on a schedule, so
+// what an agent saw and printed can be looked at after the fact (gt
+// snapshot show). Archives live under <town>/.runtime/snapshots/<session>/,
+// one gzipped record per snapshot.
+//
+// Most snapshots are stored as a line diff (util.DiffLines) against the
+// previous one; every keyframeEvery-th is stored in full, which bounds how
+// many diffs a read replays. A capture identical to the previous snapshot
+// isn't stored at all. Captures are redacted before they are written.
+package snapshot
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/redact"
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// keyframeEvery is how often a snapshot is stored in full rather than as a
+// diff against the previous one.
+const keyframeEvery = 24
+
+// fileSuffix ends every snapshot file name.
+const fileSuffix = ".json.gz"
+
+// record is one stored snapshot.
+type record struct {
+	Session string          `json:"session"`
+	At      time.Time       `json:"at"`
+	Full    []string        `json:"full,omitempty"`  // Set on keyframes
+	Edits   []util.LineEdit `json:"edits,omitempty"` // Diff against the previous snapshot
+	Depth   int             `json:"depth"`           // Diffs since the last keyframe
+}
+
+// keyframe reports whether the record holds its text in full.
+func (r record) keyframe() bool {
+	return r.Edits == nil
+}
+
+// Entry describes a stored snapshot.
+type Entry struct {
+	Session  string
+	At       time.Time
+	Keyframe bool
+	Size     int64 // Compressed bytes on disk
+	path     string
+}
+
+// View is a session's scrollback as of some time.
+type View struct {
+	Entry
+	Content string
+	Early   bool // The requested time predates the oldest snapshot
+}
+
+// Dir returns <townRoot>/.runtime/snapshots.
+func Dir(townRoot string) string {
+	return filepath.Join(townRoot, constants.DirRuntime, "snapshots")
+}
+
+func sessionDir(townRoot, session string) string {
+	return filepath.Join(Dir(townRoot), strings.ReplaceAll(session, "/", "_"))
+}
+
+// Save stores content as the session's snapshot at at. Returns false if it
+// matched the previous snapshot and nothing was stored.
+func Save(townRoot, session, content string, at time.Time) (bool, error) {
+	saved, _, err := save(townRoot, session, content, at)
+	return saved, err
+}
+
+// save is Save, also reporting whether the capture was too different from
+// the previous one to diff (util.DiffLines' coarse fallback) and was stored
+// in full instead.
+func save(townRoot, session, content string, at time.Time) (saved, coarse bool, err error) {
+	lines := splitLines(redact.String(townRoot, content))
+
+	entries, err := List(townRoot, session)
+	if err != nil {
+		return false, false, err
+	}
+	rec := record{Session: session, At: at.UTC(), Full: lines}
+	if len(entries) > 0 {
+		last := entries[len(entries)-1]
+		if !at.After(last.At) {
+			return false, false, fmt.Errorf("snapshot at %s is not after the last one (%s)", at.Format(time.RFC3339), last.At.Format(time.RFC3339))
+		}
+		prev, prevRec, err := reconstruct(entries, len(entries)-1)
+		if err != nil {
+			return false, false, err
+		}
+		if slices.Equal(prev, lines) {
+			return false, false, nil
+		}
+		if prevRec.Depth+1 < keyframeEvery {
+			// A coarse diff repeats the whole new text plus deletes, so a
+			// keyframe is smaller.
+			var edits []util.LineEdit
+			if edits, coarse = util.DiffLines(prev, lines); !coarse {
+				rec.Full = nil
+				rec.Edits = edits
+				rec.Depth = prevRec.Depth + 1
+			}
+		}
+	}
+
+	dir := sessionDir(townRoot, session)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return false, false, err
+	}
+	if err := writeRecord(filepath.Join(dir, fileName(rec.At)), rec); err != nil {
+		return false, false, err
+	}
+	return true, coarse, nil
+}
+
+// List returns a session's snapshots, oldest first.
+func List(townRoot, session string) ([]Entry, error) {
+	dir := sessionDir(townRoot, session)
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var entries []Entry
+	for _, f := range files {
+		at, ok := parseFileName(f.Name())
+		if !ok || f.IsDir() {
+			continue
+		}
+		e := Entry{Session: session, At: at, path: filepath.Join(dir, f.Name())}
+		if info, err := f.Info(); err == nil {
+			e.Size = info.Size()
+		}
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].At.Before(entries[j].At) })
+	return entries, nil
+}
+
+// Sessions returns the sessions with stored snapshots.
+func Sessions(townRoot string) ([]string, error) {
+	dirs, err := os.ReadDir(Dir(townRoot))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var sessions []string
+	for _, d := range dirs {
+		if d.IsDir() {
+			sessions = append(sessions, d.Name())
+		}
+	}
+	sort.Strings(sessions)
+	return sessions, nil
+}
+
+// At returns the session's scrollback as of t: the newest snapshot taken at
+// or before t, or the oldest if t predates them all. A zero t means the
+// newest snapshot.
+func At(townRoot, session string, t time.Time) (*View, error) {
+	entries, err := List(townRoot, session)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("no snapshots for %s", session)
+	}
+	v := &View{}
+	i := len(entries) - 1
+	if !t.IsZero() {
+		i = sort.Search(len(entries), func(i int) bool { return entries[i].At.After(t) }) - 1
+		if i < 0 {
+			i, v.Early = 0, true
+		}
+	}
+	lines, rec, err := reconstruct(entries, i)
+	if err != nil {
+		return nil, err
+	}
+	v.Entry = entries[i]
+	v.Keyframe = rec.keyframe()
+	v.Content = strings.Join(lines, "\n")
+	return v, nil
+}
+
+// Prune deletes a session's snapshots older than retain and all but the
+// newest maxCount (0 for no limit). If the oldest surviving snapshot is a
+// diff, it is first rewritten in full so it doesn't depend on deleted ones.
+// Returns the number deleted.
+func Prune(townRoot, session string, retain time.Duration, maxCount int, now time.Time) (int, error) {
+	entries, err := List(townRoot, session)
+	if err != nil {
+		return 0, err
+	}
+	cutoff := now.Add(-retain)
+	drop := sort.Search(len(entries), func(i int) bool { return !entries[i].At.Before(cutoff) })
+	if maxCount > 0 && len(entries)-drop > maxCount {
+		drop = len(entries) - maxCount
+	}
+	if err := dropOldest(townRoot, session, entries, drop); err != nil {
+		return 0, err
+	}
+	return drop, nil
+}
+
+// PruneOldest deletes a session's n oldest snapshots, rewriting the oldest
+// survivor in full as Prune does. Returns the number deleted.
+func PruneOldest(townRoot, session string, n int) (int, error) {
+	entries, err := List(townRoot, session)
+	if err != nil {
+		return 0, err
+	}
+	if n > len(entries) {
+		n = len(entries)
+	}
+	if err := dropOldest(townRoot, session, entries, n); err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+// dropOldest deletes entries[:drop], first making entries[drop] a keyframe.
+func dropOldest(townRoot, session string, entries []Entry, drop int) error {
+	if drop <= 0 {
+		return nil
+	}
+	if drop < len(entries) {
+		lines, rec, err := reconstruct(entries, drop)
+		if err != nil {
+			return err
+		}
+		if !rec.keyframe() {
+			rec.Full, rec.Edits, rec.Depth = lines, nil, 0
+			if err := writeRecord(entries[drop].path, rec); err != nil {
+				return err
+			}
+		}
+	}
+	for _, e := range entries[:drop] {
+		if err := os.Remove(e.path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	if drop == len(entries) {
+		_ = os.Remove(sessionDir(townRoot, session))
+	}
+	return nil
+}
+
+// reconstruct rebuilds the text of entries[i] from the nearest keyframe at
+// or before it.
+func reconstruct(entries []Entry, i int) ([]string, record, error) {
+	var chain []record
+	for j := i; j >= 0; j-- {
+		rec, err := readRecord(entries[j].path)
+		if err != nil {
+			return nil, record{}, err
+		}
+		chain = append(chain, rec)
+		if rec.keyframe() {
+			break
+		}
+		if j == 0 {
+			return nil, record{}, fmt.Errorf("snapshot %s: no keyframe before it", entries[i].path)
+		}
+	}
+	lines := chain[len(chain)-1].Full
+	for k := len(chain) - 2; k >= 0; k-- {
+		var err error
+		if lines, err = util.ApplyLineEdits(lines, chain[k].Edits); err != nil {
+			return nil, record{}, fmt.Errorf("snapshot %s: %w", entries[i].path, err)
+		}
+	}
+	return lines, chain[0], nil
+}
+
+func writeRecord(path string, rec record) error {
+	tmp := path + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	zw := gzip.NewWriter(f)
+	encErr := json.NewEncoder(zw).Encode(rec)
+	closeErr := zw.Close()
+	if err := f.Close(); err != nil && encErr == nil && closeErr == nil {
+		closeErr = err
+	}
+	if encErr != nil || closeErr != nil {
+		_ = os.Remove(tmp)
+		if encErr != nil {
+			return encErr
+		}
+		return closeErr
+	}
+	return os.Rename(tmp, path)
+}
+
+func readRecord(path string) (record, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return record{}, err
+	}
+	defer f.Close()
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		return record{}, fmt.Errorf("reading %s: %w", path, err)
+	}
+	defer zr.Close()
+	var rec record
+	if err := json.NewDecoder(zr).Decode(&rec); err != nil {
+		return record{}, fmt.Errorf("reading %s: %w", path, err)
+	}
+	return rec, nil
+}
+
+// fileName returns the snapshot file name for at: zero-padded Unix
+// nanoseconds, so names sort by time.
+func fileName(at time.Time) string {
+	return fmt.Sprintf("%019d%s", at.UnixNano(), fileSuffix)
+}
+
+func parseFileName(name string) (time.Time, bool) {
+	stem, ok := strings.CutSuffix(name, fileSuffix)
+	if !ok {
+		return time.Time{}, false
+	}
+	ns, err := strconv.ParseInt(stem, 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(0, ns).UTC(), true
+}
+
+// splitLines splits captured text into lines, dropping trailing blank
+// lines (the unused bottom of the pane).
+func splitLines(s string) []string {
+	lines := strings.Split(s, "\n")
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if lines == nil {
+		lines = []string{}
+	}
+	return lines
+}
@@ -0,0 +1,228 @@
+// Package statuscache keeps a town status snapshot warm in a background
+// collector and serves it over a unix socket, so gt status can answer
+// without walking tmux, beads, and mail on every call.
+//
+// The protocol is one request per connection: the client writes a command
+// line ("get" or "stop") and the server answers with a JSON Snapshot (for
+// "get") and closes the connection.
+package statuscache
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/constants"
+)
+
+// DefaultMaxAge is how old a snapshot may be before clients ignore it and
+// collect live instead.
+const DefaultMaxAge = 30 * time.Second
+
+// maxSocketPathLen is a conservative limit on unix socket path length
+// (sun_path is 104 bytes on macOS, 108 on Linux).
+const maxSocketPathLen = 100
+
+// Snapshot is the collector's latest status.
+type Snapshot struct {
+	CollectedAt time.Time       `json:"collected_at"`
+	Status      json.RawMessage `json:"status,omitempty"`
+	Error       string          `json:"error,omitempty"` // Last collection error, if it failed
+}
+
+// Fresh reports whether the snapshot holds a status collected within maxAge.
+func (s *Snapshot) Fresh(maxAge time.Duration, now time.Time) bool {
+	return s != nil && len(s.Status) > 0 && now.Sub(s.CollectedAt) <= maxAge
+}
+
+// SocketPath returns the collector's socket for a town:
+// <townRoot>/.runtime/status.sock, or a per-town socket in the temp dir if
+// that path is too long for a unix socket.
+func SocketPath(townRoot string) string {
+	path := filepath.Join(townRoot, constants.DirRuntime, "status.sock")
+	if len(path) <= maxSocketPathLen {
+		return path
+	}
+	sum := sha256.Sum256([]byte(townRoot))
+	return filepath.Join(os.TempDir(), "gt-status-"+hex.EncodeToString(sum[:6])+".sock")
+}
+
+// Server collects status on a timer and on demand, and serves the latest
+// snapshot.
+type Server struct {
+	socketPath string
+	collect    func() (json.RawMessage, error)
+	interval   time.Duration
+
+	mu      sync.RWMutex
+	snap    Snapshot
+	refresh chan struct{}
+	stop    chan struct{}
+	once    sync.Once
+}
+
+// NewServer returns a Server that calls collect every interval (and on
+// Refresh) and serves the result on socketPath.
+func NewServer(socketPath string, collect func() (json.RawMessage, error), interval time.Duration) *Server {
+	return &Server{
+		socketPath: socketPath,
+		collect:    collect,
+		interval:   interval,
+		refresh:    make(chan struct{}, 1),
+		stop:       make(chan struct{}),
+	}
+}
+
+// Refresh asks for a collection as soon as the current one (if any) is done.
+// It never blocks; refreshes requested during a collection coalesce.
+func (s *Server) Refresh() {
+	select {
+	case s.refresh <- struct{}{}:
+	default:
+	}
+}
+
+// Snapshot returns the latest snapshot.
+func (s *Server) Snapshot() Snapshot {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.snap
+}
+
+// Run collects once, then serves until ctx is done or a client sends
+// "stop". A stale socket left by a dead collector is replaced; a live one
+// is an error.
+func (s *Server) Run(ctx context.Context) error {
+	if _, err := Query(s.socketPath, "get", time.Second); err == nil {
+		return fmt.Errorf("status cache already running on %s", s.socketPath)
+	}
+	_ = os.Remove(s.socketPath)
+	if err := os.MkdirAll(filepath.Dir(s.socketPath), 0755); err != nil {
+		return fmt.Errorf("creating socket dir: %w", err)
+	}
+	ln, err := net.Listen("unix", s.socketPath)
+	if err != nil {
+		return fmt.Errorf("listening on %s: %w", s.socketPath, err)
+	}
+	defer func() { _ = os.Remove(s.socketPath) }()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-s.stop:
+			cancel()
+		}
+		_ = ln.Close()
+	}()
+
+	s.collectOnce()
+	go s.collectLoop(ctx)
+
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("accepting connection: %w", err)
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *Server) collectLoop(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-s.refresh:
+		}
+		s.collectOnce()
+	}
+}
+
+// collectOnce runs a collection and stores the result. A failed collection
+// keeps the previous status and records the error.
+func (s *Server) collectOnce() {
+	status, err := s.collect()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err != nil {
+		s.snap.Error = err.Error()
+		return
+	}
+	s.snap = Snapshot{CollectedAt: time.Now(), Status: status}
+}
+
+func (s *Server) handle(conn net.Conn) {
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(5 * time.Second))
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil {
+		return
+	}
+	switch strings.TrimSpace(line) {
+	case "get":
+		snap := s.Snapshot()
+		_ = json.NewEncoder(conn).Encode(&snap)
+	case "stop":
+		_, _ = conn.Write([]byte("{}\n"))
+		s.once.Do(func() { close(s.stop) })
+	}
+}
+
+// Query sends command to the collector at socketPath and returns its
+// snapshot ("get") or an empty one ("stop"). It fails fast if no collector
+// is listening.
+func Query(socketPath, command string, timeout time.Duration) (*Snapshot, error) {
+	conn, err := net.DialTimeout("unix", socketPath, timeout)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(timeout))
+
+	if _, err := conn.Write([]byte(command + "\n")); err != nil {
+		return nil, err
+	}
+	var snap Snapshot
+	if err := json.NewDecoder(conn).Decode(&snap); err != nil {
+		return nil, fmt.Errorf("reading snapshot: %w", err)
+	}
+	return &snap, nil
+}
+
+// Get returns the collector's snapshot if a collector is running and its
+// status is no older than maxAge. ok is false otherwise, and the caller
+// should collect live.
+func Get(townRoot string, maxAge time.Duration) (snap *Snapshot, ok bool) {
+	snap, err := Query(SocketPath(townRoot), "get", 500*time.Millisecond)
+	if err != nil || !snap.Fresh(maxAge, time.Now()) {
+		return nil, false
+	}
+	return snap, true
+}
+
+// Stop asks the collector for a town to exit. It returns an error if none
+// is running.
+func Stop(townRoot string) error {
+	if _, err := Query(SocketPath(townRoot), "stop", time.Second); err != nil {
+		return errors.New("status cache is not running")
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package statuscache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func shortSocketPath(t *testing.T) string {
+	t.Helper()
+	// t.TempDir() can exceed the unix socket path limit on some systems.
+	dir, err := os.MkdirTemp("", "sc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+	return filepath.Join(dir, "s.sock")
+}
+
+func waitForServer(t *testing.T, path string) *Snapshot {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if snap, err := Query(path, "get", time.Second); err == nil {
+			return snap
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("server did not come up")
+	return nil
+}
+
+func TestServer_GetRefreshStop(t *testing.T) {
+	path := shortSocketPath(t)
+	var calls atomic.Int32
+	srv := NewServer(path, func() (json.RawMessage, error) {
+		n := calls.Add(1)
+		return json.RawMessage(fmt.Sprintf(`{"n":%d}`, n)), nil
+	}, time.Hour)
+
+	done := make(chan error, 1)
+	go func() { done <- srv.Run(context.Background()) }()
+
+	snap := waitForServer(t, path)
+	if string(snap.Status) != `{"n":1}` {
+		t.Errorf("first snapshot = %s, want the initial collection", snap.Status)
+	}
+	if !snap.Fresh(DefaultMaxAge, time.Now()) {
+		t.Error("initial snapshot should be fresh")
+	}
+
+	srv.Refresh()
+	deadline := time.Now().Add(5 * time.Second)
+	for calls.Load() < 2 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if got := srv.Snapshot(); string(got.Status) != `{"n":2}` {
+		t.Errorf("snapshot after refresh = %s", got.Status)
+	}
+
+	// A second server on the same socket refuses to start.
+	if err := NewServer(path, nil, time.Hour).Run(context.Background()); err == nil || !strings.Contains(err.Error(), "already running") {
+		t.Errorf("second server: err = %v, want already running", err)
+	}
+
+	if _, err := Query(path, "stop", time.Second); err != nil {
+		t.Fatalf("stop: %v", err)
+	}
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Run returned %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("server did not stop")
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("socket should be removed on exit, stat err = %v", err)
+	}
+}
+
+func TestServer_FailedCollectionKeepsLastStatus(t *testing.T) {
+	srv := NewServer("", nil, time.Hour)
+	srv.collect = func() (json.RawMessage, error) { return json.RawMessage(`{"ok":true}`), nil }
+	srv.collectOnce()
+	srv.collect = func() (json.RawMessage, error) { return nil, fmt.Errorf("tmux down") }
+	srv.collectOnce()
+
+	snap := srv.Snapshot()
+	if string(snap.Status) != `{"ok":true}` || snap.Error != "tmux down" {
+		t.Errorf("snapshot = %+v, want last status kept with the error", snap)
+	}
+}
+
+func TestSnapshotFresh(t *testing.T) {
+	now := time.Now()
+	var nilSnap *Snapshot
+	if nilSnap.Fresh(time.Minute, now) {
+		t.Error("nil snapshot should not be fresh")
+	}
+	if (&Snapshot{CollectedAt: now}).Fresh(time.Minute, now) {
+		t.Error("snapshot without status should not be fresh")
+	}
+	old := &Snapshot{CollectedAt: now.Add(-2 * time.Minute), Status: json.RawMessage(`{}`)}
+	if old.Fresh(time.Minute, now) {
+		t.Error("old snapshot should not be fresh")
+	}
+}
+
+func TestSocketPath(t *testing.T) {
+	if got := SocketPath("/town"); got != "/town/.runtime/status.sock" {
+		t.Errorf("SocketPath() = %q", got)
+	}
+	long := "/" + strings.Repeat("x", 120)
+	got := SocketPath(long)
+	if len(got) > maxSocketPathLen || !strings.HasPrefix(filepath.Base(got), "gt-status-") {
+		t.Errorf("SocketPath(long) = %q, want a short temp-dir socket", got)
+	}
+	if SocketPath(long) != got {
+		t.Error("SocketPath should be stable for a town")
+	}
+}
@@ -0,0 +1,95 @@
+package suggest
+
+import (
+	"sort"
+	"strings"
+)
+
+// Fuzzy-finder scoring weights. A match is a case-insensitive subsequence;
+// these reward the patterns people type: word starts and contiguous runs.
+const (
+	fuzzyMatchScore    = 1
+	fuzzyBoundaryBonus = 8 // Match at the start or after a separator (/ - _ . space)
+	fuzzyRunBonus      = 5 // Match immediately after the previous match
+	fuzzyGapPenalty    = 1 // Per skipped character between matches
+)
+
+// FuzzyScore scores candidate against an fzf-style query. Each character of
+// the query must appear in order in the candidate (case-insensitive); the
+// best-scoring alignment is used, so "w" in "gastown/witness" counts as a
+// word start. It returns false when the query doesn't match; an empty query
+// matches everything with score 0.
+func FuzzyScore(query, candidate string) (int, bool) {
+	q := []rune(strings.ToLower(query))
+	c := []rune(strings.ToLower(candidate))
+	if len(q) == 0 {
+		return 0, true
+	}
+	if len(q) > len(c) {
+		return 0, false
+	}
+
+	// best[j] is the top score for the query so far with its last character
+	// matched at c[j]; ok[j] marks reachable positions.
+	best := make([]int, len(c))
+	ok := make([]bool, len(c))
+	for qi := range q {
+		next := make([]int, len(c))
+		nextOK := make([]bool, len(c))
+		for ci := range c {
+			if c[ci] != q[qi] {
+				continue
+			}
+			here := fuzzyMatchScore
+			if ci == 0 || strings.ContainsRune("/-_. ", c[ci-1]) {
+				here += fuzzyBoundaryBonus
+			}
+			if qi == 0 {
+				next[ci], nextOK[ci] = here, true
+				continue
+			}
+			for pj := 0; pj < ci; pj++ {
+				if !ok[pj] {
+					continue
+				}
+				s := best[pj] + here
+				if pj == ci-1 {
+					s += fuzzyRunBonus
+				} else {
+					s -= (ci - pj - 1) * fuzzyGapPenalty
+				}
+				if !nextOK[ci] || s > next[ci] {
+					next[ci], nextOK[ci] = s, true
+				}
+			}
+		}
+		best, ok = next, nextOK
+	}
+
+	score, found := 0, false
+	for j := range c {
+		if ok[j] && (!found || best[j] > score) {
+			score, found = best[j], true
+		}
+	}
+	return score, found
+}
+
+// FuzzyRank returns the indices of candidates matching query, best first.
+// Ties keep the candidates' original order.
+func FuzzyRank(query string, candidates []string) []int {
+	type scored struct{ idx, score int }
+	var matches []scored
+	for i, c := range candidates {
+		if s, ok := FuzzyScore(query, c); ok {
+			matches = append(matches, scored{i, s})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].score > matches[j].score })
+
+	out := make([]int, len(matches))
+	for i, m := range matches {
+		out[i] = m.idx
+	}
+	return out
+}
@@ -0,0 +1,38 @@
+package suggest
+
+import "testing"
+
+func TestFuzzyScore(t *testing.T) {
+	if _, ok := FuzzyScore("gtw", "gastown/witness"); !ok {
+		t.Error("subsequence should match")
+	}
+	if _, ok := FuzzyScore("wg", "gastown/witness"); ok {
+		t.Error("out-of-order characters should not match")
+	}
+	if s, ok := FuzzyScore("", "anything"); !ok || s != 0 {
+		t.Errorf("empty query = %d, %v", s, ok)
+	}
+
+	boundary, _ := FuzzyScore("w", "gastown/witness")
+	middle, _ := FuzzyScore("w", "gastown/rw")
+	if boundary <= middle {
+		t.Errorf("word-start match (%d) should beat mid-word (%d)", boundary, middle)
+	}
+	run, _ := FuzzyScore("toast", "gastown/toast")
+	spread, _ := FuzzyScore("toast", "gastown/tzozazszt")
+	if run <= spread {
+		t.Errorf("contiguous match (%d) should beat spread (%d)", run, spread)
+	}
+}
+
+func TestFuzzyRank(t *testing.T) {
+	candidates := []string{"mayor", "gastown/refinery", "gastown/crew/max", "beads/crew/max"}
+	got := FuzzyRank("gmax", candidates)
+	if len(got) != 1 || got[0] != 2 {
+		t.Errorf("FuzzyRank(gmax) = %v, want [2]", got)
+	}
+	got = FuzzyRank("max", candidates)
+	if len(got) != 2 || got[0] != 2 || got[1] != 3 {
+		t.Errorf("FuzzyRank(max) = %v, want [2 3] (ties keep order)", got)
+	}
+}
@@ -104,7 +104,8 @@ type recorderInstruments struct {
 	beadCreateTotal       metric.Int64Counter
 
 	// Histograms
-	bdDurationHist metric.Float64Histogram
+	bdDurationHist        metric.Float64Histogram
+	mailNotifyLatencyHist metric.Float64Histogram
 }
 
 var (
@@ -222,6 +223,10 @@ func initInstruments() {
 			metric.WithDescription("bd CLI call round-trip latency in milliseconds"),
 			metric.WithUnit("ms"),
 		)
+		inst.mailNotifyLatencyHist, _ = m.Float64Histogram("gastown.mail.notify_latency_ms",
+			metric.WithDescription("Mail send to nudge delivery latency in milliseconds"),
+			metric.WithUnit("ms"),
+		)
 	})
 }
 
@@ -598,6 +603,25 @@ func RecordNudge(ctx context.Context, target string, err error) {
 	)
 }
 
+// RecordMailNotifyLatency records how long a mail notification took to reach
+// the recipient's session (metrics + log event). path is "direct" (typed into
+// an idle session) or "queued" (drained at the next turn boundary).
+func RecordMailNotifyLatency(ctx context.Context, session, priority, path string, latencyMs float64) {
+	initInstruments()
+	inst.mailNotifyLatencyHist.Record(ctx, latencyMs,
+		metric.WithAttributes(
+			attribute.String("priority", priority),
+			attribute.String("path", path),
+		),
+	)
+	emit(ctx, "mail.notify_latency", otellog.SeverityInfo,
+		otellog.String("session", session),
+		otellog.String("priority", priority),
+		otellog.String("path", path),
+		otellog.Float64("latency_ms", latencyMs),
+	)
+}
+
 // RecordDone records a gt done invocation — polecat work completion (metrics + log event).
 // exitType is one of COMPLETED, ESCALATED, DEFERRED.
 func RecordDone(ctx context.Context, exitType string, err error) {
@@ -9,6 +9,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"sync"
 	"text/template"
 
@@ -38,16 +39,18 @@ var templateFuncs = template.FuncMap{
 	"cmd": CmdName, // {{ cmd }} returns the CLI command name
 }
 
-//go:embed roles/*.md.tmpl messages/*.md.tmpl
+//go:embed roles/*.md.tmpl messages/*.md.tmpl nudges/*.tmpl
 var templateFS embed.FS
 
 //go:embed launchd/*.plist systemd/*.service
 var supervisorFS embed.FS
 
-// Templates manages role and message templates.
+// Templates manages role, message, and nudge templates.
 type Templates struct {
 	roleTemplates    *template.Template
 	messageTemplates *template.Template
+	nudgeTemplates   *template.Template
+	overrides        map[string]string // "<kind>/<name>" -> town override path
 }
 
 // RoleData contains information for rendering role contexts.
@@ -134,6 +137,14 @@ func New() (*Templates, error) {
 	}
 	t.messageTemplates = msgTempl
 
+	// Nudges are one-line messages whose variables come from a map, so a
+	// variable the caller doesn't set renders empty rather than "<no value>".
+	nudgeTempl, err := template.New("").Funcs(templateFuncs).Option("missingkey=zero").ParseFS(templateFS, "nudges/*.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("parsing nudge templates: %w", err)
+	}
+	t.nudgeTemplates = nudgeTempl
+
 	return t, nil
 }
 
@@ -161,6 +172,18 @@ func (t *Templates) RenderMessage(name string, data interface{}) (string, error)
 	return buf.String(), nil
 }
 
+// RenderNudge renders a nudge template with the given variables.
+func (t *Templates) RenderNudge(name string, vars map[string]string) (string, error) {
+	templateName := name + ".tmpl"
+
+	var buf bytes.Buffer
+	if err := t.nudgeTemplates.ExecuteTemplate(&buf, templateName, vars); err != nil {
+		return "", fmt.Errorf("rendering nudge template %s: %w", templateName, err)
+	}
+
+	return strings.TrimSpace(buf.String()), nil
+}
+
 // RoleNames returns the list of available role templates.
 func (t *Templates) RoleNames() []string {
 	return []string{"mayor", "witness", "refinery", "polecat", "crew", "deacon", "boot"}
@@ -171,6 +194,11 @@ func (t *Templates) MessageNames() []string {
 	return []string{"spawn", "nudge", "escalation", "handoff"}
 }
 
+// NudgeNames returns the list of available nudge templates.
+func (t *Templates) NudgeNames() []string {
+	return []string{NudgeRefineryBounce, NudgeRefineryRejected, NudgeWitnessRecovery, NudgeMayorDirective}
+}
+
 // CreateMayorCLAUDEmd creates the Mayor's CLAUDE.md file at the specified directory.
 // This is used by both gt install and gt doctor --fix.
 //
@@ -0,0 +1,182 @@
+package templates
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// Template kinds, which are also the subdirectories of a town's template
+// directory.
+const (
+	KindRole    = "roles"
+	KindMessage = "messages"
+	KindNudge   = "nudges"
+)
+
+// Nudge template names.
+const (
+	NudgeRefineryBounce   = "refinery-bounce"   // Refinery → polecat: merge failed
+	NudgeRefineryRejected = "refinery-rejected" // Refinery → polecat: MR rejected
+	NudgeWitnessRecovery  = "witness-recovery"  // Witness → deacon: dead polecat needs recovery
+	NudgeMayorDirective   = "mayor-directive"   // Mayor → anyone: gt nudge --template
+)
+
+// TownDir returns the directory holding a town's template overrides:
+// roles/<role>.md.tmpl, messages/<name>.md.tmpl, and nudges/<name>.tmpl.
+// A file there replaces the embedded template of the same name.
+func TownDir(townRoot string) string {
+	return filepath.Join(townRoot, "settings", "templates")
+}
+
+// TemplateInfo describes one template and where it comes from.
+type TemplateInfo struct {
+	Kind     string // roles, messages, or nudges
+	Name     string // e.g. "mayor", "refinery-bounce"
+	Override string // Town override path, or "" for the embedded default
+}
+
+// Ref returns the "<kind>/<name>" reference used by gt templates render.
+func (i TemplateInfo) Ref() string {
+	return i.Kind + "/" + i.Name
+}
+
+// kindSuffix is the file suffix of each kind's templates.
+var kindSuffix = map[string]string{
+	KindRole:    ".md.tmpl",
+	KindMessage: ".md.tmpl",
+	KindNudge:   ".tmpl",
+}
+
+// NewForTown creates a Templates instance with the town's overrides from
+// TownDir applied over the embedded templates. An override that fails to
+// parse is an error naming the file.
+func NewForTown(townRoot string) (*Templates, error) {
+	t, err := New()
+	if err != nil {
+		return nil, err
+	}
+	t.overrides = make(map[string]string)
+	if townRoot == "" {
+		return t, nil
+	}
+
+	sets := map[string]*template.Template{
+		KindRole:    t.roleTemplates,
+		KindMessage: t.messageTemplates,
+		KindNudge:   t.nudgeTemplates,
+	}
+	for kind, set := range sets {
+		dir := filepath.Join(TownDir(townRoot), kind)
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue // No overrides of this kind
+		}
+		suffix := kindSuffix[kind]
+		for _, e := range entries {
+			if e.IsDir() || !strings.HasSuffix(e.Name(), suffix) {
+				continue
+			}
+			path := filepath.Join(dir, e.Name())
+			content, err := os.ReadFile(path)
+			if err != nil {
+				return nil, fmt.Errorf("reading template override %s: %w", path, err)
+			}
+			// Parsing under the embedded template's name replaces it.
+			if _, err := set.New(e.Name()).Parse(string(content)); err != nil {
+				return nil, fmt.Errorf("parsing template override %s: %w", path, err)
+			}
+			t.overrides[kind+"/"+strings.TrimSuffix(e.Name(), suffix)] = path
+		}
+	}
+	return t, nil
+}
+
+// List returns every template, embedded or added by the town, sorted by
+// kind and name.
+func (t *Templates) List() []TemplateInfo {
+	seen := make(map[string]bool)
+	var infos []TemplateInfo
+	add := func(kind, name string) {
+		ref := kind + "/" + name
+		if seen[ref] {
+			return
+		}
+		seen[ref] = true
+		infos = append(infos, TemplateInfo{Kind: kind, Name: name, Override: t.overrides[ref]})
+	}
+	for _, name := range t.RoleNames() {
+		add(KindRole, name)
+	}
+	add(KindRole, "dog")
+	for _, name := range t.MessageNames() {
+		add(KindMessage, name)
+	}
+	for _, name := range t.NudgeNames() {
+		add(KindNudge, name)
+	}
+	for ref := range t.overrides {
+		kind, name, _ := strings.Cut(ref, "/")
+		add(kind, name)
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		if infos[i].Kind != infos[j].Kind {
+			return infos[i].Kind < infos[j].Kind
+		}
+		return infos[i].Name < infos[j].Name
+	})
+	return infos
+}
+
+// ParseRef splits a "<kind>/<name>" template reference. A bare name is
+// looked up as a nudge, then a role, then a message.
+func (t *Templates) ParseRef(ref string) (kind, name string, err error) {
+	if kind, name, ok := strings.Cut(ref, "/"); ok {
+		if _, known := kindSuffix[kind]; !known {
+			return "", "", fmt.Errorf("unknown template kind %q (want roles, messages, or nudges)", kind)
+		}
+		if !t.has(kind, name) {
+			return "", "", fmt.Errorf("no %s template named %q", strings.TrimSuffix(kind, "s"), name)
+		}
+		return kind, name, nil
+	}
+	for _, kind := range []string{KindNudge, KindRole, KindMessage} {
+		if t.has(kind, ref) {
+			return kind, ref, nil
+		}
+	}
+	return "", "", fmt.Errorf("no template named %q (see gt templates list)", ref)
+}
+
+func (t *Templates) has(kind, name string) bool {
+	var set *template.Template
+	switch kind {
+	case KindRole:
+		set = t.roleTemplates
+	case KindMessage:
+		set = t.messageTemplates
+	case KindNudge:
+		set = t.nudgeTemplates
+	}
+	return set != nil && set.Lookup(name+kindSuffix[kind]) != nil
+}
+
+// RenderTownNudge renders a nudge with the town's overrides. A broken
+// override falls back to the embedded template, so a bad edit can't
+// silence a nudge.
+func RenderTownNudge(townRoot, name string, vars map[string]string) (string, error) {
+	if t, err := NewForTown(townRoot); err == nil {
+		if msg, err := t.RenderNudge(name, vars); err == nil {
+			return msg, nil
+		}
+	}
+	t, err := New()
+	if err != nil {
+		return "", err
+	}
+	return t.RenderNudge(name, vars)
+}
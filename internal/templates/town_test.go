@@ -0,0 +1,156 @@
+package templates
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeOverride(t *testing.T, townRoot, kind, file, content string) string {
+	t.Helper()
+	dir := filepath.Join(TownDir(townRoot), kind)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, file)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestRenderNudge(t *testing.T) {
+	tmpl, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	got, err := tmpl.RenderNudge(NudgeRefineryBounce, map[string]string{
+		"Branch":      "polecat/nux",
+		"Issue":       "gt-abc",
+		"FailureType": "tests",
+		"Error":       "2 failed",
+	})
+	if err != nil {
+		t.Fatalf("RenderNudge() error = %v", err)
+	}
+	want := "MERGE_FAILED: branch=polecat/nux issue=gt-abc type=tests error=2 failed — fix and resubmit with '" + CmdName() + " done'"
+	if got != want {
+		t.Errorf("RenderNudge() = %q, want %q", got, want)
+	}
+
+	// Unset variables render empty, and optional parts drop out.
+	got, err = tmpl.RenderNudge(NudgeMayorDirective, map[string]string{"Directive": "Pause the refactor"})
+	if err != nil {
+		t.Fatalf("RenderNudge() error = %v", err)
+	}
+	if strings.Contains(got, "no value") || strings.Contains(got, "(re:") {
+		t.Errorf("RenderNudge() = %q, want unset variables to drop out", got)
+	}
+
+	for _, name := range tmpl.NudgeNames() {
+		if _, err := tmpl.RenderNudge(name, nil); err != nil {
+			t.Errorf("RenderNudge(%q) error = %v", name, err)
+		}
+	}
+}
+
+func TestNewForTownOverrides(t *testing.T) {
+	townRoot := t.TempDir()
+	rolePath := writeOverride(t, townRoot, KindRole, "polecat.md.tmpl", "Custom polecat for {{ .RigName }}\n")
+	writeOverride(t, townRoot, KindNudge, "refinery-bounce.tmpl", "Bounced {{ .Branch }}\n")
+	writeOverride(t, townRoot, KindNudge, "stand-down.tmpl", "Stand down, {{ .Polecat }}\n")
+	writeOverride(t, townRoot, KindNudge, "notes.txt", "ignored")
+
+	tmpl, err := NewForTown(townRoot)
+	if err != nil {
+		t.Fatalf("NewForTown() error = %v", err)
+	}
+
+	role, err := tmpl.RenderRole("polecat", RoleData{RigName: "gastown"})
+	if err != nil {
+		t.Fatalf("RenderRole() error = %v", err)
+	}
+	if role != "Custom polecat for gastown\n" {
+		t.Errorf("RenderRole() = %q, want the override", role)
+	}
+
+	if got, _ := tmpl.RenderNudge(NudgeRefineryBounce, map[string]string{"Branch": "b"}); got != "Bounced b" {
+		t.Errorf("RenderNudge(refinery-bounce) = %q, want the override", got)
+	}
+	if got, _ := tmpl.RenderNudge("stand-down", map[string]string{"Polecat": "nux"}); got != "Stand down, nux" {
+		t.Errorf("RenderNudge(stand-down) = %q, want the town's new nudge", got)
+	}
+
+	// Untouched templates keep their defaults.
+	mayor, err := tmpl.RenderRole("mayor", RoleData{TownRoot: "/t"})
+	if err != nil || !strings.Contains(mayor, "Mayor Context") {
+		t.Errorf("RenderRole(mayor) lost its default: %v", err)
+	}
+
+	overrides := map[string]string{}
+	for _, info := range tmpl.List() {
+		overrides[info.Ref()] = info.Override
+	}
+	if overrides["roles/polecat"] != rolePath {
+		t.Errorf("List() roles/polecat override = %q, want %q", overrides["roles/polecat"], rolePath)
+	}
+	if _, ok := overrides["nudges/stand-down"]; !ok {
+		t.Error("List() missing the town's nudges/stand-down")
+	}
+	if overrides["roles/mayor"] != "" {
+		t.Errorf("List() roles/mayor should be built-in, got %q", overrides["roles/mayor"])
+	}
+}
+
+func TestNewForTownBrokenOverride(t *testing.T) {
+	townRoot := t.TempDir()
+	path := writeOverride(t, townRoot, KindNudge, "refinery-bounce.tmpl", "Bounced {{ .Branch \n")
+
+	if _, err := NewForTown(townRoot); err == nil || !strings.Contains(err.Error(), path) {
+		t.Errorf("NewForTown() error = %v, want one naming %s", err, path)
+	}
+
+	// Senders fall back to the built-in nudge.
+	got, err := RenderTownNudge(townRoot, NudgeRefineryBounce, map[string]string{"Branch": "b"})
+	if err != nil {
+		t.Fatalf("RenderTownNudge() error = %v", err)
+	}
+	if !strings.HasPrefix(got, "MERGE_FAILED: branch=b") {
+		t.Errorf("RenderTownNudge() = %q, want the built-in nudge", got)
+	}
+}
+
+func TestParseRef(t *testing.T) {
+	tmpl, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	tests := []struct {
+		ref       string
+		kind      string
+		name      string
+		wantError bool
+	}{
+		{"roles/mayor", KindRole, "mayor", false},
+		{"messages/handoff", KindMessage, "handoff", false},
+		{"mayor-directive", KindNudge, "mayor-directive", false},
+		{"witness", KindRole, "witness", false},
+		{"spawn", KindMessage, "spawn", false},
+		{"widgets/mayor", "", "", true},
+		{"roles/nobody", "", "", true},
+		{"nobody", "", "", true},
+	}
+	for _, tt := range tests {
+		kind, name, err := tmpl.ParseRef(tt.ref)
+		if (err != nil) != tt.wantError {
+			t.Errorf("ParseRef(%q) error = %v, wantError %v", tt.ref, err, tt.wantError)
+			continue
+		}
+		if kind != tt.kind || name != tt.name {
+			t.Errorf("ParseRef(%q) = %q, %q, want %q, %q", tt.ref, kind, name, tt.kind, tt.name)
+		}
+	}
+}
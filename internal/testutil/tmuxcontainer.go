@@ -0,0 +1,175 @@
+//go:build !windows
+
+package testutil
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go"
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
+)
+
+// E2EImage is the image built from Dockerfile.e2e for the end-to-end suite.
+// Keeping a fixed name lets Docker reuse the build across test runs.
+const E2EImage = "gastown-e2e-harness"
+
+// TmuxContainer is a running Dockerfile.e2e container with gt, bd, dolt,
+// tmux, and gt-fake-agent installed. Tests drive it through Exec, which runs
+// commands under bash as root with the container's tmux server.
+type TmuxContainer struct {
+	t   *testing.T
+	ctr testcontainers.Container
+}
+
+// StartTmuxContainer builds Dockerfile.e2e (cached after the first build) and
+// starts a container that idles until the test finishes. Skips the test when
+// Docker is unavailable.
+func StartTmuxContainer(t *testing.T) *TmuxContainer {
+	t.Helper()
+	if !isDockerAvailable() {
+		t.Skip("Docker not available, skipping test")
+	}
+
+	root, err := repoRoot()
+	if err != nil {
+		t.Fatalf("locating repo root: %v", err)
+	}
+
+	ctx := context.Background()
+	ctr, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			FromDockerfile: testcontainers.FromDockerfile{
+				Context:    root,
+				Dockerfile: "Dockerfile.e2e",
+				Repo:       E2EImage,
+				Tag:        "latest",
+				KeepImage:  true,
+			},
+			Entrypoint: []string{"sleep", "infinity"},
+			Env: map[string]string{
+				"TERM": "xterm-256color",
+				"HOME": "/root",
+			},
+		},
+		Started: true,
+	})
+	if err != nil {
+		t.Fatalf("starting e2e container: %v", err)
+	}
+	t.Cleanup(func() {
+		if t.Failed() {
+			if out, err := execIn(ctr, "tmux list-sessions 2>&1; tail -n 50 /town/logs/town.log 2>/dev/null"); err == nil {
+				t.Logf("container state at failure:\n%s", out)
+			}
+		}
+		if err := testcontainers.TerminateContainer(ctr); err != nil {
+			t.Logf("terminating e2e container: %v", err)
+		}
+	})
+	return &TmuxContainer{t: t, ctr: ctr}
+}
+
+// Exec runs script with bash -lc inside the container and returns its
+// combined output. A non-zero exit status is returned as an error that
+// carries the output.
+func (c *TmuxContainer) Exec(script string) (string, error) {
+	c.t.Helper()
+	return execIn(c.ctr, script)
+}
+
+// MustExec is Exec that fails the test on error.
+func (c *TmuxContainer) MustExec(script string) string {
+	c.t.Helper()
+	out, err := c.Exec(script)
+	if err != nil {
+		c.t.Fatalf("%v", err)
+	}
+	return out
+}
+
+// WriteFile writes data to path inside the container.
+func (c *TmuxContainer) WriteFile(path, data string, mode int64) {
+	c.t.Helper()
+	if err := c.ctr.CopyToContainer(context.Background(), []byte(data), path, mode); err != nil {
+		c.t.Fatalf("writing %s: %v", path, err)
+	}
+}
+
+// CapturePane returns the visible contents of a tmux session's pane.
+func (c *TmuxContainer) CapturePane(session string) (string, error) {
+	return c.Exec("tmux capture-pane -p -t " + shellQuote(session))
+}
+
+// WaitForPane polls a session's pane until it contains want.
+func (c *TmuxContainer) WaitForPane(session, want string, timeout time.Duration) {
+	c.t.Helper()
+	c.WaitFor(fmt.Sprintf("pane %s to show %q", session, want), timeout, func() (bool, string) {
+		out, err := c.CapturePane(session)
+		return err == nil && strings.Contains(out, want), out
+	})
+}
+
+// WaitFor polls cond every 250ms until it reports true or timeout passes,
+// then fails the test with the last detail cond returned.
+func (c *TmuxContainer) WaitFor(what string, timeout time.Duration, cond func() (bool, string)) {
+	c.t.Helper()
+	deadline := time.Now().Add(timeout)
+	var detail string
+	for {
+		var ok bool
+		if ok, detail = cond(); ok {
+			return
+		}
+		if time.Now().After(deadline) {
+			c.t.Fatalf("timed out after %s waiting for %s; last state:\n%s", timeout, what, detail)
+		}
+		time.Sleep(250 * time.Millisecond)
+	}
+}
+
+func execIn(ctr testcontainers.Container, script string) (string, error) {
+	code, r, err := ctr.Exec(context.Background(), []string{"bash", "-lc", script}, tcexec.Multiplexed())
+	if err != nil {
+		return "", fmt.Errorf("exec %q: %w", script, err)
+	}
+	var buf bytes.Buffer
+	if _, err := io.Copy(&buf, r); err != nil {
+		return "", fmt.Errorf("reading output of %q: %w", script, err)
+	}
+	out := buf.String()
+	if code != 0 {
+		return out, fmt.Errorf("%q exited %d:\n%s", script, code, out)
+	}
+	return out, nil
+}
+
+// repoRoot walks up from the working directory to the directory holding
+// go.mod, which is the Docker build context for Dockerfile.e2e.
+func repoRoot() (string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return dir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", fmt.Errorf("go.mod not found")
+		}
+		dir = parent
+	}
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
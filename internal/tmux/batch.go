@@ -0,0 +1,306 @@
+package tmux
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Batch queries: each tmux command is a subprocess (and, for remote rigs, an
+// ssh round trip), so checking N sessions one call at a time costs N or more
+// invocations. The functions here answer the same questions for many
+// sessions at once — one list-sessions or list-panes -a for the whole server,
+// and ";"-chained commands for per-session queries like capture-pane — so
+// callers that sweep every session (gt status, gt top, doctor) cost a
+// constant number of invocations per host.
+
+// batchBoundary separates the output of chained commands in runBatch. It
+// must not start with "-", or tmux parses it as a flag.
+const batchBoundary = "@@gt-batch-boundary@@"
+
+// paneInfoFormat is the list-panes format parsed by ListAllPanes.
+const paneInfoFormat = "#{session_name}\t#{window_index}\t#{pane_active}\t#{pane_id}\t#{pane_current_command}\t#{pane_pid}"
+
+// PaneInfo describes one pane, as reported by ListAllPanes.
+type PaneInfo struct {
+	Session     string
+	WindowIndex int
+	Active      bool   // Active pane of its window
+	PaneID      string // e.g. "%5"
+	Command     string // pane_current_command
+	PID         string
+}
+
+// runBatch runs cmds in a single tmux invocation, chained with ";", and
+// returns each command's output (trimmed, as run returns it). tmux stops at
+// the first failing command, so if the batch fails each command is re-run
+// on its own and gets its own error. All cmds must target the same server.
+func (t *Tmux) runBatch(cmds [][]string) ([]string, []error) {
+	outs := make([]string, len(cmds))
+	errs := make([]error, len(cmds))
+	if len(cmds) == 0 {
+		return outs, errs
+	}
+
+	var args []string
+	for _, c := range cmds {
+		args = append(args, c...)
+		args = append(args, ";", "display-message", "-p", batchBoundary, ";")
+	}
+	out, err := t.run(args[:len(args)-1]...)
+	if err == nil {
+		segments := splitBatchOutput(out)
+		if len(segments) == len(cmds) {
+			return segments, errs
+		}
+	}
+
+	for i, c := range cmds {
+		outs[i], errs[i] = t.run(c...)
+	}
+	return outs, errs
+}
+
+// splitBatchOutput splits runBatch output at the boundary lines.
+func splitBatchOutput(out string) []string {
+	var segments []string
+	var current []string
+	for _, line := range strings.Split(out, "\n") {
+		if line == batchBoundary {
+			segments = append(segments, strings.TrimSpace(strings.Join(current, "\n")))
+			current = nil
+			continue
+		}
+		current = append(current, line)
+	}
+	return segments
+}
+
+// onHost returns a Tmux bound to host ("" = local routing), reusing t when
+// it already is.
+func (t *Tmux) onHost(host string) *Tmux {
+	if host == t.host {
+		return t
+	}
+	return &Tmux{socketName: t.socketName, host: host}
+}
+
+// groupByHost splits sessions by the host they live on ("" = local), so a
+// batch only ever targets one tmux server. Hosts are returned sorted.
+func (t *Tmux) groupByHost(sessions []string) ([]string, map[string][]string) {
+	groups := make(map[string][]string)
+	for _, s := range sessions {
+		host := t.host
+		if host == "" {
+			host = HostForSession(s)
+		}
+		groups[host] = append(groups[host], s)
+	}
+	hosts := make([]string, 0, len(groups))
+	for host := range groups {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+	return hosts, groups
+}
+
+// ListSessionInfos returns detailed information about every session in one
+// call per server. Use it instead of GetSessionInfo or GetSessionActivity
+// in a loop.
+func (t *Tmux) ListSessionInfos() ([]*SessionInfo, error) {
+	infos, err := t.listSessionInfos()
+	if err != nil {
+		return nil, err
+	}
+	if t.host == "" {
+		for _, host := range RemoteHosts() {
+			remote, err := t.onHost(host).listSessionInfos()
+			if err != nil {
+				continue // Unreachable hosts don't hide the rest
+			}
+			for _, info := range remote {
+				if HostForSession(info.Name) == host {
+					infos = append(infos, info)
+				}
+			}
+		}
+	}
+	return infos, nil
+}
+
+func (t *Tmux) listSessionInfos() ([]*SessionInfo, error) {
+	out, err := t.run("list-sessions", "-F", sessionInfoFormat)
+	if err != nil {
+		if errors.Is(err, ErrNoServer) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var infos []*SessionInfo
+	for _, line := range strings.Split(out, "\n") {
+		if line == "" {
+			continue
+		}
+		if info, err := parseSessionInfo(line); err == nil && !IsControlSession(info.Name) {
+			infos = append(infos, info)
+		}
+	}
+	return infos, nil
+}
+
+// ListAllPanes returns every pane of every session in one call per server.
+func (t *Tmux) ListAllPanes() ([]PaneInfo, error) {
+	panes, err := t.listAllPanes()
+	if err != nil {
+		return nil, err
+	}
+	if t.host == "" {
+		for _, host := range RemoteHosts() {
+			remote, err := t.onHost(host).listAllPanes()
+			if err != nil {
+				continue
+			}
+			for _, p := range remote {
+				if HostForSession(p.Session) == host {
+					panes = append(panes, p)
+				}
+			}
+		}
+	}
+	return panes, nil
+}
+
+func (t *Tmux) listAllPanes() ([]PaneInfo, error) {
+	out, err := t.run("list-panes", "-a", "-F", paneInfoFormat)
+	if err != nil {
+		if errors.Is(err, ErrNoServer) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var panes []PaneInfo
+	for _, line := range strings.Split(out, "\n") {
+		parts := strings.SplitN(line, "\t", 6)
+		if len(parts) < 6 || IsControlSession(parts[0]) {
+			continue
+		}
+		p := PaneInfo{
+			Session: parts[0],
+			Active:  parts[2] == "1",
+			PaneID:  parts[3],
+			Command: parts[4],
+			PID:     parts[5],
+		}
+		_, _ = fmt.Sscanf(parts[1], "%d", &p.WindowIndex)
+		panes = append(panes, p)
+	}
+	return panes, nil
+}
+
+// CapturePanes captures the last lines of each target's pane (as
+// CapturePane does) with one tmux call per server. Targets that can't be
+// captured, e.g. because the session is gone, are left out of the result.
+func (t *Tmux) CapturePanes(targets []string, lines int) map[string]string {
+	result := make(map[string]string, len(targets))
+	hosts, groups := t.groupByHost(targets)
+	for _, host := range hosts {
+		group := groups[host]
+		cmds := make([][]string, len(group))
+		for i, target := range group {
+			cmds[i] = []string{"capture-pane", "-p", "-t", target, "-S", fmt.Sprintf("-%d", lines)}
+		}
+		outs, errs := t.onHost(host).runBatch(cmds)
+		for i, target := range group {
+			if errs[i] == nil {
+				result[target] = outs[i]
+			}
+		}
+	}
+	return result
+}
+
+// GetAllEnvironments returns the environment of each session (as
+// GetAllEnvironment does) with one tmux call per server. Sessions whose
+// environment can't be read are left out of the result.
+func (t *Tmux) GetAllEnvironments(sessions []string) map[string]map[string]string {
+	result := make(map[string]map[string]string, len(sessions))
+	hosts, groups := t.groupByHost(sessions)
+	for _, host := range hosts {
+		group := groups[host]
+		cmds := make([][]string, len(group))
+		for i, s := range group {
+			cmds[i] = []string{"show-environment", "-t", s}
+		}
+		outs, errs := t.onHost(host).runBatch(cmds)
+		for i, s := range group {
+			if errs[i] == nil {
+				result[s] = parseEnvironment(outs[i])
+			}
+		}
+	}
+	return result
+}
+
+// AgentsAlive reports, for each session, whether its agent is running — the
+// same answer IsAgentAlive gives — using two tmux calls per server (all
+// panes, all environments) instead of several per session. Process-tree
+// checks still run per pane, in parallel.
+func (t *Tmux) AgentsAlive(sessions []string) map[string]bool {
+	result := make(map[string]bool, len(sessions))
+	if len(sessions) == 0 {
+		return result
+	}
+
+	panes, err := t.ListAllPanes()
+	if err != nil {
+		for _, s := range sessions {
+			result[s] = t.IsAgentAlive(s)
+		}
+		return result
+	}
+	bySession := make(map[string][]PaneInfo)
+	for _, p := range panes {
+		bySession[p.Session] = append(bySession[p.Session], p)
+	}
+	envs := t.GetAllEnvironments(sessions)
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, s := range sessions {
+		wg.Add(1)
+		go func(s string) {
+			defer wg.Done()
+			env := envs[s]
+			alive := runtimeRunningInPanes(bySession[s], env["GT_PANE_ID"], processNamesFromEnv(env))
+			mu.Lock()
+			result[s] = alive
+			mu.Unlock()
+		}(s)
+	}
+	wg.Wait()
+	return result
+}
+
+// runtimeRunningInPanes is IsRuntimeRunning over already-listed panes: the
+// declared pane if there is one, otherwise any pane of the session.
+func runtimeRunningInPanes(panes []PaneInfo, declaredPane string, processNames []string) bool {
+	if len(processNames) == 0 {
+		return false
+	}
+	if declaredPane != "" {
+		for _, p := range panes {
+			if p.PaneID == declaredPane {
+				return matchesPaneRuntime(p.Command, p.PID, processNames)
+			}
+		}
+		return false
+	}
+	for _, p := range panes {
+		if matchesPaneRuntime(p.Command, p.PID, processNames) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,200 @@
+package tmux
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestSplitBatchOutput(t *testing.T) {
+	out := "one\n" + batchBoundary + "\n" + batchBoundary + "\n  two\nlines  \n" + batchBoundary
+	got := splitBatchOutput(out)
+	want := []string{"one", "", "two\nlines"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("splitBatchOutput = %q, want %q", got, want)
+	}
+}
+
+// newBatchSessions starts n sessions running a shell and returns their names.
+func newBatchSessions(tb testing.TB, tm *Tmux, n int) []string {
+	tb.Helper()
+	var names []string
+	for i := 0; i < n; i++ {
+		name := fmt.Sprintf("gt-test-batch-%d", i)
+		_ = tm.KillSession(name)
+		if err := tm.NewSession(name, ""); err != nil {
+			tb.Fatalf("NewSession: %v", err)
+		}
+		tb.Cleanup(func() { _ = tm.KillSession(name) })
+		names = append(names, name)
+	}
+	return names
+}
+
+func TestRunBatch_FallsBackPerCommand(t *testing.T) {
+	tm := newTestTmux(t)
+	names := newBatchSessions(t, tm, 2)
+
+	_ = tm.SetEnvironment(names[0], "GT_BATCH_TEST", "zero")
+	_ = tm.SetEnvironment(names[1], "GT_BATCH_TEST", "one")
+	cmds := [][]string{
+		{"show-environment", "-t", names[0], "GT_BATCH_TEST"},
+		{"show-environment", "-t", "gt-test-batch-missing", "GT_BATCH_TEST"},
+		{"show-environment", "-t", names[1], "GT_BATCH_TEST"},
+	}
+	outs, errs := tm.runBatch(cmds)
+	if errs[0] != nil || outs[0] != "GT_BATCH_TEST=zero" {
+		t.Errorf("cmd 0 = %q, %v; want GT_BATCH_TEST=zero", outs[0], errs[0])
+	}
+	if errs[1] == nil {
+		t.Errorf("cmd 1 on a missing session succeeded with %q", outs[1])
+	}
+	if errs[2] != nil || outs[2] != "GT_BATCH_TEST=one" {
+		t.Errorf("cmd 2 = %q, %v; want GT_BATCH_TEST=one", outs[2], errs[2])
+	}
+
+	outs, errs = tm.runBatch([][]string{cmds[0], cmds[2]})
+	if errs[0] != nil || errs[1] != nil || outs[0] != "GT_BATCH_TEST=zero" || outs[1] != "GT_BATCH_TEST=one" {
+		t.Errorf("batch = %q, %v", outs, errs)
+	}
+}
+
+func TestCapturePanes_MatchesCapturePane(t *testing.T) {
+	tm := newTestTmux(t)
+	names := newBatchSessions(t, tm, 3)
+
+	got := tm.CapturePanes(append(names, "gt-test-batch-missing"), 20)
+	if _, ok := got["gt-test-batch-missing"]; ok {
+		t.Error("missing session should be left out")
+	}
+	for _, name := range names {
+		want, err := tm.CapturePane(name, 20)
+		if err != nil {
+			t.Fatalf("CapturePane(%s): %v", name, err)
+		}
+		if got[name] != want {
+			t.Errorf("CapturePanes[%s] = %q, want %q", name, got[name], want)
+		}
+	}
+}
+
+func TestListSessionInfosAndPanes(t *testing.T) {
+	tm := newTestTmux(t)
+	names := newBatchSessions(t, tm, 2)
+
+	infos, err := tm.ListSessionInfos()
+	if err != nil {
+		t.Fatalf("ListSessionInfos: %v", err)
+	}
+	found := make(map[string]*SessionInfo)
+	for _, info := range infos {
+		found[info.Name] = info
+	}
+	panes, err := tm.ListAllPanes()
+	if err != nil {
+		t.Fatalf("ListAllPanes: %v", err)
+	}
+	paneCount := make(map[string]int)
+	for _, p := range panes {
+		paneCount[p.Session]++
+		if p.PaneID == "" || p.PID == "" {
+			t.Errorf("pane %+v missing ID or PID", p)
+		}
+	}
+
+	for _, name := range names {
+		info := found[name]
+		if info == nil {
+			t.Errorf("ListSessionInfos missing %s", name)
+			continue
+		}
+		if info.Windows != 1 || info.CreatedAt.IsZero() || info.ActivityAt.IsZero() {
+			t.Errorf("info for %s = %+v", name, info)
+		}
+		if paneCount[name] != 1 {
+			t.Errorf("ListAllPanes has %d panes for %s, want 1", paneCount[name], name)
+		}
+	}
+}
+
+func TestAgentsAlive_MatchesIsAgentAlive(t *testing.T) {
+	tm := newTestTmux(t)
+	names := newBatchSessions(t, tm, 3)
+
+	shell, err := tm.GetPaneCommand(names[0])
+	if err != nil {
+		t.Fatalf("GetPaneCommand: %v", err)
+	}
+	// names[0] "runs" its shell; names[1] expects an agent that isn't there;
+	// names[2] declares a pane that doesn't exist.
+	_ = tm.SetEnvironment(names[0], "GT_PROCESS_NAMES", shell)
+	_ = tm.SetEnvironment(names[1], "GT_PROCESS_NAMES", "gt-no-such-agent")
+	_ = tm.SetEnvironment(names[2], "GT_PROCESS_NAMES", shell)
+	_ = tm.SetEnvironment(names[2], "GT_PANE_ID", "%99999")
+
+	got := tm.AgentsAlive(append(names, "gt-test-batch-missing"))
+	want := map[string]bool{names[0]: true, names[1]: false, names[2]: false, "gt-test-batch-missing": false}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("AgentsAlive = %v, want %v", got, want)
+	}
+	for _, name := range names {
+		if alive := tm.IsAgentAlive(name); alive != got[name] {
+			t.Errorf("IsAgentAlive(%s) = %v, AgentsAlive says %v", name, alive, got[name])
+		}
+	}
+}
+
+// BenchmarkAgentLiveness compares checking every session one at a time with
+// the batched AgentsAlive. The tmux-calls/op metric is the number of tmux
+// subprocesses per sweep.
+func BenchmarkAgentLiveness(b *testing.B) {
+	b.Setenv("GT_TMUX_CONTROL", "0") // Count exec'd commands
+	tm := newTestTmux(b)
+	names := newBatchSessions(b, tm, 10)
+
+	run := func(b *testing.B, sweep func()) {
+		start := invocations.Load()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			sweep()
+		}
+		b.ReportMetric(float64(invocations.Load()-start)/float64(b.N), "tmux-calls/op")
+	}
+	b.Run("per-session", func(b *testing.B) {
+		run(b, func() {
+			for _, name := range names {
+				tm.IsAgentAlive(name)
+			}
+		})
+	})
+	b.Run("batched", func(b *testing.B) {
+		run(b, func() { tm.AgentsAlive(names) })
+	})
+}
+
+// BenchmarkCapture compares capturing every session's pane one at a time
+// with the batched CapturePanes.
+func BenchmarkCapture(b *testing.B) {
+	b.Setenv("GT_TMUX_CONTROL", "0") // Count exec'd commands
+	tm := newTestTmux(b)
+	names := newBatchSessions(b, tm, 10)
+
+	run := func(b *testing.B, sweep func()) {
+		start := invocations.Load()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			sweep()
+		}
+		b.ReportMetric(float64(invocations.Load()-start)/float64(b.N), "tmux-calls/op")
+	}
+	b.Run("per-session", func(b *testing.B) {
+		run(b, func() {
+			for _, name := range names {
+				_, _ = tm.CapturePane(name, 50)
+			}
+		})
+	})
+	b.Run("batched", func(b *testing.B) {
+		run(b, func() { tm.CapturePanes(names, 50) })
+	})
+}
@@ -0,0 +1,69 @@
+package tmux
+
+import (
+	"strings"
+)
+
+// EnvClientHints is the tmux session environment variable that tells nudge
+// delivery how the agent's input box takes typed text. Format:
+// comma-separated "submit=<key>" and "escape=0". Set at session startup
+// from the agent's runtime client_hints; when absent, nudges press Escape
+// then Enter, which suits Claude Code.
+const EnvClientHints = "GT_CLIENT_HINTS"
+
+// ClientHints describes how an agent runtime's input box takes typed text.
+type ClientHints struct {
+	// SubmitKey is the key that submits typed input ("" means Enter).
+	SubmitKey string
+	// SkipEscape skips the Escape pressed before submitting.
+	SkipEscape bool
+}
+
+// String formats the hints in EnvClientHints form, or "" for the defaults.
+func (h ClientHints) String() string {
+	var parts []string
+	if h.SubmitKey != "" && h.SubmitKey != "Enter" {
+		parts = append(parts, "submit="+h.SubmitKey)
+	}
+	if h.SkipEscape {
+		parts = append(parts, "escape=0")
+	}
+	return strings.Join(parts, ",")
+}
+
+// ParseClientHints parses an EnvClientHints value. Unknown or malformed
+// entries are ignored.
+func ParseClientHints(s string) ClientHints {
+	var h ClientHints
+	for _, part := range strings.Split(s, ",") {
+		key, val, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "submit":
+			h.SubmitKey = val
+		case "escape":
+			h.SkipEscape = val == "0"
+		}
+	}
+	return h
+}
+
+// submitKey returns the key that submits typed input.
+func (h ClientHints) submitKey() string {
+	if h.SubmitKey == "" {
+		return "Enter"
+	}
+	return h.SubmitKey
+}
+
+// sessionClientHints returns the client hints configured for a session via
+// EnvClientHints, or the defaults.
+func (t *Tmux) sessionClientHints(session string) ClientHints {
+	val, err := t.GetEnvironment(session, EnvClientHints)
+	if err != nil {
+		return ClientHints{}
+	}
+	return ParseClientHints(val)
+}
@@ -0,0 +1,32 @@
+package tmux
+
+import "testing"
+
+func TestClientHintsRoundTrip(t *testing.T) {
+	tests := []struct {
+		hints ClientHints
+		want  string
+	}{
+		{ClientHints{}, ""},
+		{ClientHints{SubmitKey: "Enter"}, ""},
+		{ClientHints{SkipEscape: true}, "escape=0"},
+		{ClientHints{SubmitKey: "C-m", SkipEscape: true}, "submit=C-m,escape=0"},
+	}
+	for _, tt := range tests {
+		got := tt.hints.String()
+		if got != tt.want {
+			t.Errorf("%+v.String() = %q, want %q", tt.hints, got, tt.want)
+		}
+		back := ParseClientHints(got)
+		if back.submitKey() != tt.hints.submitKey() || back.SkipEscape != tt.hints.SkipEscape {
+			t.Errorf("ParseClientHints(%q) = %+v, want %+v", got, back, tt.hints)
+		}
+	}
+}
+
+func TestParseClientHintsIgnoresJunk(t *testing.T) {
+	got := ParseClientHints("bogus, submit=Tab ,escape=1,=x")
+	if got.SubmitKey != "Tab" || got.SkipEscape {
+		t.Errorf("ParseClientHints = %+v, want SubmitKey Tab without SkipEscape", got)
+	}
+}
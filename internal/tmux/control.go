@@ -0,0 +1,496 @@
+package tmux
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Control connections: instead of exec'ing tmux for every command, the daemon
+// — the one long-lived process that talks to tmux servers constantly —
+// keeps one control-mode client (tmux -C) per server and writes commands to
+// it. Other gt processes never open one; see EnableControlConnections. Commands from all Tmux
+// values and goroutines share the connection and are pipelined — written as
+// soon as they are issued, with responses matched up in order.
+//
+// A control client has to be attached to a session, so each connection owns
+// a hidden session named _gtctl-<pid>-<n>, running cat, with
+// destroy-unattached set so tmux removes it as soon as the client goes away
+// (including when this process dies). The tmux package's session listings
+// skip these sessions. While a connection is open, its session keeps the
+// server running even after every real session is gone.
+//
+// A connection is only opened after controlWarmup exec'd commands have
+// reached the same server, so a connection never starts a server that isn't
+// already running. If the server exits or restarts, the connection dies
+// with it and the next warmup opens a fresh one. In-flight commands that
+// are safe to repeat are re-run by exec; the rest (send-keys, kills, ...)
+// may already have run, so they fail with errControlLost instead of
+// running twice.
+//
+// Only commands whose effect doesn't depend on the issuing client go over a
+// connection (see controlSafe); anything that spawns processes, attaches,
+// reads stdin, or relies on a default target is still exec'd. Set
+// GT_TMUX_CONTROL=0 to exec every command.
+
+// controlSessionPrefix names the hidden sessions that control connections
+// attach to.
+const controlSessionPrefix = "_gtctl-"
+
+// controlEndMarker is printed after each control request's commands, so the
+// reader knows where a request's output ends.
+const controlEndMarker = "@@gt-control-end@@"
+
+// controlWarmup is how many commands are exec'd against a server before a
+// control connection to it is opened.
+const controlWarmup = 4
+
+// controlRetryDelay is how long to wait before trying again after a control
+// connection fails to open.
+const controlRetryDelay = 30 * time.Second
+
+// errControlClosed is returned for requests on a connection that had died
+// before they were written.
+var errControlClosed = errors.New("tmux control connection closed")
+
+// errControlLost is returned for requests written to a connection that died
+// before answering: tmux may or may not have run them.
+var errControlLost = errors.New("tmux control connection lost before the command completed; it may have run")
+
+// controlKey identifies a tmux server: its socket and the SSH host it runs on.
+type controlKey struct {
+	socket string
+	host   string
+}
+
+// controlServer tracks exec use of, and the connection to, one server.
+type controlServer struct {
+	execs    int
+	conn     *controlConn
+	failedAt time.Time
+}
+
+var (
+	controlServers   = make(map[controlKey]*controlServer)
+	controlServersMu sync.Mutex
+	controlSeq       atomic.Int64
+	controlAllowed   atomic.Bool
+)
+
+// EnableControlConnections lets this process open control connections. The
+// daemon calls it at startup; short-lived gt commands don't, so they never
+// leave hidden sessions behind or race the daemon's connection.
+func EnableControlConnections() {
+	controlAllowed.Store(true)
+}
+
+// controlEnabled reports whether control connections may be used.
+func controlEnabled() bool {
+	return controlAllowed.Load() && os.Getenv("GT_TMUX_CONTROL") != "0"
+}
+
+// IsControlSession reports whether name is one of the hidden sessions owned
+// by a control connection.
+func IsControlSession(name string) bool {
+	return strings.HasPrefix(name, controlSessionPrefix)
+}
+
+// controlConn is one control-mode client.
+type controlConn struct {
+	cmd   *exec.Cmd
+	stdin io.WriteCloser
+
+	writeMu sync.Mutex // Serializes requests, so responses come back in pending order
+	mu      sync.Mutex // Guards pending and closed
+	pending []*controlRequest
+	closed  bool
+}
+
+// controlRequest is a command line waiting for its response.
+type controlRequest struct {
+	out  []string
+	done chan controlResult
+}
+
+type controlResult struct {
+	out    string
+	errMsg string // Set when tmux reported an error
+	err    error  // Set when the connection died
+}
+
+// controlKeyFor returns the server args are sent to.
+func (t *Tmux) controlKeyFor(args []string) controlKey {
+	host := t.host
+	if host == "" {
+		host = targetHost(args)
+	}
+	return controlKey{socket: t.socketName, host: host}
+}
+
+// runControl sends args over the server's control connection, if it has
+// one and the command is safe to send that way. ok is false if the command
+// wasn't sent (or the connection died before answering and it is safe to
+// repeat) and must be exec'd.
+func (t *Tmux) runControl(args []string) (out string, err error, ok bool) {
+	if !controlEnabled() || !controlSafe(args) {
+		return "", nil, false
+	}
+	key := t.controlKeyFor(args)
+	controlServersMu.Lock()
+	srv := controlServers[key]
+	var conn *controlConn
+	if srv != nil {
+		conn = srv.conn
+	}
+	controlServersMu.Unlock()
+	if conn == nil {
+		return "", nil, false
+	}
+
+	res := conn.send(args)
+	if res.err != nil {
+		dropControlConn(key, conn)
+		if errors.Is(res.err, errControlLost) && !controlRepeatable(args) {
+			return "", fmt.Errorf("tmux %s: %w", args[0], res.err), true
+		}
+		return "", nil, false
+	}
+	if res.errMsg != "" {
+		return "", t.wrapError(errors.New("tmux command failed"), res.errMsg, args), true
+	}
+	return res.out, nil, true
+}
+
+// noteExec records a successful exec'd command and, once the server has seen
+// controlWarmup of them, opens a control connection to it.
+func (t *Tmux) noteExec(args []string) {
+	if !controlEnabled() || len(args) == 0 || args[0] == "kill-server" {
+		return
+	}
+	key := t.controlKeyFor(args)
+	controlServersMu.Lock()
+	defer controlServersMu.Unlock()
+	srv := controlServers[key]
+	if srv == nil {
+		srv = &controlServer{}
+		controlServers[key] = srv
+	}
+	srv.execs++
+	if srv.conn != nil || srv.execs < controlWarmup || time.Since(srv.failedAt) < controlRetryDelay {
+		return
+	}
+	conn, err := openControlConn(key)
+	if err != nil {
+		srv.failedAt = time.Now()
+		return
+	}
+	srv.conn = conn
+}
+
+// dropControlConn forgets a dead connection so the server goes back to exec
+// (and, after warmup, a new connection).
+func dropControlConn(key controlKey, conn *controlConn) {
+	controlServersMu.Lock()
+	defer controlServersMu.Unlock()
+	if srv := controlServers[key]; srv != nil && srv.conn == conn {
+		srv.conn = nil
+		srv.execs = 0
+	}
+}
+
+// CloseControlConnections closes every control connection of this process.
+// Commands after this are exec'd until connections warm up again.
+func CloseControlConnections() {
+	controlServersMu.Lock()
+	var conns []*controlConn
+	for _, srv := range controlServers {
+		if srv.conn != nil {
+			conns = append(conns, srv.conn)
+			srv.conn = nil
+			srv.execs = 0
+		}
+	}
+	controlServersMu.Unlock()
+	for _, c := range conns {
+		c.close()
+	}
+}
+
+// openControlConn starts a control-mode client attached to a new hidden
+// session on the server.
+func openControlConn(key controlKey) (*controlConn, error) {
+	session := fmt.Sprintf("%s%d-%d", controlSessionPrefix, os.Getpid(), controlSeq.Add(1))
+	allArgs := []string{"-u"}
+	if key.socket != "" {
+		allArgs = append(allArgs, "-L", key.socket)
+	}
+	allArgs = append(allArgs, "-C",
+		"new-session", "-s", session, "cat", ";",
+		"set-option", "-t", session, "destroy-unattached", "on")
+	remote := &Tmux{socketName: key.socket, host: key.host}
+	cmd := remote.command(allArgs, nil)
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	invocations.Add(1)
+	c := &controlConn{cmd: cmd, stdin: stdin}
+	go c.readLoop(stdout)
+	return c, nil
+}
+
+// send writes a request and waits for its response. Requests from many
+// goroutines are pipelined on the one connection.
+func (c *controlConn) send(args []string) controlResult {
+	req := &controlRequest{done: make(chan controlResult, 1)}
+	line := controlCommandLine(args) + " ; display-message -p " + controlEndMarker + "\n"
+
+	c.writeMu.Lock()
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		c.writeMu.Unlock()
+		return controlResult{err: errControlClosed}
+	}
+	c.pending = append(c.pending, req)
+	c.mu.Unlock()
+	_, err := io.WriteString(c.stdin, line)
+	c.writeMu.Unlock()
+	if err != nil {
+		// The request may be half-written; the connection is unusable.
+		c.close()
+	}
+	return <-req.done
+}
+
+// readLoop parses the client's output into responses until it exits.
+// Output of each command arrives in a %begin ... %end (or %error) block;
+// anything outside a block is a notification and is ignored. A request's
+// commands produce one block each, ending with the end marker's block — or,
+// when one fails, a single %error block, since tmux skips the rest.
+func (c *controlConn) readLoop(stdout io.Reader) {
+	defer c.close()
+	r := bufio.NewReader(stdout)
+	var (
+		inBlock    bool
+		fromClient bool
+		blockID    string
+		body       []string
+	)
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimSuffix(line, "\n")
+
+		if !inBlock {
+			fields := strings.Fields(line)
+			switch {
+			case len(fields) == 4 && fields[0] == "%begin":
+				inBlock, blockID, body = true, fields[2], nil
+				fromClient = fields[3] == "1"
+			case len(fields) > 0 && fields[0] == "%exit":
+				return
+			}
+			continue
+		}
+
+		if fields := strings.Fields(line); len(fields) == 4 && fields[2] == blockID &&
+			(fields[0] == "%end" || fields[0] == "%error") {
+			inBlock = false
+			if fromClient {
+				c.deliver(fields[0] == "%error", body)
+			}
+			continue
+		}
+		body = append(body, line)
+	}
+}
+
+// deliver hands one block to the oldest waiting request, completing it on
+// an error or the end marker.
+func (c *controlConn) deliver(isError bool, body []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.pending) == 0 {
+		return
+	}
+	req := c.pending[0]
+	switch {
+	case isError:
+		req.done <- controlResult{errMsg: strings.TrimSpace(strings.Join(body, "\n"))}
+	case len(body) == 1 && body[0] == controlEndMarker:
+		req.done <- controlResult{out: strings.TrimSpace(strings.Join(req.out, "\n"))}
+	default:
+		req.out = append(req.out, body...)
+		return
+	}
+	c.pending = c.pending[1:]
+}
+
+// close shuts the client down and fails every waiting request.
+func (c *controlConn) close() {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return
+	}
+	c.closed = true
+	pending := c.pending
+	c.pending = nil
+	c.mu.Unlock()
+
+	_ = c.stdin.Close()
+	for _, req := range pending {
+		req.done <- controlResult{err: errControlLost}
+	}
+	go func() { _ = c.cmd.Wait() }()
+}
+
+// controlCommandLine renders args as a tmux command line: each argument
+// single-quoted, except the ";" separators between chained commands.
+func controlCommandLine(args []string) string {
+	parts := make([]string, len(args))
+	for i, a := range args {
+		if a == ";" {
+			parts[i] = ";"
+			continue
+		}
+		parts[i] = "'" + strings.ReplaceAll(a, "'", `'\''`) + "'"
+	}
+	return strings.Join(parts, " ")
+}
+
+// controlCommands are the commands that may go over a control connection,
+// mapped to the flags that make them server-wide. A command is sent only if
+// it names a target (-t) or uses one of those flags: without either, tmux
+// would apply it to the control client's own hidden session.
+var controlCommands = map[string]string{
+	"bind-key":         "*",
+	"unbind-key":       "*",
+	"list-keys":        "*",
+	"list-sessions":    "*",
+	"list-buffers":     "*",
+	"delete-buffer":    "*",
+	"capture-pane":     "",
+	"clear-history":    "",
+	"display-message":  "",
+	"has-session":      "",
+	"kill-pane":        "",
+	"kill-session":     "",
+	"kill-window":      "",
+	"list-panes":       "a",
+	"list-windows":     "a",
+	"paste-buffer":     "",
+	"rename-session":   "",
+	"resize-window":    "",
+	"select-window":    "",
+	"send-keys":        "",
+	"set-environment":  "g",
+	"set-hook":         "g",
+	"set-option":       "gs",
+	"show-environment": "g",
+	"show-options":     "gs",
+}
+
+// controlOnce are the control commands that must not run twice: re-running
+// one whose first run went unanswered could type keys twice, paste twice,
+// or kill a session that has been recreated since.
+var controlOnce = map[string]bool{
+	"delete-buffer":  true,
+	"kill-pane":      true,
+	"kill-session":   true,
+	"kill-window":    true,
+	"paste-buffer":   true,
+	"rename-session": true,
+	"send-keys":      true,
+}
+
+// controlRepeatable reports whether args may be exec'd again after a
+// control connection died while running them.
+func controlRepeatable(args []string) bool {
+	for i, a := range args {
+		if (i == 0 || args[i-1] == ";") && controlOnce[a] {
+			return false
+		}
+	}
+	return true
+}
+
+// controlSafe reports whether every command in args can be sent over a
+// control connection with the same effect as exec'ing it.
+func controlSafe(args []string) bool {
+	start := 0
+	for i := 0; i <= len(args); i++ {
+		if i < len(args) && args[i] != ";" {
+			continue
+		}
+		if !controlSafeCommand(args[start:i]) {
+			return false
+		}
+		start = i + 1
+	}
+	return true
+}
+
+func controlSafeCommand(args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+	scope, ok := controlCommands[args[0]]
+	if !ok {
+		return false
+	}
+	var flags string
+	for _, a := range args[1:] {
+		if strings.ContainsAny(a, "\n\r") {
+			return false // A control request is one line
+		}
+		if isFlagCluster(a) {
+			flags += a[1:]
+		}
+	}
+	// display-message without -p shows the message on a client's status
+	// line, which over a control connection would be the hidden client's.
+	// With -p and no formats to expand (like runBatch's boundary), the
+	// target doesn't matter.
+	if args[0] == "display-message" {
+		if !strings.Contains(flags, "p") {
+			return false
+		}
+		if !strings.Contains(args[len(args)-1], "#") {
+			return true
+		}
+	}
+	return scope == "*" || strings.Contains(flags, "t") || strings.ContainsAny(flags, scope)
+}
+
+// isFlagCluster reports whether a is a cluster of single-letter flags, like
+// "-t" or "-gv".
+func isFlagCluster(a string) bool {
+	if len(a) < 2 || a[0] != '-' {
+		return false
+	}
+	for _, r := range a[1:] {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,199 @@
+package tmux
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestControlCommandLine(t *testing.T) {
+	got := controlCommandLine([]string{"send-keys", "-t", "gt-x", "it's #{x} $HOME", ";", "has-session", "-t", "=gt-x"})
+	want := `'send-keys' '-t' 'gt-x' 'it'\''s #{x} $HOME' ; 'has-session' '-t' '=gt-x'`
+	if got != want {
+		t.Errorf("controlCommandLine = %s, want %s", got, want)
+	}
+}
+
+func TestControlSafe(t *testing.T) {
+	tests := []struct {
+		args []string
+		want bool
+	}{
+		{[]string{"has-session", "-t", "=gt-x"}, true},
+		{[]string{"list-sessions", "-F", "#{session_name}"}, true},
+		{[]string{"list-panes", "-a", "-F", "#{pane_id}"}, true},
+		{[]string{"list-panes", "-F", "#{pane_id}"}, false}, // Would list the control session's panes
+		{[]string{"set-option", "-wt", "gt-x", "window-size", "latest"}, true},
+		{[]string{"show-environment", "-g", "GT_X"}, true},
+		{[]string{"show-environment", "GT_X"}, false},
+		{[]string{"display-message", "-p", "-t", "gt-x", "#{pane_pid}"}, true},
+		{[]string{"display-message", "-t", "gt-x", "hello"}, false}, // Status line of the control client
+		{[]string{"display-message", "-p", "#{pane_pid}"}, false},
+		{[]string{"display-message", "-p", batchBoundary}, true},
+		{[]string{"new-session", "-d", "-s", "gt-x"}, false},
+		{[]string{"attach-session", "-t", "gt-x"}, false},
+		{[]string{"kill-server"}, false},
+		{[]string{"send-keys", "-t", "gt-x", "-l", "two\nlines"}, false},
+		{[]string{"capture-pane", "-p", "-t", "a", ";", "capture-pane", "-p", "-t", "b"}, true},
+		{[]string{"capture-pane", "-p", "-t", "a", ";", "split-window", "-t", "b"}, false},
+	}
+	for _, tt := range tests {
+		if got := controlSafe(tt.args); got != tt.want {
+			t.Errorf("controlSafe(%q) = %v, want %v", tt.args, got, tt.want)
+		}
+	}
+}
+
+// controlConnFor returns the connection tm's server currently has, if any.
+func controlConnFor(tm *Tmux) *controlConn {
+	controlServersMu.Lock()
+	defer controlServersMu.Unlock()
+	if srv := controlServers[controlKey{socket: tm.socketName}]; srv != nil {
+		return srv.conn
+	}
+	return nil
+}
+
+// warmControl issues enough exec'd commands to open a control connection.
+func warmControl(t *testing.T, tm *Tmux, session string) *controlConn {
+	t.Helper()
+	for i := 0; i < controlWarmup && controlConnFor(tm) == nil; i++ {
+		if _, err := tm.run("has-session", "-t", "="+session); err != nil {
+			t.Fatalf("has-session: %v", err)
+		}
+	}
+	conn := controlConnFor(tm)
+	if conn == nil {
+		t.Fatal("no control connection after warmup")
+	}
+	return conn
+}
+
+func TestControlConnection(t *testing.T) {
+	tm := newTestTmux(t)
+	t.Cleanup(CloseControlConnections)
+	names := newBatchSessions(t, tm, 2)
+	warmControl(t, tm, names[0])
+
+	// Commands now go over the connection, with no exec.
+	start := invocations.Load()
+	if err := tm.SetEnvironment(names[0], "GT_CONTROL_TEST", "it's \"quoted\" #{x}"); err != nil {
+		t.Fatalf("SetEnvironment: %v", err)
+	}
+	if got, err := tm.GetEnvironment(names[0], "GT_CONTROL_TEST"); err != nil || got != "it's \"quoted\" #{x}" {
+		t.Errorf("GetEnvironment = %q, %v", got, err)
+	}
+	if ok, err := tm.HasSession("gt-test-control-missing"); ok || err != nil {
+		t.Errorf("HasSession(missing) = %v, %v; want false, nil", ok, err)
+	}
+	if _, err := tm.GetEnvironment("gt-test-control-missing", "X"); err == nil {
+		t.Error("GetEnvironment on a missing session succeeded")
+	}
+	sessions, err := tm.ListSessions()
+	if err != nil {
+		t.Fatalf("ListSessions: %v", err)
+	}
+	for _, s := range sessions {
+		if IsControlSession(s) {
+			t.Errorf("ListSessions includes control session %s", s)
+		}
+	}
+	if got := tm.CapturePanes(names, 10); len(got) != len(names) {
+		t.Errorf("CapturePanes over control = %d panes, want %d", len(got), len(names))
+	}
+	if n := invocations.Load() - start; n != 0 {
+		t.Errorf("%d tmux subprocesses with a control connection, want 0", n)
+	}
+}
+
+func TestControlConnection_Pipelined(t *testing.T) {
+	tm := newTestTmux(t)
+	t.Cleanup(CloseControlConnections)
+	names := newBatchSessions(t, tm, 4)
+	warmControl(t, tm, names[0])
+
+	for i, name := range names {
+		_ = tm.SetEnvironment(name, "GT_PIPE", fmt.Sprint(i))
+	}
+	var wg sync.WaitGroup
+	errs := make(chan error, 100)
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			name := names[i%len(names)]
+			got, err := tm.GetEnvironment(name, "GT_PIPE")
+			if err != nil || got != fmt.Sprint(i%len(names)) {
+				errs <- fmt.Errorf("GetEnvironment(%s) = %q, %v", name, got, err)
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+func TestControlConnection_Reconnects(t *testing.T) {
+	tm := newTestTmux(t)
+	t.Cleanup(CloseControlConnections)
+	names := newBatchSessions(t, tm, 1)
+	conn := warmControl(t, tm, names[0])
+
+	// The client dies (as it does when the server restarts): the next
+	// command falls back to exec, and warmup opens a new connection.
+	_ = conn.cmd.Process.Kill()
+	if ok, err := tm.HasSession(names[0]); !ok || err != nil {
+		t.Fatalf("HasSession after the connection died = %v, %v", ok, err)
+	}
+	if next := warmControl(t, tm, names[0]); next == conn {
+		t.Error("dead connection was reused")
+	}
+	if ok, err := tm.HasSession(names[0]); !ok || err != nil {
+		t.Errorf("HasSession on the new connection = %v, %v", ok, err)
+	}
+}
+
+func TestControlRepeatable(t *testing.T) {
+	tests := []struct {
+		args []string
+		want bool
+	}{
+		{[]string{"has-session", "-t", "=gt-a"}, true},
+		{[]string{"set-environment", "-t", "gt-a", "K", "V"}, true},
+		{[]string{"send-keys", "-t", "gt-a", "-l", "hi"}, false},
+		{[]string{"display-message", "-p", "x", ";", "kill-session", "-t", "gt-a"}, false},
+		// An argument that happens to name a command isn't one.
+		{[]string{"set-environment", "-t", "gt-a", "send-keys", "1"}, true},
+	}
+	for _, tt := range tests {
+		if got := controlRepeatable(tt.args); got != tt.want {
+			t.Errorf("controlRepeatable(%q) = %v, want %v", tt.args, got, tt.want)
+		}
+	}
+}
+
+// BenchmarkHasSession compares exec'ing tmux per command with the control
+// connection.
+func BenchmarkHasSession(b *testing.B) {
+	tm := newTestTmux(b)
+	names := newBatchSessions(b, tm, 1)
+
+	b.Run("exec", func(b *testing.B) {
+		b.Setenv("GT_TMUX_CONTROL", "0")
+		for i := 0; i < b.N; i++ {
+			_, _ = tm.HasSession(names[0])
+		}
+	})
+	b.Run("control", func(b *testing.B) {
+		defer CloseControlConnections()
+		for i := 0; i < controlWarmup; i++ {
+			_, _ = tm.HasSession(names[0])
+		}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			_, _ = tm.HasSession(names[0])
+		}
+	})
+}
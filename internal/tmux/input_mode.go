@@ -0,0 +1,234 @@
+package tmux
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// InputMode classifies how an agent pane's input widget takes keys.
+type InputMode string
+
+// Input modes reported by DetectInputMode.
+const (
+	InputModeReadline  InputMode = "readline"   // Keys are echoed; Ctrl-U clears
+	InputModeVimInsert InputMode = "vim-insert" // Vim INSERT: keys are echoed, Escape leaves
+	InputModeVimNormal InputMode = "vim-normal" // Vim NORMAL: keys are commands until i
+	InputModeNoEcho    InputMode = "no-echo"    // Keys show nothing, even after i
+	InputModeUnknown   InputMode = "unknown"    // Probe failed; use the defaults
+)
+
+// inputProbe is the character typed to see whether the input widget echoes.
+// It has no binding in vim or readline NORMAL/command mode, so there it is
+// a no-op rather than a command.
+const inputProbe = "§"
+
+// inputProbeSettle is how long to wait after a probe key for the pane to
+// redraw.
+const inputProbeSettle = 150 * time.Millisecond
+
+// inputProbeTimeout is how long to keep looking for a probe's echo.
+const inputProbeTimeout = 600 * time.Millisecond
+
+// inputModeLines is how many lines at the bottom of the pane are compared.
+const inputModeLines = 30
+
+// Vim mode indicators shown in the status line by Claude Code and similar
+// TUIs.
+const (
+	vimInsertIndicator = "-- INSERT --"
+	vimNormalIndicator = "-- NORMAL --"
+)
+
+// InputStrategy is how to clear and type into an input widget in a given
+// mode.
+type InputStrategy struct {
+	// Clear are the keys that empty the input line, leaving the widget
+	// ready for typing.
+	Clear []string
+	// BeforeType are the keys that make the widget ready for typing
+	// without clearing it.
+	BeforeType []string
+	// EscapeBeforeSubmit presses Escape before the submit key, leaving
+	// vim INSERT so Enter submits rather than inserting a newline.
+	EscapeBeforeSubmit bool
+}
+
+// Strategy returns how to clear and inject input in mode m. Unknown and
+// no-echo widgets get the historical defaults: Ctrl-U, and Escape before
+// submitting in case vim mode is on.
+func (m InputMode) Strategy() InputStrategy {
+	switch m {
+	case InputModeReadline:
+		return InputStrategy{Clear: []string{"C-u"}}
+	case InputModeVimInsert:
+		// cc changes the whole line and stays in INSERT.
+		return InputStrategy{Clear: []string{"Escape", "c", "c"}, EscapeBeforeSubmit: true}
+	case InputModeVimNormal:
+		return InputStrategy{Clear: []string{"c", "c"}, BeforeType: []string{"i"}, EscapeBeforeSubmit: true}
+	default:
+		return InputStrategy{Clear: []string{"C-u"}, EscapeBeforeSubmit: true}
+	}
+}
+
+// DetectInputMode classifies the input widget of the session's agent pane.
+// A vim mode indicator in the status line answers without touching the
+// widget. Otherwise it types a probe character and checks whether it was
+// echoed; if not, it tries again after i (vim's insert command) to tell
+// NORMAL mode from a widget that echoes nothing. The probe is then undone
+// and the input checked against how it started: if anything is left
+// behind, Escape is sent and the mode is reported unknown, so callers fall
+// back to the defaults.
+func (t *Tmux) DetectInputMode(session string) (InputMode, error) {
+	t = t.forSession(session)
+	target := session
+	if agentPane, err := t.FindAgentPane(session); err == nil && agentPane != "" {
+		target = agentPane
+	}
+	return t.detectInputMode(target)
+}
+
+func (t *Tmux) detectInputMode(target string) (InputMode, error) {
+	before, err := t.CapturePaneLines(target, inputModeLines)
+	if err != nil {
+		return InputModeUnknown, err
+	}
+	switch {
+	case paneShows(before, vimInsertIndicator):
+		return InputModeVimInsert, nil
+	case paneShows(before, vimNormalIndicator):
+		return InputModeVimNormal, nil
+	}
+
+	mode, err := t.probeInputMode(target, before)
+	if err != nil {
+		return InputModeUnknown, err
+	}
+	if !t.probeUndone(target, before) {
+		_ = t.sendProbeKeys(target, "Escape")
+		return InputModeUnknown, nil
+	}
+	return mode, nil
+}
+
+// probeInputMode types the probe and classifies the widget by how it
+// responds, rubbing the probe out again.
+func (t *Tmux) probeInputMode(target string, before []string) (InputMode, error) {
+	if err := t.sendProbeKeys(target, inputProbe); err != nil {
+		return InputModeUnknown, err
+	}
+	after, echoed := t.awaitProbeEcho(target, before)
+	if echoed {
+		_ = t.sendProbeKeys(target, "BSpace")
+		if paneShows(after, vimInsertIndicator) {
+			return InputModeVimInsert, nil
+		}
+		return InputModeReadline, nil
+	}
+
+	// Nothing echoed: either vim NORMAL ignored the probe, or the widget
+	// doesn't echo. Trust a NORMAL indicator; otherwise enter insert and
+	// probe again.
+	if paneShows(after, vimNormalIndicator) {
+		return InputModeVimNormal, nil
+	}
+	if err := t.sendProbeKeys(target, "i", inputProbe); err != nil {
+		return InputModeUnknown, err
+	}
+	if _, echoed := t.awaitProbeEcho(target, before); echoed {
+		_ = t.sendProbeKeys(target, "BSpace", "Escape")
+		return InputModeVimNormal, nil
+	}
+	// The i and the probe may have been taken silently; rub them out.
+	_ = t.sendProbeKeys(target, "BSpace", "BSpace")
+	return InputModeNoEcho, nil
+}
+
+// awaitProbeEcho captures the pane until the probe shows up, for up to
+// inputProbeTimeout: a busy TUI can take longer than one settle to redraw.
+// Returns the last capture.
+func (t *Tmux) awaitProbeEcho(target string, before []string) ([]string, bool) {
+	deadline := time.Now().Add(inputProbeTimeout)
+	for {
+		after, err := t.CapturePaneLines(target, inputModeLines)
+		if err == nil && probeEchoed(before, after) {
+			return after, true
+		}
+		if time.Now().After(deadline) {
+			return after, false
+		}
+		time.Sleep(inputProbeSettle)
+	}
+}
+
+// probeUndone reports whether the input is back to how it was before
+// probing. A late echo left at the end of the input is rubbed out first.
+func (t *Tmux) probeUndone(target string, before []string) bool {
+	want := rawInputRegion(before)
+	for attempt := 0; attempt < 2; attempt++ {
+		after, err := t.CapturePaneLines(target, inputModeLines)
+		if err != nil {
+			return false
+		}
+		got := rawInputRegion(after)
+		if got == want {
+			return true
+		}
+		leftover, ok := strings.CutPrefix(got, want)
+		if !ok || !strings.Contains(leftover, inputProbe) || strings.Contains(leftover, "\n") {
+			return false
+		}
+		keys := make([]string, utf8.RuneCountInString(leftover))
+		for i := range keys {
+			keys[i] = "BSpace"
+		}
+		_ = t.sendProbeKeys(target, keys...)
+	}
+	return false
+}
+
+// sendProbeKeys sends keys one at a time and waits for the pane to redraw.
+// Keys that aren't tmux key names are sent literally.
+func (t *Tmux) sendProbeKeys(target string, keys ...string) error {
+	for _, key := range keys {
+		args := []string{"send-keys", "-t", target, key}
+		if key == inputProbe || len(key) == 1 {
+			args = []string{"send-keys", "-t", target, "-l", key}
+		}
+		if _, err := t.run(args...); err != nil {
+			return err
+		}
+	}
+	time.Sleep(inputProbeSettle)
+	return nil
+}
+
+// probeEchoed reports whether the probe appeared at the bottom of the pane
+// between the before and after captures.
+func probeEchoed(before, after []string) bool {
+	count := func(lines []string) int {
+		return strings.Count(rawInputRegion(lines), inputProbe)
+	}
+	return count(after) > count(before)
+}
+
+// paneShows reports whether any of the bottom lines contain s.
+func paneShows(lines []string, s string) bool {
+	for _, line := range lines {
+		if strings.Contains(line, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// sendInputKeys sends each key, as from an InputStrategy, as its own
+// send-keys call.
+func (t *Tmux) sendInputKeys(target string, keys []string) error {
+	for _, key := range keys {
+		if _, err := t.run("send-keys", "-t", target, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package tmux
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestProbeEchoed(t *testing.T) {
+	before := []string{"output", "> draft"}
+	if !probeEchoed(before, []string{"output", "> draft" + inputProbe}) {
+		t.Error("probe after the draft not seen")
+	}
+	if probeEchoed(before, before) {
+		t.Error("unchanged pane counted as an echo")
+	}
+	// A probe left in the scrollback isn't a new echo.
+	old := []string{"> " + inputProbe, "> draft"}
+	if probeEchoed(old, old) {
+		t.Error("old probe counted as an echo")
+	}
+}
+
+func TestInputModeStrategy(t *testing.T) {
+	if s := InputModeReadline.Strategy(); s.EscapeBeforeSubmit || strings.Join(s.Clear, " ") != "C-u" {
+		t.Errorf("readline strategy = %+v", s)
+	}
+	if s := InputModeVimNormal.Strategy(); !s.EscapeBeforeSubmit || strings.Join(s.BeforeType, " ") != "i" {
+		t.Errorf("vim-normal strategy = %+v", s)
+	}
+	if s := InputModeVimInsert.Strategy(); !s.EscapeBeforeSubmit || len(s.BeforeType) != 0 {
+		t.Errorf("vim-insert strategy = %+v", s)
+	}
+	// Unknown widgets keep the historical behavior.
+	if s := InputModeUnknown.Strategy(); !s.EscapeBeforeSubmit || strings.Join(s.Clear, " ") != "C-u" {
+		t.Errorf("unknown strategy = %+v", s)
+	}
+}
+
+// TestDetectInputMode probes a bash prompt in emacs mode, vi insert mode,
+// and vi command mode.
+func TestDetectInputMode(t *testing.T) {
+	tm := newTestTmux(t)
+	session := "gt-test-inputmode"
+	_ = tm.KillSession(session)
+	defer func() { _ = tm.KillSession(session) }()
+
+	if err := tm.NewSessionWithCommand(session, "", "env LANG=C.UTF-8 PS1='> ' bash --norc --noprofile"); err != nil {
+		t.Fatalf("session creation: %v", err)
+	}
+	time.Sleep(500 * time.Millisecond)
+
+	setup := func(cmd string) {
+		t.Helper()
+		if err := tm.SendKeys(session, cmd); err != nil {
+			t.Fatalf("SendKeys: %v", err)
+		}
+		time.Sleep(300 * time.Millisecond)
+	}
+
+	if mode, err := tm.DetectInputMode(session); err != nil || mode != InputModeReadline {
+		t.Errorf("emacs mode: DetectInputMode() = %s, %v; want readline", mode, err)
+	}
+
+	setup("set -o vi")
+	if mode, err := tm.DetectInputMode(session); err != nil || mode != InputModeReadline {
+		t.Errorf("vi insert (no indicator): DetectInputMode() = %s, %v; want readline", mode, err)
+	}
+
+	if _, err := tm.run("send-keys", "-t", session, "Escape"); err != nil {
+		t.Fatalf("Escape: %v", err)
+	}
+	time.Sleep(300 * time.Millisecond)
+	if mode, err := tm.DetectInputMode(session); err != nil || mode != InputModeVimNormal {
+		t.Errorf("vi command: DetectInputMode() = %s, %v; want vim-normal", mode, err)
+	}
+
+	// The probe leaves nothing behind.
+	if _, err := tm.run("send-keys", "-t", session, "i"); err != nil {
+		t.Fatalf("i: %v", err)
+	}
+	setup("echo probe-done")
+	lines, err := tm.CapturePaneLines(session, 50)
+	if err != nil {
+		t.Fatalf("capture: %v", err)
+	}
+	pane := strings.Join(lines, "\n")
+	if !strings.Contains(pane, "\nprobe-done") {
+		t.Errorf("command after probing did not run cleanly:\n%s", pane)
+	}
+}
+
+// TestDetectInputModeIndicatorDoesNotProbe checks that a vim mode indicator
+// on screen is trusted without typing into the input.
+func TestDetectInputModeIndicatorDoesNotProbe(t *testing.T) {
+	tm := newTestTmux(t)
+	session := "gt-test-inputmode-indicator"
+	_ = tm.KillSession(session)
+	defer func() { _ = tm.KillSession(session) }()
+
+	if err := tm.NewSessionWithCommand(session, "", "env LANG=C.UTF-8 PS1='-- INSERT -- > ' bash --norc --noprofile"); err != nil {
+		t.Fatalf("session creation: %v", err)
+	}
+	time.Sleep(500 * time.Millisecond)
+
+	if mode, err := tm.DetectInputMode(session); err != nil || mode != InputModeVimInsert {
+		t.Errorf("DetectInputMode() = %s, %v; want vim-insert from the indicator", mode, err)
+	}
+	lines, err := tm.CapturePaneLines(session, 10)
+	if err != nil {
+		t.Fatalf("capture: %v", err)
+	}
+	if pane := strings.Join(lines, "\n"); strings.Contains(pane, inputProbe) {
+		t.Errorf("probe typed despite the indicator:\n%s", pane)
+	}
+}
@@ -0,0 +1,230 @@
+package tmux
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/redact"
+)
+
+// InputSnapshot is the pending input of a session's agent pane, saved just
+// before a nudge sets it aside so `gt nudge undo` can put it back.
+type InputSnapshot struct {
+	Session string    `json:"session"`
+	Text    string    `json:"text"`
+	Raw     bool      `json:"raw,omitempty"` // No prompt found; Text is the bottom of the pane
+	At      time.Time `json:"at"`
+}
+
+// rawRegionLines is how many non-blank lines from the bottom of the pane
+// are saved when no prompt line can be found.
+const rawRegionLines = 5
+
+// promptMarkers start an agent input line. Shell prompts are deliberately
+// excluded: clearing a shell line is not something to undo.
+var promptMarkers = []string{"❯", ">"}
+
+// ExtractPendingInput finds the text typed at the agent prompt in captured
+// pane lines: the last prompt line plus its wrapped continuation lines, up
+// to the input box border. Box-drawing side borders are stripped, and
+// wrapped CJK text is rejoined without spurious spaces. Returns false when
+// no prompt line is visible.
+func ExtractPendingInput(lines []string) (string, bool) {
+	start := -1
+	var first string
+	for i := len(lines) - 1; i >= 0; i-- {
+		if rest, ok := cutPromptMarker(stripBoxBorder(lines[i])); ok {
+			start, first = i, rest
+			break
+		}
+	}
+	if start < 0 {
+		return "", false
+	}
+
+	parts := []string{strings.TrimSpace(first)}
+	for _, line := range lines[start+1:] {
+		line = stripBoxBorder(line)
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || isBoxRule(trimmed) {
+			break
+		}
+		parts = append(parts, trimmed)
+	}
+	return strings.TrimSpace(joinWrapped(parts)), true
+}
+
+// emptyPromptHints start the placeholder text some TUIs show in an empty
+// input box (Claude Code's 'Try "..."'). It reads like typed input in a
+// plain capture, but there is nothing to set aside.
+var emptyPromptHints = []string{`Try "`}
+
+// isEmptyPromptHint reports whether extracted input is a placeholder.
+func isEmptyPromptHint(text string) bool {
+	for _, h := range emptyPromptHints {
+		if strings.HasPrefix(text, h) {
+			return true
+		}
+	}
+	return false
+}
+
+// rawInputRegion returns the last few non-blank pane lines.
+func rawInputRegion(lines []string) string {
+	var region []string
+	for i := len(lines) - 1; i >= 0 && len(region) < rawRegionLines; i-- {
+		if strings.TrimSpace(lines[i]) != "" {
+			region = append([]string{strings.TrimRight(lines[i], " ")}, region...)
+		}
+	}
+	return strings.Join(region, "\n")
+}
+
+// cutPromptMarker returns the text after a leading prompt marker.
+func cutPromptMarker(line string) (string, bool) {
+	line = strings.ReplaceAll(line, "\u00a0", " ") // Claude Code pads ❯ with NBSP
+	trimmed := strings.TrimLeft(line, " ")
+	for _, m := range promptMarkers {
+		rest, ok := strings.CutPrefix(trimmed, m)
+		if ok && (rest == "" || rest[0] == ' ') {
+			return rest, true
+		}
+	}
+	return "", false
+}
+
+// stripBoxBorder removes the │ side borders of a TUI input box.
+func stripBoxBorder(line string) string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "│")
+	return strings.TrimSuffix(line, "│")
+}
+
+// isBoxRule reports whether a line is a horizontal rule or box edge.
+func isBoxRule(line string) bool {
+	for _, r := range []string{"─", "╭", "╰", "━"} {
+		if strings.HasPrefix(line, r) {
+			return true
+		}
+	}
+	return false
+}
+
+// inputUndoPath returns <townRoot>/.runtime/nudge_undo/<session>.json.
+func inputUndoPath(townRoot, session string) string {
+	return filepath.Join(townRoot, constants.DirRuntime, "nudge_undo", session+".json")
+}
+
+// setAsidePendingInput clears text typed at the prompt of target, saving it
+// under GT_ROOT for gt nudge undo, and returns it. Returns "" when the
+// prompt is empty or can't be found, and ErrClearStalled when the text is
+// still there after clearing (the nudge would be submitted with it).
+func (t *Tmux) setAsidePendingInput(session, target string, mode InputMode) (string, error) {
+	lines, err := t.CapturePaneLines(target, 0)
+	if err != nil {
+		return "", nil
+	}
+	text, ok := ExtractPendingInput(lines)
+	if !ok || text == "" || isEmptyPromptHint(text) {
+		return "", nil
+	}
+	if townRoot := os.Getenv("GT_ROOT"); townRoot != "" {
+		snap := InputSnapshot{Session: session, Text: text, At: time.Now()}
+		if err := saveInputSnapshot(townRoot, snap); err != nil {
+			return "", fmt.Errorf("saving input snapshot: %w", err)
+		}
+	}
+	if err := t.sendInputKeys(target, mode.Strategy().Clear); err != nil {
+		return "", err
+	}
+	time.Sleep(inputProbeSettle)
+	if lines, err := t.CapturePaneLines(target, 0); err == nil {
+		if after, ok := ExtractPendingInput(lines); ok && after == text {
+			return "", fmt.Errorf("%w at %s", ErrClearStalled, target)
+		}
+	}
+	return text, nil
+}
+
+// restoreSetAsideInput types text set aside by setAsidePendingInput back
+// into target after a nudge was submitted, leaving the widget in mode.
+// escaped reports that Escape was pressed before the submit.
+func (t *Tmux) restoreSetAsideInput(target, text string, mode InputMode, escaped bool) error {
+	time.Sleep(300 * time.Millisecond) // Let the submit land first
+	vim := mode == InputModeVimInsert || mode == InputModeVimNormal
+	if vim && escaped {
+		// The Escape before submit left vim NORMAL.
+		if err := t.sendInputKeys(target, []string{"i"}); err != nil {
+			return err
+		}
+	}
+	if err := t.typePendingInput(target, text); err != nil {
+		return fmt.Errorf("restoring input: %w", err)
+	}
+	if mode == InputModeVimNormal {
+		return t.sendInputKeys(target, []string{"Escape"})
+	}
+	return nil
+}
+
+// saveInputSnapshot writes snap as the session's undo file. Secrets in the
+// text are redacted first: people paste tokens at prompts, and the undo
+// file outlives the session.
+func saveInputSnapshot(townRoot string, snap InputSnapshot) error {
+	snap.Text = redact.String(townRoot, snap.Text)
+	path := inputUndoPath(townRoot, snap.Session)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(snap, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+// LoadInputSnapshot returns the input last cleared from session.
+func LoadInputSnapshot(townRoot, session string) (*InputSnapshot, error) {
+	data, err := os.ReadFile(inputUndoPath(townRoot, session))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("no cleared input saved for %s", session)
+		}
+		return nil, err
+	}
+	var snap InputSnapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, fmt.Errorf("parsing input snapshot: %w", err)
+	}
+	return &snap, nil
+}
+
+// RestoreInput types text back into the session's agent pane without
+// submitting it. Text goes through the nudge delivery path, so long or
+// multi-byte input is chunked on character boundaries.
+func (t *Tmux) RestoreInput(session, text string) error {
+	t = t.forSession(session)
+	target := session
+	if agentPane, err := t.FindAgentPane(session); err == nil && agentPane != "" {
+		target = agentPane
+	}
+	return t.typePendingInput(target, text)
+}
+
+// typePendingInput types text into target's input without submitting it.
+// send-keys turns each newline into Enter, so multi-line text is pasted
+// instead: bracketed paste keeps its line breaks as line breaks in TUIs that
+// support it.
+func (t *Tmux) typePendingInput(target, text string) error {
+	text = strings.TrimRight(sanitizeNudgeMessage(text), "\n")
+	if strings.Contains(text, "\n") {
+		return t.pasteToTarget(target, text, constants.NudgeReadyTimeout)
+	}
+	return t.sendMessageToTarget(target, text, constants.NudgeReadyTimeout)
+}
@@ -0,0 +1,172 @@
+package tmux
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExtractPendingInput(t *testing.T) {
+	tests := []struct {
+		name   string
+		lines  []string
+		want   string
+		wantOK bool
+	}{
+		{
+			name:   "claude prompt with NBSP",
+			lines:  []string{"⏺ Done.", "", "❯ fix the flaky test in", "  parser_test.go", "──────────", "  ? for shortcuts"},
+			want:   "fix the flaky test in parser_test.go",
+			wantOK: true,
+		},
+		{
+			name:   "boxed input",
+			lines:  []string{"╭────────╮", "│ > run the linter │", "╰────────╯"},
+			want:   "run the linter",
+			wantOK: true,
+		},
+		{
+			name:   "empty prompt",
+			lines:  []string{"output", "❯ ", "──────────"},
+			want:   "",
+			wantOK: true,
+		},
+		{
+			name:   "last prompt wins",
+			lines:  []string{"> old question", "answer", "> new draft"},
+			want:   "new draft",
+			wantOK: true,
+		},
+		{
+			name:   "redirect is not a prompt",
+			lines:  []string{"$ echo hi >out.txt", "building..."},
+			wantOK: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ExtractPendingInput(tt.lines)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("ExtractPendingInput() = %q, %v; want %q, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestRawInputRegion(t *testing.T) {
+	lines := []string{"a", "b", "", "c", "d", "e", "f  ", ""}
+	if got, want := rawInputRegion(lines), "b\nc\nd\ne\nf"; got != want {
+		t.Errorf("rawInputRegion() = %q, want %q", got, want)
+	}
+}
+
+func TestInputSnapshotRoundTrip(t *testing.T) {
+	town := t.TempDir()
+	if _, err := LoadInputSnapshot(town, "gt-crew-max"); err == nil {
+		t.Fatal("expected error before any snapshot is saved")
+	}
+
+	snap := InputSnapshot{Session: "gt-crew-max", Text: "half-written plan", At: time.Now().Truncate(time.Second)}
+	if err := saveInputSnapshot(town, snap); err != nil {
+		t.Fatalf("saveInputSnapshot: %v", err)
+	}
+	got, err := LoadInputSnapshot(town, "gt-crew-max")
+	if err != nil {
+		t.Fatalf("LoadInputSnapshot: %v", err)
+	}
+	if got.Text != snap.Text || got.Raw || !got.At.Equal(snap.At) {
+		t.Errorf("LoadInputSnapshot() = %+v, want %+v", got, snap)
+	}
+}
+
+func TestInputSnapshotRedactsSecrets(t *testing.T) {
+	town := t.TempDir()
+	snap := InputSnapshot{Session: "gt-crew-max", Text: "export API_KEY=abcd1234efgh5678 && deploy", At: time.Now()}
+	if err := saveInputSnapshot(town, snap); err != nil {
+		t.Fatalf("saveInputSnapshot: %v", err)
+	}
+	got, err := LoadInputSnapshot(town, "gt-crew-max")
+	if err != nil {
+		t.Fatalf("LoadInputSnapshot: %v", err)
+	}
+	if strings.Contains(got.Text, "abcd1234efgh5678") || !strings.Contains(got.Text, "deploy") {
+		t.Errorf("saved text = %q, want the key redacted and the rest kept", got.Text)
+	}
+}
+
+// TestNudgeRestoresPendingInput nudges a bash prompt with a half-typed
+// command: the nudge runs on its own and the draft is typed back unrun.
+func TestNudgeRestoresPendingInput(t *testing.T) {
+	tm := newTestTmux(t)
+	session := "gt-test-nudge-restore"
+	_ = tm.KillSession(session)
+	defer func() { _ = tm.KillSession(session) }()
+
+	if err := tm.NewSessionWithCommand(session, "", "env LANG=C.UTF-8 PS1='> ' bash --norc --noprofile"); err != nil {
+		t.Fatalf("session creation: %v", err)
+	}
+	time.Sleep(500 * time.Millisecond)
+	if _, err := tm.run("send-keys", "-t", session, "-l", "echo draft-ran"); err != nil {
+		t.Fatalf("typing draft: %v", err)
+	}
+	time.Sleep(200 * time.Millisecond)
+
+	if err := tm.NudgeSessionWith(session, "echo nudge-ran", NudgeOptions{RestoreInput: true}); err != nil {
+		t.Fatalf("NudgeSessionWith: %v", err)
+	}
+	time.Sleep(300 * time.Millisecond)
+
+	lines, err := tm.CapturePaneLines(session, 50)
+	if err != nil {
+		t.Fatalf("capture: %v", err)
+	}
+	pane := strings.Join(lines, "\n")
+	if !strings.Contains(pane, "\nnudge-ran") {
+		t.Errorf("nudge did not run on its own:\n%s", pane)
+	}
+	if strings.Contains(pane, "\ndraft-ran") {
+		t.Errorf("draft was submitted:\n%s", pane)
+	}
+	if pending, _ := ExtractPendingInput(lines); pending != "echo draft-ran" {
+		t.Errorf("pending input after nudge = %q, want the draft back", pending)
+	}
+}
+
+// TestRestoreInputMultiLine restores two lines into a bash prompt: neither
+// runs, and both stay in the input.
+func TestRestoreInputMultiLine(t *testing.T) {
+	tm := newTestTmux(t)
+	session := "gt-test-restore-multiline"
+	_ = tm.KillSession(session)
+	defer func() { _ = tm.KillSession(session) }()
+
+	if err := tm.NewSessionWithCommand(session, "", "env LANG=C.UTF-8 PS1='> ' bash --norc --noprofile"); err != nil {
+		t.Fatalf("session creation: %v", err)
+	}
+	time.Sleep(500 * time.Millisecond)
+	if err := tm.RestoreInput(session, "echo first-ran\necho second-ran\n"); err != nil {
+		t.Fatalf("RestoreInput: %v", err)
+	}
+	time.Sleep(300 * time.Millisecond)
+
+	lines, err := tm.CapturePaneLines(session, 50)
+	if err != nil {
+		t.Fatalf("capture: %v", err)
+	}
+	pane := strings.Join(lines, "\n")
+	if strings.Contains(pane, "\nfirst-ran") || strings.Contains(pane, "\nsecond-ran") {
+		t.Errorf("restored input was submitted:\n%s", pane)
+	}
+	if !strings.Contains(pane, "echo first-ran\necho second-ran") {
+		t.Errorf("restored input missing or joined into one line:\n%s", pane)
+	}
+}
+
+func TestIsEmptyPromptHint(t *testing.T) {
+	if !isEmptyPromptHint(`Try "refactor the parser"`) {
+		t.Error("placeholder not recognized")
+	}
+	if isEmptyPromptHint("try the other branch") {
+		t.Error("typed text taken for a placeholder")
+	}
+}
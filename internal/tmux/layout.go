@@ -0,0 +1,181 @@
+package tmux
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+// LayoutAgentPane is the layout name of the agent's own pane, which other
+// panes can split.
+const LayoutAgentPane = "agent"
+
+// defaultLayoutPaneSize is a layout pane's share of its split, in percent,
+// when the layout doesn't say.
+const defaultLayoutPaneSize = 30
+
+// LayoutPaneEnvKey returns the session environment variable holding the pane
+// ID of the named layout pane, e.g. GT_LAYOUT_PANE_LOGS.
+func LayoutPaneEnvKey(name string) string {
+	return "GT_LAYOUT_PANE_" + strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+// ValidateLayout checks a declared layout without touching tmux: names are
+// unique, each pane splits the agent or an earlier pane, and splits and
+// sizes are in range.
+func ValidateLayout(panes []config.RolePaneConfig) error {
+	keys := map[string]string{LayoutPaneEnvKey(LayoutAgentPane): LayoutAgentPane}
+	for _, p := range panes {
+		if p.Name == "" {
+			return fmt.Errorf("layout pane has no name")
+		}
+		key := LayoutPaneEnvKey(p.Name)
+		if other, dup := keys[key]; dup {
+			return fmt.Errorf("layout pane %q clashes with %q", p.Name, other)
+		}
+		target := p.Target
+		if target == "" {
+			target = LayoutAgentPane
+		}
+		if _, ok := keys[LayoutPaneEnvKey(target)]; !ok {
+			return fmt.Errorf("layout pane %q splits unknown pane %q (panes can only split the agent or an earlier pane)", p.Name, target)
+		}
+		switch p.Split {
+		case "", "right", "below":
+		default:
+			return fmt.Errorf("layout pane %q: invalid split %q (want right or below)", p.Name, p.Split)
+		}
+		if p.Size < 0 || p.Size > 90 {
+			return fmt.Errorf("layout pane %q: size %d%% out of range (1-90)", p.Name, p.Size)
+		}
+		keys[key] = p.Name
+	}
+	return nil
+}
+
+// ApplyLayout opens the declared panes beside the session's agent pane and
+// records each one's pane ID under LayoutPaneEnvKey. Panes open detached and
+// after the pane they split, so the agent pane keeps focus and stays the
+// session's first pane; GT_PANE_ID should already name it so nudges and
+// captures keep targeting it. Placeholders in commands must already be
+// expanded. The layout is validated before any pane opens.
+func (t *Tmux) ApplyLayout(session, workDir string, panes []config.RolePaneConfig) error {
+	if len(panes) == 0 {
+		return nil
+	}
+	if err := ValidateLayout(panes); err != nil {
+		return err
+	}
+	agent, err := t.FindAgentPane(session)
+	if err != nil {
+		return fmt.Errorf("finding agent pane: %w", err)
+	}
+	if agent == "" {
+		if agent, err = t.GetPaneID(session); err != nil {
+			return fmt.Errorf("finding agent pane: %w", err)
+		}
+	}
+
+	ids := map[string]string{LayoutAgentPane: agent}
+	for _, p := range panes {
+		target := p.Target
+		if target == "" {
+			target = LayoutAgentPane
+		}
+		dir := "-h"
+		if p.Split == "below" {
+			dir = "-v"
+		}
+		size := p.Size
+		if size == 0 {
+			size = defaultLayoutPaneSize
+		}
+		args := []string{"split-window", "-d", dir, "-l", fmt.Sprintf("%d%%", size),
+			"-t", ids[target], "-P", "-F", "#{pane_id}"}
+		if workDir != "" {
+			args = append(args, "-c", workDir)
+		}
+		if p.Command != "" {
+			args = append(args, p.Command)
+		}
+		out, err := t.run(args...)
+		if err != nil {
+			return fmt.Errorf("opening layout pane %q: %w", p.Name, err)
+		}
+		id := strings.TrimSpace(out)
+		ids[p.Name] = id
+		_ = t.SetEnvironment(session, LayoutPaneEnvKey(p.Name), id)
+		_, _ = t.run("select-pane", "-t", id, "-T", p.Name)
+	}
+	return nil
+}
+
+// LayoutPane returns the pane ID of a session's named layout pane; the name
+// "agent" returns the agent pane.
+func (t *Tmux) LayoutPane(session, name string) (string, error) {
+	if name == LayoutAgentPane {
+		return t.agentPaneTarget(session), nil
+	}
+	id, err := t.GetEnvironment(session, LayoutPaneEnvKey(name))
+	if err != nil || id == "" {
+		return "", fmt.Errorf("session %s has no layout pane %q", session, name)
+	}
+	return id, nil
+}
+
+// agentPaneCacheTTL is how long a session's agent pane ID is reused before
+// GT_PANE_ID is read again. Pane IDs don't change for a session's lifetime,
+// and sessions this process kills, creates, or renames are dropped at once;
+// the TTL covers sessions replaced by another process.
+const agentPaneCacheTTL = 30 * time.Second
+
+// agentPaneEntry is a cached agent pane ID.
+type agentPaneEntry struct {
+	pane string
+	at   time.Time
+}
+
+// agentPanes caches agent pane IDs by server and session, so captures and
+// probes don't pay an extra show-environment round trip each time.
+var agentPanes sync.Map // agentPaneKey → agentPaneEntry
+
+func (t *Tmux) agentPaneKey(session string) string {
+	return t.socketName + "\x00" + t.host + "\x00" + session
+}
+
+// forgetAgentPane drops a session's cached agent pane ID.
+func (t *Tmux) forgetAgentPane(session string) {
+	agentPanes.Delete(t.agentPaneKey(session))
+}
+
+// agentPaneTarget returns the tmux target for a session's agent pane: the
+// declared GT_PANE_ID, else the session's first window (legacy sessions).
+// Pane IDs and explicit session:window.pane targets pass through unchanged.
+func (t *Tmux) agentPaneTarget(target string) string {
+	if strings.HasPrefix(target, "%") || strings.ContainsAny(target, ":.") {
+		return target
+	}
+	key := t.agentPaneKey(target)
+	if v, ok := agentPanes.Load(key); ok {
+		if e := v.(agentPaneEntry); time.Since(e.at) < agentPaneCacheTTL {
+			return e.pane
+		}
+	}
+	if pane, err := t.GetEnvironment(target, "GT_PANE_ID"); err == nil && pane != "" {
+		agentPanes.Store(key, agentPaneEntry{pane: pane, at: time.Now()})
+		return pane
+	}
+	// Not cached: GT_PANE_ID may not be set yet on a session being built.
+	return target + ":^"
+}
@@ -0,0 +1,150 @@
+package tmux
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+func TestValidateLayout(t *testing.T) {
+	tests := []struct {
+		name    string
+		panes   []config.RolePaneConfig
+		wantErr string
+	}{
+		{"empty", nil, ""},
+		{"logs and shell", []config.RolePaneConfig{
+			{Name: "logs", Size: 40},
+			{Name: "shell", Split: "below", Target: "logs"},
+		}, ""},
+		{"no name", []config.RolePaneConfig{{}}, "no name"},
+		{"duplicate", []config.RolePaneConfig{{Name: "logs"}, {Name: "logs"}}, "clashes"},
+		{"same env key", []config.RolePaneConfig{{Name: "dev-log"}, {Name: "dev_log"}}, "clashes"},
+		{"agent name", []config.RolePaneConfig{{Name: "agent"}}, "clashes"},
+		{"later target", []config.RolePaneConfig{
+			{Name: "shell", Target: "logs"},
+			{Name: "logs"},
+		}, `unknown pane "logs"`},
+		{"bad split", []config.RolePaneConfig{{Name: "logs", Split: "left"}}, "invalid split"},
+		{"too big", []config.RolePaneConfig{{Name: "logs", Size: 95}}, "out of range"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateLayout(tt.panes)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidateLayout = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ValidateLayout = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestApplyLayout_TargetsAgentPane opens a log and shell pane beside an
+// agent, moves focus away from it, and checks captures and pane queries
+// still address the agent pane.
+func TestApplyLayout_TargetsAgentPane(t *testing.T) {
+	tm := newTestTmux(t)
+	sessionName := "gt-test-layout-" + t.Name()
+	_ = tm.KillSession(sessionName)
+
+	if err := tm.NewSessionWithCommand(sessionName, "", "sh -c 'echo agent-pane-output; exec sleep 300'"); err != nil {
+		t.Fatalf("NewSessionWithCommand: %v", err)
+	}
+	defer func() { _ = tm.KillSession(sessionName) }()
+	agent, err := tm.GetPaneID(sessionName)
+	if err != nil {
+		t.Fatalf("GetPaneID: %v", err)
+	}
+	_ = tm.SetEnvironment(sessionName, "GT_PANE_ID", agent)
+
+	// An invalid layout opens nothing.
+	if err := tm.ApplyLayout(sessionName, "", []config.RolePaneConfig{{Name: "logs"}, {Name: "x", Split: "left"}}); err == nil {
+		t.Fatal("ApplyLayout with an invalid split should fail")
+	}
+	if panes, _ := tm.run("list-panes", "-t", sessionName, "-F", "#{pane_id}"); strings.Count(strings.TrimSpace(panes), "\n") != 0 {
+		t.Fatalf("invalid layout opened panes: %q", panes)
+	}
+
+	err = tm.ApplyLayout(sessionName, "", []config.RolePaneConfig{
+		{Name: "logs", Command: "sh -c 'echo logs-pane-output; exec cat'", Size: 40},
+		{Name: "shell", Split: "below", Target: "logs"},
+	})
+	if err != nil {
+		t.Fatalf("ApplyLayout: %v", err)
+	}
+
+	out, err := tm.run("list-panes", "-t", sessionName, "-F", "#{pane_id} #{pane_title}")
+	if err != nil {
+		t.Fatalf("list-panes: %v", err)
+	}
+	panes := strings.Split(strings.TrimSpace(out), "\n")
+	if len(panes) != 3 || !strings.HasPrefix(panes[0], agent+" ") {
+		t.Fatalf("panes = %q, want 3 with the agent pane %s first", panes, agent)
+	}
+	logs, err := tm.LayoutPane(sessionName, "logs")
+	if err != nil || !strings.Contains(out, logs+" logs") {
+		t.Fatalf("LayoutPane(logs) = %q, %v; panes %q", logs, err, panes)
+	}
+	if got, _ := tm.LayoutPane(sessionName, LayoutAgentPane); got != agent {
+		t.Errorf("LayoutPane(agent) = %q, want %q", got, agent)
+	}
+	if _, err := tm.LayoutPane(sessionName, "nope"); err == nil {
+		t.Error("LayoutPane for an undeclared pane should fail")
+	}
+
+	// Focus the log pane, as a user watching it would.
+	if _, err := tm.run("select-pane", "-t", logs); err != nil {
+		t.Fatalf("select-pane: %v", err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	var capture string
+	for time.Now().Before(deadline) {
+		capture, _ = tm.CapturePane(sessionName, 20)
+		if strings.Contains(capture, "agent-pane-output") {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	if !strings.Contains(capture, "agent-pane-output") || strings.Contains(capture, "logs-pane-output") {
+		t.Errorf("CapturePane(session) = %q, want the agent pane", capture)
+	}
+	if cmd, err := tm.GetPaneCommand(sessionName); err != nil || cmd != "sleep" {
+		t.Errorf("GetPaneCommand = %q, %v; want the agent's sleep", cmd, err)
+	}
+}
+
+func TestAgentPaneTargetCache(t *testing.T) {
+	tm := NewTmuxWithSocket(noTownSocket) // No server: lookups fall back
+	const session = "gt-test-pane-cache"
+	t.Cleanup(func() { tm.forgetAgentPane(session) })
+
+	if got := tm.agentPaneTarget(session); got != session+":^" {
+		t.Fatalf("uncached target = %q, want first-window fallback", got)
+	}
+
+	agentPanes.Store(tm.agentPaneKey(session), agentPaneEntry{pane: "%7", at: time.Now()})
+	if got := tm.agentPaneTarget(session); got != "%7" {
+		t.Errorf("cached target = %q, want %%7", got)
+	}
+	if got := NewTmuxWithSocket("other").agentPaneTarget(session); got == "%7" {
+		t.Error("cache entry leaked across tmux servers")
+	}
+
+	agentPanes.Store(tm.agentPaneKey(session), agentPaneEntry{pane: "%7", at: time.Now().Add(-agentPaneCacheTTL)})
+	if got := tm.agentPaneTarget(session); got != session+":^" {
+		t.Errorf("expired entry still used: %q", got)
+	}
+
+	agentPanes.Store(tm.agentPaneKey(session), agentPaneEntry{pane: "%7", at: time.Now()})
+	_ = tm.KillSession(session)
+	if got := tm.agentPaneTarget(session); got != session+":^" {
+		t.Errorf("entry survived KillSession: %q", got)
+	}
+}
@@ -0,0 +1,118 @@
+package tmux
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/mattn/go-runewidth"
+	"golang.org/x/text/unicode/norm"
+)
+
+// splitUTF8Chunks splits s into pieces of at most max bytes without cutting
+// a multi-byte character in half, and without separating a character from
+// the combining marks, zero-width joiners, or variation selectors attached
+// to it. A send-keys call that ends mid-sequence delivers invalid UTF-8,
+// which tmux renders as replacement characters. A single cluster longer
+// than max is kept whole.
+func splitUTF8Chunks(s string, max int) []string {
+	var chunks []string
+	for len(s) > max {
+		cut := max
+		for cut > 0 && !canBreakAt(s, cut) {
+			cut--
+		}
+		if cut == 0 {
+			// One cluster exceeds max: take it whole.
+			cut = max + 1
+			for cut < len(s) && !canBreakAt(s, cut) {
+				cut++
+			}
+		}
+		chunks = append(chunks, s[:cut])
+		s = s[cut:]
+	}
+	if s != "" {
+		chunks = append(chunks, s)
+	}
+	return chunks
+}
+
+// canBreakAt reports whether s can be split before byte i: i starts a
+// character that is not attached to the one before it.
+func canBreakAt(s string, i int) bool {
+	if !utf8.RuneStart(s[i]) {
+		return false
+	}
+	next, _ := utf8.DecodeRuneInString(s[i:])
+	prev, _ := utf8.DecodeLastRuneInString(s[:i])
+	return !isClusterExtender(next) && prev != '\u200d'
+}
+
+// isClusterExtender reports whether r attaches to the preceding character
+// rather than standing alone: combining marks, ZWJ, and variation selectors.
+func isClusterExtender(r rune) bool {
+	return unicode.Is(unicode.Mn, r) || unicode.Is(unicode.Me, r) ||
+		r == '\u200d' || (r >= '\ufe00' && r <= '\ufe0f') || (r >= 0xe0100 && r <= 0xe01ef)
+}
+
+// isWide reports whether r occupies two terminal cells (CJK, most emoji).
+func isWide(r rune) bool {
+	return runewidth.RuneWidth(r) == 2
+}
+
+// joinWrapped joins lines that a TUI soft-wrapped at the pane edge. Latin
+// text wraps at spaces the TUI drops, so lines are joined with a space;
+// CJK text has no spaces and wraps mid-phrase, so a boundary next to a
+// wide character is joined directly.
+func joinWrapped(parts []string) string {
+	var b strings.Builder
+	for i, p := range parts {
+		if p == "" {
+			continue
+		}
+		if i > 0 && b.Len() > 0 {
+			last, _ := utf8.DecodeLastRuneInString(b.String())
+			first, _ := utf8.DecodeRuneInString(p)
+			if !isWide(last) && !isWide(first) {
+				b.WriteByte(' ')
+			}
+		}
+		b.WriteString(p)
+	}
+	return b.String()
+}
+
+// normalizeForCompare folds the differences between text as sent and text
+// as captured from a pane: Unicode normalization (NFC), NBSP padding, runs
+// of whitespace (including soft wraps), and the spaces tmux inserts next to
+// wide characters that don't fit at the right edge.
+func normalizeForCompare(s string) string {
+	s = norm.NFC.String(strings.ReplaceAll(s, "\u00a0", " "))
+	fields := strings.Fields(s)
+
+	var b strings.Builder
+	for i, f := range fields {
+		if i > 0 {
+			last, _ := utf8.DecodeLastRuneInString(fields[i-1])
+			first, _ := utf8.DecodeRuneInString(f)
+			if !isWide(last) && !isWide(first) {
+				b.WriteByte(' ')
+			}
+		}
+		b.WriteString(f)
+	}
+	return b.String()
+}
+
+// CaptureContainsText reports whether captured pane lines show text,
+// tolerating soft wraps, wide-character padding, NBSP, and Unicode
+// normalization differences. Use it instead of strings.Contains to check
+// that multi-byte or RTL text was delivered to a pane.
+func CaptureContainsText(lines []string, text string) bool {
+	want := normalizeForCompare(text)
+	if want == "" {
+		return true
+	}
+	return strings.Contains(normalizeForCompare(strings.Join(lines, "\n")), want)
+}
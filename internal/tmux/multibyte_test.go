@@ -0,0 +1,156 @@
+package tmux
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+// multibyteCorpus covers the scripts agents are coordinated in: CJK (wide
+// cells), emoji (4-byte runes and ZWJ sequences), RTL scripts with
+// directional marks, and decomposed combining marks.
+var multibyteCorpus = map[string]string{
+	"chinese":   "请修复解析器中的错误并运行测试",
+	"japanese":  "テストを実行してから、結果を報告してください",
+	"korean":    "파서의 버그를 수정하고 테스트를 실행하세요",
+	"emoji":     "deploy 🚀 then ping 👩\u200d💻 and 👍🏽",
+	"arabic":    "يرجى إصلاح الخطأ في المحلل",
+	"hebrew":    "נא לתקן את הבאג \u200fבמנתח",
+	"mixed":     "fix gt-abc 修复 إصلاح תיקון ✅",
+	"combining": "re\u0301sume\u0301 nai\u0308ve",
+}
+
+func TestSplitUTF8Chunks(t *testing.T) {
+	for name, text := range multibyteCorpus {
+		text = strings.Repeat(text+" ", 20)
+		for _, size := range []int{1, 5, 16, 64, sendKeysChunkSize} {
+			chunks := splitUTF8Chunks(text, size)
+			if got := strings.Join(chunks, ""); got != text {
+				t.Fatalf("%s/%d: chunks don't reassemble", name, size)
+			}
+			for i, c := range chunks {
+				if !utf8.ValidString(c) {
+					t.Fatalf("%s/%d: chunk %d is invalid UTF-8: %q", name, size, i, c)
+				}
+				r, _ := utf8.DecodeRuneInString(c)
+				if i > 0 && isClusterExtender(r) {
+					t.Errorf("%s/%d: chunk %d starts with extender %U", name, size, i, r)
+				}
+				if strings.HasSuffix(c, "\u200d") {
+					t.Errorf("%s/%d: chunk %d ends with ZWJ", name, size, i)
+				}
+			}
+		}
+	}
+}
+
+func TestSplitUTF8Chunks_ASCIIUnchanged(t *testing.T) {
+	text := strings.Repeat("A", 1100)
+	chunks := splitUTF8Chunks(text, sendKeysChunkSize)
+	if len(chunks) != 3 || len(chunks[0]) != sendKeysChunkSize || len(chunks[2]) != 76 {
+		t.Errorf("chunk sizes = %d chunks, first %d", len(chunks), len(chunks[0]))
+	}
+	if got := splitUTF8Chunks("", 10); got != nil {
+		t.Errorf("empty input = %q, want nil", got)
+	}
+}
+
+func TestJoinWrapped(t *testing.T) {
+	tests := []struct {
+		parts []string
+		want  string
+	}{
+		{[]string{"fix the flaky", "test"}, "fix the flaky test"},
+		{[]string{"请修复解析器", "中的错误"}, "请修复解析器中的错误"},
+		{[]string{"run gt-abc", "然后报告"}, "run gt-abc然后报告"},
+		{[]string{"يرجى إصلاح", "الخطأ"}, "يرجى إصلاح الخطأ"},
+		{[]string{"", "only"}, "only"},
+	}
+	for _, tt := range tests {
+		if got := joinWrapped(tt.parts); got != tt.want {
+			t.Errorf("joinWrapped(%q) = %q, want %q", tt.parts, got, tt.want)
+		}
+	}
+}
+
+func TestExtractPendingInput_Multibyte(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines []string
+		want  string
+	}{
+		{"wrapped CJK", []string{"❯ 请修复解析器中的", "  错误并运行测试", "──────────"}, "请修复解析器中的错误并运行测试"},
+		{"RTL", []string{"❯ נא לתקן את הבאג", "──────────"}, "נא לתקן את הבאג"},
+		{"emoji", []string{"│ > ship it 🚀👩\u200d💻 │"}, "ship it 🚀👩\u200d💻"},
+	}
+	for _, tt := range tests {
+		got, ok := ExtractPendingInput(tt.lines)
+		if !ok || got != tt.want {
+			t.Errorf("%s: ExtractPendingInput() = %q, %v; want %q", tt.name, got, ok, tt.want)
+		}
+	}
+}
+
+func TestCaptureContainsText(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines []string
+		text  string
+		want  bool
+	}{
+		{"soft-wrapped latin", []string{"> fix the flaky", "  test now"}, "fix the flaky test now", true},
+		{"soft-wrapped CJK", []string{"请修复解析器中", "的错误"}, "请修复解析器中的错误", true},
+		{"wide char edge padding", []string{"报告结果 ", "完成"}, "报告结果完成", true},
+		{"NFC vs NFD", []string{"r\u00e9sum\u00e9"}, "re\u0301sume\u0301", true},
+		{"NBSP padding", []string{"❯\u00a0שלום עולם"}, "שלום עולם", true},
+		{"missing", []string{"请修复"}, "请修复解析器", false},
+		{"garbled", []string{"请修\ufffd\ufffd器"}, "请修复解析器", false},
+		{"empty text", []string{"anything"}, "", true},
+	}
+	for _, tt := range tests {
+		if got := CaptureContainsText(tt.lines, tt.text); got != tt.want {
+			t.Errorf("%s: CaptureContainsText() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+// TestSendMessageToTarget_Multibyte sends each corpus entry through the
+// chunked send-keys path into cat and checks it arrives intact.
+func TestSendMessageToTarget_Multibyte(t *testing.T) {
+	tm := newTestTmux(t)
+	session := "gt-test-multibyte-" + t.Name()
+	_ = tm.KillSession(session)
+	defer func() { _ = tm.KillSession(session) }()
+
+	if err := tm.NewSessionWithCommand(session, "", "cat"); err != nil {
+		t.Fatalf("session creation: %v", err)
+	}
+	time.Sleep(200 * time.Millisecond)
+
+	for name, text := range multibyteCorpus {
+		// Repeat past the chunk size so chunk boundaries land mid-text.
+		msg := strings.Repeat(text+" ", sendKeysChunkSize/len(text)+2)
+		if err := tm.sendMessageToTarget(session, sanitizeNudgeMessage(msg), 5*time.Second); err != nil {
+			t.Fatalf("%s: sendMessageToTarget: %v", name, err)
+		}
+		if _, err := tm.run("send-keys", "-t", session, "Enter"); err != nil {
+			t.Fatalf("%s: Enter: %v", name, err)
+		}
+		time.Sleep(300 * time.Millisecond)
+
+		lines, err := tm.CapturePaneLines(session, 200)
+		if err != nil {
+			t.Fatalf("%s: capture: %v", name, err)
+		}
+		if strings.Contains(strings.Join(lines, "\n"), "\ufffd") {
+			t.Fatalf("%s: capture contains replacement characters", name)
+		}
+		if !CaptureContainsText(lines, text) {
+			t.Errorf("%s: %q not found in capture", name, text)
+		}
+		if _, err := tm.run("clear-history", "-t", session); err != nil {
+			t.Fatalf("clear-history: %v", err)
+		}
+	}
+}
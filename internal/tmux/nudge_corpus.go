@@ -0,0 +1,136 @@
+package tmux
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/redact"
+)
+
+// NudgePair is an agent pane captured just before a nudge and again after
+// it failed. Collected pairs are real-world inputs for the pane parsing and
+// diffing code: copy one into internal/tmux/testdata/nudge_corpus/ and the
+// regression tests replay it.
+type NudgePair struct {
+	Session string    `json:"session"`
+	Message string    `json:"message"`
+	Error   string    `json:"error"`
+	At      time.Time `json:"at"`
+	Before  []string  `json:"before"`
+	After   []string  `json:"after"`
+	// Pending is what ExtractPendingInput found in Before when the pair was
+	// recorded; the regression tests check it still does.
+	Pending string `json:"pending"`
+}
+
+// nudgeCorpusMaxPairs caps the pairs kept in a town's corpus; past it the
+// oldest are removed, so leaving collection on can't fill the disk.
+const nudgeCorpusMaxPairs = 200
+
+// nudgeCorpusEnabled reports whether failed nudges record a NudgePair.
+// Opt-in: captures are redacted, but still hold whatever the agent showed.
+func nudgeCorpusEnabled() bool {
+	return os.Getenv("GT_NUDGE_CORPUS") == "1" && os.Getenv("GT_ROOT") != ""
+}
+
+// NudgeCorpusDir returns <townRoot>/.runtime/nudge_corpus.
+func NudgeCorpusDir(townRoot string) string {
+	return filepath.Join(townRoot, constants.DirRuntime, "nudge_corpus")
+}
+
+// captureNudgeBefore captures the pane for a NudgePair, or returns nil when
+// collection is off.
+func (t *Tmux) captureNudgeBefore(target string) []string {
+	if !nudgeCorpusEnabled() {
+		return nil
+	}
+	lines, _ := t.CapturePaneLines(target, inputModeLines)
+	return lines
+}
+
+// recordNudgePair saves the pane before and after a failed nudge.
+// Best-effort: a corpus write never masks the nudge error.
+func (t *Tmux) recordNudgePair(session, target, message string, before []string, nudgeErr error) {
+	if before == nil || nudgeErr == nil {
+		return
+	}
+	townRoot := os.Getenv("GT_ROOT")
+	after, _ := t.CapturePaneLines(target, inputModeLines)
+	r := redact.ForTown(townRoot)
+	redactLines := func(lines []string) []string {
+		if len(lines) == 0 {
+			return nil // The pane is gone; not one empty line
+		}
+		return strings.Split(r.String(strings.Join(lines, "\n")), "\n")
+	}
+	pair := NudgePair{
+		Session: session,
+		Message: r.String(message),
+		Error:   nudgeErr.Error(),
+		At:      time.Now().UTC(),
+		Before:  redactLines(before),
+		After:   redactLines(after),
+	}
+	pair.Pending, _ = ExtractPendingInput(pair.Before)
+
+	dir := NudgeCorpusDir(townRoot)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return
+	}
+	data, err := json.MarshalIndent(pair, "", "  ")
+	if err != nil {
+		return
+	}
+	name := fmt.Sprintf("%s-%d.json", strings.ReplaceAll(session, "/", "_"), pair.At.UnixNano())
+	if os.WriteFile(filepath.Join(dir, name), data, 0600) == nil {
+		pruneNudgeCorpus(dir, nudgeCorpusMaxPairs)
+	}
+}
+
+// pruneNudgeCorpus removes the oldest pairs in dir beyond keep. File names
+// start with the session, so age comes from the recording time at the end
+// of the name.
+func pruneNudgeCorpus(dir string, keep int) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil || len(paths) <= keep {
+		return
+	}
+	recorded := func(path string) int64 {
+		name := strings.TrimSuffix(filepath.Base(path), ".json")
+		ns, _ := strconv.ParseInt(name[strings.LastIndex(name, "-")+1:], 10, 64)
+		return ns
+	}
+	sort.Slice(paths, func(i, j int) bool { return recorded(paths[i]) < recorded(paths[j]) })
+	for _, path := range paths[:len(paths)-keep] {
+		_ = os.Remove(path)
+	}
+}
+
+// LoadNudgePairs reads every NudgePair in dir, in file name order.
+func LoadNudgePairs(dir string) ([]NudgePair, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+	var pairs []NudgePair
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var pair NudgePair
+		if err := json.Unmarshal(data, &pair); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", filepath.Base(path), err)
+		}
+		pairs = append(pairs, pair)
+	}
+	return pairs, nil
+}
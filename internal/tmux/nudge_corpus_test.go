@@ -0,0 +1,169 @@
+package tmux_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"unicode"
+
+	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// corpusDir holds NudgePairs recorded from failed nudges (GT_NUDGE_CORPUS=1)
+// and checked in as regression inputs.
+const corpusDir = "testdata/nudge_corpus"
+
+func loadCorpus(t testing.TB) []tmux.NudgePair {
+	pairs, err := tmux.LoadNudgePairs(corpusDir)
+	if err != nil {
+		t.Fatalf("loading corpus: %v", err)
+	}
+	return pairs
+}
+
+// TestNudgeCorpus replays every recorded pane pair: the diff between the
+// captures round-trips, the pending input is still extracted as recorded,
+// and that input survives being restored into an empty prompt.
+func TestNudgeCorpus(t *testing.T) {
+	pairs := loadCorpus(t)
+	if len(pairs) == 0 {
+		t.Fatal("no pairs in " + corpusDir)
+	}
+	for _, p := range pairs {
+		t.Run(p.Session+"/"+p.At.Format("20060102T150405"), func(t *testing.T) {
+			for name, diff := range map[string]func(a, b []string) []util.LineEdit{
+				"myers": util.MyersDiff, "patience": util.PatienceDiff,
+			} {
+				got, err := util.ApplyLineEdits(p.Before, diff(p.Before, p.After))
+				if err != nil || !slices.Equal(got, p.After) {
+					t.Errorf("%s diff round trip = %q, %v", name, got, err)
+				}
+			}
+
+			pending, _ := tmux.ExtractPendingInput(p.Before)
+			if pending != p.Pending {
+				t.Errorf("ExtractPendingInput(before) = %q, recorded %q", pending, p.Pending)
+			}
+			if pending != "" {
+				checkRestoreRoundTrip(t, pending)
+			}
+			if p.Message != "" && tmux.CaptureContainsText(p.Before, p.Message) {
+				t.Errorf("message %q already visible before the nudge", p.Message)
+			}
+		})
+	}
+}
+
+// FuzzExtractPendingInput feeds arbitrary panes to ExtractPendingInput and
+// checks it stays well-formed, then checks that restoring the fuzzed text
+// into an input box reads back the same text.
+func FuzzExtractPendingInput(f *testing.F) {
+	f.Add("╭───╮\n│ ❯ fix the tests │\n╰───╯", "fix the tests")
+	f.Add("> 日本語の入力\n  続きの行", "日本語の入力 続き")
+	f.Add("$ ls\nno prompt here", "a > b")
+	for _, p := range loadCorpus(f) {
+		f.Add(strings.Join(p.Before, "\n"), p.Pending)
+		f.Add(strings.Join(p.After, "\n"), p.Message)
+	}
+	f.Fuzz(func(t *testing.T, pane, text string) {
+		got, ok := tmux.ExtractPendingInput(strings.Split(pane, "\n"))
+		if !ok && got != "" {
+			t.Fatalf("no prompt, but returned %q", got)
+		}
+		if got != strings.TrimSpace(got) || strings.Contains(got, "\n") {
+			t.Fatalf("result not a trimmed single line: %q", got)
+		}
+		checkRestoreRoundTrip(t, text)
+	})
+}
+
+// FuzzCaptureContainsText checks that text typed into a pane is found
+// however the pane wraps it.
+func FuzzCaptureContainsText(f *testing.F) {
+	f.Add("deliver this nudge", 7)
+	f.Add("混在 mixed テキスト", 3)
+	f.Add("é café", 2)
+	f.Fuzz(func(t *testing.T, text string, width int) {
+		fields := restorableFields(text)
+		if len(fields) == 0 || width < 1 {
+			return
+		}
+		lines := append([]string{"previous output"}, wrapFields(fields, width%40+1)...)
+		if !tmux.CaptureContainsText(lines, strings.Join(fields, " ")) {
+			t.Fatalf("%q not found in %q", strings.Join(fields, " "), lines)
+		}
+	})
+}
+
+// checkRestoreRoundTrip types text, as RestoreInput would, into an input
+// box wrapped at a few widths and checks ExtractPendingInput reads it back.
+func checkRestoreRoundTrip(t *testing.T, text string) {
+	t.Helper()
+	fields := restorableFields(text)
+	if len(fields) == 0 {
+		return
+	}
+	want := strings.Join(fields, " ")
+	for _, width := range []int{4, 20, 80} {
+		pane := renderInputBox(wrapFields(fields, width))
+		got, ok := tmux.ExtractPendingInput(pane)
+		if !ok {
+			t.Fatalf("width %d: no prompt found in %q", width, pane)
+		}
+		// Equal up to wrapping and wide-character spacing.
+		if !tmux.CaptureContainsText([]string{got}, want) || !tmux.CaptureContainsText([]string{want}, got) {
+			t.Fatalf("width %d: restored %q, read back %q", width, want, got)
+		}
+	}
+}
+
+// restorableFields splits text into the words RestoreInput would type,
+// dropping control characters as nudge sanitizing does, and text a real
+// input box can't hold unambiguously: box-drawing characters and words
+// that start like a prompt.
+func restorableFields(text string) []string {
+	var fields []string
+	for _, f := range strings.Fields(strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || r == unicode.ReplacementChar {
+			return ' '
+		}
+		return r
+	}, text)) {
+		if strings.ContainsAny(f, "│─╭╮╰╯━") || strings.HasPrefix(f, ">") || strings.HasPrefix(f, "❯") {
+			return nil
+		}
+		fields = append(fields, f)
+	}
+	return fields
+}
+
+// wrapFields soft-wraps words into lines of about width runes, the way a
+// TUI input box does.
+func wrapFields(fields []string, width int) []string {
+	var lines []string
+	var line []string
+	n := 0
+	for _, f := range fields {
+		if n > 0 && n+len([]rune(f)) > width {
+			lines = append(lines, strings.Join(line, " "))
+			line, n = nil, 0
+		}
+		line = append(line, f)
+		n += len([]rune(f)) + 1
+	}
+	return append(lines, strings.Join(line, " "))
+}
+
+// renderInputBox draws lines as the contents of a Claude Code input box.
+func renderInputBox(lines []string) []string {
+	pane := []string{"⏺ Done.", "", "╭────────────────────╮"}
+	for i, line := range lines {
+		prefix := "│   "
+		if i == 0 {
+			prefix = "│ ❯ "
+		}
+		pane = append(pane, prefix+line+" │")
+	}
+	return append(pane, "╰────────────────────╯", "  ? for shortcuts")
+}
@@ -0,0 +1,59 @@
+package tmux
+
+import (
+	"fmt"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+// pasteBufferThreshold is the message size in bytes above which nudges are
+// delivered through a tmux paste buffer instead of send-keys. 0 disables
+// the paste path. Set from operational config by SetPasteBufferThreshold.
+var pasteBufferThreshold atomic.Int64
+
+func init() {
+	pasteBufferThreshold.Store(config.DefaultNudgePasteBufferThreshold)
+}
+
+// SetPasteBufferThreshold sets the size above which nudges are pasted from a
+// tmux buffer. Pass 0 to always use send-keys.
+func SetPasteBufferThreshold(n int) {
+	if n < 0 {
+		n = 0
+	}
+	pasteBufferThreshold.Store(int64(n))
+}
+
+// usePasteBuffer reports whether a message of n bytes should be pasted.
+func usePasteBuffer(n int) bool {
+	threshold := pasteBufferThreshold.Load()
+	return threshold > 0 && int64(n) > threshold
+}
+
+// pasteBufferSeq makes buffer names unique across concurrent nudges.
+var pasteBufferSeq atomic.Uint64
+
+// pasteToTarget delivers text to a target in one step: the text is loaded
+// into a named buffer over stdin (so its size isn't bound by tmux's command
+// length limit) and pasted with bracketed paste, which keeps embedded
+// newlines from submitting early in TUIs that support it. The buffer is
+// deleted by the paste. Compared with chunked send-keys, large messages
+// arrive atomically and in a single write instead of many small ones.
+func (t *Tmux) pasteToTarget(target, text string, timeout time.Duration) error {
+	buffer := fmt.Sprintf("gt-nudge-%d-%d", os.Getpid(), pasteBufferSeq.Add(1))
+	if _, err := t.runInput(text, "load-buffer", "-b", buffer, "-"); err != nil {
+		return fmt.Errorf("loading paste buffer: %w", err)
+	}
+	err := retryTransient(timeout, func() error {
+		_, err := t.run("paste-buffer", "-d", "-p", "-r", "-b", buffer, "-t", target)
+		return err
+	})
+	if err != nil {
+		// -d only deletes on success; don't leak the payload in the server.
+		_, _ = t.run("delete-buffer", "-b", buffer)
+	}
+	return err
+}
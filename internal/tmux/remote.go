@@ -0,0 +1,194 @@
+package tmux
+
+import (
+	"os/exec"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Remote rigs: a rig whose agents run on another machine has an SSH host in
+// rigs.json. Sessions for that rig (named "<prefix>-...") live on the remote
+// tmux server, so tmux commands targeting them are run as
+// `ssh <host> tmux -u -L <socket> ...`. Everything built on run() — capture,
+// nudge, environment, kill — works unchanged; ListSessions merges the remote
+// servers' sessions with the local ones.
+//
+// Individual sessions can also be placed on a host of their own (see
+// internal/placement); an exact session entry overrides the rig's prefix.
+//
+// Pane IDs (%N) are only unique per server and carry no session name, so a
+// pane-only target is routed locally unless the Tmux was bound to a host with
+// forSession first.
+//
+// Only tmux goes over SSH. Everything else gt does for a remote session —
+// reading its worktree, writing .runtime state, mail, logs — uses local
+// paths, so remote rigs assume the town directory is on a filesystem shared
+// with the remote host at the same path (e.g. NFS).
+
+// sshConnectTimeout bounds how long a tmux call waits on an unreachable
+// host, in seconds, so one down machine can't hang every status or nudge.
+const sshConnectTimeout = "5"
+
+var (
+	remoteHosts   map[string]string // session prefix → SSH host
+	sessionHosts  map[string]string // exact session name → SSH host ("" = local)
+	remoteHostsMu sync.RWMutex
+)
+
+// SetRemoteHosts installs the session-prefix → SSH host map used to route
+// commands for remote rigs. Called from session.InitRegistry with the hosts
+// configured in rigs.json. A nil or empty map routes everything locally.
+func SetRemoteHosts(hosts map[string]string) {
+	cp := make(map[string]string, len(hosts))
+	for prefix, host := range hosts {
+		if prefix != "" && host != "" {
+			cp[prefix] = host
+		}
+	}
+	remoteHostsMu.Lock()
+	remoteHosts = cp
+	remoteHostsMu.Unlock()
+}
+
+// SetSessionHosts installs per-session placements (session → SSH host, ""
+// for this machine), replacing any previous set. Called from
+// session.InitRegistry with the town's recorded placements.
+func SetSessionHosts(hosts map[string]string) {
+	cp := make(map[string]string, len(hosts))
+	for session, host := range hosts {
+		if session != "" {
+			cp[session] = host
+		}
+	}
+	remoteHostsMu.Lock()
+	sessionHosts = cp
+	remoteHostsMu.Unlock()
+}
+
+// SetSessionHost places a single session on host ("" for this machine).
+func SetSessionHost(session, host string) {
+	remoteHostsMu.Lock()
+	defer remoteHostsMu.Unlock()
+	if sessionHosts == nil {
+		sessionHosts = make(map[string]string)
+	}
+	sessionHosts[session] = host
+}
+
+// RemoteHosts returns the distinct SSH hosts configured for remote rigs and
+// placed sessions, sorted.
+func RemoteHosts() []string {
+	remoteHostsMu.RLock()
+	defer remoteHostsMu.RUnlock()
+	seen := make(map[string]bool)
+	var hosts []string
+	for _, m := range []map[string]string{remoteHosts, sessionHosts} {
+		for _, host := range m {
+			if host != "" && !seen[host] {
+				seen[host] = true
+				hosts = append(hosts, host)
+			}
+		}
+	}
+	sort.Strings(hosts)
+	return hosts
+}
+
+// HostForSession returns the SSH host a session lives on, or "" if local.
+// A per-session placement wins; otherwise the longest matching prefix wins
+// so "gt" and "gtx" rigs don't collide.
+func HostForSession(session string) string {
+	remoteHostsMu.RLock()
+	defer remoteHostsMu.RUnlock()
+	if host, ok := sessionHosts[session]; ok {
+		return host
+	}
+	best, host := "", ""
+	for prefix, h := range remoteHosts {
+		if strings.HasPrefix(session, prefix+"-") && len(prefix) > len(best) {
+			best, host = prefix, h
+		}
+	}
+	return host
+}
+
+// targetHost picks the host for a tmux invocation from its -t/-s target.
+// Targets like "session:0.1" are reduced to the session name.
+func targetHost(args []string) string {
+	for i := 0; i+1 < len(args); i++ {
+		if args[i] != "-t" && args[i] != "-s" {
+			continue
+		}
+		target := strings.TrimPrefix(args[i+1], "=")
+		if strings.HasPrefix(target, "%") {
+			return ""
+		}
+		if idx := strings.IndexAny(target, ":."); idx >= 0 {
+			target = target[:idx]
+		}
+		return HostForSession(target)
+	}
+	return ""
+}
+
+// forSession returns a Tmux bound to the host that session lives on, so that
+// follow-up commands using pane IDs reach the same server. Returns t itself
+// for local sessions.
+func (t *Tmux) forSession(session string) *Tmux {
+	if t.host != "" {
+		return t
+	}
+	host := HostForSession(session)
+	if host == "" {
+		return t
+	}
+	return &Tmux{socketName: t.socketName, host: host}
+}
+
+// command builds the exec.Cmd for a tmux invocation, wrapping it in ssh when
+// the Tmux is bound to a host or the target session belongs to a remote rig.
+func (t *Tmux) command(allArgs, args []string) *exec.Cmd {
+	host := t.host
+	if host == "" {
+		host = targetHost(args)
+	}
+	if host == "" {
+		return exec.Command("tmux", allArgs...)
+	}
+	return exec.Command("ssh", remoteTmuxArgs(host, allArgs)...)
+}
+
+// remoteTmuxArgs returns ssh arguments that run tmux with allArgs on host.
+// ssh joins its arguments into a remote shell command line, so each tmux
+// argument is single-quoted to survive the trip intact.
+func remoteTmuxArgs(host string, allArgs []string) []string {
+	quoted := make([]string, 0, len(allArgs)+1)
+	quoted = append(quoted, "tmux")
+	for _, a := range allArgs {
+		quoted = append(quoted, "'"+strings.ReplaceAll(a, "'", `'\''`)+"'")
+	}
+	return []string{"-o", "BatchMode=yes", "-o", "ConnectTimeout=" + sshConnectTimeout,
+		"--", host, strings.Join(quoted, " ")}
+}
+
+// listRemoteSessions returns session names from every remote rig host.
+// Unreachable hosts are skipped so one down machine doesn't hide the rest.
+func (t *Tmux) listRemoteSessions() []string {
+	var names []string
+	for _, host := range RemoteHosts() {
+		remote := &Tmux{socketName: t.socketName, host: host}
+		out, err := remote.run("list-sessions", "-F", "#{session_name}")
+		if err != nil || out == "" {
+			continue
+		}
+		for _, name := range strings.Split(out, "\n") {
+			// Only report sessions that actually route to this host; the
+			// remote server may also run unrelated local sessions.
+			if HostForSession(name) == host {
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
@@ -0,0 +1,73 @@
+package tmux
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRemoteRouting(t *testing.T) {
+	SetRemoteHosts(map[string]string{"gt": "alice@box", "gtx": "bob@gpu", "bd": ""})
+	t.Cleanup(func() { SetRemoteHosts(nil) })
+
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{args: []string{"list-sessions"}, want: ""},
+		{args: []string{"has-session", "-t", "=gt-witness"}, want: "alice@box"},
+		{args: []string{"capture-pane", "-p", "-t", "gtx-nux:0.0"}, want: "bob@gpu"},
+		{args: []string{"send-keys", "-t", "%12", "-l", "hi"}, want: ""},
+		{args: []string{"has-session", "-t", "bd-witness"}, want: ""},
+		{args: []string{"has-session", "-t", "hq-mayor"}, want: ""},
+	}
+	for _, tt := range tests {
+		if got := targetHost(tt.args); got != tt.want {
+			t.Errorf("targetHost(%v) = %q, want %q", tt.args, got, tt.want)
+		}
+	}
+
+	if got := RemoteHosts(); !reflect.DeepEqual(got, []string{"alice@box", "bob@gpu"}) {
+		t.Errorf("RemoteHosts() = %v", got)
+	}
+
+	local := &Tmux{socketName: "town"}
+	if bound := local.forSession("hq-mayor"); bound != local {
+		t.Error("forSession should return the receiver for local sessions")
+	}
+	if bound := local.forSession("gtx-nux"); bound.host != "bob@gpu" || bound.socketName != "town" {
+		t.Errorf("forSession(gtx-nux) = %+v", bound)
+	}
+}
+
+func TestRemoteTmuxArgs(t *testing.T) {
+	got := remoteTmuxArgs("alice@box", []string{"-u", "-L", "town", "send-keys", "-t", "gt-nux", "-l", "it's $HOME", ""})
+	want := []string{"-o", "BatchMode=yes", "-o", "ConnectTimeout=5", "--", "alice@box",
+		`tmux '-u' '-L' 'town' 'send-keys' '-t' 'gt-nux' '-l' 'it'\''s $HOME' ''`}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("remoteTmuxArgs() =\n  %q\nwant\n  %q", got, want)
+	}
+}
+
+func TestSessionPlacementOverridesPrefix(t *testing.T) {
+	SetRemoteHosts(map[string]string{"gt": "alice@box"})
+	SetSessionHosts(map[string]string{"gt-nux": "bob@gpu"})
+	SetSessionHost("gt-rictus", "")
+	t.Cleanup(func() {
+		SetRemoteHosts(nil)
+		SetSessionHosts(nil)
+	})
+
+	for session, want := range map[string]string{
+		"gt-nux":     "bob@gpu",
+		"gt-rictus":  "", // placed locally despite the rig's host
+		"gt-witness": "alice@box",
+		"hq-mayor":   "",
+	} {
+		if got := HostForSession(session); got != want {
+			t.Errorf("HostForSession(%q) = %q, want %q", session, got, want)
+		}
+	}
+	if got := RemoteHosts(); !reflect.DeepEqual(got, []string{"alice@box", "bob@gpu"}) {
+		t.Errorf("RemoteHosts() = %v", got)
+	}
+}
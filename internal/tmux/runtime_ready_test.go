@@ -0,0 +1,99 @@
+package tmux
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+func TestWaitForRuntimeReady_Prompt(t *testing.T) {
+	tm := newTestTmux(t)
+	session := "gt-test-ready-prompt"
+	_ = tm.KillSession(session)
+	defer func() { _ = tm.KillSession(session) }()
+
+	if err := tm.NewSessionWithCommand(session, "", "sleep 0.5; echo 'ready> '; sleep 30"); err != nil {
+		t.Fatalf("NewSessionWithCommand: %v", err)
+	}
+	rc := &config.RuntimeConfig{Tmux: &config.RuntimeTmuxConfig{ReadyPromptPrefix: "ready> "}}
+	if err := tm.WaitForRuntimeReady(session, rc, 5*time.Second); err != nil {
+		t.Errorf("WaitForRuntimeReady: %v", err)
+	}
+
+	rc.Tmux.ReadyPromptPrefix = "never> "
+	err := tm.WaitForRuntimeReady(session, rc, time.Second)
+	if !errors.Is(err, ErrRuntimeNotReady) {
+		t.Fatalf("WaitForRuntimeReady without the prompt = %v, want ErrRuntimeNotReady", err)
+	}
+	if !strings.Contains(err.Error(), "ready>") {
+		t.Errorf("error %q doesn't report the last output", err)
+	}
+}
+
+func TestWaitForRuntimeReady_Stabilization(t *testing.T) {
+	tm := newTestTmux(t)
+	session := "gt-test-ready-stable"
+	_ = tm.KillSession(session)
+	defer func() { _ = tm.KillSession(session) }()
+
+	if err := tm.NewSessionWithCommand(session, "", "echo starting; sleep 30"); err != nil {
+		t.Fatalf("NewSessionWithCommand: %v", err)
+	}
+	rc := &config.RuntimeConfig{Tmux: &config.RuntimeTmuxConfig{ReadyStableMs: 500}}
+	start := time.Now()
+	if err := tm.WaitForRuntimeReady(session, rc, 5*time.Second); err != nil {
+		t.Fatalf("WaitForRuntimeReady: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 500*time.Millisecond {
+		t.Errorf("returned after %v, before the output was stable for 500ms", elapsed)
+	}
+}
+
+func TestWaitForRuntimeReady_SessionGone(t *testing.T) {
+	tm := newTestTmux(t)
+	rc := &config.RuntimeConfig{Tmux: &config.RuntimeTmuxConfig{ReadyPromptPrefix: "> "}}
+	err := tm.WaitForRuntimeReady("gt-test-ready-missing", rc, 5*time.Second)
+	if err == nil || errors.Is(err, ErrRuntimeNotReady) {
+		t.Errorf("WaitForRuntimeReady on a missing session = %v, want a session error", err)
+	}
+}
+
+func TestMarkStarting(t *testing.T) {
+	tm := newTestTmux(t)
+	session := "gt-test-mark-starting"
+	_ = tm.KillSession(session)
+	defer func() { _ = tm.KillSession(session) }()
+
+	if err := tm.NewSessionWithCommand(session, "", "sleep 30"); err != nil {
+		t.Fatalf("NewSessionWithCommand: %v", err)
+	}
+	if err := tm.waitForStartup(session, 0); err != nil {
+		t.Errorf("waitForStartup on an unmarked session: %v", err)
+	}
+
+	if err := tm.MarkStarting(session, time.Minute); err != nil {
+		t.Fatalf("MarkStarting: %v", err)
+	}
+	if err := tm.waitForStartup(session, 0); !errors.Is(err, ErrInputBlocked) {
+		t.Errorf("waitForStartup while starting = %v, want ErrInputBlocked", err)
+	}
+
+	if err := tm.MarkStarted(session); err != nil {
+		t.Fatalf("MarkStarted: %v", err)
+	}
+	if err := tm.waitForStartup(session, 0); err != nil {
+		t.Errorf("waitForStartup after MarkStarted: %v", err)
+	}
+}
+
+func TestLastPaneLine(t *testing.T) {
+	if got := lastPaneLine([]string{"a", "  b  ", "", "  "}); got != "b" {
+		t.Errorf("lastPaneLine = %q, want b", got)
+	}
+	if got := lastPaneLine(nil); got != "" {
+		t.Errorf("lastPaneLine(nil) = %q, want empty", got)
+	}
+}
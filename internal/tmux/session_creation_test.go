@@ -1,9 +1,12 @@
 package tmux
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
 )
 
 // Tests for the two-step session creation (new-session + respawn-pane) and
@@ -177,6 +180,10 @@ func TestSanitizeNudgeMessage(t *testing.T) {
 		{"preserves newline", "hello\nworld", "hello\nworld"},
 		{"preserves unicode", "hello 世界", "hello 世界"},
 		{"strips BS", "hello\x08world", "helloworld"},
+		{"strips C1 CSI", "hello\u009b31mworld", "hello31mworld"},
+		{"preserves emoji ZWJ sequence", "ship 👩\u200d💻 now", "ship 👩\u200d💻 now"},
+		{"preserves RTL with marks", "שלום \u200fעולם", "שלום \u200fעולם"},
+		{"preserves combining marks", "cafe\u0301", "cafe\u0301"},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -216,3 +223,54 @@ func TestSendMessageToTarget_Chunking(t *testing.T) {
 		t.Errorf("expected ~600 A's in output, got %d (message may have been truncated)", count)
 	}
 }
+
+// TestSendMessageToTarget_PasteBuffer verifies that messages above the paste
+// threshold are delivered intact via a paste buffer, which is then removed.
+func TestSendMessageToTarget_PasteBuffer(t *testing.T) {
+	tm := newTestTmux(t)
+	session := "gt-test-paste-" + t.Name()
+	_ = tm.KillSession(session)
+	defer func() { _ = tm.KillSession(session) }()
+
+	SetPasteBufferThreshold(100)
+	defer SetPasteBufferThreshold(config.DefaultNudgePasteBufferThreshold)
+
+	if err := tm.NewSessionWithCommand(session, "", "cat"); err != nil {
+		t.Fatalf("session creation: %v", err)
+	}
+	time.Sleep(200 * time.Millisecond)
+
+	// Lines under the pane width so each line captures whole.
+	var lines []string
+	for i := 0; i < 30; i++ {
+		lines = append(lines, fmt.Sprintf("line-%02d %s", i, strings.Repeat("B", 40)))
+	}
+	msg := strings.Join(lines, "\n")
+	if err := tm.sendMessageToTarget(session, msg, 5*time.Second); err != nil {
+		t.Fatalf("sendMessageToTarget: %v", err)
+	}
+	time.Sleep(300 * time.Millisecond)
+
+	output, _ := tm.CapturePane(session, 100)
+	for _, l := range lines {
+		if !strings.Contains(output, l) {
+			t.Fatalf("pasted output missing %q:\n%s", l, output)
+		}
+	}
+	if buffers, _ := tm.run("list-buffers", "-F", "#{buffer_name}"); strings.Contains(buffers, "gt-nudge-") {
+		t.Errorf("paste buffer not deleted: %q", buffers)
+	}
+}
+
+func TestUsePasteBuffer(t *testing.T) {
+	defer SetPasteBufferThreshold(config.DefaultNudgePasteBufferThreshold)
+
+	SetPasteBufferThreshold(10)
+	if usePasteBuffer(10) || !usePasteBuffer(11) {
+		t.Error("threshold should be exclusive")
+	}
+	SetPasteBufferThreshold(0)
+	if usePasteBuffer(1 << 20) {
+		t.Error("0 should disable the paste path")
+	}
+}
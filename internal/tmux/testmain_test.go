@@ -17,10 +17,13 @@ func TestMain(m *testing.M) {
 	// Set defaultSocket so NewTmux() connects to the test server, not the
 	// user's personal server or the sentinel that indicates "no town context".
 	SetDefaultSocket(socket)
+	// Exercise control connections as the daemon does.
+	EnableControlConnections()
 
 	code := m.Run()
 
 	// Kill the test tmux server and restore the original socket state.
+	CloseControlConnections()
 	_ = exec.Command("tmux", "-L", socket, "kill-server").Run()
 	SetDefaultSocket("")
 
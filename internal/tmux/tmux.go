@@ -14,13 +14,19 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/errkind"
+	"github.com/steveyegge/gastown/internal/logging"
 	"github.com/steveyegge/gastown/internal/telemetry"
 )
 
+// logger is the tmux subsystem logger.
+var logger = logging.For("tmux")
+
 // sessionNudgeLocks serializes nudges to the same session.
 // This prevents interleaving when multiple nudges arrive concurrently,
 // which can cause garbled input and missed Enter keys.
@@ -39,12 +45,22 @@ var validSessionNameRe = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
 
 // Common errors
 var (
-	ErrNoServer           = errors.New("no tmux server running")
-	ErrSessionExists      = errors.New("session already exists")
-	ErrSessionNotFound    = errors.New("session not found")
+	ErrNoServer = errkind.New(errkind.Transport, "no tmux server running",
+		"start the town with 'gt up'")
+	ErrSessionExists   = errors.New("session already exists")
+	ErrSessionNotFound = errkind.New(errkind.Transport, "session not found",
+		"check which sessions are running with 'gt status'")
 	ErrSessionRunning     = errors.New("session already running with healthy agent")
-	ErrInvalidSessionName = errors.New("invalid session name")
-	ErrIdleTimeout        = errors.New("agent not idle before timeout")
+	ErrInvalidSessionName = errkind.New(errkind.Config, "invalid session name",
+		"session names may only contain letters, digits, '-', and '_'")
+	ErrIdleTimeout = errkind.New(errkind.Protocol, "agent not idle before timeout",
+		"the agent is still busy; retry later, or use 'gt nudge --mode queue' to deliver at its next turn")
+	ErrInputBlocked = errkind.New(errkind.Protocol, "agent input blocked",
+		"a dialog or permission prompt has the agent's input; check with 'gt peek' and answer it")
+	ErrRuntimeNotReady = errkind.New(errkind.Protocol, "agent not ready before timeout",
+		"check what the agent is showing with 'gt peek'")
+	ErrClearStalled = errkind.New(errkind.Protocol, "pending input did not clear",
+		"the agent may be in vim mode or a dialog may have focus; check with 'gt peek', or send with 'gt nudge --no-restore'")
 )
 
 // validateSessionName checks that a session name contains only safe characters.
@@ -122,9 +138,13 @@ func BuildCommandContext(ctx context.Context, args ...string) *exec.Cmd {
 	return exec.CommandContext(ctx, "tmux", allArgs...)
 }
 
+// invocations counts tmux subprocesses started by run, for benchmarks.
+var invocations atomic.Int64
+
 // Tmux wraps tmux operations.
 type Tmux struct {
 	socketName string // tmux socket name (-L flag), empty = default socket
+	host       string // SSH host for a remote rig's server, empty = route by target (see remote.go)
 }
 
 // noTownSocket is a sentinel socket name used when no town socket is configured.
@@ -138,6 +158,13 @@ const noTownSocket = "gt-no-town-socket"
 // agents (where pane_current_command remains a shell). See gt-sk5u.
 const EnvAgentReady = "GT_AGENT_READY"
 
+// EnvStartupDeadline is the tmux session environment variable that marks a
+// session whose runtime is still starting. Its value is the Unix time after
+// which the mark is ignored, so a startup that dies midway can't block the
+// session forever. Nudges wait for it to clear rather than typing into a
+// half-drawn TUI. See MarkStarting.
+const EnvStartupDeadline = "GT_STARTUP_DEADLINE"
+
 // NewTmux creates a new Tmux wrapper using the initialized town socket.
 // Falls back to GT_TOWN_SOCKET env var (set by cross-socket tmux bindings),
 // then to a sentinel socket that fails clearly if neither is available.
@@ -169,22 +196,39 @@ func NewTmuxWithSocket(socket string) *Tmux {
 // All commands include -u flag for UTF-8 support regardless of locale settings.
 // See: https://github.com/steveyegge/gastown/issues/1219
 func (t *Tmux) run(args ...string) (string, error) {
+	return t.runInput("", args...)
+}
+
+// runInput is run with stdin connected to the given text (none if empty).
+func (t *Tmux) runInput(stdin string, args ...string) (string, error) {
 	// Prepend global flags: -u (UTF-8 mode, PATCH-004) and optionally -L (socket).
 	// The -L flag must come before the subcommand, so it goes in the prefix.
 	allArgs := []string{"-u"}
 	if t.socketName != "" {
 		allArgs = append(allArgs, "-L", t.socketName)
 	}
+	if stdin == "" {
+		if out, err, ok := t.runControl(args); ok {
+			return out, err
+		}
+	}
+
 	allArgs = append(allArgs, args...)
-	cmd := exec.Command("tmux", allArgs...)
+	cmd := t.command(allArgs, args)
+	invocations.Add(1)
+	if stdin != "" {
+		cmd.Stdin = strings.NewReader(stdin)
+	}
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
 
 	err := cmd.Run()
+	logger.Debug("ran tmux", "args", strings.Join(args, " "), "err", err)
 	if err != nil {
 		return "", t.wrapError(err, stderr.String(), args)
 	}
+	t.noteExec(args)
 
 	return strings.TrimSpace(stdout.String()), nil
 }
@@ -204,7 +248,8 @@ func (t *Tmux) wrapError(err error, stderr string, args []string) error {
 		return ErrSessionExists
 	}
 	if strings.Contains(stderr, "session not found") ||
-		strings.Contains(stderr, "can't find session") {
+		strings.Contains(stderr, "can't find session") ||
+		strings.Contains(stderr, "can't find pane") {
 		return ErrSessionNotFound
 	}
 
@@ -219,6 +264,7 @@ func (t *Tmux) NewSession(name, workDir string) error {
 	if err := validateSessionName(name); err != nil {
 		return err
 	}
+	t.forgetAgentPane(name)
 	args := []string{"new-session", "-d", "-s", name}
 	if workDir != "" {
 		args = append(args, "-c", workDir)
@@ -246,7 +292,9 @@ func (t *Tmux) NewSessionWithCommand(name, workDir, command string) error {
 	if err := validateSessionName(name); err != nil {
 		return err
 	}
-	if workDir != "" {
+	t.forgetAgentPane(name)
+	// Remote rigs' directories live on the remote host; tmux validates them there.
+	if workDir != "" && HostForSession(name) == "" {
 		info, err := os.Stat(workDir)
 		if err != nil {
 			return fmt.Errorf("invalid work directory %q: %w", workDir, err)
@@ -302,7 +350,9 @@ func (t *Tmux) NewSessionWithCommandAndEnv(name, workDir, command string, env ma
 	if err := validateSessionName(name); err != nil {
 		return err
 	}
-	if workDir != "" {
+	t.forgetAgentPane(name)
+	// Remote rigs' directories live on the remote host; tmux validates them there.
+	if workDir != "" && HostForSession(name) == "" {
 		info, err := os.Stat(workDir)
 		if err != nil {
 			return fmt.Errorf("invalid work directory %q: %w", workDir, err)
@@ -478,6 +528,7 @@ func (t *Tmux) EnsureSessionFreshWithCommand(name, workDir, command string) erro
 // session is already gone or there is no tmux server.
 func (t *Tmux) KillSession(name string) (retErr error) {
 	defer func() { telemetry.RecordSessionStop(context.Background(), name, retErr) }()
+	t.forgetAgentPane(name)
 	_, retErr = t.run("kill-session", "-t", name)
 	if retErr == ErrSessionNotFound || retErr == ErrNoServer {
 		retErr = nil
@@ -896,18 +947,23 @@ func (t *Tmux) HasSession(name string) (bool, error) {
 // ListSessions returns all session names.
 func (t *Tmux) ListSessions() ([]string, error) {
 	out, err := t.run("list-sessions", "-F", "#{session_name}")
-	if err != nil {
-		if errors.Is(err, ErrNoServer) {
-			return nil, nil // No server = no sessions
-		}
+	if err != nil && !errors.Is(err, ErrNoServer) {
 		return nil, err
 	}
 
-	if out == "" {
-		return nil, nil
+	// No local server = no local sessions; remote rigs may still have some.
+	var sessions []string
+	if out != "" {
+		for _, name := range strings.Split(out, "\n") {
+			if !IsControlSession(name) {
+				sessions = append(sessions, name)
+			}
+		}
 	}
-
-	return strings.Split(out, "\n"), nil
+	if t.host == "" {
+		sessions = append(sessions, t.listRemoteSessions()...)
+	}
+	return sessions, nil
 }
 
 // SessionSet provides O(1) session existence checks by caching session names.
@@ -959,7 +1015,7 @@ func (t *Tmux) GetSessionSet() (*SessionSet, error) {
 			line = out
 			out = ""
 		}
-		if line != "" {
+		if line != "" && !IsControlSession(line) {
 			set.sessions[line] = struct{}{}
 		}
 	}
@@ -1014,6 +1070,9 @@ func (t *Tmux) ListSessionIDs() (map[string]string, error) {
 		if idx > 0 && idx < len(line)-1 {
 			name := line[:idx]
 			id := line[idx+1:]
+			if IsControlSession(name) {
+				continue
+			}
 			result[name] = id
 		} else {
 			skipped++
@@ -1055,25 +1114,6 @@ func (t *Tmux) SendKeysRaw(session, keys string) error {
 	return err
 }
 
-// SendKeysReplace sends keystrokes, clearing any pending input first.
-// This is useful for "replaceable" notifications where only the latest matters.
-// Uses Ctrl-U to clear the input line before sending the new message.
-// The delay parameter controls how long to wait after clearing before sending (ms).
-func (t *Tmux) SendKeysReplace(session, keys string, clearDelayMs int) error {
-	// Send Ctrl-U to clear any pending input on the line
-	if _, err := t.run("send-keys", "-t", session, "C-u"); err != nil {
-		return err
-	}
-
-	// Small delay to let the clear take effect
-	if clearDelayMs > 0 {
-		time.Sleep(time.Duration(clearDelayMs) * time.Millisecond)
-	}
-
-	// Now send the actual message
-	return t.SendKeys(session, keys)
-}
-
 // SendKeysDelayed sends keystrokes after a delay (in milliseconds).
 // Useful for waiting for a process to be ready before sending input.
 func (t *Tmux) SendKeysDelayed(session, keys string, delayMs int) error {
@@ -1196,8 +1236,10 @@ func isTransientSendKeysError(err error) bool {
 // sanitizeNudgeMessage removes control characters that corrupt tmux send-keys
 // delivery. ESC (0x1b) triggers terminal escape sequences, CR (0x0d) acts as
 // premature Enter, BS (0x08) deletes characters. TAB is replaced with a space
-// to avoid triggering shell completion. Printable characters (including quotes,
-// backticks, and Unicode) are preserved.
+// to avoid triggering shell completion. C1 controls (U+0080–U+009F) are
+// stripped too: U+009B is a one-character CSI on terminals that honor 8-bit
+// controls. Printable characters (including quotes, backticks, CJK, emoji,
+// and RTL text with its directional marks) are preserved.
 func sanitizeNudgeMessage(msg string) string {
 	var b strings.Builder
 	b.Grow(len(msg))
@@ -1211,6 +1253,8 @@ func sanitizeNudgeMessage(msg string) string {
 			continue
 		case r == 0x7f: // DEL
 			continue
+		case r >= 0x80 && r <= 0x9f: // C1 controls
+			continue
 		default:
 			b.WriteRune(r)
 		}
@@ -1221,6 +1265,8 @@ func sanitizeNudgeMessage(msg string) string {
 // sendMessageToTarget sends a sanitized message to a tmux target. For small
 // messages (< sendKeysChunkSize), uses send-keys -l. For larger messages,
 // sends in chunks with delays to avoid overwhelming the TTY input buffer.
+// Messages above the paste buffer threshold skip send-keys entirely and are
+// pasted from a tmux buffer (see pasteToTarget).
 //
 // NOTE: The Linux TTY canonical mode buffer is 4096 bytes. Messages longer
 // than ~4000 bytes may be truncated by the kernel's line discipline when
@@ -1230,17 +1276,18 @@ func sanitizeNudgeMessage(msg string) string {
 const sendKeysChunkSize = 512
 
 func (t *Tmux) sendMessageToTarget(target, text string, timeout time.Duration) error {
+	if usePasteBuffer(len(text)) {
+		return t.pasteToTarget(target, text, timeout)
+	}
 	if len(text) <= sendKeysChunkSize {
 		return t.sendKeysLiteralWithRetry(target, text, timeout)
 	}
 	// Send in chunks to avoid tmux send-keys argument length limits.
-	// Each chunk is sent with a small delay to let the terminal process it.
-	for i := 0; i < len(text); i += sendKeysChunkSize {
-		end := i + sendKeysChunkSize
-		if end > len(text) {
-			end = len(text)
-		}
-		chunk := text[i:end]
+	// Chunks end on character boundaries so CJK, emoji, and RTL text
+	// aren't garbled. Each chunk is sent with a small delay to let the
+	// terminal process it.
+	chunks := splitUTF8Chunks(text, sendKeysChunkSize)
+	for i, chunk := range chunks {
 		if i == 0 {
 			// First chunk uses retry logic for startup race
 			if err := t.sendKeysLiteralWithRetry(target, chunk, timeout); err != nil {
@@ -1252,7 +1299,7 @@ func (t *Tmux) sendMessageToTarget(target, text string, timeout time.Duration) e
 			}
 		}
 		// Small delay between chunks to let the terminal process
-		if end < len(text) {
+		if i < len(chunks)-1 {
 			time.Sleep(10 * time.Millisecond)
 		}
 	}
@@ -1273,12 +1320,21 @@ func (t *Tmux) sendMessageToTarget(target, text string, timeout time.Duration) e
 // This function ONLY addresses the startup race where the agent TUI hasn't
 // initialized yet, causing tmux send-keys to fail with "not in a mode".
 func (t *Tmux) sendKeysLiteralWithRetry(target, text string, timeout time.Duration) error {
+	return retryTransient(timeout, func() error {
+		_, err := t.run("send-keys", "-t", target, "-l", text)
+		return err
+	})
+}
+
+// retryTransient runs op until it succeeds, fails with a non-transient
+// error, or timeout elapses, backing off between attempts.
+func retryTransient(timeout time.Duration, op func() error) error {
 	deadline := time.Now().Add(timeout)
 	interval := constants.NudgeRetryInterval
 	var lastErr error
 
 	for time.Now().Before(deadline) {
-		_, err := t.run("send-keys", "-t", target, "-l", text)
+		err := op()
 		if err == nil {
 			return nil
 		}
@@ -1303,23 +1359,54 @@ func (t *Tmux) sendKeysLiteralWithRetry(target, text string, timeout time.Durati
 			interval = 2 * time.Second
 		}
 	}
-	return fmt.Errorf("agent not ready for input after %s: %w", timeout, lastErr)
+	return fmt.Errorf("%w after %s: %w", errNotReadyForInput, timeout, lastErr)
 }
 
 // NudgeSession sends a message to a Claude Code session reliably.
 // This is the canonical way to send messages to Claude sessions.
-// Uses: literal mode + 500ms debounce + ESC (for vim mode) + separate Enter.
+// Uses: input mode probe + literal mode + 500ms debounce + ESC (for vim
+// mode) + separate Enter.
 // After sending, triggers SIGWINCH to wake Claude in detached sessions.
 // Verification is the Witness's job (AI), not this function.
 //
 // If the agent TUI hasn't initialized yet (cold startup), retries with backoff
 // up to NudgeReadyTimeout before giving up. See sendKeysLiteralWithRetry.
+// Likewise, if a permission prompt or other modal dialog is covering the input
+// field, waits up to NudgeReadyTimeout for it to close and returns
+// ErrInputBlocked rather than typing into the dialog.
 //
 // IMPORTANT: Nudges to the same session are serialized to prevent interleaving.
 // If multiple goroutines try to nudge the same session concurrently, they will
 // queue up and execute one at a time. This prevents garbled input when
 // SessionStart hooks and nudges arrive simultaneously.
 func (t *Tmux) NudgeSession(session, message string) error {
+	return t.nudgeSession(session, message, NudgeOptions{})
+}
+
+// NudgeSessionTyped is NudgeSession with the text typed char-by-char using
+// profile, regardless of the session's own typing profile.
+func (t *Tmux) NudgeSessionTyped(session, message string, profile TypingProfile) error {
+	return t.nudgeSession(session, message, NudgeOptions{Typing: &profile})
+}
+
+// NudgeOptions adjusts how NudgeSessionWith delivers a nudge.
+type NudgeOptions struct {
+	// Typing types the text char-by-char with this profile, regardless of
+	// the session's own typing profile.
+	Typing *TypingProfile
+	// RestoreInput moves text already typed at the agent's prompt out of
+	// the way: it is saved (for gt nudge undo), cleared, and typed back,
+	// unsubmitted, after the nudge is submitted. Without it the nudge is
+	// appended to that text and submitted with it.
+	RestoreInput bool
+}
+
+// NudgeSessionWith is NudgeSession with delivery options.
+func (t *Tmux) NudgeSessionWith(session, message string, opts NudgeOptions) error {
+	return t.nudgeSession(session, message, opts)
+}
+
+func (t *Tmux) nudgeSession(session, message string, opts NudgeOptions) (err error) {
 	// Serialize nudges to this session to prevent interleaving.
 	// Use a timed lock to avoid permanent blocking if a previous nudge hung.
 	if !acquireNudgeLock(session, nudgeLockTimeout) {
@@ -1327,6 +1414,9 @@ func (t *Tmux) NudgeSession(session, message string) error {
 	}
 	defer releaseNudgeLock(session)
 
+	// Bind to the session's server so pane-ID targets below reach a remote rig.
+	t = t.forSession(session)
+
 	// Resolve the correct target: in multi-pane sessions, find the pane
 	// running the agent rather than sending to the focused pane.
 	target := session
@@ -1341,43 +1431,98 @@ func (t *Tmux) NudgeSession(session, message string) error {
 		time.Sleep(50 * time.Millisecond)
 	}
 
-	// 2. Sanitize control characters that corrupt delivery
+	// With GT_NUDGE_CORPUS=1, a failed nudge saves the pane before and
+	// after it for the regression tests (see NudgePair).
+	before := t.captureNudgeBefore(target)
+	defer func() { t.recordNudgePair(session, target, message, before, err) }()
+
+	// 2. Wait for the runtime to finish starting and the input field to be
+	//    free — text typed into a half-drawn TUI is lost, and text typed
+	//    while a permission prompt or other modal is open lands in the dialog.
+	if err := t.waitForStartup(session, constants.NudgeReadyTimeout); err != nil {
+		return err
+	}
+	if err := t.waitForInputReady(target, constants.NudgeReadyTimeout); err != nil {
+		return err
+	}
+
+	// 3. Sanitize control characters that corrupt delivery
 	sanitized := sanitizeNudgeMessage(message)
 
-	// 3. Send text via send-keys -l. Messages > 512 bytes are chunked
-	//    with 10ms inter-chunk delays to avoid argument length limits.
-	if err := t.sendMessageToTarget(target, sanitized, constants.NudgeReadyTimeout); err != nil {
+	// The runtime's client hints (GT_CLIENT_HINTS) can skip the Escape or
+	// change the submit key. Otherwise the input widget is probed, so vim
+	// NORMAL mode is put into INSERT before typing and a plain readline
+	// prompt skips the Escape.
+	hints := t.sessionClientHints(session)
+	mode := InputModeUnknown
+	if !hints.SkipEscape || opts.RestoreInput {
+		mode, _ = t.detectInputMode(target)
+	}
+	strategy := InputStrategy{}
+	if !hints.SkipEscape {
+		strategy = mode.Strategy()
+	}
+
+	// With RestoreInput, text already at the prompt is set aside so the
+	// nudge isn't submitted with it.
+	var pending string
+	if opts.RestoreInput {
+		if pending, err = t.setAsidePendingInput(session, target, mode); err != nil {
+			return err
+		}
+		if pending != "" && mode == InputModeVimNormal && !hints.SkipEscape {
+			strategy = InputModeVimInsert.Strategy() // Clearing left it in INSERT
+		}
+	}
+	if err := t.sendInputKeys(target, strategy.BeforeType); err != nil {
+		return err
+	}
+
+	// 4. Send text via send-keys -l (or typed, per the session's typing
+	//    profile). Messages > 512 bytes are chunked with 10ms inter-chunk
+	//    delays to avoid argument length limits; very large ones are pasted
+	//    from a tmux buffer.
+	if err := t.deliverNudgeText(session, target, sanitized, opts.Typing); err != nil {
 		return err
 	}
 
-	// 4. Wait 500ms for text delivery to complete (tested, required)
+	// 5. Wait 500ms for text delivery to complete (tested, required)
 	time.Sleep(500 * time.Millisecond)
 
-	// 5. Send Escape to exit vim INSERT mode if enabled (harmless in normal mode)
-	// See: https://github.com/anthropics/gastown/issues/307
-	_, _ = t.run("send-keys", "-t", target, "Escape")
+	if strategy.EscapeBeforeSubmit {
+		// 6. Send Escape to exit vim INSERT mode
+		// See: https://github.com/anthropics/gastown/issues/307
+		_, _ = t.run("send-keys", "-t", target, "Escape")
 
-	// 6. Wait 600ms — must exceed bash readline's keyseq-timeout (500ms default)
-	// so ESC is processed alone, not as a meta prefix for the subsequent Enter.
-	// Without this, ESC+Enter within 500ms becomes M-Enter (meta-return) which
-	// does NOT submit the line.
-	time.Sleep(600 * time.Millisecond)
+		// 7. Wait 600ms — must exceed bash readline's keyseq-timeout (500ms default)
+		// so ESC is processed alone, not as a meta prefix for the subsequent Enter.
+		// Without this, ESC+Enter within 500ms becomes M-Enter (meta-return) which
+		// does NOT submit the line.
+		time.Sleep(600 * time.Millisecond)
+	}
 
-	// 7. Send Enter with retry (critical for message submission)
+	// 8. Send Enter (or the runtime's submit key) with retry (critical for
+	// message submission)
+	submit := hints.submitKey()
 	var lastErr error
 	for attempt := 0; attempt < 3; attempt++ {
 		if attempt > 0 {
 			time.Sleep(200 * time.Millisecond)
 		}
-		if _, err := t.run("send-keys", "-t", target, "Enter"); err != nil {
+		if _, err := t.run("send-keys", "-t", target, submit); err != nil {
 			lastErr = err
 			continue
 		}
-		// 8. Wake the pane to trigger SIGWINCH for detached sessions
+		// 9. Wake the pane to trigger SIGWINCH for detached sessions
 		t.WakePaneIfDetached(session)
+
+		// 10. Put set-aside input back, unsubmitted.
+		if pending != "" {
+			return t.restoreSetAsideInput(target, pending, mode, strategy.EscapeBeforeSubmit)
+		}
 		return nil
 	}
-	return fmt.Errorf("failed to send Enter after 3 attempts: %w", lastErr)
+	return fmt.Errorf("failed to send %s after 3 attempts: %w", submit, lastErr)
 }
 
 // NudgePane sends a message to a specific pane reliably.
@@ -1399,26 +1544,34 @@ func (t *Tmux) NudgePane(pane, message string) error {
 		time.Sleep(50 * time.Millisecond)
 	}
 
-	// 2. Sanitize control characters that corrupt delivery
+	// 2. Wait for the input field to be free — text typed while a
+	//    permission prompt or other modal is open lands in the dialog.
+	if err := t.waitForInputReady(pane, constants.NudgeReadyTimeout); err != nil {
+		return err
+	}
+
+	// 3. Sanitize control characters that corrupt delivery
 	sanitized := sanitizeNudgeMessage(message)
 
-	// 3. Send text via send-keys -l. Messages > 512 bytes are chunked
-	//    with 10ms inter-chunk delays to avoid argument length limits.
-	if err := t.sendMessageToTarget(pane, sanitized, constants.NudgeReadyTimeout); err != nil {
+	// 4. Send text via send-keys -l (or typed, per the session's typing
+	//    profile). Messages > 512 bytes are chunked with 10ms inter-chunk
+	//    delays to avoid argument length limits; very large ones are pasted
+	//    from a tmux buffer.
+	if err := t.deliverNudgeText(pane, pane, sanitized, nil); err != nil {
 		return err
 	}
 
-	// 4. Wait 500ms for text delivery to complete (tested, required)
+	// 5. Wait 500ms for text delivery to complete (tested, required)
 	time.Sleep(500 * time.Millisecond)
 
-	// 5. Send Escape to exit vim INSERT mode if enabled (harmless in normal mode)
+	// 6. Send Escape to exit vim INSERT mode if enabled (harmless in normal mode)
 	// See: https://github.com/anthropics/gastown/issues/307
 	_, _ = t.run("send-keys", "-t", pane, "Escape")
 
-	// 6. Wait 600ms — must exceed bash readline's keyseq-timeout (500ms default)
+	// 7. Wait 600ms — must exceed bash readline's keyseq-timeout (500ms default)
 	time.Sleep(600 * time.Millisecond)
 
-	// 7. Send Enter with retry (critical for message submission)
+	// 8. Send Enter with retry (critical for message submission)
 	var lastErr error
 	for attempt := 0; attempt < 3; attempt++ {
 		if attempt > 0 {
@@ -1428,7 +1581,7 @@ func (t *Tmux) NudgePane(pane, message string) error {
 			lastErr = err
 			continue
 		}
-		// 8. Wake the pane to trigger SIGWINCH for detached sessions
+		// 9. Wake the pane to trigger SIGWINCH for detached sessions
 		t.WakePaneIfDetached(pane)
 		return nil
 	}
@@ -1599,12 +1752,12 @@ func (t *Tmux) DismissStartupDialogsBlind(session string) error {
 // GetPaneCommand returns the current command running in a pane.
 // Returns "bash", "zsh", "claude", "node", etc.
 func (t *Tmux) GetPaneCommand(session string) (string, error) {
-	// Use display-message targeting the first window explicitly (:^) to avoid
-	// returning the active pane's command when a non-agent window is focused.
-	// Agent processes always run in the first window; without explicit targeting,
-	// a user-created window or split pane (running a shell) could cause health
-	// checks to falsely report the agent as dead.
-	out, err := t.run("display-message", "-t", session+":^", "-p", "#{pane_current_command}")
+	// Target the declared agent pane (GT_PANE_ID), else the first window (:^),
+	// to avoid returning the active pane's command when a non-agent window or
+	// layout pane is focused. Without explicit targeting, a user-created window
+	// or split pane (running a shell) could cause health checks to falsely
+	// report the agent as dead.
+	out, err := t.run("display-message", "-t", t.agentPaneTarget(session), "-p", "#{pane_current_command}")
 	if err != nil {
 		return "", err
 	}
@@ -1693,6 +1846,47 @@ func (t *Tmux) GetPaneID(session string) (string, error) {
 	return result, nil
 }
 
+// GetPaneStartCommand returns the command a session's first pane was started
+// (or last respawned) with. Returns "" if the pane was started with the
+// default shell.
+func (t *Tmux) GetPaneStartCommand(session string) (string, error) {
+	out, err := t.run("display-message", "-t", session+":0.0", "-p", "#{pane_start_command}")
+	if err != nil {
+		return "", err
+	}
+	return unquoteTmuxArg(strings.TrimSpace(out)), nil
+}
+
+// unquoteTmuxArg reverses tmux's argument escaping in formats such as
+// #{pane_start_command}, which wraps commands containing spaces or shell
+// metacharacters in double quotes and backslash-escapes ", \, and $.
+func unquoteTmuxArg(s string) string {
+	if len(s) < 2 || s[0] != '"' || s[len(s)-1] != '"' {
+		return s
+	}
+	inner := s[1 : len(s)-1]
+	var b strings.Builder
+	b.Grow(len(inner))
+	for i := 0; i < len(inner); i++ {
+		c := inner[i]
+		if c != '\\' || i+1 == len(inner) {
+			b.WriteByte(c)
+			continue
+		}
+		i++
+		// Non-printable bytes are escaped as three-digit octal (\012).
+		if i+2 < len(inner) && isOctal(inner[i]) && isOctal(inner[i+1]) && isOctal(inner[i+2]) {
+			b.WriteByte((inner[i]-'0')<<6 | (inner[i+1]-'0')<<3 | (inner[i+2] - '0'))
+			i += 2
+			continue
+		}
+		b.WriteByte(inner[i])
+	}
+	return b.String()
+}
+
+func isOctal(c byte) bool { return c >= '0' && c <= '7' }
+
 // GetPaneWorkDir returns the current working directory of a pane.
 // Targets pane 0 explicitly to avoid returning the active pane's
 // working directory in multi-pane sessions.
@@ -1709,15 +1903,12 @@ func (t *Tmux) GetPaneWorkDir(session string) (string, error) {
 }
 
 // GetPanePID returns the PID of the pane's main process.
-// When target is a session name, explicitly targets the first window (:^) to avoid
-// returning the active pane's PID when a non-agent window is focused. When target is
-// a pane ID (e.g., "%5"), uses it directly.
+// When target is a session name, targets the declared agent pane (GT_PANE_ID),
+// else the first window (:^), to avoid returning the active pane's PID when a
+// non-agent window or layout pane is focused. When target is a pane ID (e.g.,
+// "%5"), uses it directly.
 func (t *Tmux) GetPanePID(target string) (string, error) {
-	tmuxTarget := target
-	if !strings.HasPrefix(target, "%") {
-		tmuxTarget = target + ":^"
-	}
-	out, err := t.run("display-message", "-t", tmuxTarget, "-p", "#{pane_pid}")
+	out, err := t.run("display-message", "-t", t.agentPaneTarget(target), "-p", "#{pane_pid}")
 	if err != nil {
 		return "", err
 	}
@@ -1923,14 +2114,16 @@ func (t *Tmux) FindSessionByWorkDir(targetDir string, processNames []string) ([]
 	return matches, nil
 }
 
-// CapturePane captures the visible content of a pane.
+// CapturePane captures the visible content of a pane. A session name
+// captures the session's agent pane, not whichever pane has focus.
 func (t *Tmux) CapturePane(session string, lines int) (string, error) {
-	return t.run("capture-pane", "-p", "-t", session, "-S", fmt.Sprintf("-%d", lines))
+	return t.run("capture-pane", "-p", "-t", t.agentPaneTarget(session), "-S", fmt.Sprintf("-%d", lines))
 }
 
-// CapturePaneAll captures all scrollback history.
+// CapturePaneAll captures all scrollback history of a pane, or of a
+// session's agent pane.
 func (t *Tmux) CapturePaneAll(session string) (string, error) {
-	return t.run("capture-pane", "-p", "-t", session, "-S", "-")
+	return t.run("capture-pane", "-p", "-t", t.agentPaneTarget(session), "-S", "-")
 }
 
 // CapturePaneLines captures the last N lines of a pane as a slice.
@@ -1960,6 +2153,9 @@ func (t *Tmux) SelectWindow(session string, index int) error {
 
 // SetEnvironment sets an environment variable in the session.
 func (t *Tmux) SetEnvironment(session, key, value string) error {
+	if key == "GT_PANE_ID" {
+		t.forgetAgentPane(session)
+	}
 	_, err := t.run("set-environment", "-t", session, key, value)
 	return err
 }
@@ -2005,7 +2201,11 @@ func (t *Tmux) GetAllEnvironment(session string) (map[string]string, error) {
 	if err != nil {
 		return nil, err
 	}
+	return parseEnvironment(out), nil
+}
 
+// parseEnvironment parses show-environment output into a map.
+func parseEnvironment(out string) map[string]string {
 	env := make(map[string]string)
 	for _, line := range strings.Split(out, "\n") {
 		line = strings.TrimSpace(line)
@@ -2018,7 +2218,7 @@ func (t *Tmux) GetAllEnvironment(session string) (map[string]string, error) {
 			env[parts[0]] = parts[1]
 		}
 	}
-	return env, nil
+	return env
 }
 
 // RenameSession renames a session.
@@ -2026,6 +2226,8 @@ func (t *Tmux) RenameSession(oldName, newName string) error {
 	if err := validateSessionName(newName); err != nil {
 		return err
 	}
+	t.forgetAgentPane(oldName)
+	t.forgetAgentPane(newName)
 	_, err := t.run("rename-session", "-t", oldName, newName)
 	return err
 }
@@ -2034,10 +2236,12 @@ func (t *Tmux) RenameSession(oldName, newName string) error {
 type SessionInfo struct {
 	Name         string
 	Windows      int
-	Created      string
+	Created      string    // Creation time in local time, for display
+	CreatedAt    time.Time // Creation time; zero if tmux didn't report it
 	Attached     bool
-	Activity     string // Last activity time
-	LastAttached string // Last time the session was attached
+	Activity     string    // Last activity time (unix seconds, as tmux reports it)
+	ActivityAt   time.Time // Last activity time; zero if tmux didn't report it
+	LastAttached string    // Last time the session was attached
 }
 
 // DisplayMessage shows a message in the tmux status line.
@@ -2208,6 +2412,15 @@ func (t *Tmux) resolveSessionProcessNames(session string) []string {
 	return config.GetProcessNames(agentName) // Returns Claude defaults if empty
 }
 
+// processNamesFromEnv is resolveSessionProcessNames over an already-read
+// session environment.
+func processNamesFromEnv(env map[string]string) []string {
+	if names := env["GT_PROCESS_NAMES"]; names != "" {
+		return strings.Split(names, ",")
+	}
+	return config.GetProcessNames(env["GT_AGENT"])
+}
+
 // WaitForCommand polls until the pane is NOT running one of the excluded commands.
 // Useful for waiting until a shell has started a new process (e.g., claude).
 // Returns nil when a non-excluded command is detected, or error on timeout.
@@ -2274,6 +2487,24 @@ func (t *Tmux) WaitForShellReady(session string, timeout time.Duration) error {
 	return fmt.Errorf("timeout waiting for shell")
 }
 
+// matchesPromptPrefix reports whether a captured pane line matches the
+// configured ready-prompt prefix. It normalizes non-breaking spaces
+// (U+00A0) to regular spaces before matching, because Claude Code uses
+// NBSP after its ❯ prompt character while the default ReadyPromptPrefix
+// uses a regular space. See https://github.com/steveyegge/gastown/issues/1387.
+func matchesPromptPrefix(line, readyPromptPrefix string) bool {
+	if readyPromptPrefix == "" {
+		return false
+	}
+	trimmed := strings.TrimSpace(line)
+	// Normalize NBSP (U+00A0) → regular space so that prompt matching
+	// works regardless of which whitespace character the agent uses.
+	trimmed = strings.ReplaceAll(trimmed, "\u00a0", " ")
+	normalizedPrefix := strings.ReplaceAll(readyPromptPrefix, "\u00a0", " ")
+	prefix := strings.TrimSpace(normalizedPrefix)
+	return strings.HasPrefix(trimmed, normalizedPrefix) || (prefix != "" && trimmed == prefix)
+}
+
 // WaitForRuntimeReady polls until the runtime's prompt indicator appears in the pane.
 // Runtime is ready when we see the configured prompt prefix at the start of a line.
 //
@@ -2293,51 +2524,50 @@ func (t *Tmux) WaitForShellReady(session string, timeout time.Duration) error {
 //	- Deacon monitoring polecats → use patrol formula + AI analysis
 //	- Deacon restarting → Mayor watches via 'gt peek'
 //	- Mayor restarting → Deacon watches via 'gt peek'
-
-// matchesPromptPrefix reports whether a captured pane line matches the
-// configured ready-prompt prefix. It normalizes non-breaking spaces
-// (U+00A0) to regular spaces before matching, because Claude Code uses
-// NBSP after its ❯ prompt character while the default ReadyPromptPrefix
-// uses a regular space. See https://github.com/steveyegge/gastown/issues/1387.
-func matchesPromptPrefix(line, readyPromptPrefix string) bool {
-	if readyPromptPrefix == "" {
-		return false
-	}
-	trimmed := strings.TrimSpace(line)
-	// Normalize NBSP (U+00A0) → regular space so that prompt matching
-	// works regardless of which whitespace character the agent uses.
-	trimmed = strings.ReplaceAll(trimmed, "\u00a0", " ")
-	normalizedPrefix := strings.ReplaceAll(readyPromptPrefix, "\u00a0", " ")
-	prefix := strings.TrimSpace(normalizedPrefix)
-	return strings.HasPrefix(trimmed, normalizedPrefix) || (prefix != "" && trimmed == prefix)
-}
-
+//
+// Runtimes without a prompt prefix wait ReadyDelayMs, then, if
+// ReadyStableMs is set (or neither a prefix nor a delay is configured),
+// until the pane output stops changing for that long.
+//
+// Returns ErrRuntimeNotReady, with the last pane line, if the runtime isn't
+// ready within timeout, and ErrSessionNotFound if the session dies first.
 func (t *Tmux) WaitForRuntimeReady(session string, rc *config.RuntimeConfig, timeout time.Duration) error {
 	if rc == nil || rc.Tmux == nil {
 		return nil
 	}
+	deadline := time.Now().Add(timeout)
 
 	if rc.Tmux.ReadyPromptPrefix == "" {
-		if rc.Tmux.ReadyDelayMs <= 0 {
-			return nil
+		if rc.Tmux.ReadyDelayMs > 0 {
+			// Fixed delay when prompt detection is unavailable.
+			delay := time.Duration(rc.Tmux.ReadyDelayMs) * time.Millisecond
+			if delay > timeout {
+				delay = timeout
+			}
+			time.Sleep(delay)
 		}
-		// Fallback to fixed delay when prompt detection is unavailable.
-		delay := time.Duration(rc.Tmux.ReadyDelayMs) * time.Millisecond
-		if delay > timeout {
-			delay = timeout
+		stable := time.Duration(rc.Tmux.ReadyStableMs) * time.Millisecond
+		if stable <= 0 && rc.Tmux.ReadyDelayMs <= 0 {
+			stable = DefaultReadyStable
 		}
-		time.Sleep(delay)
-		return nil
+		if stable <= 0 {
+			return nil
+		}
+		return t.waitForStablePane(session, stable, deadline)
 	}
 
-	deadline := time.Now().Add(timeout)
+	var last []string
 	for time.Now().Before(deadline) {
 		// Capture last few lines of the pane
 		lines, err := t.CapturePaneLines(session, 10)
 		if err != nil {
+			if errors.Is(err, ErrSessionNotFound) || errors.Is(err, ErrNoServer) {
+				return err
+			}
 			time.Sleep(200 * time.Millisecond)
 			continue
 		}
+		last = lines
 		// Look for runtime prompt indicator at start of line
 		for _, line := range lines {
 			if matchesPromptPrefix(line, rc.Tmux.ReadyPromptPrefix) {
@@ -2346,7 +2576,79 @@ func (t *Tmux) WaitForRuntimeReady(session string, rc *config.RuntimeConfig, tim
 		}
 		time.Sleep(200 * time.Millisecond)
 	}
-	return fmt.Errorf("timeout waiting for runtime prompt")
+	return fmt.Errorf("%w: no %q prompt after %s (last output: %q)",
+		ErrRuntimeNotReady, rc.Tmux.ReadyPromptPrefix, timeout, lastPaneLine(last))
+}
+
+// MarkStarting marks the session's runtime as starting for at most timeout,
+// so nudges hold off until MarkStarted is called or the timeout passes.
+func (t *Tmux) MarkStarting(session string, timeout time.Duration) error {
+	return t.SetEnvironment(session, EnvStartupDeadline, strconv.FormatInt(time.Now().Add(timeout).Unix(), 10))
+}
+
+// MarkStarted clears the mark set by MarkStarting.
+func (t *Tmux) MarkStarted(session string) error {
+	_, err := t.run("set-environment", "-u", "-t", session, EnvStartupDeadline)
+	return err
+}
+
+// waitForStartup waits, up to timeout, while the session is marked as
+// starting. Returns ErrInputBlocked if it is still starting after timeout.
+func (t *Tmux) waitForStartup(session string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		val, err := t.GetEnvironment(session, EnvStartupDeadline)
+		if err != nil {
+			return nil
+		}
+		until, err := strconv.ParseInt(val, 10, 64)
+		if err != nil || time.Now().Unix() >= until {
+			return nil
+		}
+		if !time.Now().Before(deadline) {
+			return fmt.Errorf("%w: agent still starting after %s", ErrInputBlocked, timeout)
+		}
+		time.Sleep(constants.DialogPollInterval)
+	}
+}
+
+// DefaultReadyStable is how long pane output must stay unchanged for a
+// runtime with no readiness configuration to count as started.
+const DefaultReadyStable = 2 * time.Second
+
+// waitForStablePane polls until the pane's visible output has been
+// unchanged for stable: a generic sign that a TUI has finished drawing.
+// A blank pane doesn't count, since the runtime hasn't drawn anything yet.
+func (t *Tmux) waitForStablePane(session string, stable time.Duration, deadline time.Time) error {
+	var last string
+	var since time.Time
+	for time.Now().Before(deadline) {
+		out, err := t.CapturePane(session, 0)
+		if err != nil {
+			if errors.Is(err, ErrSessionNotFound) || errors.Is(err, ErrNoServer) {
+				return err
+			}
+			last = ""
+		} else if out != last || strings.TrimSpace(out) == "" {
+			last, since = out, time.Now()
+		} else if time.Since(since) >= stable {
+			return nil
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+	return fmt.Errorf("%w: output still changing (last output: %q)",
+		ErrRuntimeNotReady, lastPaneLine(strings.Split(last, "\n")))
+}
+
+// lastPaneLine returns the last non-blank line of captured pane output, for
+// readiness failure messages.
+func lastPaneLine(lines []string) string {
+	for i := len(lines) - 1; i >= 0; i-- {
+		if line := strings.TrimSpace(lines[i]); line != "" {
+			return line
+		}
+	}
+	return ""
 }
 
 // DefaultReadyPromptPrefix is the Claude Code prompt prefix used for idle detection.
@@ -2484,17 +2786,112 @@ func (t *Tmux) IsIdle(session string) bool {
 	return false
 }
 
+// inputDialogMarkers are strings that appear in agent TUI modal dialogs
+// (permission prompts, trust/bypass warnings). While one of these is on
+// screen, typed text lands in the dialog instead of the input field.
+var inputDialogMarkers = []string{
+	"Do you want to",
+	"Esc to cancel",
+	"Enter to confirm",
+	"trust this folder",
+	"Bypass Permissions mode",
+}
+
+// dialogOptionRe matches the highlighted line of a numbered selection menu,
+// such as "❯ 1. Yes". The selection cursor uses the same glyph as the input
+// prompt, so option lines must be excluded before treating "❯ " as an empty
+// input field.
+var dialogOptionRe = regexp.MustCompile(`^(❯|>)\s*\d+\.\s`)
+
+// shellPromptSuffixes mark a plain shell prompt line. Unlike promptSuffixes,
+// ">" is excluded because it also prefixes agent dialog selections.
+var shellPromptSuffixes = []string{"$", "%", "#"}
+
+// inputReadyScanLines is how many non-empty lines, from the bottom of the
+// visible pane, are inspected for a prompt or dialog.
+const inputReadyScanLines = 15
+
+// inputBlockedReason inspects a visible pane capture and reports why typed
+// input would not reach the agent's input field, or "" if it looks safe.
+//
+// The scan walks up from the bottom: whichever is found first wins. An input
+// prompt line below any dialog text means the dialog is stale scrollback; a
+// dialog marker or menu option below the prompt means a modal is open.
+// Panes with neither (plain shells, runtimes with other prompts) are treated
+// as ready so this never blocks agents it doesn't understand.
+func inputBlockedReason(lines []string) string {
+	seen := 0
+	for i := len(lines) - 1; i >= 0 && seen < inputReadyScanLines; i-- {
+		// Strip NBSPs and the box-drawing borders agents draw around dialogs.
+		trimmed := strings.ReplaceAll(lines[i], "\u00a0", " ")
+		trimmed = strings.TrimSpace(strings.Trim(strings.TrimSpace(trimmed), "│┃"))
+		if trimmed == "" {
+			continue
+		}
+		seen++
+		if dialogOptionRe.MatchString(trimmed) {
+			return "selection menu open"
+		}
+		for _, marker := range inputDialogMarkers {
+			if strings.Contains(trimmed, marker) {
+				return fmt.Sprintf("dialog open (%q)", marker)
+			}
+		}
+		if matchesPromptPrefix(trimmed, DefaultReadyPromptPrefix) {
+			return ""
+		}
+		for _, suffix := range shellPromptSuffixes {
+			if strings.HasSuffix(trimmed, suffix) {
+				return ""
+			}
+		}
+	}
+	return ""
+}
+
+// waitForInputReady polls the target's visible pane until no modal dialog is
+// covering the input field, so a nudge is not typed into a permission prompt.
+// Returns ErrInputBlocked if a dialog is still open after timeout. Capture
+// failures other than a missing session are ignored; the send-keys retry
+// loop handles panes that are not ready at the tmux level.
+func (t *Tmux) waitForInputReady(target string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		lines, err := t.CapturePaneLines(target, 0)
+		if err != nil {
+			if errors.Is(err, ErrSessionNotFound) || errors.Is(err, ErrNoServer) {
+				return err
+			}
+			return nil
+		}
+		reason := inputBlockedReason(lines)
+		if reason == "" {
+			return nil
+		}
+		if !time.Now().Before(deadline) {
+			return fmt.Errorf("%w: %s after %s", ErrInputBlocked, reason, timeout)
+		}
+		time.Sleep(constants.DialogPollInterval)
+	}
+}
+
+// sessionInfoFormat is the list-sessions format parsed by parseSessionInfo.
+const sessionInfoFormat = "#{session_name}|#{session_windows}|#{session_created}|#{session_attached}|#{session_activity}|#{session_last_attached}"
+
 // GetSessionInfo returns detailed information about a session.
 func (t *Tmux) GetSessionInfo(name string) (*SessionInfo, error) {
-	format := "#{session_name}|#{session_windows}|#{session_created}|#{session_attached}|#{session_activity}|#{session_last_attached}"
-	out, err := t.run("list-sessions", "-F", format, "-f", fmt.Sprintf("#{==:#{session_name},%s}", name))
+	out, err := t.run("list-sessions", "-F", sessionInfoFormat, "-f", fmt.Sprintf("#{==:#{session_name},%s}", name))
 	if err != nil {
 		return nil, err
 	}
 	if out == "" {
 		return nil, ErrSessionNotFound
 	}
+	return parseSessionInfo(out)
+}
 
+// parseSessionInfo parses one line of sessionInfoFormat output.
+func parseSessionInfo(out string) (*SessionInfo, error) {
 	parts := strings.Split(out, "|")
 	if len(parts) < 4 {
 		return nil, fmt.Errorf("unexpected session info format: %s", out)
@@ -2503,23 +2900,31 @@ func (t *Tmux) GetSessionInfo(name string) (*SessionInfo, error) {
 	windows := 0
 	_, _ = fmt.Sscanf(parts[1], "%d", &windows) // non-fatal: defaults to 0 on parse error
 
-	// Convert unix timestamp to formatted string for consumers.
+	// Convert unix timestamp to formatted string for consumers. The string
+	// is local wall-clock time and is ambiguous across a DST fall-back, so
+	// age computations use CreatedAt instead.
 	created := parts[2]
+	var createdAt time.Time
 	var createdUnix int64
 	if _, err := fmt.Sscanf(created, "%d", &createdUnix); err == nil && createdUnix > 0 {
-		created = time.Unix(createdUnix, 0).Format("2006-01-02 15:04:05")
+		createdAt = time.Unix(createdUnix, 0)
+		created = createdAt.Format("2006-01-02 15:04:05")
 	}
 
 	info := &SessionInfo{
-		Name:     parts[0],
-		Windows:  windows,
-		Created:  created,
-		Attached: parts[3] == "1",
+		Name:      parts[0],
+		Windows:   windows,
+		Created:   created,
+		CreatedAt: createdAt,
+		Attached:  parts[3] == "1",
 	}
 
 	// Activity and last attached are optional (may not be present in older tmux)
 	if len(parts) > 4 {
 		info.Activity = parts[4]
+		if activityUnix, err := strconv.ParseInt(parts[4], 10, 64); err == nil && activityUnix > 0 {
+			info.ActivityAt = time.Unix(activityUnix, 0)
+		}
 	}
 	if len(parts) > 5 {
 		info.LastAttached = parts[5]
@@ -2576,6 +2981,32 @@ func (t *Tmux) SetStatusFormat(session, rig, worker, role string) error {
 	return err
 }
 
+// MailIndicatorOption is the session user option holding the agent's inbox
+// indicator (unread count and oldest-unread age), kept current by
+// 'gt mail notify'. gt status-line shows it as its mail part.
+const MailIndicatorOption = "@gt_mail"
+
+// SetMailIndicator sets a session's inbox indicator text ("" for an empty
+// inbox).
+func (t *Tmux) SetMailIndicator(session, text string) error {
+	_, err := t.run("set-option", "-t", session, MailIndicatorOption, text)
+	return err
+}
+
+// GetMailIndicator returns a session's inbox indicator text. ok is false
+// when the indicator was never set, i.e. 'gt mail notify' isn't keeping it.
+func (t *Tmux) GetMailIndicator(session string) (text string, ok bool, err error) {
+	out, err := t.run("show-options", "-q", "-t", session, MailIndicatorOption)
+	if err != nil || out == "" {
+		return "", false, err
+	}
+	text, err = t.run("show-options", "-qv", "-t", session, MailIndicatorOption)
+	if err != nil {
+		return "", false, err
+	}
+	return text, true, nil
+}
+
 // SetDynamicStatus configures the right side with dynamic content.
 // Uses a shell command that tmux calls periodically to get current status.
 func (t *Tmux) SetDynamicStatus(session string) error {
@@ -3150,7 +3581,7 @@ func (t *Tmux) CleanupOrphanedSessions(isGTSession func(string) bool) (cleaned i
 			// Kill the zombie session
 			if killErr := t.KillSessionWithProcesses(sess); killErr != nil {
 				// Log but continue - other sessions may still need cleanup
-				fmt.Printf("  warning: failed to kill orphaned session %s: %v\n", sess, killErr)
+				logger.Warn("failed to kill orphaned session", "session", sess, "err", killErr)
 				continue
 			}
 			cleaned++
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"runtime"
 	"strings"
 	"testing"
@@ -22,7 +23,7 @@ func hasTmux() bool {
 //
 // This isolates tests from the user's interactive tmux and from other
 // packages' tests that run in parallel during `go test ./...`.
-func newTestTmux(t *testing.T) *Tmux {
+func newTestTmux(t testing.TB) *Tmux {
 	t.Helper()
 	if !hasTmux() {
 		t.Skip("tmux not installed")
@@ -196,6 +197,7 @@ func TestWrapError(t *testing.T) {
 		{"duplicate session: test", ErrSessionExists},
 		{"session not found: test", ErrSessionNotFound},
 		{"can't find session: test", ErrSessionNotFound},
+		{"can't find pane: test", ErrSessionNotFound},
 	}
 
 	for _, tt := range tests {
@@ -2171,3 +2173,134 @@ func TestCheckSessionHealth_ActivityCheck(t *testing.T) {
 	// without needing a real Claude process.
 }
 
+
+func TestUnquoteTmuxArg(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{in: "", want: ""},
+		{in: "claude", want: "claude"},
+		{in: `"sleep 200"`, want: "sleep 200"},
+		{in: `"sleep 200 && echo \"x y\""`, want: `sleep 200 && echo "x y"`},
+		{in: `"echo \$HOME ~x \\back"`, want: `echo $HOME ~x \back`},
+		{in: `"a\012b"`, want: "a\nb"},
+	}
+	for _, tt := range tests {
+		if got := unquoteTmuxArg(tt.in); got != tt.want {
+			t.Errorf("unquoteTmuxArg(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestInputBlockedReason(t *testing.T) {
+	tests := []struct {
+		name        string
+		lines       []string
+		wantBlocked bool
+	}{
+		{name: "empty pane", lines: nil},
+		{name: "plain shell", lines: []string{"$ ls", "foo bar", "$ "}},
+		{
+			name: "claude idle prompt",
+			lines: []string{
+				"● Done.",
+				"────────────────",
+				"❯ ",
+				"────────────────",
+				"  ⏵⏵ bypass permissions on (shift+tab to cycle)",
+			},
+		},
+		{
+			name: "permission prompt",
+			lines: []string{
+				"│ Bash command                      │",
+				"│   rm -rf build                    │",
+				"│ Do you want to proceed?           │",
+				"│ ❯ 1. Yes                          │",
+				"│   2. No, and tell Claude what to do │",
+			},
+			wantBlocked: true,
+		},
+		{
+			name: "selection menu without marker text",
+			lines: []string{
+				"Select model",
+				"❯ 1. Default",
+				"  2. Opus",
+			},
+			wantBlocked: true,
+		},
+		{
+			name: "esc to cancel footer",
+			lines: []string{
+				" Do you want to make this edit to main.go?",
+				"   1. Yes",
+				"   2. No",
+				"",
+				" Esc to cancel · Tab to add additional instructions",
+			},
+			wantBlocked: true,
+		},
+		{
+			name: "stale dialog text above prompt",
+			lines: []string{
+				"Do you want to proceed?",
+				"● Ran rm -rf build",
+				"❯ ",
+				"  ⏵⏵ accept edits on",
+			},
+		},
+		{
+			name:  "numbered list in shell output",
+			lines: []string{"1. first", "2. second", "$ "},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := inputBlockedReason(tt.lines)
+			if (got != "") != tt.wantBlocked {
+				t.Errorf("inputBlockedReason() = %q, wantBlocked %v", got, tt.wantBlocked)
+			}
+		})
+	}
+}
+
+func TestMailIndicator(t *testing.T) {
+	tm := newTestTmux(t)
+	name := newBatchSessions(t, tm, 1)[0]
+
+	if _, ok, err := tm.GetMailIndicator(name); ok || err != nil {
+		t.Fatalf("GetMailIndicator before any set = ok %v, %v; want unset", ok, err)
+	}
+	if err := tm.SetMailIndicator(name, ""); err != nil {
+		t.Fatal(err)
+	}
+	if text, ok, err := tm.GetMailIndicator(name); !ok || text != "" || err != nil {
+		t.Errorf("GetMailIndicator for an empty inbox = %q, %v, %v; want set and empty", text, ok, err)
+	}
+	if err := tm.SetMailIndicator(name, "📬 3 (2h)"); err != nil {
+		t.Fatal(err)
+	}
+	if text, ok, err := tm.GetMailIndicator(name); !ok || text != "📬 3 (2h)" || err != nil {
+		t.Errorf("GetMailIndicator = %q, %v, %v", text, ok, err)
+	}
+}
+
+func TestPruneNudgeCorpus(t *testing.T) {
+	dir := t.TempDir()
+	// Name order (by session) differs from recording order.
+	for _, name := range []string{"hq-mayor-300", "gt-gastown-nux-100", "hq-deacon-200", "gt-gastown-nux-400"} {
+		if err := os.WriteFile(filepath.Join(dir, name+".json"), []byte("{}"), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	pruneNudgeCorpus(dir, 2)
+	paths, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	var got []string
+	for _, p := range paths {
+		got = append(got, filepath.Base(p))
+	}
+	if want := []string{"gt-gastown-nux-400.json", "hq-mayor-300.json"}; strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("kept %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,127 @@
+package tmux
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/constants"
+)
+
+// EnvTypingProfile is the tmux session environment variable that selects
+// human-like typing for nudge delivery. Format: "<delayMs>[,<jitterMs>]".
+// Set at session startup from the agent's runtime tmux config
+// (typing_delay_ms / typing_jitter_ms). When absent, nudges use bulk
+// send-keys -l and only fall back to typing if literal injection fails.
+const EnvTypingProfile = "GT_TYPING"
+
+// errNotReadyForInput is returned by sendKeysLiteralWithRetry when the target
+// never accepted the first literal chunk. Nothing has been delivered at that
+// point, so it is safe to retry the whole message in typed mode.
+var errNotReadyForInput = errors.New("agent not ready for input")
+
+// TypingProfile configures char-by-char delivery for TUIs that drop
+// characters when a whole message arrives in a single send-keys burst.
+type TypingProfile struct {
+	// Delay is the mean pause between characters.
+	Delay time.Duration
+	// Jitter is the maximum random deviation (±) applied to each pause.
+	Jitter time.Duration
+}
+
+// DefaultTypingProfile is used for the automatic fallback after a failed
+// literal injection, when the session has no profile of its own.
+var DefaultTypingProfile = TypingProfile{Delay: 25 * time.Millisecond, Jitter: 15 * time.Millisecond}
+
+// String formats the profile in EnvTypingProfile form.
+func (p TypingProfile) String() string {
+	return fmt.Sprintf("%d,%d", p.Delay.Milliseconds(), p.Jitter.Milliseconds())
+}
+
+// ParseTypingProfile parses an EnvTypingProfile value. Returns false for
+// empty, malformed, or non-positive delays (typing disabled).
+func ParseTypingProfile(s string) (TypingProfile, bool) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return TypingProfile{}, false
+	}
+	delayStr, jitterStr, _ := strings.Cut(s, ",")
+	delayMs, err := strconv.Atoi(strings.TrimSpace(delayStr))
+	if err != nil || delayMs <= 0 {
+		return TypingProfile{}, false
+	}
+	p := TypingProfile{Delay: time.Duration(delayMs) * time.Millisecond}
+	if jitterStr != "" {
+		jitterMs, err := strconv.Atoi(strings.TrimSpace(jitterStr))
+		if err != nil || jitterMs < 0 {
+			return TypingProfile{}, false
+		}
+		p.Jitter = time.Duration(jitterMs) * time.Millisecond
+	}
+	return p, true
+}
+
+// pause returns the delay before the next keystroke: Delay ± Jitter, never negative.
+func (p TypingProfile) pause(rng *rand.Rand) time.Duration {
+	d := p.Delay
+	if p.Jitter > 0 {
+		d += time.Duration(rng.Int63n(int64(2*p.Jitter)+1)) - p.Jitter
+	}
+	if d < 0 {
+		return 0
+	}
+	return d
+}
+
+// sessionTypingProfile returns the typing profile configured for a session
+// via EnvTypingProfile, if any.
+func (t *Tmux) sessionTypingProfile(session string) (TypingProfile, bool) {
+	val, err := t.GetEnvironment(session, EnvTypingProfile)
+	if err != nil {
+		return TypingProfile{}, false
+	}
+	return ParseTypingProfile(val)
+}
+
+// SendKeysTyped sends text to a target one character at a time with
+// human-like pauses. Does not press Enter. Use for TUIs that misbehave on
+// instantaneous bulk input; it is much slower than send-keys -l, so callers
+// should prefer the literal path unless the agent profile asks for typing.
+func (t *Tmux) SendKeysTyped(target, text string, profile TypingProfile) error {
+	rng := rand.New(rand.NewSource(time.Now().UnixNano())) //nolint:gosec // G404: jitter, not security
+	first := true
+	for _, r := range text {
+		if !first {
+			time.Sleep(profile.pause(rng))
+		}
+		first = false
+		if _, err := t.run("send-keys", "-t", target, "-l", string(r)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deliverNudgeText sends nudge text to target without pressing Enter.
+// If typing is given or the session has a typing profile, text is typed
+// char-by-char. Otherwise it uses bulk send-keys -l, and if the literal
+// injection never lands it retries once in typed mode with DefaultTypingProfile.
+func (t *Tmux) deliverNudgeText(session, target, text string, typing *TypingProfile) error {
+	if typing != nil {
+		return t.SendKeysTyped(target, text, *typing)
+	}
+	if profile, ok := t.sessionTypingProfile(session); ok {
+		return t.SendKeysTyped(target, text, profile)
+	}
+	err := t.sendMessageToTarget(target, text, constants.NudgeReadyTimeout)
+	if err == nil || !errors.Is(err, errNotReadyForInput) {
+		return err
+	}
+	if typedErr := t.SendKeysTyped(target, text, DefaultTypingProfile); typedErr != nil {
+		return err
+	}
+	return nil
+}
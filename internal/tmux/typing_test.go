@@ -0,0 +1,57 @@
+package tmux
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestParseTypingProfile(t *testing.T) {
+	tests := []struct {
+		in     string
+		want   TypingProfile
+		wantOK bool
+	}{
+		{in: "", wantOK: false},
+		{in: "0", wantOK: false},
+		{in: "-5", wantOK: false},
+		{in: "abc", wantOK: false},
+		{in: "40,x", wantOK: false},
+		{in: "40,-1", wantOK: false},
+		{in: "40", want: TypingProfile{Delay: 40 * time.Millisecond}, wantOK: true},
+		{in: " 40 , 10 ", want: TypingProfile{Delay: 40 * time.Millisecond, Jitter: 10 * time.Millisecond}, wantOK: true},
+	}
+	for _, tt := range tests {
+		got, ok := ParseTypingProfile(tt.in)
+		if ok != tt.wantOK || got != tt.want {
+			t.Errorf("ParseTypingProfile(%q) = %+v, %v; want %+v, %v", tt.in, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestTypingProfileStringRoundTrip(t *testing.T) {
+	p := TypingProfile{Delay: 30 * time.Millisecond, Jitter: 12 * time.Millisecond}
+	got, ok := ParseTypingProfile(p.String())
+	if !ok || got != p {
+		t.Errorf("round trip of %q = %+v, %v; want %+v", p.String(), got, ok, p)
+	}
+}
+
+func TestTypingProfilePauseBounds(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	p := TypingProfile{Delay: 20 * time.Millisecond, Jitter: 15 * time.Millisecond}
+	for i := 0; i < 1000; i++ {
+		d := p.pause(rng)
+		if d < 5*time.Millisecond || d > 35*time.Millisecond {
+			t.Fatalf("pause() = %v, want within [5ms, 35ms]", d)
+		}
+	}
+
+	// Jitter larger than delay must clamp at zero rather than go negative.
+	p = TypingProfile{Delay: 5 * time.Millisecond, Jitter: 50 * time.Millisecond}
+	for i := 0; i < 1000; i++ {
+		if d := p.pause(rng); d < 0 {
+			t.Fatalf("pause() = %v, want non-negative", d)
+		}
+	}
+}
@@ -0,0 +1,275 @@
+// Package townbundle moves a town's logical state between machines. Where
+// gt backup copies files (including raw Dolt databases), a bundle carries
+// the state itself — every agent bead, hook, mail message, and in-flight
+// molecule as JSON, plus the rigs to re-create and the text config files —
+// so it can be replayed into a freshly installed town on a different host,
+// with absolute paths rewritten for the new location.
+package townbundle
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/backup"
+	"github.com/steveyegge/gastown/internal/beads"
+)
+
+// FormatVersion is the bundle version written by this build.
+const FormatVersion = 1
+
+// maxFileSize caps the config files carried in a bundle. Anything larger is
+// not configuration.
+const maxFileSize = 1 << 20
+
+// Bundle is the portable form of a town.
+type Bundle struct {
+	Version   int              `json:"version"`
+	CreatedAt time.Time        `json:"created_at"`
+	Town      string           `json:"town,omitempty"`
+	TownRoot  string           `json:"town_root"`      // Source location; rewritten to the importing town's root
+	Home      string           `json:"home,omitempty"` // Source $HOME; rewritten to the importing user's home
+	Host      string           `json:"host,omitempty"`
+	Rigs      []backup.RigInfo `json:"rigs,omitempty"`
+	Files     []File           `json:"files,omitempty"`
+	Databases []Database       `json:"databases"`
+}
+
+// File is a config file carried verbatim (after path rewriting).
+type File struct {
+	Path string      `json:"path"` // Slash-separated, relative to the town root
+	Mode fs.FileMode `json:"mode"`
+	Data string      `json:"data"`
+}
+
+// Database is the exported contents of one beads database.
+type Database struct {
+	Rig    string         `json:"rig,omitempty"` // Empty for the town (hq) database
+	Issues []*beads.Issue `json:"issues"`
+}
+
+// Summary counts what a bundle carries, for display.
+type Summary struct {
+	Issues    int
+	Agents    int
+	Hooked    int
+	Mail      int
+	Molecules int
+}
+
+// Summarize counts the bundle's issues by kind.
+func (b *Bundle) Summarize() Summary {
+	var s Summary
+	for _, db := range b.Databases {
+		for _, issue := range db.Issues {
+			s.Issues++
+			switch {
+			case beads.IsAgentBead(issue):
+				s.Agents++
+			case beads.HasLabel(issue, "gt:message"):
+				s.Mail++
+			case IsMolecule(issue):
+				s.Molecules++
+			}
+			if issue.Status == beads.StatusHooked {
+				s.Hooked++
+			}
+		}
+	}
+	return s
+}
+
+// IsMolecule reports whether an issue is a molecule or one of its wisps.
+func IsMolecule(issue *beads.Issue) bool {
+	return issue.Type == "molecule" || beads.HasLabel(issue, "gt:molecule") || issue.Ephemeral
+}
+
+// townConfigDirs and rigConfigDirs are the directories whose files a bundle
+// carries. Everything else is either rebuilt by gt rig add or lives in the
+// beads databases.
+var (
+	townConfigDirs = []string{"settings", "plugins"}
+	rigConfigDirs  = []string{"settings", "plugins"}
+)
+
+// CollectFiles reads the town and rig config files that belong in a bundle.
+func CollectFiles(townRoot string, rigs []backup.RigInfo) ([]File, error) {
+	var dirs []string
+	dirs = append(dirs, townConfigDirs...)
+	for _, r := range rigs {
+		for _, d := range rigConfigDirs {
+			dirs = append(dirs, filepath.Join(r.Name, d))
+		}
+	}
+
+	var files []File
+	for _, dir := range dirs {
+		root := filepath.Join(townRoot, dir)
+		err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) && p == root {
+					return filepath.SkipDir
+				}
+				return err
+			}
+			if d.IsDir() {
+				if d.Name() == ".git" || d.Name() == ".runtime" {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			info, err := d.Info()
+			if err != nil || !info.Mode().IsRegular() || info.Size() > maxFileSize {
+				return nil
+			}
+			data, err := os.ReadFile(p) //nolint:gosec // G304: walking the town's own config dirs
+			if err != nil {
+				return err
+			}
+			rel, err := filepath.Rel(townRoot, p)
+			if err != nil {
+				return err
+			}
+			files = append(files, File{Path: filepath.ToSlash(rel), Mode: info.Mode().Perm(), Data: string(data)})
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", dir, err)
+		}
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+	return files, nil
+}
+
+// Write encodes the bundle as gzipped JSON.
+func Write(w io.Writer, b *Bundle) error {
+	gz := gzip.NewWriter(w)
+	enc := json.NewEncoder(gz)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(b); err != nil {
+		_ = gz.Close()
+		return err
+	}
+	return gz.Close()
+}
+
+// Read decodes a bundle written by Write.
+func Read(r io.Reader) (*Bundle, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("not a gt town bundle: %w", err)
+	}
+	defer gz.Close()
+	var b Bundle
+	if err := json.NewDecoder(gz).Decode(&b); err != nil {
+		return nil, fmt.Errorf("not a gt town bundle: %w", err)
+	}
+	if b.Version == 0 || b.TownRoot == "" {
+		return nil, fmt.Errorf("not a gt town bundle: missing version or town root")
+	}
+	if b.Version > FormatVersion {
+		return nil, fmt.Errorf("bundle format v%d is newer than this gt supports (v%d); upgrade gt", b.Version, FormatVersion)
+	}
+	return &b, nil
+}
+
+// ReadFile decodes the bundle at path.
+func ReadFile(path string) (*Bundle, error) {
+	f, err := os.Open(path) //nolint:gosec // G304: user-supplied bundle path
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Read(f)
+}
+
+// Remapper rewrites absolute path prefixes in strings.
+type Remapper struct {
+	pairs [][2]string
+}
+
+// NewRemapper returns a Remapper for old→new prefix pairs. Longer prefixes
+// are tried first so a town root inside $HOME maps to the new town root, not
+// merely the new home.
+func NewRemapper(pairs map[string]string) *Remapper {
+	r := &Remapper{}
+	for from, to := range pairs {
+		from = strings.TrimRight(from, "/")
+		to = strings.TrimRight(to, "/")
+		if from == "" || from == to {
+			continue
+		}
+		r.pairs = append(r.pairs, [2]string{from, to})
+	}
+	sort.Slice(r.pairs, func(i, j int) bool {
+		if len(r.pairs[i][0]) != len(r.pairs[j][0]) {
+			return len(r.pairs[i][0]) > len(r.pairs[j][0])
+		}
+		return r.pairs[i][0] < r.pairs[j][0]
+	})
+	return r
+}
+
+// Apply rewrites every mapped prefix in s. A prefix only matches as a whole
+// path: /home/al does not match /home/alice or /srv/home/al, but does match
+// inside file:///home/al/repo.git.
+func (r *Remapper) Apply(s string) string {
+	if r == nil || len(r.pairs) == 0 {
+		return s
+	}
+	var out strings.Builder
+	for i := 0; i < len(s); {
+		matched := false
+		for _, p := range r.pairs {
+			from := p[0]
+			if !strings.HasPrefix(s[i:], from) {
+				continue
+			}
+			end := i + len(from)
+			if end < len(s) && !isPathEnd(s[end:]) {
+				continue
+			}
+			if i > 0 && !isPathStart(s[:i]) {
+				continue
+			}
+			out.WriteString(p[1])
+			i = end
+			matched = true
+			break
+		}
+		if !matched {
+			out.WriteByte(s[i])
+			i++
+		}
+	}
+	return out.String()
+}
+
+// isPathEnd reports whether a path may end right before after. A trailing
+// period ends a path when it ends the sentence.
+func isPathEnd(after string) bool {
+	if after[0] == '.' {
+		return len(after) == 1 || isPathBoundary(after[1]) && after[1] != '/'
+	}
+	return isPathBoundary(after[0])
+}
+
+func isPathBoundary(c byte) bool {
+	return c == '/' || !(c == '-' || c == '_' || c == '.' || c >= '0' && c <= '9' || c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z')
+}
+
+// isPathStart reports whether a path may begin right after before.
+func isPathStart(before string) bool {
+	if strings.HasSuffix(before, "://") {
+		return true
+	}
+	c := before[len(before)-1]
+	return c != '/' && isPathBoundary(c)
+}
@@ -0,0 +1,207 @@
+package townbundle
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/backup"
+	"github.com/steveyegge/gastown/internal/beads"
+)
+
+func TestRemapperApply(t *testing.T) {
+	r := NewRemapper(map[string]string{
+		"/home/al":    "/Users/al",
+		"/home/al/gt": "/srv/town",
+		"/same":       "/same",
+	})
+	tests := map[string]string{
+		"worktree: /home/al/gt/app/polecats/nux": "worktree: /srv/town/app/polecats/nux",
+		"clone of /home/al/src/app.":             "clone of /Users/al/src/app.",
+		"file:///home/al/repos/app.git":          "file:///Users/al/repos/app.git",
+		"/home/alice/gt":                         "/home/alice/gt",
+		"/srv/home/al/gt":                        "/srv/home/al/gt",
+		"/home/al/gt/app.json":                   "/srv/town/app.json",
+		`{"dir":"/home/al/gt"}`:                  `{"dir":"/srv/town"}`,
+		"no paths here":                          "no paths here",
+	}
+	for in, want := range tests {
+		if got := r.Apply(in); got != want {
+			t.Errorf("Apply(%q) = %q, want %q", in, got, want)
+		}
+	}
+	if got := (*Remapper)(nil).Apply("/home/al"); got != "/home/al" {
+		t.Errorf("nil Remapper changed input: %q", got)
+	}
+}
+
+func TestWriteReadRoundTrip(t *testing.T) {
+	b := &Bundle{
+		Version:  FormatVersion,
+		Town:     "hq-test",
+		TownRoot: "/home/al/gt",
+		Rigs:     []backup.RigInfo{{Name: "app", GitURL: "https://example.com/app.git", Prefix: "ap", Crew: []string{"max"}}},
+		Files:    []File{{Path: "settings/config.json", Mode: 0644, Data: "{}"}},
+		Databases: []Database{
+			{Issues: []*beads.Issue{{ID: "hq-1", Title: "mail", Labels: []string{"gt:message"}}}},
+			{Rig: "app", Issues: []*beads.Issue{{ID: "ap-1", Title: "work", Status: beads.StatusHooked}}},
+		},
+	}
+	var buf bytes.Buffer
+	if err := Write(&buf, b); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	got, err := Read(&buf)
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if !reflect.DeepEqual(got, b) {
+		t.Errorf("round trip mismatch:\n got %+v\nwant %+v", got, b)
+	}
+	if s := got.Summarize(); s != (Summary{Issues: 2, Mail: 1, Hooked: 1}) {
+		t.Errorf("Summarize = %+v", s)
+	}
+
+	if _, err := Read(strings.NewReader("plain text")); err == nil || !strings.Contains(err.Error(), "not a gt town bundle") {
+		t.Errorf("Read(non-bundle) err = %v", err)
+	}
+}
+
+func TestCollectAndWriteFiles(t *testing.T) {
+	src := t.TempDir()
+	for name, data := range map[string]string{
+		"settings/config.json":        `{"dir":"` + src + `/app"}`,
+		"plugins/p/plugin.md":         "plugin",
+		"app/settings/config.json":    "{}",
+		"app/config.json":             "not carried",
+		"mayor/town.json":             "not carried",
+		"settings/.runtime/state.pid": "1",
+	} {
+		p := filepath.Join(src, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	files, err := CollectFiles(src, []backup.RigInfo{{Name: "app"}, {Name: "gone"}})
+	if err != nil {
+		t.Fatalf("CollectFiles: %v", err)
+	}
+	var paths []string
+	for _, f := range files {
+		paths = append(paths, f.Path)
+	}
+	want := []string{"app/settings/config.json", "plugins/p/plugin.md", "settings/config.json"}
+	if !reflect.DeepEqual(paths, want) {
+		t.Fatalf("CollectFiles = %v, want %v", paths, want)
+	}
+
+	dest := t.TempDir()
+	if _, err := WriteFiles(dest, files, NewRemapper(map[string]string{src: dest})); err != nil {
+		t.Fatalf("WriteFiles: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dest, "settings", "config.json"))
+	if err != nil || string(data) != `{"dir":"`+dest+`/app"}` {
+		t.Errorf("settings/config.json = %q, %v", data, err)
+	}
+
+	if _, err := WriteFiles(dest, []File{{Path: "../evil", Data: "x"}}, nil); err == nil {
+		t.Error("WriteFiles accepted a path outside the town")
+	}
+}
+
+// fakeStore records calls in order.
+type fakeStore struct {
+	existing map[string]string // ID -> status
+	failing  map[string]bool
+	calls    []string
+	created  map[string]*beads.Issue
+}
+
+func (s *fakeStore) Status(id string) (string, bool) {
+	status, ok := s.existing[id]
+	return status, ok
+}
+
+func (s *fakeStore) Create(issue *beads.Issue) error {
+	if s.failing[issue.ID] {
+		return errors.New("boom")
+	}
+	s.calls = append(s.calls, "create "+issue.ID)
+	s.created[issue.ID] = issue
+	return nil
+}
+
+func (s *fakeStore) Update(issue *beads.Issue) error {
+	s.calls = append(s.calls, "update "+issue.ID)
+	return nil
+}
+
+func (s *fakeStore) SetAgentState(id, state string) error {
+	s.calls = append(s.calls, "agent "+id+" "+state)
+	return nil
+}
+
+func (s *fakeStore) SetStatus(id, status string) error {
+	s.calls = append(s.calls, "status "+id+" "+status)
+	return nil
+}
+
+func (s *fakeStore) AddDependency(id, dependsOn, depType string) error {
+	s.calls = append(s.calls, "dep "+id+" "+dependsOn+" "+depType)
+	return nil
+}
+
+func TestImportIssues(t *testing.T) {
+	issues := []*beads.Issue{
+		{ID: "ap-2", Title: "step", Parent: "ap-1", Status: beads.StatusHooked, Assignee: "app/polecats/nux",
+			Dependencies: []beads.IssueDep{{ID: "ap-1", DependencyType: "parent-child"}, {ID: "ap-3", DependencyType: "blocks"}}},
+		{ID: "ap-1", Title: "epic", Description: "see /old/town/app/notes.md"},
+		{ID: "ap-3", Title: "done already", Status: "closed",
+			Dependencies: []beads.IssueDep{{ID: "ap-gone", DependencyType: "blocks"}, {ID: "ap-old", DependencyType: "blocks"}}},
+		{ID: "ap-4", Title: "exists", Status: "open"},
+		{ID: "ap-5", Title: "fails"},
+		{ID: "ap-nux", Title: "agent", Type: "agent", Status: "open", AgentState: "working"},
+	}
+	store := &fakeStore{
+		existing: map[string]string{"ap-4": "open", "ap-nux": "open", "ap-old": "closed"},
+		failing:  map[string]bool{"ap-5": true},
+		created:  map[string]*beads.Issue{},
+	}
+	res := ImportIssues(store, issues, NewRemapper(map[string]string{"/old/town": "/new/town"}))
+
+	if res.Created != 3 || res.Updated != 2 || len(res.Errors) != 1 || !strings.HasPrefix(res.Errors[0], "ap-5: create") {
+		t.Errorf("result = %+v", res)
+	}
+	if !reflect.DeepEqual(res.Dangling, []string{"ap-3 -> ap-gone"}) {
+		t.Errorf("dangling = %v", res.Dangling)
+	}
+	want := []string{
+		"create ap-1",
+		"create ap-2",
+		"create ap-3",
+		"update ap-4",
+		"update ap-nux",
+		"status ap-2 hooked",
+		"dep ap-2 ap-3 blocks",
+		"status ap-3 closed",
+		"dep ap-3 ap-old blocks",
+		"agent ap-nux working",
+	}
+	if !reflect.DeepEqual(store.calls, want) {
+		t.Errorf("calls =\n  %v\nwant\n  %v", store.calls, want)
+	}
+	if got := store.created["ap-1"].Description; got != "see /new/town/app/notes.md" {
+		t.Errorf("description not remapped: %q", got)
+	}
+	if issues[1].Description != "see /old/town/app/notes.md" {
+		t.Error("ImportIssues modified the bundle's issue")
+	}
+}
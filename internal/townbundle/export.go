@@ -0,0 +1,118 @@
+package townbundle
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/backup"
+	"github.com/steveyegge/gastown/internal/beads"
+)
+
+// showBatchSize bounds how many IDs go into one bd show call.
+const showBatchSize = 50
+
+// ExportOptions controls what Export collects.
+type ExportOptions struct {
+	// IncludeClosed also exports closed issues. By default only live state
+	// travels: open work, hooks, unread mail, agents, and running molecules.
+	IncludeClosed bool
+}
+
+// Export collects the bundle for the town at townRoot. The Dolt server must
+// be running, since issues are read through bd.
+func Export(townRoot string, opts ExportOptions) (*Bundle, error) {
+	m, err := backup.NewManifest(townRoot)
+	if err != nil {
+		return nil, err
+	}
+	files, err := CollectFiles(townRoot, m.Rigs)
+	if err != nil {
+		return nil, err
+	}
+	home, _ := os.UserHomeDir()
+
+	b := &Bundle{
+		Version:   FormatVersion,
+		CreatedAt: time.Now().UTC(),
+		Town:      m.Town,
+		TownRoot:  townRoot,
+		Home:      home,
+		Host:      m.Host,
+		Rigs:      m.Rigs,
+		Files:     files,
+	}
+
+	issues, err := exportDatabase(beads.New(townRoot), opts)
+	if err != nil {
+		return nil, fmt.Errorf("exporting town beads: %w", err)
+	}
+	b.Databases = append(b.Databases, Database{Issues: issues})
+	for _, r := range m.Rigs {
+		issues, err := exportDatabase(beads.New(filepath.Join(townRoot, r.Name)), opts)
+		if err != nil {
+			return nil, fmt.Errorf("exporting %s beads: %w", r.Name, err)
+		}
+		b.Databases = append(b.Databases, Database{Rig: r.Name, Issues: issues})
+	}
+	return b, nil
+}
+
+// exportDatabase lists a database's issues and wisps, then re-reads them with
+// bd show for the dependency details list output leaves out.
+func exportDatabase(b *beads.Beads, opts ExportOptions) ([]*beads.Issue, error) {
+	listed, err := b.List(beads.ListOptions{Status: "all", Priority: -1})
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*beads.Issue)
+	for _, issue := range listed {
+		if opts.IncludeClosed || issue.Status != "closed" {
+			byID[issue.ID] = issue
+		}
+	}
+
+	// Wisps (molecule steps, ephemeral mail) live outside bd list.
+	if out, err := b.Run("mol", "wisp", "list", "--json"); err == nil {
+		var wrapper struct {
+			Wisps []*beads.Issue `json:"wisps"`
+		}
+		if json.Unmarshal(out, &wrapper) == nil {
+			for _, w := range wrapper.Wisps {
+				if opts.IncludeClosed || w.Status != "closed" {
+					w.Ephemeral = true
+					byID[w.ID] = w
+				}
+			}
+		}
+	}
+
+	ids := make([]string, 0, len(byID))
+	for id := range byID {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for start := 0; start < len(ids); start += showBatchSize {
+		end := min(start+showBatchSize, len(ids))
+		detailed, err := b.ShowMultiple(ids[start:end])
+		if err != nil {
+			return nil, err
+		}
+		for id, issue := range detailed {
+			if prev, ok := byID[id]; ok {
+				issue.Ephemeral = issue.Ephemeral || prev.Ephemeral
+				byID[id] = issue
+			}
+		}
+	}
+
+	issues := make([]*beads.Issue, 0, len(ids))
+	for _, id := range ids {
+		issues = append(issues, byID[id])
+	}
+	return issues, nil
+}
@@ -0,0 +1,236 @@
+package townbundle
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/beads"
+)
+
+// IssueStore is the slice of a beads database that import writes to.
+type IssueStore interface {
+	// Status returns an issue's status, and false if it doesn't exist.
+	Status(id string) (string, bool)
+	Create(issue *beads.Issue) error
+	Update(issue *beads.Issue) error
+	SetStatus(id, status string) error
+	SetAgentState(id, state string) error
+	AddDependency(id, dependsOn, depType string) error
+}
+
+// ImportResult reports what ImportIssues did.
+type ImportResult struct {
+	Created  int
+	Updated  int      // Already present in the target database; overwritten from the bundle
+	Dangling []string // Dependencies on issues in neither the bundle nor the database; skipped
+	Errors   []string // Per-issue failures; the rest of the import continues
+}
+
+// ImportIssues replays issues into store, rewriting paths with remap.
+// Issues that already exist are updated from the bundle, so an interrupted
+// import can be re-run and an import over a fresh install (which creates
+// its own agent beads) ends with the bundle's state. Parents are created
+// before their children; statuses, agent states, and dependencies are
+// applied once every issue exists. A dependency on an issue that is in
+// neither the bundle nor the database, such as closed work the export left
+// out, is skipped and reported in Dangling.
+func ImportIssues(store IssueStore, issues []*beads.Issue, remap *Remapper) ImportResult {
+	var res ImportResult
+	var applied []*beads.Issue
+	current := make(map[string]string) // ID -> status in the database before import
+	present := make(map[string]bool)
+	for _, issue := range parentsFirst(issues) {
+		rewritten := *issue
+		rewritten.Title = remap.Apply(issue.Title)
+		rewritten.Description = remap.Apply(issue.Description)
+		rewritten.AcceptanceCriteria = remap.Apply(issue.AcceptanceCriteria)
+		if status, ok := store.Status(issue.ID); ok {
+			if err := store.Update(&rewritten); err != nil {
+				res.Errors = append(res.Errors, fmt.Sprintf("%s: update: %v", issue.ID, err))
+				continue
+			}
+			current[issue.ID] = status
+			res.Updated++
+		} else {
+			if err := store.Create(&rewritten); err != nil {
+				res.Errors = append(res.Errors, fmt.Sprintf("%s: create: %v", issue.ID, err))
+				continue
+			}
+			current[issue.ID] = "open"
+			res.Created++
+		}
+		present[issue.ID] = true
+		applied = append(applied, issue)
+	}
+
+	for _, issue := range applied {
+		if issue.Status != "" && issue.Status != current[issue.ID] {
+			if err := store.SetStatus(issue.ID, issue.Status); err != nil {
+				res.Errors = append(res.Errors, fmt.Sprintf("%s: status %s: %v", issue.ID, issue.Status, err))
+			}
+		}
+		if issue.AgentState != "" {
+			if err := store.SetAgentState(issue.ID, issue.AgentState); err != nil {
+				res.Errors = append(res.Errors, fmt.Sprintf("%s: agent state %s: %v", issue.ID, issue.AgentState, err))
+			}
+		}
+		for _, dep := range issue.Dependencies {
+			if dep.DependencyType == "parent-child" && dep.ID == issue.Parent {
+				continue // Set at create time
+			}
+			if !present[dep.ID] {
+				if _, ok := store.Status(dep.ID); !ok {
+					res.Dangling = append(res.Dangling, issue.ID+" -> "+dep.ID)
+					continue
+				}
+				present[dep.ID] = true
+			}
+			if err := store.AddDependency(issue.ID, dep.ID, dep.DependencyType); err != nil {
+				res.Errors = append(res.Errors, fmt.Sprintf("%s: dependency on %s: %v", issue.ID, dep.ID, err))
+			}
+		}
+	}
+	return res
+}
+
+// parentsFirst orders issues so every parent in the set precedes its
+// children, keeping the original order otherwise.
+func parentsFirst(issues []*beads.Issue) []*beads.Issue {
+	inSet := make(map[string]*beads.Issue, len(issues))
+	for _, issue := range issues {
+		inSet[issue.ID] = issue
+	}
+	placed := make(map[string]bool, len(issues))
+	ordered := make([]*beads.Issue, 0, len(issues))
+	var place func(issue *beads.Issue, depth int)
+	place = func(issue *beads.Issue, depth int) {
+		if placed[issue.ID] {
+			return
+		}
+		placed[issue.ID] = true
+		if parent, ok := inSet[issue.Parent]; ok && depth < len(issues) {
+			place(parent, depth+1)
+		}
+		ordered = append(ordered, issue)
+	}
+	for _, issue := range issues {
+		place(issue, 0)
+	}
+	return ordered
+}
+
+// WriteFiles writes the bundle's config files under townRoot, rewriting
+// paths with remap. Returns the files written.
+func WriteFiles(townRoot string, files []File, remap *Remapper) ([]string, error) {
+	var written []string
+	for _, f := range files {
+		rel := filepath.FromSlash(f.Path)
+		if filepath.IsAbs(rel) || strings.HasPrefix(filepath.Clean(rel), "..") {
+			return written, fmt.Errorf("bundle file %q escapes the town", f.Path)
+		}
+		target := filepath.Join(townRoot, rel)
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return written, err
+		}
+		mode := f.Mode
+		if mode == 0 {
+			mode = 0644
+		}
+		if err := os.WriteFile(target, []byte(remap.Apply(f.Data)), mode); err != nil {
+			return written, err
+		}
+		written = append(written, f.Path)
+	}
+	return written, nil
+}
+
+// BeadsStore adapts a bd-backed database to IssueStore.
+type BeadsStore struct {
+	B *beads.Beads
+}
+
+// Status returns the issue's status, and false if it isn't in the database.
+func (s BeadsStore) Status(id string) (string, bool) {
+	issue, err := s.B.Show(id)
+	if err != nil {
+		return "", false
+	}
+	return issue.Status, true
+}
+
+// Create creates the issue under its original ID, keeping its type,
+// labels, assignee, creator, and wisp status.
+func (s BeadsStore) Create(issue *beads.Issue) error {
+	args := []string{"create", "--json", "--id=" + issue.ID}
+	if beads.NeedsForceForID(issue.ID) {
+		args = append(args, "--force")
+	}
+	args = append(args, "--title="+issue.Title, fmt.Sprintf("--priority=%d", issue.Priority))
+	if issue.Type != "" {
+		args = append(args, "--type="+issue.Type)
+	}
+	if issue.Description != "" {
+		args = append(args, "--description="+issue.Description)
+	}
+	if issue.AcceptanceCriteria != "" {
+		args = append(args, "--acceptance="+issue.AcceptanceCriteria)
+	}
+	if len(issue.Labels) > 0 {
+		args = append(args, "--labels="+strings.Join(issue.Labels, ","))
+	}
+	if issue.Assignee != "" {
+		args = append(args, "--assignee="+issue.Assignee)
+	}
+	if issue.Parent != "" {
+		args = append(args, "--parent="+issue.Parent)
+	}
+	if issue.CreatedBy != "" {
+		args = append(args, "--actor="+issue.CreatedBy)
+	}
+	if issue.Ephemeral {
+		args = append(args, "--ephemeral")
+	}
+	_, err := s.B.Run(args...)
+	return err
+}
+
+// Update overwrites an existing issue's title, description, priority,
+// assignee, and labels with the bundle's. Acceptance criteria, type, and
+// parent are kept as created.
+func (s BeadsStore) Update(issue *beads.Issue) error {
+	opts := beads.UpdateOptions{
+		Title:       &issue.Title,
+		Description: &issue.Description,
+		Priority:    &issue.Priority,
+		Assignee:    &issue.Assignee,
+	}
+	if len(issue.Labels) > 0 {
+		opts.SetLabels = issue.Labels
+	}
+	return s.B.Update(issue.ID, opts)
+}
+
+// SetStatus moves the issue to status, closing it if status is closed.
+func (s BeadsStore) SetStatus(id, status string) error {
+	if status == "closed" {
+		return s.B.CloseWithReason("imported closed", id)
+	}
+	return s.B.Update(id, beads.UpdateOptions{Status: &status})
+}
+
+// SetAgentState sets an agent bead's agent_state.
+func (s BeadsStore) SetAgentState(id, state string) error {
+	return s.B.UpdateAgentState(id, state)
+}
+
+// AddDependency records that id depends on dependsOn.
+func (s BeadsStore) AddDependency(id, dependsOn, depType string) error {
+	args := []string{"dep", "add", id, dependsOn}
+	if depType != "" {
+		args = append(args, "--type="+depType)
+	}
+	_, err := s.B.Run(args...)
+	return err
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 )
@@ -40,6 +41,9 @@ const (
 	// Session death events (for crash investigation)
 	EventSessionDeath EventType = "session_death" // Session terminated (with reason)
 	EventMassDeath    EventType = "mass_death"    // Multiple sessions died in short window
+
+	// EventAnnotate is an operator note on an agent's timeline (gt annotate).
+	EventAnnotate EventType = "annotate"
 )
 
 // Event represents a single agent lifecycle event.
@@ -102,7 +106,7 @@ func (l *Logger) LogEvent(event Event) error {
 // Log is a convenience method that creates an Event and logs it.
 func (l *Logger) Log(eventType EventType, agent, context string) error {
 	return l.LogEvent(Event{
-		Timestamp: time.Now(),
+		Timestamp: time.Now().UTC(),
 		Type:      eventType,
 		Agent:     agent,
 		Context:   context,
@@ -110,9 +114,10 @@ func (l *Logger) Log(eventType EventType, agent, context string) error {
 }
 
 // formatLogLine formats an event as a human-readable log line.
-// Format: 2025-12-26 15:30:45 [spawn] gastown/crew/max spawned for gt-xyz
+// Format: 2025-12-26 15:30:45Z [spawn] gastown/crew/max spawned for gt-xyz
+// The timestamp is UTC; the trailing Z says so for anyone reading the file.
 func formatLogLine(e Event) string {
-	ts := e.Timestamp.Format("2006-01-02 15:04:05")
+	ts := e.Timestamp.UTC().Format("2006-01-02 15:04:05") + "Z"
 
 	var detail string
 	switch e.Type {
@@ -204,6 +209,8 @@ func formatLogLine(e Event) string {
 		} else {
 			detail = "MASS SESSION DEATH"
 		}
+	case EventAnnotate:
+		detail = annotationPrefix + strings.Join(strings.Fields(e.Context), " ")
 	default:
 		detail = string(e.Type)
 		if e.Context != "" {
@@ -214,6 +221,9 @@ func formatLogLine(e Event) string {
 	return fmt.Sprintf("%s [%s] %s %s", ts, e.Type, e.Agent, detail)
 }
 
+// annotationPrefix introduces the note in an annotate log line.
+const annotationPrefix = "note: "
+
 // truncate shortens a string to max length with ellipsis.
 func truncate(s string, maxLen int) string {
 	if len(s) <= maxLen {
@@ -259,12 +269,13 @@ func ParseLogLines(content string) ([]Event, error) {
 }
 
 // parseLogLine parses a single log line into an Event.
-// Format: 2025-12-26 15:30:45 [spawn] gastown/crew/max spawned for gt-xyz
+// Format: 2025-12-26 15:30:45Z [spawn] gastown/crew/max spawned for gt-xyz
+// Lines written before the Z marker was added carry no zone and are also UTC.
 func parseLogLine(line string) (Event, error) {
 	var event Event
 
 	// Parse timestamp (first 19 chars: "2006-01-02 15:04:05")
-	if len(line) < 19 {
+	if len(line) < 20 {
 		return event, fmt.Errorf("line too short")
 	}
 	ts, err := time.Parse("2006-01-02 15:04:05", line[:19])
@@ -273,8 +284,15 @@ func parseLogLine(line string) (Event, error) {
 	}
 	event.Timestamp = ts
 
+	rest := line[19:]
+	if rest[0] == 'Z' {
+		rest = rest[1:]
+	}
 	// Find event type in brackets
-	rest := line[20:] // Skip timestamp and space
+	if len(rest) < 1 {
+		return event, fmt.Errorf("missing event type")
+	}
+	rest = rest[1:] // Skip space
 	if len(rest) < 3 || rest[0] != '[' {
 		return event, fmt.Errorf("missing event type")
 	}
@@ -311,7 +329,11 @@ func parseLogLine(line string) (Event, error) {
 		event.Agent = rest
 	} else {
 		event.Agent = rest[:spaceIdx]
-		// The rest is context info (not worth parsing further)
+		// The rest is context info, only worth keeping for annotations
+		// (the note is the whole point of the event).
+		if event.Type == EventAnnotate {
+			event.Context = strings.TrimPrefix(rest[spaceIdx+1:], annotationPrefix)
+		}
 	}
 
 	return event, nil
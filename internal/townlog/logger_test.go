@@ -24,7 +24,7 @@ func TestFormatLogLine(t *testing.T) {
 				Agent:     "gastown/crew/max",
 				Context:   "gt-xyz",
 			},
-			contains: []string{"2025-12-26 15:30:45", "[spawn]", "gastown/crew/max", "spawned for gt-xyz"},
+			contains: []string{"2025-12-26 15:30:45Z [spawn]", "gastown/crew/max", "spawned for gt-xyz"},
 		},
 		{
 			name: "nudge event",
@@ -36,6 +36,16 @@ func TestFormatLogLine(t *testing.T) {
 			},
 			contains: []string{"[nudge]", "gastown/crew/max", "nudged with"},
 		},
+		{
+			name: "annotate event flattens newlines",
+			event: Event{
+				Timestamp: ts,
+				Type:      EventAnnotate,
+				Agent:     "gastown/polecats/nux",
+				Context:   "killed the\nrunaway build",
+			},
+			contains: []string{"[annotate]", "gastown/polecats/nux", "note: killed the runaway build"},
+		},
 		{
 			name: "done event",
 			event: Event{
@@ -101,6 +111,21 @@ func TestParseLogLine(t *testing.T) {
 				return e.Type == EventNudge && e.Agent == "gastown/crew/max"
 			},
 		},
+		{
+			name: "annotate line keeps the note",
+			line: "2025-12-26 15:32:10 [annotate] gastown/polecats/nux note: I intervened here",
+			check: func(e Event) bool {
+				return e.Type == EventAnnotate && e.Agent == "gastown/polecats/nux" && e.Context == "I intervened here"
+			},
+		},
+		{
+			name: "zone-marked line is UTC",
+			line: "2025-12-26 15:30:45Z [spawn] gastown/crew/max spawned for gt-xyz",
+			check: func(e Event) bool {
+				return e.Type == EventSpawn && e.Agent == "gastown/crew/max" &&
+					e.Timestamp.Equal(time.Date(2025, 12, 26, 15, 30, 45, 0, time.UTC))
+			},
+		},
 		{
 			name:    "too short",
 			line:    "short",
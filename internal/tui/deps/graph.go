@@ -0,0 +1,201 @@
+// Package deps is an interactive editor for bead dependency edges: add and
+// remove edges around one bead, refusing any that would close a cycle, and
+// redraw the affected part of the graph after every change.
+package deps
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Node is one bead and its blocking edges.
+type Node struct {
+	ID        string
+	Title     string
+	Status    string
+	DependsOn []string // Beads this one waits on
+	Blocks    []string // Beads waiting on this one
+}
+
+// String renders the node as "id [status] title".
+func (n *Node) String() string {
+	return strings.TrimSpace(fmt.Sprintf("%s [%s] %s", n.ID, n.Status, n.Title))
+}
+
+// Store reads and edits dependency edges. Edges are directed: issue
+// depends on dependsOn.
+type Store interface {
+	Node(id string) (*Node, error)
+	AddDependency(issue, dependsOn string) error
+	RemoveDependency(issue, dependsOn string) error
+}
+
+// Graph caches nodes read from a Store. Edits go through the graph so the
+// cache stays consistent with the store.
+type Graph struct {
+	store Store
+	nodes map[string]*Node
+}
+
+// NewGraph creates a graph over store.
+func NewGraph(store Store) *Graph {
+	return &Graph{store: store, nodes: make(map[string]*Node)}
+}
+
+// Node returns id's node, reading it from the store on first use.
+func (g *Graph) Node(id string) (*Node, error) {
+	if n, ok := g.nodes[id]; ok {
+		return n, nil
+	}
+	n, err := g.store.Node(id)
+	if err != nil {
+		return nil, err
+	}
+	g.nodes[id] = n
+	return n, nil
+}
+
+// Forget drops cached nodes so they are re-read on next use. With no ids
+// it drops everything.
+func (g *Graph) Forget(ids ...string) {
+	if len(ids) == 0 {
+		g.nodes = make(map[string]*Node)
+		return
+	}
+	for _, id := range ids {
+		delete(g.nodes, id)
+	}
+}
+
+// CycleError reports an edge that would close a dependency cycle.
+type CycleError struct {
+	Cycle []string // issue → dependsOn → … → issue
+}
+
+func (e *CycleError) Error() string {
+	return "would create a cycle: " + strings.Join(e.Cycle, " → ")
+}
+
+// FindCycle reports the cycle that adding "issue depends on dependsOn"
+// would close, or nil if the edge is safe. An edge closes a cycle exactly
+// when issue is already reachable from dependsOn.
+func (g *Graph) FindCycle(issue, dependsOn string) ([]string, error) {
+	if issue == dependsOn {
+		return []string{issue, issue}, nil
+	}
+	parent := map[string]string{dependsOn: ""}
+	queue := []string{dependsOn}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		n, err := g.Node(id)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", id, err)
+		}
+		for _, next := range n.DependsOn {
+			if _, seen := parent[next]; seen {
+				continue
+			}
+			parent[next] = id
+			if next == issue {
+				var chain []string // id back to dependsOn
+				for at := id; at != ""; at = parent[at] {
+					chain = append(chain, at)
+				}
+				slices.Reverse(chain)
+				cycle := append([]string{issue}, chain...)
+				return append(cycle, issue), nil
+			}
+			queue = append(queue, next)
+		}
+	}
+	return nil, nil
+}
+
+// Add records that issue depends on dependsOn, unless that would close a
+// cycle (a *CycleError) or the edge already exists.
+func (g *Graph) Add(issue, dependsOn string) error {
+	n, err := g.Node(issue)
+	if err != nil {
+		return err
+	}
+	if slices.Contains(n.DependsOn, dependsOn) {
+		return fmt.Errorf("%s already depends on %s", issue, dependsOn)
+	}
+	cycle, err := g.FindCycle(issue, dependsOn)
+	if err != nil {
+		return err
+	}
+	if cycle != nil {
+		return &CycleError{Cycle: cycle}
+	}
+	if err := g.store.AddDependency(issue, dependsOn); err != nil {
+		return err
+	}
+	g.Forget(issue, dependsOn)
+	return nil
+}
+
+// Remove deletes the edge "issue depends on dependsOn".
+func (g *Graph) Remove(issue, dependsOn string) error {
+	if err := g.store.RemoveDependency(issue, dependsOn); err != nil {
+		return err
+	}
+	g.Forget(issue, dependsOn)
+	return nil
+}
+
+// Tree renders the beads id waits on (upstream) or that wait on it
+// (downstream) as an indented tree, depth levels deep. A bead already
+// drawn elsewhere in the tree is marked rather than expanded again.
+func (g *Graph) Tree(id string, upstream bool, depth int) []string {
+	var lines []string
+	seen := map[string]bool{id: true}
+	var walk func(id, prefix string, level int)
+	walk = func(id, prefix string, level int) {
+		n, err := g.Node(id)
+		if err != nil {
+			lines = append(lines, prefix+"└── (unreadable: "+err.Error()+")")
+			return
+		}
+		next := n.Blocks
+		if upstream {
+			next = n.DependsOn
+		}
+		for i, child := range next {
+			branch, indent := "├── ", "│   "
+			if i == len(next)-1 {
+				branch, indent = "└── ", "    "
+			}
+			label := child
+			if c, err := g.Node(child); err == nil {
+				label = c.String()
+			}
+			switch {
+			case seen[child]:
+				lines = append(lines, prefix+branch+label+" (see above)")
+			case level+1 >= depth && len(g.edges(child, upstream)) > 0:
+				lines = append(lines, prefix+branch+label+" …")
+			default:
+				lines = append(lines, prefix+branch+label)
+				seen[child] = true
+				walk(child, prefix+indent, level+1)
+			}
+		}
+	}
+	walk(id, "", 0)
+	return lines
+}
+
+// edges returns id's outgoing edges in one direction, or nil if unreadable.
+func (g *Graph) edges(id string, upstream bool) []string {
+	n, err := g.Node(id)
+	if err != nil {
+		return nil
+	}
+	if upstream {
+		return n.DependsOn
+	}
+	return n.Blocks
+}
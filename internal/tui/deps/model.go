@@ -0,0 +1,282 @@
+package deps
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	titleStyle    = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("12"))
+	headerStyle   = lipgloss.NewStyle().Bold(true)
+	selectedStyle = lipgloss.NewStyle().Background(lipgloss.Color("236")).Foreground(lipgloss.Color("15"))
+	detailStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+	okStyle       = lipgloss.NewStyle().Foreground(lipgloss.Color("10"))
+	errStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
+)
+
+// treeDepth is how many levels of the subgraph are drawn each way.
+const treeDepth = 3
+
+// Edit is one change made in the editor.
+type Edit struct {
+	Added     bool // false: removed
+	Issue     string
+	DependsOn string
+}
+
+func (e Edit) String() string {
+	if e.Added {
+		return fmt.Sprintf("+ %s depends on %s", e.Issue, e.DependsOn)
+	}
+	return fmt.Sprintf("- %s no longer depends on %s", e.Issue, e.DependsOn)
+}
+
+// row is one editable edge of the focused bead.
+type row struct {
+	upstream bool // Focus depends on id (false: id depends on focus)
+	id       string
+}
+
+// Model is the bubbletea model for the dependency editor.
+type Model struct {
+	graph   *Graph
+	focus   string
+	back    []string // Previously focused beads
+	rows    []row
+	cursor  int
+	adding  bool // Reading a bead ID to add
+	addUp   bool // The new edge is a dependency (false: a dependent)
+	input   string
+	message string
+	failed  bool
+	edits   []Edit
+}
+
+// New creates an editor focused on id.
+func New(store Store, id string) *Model {
+	m := &Model{graph: NewGraph(store), focus: id}
+	m.load()
+	return m
+}
+
+// Run shows the editor and returns the edits made.
+func Run(store Store, id string) ([]Edit, error) {
+	m := New(store, id)
+	if _, err := tea.NewProgram(m, tea.WithAltScreen()).Run(); err != nil {
+		return m.edits, err
+	}
+	return m.edits, nil
+}
+
+// Edits returns the changes made so far.
+func (m *Model) Edits() []Edit { return m.edits }
+
+// load rebuilds the edge rows for the focused bead.
+func (m *Model) load() {
+	m.rows = nil
+	n, err := m.graph.Node(m.focus)
+	if err != nil {
+		m.setMessage(fmt.Sprintf("reading %s: %v", m.focus, err), true)
+		return
+	}
+	for _, id := range n.DependsOn {
+		m.rows = append(m.rows, row{upstream: true, id: id})
+	}
+	for _, id := range n.Blocks {
+		m.rows = append(m.rows, row{id: id})
+	}
+	if m.cursor >= len(m.rows) {
+		m.cursor = max(len(m.rows)-1, 0)
+	}
+}
+
+func (m *Model) setMessage(msg string, failed bool) {
+	m.message, m.failed = msg, failed
+}
+
+// Init initializes the model.
+func (m *Model) Init() tea.Cmd { return nil }
+
+// Update handles key presses.
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if m.adding {
+			m.updateInput(msg)
+			return m, nil
+		}
+		switch msg.String() {
+		case "ctrl+c", "q", "esc":
+			return m, tea.Quit
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j":
+			if m.cursor < len(m.rows)-1 {
+				m.cursor++
+			}
+		case "a", "b":
+			m.adding, m.addUp, m.input = true, msg.String() == "a", ""
+			m.setMessage("", false)
+		case "d", "x", "delete":
+			m.removeSelected()
+		case "enter", "l":
+			if len(m.rows) > 0 {
+				m.back = append(m.back, m.focus)
+				m.focus = m.rows[m.cursor].id
+				m.cursor = 0
+				m.setMessage("", false)
+				m.load()
+			}
+		case "backspace", "h":
+			if len(m.back) > 0 {
+				m.focus = m.back[len(m.back)-1]
+				m.back = m.back[:len(m.back)-1]
+				m.cursor = 0
+				m.setMessage("", false)
+				m.load()
+			}
+		case "r":
+			m.graph.Forget()
+			m.load()
+			m.setMessage("refreshed", false)
+		}
+	}
+	return m, nil
+}
+
+// updateInput handles keys while a bead ID is being typed.
+func (m *Model) updateInput(msg tea.KeyMsg) {
+	switch msg.Type {
+	case tea.KeyCtrlC, tea.KeyEsc:
+		m.adding = false
+	case tea.KeyEnter:
+		m.adding = false
+		if id := strings.TrimSpace(m.input); id != "" {
+			m.add(id)
+		}
+	case tea.KeyBackspace:
+		if r := []rune(m.input); len(r) > 0 {
+			m.input = string(r[:len(r)-1])
+		}
+	case tea.KeyRunes:
+		m.input += string(msg.Runes)
+	}
+}
+
+// add creates an edge between the focused bead and id.
+func (m *Model) add(id string) {
+	e := Edit{Added: true, Issue: m.focus, DependsOn: id}
+	if !m.addUp {
+		e.Issue, e.DependsOn = id, m.focus
+	}
+	if err := m.graph.Add(e.Issue, e.DependsOn); err != nil {
+		var cycle *CycleError
+		if errors.As(err, &cycle) {
+			m.setMessage("refused: "+err.Error(), true)
+		} else {
+			m.setMessage(err.Error(), true)
+		}
+		return
+	}
+	m.edits = append(m.edits, e)
+	m.load()
+	m.setMessage(e.String(), false)
+}
+
+// removeSelected deletes the edge under the cursor.
+func (m *Model) removeSelected() {
+	if len(m.rows) == 0 {
+		return
+	}
+	r := m.rows[m.cursor]
+	e := Edit{Issue: m.focus, DependsOn: r.id}
+	if !r.upstream {
+		e.Issue, e.DependsOn = r.id, m.focus
+	}
+	if err := m.graph.Remove(e.Issue, e.DependsOn); err != nil {
+		m.setMessage(err.Error(), true)
+		return
+	}
+	m.edits = append(m.edits, e)
+	m.load()
+	m.setMessage(e.String(), false)
+}
+
+// View renders the focused bead's edges and the subgraph around it.
+func (m *Model) View() string {
+	var b strings.Builder
+	title := m.focus
+	if n, err := m.graph.Node(m.focus); err == nil {
+		title = n.String()
+	}
+	fmt.Fprintf(&b, "%s %s\n\n", titleStyle.Render("deps>"), title)
+
+	m.viewRows(&b, true, "Depends on")
+	m.viewRows(&b, false, "Blocks")
+
+	for _, dir := range []struct {
+		upstream bool
+		label    string
+	}{{true, "Upstream (what it waits on)"}, {false, "Downstream (what waits on it)"}} {
+		lines := m.graph.Tree(m.focus, dir.upstream, treeDepth)
+		if len(lines) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n%s\n", detailStyle.Render(dir.label))
+		for _, l := range lines {
+			fmt.Fprintf(&b, "  %s\n", detailStyle.Render(l))
+		}
+	}
+
+	b.WriteString("\n")
+	switch {
+	case m.adding && m.addUp:
+		fmt.Fprintf(&b, "%s depends on> %s\n", m.focus, m.input)
+	case m.adding:
+		fmt.Fprintf(&b, "blocked by %s> %s\n", m.focus, m.input)
+	case m.message != "" && m.failed:
+		b.WriteString(errStyle.Render(m.message) + "\n")
+	case m.message != "":
+		b.WriteString(okStyle.Render(m.message) + "\n")
+	}
+	if m.adding {
+		b.WriteString(detailStyle.Render("enter add • esc cancel"))
+	} else {
+		b.WriteString(detailStyle.Render("↑/↓ move • a add dependency • b add dependent • d remove • enter open • backspace back • r refresh • q quit"))
+	}
+	return b.String()
+}
+
+// viewRows renders the edges in one direction.
+func (m *Model) viewRows(b *strings.Builder, upstream bool, label string) {
+	var count int
+	for _, r := range m.rows {
+		if r.upstream == upstream {
+			count++
+		}
+	}
+	fmt.Fprintf(b, "%s\n", headerStyle.Render(fmt.Sprintf("%s (%d)", label, count)))
+	if count == 0 {
+		fmt.Fprintf(b, "  %s\n", detailStyle.Render("(none)"))
+	}
+	for i, r := range m.rows {
+		if r.upstream != upstream {
+			continue
+		}
+		line := r.id
+		if n, err := m.graph.Node(r.id); err == nil {
+			line = n.String()
+		}
+		if i == m.cursor && !m.adding {
+			b.WriteString(selectedStyle.Render("> "+line) + "\n")
+		} else {
+			b.WriteString("  " + line + "\n")
+		}
+	}
+}
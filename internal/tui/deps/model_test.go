@@ -0,0 +1,169 @@
+package deps
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// fakeStore is an in-memory dependency graph.
+type fakeStore struct {
+	deps map[string][]string // issue → depends on
+}
+
+func newFakeStore(edges ...string) *fakeStore {
+	s := &fakeStore{deps: make(map[string][]string)}
+	for _, e := range edges {
+		from, to, _ := strings.Cut(e, ">")
+		s.deps[from] = append(s.deps[from], to)
+		if _, ok := s.deps[to]; !ok {
+			s.deps[to] = nil
+		}
+	}
+	return s
+}
+
+func (s *fakeStore) Node(id string) (*Node, error) {
+	deps, ok := s.deps[id]
+	if !ok {
+		return nil, fmt.Errorf("no issue found matching %q", id)
+	}
+	n := &Node{ID: id, Status: "open", DependsOn: slices.Clone(deps)}
+	for from, to := range s.deps {
+		if slices.Contains(to, id) {
+			n.Blocks = append(n.Blocks, from)
+		}
+	}
+	slices.Sort(n.Blocks)
+	return n, nil
+}
+
+func (s *fakeStore) AddDependency(issue, dependsOn string) error {
+	if _, ok := s.deps[dependsOn]; !ok {
+		return fmt.Errorf("no issue found matching %q", dependsOn)
+	}
+	s.deps[issue] = append(s.deps[issue], dependsOn)
+	return nil
+}
+
+func (s *fakeStore) RemoveDependency(issue, dependsOn string) error {
+	s.deps[issue] = slices.DeleteFunc(s.deps[issue], func(d string) bool { return d == dependsOn })
+	return nil
+}
+
+func TestFindCycle(t *testing.T) {
+	// c → b → a, and d → a.
+	g := NewGraph(newFakeStore("c>b", "b>a", "d>a"))
+	tests := []struct {
+		issue, dependsOn string
+		want             []string
+	}{
+		{"a", "c", []string{"a", "c", "b", "a"}},
+		{"a", "b", []string{"a", "b", "a"}},
+		{"a", "a", []string{"a", "a"}},
+		{"c", "d", nil},
+		{"d", "c", nil},
+	}
+	for _, tt := range tests {
+		got, err := g.FindCycle(tt.issue, tt.dependsOn)
+		if err != nil {
+			t.Fatalf("FindCycle(%s, %s): %v", tt.issue, tt.dependsOn, err)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("FindCycle(%s, %s) = %v, want %v", tt.issue, tt.dependsOn, got, tt.want)
+		}
+	}
+}
+
+func TestGraphAddRefusesCycle(t *testing.T) {
+	store := newFakeStore("c>b", "b>a")
+	g := NewGraph(store)
+
+	err := g.Add("a", "c")
+	var cycle *CycleError
+	if !errors.As(err, &cycle) {
+		t.Fatalf("Add(a, c) = %v, want CycleError", err)
+	}
+	if len(store.deps["a"]) != 0 {
+		t.Errorf("refused edge reached the store: %v", store.deps["a"])
+	}
+	if err := g.Add("c", "b"); err == nil || !strings.Contains(err.Error(), "already depends") {
+		t.Errorf("duplicate edge: err = %v", err)
+	}
+}
+
+func TestGraphTree(t *testing.T) {
+	// Diamond: d → {b, c} → a.
+	store := newFakeStore("d>b", "d>c", "b>a", "c>a")
+	g := NewGraph(store)
+	want := []string{
+		"├── b [open]",
+		"│   └── a [open]",
+		"└── c [open]",
+		"    └── a [open] (see above)",
+	}
+	if got := g.Tree("d", true, 3); !reflect.DeepEqual(got, want) {
+		t.Errorf("upstream tree:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if got := g.Tree("a", false, 1); !reflect.DeepEqual(got, []string{"├── b [open] …", "└── c [open] …"}) {
+		t.Errorf("depth-limited downstream tree: %q", got)
+	}
+}
+
+func typeKeys(m *Model, s string) {
+	for _, r := range s {
+		m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+}
+
+func TestModelEditing(t *testing.T) {
+	store := newFakeStore("b>a", "c>b")
+	m := New(store, "b")
+	if len(m.rows) != 2 || !m.rows[0].upstream || m.rows[1].id != "c" {
+		t.Fatalf("rows = %+v, want a upstream then c downstream", m.rows)
+	}
+
+	// Adding a → c would close c → b → a → c.
+	typeKeys(m, "b")
+	typeKeys(m, "a")
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if !m.failed || !strings.Contains(m.message, "cycle") {
+		t.Errorf("cycle not refused: message %q", m.message)
+	}
+
+	// Remove b → a (the selected upstream edge), then add it back.
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	if len(store.deps["b"]) != 0 {
+		t.Fatalf("edge not removed: %v", store.deps["b"])
+	}
+	typeKeys(m, "aa")
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if !reflect.DeepEqual(store.deps["b"], []string{"a"}) {
+		t.Fatalf("edge not re-added: %v", store.deps["b"])
+	}
+
+	want := []Edit{{Issue: "b", DependsOn: "a"}, {Added: true, Issue: "b", DependsOn: "a"}}
+	if !reflect.DeepEqual(m.Edits(), want) {
+		t.Errorf("edits = %+v, want %+v", m.Edits(), want)
+	}
+	if v := m.View(); !strings.Contains(v, "Upstream") || !strings.Contains(v, "+ b depends on a") {
+		t.Errorf("view missing subgraph or last change:\n%s", v)
+	}
+}
+
+func TestModelNavigate(t *testing.T) {
+	m := New(newFakeStore("b>a", "c>b"), "b")
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.focus != "a" {
+		t.Fatalf("focus = %s, want a", m.focus)
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	if m.focus != "b" {
+		t.Errorf("back: focus = %s, want b", m.focus)
+	}
+}
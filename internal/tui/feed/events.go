@@ -482,6 +482,14 @@ func buildEventMessage(eventType string, payload map[string]interface{}) string
 		}
 		return "work slung"
 
+	case "annotate":
+		agent := getPayloadString(payload, "agent")
+		note := getPayloadString(payload, "note")
+		if agent != "" && note != "" {
+			return fmt.Sprintf("note on %s: %s", agent, note)
+		}
+		return "operator note"
+
 	case "hook":
 		bead := getPayloadString(payload, "bead")
 		if bead != "" {
@@ -9,6 +9,7 @@ import (
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/steveyegge/gastown/internal/agenthealth"
 	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/constants"
 	"github.com/steveyegge/gastown/internal/tmux"
@@ -98,11 +99,11 @@ type Model struct {
 	viewMode ViewMode
 
 	// Problems view state
-	problemAgents     []*ProblemAgent
+	problemAgents     []*agenthealth.ProblemAgent
 	selectedProblem   int
 	selectedBeadID    string // stable selection tracking by bead ID
 	problemsViewport  viewport.Model
-	stuckDetector     *StuckDetector
+	stuckDetector     *agenthealth.StuckDetector
 	lastProblemsCheck time.Time
 	problemsError     error // last error from problems fetch
 
@@ -134,12 +135,12 @@ func NewModel(bd *beads.Beads) *Model {
 		problemsViewport: viewport.New(0, 0),
 		rigs:             make(map[string]*Rig),
 		events:           make([]Event, 0, maxEventHistory),
-		problemAgents:    make([]*ProblemAgent, 0),
+		problemAgents:    make([]*agenthealth.ProblemAgent, 0),
 		keys:             DefaultKeyMap(),
 		help:             h,
 		done:             make(chan struct{}),
 		viewMode:         ViewActivity,
-		stuckDetector:    NewStuckDetector(bd),
+		stuckDetector:    agenthealth.NewStuckDetector(bd),
 	}
 }
 
@@ -184,7 +185,7 @@ type convoyUpdateMsg struct {
 
 // problemsUpdateMsg is sent when problems data is refreshed
 type problemsUpdateMsg struct {
-	agents  []*ProblemAgent
+	agents  []*agenthealth.ProblemAgent
 	fetched bool // true when data was fetched (even if agents is empty/nil)
 	err     error
 }
@@ -584,7 +585,7 @@ func (m *Model) selectPrevProblem() (tea.Model, tea.Cmd) {
 }
 
 // getSelectedProblemAgent returns the currently selected problem agent
-func (m *Model) getSelectedProblemAgent() *ProblemAgent {
+func (m *Model) getSelectedProblemAgent() *agenthealth.ProblemAgent {
 	if m.selectedProblem < 0 || len(m.problemAgents) == 0 {
 		return nil
 	}
@@ -628,7 +629,7 @@ func (m *Model) attachToSelected() (tea.Model, tea.Cmd) {
 // nudgeTarget returns the proper gt nudge target for an agent.
 // Uses rig/name format for polecats, rig/crew/name for crew,
 // and role shortcuts for singletons (mayor, deacon, witness, refinery).
-func nudgeTarget(agent *ProblemAgent) string {
+func nudgeTarget(agent *agenthealth.ProblemAgent) string {
 	switch agent.Role {
 	case constants.RoleMayor, constants.RoleDeacon:
 		return agent.Role
@@ -0,0 +1,61 @@
+package feed
+
+import (
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/agenthealth"
+)
+
+// TestNudgeTarget tests the nudge target format for all agent types
+func TestNudgeTarget(t *testing.T) {
+	tests := []struct {
+		name     string
+		agent    *agenthealth.ProblemAgent
+		expected string
+	}{
+		{
+			name:     "mayor",
+			agent:    &agenthealth.ProblemAgent{Role: "mayor", Name: "mayor", Rig: ""},
+			expected: "mayor",
+		},
+		{
+			name:     "deacon",
+			agent:    &agenthealth.ProblemAgent{Role: "deacon", Name: "deacon", Rig: ""},
+			expected: "deacon",
+		},
+		{
+			name:     "witness",
+			agent:    &agenthealth.ProblemAgent{Role: "witness", Name: "witness", Rig: "gastown"},
+			expected: "gastown/witness",
+		},
+		{
+			name:     "refinery",
+			agent:    &agenthealth.ProblemAgent{Role: "refinery", Name: "refinery", Rig: "gastown"},
+			expected: "gastown/refinery",
+		},
+		{
+			name:     "crew",
+			agent:    &agenthealth.ProblemAgent{Role: "crew", Name: "joe", Rig: "gastown"},
+			expected: "gastown/crew/joe",
+		},
+		{
+			name:     "polecat",
+			agent:    &agenthealth.ProblemAgent{Role: "polecat", Name: "Toast", Rig: "gastown"},
+			expected: "gastown/Toast",
+		},
+		{
+			name:     "unknown role falls back to session ID",
+			agent:    &agenthealth.ProblemAgent{Role: "custom", Name: "x", Rig: "r", SessionID: "gt-r-custom-x"},
+			expected: "gt-r-custom-x",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := nudgeTarget(tt.agent)
+			if got != tt.expected {
+				t.Errorf("nudgeTarget() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
@@ -193,5 +193,7 @@ var (
 		"nudge":   "⚡",
 		"boot":    "🔌",
 		"halt":    "⏹",
+		// Operator events
+		"annotate": "📝",
 	}
 )
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/steveyegge/gastown/internal/agenthealth"
 )
 
 // render produces the full TUI output
@@ -87,11 +88,11 @@ func (m *Model) renderHeader() string {
 func (m *Model) countAgentStates() (ok, stuck, idle int) {
 	for _, agent := range m.problemAgents {
 		switch agent.State {
-		case StateWorking:
+		case agenthealth.StateWorking:
 			ok++
-		case StateIdle:
+		case agenthealth.StateIdle:
 			idle++
-		case StateGUPPViolation, StateStalled, StateZombie:
+		case agenthealth.StateGUPPViolation, agenthealth.StateStalled, agenthealth.StateZombie:
 			stuck++
 		}
 	}
@@ -138,15 +139,15 @@ func (m *Model) renderProblemsContent() string {
 	}
 
 	// Count problems
-	var problemAgents []*ProblemAgent
-	var workingAgents []*ProblemAgent
-	var idleAgents []*ProblemAgent
+	var problemAgents []*agenthealth.ProblemAgent
+	var workingAgents []*agenthealth.ProblemAgent
+	var idleAgents []*agenthealth.ProblemAgent
 
 	for _, agent := range m.problemAgents {
 		switch {
 		case agent.State.NeedsAttention():
 			problemAgents = append(problemAgents, agent)
-		case agent.State == StateWorking:
+		case agent.State == agenthealth.StateWorking:
 			workingAgents = append(workingAgents, agent)
 		default:
 			idleAgents = append(idleAgents, agent)
@@ -207,7 +208,7 @@ func (m *Model) renderProblemsContent() string {
 }
 
 // renderProblemAgent renders a single problem agent line
-func (m *Model) renderProblemAgent(agent *ProblemAgent, selected bool) string {
+func (m *Model) renderProblemAgent(agent *agenthealth.ProblemAgent, selected bool) string {
 	// Format: "▶polecat-12  🔥 GUPP!    45m (violation)  gt-xyz89   myproject"
 	prefix := "  "
 	if selected {
@@ -244,13 +245,13 @@ func (m *Model) renderProblemAgent(agent *ProblemAgent, selected bool) string {
 }
 
 // getStateStyle returns the appropriate style for an agent state
-func getStateStyle(state AgentState) lipgloss.Style {
+func getStateStyle(state agenthealth.AgentState) lipgloss.Style {
 	switch state {
-	case StateGUPPViolation:
+	case agenthealth.StateGUPPViolation:
 		return GUPPStyle
-	case StateStalled:
+	case agenthealth.StateStalled:
 		return StalledStyle
-	case StateZombie:
+	case agenthealth.StateZombie:
 		return ZombieStyle
 	default:
 		return AgentIdleStyle
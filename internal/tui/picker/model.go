@@ -0,0 +1,139 @@
+// Package picker is a small fzf-style fuzzy finder: type to filter a list,
+// move with the arrow keys, Enter to choose.
+package picker
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/steveyegge/gastown/internal/suggest"
+)
+
+var (
+	promptStyle   = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("12"))
+	selectedStyle = lipgloss.NewStyle().Background(lipgloss.Color("236")).Foreground(lipgloss.Color("15"))
+	detailStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+)
+
+// Item is one pickable entry. Label is what the query is matched against;
+// Detail is shown dimmed beside it.
+type Item struct {
+	Label  string
+	Detail string
+}
+
+// Model is the bubbletea model for the picker.
+type Model struct {
+	title   string
+	items   []Item
+	labels  []string
+	query   string
+	matches []int // Indices into items, best first
+	cursor  int
+	height  int
+	chosen  int // Index into items, -1 until Enter
+}
+
+// New creates a picker over items with an initial query.
+func New(title string, items []Item, query string) *Model {
+	m := &Model{title: title, items: items, query: query, chosen: -1}
+	for _, it := range items {
+		m.labels = append(m.labels, it.Label+" "+it.Detail)
+	}
+	m.filter()
+	return m
+}
+
+// Run shows the picker and returns the chosen item's index, or -1 if the
+// user cancelled.
+func Run(title string, items []Item, query string) (int, error) {
+	m := New(title, items, query)
+	if _, err := tea.NewProgram(m, tea.WithAltScreen()).Run(); err != nil {
+		return -1, err
+	}
+	return m.chosen, nil
+}
+
+// Chosen returns the selected item's index, or -1.
+func (m *Model) Chosen() int { return m.chosen }
+
+// Init initializes the model.
+func (m *Model) Init() tea.Cmd { return nil }
+
+// Update handles key presses and resizes.
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.height = msg.Height
+	case tea.KeyMsg:
+		switch msg.Type {
+		case tea.KeyCtrlC, tea.KeyEsc:
+			return m, tea.Quit
+		case tea.KeyEnter:
+			if len(m.matches) > 0 {
+				m.chosen = m.matches[m.cursor]
+			}
+			return m, tea.Quit
+		case tea.KeyUp, tea.KeyCtrlP, tea.KeyShiftTab:
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case tea.KeyDown, tea.KeyCtrlN, tea.KeyTab:
+			if m.cursor < len(m.matches)-1 {
+				m.cursor++
+			}
+		case tea.KeyBackspace:
+			if r := []rune(m.query); len(r) > 0 {
+				m.query = string(r[:len(r)-1])
+				m.filter()
+			}
+		case tea.KeyCtrlU:
+			m.query = ""
+			m.filter()
+		case tea.KeyRunes, tea.KeySpace:
+			m.query += string(msg.Runes)
+			m.filter()
+		}
+	}
+	return m, nil
+}
+
+// filter re-ranks items for the current query and resets the cursor.
+func (m *Model) filter() {
+	m.matches = suggest.FuzzyRank(m.query, m.labels)
+	m.cursor = 0
+}
+
+// View renders the prompt and the visible matches.
+func (m *Model) View() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s\n", promptStyle.Render(m.title+">"), m.query)
+	fmt.Fprintf(&b, "%s\n", detailStyle.Render(fmt.Sprintf("  %d/%d", len(m.matches), len(m.items))))
+
+	rows := len(m.matches)
+	if m.height > 4 && rows > m.height-4 {
+		rows = m.height - 4
+	}
+	// Keep the cursor in view.
+	start := 0
+	if m.cursor >= rows {
+		start = m.cursor - rows + 1
+	}
+	for i := start; i < start+rows && i < len(m.matches); i++ {
+		it := m.items[m.matches[i]]
+		if i == m.cursor {
+			b.WriteString(selectedStyle.Render("> " + it.Label))
+		} else {
+			b.WriteString("  " + it.Label)
+		}
+		if it.Detail != "" {
+			b.WriteString("  " + detailStyle.Render(it.Detail))
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString(detailStyle.Render("\n↑/↓ move • enter select • esc cancel"))
+	return b.String()
+}
@@ -0,0 +1,43 @@
+package picker
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestPickerFilterAndSelect(t *testing.T) {
+	m := New("attach", []Item{
+		{Label: "mayor"},
+		{Label: "gastown/witness"},
+		{Label: "gastown/crew/max"},
+	}, "")
+	if len(m.matches) != 3 {
+		t.Fatalf("empty query should list all items, got %d", len(m.matches))
+	}
+
+	for _, r := range "gc" {
+		m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	if len(m.matches) != 1 || m.matches[0] != 2 {
+		t.Fatalf("matches for %q = %v", m.query, m.matches)
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	if m.query != "g" || len(m.matches) != 2 {
+		t.Fatalf("after backspace: query %q, matches %v", m.query, m.matches)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.Chosen() != m.matches[1] {
+		t.Errorf("Chosen() = %d, want %d", m.Chosen(), m.matches[1])
+	}
+}
+
+func TestPickerCancel(t *testing.T) {
+	m := New("attach", []Item{{Label: "mayor"}}, "")
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.Chosen() != -1 {
+		t.Errorf("cancel should choose nothing, got %d", m.Chosen())
+	}
+}
@@ -0,0 +1,235 @@
+package util
+
+import "fmt"
+
+// Line edit operations.
+const (
+	EditKeep   = "="
+	EditDelete = "-"
+	EditInsert = "+"
+)
+
+// Limits on the Myers search. Past either one the inputs share too little
+// to be worth a minimal script, and the script falls back to replacing
+// everything (a coarse diff) instead.
+const (
+	// maxMyersEdits bounds the edit distance searched. The search keeps a
+	// trace of about d² ints for backtracking, so this caps it near 32MB.
+	maxMyersEdits = 2000
+
+	// maxMyersLines bounds the combined lines searched (after common
+	// leading and trailing lines are matched), which sizes the search's
+	// working arrays.
+	maxMyersLines = 200000
+)
+
+// LineEdit is one step of a line edit script: keep or delete the next Count
+// lines of the old text, or insert Lines.
+type LineEdit struct {
+	Op    string   `json:"op"`
+	Count int      `json:"n,omitempty"`
+	Lines []string `json:"lines,omitempty"`
+}
+
+// MyersDiff returns a minimal line edit script turning a into b, using
+// Myers' O(ND) algorithm. Common leading and trailing lines are matched
+// before the search, so appending to a long text is cheap. Inputs past the
+// search limits get a coarse replace-all script; DiffLines reports that.
+func MyersDiff(a, b []string) []LineEdit {
+	pre, suf := commonAffixes(a, b)
+	var s editScript
+	s.keep(pre)
+	s.myers(a[pre:len(a)-suf], b[pre:len(b)-suf])
+	s.keep(suf)
+	return s.edits
+}
+
+// commonAffixes returns how many leading and trailing lines a and b share,
+// without overlapping.
+func commonAffixes(a, b []string) (pre, suf int) {
+	for pre < len(a) && pre < len(b) && a[pre] == b[pre] {
+		pre++
+	}
+	for suf < len(a)-pre && suf < len(b)-pre && a[len(a)-1-suf] == b[len(b)-1-suf] {
+		suf++
+	}
+	return pre, suf
+}
+
+// myersOps returns the per-line operations turning a into b, or false if
+// the edit distance exceeds maxMyersEdits.
+func myersOps(a, b []string) ([]string, bool) {
+	n, m := len(a), len(b)
+	max := n + m
+	if max == 0 {
+		return nil, true
+	}
+	if max > maxMyersLines {
+		return nil, false
+	}
+	offset := max + 1
+	v := make([]int, 2*max+3)
+	// trace[d] holds v[-d..d] as it was before round d, for backtracking.
+	var trace [][]int
+	for d := 0; d <= max; d++ {
+		if d > maxMyersEdits {
+			return nil, false
+		}
+		trace = append(trace, append([]int(nil), v[offset-d:offset+d+1]...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1] // Down: insertion
+			} else {
+				x = v[offset+k-1] + 1 // Right: deletion
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				return myersBacktrack(trace, n, m), true
+			}
+		}
+	}
+	return nil, false
+}
+
+// myersBacktrack walks the trace back from (n, m) to recover the path.
+func myersBacktrack(trace [][]int, n, m int) []string {
+	var ops []string
+	x, y := n, m
+	for d := len(trace) - 1; d > 0; d-- {
+		vd := trace[d]
+		at := func(k int) int { return vd[k+d] }
+		k := x - y
+		prevK := k - 1
+		if k == -d || (k != d && at(k-1) < at(k+1)) {
+			prevK = k + 1
+		}
+		prevX := at(prevK)
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			ops = append(ops, EditKeep)
+			x--
+			y--
+		}
+		if x == prevX {
+			ops = append(ops, EditInsert)
+		} else {
+			ops = append(ops, EditDelete)
+		}
+		x, y = prevX, prevY
+	}
+	for x > 0 && y > 0 {
+		ops = append(ops, EditKeep)
+		x--
+		y--
+	}
+	for i, j := 0, len(ops)-1; i < j; i, j = i+1, j-1 {
+		ops[i], ops[j] = ops[j], ops[i]
+	}
+	return ops
+}
+
+// ApplyLineEdits applies an edit script from MyersDiff to a, returning the
+// new text. It fails if the script doesn't fit a.
+func ApplyLineEdits(a []string, edits []LineEdit) ([]string, error) {
+	var out []string
+	i := 0
+	for _, e := range edits {
+		switch e.Op {
+		case EditKeep, EditDelete:
+			if e.Count < 0 || i+e.Count > len(a) {
+				return nil, fmt.Errorf("edit script overruns the text (%s%d at line %d of %d)", e.Op, e.Count, i, len(a))
+			}
+			if e.Op == EditKeep {
+				out = append(out, a[i:i+e.Count]...)
+			}
+			i += e.Count
+		case EditInsert:
+			out = append(out, e.Lines...)
+		default:
+			return nil, fmt.Errorf("unknown edit op %q", e.Op)
+		}
+	}
+	if i != len(a) {
+		return nil, fmt.Errorf("edit script covers %d of %d lines", i, len(a))
+	}
+	return out, nil
+}
+
+// editScript builds a script, merging runs of the same operation.
+type editScript struct {
+	edits  []LineEdit
+	coarse bool // Part of the script replaces text wholesale (see myers)
+}
+
+func (s *editScript) last(op string) *LineEdit {
+	if n := len(s.edits); n > 0 && s.edits[n-1].Op == op {
+		return &s.edits[n-1]
+	}
+	return nil
+}
+
+func (s *editScript) keep(n int) {
+	if n == 0 {
+		return
+	}
+	if e := s.last(EditKeep); e != nil {
+		e.Count += n
+		return
+	}
+	s.edits = append(s.edits, LineEdit{Op: EditKeep, Count: n})
+}
+
+func (s *editScript) delete(n int) {
+	if n == 0 {
+		return
+	}
+	if e := s.last(EditDelete); e != nil {
+		e.Count += n
+		return
+	}
+	s.edits = append(s.edits, LineEdit{Op: EditDelete, Count: n})
+}
+
+func (s *editScript) insert(line string) {
+	if e := s.last(EditInsert); e != nil {
+		e.Lines = append(e.Lines, line)
+		return
+	}
+	s.edits = append(s.edits, LineEdit{Op: EditInsert, Lines: []string{line}})
+}
+
+// myers appends a minimal script turning a into b, or one replacing all of
+// a if the edit distance exceeds maxMyersEdits.
+func (s *editScript) myers(a, b []string) {
+	ops, ok := myersOps(a, b)
+	if !ok {
+		// A pure deletion or insertion is exact however long it is.
+		if len(a) > 0 && len(b) > 0 {
+			s.coarse = true
+		}
+		s.delete(len(a))
+		for _, line := range b {
+			s.insert(line)
+		}
+		return
+	}
+	bi := 0
+	for _, op := range ops {
+		switch op {
+		case EditKeep:
+			s.keep(1)
+			bi++
+		case EditDelete:
+			s.delete(1)
+		case EditInsert:
+			s.insert(b[bi])
+			bi++
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package util
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMyersDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     string
+		wantEdit int // Lines deleted plus inserted
+	}{
+		{"identical", "a b c", "a b c", 0},
+		{"empty to text", "", "a b", 2},
+		{"text to empty", "a b", "", 2},
+		{"append", "a b c", "a b c d e", 2},
+		{"scrolled", "a b c d", "c d e f", 4},
+		{"middle change", "a b c d e", "a b x d e", 2},
+		{"classic", "a b c a b b a", "c b a b a c", 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := strings.Fields(tt.a), strings.Fields(tt.b)
+			edits := MyersDiff(a, b)
+			if got := editCost(edits); got != tt.wantEdit {
+				t.Errorf("edit cost = %d, want %d (%+v)", got, tt.wantEdit, edits)
+			}
+			got, err := ApplyLineEdits(a, edits)
+			if err != nil {
+				t.Fatalf("ApplyLineEdits: %v", err)
+			}
+			if strings.Join(got, " ") != strings.Join(b, " ") {
+				t.Errorf("ApplyLineEdits = %q, want %q", got, b)
+			}
+		})
+	}
+}
+
+func TestMyersDiffRandom(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	words := []string{"a", "b", "c", "d"}
+	gen := func() []string {
+		out := make([]string, rng.Intn(30))
+		for i := range out {
+			out[i] = words[rng.Intn(len(words))]
+		}
+		return out
+	}
+	for i := 0; i < 500; i++ {
+		a, b := gen(), gen()
+		got, err := ApplyLineEdits(a, MyersDiff(a, b))
+		if err != nil {
+			t.Fatalf("ApplyLineEdits(%q -> %q): %v", a, b, err)
+		}
+		if len(got) != len(b) || (len(b) > 0 && !reflect.DeepEqual(got, b)) {
+			t.Fatalf("round trip %q -> %q gave %q", a, b, got)
+		}
+	}
+}
+
+func TestMyersDiffBudget(t *testing.T) {
+	// Nothing in common and past the edit budget: a replace-all script,
+	// reported as coarse.
+	var a, b []string
+	for i := 0; i < maxMyersEdits; i++ {
+		a = append(a, fmt.Sprintf("old %d", i))
+		b = append(b, fmt.Sprintf("new %d", i))
+	}
+	b = append(b, "tail")
+	edits, coarse := DiffLines(a, b)
+	if !coarse {
+		t.Error("DiffLines past the edit budget should be coarse")
+	}
+	if len(edits) != 2 || edits[0].Op != EditDelete || edits[1].Op != EditInsert {
+		t.Errorf("want delete-all then insert-all, got %d edits", len(edits))
+	}
+	if got, err := ApplyLineEdits(a, edits); err != nil || len(got) != len(b) {
+		t.Errorf("coarse script does not round-trip: %v", err)
+	}
+
+	// Within the budget, the same shape of change is searched exactly.
+	if _, coarse := DiffLines(a[:100], b[:100]); coarse {
+		t.Error("small DiffLines should not be coarse")
+	}
+}
+
+func TestApplyLineEditsRejectsMismatch(t *testing.T) {
+	edits := []LineEdit{{Op: EditKeep, Count: 3}}
+	if _, err := ApplyLineEdits([]string{"a"}, edits); err == nil {
+		t.Error("script longer than the text accepted")
+	}
+	if _, err := ApplyLineEdits([]string{"a", "b", "c", "d"}, edits); err == nil {
+		t.Error("script shorter than the text accepted")
+	}
+}
+
+func editCost(edits []LineEdit) int {
+	n := 0
+	for _, e := range edits {
+		switch e.Op {
+		case EditDelete:
+			n += e.Count
+		case EditInsert:
+			n += len(e.Lines)
+		}
+	}
+	return n
+}
+
+// FuzzMyersDiff checks that MyersDiff returns a well-formed, minimal edit
+// script for arbitrary line pairs.
+func FuzzMyersDiff(f *testing.F) {
+	f.Add("a\nb\nc", "a\nb\nc\nd")
+	f.Add("", "x")
+	f.Add("a\nb\nc\na\nb\nb\na", "c\nb\na\nb\na\nc")
+	f.Add("❯ fix the build\n─────\n", "❯ \n─────\n")
+	f.Fuzz(func(t *testing.T, sa, sb string) {
+		a, b := strings.Split(sa, "\n"), strings.Split(sb, "\n")
+		edits := MyersDiff(a, b)
+		checkEditScript(t, a, b, edits)
+
+		if len(a)*len(b) <= 1<<16 {
+			if want := len(a) + len(b) - 2*lcsLen(a, b); editCost(edits) != want {
+				t.Fatalf("edit cost %d, minimal is %d", editCost(edits), want)
+			}
+		}
+	})
+}
+
+// checkEditScript fails t unless edits reconstructs b from a exactly,
+// consumes every line of a, and never emits empty or repeated steps.
+func checkEditScript(t *testing.T, a, b []string, edits []LineEdit) {
+	t.Helper()
+	got, err := ApplyLineEdits(a, edits)
+	if err != nil {
+		t.Fatalf("ApplyLineEdits: %v", err)
+	}
+	if !reflect.DeepEqual(got, b) {
+		t.Fatalf("round trip gave %q, want %q", got, b)
+	}
+
+	consumed := 0
+	for i, e := range edits {
+		if e.Op == EditInsert {
+			if len(e.Lines) == 0 {
+				t.Fatalf("edit %d: empty insert", i)
+			}
+		} else {
+			if e.Count <= 0 {
+				t.Fatalf("edit %d: %s with count %d", i, e.Op, e.Count)
+			}
+			consumed += e.Count
+		}
+		if i > 0 && edits[i-1].Op == e.Op {
+			t.Fatalf("edits %d and %d repeat %s", i-1, i, e.Op)
+		}
+	}
+	if consumed != len(a) {
+		t.Fatalf("script consumes %d lines of %d", consumed, len(a))
+	}
+}
+
+// lcsLen is the textbook LCS length, as a reference for MyersDiff.
+func lcsLen(a, b []string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for i := range a {
+		for j := range b {
+			switch {
+			case a[i] == b[j]:
+				cur[j+1] = prev[j] + 1
+			case prev[j+1] >= cur[j]:
+				cur[j+1] = prev[j+1]
+			default:
+				cur[j+1] = cur[j]
+			}
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,46 @@
+package util
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// AppendJSONLine marshals v and appends it as one line to the JSONL file at
+// path, creating the file and its directory as needed. When the file already
+// exceeds maxBytes, the older half of its lines is dropped first so that
+// append-only diagnostic logs stay bounded.
+func AppendJSONLine(path string, v interface{}, maxBytes int64) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	if info, err := os.Stat(path); err == nil && info.Size() > maxBytes {
+		trimJSONLines(path)
+	}
+
+	line, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.Write(append(line, '\n'))
+	return err
+}
+
+// trimJSONLines keeps the newer half of the file's lines. Best-effort: on
+// failure the file is left as it was.
+func trimJSONLines(path string) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return
+	}
+	half := len(data) / 2
+	for half < len(data) && data[half-1] != '\n' {
+		half++
+	}
+	_ = AtomicWriteFile(path, data[half:], 0644)
+}
@@ -0,0 +1,53 @@
+package util
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAppendJSONLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "samples.jsonl")
+
+	type entry struct {
+		N int `json:"n"`
+	}
+	for i := 0; i < 100; i++ {
+		if err := AppendJSONLine(path, entry{N: i}, 512); err != nil {
+			t.Fatalf("AppendJSONLine(%d): %v", i, err)
+		}
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Size() > 1024 {
+		t.Errorf("log size = %d, want it trimmed near 512 bytes", info.Size())
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var got []int
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			t.Fatalf("line %q is not valid JSON: %v", scanner.Text(), err)
+		}
+		got = append(got, e.N)
+	}
+	if len(got) == 0 || got[len(got)-1] != 99 {
+		t.Fatalf("entries = %v, want the newest entry last", got)
+	}
+	for i := 1; i < len(got); i++ {
+		if got[i] != got[i-1]+1 {
+			t.Fatalf("entries = %v, want a contiguous tail", got)
+		}
+	}
+}
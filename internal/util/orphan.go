@@ -47,17 +47,6 @@ func buildChildMap() map[int][]int {
 	return children
 }
 
-// addDescendants adds all descendant PIDs of a process to the set using
-// a pre-built child map (no additional process spawns).
-func addDescendants(parentPID int, childMap map[int][]int, pids map[int]bool) {
-	for _, pid := range childMap[parentPID] {
-		if !pids[pid] {
-			pids[pid] = true
-			addDescendants(pid, childMap, pids)
-		}
-	}
-}
-
 // getTmuxSessionPIDs returns a set of PIDs belonging to ANY tmux session.
 // This prevents killing Claude processes that are running in tmux sessions,
 // even if they temporarily show TTY "?" during startup or session transitions.
@@ -83,7 +72,7 @@ func getTmuxSessionPIDs() map[int]bool {
 
 	// For each session, get the PIDs of processes in its panes
 	for _, session := range sessions {
-		if session == "" {
+		if session == "" || tmux.IsControlSession(session) {
 			continue
 		}
 		out, err := tmux.BuildCommand("list-panes", "-t", session, "-F", "#{pane_pid}").Output()
@@ -0,0 +1,120 @@
+package util
+
+import "sort"
+
+// patienceMinLines is the combined size at which DiffLines switches from
+// MyersDiff to PatienceDiff. Scrollback captures run to thousands of lines
+// with many repeats (blank lines, box borders, prompts), where a minimal
+// script pairs up unrelated repeats and Myers' search nears its edit bound.
+const patienceMinLines = 2000
+
+// DiffLines returns an edit script turning a into b: MyersDiff for small
+// inputs, PatienceDiff for large ones. coarse reports that some stretch
+// was too different to search and is replaced wholesale, so the script may
+// be far from minimal; callers storing diffs may prefer the full text.
+func DiffLines(a, b []string) (edits []LineEdit, coarse bool) {
+	var s editScript
+	if len(a)+len(b) >= patienceMinLines {
+		s.patience(a, b)
+	} else {
+		pre, suf := commonAffixes(a, b)
+		s.keep(pre)
+		s.myers(a[pre:len(a)-suf], b[pre:len(b)-suf])
+		s.keep(suf)
+	}
+	return s.edits, s.coarse
+}
+
+// PatienceDiff returns a line edit script turning a into b using patience
+// diff: lines that occur exactly once in each text are matched first, in
+// order, and the gaps between those anchors are diffed recursively, with
+// MyersDiff for gaps that have no unique lines. The script is not always
+// minimal, but changes are grouped around the lines that identify them
+// rather than scattered over repeated ones, and long texts diff in close to
+// linear time.
+func PatienceDiff(a, b []string) []LineEdit {
+	var s editScript
+	s.patience(a, b)
+	return s.edits
+}
+
+// patience appends the patience diff of a and b.
+func (s *editScript) patience(a, b []string) {
+	pre, suf := commonAffixes(a, b)
+	s.keep(pre)
+	a, b = a[pre:len(a)-suf], b[pre:len(b)-suf]
+
+	anchors := uniqueAnchors(a, b)
+	if len(anchors) == 0 {
+		s.myers(a, b)
+	} else {
+		ai, bi := 0, 0
+		for _, an := range anchors {
+			s.patience(a[ai:an.a], b[bi:an.b])
+			s.keep(1)
+			ai, bi = an.a+1, an.b+1
+		}
+		s.patience(a[ai:], b[bi:])
+	}
+	s.keep(suf)
+}
+
+// anchor pairs a line of a with the same line of b.
+type anchor struct{ a, b int }
+
+// uniqueAnchors returns the longest run of lines that occur exactly once in
+// both a and b and appear in the same order in each.
+func uniqueAnchors(a, b []string) []anchor {
+	type seen struct{ countA, countB, a, b int }
+	lines := make(map[string]*seen)
+	for i, line := range a {
+		if e := lines[line]; e != nil {
+			e.countA++
+		} else {
+			lines[line] = &seen{countA: 1, a: i}
+		}
+	}
+	for i, line := range b {
+		if e := lines[line]; e != nil {
+			e.countB++
+			e.b = i
+		}
+	}
+	var unique []anchor
+	for _, e := range lines {
+		if e.countA == 1 && e.countB == 1 {
+			unique = append(unique, anchor{e.a, e.b})
+		}
+	}
+	sort.Slice(unique, func(i, j int) bool { return unique[i].a < unique[j].a })
+	return longestIncreasing(unique)
+}
+
+// longestIncreasing returns the longest subsequence of anchors (sorted by
+// a) whose b positions increase, by patience sorting.
+func longestIncreasing(anchors []anchor) []anchor {
+	if len(anchors) == 0 {
+		return nil
+	}
+	// tops[k] indexes the anchor ending the best run of length k+1; prev
+	// links each anchor to its predecessor in that run.
+	var tops []int
+	prev := make([]int, len(anchors))
+	for i, an := range anchors {
+		k := sort.Search(len(tops), func(k int) bool { return anchors[tops[k]].b > an.b })
+		prev[i] = -1
+		if k > 0 {
+			prev[i] = tops[k-1]
+		}
+		if k == len(tops) {
+			tops = append(tops, i)
+		} else {
+			tops[k] = i
+		}
+	}
+	run := make([]anchor, len(tops))
+	for i, k := tops[len(tops)-1], len(tops)-1; k >= 0; i, k = prev[i], k-1 {
+		run[k] = anchors[i]
+	}
+	return run
+}
@@ -0,0 +1,93 @@
+package util
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestPatienceDiffAnchorsOnUniqueLines(t *testing.T) {
+	// Adding a function between two others: Myers may pair the new
+	// function's braces and blank line with the old ones, splitting the
+	// insert in two; patience anchors on the unique signature lines and
+	// inserts it as one block.
+	a := strings.Split("func a() {\n\tx()\n}\n\nfunc c() {\n\tz()\n}", "\n")
+	b := strings.Split("func a() {\n\tx()\n}\n\nfunc b() {\n\ty()\n}\n\nfunc c() {\n\tz()\n}", "\n")
+
+	edits := PatienceDiff(a, b)
+	got, err := ApplyLineEdits(a, edits)
+	if err != nil || strings.Join(got, "\n") != strings.Join(b, "\n") {
+		t.Fatalf("round trip = %q, %v", got, err)
+	}
+	var inserts []LineEdit
+	for _, e := range edits {
+		if e.Op == EditDelete {
+			t.Errorf("unexpected delete: %+v", e)
+		}
+		if e.Op == EditInsert {
+			inserts = append(inserts, e)
+		}
+	}
+	if len(inserts) != 1 || len(inserts[0].Lines) != 4 {
+		t.Errorf("want one 4-line insert, got %+v", edits)
+	}
+}
+
+func TestPatienceDiffRandom(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	gen := func() []string {
+		out := make([]string, rng.Intn(60))
+		for i := range out {
+			// Mostly repeated lines, with a few unique ones to anchor on.
+			if rng.Intn(4) == 0 {
+				out[i] = fmt.Sprintf("unique %d", rng.Intn(40))
+			} else {
+				out[i] = []string{"", "}", "─────"}[rng.Intn(3)]
+			}
+		}
+		return out
+	}
+	for i := 0; i < 500; i++ {
+		a, b := gen(), gen()
+		got, err := ApplyLineEdits(a, PatienceDiff(a, b))
+		if err != nil {
+			t.Fatalf("ApplyLineEdits(%q -> %q): %v", a, b, err)
+		}
+		if strings.Join(got, "\n") != strings.Join(b, "\n") || len(got) != len(b) {
+			t.Fatalf("round trip %q -> %q gave %q", a, b, got)
+		}
+	}
+}
+
+func TestDiffLinesLargeCapture(t *testing.T) {
+	// A scrolled capture too far apart for Myers' edit bound: DiffLines
+	// still finds the shared lines instead of replacing everything.
+	var a, b []string
+	for i := 0; i < 6000; i++ {
+		a = append(a, fmt.Sprintf("line %d", i))
+	}
+	for i := 4500; i < 10500; i++ {
+		b = append(b, fmt.Sprintf("line %d", i))
+	}
+	edits, coarse := DiffLines(a, b)
+	got, err := ApplyLineEdits(a, edits)
+	if err != nil || len(got) != len(b) {
+		t.Fatalf("round trip failed: %v", err)
+	}
+	if cost := editCost(edits); cost != 9000 || coarse {
+		t.Errorf("edit cost = %d, coarse %v; want 9000 (1500 kept lines), not coarse", cost, coarse)
+	}
+}
+
+// FuzzPatienceDiff checks that PatienceDiff returns a well-formed edit
+// script for arbitrary line pairs.
+func FuzzPatienceDiff(f *testing.F) {
+	f.Add("a\nb\nc", "a\nb\nc\nd")
+	f.Add("x\n}\n\ny\n}", "x\n}\n\nw\n}\n\ny\n}")
+	f.Add("a\nb\nc\na\nb\nb\na", "c\nb\na\nb\na\nc")
+	f.Fuzz(func(t *testing.T, sa, sb string) {
+		a, b := strings.Split(sa, "\n"), strings.Split(sb, "\n")
+		checkEditScript(t, a, b, PatienceDiff(a, b))
+	})
+}
@@ -0,0 +1,286 @@
+package util
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+// ResourceUsage is the combined CPU and memory use of a process tree.
+type ResourceUsage struct {
+	Processes  int     `json:"processes"`
+	CPUPercent float64 `json:"cpu_percent"` // Percent of one core; may exceed 100
+	RSSBytes   int64   `json:"rss_bytes"`
+}
+
+// RSSMB returns resident memory in megabytes.
+func (u ResourceUsage) RSSMB() int64 {
+	return u.RSSBytes / (1024 * 1024)
+}
+
+// String formats the usage for status lines (e.g., "cpu 12% mem 340MB").
+func (u ResourceUsage) String() string {
+	return fmt.Sprintf("cpu %.0f%% mem %dMB", u.CPUPercent, u.RSSMB())
+}
+
+// procSample is one row of a process table snapshot.
+type procSample struct {
+	pid, ppid int
+	rssKB     int64
+	cpuTime   time.Duration // Cumulative CPU time
+}
+
+// SampleProcessTrees measures each process tree rooted at roots (typically
+// tmux pane PIDs). CPU is measured as CPU time consumed across two process
+// table snapshots taken interval apart, so it reflects current load rather
+// than the lifetime average ps reports. Memory comes from the second
+// snapshot. Roots that no longer exist are omitted from the result.
+func SampleProcessTrees(roots []int, interval time.Duration) (map[int]ResourceUsage, error) {
+	before, err := listProcesses()
+	if err != nil {
+		return nil, err
+	}
+	time.Sleep(interval)
+	after, err := listProcesses()
+	if err != nil {
+		return nil, err
+	}
+	return aggregateProcessTrees(roots, before, after, interval), nil
+}
+
+// SampleSessions measures each tmux session's process tree, keyed by session
+// name. Sessions that are gone, or that run on a remote rig host (whose
+// processes aren't visible here), are omitted.
+func SampleSessions(t *tmux.Tmux, sessions []string, interval time.Duration) (map[string]ResourceUsage, error) {
+	rootFor, roots := sessionRoots(t, sessions)
+	if len(roots) == 0 {
+		return map[string]ResourceUsage{}, nil
+	}
+
+	byRoot, err := SampleProcessTrees(roots, interval)
+	if err != nil {
+		return nil, err
+	}
+	return bySession(rootFor, byRoot), nil
+}
+
+// SessionSampler measures tmux sessions' resource usage across repeated
+// calls without sleeping: each Sample diffs against the oldest process
+// snapshot within the window. Where CPU time comes from ps (not Linux,
+// which reads /proc) it may have one-second resolution, so live views
+// refreshing every second need a window of several seconds for CPU to read
+// as more than 0% or 100%.
+type SessionSampler struct {
+	t       *tmux.Tmux
+	window  time.Duration
+	history []timedSnapshot
+}
+
+type timedSnapshot struct {
+	at    time.Time
+	procs []procSample
+}
+
+// NewSessionSampler returns a sampler averaging CPU over window.
+func NewSessionSampler(t *tmux.Tmux, window time.Duration) *SessionSampler {
+	return &SessionSampler{t: t, window: window}
+}
+
+// Sample snapshots the process table and measures each session's process
+// tree against the oldest snapshot still in the window. The first call
+// reports memory and process counts with zero CPU.
+func (s *SessionSampler) Sample(sessions []string) (map[string]ResourceUsage, error) {
+	procs, err := listProcesses()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	s.history = append(s.history, timedSnapshot{at: now, procs: procs})
+	for len(s.history) > 2 && now.Sub(s.history[1].at) >= s.window {
+		s.history = s.history[1:]
+	}
+
+	rootFor, roots := sessionRoots(s.t, sessions)
+	base := s.history[0]
+	return bySession(rootFor, aggregateProcessTrees(roots, base.procs, procs, now.Sub(base.at))), nil
+}
+
+// sessionRoots resolves each local session's pane PID.
+func sessionRoots(t *tmux.Tmux, sessions []string) (map[string]int, []int) {
+	rootFor := make(map[string]int, len(sessions))
+	roots := make([]int, 0, len(sessions))
+	for _, s := range sessions {
+		if tmux.HostForSession(s) != "" {
+			continue
+		}
+		pidStr, err := t.GetPanePID(s)
+		if err != nil {
+			continue
+		}
+		pid, err := strconv.Atoi(pidStr)
+		if err != nil {
+			continue
+		}
+		rootFor[s] = pid
+		roots = append(roots, pid)
+	}
+	return rootFor, roots
+}
+
+// bySession re-keys process tree usage by session name.
+func bySession(rootFor map[string]int, byRoot map[int]ResourceUsage) map[string]ResourceUsage {
+	result := make(map[string]ResourceUsage, len(rootFor))
+	for s, pid := range rootFor {
+		if u, ok := byRoot[pid]; ok {
+			result[s] = u
+		}
+	}
+	return result
+}
+
+// aggregateProcessTrees sums usage over each root's descendants in after,
+// taking CPU deltas against before. Processes that started between the
+// snapshots count their whole CPU time.
+func aggregateProcessTrees(roots []int, before, after []procSample, interval time.Duration) map[int]ResourceUsage {
+	prevCPU := make(map[int]time.Duration, len(before))
+	for _, p := range before {
+		prevCPU[p.pid] = p.cpuTime
+	}
+	byPID := make(map[int]procSample, len(after))
+	childMap := make(map[int][]int)
+	for _, p := range after {
+		byPID[p.pid] = p
+		childMap[p.ppid] = append(childMap[p.ppid], p.pid)
+	}
+
+	result := make(map[int]ResourceUsage, len(roots))
+	for _, root := range roots {
+		if _, ok := byPID[root]; !ok {
+			continue
+		}
+		tree := map[int]bool{root: true}
+		addDescendants(root, childMap, tree)
+
+		var usage ResourceUsage
+		var cpu time.Duration
+		for pid := range tree {
+			p := byPID[pid]
+			usage.Processes++
+			usage.RSSBytes += p.rssKB * 1024
+			if delta := p.cpuTime - prevCPU[pid]; delta > 0 {
+				cpu += delta
+			}
+		}
+		if interval > 0 {
+			usage.CPUPercent = float64(cpu) / float64(interval) * 100
+		}
+		result[root] = usage
+	}
+	return result
+}
+
+// addDescendants adds all descendant PIDs of a process to the set using
+// a pre-built child map (no additional process spawns).
+func addDescendants(parentPID int, childMap map[int][]int, pids map[int]bool) {
+	for _, pid := range childMap[parentPID] {
+		if !pids[pid] {
+			pids[pid] = true
+			addDescendants(pid, childMap, pids)
+		}
+	}
+}
+
+// parseProcessTable parses `ps -eo pid=,ppid=,rss=,time=` output.
+func parseProcessTable(out string) []procSample {
+	var procs []procSample
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 4 {
+			continue
+		}
+		pid, err1 := strconv.Atoi(fields[0])
+		ppid, err2 := strconv.Atoi(fields[1])
+		rss, err3 := strconv.ParseInt(fields[2], 10, 64)
+		cpu, err4 := parseCPUTime(fields[3])
+		if err1 != nil || err2 != nil || err3 != nil || err4 != nil {
+			continue
+		}
+		procs = append(procs, procSample{pid: pid, ppid: ppid, rssKB: rss, cpuTime: cpu})
+	}
+	return procs
+}
+
+// clockTicksPerSecond is the unit of CPU times in /proc/<pid>/stat
+// (USER_HZ), which Linux fixes at 100 on every architecture it exposes to
+// user space.
+const clockTicksPerSecond = 100
+
+// parseProcStat parses a Linux /proc/<pid>/stat line. The command name
+// (field 2) is parenthesized and may contain spaces or parentheses, so
+// fields are counted from its last ')'. pageKB is the page size in KB, the
+// unit of rss.
+func parseProcStat(line string, pageKB int64) (procSample, bool) {
+	open := strings.IndexByte(line, '(')
+	end := strings.LastIndexByte(line, ')')
+	if open < 0 || end < open {
+		return procSample{}, false
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(line[:open]))
+	if err != nil {
+		return procSample{}, false
+	}
+	// fields[0] is state (field 3); ppid is field 4, utime 14, stime 15,
+	// rss 24.
+	fields := strings.Fields(line[end+1:])
+	if len(fields) < 22 {
+		return procSample{}, false
+	}
+	ppid, err1 := strconv.Atoi(fields[1])
+	utime, err2 := strconv.ParseInt(fields[11], 10, 64)
+	stime, err3 := strconv.ParseInt(fields[12], 10, 64)
+	rss, err4 := strconv.ParseInt(fields[21], 10, 64)
+	if err1 != nil || err2 != nil || err3 != nil || err4 != nil {
+		return procSample{}, false
+	}
+	return procSample{
+		pid:     pid,
+		ppid:    ppid,
+		rssKB:   rss * pageKB,
+		cpuTime: time.Duration(utime+stime) * time.Second / clockTicksPerSecond,
+	}, true
+}
+
+// parseCPUTime parses ps cumulative CPU time: "[[dd-]hh:]mm:ss" on Linux,
+// "m:ss.hh" on macOS.
+func parseCPUTime(s string) (time.Duration, error) {
+	var days int
+	if d, rest, ok := strings.Cut(s, "-"); ok {
+		n, err := strconv.Atoi(d)
+		if err != nil {
+			return 0, err
+		}
+		days, s = n, rest
+	}
+	parts := strings.Split(s, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, fmt.Errorf("invalid cpu time %q", s)
+	}
+	secs, err := strconv.ParseFloat(parts[len(parts)-1], 64)
+	if err != nil {
+		return 0, err
+	}
+	total := time.Duration(secs * float64(time.Second))
+	unit := time.Minute
+	for i := len(parts) - 2; i >= 0; i-- {
+		n, err := strconv.Atoi(parts[i])
+		if err != nil {
+			return 0, err
+		}
+		total += time.Duration(n) * unit
+		unit = time.Hour
+	}
+	return total + time.Duration(days)*24*time.Hour, nil
+}
@@ -0,0 +1,35 @@
+//go:build linux
+
+package util
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// listProcesses snapshots the process table from /proc. Unlike ps, whose
+// CPU time has one-second resolution, /proc/<pid>/stat counts clock ticks,
+// so short sampling intervals still measure CPU accurately.
+func listProcesses() ([]procSample, error) {
+	entries, err := os.ReadDir("/proc")
+	if err != nil {
+		return nil, fmt.Errorf("listing processes: %w", err)
+	}
+	pageKB := int64(os.Getpagesize() / 1024)
+	procs := make([]procSample, 0, len(entries))
+	for _, e := range entries {
+		if _, err := strconv.Atoi(e.Name()); err != nil {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join("/proc", e.Name(), "stat"))
+		if err != nil {
+			continue // Exited since the listing
+		}
+		if p, ok := parseProcStat(string(data), pageKB); ok {
+			procs = append(procs, p)
+		}
+	}
+	return procs, nil
+}
@@ -0,0 +1,17 @@
+//go:build !windows && !linux
+
+package util
+
+import (
+	"fmt"
+	"os/exec"
+)
+
+// listProcesses snapshots the process table with a single ps call.
+func listProcesses() ([]procSample, error) {
+	out, err := exec.Command("ps", "-eo", "pid=,ppid=,rss=,time=").Output()
+	if err != nil {
+		return nil, fmt.Errorf("listing processes: %w", err)
+	}
+	return parseProcessTable(string(out)), nil
+}
@@ -0,0 +1,84 @@
+package util
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCPUTime(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Duration
+	}{
+		{"00:00:07", 7 * time.Second},
+		{"01:02:03", time.Hour + 2*time.Minute + 3*time.Second},
+		{"2-03:00:00", 51 * time.Hour},
+		{"0:01.50", 1500 * time.Millisecond}, // macOS
+		{"12:30.00", 12*time.Minute + 30*time.Second},
+	}
+	for _, tt := range tests {
+		got, err := parseCPUTime(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("parseCPUTime(%q) = %v, %v; want %v", tt.in, got, err, tt.want)
+		}
+	}
+	if _, err := parseCPUTime("garbage"); err == nil {
+		t.Error("parseCPUTime(garbage) should fail")
+	}
+}
+
+func TestAggregateProcessTrees(t *testing.T) {
+	before := parseProcessTable(`
+  100     1  1024 00:00:10
+  101   100  2048 00:00:20
+  102   101  4096 00:00:01
+  200     1   512 00:00:05
+`)
+	after := parseProcessTable(`
+  100     1  1024 00:00:10
+  101   100  2048 00:00:21
+  102   101  4096 00:00:01
+  103   100  1024 00:00:01
+  200     1   512 00:00:05
+  999     1   100 00:00:00
+`)
+	got := aggregateProcessTrees([]int{100, 200, 300}, before, after, 2*time.Second)
+
+	tree := got[100]
+	if tree.Processes != 4 {
+		t.Errorf("processes = %d, want 4 (root, child, grandchild, new child)", tree.Processes)
+	}
+	if want := int64(1024+2048+4096+1024) * 1024; tree.RSSBytes != want {
+		t.Errorf("rss = %d, want %d", tree.RSSBytes, want)
+	}
+	// 1s from pid 101 plus 1s from newly started pid 103, over 2s.
+	if tree.CPUPercent != 100 {
+		t.Errorf("cpu = %.1f%%, want 100%%", tree.CPUPercent)
+	}
+	if got[200].CPUPercent != 0 || got[200].Processes != 1 {
+		t.Errorf("idle tree = %+v", got[200])
+	}
+	if _, ok := got[300]; ok {
+		t.Error("missing root should be omitted")
+	}
+}
+
+func TestParseProcStat(t *testing.T) {
+	line := "4242 (tmux: server (x)) S 17 4242 4242 0 -1 4194560 1234 0 0 0 250 50 0 0 20 0 1 0 999 123456789 300 18446744073709551615 1 1 0 0 0 0 0 0 0 0 0 0 17 3 0 0 0 0 0\n"
+	p, ok := parseProcStat(line, 4)
+	if !ok {
+		t.Fatal("parseProcStat failed")
+	}
+	if p.pid != 4242 || p.ppid != 17 {
+		t.Errorf("pid/ppid = %d/%d, want 4242/17", p.pid, p.ppid)
+	}
+	if p.cpuTime != 3*time.Second {
+		t.Errorf("cpu = %v, want 3s (250+50 ticks)", p.cpuTime)
+	}
+	if p.rssKB != 1200 {
+		t.Errorf("rss = %dKB, want 1200 (300 pages of 4KB)", p.rssKB)
+	}
+	if _, ok := parseProcStat("garbage", 4); ok {
+		t.Error("parseProcStat(garbage) should fail")
+	}
+}
@@ -0,0 +1,10 @@
+//go:build windows
+
+package util
+
+import "errors"
+
+// listProcesses is not supported on Windows.
+func listProcesses() ([]procSample, error) {
+	return nil, errors.New("process sampling is not supported on Windows")
+}
@@ -0,0 +1,39 @@
+package util
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Timestamps are persisted in UTC. Display code converts to the host's zone
+// explicitly, and calendar bucketing (per-day totals, "yesterday") names the
+// zone it means rather than inheriting whatever zone a value was parsed in.
+
+// timestampLayouts are the forms persisted timestamps take across gt, bd,
+// and Dolt. Layouts without a zone are UTC: that is how bd and Dolt store
+// them.
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02 15:04:05Z07:00",
+}
+
+// ParseTimestamp parses a persisted timestamp in any of the forms gt, bd,
+// and Dolt write, returning it in UTC. Zone-less timestamps are read as UTC.
+func ParseTimestamp(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	for _, layout := range timestampLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized timestamp %q", s)
+}
+
+// DayKey returns t's calendar date (YYYY-MM-DD) in loc, for bucketing
+// timestamps that may have been stored in different zones.
+func DayKey(t time.Time, loc *time.Location) string {
+	return t.In(loc).Format("2006-01-02")
+}
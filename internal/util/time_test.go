@@ -0,0 +1,44 @@
+package util
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTimestamp_Forms(t *testing.T) {
+	want := time.Date(2026, 3, 8, 14, 30, 5, 0, time.UTC)
+	for _, s := range []string{
+		"2026-03-08T14:30:05Z",
+		"2026-03-08T09:30:05-05:00",
+		"2026-03-08T14:30:05",
+		"2026-03-08 14:30:05",
+		"2026-03-08 09:30:05-05:00",
+		"  2026-03-08T14:30:05Z\n",
+	} {
+		got, err := ParseTimestamp(s)
+		if err != nil {
+			t.Errorf("ParseTimestamp(%q) error: %v", s, err)
+			continue
+		}
+		if !got.Equal(want) || got.Location() != time.UTC {
+			t.Errorf("ParseTimestamp(%q) = %v, want %v in UTC", s, got, want)
+		}
+	}
+	if _, err := ParseTimestamp("yesterday"); err == nil {
+		t.Error("ParseTimestamp(yesterday) should fail")
+	}
+}
+
+func TestDayKey_UsesGivenZone(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("tz database unavailable")
+	}
+	ts := time.Date(2026, 3, 9, 2, 0, 0, 0, time.UTC) // 22:00 on the 8th in New York
+	if got := DayKey(ts, time.UTC); got != "2026-03-09" {
+		t.Errorf("DayKey(UTC) = %s, want 2026-03-09", got)
+	}
+	if got := DayKey(ts, ny); got != "2026-03-08" {
+		t.Errorf("DayKey(New York) = %s, want 2026-03-08", got)
+	}
+}
@@ -0,0 +1,200 @@
+// Package utilization records periodic samples of each agent's state
+// (busy, idle, stuck, down) and buckets them over time for the capacity
+// heatmap (gt stats heatmap). Samples come from the same beads-based
+// classification the feed's problems view uses (internal/agenthealth).
+package utilization
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/agenthealth"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// Agent states recorded in samples.
+const (
+	StateBusy  = "busy"  // Hooked work with recent progress
+	StateIdle  = "idle"  // No hooked work
+	StateStuck = "stuck" // Hooked work but stalled (or GUPP violation)
+	StateDown  = "down"  // Session dead
+)
+
+// maxLogBytes caps the sample log; when exceeded, the older half is dropped
+// on the next write. At a 5-minute interval this holds a few weeks for a
+// typical town.
+const maxLogBytes = 4 * 1024 * 1024
+
+// Sample is every agent's state at one instant, keyed by agent address
+// (e.g., "gastown/nux", "gastown/witness", "mayor").
+type Sample struct {
+	At     time.Time         `json:"at"`
+	States map[string]string `json:"states"`
+}
+
+// logPath returns <townRoot>/.runtime/utilization.jsonl.
+func logPath(townRoot string) string {
+	return filepath.Join(townRoot, constants.DirRuntime, "utilization.jsonl")
+}
+
+// stateFor maps the feed's health classification to a sample state.
+func stateFor(s agenthealth.AgentState) string {
+	switch s {
+	case agenthealth.StateWorking:
+		return StateBusy
+	case agenthealth.StateStalled, agenthealth.StateGUPPViolation:
+		return StateStuck
+	case agenthealth.StateZombie:
+		return StateDown
+	default:
+		return StateIdle
+	}
+}
+
+// agentKey returns the address-like key an agent is recorded under.
+func agentKey(a *agenthealth.ProblemAgent) string {
+	if a.Rig == "" {
+		return a.Name
+	}
+	return a.Rig + "/" + a.Name
+}
+
+// Take classifies every agent from its agent bead and session liveness.
+func Take(bd *beads.Beads) (Sample, error) {
+	agents, err := agenthealth.NewStuckDetector(bd).CheckAll()
+	if err != nil {
+		return Sample{}, err
+	}
+	s := Sample{At: time.Now().UTC(), States: make(map[string]string, len(agents))}
+	for _, a := range agents {
+		s.States[agentKey(a)] = stateFor(a.State)
+	}
+	return s, nil
+}
+
+// Record appends a sample to the town's utilization log.
+func Record(townRoot string, s Sample) error {
+	return util.AppendJSONLine(logPath(townRoot), s, maxLogBytes)
+}
+
+// Load returns samples taken at or after since, oldest first. A missing log
+// yields no samples.
+func Load(townRoot string, since time.Time) ([]Sample, error) {
+	f, err := os.Open(logPath(townRoot))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var samples []Sample
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var s Sample
+		if json.Unmarshal(scanner.Bytes(), &s) != nil || s.At.Before(since) {
+			continue
+		}
+		samples = append(samples, s)
+	}
+	return samples, scanner.Err()
+}
+
+// Heatmap is agent states bucketed over a time range. Cells[agent][i] is
+// the dominant state in bucket i, or "" when there was no sample.
+type Heatmap struct {
+	Start   time.Time
+	End     time.Time
+	Buckets int
+	Agents  []string // Sorted
+	Cells   map[string][]string
+}
+
+// BucketWidth returns the duration each bucket covers.
+func (h *Heatmap) BucketWidth() time.Duration {
+	return h.End.Sub(h.Start) / time.Duration(h.Buckets)
+}
+
+// severity breaks ties between equally common states in a bucket: the
+// worse state wins so short stalls are not hidden.
+var severity = map[string]int{StateIdle: 0, StateBusy: 1, StateDown: 2, StateStuck: 3}
+
+// Build buckets samples in [start, end) into n equal buckets. Each cell
+// holds the state seen most often in that bucket; ties go to the more
+// severe state. include filters agents by key when non-nil.
+func Build(samples []Sample, start, end time.Time, n int, include func(agent string) bool) *Heatmap {
+	h := &Heatmap{Start: start, End: end, Buckets: n, Cells: make(map[string][]string)}
+	if n <= 0 || !end.After(start) {
+		return h
+	}
+	width := end.Sub(start) / time.Duration(n)
+	if width <= 0 {
+		width = 1
+	}
+
+	counts := make(map[string][]map[string]int)
+	for _, s := range samples {
+		if s.At.Before(start) || !s.At.Before(end) {
+			continue
+		}
+		i := int(s.At.Sub(start) / width)
+		if i >= n {
+			i = n - 1
+		}
+		for agent, state := range s.States {
+			if include != nil && !include(agent) {
+				continue
+			}
+			if counts[agent] == nil {
+				counts[agent] = make([]map[string]int, n)
+			}
+			if counts[agent][i] == nil {
+				counts[agent][i] = make(map[string]int)
+			}
+			counts[agent][i][state]++
+		}
+	}
+
+	for agent, buckets := range counts {
+		row := make([]string, n)
+		for i, c := range buckets {
+			best, bestN := "", 0
+			for state, k := range c {
+				if k > bestN || (k == bestN && severity[state] > severity[best]) {
+					best, bestN = state, k
+				}
+			}
+			row[i] = best
+		}
+		h.Cells[agent] = row
+		h.Agents = append(h.Agents, agent)
+	}
+	sort.Strings(h.Agents)
+	return h
+}
+
+// Share returns the fraction of an agent's sampled buckets in state.
+func (h *Heatmap) Share(agent, state string) float64 {
+	sampled, hits := 0, 0
+	for _, s := range h.Cells[agent] {
+		if s == "" {
+			continue
+		}
+		sampled++
+		if s == state {
+			hits++
+		}
+	}
+	if sampled == 0 {
+		return 0
+	}
+	return float64(hits) / float64(sampled)
+}
@@ -0,0 +1,87 @@
+package utilization
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRecordLoad(t *testing.T) {
+	town := t.TempDir()
+	t0 := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+
+	if samples, err := Load(town, time.Time{}); err != nil || samples != nil {
+		t.Fatalf("Load (missing) = %v, %v; want nil, nil", samples, err)
+	}
+
+	for i := 0; i < 3; i++ {
+		s := Sample{At: t0.Add(time.Duration(i) * time.Hour), States: map[string]string{"gastown/nux": StateBusy}}
+		if err := Record(town, s); err != nil {
+			t.Fatalf("Record: %v", err)
+		}
+	}
+
+	samples, err := Load(town, t0.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(samples) != 2 {
+		t.Fatalf("Load since t0+1h returned %d samples, want 2", len(samples))
+	}
+	if !samples[0].At.Equal(t0.Add(time.Hour)) || samples[0].States["gastown/nux"] != StateBusy {
+		t.Errorf("first sample = %+v", samples[0])
+	}
+}
+
+func TestBuild(t *testing.T) {
+	start := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+	end := start.Add(4 * time.Hour)
+	at := func(min int) time.Time { return start.Add(time.Duration(min) * time.Minute) }
+	samples := []Sample{
+		// Bucket 0: busy twice, idle once → busy.
+		{At: at(0), States: map[string]string{"gastown/nux": StateBusy, "mayor": StateIdle}},
+		{At: at(20), States: map[string]string{"gastown/nux": StateBusy}},
+		{At: at(40), States: map[string]string{"gastown/nux": StateIdle}},
+		// Bucket 1: busy and stuck tie → stuck.
+		{At: at(70), States: map[string]string{"gastown/nux": StateBusy}},
+		{At: at(90), States: map[string]string{"gastown/nux": StateStuck}},
+		// Bucket 2: no samples.
+		// Bucket 3: idle.
+		{At: at(200), States: map[string]string{"gastown/nux": StateIdle}},
+		// Outside the range.
+		{At: end, States: map[string]string{"gastown/nux": StateDown}},
+		{At: at(-1), States: map[string]string{"gastown/nux": StateDown}},
+	}
+
+	h := Build(samples, start, end, 4, nil)
+	if h.BucketWidth() != time.Hour {
+		t.Errorf("BucketWidth() = %v, want 1h", h.BucketWidth())
+	}
+	if len(h.Agents) != 2 || h.Agents[0] != "gastown/nux" || h.Agents[1] != "mayor" {
+		t.Errorf("Agents = %v, want [gastown/nux mayor]", h.Agents)
+	}
+
+	want := []string{StateBusy, StateStuck, "", StateIdle}
+	got := h.Cells["gastown/nux"]
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("gastown/nux bucket %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+
+	if share := h.Share("gastown/nux", StateBusy); share < 0.33 || share > 0.34 {
+		t.Errorf("Share(busy) = %v, want 1/3", share)
+	}
+	if share := h.Share("unknown", StateBusy); share != 0 {
+		t.Errorf("Share(unknown agent) = %v, want 0", share)
+	}
+}
+
+func TestBuildInclude(t *testing.T) {
+	start := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+	samples := []Sample{{At: start, States: map[string]string{"gastown/nux": StateBusy, "beads/toast": StateIdle, "mayor": StateIdle}}}
+
+	h := Build(samples, start, start.Add(time.Hour), 2, func(agent string) bool { return agent == "beads/toast" })
+	if len(h.Agents) != 1 || h.Agents[0] != "beads/toast" {
+		t.Errorf("Agents = %v, want [beads/toast]", h.Agents)
+	}
+}
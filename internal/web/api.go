@@ -15,6 +15,7 @@ import (
 	"time"
 
 	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/doctor"
 	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/tmux"
 )
@@ -61,6 +62,8 @@ type APIHandler struct {
 	cmdSem chan struct{}
 	// csrfToken is validated on POST requests to prevent cross-site request forgery.
 	csrfToken string
+	// statusStream shares one status poller among /api/status/stream clients.
+	statusStream *StatusStream
 }
 
 const optionsCacheTTL = 30 * time.Second
@@ -77,7 +80,7 @@ func NewAPIHandler(defaultRunTimeout, maxRunTimeout time.Duration, csrfToken str
 	// Use PATH lookup for gt binary. Do NOT use os.Executable() here - during
 	// tests it returns the test binary, causing fork bombs when executed.
 	workDir, _ := os.Getwd()
-	return &APIHandler{
+	h := &APIHandler{
 		gtPath:            "gt",
 		workDir:           workDir,
 		defaultRunTimeout: defaultRunTimeout,
@@ -85,6 +88,8 @@ func NewAPIHandler(defaultRunTimeout, maxRunTimeout time.Duration, csrfToken str
 		cmdSem:            make(chan struct{}, maxConcurrentCommands),
 		csrfToken:         csrfToken,
 	}
+	h.statusStream = NewStatusStream(h.fetchStatus, 2*time.Second)
+	return h
 }
 
 // ServeHTTP routes API requests to the appropriate handler.
@@ -137,8 +142,12 @@ func (h *APIHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		h.handleReady(w, r)
 	case path == "/events" && r.Method == http.MethodGet:
 		h.handleSSE(w, r)
+	case path == "/status/stream" && r.Method == http.MethodGet:
+		h.handleStatusStream(w, r)
 	case path == "/session/preview" && r.Method == http.MethodGet:
 		h.handleSessionPreview(w, r)
+	case path == "/doctor" && r.Method == http.MethodGet:
+		h.handleDoctor(w, r)
 	default:
 		http.Error(w, "Not found", http.StatusNotFound)
 	}
@@ -1893,6 +1902,26 @@ func determineCrewState(activityAge time.Duration, isClaudeRunning bool, hook st
 	}
 }
 
+// handleDoctor runs gt doctor in this town and returns its JSON report.
+// Peer towns use it for 'gt doctor --all-towns'.
+func (h *APIHandler) handleDoctor(w http.ResponseWriter, r *http.Request) {
+	// doctor exits non-zero when checks fail, so the error is ignored as long
+	// as a report was produced.
+	output, err := h.runGtCommand(r.Context(), 3*time.Minute, []string{"doctor", "--json"})
+
+	report, parseErr := doctor.ParseReportJSON([]byte(output))
+	if parseErr != nil {
+		if err != nil {
+			parseErr = err
+		}
+		h.sendError(w, "doctor produced no report: "+parseErr.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(report)
+}
+
 // handleReady returns ready work items across town.
 func (h *APIHandler) handleReady(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
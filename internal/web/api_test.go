@@ -6,11 +6,15 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
 	"strings"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/steveyegge/gastown/internal/doctor"
 	"github.com/steveyegge/gastown/internal/session"
 )
 
@@ -520,6 +524,48 @@ func TestAPIHandler_Ready(t *testing.T) {
 	}
 }
 
+func TestAPIHandler_Doctor(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script stub for gt")
+	}
+	// doctor exits 1 when checks fail; the report must still be relayed.
+	stub := filepath.Join(t.TempDir(), "gt")
+	script := "#!/bin/sh\necho '{\"town\":\"/town\",\"summary\":{\"total\":1,\"errors\":1},\"checks\":[]}'\nexit 1\n"
+	if err := os.WriteFile(stub, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	handler := &APIHandler{
+		gtPath:            stub,
+		workDir:           t.TempDir(),
+		defaultRunTimeout: 5 * time.Second,
+		maxRunTimeout:     10 * time.Second,
+		cmdSem:            make(chan struct{}, maxConcurrentCommands),
+		csrfToken:         "test-token",
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/doctor", nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("GET /api/doctor status = %d, want %d: %s", w.Code, http.StatusOK, w.Body.String())
+	}
+	var report doctor.ReportJSON
+	if err := json.NewDecoder(w.Body).Decode(&report); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if report.Town != "/town" || report.Summary.Errors != 1 {
+		t.Errorf("unexpected report: %+v", report)
+	}
+
+	handler.gtPath = "false"
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/doctor", nil))
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("GET /api/doctor with failing gt status = %d, want %d", w.Code, http.StatusInternalServerError)
+	}
+}
+
 func TestAPIHandler_IssueCreate_MissingTitle(t *testing.T) {
 	handler := NewAPIHandler(30*time.Second, 60*time.Second, "test-token")
 
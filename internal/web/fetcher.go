@@ -18,6 +18,7 @@ import (
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/constants"
 	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/tmux"
 	"github.com/steveyegge/gastown/internal/workspace"
 )
 
@@ -479,6 +480,9 @@ func (f *LiveConvoyFetcher) getAllPolecatActivity() *time.Time {
 		}
 
 		sessionName := parts[0]
+		if tmux.IsControlSession(sessionName) {
+			continue
+		}
 		// Check if it's a polecat or crew session (skip infrastructure roles)
 		identity, err := session.ParseSessionName(sessionName)
 		if err != nil {
@@ -742,6 +746,9 @@ func (f *LiveConvoyFetcher) FetchWorkers() ([]WorkerRow, error) {
 		}
 
 		sessionName := parts[0]
+		if tmux.IsControlSession(sessionName) {
+			continue
+		}
 
 		// Filter for gt-<rig>-<polecat> pattern
 		// Parse session name using canonical parser
@@ -1355,6 +1362,9 @@ func (f *LiveConvoyFetcher) FetchSessions() ([]SessionRow, error) {
 		// SplitN always returns >= 1 element; parts[0] is safe unconditionally
 		parts := strings.SplitN(line, ":", 2)
 		name := parts[0]
+		if tmux.IsControlSession(name) {
+			continue
+		}
 
 		// Only include Gas Town sessions
 		if !session.IsKnownSession(name) {
@@ -1677,7 +1687,7 @@ func (f *LiveConvoyFetcher) FetchActivity() ([]ActivityRow, error) {
 // eventCategory classifies an event type into a filter category.
 func eventCategory(eventType string) string {
 	switch eventType {
-	case "spawn", "kill", "session_start", "session_end", "session_death", "mass_death", "nudge", "handoff":
+	case "spawn", "kill", "session_start", "session_end", "session_death", "mass_death", "crash_loop", "nudge", "handoff":
 		return "agent"
 	case "sling", "hook", "unhook", "done", "merge_started", "merged", "merge_failed":
 		return "work"
@@ -1718,6 +1728,7 @@ func eventIcon(eventType string) string {
 		"session_end":       "⏹️",
 		"session_death":     "☠️",
 		"mass_death":        "💥",
+		"crash_loop":        "🔁",
 		"patrol_started":    "🔍",
 		"patrol_complete":   "✔️",
 		"escalation_sent":   "⚠️",
@@ -1781,6 +1792,9 @@ func eventSummary(eventType, actor string, payload map[string]interface{}) strin
 	case "mass_death":
 		count, _ := payload["count"].(float64)
 		return fmt.Sprintf("%.0f sessions died", count)
+	case "crash_loop":
+		agent, _ := payload["agent"].(string)
+		return fmt.Sprintf("%s crash-looping", agent)
 	default:
 		return eventType
 	}
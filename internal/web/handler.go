@@ -345,12 +345,16 @@ func generateCSRFToken() string {
 // NewDashboardMux creates an HTTP handler that serves both the dashboard and API.
 // webCfg may be nil, in which case defaults are used.
 func NewDashboardMux(fetcher ConvoyFetcher, webCfg *config.WebTimeoutsConfig) (http.Handler, error) {
+	return newDashboardMux(fetcher, webCfg, generateCSRFToken())
+}
+
+// newDashboardMux builds the dashboard handler around a given CSRF token, so
+// a rebuilt handler keeps accepting requests from pages already open.
+func newDashboardMux(fetcher ConvoyFetcher, webCfg *config.WebTimeoutsConfig, csrfToken string) (http.Handler, error) {
 	if webCfg == nil {
 		webCfg = config.DefaultWebTimeoutsConfig()
 	}
 
-	csrfToken := generateCSRFToken()
-
 	fetchTimeout := config.ParseDurationOrDefault(webCfg.FetchTimeout, 8*time.Second)
 	convoyHandler, err := NewConvoyHandler(fetcher, fetchTimeout, csrfToken)
 	if err != nil {
@@ -0,0 +1,47 @@
+package web
+
+import (
+	"net/http"
+	"sync/atomic"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+// ReloadableDashboard serves the dashboard and can rebuild it with new
+// settings while running, so a long-lived gt dashboard picks up edits to
+// web_timeouts and worker_status in settings/config.json without a restart.
+type ReloadableDashboard struct {
+	csrfToken string
+	handler   atomic.Pointer[http.Handler]
+}
+
+// NewReloadableDashboard builds the dashboard for the current workspace
+// with the given timeouts (nil for defaults).
+func NewReloadableDashboard(webCfg *config.WebTimeoutsConfig) (*ReloadableDashboard, error) {
+	d := &ReloadableDashboard{csrfToken: generateCSRFToken()}
+	if err := d.Reload(webCfg); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// Reload rebuilds the dashboard with webCfg and fresh worker status
+// settings. Requests in flight finish on the old handler. On error the old
+// handler stays in place.
+func (d *ReloadableDashboard) Reload(webCfg *config.WebTimeoutsConfig) error {
+	fetcher, err := NewLiveConvoyFetcher()
+	if err != nil {
+		return err
+	}
+	h, err := newDashboardMux(fetcher, webCfg, d.csrfToken)
+	if err != nil {
+		return err
+	}
+	d.handler.Store(&h)
+	return nil
+}
+
+// ServeHTTP serves the request with the current handler.
+func (d *ReloadableDashboard) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	(*d.handler.Load()).ServeHTTP(w, r)
+}
@@ -0,0 +1,438 @@
+package web
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Status delta operations. A stream starts with a full snapshot (the
+// output of gt status --json); each later event carries the deltas that
+// turn the previous snapshot into the current one.
+const (
+	DeltaSet         = "set"          // Town field (Rig empty) or rig field set to Value; null removes it
+	DeltaRigAdd      = "rig_add"      // Rig appeared; Value is the whole rig
+	DeltaRigRemove   = "rig_remove"   // Rig is gone
+	DeltaAgentAdd    = "agent_add"    // Agent appeared; Value is the whole agent
+	DeltaAgentRemove = "agent_remove" // Agent is gone
+	DeltaAgent       = "agent"        // Agent fields changed; Changes maps field to new value (null removes)
+)
+
+// StatusDelta is one change between two status snapshots. Agents are keyed
+// by address within their rig; town-level agents (mayor, deacon) have an
+// empty Rig.
+type StatusDelta struct {
+	Op      string                 `json:"op"`
+	Rig     string                 `json:"rig,omitempty"`
+	Agent   string                 `json:"agent,omitempty"`
+	Field   string                 `json:"field,omitempty"`
+	Value   interface{}            `json:"value,omitempty"`
+	Changes map[string]interface{} `json:"changes,omitempty"`
+}
+
+// StatusUpdate is one batch of deltas, numbered so clients can tell
+// whether they missed one.
+type StatusUpdate struct {
+	Seq    uint64        `json:"seq"`
+	Deltas []StatusDelta `json:"deltas"`
+}
+
+// DiffStatus returns the deltas that turn prev into cur. Both are decoded
+// gt status --json documents.
+func DiffStatus(prev, cur map[string]interface{}) []StatusDelta {
+	var deltas []StatusDelta
+	deltas = append(deltas, diffFields(prev, cur, "", "agents", "rigs")...)
+	deltas = append(deltas, diffAgents("", asList(prev["agents"]), asList(cur["agents"]))...)
+
+	prevRigs := indexBy(asList(prev["rigs"]), "name")
+	curRigs := indexBy(asList(cur["rigs"]), "name")
+	for _, name := range sortedKeys(prevRigs, curRigs) {
+		p, c := prevRigs[name], curRigs[name]
+		switch {
+		case p == nil:
+			deltas = append(deltas, StatusDelta{Op: DeltaRigAdd, Rig: name, Value: c})
+		case c == nil:
+			deltas = append(deltas, StatusDelta{Op: DeltaRigRemove, Rig: name})
+		default:
+			deltas = append(deltas, diffFields(p, c, name, "agents")...)
+			deltas = append(deltas, diffAgents(name, asList(p["agents"]), asList(c["agents"]))...)
+		}
+	}
+	return deltas
+}
+
+// diffFields emits a set delta for each field of cur that differs from
+// prev, skipping the named fields (diffed separately).
+func diffFields(prev, cur map[string]interface{}, rig string, skip ...string) []StatusDelta {
+	var deltas []StatusDelta
+	for _, k := range sortedKeys(prev, cur) {
+		if slices.Contains(skip, k) {
+			continue
+		}
+		if !reflect.DeepEqual(prev[k], cur[k]) {
+			deltas = append(deltas, StatusDelta{Op: DeltaSet, Rig: rig, Field: k, Value: cur[k]})
+		}
+	}
+	return deltas
+}
+
+func diffAgents(rig string, prev, cur []interface{}) []StatusDelta {
+	var deltas []StatusDelta
+	prevAgents := indexBy(prev, "address")
+	curAgents := indexBy(cur, "address")
+	for _, addr := range sortedKeys(prevAgents, curAgents) {
+		p, c := prevAgents[addr], curAgents[addr]
+		switch {
+		case p == nil:
+			deltas = append(deltas, StatusDelta{Op: DeltaAgentAdd, Rig: rig, Agent: addr, Value: c})
+		case c == nil:
+			deltas = append(deltas, StatusDelta{Op: DeltaAgentRemove, Rig: rig, Agent: addr})
+		default:
+			changes := make(map[string]interface{})
+			for _, k := range sortedKeys(p, c) {
+				if !reflect.DeepEqual(p[k], c[k]) {
+					changes[k] = c[k]
+				}
+			}
+			if len(changes) > 0 {
+				deltas = append(deltas, StatusDelta{Op: DeltaAgent, Rig: rig, Agent: addr, Changes: changes})
+			}
+		}
+	}
+	return deltas
+}
+
+// ApplyStatusDeltas applies deltas to a decoded status document in place.
+// Deltas naming a rig or agent the document doesn't have are ignored.
+func ApplyStatusDeltas(status map[string]interface{}, deltas []StatusDelta) {
+	for _, d := range deltas {
+		switch d.Op {
+		case DeltaSet:
+			target := status
+			if d.Rig != "" {
+				if target = findByKey(asList(status["rigs"]), "name", d.Rig); target == nil {
+					continue
+				}
+			}
+			setOrDelete(target, d.Field, d.Value)
+		case DeltaRigAdd:
+			status["rigs"] = append(asList(status["rigs"]), d.Value)
+		case DeltaRigRemove:
+			status["rigs"] = removeByKey(asList(status["rigs"]), "name", d.Rig)
+		case DeltaAgentAdd, DeltaAgentRemove, DeltaAgent:
+			owner := status
+			if d.Rig != "" {
+				if owner = findByKey(asList(status["rigs"]), "name", d.Rig); owner == nil {
+					continue
+				}
+			}
+			agents := asList(owner["agents"])
+			switch d.Op {
+			case DeltaAgentAdd:
+				owner["agents"] = append(agents, d.Value)
+			case DeltaAgentRemove:
+				owner["agents"] = removeByKey(agents, "address", d.Agent)
+			default:
+				if a := findByKey(agents, "address", d.Agent); a != nil {
+					for k, v := range d.Changes {
+						setOrDelete(a, k, v)
+					}
+				}
+			}
+		}
+	}
+}
+
+func asList(v interface{}) []interface{} {
+	l, _ := v.([]interface{})
+	return l
+}
+
+func indexBy(list []interface{}, key string) map[string]map[string]interface{} {
+	m := make(map[string]map[string]interface{}, len(list))
+	for _, item := range list {
+		if obj, ok := item.(map[string]interface{}); ok {
+			if k, ok := obj[key].(string); ok {
+				m[k] = obj
+			}
+		}
+	}
+	return m
+}
+
+func findByKey(list []interface{}, key, want string) map[string]interface{} {
+	for _, item := range list {
+		if obj, ok := item.(map[string]interface{}); ok && obj[key] == want {
+			return obj
+		}
+	}
+	return nil
+}
+
+func removeByKey(list []interface{}, key, want string) []interface{} {
+	out := list[:0]
+	for _, item := range list {
+		if obj, ok := item.(map[string]interface{}); ok && obj[key] == want {
+			continue
+		}
+		out = append(out, item)
+	}
+	return out
+}
+
+func setOrDelete(obj map[string]interface{}, key string, v interface{}) {
+	if v == nil {
+		delete(obj, key)
+		return
+	}
+	obj[key] = v
+}
+
+func sortedKeys[V any](a, b map[string]V) []string {
+	seen := make(map[string]bool, len(a)+len(b))
+	var keys []string
+	for _, m := range []map[string]V{a, b} {
+		for k := range m {
+			if !seen[k] {
+				seen[k] = true
+				keys = append(keys, k)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// StatusStream polls town status once per interval on behalf of every
+// subscriber and fans out the deltas, so N dashboards cost one discovery
+// per interval instead of N. Polling runs only while someone is subscribed.
+type StatusStream struct {
+	fetch    func(ctx context.Context) (map[string]interface{}, error)
+	interval time.Duration
+
+	mu       sync.Mutex
+	subs     map[chan StatusUpdate]struct{}
+	snapshot map[string]interface{}
+	seq      uint64
+	stop     context.CancelFunc
+	ready    chan struct{} // Closed once the first snapshot is in
+}
+
+// subscriberBuffer is how many updates a subscriber may fall behind before
+// it is dropped (and must reconnect for a fresh snapshot).
+const subscriberBuffer = 16
+
+// NewStatusStream creates a stream that calls fetch every interval.
+func NewStatusStream(fetch func(ctx context.Context) (map[string]interface{}, error), interval time.Duration) *StatusStream {
+	return &StatusStream{fetch: fetch, interval: interval, subs: make(map[chan StatusUpdate]struct{})}
+}
+
+// Subscribe waits for the first snapshot and returns it (JSON) with its
+// sequence number, and a channel of updates. Updates with Seq at or below
+// the snapshot's are already reflected in it. The channel is closed if the
+// subscriber falls too far behind. Call unsubscribe when done.
+func (s *StatusStream) Subscribe(ctx context.Context) (snapshot []byte, seq uint64, updates <-chan StatusUpdate, unsubscribe func(), err error) {
+	ch := make(chan StatusUpdate, subscriberBuffer)
+	s.mu.Lock()
+	if s.stop == nil {
+		pollCtx, cancel := context.WithCancel(context.Background())
+		s.stop = cancel
+		s.ready = make(chan struct{})
+		go s.poll(pollCtx, s.ready)
+	}
+	ready := s.ready
+	s.subs[ch] = struct{}{}
+	s.mu.Unlock()
+	unsubscribe = func() { s.unsubscribe(ch) }
+
+	select {
+	case <-ready:
+	case <-ctx.Done():
+		unsubscribe()
+		return nil, 0, nil, nil, ctx.Err()
+	}
+
+	s.mu.Lock()
+	snapshot, err = json.Marshal(s.snapshot)
+	seq = s.seq
+	s.mu.Unlock()
+	if err != nil {
+		unsubscribe()
+		return nil, 0, nil, nil, err
+	}
+	return snapshot, seq, ch, unsubscribe, nil
+}
+
+func (s *StatusStream) unsubscribe(ch chan StatusUpdate) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.subs[ch]; ok {
+		delete(s.subs, ch)
+		close(ch)
+	}
+	if len(s.subs) == 0 && s.stop != nil {
+		s.stop()
+		s.stop = nil
+		s.snapshot = nil
+	}
+}
+
+// poll fetches status until ctx is cancelled, publishing deltas. ready is
+// closed after the first successful fetch.
+func (s *StatusStream) poll(ctx context.Context, ready chan struct{}) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	first := true
+	for {
+		if cur, err := s.fetch(ctx); err == nil && cur != nil && s.publish(ctx, cur) {
+			if first {
+				close(ready)
+				first = false
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// publish records cur as the latest snapshot and sends subscribers the
+// deltas from the previous one. It reports false if polling was stopped
+// while cur was being fetched.
+func (s *StatusStream) publish(ctx context.Context, cur map[string]interface{}) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if ctx.Err() != nil {
+		return false
+	}
+	if s.snapshot == nil {
+		s.snapshot = cur
+		return true
+	}
+	deltas := DiffStatus(s.snapshot, cur)
+	s.snapshot = cur
+	if len(deltas) == 0 {
+		return true
+	}
+	s.seq++
+	update := StatusUpdate{Seq: s.seq, Deltas: deltas}
+	for ch := range s.subs {
+		select {
+		case ch <- update:
+		default:
+			delete(s.subs, ch) // Too slow: drop so it reconnects for a fresh snapshot
+			close(ch)
+		}
+	}
+	return true
+}
+
+// fetchStatus runs gt status --json for the status stream.
+func (h *APIHandler) fetchStatus(ctx context.Context) (map[string]interface{}, error) {
+	out, err := h.runGtCommand(ctx, 30*time.Second, []string{"status", "--json"})
+	if err != nil {
+		return nil, err
+	}
+	// runGtCommand appends stderr after stdout; the JSON document comes first.
+	var status map[string]interface{}
+	if err := json.NewDecoder(strings.NewReader(out)).Decode(&status); err != nil {
+		return nil, fmt.Errorf("parsing status: %w", err)
+	}
+	return status, nil
+}
+
+// handleStatusStream streams town status as Server-Sent Events: a
+// "snapshot" event with the full gt status --json document, then a "delta"
+// event (a StatusUpdate) whenever agents, hooks, or other fields change.
+// Event IDs are sequence numbers; a gap means updates were missed and the
+// client should reconnect for a fresh snapshot.
+func (h *APIHandler) handleStatusStream(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "SSE not supported", http.StatusInternalServerError)
+		return
+	}
+
+	ctx := r.Context()
+	snapshot, seq, updates, unsubscribe, err := h.statusStream.Subscribe(ctx)
+	if err != nil {
+		return // Client went away before the first snapshot
+	}
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+
+	fmt.Fprintf(w, "event: snapshot\nid: %d\ndata: %s\n\n", seq, snapshot)
+	flusher.Flush()
+
+	keepalive := time.NewTicker(15 * time.Second)
+	defer keepalive.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-keepalive.C:
+			fmt.Fprintf(w, ": keepalive\n\n")
+			flusher.Flush()
+		case update, ok := <-updates:
+			if !ok {
+				return // Dropped for falling behind; client reconnects
+			}
+			if update.Seq <= seq {
+				continue // Already in the snapshot
+			}
+			data, err := json.Marshal(update)
+			if err != nil {
+				continue
+			}
+			fmt.Fprintf(w, "event: delta\nid: %d\ndata: %s\n\n", update.Seq, data)
+			flusher.Flush()
+		}
+	}
+}
+
+// ReadSSE reads Server-Sent Events from r, calling fn with each event's
+// name and data until r ends or fn returns an error. Comment lines
+// (keepalives) are skipped.
+func ReadSSE(r io.Reader, fn func(event, data string) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	var event string
+	var data []string
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			if len(data) > 0 {
+				if event == "" {
+					event = "message"
+				}
+				if err := fn(event, strings.Join(data, "\n")); err != nil {
+					return err
+				}
+			}
+			event, data = "", nil
+		case strings.HasPrefix(line, ":"):
+		case strings.HasPrefix(line, "event:"):
+			event = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			data = append(data, strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+	}
+	return scanner.Err()
+}
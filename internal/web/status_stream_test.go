@@ -0,0 +1,147 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func decodeStatus(t *testing.T, s string) map[string]interface{} {
+	t.Helper()
+	var m map[string]interface{}
+	if err := json.Unmarshal([]byte(s), &m); err != nil {
+		t.Fatalf("decoding %s: %v", s, err)
+	}
+	return m
+}
+
+const statusBefore = `{
+  "name": "town", "daemon": {"running": true},
+  "agents": [{"address": "mayor/", "state": "idle"}],
+  "rigs": [
+    {"name": "gastown", "polecat_count": 1,
+     "agents": [{"address": "gastown/polecats/nux", "state": "working", "hook_bead": "gt-1"}]},
+    {"name": "beads", "agents": []}
+  ]
+}`
+
+const statusAfter = `{
+  "name": "town",
+  "agents": [{"address": "mayor/", "state": "working"}],
+  "rigs": [
+    {"name": "gastown", "polecat_count": 2,
+     "agents": [
+       {"address": "gastown/polecats/nux", "state": "working"},
+       {"address": "gastown/polecats/ace", "state": "spawning"}]},
+    {"name": "wyvern", "agents": []}
+  ]
+}`
+
+func TestDiffStatus(t *testing.T) {
+	deltas := DiffStatus(decodeStatus(t, statusBefore), decodeStatus(t, statusAfter))
+
+	ops := make(map[string]int)
+	for _, d := range deltas {
+		ops[d.Op]++
+		if d.Op == DeltaAgent && d.Agent == "gastown/polecats/nux" {
+			if v, ok := d.Changes["hook_bead"]; !ok || v != nil {
+				t.Errorf("nux changes = %v, want hook_bead removed", d.Changes)
+			}
+		}
+	}
+	want := map[string]int{DeltaSet: 2, DeltaAgent: 2, DeltaAgentAdd: 1, DeltaRigAdd: 1, DeltaRigRemove: 1}
+	if !reflect.DeepEqual(ops, want) {
+		t.Errorf("ops = %v, want %v\ndeltas: %+v", ops, want, deltas)
+	}
+
+	if d := DiffStatus(decodeStatus(t, statusBefore), decodeStatus(t, statusBefore)); len(d) != 0 {
+		t.Errorf("identical snapshots produced deltas: %+v", d)
+	}
+}
+
+func TestApplyStatusDeltas_RoundTrip(t *testing.T) {
+	// Deltas go over the wire as JSON, so round-trip them the same way.
+	raw, err := json.Marshal(DiffStatus(decodeStatus(t, statusBefore), decodeStatus(t, statusAfter)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var deltas []StatusDelta
+	if err := json.Unmarshal(raw, &deltas); err != nil {
+		t.Fatal(err)
+	}
+
+	got := decodeStatus(t, statusBefore)
+	ApplyStatusDeltas(got, deltas)
+	if d := DiffStatus(got, decodeStatus(t, statusAfter)); len(d) != 0 {
+		t.Errorf("applied status differs from target: %+v", d)
+	}
+}
+
+func TestReadSSE(t *testing.T) {
+	in := ": keepalive\n\nevent: snapshot\nid: 3\ndata: {\"a\":1}\n\ndata: line one\ndata: line two\n\n"
+	type ev struct{ event, data string }
+	var got []ev
+	err := ReadSSE(strings.NewReader(in), func(event, data string) error {
+		got = append(got, ev{event, data})
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []ev{{"snapshot", `{"a":1}`}, {"message", "line one\nline two"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("events = %v, want %v", got, want)
+	}
+}
+
+func TestStatusStream(t *testing.T) {
+	var mu sync.Mutex
+	polls := 0
+	states := []string{"idle", "idle", "working"}
+	fetch := func(context.Context) (map[string]interface{}, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		state := states[min(polls, len(states)-1)]
+		polls++
+		return map[string]interface{}{
+			"name":   "town",
+			"agents": []interface{}{map[string]interface{}{"address": "mayor/", "state": state}},
+		}, nil
+	}
+	s := NewStatusStream(fetch, 10*time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	snapshot, seq, updates, unsubscribe, err := s.Subscribe(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(snapshot), `"idle"`) || seq != 0 {
+		t.Errorf("snapshot = %s seq %d, want idle mayor at seq 0", snapshot, seq)
+	}
+
+	select {
+	case u := <-updates:
+		want := []StatusDelta{{Op: DeltaAgent, Agent: "mayor/", Changes: map[string]interface{}{"state": "working"}}}
+		if u.Seq != 1 || !reflect.DeepEqual(u.Deltas, want) {
+			t.Errorf("update = %+v, want seq 1 %+v", u, want)
+		}
+	case <-ctx.Done():
+		t.Fatal("no update before timeout")
+	}
+
+	unsubscribe()
+	if _, open := <-updates; open {
+		t.Error("updates channel still open after unsubscribe")
+	}
+	s.mu.Lock()
+	stopped := s.stop == nil
+	s.mu.Unlock()
+	if !stopped {
+		t.Error("poller still running with no subscribers")
+	}
+}
@@ -0,0 +1,400 @@
+package witness
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/redact"
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// Pane anomaly kinds, reported as the "kind" of a pane_anomaly event.
+const (
+	AnomalyLooping          = "looping"           // The same output block repeating
+	AnomalyStackTrace       = "stack_trace"       // A long stack trace appeared
+	AnomalyPermissionPrompt = "permission_prompt" // A prompt has sat waiting for input
+	AnomalyRateLimit        = "rate_limit"        // A rate-limit message appeared
+)
+
+const (
+	// anomalyCaptureLines is how much of each pane is captured and compared.
+	anomalyCaptureLines = 200
+
+	// anomalyCooldown is how long a kind of anomaly stays quiet for a
+	// session after it is reported.
+	anomalyCooldown = 30 * time.Minute
+
+	// loopMinRepeats is how many back-to-back copies of an output block
+	// count as looping, and loopMaxPeriod the longest block looked for.
+	loopMinRepeats = 3
+	loopMaxPeriod  = 20
+
+	// stackTraceMinFrames is how many frame lines make a stack trace long
+	// enough to report.
+	stackTraceMinFrames = 8
+
+	// promptTailLines is how far from the bottom of the pane a permission
+	// prompt is looked for.
+	promptTailLines = 15
+)
+
+// Anomaly is one problem found in an agent's pane output.
+type Anomaly struct {
+	Kind   string `json:"kind"`
+	Detail string `json:"detail"` // The line(s) that matched, shortened
+}
+
+var (
+	stackHeaderRe = regexp.MustCompile(`^(panic: |goroutine \d+ \[|Traceback \(most recent call last\):|Exception in thread |stack backtrace:|\S*(Error|Exception)\b.*:)`)
+	stackFrameRe  = regexp.MustCompile(`^(\s+at \S|\s+File ".*", line \d+|\s+\S+\.go:\d+ \+0x|\s+\d+: \S)`)
+	rateLimitRes  = compileInsensitive(constants.DefaultRateLimitPatterns)
+	promptRes     = compileInsensitive([]string{
+		`Do you want to (proceed|make this edit|create|allow)`,
+		`No, and tell .* what to do differently`,
+		`\((y/n|yes/no)\)\s*\??\s*$`,
+		`\[(y/N|Y/n)\]\s*\??\s*$`,
+		`Press Enter to continue`,
+	})
+)
+
+func compileInsensitive(patterns []string) []*regexp.Regexp {
+	res := make([]*regexp.Regexp, 0, len(patterns))
+	for _, p := range patterns {
+		res = append(res, regexp.MustCompile(`(?i)`+p))
+	}
+	return res
+}
+
+// AnalyzePane compares an agent's pane capture with the previous one and
+// returns the anomalies found. Looping, stack traces and rate limits are
+// looked for in output that is new since prev; a permission prompt is
+// reported only when it is on screen and nothing has changed since prev,
+// meaning it has waited a full scan interval. A nil prev (first scan)
+// treats the whole capture as new.
+func AnalyzePane(prev, cur []string) []Anomaly {
+	added := addedLineIndexes(prev, cur)
+	var found []Anomaly
+	if len(added) == 0 {
+		if prev != nil {
+			if a, ok := detectWaitingPrompt(cur); ok {
+				found = append(found, a)
+			}
+		}
+		return found
+	}
+	if a, ok := detectLoop(cur, added); ok {
+		found = append(found, a)
+	}
+	newLines := make([]string, 0, len(added))
+	for i := range cur {
+		if added[i] {
+			newLines = append(newLines, cur[i])
+		}
+	}
+	if a, ok := detectStackTrace(newLines); ok {
+		found = append(found, a)
+	}
+	if a, ok := detectRateLimit(newLines); ok {
+		found = append(found, a)
+	}
+	return found
+}
+
+// addedLineIndexes returns the indexes of lines in cur that the diff from
+// prev inserts.
+func addedLineIndexes(prev, cur []string) map[int]bool {
+	added := make(map[int]bool)
+	edits, _ := util.DiffLines(prev, cur)
+	i := 0
+	for _, e := range edits {
+		switch e.Op {
+		case util.EditKeep:
+			i += e.Count
+		case util.EditInsert:
+			for range e.Lines {
+				added[i] = true
+				i++
+			}
+		}
+	}
+	return added
+}
+
+// detectLoop looks for a block of output repeated back to back at least
+// loopMinRepeats times, ending in new output. Blank lines are ignored, and
+// blocks without a letter (borders, spinners) don't count.
+func detectLoop(cur []string, added map[int]bool) (Anomaly, bool) {
+	var lines []string
+	var isNew []bool
+	for i, line := range cur {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+			isNew = append(isNew, added[i])
+		}
+	}
+	for period := 1; period <= loopMaxPeriod; period++ {
+		for start := 0; start+period*loopMinRepeats <= len(lines); start++ {
+			block := lines[start : start+period]
+			if !hasLetter(block) {
+				continue
+			}
+			reps := 1
+			for next := start + period; next+period <= len(lines) && slices.Equal(lines[next:next+period], block); next += period {
+				reps++
+			}
+			end := start + reps*period
+			if reps >= loopMinRepeats && isNew[end-1] {
+				return Anomaly{
+					Kind:   AnomalyLooping,
+					Detail: fmt.Sprintf("%d-line block repeated %d times: %s", period, reps, shorten(block[0])),
+				}, true
+			}
+			start = end - period // Skip past this run
+		}
+	}
+	return Anomaly{}, false
+}
+
+// detectStackTrace reports a stack trace with at least stackTraceMinFrames
+// frames in lines.
+func detectStackTrace(lines []string) (Anomaly, bool) {
+	header := ""
+	frames := 0
+	for _, line := range lines {
+		if header == "" && stackHeaderRe.MatchString(strings.TrimSpace(line)) {
+			header = strings.TrimSpace(line)
+		}
+		if stackFrameRe.MatchString(line) {
+			frames++
+		}
+	}
+	if frames < stackTraceMinFrames {
+		return Anomaly{}, false
+	}
+	if header == "" {
+		header = "stack trace"
+	}
+	return Anomaly{Kind: AnomalyStackTrace, Detail: fmt.Sprintf("%s (%d frames)", shorten(header), frames)}, true
+}
+
+// detectRateLimit reports the first line matching a rate-limit pattern.
+func detectRateLimit(lines []string) (Anomaly, bool) {
+	for _, line := range lines {
+		for _, re := range rateLimitRes {
+			if re.MatchString(line) {
+				return Anomaly{Kind: AnomalyRateLimit, Detail: shorten(strings.TrimSpace(line))}, true
+			}
+		}
+	}
+	return Anomaly{}, false
+}
+
+// detectWaitingPrompt reports a permission or confirmation prompt near the
+// bottom of the pane.
+func detectWaitingPrompt(cur []string) (Anomaly, bool) {
+	tail := cur[max(0, len(cur)-promptTailLines):]
+	for _, line := range tail {
+		for _, re := range promptRes {
+			if re.MatchString(line) {
+				return Anomaly{Kind: AnomalyPermissionPrompt, Detail: shorten(strings.Trim(line, " │"))}, true
+			}
+		}
+	}
+	return Anomaly{}, false
+}
+
+func hasLetter(lines []string) bool {
+	for _, line := range lines {
+		if strings.IndexFunc(line, unicode.IsLetter) >= 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// shorten trims a line for an event detail.
+func shorten(s string) string {
+	const maxLen = 120
+	if r := []rune(s); len(r) > maxLen {
+		return string(r[:maxLen-1]) + "…"
+	}
+	return s
+}
+
+// AgentAnomaly is an anomaly found in one agent's pane.
+type AgentAnomaly struct {
+	Anomaly
+	Agent   string // Mail address, e.g. gastown/polecats/nux
+	Session string
+	Skipped string // Why no event was logged (cooldown, dry run); empty if it was
+	Action  string // What was done about a permission prompt, per rig policy
+}
+
+// AnomalyResult holds the outcome of one anomaly scan of a rig.
+type AnomalyResult struct {
+	Checked int // Running sessions captured
+	Found   []AgentAnomaly
+	Errors  []error
+}
+
+// paneCapturer is the tmux surface the anomaly scan needs.
+type paneCapturer interface {
+	promptAnswerer
+	HasSession(name string) (bool, error)
+	CapturePaneLines(session string, lines int) ([]string, error)
+}
+
+// paneState is what the scan remembers about a session between runs.
+type paneState struct {
+	Lines    []string             `json:"lines"`
+	Reported map[string]time.Time `json:"reported,omitempty"` // Kind -> last event
+}
+
+// DetectAnomalies captures the pane of each running polecat and crew
+// session in the rig, analyzes it against the capture from the previous
+// scan, and logs a pane_anomaly event for each anomaly found, at most once
+// per kind per session every 30 minutes. Captures are redacted before they
+// are stored.
+//
+// A permission prompt an agent has waited on is answered per the rig's
+// witness.permissions policy: yes or no at once, or mailed to the mayor
+// (with the pane_anomaly event, under the same cooldown) when the policy
+// doesn't cover the tool.
+//
+// With dryRun, anomalies are reported but nothing is answered, no event is
+// logged and no state is saved.
+func DetectAnomalies(townRoot, rigName string, dryRun bool) *AnomalyResult {
+	var policy *config.WitnessPermissionPolicy
+	settings, err := config.LoadRigSettings(config.RigSettingsPath(filepath.Join(townRoot, rigName)))
+	if err == nil && settings.Witness != nil {
+		policy = settings.Witness.Permissions
+	}
+	return detectAnomalies(tmux.NewTmux(), townRoot, rigName, policy, dryRun, time.Now())
+}
+
+// escalatePromptFn mails the mayor an uncovered prompt; tests replace it.
+var escalatePromptFn = escalatePrompt
+
+func detectAnomalies(t paneCapturer, townRoot, rigName string, policy *config.WitnessPermissionPolicy, dryRun bool, now time.Time) *AnomalyResult {
+	result := &AnomalyResult{}
+	initRegistryFromTownRoot(townRoot)
+	sessPrefix := session.PrefixFor(rigName)
+	r := redact.ForTown(townRoot)
+
+	for _, role := range []string{"polecat", "crew"} {
+		dir := filepath.Join(townRoot, rigName, "polecats")
+		if role == "crew" {
+			dir = filepath.Join(townRoot, rigName, "crew")
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+				continue
+			}
+			f := AgentFacts{Role: role, Name: entry.Name()}
+			if role == "crew" {
+				f.Session = session.CrewSessionName(sessPrefix, f.Name)
+			} else {
+				f.Session = session.PolecatSessionName(sessPrefix, f.Name)
+			}
+			if alive, err := t.HasSession(f.Session); err != nil || !alive {
+				continue
+			}
+			lines, err := t.CapturePaneLines(f.Session, anomalyCaptureLines)
+			if err != nil {
+				result.Errors = append(result.Errors, fmt.Errorf("capturing %s: %w", f.Session, err))
+				continue
+			}
+			result.Checked++
+			lines = strings.Split(r.String(strings.Join(lines, "\n")), "\n")
+
+			state := loadPaneState(townRoot, f.Session)
+			for _, a := range AnalyzePane(state.Lines, lines) {
+				found := AgentAnomaly{Anomaly: a, Agent: f.Address(rigName), Session: f.Session}
+				var prompt PermissionPrompt
+				escalate := false
+				if a.Kind == AnomalyPermissionPrompt && policy != nil {
+					if p, ok := ParsePermissionPrompt(lines); ok {
+						if p.Tool != "" {
+							found.Detail = p.Tool + ": " + found.Detail
+						}
+						switch decidePrompt(policy, p) {
+						case config.PermissionAllow, config.PermissionDeny:
+							if err := answerPermissionPrompt(t, rigName, &found, p, policy, dryRun); err != nil {
+								result.Errors = append(result.Errors, err)
+							}
+							result.Found = append(result.Found, found)
+							continue
+						default:
+							prompt, escalate = p, true
+						}
+					}
+				}
+				switch {
+				case now.Sub(state.Reported[a.Kind]) < anomalyCooldown:
+					found.Skipped = "cooldown"
+				case dryRun:
+					found.Skipped = "dry run"
+				default:
+					state.Reported[a.Kind] = now
+					_ = events.LogFeed(events.TypePaneAnomaly, found.Agent,
+						events.PaneAnomalyPayload(rigName, found.Agent, f.Session, a.Kind, a.Detail))
+					if escalate {
+						if err := escalatePromptFn(townRoot, rigName, found.Agent, f.Session, prompt); err != nil {
+							result.Errors = append(result.Errors, fmt.Errorf("escalating %s prompt: %w", found.Agent, err))
+						} else {
+							found.Action = "escalated to mayor"
+						}
+					}
+				}
+				result.Found = append(result.Found, found)
+			}
+			if !dryRun {
+				state.Lines = lines
+				if err := savePaneState(townRoot, f.Session, state); err != nil {
+					result.Errors = append(result.Errors, fmt.Errorf("saving pane state for %s: %w", f.Session, err))
+				}
+			}
+		}
+	}
+	return result
+}
+
+// paneStatePath returns <townRoot>/.runtime/witness_anomalies/<session>.json.
+func paneStatePath(townRoot, sess string) string {
+	return filepath.Join(townRoot, constants.DirRuntime, "witness_anomalies", sess+".json")
+}
+
+func loadPaneState(townRoot, sess string) paneState {
+	var s paneState
+	if data, err := os.ReadFile(paneStatePath(townRoot, sess)); err == nil {
+		_ = json.Unmarshal(data, &s)
+	}
+	if s.Reported == nil {
+		s.Reported = make(map[string]time.Time)
+	}
+	return s
+}
+
+func savePaneState(townRoot, sess string, s paneState) error {
+	path := paneStatePath(townRoot, sess)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return util.AtomicWriteJSON(path, s)
+}
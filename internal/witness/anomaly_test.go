@@ -0,0 +1,159 @@
+package witness
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/session"
+)
+
+func paneLines(s string) []string { return strings.Split(s, "\n") }
+
+func kinds(found []Anomaly) string {
+	var out []string
+	for _, a := range found {
+		out = append(out, a.Kind)
+	}
+	return strings.Join(out, ",")
+}
+
+func TestAnalyzePane(t *testing.T) {
+	goTrace := "panic: runtime error: index out of range\n\ngoroutine 1 [running]:\n"
+	for i := 0; i < 9; i++ {
+		goTrace += fmt.Sprintf("main.f%d()\n\t/src/main.go:%d +0x1d\n", i, 10+i)
+	}
+	pyTrace := "Traceback (most recent call last):\n"
+	for i := 0; i < 8; i++ {
+		pyTrace += fmt.Sprintf("  File \"app.py\", line %d, in f%d\n    f%d()\n", i+1, i, i+1)
+	}
+	compileErrs := ""
+	for i := 0; i < 10; i++ {
+		compileErrs += fmt.Sprintf("./main.go:%d:2: undefined: x%d\n", i+1, i)
+	}
+	prompt := "⏺ Bash(rm -rf build)\n│ Do you want to proceed?\n│ ❯ 1. Yes\n│   2. No, and tell Claude what to do differently (esc)"
+	loop := "⏺ Running tests\nFAIL TestFoo\n⏺ Let me fix that\n"
+
+	tests := []struct {
+		name      string
+		prev, cur string
+		want      string
+	}{
+		{"quiet progress", "$ make\nbuilding", "$ make\nbuilding\nok  pkg 0.1s", ""},
+		{"go panic", "$ go test", "$ go test\n" + goTrace, AnomalyStackTrace},
+		{"python traceback", "", pyTrace, AnomalyStackTrace},
+		{"compile errors are not a trace", "", compileErrs, ""},
+		{"short trace", "", "Error: boom\n    at a (x.js:1)\n    at b (x.js:2)", ""},
+		{"rate limit", "working", "working\nAPI Error: Rate limit reached", AnomalyRateLimit},
+		{"looping", "start\n" + loop, "start\n" + loop + loop + loop, AnomalyLooping},
+		{"old loop, new tail", "start\n" + loop + loop + loop, "start\n" + loop + loop + loop + "done", ""},
+		{"border lines are not a loop", "x", "x\n─────\n─────\n─────\n─────", ""},
+		{"prompt still waiting", prompt, prompt, AnomalyPermissionPrompt},
+		{"prompt just appeared", "⏺ Bash(rm -rf build)", prompt, ""},
+		{"unchanged, no prompt", "idle\n❯ ", "idle\n❯ ", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var prev []string
+			if tt.prev != "" {
+				prev = paneLines(tt.prev)
+			}
+			if got := kinds(AnalyzePane(prev, paneLines(tt.cur))); got != tt.want {
+				t.Errorf("AnalyzePane = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	// A first scan has nothing to compare with, so a prompt isn't yet known
+	// to be waiting.
+	if got := AnalyzePane(nil, paneLines(prompt)); len(got) != 0 {
+		t.Errorf("first scan flagged %+v", got)
+	}
+}
+
+type fakePanes struct {
+	panes map[string]string
+	keys  []string
+}
+
+func (f *fakePanes) HasSession(name string) (bool, error) {
+	_, ok := f.panes[name]
+	return ok, nil
+}
+
+func (f *fakePanes) CapturePaneLines(sess string, lines int) ([]string, error) {
+	return paneLines(f.panes[sess]), nil
+}
+
+func (f *fakePanes) FindAgentPane(sess string) (string, error) { return "", nil }
+
+func (f *fakePanes) SendKeysRaw(sess, keys string) error {
+	f.keys = append(f.keys, sess+":"+keys)
+	return nil
+}
+
+func TestDetectAnomalies(t *testing.T) {
+	townRoot := t.TempDir()
+	for _, dir := range []string{"mayor", "gastown/polecats/nux", "gastown/polecats/furiosa", "gastown/crew/max"} {
+		if err := os.MkdirAll(filepath.Join(townRoot, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Events are logged to the town found from the working directory.
+	if err := os.WriteFile(filepath.Join(townRoot, "mayor", "town.json"), []byte(`{"name":"test"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(townRoot)
+	initRegistryFromTownRoot(townRoot)
+	prefix := session.PrefixFor("gastown")
+	nux := session.PolecatSessionName(prefix, "nux")
+	crewMax := session.CrewSessionName(prefix, "max")
+	c := &fakePanes{panes: map[string]string{
+		nux:     "working",
+		crewMax: "idle",
+		// furiosa has no session.
+	}}
+
+	loggedAnomalies := func() []events.Event {
+		evs, _, _ := events.ReadFrom(filepath.Join(townRoot, events.EventsFile), 0, 100)
+		f := events.Filter{Types: []string{events.TypePaneAnomaly}}
+		var out []events.Event
+		for _, e := range evs {
+			if f.Match(e) {
+				out = append(out, e)
+			}
+		}
+		return out
+	}
+
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	if res := detectAnomalies(c, townRoot, "gastown", nil, false, now); res.Checked != 2 || len(res.Found) != 0 {
+		t.Fatalf("first scan = %+v, want 2 checked, nothing found", res)
+	}
+
+	c.panes[nux] = "working\nAPI Error: Rate limit reached"
+	// A dry run reports without logging or moving the baseline.
+	res := detectAnomalies(c, townRoot, "gastown", nil, true, now.Add(time.Minute))
+	if len(res.Found) != 1 || res.Found[0].Skipped != "dry run" {
+		t.Fatalf("dry run = %+v", res.Found)
+	}
+	res = detectAnomalies(c, townRoot, "gastown", nil, false, now.Add(2*time.Minute))
+	if len(res.Found) != 1 || res.Found[0].Kind != AnomalyRateLimit || res.Found[0].Agent != "gastown/polecats/nux" || res.Found[0].Skipped != "" {
+		t.Fatalf("scan = %+v, want nux rate_limit", res.Found)
+	}
+	logged := loggedAnomalies()
+	if len(logged) != 1 || logged[0].Payload["kind"] != AnomalyRateLimit || logged[0].Payload["session"] != nux {
+		t.Fatalf("logged %+v, want one rate_limit event for %s", logged, nux)
+	}
+
+	// The same kind again within the cooldown is reported but not logged.
+	c.panes[nux] += "\nAPI Error: Rate limit reached"
+	res = detectAnomalies(c, townRoot, "gastown", nil, false, now.Add(5*time.Minute))
+	if len(res.Found) != 1 || res.Found[0].Skipped != "cooldown" || len(loggedAnomalies()) != 1 {
+		t.Errorf("within cooldown = %+v, %d logged", res.Found, len(loggedAnomalies()))
+	}
+}
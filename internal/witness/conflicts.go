@@ -0,0 +1,343 @@
+package witness
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// collisionRewarnAfter is how long a warned collision stays quiet while its
+// conflicting files are unchanged.
+const collisionRewarnAfter = 24 * time.Hour
+
+// AgentBranch is an agent's in-flight branch and the files it touches.
+type AgentBranch struct {
+	Role   string   `json:"role"` // "polecat" or "crew"
+	Name   string   `json:"name"`
+	Path   string   `json:"path"` // Worktree or clone
+	Branch string   `json:"branch"`
+	Files  []string `json:"files"` // Changed since the branch left the target
+}
+
+// Address returns the agent's mail address (rig/polecats/name or rig/crew/name).
+func (b AgentBranch) Address(rigName string) string {
+	if b.Role == "crew" {
+		return rigName + "/crew/" + b.Name
+	}
+	return rigName + "/polecats/" + b.Name
+}
+
+// Collision is a pair of branches that touch the same files, and whether a
+// trial merge of the two conflicts.
+type Collision struct {
+	A         AgentBranch `json:"a"`
+	B         AgentBranch `json:"b"`
+	Overlap   []string    `json:"overlap"`
+	Conflicts []string    `json:"conflicts,omitempty"`
+	Warned    bool        `json:"warned,omitempty"`
+	Skipped   string      `json:"skipped,omitempty"` // Why no mail was sent: "already warned", "dry run"
+	Error     error       `json:"-"`
+}
+
+// CollisionsResult is the outcome of a merge-watch pass over a rig.
+type CollisionsResult struct {
+	Target     string      `json:"target"`
+	Branches   int         `json:"branches"`
+	Collisions []Collision `json:"collisions"`
+	Errors     []error     `json:"-"`
+}
+
+// OverlappingPairs pairs up branches that change at least one file in
+// common. Only these can conflict, so only these get a trial merge.
+func OverlappingPairs(branches []AgentBranch) []Collision {
+	var pairs []Collision
+	for i := 0; i < len(branches); i++ {
+		files := make(map[string]bool, len(branches[i].Files))
+		for _, f := range branches[i].Files {
+			files[f] = true
+		}
+		for j := i + 1; j < len(branches); j++ {
+			var overlap []string
+			for _, f := range branches[j].Files {
+				if files[f] {
+					overlap = append(overlap, f)
+				}
+			}
+			if len(overlap) > 0 {
+				sort.Strings(overlap)
+				pairs = append(pairs, Collision{A: branches[i], B: branches[j], Overlap: overlap})
+			}
+		}
+	}
+	return pairs
+}
+
+// CheckCollisions finds the rig's in-flight agent branches that touch the
+// same files, trial-merges each such pair in a scratch worktree, and mails
+// both owners when the merge conflicts — before either reaches the merge
+// queue. A collision is mailed once, and again only if its conflicting files
+// change or a day passes. With dryRun, conflicts are reported but no mail is
+// sent.
+func CheckCollisions(townRoot, rigName string, dryRun bool) (*CollisionsResult, error) {
+	rigPath := filepath.Join(townRoot, rigName)
+	target := "main"
+	if rigCfg, err := rig.LoadRigConfig(rigPath); err == nil && rigCfg.DefaultBranch != "" {
+		target = rigCfg.DefaultBranch
+	}
+	result := &CollisionsResult{Target: target}
+
+	branches := gatherAgentBranches(rigPath, target, result)
+	result.Branches = len(branches)
+	pairs := OverlappingPairs(branches)
+	if len(pairs) == 0 {
+		return result, nil
+	}
+
+	repo, err := rigRepoBase(rigPath)
+	if err != nil {
+		return nil, err
+	}
+	scratch, cleanup, err := newScratchWorktree(repo, target)
+	if err != nil {
+		return nil, fmt.Errorf("creating scratch worktree: %w", err)
+	}
+	defer cleanup()
+
+	warnings := loadCollisionWarnings(townRoot)
+	now := time.Now()
+	warned := false
+	for _, c := range pairs {
+		c.Conflicts, c.Error = trialMerge(scratch, c.A, c.B)
+		if c.Error != nil || len(c.Conflicts) == 0 {
+			result.Collisions = append(result.Collisions, c)
+			continue
+		}
+		key := collisionKey(rigName, c)
+		files := strings.Join(c.Conflicts, ",")
+		switch prev, ok := warnings[key]; {
+		case ok && prev.Files == files && now.Sub(prev.At) < collisionRewarnAfter:
+			c.Skipped = "already warned"
+		case dryRun:
+			c.Skipped = "dry run"
+		default:
+			c.Error = mailCollision(townRoot, rigName, target, c)
+			if c.Error == nil {
+				c.Warned = true
+				warnings[key] = collisionWarning{Files: files, At: now}
+				warned = true
+			}
+		}
+		result.Collisions = append(result.Collisions, c)
+	}
+	if warned {
+		if err := saveCollisionWarnings(townRoot, warnings); err != nil {
+			result.Errors = append(result.Errors, fmt.Errorf("saving collision warnings: %w", err))
+		}
+	}
+	return result, nil
+}
+
+// gatherAgentBranches lists polecat and crew branches with commits not yet
+// on target, along with the files each changes.
+func gatherAgentBranches(rigPath, target string, result *CollisionsResult) []AgentBranch {
+	rigName := filepath.Base(rigPath)
+	var branches []AgentBranch
+	for _, role := range []string{"polecat", "crew"} {
+		dir := filepath.Join(rigPath, "polecats")
+		if role == "crew" {
+			dir = filepath.Join(rigPath, "crew")
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+				continue
+			}
+			path := filepath.Join(dir, entry.Name())
+			if role == "polecat" {
+				// New structure: polecats/<name>/<rigname>/
+				if _, err := os.Stat(filepath.Join(path, rigName)); err == nil {
+					path = filepath.Join(path, rigName)
+				}
+			}
+			if _, err := os.Stat(filepath.Join(path, ".git")); err != nil {
+				continue
+			}
+
+			g := git.NewGit(path)
+			branch, err := g.CurrentBranch()
+			if err != nil || branch == "" || branch == "HEAD" || branch == target {
+				continue
+			}
+			base := target
+			if ok, _ := g.RefExists("origin/" + target); ok {
+				base = "origin/" + target
+			}
+			files, err := g.ChangedFiles(base, "HEAD")
+			if err != nil {
+				result.Errors = append(result.Errors, fmt.Errorf("diffing %s: %w", path, err))
+				continue
+			}
+			if len(files) == 0 {
+				continue
+			}
+			branches = append(branches, AgentBranch{
+				Role:   role,
+				Name:   entry.Name(),
+				Path:   path,
+				Branch: branch,
+				Files:  files,
+			})
+		}
+	}
+	return branches
+}
+
+// rigRepoBase returns the repository scratch worktrees are created from:
+// the shared bare repo (.repo.git), or mayor/rig on older rigs.
+func rigRepoBase(rigPath string) (*git.Git, error) {
+	bareRepoPath := filepath.Join(rigPath, ".repo.git")
+	if info, err := os.Stat(bareRepoPath); err == nil && info.IsDir() {
+		return git.NewGitWithDir(bareRepoPath, ""), nil
+	}
+	mayorPath := filepath.Join(rigPath, "mayor", "rig")
+	if _, err := os.Stat(mayorPath); err != nil {
+		return nil, fmt.Errorf("no repo base found (neither .repo.git nor mayor/rig exists)")
+	}
+	return git.NewGit(mayorPath), nil
+}
+
+// newScratchWorktree adds a detached worktree at ref in a temp directory.
+// The returned cleanup removes it.
+func newScratchWorktree(repo *git.Git, ref string) (*git.Git, func(), error) {
+	tmp, err := os.MkdirTemp("", "gt-merge-watch-")
+	if err != nil {
+		return nil, nil, err
+	}
+	path := filepath.Join(tmp, "scratch")
+	if err := repo.WorktreeAddDetached(path, ref); err != nil {
+		_ = os.RemoveAll(tmp)
+		return nil, nil, err
+	}
+	cleanup := func() {
+		_ = repo.WorktreeRemove(path, true)
+		_ = os.RemoveAll(tmp)
+		_ = repo.WorktreePrune()
+	}
+	return git.NewGit(path), cleanup, nil
+}
+
+// trialMerge fetches both branches from their owners' worktrees into the
+// scratch worktree and merges b into a there, returning the conflicting
+// files. Nothing is committed and neither agent's worktree is touched.
+func trialMerge(scratch *git.Git, a, b AgentBranch) ([]string, error) {
+	if err := scratch.FetchBranch(a.Path, a.Branch); err != nil {
+		return nil, fmt.Errorf("fetching %s: %w", a.Branch, err)
+	}
+	headA, err := scratch.Rev("FETCH_HEAD")
+	if err != nil {
+		return nil, err
+	}
+	if err := scratch.FetchBranch(b.Path, b.Branch); err != nil {
+		return nil, fmt.Errorf("fetching %s: %w", b.Branch, err)
+	}
+	headB, err := scratch.Rev("FETCH_HEAD")
+	if err != nil {
+		return nil, err
+	}
+	return scratch.CheckConflicts(headB, headA)
+}
+
+// mailCollision warns both owners that their branches conflict.
+func mailCollision(townRoot, rigName, target string, c Collision) error {
+	router := mail.NewRouter(townRoot)
+	defer router.WaitPendingNotifications()
+	for _, pair := range [][2]AgentBranch{{c.A, c.B}, {c.B, c.A}} {
+		self, other := pair[0], pair[1]
+		var body strings.Builder
+		fmt.Fprintf(&body, "Your branch %s and %s's branch %s both change these files,\n",
+			self.Branch, other.Address(rigName), other.Branch)
+		fmt.Fprintf(&body, "and a trial merge of the two conflicts:\n\n")
+		for _, f := range c.Conflicts {
+			fmt.Fprintf(&body, "- %s\n", f)
+		}
+		fmt.Fprintf(&body, "\nWhichever merges to %s second will need a rebase. Coordinate with\n", target)
+		fmt.Fprintf(&body, "%s now (gt mail send) to split the work or agree on an order.\n", other.Address(rigName))
+		if err := router.Send(&mail.Message{
+			From:     fmt.Sprintf("%s/witness", rigName),
+			To:       self.Address(rigName),
+			Subject:  fmt.Sprintf("MERGE CONFLICT AHEAD: %s", other.Address(rigName)),
+			Priority: mail.PriorityHigh,
+			Type:     mail.TypeNotification,
+			Body:     body.String(),
+		}); err != nil {
+			return fmt.Errorf("mailing %s: %w", self.Address(rigName), err)
+		}
+	}
+	return nil
+}
+
+// collisionWarning records when a collision was last mailed and which files
+// conflicted then.
+type collisionWarning struct {
+	Files string    `json:"files"`
+	At    time.Time `json:"at"`
+}
+
+var collisionWarningsMu sync.Mutex
+
+// collisionWarningsPath returns <townRoot>/.runtime/witness_merge_watch.json.
+func collisionWarningsPath(townRoot string) string {
+	return filepath.Join(townRoot, constants.DirRuntime, "witness_merge_watch.json")
+}
+
+// collisionKey identifies a pair of branches regardless of order.
+func collisionKey(rigName string, c Collision) string {
+	a, b := c.A.Name+"@"+c.A.Branch, c.B.Name+"@"+c.B.Branch
+	if b < a {
+		a, b = b, a
+	}
+	return rigName + "/" + a + "/" + b
+}
+
+// loadCollisionWarnings returns previously mailed collisions.
+func loadCollisionWarnings(townRoot string) map[string]collisionWarning {
+	collisionWarningsMu.Lock()
+	defer collisionWarningsMu.Unlock()
+	m := make(map[string]collisionWarning)
+	if data, err := os.ReadFile(collisionWarningsPath(townRoot)); err == nil {
+		_ = json.Unmarshal(data, &m)
+	}
+	return m
+}
+
+// saveCollisionWarnings persists mailed collisions, dropping entries old
+// enough that they would be re-mailed anyway.
+func saveCollisionWarnings(townRoot string, m map[string]collisionWarning) error {
+	collisionWarningsMu.Lock()
+	defer collisionWarningsMu.Unlock()
+	cutoff := time.Now().Add(-collisionRewarnAfter)
+	for k, w := range m {
+		if w.At.Before(cutoff) {
+			delete(m, k)
+		}
+	}
+	path := collisionWarningsPath(townRoot)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return util.AtomicWriteJSON(path, m)
+}
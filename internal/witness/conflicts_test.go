@@ -0,0 +1,115 @@
+package witness
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/git"
+)
+
+func TestOverlappingPairs(t *testing.T) {
+	branches := []AgentBranch{
+		{Name: "nux", Files: []string{"a.go", "b.go"}},
+		{Name: "ace", Files: []string{"c.go"}},
+		{Name: "max", Role: "crew", Files: []string{"b.go", "c.go", "a.go"}},
+	}
+	pairs := OverlappingPairs(branches)
+	if len(pairs) != 2 {
+		t.Fatalf("pairs = %+v, want nux↔max and ace↔max", pairs)
+	}
+	if pairs[0].A.Name != "nux" || pairs[0].B.Name != "max" || !reflect.DeepEqual(pairs[0].Overlap, []string{"a.go", "b.go"}) {
+		t.Errorf("pair 0 = %s↔%s %v", pairs[0].A.Name, pairs[0].B.Name, pairs[0].Overlap)
+	}
+	if pairs[1].A.Name != "ace" || !reflect.DeepEqual(pairs[1].Overlap, []string{"c.go"}) {
+		t.Errorf("pair 1 = %s↔%s %v", pairs[1].A.Name, pairs[1].B.Name, pairs[1].Overlap)
+	}
+	if got := pairs[0].B.Address("gastown"); got != "gastown/crew/max" {
+		t.Errorf("Address = %q", got)
+	}
+
+	if key1, key2 := collisionKey("gastown", pairs[0]), collisionKey("gastown", Collision{A: pairs[0].B, B: pairs[0].A}); key1 != key2 {
+		t.Errorf("collision key depends on order: %q vs %q", key1, key2)
+	}
+}
+
+func TestTrialMerge(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	rigPath := t.TempDir()
+	repoPath := filepath.Join(rigPath, "mayor", "rig")
+	gitRun(t, "", "init", "-b", "main", repoPath)
+	gitRun(t, repoPath, "config", "user.email", "test@test.com")
+	gitRun(t, repoPath, "config", "user.name", "Test User")
+	writeAndCommit(t, repoPath, "shared.txt", "one\ntwo\nthree\n", "initial")
+
+	// Two polecats on their own branches, each editing the same line.
+	addPolecat := func(name, content string) AgentBranch {
+		path := filepath.Join(rigPath, "polecats", name)
+		gitRun(t, repoPath, "worktree", "add", "-b", "polecat/"+name, path, "main")
+		writeAndCommit(t, path, "shared.txt", content, name+" edit")
+		return AgentBranch{Role: "polecat", Name: name, Path: path, Branch: "polecat/" + name}
+	}
+	nux := addPolecat("nux", "one\nnux\nthree\n")
+	ace := addPolecat("ace", "one\nace\nthree\n")
+	calm := addPolecat("calm", "one\ntwo\nthree\nfour\n")
+
+	result := &CollisionsResult{}
+	branches := gatherAgentBranches(rigPath, "main", result)
+	if len(branches) != 3 || len(result.Errors) > 0 {
+		t.Fatalf("branches = %+v, errors = %v", branches, result.Errors)
+	}
+
+	repo, err := rigRepoBase(rigPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	scratch, cleanup, err := newScratchWorktree(repo, "main")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cleanup()
+
+	conflicts, err := trialMerge(scratch, nux, ace)
+	if err != nil {
+		t.Fatalf("trialMerge(nux, ace): %v", err)
+	}
+	if !reflect.DeepEqual(conflicts, []string{"shared.txt"}) {
+		t.Errorf("nux ↔ ace conflicts = %v, want shared.txt", conflicts)
+	}
+	if conflicts, err := trialMerge(scratch, ace, calm); err != nil || len(conflicts) != 0 {
+		t.Errorf("ace ↔ calm = %v, %v; want a clean merge", conflicts, err)
+	}
+
+	// The agents' worktrees are untouched.
+	data, _ := os.ReadFile(filepath.Join(nux.Path, "shared.txt"))
+	if string(data) != "one\nnux\nthree\n" {
+		t.Errorf("nux worktree changed: %q", data)
+	}
+}
+
+func gitRun(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+}
+
+func writeAndCommit(t *testing.T, dir, file, content, msg string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, file), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	g := git.NewGit(dir)
+	if err := g.Add(file); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Commit(msg); err != nil {
+		t.Fatal(err)
+	}
+}
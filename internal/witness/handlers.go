@@ -14,11 +14,14 @@ import (
 	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/events"
 	"github.com/steveyegge/gastown/internal/git"
 	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/nudge"
 	"github.com/steveyegge/gastown/internal/polecat"
 	"github.com/steveyegge/gastown/internal/rig"
 	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/templates"
 	"github.com/steveyegge/gastown/internal/tmux"
 	"github.com/steveyegge/gastown/internal/util"
 	"github.com/steveyegge/gastown/internal/workspace"
@@ -49,6 +52,15 @@ func workDirToTownRoot(workDir string) string {
 	return workDir
 }
 
+// nudgeSession types a witness nudge into a session through the town's nudge
+// scheduler, so it respects the session's circuit breaker (failing fast, and
+// dead-lettered, while the circuit is open) and its failures are counted.
+// Delivery is immediate, as the witness's nudges always were.
+func nudgeSession(townRoot, sessionName, message string) error {
+	_, err := nudge.SchedulerFor(townRoot).Submit(sessionName, message, nudge.SubmitOptions{Mode: nudge.ModeImmediate}).Wait()
+	return err
+}
+
 // registryMu serializes calls to initRegistryFromTownRoot so that concurrent
 // callers (including parallel tests) don't race on the global registries.
 var registryMu sync.Mutex
@@ -403,10 +415,17 @@ func HandleMergeFailed(workDir, rigName string, msg *mail.Message, router *mail.
 	// Nudge the polecat about the failure instead of sending permanent mail.
 	initRegistryFromWorkDir(workDir)
 	sessionName := session.PolecatSessionName(session.PrefixFor(rigName), payload.PolecatName)
-	nudgeMsg := fmt.Sprintf("MERGE_FAILED: branch=%s issue=%s type=%s error=%s — fix and resubmit with 'gt done'",
-		payload.Branch, payload.IssueID, payload.FailureType, payload.Error)
-	t := tmux.NewTmux()
-	if err := t.NudgeSession(sessionName, nudgeMsg); err != nil {
+	nudgeMsg, err := templates.RenderTownNudge(workDirToTownRoot(workDir), templates.NudgeRefineryBounce, map[string]string{
+		"Branch":      payload.Branch,
+		"Issue":       payload.IssueID,
+		"FailureType": payload.FailureType,
+		"Error":       payload.Error,
+	})
+	if err != nil {
+		result.Error = fmt.Errorf("rendering merge failure nudge: %w", err)
+		return result
+	}
+	if err := nudgeSession(workDirToTownRoot(workDir), sessionName, nudgeMsg); err != nil {
 		result.Error = fmt.Errorf("nudging polecat about failure: %w", err)
 		return result
 	}
@@ -641,7 +660,7 @@ func nudgeRefinery(townRoot, rigName string) error {
 	// No cooperative queue — idle agents never call Drain(), so queued
 	// nudges would be stuck forever. Direct delivery is safe: if the
 	// agent is busy, text buffers in tmux and is processed at next prompt.
-	return t.NudgeSession(sessionName, "New MR available - check merge queue for pending work")
+	return nudgeSession(townRoot, sessionName, "New MR available - check merge queue for pending work")
 }
 
 // RecoveryPayload contains data for RECOVERY_NEEDED escalation.
@@ -662,10 +681,18 @@ type RecoveryPayload struct {
 func EscalateRecoveryNeeded(workDir, rigName string, payload *RecoveryPayload) (string, error) {
 	initRegistryFromWorkDir(workDir)
 	sessionName := session.DeaconSessionName()
-	nudgeMsg := fmt.Sprintf("RECOVERY_NEEDED: %s/%s cleanup_status=%s branch=%s issue=%s detected=%s — coordinate recovery before authorizing cleanup",
-		rigName, payload.PolecatName, payload.CleanupStatus, payload.Branch, payload.IssueID, payload.DetectedAt.Format(time.RFC3339))
-	t := tmux.NewTmux()
-	if err := t.NudgeSession(sessionName, nudgeMsg); err != nil {
+	nudgeMsg, err := templates.RenderTownNudge(workDirToTownRoot(workDir), templates.NudgeWitnessRecovery, map[string]string{
+		"Rig":           rigName,
+		"Polecat":       payload.PolecatName,
+		"CleanupStatus": payload.CleanupStatus,
+		"Branch":        payload.Branch,
+		"Issue":         payload.IssueID,
+		"DetectedAt":    payload.DetectedAt.Format(time.RFC3339),
+	})
+	if err != nil {
+		return "", fmt.Errorf("rendering recovery nudge: %w", err)
+	}
+	if err := nudgeSession(workDirToTownRoot(workDir), sessionName, nudgeMsg); err != nil {
 		return "", fmt.Errorf("nudging deacon about recovery: %w", err)
 	}
 	return "nudge", nil
@@ -759,10 +786,12 @@ func NukePolecat(bd *BdCli, workDir, rigName, polecatName string) error {
 		// Brief delay for graceful handling
 		time.Sleep(100 * time.Millisecond)
 		// Force kill the session
+		container := polecat.SessionContainer(t, sessionName)
 		if err := t.KillSession(sessionName); err != nil {
 			// Log but continue - session might already be dead
 			// The important thing is we tried
 		}
+		polecat.RemoveContainer(container)
 	}
 
 	// Now run gt polecat nuke to clean up worktree, branch, and beads
@@ -1506,6 +1535,8 @@ func DetectStalledPolecats(workDir, rigName string) *DetectStalledPolecatsResult
 		} else {
 			stalled.Action = "auto-dismissed"
 		}
+		_ = events.LogFeed(events.TypeStuckWorker, sessionName,
+			events.StuckWorkerPayload(rigName, polecatName, stalled.StallType, "witness"))
 		result.Stalled = append(result.Stalled, stalled)
 	}
 
@@ -1717,12 +1748,9 @@ func (s *agentBeadSnapshot) age() time.Duration {
 	if s == nil || s.UpdatedAt == "" {
 		return 24 * time.Hour
 	}
-	updatedAt, err := time.Parse(time.RFC3339, s.UpdatedAt)
+	updatedAt, err := util.ParseTimestamp(s.UpdatedAt)
 	if err != nil {
-		updatedAt, err = time.Parse("2006-01-02 15:04:05", s.UpdatedAt)
-		if err != nil {
-			return 24 * time.Hour
-		}
+		return 24 * time.Hour
 	}
 	return time.Since(updatedAt)
 }
@@ -1824,13 +1852,9 @@ func getAgentBeadAge(bd *BdCli, workDir, agentBeadID string) time.Duration {
 		return 24 * time.Hour
 	}
 
-	updatedAt, err := time.Parse(time.RFC3339, issues[0].UpdatedAt)
+	updatedAt, err := util.ParseTimestamp(issues[0].UpdatedAt)
 	if err != nil {
-		// Try common alternative formats
-		updatedAt, err = time.Parse("2006-01-02 15:04:05", issues[0].UpdatedAt)
-		if err != nil {
-			return 24 * time.Hour
-		}
+		return 24 * time.Hour
 	}
 	return time.Since(updatedAt)
 }
@@ -1915,10 +1939,9 @@ then either close the bead or reset the respawn counter.`,
 			if err := router.Send(msg); err != nil {
 				fmt.Fprintf(os.Stderr, "witness: failed to send SPAWN_BLOCKED mail for %s: %v, attempting nudge fallback\n", hookBead, err)
 				// Nudge mayor as fallback — nudges are more reliable than mail
-				t := tmux.NewTmux()
 				nudgeMsg := fmt.Sprintf("SPAWN_BLOCKED %s (respawn limit reached) from %s/%s — mail send failed, investigate spawn storm",
 					hookBead, rigName, polecatName)
-				if nudgeErr := t.NudgeSession(session.MayorSessionName(), nudgeMsg); nudgeErr != nil {
+				if nudgeErr := nudgeSession(trRoot, session.MayorSessionName(), nudgeMsg); nudgeErr != nil {
 					fmt.Fprintf(os.Stderr, "witness: nudge fallback to mayor also failed for %s: %v\n", hookBead, nudgeErr)
 				}
 			}
@@ -1966,10 +1989,9 @@ Please re-dispatch to an available polecat.`,
 		if err := router.Send(msg); err != nil {
 			fmt.Fprintf(os.Stderr, "witness: failed to send RECOVERED_BEAD mail for %s: %v, attempting nudge fallback\n", hookBead, err)
 			// Nudge deacon as fallback — nudges are more reliable than mail
-			t := tmux.NewTmux()
 			nudgeMsg := fmt.Sprintf("RECOVERED_BEAD %s from %s/%s (status=%s, respawns=%d) — mail send failed, please re-dispatch",
 				hookBead, rigName, polecatName, status, respawnCount)
-			if nudgeErr := t.NudgeSession(session.DeaconSessionName(), nudgeMsg); nudgeErr != nil {
+			if nudgeErr := nudgeSession(trRoot, session.DeaconSessionName(), nudgeMsg); nudgeErr != nil {
 				fmt.Fprintf(os.Stderr, "witness: nudge fallback to deacon also failed for %s: %v\n", hookBead, nudgeErr)
 			}
 		}
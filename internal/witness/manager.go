@@ -222,6 +222,18 @@ func (m *Manager) Start(foreground bool, agentOverride string, envOverrides []st
 	theme := tmux.AssignTheme(m.rig.Name)
 	_ = t.ConfigureGasTownSession(sessionID, theme, m.rig.Name, "witness", "witness")
 
+	// Open the witness's declared pane layout (non-fatal)
+	if err := session.ApplyRoleLayout(t, session.LayoutConfig{
+		SessionID: sessionID,
+		WorkDir:   witnessDir,
+		Role:      "witness",
+		TownRoot:  townRoot,
+		RigPath:   m.rig.Path,
+		RigName:   m.rig.Name,
+	}); err != nil {
+		log.Printf("warning: session layout for %s: %v", sessionID, err)
+	}
+
 	// Wait for Claude to start - fatal if Claude fails to launch
 	if err := t.WaitForCommand(sessionID, constants.SupportedShells, constants.ClaudeStartTimeout); err != nil {
 		// Kill the zombie session before returning error
@@ -0,0 +1,223 @@
+package witness
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/mail"
+)
+
+// PermissionPrompt is a permission prompt parsed from the bottom of an
+// agent's pane.
+type PermissionPrompt struct {
+	// Tool is the tool being approved, e.g. "Bash" or "mcp__github__create_issue";
+	// "y/n" for a generic y/n gate; empty if it couldn't be told.
+	Tool string
+	// Choice is true for a Claude Code numbered-choice dialog, false for a
+	// y/n gate answered by typing.
+	Choice bool
+	// YesSelected reports that a Choice dialog's highlighted option is
+	// "Yes", so Enter accepts it.
+	YesSelected bool
+	// Context is the prompt and the output just above it, for escalation.
+	Context []string
+}
+
+// permissionContextLines is how much of the pane above a prompt goes into
+// an escalation.
+const permissionContextLines = 25
+
+var (
+	choiceQuestionRe = regexp.MustCompile(`Do you want to (proceed|make this edit|create|allow)`)
+	choiceYesRe      = regexp.MustCompile(`^[│|\s]*❯\s*1\.\s*Yes\b`)
+	ynGateRe         = regexp.MustCompile(`(?i)(\((y/n|yes/no)\)|\[(y/N|Y/n)\])\s*\??\s*:?\s*$`)
+	mcpToolRe        = regexp.MustCompile(`^(\S+) - (\w+)\(.*\(MCP\)`)
+)
+
+// dialogTitleTools maps Claude Code permission dialog titles to tool names.
+var dialogTitleTools = map[string]string{
+	"Bash command": "Bash",
+	"Edit file":    "Edit",
+	"Create file":  "Write",
+	"Read file":    "Read",
+	"Fetch":        "WebFetch",
+	"Web Search":   "WebSearch",
+}
+
+// ParsePermissionPrompt finds a permission prompt at the bottom of a pane:
+// a Claude Code tool approval dialog or a y/n gate.
+func ParsePermissionPrompt(lines []string) (PermissionPrompt, bool) {
+	tailStart := max(0, len(lines)-promptTailLines)
+	for i := len(lines) - 1; i >= tailStart; i-- {
+		line := lines[i]
+		switch {
+		case choiceQuestionRe.MatchString(line):
+			p := PermissionPrompt{Choice: true, Context: promptContext(lines, i)}
+			for _, opt := range lines[i+1:] {
+				if choiceYesRe.MatchString(opt) {
+					p.YesSelected = true
+				}
+			}
+			p.Tool = dialogTool(lines, i)
+			return p, true
+		case strings.TrimSpace(line) == "":
+			continue
+		case ynGateRe.MatchString(line):
+			return PermissionPrompt{Tool: "y/n", Context: promptContext(lines, i)}, true
+		}
+	}
+	return PermissionPrompt{}, false
+}
+
+// dialogTool names the tool the Claude Code dialog whose question is at
+// lines[q] asks about. Only the dialog itself is read: its title and, for
+// MCP tools, the line under it. Output above the dialog is printed by the
+// agent, which could print anything, so a dialog whose title can't be read
+// yields "" and is escalated.
+func dialogTool(lines []string, q int) string {
+	body := dialogBody(lines, q)
+	if len(body) == 0 {
+		return ""
+	}
+	if tool, ok := dialogTitleTools[body[0]]; ok {
+		return tool
+	}
+	if body[0] == "Tool use" && len(body) > 1 {
+		if m := mcpToolRe.FindStringSubmatch(body[1]); m != nil {
+			return "mcp__" + m[1] + "__" + m[2]
+		}
+	}
+	return ""
+}
+
+// dialogBody returns the non-blank lines of the dialog above its question at
+// lines[q], top first, without box borders. A boxed dialog ends at its top
+// edge or its first unboxed line; an unboxed one must have a top rule within
+// permissionContextLines. Returns nil if the dialog's top can't be found.
+func dialogBody(lines []string, q int) []string {
+	boxed := isBoxed(lines[q])
+	var body []string
+	for i := q - 1; i >= 0 && i >= q-permissionContextLines; i-- {
+		raw := strings.TrimSpace(lines[i])
+		if isDialogEdge(raw) || (boxed && !isBoxed(raw)) {
+			slices.Reverse(body)
+			return body
+		}
+		if text := strings.TrimSpace(strings.Trim(raw, "│|")); text != "" {
+			body = append(body, text)
+		}
+	}
+	if boxed && q <= permissionContextLines {
+		// The box reaches the top of the capture.
+		slices.Reverse(body)
+		return body
+	}
+	return nil
+}
+
+// isBoxed reports whether a line is inside a dialog's side borders.
+func isBoxed(line string) bool {
+	line = strings.TrimSpace(line)
+	return strings.HasPrefix(line, "│") || strings.HasPrefix(line, "|")
+}
+
+// isDialogEdge reports whether a trimmed line is a dialog's top edge: a box
+// corner or a horizontal rule.
+func isDialogEdge(line string) bool {
+	if strings.HasPrefix(line, "╭") || strings.HasPrefix(line, "┌") {
+		return true
+	}
+	return len(line) >= 3 && strings.Trim(line, "─") == ""
+}
+
+// promptContext returns the lines around a prompt at index i.
+func promptContext(lines []string, i int) []string {
+	return lines[max(0, i-permissionContextLines):]
+}
+
+// promptAnswerer is the tmux surface answering a prompt needs.
+type promptAnswerer interface {
+	FindAgentPane(session string) (string, error)
+	SendKeysRaw(session, keys string) error
+}
+
+// answerPrompt answers a prompt yes or no in the session's agent pane.
+func answerPrompt(t promptAnswerer, sess string, p PermissionPrompt, yes bool) error {
+	target := sess
+	if pane, err := t.FindAgentPane(sess); err == nil && pane != "" {
+		target = pane
+	}
+	var keys []string
+	switch {
+	case p.Choice && yes:
+		keys = []string{"Enter"} // The highlighted "1. Yes"
+	case p.Choice:
+		keys = []string{"Escape"} // "No, and tell Claude what to do differently"
+	case yes:
+		keys = []string{"y", "Enter"}
+	default:
+		keys = []string{"n", "Enter"}
+	}
+	for _, k := range keys {
+		if err := t.SendKeysRaw(target, k); err != nil {
+			return fmt.Errorf("answering prompt in %s: %w", sess, err)
+		}
+	}
+	return nil
+}
+
+// answerPermissionPrompt answers a prompt the policy covers and logs a
+// permission_answer event. Answers aren't rate-limited: each prompt the
+// agent waits on is a new request.
+func answerPermissionPrompt(t promptAnswerer, rigName string, found *AgentAnomaly, p PermissionPrompt, policy *config.WitnessPermissionPolicy, dryRun bool) error {
+	yes := decidePrompt(policy, p) == config.PermissionAllow
+	answer := "no"
+	if yes {
+		answer = "yes"
+	}
+	if dryRun {
+		found.Skipped = "dry run"
+		found.Action = "would answer " + answer
+		return nil
+	}
+	if err := answerPrompt(t, found.Session, p, yes); err != nil {
+		return err
+	}
+	found.Action = "answered " + answer
+	_ = events.LogFeed(events.TypePermissionAnswer, found.Agent,
+		events.PermissionAnswerPayload(rigName, found.Agent, p.Tool, answer))
+	return nil
+}
+
+// decidePrompt applies the policy to a prompt. A Choice dialog whose
+// highlighted option isn't "Yes" is escalated rather than answered yes, so
+// Enter never picks something else.
+func decidePrompt(policy *config.WitnessPermissionPolicy, p PermissionPrompt) string {
+	d := policy.Decide(p.Tool)
+	if d == config.PermissionAllow && p.Choice && !p.YesSelected {
+		return config.PermissionEscalate
+	}
+	return d
+}
+
+// escalatePrompt mails the mayor a prompt the policy doesn't cover.
+func escalatePrompt(townRoot, rigName, agent, sess string, p PermissionPrompt) error {
+	tool := p.Tool
+	if tool == "" {
+		tool = "unknown tool"
+	}
+	body := fmt.Sprintf("%s is waiting on a permission prompt for %s that the rig's witness.permissions policy doesn't cover.\n\nSession: %s\nAnswer it in the session (tmux attach -t %s), or add the tool to witness.permissions in the rig's settings/config.json.\n\n```\n%s\n```\n",
+		agent, tool, sess, sess, strings.Join(p.Context, "\n"))
+	return mail.NewRouter(townRoot).Send(&mail.Message{
+		From:     fmt.Sprintf("%s/witness", rigName),
+		To:       "mayor/",
+		Subject:  fmt.Sprintf("PERMISSION %s: %s", agent, tool),
+		Priority: mail.PriorityHigh,
+		Type:     mail.TypeNotification,
+		Body:     body,
+	})
+}
@@ -0,0 +1,126 @@
+package witness
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/session"
+)
+
+const bashDialog = `⏺ Bash(rm -rf build)
+╭──────────────────────────────────────╮
+│ Bash command                         │
+│                                      │
+│   rm -rf build                       │
+│   Remove build output                │
+│                                      │
+│ Do you want to proceed?              │
+│ ❯ 1. Yes                             │
+│   2. No, and tell Claude what to do differently (esc) │
+╰──────────────────────────────────────╯`
+
+func TestParsePermissionPrompt(t *testing.T) {
+	mcpDialog := "╭────╮\n│ Tool use │\n│   github - create_issue(title: \"x\") (MCP) │\n│ Do you want to proceed? │\n│ ❯ 1. Yes │\n│   2. No │\n╰────╯"
+	notOnYes := "⏺ Edit(main.go)\n│ Edit file │\n│ Do you want to make this edit to main.go? │\n│   1. Yes │\n│ ❯ 2. No │"
+
+	tests := []struct {
+		name   string
+		pane   string
+		wantOK bool
+		tool   string
+		choice bool
+		yesSel bool
+	}{
+		{"bash dialog", bashDialog, true, "Bash", true, true},
+		{"mcp dialog", mcpDialog, true, "mcp__github__create_issue", true, true},
+		{"cursor not on yes", notOnYes, true, "Edit", true, false},
+		{"untitled dialog ignores tool calls above", "⏺ Read(notes.md)\n│ Do you want to proceed? │\n│ ❯ 1. Yes │", true, "", true, true},
+		{"title printed above dialog", "│ Read file │\n⏺ Working\n│ Do you want to proceed? │\n│ ❯ 1. Yes │", true, "", true, true},
+		{"unboxed dialog", "⏺ Read(x)\n────────\n Bash command\n   make test\n Do you want to proceed?\n ❯ 1. Yes", true, "Bash", true, true},
+		{"y/n gate", "Overwrite config.yaml? (y/n) ", true, "y/n", false, false},
+		{"bracketed gate", "Continue? [Y/n]\n\n", true, "y/n", false, false},
+		{"no prompt", "⏺ Done.\n\n❯ ", false, "", false, false},
+		{"gate scrolled away", "Proceed? (y/n) y\nok\nbuilding", false, "", false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, ok := ParsePermissionPrompt(paneLines(tt.pane))
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			if p.Tool != tt.tool || p.Choice != tt.choice || p.YesSelected != tt.yesSel {
+				t.Errorf("prompt = {Tool:%q Choice:%v YesSelected:%v}, want {%q %v %v}",
+					p.Tool, p.Choice, p.YesSelected, tt.tool, tt.choice, tt.yesSel)
+			}
+		})
+	}
+}
+
+func TestDecidePrompt(t *testing.T) {
+	policy := &config.WitnessPermissionPolicy{Allow: []string{"Edit"}}
+	if got := decidePrompt(policy, PermissionPrompt{Tool: "Edit", Choice: true, YesSelected: true}); got != config.PermissionAllow {
+		t.Errorf("yes highlighted = %q, want allow", got)
+	}
+	// Enter would pick whatever is highlighted, so don't answer blind.
+	if got := decidePrompt(policy, PermissionPrompt{Tool: "Edit", Choice: true}); got != config.PermissionEscalate {
+		t.Errorf("yes not highlighted = %q, want escalate", got)
+	}
+}
+
+func TestDetectAnomalies_AnswersPermissionPrompts(t *testing.T) {
+	townRoot := t.TempDir()
+	for _, dir := range []string{"gastown/polecats/nux", "gastown/polecats/furiosa"} {
+		if err := os.MkdirAll(filepath.Join(townRoot, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	initRegistryFromTownRoot(townRoot)
+	prefix := session.PrefixFor("gastown")
+	nux := session.PolecatSessionName(prefix, "nux")
+	furiosa := session.PolecatSessionName(prefix, "furiosa")
+	c := &fakePanes{panes: map[string]string{
+		nux:     bashDialog,
+		furiosa: "⏺ Fetch(https://example.com)\n│ Fetch │\n│ Do you want to allow Claude to fetch this content? │\n│ ❯ 1. Yes │",
+	}}
+
+	var escalated []string
+	orig := escalatePromptFn
+	escalatePromptFn = func(_, _, agent, _ string, p PermissionPrompt) error {
+		escalated = append(escalated, agent+":"+p.Tool)
+		return nil
+	}
+	defer func() { escalatePromptFn = orig }()
+
+	policy := &config.WitnessPermissionPolicy{Allow: []string{"Bash"}}
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	detectAnomalies(c, townRoot, "gastown", policy, false, now)
+
+	// Still waiting: nux's Bash prompt is answered, furiosa's is escalated.
+	res := detectAnomalies(c, townRoot, "gastown", policy, true, now.Add(time.Minute))
+	if len(res.Found) != 2 || len(c.keys) != 0 || len(escalated) != 0 {
+		t.Fatalf("dry run = %+v, keys %v, escalated %v", res.Found, c.keys, escalated)
+	}
+	res = detectAnomalies(c, townRoot, "gastown", policy, false, now.Add(2*time.Minute))
+	actions := map[string]string{}
+	for _, a := range res.Found {
+		actions[a.Agent] = a.Action
+	}
+	if actions["gastown/polecats/nux"] != "answered yes" || actions["gastown/polecats/furiosa"] != "escalated to mayor" {
+		t.Errorf("actions = %v", actions)
+	}
+	if len(c.keys) != 1 || c.keys[0] != nux+":Enter" {
+		t.Errorf("keys = %v, want Enter to %s", c.keys, nux)
+	}
+	if len(escalated) != 1 || escalated[0] != "gastown/polecats/furiosa:WebFetch" {
+		t.Errorf("escalated = %v", escalated)
+	}
+
+	// Escalation shares the anomaly cooldown; answering doesn't.
+	detectAnomalies(c, townRoot, "gastown", policy, false, now.Add(3*time.Minute))
+	if len(c.keys) != 2 || len(escalated) != 1 {
+		t.Errorf("after rescan: keys %v, escalated %v", c.keys, escalated)
+	}
+}
@@ -0,0 +1,455 @@
+package witness
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofrs/flock"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/nudge"
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// AgentFacts is the state a witness rule is evaluated against for one agent.
+type AgentFacts struct {
+	Role          string // "polecat" or "crew"
+	Name          string
+	Session       string
+	Running       bool          // Session exists
+	Idle          time.Duration // Time since last session activity; valid only when Running
+	State         string        // Agent bead agent_state
+	HookBead      string
+	Labels        []string // Hooked bead labels
+	Priority      int      // Hooked bead priority; -1 if unknown
+	NudgeFailures int      // Consecutive failed nudges to the session
+
+	// SinceProgress is the time since the hooked work last showed progress:
+	// the latest of a commit in the agent's worktree, an update to the
+	// hooked bead, and session activity. Zero if unknown or nothing is
+	// hooked.
+	SinceProgress time.Duration
+}
+
+// Address returns the agent's mail address in rig.
+func (f AgentFacts) Address(rigName string) string {
+	if f.Role == "crew" {
+		return fmt.Sprintf("%s/crew/%s", rigName, f.Name)
+	}
+	return fmt.Sprintf("%s/polecats/%s", rigName, f.Name)
+}
+
+// RuleFiring is one rule matching one agent.
+type RuleFiring struct {
+	Rule    config.WitnessRule
+	Agent   AgentFacts
+	Reason  string // Which conditions held
+	Skipped string // Why the action didn't run (cooldown, dry run); empty if it ran
+	Error   error
+}
+
+// RulesResult holds the outcome of one rules evaluation for a rig.
+type RulesResult struct {
+	Checked int // Agents evaluated
+	Fired   []RuleFiring
+	Errors  []error // Transient errors gathering facts
+}
+
+// MatchRule reports whether every condition of rule holds for f, and if
+// so describes the conditions for mail and logs.
+func MatchRule(rule config.WitnessRule, f AgentFacts) (string, bool) {
+	w := rule.When
+	if !slices.Contains(w.RolesOrDefault(), f.Role) {
+		return "", false
+	}
+
+	var reasons []string
+	if len(w.AgentState) > 0 {
+		if !slices.Contains(w.AgentState, f.State) {
+			return "", false
+		}
+		reasons = append(reasons, "state "+f.State)
+	}
+	if w.IdleFor != "" {
+		idleFor := config.ParseDurationOrDefault(w.IdleFor, 0)
+		if !f.Running || idleFor <= 0 || f.Idle < idleFor {
+			return "", false
+		}
+		reasons = append(reasons, "idle "+f.Idle.Round(time.Minute).String())
+	}
+	if w.NudgeFailures > 0 {
+		if f.NudgeFailures < w.NudgeFailures {
+			return "", false
+		}
+		reasons = append(reasons, fmt.Sprintf("%d failed nudges", f.NudgeFailures))
+	}
+	if w.NoProgressFor != "" {
+		noProgressFor := config.ParseDurationOrDefault(w.NoProgressFor, 0)
+		if f.HookBead == "" || noProgressFor <= 0 || f.SinceProgress < noProgressFor {
+			return "", false
+		}
+		reasons = append(reasons, "no progress "+f.SinceProgress.Round(time.Minute).String())
+	}
+	if len(w.Labels) > 0 {
+		for _, l := range w.Labels {
+			if !slices.Contains(f.Labels, l) {
+				return "", false
+			}
+		}
+		reasons = append(reasons, "labels "+strings.Join(w.Labels, ","))
+	}
+	return strings.Join(reasons, ", "), true
+}
+
+// EvaluateRules evaluates the rig's witness rules (rig settings
+// witness.rules) against each of its agents and runs the actions of the
+// rules that match. A rule fires at most once per cooldown per agent. With
+// dryRun, matches are reported but no action runs and no cooldown starts.
+func EvaluateRules(bd *BdCli, townRoot, rigName string, dryRun bool) (*RulesResult, error) {
+	result := &RulesResult{}
+	initRegistryFromTownRoot(townRoot)
+
+	rigPath := filepath.Join(townRoot, rigName)
+	settings, err := config.LoadRigSettings(config.RigSettingsPath(rigPath))
+	if err != nil {
+		if errors.Is(err, config.ErrNotFound) {
+			return result, nil
+		}
+		return nil, err
+	}
+	if settings.Witness == nil || len(settings.Witness.Rules) == 0 {
+		return result, nil
+	}
+	rules := settings.Witness.Rules
+
+	roles := make(map[string]bool)
+	var need factNeeds
+	for _, r := range rules {
+		for _, role := range r.When.RolesOrDefault() {
+			roles[role] = true
+		}
+		if len(r.When.Labels) > 0 || r.Do == config.WitnessActionEscalate {
+			need.hook = true
+		}
+		if r.When.NoProgressFor != "" {
+			need.progress = true
+		}
+	}
+
+	agents := gatherAgentFacts(bd, townRoot, rigName, roles, need, result)
+	result.Checked = len(agents)
+
+	cooldowns := loadRuleCooldowns(townRoot)
+	now := time.Now()
+	fired := make(map[string]time.Time)
+	for _, f := range agents {
+		for _, rule := range rules {
+			reason, ok := MatchRule(rule, f)
+			if !ok {
+				continue
+			}
+			firing := RuleFiring{Rule: rule, Agent: f, Reason: reason}
+			key := ruleCooldownKey(rigName, rule.Name, f.Session)
+			switch {
+			case now.Sub(cooldowns[key]) < rule.CooldownD():
+				firing.Skipped = "cooldown"
+			case dryRun:
+				firing.Skipped = "dry run"
+			default:
+				firing.Error = runRuleAction(bd, townRoot, rigName, rule, f, reason)
+				cooldowns[key] = now
+				fired[key] = now
+			}
+			result.Fired = append(result.Fired, firing)
+		}
+	}
+	if len(fired) > 0 {
+		if err := saveRuleCooldowns(townRoot, rigName, rules, fired); err != nil {
+			result.Errors = append(result.Errors, fmt.Errorf("saving rule cooldowns: %w", err))
+		}
+	}
+	return result, nil
+}
+
+// factNeeds lists the facts that cost extra lookups, so they're only
+// gathered when some rule uses them.
+type factNeeds struct {
+	hook     bool // Hooked bead labels and priority
+	progress bool // SinceProgress
+}
+
+// gatherAgentFacts collects facts for the rig's agents in the given roles.
+func gatherAgentFacts(bd *BdCli, townRoot, rigName string, roles map[string]bool, need factNeeds, result *RulesResult) []AgentFacts {
+	t := tmux.NewTmux()
+	prefix := beads.GetPrefixForRig(townRoot, rigName)
+	sessPrefix := session.PrefixFor(rigName)
+	failures, _ := nudge.LoadFailures(townRoot)
+	workDir := filepath.Join(townRoot, rigName)
+
+	var agents []AgentFacts
+	for _, role := range []string{"polecat", "crew"} {
+		if !roles[role] {
+			continue
+		}
+		dir := filepath.Join(townRoot, rigName, "polecats")
+		if role == "crew" {
+			dir = filepath.Join(townRoot, rigName, "crew")
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+				continue
+			}
+			f := AgentFacts{Role: role, Name: entry.Name(), Priority: -1}
+			var lastProgress time.Time
+			var agentBeadID string
+			if role == "crew" {
+				f.Session = session.CrewSessionName(sessPrefix, f.Name)
+				agentBeadID = beads.CrewBeadIDWithPrefix(prefix, rigName, f.Name)
+			} else {
+				f.Session = session.PolecatSessionName(sessPrefix, f.Name)
+				agentBeadID = beads.PolecatBeadIDWithPrefix(prefix, rigName, f.Name)
+			}
+
+			if alive, err := t.HasSession(f.Session); err != nil {
+				result.Errors = append(result.Errors, fmt.Errorf("checking session %s: %w", f.Session, err))
+			} else if alive {
+				f.Running = true
+				if activity, err := t.GetSessionActivity(f.Session); err == nil {
+					f.Idle = time.Since(activity)
+					lastProgress = activity
+				}
+			}
+
+			f.State, f.HookBead = getAgentBeadState(bd, workDir, agentBeadID)
+			if (need.hook || need.progress) && f.HookBead != "" {
+				hooked := getHookedBeadInfo(bd, workDir, f.HookBead)
+				f.Labels, f.Priority = hooked.Labels, hooked.Priority
+				if need.progress {
+					lastProgress = latest(lastProgress, hooked.UpdatedAt, lastWorktreeCommit(filepath.Join(dir, f.Name), rigName))
+					if !lastProgress.IsZero() {
+						f.SinceProgress = time.Since(lastProgress)
+					}
+				}
+			}
+			f.NudgeFailures = failures[f.Session].Count
+			agents = append(agents, f)
+		}
+	}
+	return agents
+}
+
+// hookedBeadInfo is what rules need to know about an agent's hooked bead.
+type hookedBeadInfo struct {
+	Labels    []string
+	Priority  int       // -1 if unknown
+	UpdatedAt time.Time // Zero if unknown
+}
+
+// getHookedBeadInfo reads a bead's labels, priority, and last update.
+func getHookedBeadInfo(bd *BdCli, workDir, beadID string) hookedBeadInfo {
+	info := hookedBeadInfo{Priority: -1}
+	output, err := bd.Exec(workDir, "show", beadID, "--json")
+	if err != nil || output == "" {
+		return info
+	}
+	var issues []struct {
+		Labels    []string `json:"labels"`
+		Priority  *int     `json:"priority"`
+		UpdatedAt string   `json:"updated_at"`
+	}
+	if err := json.Unmarshal([]byte(output), &issues); err != nil || len(issues) == 0 {
+		return info
+	}
+	info.Labels = issues[0].Labels
+	if issues[0].Priority != nil {
+		info.Priority = *issues[0].Priority
+	}
+	if t, err := util.ParseTimestamp(issues[0].UpdatedAt); err == nil {
+		info.UpdatedAt = t
+	}
+	return info
+}
+
+// lastWorktreeCommit returns the time of the latest commit in an agent's
+// worktree (<agentDir>/<rig> for polecats, else <agentDir> itself), or the
+// zero time if there is no worktree.
+func lastWorktreeCommit(agentDir, rigName string) time.Time {
+	path := agentDir
+	if _, err := os.Stat(filepath.Join(agentDir, rigName, ".git")); err == nil {
+		path = filepath.Join(agentDir, rigName)
+	} else if _, err := os.Stat(filepath.Join(agentDir, ".git")); err != nil {
+		return time.Time{}
+	}
+	t, err := git.NewGit(path).LastCommitTime()
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// latest returns the latest of ts.
+func latest(ts ...time.Time) time.Time {
+	var out time.Time
+	for _, t := range ts {
+		if t.After(out) {
+			out = t
+		}
+	}
+	return out
+}
+
+// runRuleAction performs a fired rule's action.
+func runRuleAction(bd *BdCli, townRoot, rigName string, rule config.WitnessRule, f AgentFacts, reason string) error {
+	workDir := filepath.Join(townRoot, rigName)
+	switch rule.Do {
+	case config.WitnessActionMailMayor:
+		body := fmt.Sprintf("Witness rule %q matched %s (%s).\n\nSession: %s\nState: %s\nHooked: %s\n",
+			rule.Name, f.Address(rigName), reason, f.Session, f.State, f.HookBead)
+		if rule.Message != "" {
+			body = rule.Message + "\n\n" + body
+		}
+		return mail.NewRouter(townRoot).Send(&mail.Message{
+			From:     fmt.Sprintf("%s/witness", rigName),
+			To:       "mayor/",
+			Subject:  fmt.Sprintf("RULE %s: %s", rule.Name, f.Address(rigName)),
+			Priority: mail.PriorityHigh,
+			Type:     mail.TypeNotification,
+			Body:     body,
+		})
+
+	case config.WitnessActionRestart:
+		if f.Role == "crew" {
+			if err := util.ExecRun(workDir, "gt", "crew", "restart", rigName+"/"+f.Name); err != nil {
+				return fmt.Errorf("crew restart failed: %w", err)
+			}
+			return nil
+		}
+		return RestartPolecatSession(workDir, rigName, f.Name)
+
+	case config.WitnessActionEscalate:
+		if f.HookBead == "" {
+			return fmt.Errorf("%s has no hooked bead to escalate", f.Address(rigName))
+		}
+		if f.Priority < 0 {
+			return fmt.Errorf("unknown priority for %s", f.HookBead)
+		}
+		if f.Priority == 0 {
+			return nil // Already the highest priority
+		}
+		return bd.Run(workDir, "update", f.HookBead, "--priority", strconv.Itoa(f.Priority-1))
+
+	case config.WitnessActionDetach:
+		return detachStaleHook(townRoot, rigName, rule, f, reason)
+	}
+	return fmt.Errorf("unknown action %q", rule.Do)
+}
+
+// detachStaleHook unhooks an agent's work and returns it to the ready queue
+// (gt unsling sets it open and unassigned), then tells the mayor and the
+// feed so the work gets picked up again instead of rotting on the hook.
+func detachStaleHook(townRoot, rigName string, rule config.WitnessRule, f AgentFacts, reason string) error {
+	if f.HookBead == "" {
+		return fmt.Errorf("%s has nothing hooked to detach", f.Address(rigName))
+	}
+	addr := f.Address(rigName)
+	if err := util.ExecRun(filepath.Join(townRoot, rigName), "gt", "unsling", f.HookBead, addr, "--force"); err != nil {
+		return fmt.Errorf("unsling %s from %s: %w", f.HookBead, addr, err)
+	}
+	_ = events.LogFeed(events.TypeHookDetached, fmt.Sprintf("%s/witness", rigName),
+		events.HookDetachedPayload(f.HookBead, addr, rule.Name, reason))
+
+	body := fmt.Sprintf("Witness rule %q detached %s from %s (%s) and returned it to the ready queue.\n\nSession: %s\nState: %s\n",
+		rule.Name, f.HookBead, addr, reason, f.Session, f.State)
+	if rule.Message != "" {
+		body = rule.Message + "\n\n" + body
+	}
+	return mail.NewRouter(townRoot).Send(&mail.Message{
+		From:     fmt.Sprintf("%s/witness", rigName),
+		To:       "mayor/",
+		Subject:  fmt.Sprintf("DETACHED %s from %s", f.HookBead, addr),
+		Priority: mail.PriorityHigh,
+		Type:     mail.TypeNotification,
+		Body:     body,
+	})
+}
+
+var ruleCooldownsMu sync.Mutex
+
+// ruleCooldownsPath returns <townRoot>/.runtime/witness_rules.json.
+func ruleCooldownsPath(townRoot string) string {
+	return filepath.Join(townRoot, constants.DirRuntime, "witness_rules.json")
+}
+
+func ruleCooldownKey(rigName, rule, sess string) string {
+	return rigName + "/" + rule + "/" + sess
+}
+
+// loadRuleCooldowns returns when each rule last fired for each agent.
+func loadRuleCooldowns(townRoot string) map[string]time.Time {
+	ruleCooldownsMu.Lock()
+	defer ruleCooldownsMu.Unlock()
+	m := make(map[string]time.Time)
+	if data, err := os.ReadFile(ruleCooldownsPath(townRoot)); err == nil {
+		_ = json.Unmarshal(data, &m)
+	}
+	return m
+}
+
+// saveRuleCooldowns records the rule firings of rigName in the shared
+// cooldowns file. Under the file lock it re-reads the file, so witnesses of
+// other rigs firing at the same time don't lose each other's entries, and
+// drops this rig's entries whose rule's own cooldown has passed, or whose
+// rule is gone. Other rigs' entries are left for their witnesses to prune.
+func saveRuleCooldowns(townRoot, rigName string, rules []config.WitnessRule, fired map[string]time.Time) error {
+	ruleCooldownsMu.Lock()
+	defer ruleCooldownsMu.Unlock()
+	path := ruleCooldownsPath(townRoot)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	fl := flock.New(path + ".lock")
+	if err := fl.Lock(); err != nil {
+		return fmt.Errorf("acquiring rule cooldowns lock: %w", err)
+	}
+	defer func() { _ = fl.Unlock() }()
+
+	m := make(map[string]time.Time)
+	if data, err := os.ReadFile(path); err == nil {
+		_ = json.Unmarshal(data, &m)
+	}
+	for k, at := range fired {
+		m[k] = at
+	}
+	now := time.Now()
+	for k, at := range m {
+		rest, ok := strings.CutPrefix(k, rigName+"/")
+		if !ok {
+			continue
+		}
+		i := slices.IndexFunc(rules, func(r config.WitnessRule) bool {
+			return strings.HasPrefix(rest, r.Name+"/")
+		})
+		if i < 0 || now.Sub(at) >= rules[i].CooldownD() {
+			delete(m, k)
+		}
+	}
+	return util.AtomicWriteJSON(path, m)
+}
@@ -0,0 +1,213 @@
+package witness
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+func TestMatchRule(t *testing.T) {
+	polecat := AgentFacts{
+		Role:          "polecat",
+		Name:          "nux",
+		Running:       true,
+		Idle:          25 * time.Minute,
+		State:         "working",
+		HookBead:      "gt-abc",
+		Labels:        []string{"p0", "backend"},
+		NudgeFailures: 2,
+		SinceProgress: 5 * time.Hour,
+	}
+
+	tests := []struct {
+		name  string
+		when  config.WitnessRuleCondition
+		facts AgentFacts
+		want  bool
+	}{
+		{"idle over threshold", config.WitnessRuleCondition{IdleFor: "20m"}, polecat, true},
+		{"idle under threshold", config.WitnessRuleCondition{IdleFor: "30m"}, polecat, false},
+		{"idle needs running session", config.WitnessRuleCondition{IdleFor: "20m"}, AgentFacts{Role: "polecat", Idle: time.Hour}, false},
+		{"state matches", config.WitnessRuleCondition{AgentState: []string{"stuck", "working"}}, polecat, true},
+		{"state differs", config.WitnessRuleCondition{AgentState: []string{"stuck"}}, polecat, false},
+		{"nudge failures reached", config.WitnessRuleCondition{NudgeFailures: 2}, polecat, true},
+		{"nudge failures not reached", config.WitnessRuleCondition{NudgeFailures: 3}, polecat, false},
+		{"all labels present", config.WitnessRuleCondition{Labels: []string{"p0", "backend"}}, polecat, true},
+		{"label missing", config.WitnessRuleCondition{Labels: []string{"p0", "frontend"}}, polecat, false},
+		{"all conditions", config.WitnessRuleCondition{IdleFor: "20m", Labels: []string{"p0"}, NudgeFailures: 1}, polecat, true},
+		{"one condition fails", config.WitnessRuleCondition{IdleFor: "20m", Labels: []string{"p1"}}, polecat, false},
+		{"no progress over threshold", config.WitnessRuleCondition{NoProgressFor: "4h"}, polecat, true},
+		{"no progress under threshold", config.WitnessRuleCondition{NoProgressFor: "6h"}, polecat, false},
+		{"no progress needs hooked work", config.WitnessRuleCondition{NoProgressFor: "4h"}, AgentFacts{Role: "polecat", SinceProgress: 5 * time.Hour}, false},
+		{"default role excludes crew", config.WitnessRuleCondition{IdleFor: "20m"}, AgentFacts{Role: "crew", Running: true, Idle: time.Hour}, false},
+		{"crew role", config.WitnessRuleCondition{Roles: []string{"crew"}, IdleFor: "20m"}, AgentFacts{Role: "crew", Running: true, Idle: time.Hour}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := config.WitnessRule{Name: "r", When: tt.when, Do: config.WitnessActionMailMayor}
+			reason, got := MatchRule(rule, tt.facts)
+			if got != tt.want {
+				t.Fatalf("MatchRule() = %v (%q), want %v", got, reason, tt.want)
+			}
+			if got && reason == "" {
+				t.Error("MatchRule() matched with empty reason")
+			}
+		})
+	}
+}
+
+func TestEvaluateRules_NoSettings(t *testing.T) {
+	townRoot := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(townRoot, "gastown", "polecats", "nux"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	result, err := EvaluateRules(DefaultBdCli(), townRoot, "gastown", true)
+	if err != nil {
+		t.Fatalf("EvaluateRules: %v", err)
+	}
+	if result.Checked != 0 || len(result.Fired) != 0 {
+		t.Errorf("result = %+v, want nothing checked without rules", result)
+	}
+}
+
+func TestEvaluateRules_DryRun(t *testing.T) {
+	townRoot := t.TempDir()
+	rigPath := filepath.Join(townRoot, "gastown")
+	if err := os.MkdirAll(filepath.Join(rigPath, "polecats", "nux"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	settings := &config.RigSettings{
+		Type:    "rig-settings",
+		Version: config.CurrentRigSettingsVersion,
+		Witness: &config.WitnessSettings{Rules: []config.WitnessRule{
+			{Name: "stuck", When: config.WitnessRuleCondition{AgentState: []string{"stuck"}}, Do: config.WitnessActionRestart},
+		}},
+	}
+	if err := config.SaveRigSettings(config.RigSettingsPath(rigPath), settings); err != nil {
+		t.Fatal(err)
+	}
+
+	bd := &BdCli{
+		Exec: func(workDir string, args ...string) (string, error) {
+			return `[{"agent_state":"stuck","hook_bead":"gt-abc"}]`, nil
+		},
+		Run: func(workDir string, args ...string) error {
+			t.Errorf("dry run ran bd %v", args)
+			return nil
+		},
+	}
+	result, err := EvaluateRules(bd, townRoot, "gastown", true)
+	if err != nil {
+		t.Fatalf("EvaluateRules: %v", err)
+	}
+	if result.Checked != 1 || len(result.Fired) != 1 {
+		t.Fatalf("result = %+v, want 1 agent checked and 1 match", result)
+	}
+	f := result.Fired[0]
+	if f.Agent.Name != "nux" || f.Skipped != "dry run" || f.Error != nil {
+		t.Errorf("firing = %+v, want dry-run match on nux", f)
+	}
+}
+
+func TestEvaluateRules_NoProgress(t *testing.T) {
+	townRoot := t.TempDir()
+	rigPath := filepath.Join(townRoot, "gastown")
+	for _, name := range []string{"nux", "toast"} {
+		if err := os.MkdirAll(filepath.Join(rigPath, "polecats", name), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// toast has a worktree with a fresh commit; nux has none.
+	worktree := filepath.Join(rigPath, "polecats", "toast", "gastown")
+	if err := os.MkdirAll(worktree, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"-c", "user.email=t@example.com", "-c", "user.name=t", "commit", "-q", "--allow-empty", "-m", "wip"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = worktree
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+
+	settings := &config.RigSettings{
+		Type:    "rig-settings",
+		Version: config.CurrentRigSettingsVersion,
+		Witness: &config.WitnessSettings{Rules: []config.WitnessRule{
+			{Name: "stale-hook", When: config.WitnessRuleCondition{NoProgressFor: "4h"}, Do: config.WitnessActionDetach},
+		}},
+	}
+	if err := config.SaveRigSettings(config.RigSettingsPath(rigPath), settings); err != nil {
+		t.Fatal(err)
+	}
+
+	stale := time.Now().Add(-5 * time.Hour).UTC().Format(time.RFC3339)
+	bd := &BdCli{
+		Exec: func(workDir string, args ...string) (string, error) {
+			return `[{"agent_state":"working","hook_bead":"gt-abc","updated_at":"` + stale + `"}]`, nil
+		},
+		Run: func(workDir string, args ...string) error {
+			t.Errorf("dry run ran bd %v", args)
+			return nil
+		},
+	}
+	result, err := EvaluateRules(bd, townRoot, "gastown", true)
+	if err != nil {
+		t.Fatalf("EvaluateRules: %v", err)
+	}
+	if result.Checked != 2 || len(result.Fired) != 1 {
+		t.Fatalf("result = %+v, want 2 agents checked and 1 match", result)
+	}
+	f := result.Fired[0]
+	if f.Agent.Name != "nux" || f.Agent.SinceProgress < 4*time.Hour || f.Skipped != "dry run" {
+		t.Errorf("firing = %+v, want dry-run match on nux only", f)
+	}
+}
+
+func TestRuleCooldowns(t *testing.T) {
+	townRoot := t.TempDir()
+	now := time.Now().Truncate(time.Second)
+	rules := []config.WitnessRule{
+		{Name: "idle", Cooldown: "1h"},
+		{Name: "weekly", Cooldown: "168h"},
+		{Name: "old", Cooldown: "1h"},
+	}
+	// Another rig's witness recorded a firing first; it must survive.
+	other := map[string]time.Time{ruleCooldownKey("beads", "idle", "bd-max"): now.Add(-48 * time.Hour)}
+	if err := saveRuleCooldowns(townRoot, "beads", []config.WitnessRule{{Name: "idle", Cooldown: "72h"}}, other); err != nil {
+		t.Fatalf("saveRuleCooldowns: %v", err)
+	}
+
+	fired := map[string]time.Time{
+		ruleCooldownKey("gastown", "idle", "gt-nux"):    now,
+		ruleCooldownKey("gastown", "weekly", "gt-nux"):  now.Add(-48 * time.Hour),
+		ruleCooldownKey("gastown", "old", "gt-nux"):     now.Add(-2 * time.Hour),
+		ruleCooldownKey("gastown", "removed", "gt-nux"): now,
+	}
+	if err := saveRuleCooldowns(townRoot, "gastown", rules, fired); err != nil {
+		t.Fatalf("saveRuleCooldowns: %v", err)
+	}
+	got := loadRuleCooldowns(townRoot)
+	if !got[ruleCooldownKey("gastown", "idle", "gt-nux")].Equal(now) {
+		t.Errorf("cooldown = %v, want %v", got[ruleCooldownKey("gastown", "idle", "gt-nux")], now)
+	}
+	if _, ok := got[ruleCooldownKey("gastown", "weekly", "gt-nux")]; !ok {
+		t.Error("cooldown longer than a day was pruned")
+	}
+	if _, ok := got[ruleCooldownKey("gastown", "old", "gt-nux")]; ok {
+		t.Error("expired cooldown was not pruned")
+	}
+	if _, ok := got[ruleCooldownKey("gastown", "removed", "gt-nux")]; ok {
+		t.Error("cooldown of a removed rule was not pruned")
+	}
+	if _, ok := got[ruleCooldownKey("beads", "idle", "bd-max")]; !ok {
+		t.Error("another rig's cooldown was lost")
+	}
+}
@@ -2,17 +2,18 @@
 package workspace
 
 import (
-	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/errkind"
 )
 
 // ErrNotFound indicates no workspace was found.
-var ErrNotFound = errors.New("not in a Gas Town workspace")
+var ErrNotFound = errkind.New(errkind.Config, "not in a Gas Town workspace",
+	"cd into a town, pass --town, or create one with 'gt install'")
 
 // Markers used to detect a Gas Town workspace.
 const (
@@ -0,0 +1,122 @@
+package workspace
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/state"
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// TownRegistry is the per-user list of known towns, stored in
+// ~/.config/gastown/towns.json. It lets commands target a town by name
+// instead of depending on the current directory.
+type TownRegistry struct {
+	// Towns maps a short town name to its root directory, or for a peer town
+	// on another machine, to the base URL of its `gt dashboard`.
+	Towns map[string]string `json:"towns"`
+	// Current is the town used when the current directory isn't inside one.
+	Current string `json:"current,omitempty"`
+}
+
+// TownRegistryPath returns the path to the user's town registry.
+func TownRegistryPath() string {
+	return filepath.Join(state.ConfigDir(), "towns.json")
+}
+
+// LoadTownRegistry reads the user's town registry. A missing file yields an
+// empty registry.
+func LoadTownRegistry() (*TownRegistry, error) {
+	r := &TownRegistry{Towns: make(map[string]string)}
+	data, err := os.ReadFile(TownRegistryPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return r, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(data, r); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", TownRegistryPath(), err)
+	}
+	if r.Towns == nil {
+		r.Towns = make(map[string]string)
+	}
+	return r, nil
+}
+
+// SaveTownRegistry writes the user's town registry atomically.
+func SaveTownRegistry(r *TownRegistry) error {
+	return util.EnsureDirAndWriteJSONWithPerm(TownRegistryPath(), r, 0600)
+}
+
+// Names returns the registered town names, sorted.
+func (r *TownRegistry) Names() []string {
+	names := make([]string, 0, len(r.Towns))
+	for name := range r.Towns {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NameFor returns the registered name for a town root, or "" if unregistered.
+func (r *TownRegistry) NameFor(townRoot string) string {
+	for name, root := range r.Towns {
+		if filepath.Clean(root) == filepath.Clean(townRoot) {
+			return name
+		}
+	}
+	return ""
+}
+
+// IsPeerTown reports whether a registry location is a peer town's dashboard
+// URL rather than a local directory.
+func IsPeerTown(location string) bool {
+	return strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://")
+}
+
+// Resolve turns a town reference into a local town root. The reference is either a
+// registered name or a path to (or inside) a town.
+func (r *TownRegistry) Resolve(ref string) (string, error) {
+	if root, ok := r.Towns[ref]; ok {
+		if IsPeerTown(root) {
+			return "", fmt.Errorf("town %q is a peer town at %s, not a local directory", ref, root)
+		}
+		if _, err := os.Stat(filepath.Join(root, PrimaryMarker)); err != nil {
+			return "", fmt.Errorf("town %q at %s is missing %s", ref, root, PrimaryMarker)
+		}
+		return root, nil
+	}
+	if strings.ContainsRune(ref, os.PathSeparator) || ref == "." || ref == ".." {
+		root, err := FindOrError(ref)
+		if err != nil {
+			return "", fmt.Errorf("town %q: %w", ref, err)
+		}
+		return root, nil
+	}
+	if len(r.Towns) == 0 {
+		return "", fmt.Errorf("unknown town %q (no towns registered; use 'gt town add')", ref)
+	}
+	return "", fmt.Errorf("unknown town %q (known: %s)", ref, strings.Join(r.Names(), ", "))
+}
+
+// Register records townRoot under name and makes it current if no town is.
+// A root that is already registered keeps its existing name, which is
+// returned; a name already taken by a different root is an error.
+func (r *TownRegistry) Register(name, townRoot string) (string, error) {
+	if existing := r.NameFor(townRoot); existing != "" {
+		return existing, nil
+	}
+	if root, ok := r.Towns[name]; ok {
+		return "", fmt.Errorf("town name %q is already registered at %s", name, root)
+	}
+	r.Towns[name] = townRoot
+	if r.Current == "" {
+		r.Current = name
+	}
+	return name, nil
+}
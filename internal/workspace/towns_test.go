@@ -0,0 +1,100 @@
+package workspace
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func makeTestTown(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "mayor"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, PrimaryMarker), []byte(`{"name":"test"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return root
+}
+
+func TestTownRegistryRoundTrip(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	reg, err := LoadTownRegistry()
+	if err != nil {
+		t.Fatalf("LoadTownRegistry on missing file: %v", err)
+	}
+	if len(reg.Towns) != 0 {
+		t.Fatalf("expected empty registry, got %v", reg.Towns)
+	}
+
+	work := makeTestTown(t)
+	reg.Towns["work"] = work
+	reg.Current = "work"
+	if err := SaveTownRegistry(reg); err != nil {
+		t.Fatalf("SaveTownRegistry: %v", err)
+	}
+
+	loaded, err := LoadTownRegistry()
+	if err != nil {
+		t.Fatalf("LoadTownRegistry: %v", err)
+	}
+	if loaded.Current != "work" || loaded.Towns["work"] != work {
+		t.Errorf("loaded registry = %+v", loaded)
+	}
+	if got := loaded.NameFor(work + "/"); got != "work" {
+		t.Errorf("NameFor() = %q, want work", got)
+	}
+}
+
+func TestTownRegistryResolve(t *testing.T) {
+	work := makeTestTown(t)
+	reg := &TownRegistry{Towns: map[string]string{"work": work, "gone": filepath.Join(t.TempDir(), "nope")}}
+
+	if got, err := reg.Resolve("work"); err != nil || got != work {
+		t.Errorf("Resolve(work) = %q, %v", got, err)
+	}
+
+	sub := filepath.Join(work, "gastown", "crew")
+	if err := os.MkdirAll(sub, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := reg.Resolve(sub); err != nil || got != work {
+		t.Errorf("Resolve(path inside town) = %q, %v; want %q", got, err, work)
+	}
+
+	if _, err := reg.Resolve("gone"); err == nil {
+		t.Error("Resolve(gone) should fail when the town directory is missing")
+	}
+	reg.Towns["peer"] = "http://buildbox:8080"
+	if _, err := reg.Resolve("peer"); err == nil || !strings.Contains(err.Error(), "peer town") {
+		t.Errorf("Resolve(peer) error = %v, want peer town error", err)
+	}
+	_, err := reg.Resolve("unknown")
+	if err == nil || !strings.Contains(err.Error(), "known: gone, peer, work") {
+		t.Errorf("Resolve(unknown) error = %v, want list of known towns", err)
+	}
+}
+
+func TestTownRegistryRegister(t *testing.T) {
+	work, home := makeTestTown(t), makeTestTown(t)
+	reg := &TownRegistry{Towns: map[string]string{}}
+
+	if name, err := reg.Register("work", work); err != nil || name != "work" {
+		t.Fatalf("Register(work) = %q, %v", name, err)
+	}
+	if reg.Current != "work" {
+		t.Errorf("first registered town should become current, got %q", reg.Current)
+	}
+	if name, err := reg.Register("other", work+"/"); err != nil || name != "work" {
+		t.Errorf("re-registering a known root = %q, %v; want existing name", name, err)
+	}
+	if _, err := reg.Register("work", home); err == nil {
+		t.Error("Register should refuse a name taken by another root")
+	}
+	if _, err := reg.Register("home", home); err != nil || reg.Current != "work" {
+		t.Errorf("Register(home) err=%v current=%q; current should not change", err, reg.Current)
+	}
+}